@@ -18,14 +18,22 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/event"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	iampolicy "github.com/minio/pkg/iam/policy"
 )
@@ -45,7 +53,7 @@ func (a adminAPIHandlers) PutBucketQuotaConfigHandler(w http.ResponseWriter, r *
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketQuotaAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.SetBucketQuotaAdminAction)
 	if objectAPI == nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
 		return
@@ -54,6 +62,10 @@ func (a adminAPIHandlers) PutBucketQuotaConfigHandler(w http.ResponseWriter, r *
 	vars := mux.Vars(r)
 	bucket := pathClean(vars["bucket"])
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.SetBucketQuotaAdminAction, bucketAdminScope(bucket)) {
+		return
+	}
+
 	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
 		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
 		return
@@ -85,7 +97,7 @@ func (a adminAPIHandlers) GetBucketQuotaConfigHandler(w http.ResponseWriter, r *
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketQuotaAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.GetBucketQuotaAdminAction)
 	if objectAPI == nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
 		return
@@ -94,6 +106,10 @@ func (a adminAPIHandlers) GetBucketQuotaConfigHandler(w http.ResponseWriter, r *
 	vars := mux.Vars(r)
 	bucket := pathClean(vars["bucket"])
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.GetBucketQuotaAdminAction, bucketAdminScope(bucket)) {
+		return
+	}
+
 	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
 		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
 		return
@@ -130,11 +146,14 @@ func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.
 	}
 
 	// Get current object layer instance.
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
 	if objectAPI == nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
 		return
 	}
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.SetBucketTargetAction, bucketAdminScope(bucket)) {
+		return
+	}
 
 	// Check if bucket exists.
 	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
@@ -258,11 +277,14 @@ func (a adminAPIHandlers) ListRemoteTargetsHandler(w http.ResponseWriter, r *htt
 		return
 	}
 	// Get current object layer instance.
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
 	if objectAPI == nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
 		return
 	}
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.GetBucketTargetAction, bucketAdminScope(bucket)) {
+		return
+	}
 	if bucket != "" {
 		// Check if bucket exists.
 		if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
@@ -275,6 +297,16 @@ func (a adminAPIHandlers) ListRemoteTargetsHandler(w http.ResponseWriter, r *htt
 		}
 	}
 	targets := globalBucketTargetSys.ListTargets(ctx, bucket, arnType)
+	if bucket == "" {
+		scopeFilter := newAdminScopeFilter(r, cred, iampolicy.GetBucketTargetAction)
+		scopedTargets := targets[:0]
+		for _, target := range targets {
+			if scopeFilter.allowed(bucketAdminScope(target.SourceBucket)) {
+				scopedTargets = append(scopedTargets, target)
+			}
+		}
+		targets = scopedTargets
+	}
 	data, err := json.Marshal(targets)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -298,11 +330,14 @@ func (a adminAPIHandlers) RemoveRemoteTargetHandler(w http.ResponseWriter, r *ht
 		return
 	}
 	// Get current object layer instance.
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
 	if objectAPI == nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
 		return
 	}
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.SetBucketTargetAction, bucketAdminScope(bucket)) {
+		return
+	}
 
 	// Check if bucket exists.
 	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
@@ -328,7 +363,1187 @@ func (a adminAPIHandlers) RemoveRemoteTargetHandler(w http.ResponseWriter, r *ht
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if err = removeRemoteTargetSTS(bucket, arn); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessNoContent(w)
+}
+
+// SetReplicationMeshHandler - PUT /minio/admin/v3/set-replication-mesh?bucket=
+//
+// Replicates the bucket in active-active mode to all peers in the
+// encrypted JSON body, creating remote targets and replication rules
+// on this site as needed. The peers are not configured, the API must
+// be called on every site of the mesh with the other sites as peers.
+func (a adminAPIHandlers) SetReplicationMeshHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetReplicationMesh")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if !globalBucketVersioningSys.Enabled(bucket) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrReplicationNeedsVersioningError), r.URL)
+		return
+	}
+
+	reqBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+	var peers []replicationPeer
+	if err = json.Unmarshal(reqBytes, &peers); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if err = configureReplicationMesh(ctx, bucket, peers); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 
 	// Write success response.
 	writeSuccessNoContent(w)
 }
+
+// ReplicationConvergenceHandler - GET /minio/admin/v3/replication-convergence?bucket=&prefix=&max-versions=
+//
+// Checks whether all versions created locally under prefix have been
+// replicated to every target of the bucket.
+func (a adminAPIHandlers) ReplicationConvergenceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationConvergence")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	prefix := r.Form.Get("prefix")
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	maxVersions := int64(100000)
+	if v := r.Form.Get("max-versions"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxVersions = n
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	rc, err := checkReplicationConvergence(ctx, objectAPI, bucket, prefix, maxVersions)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(rc)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationOldestUnreplicatedHandler - GET /minio/admin/v3/replication-oldest-unreplicated?bucket=&arn=&prefix=&max-versions=
+//
+// Returns the oldest version pending or failed replication for each
+// replication target of the bucket, meant for replication SLO alerting.
+func (a adminAPIHandlers) ReplicationOldestUnreplicatedHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationOldestUnreplicated")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	prefix := r.Form.Get("prefix")
+	arn := r.Form.Get("arn")
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	maxVersions := int64(100000)
+	if v := r.Form.Get("max-versions"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxVersions = n
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	st, err := oldestUnreplicatedVersions(ctx, objectAPI, bucket, prefix, arn, maxVersions)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationEstimateHandler - POST /minio/admin/v3/replication-estimate?bucket=&prefix=&max-versions=
+//
+// Reports the versions and bytes that the replication configuration in
+// the request body would need to replicate, and an ETA under the
+// current bandwidth settings, without queueing any work. Without a
+// request body the current replication configuration of the bucket is
+// used.
+func (a adminAPIHandlers) ReplicationEstimateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationEstimate")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	prefix := r.Form.Get("prefix")
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	maxVersions := int64(100000)
+	if v := r.Form.Get("max-versions"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxVersions = n
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var cfg *replication.Config
+	var err error
+	if r.ContentLength > 0 {
+		if cfg, err = replication.ParseConfig(io.LimitReader(r.Body, r.ContentLength)); err != nil {
+			apiErr := errorCodes.ToAPIErr(ErrMalformedXML)
+			apiErr.Description = err.Error()
+			writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+			return
+		}
+		sameTarget, apiErr := validateReplicationDestination(ctx, bucket, cfg)
+		if apiErr != noError {
+			writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+			return
+		}
+		if err = cfg.Validate(bucket, sameTarget); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	} else if cfg, err = getReplicationConfig(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	est, err := estimateReplication(ctx, objectAPI, bucket, prefix, cfg, maxVersions)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(est)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// LifecycleSimulateHandler - POST /minio/admin/v3/ilm-simulate?bucket=&prefix=&days=&max-versions=&max-objects=
+//
+// Reports the object versions that the lifecycle configuration in the
+// request body would expire or transition within the next days, with
+// counts and sizes, without modifying anything. Without a request body
+// the current lifecycle configuration of the bucket is used.
+func (a adminAPIHandlers) LifecycleSimulateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "LifecycleSimulate")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objectAPI == nil {
+		return
+	}
+
+	opts := lifecycleSimulationOpts{
+		prefix:      r.Form.Get("prefix"),
+		maxVersions: 100000,
+		maxObjects:  1000,
+	}
+	if v := r.Form.Get("max-versions"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		opts.maxVersions = n
+	}
+	for name, value := range map[string]*int{"days": &opts.days, "max-objects": &opts.maxObjects} {
+		if v := r.Form.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+				return
+			}
+			*value = n
+		}
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var lc *lifecycle.Lifecycle
+	var err error
+	if r.ContentLength > 0 {
+		if lc, err = lifecycle.ParseLifecycleConfig(io.LimitReader(r.Body, r.ContentLength)); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		if err = lc.Validate(); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		if err = validateTransitionTier(lc); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	} else if lc, err = globalLifecycleSys.Get(bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	sim, err := simulateLifecycle(ctx, objectAPI, bucket, lc, opts)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(sim)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ClientSideEncryptionReportHandler - GET /minio/admin/v3/cse-report?bucket=&prefix=&max-versions=&max-objects=
+//
+// Reports the object versions of the bucket that were encrypted by
+// an S3 encryption client, with counts and sizes by envelope version.
+func (a adminAPIHandlers) ClientSideEncryptionReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ClientSideEncryptionReport")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	opts := cseReportOpts{
+		prefix:      r.Form.Get("prefix"),
+		maxVersions: 100000,
+		maxObjects:  1000,
+	}
+	if v := r.Form.Get("max-versions"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		opts.maxVersions = n
+	}
+	if v := r.Form.Get("max-objects"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		opts.maxObjects = n
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	report, err := reportClientSideEncryption(ctx, objectAPI, bucket, opts)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationResyncStatusHandler - GET /minio/admin/v3/replication-resync-status?bucket=&arn=
+//
+// Returns the progress of the replication resyncs of the bucket.
+func (a adminAPIHandlers) ReplicationResyncStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationResyncStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	arn := r.Form.Get("arn")
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	statuses, err := globalReplicationResyncer.Status(ctx, objectAPI, bucket, arn)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// SetReplicationBandwidthScheduleHandler - PUT /minio/admin/v3/replication-bandwidth-schedule?bucket=&arn=
+//
+// Sets the bandwidth limits by time window for a replication target of
+// the bucket. A schedule without windows removes it.
+func (a adminAPIHandlers) SetReplicationBandwidthScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetReplicationBandwidthSchedule")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	arn := vars["arn"]
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var schedule bandwidth.Schedule
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&schedule); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if err := setBandwidthSchedule(ctx, bucket, arn, schedule); err != nil {
+		switch err.(type) {
+		case BucketRemoteTargetNotFound:
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		default:
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrReplicationBandwidthLimitError, err), r.URL)
+		}
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// GetReplicationBandwidthScheduleHandler - GET /minio/admin/v3/replication-bandwidth-schedule?bucket=
+//
+// Returns the bandwidth schedules of the replication targets of the
+// bucket.
+func (a adminAPIHandlers) GetReplicationBandwidthScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetReplicationBandwidthSchedule")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, err := globalBucketMetadataSys.GetBandwidthScheduleConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// SetReplicationTargetSTSHandler - PUT/DELETE /minio/admin/v3/replication-target-sts?bucket=&arn=
+//
+// Sets the STS settings used by a replication target of the bucket to
+// obtain temporary credentials, or removes them on DELETE so that the
+// static keys of the target are used again.
+func (a adminAPIHandlers) SetReplicationTargetSTSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetReplicationTargetSTS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	arn := vars["arn"]
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var sts *remoteTargetSTS
+	if r.Method != http.MethodDelete {
+		sts = &remoteTargetSTS{}
+		if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(sts); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+			return
+		}
+	}
+
+	if err := setRemoteTargetSTS(ctx, bucket, arn, sts); err != nil {
+		switch err.(type) {
+		case BucketRemoteTargetNotFound:
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		default:
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrReplicationRemoteSTSConfigError, err), r.URL)
+		}
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// GetReplicationTargetSTSHandler - GET /minio/admin/v3/replication-target-sts?bucket=
+//
+// Returns the STS settings of the replication targets of the bucket.
+func (a adminAPIHandlers) GetReplicationTargetSTSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetReplicationTargetSTS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, err := globalBucketMetadataSys.GetBucketTargetsSTSConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+func replicationDLQFilterFromRequest(r *http.Request) replicationDLQFilter {
+	return replicationDLQFilter{
+		ID:     r.Form.Get("id"),
+		ARN:    r.Form.Get("arn"),
+		Prefix: r.Form.Get("prefix"),
+	}
+}
+
+// ListReplicationDLQHandler - GET /minio/admin/v3/replication-dlq?bucket=&arn=&prefix=&id=&max-entries=
+//
+// Lists replication operations of the bucket that kept failing after
+// being retried.
+func (a adminAPIHandlers) ListReplicationDLQHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListReplicationDLQ")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	maxEntries := 1000
+	if v := r.Form.Get("max-entries"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxEntries = n
+	}
+
+	entries, err := listReplicationDLQ(ctx, objectAPI, bucket, replicationDLQFilterFromRequest(r), maxEntries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplicationConflictsHandler - GET /minio/admin/v3/replication-conflicts?bucket=&prefix=&max-entries=
+//
+// Lists conflicting active-active writes detected on the bucket along
+// with how they were resolved.
+func (a adminAPIHandlers) ReplicationConflictsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationConflicts")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	maxEntries := 1000
+	if v := r.Form.Get("max-entries"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxEntries = n
+	}
+
+	conflicts, err := listReplicationConflicts(ctx, objectAPI, bucket, r.Form.Get("prefix"), maxEntries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(conflicts)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// RetryReplicationDLQHandler - POST /minio/admin/v3/replication-dlq/retry?bucket=&arn=&prefix=&id=
+//
+// Re-queues the matching dead-lettered replication operations. Entries
+// are removed once the operation completes.
+func (a adminAPIHandlers) RetryReplicationDLQHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RetryReplicationDLQ")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	n, err := retryReplicationDLQ(ctx, objectAPI, bucket, replicationDLQFilterFromRequest(r))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(map[string]int{"retried": n})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// PurgeReplicationDLQHandler - DELETE /minio/admin/v3/replication-dlq?bucket=&arn=&prefix=&id=
+//
+// Removes the matching dead-lettered replication operations without
+// retrying them.
+func (a adminAPIHandlers) PurgeReplicationDLQHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PurgeReplicationDLQ")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketTargetAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	n, err := purgeReplicationDLQ(ctx, objectAPI, bucket, replicationDLQFilterFromRequest(r))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(map[string]int{"purged": n})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReplayEventsHandler - POST /minio/admin/v3/replay-events?bucket=&arn=&prefix=&start=&end=
+//
+// Replays the journaled events of the bucket, optionally restricted to
+// an object prefix and a time range in RFC 3339 format, to the
+// notification target. Every node replays the events it journaled.
+func (a adminAPIHandlers) ReplayEventsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplayEvents")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if globalEventJournal == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrNotImplemented, errEventJournalDisabled), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	arn, filter, err := eventReplayFromForm(r.Form.Get("arn"), bucket, r.Form.Get("prefix"), r.Form.Get("start"), r.Form.Get("end"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	local := eventReplayResult{Node: globalLocalNodeName}
+	local.Replayed, err = replayJournaledEvents(ctx, arn, filter)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	results := []eventReplayResult{local}
+	for _, result := range globalNotificationSys.ReplayEvents(ctx, arn, filter) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// EventTargetStatsHandler - GET /minio/admin/v3/event-target-stats
+//
+// Lists the notification targets of every node with their delivery
+// statistics and the time of their oldest undelivered event.
+func (a adminAPIHandlers) EventTargetStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "EventTargetStats")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	results := []eventTargetStatsResult{{
+		Node:    globalLocalNodeName,
+		Targets: globalNotificationSys.targetList.Stats(globalSite.Region),
+	}}
+	for _, result := range globalNotificationSys.GetEventTargetStats(ctx) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// TestEventTargetsHandler - POST /minio/admin/v3/event-target-test?arn=&bucket=
+//
+// Delivers a synthetic s3:TestEvent through the notification target, or
+// through all targets if arn is not set, on every node and reports per
+// target whether it accepted the event, the latency and the error. If
+// bucket is set, only the targets of its notification configuration
+// are tested.
+func (a adminAPIHandlers) TestEventTargetsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TestEventTargets")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.Form.Get("bucket")
+	if bucket != "" {
+		// Check if bucket exists.
+		if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
+	targetID, err := eventDeadLetterTargetFromForm(r.Form.Get("arn"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	local := eventTargetTestResult{Node: globalLocalNodeName}
+	local.Targets, err = globalNotificationSys.testEventTargets(targetID, bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	results := []eventTargetTestResult{local}
+	for _, result := range globalNotificationSys.TestEventTargets(ctx, r.Form.Get("arn"), bucket) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// eventDeadLettersReq - validates the request to the event dead-letter
+// store and returns the target selected by the optional arn parameter.
+func eventDeadLettersReq(ctx context.Context, w http.ResponseWriter, r *http.Request) (targetID event.TargetID, ok bool) {
+	// Get current object layer instance.
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return targetID, false
+	}
+
+	if globalEventDeadLetters == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrNotImplemented, errEventDeadLettersDisabled), r.URL)
+		return targetID, false
+	}
+
+	targetID, err := eventDeadLetterTargetFromForm(r.Form.Get("arn"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return targetID, false
+	}
+	return targetID, true
+}
+
+// ListEventDeadLettersHandler - GET /minio/admin/v3/event-deadletters?arn=&max-entries=
+//
+// Lists events which could not be delivered to the notification target,
+// or to any target if arn is not set, per node.
+func (a adminAPIHandlers) ListEventDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListEventDeadLetters")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	targetID, ok := eventDeadLettersReq(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	maxEntries := 1000
+	if v := r.Form.Get("max-entries"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxEntries = n
+	}
+
+	local := eventDeadLetterResult{Node: globalLocalNodeName}
+	var err error
+	local.DeadLetters, err = globalEventDeadLetters.List(targetID, maxEntries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	results := []eventDeadLetterResult{local}
+	for _, result := range globalNotificationSys.ListEventDeadLetters(ctx, r.Form.Get("arn"), maxEntries) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ExportEventDeadLettersHandler - GET /minio/admin/v3/event-deadletters/export?arn=
+//
+// Streams all events which could not be delivered to the notification
+// target, or to any target if arn is not set, as JSON lines.
+func (a adminAPIHandlers) ExportEventDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportEventDeadLetters")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	targetID, ok := eventDeadLettersReq(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	local, err := globalEventDeadLetters.List(targetID, 0)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentType, "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for _, dl := range local {
+		if err = enc.Encode(dl); err != nil {
+			return
+		}
+	}
+	for _, result := range globalNotificationSys.ListEventDeadLetters(ctx, r.Form.Get("arn"), 0) {
+		if result.Error != "" {
+			logger.LogIf(ctx, fmt.Errorf("unable to export event dead letters of %s: %s", result.Node, result.Error))
+			continue
+		}
+		for _, dl := range result.DeadLetters {
+			if err = enc.Encode(dl); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// RedriveEventDeadLettersHandler - POST /minio/admin/v3/event-deadletters/redrive?arn=
+//
+// Sends the events which could not be delivered to their notification
+// targets again. Events are removed once the target accepted them.
+func (a adminAPIHandlers) RedriveEventDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RedriveEventDeadLetters")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	targetID, ok := eventDeadLettersReq(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	local := eventDeadLetterResult{Node: globalLocalNodeName}
+	var err error
+	local.Redriven, err = globalEventDeadLetters.Redrive(ctx, targetID)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	results := []eventDeadLetterResult{local}
+	for _, result := range globalNotificationSys.RedriveEventDeadLetters(ctx, r.Form.Get("arn")) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// PurgeEventDeadLettersHandler - DELETE /minio/admin/v3/event-deadletters?arn=
+//
+// Removes the events which could not be delivered without sending them.
+func (a adminAPIHandlers) PurgeEventDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PurgeEventDeadLetters")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	targetID, ok := eventDeadLettersReq(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	local := eventDeadLetterResult{Node: globalLocalNodeName}
+	var err error
+	local.Purged, err = globalEventDeadLetters.Purge(targetID)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	results := []eventDeadLetterResult{local}
+	for _, result := range globalNotificationSys.PurgeEventDeadLetters(ctx, r.Form.Get("arn")) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
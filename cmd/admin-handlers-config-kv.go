@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -40,6 +41,7 @@ import (
 )
 
 // DelConfigKVHandler - DELETE /minio/admin/v3/del-config-kv
+// With dryRun=true the change is validated and reported, but not applied.
 func (a adminAPIHandlers) DelConfigKVHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "DeleteConfigKV")
 
@@ -69,18 +71,35 @@ func (a adminAPIHandlers) DelConfigKVHandler(w http.ResponseWriter, r *http.Requ
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	before := cfg.Clone()
 
 	if err = cfg.DelFrom(bytes.NewReader(kvBytes)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
+	if r.Form.Get("dryRun") == "true" {
+		if err = validateConfig(cfg); err != nil {
+			writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), err.Error(), r.URL)
+			return
+		}
+		writeConfigDryRun(ctx, w, r, before, cfg, true)
+		return
+	}
+
 	if err = saveServerConfig(ctx, objectAPI, cfg); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
-	dynamic := config.SubSystemsDynamic.Contains(string(kvBytes))
+	// Write the deleted KV settings to history, the change is
+	// already saved so that failing to record it is only logged.
+	info := newConfigHistoryInfo(cred, "DeleteConfigKV", before, cfg)
+	info.Delete = true
+	logger.LogIf(ctx, saveServerConfigHistory(ctx, objectAPI, kvBytes, info))
+
+	subSys := strings.SplitN(strings.TrimSpace(string(kvBytes)), config.SubSystemSeparator, 2)[0]
+	dynamic := config.SubSystemsDynamic.Contains(subSys)
 	if dynamic {
 		applyDynamic(ctx, objectAPI, cfg, r, w)
 	}
@@ -98,6 +117,7 @@ func applyDynamic(ctx context.Context, objectAPI ObjectLayer, cfg config.Config,
 }
 
 // SetConfigKVHandler - PUT /minio/admin/v3/set-config-kv
+// With dryRun=true the change is validated and reported, but not applied.
 func (a adminAPIHandlers) SetConfigKVHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetConfigKV")
 
@@ -127,6 +147,7 @@ func (a adminAPIHandlers) SetConfigKVHandler(w http.ResponseWriter, r *http.Requ
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	before := cfg.Clone()
 
 	dynamic, err := cfg.ReadConfig(bytes.NewReader(kvBytes))
 	if err != nil {
@@ -139,6 +160,11 @@ func (a adminAPIHandlers) SetConfigKVHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if r.Form.Get("dryRun") == "true" {
+		writeConfigDryRun(ctx, w, r, before, cfg, dynamic)
+		return
+	}
+
 	// Update the actual server config on disk.
 	if err = saveServerConfig(ctx, objectAPI, cfg); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -146,7 +172,7 @@ func (a adminAPIHandlers) SetConfigKVHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Write to the config input KV to history.
-	if err = saveServerConfigHistory(ctx, objectAPI, kvBytes); err != nil {
+	if err = saveServerConfigHistory(ctx, objectAPI, kvBytes, newConfigHistoryInfo(cred, "SetConfigKV", before, cfg)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
@@ -227,7 +253,7 @@ func (a adminAPIHandlers) RestoreConfigHistoryKVHandler(w http.ResponseWriter, r
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -250,8 +276,20 @@ func (a adminAPIHandlers) RestoreConfigHistoryKVHandler(w http.ResponseWriter, r
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	before := cfg.Clone()
 
-	if _, err = cfg.ReadConfig(bytes.NewReader(kvBytes)); err != nil {
+	// Deleted KV settings are deleted again.
+	info, err := readServerConfigHistoryInfo(ctx, objectAPI, restoreID)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if info.Delete {
+		err = cfg.DelFrom(bytes.NewReader(kvBytes))
+	} else {
+		_, err = cfg.ReadConfig(bytes.NewReader(kvBytes))
+	}
+	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
@@ -266,6 +304,11 @@ func (a adminAPIHandlers) RestoreConfigHistoryKVHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// The restored entry is replaced by one recording the restore.
+	restored := newConfigHistoryInfo(cred, "RestoreConfigHistoryKV", before, cfg)
+	restored.Delete = info.Delete
+	logger.LogIf(ctx, saveServerConfigHistory(ctx, objectAPI, kvBytes, restored))
+
 	delServerConfigHistory(ctx, objectAPI, restoreID)
 }
 
@@ -337,6 +380,7 @@ func (a adminAPIHandlers) HelpConfigKVHandler(w http.ResponseWriter, r *http.Req
 }
 
 // SetConfigHandler - PUT /minio/admin/v3/config
+// With dryRun=true the change is validated and reported, but not applied.
 func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetConfig")
 
@@ -361,6 +405,12 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	before, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	cfg := newServerConfig()
 	if _, err = cfg.ReadConfig(bytes.NewReader(kvBytes)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -372,6 +422,12 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if r.Form.Get("dryRun") == "true" {
+		// An imported configuration is only applied after a restart.
+		writeConfigDryRun(ctx, w, r, before, cfg, false)
+		return
+	}
+
 	// Update the actual server config on disk.
 	if err = saveServerConfig(ctx, objectAPI, cfg); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -379,7 +435,7 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Write to the config input KV to history.
-	if err = saveServerConfigHistory(ctx, objectAPI, kvBytes); err != nil {
+	if err = saveServerConfigHistory(ctx, objectAPI, kvBytes, newConfigHistoryInfo(cred, "SetConfig", before, cfg)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
@@ -426,6 +482,8 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 				off = !xldap.Enabled(kv)
 			case config.IdentityTLSSubSys:
 				off = !globalSTSTLSConfig.Enabled
+			case config.IdentityKerberosSubSys:
+				off = !globalKerberosConfig.Enabled
 			}
 			if off {
 				s.WriteString(config.KvComment)
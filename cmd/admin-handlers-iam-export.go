@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+const (
+	// Maximum size of an encrypted IAM import request.
+	maxIAMImportSize = 100 << 20 // 100 MiB
+
+	minIAMExportPassphraseLen = 8
+)
+
+var (
+	errIAMExportInvalidPassphrase = AdminError{
+		Code:       "XMinioAdminIAMExportInvalidPassphrase",
+		Message:    "The IAM export passphrase must be at least 8 characters long",
+		StatusCode: http.StatusBadRequest,
+	}
+	errIAMExportTooLarge = AdminError{
+		Code:       "XMinioAdminIAMExportTooLarge",
+		Message:    "The IAM export request is missing a content length or is too large",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// iamExportReq is the (encrypted) body of an IAM export request.
+type iamExportReq struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// iamImportReq is the (encrypted) body of an IAM import request. The
+// bundle is encrypted with the passphrase chosen on export.
+type iamImportReq struct {
+	Passphrase string `json:"passphrase"`
+	Bundle     []byte `json:"bundle"`
+}
+
+// validateIAMExportReq authenticates IAM export and import requests.
+// Bundles contain the secret keys of all users, so only the root user
+// may export or import them.
+func validateIAMExportReq(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) (auth.Credentials, bool) {
+	objectAPI, cred := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return cred, false
+	}
+	if cred.AccessKey != globalActiveCred.AccessKey {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return cred, false
+	}
+	return cred, true
+}
+
+func decryptIAMExportReq(cred auth.Credentials, r *http.Request, maxSize int64, v interface{}) error {
+	if r.ContentLength > maxSize || r.ContentLength == -1 {
+		return errIAMExportTooLarge
+	}
+	data, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ExportIAMHandler - POST /minio/admin/v3/export-iam
+// ----------
+// Returns the full IAM state as a bundle encrypted with the passphrase
+// of the request.
+func (a adminAPIHandlers) ExportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportIAM")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	cred, ok := validateIAMExportReq(ctx, w, r, iampolicy.ListUsersAdminAction)
+	if !ok {
+		return
+	}
+
+	var req iamExportReq
+	if err := decryptIAMExportReq(cred, r, maxEConfigJSONSize, &req); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if len(req.Passphrase) < minIAMExportPassphraseLen {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errIAMExportInvalidPassphrase), r.URL)
+		return
+	}
+
+	bundle, err := globalIAMSys.ExportIAM(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	encryptedBundle, err := madmin.EncryptData(req.Passphrase, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, encryptedBundle)
+}
+
+// ImportIAMHandler - PUT /minio/admin/v3/import-iam?conflict={fail|skip|overwrite}
+// ----------
+// Imports an IAM bundle created by ExportIAMHandler. By default the
+// import fails if any entity of the bundle already exists.
+func (a adminAPIHandlers) ImportIAMHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ImportIAM")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	cred, ok := validateIAMExportReq(ctx, w, r, iampolicy.CreateUserAdminAction)
+	if !ok {
+		return
+	}
+
+	conflict := r.Form.Get("conflict")
+	if conflict == "" {
+		conflict = iamImportConflictFail
+	}
+
+	var req iamImportReq
+	if err := decryptIAMExportReq(cred, r, maxIAMImportSize, &req); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := madmin.DecryptData(req.Passphrase, bytes.NewReader(req.Bundle))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	var bundle IAMExportBundle
+	if err = json.Unmarshal(data, &bundle); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	result, err := globalIAMSys.ImportIAM(ctx, bundle, conflict)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	resp, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
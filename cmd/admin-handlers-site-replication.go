@@ -201,7 +201,7 @@ func (a adminAPIHandlers) SRPeerReplicateBucketItem(w http.ResponseWriter, r *ht
 		return
 	}
 
-	var item madmin.SRBucketMeta
+	var item srBucketMeta
 	if err := parseJSONBody(ctx, r.Body, &item, ""); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -232,6 +232,8 @@ func (a adminAPIHandlers) SRPeerReplicateBucketItem(w http.ResponseWriter, r *ht
 		err = globalSiteReplicationSys.PeerBucketObjectLockConfigHandler(ctx, item.Bucket, item.ObjectLockConfig)
 	case madmin.SRBucketMetaTypeSSEConfig:
 		err = globalSiteReplicationSys.PeerBucketSSEConfigHandler(ctx, item.Bucket, item.SSEConfig)
+	case srBucketMetaTypeLifecycle:
+		err = globalSiteReplicationSys.PeerBucketLifecycleHandler(ctx, item.Bucket, item.LifecycleConfig)
 	}
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -240,6 +242,43 @@ func (a adminAPIHandlers) SRPeerReplicateBucketItem(w http.ResponseWriter, r *ht
 	}
 }
 
+// SRPeerReplicateTier - PUT /minio/admin/v3/site-replication/peer/tier
+func (a adminAPIHandlers) SRPeerReplicateTier(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerReplicateTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objectAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		return
+	}
+
+	// The tier configuration carries the credentials of the remote
+	// tier and is encrypted with the site replicator secret key.
+	reqBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	var tier madmin.TierConfig
+	if err = json.Unmarshal(reqBytes, &tier); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if err = globalSiteReplicationSys.PeerTierChangeHandler(ctx, objectAPI, tier); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
 // SiteReplicationDisable - PUT /minio/admin/v3/site-replication/disable
 func (a adminAPIHandlers) SiteReplicationDisable(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SiteReplicationDisable")
@@ -338,6 +377,29 @@ func (a adminAPIHandlers) SiteReplicationStatus(w http.ResponseWriter, r *http.R
 	}
 }
 
+// SiteReplicationHealth - GET /minio/admin/v3/site-replication/health
+func (a adminAPIHandlers) SiteReplicationHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationHealth")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objectAPI == nil {
+		return
+	}
+
+	info, err := globalSiteReplicationSys.SiteReplicationHealth(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(info); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
 // SiteReplicationMetaInfo - GET /minio/admin/v3/site-replication/metainfo
 func (a adminAPIHandlers) SiteReplicationMetaInfo(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SiteReplicationMetaInfo")
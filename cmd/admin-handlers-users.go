@@ -40,7 +40,7 @@ func (a adminAPIHandlers) RemoveUser(w http.ResponseWriter, r *http.Request) {
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DeleteUserAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.DeleteUserAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -48,6 +48,10 @@ func (a adminAPIHandlers) RemoveUser(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	accessKey := vars["accessKey"]
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.DeleteUserAdminAction, adminScope{User: accessKey}) {
+		return
+	}
+
 	ok, _, err := globalIAMSys.IsTempUser(accessKey)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -88,6 +92,10 @@ func (a adminAPIHandlers) ListBucketUsers(w http.ResponseWriter, r *http.Request
 
 	bucket := mux.Vars(r)["bucket"]
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.ListUsersAdminAction, bucketAdminScope(bucket)) {
+		return
+	}
+
 	password := cred.SecretKey
 
 	allCredentials, err := globalIAMSys.ListBucketUsers(bucket)
@@ -96,6 +104,13 @@ func (a adminAPIHandlers) ListBucketUsers(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	scopeFilter := newAdminScopeFilter(r, cred, iampolicy.ListUsersAdminAction)
+	for user := range allCredentials {
+		if !scopeFilter.allowed(adminScope{User: user}) {
+			delete(allCredentials, user)
+		}
+	}
+
 	data, err := json.Marshal(allCredentials)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -124,12 +139,19 @@ func (a adminAPIHandlers) ListUsers(w http.ResponseWriter, r *http.Request) {
 
 	password := cred.SecretKey
 
-	allCredentials, err := globalIAMSys.ListUsers()
+	allCredentials, err := globalIAMSys.ListUsersWithRotation()
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
+	scopeFilter := newAdminScopeFilter(r, cred, iampolicy.ListUsersAdminAction)
+	for user := range allCredentials {
+		if !scopeFilter.allowed(adminScope{User: user}) {
+			delete(allCredentials, user)
+		}
+	}
+
 	data, err := json.Marshal(allCredentials)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -187,6 +209,10 @@ func (a adminAPIHandlers) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkDenyOnly && !checkAdminScope(ctx, w, r, cred, iampolicy.GetUserAdminAction, adminScope{User: name}) {
+		return
+	}
+
 	userInfo, err := globalIAMSys.GetUserInfo(ctx, name)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -208,7 +234,7 @@ func (a adminAPIHandlers) UpdateGroupMembers(w http.ResponseWriter, r *http.Requ
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AddUserToGroupAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AddUserToGroupAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -226,6 +252,15 @@ func (a adminAPIHandlers) UpdateGroupMembers(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.AddUserToGroupAdminAction, adminScope{Group: updReq.Group}) {
+		return
+	}
+	for _, member := range updReq.Members {
+		if !checkAdminScope(ctx, w, r, cred, iampolicy.AddUserToGroupAdminAction, adminScope{User: member}) {
+			return
+		}
+	}
+
 	if updReq.IsRemove {
 		err = globalIAMSys.RemoveUsersFromGroup(ctx, updReq.Group, updReq.Members)
 	} else {
@@ -247,13 +282,69 @@ func (a adminAPIHandlers) UpdateGroupMembers(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// groupSubgroupsReq - request body of UpdateGroupSubgroups.
+type groupSubgroupsReq struct {
+	Group     string   `json:"group"`
+	Subgroups []string `json:"subgroups"`
+	IsRemove  bool     `json:"isRemove"`
+}
+
+// UpdateGroupSubgroups - PUT /minio/admin/v3/update-group-subgroups
+func (a adminAPIHandlers) UpdateGroupSubgroups(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UpdateGroupSubgroups")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AddUserToGroupAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	var updReq groupSubgroupsReq
+	if err = json.Unmarshal(data, &updReq); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	// Nesting grants the policies of the parent to the subgroup
+	// members, so the scope must cover both groups.
+	for _, group := range append([]string{updReq.Group}, updReq.Subgroups...) {
+		if !checkAdminScope(ctx, w, r, cred, iampolicy.AddUserToGroupAdminAction, adminScope{Group: group}) {
+			return
+		}
+	}
+
+	if updReq.IsRemove {
+		err = globalIAMSys.RemoveSubgroups(ctx, updReq.Group, updReq.Subgroups)
+	} else {
+		err = globalIAMSys.AddSubgroups(ctx, updReq.Group, updReq.Subgroups)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// groupDesc - madmin.GroupDesc along with the group nesting.
+type groupDesc struct {
+	madmin.GroupDesc
+	Subgroups    []string `json:"subgroups,omitempty"`
+	ParentGroups []string `json:"parentGroups,omitempty"`
+}
+
 // GetGroup - /minio/admin/v3/group?group=mygroup1
 func (a adminAPIHandlers) GetGroup(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetGroup")
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetGroupAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.GetGroupAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -261,13 +352,27 @@ func (a adminAPIHandlers) GetGroup(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	group := vars["group"]
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.GetGroupAdminAction, adminScope{Group: group}) {
+		return
+	}
+
 	gdesc, err := globalIAMSys.GetGroupDescription(group)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
-	body, err := json.Marshal(gdesc)
+	subgroups, parents, err := globalIAMSys.GetGroupNesting(group)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(groupDesc{
+		GroupDesc:    gdesc,
+		Subgroups:    subgroups,
+		ParentGroups: parents,
+	})
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -282,7 +387,7 @@ func (a adminAPIHandlers) ListGroups(w http.ResponseWriter, r *http.Request) {
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListGroupsAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ListGroupsAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -293,6 +398,15 @@ func (a adminAPIHandlers) ListGroups(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	scopeFilter := newAdminScopeFilter(r, cred, iampolicy.ListGroupsAdminAction)
+	scopedGroups := groups[:0]
+	for _, group := range groups {
+		if scopeFilter.allowed(adminScope{Group: group}) {
+			scopedGroups = append(scopedGroups, group)
+		}
+	}
+	groups = scopedGroups
+
 	body, err := json.Marshal(groups)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -308,7 +422,7 @@ func (a adminAPIHandlers) SetGroupStatus(w http.ResponseWriter, r *http.Request)
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.EnableGroupAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.EnableGroupAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -317,6 +431,10 @@ func (a adminAPIHandlers) SetGroupStatus(w http.ResponseWriter, r *http.Request)
 	group := vars["group"]
 	status := vars["status"]
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.EnableGroupAdminAction, adminScope{Group: group}) {
+		return
+	}
+
 	var err error
 	switch status {
 	case statusEnabled:
@@ -338,7 +456,7 @@ func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request)
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.EnableUserAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.EnableUserAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -353,6 +471,10 @@ func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.EnableUserAdminAction, adminScope{User: accessKey}) {
+		return
+	}
+
 	if err := globalIAMSys.SetUserStatus(ctx, accessKey, madmin.AccountStatus(status)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -422,6 +544,10 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !checkDenyOnly && !checkAdminScope(ctx, w, r, cred, iampolicy.CreateUserAdminAction, adminScope{User: accessKey}) {
+		return
+	}
+
 	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
 		// More than maxConfigSize bytes were available
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
@@ -582,6 +708,9 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
 			return
 		}
+		if !checkAdminScope(ctx, w, r, cred, iampolicy.CreateServiceAccountAdminAction, adminScope{User: targetUser}) {
+			return
+		}
 
 		// In case of LDAP we need to resolve the targetUser to a DN and
 		// query their groups:
@@ -693,22 +822,27 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if !globalIAMSys.IsAllowed(iampolicy.Args{
-		AccountName:     cred.AccessKey,
-		Action:          iampolicy.UpdateServiceAccountAdminAction,
-		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
-		IsOwner:         owner,
-		Claims:          claims,
-	}) {
-		requestUser := cred.AccessKey
-		if cred.ParentUser != "" {
-			requestUser = cred.ParentUser
-		}
+	requestUser := cred.AccessKey
+	if cred.ParentUser != "" {
+		requestUser = cred.ParentUser
+	}
 
-		if requestUser != svcAccount.ParentUser {
+	// Service accounts of other users may be managed by admins whose
+	// scope includes the parent user.
+	if requestUser != svcAccount.ParentUser {
+		if !globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     cred.AccessKey,
+			Action:          iampolicy.UpdateServiceAccountAdminAction,
+			ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+			IsOwner:         owner,
+			Claims:          claims,
+		}) {
 			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
 			return
 		}
+		if !checkAdminScope(ctx, w, r, cred, iampolicy.UpdateServiceAccountAdminAction, adminScope{User: svcAccount.ParentUser}) {
+			return
+		}
 	}
 
 	password := cred.SecretKey
@@ -796,22 +930,27 @@ func (a adminAPIHandlers) InfoServiceAccount(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if !globalIAMSys.IsAllowed(iampolicy.Args{
-		AccountName:     cred.AccessKey,
-		Action:          iampolicy.ListServiceAccountsAdminAction,
-		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
-		IsOwner:         owner,
-		Claims:          claims,
-	}) {
-		requestUser := cred.AccessKey
-		if cred.ParentUser != "" {
-			requestUser = cred.ParentUser
-		}
+	requestUser := cred.AccessKey
+	if cred.ParentUser != "" {
+		requestUser = cred.ParentUser
+	}
 
-		if requestUser != svcAccount.ParentUser {
+	// Service accounts of other users may be managed by admins whose
+	// scope includes the parent user.
+	if requestUser != svcAccount.ParentUser {
+		if !globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     cred.AccessKey,
+			Action:          iampolicy.ListServiceAccountsAdminAction,
+			ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+			IsOwner:         owner,
+			Claims:          claims,
+		}) {
 			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
 			return
 		}
+		if !checkAdminScope(ctx, w, r, cred, iampolicy.ListServiceAccountsAdminAction, adminScope{User: svcAccount.ParentUser}) {
+			return
+		}
 	}
 
 	var svcAccountPolicy iampolicy.Policy
@@ -890,6 +1029,9 @@ func (a adminAPIHandlers) ListServiceAccounts(w http.ResponseWriter, r *http.Req
 			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
 			return
 		}
+		if !checkAdminScope(ctx, w, r, cred, iampolicy.ListServiceAccountsAdminAction, adminScope{User: user}) {
+			return
+		}
 		targetAccount = user
 	} else {
 		targetAccount = cred.AccessKey
@@ -968,12 +1110,12 @@ func (a adminAPIHandlers) DeleteServiceAccount(w http.ResponseWriter, r *http.Re
 		Claims:          claims,
 	})
 
-	if !adminPrivilege {
-		parentUser := cred.AccessKey
-		if cred.ParentUser != "" {
-			parentUser = cred.ParentUser
-		}
-		if parentUser != svcAccount.ParentUser {
+	parentUser := cred.AccessKey
+	if cred.ParentUser != "" {
+		parentUser = cred.ParentUser
+	}
+	if parentUser != svcAccount.ParentUser {
+		if !adminPrivilege || !newAdminScopeFilter(r, cred, iampolicy.RemoveServiceAccountAdminAction).allowed(adminScope{User: svcAccount.ParentUser}) {
 			// The service account belongs to another user but return not
 			// found error to mitigate brute force attacks. or the
 			// serviceAccount doesn't exist.
@@ -1194,7 +1336,7 @@ func (a adminAPIHandlers) InfoCannedPolicy(w http.ResponseWriter, r *http.Reques
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetPolicyAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.GetPolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1206,6 +1348,10 @@ func (a adminAPIHandlers) InfoCannedPolicy(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.GetPolicyAdminAction, adminScope{Policy: name}) {
+		return
+	}
+
 	policyDoc, err := globalIAMSys.InfoPolicy(name)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -1242,20 +1388,28 @@ func (a adminAPIHandlers) ListBucketPolicies(w http.ResponseWriter, r *http.Requ
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListUserPoliciesAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ListUserPoliciesAdminAction)
 	if objectAPI == nil {
 		return
 	}
 
 	bucket := mux.Vars(r)["bucket"]
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.ListUserPoliciesAdminAction, bucketAdminScope(bucket)) {
+		return
+	}
+
 	policies, err := globalIAMSys.ListPolicies(ctx, bucket)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
+	scopeFilter := newAdminScopeFilter(r, cred, iampolicy.ListUserPoliciesAdminAction)
 	newPolicies := make(map[string]iampolicy.Policy)
 	for name, p := range policies {
+		if !scopeFilter.allowed(adminScope{Policy: name}) {
+			continue
+		}
 		_, err = json.Marshal(p)
 		if err != nil {
 			logger.LogIf(ctx, err)
@@ -1275,7 +1429,7 @@ func (a adminAPIHandlers) ListCannedPolicies(w http.ResponseWriter, r *http.Requ
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListUserPoliciesAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ListUserPoliciesAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1286,8 +1440,12 @@ func (a adminAPIHandlers) ListCannedPolicies(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	scopeFilter := newAdminScopeFilter(r, cred, iampolicy.ListUserPoliciesAdminAction)
 	newPolicies := make(map[string]iampolicy.Policy)
 	for name, p := range policies {
+		if !scopeFilter.allowed(adminScope{Policy: name}) {
+			continue
+		}
 		_, err = json.Marshal(p)
 		if err != nil {
 			logger.LogIf(ctx, err)
@@ -1307,7 +1465,7 @@ func (a adminAPIHandlers) RemoveCannedPolicy(w http.ResponseWriter, r *http.Requ
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DeletePolicyAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.DeletePolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1315,6 +1473,10 @@ func (a adminAPIHandlers) RemoveCannedPolicy(w http.ResponseWriter, r *http.Requ
 	vars := mux.Vars(r)
 	policyName := vars["name"]
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.DeletePolicyAdminAction, adminScope{Policy: policyName}) {
+		return
+	}
+
 	if err := globalIAMSys.DeletePolicy(ctx, policyName, true); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -1337,7 +1499,7 @@ func (a adminAPIHandlers) AddCannedPolicy(w http.ResponseWriter, r *http.Request
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.CreatePolicyAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.CreatePolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1345,6 +1507,10 @@ func (a adminAPIHandlers) AddCannedPolicy(w http.ResponseWriter, r *http.Request
 	vars := mux.Vars(r)
 	policyName := vars["name"]
 
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.CreatePolicyAdminAction, adminScope{Policy: policyName}) {
+		return
+	}
+
 	// Error out if Content-Length is missing.
 	if r.ContentLength <= 0 {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -1375,6 +1541,16 @@ func (a adminAPIHandlers) AddCannedPolicy(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// The policy must not grant access beyond the scope of the
+	// request sender.
+	filter := newAdminScopeFilter(r, cred, iampolicy.CreatePolicyAdminAction)
+	for _, scope := range policyAdminScopes(*iamPolicy) {
+		if !filter.allowed(scope) {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			return
+		}
+	}
+
 	if err = globalIAMSys.SetPolicy(ctx, policyName, *iamPolicy); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -1398,7 +1574,7 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AttachPolicyAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.AttachPolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1408,6 +1584,23 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 	entityName := vars["userOrGroup"]
 	isGroup := vars["isGroup"] == "true"
 
+	scope := adminScope{User: entityName}
+	if isGroup {
+		scope = adminScope{Group: entityName}
+	}
+	if !checkAdminScope(ctx, w, r, cred, iampolicy.AttachPolicyAdminAction, scope) {
+		return
+	}
+
+	// Delegated admins may only attach policies within their scope.
+	scopeFilter := newAdminScopeFilter(r, cred, iampolicy.AttachPolicyAdminAction)
+	for _, policy := range newMappedPolicy(policyName).toSlice() {
+		if !scopeFilter.allowed(adminScope{Policy: policy}) {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			return
+		}
+	}
+
 	if !isGroup {
 		ok, _, err := globalIAMSys.IsTempUser(entityName)
 		if err != nil && err != errNoSuchUser {
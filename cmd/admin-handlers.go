@@ -43,6 +43,7 @@ import (
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/dsync"
+	"github.com/minio/minio/internal/fips"
 	"github.com/minio/minio/internal/handlers"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
@@ -452,6 +453,7 @@ func (a adminAPIHandlers) ForceUnlockHandler(w http.ResponseWriter, r *http.Requ
 	for _, locker := range lockers {
 		locker.ForceUnlock(ctx, args)
 	}
+	dsync.RecordForceUnlock(dsync.ForceUnlockAdmin)
 }
 
 // TopLocksHandler Get list of locks in use
@@ -1019,6 +1021,36 @@ func (a adminAPIHandlers) SpeedtestHandler(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// SpeedtestHistoryHandler - GET /minio/admin/v3/speedtest/history
+// ----------
+// Returns the results of the speedtests run in the configured
+// maintenance windows, oldest first.
+func (a adminAPIHandlers) SpeedtestHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SpeedtestHistory")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealthInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	history, err := loadSpeedtestHistory(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if history == nil {
+		history = []scheduledSpeedtestResult{}
+	}
+
+	resp, err := json.Marshal(history)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
 // Admin API errors
 const (
 	AdminUpdateUnexpectedFailure = "XMinioAdminUpdateUnexpectedFailure"
@@ -1031,16 +1063,17 @@ const (
 // - input entry is not of the type *madmin.TraceInfo*
 // - errOnly entries are to be traced, not status code 2xx, 3xx.
 // - madmin.TraceInfo type is asked by opts
-func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace bool) {
+// - the API, bucket, status and sampling filters of opts match
+func mustTrace(entry interface{}, opts traceOpts) (shouldTrace bool) {
 	trcInfo, ok := entry.(madmin.TraceInfo)
 	if !ok {
 		return false
 	}
 
-	// Override shouldTrace decision with errOnly filtering
+	// Override shouldTrace decision with the filters
 	defer func() {
-		if shouldTrace && opts.OnlyErrors {
-			shouldTrace = trcInfo.RespInfo.StatusCode >= http.StatusBadRequest
+		if shouldTrace {
+			shouldTrace = opts.matchFilters(trcInfo)
 		}
 	}()
 
@@ -1074,7 +1107,7 @@ func mustTrace(entry interface{}, opts madmin.ServiceTraceOpts) (shouldTrace boo
 	return opts.OS && trcInfo.TraceType == madmin.TraceOS
 }
 
-func extractTraceOptions(r *http.Request) (opts madmin.ServiceTraceOpts, err error) {
+func extractTraceOptions(r *http.Request) (opts traceOpts, err error) {
 	q := r.Form
 
 	opts.OnlyErrors = q.Get("err") == "true"
@@ -1098,6 +1131,7 @@ func extractTraceOptions(r *http.Request) (opts madmin.ServiceTraceOpts, err err
 		}
 		opts.Threshold = d
 	}
+	err = opts.parseTraceFilters(q)
 	return
 }
 
@@ -1267,6 +1301,293 @@ func (a adminAPIHandlers) KMSCreateKeyHandler(w http.ResponseWriter, r *http.Req
 	writeSuccessResponseHeadersOnly(w)
 }
 
+// kmsImportKeyReq is the request body of KMSImportKeyHandler. It is
+// encrypted with the secret key of the requesting admin.
+type kmsImportKeyReq struct {
+	Key     []byte   `json:"key"`
+	Buckets []string `json:"buckets"`
+}
+
+// KMSImportKeyHandler - POST /minio/admin/v3/kms/key/import?key-id=<master-key-id>
+//
+// Imports externally generated key material as KMS key. The key may
+// only be used for SSE-KMS on the buckets listed in the request.
+func (a adminAPIHandlers) KMSImportKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSImportKey")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.KMSCreateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if GlobalKMS == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	keyID := r.Form.Get("key-id")
+	if keyID == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	reqBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+	var req kmsImportKeyReq
+	if err = json.Unmarshal(reqBytes, &req); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+	if len(req.Key) != 32 {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errKMSImportInvalidKey), r.URL)
+		return
+	}
+	if len(req.Buckets) == 0 {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errKMSImportNoBuckets), r.URL)
+		return
+	}
+	for _, bucket := range req.Buckets {
+		if _, err = objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
+	// Refresh from the disk in case we had missed notifications from peers.
+	if err = globalKMSImportedKeysSys.Load(ctx, objectAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	key := KMSImportedKey{KeyID: keyID, Buckets: req.Buckets}
+	if err = globalKMSImportedKeysSys.Import(ctx, objectAPI, key, req.Key); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// KMSListImportedKeysHandler - GET /minio/admin/v3/kms/key/imported
+func (a adminAPIHandlers) KMSListImportedKeysHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSListImportedKeys")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSKeyStatusAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	data, err := json.Marshal(globalKMSImportedKeysSys.List())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// fipsStatus describes the cryptographic primitives the server is
+// restricted to.
+type fipsStatus struct {
+	Enabled bool `json:"enabled"`
+	// Module is true if the server has been built with
+	// a FIPS 140 certified cryptographic module.
+	Module          bool     `json:"module"`
+	TLSMaxVersion   string   `json:"tlsMaxVersion,omitempty"`
+	TLSCipherSuites []string `json:"tlsCipherSuites"`
+	TLSCurves       []string `json:"tlsCurves"`
+	SSECipherSuites []string `json:"sseCipherSuites"`
+}
+
+// FIPSStatusHandler - GET /minio/admin/v3/fips/status
+func (a adminAPIHandlers) FIPSStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "FIPSStatus")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status := fipsStatus{
+		Enabled: fips.Required(),
+		Module:  fips.Enabled,
+	}
+	if fips.MaxVersionTLS() == tls.VersionTLS12 {
+		status.TLSMaxVersion = "TLS 1.2"
+	}
+	for _, id := range fips.CipherSuitesTLS() {
+		status.TLSCipherSuites = append(status.TLSCipherSuites, tls.CipherSuiteName(id))
+	}
+	for _, curve := range fips.EllipticCurvesTLS() {
+		status.TLSCurves = append(status.TLSCurves, curve.String())
+	}
+	for _, cipher := range fips.CipherSuitesDARE() {
+		status.SSECipherSuites = append(status.SSECipherSuites, fips.CipherSuiteNameDARE(cipher))
+	}
+
+	resp, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// DriveHealthHandler - GET /minio/admin/v3/drive-health
+// ----------
+// Returns the latest SMART and kernel I/O error counters of the drives
+// of every node and the health events raised for them.
+func (a adminAPIHandlers) DriveHealthHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DriveHealth")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	results := []driveHealthStatus{globalDriveHealth.Status()}
+	for _, result := range globalNotificationSys.GetDriveHealth(ctx) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	resp, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// InflightRequestsHandler - GET /minio/admin/v3/inflight-requests
+// ----------
+// Returns the API requests currently executing on every node, with the
+// bytes they transferred so far.
+func (a adminAPIHandlers) InflightRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "InflightRequests")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TraceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	results := []inflightRequestsStatus{globalInflightRequests.Status()}
+	for _, result := range globalNotificationSys.GetInflightRequests(ctx) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	resp, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// CancelInflightRequestHandler - POST /minio/admin/v3/inflight-requests/cancel?id=<request-id>&node=<node>
+// ----------
+// Cancels an executing API request, its next read or write fails. The
+// request is looked up on all nodes unless node is set.
+func (a adminAPIHandlers) CancelInflightRequestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelInflightRequest")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServiceStopAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	id, node := r.Form.Get("id"), r.Form.Get("node")
+	if id == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	canceled := (node == "" || node == globalLocalNodeName) && globalInflightRequests.cancel(id)
+	if !canceled && node != globalLocalNodeName {
+		var err error
+		canceled, err = globalNotificationSys.CancelInflightRequest(ctx, node, id)
+		if err != nil && !canceled {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+	if !canceled {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errNoSuchInflightRequest), r.URL)
+		return
+	}
+	writeSuccessNoContent(w)
+}
+
+// ReloadCertsHandler - POST /minio/admin/v3/reload-certs
+// ----------
+// Reloads the TLS certificates and root CAs of every node from its
+// certs directory, without restarting the servers.
+func (a adminAPIHandlers) ReloadCertsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReloadCerts")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServiceRestartAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if globalCertsReloader == nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errServerNotInitialized), r.URL)
+		return
+	}
+
+	results := []certsReloadStatus{globalCertsReloader.Status(globalCertsReloader.Reload())}
+	for _, result := range globalNotificationSys.ReloadCerts(ctx) {
+		if result.Node != "" {
+			results = append(results, result)
+		}
+	}
+
+	resp, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// ProbeDependenciesHandler - GET /minio/admin/v3/probe-dependencies
+// ----------
+// Probes the external dependencies configured on this server, such as
+// identity providers, KMS, notification targets, tiers and replication
+// targets, and returns the latency, TLS details and error of each.
+func (a adminAPIHandlers) ProbeDependenciesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ProbeDependencies")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealthInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	resp, err := json.Marshal(probeDependencies(ctx))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
 // KMSKeyStatusHandler - GET /minio/admin/v3/kms/status
 func (a adminAPIHandlers) KMSStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "KMSStatus")
@@ -1382,6 +1703,209 @@ func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Req
 	writeSuccessResponseJSON(w, resp)
 }
 
+// kmsBucketRotationReq is the body of a bucket key rotation schedule
+// request.
+type kmsBucketRotationReq struct {
+	Interval string `json:"interval"`
+}
+
+// KMSSetBucketRotationHandler - PUT /minio/admin/v3/kms/bucket/rotation?bucket=<bucket>
+//
+// Schedules the rotation of the SSE-KMS key of the bucket, or disables
+// it on DELETE. The rotation history is kept in both cases.
+func (a adminAPIHandlers) KMSSetBucketRotationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSSetBucketRotation")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	bucket := pathClean(mux.Vars(r)["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSCreateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if GlobalKMS == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var req kmsBucketRotationReq
+	if r.Method != http.MethodDelete {
+		if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&req); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+			return
+		}
+		if req.Interval == "" {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errKMSRotationInterval), r.URL)
+			return
+		}
+	}
+
+	cfg, err := setBucketKMSRotation(ctx, objectAPI, bucket, req.Interval)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// KMSGetBucketRotationHandler - GET /minio/admin/v3/kms/bucket/rotation?bucket=<bucket>
+//
+// Returns the SSE-KMS key rotation schedule and history of the bucket.
+func (a adminAPIHandlers) KMSGetBucketRotationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSGetBucketRotation")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	bucket := pathClean(mux.Vars(r)["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSKeyStatusAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, err := globalBucketMetadataSys.GetKMSKeyRotationConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// KMSRotateBucketKeyHandler - POST /minio/admin/v3/kms/bucket/rotate?bucket=<bucket>
+//
+// Rotates the SSE-KMS key of the bucket immediately and returns the
+// new key version.
+func (a adminAPIHandlers) KMSRotateBucketKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSRotateBucketKey")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	bucket := pathClean(mux.Vars(r)["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSCreateKeyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	rotation, err := rotateBucketKMSKey(ctx, objectAPI, bucket, kmsKeyRotationManual)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(rotation)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// KMSReencryptBucketHandler - POST /minio/admin/v3/kms/bucket/reencrypt?bucket=<bucket>
+//
+// Starts re-wrapping the object keys of all SSE-KMS encrypted objects of
+// the bucket with the current SSE-KMS key of the bucket. GET returns the
+// progress of the job and DELETE cancels it.
+func (a adminAPIHandlers) KMSReencryptBucketHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSReencryptBucket")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+	bucket := pathClean(mux.Vars(r)["bucket"])
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	var action iampolicy.AdminAction = iampolicy.KMSCreateKeyAdminAction
+	if r.Method == http.MethodGet {
+		action = iampolicy.KMSKeyStatusAdminAction
+	}
+	objectAPI, _ := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return
+	}
+
+	if GlobalKMS == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var (
+		job *kmsReencryptJob
+		err error
+	)
+	switch r.Method {
+	case http.MethodPost:
+		job, err = startKMSReencryptJob(ctx, objectAPI, bucket)
+	case http.MethodDelete:
+		job, err = cancelKMSReencryptJob(ctx, objectAPI, bucket)
+	default:
+		job, err = loadKMSReencryptJob(ctx, objectAPI, bucket)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
 func getServerInfo(ctx context.Context, r *http.Request) madmin.InfoMessage {
 	kmsStat := fetchKMSStatus()
 
@@ -1923,7 +2447,11 @@ func getTLSInfo() madmin.TLSInfo {
 	}
 
 	if globalIsTLS {
-		for _, c := range globalPublicCerts {
+		publicCerts := globalPublicCerts
+		if globalCertsReloader != nil {
+			publicCerts = globalCertsReloader.PublicCerts()
+		}
+		for _, c := range publicCerts {
 			tlsInfo.Certs = append(tlsInfo.Certs, madmin.TLSCert{
 				PubKeyAlgo:    c.PublicKeyAlgorithm.String(),
 				SignatureAlgo: c.SignatureAlgorithm.String(),
@@ -2171,7 +2699,7 @@ func checkConnection(endpointStr string, timeout time.Duration) error {
 		ResponseHeaderTimeout: 5 * time.Second,
 		TLSHandshakeTimeout:   5 * time.Second,
 		ExpectContinueTimeout: 5 * time.Second,
-		TLSClientConfig:       &tls.Config{RootCAs: globalRootCAs},
+		TLSClientConfig:       fips.RestrictTLS(&tls.Config{RootCAs: globalRootCAs}),
 		// Go net/http automatically unzip if content-type is
 		// gzip disable this feature, as we are always interested
 		// in raw stream.
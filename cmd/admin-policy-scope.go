@@ -0,0 +1,319 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/minio/minio/internal/arn"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Admin statements may carry a `Resource` element to restrict the
+// entities they apply to, for example
+//
+//	{
+//	  "Effect": "Allow",
+//	  "Action": ["admin:CreateUser", "admin:AttachUserOrGroupPolicy"],
+//	  "Resource": ["arn:aws:s3:::users/tenant-a-*", "arn:aws:s3:::groups/tenant-a"]
+//	}
+//
+// Buckets are matched by their name, users by `users/<name>`, groups
+// by `groups/<name>` and policies by `policies/<name>`. Buckets tagged
+// with a `tenant` tag additionally match `tenants/<value>`. Admin
+// statements without resources remain unrestricted, which keeps
+// existing policies working as before.
+const (
+	adminScopeUsersPrefix    = "users"
+	adminScopeGroupsPrefix   = "groups"
+	adminScopePoliciesPrefix = "policies"
+	adminScopeTenantsPrefix  = "tenants"
+
+	// adminScopeTenantTag is the bucket tag naming the tenant
+	// owning a bucket.
+	adminScopeTenantTag = "tenant"
+)
+
+// mfaPresentKey reports whether the temporary credentials of the
+// request were issued after multi-factor authentication, as claimed by
+// the identity provider in the `amr` claim.
+const mfaPresentKey condition.KeyName = "aws:MultiFactorAuthPresent"
+
+// mfaAuthMethods are the `amr` values (RFC 8176) implying a second
+// authentication factor.
+var mfaAuthMethods = []string{"mfa", "otp", "hwk"}
+
+func init() {
+	// Make the key known to the policy parser, it is compared as
+	// a string, e.g. "StringEquals": {"aws:MultiFactorAuthPresent": "true"}.
+	condition.AllSupportedKeys = append(condition.AllSupportedKeys, mfaPresentKey)
+	condition.CommonKeys = append(condition.CommonKeys, mfaPresentKey)
+}
+
+// isMFAPresent returns whether the `amr` claim in claims names an
+// authentication method implying multi-factor authentication.
+func isMFAPresent(claims map[string]interface{}) bool {
+	var methods []string
+	switch amr := claims[amrClaim].(type) {
+	case string:
+		methods = []string{amr}
+	case []string:
+		methods = amr
+	case []interface{}:
+		for _, m := range amr {
+			if s, ok := m.(string); ok {
+				methods = append(methods, s)
+			}
+		}
+	}
+	for _, m := range methods {
+		for _, mfa := range mfaAuthMethods {
+			if strings.EqualFold(m, mfa) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// adminScope is the entity an admin action is performed on.
+type adminScope struct {
+	Bucket string
+	User   string
+	Group  string
+	Policy string
+	// Tenant is the tenant owning the entity, if known.
+	Tenant string
+}
+
+// resources returns the resource strings matched against the
+// statement resources for this scope.
+func (s adminScope) resources() []string {
+	var resource string
+	switch {
+	case s.User != "":
+		resource = path.Join(adminScopeUsersPrefix, s.User)
+	case s.Group != "":
+		resource = path.Join(adminScopeGroupsPrefix, s.Group)
+	case s.Policy != "":
+		resource = path.Join(adminScopePoliciesPrefix, s.Policy)
+	default:
+		resource = s.Bucket
+	}
+	resources := []string{resource}
+	if s.Tenant != "" {
+		resources = append(resources, path.Join(adminScopeTenantsPrefix, s.Tenant))
+	}
+	return resources
+}
+
+func (s adminScope) isEmpty() bool {
+	return s.Bucket == "" && s.User == "" && s.Group == "" && s.Policy == ""
+}
+
+// bucketAdminScope returns the scope of a bucket, including the
+// tenant set in its tags.
+func bucketAdminScope(bucket string) adminScope {
+	scope := adminScope{Bucket: bucket}
+	if globalBucketMetadataSys == nil {
+		return scope
+	}
+	if tags, err := globalBucketMetadataSys.GetTaggingConfig(bucket); err == nil {
+		scope.Tenant = tags.ToMap()[adminScopeTenantTag]
+	}
+	return scope
+}
+
+// policyAdminScopes returns the scopes of the resources the allow
+// statements of p grant access to, so that admins scoped to a tenant
+// can only create policies within the tenant. Allow statements without
+// resources apply to all entities.
+func policyAdminScopes(p iampolicy.Policy) []adminScope {
+	var scopes []adminScope
+	for _, statement := range p.Statements {
+		if statement.Effect != policy.Allow {
+			continue
+		}
+		if len(statement.Resources) == 0 {
+			scopes = append(scopes, adminScope{Bucket: "*"})
+			continue
+		}
+		admin := isAdminStatement(statement)
+		for _, resource := range statement.Resources.ToSlice() {
+			scope := adminScope{Bucket: resource.Pattern}
+			// Resources naming a single bucket also match the tenant
+			// owning the bucket.
+			if bucket := strings.SplitN(resource.Pattern, SlashSeparator, 2)[0]; !admin && !strings.ContainsAny(bucket, "*?$") {
+				scope.Tenant = bucketAdminScope(bucket).Tenant
+			}
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// isAdminScopeAllowed evaluates resource scoping of all admin
+// statements in p granting or denying the action. An explicit deny
+// whose resources match the scope always wins. Otherwise at least one
+// allow statement must either be unscoped or match the scope.
+func isAdminScopeAllowed(p iampolicy.Policy, action iampolicy.AdminAction, scope adminScope, conditionValues map[string][]string) bool {
+	if scope.isEmpty() {
+		return true
+	}
+
+	resources := scope.resources()
+	allowed := false
+	for _, statement := range p.Statements {
+		if !statement.Actions.Match(iampolicy.Action(action)) {
+			continue
+		}
+		if !statement.Conditions.Evaluate(conditionValues) {
+			continue
+		}
+		matched := len(statement.Resources) == 0
+		for _, resource := range resources {
+			if matched {
+				break
+			}
+			matched = statement.Resources.Match(resource, conditionValues)
+		}
+		if !matched {
+			continue
+		}
+		if statement.Effect == policy.Deny {
+			return false
+		}
+		allowed = true
+	}
+	return allowed
+}
+
+// adminScopePolicies returns the policy names that apply to the
+// account, following the same lookups as IsAllowed for temporary
+// credentials and service accounts.
+func (sys *IAMSys) adminScopePolicies(args iampolicy.Args) ([]string, error) {
+	accountName := args.AccountName
+	ok, parentUser, err := sys.IsTempUser(accountName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		ok, parentUser, err = sys.IsServiceAccount(accountName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ok {
+		accountName = parentUser
+	}
+
+	policies, err := sys.PolicyDBGet(accountName, false, args.Groups...)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) > 0 {
+		return policies, nil
+	}
+
+	if roleArn := args.GetRoleArn(); roleArn != "" {
+		rArn, err := arn.Parse(roleArn)
+		if err != nil {
+			return nil, err
+		}
+		return newMappedPolicy(sys.rolesMap[rArn]).toSlice(), nil
+	}
+	policySet, _ := iampolicy.GetPoliciesFromClaims(args.Claims, iamPolicyClaimNameOpenID())
+	return policySet.ToSlice(), nil
+}
+
+// adminScopeFilter evaluates the resource scoping of the admin
+// policies of an account for a single action, resolving the policies
+// only once for checking many entities, e.g. when filtering lists.
+type adminScopeFilter struct {
+	unrestricted    bool
+	policy          iampolicy.Policy
+	action          iampolicy.AdminAction
+	conditionValues map[string][]string
+}
+
+// allowed returns whether the action may be performed on scope.
+func (f adminScopeFilter) allowed(scope adminScope) bool {
+	if f.unrestricted {
+		return true
+	}
+	return isAdminScopeAllowed(f.policy, f.action, scope, f.conditionValues)
+}
+
+// getAdminScopeFilter - returns the filter of the admin policies of the
+// account for the action in args.
+func (sys *IAMSys) getAdminScopeFilter(args iampolicy.Args) adminScopeFilter {
+	filter := adminScopeFilter{
+		action:          iampolicy.AdminAction(args.Action),
+		conditionValues: args.ConditionValues,
+	}
+
+	// OPA and owner decisions are final.
+	if globalPolicyOPA != nil || args.IsOwner {
+		filter.unrestricted = true
+		return filter
+	}
+
+	policies, err := sys.adminScopePolicies(args)
+	if err == nil && len(policies) > 0 {
+		filter.policy = sys.GetCombinedPolicy(policies...)
+	}
+	return filter
+}
+
+// IsAdminScopeAllowed - checks that the resource scoping of the admin
+// policies of the account permits performing the action on scope.
+// This is meant to be called after IsAllowed() has succeeded.
+func (sys *IAMSys) IsAdminScopeAllowed(args iampolicy.Args, scope adminScope) bool {
+	return sys.getAdminScopeFilter(args).allowed(scope)
+}
+
+// newAdminScopeFilter returns the scope filter of the authenticated
+// admin request for the action.
+func newAdminScopeFilter(r *http.Request, cred auth.Credentials, action iampolicy.AdminAction) adminScopeFilter {
+	claims := mustGetClaimsFromToken(r)
+	return globalIAMSys.getAdminScopeFilter(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Groups:          cred.Groups,
+		Action:          iampolicy.Action(action),
+		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+		IsOwner:         cred.AccessKey == globalActiveCred.AccessKey,
+		Claims:          claims,
+	})
+}
+
+// checkAdminScope verifies that the authenticated admin request is
+// allowed to perform the action on the given scope, writing an access
+// denied response otherwise.
+func checkAdminScope(ctx context.Context, w http.ResponseWriter, r *http.Request, cred auth.Credentials, action iampolicy.AdminAction, scope adminScope) bool {
+	if newAdminScopeFilter(r, cred, action).allowed(scope) {
+		return true
+	}
+	writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+	return false
+}
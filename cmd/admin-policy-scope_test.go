@@ -0,0 +1,226 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+func TestIsAdminScopeAllowed(t *testing.T) {
+	scopedPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["admin:CreateUser", "admin:SetBucketQuota"],
+      "Resource": ["arn:aws:s3:::users/tenant-a-*", "arn:aws:s3:::tenant-a-*"]
+    },
+    {
+      "Effect": "Deny",
+      "Action": ["admin:CreateUser"],
+      "Resource": ["arn:aws:s3:::users/tenant-a-admin"]
+    },
+    {
+      "Effect": "Allow",
+      "Action": ["admin:ServerInfo"]
+    }
+  ]
+}`
+	p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(scopedPolicy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		action   iampolicy.AdminAction
+		scope    adminScope
+		expected bool
+	}{
+		{iampolicy.CreateUserAdminAction, adminScope{User: "tenant-a-bob"}, true},
+		{iampolicy.CreateUserAdminAction, adminScope{User: "tenant-b-bob"}, false},
+		{iampolicy.CreateUserAdminAction, adminScope{User: "tenant-a-admin"}, false},
+		{iampolicy.CreateUserAdminAction, adminScope{}, true},
+		{iampolicy.SetBucketQuotaAdminAction, adminScope{Bucket: "tenant-a-photos"}, true},
+		{iampolicy.SetBucketQuotaAdminAction, adminScope{Bucket: "tenant-b-photos"}, false},
+		{iampolicy.ServerInfoAdminAction, adminScope{Bucket: "any"}, true},
+		{iampolicy.EnableGroupAdminAction, adminScope{Group: "tenant-a"}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := isAdminScopeAllowed(*p, testCase.action, testCase.scope, map[string][]string{})
+		if result != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, result)
+		}
+	}
+}
+
+func TestAdminScopeFilterTenants(t *testing.T) {
+	tenantPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["admin:ListUsers", "admin:ListUserPolicies", "admin:SetBucketQuota"],
+      "Resource": ["arn:aws:s3:::users/tenant-a-*", "arn:aws:s3:::policies/tenant-a-*", "arn:aws:s3:::tenants/tenant-a"]
+    },
+    {
+      "Effect": "Deny",
+      "Action": ["admin:ListUserPolicies"],
+      "Resource": ["arn:aws:s3:::policies/tenant-a-secret"]
+    }
+  ]
+}`
+	p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(tenantPolicy)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listUsers := adminScopeFilter{policy: *p, action: iampolicy.ListUsersAdminAction}
+	var users []string
+	for _, user := range []string{"tenant-a-alice", "tenant-b-bob", "tenant-a-carol"} {
+		if listUsers.allowed(adminScope{User: user}) {
+			users = append(users, user)
+		}
+	}
+	if len(users) != 2 || users[0] != "tenant-a-alice" || users[1] != "tenant-a-carol" {
+		t.Errorf("unexpected scoped users %v", users)
+	}
+
+	testCases := []struct {
+		action   iampolicy.AdminAction
+		scope    adminScope
+		expected bool
+	}{
+		{iampolicy.ListUserPoliciesAdminAction, adminScope{Policy: "tenant-a-readonly"}, true},
+		{iampolicy.ListUserPoliciesAdminAction, adminScope{Policy: "tenant-a-secret"}, false},
+		{iampolicy.ListUserPoliciesAdminAction, adminScope{Policy: "consoleAdmin"}, false},
+		{iampolicy.SetBucketQuotaAdminAction, adminScope{Bucket: "photos", Tenant: "tenant-a"}, true},
+		{iampolicy.SetBucketQuotaAdminAction, adminScope{Bucket: "photos", Tenant: "tenant-b"}, false},
+		{iampolicy.SetBucketQuotaAdminAction, adminScope{Bucket: "photos"}, false},
+	}
+
+	for i, testCase := range testCases {
+		filter := adminScopeFilter{policy: *p, action: testCase.action}
+		if result := filter.allowed(testCase.scope); result != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, result)
+		}
+	}
+
+	if !(adminScopeFilter{unrestricted: true}).allowed(adminScope{User: "tenant-b-bob"}) {
+		t.Error("unrestricted filter must allow all scopes")
+	}
+}
+
+func TestPolicyAdminScopes(t *testing.T) {
+	creator, err := iampolicy.ParseConfig(bytes.NewReader([]byte(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["admin:CreatePolicy"],
+      "Resource": ["arn:aws:s3:::policies/tenant-a-*", "arn:aws:s3:::tenant-a-*", "arn:aws:s3:::users/tenant-a-*"]
+    }
+  ]
+}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		policy   string
+		expected bool
+	}{
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::tenant-a-photos/*"]}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::tenant-a-*"]}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::tenant-b-photos/*"]}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*"]}]}`, false},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*"]}]}`, true},
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["admin:CreateUser"]}]}`, false},
+	}
+	for i, testCase := range testCases {
+		p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(testCase.policy)))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		allowed := true
+		for _, scope := range policyAdminScopes(*p) {
+			allowed = allowed && isAdminScopeAllowed(*creator, iampolicy.CreatePolicyAdminAction, scope, map[string][]string{})
+		}
+		if allowed != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, allowed)
+		}
+	}
+}
+
+func TestIsMFAPresent(t *testing.T) {
+	testCases := []struct {
+		claims map[string]interface{}
+		mfa    bool
+	}{
+		{nil, false},
+		{map[string]interface{}{"amr": "pwd"}, false},
+		{map[string]interface{}{"amr": []interface{}{"pwd", "otp"}}, true},
+		{map[string]interface{}{"amr": []string{"MFA"}}, true},
+		{map[string]interface{}{"amr": []interface{}{"pwd", 1}}, false},
+	}
+	for i, testCase := range testCases {
+		if mfa := isMFAPresent(testCase.claims); mfa != testCase.mfa {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.mfa, mfa)
+		}
+	}
+}
+
+func TestMFAPresentCondition(t *testing.T) {
+	p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::*"],
+      "Condition": {"StringEquals": {"aws:MultiFactorAuthPresent": "true"}}
+    }
+  ]
+}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	for _, mfa := range []bool{false, true} {
+		claims := map[string]interface{}{"amr": []interface{}{"pwd"}}
+		if mfa {
+			claims["amr"] = []interface{}{"pwd", "mfa"}
+		}
+		allowed := p.IsAllowed(iampolicy.Args{
+			AccountName:     "alice",
+			Action:          iampolicy.GetObjectAction,
+			BucketName:      "bucket",
+			ObjectName:      "object",
+			ConditionValues: getConditionValues(r, "", "alice", claims),
+		})
+		if allowed != mfa {
+			t.Errorf("MFA %v: expected allowed %v, got %v", mfa, mfa, allowed)
+		}
+	}
+}
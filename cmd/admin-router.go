@@ -159,6 +159,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// Add/Remove members from group
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/update-group-members").HandlerFunc(gz(httpTraceHdrs(adminAPI.UpdateGroupMembers)))
 
+		// Add/Remove subgroups of group
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/update-group-subgroups").HandlerFunc(gz(httpTraceHdrs(adminAPI.UpdateGroupSubgroups)))
+
 		// Get Group
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/group").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetGroup))).Queries("group", "{group:.*}")
 
@@ -168,6 +171,20 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// Set Group Status
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-group-status").HandlerFunc(gz(httpTraceHdrs(adminAPI.SetGroupStatus))).Queries("group", "{group:.*}").Queries("status", "{status:.*}")
 
+		// Public access report
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/bucket-policy-public-access").HandlerFunc(gz(httpTraceHdrs(adminAPI.BucketPolicyPublicAccessHandler)))
+
+		// STS revocation list
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/revoke-sts").HandlerFunc(gz(httpTraceHdrs(adminAPI.RevokeSTSHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-sts-revocations").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListSTSRevocationsHandler)))
+
+		// Policy decision trace
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/policy-trace").HandlerFunc(gz(httpTraceHdrs(adminAPI.PolicyTraceHandler)))
+
+		// IAM export and import
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/export-iam").HandlerFunc(gz(httpTraceHdrs(adminAPI.ExportIAMHandler)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/import-iam").HandlerFunc(gz(httpTraceHdrs(adminAPI.ImportIAMHandler)))
+
 		if globalIsDistErasure || globalIsErasure {
 			// GetBucketQuotaConfig
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-quota").HandlerFunc(
@@ -186,14 +203,94 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			// RemoveRemoteTargetHandler
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-remote-target").HandlerFunc(
 				gz(httpTraceHdrs(adminAPI.RemoveRemoteTargetHandler))).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			// SetReplicationMeshHandler
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-replication-mesh").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.SetReplicationMeshHandler))).Queries("bucket", "{bucket:.*}")
+			// ReplicationConvergenceHandler
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-convergence").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ReplicationConvergenceHandler))).Queries("bucket", "{bucket:.*}")
+			// ReplicationOldestUnreplicatedHandler
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-oldest-unreplicated").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ReplicationOldestUnreplicatedHandler))).Queries("bucket", "{bucket:.*}")
+			// Replication estimate
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication-estimate").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ReplicationEstimateHandler))).Queries("bucket", "{bucket:.*}")
+			// ReplicationResyncStatusHandler
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-resync-status").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ReplicationResyncStatusHandler))).Queries("bucket", "{bucket:.*}")
+			// Replication bandwidth schedules
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/replication-bandwidth-schedule").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.SetReplicationBandwidthScheduleHandler))).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-bandwidth-schedule").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.GetReplicationBandwidthScheduleHandler))).Queries("bucket", "{bucket:.*}")
+			// Replication target STS credentials
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/replication-target-sts").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.SetReplicationTargetSTSHandler))).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/replication-target-sts").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.SetReplicationTargetSTSHandler))).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-target-sts").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.GetReplicationTargetSTSHandler))).Queries("bucket", "{bucket:.*}")
+			// Replication dead-letter queue
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-dlq").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ListReplicationDLQHandler))).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication-dlq/retry").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.RetryReplicationDLQHandler))).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/replication-dlq").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.PurgeReplicationDLQHandler))).Queries("bucket", "{bucket:.*}")
+			// Bucket event journal
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replay-events").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ReplayEventsHandler))).Queries("bucket", "{bucket:.*}")
+			// Notification target delivery statistics
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/event-target-stats").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.EventTargetStatsHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/event-target-test").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.TestEventTargetsHandler)))
+			// Undeliverable bucket events
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/event-deadletters").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ListEventDeadLettersHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/event-deadletters/export").HandlerFunc(
+				httpTraceHdrs(adminAPI.ExportEventDeadLettersHandler))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/event-deadletters/redrive").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.RedriveEventDeadLettersHandler)))
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/event-deadletters").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.PurgeEventDeadLettersHandler)))
+			// ReplicationConflictsHandler
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication-conflicts").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ReplicationConflictsHandler))).Queries("bucket", "{bucket:.*}")
 
 			// Remote Tier management operations
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier").HandlerFunc(gz(httpTraceHdrs(adminAPI.AddTierHandler)))
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/tier/{tier}").HandlerFunc(gz(httpTraceHdrs(adminAPI.EditTierHandler)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListTierHandler)))
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier-fs").HandlerFunc(gz(httpTraceHdrs(adminAPI.AddTierFSHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-fs").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListTierFSHandler)))
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier-tape").HandlerFunc(gz(httpTraceHdrs(adminAPI.AddTierTapeHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-tape").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListTierTapeHandler)))
 
 			// Tier stats
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-stats").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierStatsHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-verify-status").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierVerifyStatusHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-usage").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierUsageHandler)))
+
+			// Tier migration
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/tier-migrate").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.TierMigrateHandler))).Queries("from", "{from:.*}", "to", "{to:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-migrate-status").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierMigrateStatusHandler)))
+
+			// Tier endpoint and credential rotation
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/tier-rotate/{tier}").HandlerFunc(gz(httpTraceHdrs(adminAPI.TierRotateHandler)))
+
+			// Tier content encoding
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier-encoding/{tier}").HandlerFunc(gz(httpTraceHdrs(adminAPI.SetTierEncodingHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tier-encoding/{tier}").HandlerFunc(gz(httpTraceHdrs(adminAPI.GetTierEncodingHandler)))
+
+			// Lifecycle dry-run
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/ilm-simulate").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.LifecycleSimulateHandler))).Queries("bucket", "{bucket:.*}")
+
+			// Client-side encryption report
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/cse-report").HandlerFunc(
+				gz(httpTraceHdrs(adminAPI.ClientSideEncryptionReportHandler))).Queries("bucket", "{bucket:.*}")
 
 			// Cluster Replication APIs
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/add").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationAdd)))
@@ -201,11 +298,13 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/info").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationInfo)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/metainfo").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationMetaInfo)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/status").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationStatus)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/health").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationHealth)))
 
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/join").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerJoin)))
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/site-replication/peer/bucket-ops").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerBucketOps))).Queries("bucket", "{bucket:.*}").Queries("operation", "{operation:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/iam-item").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerReplicateIAMItem)))
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/bucket-meta").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerReplicateBucketItem)))
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/tier").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerReplicateTier)))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/peer/idp-settings").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerGetIDPSettings)))
 		}
 
@@ -218,6 +317,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		}
 
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/speedtest").HandlerFunc(httpTraceHdrs(adminAPI.SpeedtestHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/speedtest/history").HandlerFunc(gz(httpTraceAll(adminAPI.SpeedtestHistoryHandler)))
 
 		// HTTP Trace
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/trace").HandlerFunc(gz(http.HandlerFunc(adminAPI.TraceHandler)))
@@ -225,11 +325,34 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// Console Logs
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/log").HandlerFunc(gz(httpTraceAll(adminAPI.ConsoleLogHandler)))
 
+		// FIPS mode status
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/fips/status").HandlerFunc(gz(httpTraceAll(adminAPI.FIPSStatusHandler)))
+
+		// Drive health
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/drive-health").HandlerFunc(gz(httpTraceAll(adminAPI.DriveHealthHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/inflight-requests").HandlerFunc(gz(httpTraceAll(adminAPI.InflightRequestsHandler)))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/inflight-requests/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.CancelInflightRequestHandler)))
+
+		// TLS certificates reload
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/reload-certs").HandlerFunc(gz(httpTraceAll(adminAPI.ReloadCertsHandler)))
+
+		// Dependency diagnostics
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/probe-dependencies").HandlerFunc(gz(httpTraceAll(adminAPI.ProbeDependenciesHandler)))
+
 		// -- KMS APIs --
 		//
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/kms/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSStatusHandler)))
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/key/create").HandlerFunc(gz(httpTraceAll(adminAPI.KMSCreateKeyHandler))).Queries("key-id", "{key-id:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/key/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSKeyStatusHandler)))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/key/import").HandlerFunc(gz(httpTraceHdrs(adminAPI.KMSImportKeyHandler))).Queries("key-id", "{key-id:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/key/imported").HandlerFunc(gz(httpTraceAll(adminAPI.KMSListImportedKeysHandler)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/kms/bucket/rotation").HandlerFunc(gz(httpTraceAll(adminAPI.KMSSetBucketRotationHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/kms/bucket/rotation").HandlerFunc(gz(httpTraceAll(adminAPI.KMSSetBucketRotationHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/kms/bucket/rotation").HandlerFunc(gz(httpTraceAll(adminAPI.KMSGetBucketRotationHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/bucket/rotate").HandlerFunc(gz(httpTraceAll(adminAPI.KMSRotateBucketKeyHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/bucket/reencrypt").HandlerFunc(gz(httpTraceAll(adminAPI.KMSReencryptBucketHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/kms/bucket/reencrypt").HandlerFunc(gz(httpTraceAll(adminAPI.KMSReencryptBucketHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/kms/bucket/reencrypt").HandlerFunc(gz(httpTraceAll(adminAPI.KMSReencryptBucketHandler))).Queries("bucket", "{bucket:.*}")
 
 		if !globalIsGateway {
 			// Keep obdinfo for backward compatibility with mc
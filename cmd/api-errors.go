@@ -37,6 +37,7 @@ import (
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/config/dns"
 	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
 
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
@@ -112,6 +113,9 @@ const (
 	ErrNoSuchLifecycleConfiguration
 	ErrInvalidLifecycleWithObjectLock
 	ErrNoSuchBucketSSEConfig
+	ErrNoSuchPublicAccessBlockConfiguration
+	ErrPublicPolicyBlocked
+	ErrPublicACLBlocked
 	ErrNoSuchCORSConfiguration
 	ErrNoSuchWebsiteConfiguration
 	ErrReplicationConfigurationNotFoundError
@@ -120,6 +124,7 @@ const (
 	ErrRemoteTargetNotFoundError
 	ErrReplicationRemoteConnectionError
 	ErrReplicationBandwidthLimitError
+	ErrReplicationRemoteSTSConfigError
 	ErrBucketRemoteIdenticalToSource
 	ErrBucketRemoteAlreadyExists
 	ErrBucketRemoteLabelInUse
@@ -212,6 +217,8 @@ const (
 	ErrInvalidSSECustomerParameters
 	ErrIncompatibleEncryptionMethod
 	ErrKMSNotConfigured
+	ErrKMSKeyNotAllowed
+	ErrKMSUnavailable
 
 	ErrNoAccessKey
 	ErrInvalidToken
@@ -225,8 +232,10 @@ const (
 	ErrFilterNamePrefix
 	ErrFilterNameSuffix
 	ErrFilterValueInvalid
+	ErrFilterConditionInvalid
 	ErrOverlappingConfigs
 	ErrUnsupportedNotification
+	ErrEventSchemaVersion
 
 	// S3 extended errors.
 	ErrContentSHA256Mismatch
@@ -283,6 +292,7 @@ const (
 	// Bucket Quota error codes
 	ErrAdminBucketQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
+	ErrAdminNoSuchInflightRequest
 
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
@@ -595,6 +605,21 @@ var errorCodes = errorCodeMap{
 		Description:    "The server side encryption configuration was not found",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrNoSuchPublicAccessBlockConfiguration: {
+		Code:           "NoSuchPublicAccessBlockConfiguration",
+		Description:    "The public access block configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrPublicPolicyBlocked: {
+		Code:           "AccessDenied",
+		Description:    "The bucket policy grants public access, which is blocked by the public access block configuration",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrPublicACLBlocked: {
+		Code:           "AccessDenied",
+		Description:    "Public ACLs are blocked by the public access block configuration",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrNoSuchKey: {
 		Code:           "NoSuchKey",
 		Description:    "The specified key does not exist.",
@@ -890,6 +915,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Bandwidth limit for remote target must be atleast 100MBps",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrReplicationRemoteSTSConfigError: {
+		Code:           "XMinioAdminReplicationRemoteSTSConfigError",
+		Description:    "Invalid STS configuration for remote target",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrReplicationNoMatchingRuleError: {
 		Code:           "XMinioReplicationNoMatchingRule",
 		Description:    "No matching replication rule found for this object prefix",
@@ -1027,11 +1057,21 @@ var errorCodes = errorCodeMap{
 		Description:    "Cannot specify more than one suffix rule in a filter.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrEventSchemaVersion: {
+		Code:           "InvalidArgument",
+		Description:    "The event schema version is not supported.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrFilterValueInvalid: {
 		Code:           "InvalidArgument",
 		Description:    "Size of filter rule value cannot exceed 1024 bytes in UTF-8 representation",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrFilterConditionInvalid: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid object attribute condition in the notification filter",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrOverlappingConfigs: {
 		Code:           "InvalidArgument",
 		Description:    "Configurations overlap. Configurations on the same bucket cannot share a common event type.",
@@ -1127,6 +1167,16 @@ var errorCodes = errorCodeMap{
 		Description:    "Server side encryption specified but KMS is not configured",
 		HTTPStatusCode: http.StatusNotImplemented,
 	},
+	ErrKMSKeyNotAllowed: {
+		Code:           "AccessDenied",
+		Description:    "The KMS key has been imported for other buckets and cannot be used for this bucket",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrKMSUnavailable: {
+		Code:           "ServiceUnavailable",
+		Description:    "The KMS is currently unavailable. Please try again later",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
 	ErrNoAccessKey: {
 		Code:           "AccessDenied",
 		Description:    "No AWSAccessKey was presented",
@@ -1267,6 +1317,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The quota configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrAdminNoSuchInflightRequest: {
+		Code:           "XMinioAdminNoSuchInflightRequest",
+		Description:    "The specified request is not in progress",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1871,6 +1926,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrAdminGroupNotEmpty
 	case errNoSuchPolicy:
 		apiErr = ErrAdminNoSuchPolicy
+	case errNoSuchInflightRequest:
+		apiErr = ErrAdminNoSuchInflightRequest
 	case errSignatureMismatch:
 		apiErr = ErrSignatureDoesNotMatch
 	case errInvalidRange:
@@ -1912,6 +1969,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrIncompatibleEncryptionMethod
 	case errKMSNotConfigured:
 		apiErr = ErrKMSNotConfigured
+	case errKMSKeyNotAllowed:
+		apiErr = ErrKMSKeyNotAllowed
+	case kms.ErrUnavailable:
+		apiErr = ErrKMSUnavailable
 	case context.Canceled, context.DeadlineExceeded:
 		apiErr = ErrOperationTimedOut
 	case errDiskNotFound:
@@ -2022,6 +2083,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchLifecycleConfiguration
 	case BucketSSEConfigNotFound:
 		apiErr = ErrNoSuchBucketSSEConfig
+	case BucketPublicAccessBlockNotFound:
+		apiErr = ErrNoSuchPublicAccessBlockConfiguration
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
 	case BucketObjectLockConfigNotFound:
@@ -2073,12 +2136,16 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrFilterNameSuffix
 	case *event.ErrInvalidFilterValue:
 		apiErr = ErrFilterValueInvalid
+	case *event.ErrInvalidFilterCondition:
+		apiErr = ErrFilterConditionInvalid
 	case *event.ErrDuplicateEventName:
 		apiErr = ErrOverlappingConfigs
 	case *event.ErrDuplicateQueueConfiguration:
 		apiErr = ErrOverlappingFilterNotification
 	case *event.ErrUnsupportedConfiguration:
 		apiErr = ErrUnsupportedNotification
+	case *event.ErrUnsupportedSchemaVersion:
+		apiErr = ErrEventSchemaVersion
 	case OperationTimedOut:
 		apiErr = ErrOperationTimedOut
 	case BackendDown:
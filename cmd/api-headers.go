@@ -124,6 +124,15 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		}
 	}
 
+	// Expired restored copies are pending removal, the object is no
+	// longer reported as restored.
+	restoreExpired := !objInfo.RestoreExpires.IsZero() && !objInfo.RestoreExpires.After(UTCNow())
+
+	// Objects whose synchronous replication timed out report it.
+	if status, ok := objInfo.UserDefined[ReservedMetadataPrefixLower+ReplicationSyncStatus]; ok {
+		w.Header().Set(xhttp.MinIOReplicationSyncStatus, status)
+	}
+
 	// Set all other user defined metadata.
 	for k, v := range objInfo.UserDefined {
 		if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
@@ -132,6 +141,10 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 			continue
 		}
 
+		if restoreExpired && equals(k, xhttp.AmzRestore, xhttp.AmzRestoreExpiryDays, xhttp.AmzRestoreRequestDate) {
+			continue
+		}
+
 		// https://github.com/google/security-research/security/advisories/GHSA-76wf-9vgp-pj7w
 		if equals(k, xhttp.AmzMetaUnencryptedContentLength, xhttp.AmzMetaUnencryptedContentMD5) {
 			continue
@@ -184,6 +197,10 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		w.Header()[xhttp.AmzBucketReplicationStatus] = []string{objInfo.ReplicationStatus.String()}
 	}
 
+	if sites := objInfo.ReplicationStatusBySite(); len(sites) > 0 {
+		w.Header()[xhttp.MinIOReplicationSiteStatus] = []string{replicationSiteStatusHeader(sites)}
+	}
+
 	if objInfo.IsRemote() {
 		// Check if object is being restored. For more information on x-amz-restore header see
 		// https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadObject.html#API_HeadObject_ResponseSyntax
@@ -574,7 +574,7 @@ func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter,
 			case crypto.S3:
 				content.UserMetadata[xhttp.AmzServerSideEncryption] = xhttp.AmzEncryptionAES
 			case crypto.S3KMS:
-				content.UserMetadata[xhttp.AmzServerSideEncryption] = xhttp.AmzEncryptionKMS
+				content.UserMetadata[xhttp.AmzServerSideEncryption] = crypto.S3KMS.Algorithm(object.UserDefined)
 			case crypto.SSEC:
 				content.UserMetadata[xhttp.AmzServerSideEncryptionCustomerAlgorithm] = xhttp.AmzEncryptionAES
 			}
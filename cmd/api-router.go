@@ -162,11 +162,6 @@ var rejectedBucketAPIs = []rejectedAPI{
 		methods: []string{http.MethodDelete, http.MethodPut, http.MethodHead},
 		queries: []string{"acl", ""},
 	},
-	{
-		api:     "publicAccessBlock",
-		methods: []string{http.MethodDelete, http.MethodPut, http.MethodGet},
-		queries: []string{"publicAccessBlock", ""},
-	},
 	{
 		api:     "ownershipControls",
 		methods: []string{http.MethodDelete, http.MethodPut, http.MethodGet},
@@ -340,6 +335,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketNotification
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketnotification", maxClients(gz(httpTraceAll(api.GetBucketNotificationHandler))))).Queries("notification", "")
+		// ListenNotification over websocket
+		router.Methods(http.MethodGet).HeadersRegexp("Upgrade", "(?i)^websocket$").HandlerFunc(
+			collectAPIStats("listennotificationwebsocket", maxClients(httpTraceHdrs(api.ListenNotificationWebsocketHandler)))).Queries("events", "{events:.*}")
 		// ListenNotification
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("listennotification", maxClients(gz(httpTraceAll(api.ListenNotificationHandler))))).Queries("events", "{events:.*}")
@@ -391,6 +389,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketPolicyStatus
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getpolicystatus", maxClients(gz(httpTraceAll(api.GetBucketPolicyStatusHandler))))).Queries("policyStatus", "")
+		// GetPublicAccessBlock
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getpublicaccessblock", maxClients(gz(httpTraceAll(api.GetPublicAccessBlockHandler))))).Queries("publicAccessBlock", "")
 		// PutBucketLifecycle
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketlifecycle", maxClients(gz(httpTraceAll(api.PutBucketLifecycleHandler))))).Queries("lifecycle", "")
@@ -404,6 +405,9 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketPolicy
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketpolicy", maxClients(gz(httpTraceAll(api.PutBucketPolicyHandler))))).Queries("policy", "")
+		// PutPublicAccessBlock
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putpublicaccessblock", maxClients(gz(httpTraceAll(api.PutPublicAccessBlockHandler))))).Queries("publicAccessBlock", "")
 
 		// PutBucketObjectLockConfig
 		router.Methods(http.MethodPut).HandlerFunc(
@@ -435,6 +439,9 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketPolicy
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketpolicy", maxClients(gz(httpTraceAll(api.DeleteBucketPolicyHandler))))).Queries("policy", "")
+		// DeletePublicAccessBlock
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletepublicaccessblock", maxClients(gz(httpTraceAll(api.DeletePublicAccessBlockHandler))))).Queries("publicAccessBlock", "")
 		// DeleteBucketReplication
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketreplicationconfiguration", maxClients(gz(httpTraceAll(api.DeleteBucketReplicationConfigHandler))))).Queries("replication", "")
@@ -468,6 +475,10 @@ func registerAPIRouter(router *mux.Router) {
 
 	// Root operation
 
+	// ListenNotification over websocket
+	apiRouter.Methods(http.MethodGet).Path(SlashSeparator).HeadersRegexp("Upgrade", "(?i)^websocket$").HandlerFunc(
+		collectAPIStats("listennotificationwebsocket", maxClients(httpTraceHdrs(api.ListenNotificationWebsocketHandler)))).Queries("events", "{events:.*}")
+
 	// ListenNotification
 	apiRouter.Methods(http.MethodGet).Path(SlashSeparator).HandlerFunc(
 		collectAPIStats("listennotification", maxClients(gz(httpTraceAll(api.ListenNotificationHandler))))).Queries("events", "{events:.*}")
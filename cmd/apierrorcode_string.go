@@ -47,258 +47,267 @@ func _() {
 	_ = x[ErrNoSuchLifecycleConfiguration-36]
 	_ = x[ErrInvalidLifecycleWithObjectLock-37]
 	_ = x[ErrNoSuchBucketSSEConfig-38]
-	_ = x[ErrNoSuchCORSConfiguration-39]
-	_ = x[ErrNoSuchWebsiteConfiguration-40]
-	_ = x[ErrReplicationConfigurationNotFoundError-41]
-	_ = x[ErrRemoteDestinationNotFoundError-42]
-	_ = x[ErrReplicationDestinationMissingLock-43]
-	_ = x[ErrRemoteTargetNotFoundError-44]
-	_ = x[ErrReplicationRemoteConnectionError-45]
-	_ = x[ErrReplicationBandwidthLimitError-46]
-	_ = x[ErrBucketRemoteIdenticalToSource-47]
-	_ = x[ErrBucketRemoteAlreadyExists-48]
-	_ = x[ErrBucketRemoteLabelInUse-49]
-	_ = x[ErrBucketRemoteArnTypeInvalid-50]
-	_ = x[ErrBucketRemoteArnInvalid-51]
-	_ = x[ErrBucketRemoteRemoveDisallowed-52]
-	_ = x[ErrRemoteTargetNotVersionedError-53]
-	_ = x[ErrReplicationSourceNotVersionedError-54]
-	_ = x[ErrReplicationNeedsVersioningError-55]
-	_ = x[ErrReplicationBucketNeedsVersioningError-56]
-	_ = x[ErrReplicationDenyEditError-57]
-	_ = x[ErrReplicationNoMatchingRuleError-58]
-	_ = x[ErrObjectRestoreAlreadyInProgress-59]
-	_ = x[ErrNoSuchKey-60]
-	_ = x[ErrNoSuchUpload-61]
-	_ = x[ErrInvalidVersionID-62]
-	_ = x[ErrNoSuchVersion-63]
-	_ = x[ErrNotImplemented-64]
-	_ = x[ErrPreconditionFailed-65]
-	_ = x[ErrRequestTimeTooSkewed-66]
-	_ = x[ErrSignatureDoesNotMatch-67]
-	_ = x[ErrMethodNotAllowed-68]
-	_ = x[ErrInvalidPart-69]
-	_ = x[ErrInvalidPartOrder-70]
-	_ = x[ErrAuthorizationHeaderMalformed-71]
-	_ = x[ErrMalformedPOSTRequest-72]
-	_ = x[ErrPOSTFileRequired-73]
-	_ = x[ErrSignatureVersionNotSupported-74]
-	_ = x[ErrBucketNotEmpty-75]
-	_ = x[ErrAllAccessDisabled-76]
-	_ = x[ErrMalformedPolicy-77]
-	_ = x[ErrMissingFields-78]
-	_ = x[ErrMissingCredTag-79]
-	_ = x[ErrCredMalformed-80]
-	_ = x[ErrInvalidRegion-81]
-	_ = x[ErrInvalidServiceS3-82]
-	_ = x[ErrInvalidServiceSTS-83]
-	_ = x[ErrInvalidRequestVersion-84]
-	_ = x[ErrMissingSignTag-85]
-	_ = x[ErrMissingSignHeadersTag-86]
-	_ = x[ErrMalformedDate-87]
-	_ = x[ErrMalformedPresignedDate-88]
-	_ = x[ErrMalformedCredentialDate-89]
-	_ = x[ErrMalformedCredentialRegion-90]
-	_ = x[ErrMalformedExpires-91]
-	_ = x[ErrNegativeExpires-92]
-	_ = x[ErrAuthHeaderEmpty-93]
-	_ = x[ErrExpiredPresignRequest-94]
-	_ = x[ErrRequestNotReadyYet-95]
-	_ = x[ErrUnsignedHeaders-96]
-	_ = x[ErrMissingDateHeader-97]
-	_ = x[ErrInvalidQuerySignatureAlgo-98]
-	_ = x[ErrInvalidQueryParams-99]
-	_ = x[ErrBucketAlreadyOwnedByYou-100]
-	_ = x[ErrInvalidDuration-101]
-	_ = x[ErrBucketAlreadyExists-102]
-	_ = x[ErrMetadataTooLarge-103]
-	_ = x[ErrUnsupportedMetadata-104]
-	_ = x[ErrMaximumExpires-105]
-	_ = x[ErrSlowDown-106]
-	_ = x[ErrInvalidPrefixMarker-107]
-	_ = x[ErrBadRequest-108]
-	_ = x[ErrKeyTooLongError-109]
-	_ = x[ErrInvalidBucketObjectLockConfiguration-110]
-	_ = x[ErrObjectLockConfigurationNotFound-111]
-	_ = x[ErrObjectLockConfigurationNotAllowed-112]
-	_ = x[ErrNoSuchObjectLockConfiguration-113]
-	_ = x[ErrObjectLocked-114]
-	_ = x[ErrInvalidRetentionDate-115]
-	_ = x[ErrPastObjectLockRetainDate-116]
-	_ = x[ErrUnknownWORMModeDirective-117]
-	_ = x[ErrBucketTaggingNotFound-118]
-	_ = x[ErrObjectLockInvalidHeaders-119]
-	_ = x[ErrInvalidTagDirective-120]
-	_ = x[ErrInvalidEncryptionMethod-121]
-	_ = x[ErrInsecureSSECustomerRequest-122]
-	_ = x[ErrSSEMultipartEncrypted-123]
-	_ = x[ErrSSEEncryptedObject-124]
-	_ = x[ErrInvalidEncryptionParameters-125]
-	_ = x[ErrInvalidSSECustomerAlgorithm-126]
-	_ = x[ErrInvalidSSECustomerKey-127]
-	_ = x[ErrMissingSSECustomerKey-128]
-	_ = x[ErrMissingSSECustomerKeyMD5-129]
-	_ = x[ErrSSECustomerKeyMD5Mismatch-130]
-	_ = x[ErrInvalidSSECustomerParameters-131]
-	_ = x[ErrIncompatibleEncryptionMethod-132]
-	_ = x[ErrKMSNotConfigured-133]
-	_ = x[ErrNoAccessKey-134]
-	_ = x[ErrInvalidToken-135]
-	_ = x[ErrEventNotification-136]
-	_ = x[ErrARNNotification-137]
-	_ = x[ErrRegionNotification-138]
-	_ = x[ErrOverlappingFilterNotification-139]
-	_ = x[ErrFilterNameInvalid-140]
-	_ = x[ErrFilterNamePrefix-141]
-	_ = x[ErrFilterNameSuffix-142]
-	_ = x[ErrFilterValueInvalid-143]
-	_ = x[ErrOverlappingConfigs-144]
-	_ = x[ErrUnsupportedNotification-145]
-	_ = x[ErrContentSHA256Mismatch-146]
-	_ = x[ErrReadQuorum-147]
-	_ = x[ErrWriteQuorum-148]
-	_ = x[ErrStorageFull-149]
-	_ = x[ErrRequestBodyParse-150]
-	_ = x[ErrObjectExistsAsDirectory-151]
-	_ = x[ErrInvalidObjectName-152]
-	_ = x[ErrInvalidObjectNamePrefixSlash-153]
-	_ = x[ErrInvalidResourceName-154]
-	_ = x[ErrServerNotInitialized-155]
-	_ = x[ErrOperationTimedOut-156]
-	_ = x[ErrClientDisconnected-157]
-	_ = x[ErrOperationMaxedOut-158]
-	_ = x[ErrInvalidRequest-159]
-	_ = x[ErrTransitionStorageClassNotFoundError-160]
-	_ = x[ErrInvalidStorageClass-161]
-	_ = x[ErrBackendDown-162]
-	_ = x[ErrMalformedJSON-163]
-	_ = x[ErrAdminNoSuchUser-164]
-	_ = x[ErrAdminNoSuchGroup-165]
-	_ = x[ErrAdminGroupNotEmpty-166]
-	_ = x[ErrAdminNoSuchPolicy-167]
-	_ = x[ErrAdminInvalidArgument-168]
-	_ = x[ErrAdminInvalidAccessKey-169]
-	_ = x[ErrAdminInvalidSecretKey-170]
-	_ = x[ErrAdminConfigNoQuorum-171]
-	_ = x[ErrAdminConfigTooLarge-172]
-	_ = x[ErrAdminConfigBadJSON-173]
-	_ = x[ErrAdminConfigDuplicateKeys-174]
-	_ = x[ErrAdminCredentialsMismatch-175]
-	_ = x[ErrInsecureClientRequest-176]
-	_ = x[ErrObjectTampered-177]
-	_ = x[ErrSiteReplicationInvalidRequest-178]
-	_ = x[ErrSiteReplicationPeerResp-179]
-	_ = x[ErrSiteReplicationBackendIssue-180]
-	_ = x[ErrSiteReplicationServiceAccountError-181]
-	_ = x[ErrSiteReplicationBucketConfigError-182]
-	_ = x[ErrSiteReplicationBucketMetaError-183]
-	_ = x[ErrSiteReplicationIAMError-184]
-	_ = x[ErrAdminBucketQuotaExceeded-185]
-	_ = x[ErrAdminNoSuchQuotaConfiguration-186]
-	_ = x[ErrHealNotImplemented-187]
-	_ = x[ErrHealNoSuchProcess-188]
-	_ = x[ErrHealInvalidClientToken-189]
-	_ = x[ErrHealMissingBucket-190]
-	_ = x[ErrHealAlreadyRunning-191]
-	_ = x[ErrHealOverlappingPaths-192]
-	_ = x[ErrIncorrectContinuationToken-193]
-	_ = x[ErrEmptyRequestBody-194]
-	_ = x[ErrUnsupportedFunction-195]
-	_ = x[ErrInvalidExpressionType-196]
-	_ = x[ErrBusy-197]
-	_ = x[ErrUnauthorizedAccess-198]
-	_ = x[ErrExpressionTooLong-199]
-	_ = x[ErrIllegalSQLFunctionArgument-200]
-	_ = x[ErrInvalidKeyPath-201]
-	_ = x[ErrInvalidCompressionFormat-202]
-	_ = x[ErrInvalidFileHeaderInfo-203]
-	_ = x[ErrInvalidJSONType-204]
-	_ = x[ErrInvalidQuoteFields-205]
-	_ = x[ErrInvalidRequestParameter-206]
-	_ = x[ErrInvalidDataType-207]
-	_ = x[ErrInvalidTextEncoding-208]
-	_ = x[ErrInvalidDataSource-209]
-	_ = x[ErrInvalidTableAlias-210]
-	_ = x[ErrMissingRequiredParameter-211]
-	_ = x[ErrObjectSerializationConflict-212]
-	_ = x[ErrUnsupportedSQLOperation-213]
-	_ = x[ErrUnsupportedSQLStructure-214]
-	_ = x[ErrUnsupportedSyntax-215]
-	_ = x[ErrUnsupportedRangeHeader-216]
-	_ = x[ErrLexerInvalidChar-217]
-	_ = x[ErrLexerInvalidOperator-218]
-	_ = x[ErrLexerInvalidLiteral-219]
-	_ = x[ErrLexerInvalidIONLiteral-220]
-	_ = x[ErrParseExpectedDatePart-221]
-	_ = x[ErrParseExpectedKeyword-222]
-	_ = x[ErrParseExpectedTokenType-223]
-	_ = x[ErrParseExpected2TokenTypes-224]
-	_ = x[ErrParseExpectedNumber-225]
-	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-226]
-	_ = x[ErrParseExpectedTypeName-227]
-	_ = x[ErrParseExpectedWhenClause-228]
-	_ = x[ErrParseUnsupportedToken-229]
-	_ = x[ErrParseUnsupportedLiteralsGroupBy-230]
-	_ = x[ErrParseExpectedMember-231]
-	_ = x[ErrParseUnsupportedSelect-232]
-	_ = x[ErrParseUnsupportedCase-233]
-	_ = x[ErrParseUnsupportedCaseClause-234]
-	_ = x[ErrParseUnsupportedAlias-235]
-	_ = x[ErrParseUnsupportedSyntax-236]
-	_ = x[ErrParseUnknownOperator-237]
-	_ = x[ErrParseMissingIdentAfterAt-238]
-	_ = x[ErrParseUnexpectedOperator-239]
-	_ = x[ErrParseUnexpectedTerm-240]
-	_ = x[ErrParseUnexpectedToken-241]
-	_ = x[ErrParseUnexpectedKeyword-242]
-	_ = x[ErrParseExpectedExpression-243]
-	_ = x[ErrParseExpectedLeftParenAfterCast-244]
-	_ = x[ErrParseExpectedLeftParenValueConstructor-245]
-	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-246]
-	_ = x[ErrParseExpectedArgumentDelimiter-247]
-	_ = x[ErrParseCastArity-248]
-	_ = x[ErrParseInvalidTypeParam-249]
-	_ = x[ErrParseEmptySelect-250]
-	_ = x[ErrParseSelectMissingFrom-251]
-	_ = x[ErrParseExpectedIdentForGroupName-252]
-	_ = x[ErrParseExpectedIdentForAlias-253]
-	_ = x[ErrParseUnsupportedCallWithStar-254]
-	_ = x[ErrParseNonUnaryAgregateFunctionCall-255]
-	_ = x[ErrParseMalformedJoin-256]
-	_ = x[ErrParseExpectedIdentForAt-257]
-	_ = x[ErrParseAsteriskIsNotAloneInSelectList-258]
-	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-259]
-	_ = x[ErrParseInvalidContextForWildcardInSelectList-260]
-	_ = x[ErrIncorrectSQLFunctionArgumentType-261]
-	_ = x[ErrValueParseFailure-262]
-	_ = x[ErrEvaluatorInvalidArguments-263]
-	_ = x[ErrIntegerOverflow-264]
-	_ = x[ErrLikeInvalidInputs-265]
-	_ = x[ErrCastFailed-266]
-	_ = x[ErrInvalidCast-267]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-268]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-269]
-	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-270]
-	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-271]
-	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-272]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-273]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-274]
-	_ = x[ErrEvaluatorBindingDoesNotExist-275]
-	_ = x[ErrMissingHeaders-276]
-	_ = x[ErrInvalidColumnIndex-277]
-	_ = x[ErrAdminConfigNotificationTargetsFailed-278]
-	_ = x[ErrAdminProfilerNotEnabled-279]
-	_ = x[ErrInvalidDecompressedSize-280]
-	_ = x[ErrAddUserInvalidArgument-281]
-	_ = x[ErrAdminAccountNotEligible-282]
-	_ = x[ErrAccountNotEligible-283]
-	_ = x[ErrAdminServiceAccountNotFound-284]
-	_ = x[ErrPostPolicyConditionInvalidFormat-285]
+	_ = x[ErrNoSuchPublicAccessBlockConfiguration-39]
+	_ = x[ErrPublicPolicyBlocked-40]
+	_ = x[ErrPublicACLBlocked-41]
+	_ = x[ErrNoSuchCORSConfiguration-42]
+	_ = x[ErrNoSuchWebsiteConfiguration-43]
+	_ = x[ErrReplicationConfigurationNotFoundError-44]
+	_ = x[ErrRemoteDestinationNotFoundError-45]
+	_ = x[ErrReplicationDestinationMissingLock-46]
+	_ = x[ErrRemoteTargetNotFoundError-47]
+	_ = x[ErrReplicationRemoteConnectionError-48]
+	_ = x[ErrReplicationBandwidthLimitError-49]
+	_ = x[ErrReplicationRemoteSTSConfigError-50]
+	_ = x[ErrBucketRemoteIdenticalToSource-51]
+	_ = x[ErrBucketRemoteAlreadyExists-52]
+	_ = x[ErrBucketRemoteLabelInUse-53]
+	_ = x[ErrBucketRemoteArnTypeInvalid-54]
+	_ = x[ErrBucketRemoteArnInvalid-55]
+	_ = x[ErrBucketRemoteRemoveDisallowed-56]
+	_ = x[ErrRemoteTargetNotVersionedError-57]
+	_ = x[ErrReplicationSourceNotVersionedError-58]
+	_ = x[ErrReplicationNeedsVersioningError-59]
+	_ = x[ErrReplicationBucketNeedsVersioningError-60]
+	_ = x[ErrReplicationDenyEditError-61]
+	_ = x[ErrReplicationNoMatchingRuleError-62]
+	_ = x[ErrObjectRestoreAlreadyInProgress-63]
+	_ = x[ErrNoSuchKey-64]
+	_ = x[ErrNoSuchUpload-65]
+	_ = x[ErrInvalidVersionID-66]
+	_ = x[ErrNoSuchVersion-67]
+	_ = x[ErrNotImplemented-68]
+	_ = x[ErrPreconditionFailed-69]
+	_ = x[ErrRequestTimeTooSkewed-70]
+	_ = x[ErrSignatureDoesNotMatch-71]
+	_ = x[ErrMethodNotAllowed-72]
+	_ = x[ErrInvalidPart-73]
+	_ = x[ErrInvalidPartOrder-74]
+	_ = x[ErrAuthorizationHeaderMalformed-75]
+	_ = x[ErrMalformedPOSTRequest-76]
+	_ = x[ErrPOSTFileRequired-77]
+	_ = x[ErrSignatureVersionNotSupported-78]
+	_ = x[ErrBucketNotEmpty-79]
+	_ = x[ErrAllAccessDisabled-80]
+	_ = x[ErrMalformedPolicy-81]
+	_ = x[ErrMissingFields-82]
+	_ = x[ErrMissingCredTag-83]
+	_ = x[ErrCredMalformed-84]
+	_ = x[ErrInvalidRegion-85]
+	_ = x[ErrInvalidServiceS3-86]
+	_ = x[ErrInvalidServiceSTS-87]
+	_ = x[ErrInvalidRequestVersion-88]
+	_ = x[ErrMissingSignTag-89]
+	_ = x[ErrMissingSignHeadersTag-90]
+	_ = x[ErrMalformedDate-91]
+	_ = x[ErrMalformedPresignedDate-92]
+	_ = x[ErrMalformedCredentialDate-93]
+	_ = x[ErrMalformedCredentialRegion-94]
+	_ = x[ErrMalformedExpires-95]
+	_ = x[ErrNegativeExpires-96]
+	_ = x[ErrAuthHeaderEmpty-97]
+	_ = x[ErrExpiredPresignRequest-98]
+	_ = x[ErrRequestNotReadyYet-99]
+	_ = x[ErrUnsignedHeaders-100]
+	_ = x[ErrMissingDateHeader-101]
+	_ = x[ErrInvalidQuerySignatureAlgo-102]
+	_ = x[ErrInvalidQueryParams-103]
+	_ = x[ErrBucketAlreadyOwnedByYou-104]
+	_ = x[ErrInvalidDuration-105]
+	_ = x[ErrBucketAlreadyExists-106]
+	_ = x[ErrMetadataTooLarge-107]
+	_ = x[ErrUnsupportedMetadata-108]
+	_ = x[ErrMaximumExpires-109]
+	_ = x[ErrSlowDown-110]
+	_ = x[ErrInvalidPrefixMarker-111]
+	_ = x[ErrBadRequest-112]
+	_ = x[ErrKeyTooLongError-113]
+	_ = x[ErrInvalidBucketObjectLockConfiguration-114]
+	_ = x[ErrObjectLockConfigurationNotFound-115]
+	_ = x[ErrObjectLockConfigurationNotAllowed-116]
+	_ = x[ErrNoSuchObjectLockConfiguration-117]
+	_ = x[ErrObjectLocked-118]
+	_ = x[ErrInvalidRetentionDate-119]
+	_ = x[ErrPastObjectLockRetainDate-120]
+	_ = x[ErrUnknownWORMModeDirective-121]
+	_ = x[ErrBucketTaggingNotFound-122]
+	_ = x[ErrObjectLockInvalidHeaders-123]
+	_ = x[ErrInvalidTagDirective-124]
+	_ = x[ErrInvalidEncryptionMethod-125]
+	_ = x[ErrInsecureSSECustomerRequest-126]
+	_ = x[ErrSSEMultipartEncrypted-127]
+	_ = x[ErrSSEEncryptedObject-128]
+	_ = x[ErrInvalidEncryptionParameters-129]
+	_ = x[ErrInvalidSSECustomerAlgorithm-130]
+	_ = x[ErrInvalidSSECustomerKey-131]
+	_ = x[ErrMissingSSECustomerKey-132]
+	_ = x[ErrMissingSSECustomerKeyMD5-133]
+	_ = x[ErrSSECustomerKeyMD5Mismatch-134]
+	_ = x[ErrInvalidSSECustomerParameters-135]
+	_ = x[ErrIncompatibleEncryptionMethod-136]
+	_ = x[ErrKMSNotConfigured-137]
+	_ = x[ErrKMSKeyNotAllowed-138]
+	_ = x[ErrKMSUnavailable-139]
+	_ = x[ErrNoAccessKey-140]
+	_ = x[ErrInvalidToken-141]
+	_ = x[ErrEventNotification-142]
+	_ = x[ErrARNNotification-143]
+	_ = x[ErrRegionNotification-144]
+	_ = x[ErrOverlappingFilterNotification-145]
+	_ = x[ErrFilterNameInvalid-146]
+	_ = x[ErrFilterNamePrefix-147]
+	_ = x[ErrFilterNameSuffix-148]
+	_ = x[ErrFilterValueInvalid-149]
+	_ = x[ErrFilterConditionInvalid-150]
+	_ = x[ErrOverlappingConfigs-151]
+	_ = x[ErrUnsupportedNotification-152]
+	_ = x[ErrEventSchemaVersion-153]
+	_ = x[ErrContentSHA256Mismatch-154]
+	_ = x[ErrReadQuorum-155]
+	_ = x[ErrWriteQuorum-156]
+	_ = x[ErrStorageFull-157]
+	_ = x[ErrRequestBodyParse-158]
+	_ = x[ErrObjectExistsAsDirectory-159]
+	_ = x[ErrInvalidObjectName-160]
+	_ = x[ErrInvalidObjectNamePrefixSlash-161]
+	_ = x[ErrInvalidResourceName-162]
+	_ = x[ErrServerNotInitialized-163]
+	_ = x[ErrOperationTimedOut-164]
+	_ = x[ErrClientDisconnected-165]
+	_ = x[ErrOperationMaxedOut-166]
+	_ = x[ErrInvalidRequest-167]
+	_ = x[ErrTransitionStorageClassNotFoundError-168]
+	_ = x[ErrInvalidStorageClass-169]
+	_ = x[ErrBackendDown-170]
+	_ = x[ErrMalformedJSON-171]
+	_ = x[ErrAdminNoSuchUser-172]
+	_ = x[ErrAdminNoSuchGroup-173]
+	_ = x[ErrAdminGroupNotEmpty-174]
+	_ = x[ErrAdminNoSuchPolicy-175]
+	_ = x[ErrAdminInvalidArgument-176]
+	_ = x[ErrAdminInvalidAccessKey-177]
+	_ = x[ErrAdminInvalidSecretKey-178]
+	_ = x[ErrAdminConfigNoQuorum-179]
+	_ = x[ErrAdminConfigTooLarge-180]
+	_ = x[ErrAdminConfigBadJSON-181]
+	_ = x[ErrAdminConfigDuplicateKeys-182]
+	_ = x[ErrAdminCredentialsMismatch-183]
+	_ = x[ErrInsecureClientRequest-184]
+	_ = x[ErrObjectTampered-185]
+	_ = x[ErrSiteReplicationInvalidRequest-186]
+	_ = x[ErrSiteReplicationPeerResp-187]
+	_ = x[ErrSiteReplicationBackendIssue-188]
+	_ = x[ErrSiteReplicationServiceAccountError-189]
+	_ = x[ErrSiteReplicationBucketConfigError-190]
+	_ = x[ErrSiteReplicationBucketMetaError-191]
+	_ = x[ErrSiteReplicationIAMError-192]
+	_ = x[ErrAdminBucketQuotaExceeded-193]
+	_ = x[ErrAdminNoSuchQuotaConfiguration-194]
+	_ = x[ErrAdminNoSuchInflightRequest-195]
+	_ = x[ErrHealNotImplemented-196]
+	_ = x[ErrHealNoSuchProcess-197]
+	_ = x[ErrHealInvalidClientToken-198]
+	_ = x[ErrHealMissingBucket-199]
+	_ = x[ErrHealAlreadyRunning-200]
+	_ = x[ErrHealOverlappingPaths-201]
+	_ = x[ErrIncorrectContinuationToken-202]
+	_ = x[ErrEmptyRequestBody-203]
+	_ = x[ErrUnsupportedFunction-204]
+	_ = x[ErrInvalidExpressionType-205]
+	_ = x[ErrBusy-206]
+	_ = x[ErrUnauthorizedAccess-207]
+	_ = x[ErrExpressionTooLong-208]
+	_ = x[ErrIllegalSQLFunctionArgument-209]
+	_ = x[ErrInvalidKeyPath-210]
+	_ = x[ErrInvalidCompressionFormat-211]
+	_ = x[ErrInvalidFileHeaderInfo-212]
+	_ = x[ErrInvalidJSONType-213]
+	_ = x[ErrInvalidQuoteFields-214]
+	_ = x[ErrInvalidRequestParameter-215]
+	_ = x[ErrInvalidDataType-216]
+	_ = x[ErrInvalidTextEncoding-217]
+	_ = x[ErrInvalidDataSource-218]
+	_ = x[ErrInvalidTableAlias-219]
+	_ = x[ErrMissingRequiredParameter-220]
+	_ = x[ErrObjectSerializationConflict-221]
+	_ = x[ErrUnsupportedSQLOperation-222]
+	_ = x[ErrUnsupportedSQLStructure-223]
+	_ = x[ErrUnsupportedSyntax-224]
+	_ = x[ErrUnsupportedRangeHeader-225]
+	_ = x[ErrLexerInvalidChar-226]
+	_ = x[ErrLexerInvalidOperator-227]
+	_ = x[ErrLexerInvalidLiteral-228]
+	_ = x[ErrLexerInvalidIONLiteral-229]
+	_ = x[ErrParseExpectedDatePart-230]
+	_ = x[ErrParseExpectedKeyword-231]
+	_ = x[ErrParseExpectedTokenType-232]
+	_ = x[ErrParseExpected2TokenTypes-233]
+	_ = x[ErrParseExpectedNumber-234]
+	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-235]
+	_ = x[ErrParseExpectedTypeName-236]
+	_ = x[ErrParseExpectedWhenClause-237]
+	_ = x[ErrParseUnsupportedToken-238]
+	_ = x[ErrParseUnsupportedLiteralsGroupBy-239]
+	_ = x[ErrParseExpectedMember-240]
+	_ = x[ErrParseUnsupportedSelect-241]
+	_ = x[ErrParseUnsupportedCase-242]
+	_ = x[ErrParseUnsupportedCaseClause-243]
+	_ = x[ErrParseUnsupportedAlias-244]
+	_ = x[ErrParseUnsupportedSyntax-245]
+	_ = x[ErrParseUnknownOperator-246]
+	_ = x[ErrParseMissingIdentAfterAt-247]
+	_ = x[ErrParseUnexpectedOperator-248]
+	_ = x[ErrParseUnexpectedTerm-249]
+	_ = x[ErrParseUnexpectedToken-250]
+	_ = x[ErrParseUnexpectedKeyword-251]
+	_ = x[ErrParseExpectedExpression-252]
+	_ = x[ErrParseExpectedLeftParenAfterCast-253]
+	_ = x[ErrParseExpectedLeftParenValueConstructor-254]
+	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-255]
+	_ = x[ErrParseExpectedArgumentDelimiter-256]
+	_ = x[ErrParseCastArity-257]
+	_ = x[ErrParseInvalidTypeParam-258]
+	_ = x[ErrParseEmptySelect-259]
+	_ = x[ErrParseSelectMissingFrom-260]
+	_ = x[ErrParseExpectedIdentForGroupName-261]
+	_ = x[ErrParseExpectedIdentForAlias-262]
+	_ = x[ErrParseUnsupportedCallWithStar-263]
+	_ = x[ErrParseNonUnaryAgregateFunctionCall-264]
+	_ = x[ErrParseMalformedJoin-265]
+	_ = x[ErrParseExpectedIdentForAt-266]
+	_ = x[ErrParseAsteriskIsNotAloneInSelectList-267]
+	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-268]
+	_ = x[ErrParseInvalidContextForWildcardInSelectList-269]
+	_ = x[ErrIncorrectSQLFunctionArgumentType-270]
+	_ = x[ErrValueParseFailure-271]
+	_ = x[ErrEvaluatorInvalidArguments-272]
+	_ = x[ErrIntegerOverflow-273]
+	_ = x[ErrLikeInvalidInputs-274]
+	_ = x[ErrCastFailed-275]
+	_ = x[ErrInvalidCast-276]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-277]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-278]
+	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-279]
+	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-280]
+	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-281]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-282]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-283]
+	_ = x[ErrEvaluatorBindingDoesNotExist-284]
+	_ = x[ErrMissingHeaders-285]
+	_ = x[ErrInvalidColumnIndex-286]
+	_ = x[ErrAdminConfigNotificationTargetsFailed-287]
+	_ = x[ErrAdminProfilerNotEnabled-288]
+	_ = x[ErrInvalidDecompressedSize-289]
+	_ = x[ErrAddUserInvalidArgument-290]
+	_ = x[ErrAdminAccountNotEligible-291]
+	_ = x[ErrAccountNotEligible-292]
+	_ = x[ErrAdminServiceAccountNotFound-293]
+	_ = x[ErrPostPolicyConditionInvalidFormat-294]
 }
 
-const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
+const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchPublicAccessBlockConfigurationPublicPolicyBlockedPublicACLBlockedNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorReplicationRemoteSTSConfigErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredKMSKeyNotAllowedKMSUnavailableNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidFilterConditionInvalidOverlappingConfigsUnsupportedNotificationEventSchemaVersionContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationAdminNoSuchInflightRequestHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
 
-var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 146, 159, 171, 193, 213, 239, 253, 274, 291, 306, 329, 346, 364, 381, 405, 420, 441, 459, 471, 491, 508, 531, 552, 564, 582, 603, 631, 661, 682, 705, 731, 768, 798, 831, 856, 888, 918, 947, 972, 994, 1020, 1042, 1070, 1099, 1133, 1164, 1201, 1225, 1255, 1285, 1294, 1306, 1322, 1335, 1349, 1367, 1387, 1408, 1424, 1435, 1451, 1479, 1499, 1515, 1543, 1557, 1574, 1589, 1602, 1616, 1629, 1642, 1658, 1675, 1696, 1710, 1731, 1744, 1766, 1789, 1814, 1830, 1845, 1860, 1881, 1899, 1914, 1931, 1956, 1974, 1997, 2012, 2031, 2047, 2066, 2080, 2088, 2107, 2117, 2132, 2168, 2199, 2232, 2261, 2273, 2293, 2317, 2341, 2362, 2386, 2405, 2428, 2454, 2475, 2493, 2520, 2547, 2568, 2589, 2613, 2638, 2666, 2694, 2710, 2721, 2733, 2750, 2765, 2783, 2812, 2829, 2845, 2861, 2879, 2897, 2920, 2941, 2951, 2962, 2973, 2989, 3012, 3029, 3057, 3076, 3096, 3113, 3131, 3148, 3162, 3197, 3216, 3227, 3240, 3255, 3271, 3289, 3306, 3326, 3347, 3368, 3387, 3406, 3424, 3448, 3472, 3493, 3507, 3536, 3559, 3586, 3620, 3652, 3682, 3705, 3729, 3758, 3776, 3793, 3815, 3832, 3850, 3870, 3896, 3912, 3931, 3952, 3956, 3974, 3991, 4017, 4031, 4055, 4076, 4091, 4109, 4132, 4147, 4166, 4183, 4200, 4224, 4251, 4274, 4297, 4314, 4336, 4352, 4372, 4391, 4413, 4434, 4454, 4476, 4500, 4519, 4561, 4582, 4605, 4626, 4657, 4676, 4698, 4718, 4744, 4765, 4787, 4807, 4831, 4854, 4873, 4893, 4915, 4938, 4969, 5007, 5048, 5078, 5092, 5113, 5129, 5151, 5181, 5207, 5235, 5268, 5286, 5309, 5344, 5384, 5426, 5458, 5475, 5500, 5515, 5532, 5542, 5553, 5591, 5645, 5691, 5743, 5791, 5834, 5878, 5906, 5920, 5938, 5974, 5997, 6020, 6042, 6065, 6083, 6110, 6142}
+var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 146, 159, 171, 193, 213, 239, 253, 274, 291, 306, 329, 346, 364, 381, 405, 420, 441, 459, 471, 491, 508, 531, 552, 564, 582, 603, 631, 661, 682, 718, 737, 753, 776, 802, 839, 869, 902, 927, 959, 989, 1020, 1049, 1074, 1096, 1122, 1144, 1172, 1201, 1235, 1266, 1303, 1327, 1357, 1387, 1396, 1408, 1424, 1437, 1451, 1469, 1489, 1510, 1526, 1537, 1553, 1581, 1601, 1617, 1645, 1659, 1676, 1691, 1704, 1718, 1731, 1744, 1760, 1777, 1798, 1812, 1833, 1846, 1868, 1891, 1916, 1932, 1947, 1962, 1983, 2001, 2016, 2033, 2058, 2076, 2099, 2114, 2133, 2149, 2168, 2182, 2190, 2209, 2219, 2234, 2270, 2301, 2334, 2363, 2375, 2395, 2419, 2443, 2464, 2488, 2507, 2530, 2556, 2577, 2595, 2622, 2649, 2670, 2691, 2715, 2740, 2768, 2796, 2812, 2828, 2842, 2853, 2865, 2882, 2897, 2915, 2944, 2961, 2977, 2993, 3011, 3033, 3051, 3074, 3092, 3113, 3123, 3134, 3145, 3161, 3184, 3201, 3229, 3248, 3268, 3285, 3303, 3320, 3334, 3369, 3388, 3399, 3412, 3427, 3443, 3461, 3478, 3498, 3519, 3540, 3559, 3578, 3596, 3620, 3644, 3665, 3679, 3708, 3731, 3758, 3792, 3824, 3854, 3877, 3901, 3930, 3956, 3974, 3991, 4013, 4030, 4048, 4068, 4094, 4110, 4129, 4150, 4154, 4172, 4189, 4215, 4229, 4253, 4274, 4289, 4307, 4330, 4345, 4364, 4381, 4398, 4422, 4449, 4472, 4495, 4512, 4534, 4550, 4570, 4589, 4611, 4632, 4652, 4674, 4698, 4717, 4759, 4780, 4803, 4824, 4855, 4874, 4896, 4916, 4942, 4963, 4985, 5005, 5029, 5052, 5071, 5091, 5113, 5136, 5167, 5205, 5246, 5276, 5290, 5311, 5327, 5349, 5379, 5405, 5433, 5466, 5484, 5507, 5542, 5582, 5624, 5656, 5673, 5698, 5713, 5730, 5740, 5751, 5789, 5843, 5889, 5941, 5989, 6032, 6076, 6104, 6118, 6136, 6172, 6195, 6218, 6240, 6263, 6281, 6308, 6340}
 
 func (i APIErrorCode) String() string {
 	if i < 0 || i >= APIErrorCode(len(_APIErrorCode_index)-1) {
@@ -22,6 +22,7 @@ import (
 	"runtime"
 
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/config/limits"
 )
 
 // healTask represents what to heal along with options
@@ -85,8 +86,13 @@ func (h *healRoutine) AddWorker(ctx context.Context, objAPI ObjectLayer) {
 
 			var res madmin.HealResultItem
 			var err error
+			release, ok := globalResourceLimits.acquire(ctx, limits.Heal)
+			if !ok {
+				return
+			}
 			switch task.bucket {
 			case nopHeal:
+				release()
 				task.respCh <- healResult{err: errSkipFile}
 				continue
 			case SlashSeparator:
@@ -98,6 +104,7 @@ func (h *healRoutine) AddWorker(ctx context.Context, objAPI ObjectLayer) {
 					res, err = objAPI.HealObject(ctx, task.bucket, task.object, task.versionID, task.opts)
 				}
 			}
+			release()
 
 			task.respCh <- healResult{result: res, err: err}
 		case <-ctx.Done():
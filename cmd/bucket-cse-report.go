@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/minio/minio/internal/crypto"
+)
+
+// cseReportObject is a client-side encrypted object version.
+type cseReportObject struct {
+	Name      string `json:"name"`
+	VersionID string `json:"versionId,omitempty"`
+	Size      int64  `json:"size"`
+	crypto.CSEInfo
+	// Server-side encryption applied on top, if any.
+	SSE string `json:"sse,omitempty"`
+	// Transitioned to a remote tier.
+	Tier string `json:"tier,omitempty"`
+}
+
+// cseReportCount is the number and size of client-side encrypted
+// object versions.
+type cseReportCount struct {
+	Versions int64 `json:"versions"`
+	Bytes    int64 `json:"bytes"`
+}
+
+func (c *cseReportCount) add(oi ObjectInfo) {
+	c.Versions++
+	c.Bytes += oi.Size
+}
+
+// cseReport lists the client-side encrypted object versions of a bucket.
+type cseReport struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	versionScan
+	// Client-side encrypted versions, by envelope version of the
+	// encryption client.
+	V1 cseReportCount `json:"v1"`
+	V2 cseReportCount `json:"v2"`
+	// The first client-side encrypted versions, up to the maximum
+	// number of objects requested.
+	Objects []cseReportObject `json:"objects"`
+}
+
+type cseReportOpts struct {
+	prefix      string
+	maxVersions int64
+	maxObjects  int
+}
+
+func (report *cseReport) add(oi ObjectInfo, maxObjects int) {
+	info, ok := crypto.ParseClientSideEncryption(oi.UserDefined)
+	if !ok {
+		return
+	}
+	if info.Version == 1 {
+		report.V1.add(oi)
+	} else {
+		report.V2.add(oi)
+	}
+	if len(report.Objects) >= maxObjects {
+		return
+	}
+	obj := cseReportObject{
+		Name:      oi.Name,
+		VersionID: oi.VersionID,
+		Size:      oi.Size,
+		CSEInfo:   info,
+		Tier:      oi.TransitionedObject.Tier,
+	}
+	if kind, ok := crypto.IsEncrypted(oi.UserDefined); ok && kind != nil {
+		obj.SSE = kind.String()
+	}
+	report.Objects = append(report.Objects, obj)
+}
+
+// reportClientSideEncryption scans up to maxVersions versions under
+// prefix and reports the versions that were encrypted by an S3
+// encryption client. Nothing is modified.
+func reportClientSideEncryption(ctx context.Context, objAPI ObjectLayer, bucket string, opts cseReportOpts) (report cseReport, err error) {
+	report.Bucket = bucket
+	report.Prefix = opts.prefix
+	report.Objects = []cseReportObject{}
+
+	err = report.scanVersions(ctx, objAPI, bucket, opts.prefix, opts.maxVersions, func(oi ObjectInfo) {
+		if !oi.DeleteMarker {
+			report.add(oi, opts.maxObjects)
+		}
+	})
+	return report, err
+}
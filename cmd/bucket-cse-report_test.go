@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/minio/internal/crypto"
+)
+
+func TestReportClientSideEncryption(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("abcd")
+	for object, metadata := range map[string]map[string]string{
+		"v1":    {crypto.MetaCSEKeyV1: "a2V5", crypto.MetaCSEIV: "aXY="},
+		"v2":    {crypto.MetaCSEKeyV2: "a2V5", crypto.MetaCSEIV: "aXY=", crypto.MetaCSECEKAlg: "AES/GCM/NoPadding", crypto.MetaCSEWrapAlg: "kms+context"},
+		"plain": {"X-Amz-Meta-Key": "value"},
+	} {
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := reportClientSideEncryption(ctx, obj, bucket, cseReportOpts{maxVersions: 100, maxObjects: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Scanned != 3 || report.V1 != (cseReportCount{Versions: 1, Bytes: 4}) || report.V2 != (cseReportCount{Versions: 1, Bytes: 4}) {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if len(report.Objects) != 1 || report.Objects[0].Name != "v1" || report.Objects[0].Version != 1 {
+		t.Fatalf("unexpected objects %+v", report.Objects)
+	}
+}
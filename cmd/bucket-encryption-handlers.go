@@ -85,6 +85,11 @@ func (api objectAPIHandlers) PutBucketEncryptionHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if !globalKMSImportedKeysSys.IsAllowed(encConfig.KeyID(), bucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrKMSKeyNotAllowed), r.URL)
+		return
+	}
+
 	configData, err := xml.Marshal(encConfig)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
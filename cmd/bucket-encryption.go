@@ -22,6 +22,8 @@ import (
 	"io"
 
 	sse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/fips"
+	"github.com/minio/sio"
 )
 
 // BucketSSEConfigSys - in-memory cache of bucket encryption config
@@ -54,8 +56,40 @@ func validateBucketSSEConfig(r io.Reader) (*sse.BucketSSEConfig, error) {
 	}
 
 	if len(encConfig.Rules) == 1 {
+		if fips.Required() && encConfig.ContentCipher() == sse.ChaCha20Poly1305 {
+			return nil, errors.New("ChaCha20-Poly1305 is not supported in FIPS mode")
+		}
 		return encConfig, nil
 	}
 
 	return nil, errors.New("Unsupported bucket encryption configuration")
 }
+
+// contentCipherSuites returns the DARE cipher suites for the content of
+// objects in the given bucket. Objects are encrypted with the first
+// cipher suite, which is the content cipher selected by the bucket
+// encryption configuration, if any.
+func contentCipherSuites(bucket string) []byte {
+	cipherSuites := fips.CipherSuitesDARE()
+	sseConfig, err := globalBucketSSEConfigSys.Get(bucket)
+	if err != nil || sseConfig == nil {
+		return cipherSuites
+	}
+
+	var cipher byte
+	switch sseConfig.ContentCipher() {
+	case sse.AES256GCM:
+		cipher = sio.AES_256_GCM
+	case sse.ChaCha20Poly1305:
+		cipher = sio.CHACHA20_POLY1305
+	default:
+		return cipherSuites
+	}
+	for i, c := range cipherSuites {
+		if c == cipher {
+			return append([]byte{cipher}, append(cipherSuites[:i:i], cipherSuites[i+1:]...)...)
+		}
+	}
+	// The content cipher is not available, e.g. in FIPS mode.
+	return cipherSuites
+}
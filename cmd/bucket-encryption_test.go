@@ -19,7 +19,11 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"os"
 	"testing"
+
+	"github.com/minio/sio"
 )
 
 func TestValidateBucketSSEConfig(t *testing.T) {
@@ -68,3 +72,35 @@ func TestValidateBucketSSEConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestContentCipherSuites(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	newAllSubsystems()
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	for _, bucket := range []string{"chacha", "default"} {
+		if err = objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = globalBucketMetadataSys.Update("chacha", bucketSSEConfig,
+		[]byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm><ContentCipher>ChaCha20-Poly1305</ContentCipher></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if cipherSuites := contentCipherSuites("chacha"); !bytes.Equal(cipherSuites, []byte{sio.CHACHA20_POLY1305, sio.AES_256_GCM}) {
+		t.Fatalf("unexpected cipher suites %v", cipherSuites)
+	}
+	if cipherSuites := contentCipherSuites("default"); cipherSuites[0] != sio.AES_256_GCM {
+		t.Fatalf("unexpected cipher suites %v", cipherSuites)
+	}
+}
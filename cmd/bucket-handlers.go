@@ -1058,8 +1058,11 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 					return
 				}
+				if crypto.S3KMS.IsDualLayerRequested(formValues) {
+					metadata[crypto.MetaDualLayer] = "true"
+				}
 			}
-			reader, objectEncryptionKey, err = newEncryptReader(hashReader, kind, keyID, key, bucket, object, metadata, kmsCtx)
+			reader, objectEncryptionKey, err = newEncryptReader(ctx, hashReader, kind, keyID, key, bucket, object, metadata, kmsCtx)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 				return
@@ -1632,6 +1635,9 @@ func (api objectAPIHandlers) PutBucketReplicationConfigHandler(w http.ResponseWr
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	for _, warning := range replicationConfig.DivergenceWarnings() {
+		w.Header().Add(xhttp.MinIOReplicationWarning, warning)
+	}
 
 	// Write success response.
 	writeSuccessResponseHeadersOnly(w)
@@ -1864,6 +1870,12 @@ func (api objectAPIHandlers) ResetBucketReplicationStateHandler(w http.ResponseW
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	// Resync existing objects in the background, the progress is
+	// checkpointed so that an interrupted resync can be resumed.
+	if err = globalReplicationResyncer.Start(ctx, objectAPI, bucket, tgtArns[0], resetID); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
 	data, err := json.Marshal(rinfo)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -0,0 +1,335 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/minio/kes"
+	"github.com/minio/madmin-go"
+	sse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Rotation schedule and history of the SSE-KMS key of a bucket.
+	bucketKMSRotationConfig = "kms-key-rotation.json"
+
+	// Shortest accepted rotation interval.
+	minKMSKeyRotationInterval = time.Hour
+
+	// Number of rotations kept in the history of a bucket.
+	maxKMSKeyRotationHistory = 100
+
+	// How often the buckets are checked for due rotations.
+	kmsKeyRotationCheckInterval = 10 * time.Minute
+)
+
+// Triggers of a key rotation.
+const (
+	kmsKeyRotationScheduled = "scheduled"
+	kmsKeyRotationManual    = "manual"
+)
+
+var (
+	errKMSRotationInterval = AdminError{
+		Code:       "XMinioAdminKMSRotationInvalidInterval",
+		Message:    fmt.Sprintf("Key rotation interval must be a duration of at least %s", minKMSKeyRotationInterval),
+		StatusCode: http.StatusBadRequest,
+	}
+	errKMSRotationNoSSEKMS = AdminError{
+		Code:       "XMinioAdminKMSRotationNoSSEKMS",
+		Message:    "Key rotation requires the bucket to have an SSE-KMS default encryption configuration",
+		StatusCode: http.StatusBadRequest,
+	}
+
+	kmsKeyRotationLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+)
+
+// kmsKeyRotation is a single rotation of the SSE-KMS key of a bucket.
+type kmsKeyRotation struct {
+	KeyID     string    `json:"keyId"`
+	Version   int       `json:"version"`
+	RotatedAt time.Time `json:"rotatedAt"`
+	Trigger   string    `json:"trigger"`
+}
+
+// kmsKeyRotationConfig schedules the rotation of the SSE-KMS key of
+// a bucket. Every rotation creates a new version of the base key at
+// the KMS, named `<base>-v<version>`, and makes it the key of the
+// bucket default encryption, so that the keys of new objects are
+// wrapped with it. Existing objects keep referencing the key version
+// they were written with.
+type kmsKeyRotationConfig struct {
+	// Empty if rotation is not scheduled, e.g. "720h".
+	Interval     string           `json:"interval,omitempty"`
+	BaseKeyID    string           `json:"baseKeyId"`
+	Version      int              `json:"version"`
+	NextRotation time.Time        `json:"nextRotation,omitempty"`
+	History      []kmsKeyRotation `json:"history,omitempty"`
+}
+
+func parseKMSKeyRotationInterval(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < minKMSKeyRotationInterval {
+		return 0, errKMSRotationInterval
+	}
+	return d, nil
+}
+
+// parseKMSKeyRotationConfig - parses and validates the key rotation
+// settings of a bucket.
+func parseKMSKeyRotationConfig(reader io.Reader) (*kmsKeyRotationConfig, error) {
+	var cfg kmsKeyRotationConfig
+	if err := json.NewDecoder(reader).Decode(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Interval != "" {
+		if _, err := parseKMSKeyRotationInterval(cfg.Interval); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// KeyID returns the current version of the key.
+func (c *kmsKeyRotationConfig) KeyID() string {
+	if c.Version == 0 {
+		return c.BaseKeyID
+	}
+	return fmt.Sprintf("%s-v%d", c.BaseKeyID, c.Version)
+}
+
+// Due returns true if a scheduled rotation is due at now.
+func (c *kmsKeyRotationConfig) Due(now time.Time) bool {
+	return c.Interval != "" && !now.Before(c.NextRotation)
+}
+
+// schedule sets the rotation interval, rotation is disabled if interval
+// is empty.
+func (c *kmsKeyRotationConfig) schedule(interval string, now time.Time) error {
+	if interval == "" {
+		c.Interval, c.NextRotation = "", time.Time{}
+		return nil
+	}
+	d, err := parseKMSKeyRotationInterval(interval)
+	if err != nil {
+		return err
+	}
+	c.Interval, c.NextRotation = interval, now.Add(d)
+	return nil
+}
+
+// rotate advances the key version. If the bucket encryption has been
+// changed to another key since the last rotation, that key becomes the
+// new base key.
+func (c *kmsKeyRotationConfig) rotate(currentKeyID, trigger string, now time.Time) kmsKeyRotation {
+	if currentKeyID != c.KeyID() {
+		c.BaseKeyID, c.Version = currentKeyID, 0
+	}
+	c.Version++
+	if c.Interval != "" {
+		d, _ := parseKMSKeyRotationInterval(c.Interval)
+		c.NextRotation = now.Add(d)
+	}
+
+	rotation := kmsKeyRotation{
+		KeyID:     c.KeyID(),
+		Version:   c.Version,
+		RotatedAt: now,
+		Trigger:   trigger,
+	}
+	c.History = append(c.History, rotation)
+	if n := len(c.History); n > maxKMSKeyRotationHistory {
+		c.History = append([]kmsKeyRotation(nil), c.History[n-maxKMSKeyRotationHistory:]...)
+	}
+	return rotation
+}
+
+func saveKMSKeyRotationConfig(bucket string, cfg *kmsKeyRotationConfig) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketKMSRotationConfig, data)
+}
+
+// bucketKMSKeyID returns the key of the SSE-KMS default encryption of
+// the bucket.
+func bucketKMSKeyID(bucket string) (*sse.BucketSSEConfig, string, error) {
+	sseConfig, err := globalBucketSSEConfigSys.Get(bucket)
+	if err != nil {
+		if _, ok := err.(BucketSSEConfigNotFound); ok {
+			return nil, "", errKMSRotationNoSSEKMS
+		}
+		return nil, "", err
+	}
+	if !sseConfig.IsKMS() || sseConfig.KeyID() == "" {
+		return nil, "", errKMSRotationNoSSEKMS
+	}
+	return sseConfig, sseConfig.KeyID(), nil
+}
+
+// lockBucketKMSRotation serializes the rotations of a bucket.
+func lockBucketKMSRotation(ctx context.Context, objAPI ObjectLayer, bucket string) (RWLocker, LockContext, error) {
+	locker := objAPI.NewNSLock(minioMetaBucket, path.Join("kms/rotation", bucket+".lock"))
+	lkctx, err := locker.GetLock(ctx, kmsKeyRotationLockTimeout)
+	return locker, lkctx, err
+}
+
+// setBucketKMSRotation schedules the rotation of the SSE-KMS key of the
+// bucket every interval, or disables it if interval is empty. The
+// rotation history is kept.
+func setBucketKMSRotation(ctx context.Context, objAPI ObjectLayer, bucket, interval string) (*kmsKeyRotationConfig, error) {
+	locker, lkctx, err := lockBucketKMSRotation(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer locker.Unlock(lkctx.Cancel)
+
+	_, keyID, err := bucketKMSKeyID(bucket)
+	if err != nil && interval != "" {
+		return nil, err
+	}
+
+	cur, err := globalBucketMetadataSys.GetKMSKeyRotationConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	cfg := *cur
+	if cfg.BaseKeyID == "" {
+		cfg.BaseKeyID = keyID
+	}
+	if err = cfg.schedule(interval, UTCNow()); err != nil {
+		return nil, err
+	}
+	if err = saveKMSKeyRotationConfig(bucket, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// rotateBucketKMSKey creates the next version of the SSE-KMS key of the
+// bucket and makes it the key of the bucket default encryption.
+func rotateBucketKMSKey(ctx context.Context, objAPI ObjectLayer, bucket, trigger string) (kmsKeyRotation, error) {
+	if GlobalKMS == nil {
+		return kmsKeyRotation{}, errKMSNotConfigured
+	}
+
+	locker, lkctx, err := lockBucketKMSRotation(ctx, objAPI, bucket)
+	if err != nil {
+		return kmsKeyRotation{}, err
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	sseConfig, keyID, err := bucketKMSKeyID(bucket)
+	if err != nil {
+		return kmsKeyRotation{}, err
+	}
+	cur, err := globalBucketMetadataSys.GetKMSKeyRotationConfig(bucket)
+	if err != nil {
+		return kmsKeyRotation{}, err
+	}
+	cfg := *cur
+	cfg.History = append([]kmsKeyRotation(nil), cur.History...)
+	rotation := cfg.rotate(keyID, trigger, UTCNow())
+
+	if err = GlobalKMS.CreateKey(rotation.KeyID); err != nil && !errors.Is(err, kes.ErrKeyExists) {
+		return kmsKeyRotation{}, err
+	}
+
+	rotated := sse.BucketSSEConfig{XMLNS: sseConfig.XMLNS, Rules: []sse.Rule{sseConfig.Rules[0]}}
+	rotated.Rules[0].DefaultEncryptionAction.MasterKeyID = rotation.KeyID
+	configData, err := xml.Marshal(rotated)
+	if err != nil {
+		return kmsKeyRotation{}, err
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketSSEConfig, configData); err != nil {
+		return kmsKeyRotation{}, err
+	}
+	if err = saveKMSKeyRotationConfig(bucket, &cfg); err != nil {
+		return kmsKeyRotation{}, err
+	}
+
+	cfgStr := base64.StdEncoding.EncodeToString(configData)
+	if err = globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
+		Type:      madmin.SRBucketMetaTypeSSEConfig,
+		Bucket:    bucket,
+		SSEConfig: &cfgStr,
+	}); err != nil {
+		return kmsKeyRotation{}, err
+	}
+	return rotation, nil
+}
+
+// initBucketKMSRotation starts rotating the SSE-KMS keys of the buckets
+// according to their schedules in the background.
+func initBucketKMSRotation(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		ticker := time.NewTicker(kmsKeyRotationCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if GlobalKMS != nil {
+					runBucketKMSRotation(ctx, objAPI)
+				}
+			}
+		}
+	}()
+}
+
+// runBucketKMSRotation rotates the keys of all buckets whose rotation
+// is due, only one node of the cluster runs it at a time.
+func runBucketKMSRotation(ctx context.Context, objAPI ObjectLayer) {
+	locker := objAPI.NewNSLock(minioMetaBucket, "kms/runBucketKMSRotation.lock")
+	lkctx, err := locker.GetLock(ctx, kmsKeyRotationLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	now := UTCNow()
+	for _, bi := range buckets {
+		cfg, err := globalBucketMetadataSys.GetKMSKeyRotationConfig(bi.Name)
+		if err != nil || !cfg.Due(now) {
+			continue
+		}
+		if _, err = rotateBucketKMSKey(ctx, objAPI, bi.Name, kmsKeyRotationScheduled); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("unable to rotate the KMS key of bucket %s: %w", bi.Name, err))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKMSKeyRotationConfigRotate(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := kmsKeyRotationConfig{BaseKeyID: "bucket-key"}
+	if err := cfg.schedule("24h", now); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Due(now) || !cfg.Due(now.Add(24*time.Hour)) {
+		t.Fatalf("unexpected next rotation %v", cfg.NextRotation)
+	}
+
+	r := cfg.rotate("bucket-key", kmsKeyRotationScheduled, now.Add(24*time.Hour))
+	if r.KeyID != "bucket-key-v1" || r.Version != 1 || cfg.KeyID() != "bucket-key-v1" {
+		t.Fatalf("unexpected rotation %v", r)
+	}
+	if !cfg.NextRotation.Equal(now.Add(48 * time.Hour)) {
+		t.Fatalf("unexpected next rotation %v", cfg.NextRotation)
+	}
+	if r = cfg.rotate("bucket-key-v1", kmsKeyRotationManual, now.Add(25*time.Hour)); r.KeyID != "bucket-key-v2" {
+		t.Fatalf("unexpected rotation %v", r)
+	}
+
+	// The bucket encryption was changed to another key in the meantime.
+	if r = cfg.rotate("other-key", kmsKeyRotationScheduled, now.Add(48*time.Hour)); r.KeyID != "other-key-v1" {
+		t.Fatalf("unexpected rotation %v", r)
+	}
+	if len(cfg.History) != 3 || cfg.History[1].Trigger != kmsKeyRotationManual {
+		t.Fatalf("unexpected history %v", cfg.History)
+	}
+
+	if err := cfg.schedule("", now); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Due(now.Add(1000*time.Hour)) || len(cfg.History) != 3 {
+		t.Fatal("disabling the schedule must stop rotations and keep the history")
+	}
+}
+
+func TestKMSKeyRotationHistoryLimit(t *testing.T) {
+	cfg := kmsKeyRotationConfig{BaseKeyID: "key"}
+	now := UTCNow()
+	for i := 0; i < maxKMSKeyRotationHistory+5; i++ {
+		cfg.rotate(cfg.KeyID(), kmsKeyRotationManual, now)
+	}
+	if len(cfg.History) != maxKMSKeyRotationHistory {
+		t.Fatalf("expected %d entries, got %d", maxKMSKeyRotationHistory, len(cfg.History))
+	}
+	if last := cfg.History[len(cfg.History)-1]; last.Version != maxKMSKeyRotationHistory+5 {
+		t.Fatalf("unexpected last rotation %v", last)
+	}
+}
+
+func TestParseKMSKeyRotationConfig(t *testing.T) {
+	testCases := []struct {
+		config string
+		valid  bool
+	}{
+		{`{"interval":"720h","baseKeyId":"key","version":2}`, true},
+		{`{"baseKeyId":"key"}`, true},
+		{`{"interval":"30m","baseKeyId":"key"}`, false},
+		{`{"interval":"monthly","baseKeyId":"key"}`, false},
+		{`{"interval":`, false},
+	}
+	for i, testCase := range testCases {
+		_, err := parseKMSKeyRotationConfig(strings.NewReader(testCase.config))
+		if (err == nil) != testCase.valid {
+			t.Errorf("Test %d: expected valid=%v, got %v", i+1, testCase.valid, err)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+)
+
+// Objects read in buckets whose lifecycle configuration transitions on
+// DaysSinceLastAccess have their last access time recorded. Reads are
+// collected in memory and persisted periodically as a metadata-only
+// update, at most once per lastAccessResolution for a given version.
+const (
+	lastAccessTimeKey = ReservedMetadataPrefixLower + "last-access"
+
+	lastAccessResolution    = 24 * time.Hour
+	lastAccessFlushInterval = time.Minute
+	lastAccessMaxPending    = 100000
+)
+
+var errLastAccessObjectChanged = errors.New("object changed since it was accessed")
+
+var globalLastAccessTracker *lastAccessTracker
+
+type lastAccessKey struct {
+	bucket, object, versionID string
+}
+
+type lastAccessEntry struct {
+	modTime    time.Time
+	accessTime time.Time
+}
+
+// lastAccessTracker batches last access time updates of objects.
+type lastAccessTracker struct {
+	mu      sync.Mutex
+	pending map[lastAccessKey]lastAccessEntry
+}
+
+func newLastAccessTracker() *lastAccessTracker {
+	return &lastAccessTracker{
+		pending: make(map[lastAccessKey]lastAccessEntry),
+	}
+}
+
+func initLastAccessTracker(ctx context.Context, objectAPI ObjectLayer) {
+	globalLastAccessTracker = newLastAccessTracker()
+	go globalLastAccessTracker.run(ctx, objectAPI)
+}
+
+// lastAccessTime returns the last access time recorded in meta, zero
+// time if there is none.
+func lastAccessTime(meta map[string]string) time.Time {
+	v, ok := meta[lastAccessTimeKey]
+	if !ok {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// Record notes a read of oi if the lifecycle configuration of bucket
+// makes use of last access times.
+func (t *lastAccessTracker) Record(bucket string, oi ObjectInfo) {
+	if t == nil || oi.DeleteMarker || !oi.IsLatest {
+		return
+	}
+	lc, err := globalLifecycleSys.Get(bucket)
+	if err != nil || !lc.HasLastAccessTransition() {
+		return
+	}
+
+	now := UTCNow()
+	if now.Sub(lastAccessTime(oi.UserDefined)) < lastAccessResolution {
+		return
+	}
+
+	key := lastAccessKey{bucket: bucket, object: oi.Name, versionID: oi.VersionID}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.pending[key]; !ok && len(t.pending) >= lastAccessMaxPending {
+		// Dropping a read only delays the transition of a
+		// frequently read object, the next read records it.
+		return
+	}
+	t.pending[key] = lastAccessEntry{modTime: oi.ModTime, accessTime: now}
+}
+
+func (t *lastAccessTracker) run(ctx context.Context, objectAPI ObjectLayer) {
+	ticker := time.NewTicker(lastAccessFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.flush(ctx, objectAPI)
+		}
+	}
+}
+
+// flush persists all pending last access times.
+func (t *lastAccessTracker) flush(ctx context.Context, objectAPI ObjectLayer) {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[lastAccessKey]lastAccessEntry, len(pending))
+	t.mu.Unlock()
+
+	for key, entry := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		entry := entry
+		_, err := objectAPI.PutObjectMetadata(ctx, key.bucket, key.object, ObjectOptions{
+			VersionID: key.versionID,
+			MTime:     entry.modTime,
+			EvalMetadataFn: func(oi ObjectInfo) error {
+				// The object was overwritten in the meantime.
+				if !oi.ModTime.Equal(entry.modTime) {
+					return errLastAccessObjectChanged
+				}
+				oi.UserDefined[lastAccessTimeKey] = entry.accessTime.Format(time.RFC3339)
+				return nil
+			},
+		})
+		if err != nil && !errors.Is(err, errLastAccessObjectChanged) && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			logger.LogIf(ctx, err)
+		}
+	}
+}
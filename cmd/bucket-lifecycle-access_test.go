@@ -0,0 +1,70 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLastAccessTrackerFlush(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("abcd")
+	oi, err := obj.PutObject(ctx, "bucket", "obj", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accessTime := UTCNow().Truncate(time.Second)
+	tracker := newLastAccessTracker()
+	tracker.pending[lastAccessKey{bucket: "bucket", object: "obj"}] = lastAccessEntry{modTime: oi.ModTime, accessTime: accessTime}
+	// Entries of deleted objects are ignored.
+	tracker.pending[lastAccessKey{bucket: "bucket", object: "missing"}] = lastAccessEntry{modTime: oi.ModTime, accessTime: accessTime}
+	tracker.flush(ctx, obj)
+	if len(tracker.pending) != 0 {
+		t.Fatalf("expected no pending entries, got %d", len(tracker.pending))
+	}
+
+	got, err := obj.GetObjectInfo(ctx, "bucket", "obj", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lastAccessTime(got.UserDefined).Equal(accessTime) {
+		t.Fatalf("expected last access %v, got %v", accessTime, lastAccessTime(got.UserDefined))
+	}
+	if !got.ModTime.Equal(oi.ModTime) || got.ETag != oi.ETag {
+		t.Fatal("last access updates must not modify the object")
+	}
+	if opts := got.ToLifecycleOpts(); !opts.LastAccessTime.Equal(accessTime) {
+		t.Fatalf("expected lifecycle last access %v, got %v", accessTime, opts.LastAccessTime)
+	}
+}
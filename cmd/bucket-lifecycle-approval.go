@@ -0,0 +1,170 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/minio/minio/internal/config/ilmapproval"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Expirations by the lifecycle rules configured with `ilm_approval` are
+// approved by a webhook before they are applied, e.g. for buckets under
+// regulatory retention. The webhook is sent
+//
+//	{"bucket": "...", "object": "...", "versionId": "...", "ruleId": "..."}
+//
+// and must respond with `200 OK` and `{"allow": true}` for the expiration
+// to proceed. Vetoed expirations are audit logged and evaluated again by
+// the next scan.
+
+var globalILMApproval = &ilmApprovalSys{}
+
+type ilmApprovalRequest struct {
+	Bucket    string `json:"bucket"`
+	Object    string `json:"object"`
+	VersionID string `json:"versionId,omitempty"`
+	RuleID    string `json:"ruleId"`
+}
+
+type ilmApprovalResponse struct {
+	Allow bool `json:"allow"`
+}
+
+type ilmApprovalSys struct {
+	mu     sync.RWMutex
+	cfg    ilmapproval.Config
+	client *http.Client
+}
+
+// Update sets the webhook configuration.
+func (s *ilmApprovalSys) Update(cfg ilmapproval.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cfg.Enabled() && s.client == nil {
+		s.client = &http.Client{Transport: NewGatewayHTTPTransport()}
+	}
+	s.cfg = cfg
+}
+
+// Required returns true if expirations by the rule ruleID of bucket
+// must be approved.
+func (s *ilmApprovalSys) Required(bucket, ruleID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg.Matches(bucket, ruleID)
+}
+
+// Approve returns true if the expiration of oi by the rule ruleID may
+// proceed.
+func (s *ilmApprovalSys) Approve(ctx context.Context, oi ObjectInfo, ruleID string) bool {
+	s.mu.RLock()
+	cfg, client := s.cfg, s.client
+	s.mu.RUnlock()
+	if !cfg.Matches(oi.Bucket, ruleID) {
+		return true
+	}
+
+	allow, err := s.ask(ctx, cfg, client, ilmApprovalRequest{
+		Bucket:    oi.Bucket,
+		Object:    oi.Name,
+		VersionID: oi.VersionID,
+		RuleID:    ruleID,
+	})
+	if err != nil {
+		logger.LogOnceIf(ctx, fmt.Errorf("Unable to get approval of lifecycle expiration of %s/%s(%s): %w", oi.Bucket, oi.Name, oi.VersionID, err), "ilm-approval")
+		allow = cfg.FailOpen
+	}
+	if !allow {
+		auditLogLifecycle(ctx, oi, ILMExpiryVetoed)
+	}
+	return allow
+}
+
+// approveExpiry returns true if the expiration of oi queued by the
+// scanner may proceed.
+func (s *ilmApprovalSys) approveExpiry(ctx context.Context, oi ObjectInfo) bool {
+	s.mu.RLock()
+	enabled := s.cfg.Enabled()
+	s.mu.RUnlock()
+	if !enabled {
+		return true
+	}
+	lc, err := globalLifecycleSys.Get(oi.Bucket)
+	if err != nil {
+		// Expirations are rejected without lifecycle configuration.
+		return true
+	}
+	_, ruleID := lc.ComputeRuleActionAt(oi.ToLifecycleOpts(), UTCNow())
+	return s.Approve(ctx, oi, ruleID)
+}
+
+// approveVersions returns the versions of bucket whose expiration by the
+// rule ruleID may proceed.
+func (s *ilmApprovalSys) approveVersions(ctx context.Context, bucket, ruleID string, versions []ObjectToDelete) []ObjectToDelete {
+	if !s.Required(bucket, ruleID) {
+		return versions
+	}
+	approved := versions[:0:0]
+	for _, v := range versions {
+		if s.Approve(ctx, ObjectInfo{Bucket: bucket, Name: v.ObjectName, VersionID: v.VersionID}, ruleID) {
+			approved = append(approved, v)
+		}
+	}
+	return approved
+}
+
+func (s *ilmApprovalSys) ask(ctx context.Context, cfg ilmapproval.Config, client *http.Client, areq ilmApprovalRequest) (bool, error) {
+	body, err := json.Marshal(areq)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL.String(), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+	if cfg.AuthToken != "" {
+		req.Header.Set(xhttp.Authorization, cfg.AuthToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	var aresp ilmApprovalResponse
+	if err = json.NewDecoder(resp.Body).Decode(&aresp); err != nil {
+		return false, err
+	}
+	return aresp.Allow, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config/ilmapproval"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestILMApprovalApprove(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var areq ilmApprovalRequest
+		if err := json.NewDecoder(r.Body).Decode(&areq); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch areq.Object {
+		case "fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			json.NewEncoder(w).Encode(ilmApprovalResponse{Allow: areq.Object == "allow"})
+		}
+	}))
+	defer ts.Close()
+
+	u, err := xnet.ParseHTTPURL(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object   string
+		failOpen bool
+		ruleID   string
+		expected bool
+	}{
+		{"allow", false, "rule", true},
+		{"veto", false, "rule", false},
+		{"fail", false, "rule", false},
+		{"fail", true, "rule", true},
+		// Expirations by other rules don't require approval.
+		{"veto", false, "other", true},
+	}
+	for i, testCase := range testCases {
+		s := &ilmApprovalSys{}
+		s.Update(ilmapproval.Config{
+			URL:       u,
+			AuthToken: "Bearer token",
+			Timeout:   5 * time.Second,
+			FailOpen:  testCase.failOpen,
+			Rules:     []string{"bucket/rule"},
+		})
+		oi := ObjectInfo{Bucket: "bucket", Name: testCase.object}
+		if got := s.Approve(context.Background(), oi, testCase.ruleID); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestILMApprovalApproveVersions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var areq ilmApprovalRequest
+		json.NewDecoder(r.Body).Decode(&areq)
+		json.NewEncoder(w).Encode(ilmApprovalResponse{Allow: areq.VersionID != "v2"})
+	}))
+	defer ts.Close()
+
+	u, err := xnet.ParseHTTPURL(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &ilmApprovalSys{}
+	s.Update(ilmapproval.Config{URL: u, Timeout: 5 * time.Second})
+
+	versions := []ObjectToDelete{
+		{ObjectV: ObjectV{ObjectName: "obj", VersionID: "v1"}},
+		{ObjectV: ObjectV{ObjectName: "obj", VersionID: "v2"}},
+		{ObjectV: ObjectV{ObjectName: "obj", VersionID: "v3"}},
+	}
+	approved := s.approveVersions(context.Background(), "bucket", "rule", versions)
+	if len(approved) != 2 || approved[0].VersionID != "v1" || approved[1].VersionID != "v3" {
+		t.Fatalf("unexpected approved versions %v", approved)
+	}
+}
@@ -96,6 +96,12 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Call site replication hook.
+	if err = globalSiteReplicationSys.BucketLifecycleHook(ctx, bucket, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Success.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -173,6 +179,12 @@ func (api objectAPIHandlers) DeleteBucketLifecycleHandler(w http.ResponseWriter,
 		return
 	}
 
+	// Call site replication hook.
+	if err := globalSiteReplicationSys.BucketLifecycleHook(ctx, bucket, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Success.
 	writeSuccessNoContent(w)
 }
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Lifecycle rules of the buckets and prefixes configured with the
+// scanner `ilm_priority` setting are applied every `ilm_priority_cycle`
+// in addition to the regular scanner cycle, which may take days on large
+// deployments.
+
+var (
+	globalILMPriority = &ilmPrioritySys{cycle: time.Hour}
+
+	ilmPriorityLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+)
+
+type ilmPrioritySys struct {
+	mu       sync.Mutex
+	prefixes []string
+	cycle    time.Duration
+}
+
+// Update sets the priority prefixes, as `bucket/prefix`, and the cycle
+// they are evaluated at.
+func (s *ilmPrioritySys) Update(prefixes []string, cycle time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prefixes = prefixes
+	s.cycle = cycle
+}
+
+func (s *ilmPrioritySys) get() ([]string, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prefixes, s.cycle
+}
+
+// initILMPriorityScanner starts applying lifecycle rules to the priority
+// prefixes in the background.
+func initILMPriorityScanner(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		_, cycle := globalILMPriority.get()
+		timer := time.NewTimer(cycle)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				prefixes, cycle := globalILMPriority.get()
+				if len(prefixes) > 0 {
+					runILMPriorityScanner(ctx, objAPI, prefixes)
+				}
+				timer.Reset(cycle)
+			}
+		}
+	}()
+}
+
+// runILMPriorityScanner applies lifecycle rules to all prefixes, only one
+// node of the cluster runs it per cycle.
+func runILMPriorityScanner(ctx context.Context, objAPI ObjectLayer, prefixes []string) {
+	locker := objAPI.NewNSLock(minioMetaBucket, "scanner/runILMPriorityScanner.lock")
+	lkctx, err := locker.GetLock(ctx, ilmPriorityLeaderLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	for _, p := range prefixes {
+		bucket, prefix := p, ""
+		if i := strings.Index(p, SlashSeparator); i >= 0 {
+			bucket, prefix = p[:i], p[i+1:]
+		}
+		if err := applyLifecyclePrefix(ctx, objAPI, bucket, prefix); err != nil && !isErrBucketNotFound(err) {
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
+// applyLifecyclePrefix evaluates the lifecycle rules of bucket on all
+// object versions under prefix and queues the resulting expirations and
+// transitions, like the scanner does.
+func applyLifecyclePrefix(ctx context.Context, objAPI ObjectLayer, bucket, prefix string) error {
+	lc, err := globalLifecycleSys.Get(bucket)
+	if err != nil || !lc.HasActiveRules(prefix, true) {
+		return nil
+	}
+
+	var marker, versionMarker string
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, bucket, prefix, marker, versionMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range loi.Objects {
+			atomic.AddUint64(&globalScannerStats.ilmChecks, 1)
+			action := evalActionFromLifecycle(ctx, *lc, oi, false)
+			if action == lifecycle.NoneAction {
+				continue
+			}
+			atomic.AddUint64(&globalScannerStats.actions[action], 1)
+			applyLifecycleAction(action, oi)
+		}
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker, versionMarker = loi.NextMarker, loi.NextVersionIDMarker
+		scannerSleeper.Sleep(ctx, dataScannerSleepPerFolder)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
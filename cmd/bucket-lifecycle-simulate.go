@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+)
+
+// lifecycleSimulationCount is the number and size of object versions an
+// action would apply to.
+type lifecycleSimulationCount struct {
+	Versions int64 `json:"versions"`
+	Bytes    int64 `json:"bytes"`
+}
+
+func (c *lifecycleSimulationCount) add(oi ObjectInfo) {
+	c.Versions++
+	c.Bytes += oi.Size
+}
+
+// lifecycleSimulationObject is an object version an action would apply to.
+type lifecycleSimulationObject struct {
+	Name      string `json:"name"`
+	VersionID string `json:"versionId,omitempty"`
+	Action    string `json:"action"`
+	Tier      string `json:"tier,omitempty"`
+	Size      int64  `json:"size"`
+}
+
+// lifecycleSimulation is the result of a lifecycle dry-run.
+type lifecycleSimulation struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	// Time the rules were evaluated at.
+	At time.Time `json:"at"`
+	versionScan
+	// Versions that would be deleted, including delete markers
+	// added to versioned buckets.
+	Expire lifecycleSimulationCount `json:"expire"`
+	// Restored copies of transitioned objects that would be removed.
+	ExpireRestored lifecycleSimulationCount `json:"expireRestored"`
+	// Versions that would be transitioned, by tier.
+	Transition map[string]lifecycleSimulationCount `json:"transition"`
+	// Versions locked by object lock retention, which are kept.
+	Locked lifecycleSimulationCount `json:"locked"`
+	// The first affected versions, up to the maximum number of
+	// objects requested.
+	Objects []lifecycleSimulationObject `json:"objects"`
+}
+
+type lifecycleSimulationOpts struct {
+	prefix      string
+	days        int
+	maxVersions int64
+	maxObjects  int
+}
+
+// simulateVersion adds the action lc would take on oi at the
+// simulation time.
+func (sim *lifecycleSimulation) simulateVersion(ctx context.Context, lc *lifecycle.Lifecycle, lockEnabled bool, oi ObjectInfo, maxObjects int) {
+	opts := oi.ToLifecycleOpts()
+	action := lc.ComputeActionAt(opts, sim.At)
+
+	var name, tier string
+	switch action {
+	case lifecycle.DeleteAction, lifecycle.DeleteVersionAction:
+		if action == lifecycle.DeleteVersionAction && lockEnabled && enforceRetentionForDeletion(ctx, oi) {
+			sim.Locked.add(oi)
+			return
+		}
+		name = "expire"
+		sim.Expire.add(oi)
+	case lifecycle.DeleteRestoredAction, lifecycle.DeleteRestoredVersionAction:
+		name = "expire-restored"
+		sim.ExpireRestored.add(oi)
+	case lifecycle.TransitionAction, lifecycle.TransitionVersionAction:
+		name = "transition"
+		tier = lc.TransitionTier(opts)
+		c := sim.Transition[tier]
+		c.add(oi)
+		sim.Transition[tier] = c
+	default:
+		return
+	}
+
+	if len(sim.Objects) < maxObjects {
+		sim.Objects = append(sim.Objects, lifecycleSimulationObject{
+			Name:      oi.Name,
+			VersionID: oi.VersionID,
+			Action:    name,
+			Tier:      tier,
+			Size:      oi.Size,
+		})
+	}
+}
+
+// simulateLifecycle scans up to maxVersions versions under prefix and
+// reports the versions lc would expire or transition within the given
+// number of days from now. Object lock retention is evaluated as of
+// now. Nothing is modified.
+func simulateLifecycle(ctx context.Context, objAPI ObjectLayer, bucket string, lc *lifecycle.Lifecycle, opts lifecycleSimulationOpts) (sim lifecycleSimulation, err error) {
+	sim.Bucket = bucket
+	sim.Prefix = opts.prefix
+	sim.At = UTCNow().Add(time.Duration(opts.days) * 24 * time.Hour)
+	sim.Transition = make(map[string]lifecycleSimulationCount)
+	sim.Objects = []lifecycleSimulationObject{}
+
+	rcfg, _ := globalBucketObjectLockSys.Get(bucket)
+
+	err = sim.scanVersions(ctx, objAPI, bucket, opts.prefix, opts.maxVersions, func(oi ObjectInfo) {
+		sim.simulateVersion(ctx, lc, rcfg.LockEnabled, oi, opts.maxObjects)
+	})
+	return sim, err
+}
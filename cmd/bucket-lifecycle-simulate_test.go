@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+)
+
+func TestLifecycleSimulateVersion(t *testing.T) {
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>logs</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>30</Days></Expiration></Rule><Rule><ID>data</ID><Filter><Prefix>data/</Prefix></Filter><Status>Enabled</Status><Transition><Days>10</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := UTCNow()
+	versions := []ObjectInfo{
+		{Name: "logs/old", Size: 100, IsLatest: true, ModTime: now.Add(-40 * 24 * time.Hour)},
+		// expires within the simulated days
+		{Name: "logs/recent", Size: 200, IsLatest: true, ModTime: now.Add(-25 * 24 * time.Hour)},
+		{Name: "logs/new", Size: 300, IsLatest: true, ModTime: now},
+		{Name: "data/old", Size: 400, IsLatest: true, ModTime: now.Add(-20 * 24 * time.Hour)},
+		{Name: "data/tiered", Size: 500, IsLatest: true, ModTime: now.Add(-20 * 24 * time.Hour), TransitionedObject: TransitionedObject{Status: lifecycle.TransitionComplete}},
+	}
+
+	sim := lifecycleSimulation{
+		At:         now.Add(7 * 24 * time.Hour),
+		Transition: make(map[string]lifecycleSimulationCount),
+	}
+	for _, oi := range versions {
+		sim.simulateVersion(context.Background(), lc, false, oi, 2)
+	}
+	if sim.Expire != (lifecycleSimulationCount{Versions: 2, Bytes: 300}) {
+		t.Fatalf("unexpected expire count %+v", sim.Expire)
+	}
+	if c := sim.Transition["WARM"]; c != (lifecycleSimulationCount{Versions: 1, Bytes: 400}) {
+		t.Fatalf("unexpected transition count %+v", c)
+	}
+	if len(sim.Objects) != 2 || sim.Objects[0].Name != "logs/old" || sim.Objects[0].Action != "expire" {
+		t.Fatalf("unexpected objects %+v", sim.Objects)
+	}
+}
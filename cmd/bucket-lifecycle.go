@@ -33,6 +33,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	sse "github.com/minio/minio/internal/bucket/encryption"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/config/limits"
 	"github.com/minio/minio/internal/event"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
@@ -50,6 +51,12 @@ const (
 	TransitionedVersionID = "transitioned-versionID"
 	// TransitionTier name of transition storage class
 	TransitionTier = "transition-tier"
+	// TransitionedChecksum is the SHA-256 checksum of the content
+	// written to the remote tier
+	TransitionedChecksum = "transitioned-checksum"
+	// TransitionedEncoded is set if the content written to the remote
+	// tier was compressed or encrypted
+	TransitionedEncoded = "transitioned-encoded"
 )
 
 // LifecycleSys - Bucket lifecycle subsystem.
@@ -111,11 +118,11 @@ func (es *expiryState) enqueueByDays(oi ObjectInfo, restoredObject bool, rmVersi
 
 // enqueueByNewerNoncurrent enqueues object versions expired by
 // NewerNoncurrentVersions limit for expiry.
-func (es *expiryState) enqueueByNewerNoncurrent(bucket string, versions []ObjectToDelete) {
+func (es *expiryState) enqueueByNewerNoncurrent(bucket, ruleID string, versions []ObjectToDelete) {
 	select {
 	case <-GlobalContext.Done():
 		es.close()
-	case es.byNewerNoncurrentCh <- newerNoncurrentTask{bucket: bucket, versions: versions}:
+	case es.byNewerNoncurrentCh <- newerNoncurrentTask{bucket: bucket, ruleID: ruleID, versions: versions}:
 	default:
 	}
 }
@@ -133,6 +140,9 @@ func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
 	globalExpiryState = newExpiryState()
 	go func() {
 		for t := range globalExpiryState.byDaysCh {
+			if !t.restoredObject && !globalILMApproval.approveExpiry(ctx, t.objInfo) {
+				continue
+			}
 			if t.objInfo.TransitionedObject.Status != "" {
 				applyExpiryOnTransitionedObject(ctx, objectAPI, t.objInfo, t.restoredObject)
 			} else {
@@ -142,7 +152,10 @@ func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
 	}()
 	go func() {
 		for t := range globalExpiryState.byNewerNoncurrentCh {
-			deleteObjectVersions(ctx, objectAPI, t.bucket, t.versions)
+			versions := globalILMApproval.approveVersions(ctx, t.bucket, t.ruleID, t.versions)
+			if len(versions) > 0 {
+				deleteObjectVersions(ctx, objectAPI, t.bucket, versions)
+			}
 		}
 	}()
 }
@@ -151,6 +164,7 @@ func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
 // by NewerNoncurrentVersions
 type newerNoncurrentTask struct {
 	bucket   string
+	ruleID   string
 	versions []ObjectToDelete
 }
 
@@ -209,6 +223,10 @@ func (t *transitionState) worker(ctx context.Context, objectAPI ObjectLayer) {
 		case <-ctx.Done():
 			return
 		case oi, ok := <-t.transitionCh:
+			if !ok {
+				return
+			}
+			release, ok := globalResourceLimits.acquire(ctx, limits.Transition)
 			if !ok {
 				return
 			}
@@ -217,6 +235,7 @@ func (t *transitionState) worker(ctx context.Context, objectAPI ObjectLayer) {
 				logger.LogIf(ctx, fmt.Errorf("Transition failed for %s/%s version:%s with %w", oi.Bucket, oi.Name, oi.VersionID, err))
 			}
 			atomic.AddInt32(&t.activeTasks, -1)
+			release()
 		}
 	}
 }
@@ -291,6 +310,21 @@ const (
 //
 // 1. when a restored (via PostRestoreObject API) object expires.
 // 2. when a transitioned object expires (based on an ILM rule).
+// ilmExpiryEventName - returns the name of the event sent when lifecycle
+// expires an object, or creates a delete marker for it.
+func ilmExpiryEventName(deleteMarker bool) event.Name {
+	if globalNotifyInternalEvents {
+		if deleteMarker {
+			return event.ObjectLifecycleExpirationDeleteMarkerCreated
+		}
+		return event.ObjectLifecycleExpirationDelete
+	}
+	if deleteMarker {
+		return event.ObjectRemovedDeleteMarkerCreated
+	}
+	return event.ObjectRemovedDelete
+}
+
 func expireTransitionedObject(ctx context.Context, objectAPI ObjectLayer, oi *ObjectInfo, lcOpts lifecycle.ObjectOpts, action expireAction) error {
 	var opts ObjectOptions
 	opts.Versioned = globalBucketVersioningSys.Enabled(oi.Bucket)
@@ -319,10 +353,6 @@ func expireTransitionedObject(ctx context.Context, objectAPI ObjectLayer, oi *Ob
 		// Send audit for the lifecycle delete operation
 		auditLogLifecycle(ctx, *oi, ILMExpiry)
 
-		eventName := event.ObjectRemovedDelete
-		if lcOpts.DeleteMarker {
-			eventName = event.ObjectRemovedDeleteMarkerCreated
-		}
 		objInfo := ObjectInfo{
 			Name:         oi.Name,
 			VersionID:    lcOpts.VersionID,
@@ -330,7 +360,7 @@ func expireTransitionedObject(ctx context.Context, objectAPI ObjectLayer, oi *Ob
 		}
 		// Notify object deleted event.
 		sendEvent(eventArgs{
-			EventName:  eventName,
+			EventName:  ilmExpiryEventName(lcOpts.DeleteMarker),
 			BucketName: oi.Bucket,
 			Object:     objInfo,
 			Host:       "Internal: [ILM-EXPIRY]",
@@ -370,10 +400,12 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo)
 	if err != nil {
 		return err
 	}
+	ruleID, tier := lc.TransitionRule(oi.ToLifecycleOpts())
 	opts := ObjectOptions{
 		Transition: TransitionOptions{
 			Status: lifecycle.TransitionPending,
-			Tier:   lc.TransitionTier(oi.ToLifecycleOpts()),
+			Tier:   tier,
+			RuleID: ruleID,
 			ETag:   oi.ETag,
 		},
 		VersionID:        oi.VersionID,
@@ -390,12 +422,15 @@ func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs
 	if err != nil {
 		return nil, fmt.Errorf("transition storage class not configured")
 	}
+	if ob, ok := tgtClient.(offlineWarmBackend); ok && ob.Offline() && opts.Transition.RestoreRequest == nil {
+		return nil, InvalidObjectState{Bucket: bucket, Object: object}
+	}
 
 	fn, off, length, err := NewGetObjectReader(rs, oi, opts)
 	if err != nil {
 		return nil, ErrorRespToObjectError(err, bucket, object)
 	}
-	gopts := WarmBackendGetOpts{}
+	gopts := WarmBackendGetOpts{encoded: isTierObjectEncoded(oi)}
 
 	// get correct offsets for object
 	if off >= 0 && length >= 0 {
@@ -403,10 +438,28 @@ func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs
 		gopts.length = length
 	}
 
+	// Restored copies are written to the hot tier and not cached.
+	cache := globalTierReadCache
+	if opts.Transition.RestoreRequest != nil || off < 0 || length < 0 {
+		cache = nil
+	}
+	var cacheKey string
+	if cache != nil {
+		cacheKey = tierCacheKey(oi)
+		if reader, ok := cache.Get(cacheKey, off, length); ok {
+			return fn(reader, h, func() { reader.Close() })
+		}
+	}
+
 	reader, err := tgtClient.Get(ctx, oi.TransitionedObject.Name, remoteVersionID(oi.TransitionedObject.VersionID), gopts)
 	if err != nil {
+		globalTierUsage.recordGet(oi.TransitionedObject.Tier, bucket)
 		return nil, err
 	}
+	reader = globalTierUsage.countGet(oi.TransitionedObject.Tier, bucket, reader)
+	if cache != nil && off == 0 && length == oi.Size {
+		reader = cache.Fill(cacheKey, oi.Size, reader)
+	}
 	closer := func() {
 		reader.Close()
 	}
@@ -745,6 +798,7 @@ func (oi ObjectInfo) ToLifecycleOpts() lifecycle.ObjectOpts {
 	return lifecycle.ObjectOpts{
 		Name:             oi.Name,
 		UserTags:         oi.UserTags,
+		Size:             oi.Size,
 		VersionID:        oi.VersionID,
 		ModTime:          oi.ModTime,
 		IsLatest:         oi.IsLatest,
@@ -754,5 +808,6 @@ func (oi ObjectInfo) ToLifecycleOpts() lifecycle.ObjectOpts {
 		RestoreOngoing:   oi.RestoreOngoing,
 		RestoreExpires:   oi.RestoreExpires,
 		TransitionStatus: oi.TransitionedObject.Status,
+		LastAccessTime:   lastAccessTime(oi.UserDefined),
 	}
 }
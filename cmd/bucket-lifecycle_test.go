@@ -24,9 +24,31 @@ import (
 	"time"
 
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/event"
 	xhttp "github.com/minio/minio/internal/http"
 )
 
+func TestILMExpiryEventName(t *testing.T) {
+	defer func(enabled bool) { globalNotifyInternalEvents = enabled }(globalNotifyInternalEvents)
+
+	testCases := []struct {
+		internalEvents bool
+		deleteMarker   bool
+		expected       event.Name
+	}{
+		{false, false, event.ObjectRemovedDelete},
+		{false, true, event.ObjectRemovedDeleteMarkerCreated},
+		{true, false, event.ObjectLifecycleExpirationDelete},
+		{true, true, event.ObjectLifecycleExpirationDeleteMarkerCreated},
+	}
+	for i, testCase := range testCases {
+		globalNotifyInternalEvents = testCase.internalEvents
+		if got := ilmExpiryEventName(testCase.deleteMarker); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
 // TestParseRestoreObjStatus tests parseRestoreObjStatus
 func TestParseRestoreObjStatus(t *testing.T) {
 	testCases := []struct {
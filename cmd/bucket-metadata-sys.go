@@ -26,9 +26,11 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/bandwidth"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/publicaccess"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
 	"github.com/minio/minio/internal/event"
@@ -136,6 +138,14 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 			return NotImplemented{}
 		}
 		meta.ReplicationConfigXML = configData
+	case bucketPublicAccessBlockConfig:
+		meta.PublicAccessBlockConfigXML = configData
+	case bucketBandwidthScheduleConfig:
+		meta.BandwidthScheduleConfigJSON = configData
+	case bucketTargetsSTSConfig:
+		meta.BucketTargetsSTSConfigJSON = configData
+	case bucketKMSRotationConfig:
+		meta.KMSKeyRotationConfigJSON = configData
 	case bucketTargetsFile:
 		meta.BucketTargetsConfigJSON, meta.BucketTargetsConfigMetaJSON, err = encryptBucketMetadata(meta.Name, configData, kms.Context{
 			bucket:            meta.Name,
@@ -297,6 +307,73 @@ func (sys *BucketMetadataSys) GetSSEConfig(bucket string) (*bucketsse.BucketSSEC
 	return meta.sseConfig, nil
 }
 
+// GetPublicAccessBlockConfig returns configured public access block
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetPublicAccessBlockConfig(bucket string) (*publicaccess.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketPublicAccessBlockNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.publicAccessBlockConfig == nil {
+		return nil, BucketPublicAccessBlockNotFound{Bucket: bucket}
+	}
+	return meta.publicAccessBlockConfig, nil
+}
+
+// GetBandwidthScheduleConfig returns the configured bandwidth schedules
+// of the replication targets, which is empty if there are none.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetBandwidthScheduleConfig(bucket string) (*bandwidth.ScheduleConfig, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return &bandwidth.ScheduleConfig{}, nil
+		}
+		return nil, err
+	}
+	if meta.bandwidthScheduleConfig == nil {
+		return &bandwidth.ScheduleConfig{}, nil
+	}
+	return meta.bandwidthScheduleConfig, nil
+}
+
+// GetBucketTargetsSTSConfig returns the STS settings of the replication
+// targets, which is empty if there are none.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetBucketTargetsSTSConfig(bucket string) (*remoteTargetsSTSConfig, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return &remoteTargetsSTSConfig{}, nil
+		}
+		return nil, err
+	}
+	if meta.bucketTargetsSTSConfig == nil {
+		return &remoteTargetsSTSConfig{}, nil
+	}
+	return meta.bucketTargetsSTSConfig, nil
+}
+
+// GetKMSKeyRotationConfig returns the SSE-KMS key rotation schedule and
+// history of the bucket, which is empty if the key was never rotated.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetKMSKeyRotationConfig(bucket string) (*kmsKeyRotationConfig, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return &kmsKeyRotationConfig{}, nil
+		}
+		return nil, err
+	}
+	if meta.kmsKeyRotationConfig == nil {
+		return &kmsKeyRotationConfig{}, nil
+	}
+	return meta.kmsKeyRotationConfig, nil
+}
+
 // GetPolicyConfig returns configured bucket policy
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetPolicyConfig(bucket string) (*policy.Policy, error) {
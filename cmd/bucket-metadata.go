@@ -31,9 +31,11 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/bandwidth"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/bucket/publicaccess"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
 	"github.com/minio/minio/internal/crypto"
@@ -81,19 +83,27 @@ type BucketMetadata struct {
 	ReplicationConfigXML        []byte
 	BucketTargetsConfigJSON     []byte
 	BucketTargetsConfigMetaJSON []byte
+	PublicAccessBlockConfigXML  []byte
+	BandwidthScheduleConfigJSON []byte
+	BucketTargetsSTSConfigJSON  []byte
+	KMSKeyRotationConfigJSON    []byte
 
 	// Unexported fields. Must be updated atomically.
-	policyConfig           *policy.Policy
-	notificationConfig     *event.Config
-	lifecycleConfig        *lifecycle.Lifecycle
-	objectLockConfig       *objectlock.Config
-	versioningConfig       *versioning.Versioning
-	sseConfig              *bucketsse.BucketSSEConfig
-	taggingConfig          *tags.Tags
-	quotaConfig            *madmin.BucketQuota
-	replicationConfig      *replication.Config
-	bucketTargetConfig     *madmin.BucketTargets
-	bucketTargetConfigMeta map[string]string
+	policyConfig            *policy.Policy
+	notificationConfig      *event.Config
+	lifecycleConfig         *lifecycle.Lifecycle
+	objectLockConfig        *objectlock.Config
+	versioningConfig        *versioning.Versioning
+	sseConfig               *bucketsse.BucketSSEConfig
+	taggingConfig           *tags.Tags
+	quotaConfig             *madmin.BucketQuota
+	replicationConfig       *replication.Config
+	bucketTargetConfig      *madmin.BucketTargets
+	bucketTargetConfigMeta  map[string]string
+	publicAccessBlockConfig *publicaccess.Config
+	bandwidthScheduleConfig *bandwidth.ScheduleConfig
+	bucketTargetsSTSConfig  *remoteTargetsSTSConfig
+	kmsKeyRotationConfig    *kmsKeyRotationConfig
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -250,6 +260,42 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	} else {
 		b.bucketTargetConfig = &madmin.BucketTargets{}
 	}
+
+	if len(b.PublicAccessBlockConfigXML) != 0 {
+		b.publicAccessBlockConfig, err = publicaccess.ParseConfig(bytes.NewReader(b.PublicAccessBlockConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.publicAccessBlockConfig = nil
+	}
+
+	if len(b.BandwidthScheduleConfigJSON) != 0 {
+		b.bandwidthScheduleConfig, err = bandwidth.ParseScheduleConfig(bytes.NewReader(b.BandwidthScheduleConfigJSON))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.bandwidthScheduleConfig = nil
+	}
+
+	if len(b.BucketTargetsSTSConfigJSON) != 0 {
+		b.bucketTargetsSTSConfig, err = parseRemoteTargetsSTSConfig(bytes.NewReader(b.BucketTargetsSTSConfigJSON))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.bucketTargetsSTSConfig = nil
+	}
+
+	if len(b.KMSKeyRotationConfigJSON) != 0 {
+		b.kmsKeyRotationConfig, err = parseKMSKeyRotationConfig(bytes.NewReader(b.KMSKeyRotationConfigJSON))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.kmsKeyRotationConfig = nil
+	}
 	return nil
 }
 
@@ -108,6 +108,30 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 				return
 			}
+		case "PublicAccessBlockConfigXML":
+			z.PublicAccessBlockConfigXML, err = dc.ReadBytes(z.PublicAccessBlockConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "PublicAccessBlockConfigXML")
+				return
+			}
+		case "BandwidthScheduleConfigJSON":
+			z.BandwidthScheduleConfigJSON, err = dc.ReadBytes(z.BandwidthScheduleConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "BandwidthScheduleConfigJSON")
+				return
+			}
+		case "BucketTargetsSTSConfigJSON":
+			z.BucketTargetsSTSConfigJSON, err = dc.ReadBytes(z.BucketTargetsSTSConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "BucketTargetsSTSConfigJSON")
+				return
+			}
+		case "KMSKeyRotationConfigJSON":
+			z.KMSKeyRotationConfigJSON, err = dc.ReadBytes(z.KMSKeyRotationConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "KMSKeyRotationConfigJSON")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -121,9 +145,9 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 14
+	// map header, size 18
 	// write "Name"
-	err = en.Append(0x8e, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	err = en.Append(0xde, 0x0, 0x12, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	if err != nil {
 		return
 	}
@@ -262,15 +286,55 @@ func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 		return
 	}
+	// write "PublicAccessBlockConfigXML"
+	err = en.Append(0xba, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.PublicAccessBlockConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "PublicAccessBlockConfigXML")
+		return
+	}
+	// write "BandwidthScheduleConfigJSON"
+	err = en.Append(0xbb, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.BandwidthScheduleConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "BandwidthScheduleConfigJSON")
+		return
+	}
+	// write "BucketTargetsSTSConfigJSON"
+	err = en.Append(0xba, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x53, 0x54, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.BucketTargetsSTSConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "BucketTargetsSTSConfigJSON")
+		return
+	}
+	// write "KMSKeyRotationConfigJSON"
+	err = en.Append(0xb8, 0x4b, 0x4d, 0x53, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.KMSKeyRotationConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "KMSKeyRotationConfigJSON")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 14
+	// map header, size 18
 	// string "Name"
-	o = append(o, 0x8e, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = append(o, 0xde, 0x0, 0x12, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	o = msgp.AppendString(o, z.Name)
 	// string "Created"
 	o = append(o, 0xa7, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64)
@@ -311,6 +375,18 @@ func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "BucketTargetsConfigMetaJSON"
 	o = append(o, 0xbb, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x4a, 0x53, 0x4f, 0x4e)
 	o = msgp.AppendBytes(o, z.BucketTargetsConfigMetaJSON)
+	// string "PublicAccessBlockConfigXML"
+	o = append(o, 0xba, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.PublicAccessBlockConfigXML)
+	// string "BandwidthScheduleConfigJSON"
+	o = append(o, 0xbb, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.BandwidthScheduleConfigJSON)
+	// string "BucketTargetsSTSConfigJSON"
+	o = append(o, 0xba, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x53, 0x54, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.BucketTargetsSTSConfigJSON)
+	// string "KMSKeyRotationConfigJSON"
+	o = append(o, 0xb8, 0x4b, 0x4d, 0x53, 0x4b, 0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.KMSKeyRotationConfigJSON)
 	return
 }
 
@@ -416,6 +492,30 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 				return
 			}
+		case "PublicAccessBlockConfigXML":
+			z.PublicAccessBlockConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.PublicAccessBlockConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "PublicAccessBlockConfigXML")
+				return
+			}
+		case "BandwidthScheduleConfigJSON":
+			z.BandwidthScheduleConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.BandwidthScheduleConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "BandwidthScheduleConfigJSON")
+				return
+			}
+		case "BucketTargetsSTSConfigJSON":
+			z.BucketTargetsSTSConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.BucketTargetsSTSConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "BucketTargetsSTSConfigJSON")
+				return
+			}
+		case "KMSKeyRotationConfigJSON":
+			z.KMSKeyRotationConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.KMSKeyRotationConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "KMSKeyRotationConfigJSON")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -430,6 +530,6 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketMetadata) Msgsize() (s int) {
-	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON)
+	s = 3 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON) + 27 + msgp.BytesPrefixSize + len(z.PublicAccessBlockConfigXML) + 28 + msgp.BytesPrefixSize + len(z.BandwidthScheduleConfigJSON) + 27 + msgp.BytesPrefixSize + len(z.BucketTargetsSTSConfigJSON) + 25 + msgp.BytesPrefixSize + len(z.KMSKeyRotationConfigJSON)
 	return
 }
@@ -167,6 +167,7 @@ func (api objectAPIHandlers) PutBucketNotificationHandler(w http.ResponseWriter,
 
 	rulesMap := config.ToRulesMap()
 	globalNotificationSys.AddRulesMap(bucketName, rulesMap)
+	globalNotificationSys.SendTestEvent(bucketName, config)
 
 	writeSuccessResponseHeadersOnly(w)
 }
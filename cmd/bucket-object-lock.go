@@ -21,6 +21,8 @@ import (
 	"context"
 	"math"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/minio/minio/internal/auth"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
@@ -359,3 +361,62 @@ func checkPutObjectLockAllowed(ctx context.Context, rq *http.Request, bucket, ob
 func NewBucketObjectLockSys() *BucketObjectLockSys {
 	return &BucketObjectLockSys{}
 }
+
+// replicaLockChangeIsNewer returns true if a retention or legal hold change
+// made on the replication source at srcTimestamp supersedes the change last
+// applied to the replica, recorded at the on-disk timestamp.
+func replicaLockChangeIsNewer(ondiskTimestamp string, srcTimestamp time.Time) bool {
+	if srcTimestamp.IsZero() {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ondiskTimestamp)
+	return err != nil || t.Before(srcTimestamp)
+}
+
+// replicaObjectLockMetadata applies the retention and legal hold of a
+// metadata replication request to meta, the new metadata of the replica.
+// A setting is taken from the request only if it changed on the source
+// after it last changed on the replica, otherwise the setting found in
+// replicaMeta, the current metadata of the replica, is kept. This keeps
+// replicas consistent when changes arrive out of order. A newer retention
+// change without retention removes the retention of the replica.
+func replicaObjectLockMetadata(meta, replicaMeta map[string]string, mode objectlock.RetMode, retainDate objectlock.RetentionDate, legalHold objectlock.ObjectLegalHold, opts ObjectOptions) {
+	modeKey := strings.ToLower(xhttp.AmzObjectLockMode)
+	retainDateKey := strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)
+	legalHoldKey := strings.ToLower(xhttp.AmzObjectLockLegalHold)
+	retentionTimestampKey := ReservedMetadataPrefixLower + ObjectLockRetentionTimestamp
+	legalHoldTimestampKey := ReservedMetadataPrefixLower + ObjectLockLegalHoldTimestamp
+
+	if srcTimestamp := opts.ReplicationSourceRetentionTimestamp; replicaLockChangeIsNewer(replicaMeta[retentionTimestampKey], srcTimestamp) {
+		delete(meta, modeKey)
+		delete(meta, retainDateKey)
+		if mode.Valid() {
+			meta[modeKey] = string(mode)
+			meta[retainDateKey] = retainDate.UTC().Format(iso8601TimeFormat)
+		}
+		meta[retentionTimestampKey] = srcTimestamp.Format(time.RFC3339Nano)
+	} else {
+		delete(meta, modeKey)
+		delete(meta, retainDateKey)
+		if ret := objectlock.GetObjectRetentionMeta(replicaMeta); ret.Mode.Valid() {
+			meta[modeKey] = string(ret.Mode)
+			meta[retainDateKey] = ret.RetainUntilDate.UTC().Format(iso8601TimeFormat)
+		}
+		if ts, ok := replicaMeta[retentionTimestampKey]; ok {
+			meta[retentionTimestampKey] = ts
+		}
+	}
+
+	if srcTimestamp := opts.ReplicationSourceLegalholdTimestamp; legalHold.Status.Valid() && replicaLockChangeIsNewer(replicaMeta[legalHoldTimestampKey], srcTimestamp) {
+		meta[legalHoldKey] = string(legalHold.Status)
+		meta[legalHoldTimestampKey] = srcTimestamp.Format(time.RFC3339Nano)
+	} else {
+		delete(meta, legalHoldKey)
+		if lhold := objectlock.GetObjectLegalHoldMeta(replicaMeta); lhold.Status.Valid() {
+			meta[legalHoldKey] = string(lhold.Status)
+		}
+		if ts, ok := replicaMeta[legalHoldTimestampKey]; ok {
+			meta[legalHoldTimestampKey] = ts
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestReplicaObjectLockMetadata(t *testing.T) {
+	modeKey := strings.ToLower(xhttp.AmzObjectLockMode)
+	retainDateKey := strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)
+	legalHoldKey := strings.ToLower(xhttp.AmzObjectLockLegalHold)
+	retentionTimestampKey := ReservedMetadataPrefixLower + ObjectLockRetentionTimestamp
+	legalHoldTimestampKey := ReservedMetadataPrefixLower + ObjectLockLegalHoldTimestamp
+
+	ondisk := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	older, newer := ondisk.Add(-time.Hour), ondisk.Add(time.Hour)
+	retainUntil := ondisk.Add(24 * time.Hour)
+	replicaMeta := map[string]string{
+		modeKey:               string(objectlock.RetGovernance),
+		retainDateKey:         retainUntil.Format(iso8601TimeFormat),
+		retentionTimestampKey: ondisk.Format(time.RFC3339Nano),
+		legalHoldKey:          string(objectlock.LegalHoldOn),
+		legalHoldTimestampKey: ondisk.Format(time.RFC3339Nano),
+	}
+	compliance := objectlock.RetentionDate{Time: retainUntil.Add(time.Hour)}
+	holdOff := objectlock.ObjectLegalHold{Status: objectlock.LegalHoldOff}
+
+	testCases := []struct {
+		mode      objectlock.RetMode
+		legalHold objectlock.ObjectLegalHold
+		opts      ObjectOptions
+		expected  map[string]string
+	}{
+		// Newer changes on the source are applied.
+		{
+			mode:      objectlock.RetCompliance,
+			legalHold: holdOff,
+			opts:      ObjectOptions{ReplicationSourceRetentionTimestamp: newer, ReplicationSourceLegalholdTimestamp: newer},
+			expected: map[string]string{
+				modeKey:               string(objectlock.RetCompliance),
+				retainDateKey:         compliance.UTC().Format(iso8601TimeFormat),
+				retentionTimestampKey: newer.Format(time.RFC3339Nano),
+				legalHoldKey:          string(objectlock.LegalHoldOff),
+				legalHoldTimestampKey: newer.Format(time.RFC3339Nano),
+			},
+		},
+		// Older changes arriving out of order are ignored.
+		{
+			mode:      objectlock.RetCompliance,
+			legalHold: holdOff,
+			opts:      ObjectOptions{ReplicationSourceRetentionTimestamp: older, ReplicationSourceLegalholdTimestamp: older},
+			expected:  replicaMeta,
+		},
+		// A newer change without retention removes the retention.
+		{
+			legalHold: holdOff,
+			opts:      ObjectOptions{ReplicationSourceRetentionTimestamp: newer},
+			expected: map[string]string{
+				retentionTimestampKey: newer.Format(time.RFC3339Nano),
+				legalHoldKey:          string(objectlock.LegalHoldOn),
+				legalHoldTimestampKey: ondisk.Format(time.RFC3339Nano),
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		meta := map[string]string{}
+		replicaObjectLockMetadata(meta, replicaMeta, testCase.mode, compliance, testCase.legalHold, testCase.opts)
+		if len(meta) != len(testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, meta)
+			continue
+		}
+		for k, v := range testCase.expected {
+			if meta[k] != v {
+				t.Errorf("Test %d: expected %s=%s, got %s", i+1, k, v, meta[k])
+			}
+		}
+	}
+}
@@ -43,6 +43,12 @@ func (sys *PolicySys) Get(bucket string) (*policy.Policy, error) {
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (sys *PolicySys) IsAllowed(args policy.Args) bool {
+	// Public access granted by bucket policies is ignored
+	// for buckets restricting public access.
+	if !args.IsOwner && getPublicAccessBlock(args.BucketName).RestrictPublicBuckets {
+		return false
+	}
+
 	p, err := sys.Get(args.BucketName)
 	if err == nil {
 		return p.IsAllowed(args)
@@ -182,6 +188,7 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 			}
 		}
 	}
+	args[mfaPresentKey.ToKey().Name()] = []string{strconv.FormatBool(isMFAPresent(claims))}
 
 	return args
 }
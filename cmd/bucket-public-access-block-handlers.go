@@ -0,0 +1,209 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/publicaccess"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+const (
+	// Bucket public access block configuration file name.
+	bucketPublicAccessBlockConfig = "public-access-block.xml"
+
+	maxPublicAccessBlockConfigSize = 64 * 1024
+)
+
+// getPublicAccessBlock returns the public access block in effect for
+// the bucket, i.e. the deployment wide settings merged with the
+// settings of the bucket.
+func getPublicAccessBlock(bucket string) publicaccess.Config {
+	globalPublicAccessBlockMu.RLock()
+	cfg := globalPublicAccessBlockConfig
+	globalPublicAccessBlockMu.RUnlock()
+
+	if bucketCfg, err := globalBucketMetadataSys.GetPublicAccessBlockConfig(bucket); err == nil {
+		cfg = cfg.Merge(*bucketCfg)
+	}
+	return cfg
+}
+
+// checkPublicPolicyAllowed returns ErrPublicPolicyBlocked if the
+// policy grants anonymous access to a bucket blocking public policies.
+func checkPublicPolicyAllowed(bucket string, p *policy.Policy) APIErrorCode {
+	if !getPublicAccessBlock(bucket).BlockPublicPolicy {
+		return ErrNone
+	}
+	for _, statement := range p.Statements {
+		if isPublicStatement(statement) {
+			return ErrPublicPolicyBlocked
+		}
+	}
+	return ErrNone
+}
+
+// checkPublicACLAllowed returns ErrPublicACLBlocked if the request
+// sets a public canned ACL on a bucket blocking public ACLs.
+func checkPublicACLAllowed(bucket string, r *http.Request) APIErrorCode {
+	if publicaccess.IsPublicACL(r.Header.Get(xhttp.AmzACL)) && getPublicAccessBlock(bucket).BlockPublicAcls {
+		return ErrPublicACLBlocked
+	}
+	return ErrNone
+}
+
+// PutPublicAccessBlockHandler - Stores the public access block of the bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutPublicAccessBlock.html
+//
+// Public access blocks govern the bucket policy, hence the bucket policy
+// actions authorize managing them.
+func (api objectAPIHandlers) PutPublicAccessBlockHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutPublicAccessBlock")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, err := publicaccess.ParseConfig(io.LimitReader(r.Body, maxPublicAccessBlockConfigSize))
+	if err != nil {
+		apiErr := APIError{
+			Code:           "MalformedXML",
+			Description:    fmt.Sprintf("%s (%s)", errorCodes[ErrMalformedXML].Description, err),
+			HTTPStatusCode: errorCodes[ErrMalformedXML].HTTPStatusCode,
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(cfg)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(bucket, bucketPublicAccessBlockConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetPublicAccessBlockHandler - Returns the public access block of the bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetPublicAccessBlock.html
+func (api objectAPIHandlers) GetPublicAccessBlockHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetPublicAccessBlock")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	var err error
+	if _, err = objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, err := globalBucketMetadataSys.GetPublicAccessBlockConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(cfg)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeletePublicAccessBlockHandler - Removes the public access block of the bucket
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeletePublicAccessBlock.html
+func (api objectAPIHandlers) DeletePublicAccessBlockHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeletePublicAccessBlock")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	var err error
+	if _, err = objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(bucket, bucketPublicAccessBlockConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/bucket/publicaccess"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+func TestPublicAccessBlockEnforcement(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	newAllSubsystems()
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	if err = objLayer.MakeBucketWithLocation(ctx, "photos", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update("photos", bucketPublicAccessBlockConfig,
+		[]byte(`<PublicAccessBlockConfiguration><BlockPublicPolicy>true</BlockPublicPolicy></PublicAccessBlockConfiguration>`)); err != nil {
+		t.Fatal(err)
+	}
+
+	publicPolicy, err := policy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::photos/*"]}]}`), "photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s3Err := checkPublicPolicyAllowed("photos", publicPolicy); s3Err != ErrPublicPolicyBlocked {
+		t.Fatalf("expected public policy to be blocked, got %v", s3Err)
+	}
+	if s3Err := checkPublicPolicyAllowed("other", publicPolicy); s3Err != ErrNone {
+		t.Fatalf("expected public policy to be allowed, got %v", s3Err)
+	}
+
+	r, err := http.NewRequest(http.MethodPut, "http://localhost/photos/image.png", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set(xhttp.AmzACL, "public-read")
+	if s3Err := checkPublicACLAllowed("photos", r); s3Err != ErrNone {
+		t.Fatalf("expected public ACL to be allowed, got %v", s3Err)
+	}
+
+	// Deployment wide settings apply to all buckets.
+	globalPublicAccessBlockMu.Lock()
+	globalPublicAccessBlockConfig = publicaccess.Config{BlockPublicAcls: true, RestrictPublicBuckets: true}
+	globalPublicAccessBlockMu.Unlock()
+	defer func() {
+		globalPublicAccessBlockMu.Lock()
+		globalPublicAccessBlockConfig = publicaccess.Config{}
+		globalPublicAccessBlockMu.Unlock()
+	}()
+
+	if s3Err := checkPublicACLAllowed("photos", r); s3Err != ErrPublicACLBlocked {
+		t.Fatalf("expected public ACL to be blocked, got %v", s3Err)
+	}
+	if globalPolicySys.IsAllowed(policy.Args{Action: policy.GetObjectAction, BucketName: "photos", ObjectName: "image.png"}) {
+		t.Fatal("expected anonymous access to be denied")
+	}
+	if !globalPolicySys.IsAllowed(policy.Args{Action: policy.GetObjectAction, BucketName: "photos", ObjectName: "image.png", IsOwner: true}) {
+		t.Fatal("expected owner access to be allowed")
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/minio/madmin-go"
+)
+
+// azureReplicationTarget replicates to an Azure Blob Storage container.
+// The remote target access key is the storage account name and the
+// secret key the account key. Blob versioning is a storage account
+// setting that must be enabled for prior versions to be retained.
+type azureReplicationTarget struct {
+	containerURL azblob.ContainerURL
+	bucket       string
+}
+
+// azureMetadataKey converts a metadata key to a valid Azure metadata
+// name, which must be a C# identifier.
+func azureMetadataKey(k string) string {
+	key := []byte(strings.ToLower(k))
+	for i, c := range key {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			key[i] = '_'
+		}
+	}
+	if len(key) == 0 || (key[0] >= '0' && key[0] <= '9') {
+		return "_" + string(key)
+	}
+	return string(key)
+}
+
+func azureAccessTier(sc string) azblob.AccessTierType {
+	for _, t := range azblob.PossibleAccessTierTypeValues() {
+		if strings.EqualFold(sc, string(t)) {
+			return t
+		}
+	}
+	return azblob.AccessTierNone
+}
+
+func (az *azureReplicationTarget) Put(ctx context.Context, object string, r io.Reader, length int64, meta nativeObjectMeta) error {
+	blobURL := az.containerURL.NewBlockBlobURL(object)
+	metadata := make(azblob.Metadata, len(meta.UserMeta))
+	for k, v := range meta.UserMeta {
+		metadata[azureMetadataKey(k)] = v
+	}
+	_, err := azblob.UploadStreamToBlockBlob(ctx, r, blobURL, azblob.UploadStreamToBlockBlobOptions{
+		BlobHTTPHeaders: azblob.BlobHTTPHeaders{
+			ContentType:        meta.ContentType,
+			ContentEncoding:    meta.ContentEncoding,
+			ContentLanguage:    meta.ContentLanguage,
+			ContentDisposition: meta.ContentDisposition,
+			CacheControl:       meta.CacheControl,
+		},
+		Metadata: metadata,
+	})
+	if err != nil {
+		return azureToObjectError(err, az.bucket, object)
+	}
+	// Only Azure access tiers are applied, S3 storage classes such as
+	// STANDARD leave the account default tier in place.
+	if tier := azureAccessTier(meta.StorageClass); tier != azblob.AccessTierNone {
+		if _, err = blobURL.SetTier(ctx, tier, azblob.LeaseAccessConditions{}); err != nil {
+			return azureToObjectError(err, az.bucket, object)
+		}
+	}
+	return nil
+}
+
+func (az *azureReplicationTarget) SourceVersion(ctx context.Context, object string) (string, error) {
+	props, err := az.containerURL.NewBlobURL(object).GetProperties(ctx, azblob.BlobAccessConditions{})
+	if err != nil {
+		return "", azureToObjectError(err, az.bucket, object)
+	}
+	return props.NewMetadata()[azureMetadataKey(nativeMetaSourceVersionID)], nil
+}
+
+func (az *azureReplicationTarget) Remove(ctx context.Context, object string) error {
+	_, err := az.containerURL.NewBlobURL(object).Delete(ctx, azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{})
+	return azureToObjectError(err, az.bucket, object)
+}
+
+// RemoveVersion removes the current blob if it was replicated from the
+// source version. Prior blob versions cannot be addressed with this
+// API version and are left to the account's version lifecycle.
+func (az *azureReplicationTarget) RemoveVersion(ctx context.Context, object, srcVersionID string) error {
+	current, err := az.SourceVersion(ctx, object)
+	if err != nil {
+		return err
+	}
+	if current != srcVersionID {
+		return nil
+	}
+	return az.Remove(ctx, object)
+}
+
+func (az *azureReplicationTarget) BucketExists(ctx context.Context) (bool, error) {
+	_, err := az.containerURL.GetProperties(ctx, azblob.LeaseAccessConditions{})
+	if err != nil {
+		if isErrBucketNotFound(azureToObjectError(err, az.bucket)) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Versioned always returns true as blob versioning cannot be queried
+// through the data plane API.
+func (az *azureReplicationTarget) Versioned(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func newAzureReplicationTarget(tcfg *madmin.BucketTarget) (*azureReplicationTarget, error) {
+	creds := tcfg.Credentials
+	if creds == nil {
+		return nil, errors.New("missing Azure credentials")
+	}
+	credential, err := azblob.NewSharedKeyCredential(creds.AccessKey, creds.SecretKey)
+	if err != nil {
+		if _, ok := err.(base64.CorruptInputError); ok {
+			return nil, errors.New("invalid Azure credentials")
+		}
+		return nil, err
+	}
+	endpoint := tcfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("%s.blob.core.windows.net", creds.AccessKey)
+	}
+	scheme := "https"
+	if !tcfg.Secure {
+		scheme = "http"
+	}
+	u, err := url.Parse(fmt.Sprintf("%s://%s", scheme, endpoint))
+	if err != nil {
+		return nil, err
+	}
+	p := azblob.NewPipeline(credential, azblob.PipelineOptions{})
+	return &azureReplicationTarget{
+		containerURL: azblob.NewServiceURL(*u, p).NewContainerURL(tcfg.TargetBucket),
+		bucket:       tcfg.TargetBucket,
+	}, nil
+}
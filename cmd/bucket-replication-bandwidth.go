@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Bandwidth schedules of the replication targets.
+	bucketBandwidthScheduleConfig = "replication-bandwidth-schedule.json"
+
+	bandwidthScheduleInterval = time.Minute
+)
+
+// bandwidthScheduler applies the bandwidth limits of the active time
+// windows of all replication targets to the bucket monitor.
+type bandwidthScheduler struct {
+	mu sync.Mutex
+	// Targets with a limit applied by bucket.
+	applied map[string]map[string]struct{}
+}
+
+var globalBandwidthScheduler = &bandwidthScheduler{
+	applied: make(map[string]map[string]struct{}),
+}
+
+// apply updates the target bandwidth limits of the bucket for time now.
+func (s *bandwidthScheduler) apply(bucket string, now time.Time) {
+	if globalIsGateway || globalBucketMonitor == nil {
+		return
+	}
+	cfg, err := globalBucketMetadataSys.GetBandwidthScheduleConfig(bucket)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied := make(map[string]struct{})
+	for arn, schedule := range cfg.Targets {
+		if limit, ok := schedule.Limit(now); ok {
+			globalBucketMonitor.SetTargetBandwidthLimit(bucket, arn, limit)
+			applied[arn] = struct{}{}
+		}
+	}
+	// Targets outside of their windows fall back to the limit of the
+	// bucket.
+	for arn := range s.applied[bucket] {
+		if _, ok := applied[arn]; !ok {
+			globalBucketMonitor.SetTargetBandwidthLimit(bucket, arn, 0)
+		}
+	}
+	if len(applied) == 0 {
+		delete(s.applied, bucket)
+		return
+	}
+	s.applied[bucket] = applied
+}
+
+// run re-applies the bandwidth schedules of all buckets every minute.
+func (s *bandwidthScheduler) run(ctx context.Context, objAPI ObjectLayer) {
+	ticker := time.NewTicker(bandwidthScheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		buckets, err := objAPI.ListBuckets(ctx)
+		if err != nil {
+			logger.LogIf(ctx, err)
+		}
+		now := UTCNow()
+		for _, bucket := range buckets {
+			s.apply(bucket.Name, now)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// setBandwidthSchedule sets the bandwidth schedule of the replication
+// target of the bucket, removing it if the schedule has no windows.
+func setBandwidthSchedule(ctx context.Context, bucket, arn string, schedule bandwidth.Schedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	if tgt := globalBucketTargetSys.GetRemoteBucketTargetByArn(ctx, bucket, arn); tgt.Empty() {
+		return BucketRemoteTargetNotFound{Bucket: bucket}
+	}
+
+	cur, err := globalBucketMetadataSys.GetBandwidthScheduleConfig(bucket)
+	if err != nil {
+		return err
+	}
+	cfg := bandwidth.ScheduleConfig{Targets: make(map[string]*bandwidth.Schedule, len(cur.Targets)+1)}
+	for tgtArn, s := range cur.Targets {
+		cfg.Targets[tgtArn] = s
+	}
+	if len(schedule.Windows) == 0 {
+		delete(cfg.Targets, arn)
+	} else {
+		cfg.Targets[arn] = &schedule
+	}
+
+	var configData []byte
+	if len(cfg.Targets) > 0 {
+		if configData, err = json.Marshal(cfg); err != nil {
+			return err
+		}
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketBandwidthScheduleConfig, configData); err != nil {
+		return err
+	}
+	globalBandwidthScheduler.apply(bucket, UTCNow())
+	return nil
+}
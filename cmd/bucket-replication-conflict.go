@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/config/conflict"
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	replicationConflictsPrefix = "replication-conflicts"
+
+	// Tag added to the replicated version of a conflict resolved by
+	// keeping both versions, its value is the local version id.
+	replicationConflictTag = "minio-replication-conflict"
+)
+
+// Winners of a replication conflict.
+const (
+	replicationConflictLocal  = "local"
+	replicationConflictRemote = "remote"
+)
+
+var errReplicationConflictEncrypted = errors.New("encrypted versions cannot be re-asserted as the latest version")
+
+// replicationSiteTransport sends the name of the local site along with
+// every replication request, which lets the remote site identify the
+// origin of conflicting writes.
+type replicationSiteTransport struct {
+	http.RoundTripper
+}
+
+func (t replicationSiteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if name := globalSite.Name; name != "" {
+		r = r.Clone(r.Context())
+		r.Header.Set(xhttp.MinIOSourceReplicationSite, name)
+	}
+	return t.RoundTripper.RoundTrip(r)
+}
+
+// ReplicationConflict is a replica that arrived for an object whose
+// latest version was written locally and not replicated yet.
+type ReplicationConflict struct {
+	ID              string    `json:"id"`
+	Bucket          string    `json:"bucket"`
+	Object          string    `json:"object"`
+	LocalVersionID  string    `json:"localVersionId"`
+	LocalModTime    time.Time `json:"localModTime"`
+	RemoteVersionID string    `json:"remoteVersionId"`
+	RemoteModTime   time.Time `json:"remoteModTime"`
+	RemoteSite      string    `json:"remoteSite,omitempty"`
+	Resolution      string    `json:"resolution"`
+	// Winner is the version kept as the latest version, either
+	// "local" or "remote".
+	Winner     string    `json:"winner"`
+	Error      string    `json:"error,omitempty"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+func replicationConflictID(bucket, object, localVersionID, remoteVersionID string) string {
+	return getSHA256Hash([]byte(path.Join(bucket, object, localVersionID, remoteVersionID)))
+}
+
+func replicationConflictsBucketPath(bucket string) string {
+	return path.Join(bucketMetaPrefix, bucket, replicationConflictsPrefix) + SlashSeparator
+}
+
+func replicationConflictPath(bucket, id string) string {
+	return path.Join(bucketMetaPrefix, bucket, replicationConflictsPrefix, id+".json")
+}
+
+// replicationConflictCandidate returns the latest version of the object
+// if the incoming replication request would conflict with it, that is
+// if it was written locally and is not replicated yet.
+func replicationConflictCandidate(ctx context.Context, objAPI ObjectLayer, bucket, object string, r *http.Request) *ObjectInfo {
+	if _, ok := r.Header[xhttp.MinIOSourceReplicationRequest]; !ok {
+		return nil
+	}
+	if !globalBucketVersioningSys.Enabled(bucket) {
+		return nil
+	}
+	oi, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil || oi.DeleteMarker {
+		return nil
+	}
+	switch oi.ReplicationStatus {
+	case replication.Pending, replication.Failed:
+		return &oi
+	}
+	return nil
+}
+
+// checkReplicationConflict resolves the conflict between the local
+// candidate version and the replica just written, if any.
+func checkReplicationConflict(objAPI ObjectLayer, candidate *ObjectInfo, replica ObjectInfo, r *http.Request) {
+	if candidate == nil || candidate.VersionID == replica.VersionID {
+		return
+	}
+	c := ReplicationConflict{
+		ID:              replicationConflictID(replica.Bucket, replica.Name, candidate.VersionID, replica.VersionID),
+		Bucket:          replica.Bucket,
+		Object:          replica.Name,
+		LocalVersionID:  candidate.VersionID,
+		LocalModTime:    candidate.ModTime,
+		RemoteVersionID: replica.VersionID,
+		RemoteModTime:   replica.ModTime,
+		RemoteSite:      r.Header.Get(xhttp.MinIOSourceReplicationSite),
+		DetectedAt:      UTCNow(),
+	}
+	go resolveReplicationConflict(GlobalContext, objAPI, c)
+}
+
+// resolveReplicationConflict applies the configured conflict resolution
+// and records the conflict.
+func resolveReplicationConflict(ctx context.Context, objAPI ObjectLayer, c ReplicationConflict) {
+	globalReplicationConflictMu.RLock()
+	cfg := globalReplicationConflictConfig
+	globalReplicationConflictMu.RUnlock()
+
+	c.Resolution = cfg.Resolution
+	if c.Resolution == "" {
+		c.Resolution = conflict.LastWriterWins
+	}
+
+	// Versions are ordered by modification time, hence the newer
+	// version stays the latest version unless resolved otherwise.
+	c.Winner = replicationConflictLocal
+	if c.RemoteModTime.After(c.LocalModTime) {
+		c.Winner = replicationConflictRemote
+	}
+
+	var err error
+	switch c.Resolution {
+	case conflict.KeepBoth:
+		err = tagReplicationConflict(ctx, objAPI, c)
+	case conflict.SitePriorityWins:
+		// The site with the higher priority re-asserts its version,
+		// which then replicates to the other site.
+		if cfg.LocalWins(globalSite.Name, c.RemoteSite) {
+			if c.Winner == replicationConflictRemote {
+				err = reassertReplicationConflictWinner(ctx, objAPI, c)
+			}
+			c.Winner = replicationConflictLocal
+		} else {
+			c.Winner = replicationConflictRemote
+		}
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		c.Error = err.Error()
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	logger.LogIf(ctx, saveConfig(ctx, objAPI, replicationConflictPath(c.Bucket, c.ID), data))
+}
+
+// tagReplicationConflict tags the replicated version with the id of the
+// conflicting local version.
+func tagReplicationConflict(ctx context.Context, objAPI ObjectLayer, c ReplicationConflict) error {
+	opts := ObjectOptions{VersionID: c.RemoteVersionID}
+	oi, err := objAPI.GetObjectInfo(ctx, c.Bucket, c.Object, opts)
+	if err != nil {
+		return err
+	}
+	t, err := tags.ParseObjectTags(oi.UserTags)
+	if err != nil {
+		return err
+	}
+	if err = t.Set(replicationConflictTag, c.LocalVersionID); err != nil {
+		return err
+	}
+	_, err = objAPI.PutObjectTags(ctx, c.Bucket, c.Object, t.String(), opts)
+	return err
+}
+
+// reassertReplicationConflictWinner writes the local version again as
+// the latest version and schedules its replication.
+func reassertReplicationConflictWinner(ctx context.Context, objAPI ObjectLayer, c ReplicationConflict) error {
+	gr, err := objAPI.GetObjectNInfo(ctx, c.Bucket, c.Object, nil, http.Header{}, noLock, ObjectOptions{VersionID: c.LocalVersionID})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	oi := gr.ObjInfo
+	if _, encrypted := crypto.IsEncrypted(oi.UserDefined); encrypted {
+		return errReplicationConflictEncrypted
+	}
+	size, err := oi.GetActualSize()
+	if err != nil {
+		return err
+	}
+	hr, err := hash.NewReader(gr, size, "", "", size)
+	if err != nil {
+		return err
+	}
+
+	metadata := make(map[string]string, len(oi.UserDefined))
+	for k, v := range oi.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) || strings.EqualFold(k, xhttp.AmzBucketReplicationStatus) {
+			continue
+		}
+		metadata[k] = v
+	}
+	if oi.UserTags != "" {
+		metadata[xhttp.AmzObjectTagging] = oi.UserTags
+	}
+
+	opts := ObjectOptions{
+		Versioned:   true,
+		UserDefined: metadata,
+	}
+	dsc := mustReplicate(ctx, c.Bucket, c.Object, getMustReplicateOptions(ObjectInfo{
+		UserDefined: metadata,
+	}, replication.ObjectReplicationType, opts))
+	if dsc.ReplicateAny() {
+		metadata[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+		metadata[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+	}
+	objInfo, err := objAPI.PutObject(ctx, c.Bucket, c.Object, NewPutObjReader(hr), opts)
+	if err != nil {
+		return err
+	}
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), objAPI, dsc, replication.ObjectReplicationType)
+	}
+	return nil
+}
+
+func loadReplicationConflict(ctx context.Context, objAPI ObjectLayer, bucket, id string) (*ReplicationConflict, error) {
+	data, err := readConfig(ctx, objAPI, replicationConflictPath(bucket, id))
+	if err != nil {
+		return nil, err
+	}
+	var c ReplicationConflict
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// listReplicationConflicts returns up to max recorded conflicts of
+// objects with the prefix, all if max is not positive.
+func listReplicationConflicts(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, max int) ([]ReplicationConflict, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	conflicts := []ReplicationConflict{}
+	for item := range listIAMConfigItems(ctx, objAPI, replicationConflictsBucketPath(bucket)) {
+		if item.Err != nil {
+			return nil, item.Err
+		}
+		c, err := loadReplicationConflict(ctx, objAPI, bucket, strings.TrimSuffix(item.Item, ".json"))
+		if errors.Is(err, errConfigNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(c.Object, prefix) {
+			continue
+		}
+		conflicts = append(conflicts, *c)
+		if max > 0 && len(conflicts) >= max {
+			break
+		}
+	}
+	return conflicts, nil
+}
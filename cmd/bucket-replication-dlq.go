@@ -0,0 +1,357 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	replicationDLQPrefix = "replication-dlq"
+
+	// Number of most recent failed attempts kept per entry.
+	replicationDLQMaxAttempts = 10
+)
+
+// Replication operations recorded in the dead-letter queue.
+const (
+	replicationDLQOpObject       = "object"
+	replicationDLQOpDeleteMarker = "delete-marker"
+	replicationDLQOpVersionPurge = "version-purge"
+)
+
+// ReplicationDLQAttempt is a failed attempt of a dead-lettered
+// replication operation.
+type ReplicationDLQAttempt struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+}
+
+// ReplicationDLQEntry is a replication operation that kept failing
+// after being retried. Entries are removed once the operation
+// completes, whether it is retried through the admin API, the MRF
+// queue or the scanner.
+type ReplicationDLQEntry struct {
+	ID           string                  `json:"id"`
+	Bucket       string                  `json:"bucket"`
+	Object       string                  `json:"object"`
+	VersionID    string                  `json:"versionId,omitempty"`
+	TargetARN    string                  `json:"targetArn"`
+	Op           string                  `json:"op"`
+	Failures     int                     `json:"failures"`
+	FirstFailure time.Time               `json:"firstFailure"`
+	LastFailure  time.Time               `json:"lastFailure"`
+	Attempts     []ReplicationDLQAttempt `json:"attempts"`
+}
+
+// replicationDLQFilter selects entries of the dead-letter queue of a
+// bucket, empty fields match all entries.
+type replicationDLQFilter struct {
+	ID     string
+	ARN    string
+	Prefix string
+}
+
+func (f replicationDLQFilter) matches(e ReplicationDLQEntry) bool {
+	return (f.ID == "" || f.ID == e.ID) &&
+		(f.ARN == "" || f.ARN == e.TargetARN) &&
+		strings.HasPrefix(e.Object, f.Prefix)
+}
+
+func replicationDLQEntryID(bucket, object, versionID, arn string) string {
+	return getSHA256Hash([]byte(path.Join(bucket, object, versionID, arn)))
+}
+
+func replicationDLQBucketPath(bucket string) string {
+	return path.Join(bucketMetaPrefix, bucket, replicationDLQPrefix) + SlashSeparator
+}
+
+func replicationDLQEntryPath(bucket, id string) string {
+	return path.Join(bucketMetaPrefix, bucket, replicationDLQPrefix, id+".json")
+}
+
+func loadReplicationDLQEntry(ctx context.Context, objAPI ObjectLayer, bucket, id string) (*ReplicationDLQEntry, error) {
+	data, err := readConfig(ctx, objAPI, replicationDLQEntryPath(bucket, id))
+	if err != nil {
+		return nil, err
+	}
+	var e ReplicationDLQEntry
+	if err = json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// addReplicationDLQEntry records a failed attempt of the replication
+// operation, creating its entry if needed.
+func addReplicationDLQEntry(ctx context.Context, objAPI ObjectLayer, e ReplicationDLQEntry, failure error) error {
+	e.ID = replicationDLQEntryID(e.Bucket, e.Object, e.VersionID, e.TargetARN)
+	now := UTCNow()
+	cur, err := loadReplicationDLQEntry(ctx, objAPI, e.Bucket, e.ID)
+	switch {
+	case err == nil:
+		e.Failures = cur.Failures
+		e.FirstFailure = cur.FirstFailure
+		e.Attempts = cur.Attempts
+	case errors.Is(err, errConfigNotFound):
+		e.FirstFailure = now
+	default:
+		return err
+	}
+
+	msg := "replication failed"
+	if failure != nil {
+		msg = failure.Error()
+	}
+	e.Failures++
+	e.LastFailure = now
+	e.Attempts = append(e.Attempts, ReplicationDLQAttempt{Time: now, Error: msg})
+	if len(e.Attempts) > replicationDLQMaxAttempts {
+		e.Attempts = e.Attempts[len(e.Attempts)-replicationDLQMaxAttempts:]
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, replicationDLQEntryPath(e.Bucket, e.ID), data)
+}
+
+// removeReplicationDLQEntry removes the entry of a replication
+// operation that completed.
+func removeReplicationDLQEntry(ctx context.Context, objAPI ObjectLayer, bucket, object, versionID, arn string) error {
+	err := deleteConfig(ctx, objAPI, replicationDLQEntryPath(bucket, replicationDLQEntryID(bucket, object, versionID, arn)))
+	if errors.Is(err, errConfigNotFound) {
+		return nil
+	}
+	return err
+}
+
+// updateReplicationDLQ records the outcome of an object replication.
+// Failures are dead-lettered once the operation has been retried,
+// completions of previously failed operations clear their entry.
+func updateReplicationDLQ(ctx context.Context, objAPI ObjectLayer, ri ReplicateObjectInfo, rinfos replicatedInfos) {
+	for _, rinfo := range rinfos.Targets {
+		if rinfo.Empty() {
+			continue
+		}
+		var err error
+		switch {
+		case rinfo.ReplicationStatus == replication.Completed && rinfo.PrevReplicationStatus == replication.Failed:
+			err = removeReplicationDLQEntry(ctx, objAPI, ri.Bucket, ri.Name, ri.VersionID, rinfo.Arn)
+		case rinfo.ReplicationStatus == replication.Failed && ri.RetryCount >= 1:
+			err = addReplicationDLQEntry(ctx, objAPI, ReplicationDLQEntry{
+				Bucket:    ri.Bucket,
+				Object:    ri.Name,
+				VersionID: ri.VersionID,
+				TargetARN: rinfo.Arn,
+				Op:        replicationDLQOpObject,
+			}, rinfo.Err)
+		}
+		logger.LogIf(ctx, err)
+	}
+}
+
+// updateReplicationDeleteDLQ records the outcome of a delete
+// replication. Deletes are not re-queued on failure, so they are
+// dead-lettered when a heal attempt fails.
+func updateReplicationDeleteDLQ(ctx context.Context, objAPI ObjectLayer, dobj DeletedObjectReplicationInfo, rinfos replicatedInfos) {
+	op, versionID := replicationDLQOpDeleteMarker, dobj.DeleteMarkerVersionID
+	if dobj.VersionID != "" {
+		op, versionID = replicationDLQOpVersionPurge, dobj.VersionID
+	}
+	for _, rinfo := range rinfos.Targets {
+		if rinfo.Empty() {
+			continue
+		}
+		completed := rinfo.ReplicationStatus == replication.Completed
+		failed := rinfo.ReplicationStatus == replication.Failed
+		prevFailed := rinfo.PrevReplicationStatus == replication.Failed
+		if dobj.VersionID != "" {
+			completed = rinfo.VersionPurgeStatus == Complete
+			failed = rinfo.VersionPurgeStatus == Failed
+			prevFailed = dobj.ReplicationState.PurgeTargets[rinfo.Arn] == Failed
+		}
+		var err error
+		switch {
+		case completed && prevFailed:
+			err = removeReplicationDLQEntry(ctx, objAPI, dobj.Bucket, dobj.ObjectName, versionID, rinfo.Arn)
+		case failed && dobj.OpType == replication.HealReplicationType:
+			err = addReplicationDLQEntry(ctx, objAPI, ReplicationDLQEntry{
+				Bucket:    dobj.Bucket,
+				Object:    dobj.ObjectName,
+				VersionID: versionID,
+				TargetARN: rinfo.Arn,
+				Op:        op,
+			}, rinfo.Err)
+		}
+		logger.LogIf(ctx, err)
+	}
+}
+
+// walkReplicationDLQ calls fn on all entries of the dead-letter queue
+// of the bucket matching the filter, until fn returns false.
+func walkReplicationDLQ(ctx context.Context, objAPI ObjectLayer, bucket string, filter replicationDLQFilter, fn func(ReplicationDLQEntry) bool) error {
+	if filter.ID != "" {
+		e, err := loadReplicationDLQEntry(ctx, objAPI, bucket, filter.ID)
+		if errors.Is(err, errConfigNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if filter.matches(*e) {
+			fn(*e)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for item := range listIAMConfigItems(ctx, objAPI, replicationDLQBucketPath(bucket)) {
+		if item.Err != nil {
+			return item.Err
+		}
+		e, err := loadReplicationDLQEntry(ctx, objAPI, bucket, strings.TrimSuffix(item.Item, ".json"))
+		if errors.Is(err, errConfigNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if filter.matches(*e) && !fn(*e) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// listReplicationDLQ returns up to max dead-lettered replication
+// operations of the bucket, all if max is not positive.
+func listReplicationDLQ(ctx context.Context, objAPI ObjectLayer, bucket string, filter replicationDLQFilter, max int) ([]ReplicationDLQEntry, error) {
+	entries := []ReplicationDLQEntry{}
+	err := walkReplicationDLQ(ctx, objAPI, bucket, filter, func(e ReplicationDLQEntry) bool {
+		entries = append(entries, e)
+		return max <= 0 || len(entries) < max
+	})
+	return entries, err
+}
+
+// purgeReplicationDLQ removes the matching entries without retrying
+// them and returns the number of entries removed.
+func purgeReplicationDLQ(ctx context.Context, objAPI ObjectLayer, bucket string, filter replicationDLQFilter) (n int, err error) {
+	var entries []ReplicationDLQEntry
+	if err = walkReplicationDLQ(ctx, objAPI, bucket, filter, func(e ReplicationDLQEntry) bool {
+		entries = append(entries, e)
+		return true
+	}); err != nil {
+		return 0, err
+	}
+	for _, e := range entries {
+		if err = deleteConfig(ctx, objAPI, replicationDLQEntryPath(bucket, e.ID)); err != nil && !errors.Is(err, errConfigNotFound) {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// retryReplicationDLQ re-queues the matching entries for replication
+// and returns the number of operations queued. Entries stay in the
+// queue until the operation completes, entries of versions that no
+// longer exist or are already replicated are removed.
+func retryReplicationDLQ(ctx context.Context, objAPI ObjectLayer, bucket string, filter replicationDLQFilter) (n int, err error) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+	rcfg := replicationConfig{Config: cfg, remotes: tgts}
+
+	var entries []ReplicationDLQEntry
+	if err = walkReplicationDLQ(ctx, objAPI, bucket, filter, func(e ReplicationDLQEntry) bool {
+		entries = append(entries, e)
+		return true
+	}); err != nil {
+		return 0, err
+	}
+
+	for _, e := range entries {
+		// Delete markers and versions pending purge are returned
+		// along with a method not allowed error.
+		oi, err := objAPI.GetObjectInfo(ctx, bucket, e.Object, ObjectOptions{VersionID: e.VersionID})
+		if err != nil && !isErrMethodNotAllowed(err) {
+			if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+				return n, err
+			}
+			if err = deleteConfig(ctx, objAPI, replicationDLQEntryPath(bucket, e.ID)); err != nil && !errors.Is(err, errConfigNotFound) {
+				return n, err
+			}
+			continue
+		}
+
+		roi := getHealReplicateObjectInfo(oi, rcfg)
+		if oi.DeleteMarker || !oi.VersionPurgeStatus.Empty() {
+			versionID, dmVersionID := "", ""
+			if oi.VersionPurgeStatus.Empty() {
+				dmVersionID = oi.VersionID
+			} else {
+				versionID = oi.VersionID
+			}
+			globalReplicationPool.queueReplicaDeleteTask(DeletedObjectReplicationInfo{
+				DeletedObject: DeletedObject{
+					ObjectName:            oi.Name,
+					DeleteMarkerVersionID: dmVersionID,
+					VersionID:             versionID,
+					ReplicationState:      roi.getReplicationState(roi.Dsc.String(), versionID, true),
+					DeleteMarkerMTime:     DeleteMarkerMTime{oi.ModTime},
+					DeleteMarker:          oi.DeleteMarker,
+				},
+				Bucket:    bucket,
+				OpType:    replication.HealReplicationType,
+				TargetArn: e.TargetARN,
+			})
+			n++
+			continue
+		}
+
+		if roi.TargetReplicationStatus(e.TargetARN) == replication.Completed {
+			if err = deleteConfig(ctx, objAPI, replicationDLQEntryPath(bucket, e.ID)); err != nil && !errors.Is(err, errConfigNotFound) {
+				return n, err
+			}
+			continue
+		}
+		roi.OpType = replication.HealReplicationType
+		if err = globalReplicationPool.queueReplicaTaskWait(ctx, roi); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestReplicationDLQ(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objAPI, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objAPI.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	add := func(object, arn string, failure error) {
+		t.Helper()
+		if err := addReplicationDLQEntry(ctx, objAPI, ReplicationDLQEntry{
+			Bucket:    "bucket",
+			Object:    object,
+			VersionID: "v1",
+			TargetARN: arn,
+			Op:        replicationDLQOpObject,
+		}, failure); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < replicationDLQMaxAttempts+2; i++ {
+		add("photos/a.jpg", "arn1", fmt.Errorf("attempt %d", i))
+	}
+	add("photos/a.jpg", "arn2", nil)
+	add("docs/b.txt", "arn1", errors.New("target offline"))
+
+	entries, err := listReplicationDLQ(ctx, objAPI, "bucket", replicationDLQFilter{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	entries, err = listReplicationDLQ(ctx, objAPI, "bucket", replicationDLQFilter{ARN: "arn1", Prefix: "photos/"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Failures != replicationDLQMaxAttempts+2 || len(e.Attempts) != replicationDLQMaxAttempts {
+		t.Fatalf("unexpected attempt history: %d failures, %d attempts", e.Failures, len(e.Attempts))
+	}
+	if last := e.Attempts[len(e.Attempts)-1].Error; last != fmt.Sprintf("attempt %d", replicationDLQMaxAttempts+1) {
+		t.Fatalf("unexpected last attempt error %q", last)
+	}
+
+	if err = removeReplicationDLQEntry(ctx, objAPI, "bucket", "photos/a.jpg", "v1", "arn2"); err != nil {
+		t.Fatal(err)
+	}
+	// Removing an entry that does not exist is not an error.
+	if err = removeReplicationDLQEntry(ctx, objAPI, "bucket", "photos/a.jpg", "v1", "arn2"); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := purgeReplicationDLQ(ctx, objAPI, "bucket", replicationDLQFilter{ID: e.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 purged entry, got %d", n)
+	}
+	entries, err = listReplicationDLQ(ctx, objAPI, "bucket", replicationDLQFilter{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Object != "docs/b.txt" {
+		t.Fatalf("unexpected remaining entries %v", entries)
+	}
+}
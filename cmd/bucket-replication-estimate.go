@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/crypto"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// replicationTargetEstimate is the work a replication configuration
+// would queue for one of its targets.
+type replicationTargetEstimate struct {
+	Arn string `json:"arn"`
+	// Versions and delete markers that would need replication.
+	Versions      int64 `json:"versions"`
+	DeleteMarkers int64 `json:"deleteMarkers"`
+	Bytes         int64 `json:"bytes"`
+	// Versions matching the rules that are already replicated.
+	Replicated int64 `json:"replicated"`
+	// Bandwidth in bytes per second used for the ETA, which is the
+	// bandwidth limit of the target or the current replication
+	// bandwidth of the bucket if it is unlimited.
+	Bandwidth int64 `json:"bandwidth"`
+	// Estimated time to replicate Bytes, omitted if no bandwidth is
+	// known.
+	ETASeconds int64 `json:"etaSeconds,omitempty"`
+}
+
+// replicationEstimate is the result of a replication dry-run.
+type replicationEstimate struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	versionScan
+	Targets []replicationTargetEstimate `json:"targets"`
+}
+
+// replicationEstimateOpts returns the replication options of an
+// existing version oi to the target.
+func replicationEstimateOpts(oi ObjectInfo, arn string) replication.ObjectOpts {
+	opts := replication.ObjectOpts{
+		Name:           oi.Name,
+		UserTags:       oi.UserTags,
+		UserMetadata:   oi.UserDefined,
+		DeleteMarker:   oi.DeleteMarker,
+		SSEC:           crypto.SSEC.IsEncrypted(oi.UserDefined),
+		OpType:         replication.ObjectReplicationType,
+		Replica:        oi.ReplicationStatus == replication.Replica,
+		ExistingObject: true,
+		TargetArn:      arn,
+	}
+	if opts.UserTags == "" {
+		opts.UserTags = oi.UserDefined[xhttp.AmzObjectTagging]
+	}
+	if oi.DeleteMarker {
+		opts.OpType = replication.DeleteReplicationType
+	}
+	return opts
+}
+
+// estimateVersion adds the version to the estimate of the target.
+func (e *replicationTargetEstimate) estimateVersion(cfg *replication.Config, oi ObjectInfo) {
+	opts := replicationEstimateOpts(oi, e.Arn)
+	if len(cfg.FilterActionableRules(opts)) == 0 {
+		return
+	}
+	status := replicationStatusesMap(oi.ReplicationStatusInternal)[e.Arn]
+	if status == "" && cfg.RoleArn == e.Arn {
+		status = oi.ReplicationStatus
+	}
+	if status == replication.Completed {
+		e.Replicated++
+		return
+	}
+	if !cfg.Replicate(opts) {
+		return
+	}
+	if oi.DeleteMarker {
+		e.DeleteMarkers++
+		return
+	}
+	e.Versions++
+	e.Bytes += oi.Size
+}
+
+// estimate sets the bandwidth and ETA of the target.
+func (e *replicationTargetEstimate) estimate(bucket string) {
+	if globalBucketMonitor != nil {
+		e.Bandwidth = globalBucketMonitor.Limit(bucket, e.Arn)
+		if e.Bandwidth == 0 {
+			e.Bandwidth = int64(globalBucketMonitor.CurrentBandwidth(bucket))
+		}
+	}
+	if e.Bandwidth > 0 {
+		e.ETASeconds = (e.Bytes + e.Bandwidth - 1) / e.Bandwidth
+	}
+}
+
+// estimateReplication scans up to maxVersions versions under prefix
+// and reports, for each target of cfg, the versions and bytes that
+// would need replication if cfg were the replication configuration of
+// the bucket. No replication work is queued.
+func estimateReplication(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, cfg *replication.Config, maxVersions int64) (est replicationEstimate, err error) {
+	est.Bucket = bucket
+	est.Prefix = prefix
+	targets := make(map[string]*replicationTargetEstimate)
+	for _, arn := range cfg.FilterTargetArns(replication.ObjectOpts{OpType: replication.ResyncReplicationType}) {
+		targets[arn] = &replicationTargetEstimate{Arn: arn}
+	}
+
+	err = est.scanVersions(ctx, objAPI, bucket, prefix, maxVersions, func(oi ObjectInfo) {
+		for _, tgt := range targets {
+			tgt.estimateVersion(cfg, oi)
+		}
+	})
+	if err != nil {
+		return est, err
+	}
+
+	est.Targets = make([]replicationTargetEstimate, 0, len(targets))
+	for _, tgt := range targets {
+		tgt.estimate(bucket)
+		est.Targets = append(est.Targets, *tgt)
+	}
+	sort.Slice(est.Targets, func(i, j int) bool {
+		return est.Targets[i].Arn < est.Targets[j].Arn
+	})
+	return est, nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/crypto"
+)
+
+func TestReplicationTargetEstimate(t *testing.T) {
+	arn := "arn:minio:replication::id:target"
+	cfg, err := replication.ParseConfig(bytes.NewReader([]byte(`<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication><Priority>1</Priority><Filter><Prefix>docs/</Prefix></Filter><Destination><Bucket>` + arn + `</Bucket></Destination></Rule></ReplicationConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions := []ObjectInfo{
+		{Name: "docs/a", Size: 100},
+		{Name: "docs/b", Size: 200, ReplicationStatusInternal: arn + "=FAILED;"},
+		{Name: "docs/c", Size: 300, ReplicationStatusInternal: arn + "=COMPLETED;"},
+		{Name: "docs/d", DeleteMarker: true},
+		{Name: "docs/e", Size: 400, UserDefined: map[string]string{crypto.MetaSealedKeySSEC: "key"}},
+		{Name: "other/f", Size: 500},
+	}
+	e := &replicationTargetEstimate{Arn: arn}
+	for _, oi := range versions {
+		e.estimateVersion(cfg, oi)
+	}
+	if e.Versions != 2 || e.Bytes != 300 || e.DeleteMarkers != 1 || e.Replicated != 1 {
+		t.Fatalf("unexpected estimate %+v", e)
+	}
+
+	e.Bandwidth = 0
+	e.estimate("bucket")
+	if e.ETASeconds != 0 {
+		t.Fatalf("expected no ETA without bandwidth, got %d", e.ETASeconds)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/minio/madmin-go"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	xioutil "github.com/minio/minio/internal/ioutil"
+)
+
+const gcsDefaultEndpoint = "storage.googleapis.com"
+
+// gcsReplicationTarget replicates to a Google Cloud Storage bucket. The
+// remote target secret key holds the base64 encoded service account
+// credentials JSON. Object versions map to GCS object generations.
+type gcsReplicationTarget struct {
+	client *storage.Client
+	bucket string
+}
+
+func (gcs *gcsReplicationTarget) Put(ctx context.Context, object string, r io.Reader, length int64, meta nativeObjectMeta) error {
+	// Cancelling the context aborts the upload on copy failures.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := gcs.client.Bucket(gcs.bucket).Object(object).NewWriter(ctx)
+	w.ContentType = meta.ContentType
+	w.ContentEncoding = meta.ContentEncoding
+	w.ContentLanguage = meta.ContentLanguage
+	w.ContentDisposition = meta.ContentDisposition
+	w.CacheControl = meta.CacheControl
+	w.Metadata = meta.UserMeta
+	// S3 storage classes have no GCS equivalent and leave the bucket
+	// default storage class in place.
+	switch meta.StorageClass {
+	case "", "STANDARD", "REDUCED_REDUNDANCY":
+	default:
+		w.StorageClass = meta.StorageClass
+	}
+	if _, err := xioutil.Copy(w, r); err != nil {
+		return gcsToObjectError(err, gcs.bucket, object)
+	}
+	return gcsToObjectError(w.Close(), gcs.bucket, object)
+}
+
+func (gcs *gcsReplicationTarget) SourceVersion(ctx context.Context, object string) (string, error) {
+	attrs, err := gcs.client.Bucket(gcs.bucket).Object(object).Attrs(ctx)
+	if err != nil {
+		return "", gcsToObjectError(err, gcs.bucket, object)
+	}
+	return attrs.Metadata[nativeMetaSourceVersionID], nil
+}
+
+func (gcs *gcsReplicationTarget) Remove(ctx context.Context, object string) error {
+	err := gcs.client.Bucket(gcs.bucket).Object(object).Delete(ctx)
+	return gcsToObjectError(err, gcs.bucket, object)
+}
+
+// RemoveVersion deletes the generations of object that were replicated
+// from the source version.
+func (gcs *gcsReplicationTarget) RemoveVersion(ctx context.Context, object, srcVersionID string) error {
+	bkt := gcs.client.Bucket(gcs.bucket)
+	it := bkt.Objects(ctx, &storage.Query{
+		Prefix:   object,
+		Versions: true,
+	})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return gcsToObjectError(err, gcs.bucket, object)
+		}
+		if attrs.Name != object || attrs.Metadata[nativeMetaSourceVersionID] != srcVersionID {
+			continue
+		}
+		if err = bkt.Object(object).Generation(attrs.Generation).Delete(ctx); err != nil {
+			return gcsToObjectError(err, gcs.bucket, object)
+		}
+	}
+}
+
+func (gcs *gcsReplicationTarget) BucketExists(ctx context.Context) (bool, error) {
+	_, err := gcs.client.Bucket(gcs.bucket).Attrs(ctx)
+	if err != nil {
+		if err == storage.ErrBucketNotExist {
+			return false, nil
+		}
+		return false, gcsToObjectError(err, gcs.bucket)
+	}
+	return true, nil
+}
+
+func (gcs *gcsReplicationTarget) Versioned(ctx context.Context) (bool, error) {
+	attrs, err := gcs.client.Bucket(gcs.bucket).Attrs(ctx)
+	if err != nil {
+		return false, gcsToObjectError(err, gcs.bucket)
+	}
+	return attrs.VersioningEnabled, nil
+}
+
+func newGCSReplicationTarget(tcfg *madmin.BucketTarget) (*gcsReplicationTarget, error) {
+	if tcfg.Credentials == nil {
+		return nil, errors.New("missing GCS credentials")
+	}
+	credsJSON, err := base64.URLEncoding.DecodeString(tcfg.Credentials.SecretKey)
+	if err != nil {
+		return nil, errors.New("invalid GCS credentials")
+	}
+	opts := []option.ClientOption{
+		option.WithCredentialsJSON(credsJSON),
+		option.WithScopes(storage.ScopeReadWrite),
+	}
+	if tcfg.Endpoint != "" && tcfg.Endpoint != gcsDefaultEndpoint {
+		opts = append(opts, option.WithEndpoint(fmt.Sprintf("%s/storage/v1/", tcfg.URL())))
+	}
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &gcsReplicationTarget{client: client, bucket: tcfg.TargetBucket}, nil
+}
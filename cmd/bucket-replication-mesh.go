@@ -0,0 +1,449 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/replication"
+	sreplication "github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/crypto"
+)
+
+// Maximum number of unconverged versions reported by a convergence check.
+const maxUnconvergedVersions = 1000
+
+// replicationPeer is a remote bucket taking part in an active-active
+// replication mesh.
+type replicationPeer struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	// Bucket defaults to the name of the local bucket.
+	Bucket string `json:"bucket,omitempty"`
+}
+
+// error returned when the mesh peers are not valid
+var errReplicationMeshInvalid = AdminError{
+	Code:       "XMinioAdminInvalidReplicationMesh",
+	Message:    "Replication mesh peers must have a unique name and a valid endpoint",
+	StatusCode: http.StatusBadRequest,
+}
+
+func validateReplicationPeers(peers []replicationPeer) error {
+	if len(peers) == 0 {
+		return errReplicationMeshInvalid
+	}
+	names := make(map[string]struct{}, len(peers))
+	for _, peer := range peers {
+		if peer.Name == "" {
+			return errReplicationMeshInvalid
+		}
+		if _, ok := names[peer.Name]; ok {
+			return errReplicationMeshInvalid
+		}
+		names[peer.Name] = struct{}{}
+		if u, err := url.Parse(peer.Endpoint); err != nil || u.Host == "" {
+			return errReplicationMeshInvalid
+		}
+	}
+	return nil
+}
+
+// configureReplicationMesh replicates the local bucket to all peers. Only
+// the local site is configured, the peers are not contacted: a mesh is
+// complete once this has been called on every site with the other sites
+// as peers. Replicas are never replicated onward, so each site
+// replicates only the versions it created to every other site and no
+// replication loops are possible.
+func configureReplicationMesh(ctx context.Context, bucket string, peers []replicationPeer) error {
+	if err := validateReplicationPeers(peers); err != nil {
+		return err
+	}
+	for _, peer := range peers {
+		if err := configureReplicationPeer(ctx, bucket, "mesh-"+peer.Name, peer); err != nil {
+			return fmt.Errorf("%s: %w", peer.Name, err)
+		}
+	}
+	return nil
+}
+
+// configureReplicationPeer creates a bucket remote target for the peer
+// unless it exists and sets up a replication rule with the given ID
+// replicating everything to it, an existing rule with the same ID is
+// replaced.
+func configureReplicationPeer(ctx context.Context, bucket, ruleID string, peer replicationPeer) error {
+	targetBucket := peer.Bucket
+	if targetBucket == "" {
+		targetBucket = bucket
+	}
+	ep, err := url.Parse(peer.Endpoint)
+	if err != nil {
+		return err
+	}
+	targets := globalBucketTargetSys.ListTargets(ctx, bucket, string(madmin.ReplicationService))
+	targetARN := ""
+	for _, target := range targets {
+		if target.SourceBucket == bucket &&
+			target.TargetBucket == targetBucket &&
+			target.Endpoint == ep.Host &&
+			target.Secure == (ep.Scheme == "https") &&
+			target.Type == madmin.ReplicationService {
+			targetARN = target.Arn
+			break
+		}
+	}
+	if targetARN == "" {
+		bucketTarget := madmin.BucketTarget{
+			SourceBucket: bucket,
+			Endpoint:     ep.Host,
+			Credentials: &madmin.Credentials{
+				AccessKey: peer.AccessKey,
+				SecretKey: peer.SecretKey,
+			},
+			TargetBucket:    targetBucket,
+			Secure:          ep.Scheme == "https",
+			API:             "s3v4",
+			Type:            madmin.ReplicationService,
+			Region:          "",
+			ReplicationSync: false,
+		}
+		bucketTarget.Arn = globalBucketTargetSys.getRemoteARN(bucket, &bucketTarget)
+		err := globalBucketTargetSys.SetTarget(ctx, bucket, &bucketTarget, false)
+		if err != nil {
+			return fmt.Errorf("Bucket target creation error: %w", err)
+		}
+		targets, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		tgtBytes, err := json.Marshal(&targets)
+		if err != nil {
+			return err
+		}
+		if err = globalBucketMetadataSys.Update(bucket, bucketTargetsFile, tgtBytes); err != nil {
+			return err
+		}
+		targetARN = bucketTarget.Arn
+	}
+
+	// Create bucket replication rule to this peer.
+
+	// To add the bucket replication rule, we fetch the current
+	// server configuration, and convert it to minio-go's
+	// replication configuration type (by converting to xml and
+	// parsing it back), use minio-go's add rule function, and
+	// finally convert it back to the server type (again via xml).
+	// This is needed as there is no add-rule function in the server
+	// yet. MinIO rule extensions unknown to minio-go are restored
+	// after the conversion.
+	replicationConfigS, err := globalBucketMetadataSys.GetReplicationConfig(ctx, bucket)
+	if err != nil {
+		_, ok := err.(BucketReplicationConfigNotFound)
+		if !ok {
+			return err
+		}
+	}
+	newReplicationConfig, err := addReplicationPeerRule(replicationConfigS, ruleID, targetARN)
+	if err != nil {
+		return err
+	}
+	sameTarget, apiErr := validateReplicationDestination(ctx, bucket, newReplicationConfig)
+	if apiErr != noError {
+		return fmt.Errorf("bucket replication config validation error: %#v", apiErr)
+	}
+	err = newReplicationConfig.Validate(bucket, sameTarget)
+	if err != nil {
+		return err
+	}
+	// Config looks good, so we save it.
+	replCfgData, err := xml.Marshal(newReplicationConfig)
+	if err != nil {
+		return err
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, replCfgData); err != nil {
+		return fmt.Errorf("Error updating replication configuration: %w", err)
+	}
+	return nil
+}
+
+// addReplicationPeerRule adds or updates the rule replicating to
+// targetARN in a copy of the server replication configuration, see
+// configureReplicationPeer.
+func addReplicationPeerRule(replicationConfigS *sreplication.Config, ruleID, targetARN string) (*sreplication.Config, error) {
+	var replicationConfig replication.Config
+	if replicationConfigS != nil {
+		replCfgSBytes, err := xml.Marshal(replicationConfigS)
+		if err != nil {
+			return nil, err
+		}
+		err = xml.Unmarshal(replCfgSBytes, &replicationConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var (
+		hasRule bool
+		opts    = replication.Options{
+			ID: ruleID,
+
+			// Use a helper to generate unique priority numbers.
+			Priority: fmt.Sprintf("%d", getPriorityHelper(replicationConfig)),
+
+			Op:         replication.AddOption,
+			RuleStatus: "enable",
+			DestBucket: targetARN,
+
+			// Replicate everything!
+			ReplicateDeletes:        "enable",
+			ReplicateDeleteMarkers:  "enable",
+			ReplicaSync:             "enable",
+			ExistingObjectReplicate: "enable",
+		}
+	)
+	for _, r := range replicationConfig.Rules {
+		if r.ID == ruleID {
+			hasRule = true
+		}
+	}
+	var err error
+	switch {
+	case hasRule:
+		err = replicationConfig.EditRule(opts)
+	default:
+		err = replicationConfig.AddRule(opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error adding bucket replication rule: %w", err)
+	}
+
+	// Now convert the configuration back to server's type.
+	newReplCfgBytes, err := xml.Marshal(replicationConfig)
+	if err != nil {
+		return nil, err
+	}
+	newReplicationConfig, err := sreplication.ParseConfig(bytes.NewReader(newReplCfgBytes))
+	if err != nil {
+		return nil, err
+	}
+	restoreReplicationRuleExtensions(newReplicationConfig, replicationConfigS)
+	return newReplicationConfig, nil
+}
+
+// restoreReplicationRuleExtensions copies the MinIO extensions of the
+// rules in orig, which do not survive a conversion to minio-go's
+// replication configuration, to the rules with the same ID in cfg.
+func restoreReplicationRuleExtensions(cfg, orig *sreplication.Config) {
+	if orig == nil {
+		return
+	}
+	origRules := make(map[string]sreplication.Rule, len(orig.Rules))
+	for _, r := range orig.Rules {
+		origRules[r.ID] = r
+	}
+	for i, r := range cfg.Rules {
+		o, ok := origRules[r.ID]
+		if !ok {
+			continue
+		}
+		cfg.Rules[i].Filter.ExcludeTags = o.Filter.ExcludeTags
+		cfg.Rules[i].Filter.Metadata = o.Filter.Metadata
+		cfg.Rules[i].Filter.ExcludeMetadata = o.Filter.ExcludeMetadata
+		cfg.Rules[i].SyncReplication = o.SyncReplication
+	}
+}
+
+// replicationConvergenceTarget summarizes the replication status of
+// all scanned versions for a single replication target.
+type replicationConvergenceTarget struct {
+	Arn       string `json:"arn"`
+	Site      string `json:"site"`
+	Completed int64  `json:"completed"`
+	Pending   int64  `json:"pending"`
+	Failed    int64  `json:"failed"`
+	// Missing counts versions which should have been replicated
+	// but have no replication status for the target, e.g. versions
+	// created before the target was added.
+	Missing int64 `json:"missing"`
+}
+
+// replicationUnconvergedVersion is a version not yet replicated to all
+// of its targets.
+type replicationUnconvergedVersion struct {
+	Object    string            `json:"object"`
+	VersionID string            `json:"versionId,omitempty"`
+	Targets   map[string]string `json:"targets"`
+}
+
+// replicationConvergence is the result of a convergence check of a
+// bucket, it is converged once all versions created locally have been
+// replicated to all targets.
+type replicationConvergence struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	versionScan
+	Replicas    int64                           `json:"replicas"`
+	Targets     []replicationConvergenceTarget  `json:"targets"`
+	Unconverged []replicationUnconvergedVersion `json:"unconverged,omitempty"`
+	Converged   bool                            `json:"converged"`
+}
+
+// replicationTargetSite returns a human readable name of the site a
+// replication target points to.
+func replicationTargetSite(tgt madmin.BucketTarget) string {
+	if name := globalSiteReplicationSys.peerNameByEndpoint(tgt.Endpoint); name != "" {
+		return name
+	}
+	return tgt.Endpoint
+}
+
+// checkReplicationConvergence scans up to maxVersions versions under
+// prefix and reports, per replication target, how many of them are
+// replicated, along with a sample of unconverged versions.
+func checkReplicationConvergence(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, maxVersions int64) (rc replicationConvergence, err error) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return rc, err
+	}
+
+	rc.Bucket = bucket
+	rc.Prefix = prefix
+	targets := make(map[string]*replicationConvergenceTarget)
+	for _, tgt := range globalBucketTargetSys.ListTargets(ctx, bucket, string(madmin.ReplicationService)) {
+		targets[tgt.Arn] = &replicationConvergenceTarget{
+			Arn:  tgt.Arn,
+			Site: replicationTargetSite(tgt),
+		}
+	}
+
+	check := func(oi ObjectInfo) {
+		if oi.ReplicationStatus == sreplication.Replica {
+			// Replicas are replicated by the site they were
+			// created on.
+			rc.Replicas++
+			return
+		}
+		opts := sreplication.ObjectOpts{
+			Name:         oi.Name,
+			UserTags:     oi.UserTags,
+			UserMetadata: oi.UserDefined,
+			VersionID:    oi.VersionID,
+			DeleteMarker: oi.DeleteMarker,
+			SSEC:         crypto.SSEC.IsEncrypted(oi.UserDefined),
+			OpType:       sreplication.ObjectReplicationType,
+		}
+		if oi.DeleteMarker {
+			opts.OpType = sreplication.DeleteReplicationType
+			// Delete markers are not replicated by version ID.
+			opts.VersionID = ""
+		}
+		statuses := replicationStatusesMap(oi.ReplicationStatusInternal)
+		unconverged := make(map[string]string)
+		for _, arn := range cfg.FilterTargetArns(opts) {
+			opts.TargetArn = arn
+			if !cfg.Replicate(opts) {
+				continue
+			}
+			tgt, ok := targets[arn]
+			if !ok {
+				tgt = &replicationConvergenceTarget{Arn: arn, Site: arn}
+				targets[arn] = tgt
+			}
+			st := statuses[arn]
+			switch st {
+			case sreplication.Completed:
+				tgt.Completed++
+				continue
+			case sreplication.Pending:
+				tgt.Pending++
+			case sreplication.Failed:
+				tgt.Failed++
+			default:
+				tgt.Missing++
+			}
+			unconverged[tgt.Site] = string(st)
+		}
+		if len(unconverged) > 0 && len(rc.Unconverged) < maxUnconvergedVersions {
+			rc.Unconverged = append(rc.Unconverged, replicationUnconvergedVersion{
+				Object:    oi.Name,
+				VersionID: oi.VersionID,
+				Targets:   unconverged,
+			})
+		}
+	}
+
+	if err = rc.scanVersions(ctx, objAPI, bucket, prefix, maxVersions, check); err != nil {
+		return rc, err
+	}
+
+	rc.Converged = !rc.Truncated
+	for _, tgt := range targets {
+		if tgt.Pending+tgt.Failed+tgt.Missing > 0 {
+			rc.Converged = false
+		}
+		rc.Targets = append(rc.Targets, *tgt)
+	}
+	sort.Slice(rc.Targets, func(i, j int) bool {
+		return rc.Targets[i].Arn < rc.Targets[j].Arn
+	})
+	return rc, nil
+}
+
+// ReplicationStatusBySite returns the replication status of the object
+// version for each site it is replicated to.
+func (o *ObjectInfo) ReplicationStatusBySite() map[string]sreplication.StatusType {
+	statuses := replicationStatusesMap(o.ReplicationStatusInternal)
+	if len(statuses) == 0 || globalBucketTargetSys == nil {
+		return nil
+	}
+	sites := make(map[string]sreplication.StatusType, len(statuses))
+	for arn, st := range statuses {
+		site := arn
+		if tgt := globalBucketTargetSys.GetRemoteBucketTargetByArn(GlobalContext, o.Bucket, arn); tgt.Arn != "" {
+			site = replicationTargetSite(tgt)
+		}
+		sites[site] = st
+	}
+	return sites
+}
+
+// replicationSiteStatusHeader returns the per site replication status
+// in the format of the internal replication status, e.g.
+// "site-a=COMPLETED;site-b=PENDING;".
+func replicationSiteStatusHeader(sites map[string]sreplication.StatusType) string {
+	names := make([]string, 0, len(sites))
+	for name := range sites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%s=%s;", name, sites[name])
+	}
+	return sb.String()
+}
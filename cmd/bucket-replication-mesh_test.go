@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func TestValidateReplicationPeers(t *testing.T) {
+	testCases := []struct {
+		peers   []replicationPeer
+		success bool
+	}{
+		{nil, false},
+		{[]replicationPeer{{Name: "site-b", Endpoint: "https://b.example.com"}}, true},
+		{[]replicationPeer{{Endpoint: "https://b.example.com"}}, false},
+		{[]replicationPeer{{Name: "site-b", Endpoint: "b.example.com"}}, false},
+		{[]replicationPeer{
+			{Name: "site-b", Endpoint: "https://b.example.com"},
+			{Name: "site-b", Endpoint: "https://c.example.com"},
+		}, false},
+	}
+	for i, testCase := range testCases {
+		err := validateReplicationPeers(testCase.peers)
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestReplicationSiteStatusHeader(t *testing.T) {
+	sites := map[string]replication.StatusType{
+		"site-c": replication.Pending,
+		"site-b": replication.Completed,
+	}
+	if got, want := replicationSiteStatusHeader(sites), "site-b=COMPLETED;site-c=PENDING;"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAddReplicationPeerRuleKeepsExtensions(t *testing.T) {
+	cfgXML := `<ReplicationConfiguration><Rule><ID>docs</ID><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>docs/</Prefix><ExcludeTag><Key>classification</Key><Value>sensitive*</Value></ExcludeTag><Metadata><Key>X-Amz-Meta-Region</Key><Value>eu-*</Value></Metadata><ExcludeMetadata><Key>legal-hold</Key><Value>*</Value></ExcludeMetadata></Filter><Priority>1</Priority><Destination><Bucket>arn:minio:replication::docs:target</Bucket></Destination><SyncReplication><Status>Enabled</Status><Timeout>5s</Timeout></SyncReplication></Rule></ReplicationConfiguration>`
+	cfg, err := replication.ParseConfig(strings.NewReader(cfgXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newCfg, err := addReplicationPeerRule(cfg, "site-repl-b", "arn:minio:replication::b:target")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newCfg.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(newCfg.Rules))
+	}
+	for _, r := range newCfg.Rules {
+		if r.ID != "docs" {
+			continue
+		}
+		if len(r.Filter.ExcludeTags) != 1 || len(r.Filter.Metadata) != 1 || len(r.Filter.ExcludeMetadata) != 1 {
+			t.Fatalf("filter extensions were dropped: %#v", r.Filter)
+		}
+		if r.SyncReplication != cfg.Rules[0].SyncReplication {
+			t.Fatalf("expected %v, got %v", cfg.Rules[0].SyncReplication, r.SyncReplication)
+		}
+		return
+	}
+	t.Fatal("existing rule was dropped")
+}
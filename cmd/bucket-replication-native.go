@@ -0,0 +1,231 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Remote target API values selecting a replication target that is
+// reached through the provider SDK instead of the S3 API.
+const (
+	replicationTargetAPIAzure = "azure"
+	replicationTargetAPIGCS   = "gcs"
+)
+
+// Metadata recorded on objects replicated to native targets, mapping
+// the source version so that re-replication and version purges can
+// be matched against it.
+const (
+	nativeMetaSourceVersionID = "minio-source-version-id"
+	nativeMetaSourceMTime     = "minio-source-mtime"
+	nativeMetaSourceETag      = "minio-source-etag"
+	nativeMetaTagging         = "minio-tagging"
+)
+
+// nativeObjectMeta is the provider neutral metadata of a replicated
+// object version.
+type nativeObjectMeta struct {
+	ContentType        string
+	ContentEncoding    string
+	ContentLanguage    string
+	ContentDisposition string
+	CacheControl       string
+	StorageClass       string
+	// UserMeta holds user metadata without the `X-Amz-Meta-` prefix
+	// along with the source version information.
+	UserMeta map[string]string
+}
+
+func (m nativeObjectMeta) size() (n int) {
+	for k, v := range m.UserMeta {
+		n += len(k) + len(v)
+	}
+	return n + len(m.ContentType) + len(m.ContentEncoding) + len(m.ContentLanguage) +
+		len(m.ContentDisposition) + len(m.CacheControl)
+}
+
+// newNativeObjectMeta maps the S3 replication options of an object
+// version to metadata supported by native targets.
+func newNativeObjectMeta(objInfo ObjectInfo, putOpts miniogo.PutObjectOptions) nativeObjectMeta {
+	meta := nativeObjectMeta{
+		ContentType:        putOpts.ContentType,
+		ContentEncoding:    putOpts.ContentEncoding,
+		ContentLanguage:    putOpts.ContentLanguage,
+		ContentDisposition: putOpts.ContentDisposition,
+		CacheControl:       putOpts.CacheControl,
+		StorageClass:       putOpts.StorageClass,
+		UserMeta:           make(map[string]string, len(putOpts.UserMetadata)+4),
+	}
+	for k, v := range putOpts.UserMetadata {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			k = k[len("x-amz-meta-"):]
+		}
+		meta.UserMeta[strings.ToLower(k)] = v
+	}
+	meta.UserMeta[nativeMetaSourceVersionID] = objInfo.VersionID
+	meta.UserMeta[nativeMetaSourceMTime] = objInfo.ModTime.UTC().Format(time.RFC3339Nano)
+	meta.UserMeta[nativeMetaSourceETag] = objInfo.ETag
+	if objInfo.UserTags != "" {
+		meta.UserMeta[nativeMetaTagging] = objInfo.UserTags
+	}
+	return meta
+}
+
+// nativeReplicationTarget is a replication target reached through a
+// provider SDK. Targets are expected to keep prior versions of
+// overwritten and removed objects through provider side versioning.
+type nativeReplicationTarget interface {
+	// Put writes the object version as the current copy of object.
+	Put(ctx context.Context, object string, r io.Reader, length int64, meta nativeObjectMeta) error
+	// SourceVersion returns the source version id recorded on the
+	// current copy of object.
+	SourceVersion(ctx context.Context, object string) (string, error)
+	// Remove removes the current copy of object, the equivalent of a
+	// delete marker on versioned targets.
+	Remove(ctx context.Context, object string) error
+	// RemoveVersion permanently removes the copy of object that was
+	// replicated from the source version.
+	RemoveVersion(ctx context.Context, object, srcVersionID string) error
+	// BucketExists returns true if the target bucket exists.
+	BucketExists(ctx context.Context) (bool, error)
+	// Versioned returns true if the target keeps prior versions.
+	Versioned(ctx context.Context) (bool, error)
+}
+
+func isNativeReplicationTarget(tcfg *madmin.BucketTarget) bool {
+	switch tcfg.API {
+	case replicationTargetAPIAzure, replicationTargetAPIGCS:
+		return true
+	}
+	return false
+}
+
+func newNativeReplicationTarget(tcfg *madmin.BucketTarget) (nativeReplicationTarget, error) {
+	switch tcfg.API {
+	case replicationTargetAPIAzure:
+		return newAzureReplicationTarget(tcfg)
+	case replicationTargetAPIGCS:
+		return newGCSReplicationTarget(tcfg)
+	}
+	return nil, fmt.Errorf("unsupported replication target API %s", tcfg.API)
+}
+
+// replicateObjectToNativeTarget uploads the object version read from gr
+// to a native target. Native targets do not support metadata only
+// updates, such changes are replicated by rewriting the object.
+func replicateObjectToNativeTarget(ctx context.Context, ri ReplicateObjectInfo, gr *GetObjectReader, size int64, tgt *TargetClient, rinfo replicatedTargetInfo, startTime time.Time) replicatedTargetInfo {
+	objInfo := gr.ObjInfo
+	bucket := objInfo.Bucket
+	defer func() {
+		if rinfo.ReplicationStatus == replication.Completed && ri.OpType == replication.ExistingObjectReplicationType && tgt.ResetID != "" {
+			rinfo.ResyncTimestamp = fmt.Sprintf("%s;%s", UTCNow().Format(http.TimeFormat), tgt.ResetID)
+			rinfo.ReplicationResynced = true
+		}
+		rinfo.Duration = time.Since(startTime)
+	}()
+
+	if ri.OpType != replication.MetadataReplicationType {
+		if srcVersionID, err := tgt.native.SourceVersion(ctx, objInfo.Name); err == nil && srcVersionID == objInfo.VersionID {
+			rinfo.ReplicationStatus = replication.Completed
+			rinfo.ReplicationAction = replicateNone
+			return rinfo
+		}
+	}
+
+	putOpts, err := putReplicationOpts(ctx, tgt.StorageClass, objInfo)
+	if err != nil {
+		rinfo.Err = err
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s err:%w", bucket, err))
+		sendEvent(eventArgs{
+			EventName:  event.ObjectReplicationNotTracked,
+			BucketName: bucket,
+			Object:     objInfo,
+			Host:       "Internal: [Replication]",
+		})
+		return rinfo
+	}
+	meta := newNativeObjectMeta(objInfo, putOpts)
+
+	opts := &bandwidth.MonitorReaderOptions{
+		Bucket:     bucket,
+		TargetARN:  tgt.ARN,
+		HeaderSize: meta.size(),
+	}
+	newCtx := ctx
+	if globalBucketMonitor.IsTargetThrottled(bucket, tgt.ARN) {
+		var cancel context.CancelFunc
+		newCtx, cancel = context.WithTimeout(ctx, throttleDeadline)
+		defer cancel()
+	}
+	r := bandwidth.NewMonitoredReader(newCtx, globalBucketMonitor, gr, opts)
+
+	rinfo.Size = size
+	rinfo.ReplicationAction = replicateAll
+	if err = tgt.native.Put(ctx, objInfo.Name, r, size, meta); err != nil {
+		rinfo.ReplicationStatus = replication.Failed
+		rinfo.Err = err
+		logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
+		return rinfo
+	}
+	rinfo.ReplicationStatus = replication.Completed
+	return rinfo
+}
+
+// replicateDeleteToNativeTarget replicates delete markers by removing
+// the current copy on the target and version purges by removing the
+// copy replicated from the purged version.
+func replicateDeleteToNativeTarget(ctx context.Context, dobj DeletedObjectReplicationInfo, tgt *TargetClient, rinfo replicatedTargetInfo) replicatedTargetInfo {
+	var err error
+	if dobj.VersionID == "" {
+		err = tgt.native.Remove(ctx, dobj.ObjectName)
+	} else {
+		err = tgt.native.RemoveVersion(ctx, dobj.ObjectName, dobj.VersionID)
+	}
+	if isErrObjectNotFound(err) {
+		err = nil
+	}
+	if err != nil {
+		rinfo.Err = err
+		if dobj.VersionID == "" {
+			rinfo.ReplicationStatus = replication.Failed
+		} else {
+			rinfo.VersionPurgeStatus = Failed
+		}
+		logger.LogIf(ctx, fmt.Errorf("Unable to replicate delete to %s/%s(%s): %s", tgt.Bucket, dobj.ObjectName, dobj.VersionID, err))
+		return rinfo
+	}
+	if dobj.VersionID == "" {
+		rinfo.ReplicationStatus = replication.Completed
+	} else {
+		rinfo.VersionPurgeStatus = Complete
+	}
+	return rinfo
+}
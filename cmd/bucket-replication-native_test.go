@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+)
+
+func TestNewNativeObjectMeta(t *testing.T) {
+	modTime := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	objInfo := ObjectInfo{
+		VersionID: "c7b4ec4b-1a3f-4dbb-8d43-9a6a2b0b9b1e",
+		ModTime:   modTime,
+		ETag:      "d41d8cd98f00b204e9800998ecf8427e",
+		UserTags:  "k1=v1&k2=v2",
+	}
+	putOpts := miniogo.PutObjectOptions{
+		ContentType:  "text/plain",
+		CacheControl: "no-cache",
+		UserMetadata: map[string]string{
+			"X-Amz-Meta-Project": "dr",
+			"x-amz-meta-Owner":   "ops",
+		},
+	}
+
+	meta := newNativeObjectMeta(objInfo, putOpts)
+	if meta.ContentType != "text/plain" || meta.CacheControl != "no-cache" {
+		t.Fatalf("unexpected content headers %#v", meta)
+	}
+	expected := map[string]string{
+		"project":                 "dr",
+		"owner":                   "ops",
+		nativeMetaSourceVersionID: objInfo.VersionID,
+		nativeMetaSourceMTime:     "2021-12-01T10:00:00Z",
+		nativeMetaSourceETag:      objInfo.ETag,
+		nativeMetaTagging:         "k1=v1&k2=v2",
+	}
+	if len(meta.UserMeta) != len(expected) {
+		t.Fatalf("expected %d metadata entries, got %v", len(expected), meta.UserMeta)
+	}
+	for k, v := range expected {
+		if meta.UserMeta[k] != v {
+			t.Errorf("metadata %s: expected %q, got %q", k, v, meta.UserMeta[k])
+		}
+	}
+}
+
+func TestAzureMetadataKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"project", "project"},
+		{nativeMetaSourceVersionID, "minio_source_version_id"},
+		{"Cost.Center", "cost_center"},
+		{"2fa", "_2fa"},
+		{"", "_"},
+	}
+	for i, testCase := range testCases {
+		if got := azureMetadataKey(testCase.key); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+// Stride of a flow with weight 1, flows with weight w advance their
+// pass by replicationQueueStride/w per dequeued task.
+const replicationQueueStride = 1 << 20
+
+// replicationFlow is the FIFO of tasks queued for a single replication rule.
+type replicationFlow struct {
+	weight uint64
+	pass   uint64
+	tasks  []interface{}
+}
+
+// replicationQueue is a bounded, weighted fair queue of replication
+// tasks. Every replication rule is a separate flow weighted by its
+// priority, so that after a backlog builds up operations of higher
+// priority rules are dequeued proportionally more often than those of
+// bulk rules, without starving the latter. Flows are scheduled by
+// stride scheduling: the non-empty flow with the smallest pass is
+// dequeued next.
+type replicationQueue struct {
+	mu      sync.Mutex
+	flows   map[string]*replicationFlow
+	vtime   uint64
+	size    int
+	maxSize int
+	// readyCh holds one token per queued task, letting workers wait on
+	// the queue in a select statement.
+	readyCh chan struct{}
+}
+
+func newReplicationQueue(maxSize int) *replicationQueue {
+	return &replicationQueue{
+		flows:   make(map[string]*replicationFlow),
+		maxSize: maxSize,
+		readyCh: make(chan struct{}, maxSize),
+	}
+}
+
+// ready returns a channel receiving a token for every queued task.
+// Callers must call pop() once for every token received.
+func (q *replicationQueue) ready() <-chan struct{} {
+	return q.readyCh
+}
+
+// push queues the task in the flow with the given weight, returning
+// false if the queue is full.
+func (q *replicationQueue) push(flow string, weight int, task interface{}) bool {
+	if weight < 1 {
+		weight = 1
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size >= q.maxSize {
+		return false
+	}
+
+	f, ok := q.flows[flow]
+	if !ok {
+		// New flows start at the current virtual time to not be
+		// able to claim the share they missed while idle.
+		f = &replicationFlow{pass: q.vtime}
+		q.flows[flow] = f
+	}
+	// The weight follows the latest rule priority.
+	f.weight = uint64(weight)
+	f.tasks = append(f.tasks, task)
+	q.size++
+	q.readyCh <- struct{}{}
+	return true
+}
+
+// pop dequeues the next task.
+func (q *replicationQueue) pop() (interface{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var (
+		next *replicationFlow
+		name string
+	)
+	for k, f := range q.flows {
+		if next == nil || f.pass < next.pass || (f.pass == next.pass && f.weight > next.weight) {
+			next, name = f, k
+		}
+	}
+	if next == nil {
+		return nil, false
+	}
+
+	task := next.tasks[0]
+	next.tasks[0] = nil
+	next.tasks = next.tasks[1:]
+	q.size--
+
+	q.vtime = next.pass
+	next.pass += replicationQueueStride / next.weight
+	if len(next.tasks) == 0 {
+		delete(q.flows, name)
+	}
+	return task, true
+}
+
+// Len returns the number of queued tasks.
+func (q *replicationQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// replicationTaskFlow returns the flow and weight for a replication
+// task, i.e. the highest priority rule of the bucket matching it.
+func replicationTaskFlow(ctx context.Context, bucket string, opts replication.ObjectOpts) (string, int) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil || cfg == nil {
+		return bucket, 1
+	}
+	var rule *replication.Rule
+	for _, r := range cfg.FilterActionableRules(opts) {
+		if rule == nil || r.Priority > rule.Priority {
+			r := r
+			rule = &r
+		}
+	}
+	if rule == nil {
+		return bucket, 1
+	}
+	id := rule.ID
+	if id == "" {
+		id = strconv.Itoa(rule.Priority)
+	}
+	return bucket + SlashSeparator + id, rule.Priority + 1
+}
+
+func (ri ReplicateObjectInfo) replicationOpts() replication.ObjectOpts {
+	return replication.ObjectOpts{
+		Name:         ri.Name,
+		UserTags:     ri.UserTags,
+		UserMetadata: ri.UserDefined,
+		VersionID:    ri.VersionID,
+		TargetArn:    ri.TargetArn,
+	}
+}
+
+func (doi DeletedObjectReplicationInfo) replicationOpts() replication.ObjectOpts {
+	return replication.ObjectOpts{
+		Name:         doi.ObjectName,
+		VersionID:    doi.VersionID,
+		DeleteMarker: doi.DeleteMarker,
+		OpType:       replication.DeleteReplicationType,
+		TargetArn:    doi.TargetArn,
+	}
+}
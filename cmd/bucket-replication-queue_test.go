@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestReplicationQueueWeightedFair(t *testing.T) {
+	q := newReplicationQueue(100)
+	for i := 0; i < 40; i++ {
+		if !q.push("archive/bulk", 1, "bulk") {
+			t.Fatal("unexpected full queue")
+		}
+		if !q.push("critical/rule", 3, "critical") {
+			t.Fatal("unexpected full queue")
+		}
+	}
+	if q.Len() != 80 {
+		t.Fatalf("unexpected queue length %d", q.Len())
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 40; i++ {
+		<-q.ready()
+		task, ok := q.pop()
+		if !ok {
+			t.Fatal("expected a task")
+		}
+		counts[task.(string)]++
+	}
+	// Weight 3 vs. 1 gives the critical flow 3/4 of the dequeued tasks.
+	if counts["critical"] != 30 {
+		t.Fatalf("unexpected share of dequeued tasks %v", counts)
+	}
+
+	// Remaining tasks drain completely.
+	for q.Len() > 0 {
+		<-q.ready()
+		if _, ok := q.pop(); !ok {
+			t.Fatal("expected a task")
+		}
+	}
+	if _, ok := q.pop(); ok {
+		t.Fatal("expected an empty queue")
+	}
+}
+
+func TestReplicationQueueFull(t *testing.T) {
+	q := newReplicationQueue(2)
+	if !q.push("a", 1, 1) || !q.push("b", 1, 2) {
+		t.Fatal("unexpected full queue")
+	}
+	if q.push("a", 1, 3) {
+		t.Fatal("expected a full queue")
+	}
+}
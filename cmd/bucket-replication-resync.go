@@ -0,0 +1,545 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	replicationResyncFile = "replication-resync.json"
+
+	// Checkpoints are persisted after this many objects have been
+	// scanned or this much time has passed, whichever comes first.
+	resyncCheckpointObjects  = 1000
+	resyncCheckpointInterval = 30 * time.Second
+)
+
+var (
+	resyncLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+	resyncStateLockTimeout  = newDynamicTimeout(30*time.Second, 10*time.Second)
+)
+
+// resyncStatusType is the status of a replication resync.
+type resyncStatusType string
+
+const (
+	resyncOngoing   resyncStatusType = "Ongoing"
+	resyncCompleted resyncStatusType = "Completed"
+	resyncFailed    resyncStatusType = "Failed"
+)
+
+// resyncCheckpoint is the resync position within an erasure set.
+// Objects are listed in lexical order, so scanning resumes from
+// LastObject.
+type resyncCheckpoint struct {
+	LastObject string `json:"lastObject,omitempty"`
+	Done       bool   `json:"done,omitempty"`
+}
+
+// ReplicationResyncStatus - progress of the resync of a bucket to a
+// replication target. Totals are estimated from data usage when the
+// resync starts.
+type ReplicationResyncStatus struct {
+	Arn        string           `json:"arn"`
+	ResetID    string           `json:"resetID"`
+	Status     resyncStatusType `json:"status"`
+	Error      string           `json:"error,omitempty"`
+	StartTime  time.Time        `json:"startTime"`
+	LastUpdate time.Time        `json:"lastUpdate"`
+
+	TotalObjects   uint64 `json:"totalObjects"`
+	TotalBytes     uint64 `json:"totalBytes"`
+	ScannedObjects uint64 `json:"scannedObjects"`
+	ScannedBytes   uint64 `json:"scannedBytes"`
+	QueuedObjects  uint64 `json:"queuedObjects"`
+	QueuedBytes    uint64 `json:"queuedBytes"`
+
+	ObjectsRemaining uint64 `json:"objectsRemaining"`
+	BytesRemaining   uint64 `json:"bytesRemaining"`
+
+	// Checkpoints are keyed by "<pool>/<set>" of the erasure set.
+	Checkpoints map[string]resyncCheckpoint `json:"checkpoints,omitempty"`
+}
+
+func (s *ReplicationResyncStatus) updateRemaining() {
+	s.ObjectsRemaining, s.BytesRemaining = 0, 0
+	if s.Status != resyncOngoing {
+		return
+	}
+	if s.TotalObjects > s.ScannedObjects {
+		s.ObjectsRemaining = s.TotalObjects - s.ScannedObjects
+	}
+	if s.TotalBytes > s.ScannedBytes {
+		s.BytesRemaining = s.TotalBytes - s.ScannedBytes
+	}
+}
+
+// bucketResyncState holds the resync status of all replication
+// targets of a bucket.
+type bucketResyncState struct {
+	Targets map[string]*ReplicationResyncStatus `json:"targets"`
+}
+
+func resyncStatePath(bucket string) string {
+	return path.Join(bucketMetaPrefix, bucket, replicationResyncFile)
+}
+
+func resyncSetKey(poolIdx, setIdx int) string {
+	return fmt.Sprintf("%d/%d", poolIdx, setIdx)
+}
+
+func loadBucketResyncState(ctx context.Context, objAPI ObjectLayer, bucket string) (bucketResyncState, error) {
+	state := bucketResyncState{Targets: make(map[string]*ReplicationResyncStatus)}
+	data, err := readConfig(ctx, objAPI, resyncStatePath(bucket))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err = json.Unmarshal(data, &state); err != nil {
+		return state, err
+	}
+	if state.Targets == nil {
+		state.Targets = make(map[string]*ReplicationResyncStatus)
+	}
+	return state, nil
+}
+
+// replicationResyncer runs checkpointed resyncs of existing objects
+// to replication targets. Each resync is run by a single node, which
+// persists its progress so that any node can resume it.
+type replicationResyncer struct {
+	mu sync.Mutex
+	// Resyncs run by this node, keyed by bucket, target and reset ID.
+	running map[string]struct{}
+}
+
+var globalReplicationResyncer = &replicationResyncer{}
+
+// saveStatus persists the status of the target resync, keeping the
+// status of other targets of the bucket. The state is shared by all
+// nodes, so it is updated under a cluster lock. Progress of a resync
+// that has since been restarted with a newer reset ID is dropped.
+func (r *replicationResyncer) saveStatus(ctx context.Context, objAPI ObjectLayer, bucket string, st ReplicationResyncStatus) error {
+	locker := objAPI.NewNSLock(minioMetaBucket, resyncStatePath(bucket)+".lock")
+	lkctx, err := locker.GetLock(ctx, resyncStateLockTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	state, err := loadBucketResyncState(ctx, objAPI, bucket)
+	if err != nil {
+		return err
+	}
+	if cur, ok := state.Targets[st.Arn]; ok && cur.ResetID != st.ResetID && cur.StartTime.After(st.StartTime) {
+		return nil
+	}
+	st.LastUpdate = UTCNow()
+	state.Targets[st.Arn] = &st
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, resyncStatePath(bucket), data)
+}
+
+// Status - returns the resync status of the bucket targets, or of the
+// target with arn only if set.
+func (r *replicationResyncer) Status(ctx context.Context, objAPI ObjectLayer, bucket, arn string) ([]ReplicationResyncStatus, error) {
+	state, err := loadBucketResyncState(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+	statuses := []ReplicationResyncStatus{}
+	for tgtArn, st := range state.Targets {
+		if arn != "" && tgtArn != arn {
+			continue
+		}
+		st.updateRemaining()
+		st.Checkpoints = nil
+		statuses = append(statuses, *st)
+	}
+	return statuses, nil
+}
+
+// Start - starts a new resync of the bucket to the target, replacing
+// any previous resync of that target.
+func (r *replicationResyncer) Start(ctx context.Context, objAPI ObjectLayer, bucket, arn, resetID string) error {
+	st := ReplicationResyncStatus{
+		Arn:       arn,
+		ResetID:   resetID,
+		Status:    resyncOngoing,
+		StartTime: UTCNow(),
+	}
+	if dataUsageInfo, err := loadDataUsageFromBackend(ctx, objAPI); err == nil {
+		if bui, ok := dataUsageInfo.BucketsUsage[bucket]; ok {
+			st.TotalObjects = bui.ObjectsCount
+			st.TotalBytes = bui.Size
+		}
+	}
+	if err := r.saveStatus(ctx, objAPI, bucket, st); err != nil {
+		return err
+	}
+	go r.run(GlobalContext, objAPI, bucket, st)
+	return nil
+}
+
+// Resume - resumes the ongoing resyncs of all buckets whose node was
+// restarted or went away, from their last checkpoints. It is called
+// by the data scanner at the start of every cycle.
+func (r *replicationResyncer) Resume(ctx context.Context, objAPI ObjectLayer) {
+	if _, ok := objAPI.(*erasureServerPools); !ok {
+		return
+	}
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, bucket := range buckets {
+		state, err := loadBucketResyncState(ctx, objAPI, bucket.Name)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for _, st := range state.Targets {
+			if st.Status == resyncOngoing {
+				go r.run(ctx, objAPI, bucket.Name, *st)
+			}
+		}
+	}
+}
+
+// markRunning records that this node runs the resync, returning
+// false if it already does.
+func (r *replicationResyncer) markRunning(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[key]; ok {
+		return false
+	}
+	if r.running == nil {
+		r.running = make(map[string]struct{})
+	}
+	r.running[key] = struct{}{}
+	return true
+}
+
+func (r *replicationResyncer) markDone(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.running, key)
+}
+
+// run resyncs the bucket to the target, starting from the
+// checkpoints of st. The resync is abandoned if another node already
+// runs it or it was restarted with a different reset ID.
+func (r *replicationResyncer) run(ctx context.Context, objAPI ObjectLayer, bucket string, st ReplicationResyncStatus) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+
+	runKey := path.Join(bucket, st.Arn, st.ResetID)
+	if !r.markRunning(runKey) {
+		return
+	}
+	defer r.markDone(runKey)
+
+	locker := objAPI.NewNSLock(minioMetaBucket, path.Join("replication/resync", runKey))
+	lkctx, err := locker.GetLock(ctx, resyncLeaderLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	// Reload the status now that the lock is held, the previous
+	// owner may have made progress.
+	state, err := loadBucketResyncState(ctx, objAPI, bucket)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	cur, ok := state.Targets[st.Arn]
+	if !ok || cur.ResetID != st.ResetID || cur.Status != resyncOngoing {
+		return
+	}
+	st = *cur
+	if st.Checkpoints == nil {
+		st.Checkpoints = make(map[string]resyncCheckpoint)
+	}
+
+	rcfg, err := globalBucketMetadataSys.GetReplicationConfig(ctx, bucket)
+	if err != nil {
+		r.finish(ctx, objAPI, bucket, st, err)
+		return
+	}
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		r.finish(ctx, objAPI, bucket, st, err)
+		return
+	}
+	p := &resyncProgress{
+		r:      r,
+		objAPI: objAPI,
+		bucket: bucket,
+		st:     st,
+		rcfg:   replicationConfig{Config: rcfg, remotes: tgts},
+		saved:  UTCNow(),
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+	)
+	for poolIdx, pool := range z.serverPools {
+		for setIdx, set := range pool.sets {
+			key := resyncSetKey(poolIdx, setIdx)
+			cp := p.checkpoint(key)
+			if cp.Done {
+				continue
+			}
+			wg.Add(1)
+			go func(set *erasureObjects, key string, cp resyncCheckpoint) {
+				defer wg.Done()
+				if err := p.resyncSet(ctx, set, key, cp); err != nil {
+					errMu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMu.Unlock()
+				}
+			}(set, key, cp)
+		}
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		// Interrupted, persist the progress so that it can be resumed.
+		logger.LogIf(GlobalContext, p.save(GlobalContext, true))
+		return
+	}
+	r.finish(ctx, objAPI, bucket, p.status(), firstErr)
+}
+
+func (r *replicationResyncer) finish(ctx context.Context, objAPI ObjectLayer, bucket string, st ReplicationResyncStatus, err error) {
+	st.Status = resyncCompleted
+	if err != nil {
+		st.Status = resyncFailed
+		st.Error = err.Error()
+	}
+	st.Checkpoints = nil
+	logger.LogIf(ctx, r.saveStatus(ctx, objAPI, bucket, st))
+}
+
+// resyncProgress tracks the progress of a running resync across
+// erasure sets.
+type resyncProgress struct {
+	r      *replicationResyncer
+	objAPI ObjectLayer
+	bucket string
+	rcfg   replicationConfig
+
+	mu      sync.Mutex
+	st      ReplicationResyncStatus
+	pending int
+	saved   time.Time
+}
+
+func (p *resyncProgress) checkpoint(key string) resyncCheckpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.st.Checkpoints[key]
+}
+
+func (p *resyncProgress) status() ReplicationResyncStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.st
+}
+
+// scanned records that object has been scanned in the erasure set
+// key, queueing the given number of its versions for resync.
+func (p *resyncProgress) scanned(ctx context.Context, key, object string, size int64, queued int, queuedSize int64) {
+	p.mu.Lock()
+	p.st.ScannedObjects++
+	p.st.ScannedBytes += uint64(size)
+	p.st.QueuedObjects += uint64(queued)
+	p.st.QueuedBytes += uint64(queuedSize)
+	p.st.Checkpoints[key] = resyncCheckpoint{LastObject: object}
+	p.pending++
+	p.mu.Unlock()
+
+	logger.LogIf(ctx, p.save(ctx, false))
+}
+
+// setDone records that the erasure set key has been fully scanned.
+func (p *resyncProgress) setDone(ctx context.Context, key string) {
+	p.mu.Lock()
+	cp := p.st.Checkpoints[key]
+	cp.Done = true
+	p.st.Checkpoints[key] = cp
+	p.mu.Unlock()
+
+	logger.LogIf(ctx, p.save(ctx, true))
+}
+
+// save persists the checkpoints if enough progress has been made
+// since the last save, or unconditionally if force is set.
+func (p *resyncProgress) save(ctx context.Context, force bool) error {
+	p.mu.Lock()
+	if !force && p.pending < resyncCheckpointObjects && time.Since(p.saved) < resyncCheckpointInterval {
+		p.mu.Unlock()
+		return nil
+	}
+	st := p.st
+	st.Checkpoints = make(map[string]resyncCheckpoint, len(p.st.Checkpoints))
+	for k, v := range p.st.Checkpoints {
+		st.Checkpoints[k] = v
+	}
+	p.pending = 0
+	p.saved = UTCNow()
+	p.mu.Unlock()
+
+	return p.r.saveStatus(ctx, p.objAPI, p.bucket, st)
+}
+
+// resyncSet lists the erasure set from its checkpoint and queues all
+// versions that must be resynced to the target.
+func (p *resyncProgress) resyncSet(ctx context.Context, set *erasureObjects, key string, cp resyncCheckpoint) error {
+	disks, _ := set.getOnlineDisksWithHealing()
+	if len(disks) == 0 {
+		return fmt.Errorf("replication resync: no online drives in erasure set %s", key)
+	}
+
+	resolver := metadataResolutionParams{
+		dirQuorum: 1,
+		objQuorum: 1,
+		bucket:    p.bucket,
+	}
+
+	var entryErr error
+	loadEntry := func(entry metaCacheEntry) {
+		if entry.isDir() || entryErr != nil {
+			return
+		}
+		fivs, err := entry.fileInfoVersions(p.bucket)
+		if err != nil {
+			return
+		}
+		var size, queuedSize int64
+		var queued int
+		for _, version := range fivs.Versions {
+			oi := version.ToObjectInfo(p.bucket, version.Name)
+			size += oi.Size
+			ok, err := p.resyncVersion(ctx, oi)
+			if err != nil {
+				entryErr = err
+				return
+			}
+			if ok {
+				queued++
+				queuedSize += oi.Size
+			}
+		}
+		p.scanned(ctx, key, entry.name, size, queued, queuedSize)
+	}
+
+	lopts := listPathRawOptions{
+		disks:          disks,
+		bucket:         p.bucket,
+		recursive:      true,
+		forwardTo:      cp.LastObject,
+		minDisks:       1,
+		reportNotFound: false,
+		agreed:         loadEntry,
+		partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+			entry, ok := entries.resolve(&resolver)
+			if !ok {
+				entry, _ = entries.firstFound()
+			}
+			loadEntry(*entry)
+		},
+	}
+	if err := listPathRaw(ctx, lopts); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	if entryErr != nil {
+		return entryErr
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	p.setDone(ctx, key)
+	return nil
+}
+
+// resyncVersion queues the object version for resync to the target,
+// returning true if it was queued.
+func (p *resyncProgress) resyncVersion(ctx context.Context, oi ObjectInfo) (bool, error) {
+	roi := getHealReplicateObjectInfo(oi, p.rcfg)
+	tgt, ok := roi.ExistingObjResync.targets[p.st.Arn]
+	if !ok || !tgt.Replicate || tgt.ResetID != p.st.ResetID {
+		return false, nil
+	}
+	// Only resync to the target of this resync.
+	roi.ExistingObjResync = ResyncDecision{targets: map[string]ResyncTargetDecision{p.st.Arn: tgt}}
+
+	if oi.DeleteMarker || !oi.VersionPurgeStatus.Empty() {
+		versionID, dmVersionID := "", ""
+		if oi.VersionPurgeStatus.Empty() {
+			dmVersionID = oi.VersionID
+		} else {
+			versionID = oi.VersionID
+		}
+		queueReplicateDeletesWrapper(DeletedObjectReplicationInfo{
+			DeletedObject: DeletedObject{
+				ObjectName:            oi.Name,
+				DeleteMarkerVersionID: dmVersionID,
+				VersionID:             versionID,
+				ReplicationState:      roi.getReplicationState(roi.Dsc.String(), versionID, true),
+				DeleteMarkerMTime:     DeleteMarkerMTime{oi.ModTime},
+				DeleteMarker:          oi.DeleteMarker,
+			},
+			Bucket: oi.Bucket,
+			OpType: replication.ExistingObjectReplicationType,
+		}, roi.ExistingObjResync)
+		return true, nil
+	}
+
+	roi.OpType = replication.ExistingObjectReplicationType
+	if err := globalReplicationPool.queueReplicaTaskWait(ctx, roi); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReplicationResyncStatusRemaining(t *testing.T) {
+	st := ReplicationResyncStatus{
+		Status:         resyncOngoing,
+		TotalObjects:   10,
+		TotalBytes:     1000,
+		ScannedObjects: 4,
+		ScannedBytes:   1200,
+	}
+	st.updateRemaining()
+	if st.ObjectsRemaining != 6 || st.BytesRemaining != 0 {
+		t.Fatalf("unexpected remaining %d objects, %d bytes", st.ObjectsRemaining, st.BytesRemaining)
+	}
+
+	st.Status = resyncCompleted
+	st.updateRemaining()
+	if st.ObjectsRemaining != 0 {
+		t.Fatalf("completed resync must have nothing remaining, got %d", st.ObjectsRemaining)
+	}
+}
+
+func TestReplicationResyncSaveStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objAPI, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objAPI.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	r := &replicationResyncer{}
+	for _, arn := range []string{"arn1", "arn2"} {
+		st := ReplicationResyncStatus{
+			Arn:         arn,
+			ResetID:     "reset",
+			Status:      resyncOngoing,
+			Checkpoints: map[string]resyncCheckpoint{resyncSetKey(0, 0): {LastObject: "a/b"}},
+		}
+		if err = r.saveStatus(ctx, objAPI, "bucket", st); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state, err := loadBucketResyncState(ctx, objAPI, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Targets) != 2 {
+		t.Fatalf("expected resync status of 2 targets, got %d", len(state.Targets))
+	}
+	if cp := state.Targets["arn1"].Checkpoints["0/0"]; cp.LastObject != "a/b" {
+		t.Fatalf("checkpoint not persisted: %#v", cp)
+	}
+
+	statuses, err := r.Status(ctx, objAPI, "bucket", "arn2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Arn != "arn2" || statuses[0].Checkpoints != nil {
+		t.Fatalf("unexpected statuses %#v", statuses)
+	}
+}
+
+func TestReplicationResyncSaveStatusConcurrent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objAPI, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objAPI.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	// Separate resyncers stand in for different nodes saving the
+	// progress of resyncs to different targets of the bucket.
+	var wg sync.WaitGroup
+	arns := []string{"arn1", "arn2", "arn3", "arn4"}
+	for _, arn := range arns {
+		wg.Add(1)
+		go func(arn string) {
+			defer wg.Done()
+			r := &replicationResyncer{}
+			for i := 0; i < 5; i++ {
+				st := ReplicationResyncStatus{Arn: arn, ResetID: "reset", Status: resyncOngoing}
+				if err := r.saveStatus(ctx, objAPI, "bucket", st); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(arn)
+	}
+	wg.Wait()
+
+	state, err := loadBucketResyncState(ctx, objAPI, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Targets) != len(arns) {
+		t.Fatalf("expected resync status of %d targets, got %d", len(arns), len(state.Targets))
+	}
+
+	// Progress of a replaced resync must not overwrite the new one.
+	r := &replicationResyncer{}
+	now := UTCNow()
+	if err = r.saveStatus(ctx, objAPI, "bucket", ReplicationResyncStatus{Arn: "arn1", ResetID: "new", Status: resyncOngoing, StartTime: now}); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.saveStatus(ctx, objAPI, "bucket", ReplicationResyncStatus{Arn: "arn1", ResetID: "old", Status: resyncOngoing, StartTime: now.Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	state, err = loadBucketResyncState(ctx, objAPI, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := state.Targets["arn1"].ResetID; id != "new" {
+		t.Fatalf("expected reset ID new, got %s", id)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/event"
+)
+
+// replicationOldestUnreplicated is the oldest version of a bucket that
+// is pending or failed replication to a target.
+type replicationOldestUnreplicated struct {
+	Arn       string    `json:"arn"`
+	Object    string    `json:"object,omitempty"`
+	VersionID string    `json:"versionId,omitempty"`
+	ModTime   time.Time `json:"modTime,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	// Age of the version in seconds, which is a lower bound of the
+	// replication lag of the target.
+	AgeSeconds int64 `json:"ageSeconds"`
+	// Number of scanned versions pending or failed replication.
+	Unreplicated int64 `json:"unreplicated"`
+}
+
+// replicationSLOStatus is the result of a scan for the oldest
+// unreplicated version of each replication target.
+type replicationSLOStatus struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+	versionScan
+	Targets []replicationOldestUnreplicated `json:"targets"`
+}
+
+// oldestUnreplicatedVersions scans up to maxVersions versions under
+// prefix and returns the oldest version pending or failed replication
+// for each target, or only for arn if set.
+func oldestUnreplicatedVersions(ctx context.Context, objAPI ObjectLayer, bucket, prefix, arn string, maxVersions int64) (st replicationSLOStatus, err error) {
+	st.Bucket = bucket
+	st.Prefix = prefix
+	now := UTCNow()
+	targets := make(map[string]*replicationOldestUnreplicated)
+	for _, tgt := range globalBucketTargetSys.ListTargets(ctx, bucket, string(madmin.ReplicationService)) {
+		if arn == "" || tgt.Arn == arn {
+			targets[tgt.Arn] = &replicationOldestUnreplicated{Arn: tgt.Arn}
+		}
+	}
+
+	check := func(oi ObjectInfo) {
+		for tgtArn, status := range replicationStatusesMap(oi.ReplicationStatusInternal) {
+			if status != replication.Pending && status != replication.Failed {
+				continue
+			}
+			tgt, ok := targets[tgtArn]
+			if !ok {
+				continue
+			}
+			tgt.Unreplicated++
+			if tgt.Object == "" || oi.ModTime.Before(tgt.ModTime) {
+				tgt.Object = oi.Name
+				tgt.VersionID = oi.VersionID
+				tgt.ModTime = oi.ModTime
+				tgt.Status = string(status)
+				tgt.AgeSeconds = int64(now.Sub(oi.ModTime) / time.Second)
+			}
+		}
+	}
+
+	if err = st.scanVersions(ctx, objAPI, bucket, prefix, maxVersions, check); err != nil {
+		return st, err
+	}
+
+	st.Targets = make([]replicationOldestUnreplicated, 0, len(targets))
+	for _, tgt := range targets {
+		st.Targets = append(st.Targets, *tgt)
+	}
+	sort.Slice(st.Targets, func(i, j int) bool {
+		return st.Targets[i].Arn < st.Targets[j].Arn
+	})
+	return st, nil
+}
+
+// Maximum number of versions tracked for missed replication thresholds,
+// the tracker is reset once exceeded.
+const maxReplicationThresholdsTracked = 100000
+
+// replicationThresholdTracker remembers the versions and targets that a
+// missed replication threshold event was sent for, so that failing
+// retries do not send it again. Tracking is node local and not
+// persisted.
+type replicationThresholdTracker struct {
+	mu     sync.Mutex
+	missed map[string]struct{}
+}
+
+func newReplicationThresholdTracker() *replicationThresholdTracker {
+	return &replicationThresholdTracker{missed: make(map[string]struct{})}
+}
+
+// markMissed returns true if key was not marked as missed before.
+func (t *replicationThresholdTracker) markMissed(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.missed[key]; ok {
+		return false
+	}
+	if len(t.missed) >= maxReplicationThresholdsTracked {
+		t.missed = make(map[string]struct{})
+	}
+	t.missed[key] = struct{}{}
+	return true
+}
+
+// clear forgets key and returns true if it was marked as missed.
+func (t *replicationThresholdTracker) clear(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.missed[key]
+	delete(t.missed, key)
+	return ok
+}
+
+var globalReplicationThresholds = newReplicationThresholdTracker()
+
+// replicationThresholdEvent returns the replication threshold event of
+// a replication attempt of objInfo to a target, if any. Versions that
+// are not replicated within the event threshold of the rules of the
+// target miss the threshold, versions replicated later are replicated
+// after the threshold. Existing object replication and metadata updates
+// do not send threshold events since the age of the version does not
+// reflect replication delays.
+func replicationThresholdEvent(cfg *replication.Config, ri ReplicateObjectInfo, rinfo replicatedTargetInfo, now time.Time) (event.Name, bool) {
+	if ri.OpType == replication.ExistingObjectReplicationType || rinfo.ReplicationAction != replicateAll {
+		return 0, false
+	}
+	objInfo := ri.ObjectInfo
+	threshold := cfg.EventThreshold(replication.ObjectOpts{
+		Name:         objInfo.Name,
+		UserTags:     objInfo.UserTags,
+		UserMetadata: objInfo.UserDefined,
+		TargetArn:    rinfo.Arn,
+	})
+	if threshold == 0 {
+		return 0, false
+	}
+
+	key := pathJoin(objInfo.Bucket, objInfo.Name, objInfo.VersionID, rinfo.Arn)
+	missed := now.Sub(objInfo.ModTime) > threshold
+	switch rinfo.ReplicationStatus {
+	case replication.Completed:
+		if globalReplicationThresholds.clear(key) || missed {
+			return event.ObjectReplicationReplicatedAfterThreshold, true
+		}
+	case replication.Pending, replication.Failed:
+		if missed && globalReplicationThresholds.markMissed(key) {
+			return event.ObjectReplicationMissedThreshold, true
+		}
+	}
+	return 0, false
+}
+
+// sendReplicationThresholdEvents sends the replication threshold events
+// of a replication attempt of ri to all targets.
+func sendReplicationThresholdEvents(cfg *replication.Config, ri ReplicateObjectInfo, rinfos replicatedInfos) {
+	now := UTCNow()
+	for _, rinfo := range rinfos.Targets {
+		if rinfo.Empty() || rinfo.ReplicationStatus == rinfo.PrevReplicationStatus && rinfo.ReplicationStatus == replication.Completed {
+			continue
+		}
+		eventName, ok := replicationThresholdEvent(cfg, ri, rinfo, now)
+		if !ok {
+			continue
+		}
+		sendEvent(eventArgs{
+			EventName:    eventName,
+			BucketName:   ri.Bucket,
+			Object:       ri.ObjectInfo,
+			RespElements: map[string]string{replicationTargetArnElement: rinfo.Arn},
+			Host:         "Internal: [Replication]",
+		})
+	}
+}
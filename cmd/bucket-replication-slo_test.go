@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/event"
+)
+
+func TestReplicationThresholdEvent(t *testing.T) {
+	const arn = "arn:minio:replication:xxx::target"
+	cfg, err := replication.ParseConfig(bytes.NewReader([]byte(`<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Priority>1</Priority><Destination><Bucket>` + arn + `</Bucket><Metrics><Status>Enabled</Status><EventThreshold><Minutes>15</Minutes></EventThreshold></Metrics></Destination></Rule></ReplicationConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := UTCNow()
+	ri := func(name string, age time.Duration) ReplicateObjectInfo {
+		return ReplicateObjectInfo{ObjectInfo: ObjectInfo{Bucket: "bucket", Name: name, VersionID: "v1", ModTime: now.Add(-age)}}
+	}
+	rinfo := func(status replication.StatusType) replicatedTargetInfo {
+		return replicatedTargetInfo{Arn: arn, ReplicationStatus: status, ReplicationAction: replicateAll}
+	}
+
+	testCases := []struct {
+		ri        ReplicateObjectInfo
+		rinfo     replicatedTargetInfo
+		eventName event.Name
+		ok        bool
+	}{
+		{ri("recent", time.Minute), rinfo(replication.Failed), 0, false},
+		{ri("recent", time.Minute), rinfo(replication.Completed), 0, false},
+		{ri("old", time.Hour), rinfo(replication.Failed), event.ObjectReplicationMissedThreshold, true},
+		// retries do not send missed threshold events again.
+		{ri("old", time.Hour), rinfo(replication.Failed), 0, false},
+		{ri("old", time.Hour), rinfo(replication.Completed), event.ObjectReplicationReplicatedAfterThreshold, true},
+		{ri("old", time.Hour), replicatedTargetInfo{Arn: arn, ReplicationStatus: replication.Failed, ReplicationAction: replicateMetadata}, 0, false},
+	}
+	for i, tc := range testCases {
+		eventName, ok := replicationThresholdEvent(cfg, tc.ri, tc.rinfo, now)
+		if ok != tc.ok || eventName != tc.eventName {
+			t.Errorf("Test %d: expected %v %v, got %v %v", i+1, tc.eventName, tc.ok, eventName, ok)
+		}
+	}
+}
@@ -92,10 +92,18 @@ func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration t
 		bs.Stats[arn] = b
 	}
 	switch status {
+	case replication.Pending:
+		b.PendingCount++
+		b.PendingSize += n
 	case replication.Completed:
+		b.CompletedOperations++
+		b.LastReplicationTime = UTCNow()
 		switch prevStatus { // adjust counters based on previous state
 		case replication.Failed:
 			b.FailedCount--
+		case replication.Pending:
+			b.PendingCount--
+			b.PendingSize -= n
 		}
 		if opType == replication.ObjectReplicationType {
 			b.ReplicatedSize += n
@@ -108,6 +116,11 @@ func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration t
 			}
 		}
 	case replication.Failed:
+		b.FailedOperations++
+		if prevStatus == replication.Pending {
+			b.PendingCount--
+			b.PendingSize -= n
+		}
 		if opType == replication.ObjectReplicationType {
 			if prevStatus == replication.Pending {
 				b.FailedSize += n
@@ -121,6 +134,50 @@ func (r *ReplicationStats) Update(bucket string, arn string, n int64, duration t
 	}
 }
 
+// UpdateLag records the replication lag of an object version replicated
+// to the target.
+func (r *ReplicationStats) UpdateLag(bucket string, arn string, lag time.Duration) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+		r.Cache[bucket] = bs
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+		bs.Stats[arn] = b
+	}
+	b.Lag.update(lag)
+}
+
+// UpdateProxyLatency records the latency of a read of an object of the
+// given size proxied to the target.
+func (r *ReplicationStats) UpdateProxyLatency(bucket string, arn string, size int64, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	bs, ok := r.Cache[bucket]
+	if !ok {
+		bs = &BucketReplicationStats{Stats: make(map[string]*BucketReplicationStat)}
+		r.Cache[bucket] = bs
+	}
+	b, ok := bs.Stats[arn]
+	if !ok {
+		b = &BucketReplicationStat{}
+		bs.Stats[arn] = b
+	}
+	b.Latency.updateProxy(size, duration)
+}
+
 // GetInitialUsage get replication metrics available at the time of cluster initialization
 func (r *ReplicationStats) GetInitialUsage(bucket string) BucketReplicationStats {
 	if r == nil {
@@ -195,6 +252,8 @@ outer:
 				ReplicatedSize: int64(uinfo.ReplicatedSize),
 				ReplicaSize:    int64(uinfo.ReplicaSize),
 				FailedCount:    int64(uinfo.ReplicationFailedCount),
+				PendingSize:    int64(uinfo.ReplicationPendingSize),
+				PendingCount:   int64(uinfo.ReplicationPendingCount),
 			}
 		}
 		b.ReplicaSize += int64(usage.ReplicaSize)
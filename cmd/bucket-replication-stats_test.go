@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func TestReplicationStatsPendingAndLag(t *testing.T) {
+	r := NewReplicationStats(context.Background(), nil)
+	for i := 0; i < 3; i++ {
+		r.Update("bucket", "arn", 100, 0, replication.Pending, "", replication.ObjectReplicationType)
+	}
+	r.Update("bucket", "arn", 100, time.Second, replication.Completed, replication.Pending, replication.ObjectReplicationType)
+	r.Update("bucket", "arn", 100, 0, replication.Failed, replication.Pending, replication.ObjectReplicationType)
+
+	r.UpdateLag("bucket", "arn", 500*time.Millisecond)
+	r.UpdateLag("bucket", "arn", 30*time.Second)
+	r.UpdateLag("bucket", "arn", 48*time.Hour)
+
+	st := r.Get("bucket").Stats["arn"]
+	if st.PendingCount != 1 || st.PendingSize != 100 {
+		t.Fatalf("unexpected pending %d operations, %d bytes", st.PendingCount, st.PendingSize)
+	}
+	if st.CompletedOperations != 1 || st.FailedOperations != 1 {
+		t.Fatalf("unexpected %d completed, %d failed operations", st.CompletedOperations, st.FailedOperations)
+	}
+
+	lag := st.Lag.merge(ReplicationLag{}).getHistogram()
+	expected := map[string]uint64{
+		"LESS_THAN_1_SEC":          1,
+		"BETWEEN_10_SEC_AND_1_MIN": 1,
+		"GREATER_THAN_1_DAY":       1,
+		"BETWEEN_1_SEC_AND_10_SEC": 0,
+	}
+	for k, v := range expected {
+		if lag[k] != v {
+			t.Errorf("lag %s: expected %d, got %d", k, v, lag[k])
+		}
+	}
+	if len(lag) != replicationLagBucketLen {
+		t.Fatalf("expected %d lag intervals, got %d", replicationLagBucketLen, len(lag))
+	}
+}
+
+func TestReplicationStatsProxyLatency(t *testing.T) {
+	r := NewReplicationStats(context.Background(), nil)
+	r.UpdateProxyLatency("bucket", "arn", 100, 20*time.Millisecond)
+	r.UpdateProxyLatency("bucket", "arn", 100, 40*time.Millisecond)
+
+	st := r.Get("bucket").Stats["arn"]
+	latency := st.Latency.merge(ReplicationLatency{})
+	if got := latency.getProxyLatency()["LESS_THAN_1_KiB"]; got != 30 {
+		t.Fatalf("expected an average proxy latency of 30ms, got %dms", got)
+	}
+	if got := latency.getUploadLatency()["LESS_THAN_1_KiB"]; got != 0 {
+		t.Fatalf("proxied reads must not be counted as uploads, got %dms", got)
+	}
+}
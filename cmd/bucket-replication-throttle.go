@@ -0,0 +1,289 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/shirou/gopsutil/v3/disk"
+	psnet "github.com/shirou/gopsutil/v3/net"
+	"golang.org/x/time/rate"
+)
+
+// Interval at which adaptive replication samples the node utilization.
+const replicationThrottleInterval = 10 * time.Second
+
+// replicationThrottleConfig holds the node local limits of replication
+// workers configured in the `api` sub-system.
+type replicationThrottleConfig struct {
+	// Bytes per second a worker may transfer, 0 for unlimited.
+	WorkerBandwidth uint64
+	// Adaptive reduces the number of active workers while the
+	// node utilization is above the thresholds below.
+	Adaptive bool
+	// Busy time percentage of the busiest drive.
+	DiskUtil float64
+	// Bytes per second sent and received on all network
+	// interfaces, 0 to ignore network utilization.
+	NetBandwidth uint64
+}
+
+// replicationThrottle limits the replication workers of a node so that
+// replication cannot starve foreground S3 traffic. While backing off,
+// the number of active workers is halved on every busy sample and grows
+// again once the node is no longer busy.
+type replicationThrottle struct {
+	mu  sync.Mutex
+	cfg replicationThrottleConfig
+	// Number of workers replicating, and their limit while
+	// backing off, 0 otherwise.
+	active int
+	limit  int
+	// Closed and replaced whenever active or limit change.
+	changed chan struct{}
+}
+
+func newReplicationThrottle(cfg replicationThrottleConfig) *replicationThrottle {
+	return &replicationThrottle{
+		cfg:     cfg,
+		changed: make(chan struct{}),
+	}
+}
+
+func (t *replicationThrottle) notifyLocked() {
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+func (t *replicationThrottle) config() replicationThrottleConfig {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cfg
+}
+
+// SetConfig updates the limits, disabling adaptive replication
+// stops backing off immediately.
+func (t *replicationThrottle) SetConfig(cfg replicationThrottleConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cfg = cfg
+	if !cfg.Adaptive && t.limit > 0 {
+		t.limit = 0
+		t.notifyLocked()
+	}
+}
+
+// Limit returns the number of workers allowed to replicate
+// concurrently, 0 while not backing off.
+func (t *replicationThrottle) Limit() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit
+}
+
+// acquire blocks until the worker may replicate, returns false if
+// ctx is canceled first. Callers must release after replicating.
+func (t *replicationThrottle) acquire(ctx context.Context) bool {
+	for {
+		t.mu.Lock()
+		if t.limit == 0 || t.active < t.limit {
+			t.active++
+			t.mu.Unlock()
+			return true
+		}
+		changed := t.changed
+		t.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-changed:
+		}
+	}
+}
+
+func (t *replicationThrottle) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active--
+	t.notifyLocked()
+}
+
+// adjust updates the worker limit after a utilization sample, workers
+// is the total number of replication workers of the node.
+func (t *replicationThrottle) adjust(busy bool, workers int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	limit := t.limit
+	switch {
+	case !t.cfg.Adaptive:
+		limit = 0
+	case busy:
+		if limit == 0 || limit > workers {
+			limit = workers
+		}
+		limit /= 2
+		if limit < 1 {
+			limit = 1
+		}
+	case limit > 0:
+		limit += limit/4 + 1
+		if limit >= workers {
+			limit = 0
+		}
+	}
+	if limit != t.limit {
+		t.limit = limit
+		t.notifyLocked()
+	}
+}
+
+type replicationWorkerLimiterKey struct{}
+
+// workerContext returns the context a worker replicates a task with,
+// carrying the bandwidth limit of the worker if any.
+func (t *replicationThrottle) workerContext(ctx context.Context) context.Context {
+	bw := t.config().WorkerBandwidth
+	if bw == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, replicationWorkerLimiterKey{}, rate.NewLimiter(rate.Limit(bw), int(bw)))
+}
+
+// replicationWorkerReader throttles reads to the bandwidth limit of the
+// replication worker, shared by all targets the worker replicates to.
+type replicationWorkerReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func newReplicationWorkerReader(ctx context.Context, r io.Reader) io.Reader {
+	limiter, ok := ctx.Value(replicationWorkerLimiterKey{}).(*rate.Limiter)
+	if !ok {
+		return r
+	}
+	return &replicationWorkerReader{ctx: ctx, r: r, limiter: limiter}
+}
+
+func (r *replicationWorkerReader) Read(buf []byte) (int, error) {
+	if b := r.limiter.Burst(); len(buf) > b {
+		buf = buf[:b]
+	}
+	if err := r.limiter.WaitN(r.ctx, len(buf)); err != nil {
+		return 0, err
+	}
+	return r.r.Read(buf)
+}
+
+// nodeUtilization is a sample of the cumulative IO counters of a node.
+type nodeUtilization struct {
+	at time.Time
+	// Milliseconds spent doing IO by device.
+	diskIOTime map[string]uint64
+	// Bytes sent and received on all non loopback interfaces.
+	netBytes uint64
+}
+
+func sampleNodeUtilization(ctx context.Context) (u nodeUtilization, err error) {
+	u.at = time.Now()
+	counters, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		return u, err
+	}
+	u.diskIOTime = make(map[string]uint64, len(counters))
+	for name, c := range counters {
+		u.diskIOTime[name] = c.IoTime
+	}
+
+	nics, err := psnet.IOCountersWithContext(ctx, true)
+	if err != nil {
+		return u, err
+	}
+	for _, nic := range nics {
+		if strings.HasPrefix(nic.Name, "lo") {
+			continue
+		}
+		u.netBytes += nic.BytesSent + nic.BytesRecv
+	}
+	return u, nil
+}
+
+// busy returns true if the utilization between prev and u crosses the
+// thresholds of cfg.
+func (u nodeUtilization) busy(prev nodeUtilization, cfg replicationThrottleConfig) bool {
+	elapsed := u.at.Sub(prev.at)
+	if elapsed <= 0 {
+		return false
+	}
+	for name, ioTime := range u.diskIOTime {
+		prevIOTime, ok := prev.diskIOTime[name]
+		if !ok || ioTime < prevIOTime {
+			continue
+		}
+		util := 100 * float64(time.Duration(ioTime-prevIOTime)*time.Millisecond) / float64(elapsed)
+		if util >= cfg.DiskUtil {
+			return true
+		}
+	}
+	if cfg.NetBandwidth > 0 && u.netBytes >= prev.netBytes {
+		bw := float64(u.netBytes-prev.netBytes) / elapsed.Seconds()
+		if bw >= float64(cfg.NetBandwidth) {
+			return true
+		}
+	}
+	return false
+}
+
+// run samples the node utilization while adaptive replication is
+// enabled and adjusts the worker limit accordingly.
+func (t *replicationThrottle) run(ctx context.Context, workers func() int) {
+	ticker := time.NewTicker(replicationThrottleInterval)
+	defer ticker.Stop()
+
+	var prev nodeUtilization
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cfg := t.config()
+		if !cfg.Adaptive {
+			prev = nodeUtilization{}
+			t.adjust(false, workers())
+			continue
+		}
+		u, err := sampleNodeUtilization(ctx)
+		if err != nil {
+			logger.LogOnceIf(ctx, err, "replication-adaptive")
+			continue
+		}
+		if !prev.at.IsZero() {
+			t.adjust(u.busy(prev, cfg), workers())
+		}
+		prev = u
+	}
+}
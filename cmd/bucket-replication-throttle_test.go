@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReplicationThrottleAdjust(t *testing.T) {
+	throttle := newReplicationThrottle(replicationThrottleConfig{Adaptive: true})
+
+	testCases := []struct {
+		busy     bool
+		expected int
+	}{
+		{true, 50},
+		{true, 25},
+		{false, 32},
+		{false, 41},
+		{true, 20},
+		{false, 26},
+		{false, 33},
+		{false, 42},
+		{false, 53},
+		{false, 67},
+		{false, 84},
+		{false, 0},
+	}
+	for i, testCase := range testCases {
+		throttle.adjust(testCase.busy, 100)
+		if got := throttle.Limit(); got != testCase.expected {
+			t.Fatalf("Test %d: expected limit %d, got %d", i+1, testCase.expected, got)
+		}
+	}
+
+	throttle.adjust(true, 1)
+	if got := throttle.Limit(); got != 1 {
+		t.Fatalf("expected limit 1, got %d", got)
+	}
+	throttle.SetConfig(replicationThrottleConfig{})
+	if got := throttle.Limit(); got != 0 {
+		t.Fatalf("expected no limit after disabling adaptive replication, got %d", got)
+	}
+}
+
+func TestReplicationThrottleAcquire(t *testing.T) {
+	throttle := newReplicationThrottle(replicationThrottleConfig{Adaptive: true})
+	throttle.adjust(true, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if !throttle.acquire(ctx) {
+		t.Fatal("expected first worker to be admitted")
+	}
+	if throttle.acquire(ctx) {
+		t.Fatal("expected second worker to wait while backing off")
+	}
+
+	done := make(chan bool)
+	go func() { done <- throttle.acquire(context.Background()) }()
+	throttle.release()
+	if !<-done {
+		t.Fatal("expected waiting worker to be admitted after release")
+	}
+}
+
+func TestNodeUtilizationBusy(t *testing.T) {
+	now := time.Now()
+	prev := nodeUtilization{at: now, diskIOTime: map[string]uint64{"sda": 1000, "sdb": 0}, netBytes: 0}
+	cfg := replicationThrottleConfig{DiskUtil: 90, NetBandwidth: 100 << 20}
+
+	testCases := []struct {
+		u        nodeUtilization
+		expected bool
+	}{
+		// 50% disk utilization, 50MiB/s
+		{nodeUtilization{at: now.Add(10 * time.Second), diskIOTime: map[string]uint64{"sda": 6000, "sdb": 0}, netBytes: 500 << 20}, false},
+		// 95% disk utilization on sdb
+		{nodeUtilization{at: now.Add(10 * time.Second), diskIOTime: map[string]uint64{"sda": 1000, "sdb": 9500}}, true},
+		// 200MiB/s
+		{nodeUtilization{at: now.Add(10 * time.Second), diskIOTime: map[string]uint64{"sda": 1000}, netBytes: 2000 << 20}, true},
+	}
+	for i, testCase := range testCases {
+		if got := testCase.u.busy(prev, cfg); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -41,7 +41,8 @@ type replicatedTargetInfo struct {
 	PrevReplicationStatus replication.StatusType
 	VersionPurgeStatus    VersionPurgeStatusType
 	ResyncTimestamp       string
-	ReplicationResynced   bool // true only if resync attempted for this target
+	ReplicationResynced   bool  // true only if resync attempted for this target
+	Err                   error // reason replication to the target failed
 }
 
 // Empty returns true for a target if arn is empty
@@ -175,6 +176,9 @@ type replicateTargetDecision struct {
 	Synchronous bool   // Synchronous replication configured.
 	Arn         string // ARN of replication target
 	ID          string
+	// Timeout of synchronous replication, replication continues
+	// asynchronously after it elapsed. Zero waits for replication.
+	Timeout time.Duration
 }
 
 func (t *replicateTargetDecision) String() string {
@@ -215,6 +219,23 @@ func (d *ReplicateDecision) Synchronous() bool {
 	return false
 }
 
+// SyncTimeout returns the time to wait for synchronous replication,
+// zero if any synchronous target waits until replication completes.
+func (d *ReplicateDecision) SyncTimeout() (timeout time.Duration) {
+	for _, t := range d.targetsMap {
+		if !t.Synchronous {
+			continue
+		}
+		if t.Timeout <= 0 {
+			return 0
+		}
+		if t.Timeout > timeout {
+			timeout = t.Timeout
+		}
+	}
+	return timeout
+}
+
 func (d *ReplicateDecision) String() string {
 	b := new(bytes.Buffer)
 	for key, value := range d.targetsMap {
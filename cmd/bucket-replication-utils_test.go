@@ -18,7 +18,9 @@
 package cmd
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/minio/minio/internal/bucket/replication"
 )
@@ -245,3 +247,29 @@ func TestCompositeReplicationStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestReplicateDecisionSyncTimeout(t *testing.T) {
+	newDecision := func(sync bool, timeout time.Duration) replicateTargetDecision {
+		d := newReplicateTargetDecision(fmt.Sprintf("arn-%t-%s", sync, timeout), true, sync)
+		d.Timeout = timeout
+		return d
+	}
+	testCases := []struct {
+		targets  []replicateTargetDecision
+		expected time.Duration
+	}{
+		{[]replicateTargetDecision{newDecision(false, 0)}, 0},
+		{[]replicateTargetDecision{newDecision(true, 5*time.Second), newDecision(true, 10*time.Second), newDecision(false, 0)}, 10 * time.Second},
+		// Synchronous targets without timeout wait for replication.
+		{[]replicateTargetDecision{newDecision(true, 5*time.Second), newDecision(true, 0)}, 0},
+	}
+	for i, tc := range testCases {
+		var dsc ReplicateDecision
+		for _, d := range tc.targets {
+			dsc.Set(d)
+		}
+		if got := dsc.SyncTimeout(); got != tc.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expected, got)
+		}
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/internal/bucket/bandwidth"
 	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/config/limits"
 	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
@@ -53,6 +54,9 @@ const (
 	ReplicationStatus = "replication-status"
 	// ReplicationTimestamp - the last time replication was initiated on this cluster for this object version
 	ReplicationTimestamp = "replication-timestamp"
+	// ReplicationSyncStatus - set if synchronous replication of this object version timed out and
+	// continued asynchronously
+	ReplicationSyncStatus = "replication-sync-status"
 	// ReplicaStatus - this header is present if a replica was received by this cluster for this object version
 	ReplicaStatus = "replica-status"
 	// ReplicaTimestamp - the last time a replica was received by this cluster for this object version
@@ -104,6 +108,16 @@ func validateReplicationDestination(ctx context.Context, bucket string, rCfg *re
 		if clnt == nil {
 			return false, toAPIError(ctx, BucketRemoteTargetNotFound{Bucket: bucket})
 		}
+		if clnt.native != nil {
+			if found, err := clnt.native.BucketExists(ctx); !found {
+				return false, errorCodes.ToAPIErrWithErr(ErrRemoteDestinationNotFoundError, err)
+			}
+			// object lock is not replicated to native targets
+			if ret, err := globalBucketObjectLockSys.Get(bucket); err == nil && ret.LockEnabled {
+				return false, errorCodes.ToAPIErr(ErrReplicationDestinationMissingLock)
+			}
+			return false, toAPIError(ctx, nil)
+		}
 		if found, err := clnt.BucketExists(ctx, arn.Bucket); !found {
 			return false, errorCodes.ToAPIErrWithErr(ErrRemoteDestinationNotFoundError, err)
 		}
@@ -191,6 +205,7 @@ func mustReplicate(ctx context.Context, bucket, object string, mopts mustReplica
 	opts := replication.ObjectOpts{
 		Name:           object,
 		SSEC:           crypto.SSEC.IsEncrypted(mopts.meta),
+		UserMetadata:   mopts.meta,
 		Replica:        replStatus == replication.Replica,
 		ExistingObject: mopts.isExistingObjectReplication(),
 	}
@@ -205,11 +220,14 @@ func mustReplicate(ctx context.Context, bucket, object string, mopts mustReplica
 		// whether to replicate as the target could be temporarily down
 		opts.TargetArn = tgtArn
 		replicate := cfg.Replicate(opts)
-		var synchronous bool
-		if tgt != nil {
-			synchronous = tgt.replicateSync
+		synchronous, timeout := cfg.SyncReplication(opts)
+		if tgt != nil && tgt.replicateSync {
+			// Synchronous targets wait until replication completes.
+			synchronous, timeout = true, 0
 		}
-		dsc.Set(newReplicateTargetDecision(tgtArn, replicate, synchronous))
+		d := newReplicateTargetDecision(tgtArn, replicate, synchronous)
+		d.Timeout = timeout
+		dsc.Set(d)
 	}
 	return dsc
 }
@@ -264,9 +282,11 @@ func checkReplicateDelete(ctx context.Context, bucket string, dobj ObjectToDelet
 		Name:         dobj.ObjectName,
 		SSEC:         crypto.SSEC.IsEncrypted(oi.UserDefined),
 		UserTags:     oi.UserTags,
+		UserMetadata: oi.UserDefined,
 		DeleteMarker: oi.DeleteMarker,
 		VersionID:    dobj.VersionID,
 		OpType:       replication.DeleteReplicationType,
+		Replica:      oi.ReplicationStatus == replication.Replica,
 	}
 	tgtArns := rcfg.FilterTargetArns(opts)
 	if len(tgtArns) > 0 {
@@ -427,6 +447,7 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectReplicationInfo, obj
 		}(idx, tgt)
 	}
 	wg.Wait()
+	updateReplicationDeleteDLQ(ctx, objectAPI, dobj, rinfos)
 
 	replicationStatus = rinfos.ReplicationStatus()
 	prevStatus := dobj.DeleteMarkerReplicationStatus()
@@ -501,7 +522,8 @@ func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectReplicationI
 		return
 	}
 	if tgt.IsOffline() {
-		logger.LogIf(ctx, fmt.Errorf("remote target is offline for bucket:%s arn:%s", dobj.Bucket, tgt.ARN))
+		rinfo.Err = fmt.Errorf("remote target is offline for bucket:%s arn:%s", dobj.Bucket, tgt.ARN)
+		logger.LogIf(ctx, rinfo.Err)
 		sendEvent(eventArgs{
 			BucketName: dobj.Bucket,
 			Object: ObjectInfo{
@@ -520,6 +542,9 @@ func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectReplicationI
 		}
 		return
 	}
+	if tgt.native != nil {
+		return replicateDeleteToNativeTarget(ctx, dobj, tgt, rinfo)
+	}
 	// early return if already replicated delete marker for existing object replication/ healing delete markers
 	if dobj.DeleteMarkerVersionID != "" && (dobj.OpType == replication.ExistingObjectReplicationType || dobj.OpType == replication.HealReplicationType) {
 		if _, err := tgt.StatObject(ctx, tgt.Bucket, dobj.ObjectName, miniogo.StatObjectOptions{
@@ -545,6 +570,7 @@ func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectReplicationI
 		},
 	})
 	if rmErr != nil {
+		rinfo.Err = rmErr
 		if dobj.VersionID == "" {
 			rinfo.ReplicationStatus = replication.Failed
 		} else {
@@ -576,6 +602,11 @@ func getCopyObjMetadata(oi ObjectInfo, sc string) map[string]string {
 		if equals(k, xhttp.AmzMetaUnencryptedContentLength, xhttp.AmzMetaUnencryptedContentMD5) {
 			continue
 		}
+
+		// Removed retention is replicated through the retention timestamp.
+		if v == "" && equals(k, xhttp.AmzObjectLockMode, xhttp.AmzObjectLockRetainUntilDate, xhttp.AmzObjectLockLegalHold) {
+			continue
+		}
 		meta[k] = v
 	}
 
@@ -680,36 +711,26 @@ func putReplicationOpts(ctx context.Context, sc string, objInfo ObjectInfo) (put
 	if cc, ok := lkMap.Lookup(xhttp.CacheControl); ok {
 		putOpts.CacheControl = cc
 	}
-	if mode, ok := lkMap.Lookup(xhttp.AmzObjectLockMode); ok {
+	retTimestamp, lholdTimestamp, err := objectLockTimestamps(objInfo)
+	if err != nil {
+		return putOpts, err
+	}
+	if mode, ok := lkMap.Lookup(xhttp.AmzObjectLockMode); ok && mode != "" {
 		rmode := miniogo.RetentionMode(mode)
 		putOpts.Mode = rmode
 	}
-	if retainDateStr, ok := lkMap.Lookup(xhttp.AmzObjectLockRetainUntilDate); ok {
+	if retainDateStr, ok := lkMap.Lookup(xhttp.AmzObjectLockRetainUntilDate); ok && retainDateStr != "" {
 		rdate, err := time.Parse(time.RFC3339, retainDateStr)
 		if err != nil {
 			return putOpts, err
 		}
 		putOpts.RetainUntilDate = rdate
 		// set retention timestamp in opts
-		retTimestamp := objInfo.ModTime
-		if retainTmstampStr, ok := objInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp]; ok {
-			retTimestamp, err = time.Parse(time.RFC3339Nano, retainTmstampStr)
-			if err != nil {
-				return putOpts, err
-			}
-		}
 		putOpts.Internal.RetentionTimestamp = retTimestamp
 	}
-	if lhold, ok := lkMap.Lookup(xhttp.AmzObjectLockLegalHold); ok {
+	if lhold, ok := lkMap.Lookup(xhttp.AmzObjectLockLegalHold); ok && lhold != "" {
 		putOpts.LegalHold = miniogo.LegalHoldStatus(lhold)
 		// set legalhold timestamp in opts
-		lholdTimestamp := objInfo.ModTime
-		if lholdTmstampStr, ok := objInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockLegalHoldTimestamp]; ok {
-			lholdTimestamp, err = time.Parse(time.RFC3339Nano, lholdTmstampStr)
-			if err != nil {
-				return putOpts, err
-			}
-		}
 		putOpts.Internal.LegalholdTimestamp = lholdTimestamp
 	}
 	if crypto.S3.IsEncrypted(objInfo.UserDefined) {
@@ -718,6 +739,31 @@ func putReplicationOpts(ctx context.Context, sc string, objInfo ObjectInfo) (put
 	return
 }
 
+// objectLockTimestamps returns the times of the last retention and legal
+// hold changes of the object version, zero if the version never had the
+// setting. Settings applied on upload have the modification time of the
+// version.
+func objectLockTimestamps(oi ObjectInfo) (retention, legalHold time.Time, err error) {
+	lkMap := caseInsensitiveMap(oi.UserDefined)
+	if _, ok := lkMap.Lookup(xhttp.AmzObjectLockRetainUntilDate); ok {
+		retention = oi.ModTime
+	}
+	if ts, ok := oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp]; ok {
+		if retention, err = time.Parse(time.RFC3339Nano, ts); err != nil {
+			return retention, legalHold, err
+		}
+	}
+	if _, ok := lkMap.Lookup(xhttp.AmzObjectLockLegalHold); ok {
+		legalHold = oi.ModTime
+	}
+	if ts, ok := oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockLegalHoldTimestamp]; ok {
+		if legalHold, err = time.Parse(time.RFC3339Nano, ts); err != nil {
+			return retention, legalHold, err
+		}
+	}
+	return retention, legalHold, nil
+}
+
 type replicationAction string
 
 const (
@@ -798,7 +844,8 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo, opType replicati
 			found = true
 			break
 		}
-		if found {
+		// Removed retention is kept on the source as empty values.
+		if found && v != "" {
 			compareMeta1[strings.ToLower(k)] = v
 		}
 	}
@@ -818,6 +865,14 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo, opType replicati
 		}
 	}
 
+	// Retention dates may be formatted with or without fractional seconds.
+	retainKey := strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)
+	for _, m := range []map[string]string{compareMeta1, compareMeta2} {
+		if t, err := time.Parse(time.RFC3339, m[retainKey]); err == nil {
+			m[retainKey] = t.UTC().Format(time.RFC3339)
+		}
+	}
+
 	if !reflect.DeepEqual(compareMeta1, compareMeta2) {
 		return replicateMetadata
 	}
@@ -826,9 +881,9 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo, opType replicati
 }
 
 // replicateObject replicates the specified version of the object to destination bucket
-// The source object is then updated to reflect the replication status.
-func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI ObjectLayer, trigger string) {
-	var replicationStatus replication.StatusType
+// The source object is then updated to reflect the replication status, which is
+// returned if replication was attempted.
+func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI ObjectLayer, trigger string) (replicationStatus replication.StatusType) {
 	defer func() {
 		if replicationStatus.Empty() {
 			// replication status is empty means
@@ -861,8 +916,10 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		return
 	}
 	tgtArns := cfg.FilterTargetArns(replication.ObjectOpts{
-		Name: object,
-		SSEC: crypto.SSEC.IsEncrypted(objInfo.UserDefined),
+		Name:         object,
+		SSEC:         crypto.SSEC.IsEncrypted(objInfo.UserDefined),
+		UserTags:     objInfo.UserTags,
+		UserMetadata: objInfo.UserDefined,
 	})
 	// Lock the object name before starting replication.
 	// Use separate lock that doesn't collide with regular objects.
@@ -903,9 +960,7 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		}(i, tgt)
 	}
 	wg.Wait()
-	// FIXME: add support for missing replication events
-	// - event.ObjectReplicationMissedThreshold
-	// - event.ObjectReplicationReplicatedAfterThreshold
+	replicationStatus = rinfos.ReplicationStatus()
 	eventName := event.ObjectReplicationComplete
 	if rinfos.ReplicationStatus() == replication.Failed {
 		eventName = event.ObjectReplicationFailed
@@ -945,6 +1000,12 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			if rinfo.ReplicationStatus != rinfo.PrevReplicationStatus {
 				globalReplicationStats.Update(bucket, rinfo.Arn, rinfo.Size, rinfo.Duration, rinfo.ReplicationStatus, rinfo.PrevReplicationStatus, opType)
 			}
+			// lag of metadata updates and existing object replication
+			// reflects the age of the object rather than replication delays.
+			if rinfo.ReplicationStatus == replication.Completed && rinfo.PrevReplicationStatus != replication.Completed &&
+				opType == replication.ObjectReplicationType && ri.OpType != replication.ExistingObjectReplicationType {
+				globalReplicationStats.UpdateLag(bucket, rinfo.Arn, UTCNow().Sub(objInfo.ModTime))
+			}
 		}
 	}
 
@@ -955,6 +1016,9 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		Host:       "Internal: [Replication]",
 	})
 
+	sendReplicationThresholdEvents(cfg, ri, rinfos)
+	updateReplicationDLQ(ctx, objectAPI, ri, rinfos)
+
 	// re-queue failures once more - keep a retry count to avoid flooding the queue if
 	// the target site is down. Leave it to scanner to catch up instead.
 	if rinfos.ReplicationStatus() != replication.Completed && ri.RetryCount < 1 {
@@ -963,6 +1027,7 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 		ri.RetryCount++
 		globalReplicationPool.queueReplicaFailedTask(ri)
 	}
+	return
 }
 
 // replicateObjectToTarget replicates the specified version of the object to destination bucket
@@ -1003,7 +1068,8 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 		return
 	}
 	if tgt.IsOffline() {
-		logger.LogIf(ctx, fmt.Errorf("remote target is offline for bucket:%s arn:%s", bucket, tgt.ARN))
+		rinfo.Err = fmt.Errorf("remote target is offline for bucket:%s arn:%s", bucket, tgt.ARN)
+		logger.LogIf(ctx, rinfo.Err)
 		sendEvent(eventArgs{
 			EventName:  event.ObjectReplicationNotTracked,
 			BucketName: bucket,
@@ -1017,6 +1083,7 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 		VersionID: objInfo.VersionID,
 	})
 	if err != nil {
+		rinfo.Err = err
 		sendEvent(eventArgs{
 			EventName:  event.ObjectReplicationNotTracked,
 			BucketName: bucket,
@@ -1057,6 +1124,10 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 		return rinfo
 	}
 
+	if tgt.native != nil {
+		return replicateObjectToNativeTarget(ctx, ri, gr, size, tgt, rinfo, startTime)
+	}
+
 	rAction = replicateAll
 	oi, cerr := tgt.StatObject(ctx, tgt.Bucket, object, miniogo.StatObjectOptions{
 		VersionID: objInfo.VersionID,
@@ -1124,14 +1195,25 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 				ReplicationRequest: true, // always set this to distinguish between `mc mirror` replication and serverside
 			},
 		}
+		// Send the times of the last retention and legal hold changes so
+		// that the target applies only changes newer than its own.
+		dstOpts.Internal.RetentionTimestamp, dstOpts.Internal.LegalholdTimestamp, err = objectLockTimestamps(objInfo)
+		if err != nil {
+			rinfo.ReplicationStatus = replication.Failed
+			rinfo.Err = err
+			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
+			return
+		}
 		if _, err = c.CopyObject(ctx, tgt.Bucket, object, tgt.Bucket, object, getCopyObjMetadata(objInfo, tgt.StorageClass), srcOpts, dstOpts); err != nil {
 			rinfo.ReplicationStatus = replication.Failed
+			rinfo.Err = err
 			logger.LogIf(ctx, fmt.Errorf("Unable to replicate metadata for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 		}
 	} else {
 		var putOpts minio.PutObjectOptions
 		putOpts, err = putReplicationOpts(ctx, tgt.StorageClass, objInfo)
 		if err != nil {
+			rinfo.Err = err
 			logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s err:%w", bucket, err))
 			sendEvent(eventArgs{
 				EventName:  event.ObjectReplicationNotTracked,
@@ -1148,24 +1230,27 @@ func replicateObjectToTarget(ctx context.Context, ri ReplicateObjectInfo, object
 
 		opts := &bandwidth.MonitorReaderOptions{
 			Bucket:     objInfo.Bucket,
+			TargetARN:  tgt.ARN,
 			HeaderSize: headerSize,
 		}
 		newCtx := ctx
-		if globalBucketMonitor.IsThrottled(bucket) {
+		if globalBucketMonitor.IsTargetThrottled(bucket, tgt.ARN) {
 			var cancel context.CancelFunc
 			newCtx, cancel = context.WithTimeout(ctx, throttleDeadline)
 			defer cancel()
 		}
-		r := bandwidth.NewMonitoredReader(newCtx, globalBucketMonitor, gr, opts)
+		r := newReplicationWorkerReader(newCtx, bandwidth.NewMonitoredReader(newCtx, globalBucketMonitor, gr, opts))
 		if objInfo.isMultipart() {
 			if err := replicateObjectWithMultipart(ctx, c, tgt.Bucket, object,
 				r, objInfo, putOpts); err != nil {
 				rinfo.ReplicationStatus = replication.Failed
+				rinfo.Err = err
 				logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 			}
 		} else {
 			if _, err = c.PutObject(ctx, tgt.Bucket, object, r, size, "", "", putOpts); err != nil {
 				rinfo.ReplicationStatus = replication.Failed
+				rinfo.Err = err
 				logger.LogIf(ctx, fmt.Errorf("Unable to replicate for object %s/%s(%s): %s", bucket, objInfo.Name, objInfo.VersionID, err))
 			}
 		}
@@ -1300,11 +1385,12 @@ type ReplicationPool struct {
 	ctx                     context.Context
 	mrfWorkerKillCh         chan struct{}
 	workerKillCh            chan struct{}
-	replicaCh               chan ReplicateObjectInfo
-	replicaDeleteCh         chan DeletedObjectReplicationInfo
-	mrfReplicaCh            chan ReplicateObjectInfo
+	replicaQueue            *replicationQueue
+	replicaDeleteQueue      *replicationQueue
+	mrfReplicaQueue         *replicationQueue
 	existingReplicaCh       chan ReplicateObjectInfo
 	existingReplicaDeleteCh chan DeletedObjectReplicationInfo
+	throttle                *replicationThrottle
 	workerSize              int
 	mrfWorkerSize           int
 	workerWg                sync.WaitGroup
@@ -1316,13 +1402,14 @@ type ReplicationPool struct {
 // NewReplicationPool creates a pool of replication workers of specified size
 func NewReplicationPool(ctx context.Context, o ObjectLayer, opts replicationPoolOpts) *ReplicationPool {
 	pool := &ReplicationPool{
-		replicaCh:               make(chan ReplicateObjectInfo, 100000),
-		replicaDeleteCh:         make(chan DeletedObjectReplicationInfo, 100000),
-		mrfReplicaCh:            make(chan ReplicateObjectInfo, 100000),
+		replicaQueue:            newReplicationQueue(100000),
+		replicaDeleteQueue:      newReplicationQueue(100000),
+		mrfReplicaQueue:         newReplicationQueue(100000),
 		workerKillCh:            make(chan struct{}, opts.Workers),
 		mrfWorkerKillCh:         make(chan struct{}, opts.FailedWorkers),
 		existingReplicaCh:       make(chan ReplicateObjectInfo, 100000),
 		existingReplicaDeleteCh: make(chan DeletedObjectReplicationInfo, 100000),
+		throttle:                newReplicationThrottle(opts.Throttle),
 		ctx:                     ctx,
 		objLayer:                o,
 	}
@@ -1330,9 +1417,38 @@ func NewReplicationPool(ctx context.Context, o ObjectLayer, opts replicationPool
 	pool.ResizeWorkers(opts.Workers)
 	pool.ResizeFailedWorkers(opts.FailedWorkers)
 	go pool.AddExistingObjectReplicateWorker()
+	go pool.throttle.run(ctx, pool.totalWorkers)
 	return pool
 }
 
+// totalWorkers returns the number of replication workers of this node.
+func (p *ReplicationPool) totalWorkers() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// existing object replication has a single worker.
+	return p.workerSize + p.mrfWorkerSize + 1
+}
+
+// throttled runs fn once the throttle admits the worker, with a context
+// carrying the bandwidth limit of the worker.
+func (p *ReplicationPool) throttled(fn func(ctx context.Context)) {
+	release, ok := globalResourceLimits.acquire(p.ctx, limits.Replication)
+	if !ok {
+		return
+	}
+	defer release()
+	if !p.throttle.acquire(p.ctx) {
+		return
+	}
+	defer p.throttle.release()
+	fn(p.throttle.workerContext(p.ctx))
+}
+
+// SetThrottle updates the replication worker limits.
+func (p *ReplicationPool) SetThrottle(cfg replicationThrottleConfig) {
+	p.throttle.SetConfig(cfg)
+}
+
 // AddMRFWorker adds a pending/failed replication worker to handle requests that could not be queued
 // to the other workers
 func (p *ReplicationPool) AddMRFWorker() {
@@ -1340,11 +1456,12 @@ func (p *ReplicationPool) AddMRFWorker() {
 		select {
 		case <-p.ctx.Done():
 			return
-		case oi, ok := <-p.mrfReplicaCh:
-			if !ok {
-				return
+		case <-p.mrfReplicaQueue.ready():
+			if oi, ok := p.mrfReplicaQueue.pop(); ok {
+				p.throttled(func(ctx context.Context) {
+					replicateObject(ctx, oi.(ReplicateObjectInfo), p.objLayer, ReplicateMRF)
+				})
 			}
-			replicateObject(p.ctx, oi, p.objLayer, ReplicateMRF)
 		case <-p.mrfWorkerKillCh:
 			return
 		}
@@ -1358,16 +1475,18 @@ func (p *ReplicationPool) AddWorker() {
 		select {
 		case <-p.ctx.Done():
 			return
-		case oi, ok := <-p.replicaCh:
-			if !ok {
-				return
+		case <-p.replicaQueue.ready():
+			if oi, ok := p.replicaQueue.pop(); ok {
+				p.throttled(func(ctx context.Context) {
+					replicateObject(ctx, oi.(ReplicateObjectInfo), p.objLayer, ReplicateIncoming)
+				})
 			}
-			replicateObject(p.ctx, oi, p.objLayer, ReplicateIncoming)
-		case doi, ok := <-p.replicaDeleteCh:
-			if !ok {
-				return
+		case <-p.replicaDeleteQueue.ready():
+			if doi, ok := p.replicaDeleteQueue.pop(); ok {
+				p.throttled(func(ctx context.Context) {
+					replicateDelete(ctx, doi.(DeletedObjectReplicationInfo), p.objLayer, ReplicateDelete)
+				})
 			}
-			replicateDelete(p.ctx, doi, p.objLayer, ReplicateDelete)
 		case <-p.workerKillCh:
 			return
 		}
@@ -1384,12 +1503,16 @@ func (p *ReplicationPool) AddExistingObjectReplicateWorker() {
 			if !ok {
 				return
 			}
-			replicateObject(p.ctx, oi, p.objLayer, ReplicateExisting)
+			p.throttled(func(ctx context.Context) {
+				replicateObject(ctx, oi, p.objLayer, ReplicateExisting)
+			})
 		case doi, ok := <-p.existingReplicaDeleteCh:
 			if !ok {
 				return
 			}
-			replicateDelete(p.ctx, doi, p.objLayer, ReplicateExistingDelete)
+			p.throttled(func(ctx context.Context) {
+				replicateDelete(ctx, doi, p.objLayer, ReplicateExistingDelete)
+			})
 		}
 	}
 }
@@ -1438,15 +1561,8 @@ func (p *ReplicationPool) queueReplicaFailedTask(ri ReplicateObjectInfo) {
 	if p == nil {
 		return
 	}
-	select {
-	case <-GlobalContext.Done():
-		p.once.Do(func() {
-			close(p.replicaCh)
-			close(p.mrfReplicaCh)
-			close(p.existingReplicaCh)
-		})
-	case p.mrfReplicaCh <- ri:
-	default:
+	flow, weight := replicationTaskFlow(GlobalContext, ri.Bucket, ri.replicationOpts())
+	if !p.mrfReplicaQueue.push(flow, weight, ri) {
 		logger.LogOnceIf(GlobalContext, fmt.Errorf("WARNING: Replication failed workers could not keep up with healing failures - consider increasing number of replication failed workers with `mc admin config set api replication_failed_workers=%d`", p.suggestedWorkers(true)), replicationSubsystem)
 	}
 }
@@ -1455,28 +1571,38 @@ func (p *ReplicationPool) queueReplicaTask(ri ReplicateObjectInfo) {
 	if p == nil {
 		return
 	}
-	var ch chan ReplicateObjectInfo
-	switch ri.OpType {
-	case replication.ExistingObjectReplicationType:
-		ch = p.existingReplicaCh
-	case replication.HealReplicationType:
-		fallthrough
-	default:
-		ch = p.replicaCh
+	if ri.OpType != replication.ExistingObjectReplicationType {
+		flow, weight := replicationTaskFlow(GlobalContext, ri.Bucket, ri.replicationOpts())
+		if !p.replicaQueue.push(flow, weight, ri) {
+			logger.LogOnceIf(GlobalContext, fmt.Errorf("WARNING: Replication workers could not keep up with incoming traffic - consider increasing number of replication workers with `mc admin config set api replication_workers=%d`", p.suggestedWorkers(false)), replicationSubsystem)
+		}
+		return
 	}
 	select {
 	case <-GlobalContext.Done():
 		p.once.Do(func() {
-			close(p.replicaCh)
-			close(p.mrfReplicaCh)
 			close(p.existingReplicaCh)
 		})
-	case ch <- ri:
+	case p.existingReplicaCh <- ri:
 	default:
 		logger.LogOnceIf(GlobalContext, fmt.Errorf("WARNING: Replication workers could not keep up with incoming traffic - consider increasing number of replication workers with `mc admin config set api replication_workers=%d`", p.suggestedWorkers(false)), replicationSubsystem)
 	}
 }
 
+// queueReplicaTaskWait queues ri for existing object replication,
+// waiting for the workers to catch up instead of dropping the task.
+func (p *ReplicationPool) queueReplicaTaskWait(ctx context.Context, ri ReplicateObjectInfo) error {
+	if p == nil {
+		return errServerNotInitialized
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.existingReplicaCh <- ri:
+		return nil
+	}
+}
+
 func queueReplicateDeletesWrapper(doi DeletedObjectReplicationInfo, existingObjectResync ResyncDecision) {
 	for k, v := range existingObjectResync.targets {
 		if v.Replicate {
@@ -1492,23 +1618,20 @@ func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInf
 	if p == nil {
 		return
 	}
-	var ch chan DeletedObjectReplicationInfo
-	switch doi.OpType {
-	case replication.ExistingObjectReplicationType:
-		ch = p.existingReplicaDeleteCh
-	case replication.HealReplicationType:
-		fallthrough
-	default:
-		ch = p.replicaDeleteCh
+	if doi.OpType != replication.ExistingObjectReplicationType {
+		flow, weight := replicationTaskFlow(GlobalContext, doi.Bucket, doi.replicationOpts())
+		if !p.replicaDeleteQueue.push(flow, weight, doi) {
+			logger.LogOnceIf(GlobalContext, fmt.Errorf("WARNING: Replication workers could not keep up with incoming traffic - consider increasing number of replication workers with `mc admin config set api replication_workers=%d`", p.suggestedWorkers(false)), replicationSubsystem)
+		}
+		return
 	}
 
 	select {
 	case <-GlobalContext.Done():
 		p.once.Do(func() {
-			close(p.replicaDeleteCh)
 			close(p.existingReplicaDeleteCh)
 		})
-	case ch <- doi:
+	case p.existingReplicaDeleteCh <- doi:
 	default:
 		logger.LogOnceIf(GlobalContext, fmt.Errorf("WARNING: Replication workers could not keep up with incoming traffic - consider increasing number of replication workers with `mc admin config set api replication_workers=%d`", p.suggestedWorkers(false)), replicationSubsystem)
 	}
@@ -1517,24 +1640,40 @@ func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInf
 type replicationPoolOpts struct {
 	Workers       int
 	FailedWorkers int
+	Throttle      replicationThrottleConfig
 }
 
 func initBackgroundReplication(ctx context.Context, objectAPI ObjectLayer) {
 	globalReplicationPool = NewReplicationPool(ctx, objectAPI, replicationPoolOpts{
 		Workers:       globalAPIConfig.getReplicationWorkers(),
 		FailedWorkers: globalAPIConfig.getReplicationFailedWorkers(),
+		Throttle:      globalAPIConfig.getReplicationThrottle(),
 	})
 	globalReplicationStats = NewReplicationStats(ctx, objectAPI)
 	go globalReplicationStats.loadInitialReplicationMetrics(ctx)
+	go globalBandwidthScheduler.run(ctx, objectAPI)
 }
 
 // get Reader from replication target if active-active replication is in place and
-// this node returns a 404
+// this node returns a 404. Targets are tried in order until one of them returns
+// the object.
 func proxyGetToReplicationTarget(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, opts ObjectOptions, proxyTargets *madmin.BucketTargets) (gr *GetObjectReader, proxy bool) {
-	tgt, oi, proxy := proxyHeadToRepTarget(ctx, bucket, object, opts, proxyTargets)
-	if !proxy {
+	if !proxyAllowed(opts) {
 		return nil, false
 	}
+	for _, t := range proxyTargets.Targets {
+		tgt, oi, ok := proxyHeadToTarget(ctx, bucket, object, opts, t)
+		if !ok {
+			continue
+		}
+		if gr, ok = proxyGetToTarget(ctx, tgt, oi, rs, h, opts); ok {
+			return gr, true
+		}
+	}
+	return nil, false
+}
+
+func proxyGetToTarget(ctx context.Context, tgt *TargetClient, oi ObjectInfo, rs *HTTPRangeSpec, h http.Header, opts ObjectOptions) (gr *GetObjectReader, proxy bool) {
 	fn, off, length, err := NewGetObjectReader(rs, oi, opts)
 	if err != nil {
 		return nil, false
@@ -1557,10 +1696,12 @@ func proxyGetToReplicationTarget(ctx context.Context, bucket, object string, rs
 		return nil, false
 	}
 	c := miniogo.Core{Client: tgt.Client}
-	obj, _, _, err := c.GetObject(ctx, bucket, object, gopts)
+	start := time.Now()
+	obj, _, _, err := c.GetObject(ctx, tgt.Bucket, oi.Name, gopts)
 	if err != nil {
 		return nil, false
 	}
+	globalReplicationStats.UpdateProxyLatency(oi.Bucket, tgt.ARN, oi.Size, time.Since(start))
 	closeReader := func() { obj.Close() }
 
 	reader, err := fn(obj, h, closeReader)
@@ -1571,6 +1712,8 @@ func proxyGetToReplicationTarget(ctx context.Context, bucket, object string, rs
 	return reader, true
 }
 
+// getproxyTargets returns the targets a read of an object missing locally
+// may be proxied to, ordered by the priority of their replication rules.
 func getproxyTargets(ctx context.Context, bucket, object string, opts ObjectOptions) (tgts *madmin.BucketTargets) {
 	cfg, err := getReplicationConfig(ctx, bucket)
 	if err != nil || cfg == nil {
@@ -1587,84 +1730,144 @@ func getproxyTargets(ctx context.Context, bucket, object string, opts ObjectOpti
 	return tgts
 }
 
-func proxyHeadToRepTarget(ctx context.Context, bucket, object string, opts ObjectOptions, proxyTargets *madmin.BucketTargets) (tgt *TargetClient, oi ObjectInfo, proxy bool) {
+// proxyAllowed returns false for requests that were proxied by a remote
+// site already.
+func proxyAllowed(opts ObjectOptions) bool {
 	// this option is set when active-active replication is in place between site A -> B,
 	// and site B does not have the object yet.
-	if opts.ProxyRequest || (opts.ProxyHeaderSet && !opts.ProxyRequest) { // true only when site B sets MinIOSourceProxyRequest header
+	return !opts.ProxyRequest && !opts.ProxyHeaderSet
+}
+
+// proxyHeadToTarget returns the object info from the target, skipping
+// targets that are offline or have proxying disabled.
+func proxyHeadToTarget(ctx context.Context, bucket, object string, opts ObjectOptions, t madmin.BucketTarget) (tgt *TargetClient, oi ObjectInfo, proxy bool) {
+	tgt = globalBucketTargetSys.GetRemoteTargetClient(ctx, t.Arn)
+	if tgt == nil || tgt.IsOffline() {
+		return nil, oi, false
+	}
+	// if proxying explicitly disabled on remote target
+	if tgt.disableProxy {
 		return nil, oi, false
 	}
-	for _, t := range proxyTargets.Targets {
-		tgt = globalBucketTargetSys.GetRemoteTargetClient(ctx, t.Arn)
-		if tgt == nil || tgt.IsOffline() {
-			continue
-		}
-		// if proxying explicitly disabled on remote target
-		if tgt.disableProxy {
-			continue
-		}
-
-		gopts := miniogo.GetObjectOptions{
-			VersionID:            opts.VersionID,
-			ServerSideEncryption: opts.ServerSideEncryption,
-			Internal: miniogo.AdvancedGetOptions{
-				ReplicationProxyRequest: "true",
-			},
-		}
-		objInfo, err := tgt.StatObject(ctx, t.TargetBucket, object, gopts)
-		if err != nil {
-			continue
-		}
 
-		tags, _ := tags.MapToObjectTags(objInfo.UserTags)
-		oi = ObjectInfo{
-			Bucket:                    bucket,
-			Name:                      object,
-			ModTime:                   objInfo.LastModified,
-			Size:                      objInfo.Size,
-			ETag:                      objInfo.ETag,
-			VersionID:                 objInfo.VersionID,
-			IsLatest:                  objInfo.IsLatest,
-			DeleteMarker:              objInfo.IsDeleteMarker,
-			ContentType:               objInfo.ContentType,
-			Expires:                   objInfo.Expires,
-			StorageClass:              objInfo.StorageClass,
-			ReplicationStatusInternal: objInfo.ReplicationStatus,
-			UserTags:                  tags.String(),
-		}
-		oi.UserDefined = make(map[string]string, len(objInfo.Metadata))
-		for k, v := range objInfo.Metadata {
-			oi.UserDefined[k] = v[0]
-		}
-		ce, ok := oi.UserDefined[xhttp.ContentEncoding]
-		if !ok {
-			ce, ok = oi.UserDefined[strings.ToLower(xhttp.ContentEncoding)]
-		}
-		if ok {
-			oi.ContentEncoding = ce
-		}
-		return tgt, oi, true
+	gopts := miniogo.GetObjectOptions{
+		VersionID:            opts.VersionID,
+		ServerSideEncryption: opts.ServerSideEncryption,
+		Internal: miniogo.AdvancedGetOptions{
+			ReplicationProxyRequest: "true",
+		},
+	}
+	start := time.Now()
+	objInfo, err := tgt.StatObject(ctx, t.TargetBucket, object, gopts)
+	if err != nil {
+		return nil, oi, false
+	}
+	globalReplicationStats.UpdateProxyLatency(bucket, t.Arn, objInfo.Size, time.Since(start))
+
+	tags, _ := tags.MapToObjectTags(objInfo.UserTags)
+	oi = ObjectInfo{
+		Bucket:                    bucket,
+		Name:                      object,
+		ModTime:                   objInfo.LastModified,
+		Size:                      objInfo.Size,
+		ETag:                      objInfo.ETag,
+		VersionID:                 objInfo.VersionID,
+		IsLatest:                  objInfo.IsLatest,
+		DeleteMarker:              objInfo.IsDeleteMarker,
+		ContentType:               objInfo.ContentType,
+		Expires:                   objInfo.Expires,
+		StorageClass:              objInfo.StorageClass,
+		ReplicationStatusInternal: objInfo.ReplicationStatus,
+		UserTags:                  tags.String(),
+	}
+	oi.UserDefined = make(map[string]string, len(objInfo.Metadata))
+	for k, v := range objInfo.Metadata {
+		oi.UserDefined[k] = v[0]
+	}
+	ce, ok := oi.UserDefined[xhttp.ContentEncoding]
+	if !ok {
+		ce, ok = oi.UserDefined[strings.ToLower(xhttp.ContentEncoding)]
+	}
+	if ok {
+		oi.ContentEncoding = ce
 	}
-	return nil, oi, false
+	return tgt, oi, true
 }
 
 // get object info from replication target if active-active replication is in place and
 // this node returns a 404
 func proxyHeadToReplicationTarget(ctx context.Context, bucket, object string, opts ObjectOptions, proxyTargets *madmin.BucketTargets) (oi ObjectInfo, proxy bool) {
-	_, oi, proxy = proxyHeadToRepTarget(ctx, bucket, object, opts, proxyTargets)
-	return oi, proxy
+	if !proxyAllowed(opts) {
+		return oi, false
+	}
+	for _, t := range proxyTargets.Targets {
+		if _, oi, proxy = proxyHeadToTarget(ctx, bucket, object, opts, t); proxy {
+			return oi, true
+		}
+	}
+	return oi, false
 }
 
-func scheduleReplication(ctx context.Context, objInfo ObjectInfo, o ObjectLayer, dsc ReplicateDecision, opType replication.Type) {
-	if dsc.Synchronous() {
-		replicateObject(ctx, ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType, Dsc: dsc}, o, ReplicateIncoming)
-	} else {
-		globalReplicationPool.queueReplicaTask(ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType, Dsc: dsc})
-	}
+// Synchronous replication status of objects whose replication continues
+// asynchronously after the synchronous replication timeout elapsed.
+const replicationSyncTimedOut = "ASYNC"
+
+// scheduleReplication replicates the object, returning the replication status
+// if it was replicated synchronously.
+func scheduleReplication(ctx context.Context, objInfo ObjectInfo, o ObjectLayer, dsc ReplicateDecision, opType replication.Type) (syncStatus string) {
+	// account pending replication before replicating, synchronous
+	// replication completes before returning.
 	if sz, err := objInfo.GetActualSize(); err == nil {
 		for arn := range dsc.targetsMap {
 			globalReplicationStats.Update(objInfo.Bucket, arn, sz, 0, objInfo.ReplicationStatus, replication.StatusType(""), opType)
 		}
 	}
+	ri := ReplicateObjectInfo{ObjectInfo: objInfo, OpType: opType, Dsc: dsc}
+	if !dsc.Synchronous() {
+		globalReplicationPool.queueReplicaTask(ri)
+		return ""
+	}
+	timeout := dsc.SyncTimeout()
+	if timeout <= 0 {
+		return replicateObject(ctx, ri, o, ReplicateIncoming).String()
+	}
+
+	// Replication must outlive the request once demoted to asynchronous.
+	statusCh := make(chan replication.StatusType, 1)
+	go func() {
+		statusCh <- replicateObject(GlobalContext, ri, o, ReplicateIncoming)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case status := <-statusCh:
+		return status.String()
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	if err := setReplicationSyncTimedOut(GlobalContext, o, objInfo); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s(%s): %w",
+			objInfo.Bucket, objInfo.Name, objInfo.VersionID, err))
+	}
+	return replicationSyncTimedOut
+}
+
+// setReplicationSyncTimedOut records in the object metadata that synchronous
+// replication of the object version continues asynchronously.
+func setReplicationSyncTimedOut(ctx context.Context, o ObjectLayer, objInfo ObjectInfo) error {
+	popts := ObjectOptions{
+		MTime:     objInfo.ModTime,
+		VersionID: objInfo.VersionID,
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			oi.UserDefined[ReservedMetadataPrefixLower+ReplicationSyncStatus] = replicationSyncTimedOut
+			if oi.UserTags != "" {
+				oi.UserDefined[xhttp.AmzObjectTagging] = oi.UserTags
+			}
+			return nil
+		},
+	}
+	_, err := o.PutObjectMetadata(ctx, objInfo.Bucket, objInfo.Name, popts)
+	return err
 }
 
 func scheduleReplicationDelete(ctx context.Context, dv DeletedObjectReplicationInfo, o ObjectLayer) {
@@ -1706,6 +1909,7 @@ func (c replicationConfig) Resync(ctx context.Context, oi ObjectInfo, dsc *Repli
 			Name:           oi.Name,
 			SSEC:           crypto.SSEC.IsEncrypted(oi.UserDefined),
 			UserTags:       oi.UserTags,
+			UserMetadata:   oi.UserDefined,
 			DeleteMarker:   oi.DeleteMarker,
 			VersionID:      oi.VersionID,
 			OpType:         replication.DeleteReplicationType,
@@ -1813,10 +2017,19 @@ func getLatestReplicationStats(bucket string, u BucketUsageInfo) (s BucketReplic
 				oldst = &BucketReplicationStat{}
 			}
 			stats[arn] = &BucketReplicationStat{
-				FailedCount:    stat.FailedCount + oldst.FailedCount,
-				FailedSize:     stat.FailedSize + oldst.FailedSize,
-				ReplicatedSize: stat.ReplicatedSize + oldst.ReplicatedSize,
-				Latency:        stat.Latency.merge(oldst.Latency),
+				FailedCount:         stat.FailedCount + oldst.FailedCount,
+				FailedSize:          stat.FailedSize + oldst.FailedSize,
+				ReplicatedSize:      stat.ReplicatedSize + oldst.ReplicatedSize,
+				PendingCount:        stat.PendingCount + oldst.PendingCount,
+				PendingSize:         stat.PendingSize + oldst.PendingSize,
+				CompletedOperations: stat.CompletedOperations + oldst.CompletedOperations,
+				FailedOperations:    stat.FailedOperations + oldst.FailedOperations,
+				Latency:             stat.Latency.merge(oldst.Latency),
+				Lag:                 stat.Lag.merge(oldst.Lag),
+				LastReplicationTime: stat.LastReplicationTime,
+			}
+			if oldst.LastReplicationTime.After(stat.LastReplicationTime) {
+				stats[arn].LastReplicationTime = oldst.LastReplicationTime
 			}
 		}
 	}
@@ -1833,6 +2046,8 @@ func getLatestReplicationStats(bucket string, u BucketUsageInfo) (s BucketReplic
 		st.ReplicatedSize += stat.ReplicatedSize
 		st.FailedSize += stat.FailedSize
 		st.FailedCount += stat.FailedCount
+		st.PendingSize += stat.PendingSize
+		st.PendingCount += stat.PendingCount
 	}
 	s = BucketReplicationStats{
 		Stats: make(map[string]*BucketReplicationStat, len(stats)),
@@ -1858,11 +2073,19 @@ func getLatestReplicationStats(bucket string, u BucketUsageInfo) (s BucketReplic
 		// happen since data usage picture can lag behind actual usage state at the time of cluster start
 		st.FailedSize = int64(math.Max(float64(tgtstat.FailedSize), 0))
 		st.FailedCount = int64(math.Max(float64(tgtstat.FailedCount), 0))
+		st.PendingSize = int64(math.Max(float64(tgtstat.PendingSize), 0))
+		st.PendingCount = int64(math.Max(float64(tgtstat.PendingCount), 0))
+		st.CompletedOperations = tgtstat.CompletedOperations
+		st.FailedOperations = tgtstat.FailedOperations
 		st.Latency = tgtstat.Latency
+		st.Lag = tgtstat.Lag
+		st.LastReplicationTime = tgtstat.LastReplicationTime
 
 		s.Stats[arn] = &st
 		s.FailedSize += st.FailedSize
 		s.FailedCount += st.FailedCount
+		s.PendingSize += st.PendingSize
+		s.PendingCount += st.PendingCount
 	}
 	// normalize overall stats
 	s.ReplicaSize = int64(math.Max(float64(totReplicaSize), float64(u.ReplicaSize)))
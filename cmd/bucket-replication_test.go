@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
@@ -288,3 +289,39 @@ func TestReplicationResyncwrapper(t *testing.T) {
 		}
 	}
 }
+
+func TestSetReplicationSyncTimedOut(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objAPI, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objAPI.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	if err = objAPI.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	objInfo, err := objAPI.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{
+		UserDefined: map[string]string{xhttp.AmzObjectTagging: "k=v"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = setReplicationSyncTimedOut(ctx, objAPI, objInfo); err != nil {
+		t.Fatal(err)
+	}
+
+	oi, err := objAPI.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := oi.UserDefined[ReservedMetadataPrefixLower+ReplicationSyncStatus]; s != replicationSyncTimedOut {
+		t.Fatalf("expected sync status %s, got %q", replicationSyncTimedOut, s)
+	}
+	if oi.UserTags != "k=v" || !oi.ModTime.Equal(objInfo.ModTime) {
+		t.Fatalf("object tags or modtime changed: %q %v", oi.UserTags, oi.ModTime)
+	}
+}
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"math"
 	"time"
 )
 
@@ -27,18 +28,20 @@ import (
 type ReplicationLatency struct {
 	// Single & Multipart PUTs latency
 	UploadHistogram LastMinuteLatencies
+	// Latency of reads proxied to the target
+	ProxyHistogram LastMinuteLatencies
 }
 
 // Merge two replication latency into a new one
 func (rl ReplicationLatency) merge(other ReplicationLatency) (newReplLatency ReplicationLatency) {
 	newReplLatency.UploadHistogram = rl.UploadHistogram.Merge(other.UploadHistogram)
+	newReplLatency.ProxyHistogram = rl.ProxyHistogram.Merge(other.ProxyHistogram)
 	return
 }
 
-// Get upload latency of each object size range
-func (rl ReplicationLatency) getUploadLatency() (ret map[string]uint64) {
+func lastMinuteLatenciesMillis(l LastMinuteLatencies) (ret map[string]uint64) {
 	ret = make(map[string]uint64)
-	avg := rl.UploadHistogram.GetAvg()
+	avg := l.GetAvg()
 	for k, v := range avg {
 		// Convert nanoseconds to milliseconds
 		ret[sizeTagToString(k)] = v.avg() / uint64(time.Millisecond)
@@ -46,11 +49,77 @@ func (rl ReplicationLatency) getUploadLatency() (ret map[string]uint64) {
 	return
 }
 
+// Get upload latency of each object size range
+func (rl ReplicationLatency) getUploadLatency() (ret map[string]uint64) {
+	return lastMinuteLatenciesMillis(rl.UploadHistogram)
+}
+
+// Get proxy latency of each object size range
+func (rl ReplicationLatency) getProxyLatency() (ret map[string]uint64) {
+	return lastMinuteLatenciesMillis(rl.ProxyHistogram)
+}
+
 // Update replication upload latency with a new value
 func (rl *ReplicationLatency) update(size int64, duration time.Duration) {
 	rl.UploadHistogram.Add(size, duration)
 }
 
+// Update proxy latency with a new value
+func (rl *ReplicationLatency) updateProxy(size int64, duration time.Duration) {
+	rl.ProxyHistogram.Add(size, duration)
+}
+
+// replicationLagBucketLen must be the length of replicationLagIntervals
+const replicationLagBucketLen = 7
+
+// replicationLagIntervals are the upper bounds of the replication lag
+// histogram intervals. Lag is the time from the modification of an
+// object version until it is replicated to a target.
+var replicationLagIntervals = [replicationLagBucketLen]struct {
+	name string
+	end  time.Duration
+}{
+	{"LESS_THAN_1_SEC", time.Second},
+	{"BETWEEN_1_SEC_AND_10_SEC", 10 * time.Second},
+	{"BETWEEN_10_SEC_AND_1_MIN", time.Minute},
+	{"BETWEEN_1_MIN_AND_10_MIN", 10 * time.Minute},
+	{"BETWEEN_10_MIN_AND_1_HOUR", time.Hour},
+	{"BETWEEN_1_HOUR_AND_1_DAY", 24 * time.Hour},
+	{"GREATER_THAN_1_DAY", math.MaxInt64},
+}
+
+// ReplicationLag holds the distribution of replication lag
+type ReplicationLag struct {
+	Histogram [replicationLagBucketLen]uint64
+}
+
+// update adds a replication with the given lag to the distribution
+func (rl *ReplicationLag) update(lag time.Duration) {
+	for i, interval := range replicationLagIntervals {
+		if lag < interval.end {
+			rl.Histogram[i]++
+			return
+		}
+	}
+}
+
+// merge two replication lag distributions into a new one
+func (rl ReplicationLag) merge(other ReplicationLag) (newLag ReplicationLag) {
+	for i := range rl.Histogram {
+		newLag.Histogram[i] = rl.Histogram[i] + other.Histogram[i]
+	}
+	return
+}
+
+// getHistogram returns the number of replications in each lag interval
+func (rl ReplicationLag) getHistogram() map[string]uint64 {
+	ret := make(map[string]uint64, len(rl.Histogram))
+	for i, v := range rl.Histogram {
+		ret[replicationLagIntervals[i].name] = v
+	}
+	return ret
+}
+
 // BucketStats bucket statistics
 type BucketStats struct {
 	ReplicationStats BucketReplicationStats
@@ -113,6 +182,14 @@ type BucketReplicationStat struct {
 	FailedCount int64 `json:"failedReplicationCount"`
 	// Replication latency information
 	Latency ReplicationLatency `json:"replicationLatency"`
+	// Replication lag distribution
+	Lag ReplicationLag `json:"replicationLag"`
+	// Total number of completed operations since server start
+	CompletedOperations int64 `json:"completedOperations"`
+	// Total number of operations that failed since server start
+	FailedOperations int64 `json:"failedOperations"`
+	// Time of the last completed replication operation
+	LastReplicationTime time.Time `json:"lastReplicationTime"`
 }
 
 func (bs *BucketReplicationStat) hasReplicationUsage() bool {
@@ -81,6 +81,12 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 						err = msgp.WrapError(err, "Latency", "UploadHistogram")
 						return
 					}
+				case "ProxyHistogram":
+					err = z.Latency.ProxyHistogram.DecodeMsg(dc)
+					if err != nil {
+						err = msgp.WrapError(err, "Latency", "ProxyHistogram")
+						return
+					}
 				default:
 					err = dc.Skip()
 					if err != nil {
@@ -89,6 +95,65 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 					}
 				}
 			}
+		case "Lag":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Lag")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "Lag")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Histogram":
+					var zb0004 uint32
+					zb0004, err = dc.ReadArrayHeader()
+					if err != nil {
+						err = msgp.WrapError(err, "Lag", "Histogram")
+						return
+					}
+					if zb0004 != uint32(replicationLagBucketLen) {
+						err = msgp.ArrayError{Wanted: uint32(replicationLagBucketLen), Got: zb0004}
+						return
+					}
+					for za0001 := range z.Lag.Histogram {
+						z.Lag.Histogram[za0001], err = dc.ReadUint64()
+						if err != nil {
+							err = msgp.WrapError(err, "Lag", "Histogram", za0001)
+							return
+						}
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "Lag")
+						return
+					}
+				}
+			}
+		case "CompletedOperations":
+			z.CompletedOperations, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "CompletedOperations")
+				return
+			}
+		case "FailedOperations":
+			z.FailedOperations, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "FailedOperations")
+				return
+			}
+		case "LastReplicationTime":
+			z.LastReplicationTime, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "LastReplicationTime")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -102,9 +167,9 @@ func (z *BucketReplicationStat) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+	// map header, size 11
 	// write "PendingSize"
-	err = en.Append(0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	err = en.Append(0x8b, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	if err != nil {
 		return
 	}
@@ -168,9 +233,9 @@ func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
 	if err != nil {
 		return
 	}
-	// map header, size 1
+	// map header, size 2
 	// write "UploadHistogram"
-	err = en.Append(0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	err = en.Append(0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	if err != nil {
 		return
 	}
@@ -179,15 +244,78 @@ func (z *BucketReplicationStat) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// write "ProxyHistogram"
+	err = en.Append(0xae, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	if err != nil {
+		return
+	}
+	err = z.Latency.ProxyHistogram.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Latency", "ProxyHistogram")
+		return
+	}
+	// write "Lag"
+	err = en.Append(0xa3, 0x4c, 0x61, 0x67)
+	if err != nil {
+		return
+	}
+	// map header, size 1
+	// write "Histogram"
+	err = en.Append(0x81, 0xa9, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(replicationLagBucketLen))
+	if err != nil {
+		err = msgp.WrapError(err, "Lag", "Histogram")
+		return
+	}
+	for za0001 := range z.Lag.Histogram {
+		err = en.WriteUint64(z.Lag.Histogram[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Lag", "Histogram", za0001)
+			return
+		}
+	}
+	// write "CompletedOperations"
+	err = en.Append(0xb3, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.CompletedOperations)
+	if err != nil {
+		err = msgp.WrapError(err, "CompletedOperations")
+		return
+	}
+	// write "FailedOperations"
+	err = en.Append(0xb0, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.FailedOperations)
+	if err != nil {
+		err = msgp.WrapError(err, "FailedOperations")
+		return
+	}
+	// write "LastReplicationTime"
+	err = en.Append(0xb3, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.LastReplicationTime)
+	if err != nil {
+		err = msgp.WrapError(err, "LastReplicationTime")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// map header, size 11
 	// string "PendingSize"
-	o = append(o, 0x87, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
+	o = append(o, 0x8b, 0xab, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65)
 	o = msgp.AppendInt64(o, z.PendingSize)
 	// string "ReplicatedSize"
 	o = append(o, 0xae, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65)
@@ -206,14 +334,39 @@ func (z *BucketReplicationStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.AppendInt64(o, z.FailedCount)
 	// string "Latency"
 	o = append(o, 0xa7, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79)
-	// map header, size 1
+	// map header, size 2
 	// string "UploadHistogram"
-	o = append(o, 0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o = append(o, 0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	o, err = z.Latency.UploadHistogram.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Latency", "UploadHistogram")
 		return
 	}
+	// string "ProxyHistogram"
+	o = append(o, 0xae, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o, err = z.Latency.ProxyHistogram.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Latency", "ProxyHistogram")
+		return
+	}
+	// string "Lag"
+	o = append(o, 0xa3, 0x4c, 0x61, 0x67)
+	// map header, size 1
+	// string "Histogram"
+	o = append(o, 0x81, 0xa9, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o = msgp.AppendArrayHeader(o, uint32(replicationLagBucketLen))
+	for za0001 := range z.Lag.Histogram {
+		o = msgp.AppendUint64(o, z.Lag.Histogram[za0001])
+	}
+	// string "CompletedOperations"
+	o = append(o, 0xb3, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendInt64(o, z.CompletedOperations)
+	// string "FailedOperations"
+	o = append(o, 0xb0, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendInt64(o, z.FailedOperations)
+	// string "LastReplicationTime"
+	o = append(o, 0xb3, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65)
+	o = msgp.AppendTime(o, z.LastReplicationTime)
 	return
 }
 
@@ -292,6 +445,12 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 						err = msgp.WrapError(err, "Latency", "UploadHistogram")
 						return
 					}
+				case "ProxyHistogram":
+					bts, err = z.Latency.ProxyHistogram.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Latency", "ProxyHistogram")
+						return
+					}
 				default:
 					bts, err = msgp.Skip(bts)
 					if err != nil {
@@ -300,6 +459,65 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "Lag":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Lag")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Lag")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "Histogram":
+					var zb0004 uint32
+					zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Lag", "Histogram")
+						return
+					}
+					if zb0004 != uint32(replicationLagBucketLen) {
+						err = msgp.ArrayError{Wanted: uint32(replicationLagBucketLen), Got: zb0004}
+						return
+					}
+					for za0001 := range z.Lag.Histogram {
+						z.Lag.Histogram[za0001], bts, err = msgp.ReadUint64Bytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Lag", "Histogram", za0001)
+							return
+						}
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Lag")
+						return
+					}
+				}
+			}
+		case "CompletedOperations":
+			z.CompletedOperations, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CompletedOperations")
+				return
+			}
+		case "FailedOperations":
+			z.FailedOperations, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FailedOperations")
+				return
+			}
+		case "LastReplicationTime":
+			z.LastReplicationTime, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LastReplicationTime")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -314,7 +532,7 @@ func (z *BucketReplicationStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketReplicationStat) Msgsize() (s int) {
-	s = 1 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize()
+	s = 1 + 12 + msgp.Int64Size + 15 + msgp.Int64Size + 12 + msgp.Int64Size + 11 + msgp.Int64Size + 13 + msgp.Int64Size + 12 + msgp.Int64Size + 8 + 1 + 16 + z.Latency.UploadHistogram.Msgsize() + 15 + z.Latency.ProxyHistogram.Msgsize() + 4 + 1 + 10 + msgp.ArrayHeaderSize + (replicationLagBucketLen * (msgp.Uint64Size)) + 20 + msgp.Int64Size + 17 + msgp.Int64Size + 20 + msgp.TimeSize
 	return
 }
 
@@ -792,6 +1010,143 @@ func (z *BucketStats) Msgsize() (s int) {
 	return
 }
 
+// DecodeMsg implements msgp.Decodable
+func (z *ReplicationLag) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Histogram":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Histogram")
+				return
+			}
+			if zb0002 != uint32(replicationLagBucketLen) {
+				err = msgp.ArrayError{Wanted: uint32(replicationLagBucketLen), Got: zb0002}
+				return
+			}
+			for za0001 := range z.Histogram {
+				z.Histogram[za0001], err = dc.ReadUint64()
+				if err != nil {
+					err = msgp.WrapError(err, "Histogram", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ReplicationLag) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "Histogram"
+	err = en.Append(0x81, 0xa9, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(replicationLagBucketLen))
+	if err != nil {
+		err = msgp.WrapError(err, "Histogram")
+		return
+	}
+	for za0001 := range z.Histogram {
+		err = en.WriteUint64(z.Histogram[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Histogram", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ReplicationLag) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "Histogram"
+	o = append(o, 0x81, 0xa9, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o = msgp.AppendArrayHeader(o, uint32(replicationLagBucketLen))
+	for za0001 := range z.Histogram {
+		o = msgp.AppendUint64(o, z.Histogram[za0001])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ReplicationLag) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Histogram":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Histogram")
+				return
+			}
+			if zb0002 != uint32(replicationLagBucketLen) {
+				err = msgp.ArrayError{Wanted: uint32(replicationLagBucketLen), Got: zb0002}
+				return
+			}
+			for za0001 := range z.Histogram {
+				z.Histogram[za0001], bts, err = msgp.ReadUint64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Histogram", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ReplicationLag) Msgsize() (s int) {
+	s = 1 + 10 + msgp.ArrayHeaderSize + (replicationLagBucketLen * (msgp.Uint64Size))
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *ReplicationLatency) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
@@ -816,6 +1171,12 @@ func (z *ReplicationLatency) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "UploadHistogram")
 				return
 			}
+		case "ProxyHistogram":
+			err = z.ProxyHistogram.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "ProxyHistogram")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -829,9 +1190,9 @@ func (z *ReplicationLatency) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *ReplicationLatency) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+	// map header, size 2
 	// write "UploadHistogram"
-	err = en.Append(0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	err = en.Append(0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	if err != nil {
 		return
 	}
@@ -840,20 +1201,37 @@ func (z *ReplicationLatency) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "UploadHistogram")
 		return
 	}
+	// write "ProxyHistogram"
+	err = en.Append(0xae, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	if err != nil {
+		return
+	}
+	err = z.ProxyHistogram.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "ProxyHistogram")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *ReplicationLatency) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 2
 	// string "UploadHistogram"
-	o = append(o, 0x81, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o = append(o, 0x82, 0xaf, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
 	o, err = z.UploadHistogram.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "UploadHistogram")
 		return
 	}
+	// string "ProxyHistogram"
+	o = append(o, 0xae, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d)
+	o, err = z.ProxyHistogram.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ProxyHistogram")
+		return
+	}
 	return
 }
 
@@ -881,6 +1259,12 @@ func (z *ReplicationLatency) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "UploadHistogram")
 				return
 			}
+		case "ProxyHistogram":
+			bts, err = z.ProxyHistogram.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProxyHistogram")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -895,6 +1279,6 @@ func (z *ReplicationLatency) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *ReplicationLatency) Msgsize() (s int) {
-	s = 1 + 16 + z.UploadHistogram.Msgsize()
+	s = 1 + 16 + z.UploadHistogram.Msgsize() + 15 + z.ProxyHistogram.Msgsize()
 	return
 }
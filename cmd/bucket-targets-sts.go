@@ -0,0 +1,256 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+const (
+	// STS settings of the replication targets.
+	bucketTargetsSTSConfig = "bucket-targets-sts.json"
+
+	// Range of the session duration accepted by AssumeRole.
+	minRemoteTargetSTSDuration = 900
+	maxRemoteTargetSTSDuration = 43200
+)
+
+var (
+	errRemoteTargetSTSEndpoint = errors.New("STS endpoint must be a valid http or https URL")
+	errRemoteTargetSTSDuration = errors.New("STS session duration must be between 900 and 43200 seconds")
+	errRemoteTargetSTSNoCreds  = errors.New("AssumeRole requires the remote target to have credentials")
+	errRemoteTargetSTSTokenDir = errors.New("web identity tokens require " + config.EnvReplicationSTSTokenDir + " to be set")
+	errRemoteTargetSTSToken    = errors.New("web identity token must be the name of a file in " + config.EnvReplicationSTSTokenDir)
+)
+
+// remoteTargetSTS configures a replication target to authenticate
+// with temporary credentials obtained from an STS endpoint instead of
+// its static keys. With a web identity token the token is exchanged
+// through AssumeRoleWithWebIdentity, otherwise the static keys of the
+// target are used to call AssumeRole. Credentials are renewed
+// automatically before they expire.
+type remoteTargetSTS struct {
+	Endpoint        string `json:"endpoint"`
+	RoleARN         string `json:"roleArn,omitempty"`
+	RoleSessionName string `json:"roleSessionName,omitempty"`
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+	// Name of the token file in the directory set by the server
+	// with MINIO_REPLICATION_STS_TOKEN_DIR. Paths are not accepted,
+	// so admins cannot make the server read arbitrary files. The
+	// file is read again on every renewal, so rotated tokens are
+	// picked up.
+	WebIdentityToken string `json:"webIdentityToken,omitempty"`
+}
+
+// validateRemoteTargetSTSToken checks that the web identity token is
+// the name of a file rather than a path.
+func validateRemoteTargetSTSToken(name string) error {
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) {
+		return errRemoteTargetSTSToken
+	}
+	return nil
+}
+
+// remoteTargetSTSTokenFile returns the path of the web identity token
+// file with the given name. The directory is resolved on every call,
+// so settings loaded on a node without a token directory only fail
+// once credentials are needed.
+func remoteTargetSTSTokenFile(name string) (string, error) {
+	if err := validateRemoteTargetSTSToken(name); err != nil {
+		return "", err
+	}
+	dir := env.Get(config.EnvReplicationSTSTokenDir, "")
+	if dir == "" {
+		return "", errRemoteTargetSTSTokenDir
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Validate - validates the STS settings.
+func (s remoteTargetSTS) Validate() error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errRemoteTargetSTSEndpoint
+	}
+	if s.DurationSeconds != 0 && (s.DurationSeconds < minRemoteTargetSTSDuration || s.DurationSeconds > maxRemoteTargetSTSDuration) {
+		return errRemoteTargetSTSDuration
+	}
+	if s.WebIdentityToken != "" {
+		return validateRemoteTargetSTSToken(s.WebIdentityToken)
+	}
+	return nil
+}
+
+// credentials returns auto renewing credentials for the target.
+func (s remoteTargetSTS) credentials(tcfg *madmin.BucketTarget, transport http.RoundTripper) (*credentials.Credentials, error) {
+	if s.WebIdentityToken != "" {
+		tokenFile, err := remoteTargetSTSTokenFile(s.WebIdentityToken)
+		if err != nil {
+			return nil, err
+		}
+		return credentials.New(&credentials.STSWebIdentity{
+			Client:      &http.Client{Transport: transport},
+			STSEndpoint: s.Endpoint,
+			RoleARN:     s.RoleARN,
+			GetWebIDTokenExpiry: func() (*credentials.WebIdentityToken, error) {
+				token, err := ioutil.ReadFile(tokenFile)
+				if err != nil {
+					return nil, err
+				}
+				return &credentials.WebIdentityToken{
+					Token:  strings.TrimSpace(string(token)),
+					Expiry: s.DurationSeconds,
+				}, nil
+			},
+		}), nil
+	}
+
+	if tcfg.Credentials == nil || tcfg.Credentials.AccessKey == "" || tcfg.Credentials.SecretKey == "" {
+		return nil, errRemoteTargetSTSNoCreds
+	}
+	return credentials.NewSTSAssumeRole(s.Endpoint, credentials.STSAssumeRoleOptions{
+		AccessKey:       tcfg.Credentials.AccessKey,
+		SecretKey:       tcfg.Credentials.SecretKey,
+		Location:        tcfg.Region,
+		DurationSeconds: s.DurationSeconds,
+		RoleARN:         s.RoleARN,
+		RoleSessionName: s.RoleSessionName,
+	})
+}
+
+// remoteTargetsSTSConfig holds the STS settings of the replication
+// targets of a bucket by target ARN.
+type remoteTargetsSTSConfig struct {
+	Targets map[string]remoteTargetSTS `json:"targets"`
+}
+
+// parseRemoteTargetsSTSConfig - parses and validates the STS settings
+// of the replication targets.
+func parseRemoteTargetsSTSConfig(reader io.Reader) (*remoteTargetsSTSConfig, error) {
+	var cfg remoteTargetsSTSConfig
+	if err := json.NewDecoder(reader).Decode(&cfg); err != nil {
+		return nil, err
+	}
+	for _, s := range cfg.Targets {
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// remoteTargetSTSFor returns the STS settings of the replication
+// target, if any.
+func remoteTargetSTSFor(tcfg *madmin.BucketTarget) (remoteTargetSTS, bool) {
+	if globalBucketMetadataSys == nil || tcfg.SourceBucket == "" {
+		return remoteTargetSTS{}, false
+	}
+	cfg, err := globalBucketMetadataSys.GetBucketTargetsSTSConfig(tcfg.SourceBucket)
+	if err != nil {
+		return remoteTargetSTS{}, false
+	}
+	s, ok := cfg.Targets[tcfg.Arn]
+	return s, ok
+}
+
+// setRemoteTargetSTS sets the STS settings of the replication target
+// of the bucket, removing them if sts is nil. The target clients are
+// recreated to use the new credentials.
+func setRemoteTargetSTS(ctx context.Context, bucket, arn string, sts *remoteTargetSTS) error {
+	if sts != nil {
+		if err := sts.Validate(); err != nil {
+			return err
+		}
+		// Reject tokens this node cannot read right away.
+		if sts.WebIdentityToken != "" {
+			if _, err := remoteTargetSTSTokenFile(sts.WebIdentityToken); err != nil {
+				return err
+			}
+		}
+	}
+	tgt := globalBucketTargetSys.GetRemoteBucketTargetByArn(ctx, bucket, arn)
+	if tgt.Empty() {
+		return BucketRemoteTargetNotFound{Bucket: bucket}
+	}
+	if sts != nil && sts.WebIdentityToken == "" && (tgt.Credentials == nil || tgt.Credentials.AccessKey == "") {
+		return errRemoteTargetSTSNoCreds
+	}
+
+	if err := updateRemoteTargetsSTSConfig(bucket, arn, sts); err != nil {
+		return err
+	}
+
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	globalBucketTargetSys.UpdateAllTargets(bucket, tgts)
+	return nil
+}
+
+// removeRemoteTargetSTS removes the STS settings of a removed
+// replication target of the bucket, if any.
+func removeRemoteTargetSTS(bucket, arn string) error {
+	cur, err := globalBucketMetadataSys.GetBucketTargetsSTSConfig(bucket)
+	if err != nil {
+		return err
+	}
+	if _, ok := cur.Targets[arn]; !ok {
+		return nil
+	}
+	return updateRemoteTargetsSTSConfig(bucket, arn, nil)
+}
+
+// updateRemoteTargetsSTSConfig stores the STS settings of the target
+// of the bucket, removing them if sts is nil.
+func updateRemoteTargetsSTSConfig(bucket, arn string, sts *remoteTargetSTS) error {
+	cur, err := globalBucketMetadataSys.GetBucketTargetsSTSConfig(bucket)
+	if err != nil {
+		return err
+	}
+	cfg := remoteTargetsSTSConfig{Targets: make(map[string]remoteTargetSTS, len(cur.Targets)+1)}
+	for tgtArn, s := range cur.Targets {
+		cfg.Targets[tgtArn] = s
+	}
+	if sts == nil {
+		delete(cfg.Targets, arn)
+	} else {
+		cfg.Targets[arn] = *sts
+	}
+
+	var configData []byte
+	if len(cfg.Targets) > 0 {
+		if configData, err = json.Marshal(cfg); err != nil {
+			return err
+		}
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketTargetsSTSConfig, configData)
+}
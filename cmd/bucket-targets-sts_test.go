@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/config"
+)
+
+func TestRemoteTargetSTSValidate(t *testing.T) {
+	testCases := []struct {
+		sts     remoteTargetSTS
+		success bool
+	}{
+		{remoteTargetSTS{Endpoint: "https://sts.example.com"}, true},
+		{remoteTargetSTS{Endpoint: "http://sts.example.com:9000", DurationSeconds: 3600}, true},
+		{remoteTargetSTS{Endpoint: "sts.example.com"}, false},
+		{remoteTargetSTS{Endpoint: "ftp://sts.example.com"}, false},
+		{remoteTargetSTS{}, false},
+		{remoteTargetSTS{Endpoint: "https://sts.example.com", DurationSeconds: 60}, false},
+		{remoteTargetSTS{Endpoint: "https://sts.example.com", DurationSeconds: 86400}, false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.sts.Validate()
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestParseRemoteTargetsSTSConfig(t *testing.T) {
+	cfg, err := parseRemoteTargetsSTSConfig(strings.NewReader(`{"targets":{"arn:minio:replication::id:bucket":{"endpoint":"https://sts.example.com","roleArn":"arn:aws:iam::123456789012:role/replication"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sts, ok := cfg.Targets["arn:minio:replication::id:bucket"]
+	if !ok || sts.RoleARN != "arn:aws:iam::123456789012:role/replication" {
+		t.Fatalf("unexpected config %v", cfg)
+	}
+
+	if _, err = parseRemoteTargetsSTSConfig(strings.NewReader(`{"targets":{"arn":{"endpoint":"invalid"}}}`)); err == nil {
+		t.Fatal("expected an error for an invalid endpoint")
+	}
+}
+
+func TestRemoteTargetSTSCredentials(t *testing.T) {
+	sts := remoteTargetSTS{Endpoint: "https://sts.example.com"}
+	if _, err := sts.credentials(&madmin.BucketTarget{}, nil); err != errRemoteTargetSTSNoCreds {
+		t.Fatalf("expected %v, got %v", errRemoteTargetSTSNoCreds, err)
+	}
+	tgt := &madmin.BucketTarget{Credentials: &madmin.Credentials{AccessKey: "access", SecretKey: "secretkey"}}
+	if _, err := sts.credentials(tgt, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Web identity does not need static credentials of the target.
+	t.Setenv(config.EnvReplicationSTSTokenDir, t.TempDir())
+	sts.WebIdentityToken = "token"
+	if _, err := sts.credentials(&madmin.BucketTarget{}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemoteTargetSTSWebIdentityToken(t *testing.T) {
+	sts := remoteTargetSTS{Endpoint: "https://sts.example.com", WebIdentityToken: "token"}
+
+	// Settings load on nodes without a token directory, credentials
+	// cannot be created there.
+	t.Setenv(config.EnvReplicationSTSTokenDir, "")
+	if err := sts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseRemoteTargetsSTSConfig(strings.NewReader(`{"targets":{"arn":{"endpoint":"https://sts.example.com","webIdentityToken":"token"}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sts.credentials(&madmin.BucketTarget{}, nil); err != errRemoteTargetSTSTokenDir {
+		t.Fatalf("expected %v without a token directory, got %v", errRemoteTargetSTSTokenDir, err)
+	}
+
+	dir := t.TempDir()
+	t.Setenv(config.EnvReplicationSTSTokenDir, dir)
+	if tokenFile, err := remoteTargetSTSTokenFile(sts.WebIdentityToken); err != nil || tokenFile != filepath.Join(dir, "token") {
+		t.Fatalf("unexpected token file %s, %v", tokenFile, err)
+	}
+
+	for _, name := range []string{"/proc/self/environ", "../config.json", "secrets/token", "..", ".", `..\\token`} {
+		sts.WebIdentityToken = name
+		if err := sts.Validate(); err != errRemoteTargetSTSToken {
+			t.Errorf("%s: expected %v, got %v", name, errRemoteTargetSTSToken, err)
+		}
+		if _, err := sts.credentials(&madmin.BucketTarget{}, nil); err != errRemoteTargetSTSToken {
+			t.Errorf("%s: expected %v, got %v", name, errRemoteTargetSTSToken, err)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -113,7 +114,15 @@ func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *m
 		return BucketRemoteTargetNotFound{Bucket: tgt.TargetBucket}
 	}
 	// validate if target credentials are ok
-	if _, err = clnt.BucketExists(ctx, tgt.TargetBucket); err != nil {
+	if clnt.native != nil {
+		found, err := clnt.native.BucketExists(ctx)
+		if err != nil {
+			return BucketRemoteConnectionErr{Bucket: tgt.TargetBucket, Err: err}
+		}
+		if !found {
+			return BucketRemoteTargetNotFound{Bucket: tgt.TargetBucket}
+		}
+	} else if _, err = clnt.BucketExists(ctx, tgt.TargetBucket); err != nil {
 		if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
 			return BucketRemoteTargetNotFound{Bucket: tgt.TargetBucket}
 		}
@@ -126,12 +135,22 @@ func (sys *BucketTargetSys) SetTarget(ctx context.Context, bucket string, tgt *m
 		if !globalBucketVersioningSys.Enabled(bucket) {
 			return BucketReplicationSourceNotVersioned{Bucket: bucket}
 		}
-		vcfg, err := clnt.GetBucketVersioning(ctx, tgt.TargetBucket)
-		if err != nil {
-			return BucketRemoteConnectionErr{Bucket: tgt.TargetBucket, Err: err}
-		}
-		if vcfg.Status != string(versioning.Enabled) {
-			return BucketRemoteTargetNotVersioned{Bucket: tgt.TargetBucket}
+		if clnt.native != nil {
+			versioned, err := clnt.native.Versioned(ctx)
+			if err != nil {
+				return BucketRemoteConnectionErr{Bucket: tgt.TargetBucket, Err: err}
+			}
+			if !versioned {
+				return BucketRemoteTargetNotVersioned{Bucket: tgt.TargetBucket}
+			}
+		} else {
+			vcfg, err := clnt.GetBucketVersioning(ctx, tgt.TargetBucket)
+			if err != nil {
+				return BucketRemoteConnectionErr{Bucket: tgt.TargetBucket, Err: err}
+			}
+			if vcfg.Status != string(versioning.Enabled) {
+				return BucketRemoteTargetNotVersioned{Bucket: tgt.TargetBucket}
+			}
 		}
 	}
 	sys.Lock()
@@ -264,6 +283,32 @@ func (sys *BucketTargetSys) GetRemoteBucketTargetByArn(ctx context.Context, buck
 	return tgt
 }
 
+// remoteTargetHealth - reachability of a remote bucket target.
+type remoteTargetHealth struct {
+	ARN      string
+	Endpoint string
+	Online   bool
+}
+
+// targetsHealth returns the reachability of all remote targets,
+// as seen by their periodic health checks.
+func (sys *BucketTargetSys) targetsHealth() []remoteTargetHealth {
+	sys.RLock()
+	defer sys.RUnlock()
+	targets := make([]remoteTargetHealth, 0, len(sys.arnRemotesMap))
+	for arn, tc := range sys.arnRemotesMap {
+		th := remoteTargetHealth{ARN: arn, Online: !tc.IsOffline()}
+		if tc.Client != nil {
+			th.Endpoint = tc.EndpointURL().String()
+		}
+		targets = append(targets, th)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].ARN < targets[j].ARN
+	})
+	return targets
+}
+
 // NewBucketTargetSys - creates new replication system.
 func NewBucketTargetSys() *BucketTargetSys {
 	return &BucketTargetSys{
@@ -302,6 +347,9 @@ func (sys *BucketTargetSys) UpdateAllTargets(bucket string, tgts *madmin.BucketT
 		if err != nil {
 			continue
 		}
+		if prev, ok := sys.arnRemotesMap[tgt.Arn]; ok && prev.healthCancelFn != nil {
+			prev.healthCancelFn()
+		}
 		sys.arnRemotesMap[tgt.Arn] = tgtClient
 		sys.updateBandwidthLimit(bucket, tgt.BandwidthLimit)
 	}
@@ -339,17 +387,40 @@ var (
 
 // Returns a minio-go Client configured to access remote host described in replication target config.
 func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget) (*TargetClient, error) {
-	config := tcfg.Credentials
-	creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
+	if isNativeReplicationTarget(tcfg) {
+		native, err := newNativeReplicationTarget(tcfg)
+		if err != nil {
+			return nil, err
+		}
+		return &TargetClient{
+			native:        native,
+			replicateSync: tcfg.ReplicationSync,
+			Bucket:        tcfg.TargetBucket,
+			StorageClass:  tcfg.StorageClass,
+			disableProxy:  true,
+			ARN:           tcfg.Arn,
+			ResetID:       tcfg.ResetID,
+		}, nil
+	}
 	getRemoteTargetInstanceTransportOnce.Do(func() {
 		getRemoteTargetInstanceTransport = NewRemoteTargetHTTPTransport()
 	})
+	var creds *credentials.Credentials
+	if sts, ok := remoteTargetSTSFor(tcfg); ok {
+		var err error
+		if creds, err = sts.credentials(tcfg, getRemoteTargetInstanceTransport); err != nil {
+			return nil, err
+		}
+	} else {
+		config := tcfg.Credentials
+		creds = credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
+	}
 
 	api, err := minio.New(tcfg.Endpoint, &miniogo.Options{
 		Creds:     creds,
 		Secure:    tcfg.Secure,
 		Region:    tcfg.Region,
-		Transport: getRemoteTargetInstanceTransport,
+		Transport: replicationSiteTransport{getRemoteTargetInstanceTransport},
 	})
 	if err != nil {
 		return nil, err
@@ -448,4 +519,14 @@ type TargetClient struct {
 	healthCancelFn      context.CancelFunc // cancellation function for client healthcheck
 	ARN                 string             // ARN to uniquely identify remote target
 	ResetID             string
+	native              nativeReplicationTarget // set for Azure and GCS targets, which have no S3 client
+}
+
+// IsOffline returns true if the remote target is offline. Native targets
+// are not health checked, failed operations are retried instead.
+func (tc *TargetClient) IsOffline() bool {
+	if tc.native != nil {
+		return false
+	}
+	return tc.Client.IsOffline()
 }
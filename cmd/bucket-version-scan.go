@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "context"
+
+// versionScan is the extent of a scan of the versions of a bucket
+// bounded by a maximum number of versions. It is embedded in the
+// results of such scans.
+type versionScan struct {
+	Scanned int64 `json:"scanned"`
+	// Truncated is set if the scan stopped after the maximum
+	// number of versions.
+	Truncated bool `json:"truncated"`
+}
+
+// scanVersions calls fn for the versions under prefix in listing
+// order, stopping after maxVersions versions.
+func (s *versionScan) scanVersions(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, maxVersions int64, fn func(oi ObjectInfo)) error {
+	var marker, versionMarker string
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, bucket, prefix, marker, versionMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range loi.Objects {
+			if s.Scanned >= maxVersions {
+				s.Truncated = true
+				return nil
+			}
+			s.Scanned++
+			fn(oi)
+		}
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker, versionMarker = loi.NextMarker, loi.NextVersionIDMarker
+	}
+}
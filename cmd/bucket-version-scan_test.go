@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestScanVersions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("abcd")
+	for i := 0; i < 3; i++ {
+		if _, err = obj.PutObject(ctx, bucket, fmt.Sprintf("object-%d", i), mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		maxVersions int64
+		scan        versionScan
+	}{
+		{2, versionScan{Scanned: 2, Truncated: true}},
+		{3, versionScan{Scanned: 3}},
+		{10, versionScan{Scanned: 3}},
+	}
+	for i, testCase := range testCases {
+		var scan versionScan
+		var names []string
+		err = scan.scanVersions(ctx, obj, bucket, "", testCase.maxVersions, func(oi ObjectInfo) {
+			names = append(names, oi.Name)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if scan != testCase.scan || int64(len(names)) != scan.Scanned {
+			t.Errorf("Test %d: expected %+v, got %+v with %d versions", i+1, testCase.scan, scan, len(names))
+		}
+	}
+
+	// The scan is reported at the top level of the results embedding it.
+	buf, err := json.Marshal(cseReport{Bucket: bucket, versionScan: versionScan{Scanned: 2, Truncated: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(buf, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["scanned"] != float64(2) || m["truncated"] != true {
+		t.Fatalf("unexpected json %s", buf)
+	}
+}
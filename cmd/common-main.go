@@ -56,6 +56,7 @@ import (
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/color"
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/fips"
 	"github.com/minio/minio/internal/handlers"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
@@ -425,6 +426,14 @@ func handleCommonCmdArgs(ctx *cli.Context) {
 
 	globalMinioAddr = addr
 
+	// FIPS mode has to be known before any TLS configuration
+	// or encryption key is created.
+	fipsMode, err := config.ParseBool(env.Get(config.EnvFIPS, config.EnableOff))
+	logger.FatalIf(err, "Invalid value for %s", config.EnvFIPS)
+	if fipsMode {
+		fips.Require()
+	}
+
 	// Check "no-compat" flag from command line argument.
 	globalCLIContext.StrictS3Compat = true
 	if ctx.IsSet("no-compat") || ctx.GlobalIsSet("no-compat") {
@@ -704,6 +713,49 @@ func handleCommonEnvVars() {
 	// in-place update is off.
 	globalInplaceUpdateDisabled = strings.EqualFold(env.Get(config.EnvUpdate, config.EnableOn), config.EnableOff)
 
+	globalNotifyInternalEvents, err = config.ParseBool(env.Get(config.EnvNotifyInternalEvents, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidNotifyInternalEventsValue(err), "Invalid MINIO_NOTIFY_INTERNAL_EVENTS value in environment variable")
+	}
+
+	// Bucket events are journaled locally only if a directory is set.
+	if dir := env.Get(config.EnvEventJournalDir, ""); dir != "" {
+		retention, err := time.ParseDuration(env.Get(config.EnvEventJournalRetention, "24h"))
+		if err != nil || retention <= 0 {
+			logger.Fatal(errInvalidArgument, "Invalid MINIO_EVENT_JOURNAL_RETENTION value in environment variable")
+		}
+		if !filepath.IsAbs(dir) {
+			logger.Fatal(errInvalidArgument, "MINIO_EVENT_JOURNAL_DIR must be an absolute path")
+		}
+		globalEventJournal, err = newEventJournal(dir, retention)
+		logger.FatalIf(err, "Unable to initialize the event journal at %s", dir)
+	}
+
+	// Undeliverable bucket events are kept only if a directory is set.
+	if dir := env.Get(config.EnvEventDeadLetterDir, ""); dir != "" {
+		limit, err := strconv.Atoi(env.Get(config.EnvEventDeadLetterLimit, "100000"))
+		if err != nil || limit < 0 {
+			logger.Fatal(errInvalidArgument, "Invalid MINIO_EVENT_DEADLETTER_LIMIT value in environment variable")
+		}
+		if !filepath.IsAbs(dir) {
+			logger.Fatal(errInvalidArgument, "MINIO_EVENT_DEADLETTER_DIR must be an absolute path")
+		}
+		globalEventDeadLetters, err = newEventDeadLetterStore(dir, limit)
+		logger.FatalIf(err, "Unable to initialize the event dead-letter store at %s", dir)
+	}
+
+	// Drive health is sampled periodically, unless the interval is 0.
+	globalDriveHealth.interval, err = time.ParseDuration(env.Get(config.EnvDriveHealthInterval, "10m"))
+	if err != nil || globalDriveHealth.interval < 0 {
+		logger.Fatal(errInvalidArgument, "Invalid MINIO_DRIVE_HEALTH_INTERVAL value in environment variable")
+	}
+
+	// The certs directory is checked for changes periodically, unless the interval is 0.
+	globalCertsReloadInterval, err = time.ParseDuration(env.Get(config.EnvCertsReloadInterval, "1m"))
+	if err != nil || globalCertsReloadInterval < 0 {
+		logger.Fatal(errInvalidArgument, "Invalid MINIO_CERTS_RELOAD_INTERVAL value in environment variable")
+	}
+
 	// Check if the supported credential env vars,
 	// "MINIO_ROOT_USER" and "MINIO_ROOT_PASSWORD" are provided
 	// Warn user if deprecated environment variables,
@@ -753,16 +805,34 @@ func handleCommonEnvVars() {
 		globalActiveCred = cred
 	}
 
+	doubleEncryption, err := config.ParseBool(env.Get(config.EnvKMSDoubleEncryption, config.EnableOff))
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_KMS_DOUBLE_ENCRYPTION value in environment variable")
+	}
+
+	// With double encryption enabled exactly two KMS must be configured.
+	// The first one, in the order secret key, KES, KMIP, Vault, is the primary.
+	kmsEnvKeys := []string{config.EnvKMSSecretKey, config.EnvKESEndpoint, config.EnvKMIPEndpoint, config.EnvVaultEndpoint}
+	var configured []string
+	for _, envKey := range kmsEnvKeys {
+		if env.IsSet(envKey) {
+			configured = append(configured, fmt.Sprintf("%q", envKey))
+		}
+	}
 	switch {
-	case env.IsSet(config.EnvKMSSecretKey) && env.IsSet(config.EnvKESEndpoint):
-		logger.Fatal(errors.New("ambigious KMS configuration"), fmt.Sprintf("The environment contains %q as well as %q", config.EnvKMSSecretKey, config.EnvKESEndpoint))
+	case doubleEncryption && len(configured) != 2:
+		logger.Fatal(errors.New("invalid KMS configuration"), fmt.Sprintf("%q requires exactly two of %q", config.EnvKMSDoubleEncryption, kmsEnvKeys))
+	case !doubleEncryption && len(configured) > 1:
+		logger.Fatal(errors.New("ambigious KMS configuration"), fmt.Sprintf("The environment contains %s", strings.Join(configured, " as well as ")))
 	}
 
+	var backends []kms.KMS
 	if env.IsSet(config.EnvKMSSecretKey) {
-		GlobalKMS, err = kms.Parse(env.Get(config.EnvKMSSecretKey, ""))
+		KMS, err := kms.Parse(env.Get(config.EnvKMSSecretKey, ""))
 		if err != nil {
 			logger.Fatal(err, "Unable to parse the KMS secret key inherited from the shell environment")
 		}
+		backends = append(backends, KMS)
 	}
 	if env.IsSet(config.EnvKESEndpoint) {
 		var endpoints []string
@@ -809,8 +879,127 @@ func handleCommonEnvVars() {
 		if err = KMS.CreateKey(defaultKeyID); err != nil && !errors.Is(err, kes.ErrKeyExists) && !errors.Is(err, kes.ErrNotAllowed) {
 			logger.Fatal(err, "Unable to initialize a connection to KES as specified by the shell environment")
 		}
-		GlobalKMS = KMS
+		backends = append(backends, KMS)
+	}
+	if env.IsSet(config.EnvKMIPEndpoint) {
+		var endpoints []string
+		for _, endpoint := range strings.Split(env.Get(config.EnvKMIPEndpoint, ""), ",") {
+			if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		certificate, err := tls.LoadX509KeyPair(env.Get(config.EnvKMIPClientCert, ""), env.Get(config.EnvKMIPClientKey, ""))
+		if err != nil {
+			logger.Fatal(err, "Unable to load KMIP client certificate as specified by the shell environment")
+		}
+		rootCAs, err := certs.GetRootCAs(env.Get(config.EnvKMIPServerCA, globalCertsCADir.Get()))
+		if err != nil {
+			logger.Fatal(err, fmt.Sprintf("Unable to load X.509 root CAs for KMIP from %q", env.Get(config.EnvKMIPServerCA, globalCertsCADir.Get())))
+		}
+
+		defaultKeyID := env.Get(config.EnvKMIPKeyName, "")
+		KMS, err := kms.NewKMIP(kms.KMIPConfig{
+			Endpoints:    endpoints,
+			DefaultKeyID: defaultKeyID,
+			Version:      env.Get(config.EnvKMIPVersion, "1.4"),
+			Certificate:  certificate,
+			RootCAs:      rootCAs,
+		})
+		if err != nil {
+			logger.Fatal(err, "Unable to initialize a connection to the KMIP server as specified by the shell environment")
+		}
+
+		// As for KES, the default key is created if it does not exist,
+		// MinIO may not be allowed to create keys on the KMIP server.
+		if err = KMS.CreateKey(defaultKeyID); err != nil && !errors.Is(err, kes.ErrKeyExists) {
+			logger.LogIf(GlobalContext, fmt.Errorf("Unable to create the default KMIP key %q: %w", defaultKeyID, err))
+		}
+		backends = append(backends, KMS)
+	}
+
+	if env.IsSet(config.EnvVaultEndpoint) {
+		rootCAs, err := certs.GetRootCAs(env.Get(config.EnvVaultServerCA, globalCertsCADir.Get()))
+		if err != nil {
+			logger.Fatal(err, fmt.Sprintf("Unable to load X.509 root CAs for Vault from %q", env.Get(config.EnvVaultServerCA, globalCertsCADir.Get())))
+		}
+		defaultKeyID := env.Get(config.EnvVaultKeyName, "")
+		KMS, err := kms.NewVault(kms.VaultConfig{
+			Endpoint:      env.Get(config.EnvVaultEndpoint, ""),
+			Namespace:     env.Get(config.EnvVaultNamespace, ""),
+			Mount:         env.Get(config.EnvVaultTransitMount, ""),
+			DefaultKeyID:  defaultKeyID,
+			AppRoleID:     env.Get(config.EnvVaultAppRoleID, ""),
+			AppRoleSecret: env.Get(config.EnvVaultAppRoleSecret, ""),
+			AppRoleMount:  env.Get(config.EnvVaultAppRoleMount, ""),
+			K8SRole:       env.Get(config.EnvVaultK8SRole, ""),
+			K8SJWTFile:    env.Get(config.EnvVaultK8SJWTFile, "/var/run/secrets/kubernetes.io/serviceaccount/token"),
+			K8SMount:      env.Get(config.EnvVaultK8SMount, ""),
+			RootCAs:       rootCAs,
+		})
+		if err != nil {
+			logger.Fatal(err, "Unable to initialize a connection to Vault as specified by the shell environment")
+		}
+
+		// As for KES, the default key is created if it does not exist,
+		// MinIO may not be allowed to create Transit keys.
+		if err = KMS.CreateKey(defaultKeyID); err != nil && !errors.Is(err, kes.ErrKeyExists) {
+			logger.LogIf(GlobalContext, fmt.Errorf("Unable to create the default Vault Transit key %q: %w", defaultKeyID, err))
+		}
+		backends = append(backends, KMS)
+	}
+
+	switch len(backends) {
+	case 1:
+		GlobalKMS = backends[0]
+	case 2:
+		GlobalKMS = kms.NewDouble(backends[0], backends[1])
+	}
+	if GlobalKMS != nil {
+		GlobalKMS = kms.NewBreaker(GlobalKMS, parseKMSBreakerConfig())
+	}
+
+	kmsAudit, err := config.ParseBool(env.Get(config.EnvKMSAudit, config.EnableOff))
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_KMS_AUDIT value in environment variable")
+	}
+	if kmsAudit && GlobalKMS != nil {
+		GlobalKMS = kms.NewAudit(GlobalKMS, globalKMSAuditLog.log)
+	}
+}
+
+// parseKMSBreakerConfig returns the KMS circuit breaker configuration
+// from the environment.
+func parseKMSBreakerConfig() kms.BreakerConfig {
+	breaker := kms.BreakerConfig{
+		Mode: kms.BreakerMode(env.Get(config.EnvKMSBreaker, string(kms.BreakerOff))),
+	}
+	switch breaker.Mode {
+	case kms.BreakerOff, kms.BreakerReject, kms.BreakerQueue, kms.BreakerCache:
+	default:
+		logger.Fatal(errInvalidArgument, fmt.Sprintf("Invalid %s value %q in environment variable", config.EnvKMSBreaker, breaker.Mode))
+	}
+
+	var err error
+	if breaker.Threshold, err = strconv.Atoi(env.Get(config.EnvKMSBreakerThreshold, "5")); err != nil || breaker.Threshold <= 0 {
+		logger.Fatal(errInvalidArgument, fmt.Sprintf("Invalid %s value in environment variable", config.EnvKMSBreakerThreshold))
+	}
+	if breaker.CacheSize, err = strconv.Atoi(env.Get(config.EnvKMSBreakerCacheSize, "10000")); err != nil || breaker.CacheSize < 0 {
+		logger.Fatal(errInvalidArgument, fmt.Sprintf("Invalid %s value in environment variable", config.EnvKMSBreakerCacheSize))
+	}
+	for _, d := range []struct {
+		env   string
+		value string
+		dst   *time.Duration
+	}{
+		{config.EnvKMSBreakerCooldown, "30s", &breaker.Cooldown},
+		{config.EnvKMSBreakerQueueTimeout, "10s", &breaker.QueueTimeout},
+		{config.EnvKMSBreakerCacheTTL, "5m", &breaker.CacheTTL},
+	} {
+		if *d.dst, err = time.ParseDuration(env.Get(d.env, d.value)); err != nil || *d.dst < 0 {
+			logger.Fatal(errInvalidArgument, fmt.Sprintf("Invalid %s value in environment variable", d.env))
+		}
 	}
+	return breaker
 }
 
 func logStartupMessage(msg string) {
@@ -825,13 +1014,34 @@ func getTLSConfig() (x509Certs []*x509.Certificate, manager *certs.Manager, secu
 		return nil, nil, false, nil
 	}
 
-	if x509Certs, err = config.ParsePublicCertFile(getPublicCertFile()); err != nil {
+	x509Certs, manager, err = loadTLSCerts(GlobalContext, func(err error) error {
+		logger.LogIf(GlobalContext, err, logger.Minio)
+		return nil
+	})
+	if err != nil {
 		return nil, nil, false, err
 	}
+	secureConn = true
 
-	manager, err = certs.NewManager(GlobalContext, getPublicCertFile(), getPrivateKeyFile(), config.LoadX509KeyPair)
+	// syscall.SIGHUP to reload the certs.
+	manager.ReloadOnSignal(syscall.SIGHUP)
+
+	return x509Certs, manager, secureConn, nil
+}
+
+// loadTLSCerts loads the public certificate, the private key and the
+// certificates of all domain directories of the certs directory. The
+// certificates are watched for changes until ctx is canceled. Domain
+// certificates which fail to load are passed to onErr, loading stops
+// if it returns an error.
+func loadTLSCerts(ctx context.Context, onErr func(err error) error) (x509Certs []*x509.Certificate, manager *certs.Manager, err error) {
+	if x509Certs, err = config.ParsePublicCertFile(getPublicCertFile()); err != nil {
+		return nil, nil, err
+	}
+
+	manager, err = certs.NewManager(ctx, getPublicCertFile(), getPrivateKeyFile(), loadX509KeyPair)
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, err
 	}
 
 	// MinIO has support for multiple certificates. It expects the following structure:
@@ -855,13 +1065,13 @@ func getTLSConfig() (x509Certs []*x509.Certificate, manager *certs.Manager, secu
 	// If so, we try to add it to certificate manager.
 	root, err := os.Open(globalCertsDir.Get())
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, err
 	}
 	defer root.Close()
 
 	files, err := root.Readdir(-1)
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, err
 	}
 	for _, file := range files {
 		// Ignore all
@@ -891,15 +1101,32 @@ func getTLSConfig() (x509Certs []*x509.Certificate, manager *certs.Manager, secu
 		}
 		if err = manager.AddCertificate(certFile, keyFile); err != nil {
 			err = fmt.Errorf("Unable to load TLS certificate '%s,%s': %w", certFile, keyFile, err)
-			logger.LogIf(GlobalContext, err, logger.Minio)
+			if err = onErr(err); err != nil {
+				return nil, nil, err
+			}
 		}
 	}
-	secureConn = true
-
-	// syscall.SIGHUP to reload the certs.
-	manager.ReloadOnSignal(syscall.SIGHUP)
+	return x509Certs, manager, nil
+}
 
-	return x509Certs, manager, secureConn, nil
+// loadX509KeyPair loads the TLS certificate from the given files. In
+// FIPS mode, certificates with keys or signatures that are not FIPS
+// approved are rejected.
+func loadX509KeyPair(certFile, keyFile string) (tls.Certificate, error) {
+	cert, err := config.LoadX509KeyPair(certFile, keyFile)
+	if err != nil || !fips.Required() {
+		return cert, err
+	}
+	for _, der := range cert.Certificate {
+		x509Cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		if err = fips.VerifyCertificate(x509Cert); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+	return cert, nil
 }
 
 // contextCanceled returns whether a context is canceled.
@@ -25,49 +25,80 @@ import (
 	"sync"
 
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/bucket/publicaccess"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/config/api"
 	"github.com/minio/minio/internal/config/cache"
 	"github.com/minio/minio/internal/config/compress"
+	"github.com/minio/minio/internal/config/conflict"
 	"github.com/minio/minio/internal/config/dns"
 	"github.com/minio/minio/internal/config/etcd"
 	"github.com/minio/minio/internal/config/heal"
+	"github.com/minio/minio/internal/config/iamevents"
+	"github.com/minio/minio/internal/config/identity/kerberos"
 	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/config/identity/openid"
+	"github.com/minio/minio/internal/config/identity/rotation"
 	xtls "github.com/minio/minio/internal/config/identity/tls"
+	"github.com/minio/minio/internal/config/ilmapproval"
+	"github.com/minio/minio/internal/config/limits"
+	xmetrics "github.com/minio/minio/internal/config/metrics"
 	"github.com/minio/minio/internal/config/notify"
 	"github.com/minio/minio/internal/config/policy/opa"
+	"github.com/minio/minio/internal/config/profiling"
 	"github.com/minio/minio/internal/config/scanner"
+	"github.com/minio/minio/internal/config/speedtest"
 	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/config/subnet"
+	"github.com/minio/minio/internal/config/tiercache"
+	"github.com/minio/minio/internal/config/tierverify"
+	"github.com/minio/minio/internal/config/tracing"
 	"github.com/minio/minio/internal/crypto"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/logger/target/http"
 	"github.com/minio/minio/internal/logger/target/kafka"
+	"github.com/minio/minio/internal/logger/target/splunk"
+	"github.com/minio/minio/internal/logger/target/syslog"
 	"github.com/minio/pkg/env"
 )
 
 func initHelp() {
 	kvs := map[string]config.KVS{
-		config.EtcdSubSys:           etcd.DefaultKVS,
-		config.CacheSubSys:          cache.DefaultKVS,
-		config.CompressionSubSys:    compress.DefaultKVS,
-		config.IdentityLDAPSubSys:   xldap.DefaultKVS,
-		config.IdentityOpenIDSubSys: openid.DefaultKVS,
-		config.IdentityTLSSubSys:    xtls.DefaultKVS,
-		config.PolicyOPASubSys:      opa.DefaultKVS,
-		config.SiteSubSys:           config.DefaultSiteKVS,
-		config.RegionSubSys:         config.DefaultRegionKVS,
-		config.APISubSys:            api.DefaultKVS,
-		config.CredentialsSubSys:    config.DefaultCredentialKVS,
-		config.LoggerWebhookSubSys:  logger.DefaultLoggerWebhookKVS,
-		config.AuditWebhookSubSys:   logger.DefaultAuditWebhookKVS,
-		config.AuditKafkaSubSys:     logger.DefaultAuditKafkaKVS,
-		config.HealSubSys:           heal.DefaultKVS,
-		config.ScannerSubSys:        scanner.DefaultKVS,
-		config.SubnetSubSys:         subnet.DefaultKVS,
+		config.EtcdSubSys:               etcd.DefaultKVS,
+		config.CacheSubSys:              cache.DefaultKVS,
+		config.CompressionSubSys:        compress.DefaultKVS,
+		config.IdentityLDAPSubSys:       xldap.DefaultKVS,
+		config.IdentityOpenIDSubSys:     openid.DefaultKVS,
+		config.IdentityTLSSubSys:        xtls.DefaultKVS,
+		config.PolicyOPASubSys:          opa.DefaultKVS,
+		config.SiteSubSys:               config.DefaultSiteKVS,
+		config.RegionSubSys:             config.DefaultRegionKVS,
+		config.APISubSys:                api.DefaultKVS,
+		config.CredentialsSubSys:        config.DefaultCredentialKVS,
+		config.LoggerWebhookSubSys:      logger.DefaultLoggerWebhookKVS,
+		config.AuditWebhookSubSys:       logger.DefaultAuditWebhookKVS,
+		config.AuditKafkaSubSys:         logger.DefaultAuditKafkaKVS,
+		config.AuditSplunkSubSys:        logger.DefaultAuditSplunkKVS,
+		config.LoggerSyslogSubSys:       logger.DefaultLoggerSyslogKVS,
+		config.AuditSyslogSubSys:        logger.DefaultAuditSyslogKVS,
+		config.HealSubSys:               heal.DefaultKVS,
+		config.ScannerSubSys:            scanner.DefaultKVS,
+		config.SubnetSubSys:             subnet.DefaultKVS,
+		config.CredentialRotationSubSys: rotation.DefaultKVS,
+		config.IdentityKerberosSubSys:   kerberos.DefaultKVS,
+		config.PublicAccessBlockSubSys:  publicaccess.DefaultKVS,
+		config.IAMEventsSubSys:          iamevents.DefaultKVS,
+		config.ReplConflictSubSys:       conflict.DefaultKVS,
+		config.TierCacheSubSys:          tiercache.DefaultKVS,
+		config.TierVerifySubSys:         tierverify.DefaultKVS,
+		config.LimitsSubSys:             limits.DefaultKVS,
+		config.SpeedtestSubSys:          speedtest.DefaultKVS,
+		config.ILMApprovalSubSys:        ilmapproval.DefaultKVS,
+		config.TracingSubSys:            tracing.DefaultKVS,
+		config.ProfilingSubSys:          profiling.DefaultKVS,
+		config.MetricsSubSys:            xmetrics.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -138,6 +169,21 @@ func initHelp() {
 			Description:     "send audit logs to kafka endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.AuditSplunkSubSys,
+			Description:     "send audit logs to Splunk HTTP Event Collector endpoints",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.LoggerSyslogSubSys,
+			Description:     "send server logs to syslog servers",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:             config.AuditSyslogSubSys,
+			Description:     "send audit logs to syslog servers",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyWebhookSubSys,
 			Description:     "publish bucket notifications to webhook endpoints",
@@ -168,6 +214,11 @@ func initHelp() {
 			Description:     "publish bucket notifications to NSQ endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifyPubSubSubSys,
+			Description:     "publish bucket notifications to Google Cloud Pub/Sub topics",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyMySQLSubSys,
 			Description:     "publish bucket notifications to MySQL databases",
@@ -183,17 +234,79 @@ func initHelp() {
 			Description:     "publish bucket notifications to Elasticsearch endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifyEventHubsSubSys,
+			Description:     "publish bucket notifications to Azure Event Hubs",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:             config.NotifyRedisSubSys,
 			Description:     "publish bucket notifications to Redis datastores",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.NotifySQSSubSys,
+			Description:     "publish bucket notifications to Amazon SQS queues",
+			MultipleTargets: true,
+		},
 		config.HelpKV{
 			Key:         config.SubnetSubSys,
 			Type:        "string",
 			Description: "set subnet config for the cluster e.g. api key",
 			Optional:    true,
 		},
+		config.HelpKV{
+			Key:         config.CredentialRotationSubSys,
+			Description: "enforce maximum age of user and service account access keys",
+		},
+		config.HelpKV{
+			Key:         config.IdentityKerberosSubSys,
+			Description: "enable Kerberos SPNEGO authentication for STS",
+		},
+		config.HelpKV{
+			Key:         config.PublicAccessBlockSubSys,
+			Description: "manage deployment wide public access block settings",
+		},
+		config.HelpKV{
+			Key:         config.IAMEventsSubSys,
+			Description: "send IAM change events to notification targets",
+		},
+		config.HelpKV{
+			Key:         config.ReplConflictSubSys,
+			Description: "manage conflict resolution of active-active replication",
+		},
+		config.HelpKV{
+			Key:         config.TierCacheSubSys,
+			Description: "cache data read from remote tiers on local drives",
+		},
+		config.HelpKV{
+			Key:         config.TierVerifySubSys,
+			Description: "verify the integrity of data on remote tiers in the background",
+		},
+		config.HelpKV{
+			Key:         config.ILMApprovalSubSys,
+			Description: "approve lifecycle expirations with an external webhook",
+		},
+		config.HelpKV{
+			Key:         config.LimitsSubSys,
+			Description: "limit the memory, requests and background concurrency of a node",
+		},
+		config.HelpKV{
+			Key:         config.SpeedtestSubSys,
+			Description: "run drive, network and object speedtests in a maintenance window",
+		},
+		config.HelpKV{
+			Key:         config.TracingSubSys,
+			Description: "export traces of requests to an OTLP endpoint",
+		},
+		config.HelpKV{
+			Key:         config.ProfilingSubSys,
+			Description: "capture profiles continuously and store them in a bucket",
+		},
+		config.HelpKV{
+			Key:         config.MetricsSubSys,
+			Description: "enable optional metrics and limit their cardinality",
+		},
 	}
 
 	if globalIsErasure {
@@ -206,34 +319,53 @@ func initHelp() {
 	}
 
 	helpMap := map[string]config.HelpKVS{
-		"":                          helpSubSys, // Help for all sub-systems.
-		config.SiteSubSys:           config.SiteHelp,
-		config.RegionSubSys:         config.RegionHelp,
-		config.APISubSys:            api.Help,
-		config.StorageClassSubSys:   storageclass.Help,
-		config.EtcdSubSys:           etcd.Help,
-		config.CacheSubSys:          cache.Help,
-		config.CompressionSubSys:    compress.Help,
-		config.HealSubSys:           heal.Help,
-		config.ScannerSubSys:        scanner.Help,
-		config.IdentityOpenIDSubSys: openid.Help,
-		config.IdentityLDAPSubSys:   xldap.Help,
-		config.IdentityTLSSubSys:    xtls.Help,
-		config.PolicyOPASubSys:      opa.Help,
-		config.LoggerWebhookSubSys:  logger.Help,
-		config.AuditWebhookSubSys:   logger.HelpWebhook,
-		config.AuditKafkaSubSys:     logger.HelpKafka,
-		config.NotifyAMQPSubSys:     notify.HelpAMQP,
-		config.NotifyKafkaSubSys:    notify.HelpKafka,
-		config.NotifyMQTTSubSys:     notify.HelpMQTT,
-		config.NotifyNATSSubSys:     notify.HelpNATS,
-		config.NotifyNSQSubSys:      notify.HelpNSQ,
-		config.NotifyMySQLSubSys:    notify.HelpMySQL,
-		config.NotifyPostgresSubSys: notify.HelpPostgres,
-		config.NotifyRedisSubSys:    notify.HelpRedis,
-		config.NotifyWebhookSubSys:  notify.HelpWebhook,
-		config.NotifyESSubSys:       notify.HelpES,
-		config.SubnetSubSys:         subnet.HelpSubnet,
+		"":                              helpSubSys, // Help for all sub-systems.
+		config.SiteSubSys:               config.SiteHelp,
+		config.RegionSubSys:             config.RegionHelp,
+		config.APISubSys:                api.Help,
+		config.StorageClassSubSys:       storageclass.Help,
+		config.EtcdSubSys:               etcd.Help,
+		config.CacheSubSys:              cache.Help,
+		config.CompressionSubSys:        compress.Help,
+		config.HealSubSys:               heal.Help,
+		config.ScannerSubSys:            scanner.Help,
+		config.IdentityOpenIDSubSys:     openid.Help,
+		config.IdentityLDAPSubSys:       xldap.Help,
+		config.IdentityTLSSubSys:        xtls.Help,
+		config.PolicyOPASubSys:          opa.Help,
+		config.LoggerWebhookSubSys:      logger.Help,
+		config.AuditWebhookSubSys:       logger.HelpWebhook,
+		config.AuditKafkaSubSys:         logger.HelpKafka,
+		config.AuditSplunkSubSys:        logger.HelpSplunk,
+		config.LoggerSyslogSubSys:       logger.HelpSyslog,
+		config.AuditSyslogSubSys:        logger.HelpSyslog,
+		config.NotifyAMQPSubSys:         notify.HelpAMQP,
+		config.NotifyKafkaSubSys:        notify.HelpKafka,
+		config.NotifyMQTTSubSys:         notify.HelpMQTT,
+		config.NotifyNATSSubSys:         notify.HelpNATS,
+		config.NotifyNSQSubSys:          notify.HelpNSQ,
+		config.NotifyPubSubSubSys:       notify.HelpPubSub,
+		config.NotifyMySQLSubSys:        notify.HelpMySQL,
+		config.NotifyPostgresSubSys:     notify.HelpPostgres,
+		config.NotifyRedisSubSys:        notify.HelpRedis,
+		config.NotifyWebhookSubSys:      notify.HelpWebhook,
+		config.NotifyESSubSys:           notify.HelpES,
+		config.NotifyEventHubsSubSys:    notify.HelpEventHubs,
+		config.NotifySQSSubSys:          notify.HelpSQS,
+		config.SubnetSubSys:             subnet.HelpSubnet,
+		config.CredentialRotationSubSys: rotation.Help,
+		config.IdentityKerberosSubSys:   kerberos.Help,
+		config.PublicAccessBlockSubSys:  publicaccess.Help,
+		config.IAMEventsSubSys:          iamevents.Help,
+		config.ReplConflictSubSys:       conflict.Help,
+		config.TierCacheSubSys:          tiercache.Help,
+		config.TierVerifySubSys:         tierverify.Help,
+		config.LimitsSubSys:             limits.Help,
+		config.SpeedtestSubSys:          speedtest.Help,
+		config.ILMApprovalSubSys:        ilmapproval.Help,
+		config.TracingSubSys:            tracing.Help,
+		config.ProfilingSubSys:          profiling.Help,
+		config.MetricsSubSys:            xmetrics.Help,
 	}
 
 	config.RegisterHelpSubSys(helpMap)
@@ -313,6 +445,54 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
+	if _, err = rotation.LookupConfig(s[config.CredentialRotationSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = publicaccess.LookupConfig(s[config.PublicAccessBlockSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = iamevents.LookupConfig(s[config.IAMEventsSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = conflict.LookupConfig(s[config.ReplConflictSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = tiercache.LookupConfig(s[config.TierCacheSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = tierverify.LookupConfig(s[config.TierVerifySubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = ilmapproval.LookupConfig(s[config.ILMApprovalSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = limits.LookupConfig(s[config.LimitsSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = speedtest.LookupConfig(s[config.SpeedtestSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = tracing.LookupConfig(s[config.TracingSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = profiling.LookupConfig(s[config.ProfilingSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = xmetrics.LookupConfig(s[config.MetricsSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -351,6 +531,9 @@ func validateConfig(s config.Config) error {
 			return err
 		}
 	}
+	if _, err := kerberos.LookupConfig(s[config.IdentityKerberosSubSys][config.Default]); err != nil {
+		return err
+	}
 
 	if _, err := opa.LookupConfig(s[config.PolicyOPASubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody); err != nil {
@@ -498,6 +681,16 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		}
 	}
 
+	tierCacheCfg, err := tiercache.LookupConfig(s[config.TierCacheSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to setup tier cache: %w", err))
+	} else if tierCacheCfg.Enabled() && globalTierReadCache == nil {
+		globalTierReadCache, err = newTierReadCache(tierCacheCfg)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to setup tier cache: %w", err))
+		}
+	}
+
 	globalAutoEncryption = crypto.LookupAutoEncryption() // Enable auto-encryption if enabled
 	if globalAutoEncryption && GlobalKMS == nil {
 		logger.Fatal(errors.New("no KMS configured"), "MINIO_KMS_AUTO_ENCRYPTION requires a valid KMS configuration")
@@ -512,6 +705,11 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		logger.Info("CRITICAL: enabling %s is not recommended in a production environment", xtls.EnvIdentityTLSSkipVerify)
 	}
 
+	globalKerberosConfig, err = kerberos.LookupConfig(s[config.IdentityKerberosSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize Kerberos: %w", err))
+	}
+
 	globalOpenIDConfig, err = openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
 		NewGatewayHTTPTransport(), xhttp.DrainBody, globalSite.Region)
 	if err != nil {
@@ -580,10 +778,55 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		}
 	}
 
+	for name, l := range loggerCfg.AuditSplunk {
+		if l.Enabled {
+			l.Name = name
+			l.LogOnce = logger.LogOnceIf
+			l.UserAgent = loggerUserAgent
+			l.Host = globalLocalNodeName
+			l.Transport = NewGatewayHTTPTransport()
+			// Enable Splunk audit logging
+			if err = logger.AddAuditTarget(splunk.New(l)); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize server audit Splunk target: %w", err))
+			}
+		}
+	}
+
+	for name, l := range loggerCfg.Syslog {
+		if l.Enabled {
+			l.Name = name
+			l.LogOnce = logger.LogOnceIf
+			l.Hostname = globalLocalNodeName
+			if l.TLSConfig != nil {
+				l.TLSConfig.RootCAs = globalRootCAs
+			}
+			// Enable syslog logging
+			if err = logger.AddTarget(syslog.New(l)); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize server logger syslog target: %w", err))
+			}
+		}
+	}
+
+	for name, l := range loggerCfg.AuditSyslog {
+		if l.Enabled {
+			l.Name = name
+			l.LogOnce = logger.LogOnceIf
+			l.Hostname = globalLocalNodeName
+			if l.TLSConfig != nil {
+				l.TLSConfig.RootCAs = globalRootCAs
+			}
+			// Enable syslog audit logging
+			if err = logger.AddAuditTarget(syslog.New(l)); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize server audit syslog target: %w", err))
+			}
+		}
+	}
+
 	globalConfigTargetList, err = notify.GetNotificationTargets(GlobalContext, s, NewGatewayHTTPTransport(), false)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize notification target(s): %w", err))
 	}
+	setConfigTargets(s)
 
 	globalEnvTargetList, err = notify.GetNotificationTargets(GlobalContext, newServerConfig(), NewGatewayHTTPTransport(), true)
 	if err != nil {
@@ -635,6 +878,72 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 		return fmt.Errorf("Unable to apply scanner config: %w", err)
 	}
 
+	// Credential rotation
+	rotationCfg, err := rotation.LookupConfig(s[config.CredentialRotationSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply credential rotation config: %w", err)
+	}
+
+	// Public access block
+	publicAccessCfg, err := publicaccess.LookupConfig(s[config.PublicAccessBlockSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply public access block config: %w", err)
+	}
+
+	// IAM change events
+	iamEventsCfg, err := iamevents.LookupConfig(s[config.IAMEventsSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply IAM events config: %w", err)
+	}
+
+	// Replication conflict resolution
+	conflictCfg, err := conflict.LookupConfig(s[config.ReplConflictSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply replication conflict config: %w", err)
+	}
+
+	// Tiered data verification
+	tierVerifyCfg, err := tierverify.LookupConfig(s[config.TierVerifySubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply tier verification config: %w", err)
+	}
+
+	// Lifecycle expiration approval
+	ilmApprovalCfg, err := ilmapproval.LookupConfig(s[config.ILMApprovalSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply lifecycle approval config: %w", err)
+	}
+
+	// Distributed tracing
+	tracingCfg, err := tracing.LookupConfig(s[config.TracingSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply tracing config: %w", err)
+	}
+
+	// Continuous profiling
+	profilingCfg, err := profiling.LookupConfig(s[config.ProfilingSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply profiling config: %w", err)
+	}
+
+	// Optional metrics
+	metricsCfg, err := xmetrics.LookupConfig(s[config.MetricsSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply metrics config: %w", err)
+	}
+
+	// Resource limits
+	limitsCfg, err := limits.LookupConfig(s[config.LimitsSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply resource limits config: %w", err)
+	}
+
+	// Scheduled speedtests
+	speedtestCfg, err := speedtest.LookupConfig(s[config.SpeedtestSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply speedtest config: %w", err)
+	}
+
 	// Apply configurations.
 	// We should not fail after this.
 	var setDriveCounts []int
@@ -649,9 +958,47 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 
 	globalHealConfig.Update(healCfg)
 
+	globalCredentialRotationMu.Lock()
+	globalCredentialRotationConfig = rotationCfg
+	globalCredentialRotationMu.Unlock()
+
+	globalPublicAccessBlockMu.Lock()
+	globalPublicAccessBlockConfig = publicAccessCfg
+	globalPublicAccessBlockMu.Unlock()
+
+	globalIAMEventsMu.Lock()
+	globalIAMEventsConfig = iamEventsCfg
+	globalIAMEventsMu.Unlock()
+
+	globalReplicationConflictMu.Lock()
+	globalReplicationConflictConfig = conflictCfg
+	globalReplicationConflictMu.Unlock()
+
+	globalTierVerifier.setSampleRate(tierVerifyCfg.SampleRate)
+
+	globalILMApproval.Update(ilmApprovalCfg)
+
+	if err = globalTracing.Update(ctx, tracingCfg); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to configure the trace exporter: %w", err))
+	}
+
+	globalContinuousProfiler.Update(profilingCfg)
+
+	globalBucketLatency.Update(metricsCfg)
+	globalMetricsGovernance.Update(metricsCfg)
+
+	globalResourceLimits.Update(limitsCfg)
+
+	globalScheduledSpeedtest.Update(speedtestCfg)
+
 	// update dynamic scanner values.
 	scannerCycle.Update(scannerCfg.Cycle)
 	logger.LogIf(ctx, scannerSleeper.Update(scannerCfg.Delay, scannerCfg.MaxWait))
+	globalILMPriority.Update(scannerCfg.ILMPriority, scannerCfg.ILMPriorityCycle)
+
+	if err = reloadConfigTargets(ctx, s); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to reload notification target(s): %w", err))
+	}
 
 	// Update all dynamic config values in memory.
 	globalServerConfigMu.Lock()
@@ -0,0 +1,210 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/etcd"
+	xldap "github.com/minio/minio/internal/config/identity/ldap"
+	"github.com/minio/minio/internal/config/identity/openid"
+	"github.com/minio/minio/internal/config/notify"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/pkg/env"
+)
+
+// Connectivity checks of KMS, which is configured by environment
+// variables only, are reported under this name.
+const kmsConnectivityCheck = "kms"
+
+// configConnectivityCheck is the outcome of connecting to an external
+// service used by a sub-system, Error is empty when it succeeded.
+type configConnectivityCheck struct {
+	SubSys string `json:"subSys"`
+	Target string `json:"target,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// configDryRun is the result of validating a configuration change
+// without applying it.
+type configDryRun struct {
+	Changes []config.Change `json:"changes"`
+	// Changed sub-systems which would be applied right away.
+	Dynamic []string `json:"dynamic"`
+	// Changed sub-systems which would only be applied after the
+	// servers are restarted.
+	Restart      []string                  `json:"restart"`
+	Connectivity []configConnectivityCheck `json:"connectivity,omitempty"`
+}
+
+// newConfigDryRun returns the changes from before to after and the
+// sub-systems they affect. Changes are only applied right away if
+// the admin API applies dynamic sub-systems.
+func newConfigDryRun(before, after config.Config, applyDynamic bool) configDryRun {
+	dryRun := configDryRun{
+		Changes: before.Diff(after),
+		Dynamic: []string{},
+		Restart: []string{},
+	}
+	if dryRun.Changes == nil {
+		dryRun.Changes = []config.Change{}
+	}
+
+	// Dynamic sub-systems are only applied right away if all changes
+	// are dynamic, as with the actual configuration change.
+	dynamic := applyDynamic
+	subSystems := make(map[string]struct{})
+	for _, change := range dryRun.Changes {
+		subSystems[change.SubSys] = struct{}{}
+		if !config.SubSystemsDynamic.Contains(change.SubSys) {
+			dynamic = false
+		}
+	}
+	for subSys := range subSystems {
+		if dynamic {
+			dryRun.Dynamic = append(dryRun.Dynamic, subSys)
+		} else {
+			dryRun.Restart = append(dryRun.Restart, subSys)
+		}
+	}
+	sort.Strings(dryRun.Dynamic)
+	sort.Strings(dryRun.Restart)
+	return dryRun
+}
+
+// checkConfigConnectivity connects to the identity providers, etcd and
+// notification targets enabled in cfg and to the KMS. Unlike
+// validateConfig, which stops at the first failure and only tests
+// notification targets that are not configured yet, every service is
+// checked and reported.
+func checkConfigConnectivity(ctx context.Context, cfg config.Config) []configConnectivityCheck {
+	var checks []configConnectivityCheck
+	check := func(subSys, target string, err error) {
+		c := configConnectivityCheck{SubSys: subSys}
+		if target != config.Default {
+			c.Target = target
+		}
+		if err != nil {
+			c.Error = err.Error()
+		}
+		checks = append(checks, c)
+	}
+
+	func() {
+		// We must have a global lock for this so nobody else modifies env while we do.
+		defer env.LockSetEnv()()
+
+		// Disable merging env values with config, as in validateConfig.
+		env.SetEnvOff()
+		defer env.SetEnvOn()
+
+		if kvs := cfg[config.EtcdSubSys][config.Default]; etcd.Enabled(kvs) {
+			etcdCfg, err := etcd.LookupConfig(kvs, globalRootCAs)
+			if err == nil {
+				etcdClnt, cerr := etcd.New(etcdCfg)
+				if err = cerr; err == nil {
+					etcdClnt.Close()
+				}
+			}
+			check(config.EtcdSubSys, config.Default, err)
+		}
+
+		if kvs := cfg[config.IdentityOpenIDSubSys][config.Default]; openid.Enabled(kvs) {
+			// Looking up the configuration fetches the discovery document.
+			_, err := openid.LookupConfig(kvs, NewGatewayHTTPTransport(), xhttp.DrainBody, globalSite.Region)
+			check(config.IdentityOpenIDSubSys, config.Default, err)
+		}
+
+		if kvs := cfg[config.IdentityLDAPSubSys][config.Default]; xldap.Enabled(kvs) {
+			ldapCfg, err := xldap.Lookup(kvs, globalRootCAs)
+			if err == nil && ldapCfg.Enabled {
+				conn, cerr := ldapCfg.Connect()
+				if err = cerr; err == nil {
+					conn.Close()
+				}
+			}
+			check(config.IdentityLDAPSubSys, config.Default, err)
+		}
+
+		// Test each target on its own to report all failures.
+		for _, target := range enabledNotificationTargets(cfg) {
+			check(target.subSys, target.name, testNotificationTarget(ctx, cfg, target))
+		}
+	}()
+
+	if GlobalKMS != nil {
+		_, err := GlobalKMS.Stat()
+		check(kmsConnectivityCheck, config.Default, err)
+	}
+	return checks
+}
+
+// notificationTarget names a notification target of a configuration.
+type notificationTarget struct {
+	subSys, name string
+}
+
+// enabledNotificationTargets returns the enabled notification targets
+// of cfg, sorted by sub-system and name.
+func enabledNotificationTargets(cfg config.Config) []notificationTarget {
+	subSystems := config.NotifySubSystems.ToSlice()
+	sort.Strings(subSystems)
+
+	var targets []notificationTarget
+	for _, subSys := range subSystems {
+		names := make([]string, 0, len(cfg[subSys]))
+		for name, kvs := range cfg[subSys] {
+			if kvs.Get(config.Enable) == config.EnableOn {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			targets = append(targets, notificationTarget{subSys: subSys, name: name})
+		}
+	}
+	return targets
+}
+
+// testNotificationTarget connects to a single notification target of cfg.
+func testNotificationTarget(ctx context.Context, cfg config.Config, target notificationTarget) error {
+	targetCfg := config.Config{target.subSys: {target.name: cfg[target.subSys][target.name]}}
+	return notify.TestNotificationTargets(ctx, targetCfg, NewGatewayHTTPTransport(), nil)
+}
+
+// writeConfigDryRun responds with the changes from before to the
+// validated configuration after, which is not applied. Connectivity
+// checks are run on request.
+func writeConfigDryRun(ctx context.Context, w http.ResponseWriter, r *http.Request, before, after config.Config, applyDynamic bool) {
+	dryRun := newConfigDryRun(before, after, applyDynamic)
+	if r.Form.Get("checkConnectivity") == "true" {
+		dryRun.Connectivity = checkConfigConnectivity(ctx, after)
+	}
+
+	data, err := json.Marshal(dryRun)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
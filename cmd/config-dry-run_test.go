@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/heal"
+	"github.com/minio/minio/internal/logger"
+)
+
+func TestNewConfigDryRun(t *testing.T) {
+	before := newServerConfig()
+
+	healCfg := before.Clone()
+	healCfg[config.HealSubSys][config.Default] = config.KVS{
+		config.KV{Key: heal.Bitrot, Value: config.EnableOn},
+	}
+
+	mixedCfg := healCfg.Clone()
+	mixedCfg[config.AuditWebhookSubSys][config.Default] = config.KVS{
+		config.KV{Key: config.Enable, Value: config.EnableOn},
+		config.KV{Key: logger.AuthToken, Value: "token"},
+	}
+
+	testCases := []struct {
+		after        config.Config
+		applyDynamic bool
+		dynamic      []string
+		restart      []string
+	}{
+		{before.Clone(), true, []string{}, []string{}},
+		{healCfg, true, []string{config.HealSubSys}, []string{}},
+		// An imported configuration is not applied right away.
+		{healCfg, false, []string{}, []string{config.HealSubSys}},
+		// Dynamic sub-systems wait for the restart of the others.
+		{mixedCfg, true, []string{}, []string{config.AuditWebhookSubSys, config.HealSubSys}},
+	}
+
+	for i, testCase := range testCases {
+		dryRun := newConfigDryRun(before, testCase.after, testCase.applyDynamic)
+		if !reflect.DeepEqual(dryRun.Dynamic, testCase.dynamic) {
+			t.Errorf("case %d: expected dynamic %v, got %v", i+1, testCase.dynamic, dryRun.Dynamic)
+		}
+		if !reflect.DeepEqual(dryRun.Restart, testCase.restart) {
+			t.Errorf("case %d: expected restart %v, got %v", i+1, testCase.restart, dryRun.Restart)
+		}
+		if len(dryRun.Changes) != len(before.Diff(testCase.after)) {
+			t.Errorf("case %d: unexpected changes %v", i+1, dryRun.Changes)
+		}
+	}
+
+	// Sensitive values are never reported.
+	for _, change := range newConfigDryRun(before, mixedCfg, true).Changes {
+		if change.Key == logger.AuthToken && change.NewValue == "token" {
+			t.Errorf("expected %s to be redacted", logger.AuthToken)
+		}
+	}
+}
@@ -29,8 +29,10 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/kms"
+	"github.com/minio/minio/internal/logger"
 )
 
 const (
@@ -38,6 +40,9 @@ const (
 
 	kvPrefix = ".kv"
 
+	// Suffix of the info recorded along with a config history entry.
+	historyInfoSuffix = ".info"
+
 	// Captures all the previous SetKV operations and allows rollback.
 	minioConfigHistoryPrefix = minioConfigPrefix + "/history"
 
@@ -45,10 +50,38 @@ const (
 	minioConfigFile = "config.json"
 )
 
+// configHistoryInfo - who made a change recorded in the config history
+// and the changed values, with sensitive values redacted.
+type configHistoryInfo struct {
+	User       string          `json:"user"`
+	ParentUser string          `json:"parentUser,omitempty"`
+	API        string          `json:"api"`
+	Changes    []config.Change `json:"changes"`
+	// Delete is set if the KV settings of the entry were deleted.
+	Delete bool `json:"delete,omitempty"`
+}
+
+func newConfigHistoryInfo(cred auth.Credentials, api string, before, after config.Config) configHistoryInfo {
+	return configHistoryInfo{
+		User:       cred.AccessKey,
+		ParentUser: cred.ParentUser,
+		API:        api,
+		Changes:    before.Diff(after),
+	}
+}
+
+// configHistoryEntry - a config history entry along with its info,
+// entries saved by older releases have none. Clients only aware of
+// madmin.ConfigHistoryEntry ignore the info.
+type configHistoryEntry struct {
+	madmin.ConfigHistoryEntry
+	*configHistoryInfo
+}
+
 func listServerConfigHistory(ctx context.Context, objAPI ObjectLayer, withData bool, count int) (
-	[]madmin.ConfigHistoryEntry, error,
+	[]configHistoryEntry, error,
 ) {
-	var configHistory []madmin.ConfigHistoryEntry
+	var configHistory []configHistoryEntry
 
 	// List all kvs
 	marker := ""
@@ -58,10 +91,13 @@ func listServerConfigHistory(ctx context.Context, objAPI ObjectLayer, withData b
 			return nil, err
 		}
 		for _, obj := range res.Objects {
-			cfgEntry := madmin.ConfigHistoryEntry{
+			if !strings.HasSuffix(obj.Name, kvPrefix) {
+				continue
+			}
+			cfgEntry := configHistoryEntry{ConfigHistoryEntry: madmin.ConfigHistoryEntry{
 				RestoreID:  strings.TrimSuffix(path.Base(obj.Name), kvPrefix),
 				CreateTime: obj.ModTime, // ModTime is createTime for config history entries.
-			}
+			}}
 			if withData {
 				data, err := readConfig(ctx, objAPI, obj.Name)
 				if err != nil {
@@ -76,6 +112,14 @@ func listServerConfigHistory(ctx context.Context, objAPI ObjectLayer, withData b
 					}
 				}
 				cfgEntry.Data = string(data)
+
+				info, err := readServerConfigHistoryInfo(ctx, objAPI, cfgEntry.RestoreID)
+				if err != nil && !errors.Is(err, errConfigNotFound) {
+					return nil, err
+				}
+				if err == nil {
+					cfgEntry.configHistoryInfo = &info
+				}
 			}
 			configHistory = append(configHistory, cfgEntry)
 			count--
@@ -100,7 +144,14 @@ func delServerConfigHistory(ctx context.Context, objAPI ObjectLayer, uuidKV stri
 	_, err := objAPI.DeleteObject(ctx, minioMetaBucket, historyFile, ObjectOptions{
 		DeletePrefix: true,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	infoFile := pathJoin(minioConfigHistoryPrefix, uuidKV+historyInfoSuffix)
+	if err = deleteConfig(ctx, objAPI, infoFile); err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+	return nil
 }
 
 func readServerConfigHistory(ctx context.Context, objAPI ObjectLayer, uuidKV string) ([]byte, error) {
@@ -118,9 +169,34 @@ func readServerConfigHistory(ctx context.Context, objAPI ObjectLayer, uuidKV str
 	return data, err
 }
 
-func saveServerConfigHistory(ctx context.Context, objAPI ObjectLayer, kv []byte) error {
-	uuidKV := mustGetUUID() + kvPrefix
-	historyFile := pathJoin(minioConfigHistoryPrefix, uuidKV)
+// readServerConfigHistoryInfo - returns the info of the config history
+// entry, errConfigNotFound if none was recorded.
+func readServerConfigHistoryInfo(ctx context.Context, objAPI ObjectLayer, uuidKV string) (configHistoryInfo, error) {
+	var info configHistoryInfo
+	infoFile := pathJoin(minioConfigHistoryPrefix, uuidKV+historyInfoSuffix)
+	data, err := readConfig(ctx, objAPI, infoFile)
+	if err != nil {
+		return info, err
+	}
+
+	if GlobalKMS != nil {
+		data, err = config.DecryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, infoFile),
+		})
+		if err != nil {
+			return info, err
+		}
+	}
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
+
+// saveServerConfigHistory - saves the KV settings of a config change
+// along with its info. Failing to save the info is only logged, the
+// entry can be restored without it.
+func saveServerConfigHistory(ctx context.Context, objAPI ObjectLayer, kv []byte, info configHistoryInfo) error {
+	uuid := mustGetUUID()
+	historyFile := pathJoin(minioConfigHistoryPrefix, uuid+kvPrefix)
 
 	if GlobalKMS != nil {
 		var err error
@@ -131,7 +207,29 @@ func saveServerConfigHistory(ctx context.Context, objAPI ObjectLayer, kv []byte)
 			return err
 		}
 	}
-	return saveConfig(ctx, objAPI, historyFile, kv)
+	if err := saveConfig(ctx, objAPI, historyFile, kv); err != nil {
+		return err
+	}
+	logger.LogIf(ctx, saveServerConfigHistoryInfo(ctx, objAPI, uuid, info))
+	return nil
+}
+
+func saveServerConfigHistoryInfo(ctx context.Context, objAPI ObjectLayer, uuid string, info configHistoryInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	infoFile := pathJoin(minioConfigHistoryPrefix, uuid+historyInfoSuffix)
+	if GlobalKMS != nil {
+		data, err = config.EncryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, infoFile),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, infoFile, data)
 }
 
 func saveServerConfig(ctx context.Context, objAPI ObjectLayer, cfg interface{}) error {
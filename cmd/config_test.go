@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/logger"
+)
+
+func TestServerConfigHistoryInfo(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objAPI, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	before := newServerConfig()
+	after := before.Clone()
+	after[config.AuditWebhookSubSys][config.Default] = config.KVS{
+		config.KV{Key: config.Enable, Value: config.EnableOn},
+		config.KV{Key: logger.AuthToken, Value: "token"},
+	}
+	kv := []byte("audit_webhook enable=on auth_token=token")
+	info := newConfigHistoryInfo(auth.Credentials{AccessKey: "admin"}, "SetConfigKV", before, after)
+	if err = saveServerConfigHistory(ctx, objAPI, kv, info); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := listServerConfigHistory(ctx, objAPI, true, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Data != string(kv) {
+		t.Errorf("expected the KV settings %q, got %q", kv, entry.Data)
+	}
+	if entry.configHistoryInfo == nil || entry.User != "admin" || entry.API != "SetConfigKV" {
+		t.Fatalf("unexpected history info %+v", entry.configHistoryInfo)
+	}
+	for _, change := range entry.Changes {
+		if change.Key == logger.AuthToken && change.NewValue != "*redacted*" {
+			t.Errorf("expected the token to be redacted, got %q", change.NewValue)
+		}
+	}
+
+	// Clients only aware of the madmin entry still decode the list.
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var madminEntries []madmin.ConfigHistoryEntry
+	if err = json.Unmarshal(data, &madminEntries); err != nil {
+		t.Fatal(err)
+	}
+	if len(madminEntries) != 1 || madminEntries[0].RestoreID != entry.RestoreID {
+		t.Fatalf("unexpected entries %v", madminEntries)
+	}
+
+	if err = delServerConfigHistory(ctx, objAPI, entry.RestoreID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = readServerConfigHistoryInfo(ctx, objAPI, entry.RestoreID); err != errConfigNotFound {
+		t.Errorf("expected the info to be deleted, got %v", err)
+	}
+}
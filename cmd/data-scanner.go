@@ -38,7 +38,6 @@ import (
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/color"
 	"github.com/minio/minio/internal/config/heal"
-	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/console"
 )
@@ -143,6 +142,10 @@ func runDataScanner(pctx context.Context, objAPI ObjectLayer) {
 				console.Debugln("starting scanner cycle")
 			}
 
+			// Pick up replication resyncs left behind by restarted
+			// or lost nodes.
+			globalReplicationResyncer.Resume(ctx, objAPI)
+
 			// Wait before starting next cycle and wait on startup.
 			results := make(chan DataUsageInfo, 1)
 			go storeDataUsageInBackend(ctx, objAPI, results)
@@ -887,20 +890,26 @@ func (i *scannerItem) applyLifecycle(ctx context.Context, o ObjectLayer, oi Obje
 	if i.debug {
 		logger.LogIf(ctx, err)
 	}
-	if i.lifeCycle == nil {
-		if i.debug {
-			// disabled, very chatty:
-			// console.Debugf(applyActionsLogPrefix+" no lifecycle rules to apply: %q\n", i.objectPath())
+	lc := i.lifeCycle
+	if lc == nil {
+		if oi.RestoreExpires.IsZero() {
+			if i.debug {
+				// disabled, very chatty:
+				// console.Debugf(applyActionsLogPrefix+" no lifecycle rules to apply: %q\n", i.objectPath())
+			}
+			return false, size
 		}
-		return false, size
+		// Restored copies expire without lifecycle rules.
+		lc = &lifecycle.Lifecycle{}
 	}
 
 	atomic.AddUint64(&globalScannerStats.ilmChecks, 1)
 	versionID := oi.VersionID
-	action := i.lifeCycle.ComputeAction(
+	action := lc.ComputeAction(
 		lifecycle.ObjectOpts{
 			Name:             i.objectPath(),
 			UserTags:         oi.UserTags,
+			Size:             oi.Size,
 			ModTime:          oi.ModTime,
 			VersionID:        oi.VersionID,
 			DeleteMarker:     oi.DeleteMarker,
@@ -910,6 +919,7 @@ func (i *scannerItem) applyLifecycle(ctx context.Context, o ObjectLayer, oi Obje
 			RestoreOngoing:   oi.RestoreOngoing,
 			RestoreExpires:   oi.RestoreExpires,
 			TransitionStatus: oi.TransitionedObject.Status,
+			LastAccessTime:   lastAccessTime(oi.UserDefined),
 		})
 	if i.debug {
 		if versionID != "" {
@@ -966,24 +976,31 @@ func (i *scannerItem) applyTierObjSweep(ctx context.Context, o ObjectLayer, oi O
 }
 
 // applyNewerNoncurrentVersionLimit removes noncurrent versions older than the most recent NewerNoncurrentVersions configured.
+// The limit is evaluated per version since rules may be restricted by object size.
 // Note: This function doesn't update sizeSummary since it always removes versions that it doesn't return.
 func (i *scannerItem) applyNewerNoncurrentVersionLimit(ctx context.Context, _ ObjectLayer, fivs []FileInfo) ([]FileInfo, error) {
-	if i.lifeCycle == nil {
+	if i.lifeCycle == nil || len(fivs) < 2 {
 		return fivs, nil
 	}
 
-	_, days, lim := i.lifeCycle.NoncurrentVersionsExpirationLimit(lifecycle.ObjectOpts{Name: i.objectPath()})
-	if lim == 0 || len(fivs) <= lim+1 { // fewer than lim _noncurrent_ versions
-		return fivs, nil
-	}
-
-	overflowVersions := fivs[lim+1:]
-	// current version + most recent lim noncurrent versions
-	fivs = fivs[:lim+1]
-
 	rcfg, _ := globalBucketObjectLockSys.Get(i.bucket)
-	toDel := make([]ObjectToDelete, 0, len(overflowVersions))
-	for _, fi := range overflowVersions {
+	// current version is always retained
+	remaining := make([]FileInfo, 1, len(fivs))
+	remaining[0] = fivs[0]
+	// Versions to delete by the ID of the rule expiring them.
+	toDel := make(map[string][]ObjectToDelete)
+	for newer, fi := range fivs[1:] {
+		ruleID, days, lim := i.lifeCycle.NoncurrentVersionsExpirationLimit(lifecycle.ObjectOpts{
+			Name:         i.objectPath(),
+			Size:         fi.Size,
+			DeleteMarker: fi.Deleted,
+		})
+		// fewer than lim newer _noncurrent_ versions
+		if lim == 0 || newer < lim {
+			remaining = append(remaining, fi)
+			continue
+		}
+
 		obj := fi.ToObjectInfo(i.bucket, i.objectPath())
 		// skip versions with object locking enabled
 		if rcfg.LockEnabled && enforceRetentionForDeletion(ctx, obj) {
@@ -996,7 +1013,7 @@ func (i *scannerItem) applyNewerNoncurrentVersionLimit(ctx context.Context, _ Ob
 			}
 			// add this version back to remaining versions for
 			// subsequent lifecycle policy applications
-			fivs = append(fivs, fi)
+			remaining = append(remaining, fi)
 			continue
 		}
 
@@ -1004,11 +1021,11 @@ func (i *scannerItem) applyNewerNoncurrentVersionLimit(ctx context.Context, _ Ob
 		if time.Now().UTC().Before(lifecycle.ExpectedExpiryTime(obj.SuccessorModTime, days)) {
 			// add this version back to remaining versions for
 			// subsequent lifecycle policy applications
-			fivs = append(fivs, fi)
+			remaining = append(remaining, fi)
 			continue
 		}
 
-		toDel = append(toDel, ObjectToDelete{
+		toDel[ruleID] = append(toDel[ruleID], ObjectToDelete{
 			ObjectV: ObjectV{
 				ObjectName: fi.Name,
 				VersionID:  fi.VersionID,
@@ -1016,8 +1033,10 @@ func (i *scannerItem) applyNewerNoncurrentVersionLimit(ctx context.Context, _ Ob
 		})
 	}
 
-	globalExpiryState.enqueueByNewerNoncurrent(i.bucket, toDel)
-	return fivs, nil
+	for ruleID, versions := range toDel {
+		globalExpiryState.enqueueByNewerNoncurrent(i.bucket, ruleID, versions)
+	}
+	return remaining, nil
 }
 
 // applyVersionActions will apply lifecycle checks on all versions of a scanned item. Returns versions that remain
@@ -1128,14 +1147,9 @@ func applyExpiryOnNonTransitionedObjects(ctx context.Context, objLayer ObjectLay
 	// Send audit for the lifecycle delete operation
 	auditLogLifecycle(ctx, obj, ILMExpiry)
 
-	eventName := event.ObjectRemovedDelete
-	if obj.DeleteMarker {
-		eventName = event.ObjectRemovedDeleteMarkerCreated
-	}
-
 	// Notify object deleted event.
 	sendEvent(eventArgs{
-		EventName:  eventName,
+		EventName:  ilmExpiryEventName(obj.DeleteMarker),
 		BucketName: obj.Bucket,
 		Object:     obj,
 		Host:       "Internal: [ILM-EXPIRY]",
@@ -1394,6 +1408,8 @@ const (
 	ILMFreeVersionDelete = "ilm:free-version-delete"
 	// ILMTransition - audit trail for ILM transitioning.
 	ILMTransition = " ilm:transition"
+	// ILMExpiryVetoed - audit trail for ILM expiry vetoed by the approval webhook
+	ILMExpiryVetoed = "ilm:expiry-vetoed"
 )
 
 func auditLogLifecycle(ctx context.Context, oi ObjectInfo, trigger string) {
@@ -1405,6 +1421,8 @@ func auditLogLifecycle(ctx context.Context, oi ObjectInfo, trigger string) {
 		apiName = "ILMFreeVersionDelete"
 	case ILMTransition:
 		apiName = "ILMTransition"
+	case ILMExpiryVetoed:
+		apiName = "ILMExpiryVetoed"
 	}
 	auditLogInternal(ctx, oi.Bucket, oi.Name, AuditLogOptions{
 		Trigger:   trigger,
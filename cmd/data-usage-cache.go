@@ -799,6 +799,9 @@ func (d *dataUsageCache) bucketsUsageInfo(buckets []BucketInfo) map[string]Bucke
 				}
 			}
 		}
+		if flat.AllTierStats != nil {
+			bui.TierStats = flat.AllTierStats.adminStats(make(map[string]madmin.TierStats, len(flat.AllTierStats.Tiers)))
+		}
 		dst[bucket.Name] = bui
 	}
 	return dst
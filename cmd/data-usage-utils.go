@@ -62,6 +62,9 @@ type BucketUsageInfo struct {
 	ObjectSizesHistogram map[string]uint64                `json:"objectsSizesHistogram"`
 	ReplicaSize          uint64                           `json:"objectReplicaTotalSize"`
 	ReplicationInfo      map[string]BucketTargetUsageInfo `json:"objectsReplicationInfo"`
+	// TierStats contains the usage of the bucket per tier, including
+	// the hot tier.
+	TierStats map[string]madmin.TierStats `json:"tierStats,omitempty"`
 }
 
 // DataUsageInfo represents data usage stats of the underlying Object API
@@ -0,0 +1,384 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/event/target"
+	"github.com/minio/minio/internal/kms"
+)
+
+// Types of probed dependencies, notification targets are reported by
+// the name of their sub-system.
+const (
+	dependencyOpenID      = "openid"
+	dependencyLDAP        = "ldap"
+	dependencyKMS         = "kms"
+	dependencyTier        = "tier"
+	dependencyReplication = "replication"
+)
+
+var tlsVersionNames = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+// dependencyTLS - the TLS connection to a dependency and the
+// certificate it presented.
+type dependencyTLS struct {
+	Version     string    `json:"version,omitempty"`
+	CipherSuite string    `json:"cipherSuite,omitempty"`
+	Subject     string    `json:"subject,omitempty"`
+	Issuer      string    `json:"issuer,omitempty"`
+	DNSNames    []string  `json:"dnsNames,omitempty"`
+	NotBefore   time.Time `json:"notBefore,omitempty"`
+	NotAfter    time.Time `json:"notAfter,omitempty"`
+	// VerifyError is set if the certificate is not trusted by this
+	// server, Error if the handshake failed.
+	VerifyError string `json:"verifyError,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// dependencyProbe - the outcome of probing an external dependency.
+type dependencyProbe struct {
+	Type     string         `json:"type"`
+	Name     string         `json:"name,omitempty"`
+	Endpoint string         `json:"endpoint,omitempty"`
+	TLS      *dependencyTLS `json:"tls,omitempty"`
+	deepHealthCheck
+}
+
+func newDependencyTLS(state tls.ConnectionState) *dependencyTLS {
+	info := &dependencyTLS{
+		Version:     tlsVersionNames[state.Version],
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+	}
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		info.Subject = cert.Subject.String()
+		info.Issuer = cert.Issuer.String()
+		info.DNSNames = cert.DNSNames
+		info.NotBefore = cert.NotBefore
+		info.NotAfter = cert.NotAfter
+	}
+	return info
+}
+
+// probeTLS performs a TLS handshake with addr and verifies the
+// presented certificate against the root CAs of this server. The
+// details are reported even if the certificate is not trusted.
+func probeTLS(ctx context.Context, addr string) *dependencyTLS {
+	ctx, cancel := context.WithTimeout(ctx, deepHealthCheckTimeout)
+	defer cancel()
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return &dependencyTLS{Error: err.Error()}
+	}
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			ServerName: host,
+			// The certificate is verified below, so that it can be
+			// reported when it is not trusted.
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return &dependencyTLS{Error: err.Error()}
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	info := newDependencyTLS(state)
+	if len(state.PeerCertificates) == 0 {
+		info.VerifyError = "no certificate presented"
+		return info
+	}
+	opts := x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         globalRootCAs,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err = state.PeerCertificates[0].Verify(opts); err != nil {
+		info.VerifyError = err.Error()
+	}
+	return info
+}
+
+// tlsAddr returns the address of an HTTPS endpoint, with the default
+// port if none is set, or an empty string for other endpoints.
+func tlsAddr(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return ""
+	}
+	if u.Port() == "" {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+	return u.Host
+}
+
+// probeDependency runs the check fn of a dependency, after probing the
+// TLS connection to addr unless it is empty.
+func probeDependency(ctx context.Context, probe dependencyProbe, addr string, fn func(ctx context.Context) error) dependencyProbe {
+	if addr != "" {
+		probe.TLS = probeTLS(ctx, addr)
+	}
+	probe.deepHealthCheck = checkDependency(ctx, fn)
+	return probe
+}
+
+func probeIdentity(ctx context.Context) []dependencyProbe {
+	var probes []dependencyProbe
+	if globalOpenIDConfig.Enabled {
+		probe := dependencyProbe{Type: dependencyOpenID}
+		var addr string
+		if globalOpenIDConfig.JWKS.URL != nil {
+			probe.Endpoint = globalOpenIDConfig.JWKS.URL.String()
+			addr = tlsAddr(probe.Endpoint)
+		}
+		probes = append(probes, probeDependency(ctx, probe, addr, globalOpenIDConfig.TestConnection))
+	}
+	if globalLDAPConfig.Enabled {
+		probe := dependencyProbe{
+			Type:     dependencyLDAP,
+			Endpoint: globalLDAPConfig.ServerAddr,
+		}
+		// The LDAP client establishes TLS itself, also with StartTLS.
+		if state, ok, err := globalLDAPConfig.TLSConnectionState(); err != nil {
+			probe.TLS = &dependencyTLS{Error: err.Error()}
+		} else if ok {
+			probe.TLS = newDependencyTLS(state)
+		}
+		probes = append(probes, probeDependency(ctx, probe, "", func(ctx context.Context) error {
+			return globalLDAPConfig.TestConnection()
+		}))
+	}
+	return probes
+}
+
+// probeKMS checks the status of the KMS and the TLS connection to
+// each of its endpoints.
+func probeKMS(ctx context.Context) []dependencyProbe {
+	if GlobalKMS == nil {
+		return nil
+	}
+	var status kms.Status
+	probe := probeDependency(ctx, dependencyProbe{Type: dependencyKMS}, "", func(ctx context.Context) (err error) {
+		status, err = GlobalKMS.Stat()
+		return err
+	})
+	probe.Name = status.Name
+	probes := []dependencyProbe{probe}
+	for _, endpoint := range status.Endpoints {
+		probe := dependencyProbe{
+			Type:     dependencyKMS,
+			Name:     status.Name,
+			Endpoint: endpoint,
+		}
+		addr := tlsAddr(endpoint)
+		probe.deepHealthCheck = checkDependency(ctx, func(ctx context.Context) error {
+			if addr == "" {
+				return fmt.Errorf("unsupported endpoint %s", endpoint)
+			}
+			if probe.TLS = probeTLS(ctx, addr); probe.TLS.Error != "" {
+				return errors.New(probe.TLS.Error)
+			}
+			return nil
+		})
+		probes = append(probes, probe)
+	}
+	return probes
+}
+
+// notificationTargetTLSAddr returns the address of the TLS connection
+// to a notification target, for the targets which report one.
+func notificationTargetTLSAddr(subSys string, kvs config.KVS) string {
+	switch subSys {
+	case config.NotifyWebhookSubSys:
+		return tlsAddr(kvs.Get(target.WebhookEndpoint))
+	case config.NotifyESSubSys:
+		return tlsAddr(kvs.Get(target.ElasticURL))
+	case config.NotifyKafkaSubSys:
+		if kvs.Get(target.KafkaTLS) != config.EnableOn {
+			return ""
+		}
+		return strings.TrimSpace(strings.Split(kvs.Get(target.KafkaBrokers), ",")[0])
+	}
+	return ""
+}
+
+func notificationTargetEndpoint(subSys string, kvs config.KVS) string {
+	switch subSys {
+	case config.NotifyWebhookSubSys:
+		return kvs.Get(target.WebhookEndpoint)
+	case config.NotifyESSubSys:
+		return kvs.Get(target.ElasticURL)
+	case config.NotifyKafkaSubSys:
+		return kvs.Get(target.KafkaBrokers)
+	}
+	return ""
+}
+
+func probeNotificationTargets(ctx context.Context) []dependencyProbe {
+	cfg := globalServerConfig.Clone()
+	targets := enabledNotificationTargets(cfg)
+	probes := make([]dependencyProbe, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t notificationTarget) {
+			defer wg.Done()
+			kvs := cfg[t.subSys][t.name]
+			probe := dependencyProbe{
+				Type:     t.subSys,
+				Name:     t.name,
+				Endpoint: notificationTargetEndpoint(t.subSys, kvs),
+			}
+			probes[i] = probeDependency(ctx, probe, notificationTargetTLSAddr(t.subSys, kvs), func(ctx context.Context) error {
+				return testNotificationTarget(ctx, cfg, t)
+			})
+		}(i, t)
+	}
+	wg.Wait()
+	return probes
+}
+
+func probeTiers(ctx context.Context) []dependencyProbe {
+	if globalTierConfigMgr == nil {
+		return nil
+	}
+	names := globalTierConfigMgr.tierNames()
+	probes := make([]dependencyProbe, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			probe := dependencyProbe{Type: dependencyTier, Name: name}
+			if tier, ok := globalTierConfigMgr.getTier(name); ok {
+				switch {
+				case tier.S3 != nil:
+					probe.Endpoint = tier.S3.Endpoint
+				case tier.Azure != nil:
+					probe.Endpoint = tier.Azure.Endpoint
+				case tier.GCS != nil:
+					probe.Endpoint = tier.GCS.Endpoint
+				}
+			}
+			probes[i] = probeDependency(ctx, probe, tlsAddr(probe.Endpoint), func(ctx context.Context) error {
+				d, err := globalTierConfigMgr.getDriver(name)
+				if err != nil {
+					return err
+				}
+				_, err = d.InUse(ctx)
+				return err
+			})
+		}(i, name)
+	}
+	wg.Wait()
+	return probes
+}
+
+func probeReplicationTargets(ctx context.Context) []dependencyProbe {
+	if globalBucketTargetSys == nil {
+		return nil
+	}
+	targets := globalBucketTargetSys.ListTargets(ctx, "", "")
+	probes := make([]dependencyProbe, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, arn, endpoint string, secure bool) {
+			defer wg.Done()
+			probe := dependencyProbe{
+				Type:     dependencyReplication,
+				Name:     arn,
+				Endpoint: endpoint,
+			}
+			var addr string
+			if secure {
+				addr = tlsAddr("https://" + endpoint)
+			}
+			probes[i] = probeDependency(ctx, probe, addr, func(ctx context.Context) error {
+				tc := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+				if tc == nil {
+					return errors.New("remote target is not initialized")
+				}
+				if tc.native != nil {
+					// Native targets have no S3 client, the TLS
+					// probe is the only check.
+					return nil
+				}
+				ok, err := tc.BucketExists(ctx, tc.Bucket)
+				if err == nil && !ok {
+					err = fmt.Errorf("remote bucket %s does not exist", tc.Bucket)
+				}
+				return err
+			})
+		}(i, t.Arn, t.Endpoint, t.Secure)
+	}
+	wg.Wait()
+	return probes
+}
+
+// probeDependencies probes all external dependencies configured on
+// this server, in the order identity providers, KMS, notification
+// targets, tiers and replication targets.
+func probeDependencies(ctx context.Context) []dependencyProbe {
+	probeFns := []func(ctx context.Context) []dependencyProbe{
+		probeIdentity,
+		probeKMS,
+		probeNotificationTargets,
+		probeTiers,
+		probeReplicationTargets,
+	}
+	results := make([][]dependencyProbe, len(probeFns))
+	var wg sync.WaitGroup
+	for i, fn := range probeFns {
+		wg.Add(1)
+		go func(i int, fn func(ctx context.Context) []dependencyProbe) {
+			defer wg.Done()
+			results[i] = fn(ctx)
+		}(i, fn)
+	}
+	wg.Wait()
+
+	probes := []dependencyProbe{}
+	for _, result := range results {
+		probes = append(probes, result...)
+	}
+	return probes
+}
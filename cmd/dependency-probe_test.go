@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTLSAddr(t *testing.T) {
+	testCases := []struct {
+		endpoint string
+		addr     string
+	}{
+		{"https://example.com", "example.com:443"},
+		{"https://example.com:9000/path", "example.com:9000"},
+		{"http://example.com", ""},
+		{"example.com:9000", ""},
+		{"", ""},
+	}
+	for _, testCase := range testCases {
+		if addr := tlsAddr(testCase.endpoint); addr != testCase.addr {
+			t.Errorf("%q: expected %q, got %q", testCase.endpoint, testCase.addr, addr)
+		}
+	}
+}
+
+func TestProbeTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	info := probeTLS(context.Background(), strings.TrimPrefix(server.URL, "https://"))
+	if info.Error != "" {
+		t.Fatalf("unexpected handshake error %s", info.Error)
+	}
+	if info.Version == "" || info.CipherSuite == "" || info.NotAfter.IsZero() {
+		t.Errorf("expected connection details, got %+v", info)
+	}
+	// The test certificate is not signed by a trusted CA.
+	if info.VerifyError == "" {
+		t.Errorf("expected the certificate to be untrusted")
+	}
+
+	server.Close()
+	if info = probeTLS(context.Background(), strings.TrimPrefix(server.URL, "https://")); info.Error == "" {
+		t.Errorf("expected a handshake error with a closed server")
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/smart"
+)
+
+// The health of the local drives is sampled periodically from SMART and
+// the kernel I/O error counters. A health event is raised whenever a
+// counter indicating failing media grows between two samples, so that
+// drives can be replaced before they fail while healing.
+const (
+	// driveHealthSamples is the number of samples kept per drive, a day
+	// at the default interval.
+	driveHealthSamples = 144
+	// driveHealthEvents is the number of most recent events kept.
+	driveHealthEvents = 100
+)
+
+var globalDriveHealth = newDriveHealthMonitor()
+
+// getDriveHealth reads the health counters of the drive of a path.
+var getDriveHealth = smart.GetHealth
+
+// driveHealthEvent is raised when a drive shows signs of failing media.
+type driveHealthEvent struct {
+	Time    time.Time `json:"time"`
+	Drive   string    `json:"drive"`
+	Device  string    `json:"device"`
+	Counter string    `json:"counter"`
+	// Previous is the value of the counter in the previous sample and
+	// Oldest its value in the oldest sample kept.
+	Previous uint64 `json:"previous"`
+	Oldest   uint64 `json:"oldest"`
+	Current  uint64 `json:"current"`
+	Message  string `json:"message"`
+}
+
+// driveHealthInfo is the latest health sample of a drive.
+type driveHealthInfo struct {
+	Drive  string       `json:"drive"`
+	Time   time.Time    `json:"time"`
+	Health smart.Health `json:"health"`
+	// Events is the number of health events raised for the drive
+	// since server start.
+	Events uint64 `json:"events"`
+	Error  string `json:"error,omitempty"`
+}
+
+// driveHealthStatus is the drive health of a node.
+type driveHealthStatus struct {
+	Node   string             `json:"node"`
+	Drives []driveHealthInfo  `json:"drives,omitempty"`
+	Events []driveHealthEvent `json:"events,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+type driveHealthSample struct {
+	time   time.Time
+	health smart.Health
+}
+
+type driveHealthState struct {
+	samples []driveHealthSample
+	events  uint64
+	err     string
+}
+
+type driveHealthMonitor struct {
+	interval time.Duration
+
+	mu     sync.Mutex
+	drives map[string]*driveHealthState
+	events []driveHealthEvent
+}
+
+func newDriveHealthMonitor() *driveHealthMonitor {
+	return &driveHealthMonitor{
+		interval: 10 * time.Minute,
+		drives:   make(map[string]*driveHealthState),
+	}
+}
+
+func initDriveHealthMonitor(ctx context.Context) {
+	if globalDriveHealth.interval > 0 {
+		go globalDriveHealth.run(ctx)
+	}
+}
+
+func (m *driveHealthMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.sample(ctx, localDrivePaths())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// localDrivePaths returns the paths of the drives of this node.
+func localDrivePaths() (drives []string) {
+	for _, ep := range globalEndpoints {
+		for _, endpoint := range ep.Endpoints {
+			if endpoint.IsLocal {
+				drives = append(drives, endpoint.Path)
+			}
+		}
+	}
+	return drives
+}
+
+// healthCounters are the counters of a sample which indicate failing
+// media, a growing value raises an event.
+func healthCounters(h smart.Health) []struct {
+	name  string
+	value uint64
+} {
+	return []struct {
+		name  string
+		value uint64
+	}{
+		{"reallocatedSectors", h.ReallocatedSectors},
+		{"pendingSectors", h.PendingSectors},
+		{"uncorrectableSectors", h.UncorrectableSectors},
+		{"mediaErrors", h.MediaErrors},
+		{"ioErrors", h.IOErrors},
+	}
+}
+
+// sample reads the health of the drives and raises events for counters
+// which grew since the previous sample.
+func (m *driveHealthMonitor) sample(ctx context.Context, drives []string) {
+	now := time.Now().UTC()
+	for _, drive := range drives {
+		health, err := getDriveHealth(drive)
+
+		m.mu.Lock()
+		state, ok := m.drives[drive]
+		if !ok {
+			state = &driveHealthState{}
+			m.drives[drive] = state
+		}
+		if err != nil {
+			state.err = err.Error()
+			m.mu.Unlock()
+			continue
+		}
+		state.err = ""
+
+		var events []driveHealthEvent
+		if n := len(state.samples); n > 0 {
+			prev, oldest := state.samples[n-1].health, state.samples[0].health
+			prevCounters, oldestCounters := healthCounters(prev), healthCounters(oldest)
+			for i, c := range healthCounters(health) {
+				if c.value <= prevCounters[i].value {
+					continue
+				}
+				events = append(events, driveHealthEvent{
+					Time:     now,
+					Drive:    drive,
+					Device:   health.Device,
+					Counter:  c.name,
+					Previous: prevCounters[i].value,
+					Oldest:   oldestCounters[i].value,
+					Current:  c.value,
+					Message: fmt.Sprintf("%s of drive %s (%s) increased from %d to %d, %d since %s",
+						c.name, drive, health.Device, prevCounters[i].value, c.value,
+						c.value-oldestCounters[i].value, state.samples[0].time.Format(time.RFC3339)),
+				})
+			}
+			if health.CriticalWarning != 0 && prev.CriticalWarning == 0 {
+				events = append(events, driveHealthEvent{
+					Time:    now,
+					Drive:   drive,
+					Device:  health.Device,
+					Counter: "criticalWarning",
+					Current: uint64(health.CriticalWarning),
+					Message: fmt.Sprintf("drive %s (%s) reports critical warning %#x", drive, health.Device, health.CriticalWarning),
+				})
+			}
+			if health.SpareLow() && !prev.SpareLow() {
+				events = append(events, driveHealthEvent{
+					Time:     now,
+					Drive:    drive,
+					Device:   health.Device,
+					Counter:  "spareAvailable",
+					Previous: uint64(prev.SpareAvailable),
+					Current:  uint64(health.SpareAvailable),
+					Message: fmt.Sprintf("available spare of drive %s (%s) is %d%%, below the threshold of %d%%",
+						drive, health.Device, health.SpareAvailable, health.SpareThreshold),
+				})
+			}
+		}
+
+		state.samples = append(state.samples, driveHealthSample{time: now, health: health})
+		if len(state.samples) > driveHealthSamples {
+			state.samples = state.samples[len(state.samples)-driveHealthSamples:]
+		}
+		state.events += uint64(len(events))
+		m.events = append(m.events, events...)
+		if len(m.events) > driveHealthEvents {
+			m.events = m.events[len(m.events)-driveHealthEvents:]
+		}
+		m.mu.Unlock()
+
+		for _, ev := range events {
+			logger.LogIf(ctx, fmt.Errorf("drive health: %s", ev.Message), logger.Application)
+		}
+	}
+}
+
+// Status returns the latest sample of every drive and the most recent
+// events, oldest first.
+func (m *driveHealthMonitor) Status() driveHealthStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status := driveHealthStatus{
+		Node:   globalLocalNodeName,
+		Events: append([]driveHealthEvent{}, m.events...),
+	}
+	for drive, state := range m.drives {
+		info := driveHealthInfo{Drive: drive, Events: state.events, Error: state.err}
+		if n := len(state.samples); n > 0 {
+			info.Time = state.samples[n-1].time
+			info.Health = state.samples[n-1].health
+		}
+		status.Drives = append(status.Drives, info)
+	}
+	sort.Slice(status.Drives, func(i, j int) bool {
+		return status.Drives[i].Drive < status.Drives[j].Drive
+	})
+	return status
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/minio/minio/internal/smart"
+)
+
+func TestDriveHealthMonitor(t *testing.T) {
+	health := map[string]smart.Health{
+		"/mnt/drive1": {Device: "/dev/sda", ReallocatedSectors: 8},
+		"/mnt/drive2": {Device: "/dev/nvme0n1", SpareAvailable: 100, SpareThreshold: 10},
+	}
+	defer func(f func(string) (smart.Health, error)) { getDriveHealth = f }(getDriveHealth)
+	getDriveHealth = func(drive string) (smart.Health, error) {
+		h, ok := health[drive]
+		if !ok {
+			return h, errors.New("no such drive")
+		}
+		return h, nil
+	}
+
+	ctx := context.Background()
+	drives := []string{"/mnt/drive1", "/mnt/drive2", "/mnt/drive3"}
+	m := newDriveHealthMonitor()
+
+	// The first sample is the baseline and raises no events.
+	m.sample(ctx, drives)
+	if status := m.Status(); len(status.Events) != 0 {
+		t.Fatalf("expected no events, got %v", status.Events)
+	}
+
+	// Unchanged counters raise no events.
+	m.sample(ctx, drives)
+	if status := m.Status(); len(status.Events) != 0 {
+		t.Fatalf("expected no events, got %v", status.Events)
+	}
+
+	health["/mnt/drive1"] = smart.Health{Device: "/dev/sda", ReallocatedSectors: 24, IOErrors: 2}
+	health["/mnt/drive2"] = smart.Health{Device: "/dev/nvme0n1", SpareAvailable: 5, SpareThreshold: 10, CriticalWarning: 1}
+	m.sample(ctx, drives)
+
+	status := m.Status()
+	counters := make(map[string]driveHealthEvent)
+	for _, ev := range status.Events {
+		counters[ev.Drive+":"+ev.Counter] = ev
+	}
+	if len(counters) != 4 {
+		t.Fatalf("expected 4 events, got %v", status.Events)
+	}
+	if ev := counters["/mnt/drive1:reallocatedSectors"]; ev.Previous != 8 || ev.Oldest != 8 || ev.Current != 24 {
+		t.Errorf("unexpected reallocated sectors event %+v", ev)
+	}
+	for _, key := range []string{"/mnt/drive1:ioErrors", "/mnt/drive2:criticalWarning", "/mnt/drive2:spareAvailable"} {
+		if _, ok := counters[key]; !ok {
+			t.Errorf("expected event %s", key)
+		}
+	}
+
+	if len(status.Drives) != 3 {
+		t.Fatalf("expected 3 drives, got %d", len(status.Drives))
+	}
+	for _, drive := range status.Drives {
+		switch drive.Drive {
+		case "/mnt/drive1", "/mnt/drive2":
+			if drive.Events != 2 || drive.Health.Device == "" {
+				t.Errorf("unexpected status of %s: %+v", drive.Drive, drive)
+			}
+		case "/mnt/drive3":
+			if drive.Error == "" || !drive.Time.IsZero() {
+				t.Errorf("expected error for %s: %+v", drive.Drive, drive)
+			}
+		}
+	}
+
+	// Events are raised once and not repeated by the next sample.
+	m.sample(ctx, drives)
+	if n := len(m.Status().Events); n != 4 {
+		t.Errorf("expected 4 events, got %d", n)
+	}
+}
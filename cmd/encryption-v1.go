@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
@@ -46,6 +47,7 @@ var (
 	errEncryptedObject      = errors.New("The object was stored using a form of SSE")
 	errInvalidSSEParameters = errors.New("The SSE-C key for key-rotation is not correct") // special access denied
 	errKMSNotConfigured     = errors.New("KMS not configured for a server side encrypted object")
+	errKMSKeyNotAllowed     = errors.New("KMS key has been imported for other buckets")
 	// Additional MinIO errors for SSE-C requests.
 	errObjectTampered = errors.New("The requested object was modified and may be compromised")
 	// error returned when invalid encryption parameters are specified
@@ -137,18 +139,19 @@ func ParseSSECustomerHeader(header http.Header) (key []byte, err error) {
 }
 
 // This function rotates old to new key.
-func rotateKey(oldKey []byte, newKeyID string, newKey []byte, bucket, object string, metadata map[string]string, ctx kms.Context) error {
+func rotateKey(ctx context.Context, oldKey []byte, newKeyID string, newKey []byte, bucket, object string, metadata map[string]string, cryptoCtx kms.Context) error {
+	KMS := kmsForRequest(ctx)
 	kind, _ := crypto.IsEncrypted(metadata)
 	switch kind {
 	case crypto.S3:
-		if GlobalKMS == nil {
+		if KMS == nil {
 			return errKMSNotConfigured
 		}
 		keyID, kmsKey, sealedKey, err := crypto.S3.ParseMetadata(metadata)
 		if err != nil {
 			return err
 		}
-		oldKey, err := GlobalKMS.DecryptKey(keyID, kmsKey, kms.Context{bucket: path.Join(bucket, object)})
+		oldKey, err := KMS.DecryptKey(keyID, kmsKey, kms.Context{bucket: path.Join(bucket, object)})
 		if err != nil {
 			return err
 		}
@@ -157,7 +160,7 @@ func rotateKey(oldKey []byte, newKeyID string, newKey []byte, bucket, object str
 			return err
 		}
 
-		newKey, err := GlobalKMS.GenerateKey("", kms.Context{bucket: path.Join(bucket, object)})
+		newKey, err := KMS.GenerateKey("", kms.Context{bucket: path.Join(bucket, object)})
 		if err != nil {
 			return err
 		}
@@ -165,16 +168,16 @@ func rotateKey(oldKey []byte, newKeyID string, newKey []byte, bucket, object str
 		crypto.S3.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey)
 		return nil
 	case crypto.S3KMS:
-		if GlobalKMS == nil {
+		if KMS == nil {
 			return errKMSNotConfigured
 		}
-		objectKey, err := crypto.S3KMS.UnsealObjectKey(GlobalKMS, metadata, bucket, object)
+		objectKey, err := crypto.S3KMS.UnsealObjectKey(KMS, metadata, bucket, object)
 		if err != nil {
 			return err
 		}
 
-		if len(ctx) == 0 {
-			_, _, _, ctx, err = crypto.S3KMS.ParseMetadata(metadata)
+		if len(cryptoCtx) == 0 {
+			_, _, _, cryptoCtx, err = crypto.S3KMS.ParseMetadata(metadata)
 			if err != nil {
 				return err
 			}
@@ -187,19 +190,27 @@ func rotateKey(oldKey []byte, newKeyID string, newKey []byte, bucket, object str
 		// of the client provided context and add the bucket
 		// key, if not present.
 		kmsCtx := kms.Context{}
-		for k, v := range ctx {
+		for k, v := range cryptoCtx {
 			kmsCtx[k] = v
 		}
 		if _, ok := kmsCtx[bucket]; !ok {
 			kmsCtx[bucket] = path.Join(bucket, object)
 		}
-		newKey, err := GlobalKMS.GenerateKey(newKeyID, kmsCtx)
+		if !globalKMSImportedKeysSys.IsAllowed(newKeyID, bucket) {
+			return errKMSKeyNotAllowed
+		}
+		newKey, err := KMS.GenerateKey(newKeyID, kmsCtx)
 		if err != nil {
 			return err
 		}
+		if crypto.S3KMS.IsDualLayer(metadata) {
+			if err = crypto.S3KMS.RotateDualLayerKey(KMS, newKey.KeyID, kmsCtx, bucket, object, metadata); err != nil {
+				return err
+			}
+		}
 
 		sealedKey := objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), bucket, object)
-		crypto.S3KMS.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey, ctx)
+		crypto.S3KMS.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey, cryptoCtx)
 		return nil
 	case crypto.SSEC:
 		sealedKey, err := crypto.SSEC.ParseMetadata(metadata)
@@ -226,14 +237,15 @@ func rotateKey(oldKey []byte, newKeyID string, newKey []byte, bucket, object str
 	}
 }
 
-func newEncryptMetadata(kind crypto.Type, keyID string, key []byte, bucket, object string, metadata map[string]string, ctx kms.Context) (crypto.ObjectKey, error) {
+func newEncryptMetadata(ctx context.Context, kind crypto.Type, keyID string, key []byte, bucket, object string, metadata map[string]string, cryptoCtx kms.Context) (crypto.ObjectKey, error) {
+	KMS := kmsForRequest(ctx)
 	var sealedKey crypto.SealedKey
 	switch kind {
 	case crypto.S3:
-		if GlobalKMS == nil {
+		if KMS == nil {
 			return crypto.ObjectKey{}, errKMSNotConfigured
 		}
-		key, err := GlobalKMS.GenerateKey("", kms.Context{bucket: path.Join(bucket, object)})
+		key, err := KMS.GenerateKey("", kms.Context{bucket: path.Join(bucket, object)})
 		if err != nil {
 			return crypto.ObjectKey{}, err
 		}
@@ -243,7 +255,7 @@ func newEncryptMetadata(kind crypto.Type, keyID string, key []byte, bucket, obje
 		crypto.S3.CreateMetadata(metadata, key.KeyID, key.Ciphertext, sealedKey)
 		return objectKey, nil
 	case crypto.S3KMS:
-		if GlobalKMS == nil {
+		if KMS == nil {
 			return crypto.ObjectKey{}, errKMSNotConfigured
 		}
 
@@ -254,20 +266,28 @@ func newEncryptMetadata(kind crypto.Type, keyID string, key []byte, bucket, obje
 		// of the client provided context and add the bucket
 		// key, if not present.
 		kmsCtx := kms.Context{}
-		for k, v := range ctx {
+		for k, v := range cryptoCtx {
 			kmsCtx[k] = v
 		}
 		if _, ok := kmsCtx[bucket]; !ok {
 			kmsCtx[bucket] = path.Join(bucket, object)
 		}
-		key, err := GlobalKMS.GenerateKey(keyID, kmsCtx)
+		if !globalKMSImportedKeysSys.IsAllowed(keyID, bucket) {
+			return crypto.ObjectKey{}, errKMSKeyNotAllowed
+		}
+		key, err := KMS.GenerateKey(keyID, kmsCtx)
 		if err != nil {
 			return crypto.ObjectKey{}, err
 		}
+		if crypto.S3KMS.IsDualLayer(metadata) {
+			if _, err = crypto.S3KMS.CreateDualLayerKey(KMS, key.KeyID, kmsCtx, bucket, object, metadata); err != nil {
+				return crypto.ObjectKey{}, err
+			}
+		}
 
 		objectKey := crypto.GenerateKey(key.Plaintext, rand.Reader)
 		sealedKey = objectKey.Seal(key.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), bucket, object)
-		crypto.S3KMS.CreateMetadata(metadata, key.KeyID, key.Ciphertext, sealedKey, ctx)
+		crypto.S3KMS.CreateMetadata(metadata, key.KeyID, key.Ciphertext, sealedKey, cryptoCtx)
 		return objectKey, nil
 	case crypto.SSEC:
 		objectKey := crypto.GenerateKey(key, rand.Reader)
@@ -279,20 +299,42 @@ func newEncryptMetadata(kind crypto.Type, keyID string, key []byte, bucket, obje
 	}
 }
 
-func newEncryptReader(content io.Reader, kind crypto.Type, keyID string, key []byte, bucket, object string, metadata map[string]string, ctx kms.Context) (io.Reader, crypto.ObjectKey, error) {
-	objectEncryptionKey, err := newEncryptMetadata(kind, keyID, key, bucket, object, metadata, ctx)
+func newEncryptReader(ctx context.Context, content io.Reader, kind crypto.Type, keyID string, key []byte, bucket, object string, metadata map[string]string, cryptoCtx kms.Context) (io.Reader, crypto.ObjectKey, error) {
+	objectEncryptionKey, err := newEncryptMetadata(ctx, kind, keyID, key, bucket, object, metadata, cryptoCtx)
 	if err != nil {
 		return nil, crypto.ObjectKey{}, err
 	}
 
-	reader, err := sio.EncryptReader(content, sio.Config{Key: objectEncryptionKey[:], MinVersion: sio.Version20, CipherSuites: fips.CipherSuitesDARE()})
+	reader, err := sio.EncryptReader(content, sio.Config{Key: objectEncryptionKey[:], MinVersion: sio.Version20, CipherSuites: contentCipherSuites(bucket)})
 	if err != nil {
 		return nil, crypto.ObjectKey{}, crypto.ErrInvalidCustomerKey
 	}
+	if reader, err = newDualLayerReader(ctx, reader, bucket, object, 0, 0, metadata); err != nil {
+		return nil, crypto.ObjectKey{}, err
+	}
 
 	return reader, objectEncryptionKey, nil
 }
 
+// newDualLayerReader adds or removes the second encryption layer of
+// DSSE-KMS objects to or from the encrypted content r of the object, or
+// of its part partID, starting at offset. The content of other objects
+// is returned as is.
+func newDualLayerReader(ctx context.Context, r io.Reader, bucket, object string, partID uint32, offset int64, metadata map[string]string) (io.Reader, error) {
+	if !crypto.S3KMS.IsDualLayer(metadata) {
+		return r, nil
+	}
+	KMS := kmsForRequest(ctx)
+	if KMS == nil {
+		return nil, errKMSNotConfigured
+	}
+	key, err := crypto.S3KMS.UnsealDualLayerKey(KMS, metadata, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.DualLayerReader(key, partID, r, offset)
+}
+
 // set new encryption metadata from http request headers for SSE-C and generated key from KMS in the case of
 // SSE-S3
 func setEncryptionMetadata(r *http.Request, bucket, object string, metadata map[string]string) (err error) {
@@ -313,8 +355,11 @@ func setEncryptionMetadata(r *http.Request, bucket, object string, metadata map[
 		if err != nil {
 			return err
 		}
+		if crypto.S3KMS.IsDualLayerRequested(r.Header) {
+			metadata[crypto.MetaDualLayer] = "true"
+		}
 	}
-	_, err = newEncryptMetadata(kind, keyID, key, bucket, object, metadata, ctx)
+	_, err = newEncryptMetadata(r.Context(), kind, keyID, key, bucket, object, metadata, ctx)
 	return
 }
 
@@ -346,14 +391,17 @@ func EncryptRequest(content io.Reader, r *http.Request, bucket, object string, m
 		if err != nil {
 			return nil, crypto.ObjectKey{}, err
 		}
+		if crypto.S3KMS.IsDualLayerRequested(r.Header) {
+			metadata[crypto.MetaDualLayer] = "true"
+		}
 	}
-	return newEncryptReader(content, kind, keyID, key, bucket, object, metadata, ctx)
+	return newEncryptReader(r.Context(), content, kind, keyID, key, bucket, object, metadata, ctx)
 }
 
-func decryptObjectInfo(key []byte, bucket, object string, metadata map[string]string) ([]byte, error) {
+func decryptObjectInfo(ctx context.Context, key []byte, bucket, object string, metadata map[string]string) ([]byte, error) {
 	switch kind, _ := crypto.IsEncrypted(metadata); kind {
 	case crypto.S3:
-		var KMS kms.KMS = GlobalKMS
+		KMS := kmsForRequest(ctx)
 		if isCacheEncrypted(metadata) {
 			KMS = globalCacheKMS
 		}
@@ -366,10 +414,11 @@ func decryptObjectInfo(key []byte, bucket, object string, metadata map[string]st
 		}
 		return objectKey[:], nil
 	case crypto.S3KMS:
-		if GlobalKMS == nil {
+		KMS := kmsForRequest(ctx)
+		if KMS == nil {
 			return nil, errKMSNotConfigured
 		}
-		objectKey, err := crypto.S3KMS.UnsealObjectKey(GlobalKMS, metadata, bucket, object)
+		objectKey, err := crypto.S3KMS.UnsealObjectKey(KMS, metadata, bucket, object)
 		if err != nil {
 			return nil, err
 		}
@@ -421,10 +470,14 @@ func DecryptCopyRequestR(client io.Reader, h http.Header, bucket, object string,
 }
 
 func newDecryptReader(client io.Reader, key []byte, bucket, object string, seqNumber uint32, metadata map[string]string) (io.Reader, error) {
-	objectEncryptionKey, err := decryptObjectInfo(key, bucket, object, metadata)
+	objectEncryptionKey, err := decryptObjectInfo(GlobalContext, key, bucket, object, metadata)
 	if err != nil {
 		return nil, err
 	}
+	offset := int64(seqNumber) * (SSEDAREPackageBlockSize + SSEDAREPackageMetaSize)
+	if client, err = newDualLayerReader(GlobalContext, client, bucket, object, 0, offset, metadata); err != nil {
+		return nil, err
+	}
 	return newDecryptReaderWithObjectKey(client, objectEncryptionKey, seqNumber)
 }
 
@@ -533,7 +586,7 @@ func (d *DecryptBlocksReader) buildDecrypter(partID int) error {
 		return err
 	}
 
-	objectEncryptionKey, err := decryptObjectInfo(key, d.bucket, d.object, m)
+	objectEncryptionKey, err := decryptObjectInfo(GlobalContext, key, d.bucket, d.object, m)
 	if err != nil {
 		return err
 	}
@@ -548,7 +601,11 @@ func (d *DecryptBlocksReader) buildDecrypter(partID int) error {
 	// Limit the reader, so the decryptor doesnt receive bytes
 	// from the next part (different DARE stream)
 	encLenToRead := d.parts[d.partIndex].Size - d.partEncRelOffset
-	decrypter, err := newDecryptReaderWithObjectKey(io.LimitReader(d.reader, encLenToRead), partEncryptionKey, d.startSeqNum)
+	partReader, err := newDualLayerReader(GlobalContext, io.LimitReader(d.reader, encLenToRead), d.bucket, d.object, uint32(partID), d.partEncRelOffset, m)
+	if err != nil {
+		return err
+	}
+	decrypter, err := newDecryptReaderWithObjectKey(partReader, partEncryptionKey, d.startSeqNum)
 	if err != nil {
 		return err
 	}
@@ -699,7 +756,7 @@ func getDecryptedETag(headers http.Header, objInfo ObjectInfo, copySource bool)
 		return objInfo.ETag[len(objInfo.ETag)-32:]
 	}
 
-	objectEncryptionKey, err := decryptObjectInfo(key[:], objInfo.Bucket, objInfo.Name, objInfo.UserDefined)
+	objectEncryptionKey, err := decryptObjectInfo(GlobalContext, key[:], objInfo.Bucket, objInfo.Name, objInfo.UserDefined)
 	if err != nil {
 		return objInfo.ETag
 	}
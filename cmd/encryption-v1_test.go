@@ -20,6 +20,8 @@ package cmd
 import (
 	"bytes"
 	"encoding/base64"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/minio/minio/internal/crypto"
 	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/kms"
 	"github.com/minio/sio"
 )
 
@@ -79,6 +82,56 @@ func TestEncryptRequest(t *testing.T) {
 	}
 }
 
+func TestEncryptRequestDualLayer(t *testing.T) {
+	defer func(KMS kms.KMS) { GlobalKMS = KMS }(GlobalKMS)
+	var err error
+	if GlobalKMS, err = kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw="); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("dual-layer encrypted content "), 5000)
+	req := &http.Request{Header: http.Header{}}
+	req.Header.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMSDSSE)
+	metadata := map[string]string{}
+	r, objectKey, err := EncryptRequest(bytes.NewReader(data), req, "bucket", "object", metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !crypto.S3KMS.IsDualLayer(metadata) {
+		t.Fatal("expected the object to be encrypted with two layers")
+	}
+	oi := ObjectInfo{Bucket: "bucket", Name: "object", Size: int64(len(encrypted)), UserDefined: metadata}
+	if size, _ := sio.EncryptedSize(uint64(len(data))); oi.Size != int64(size) {
+		t.Fatalf("expected encrypted size %d, got %d", size, oi.Size)
+	}
+
+	// The content cannot be decrypted with the object key alone.
+	if _, err = sio.DecryptBuffer(nil, encrypted, sio.Config{Key: objectKey[:]}); err == nil {
+		t.Fatal("content decrypted without removing the second layer")
+	}
+
+	rs := &HTTPRangeSpec{Start: 100000, End: 120000}
+	encOff, encLength, skipLen, seqNumber, partStart, err := oi.GetDecryptedRange(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dr, err := DecryptBlocksRequestR(bytes.NewReader(encrypted[encOff:encOff+encLength]), http.Header{}, seqNumber, partStart, oi, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, skipLen+20001)
+	if _, err = io.ReadFull(dr, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[skipLen:], data[100000:120001]) {
+		t.Fatal("unexpected content of the decrypted range")
+	}
+}
+
 var decryptObjectInfoTests = []struct {
 	info    ObjectInfo
 	request *http.Request
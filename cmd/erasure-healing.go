@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
 )
@@ -594,6 +595,15 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 	// Set the size of the object in the heal result
 	result.ObjectSize = latestMeta.Size
 
+	if globalNotifyInternalEvents {
+		sendEvent(eventArgs{
+			EventName:  event.ObjectHealRepaired,
+			BucketName: bucket,
+			Object:     latestMeta.ToObjectInfo(bucket, object),
+			Host:       "Internal: [Heal]",
+		})
+	}
+
 	return result, nil
 }
 
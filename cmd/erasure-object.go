@@ -20,6 +20,8 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -1214,6 +1216,10 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 	if opts.Expiration.Expire {
 		// Check if the current bucket has a configured lifecycle policy
 		lc, _ = globalLifecycleSys.Get(bucket)
+		if lc == nil && opts.Transition.ExpireRestored {
+			// Restored copies expire without lifecycle rules.
+			lc = &lifecycle.Lifecycle{}
+		}
 	}
 
 	// expiration attempted on a bucket with no lifecycle
@@ -1612,8 +1618,10 @@ func (er erasureObjects) TransitionObject(ctx context.Context, bucket, object st
 	}()
 
 	var rv remoteVersionID
-	rv, err = tgtClient.Put(ctx, destObj, pr, fi.Size)
+	h := sha256.New()
+	rv, err = tgtClient.Put(ctx, destObj, io.TeeReader(pr, h), fi.Size)
 	pr.CloseWithError(err)
+	globalTierUsage.recordPut(opts.Transition.Tier, bucket, opts.Transition.RuleID, fi.Size, err)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to transition %s/%s(%s) to %s tier: %w", bucket, object, opts.VersionID, opts.Transition.Tier, err))
 		return err
@@ -1622,6 +1630,12 @@ func (er erasureObjects) TransitionObject(ctx context.Context, bucket, object st
 	fi.TransitionedObjName = destObj
 	fi.TransitionTier = opts.Transition.Tier
 	fi.TransitionVersionID = string(rv)
+	fi.Metadata[ReservedMetadataPrefixLower+TransitionedChecksum] = hex.EncodeToString(h.Sum(nil))
+	if isTierEncoded(tgtClient) {
+		fi.Metadata[ReservedMetadataPrefixLower+TransitionedEncoded] = "true"
+	} else {
+		delete(fi.Metadata, ReservedMetadataPrefixLower+TransitionedEncoded)
+	}
 	eventName := event.ObjectTransitionComplete
 
 	storageDisks := er.getDisks()
@@ -30,6 +30,7 @@ import (
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/bpool"
 	"github.com/minio/minio/internal/color"
+	"github.com/minio/minio/internal/config/limits"
 	"github.com/minio/minio/internal/dsync"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
@@ -509,7 +510,13 @@ func (er erasureObjects) nsScanner(ctx context.Context, buckets []BucketInfo, bf
 				// Calc usage
 				before := cache.Info.LastUpdate
 				var err error
-				cache, err = disk.NSScanner(ctx, cache, updates)
+				if release, ok := globalResourceLimits.acquire(ctx, limits.Scanner); ok {
+					cache, err = disk.NSScanner(ctx, cache, updates)
+					release()
+				} else {
+					close(updates)
+					err = ctx.Err()
+				}
 				cache.Info.BloomFilter = nil
 				if err != nil {
 					if !cache.Info.LastUpdate.IsZero() && cache.Info.LastUpdate.After(before) {
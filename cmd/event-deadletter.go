@@ -0,0 +1,257 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Events which a notification target fails to accept, because the
+// delivery failed or its queue store is full, are kept in a dead-letter
+// store on local disk. They can be inspected, exported and re-driven to
+// the target through the admin API.
+
+const eventDeadLetterExt = ".json"
+
+// globalEventDeadLetters is nil unless MINIO_EVENT_DEADLETTER_DIR is set.
+var globalEventDeadLetters *eventDeadLetterStore
+
+var (
+	errEventDeadLettersDisabled = errors.New("event dead-letter store is not enabled")
+	errEventDeadLettersFull     = errors.New("event dead-letter store limit reached")
+)
+
+// eventDeadLetter is an event which could not be delivered to a target.
+type eventDeadLetter struct {
+	ID     string         `json:"id"`
+	Target event.TargetID `json:"target"`
+	Time   time.Time      `json:"time"`
+	Error  string         `json:"error"`
+	Event  event.Event    `json:"event"`
+}
+
+type eventDeadLetterStore struct {
+	// Serializes writes and counting entries per target.
+	mu    sync.Mutex
+	dir   string
+	limit int
+}
+
+func newEventDeadLetterStore(dir string, limit int) (*eventDeadLetterStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &eventDeadLetterStore{dir: dir, limit: limit}, nil
+}
+
+func (s *eventDeadLetterStore) targetDir(targetID event.TargetID) string {
+	return filepath.Join(s.dir, targetID.Name+"-"+targetID.ID)
+}
+
+// Put - keeps the event which could not be delivered to the target.
+// Putting to a nil store is a no-op.
+func (s *eventDeadLetterStore) Put(targetID event.TargetID, ev event.Event, cause error) error {
+	if s == nil {
+		return nil
+	}
+	dl := eventDeadLetter{
+		ID:     mustGetUUID(),
+		Target: targetID,
+		Time:   UTCNow(),
+		Event:  ev,
+	}
+	if cause != nil {
+		dl.Error = cause.Error()
+	}
+	data, err := json.Marshal(dl)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := s.targetDir(targetID)
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if s.limit > 0 && len(entries) >= s.limit {
+		return errEventDeadLettersFull
+	}
+	// Names start with the time so that entries list in stored order.
+	name := fmt.Sprintf("%020d-%s%s", dl.Time.UnixNano(), dl.ID, eventDeadLetterExt)
+	return ioutil.WriteFile(filepath.Join(dir, name), data, 0o600)
+}
+
+// targetDirs - returns the directories of the dead letters of the
+// target, or of all targets if targetID is empty.
+func (s *eventDeadLetterStore) targetDirs(targetID event.TargetID) ([]string, error) {
+	if targetID != (event.TargetID{}) {
+		return []string{s.targetDir(targetID)}, nil
+	}
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(s.dir, entry.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// walk - calls fn for the dead letters of the target, or of all targets
+// if targetID is empty, until fn returns false. Dead letters of a target
+// are visited in the order they were stored.
+func (s *eventDeadLetterStore) walk(targetID event.TargetID, fn func(path string, dl eventDeadLetter) bool) error {
+	if s == nil {
+		return errEventDeadLettersDisabled
+	}
+	dirs, err := s.targetDirs(targetID)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		for _, entry := range entries {
+			if !strings.HasSuffix(entry.Name(), eventDeadLetterExt) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				if os.IsNotExist(err) { // re-driven or purged in the meantime
+					continue
+				}
+				return err
+			}
+			var dl eventDeadLetter
+			if err = json.Unmarshal(data, &dl); err != nil {
+				return err
+			}
+			if !fn(path, dl) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// List - returns up to maxEntries dead letters of the target, or of all
+// targets if targetID is empty. maxEntries <= 0 returns all entries.
+func (s *eventDeadLetterStore) List(targetID event.TargetID, maxEntries int) ([]eventDeadLetter, error) {
+	dls := []eventDeadLetter{}
+	err := s.walk(targetID, func(_ string, dl eventDeadLetter) bool {
+		dls = append(dls, dl)
+		return maxEntries <= 0 || len(dls) < maxEntries
+	})
+	return dls, err
+}
+
+// Redrive - sends the dead letters of the target, or of all targets if
+// targetID is empty, to their targets again. Delivered dead letters are
+// removed, the others are kept with the new error.
+func (s *eventDeadLetterStore) Redrive(ctx context.Context, targetID event.TargetID) (redriven int, err error) {
+	if s == nil {
+		return 0, errEventDeadLettersDisabled
+	}
+	targets := globalNotificationSys.targetList.TargetMap()
+	err = s.walk(targetID, func(path string, dl eventDeadLetter) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		target, ok := targets[dl.Target]
+		if !ok {
+			return true
+		}
+		if saveErr := target.Save(dl.Event); saveErr != nil {
+			dl.Error = saveErr.Error()
+			if data, mErr := json.Marshal(dl); mErr == nil {
+				logger.LogIf(ctx, ioutil.WriteFile(path, data, 0o600))
+			}
+			return true
+		}
+		if rmErr := os.Remove(path); rmErr == nil || os.IsNotExist(rmErr) {
+			redriven++
+		}
+		return true
+	})
+	if err != nil {
+		return redriven, err
+	}
+	return redriven, ctx.Err()
+}
+
+// Purge - removes the dead letters of the target, or of all targets if
+// targetID is empty.
+func (s *eventDeadLetterStore) Purge(targetID event.TargetID) (purged int, err error) {
+	err = s.walk(targetID, func(path string, _ eventDeadLetter) bool {
+		if os.Remove(path) == nil {
+			purged++
+		}
+		return true
+	})
+	return purged, err
+}
+
+// eventDeadLetterTargetFromForm - returns the target of the ARN, or an
+// empty target ID, which selects all targets, if arn is empty.
+func eventDeadLetterTargetFromForm(arn string) (event.TargetID, error) {
+	if arn == "" {
+		return event.TargetID{}, nil
+	}
+	parsed, err := event.ParseARN(arn)
+	if err != nil {
+		return event.TargetID{}, err
+	}
+	return parsed.TargetID, nil
+}
+
+// eventDeadLetterResult is the dead-letter store operation result of
+// a single node.
+type eventDeadLetterResult struct {
+	Node        string            `json:"node"`
+	DeadLetters []eventDeadLetter `json:"deadLetters,omitempty"`
+	Redriven    int               `json:"redriven,omitempty"`
+	Purged      int               `json:"purged,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
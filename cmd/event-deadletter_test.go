@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/minio/minio/internal/event"
+)
+
+func TestEventDeadLetterStore(t *testing.T) {
+	s, err := newEventDeadLetterStore(t.TempDir(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhook := event.TargetID{ID: "1", Name: "webhook"}
+	kafka := event.TargetID{ID: "2", Name: "kafka"}
+	cause := errors.New("connection refused")
+
+	for _, object := range []string{"a", "b", "c"} {
+		if err = s.Put(webhook, testJournalEvent("photos", object), cause); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = s.Put(webhook, testJournalEvent("photos", "d"), cause); err != errEventDeadLettersFull {
+		t.Fatalf("expected %v, got %v", errEventDeadLettersFull, err)
+	}
+	if err = s.Put(kafka, testJournalEvent("photos", "e"), cause); err != nil {
+		t.Fatal(err)
+	}
+
+	dls, err := s.List(webhook, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dls) != 3 {
+		t.Fatalf("expected 3 dead letters, got %d", len(dls))
+	}
+	for i, object := range []string{"a", "b", "c"} {
+		if dls[i].Event.S3.Object.Key != object || dls[i].Target != webhook || dls[i].Error != cause.Error() {
+			t.Errorf("unexpected dead letter %d: %+v", i, dls[i])
+		}
+	}
+	if dls, err = s.List(webhook, 2); err != nil || len(dls) != 2 {
+		t.Fatalf("expected 2 dead letters, got %d, %v", len(dls), err)
+	}
+	if dls, err = s.List(event.TargetID{}, 0); err != nil || len(dls) != 4 {
+		t.Fatalf("expected 4 dead letters, got %d, %v", len(dls), err)
+	}
+
+	if n, err := s.Purge(kafka); err != nil || n != 1 {
+		t.Fatalf("expected 1 purged dead letter, got %d, %v", n, err)
+	}
+	if dls, err = s.List(event.TargetID{}, 0); err != nil || len(dls) != 3 {
+		t.Fatalf("expected 3 dead letters, got %d, %v", len(dls), err)
+	}
+}
+
+func TestEventDeadLetterStoreDisabled(t *testing.T) {
+	var s *eventDeadLetterStore
+	if err := s.Put(event.TargetID{ID: "1", Name: "webhook"}, testJournalEvent("photos", "a"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.List(event.TargetID{}, 0); err != errEventDeadLettersDisabled {
+		t.Fatalf("expected %v, got %v", errEventDeadLettersDisabled, err)
+	}
+}
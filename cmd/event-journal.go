@@ -0,0 +1,276 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/logger"
+)
+
+// The event journal keeps the bucket events sent to notification
+// targets on local disk, so they can be replayed to a target which
+// missed them. Every node journals the events of the requests it
+// served in hourly segments which are removed after the retention.
+
+const (
+	eventJournalSegment   = time.Hour
+	eventJournalExt       = ".events"
+	eventJournalMaxRecord = 1 << 20
+)
+
+// globalEventJournal is nil unless MINIO_EVENT_JOURNAL_DIR is set.
+var globalEventJournal *eventJournal
+
+var errEventJournalDisabled = errors.New("event journal is not enabled")
+
+type eventJournalRecord struct {
+	Time  time.Time   `json:"time"`
+	Event event.Event `json:"event"`
+}
+
+type eventJournal struct {
+	mu        sync.Mutex
+	dir       string
+	retention time.Duration
+	segment   int64 // start of the current segment in unix seconds
+	file      *os.File
+}
+
+func newEventJournal(dir string, retention time.Duration) (*eventJournal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &eventJournal{dir: dir, retention: retention}, nil
+}
+
+func (j *eventJournal) segmentPath(segment int64) string {
+	return filepath.Join(j.dir, strconv.FormatInt(segment, 10)+eventJournalExt)
+}
+
+// segments returns the start of all segments on disk in ascending order.
+func (j *eventJournal) segments() ([]int64, error) {
+	entries, err := ioutil.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, eventJournalExt) {
+			continue
+		}
+		segment, err := strconv.ParseInt(strings.TrimSuffix(name, eventJournalExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	sort.Slice(segments, func(i, k int) bool { return segments[i] < segments[k] })
+	return segments, nil
+}
+
+// Append - journals the event. Appending to a nil journal is a no-op.
+func (j *eventJournal) Append(ev event.Event) error {
+	if j == nil {
+		return nil
+	}
+	now := UTCNow()
+	data, err := json.Marshal(eventJournalRecord{Time: now, Event: ev})
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	segment := now.Truncate(eventJournalSegment).Unix()
+	if j.file == nil || segment != j.segment {
+		if j.file != nil {
+			j.file.Close()
+			j.file = nil
+		}
+		f, err := os.OpenFile(j.segmentPath(segment), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		j.file, j.segment = f, segment
+		j.prune(now)
+	}
+	_, err = j.file.Write(append(data, '\n'))
+	return err
+}
+
+// prune removes all segments which only contain events older than
+// the retention.
+func (j *eventJournal) prune(now time.Time) {
+	segments, err := j.segments()
+	if err != nil {
+		logger.LogIf(GlobalContext, err)
+		return
+	}
+	for _, segment := range segments {
+		end := time.Unix(segment, 0).Add(eventJournalSegment)
+		if now.Sub(end) > j.retention {
+			if err = os.Remove(j.segmentPath(segment)); err != nil && !os.IsNotExist(err) {
+				logger.LogIf(GlobalContext, err)
+			}
+		}
+	}
+}
+
+// eventReplayFilter selects the journaled events to replay.
+type eventReplayFilter struct {
+	Bucket     string
+	Prefix     string
+	Start, End time.Time // zero values are unbounded
+}
+
+// eventReplayFromForm - parses the target ARN and the filter of a
+// replay, times are in RFC 3339 format.
+func eventReplayFromForm(arnStr, bucket, prefix, start, end string) (arn event.ARN, filter eventReplayFilter, err error) {
+	parsed, err := event.ParseARN(arnStr)
+	if err != nil {
+		return arn, filter, err
+	}
+	filter = eventReplayFilter{Bucket: bucket, Prefix: prefix}
+	if start != "" {
+		if filter.Start, err = time.Parse(time.RFC3339Nano, start); err != nil {
+			return arn, filter, err
+		}
+	}
+	if end != "" {
+		if filter.End, err = time.Parse(time.RFC3339Nano, end); err != nil {
+			return arn, filter, err
+		}
+	}
+	return *parsed, filter, nil
+}
+
+func (f eventReplayFilter) matches(rec eventJournalRecord) bool {
+	if !f.Start.IsZero() && rec.Time.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && !rec.Time.Before(f.End) {
+		return false
+	}
+	if rec.Event.S3.Bucket.Name != f.Bucket {
+		return false
+	}
+	if f.Prefix == "" {
+		return true
+	}
+	objectName, err := url.QueryUnescape(rec.Event.S3.Object.Key)
+	if err != nil {
+		objectName = rec.Event.S3.Object.Key
+	}
+	return strings.HasPrefix(objectName, f.Prefix)
+}
+
+// Replay - calls fn for every journaled event matching the filter in
+// the order the events were journaled and returns the number of
+// replayed events.
+func (j *eventJournal) Replay(ctx context.Context, filter eventReplayFilter, fn func(event.Event) error) (int, error) {
+	if j == nil {
+		return 0, errEventJournalDisabled
+	}
+	segments, err := j.segments()
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, segment := range segments {
+		start := time.Unix(segment, 0)
+		if !filter.End.IsZero() && !start.Before(filter.End) {
+			break
+		}
+		if !filter.Start.IsZero() && start.Add(eventJournalSegment).Before(filter.Start) {
+			continue
+		}
+		replayed, err := j.replaySegment(ctx, segment, filter, fn)
+		n += replayed
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (j *eventJournal) replaySegment(ctx context.Context, segment int64, filter eventReplayFilter, fn func(event.Event) error) (int, error) {
+	f, err := os.Open(j.segmentPath(segment))
+	if err != nil {
+		if os.IsNotExist(err) { // pruned in the meantime
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+
+	var n int
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64<<10), eventJournalMaxRecord)
+	for scanner.Scan() {
+		if err = ctx.Err(); err != nil {
+			return n, err
+		}
+		var rec eventJournalRecord
+		if err = json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			// A partially written record of a concurrent append.
+			continue
+		}
+		if !filter.matches(rec) {
+			continue
+		}
+		if err = fn(rec.Event); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, scanner.Err()
+}
+
+// replayJournaledEvents - replays the events journaled by this node
+// which match the filter to the notification target.
+func replayJournaledEvents(ctx context.Context, arn event.ARN, filter eventReplayFilter) (int, error) {
+	target, ok := globalNotificationSys.targetList.TargetMap()[arn.TargetID]
+	if !ok {
+		return 0, &event.ErrARNNotFound{ARN: arn}
+	}
+	return globalEventJournal.Replay(ctx, filter, target.Save)
+}
+
+// eventReplayResult is the outcome of a replay on a node.
+type eventReplayResult struct {
+	Node     string `json:"node"`
+	Replayed int    `json:"replayed"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+)
+
+func testJournalEvent(bucket, object string) event.Event {
+	var ev event.Event
+	ev.EventName = event.ObjectCreatedPut
+	ev.S3.Bucket.Name = bucket
+	ev.S3.Object.Key = object
+	return ev
+}
+
+func TestEventJournalReplay(t *testing.T) {
+	j, err := newEventJournal(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ev := range []event.Event{
+		testJournalEvent("photos", "2021/a.jpg"),
+		testJournalEvent("photos", "2022/b.jpg"),
+		testJournalEvent("videos", "2021/c.mp4"),
+		testJournalEvent("photos", "2021%2Fd.jpg"),
+	} {
+		if err = j.Append(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		filter   eventReplayFilter
+		expected int
+	}{
+		{eventReplayFilter{Bucket: "photos"}, 3},
+		{eventReplayFilter{Bucket: "photos", Prefix: "2021/"}, 2},
+		{eventReplayFilter{Bucket: "videos"}, 1},
+		{eventReplayFilter{Bucket: "photos", Start: UTCNow().Add(time.Minute)}, 0},
+		{eventReplayFilter{Bucket: "photos", End: UTCNow().Add(-time.Minute)}, 0},
+		{eventReplayFilter{Bucket: "photos", Start: UTCNow().Add(-time.Minute), End: UTCNow().Add(time.Minute)}, 3},
+	}
+	for i, testCase := range testCases {
+		var replayed []event.Event
+		n, err := j.Replay(context.Background(), testCase.filter, func(ev event.Event) error {
+			replayed = append(replayed, ev)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if n != testCase.expected || len(replayed) != testCase.expected {
+			t.Errorf("Test %d: expected %d events, got %d", i+1, testCase.expected, n)
+		}
+	}
+}
+
+func TestEventJournalPrune(t *testing.T) {
+	j, err := newEventJournal(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := UTCNow().Add(-3 * time.Hour).Truncate(eventJournalSegment).Unix()
+	if err = ioutil.WriteFile(j.segmentPath(old), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = j.Append(testJournalEvent("photos", "a.jpg")); err != nil {
+		t.Fatal(err)
+	}
+	segments, err := j.segments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 1 || segments[0] == old {
+		t.Fatalf("expected the expired segment to be removed, got %v", segments)
+	}
+}
+
+func TestEventJournalDisabled(t *testing.T) {
+	var j *eventJournal
+	if err := j.Append(testJournalEvent("photos", "a.jpg")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.Replay(context.Background(), eventReplayFilter{Bucket: "photos"}, nil); err != errEventJournalDisabled {
+		t.Fatalf("expected %v, got %v", errEventJournalDisabled, err)
+	}
+}
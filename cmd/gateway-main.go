@@ -186,17 +186,16 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	logger.FatalIf(err, "Invalid TLS certificate file")
 
 	// Check and load Root CAs.
-	globalRootCAs, err = certs.GetRootCAs(globalCertsCADir.Get())
+	// The global public crts are part of the global root CAs, as on
+	// reload.
+	globalRootCAs, err = loadRootCAs(globalPublicCerts)
 	logger.FatalIf(err, "Failed to read root CAs (%v)", err)
 
-	// Add the global public crts as part of global root CAs
-	for _, publicCrt := range globalPublicCerts {
-		globalRootCAs.AddCert(publicCrt)
-	}
-
 	// Register root CAs for remote ENVs
 	env.RegisterGlobalCAs(globalRootCAs)
 
+	globalCertsReloader = newCertsReloader(globalTLSCerts, globalPublicCerts, globalRootCAs)
+
 	// Initialize all help
 	initHelp()
 
@@ -256,7 +255,8 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 
 	var getCert certs.GetCertificateFunc
 	if globalTLSCerts != nil {
-		getCert = globalTLSCerts.GetCertificate
+		getCert = globalCertsReloader.GetCertificate
+		go globalCertsReloader.watch(GlobalContext, globalCertsReloadInterval)
 	}
 
 	listeners := ctx.Int("listeners")
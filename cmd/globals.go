@@ -29,6 +29,7 @@ import (
 	"github.com/minio/console/restapi"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/bucket/bandwidth"
+	"github.com/minio/minio/internal/bucket/publicaccess"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/handlers"
 	"github.com/minio/minio/internal/kms"
@@ -38,9 +39,13 @@ import (
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/config/cache"
 	"github.com/minio/minio/internal/config/compress"
+	"github.com/minio/minio/internal/config/conflict"
 	"github.com/minio/minio/internal/config/dns"
+	"github.com/minio/minio/internal/config/iamevents"
+	"github.com/minio/minio/internal/config/identity/kerberos"
 	xldap "github.com/minio/minio/internal/config/identity/ldap"
 	"github.com/minio/minio/internal/config/identity/openid"
+	"github.com/minio/minio/internal/config/identity/rotation"
 	xtls "github.com/minio/minio/internal/config/identity/tls"
 	"github.com/minio/minio/internal/config/policy/opa"
 	"github.com/minio/minio/internal/config/storageclass"
@@ -152,6 +157,10 @@ var (
 	// This flag is set to 'true' when MINIO_UPDATE env is set to 'off'. Default is false.
 	globalInplaceUpdateDisabled = false
 
+	// This flag is set to 'true' when MINIO_NOTIFY_INTERNAL_EVENTS env is set to 'on'.
+	// Lifecycle expirations and heals are then sent as their own event types.
+	globalNotifyInternalEvents = false
+
 	globalSite = config.Site{
 		Region: globalMinioDefaultRegion,
 	}
@@ -197,6 +206,8 @@ var (
 	globalOpenIDConfig openid.Config
 	globalSTSTLSConfig xtls.Config
 
+	globalKerberosConfig kerberos.Config
+
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 
@@ -205,6 +216,14 @@ var (
 
 	globalTLSCerts *certs.Manager
 
+	// Reloads the TLS certificates and root CAs, nil until the server is initialized.
+	globalCertsReloader *certsReloader
+	// Interval of checking the certs directory for changes, 0 disables it.
+	globalCertsReloadInterval time.Duration
+
+	// Issues the internode mTLS certificates, nil unless enabled.
+	globalInternodeCA *internodeCA
+
 	globalHTTPServer        *xhttp.Server
 	globalHTTPServerErrorCh = make(chan error)
 	globalOSSignalCh        = make(chan os.Signal, 1)
@@ -284,6 +303,23 @@ var (
 	globalCompressConfigMu sync.Mutex
 	globalCompressConfig   compress.Config
 
+	// Maximum credential age enforcement.
+	globalCredentialRotationMu     sync.Mutex
+	globalCredentialRotationConfig rotation.Config
+
+	// Deployment wide public access block, merged with the
+	// public access block of each bucket.
+	globalPublicAccessBlockMu     sync.RWMutex
+	globalPublicAccessBlockConfig publicaccess.Config
+
+	// Notification targets receiving IAM change events.
+	globalIAMEventsMu     sync.RWMutex
+	globalIAMEventsConfig iamevents.Config
+
+	// Resolution of conflicting active-active replication writes.
+	globalReplicationConflictMu     sync.RWMutex
+	globalReplicationConflictConfig conflict.Config
+
 	// Some standard object extensions which we strictly dis-allow for compression.
 	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z", ".xz", ".mp4", ".mkv", ".mov", ".jpg", ".png", ".gif"}
 
@@ -327,6 +363,10 @@ var (
 
 	globalTierConfigMgr *TierConfigMgr
 
+	globalSTSRevocationSys *STSRevocationSys
+
+	globalKMSImportedKeysSys *KMSImportedKeysSys
+
 	globalTierJournal *tierJournal
 
 	globalConsoleSrv *restapi.Server
@@ -37,6 +37,8 @@ type apiConfig struct {
 
 	requestsDeadline time.Duration
 	requestsPool     chan struct{}
+	// memory reserved by every S3 request for its buffers.
+	requestMemory    uint64
 	clusterDeadline  time.Duration
 	listQuorum       int
 	corsAllowOrigins []string
@@ -44,6 +46,7 @@ type apiConfig struct {
 	totalDriveCount          int
 	replicationWorkers       int
 	replicationFailedWorkers int
+	replicationThrottle      replicationThrottleConfig
 	transitionWorkers        int
 
 	staleUploadsExpiry          time.Duration
@@ -105,16 +108,18 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 		}
 	}
 
+	// ram_per_request is (2MiB+128KiB) * driveCount \
+	//    + 2 * 10MiB (default erasure block size v1) + 2 * 1MiB (default erasure block size v2)
+	blockSize := xioutil.BlockSizeLarge + xioutil.BlockSizeSmall
+	t.requestMemory = uint64(maxSetDrives*blockSize + int(blockSizeV1*2+blockSizeV2*2))
+
 	var apiRequestsMaxPerNode int
 	if cfg.RequestsMax <= 0 {
 		maxMem := availableMemory()
 
 		// max requests per node is calculated as
 		// total_ram / ram_per_request
-		// ram_per_request is (2MiB+128KiB) * driveCount \
-		//    + 2 * 10MiB (default erasure block size v1) + 2 * 1MiB (default erasure block size v2)
-		blockSize := xioutil.BlockSizeLarge + xioutil.BlockSizeSmall
-		apiRequestsMaxPerNode = int(maxMem / uint64(maxSetDrives*blockSize+int(blockSizeV1*2+blockSizeV2*2)))
+		apiRequestsMaxPerNode = int(maxMem / t.requestMemory)
 
 		if globalIsErasure {
 			logger.Info("Automatically configured API requests per node based on available memory on the system: %d", apiRequestsMaxPerNode)
@@ -143,6 +148,15 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 	}
 	t.replicationFailedWorkers = cfg.ReplicationFailedWorkers
 	t.replicationWorkers = cfg.ReplicationWorkers
+	t.replicationThrottle = replicationThrottleConfig{
+		WorkerBandwidth: cfg.ReplicationWorkerBandwidth,
+		Adaptive:        cfg.ReplicationAdaptive,
+		DiskUtil:        cfg.ReplicationAdaptiveDiskUtil,
+		NetBandwidth:    cfg.ReplicationAdaptiveNetBW,
+	}
+	if globalReplicationPool != nil {
+		globalReplicationPool.SetThrottle(t.replicationThrottle)
+	}
 	if globalTransitionState != nil && cfg.TransitionWorkers != t.transitionWorkers {
 		globalTransitionState.UpdateWorkers(cfg.TransitionWorkers)
 	}
@@ -232,6 +246,13 @@ func (t *apiConfig) getRequestsPool() (chan struct{}, time.Duration) {
 	return t.requestsPool, t.requestsDeadline
 }
 
+func (t *apiConfig) getRequestMemory() uint64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.requestMemory
+}
+
 // maxClients throttles the S3 API calls
 func maxClients(f http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -256,7 +277,17 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 		select {
 		case pool <- struct{}{}:
 			defer func() { <-pool }()
+			release, ok := globalResourceLimits.admitRequest(r.Context(), deadlineTimer.C)
 			globalHTTPStats.addRequestsInQueue(-1)
+			if !ok {
+				if r.Context().Err() == nil {
+					writeErrorResponse(r.Context(), w,
+						errorCodes.ToAPIErr(ErrOperationMaxedOut),
+						r.URL)
+				}
+				return
+			}
+			defer release()
 			f.ServeHTTP(w, r)
 		case <-deadlineTimer.C:
 			// Send a http timeout message
@@ -286,6 +317,13 @@ func (t *apiConfig) getReplicationWorkers() int {
 	return t.replicationWorkers
 }
 
+func (t *apiConfig) getReplicationThrottle() replicationThrottleConfig {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.replicationThrottle
+}
+
 func (t *apiConfig) getTransitionWorkers() int {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
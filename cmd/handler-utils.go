@@ -31,7 +31,9 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/handlers"
@@ -384,9 +386,17 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 
 		statsWriter := logger.NewResponseWriter(w)
 
-		f.ServeHTTP(statsWriter, r)
+		tw, tr, done := globalInflightRequests.track(api, statsWriter, r)
+		defer done()
 
+		start := time.Now()
+		f.ServeHTTP(tw, tr)
+
+		bucket := mux.Vars(r)["bucket"]
 		globalHTTPStats.updateStats(api, r, statsWriter)
+		globalBucketLatency.record(api, bucket, time.Since(start))
+		globalMetricsGovernance.recordTraffic(bucket, r)
+		traceAPIResult(r.Context(), api, statsWriter)
 	}
 }
 
@@ -0,0 +1,335 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/kms"
+)
+
+// Timeout of every individual dependency check.
+const deepHealthCheckTimeout = 5 * time.Second
+
+// deepHealthCheck - the outcome of checking a dependency.
+type deepHealthCheck struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type deepHealthDrive struct {
+	Endpoint string `json:"endpoint"`
+	Pool     int    `json:"pool"`
+	Set      int    `json:"set"`
+	Disk     int    `json:"disk"`
+	State    string `json:"state"`
+	Healing  bool   `json:"healing,omitempty"`
+	deepHealthCheck
+}
+
+type deepHealthNamed struct {
+	Name string `json:"name"`
+	deepHealthCheck
+}
+
+type deepHealthRemoteTarget struct {
+	ARN      string `json:"arn"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Status   string `json:"status"`
+}
+
+// deepHealthResult - per dependency health of the cluster as seen
+// from this node. Orchestrators may act on Healthy alone, the details
+// tell which dependency is at fault.
+type deepHealthResult struct {
+	Healthy            bool                     `json:"healthy"`
+	WriteQuorum        int                      `json:"writeQuorum,omitempty"`
+	HealingDrives      int                      `json:"healingDrives,omitempty"`
+	Drives             []deepHealthDrive        `json:"drives"`
+	Locks              deepHealthCheck          `json:"locks"`
+	KMS                *deepHealthCheck         `json:"kms,omitempty"`
+	Identity           []deepHealthNamed        `json:"identity,omitempty"`
+	Tiers              []deepHealthNamed        `json:"tiers,omitempty"`
+	ReplicationTargets []deepHealthRemoteTarget `json:"replicationTargets,omitempty"`
+}
+
+// checkDependency runs the check fn with a timeout and returns its
+// outcome and how long it took.
+func checkDependency(ctx context.Context, fn func(ctx context.Context) error) deepHealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, deepHealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+	check := deepHealthCheck{
+		Status:  available,
+		Latency: time.Since(start).String(),
+	}
+	if err != nil {
+		check.Status = unavailable
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// deepHealthDrives checks every drive of the cluster, timing how long
+// each takes to answer a DiskInfo call.
+func deepHealthDrives(ctx context.Context, objLayer ObjectLayer) []deepHealthDrive {
+	z, ok := objLayer.(*erasureServerPools)
+	if !ok {
+		storageInfo, _ := objLayer.StorageInfo(ctx)
+		drives := make([]deepHealthDrive, 0, len(storageInfo.Disks))
+		for _, disk := range storageInfo.Disks {
+			drive := deepHealthDrive{
+				Endpoint:        disk.Endpoint,
+				State:           disk.State,
+				deepHealthCheck: deepHealthCheck{Status: available},
+			}
+			if disk.State != madmin.DriveStateOk {
+				drive.Status = unavailable
+			}
+			drives = append(drives, drive)
+		}
+		return drives
+	}
+
+	var drives []deepHealthDrive
+	var disks []StorageAPI
+	for poolIdx, pool := range z.serverPools {
+		for setIdx, set := range pool.sets {
+			endpoints := set.getEndpoints()
+			for diskIdx, disk := range set.getDisks() {
+				drives = append(drives, deepHealthDrive{
+					Endpoint: endpoints[diskIdx].String(),
+					Pool:     poolIdx,
+					Set:      setIdx,
+					Disk:     diskIdx,
+				})
+				disks = append(disks, disk)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range disks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if disks[i] == nil {
+				drives[i].State = diskErrToDriveState(errDiskNotFound)
+				drives[i].Status = unavailable
+				drives[i].Error = errDiskNotFound.Error()
+				return
+			}
+			drives[i].deepHealthCheck = checkDependency(ctx, func(ctx context.Context) error {
+				info, err := disks[i].DiskInfo(ctx)
+				drives[i].Healing = info.Healing
+				drives[i].State = diskErrToDriveState(err)
+				return err
+			})
+		}(i)
+	}
+	wg.Wait()
+	return drives
+}
+
+// deepHealthLocks checks that lock quorum is available by
+// acquiring and releasing a lock.
+func deepHealthLocks(ctx context.Context, objLayer ObjectLayer) deepHealthCheck {
+	return checkDependency(ctx, func(ctx context.Context) error {
+		lk := objLayer.NewNSLock(minioMetaBucket, "health/deep-check.lock")
+		lkctx, err := lk.GetLock(ctx, newDynamicTimeout(deepHealthCheckTimeout, time.Second))
+		if err != nil {
+			return err
+		}
+		lk.Unlock(lkctx.Cancel)
+		return nil
+	})
+}
+
+func deepHealthKMS(ctx context.Context) *deepHealthCheck {
+	if GlobalKMS == nil {
+		return nil
+	}
+	check := checkDependency(ctx, func(ctx context.Context) error {
+		_, err := GlobalKMS.Stat()
+		return err
+	})
+	if breaker, ok := kms.Breaker(GlobalKMS); ok && breaker.Open {
+		check.Status = unavailable
+		if check.Error == "" {
+			check.Error = "circuit breaker is open"
+		}
+	}
+	return &check
+}
+
+func deepHealthIdentity(ctx context.Context) []deepHealthNamed {
+	var idps []deepHealthNamed
+	if globalOpenIDConfig.Enabled {
+		idps = append(idps, deepHealthNamed{
+			Name:            "openid",
+			deepHealthCheck: checkDependency(ctx, globalOpenIDConfig.TestConnection),
+		})
+	}
+	if globalLDAPConfig.Enabled {
+		idps = append(idps, deepHealthNamed{
+			Name: "ldap",
+			deepHealthCheck: checkDependency(ctx, func(ctx context.Context) error {
+				return globalLDAPConfig.TestConnection()
+			}),
+		})
+	}
+	return idps
+}
+
+func deepHealthTiers(ctx context.Context) []deepHealthNamed {
+	if globalTierConfigMgr == nil {
+		return nil
+	}
+	names := globalTierConfigMgr.tierNames()
+	tiers := make([]deepHealthNamed, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			tiers[i] = deepHealthNamed{
+				Name: name,
+				deepHealthCheck: checkDependency(ctx, func(ctx context.Context) error {
+					d, err := globalTierConfigMgr.getDriver(name)
+					if err != nil {
+						return err
+					}
+					_, err = d.InUse(ctx)
+					return err
+				}),
+			}
+		}(i, name)
+	}
+	wg.Wait()
+	return tiers
+}
+
+func deepHealthReplicationTargets() []deepHealthRemoteTarget {
+	if globalBucketTargetSys == nil {
+		return nil
+	}
+	var targets []deepHealthRemoteTarget
+	for _, th := range globalBucketTargetSys.targetsHealth() {
+		target := deepHealthRemoteTarget{
+			ARN:      th.ARN,
+			Endpoint: th.Endpoint,
+			Status:   available,
+		}
+		if !th.Online {
+			target.Status = unavailable
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// deepHealth checks all dependencies of the cluster. The cluster is
+// healthy if it has write quorum and the lock quorum, KMS and identity
+// providers are available; tiers and replication targets are reported
+// but do not affect the overall health.
+func deepHealth(ctx context.Context, objLayer ObjectLayer) deepHealthResult {
+	var result deepHealthResult
+	var wg sync.WaitGroup
+	wg.Add(6)
+	go func() {
+		defer wg.Done()
+		health := objLayer.Health(ctx, HealthOptions{})
+		result.Healthy = health.Healthy
+		result.WriteQuorum = health.WriteQuorum
+		result.HealingDrives = health.HealingDrives
+	}()
+	go func() {
+		defer wg.Done()
+		result.Drives = deepHealthDrives(ctx, objLayer)
+	}()
+	go func() {
+		defer wg.Done()
+		result.Locks = deepHealthLocks(ctx, objLayer)
+	}()
+	go func() {
+		defer wg.Done()
+		result.KMS = deepHealthKMS(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		result.Identity = deepHealthIdentity(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		result.Tiers = deepHealthTiers(ctx)
+		result.ReplicationTargets = deepHealthReplicationTargets()
+	}()
+	wg.Wait()
+
+	if result.Locks.Status != available {
+		result.Healthy = false
+	}
+	if result.KMS != nil && result.KMS.Status != available {
+		result.Healthy = false
+	}
+	for _, idp := range result.Identity {
+		if idp.Status != available {
+			result.Healthy = false
+		}
+	}
+	return result
+}
+
+// ClusterDeepCheckHandler returns the health of the cluster and of all
+// its dependencies as a JSON document.
+func ClusterDeepCheckHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ClusterDeepCheckHandler")
+
+	if shouldProxy() {
+		w.Header().Set(xhttp.MinIOServerStatus, unavailable)
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
+
+	objLayer := newObjectLayerFn()
+
+	ctx, cancel := context.WithTimeout(ctx, globalAPIConfig.getClusterDeadline()+deepHealthCheckTimeout)
+	defer cancel()
+
+	result := deepHealth(ctx, objLayer)
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+	writeResponse(w, status, data, mimeJSON)
+}
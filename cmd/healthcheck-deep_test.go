@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestCheckDependency(t *testing.T) {
+	check := checkDependency(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+	if check.Status != available || check.Latency == "" || check.Error != "" {
+		t.Errorf("unexpected check %+v", check)
+	}
+
+	check = checkDependency(context.Background(), func(ctx context.Context) error {
+		return errors.New("unreachable")
+	})
+	if check.Status != unavailable || check.Error != "unreachable" {
+		t.Errorf("unexpected check %+v", check)
+	}
+}
+
+func TestClusterDeepCheckHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(obj)
+	defer setObjectLayer(nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, healthCheckPathPrefix+healthCheckClusterDeepPath, nil)
+	ClusterDeepCheckHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+
+	var result deepHealthResult
+	if err = json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Healthy || result.WriteQuorum == 0 {
+		t.Errorf("expected a healthy cluster, got %+v", result)
+	}
+	if len(result.Drives) != len(fsDirs) {
+		t.Fatalf("expected %d drives, got %d", len(fsDirs), len(result.Drives))
+	}
+	for _, drive := range result.Drives {
+		if drive.Status != available || drive.State != madmin.DriveStateOk || drive.Latency == "" {
+			t.Errorf("unexpected drive %+v", drive)
+		}
+	}
+	if result.Locks.Status != available {
+		t.Errorf("expected locks to be available, got %+v", result.Locks)
+	}
+	if result.KMS != nil {
+		t.Errorf("expected no KMS, got %+v", result.KMS)
+	}
+}
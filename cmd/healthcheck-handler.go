@@ -24,9 +24,13 @@ import (
 	"strconv"
 
 	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/kms"
 )
 
-const unavailable = "offline"
+const (
+	available   = "online"
+	unavailable = "offline"
+)
 
 func shouldProxy() bool {
 	return newObjectLayerFn() == nil
@@ -57,6 +61,7 @@ func ClusterCheckHandler(w http.ResponseWriter, r *http.Request) {
 	if result.WriteQuorum > 0 {
 		w.Header().Set(xhttp.MinIOWriteQuorum, strconv.Itoa(result.WriteQuorum))
 	}
+	setKMSStatusHeader(w)
 	if !result.Healthy {
 		// return how many drives are being healed if any
 		if result.HealingDrives > 0 {
@@ -75,6 +80,21 @@ func ClusterCheckHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, http.StatusOK, nil, mimeNone)
 }
 
+// setKMSStatusHeader reports whether the circuit breaker of the KMS,
+// if configured, considers the KMS to be online.
+func setKMSStatusHeader(w http.ResponseWriter) {
+	if GlobalKMS == nil {
+		return
+	}
+	if breaker, ok := kms.Breaker(GlobalKMS); ok {
+		if breaker.Open {
+			w.Header().Set(xhttp.MinIOKMSStatus, unavailable)
+		} else {
+			w.Header().Set(xhttp.MinIOKMSStatus, available)
+		}
+	}
+}
+
 // ClusterReadCheckHandler returns if the server is ready for requests.
 func ClusterReadCheckHandler(w http.ResponseWriter, r *http.Request) {
 	if globalIsGateway {
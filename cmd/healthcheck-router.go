@@ -19,8 +19,10 @@ package cmd
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/minio/pkg/env"
 )
 
 const (
@@ -29,6 +31,7 @@ const (
 	healthCheckReadinessPath   = "/ready"
 	healthCheckClusterPath     = "/cluster"
 	healthCheckClusterReadPath = "/cluster/read"
+	healthCheckClusterDeepPath = "/cluster/deep"
 	healthCheckPathPrefix      = minioReservedBucketPath + healthCheckPath
 )
 
@@ -43,6 +46,14 @@ func registerHealthCheckRouter(router *mux.Router) {
 	healthRouter.Methods(http.MethodGet).Path(healthCheckClusterReadPath).HandlerFunc(httpTraceAll(ClusterReadCheckHandler))
 	healthRouter.Methods(http.MethodHead).Path(healthCheckClusterReadPath).HandlerFunc(httpTraceAll(ClusterReadCheckHandler))
 
+	// Deep cluster check handler reports the health of every
+	// dependency, it is authenticated like the metrics.
+	deepCheckHandler := http.Handler(httpTraceAll(ClusterDeepCheckHandler))
+	if prometheusAuthType(strings.ToLower(env.Get(EnvPrometheusAuthType, string(prometheusJWT)))) != prometheusPublic {
+		deepCheckHandler = AuthMiddleware(deepCheckHandler)
+	}
+	healthRouter.Methods(http.MethodGet).Path(healthCheckClusterDeepPath).Handler(deepCheckHandler)
+
 	// Liveness handler
 	healthRouter.Methods(http.MethodGet).Path(healthCheckLivenessPath).HandlerFunc(httpTraceAll(LivenessCheckHandler))
 	healthRouter.Methods(http.MethodHead).Path(healthCheckLivenessPath).HandlerFunc(httpTraceAll(LivenessCheckHandler))
@@ -0,0 +1,359 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package cmd
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	xmetrics "github.com/minio/minio/internal/config/metrics"
+)
+
+// otherBucketsLabel is the bucket label of the requests to buckets
+// not exported with their own label.
+const otherBucketsLabel = "_other"
+
+// bucketLatencyEvictInterval is how often the tracked buckets are
+// ranked by their requests since the last ranking. Buckets falling
+// out of the limit are evicted in favor of busier untracked buckets.
+const bucketLatencyEvictInterval = 5 * time.Minute
+
+// bucketLatencyBucketLen must be the length of bucketLatencyIntervals
+const bucketLatencyBucketLen = 8
+
+// bucketLatencyIntervals are the upper bounds of the request latency
+// histogram intervals by API and bucket.
+var bucketLatencyIntervals = [bucketLatencyBucketLen]struct {
+	name string
+	end  time.Duration
+}{
+	{"LESS_THAN_50_MS", 50 * time.Millisecond},
+	{"BETWEEN_50_MS_AND_100_MS", 100 * time.Millisecond},
+	{"BETWEEN_100_MS_AND_250_MS", 250 * time.Millisecond},
+	{"BETWEEN_250_MS_AND_500_MS", 500 * time.Millisecond},
+	{"BETWEEN_500_MS_AND_1_SEC", time.Second},
+	{"BETWEEN_1_SEC_AND_5_SEC", 5 * time.Second},
+	{"BETWEEN_5_SEC_AND_10_SEC", 10 * time.Second},
+	{"GREATER_THAN_10_SEC", math.MaxInt64},
+}
+
+// apiLatency is the histogram of the latency of one API, all counts
+// are accessed atomically.
+type apiLatency [bucketLatencyBucketLen]uint64
+
+func (h *apiLatency) add(d time.Duration) {
+	for i, interval := range bucketLatencyIntervals {
+		if d < interval.end {
+			atomic.AddUint64(&h[i], 1)
+			return
+		}
+	}
+}
+
+func (h *apiLatency) merge(o *apiLatency) {
+	for i := range h {
+		atomic.AddUint64(&h[i], atomic.LoadUint64(&o[i]))
+	}
+}
+
+func (h *apiLatency) load() (l apiLatency) {
+	for i := range h {
+		l[i] = atomic.LoadUint64(&h[i])
+	}
+	return l
+}
+
+// getHistogram returns the number of requests in each latency interval
+func (h apiLatency) getHistogram() map[string]uint64 {
+	ret := make(map[string]uint64, len(h))
+	for i, v := range h {
+		ret[bucketLatencyIntervals[i].name] = v
+	}
+	return ret
+}
+
+// bucketLatency holds the latency histograms of a bucket by API,
+// requests and window are accessed atomically.
+type bucketLatency struct {
+	// requests since the bucket is tracked.
+	requests uint64
+	// window is the number of requests since the last ranking.
+	window uint64
+	apis   sync.Map // API name -> *apiLatency
+}
+
+func (b *bucketLatency) api(name string) *apiLatency {
+	if h, ok := b.apis.Load(name); ok {
+		return h.(*apiLatency)
+	}
+	h, _ := b.apis.LoadOrStore(name, &apiLatency{})
+	return h.(*apiLatency)
+}
+
+func (b *bucketLatency) add(api string, d time.Duration) {
+	b.api(api).add(d)
+	atomic.AddUint64(&b.requests, 1)
+	atomic.AddUint64(&b.window, 1)
+}
+
+func (b *bucketLatency) merge(o *bucketLatency) {
+	o.apis.Range(func(k, v interface{}) bool {
+		b.api(k.(string)).merge(v.(*apiLatency))
+		return true
+	})
+	atomic.AddUint64(&b.requests, atomic.LoadUint64(&o.requests))
+}
+
+func (b *bucketLatency) copyAPIs() map[string]apiLatency {
+	apis := make(map[string]apiLatency)
+	b.apis.Range(func(k, v interface{}) bool {
+		apis[k.(string)] = v.(*apiLatency).load()
+		return true
+	})
+	return apis
+}
+
+// bucketLatencyStats tracks the latency of the requests of a node by
+// API and bucket. At most limit buckets are tracked, buckets are
+// tracked as they are seen while there are free slots and the tracked
+// buckets are periodically ranked by their recent requests against
+// the untracked buckets. Only the top buckets by requests are exported
+// with their own label, such that the cardinality of the metrics is
+// bounded.
+type bucketLatencyStats struct {
+	// mu is only held exclusively to update the configuration and
+	// to rank the buckets, requests are recorded under the read lock.
+	mu      sync.RWMutex
+	cfg     xmetrics.Config
+	buckets map[string]*bucketLatency
+	other   *bucketLatency
+	// candidates counts the requests to untracked buckets since
+	// the last ranking, it holds at most limit buckets.
+	candidates     sync.Map // bucket -> *uint64
+	candidateCount int64
+	// ranked is the time of the last ranking in unix nanoseconds.
+	ranked int64
+}
+
+var globalBucketLatency = &bucketLatencyStats{}
+
+// Update applies a new configuration, all statistics are reset
+// when the latency histograms are disabled.
+func (s *bucketLatencyStats) Update(cfg xmetrics.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+	if !cfg.BucketLatency {
+		s.buckets, s.other = nil, nil
+		s.resetCandidates()
+		return
+	}
+	if s.buckets == nil {
+		s.buckets, s.other = make(map[string]*bucketLatency), &bucketLatency{}
+		atomic.StoreInt64(&s.ranked, time.Now().UnixNano())
+	}
+}
+
+func (s *bucketLatencyStats) record(api, bucket string, d time.Duration) {
+	if bucket == "" {
+		return
+	}
+	s.mu.RLock()
+	if s.buckets == nil {
+		s.mu.RUnlock()
+		return
+	}
+	b, ok := s.buckets[bucket]
+	full := len(s.buckets) >= s.cfg.BucketLatencyLimit
+	switch {
+	case ok:
+		b.add(api, d)
+	case full:
+		s.other.add(api, d)
+		s.addCandidate(bucket)
+	}
+	due := time.Now().UnixNano()-atomic.LoadInt64(&s.ranked) >= int64(bucketLatencyEvictInterval)
+	s.mu.RUnlock()
+
+	if !ok && !full {
+		s.track(api, bucket, d)
+	}
+	if due {
+		s.rank(time.Now())
+	}
+}
+
+// track starts tracking the bucket while there are free slots.
+func (s *bucketLatencyStats) track(api, bucket string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.buckets == nil {
+		return
+	}
+	b, ok := s.buckets[bucket]
+	if !ok {
+		if len(s.buckets) >= s.cfg.BucketLatencyLimit {
+			s.other.add(api, d)
+			return
+		}
+		b = &bucketLatency{}
+		s.buckets[bucket] = b
+	}
+	b.add(api, d)
+}
+
+func (s *bucketLatencyStats) addCandidate(bucket string) {
+	if n, ok := s.candidates.Load(bucket); ok {
+		atomic.AddUint64(n.(*uint64), 1)
+		return
+	}
+	if atomic.AddInt64(&s.candidateCount, 1) > int64(s.cfg.BucketLatencyLimit) {
+		atomic.AddInt64(&s.candidateCount, -1)
+		return
+	}
+	n, loaded := s.candidates.LoadOrStore(bucket, new(uint64))
+	if loaded {
+		atomic.AddInt64(&s.candidateCount, -1)
+	}
+	atomic.AddUint64(n.(*uint64), 1)
+}
+
+func (s *bucketLatencyStats) resetCandidates() {
+	s.candidates.Range(func(k, _ interface{}) bool {
+		s.candidates.Delete(k)
+		return true
+	})
+	atomic.StoreInt64(&s.candidateCount, 0)
+}
+
+// rank keeps the limit buckets with the most requests since the last
+// ranking among the tracked and the untracked buckets. The histograms
+// of evicted buckets are aggregated as otherBucketsLabel, newly
+// tracked buckets start with empty histograms.
+func (s *bucketLatencyStats) rank(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.buckets == nil || now.UnixNano()-atomic.LoadInt64(&s.ranked) < int64(bucketLatencyEvictInterval) {
+		return
+	}
+	atomic.StoreInt64(&s.ranked, now.UnixNano())
+
+	type ranked struct {
+		name     string
+		requests uint64
+		tracked  bool
+	}
+	all := make([]ranked, 0, len(s.buckets))
+	for name, b := range s.buckets {
+		all = append(all, ranked{name, atomic.SwapUint64(&b.window, 0), true})
+	}
+	s.candidates.Range(func(k, v interface{}) bool {
+		all = append(all, ranked{k.(string), atomic.LoadUint64(v.(*uint64)), false})
+		return true
+	})
+	s.resetCandidates()
+
+	// Tracked buckets win ties so that they are not swapped back
+	// and forth with equally busy untracked buckets.
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].requests != all[j].requests {
+			return all[i].requests > all[j].requests
+		}
+		if all[i].tracked != all[j].tracked {
+			return all[i].tracked
+		}
+		return all[i].name < all[j].name
+	})
+	for i, r := range all {
+		switch {
+		case i < s.cfg.BucketLatencyLimit && !r.tracked:
+			s.buckets[r.name] = &bucketLatency{}
+		case i >= s.cfg.BucketLatencyLimit && r.tracked:
+			s.other.merge(s.buckets[r.name])
+			delete(s.buckets, r.name)
+		}
+	}
+}
+
+// histograms returns the histograms of the top buckets by requests
+// and of all other buckets aggregated as otherBucketsLabel.
+func (s *bucketLatencyStats) histograms() map[string]map[string]apiLatency {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.buckets == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.buckets))
+	requests := make(map[string]uint64, len(s.buckets))
+	for name, b := range s.buckets {
+		names = append(names, name)
+		requests[name] = atomic.LoadUint64(&b.requests)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if ri, rj := requests[names[i]], requests[names[j]]; ri != rj {
+			return ri > rj
+		}
+		return names[i] < names[j]
+	})
+
+	other := &bucketLatency{}
+	other.merge(s.other)
+	result := make(map[string]map[string]apiLatency)
+	for i, name := range names {
+		if i >= s.cfg.BucketLatencyTop {
+			other.merge(s.buckets[name])
+			continue
+		}
+		result[name] = s.buckets[name].copyAPIs()
+	}
+	if atomic.LoadUint64(&other.requests) > 0 {
+		result[otherBucketsLabel] = other.copyAPIs()
+	}
+	return result
+}
+
+func getBucketRequestsLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      latencyDistribution,
+		Help:      "Distribution of the time to serve requests by API and bucket.",
+		Type:      histogramMetric,
+	}
+}
+
+func getBucketLatencyMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		for bucket, apis := range globalBucketLatency.histograms() {
+			for api, h := range apis {
+				metrics = append(metrics, Metric{
+					Description:          getBucketRequestsLatencyMD(),
+					VariableLabels:       map[string]string{"api": api, "bucket": bucket},
+					HistogramBucketLabel: "range",
+					Histogram:            h.getHistogram(),
+				})
+			}
+		}
+		return
+	})
+	return mg
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	xmetrics "github.com/minio/minio/internal/config/metrics"
+)
+
+func TestBucketLatencyStats(t *testing.T) {
+	s := &bucketLatencyStats{}
+	s.record("listobjectsv2", "photos", time.Second)
+	if h := s.histograms(); h != nil {
+		t.Fatalf("expected no histograms while disabled, got %v", h)
+	}
+
+	s.Update(xmetrics.Config{BucketLatency: true, BucketLatencyTop: 2, BucketLatencyLimit: 3})
+	for i := 0; i < 3; i++ {
+		s.record("listobjectsv2", "photos", 2*time.Second)
+		s.record("getobject", "logs", 10*time.Millisecond)
+	}
+	s.record("getobject", "photos", 20*time.Second)
+	s.record("getobject", "records", 10*time.Millisecond)
+	// Buckets beyond the limit are only aggregated.
+	s.record("getobject", "backups", 10*time.Millisecond)
+	s.record("getobject", "", 10*time.Millisecond)
+
+	h := s.histograms()
+	if len(h) != 3 {
+		t.Fatalf("expected the top 2 buckets and %s, got %v", otherBucketsLabel, h)
+	}
+	photos := h["photos"]["listobjectsv2"]
+	if photos[5] != 3 {
+		t.Errorf("expected 3 requests up to 2.5s, got %v", photos)
+	}
+	if slow := h["photos"]["getobject"]; slow[bucketLatencyBucketLen-1] != 1 {
+		t.Errorf("expected 1 request slower than all bounds, got %v", slow)
+	}
+	if logs := h["logs"]["getobject"]; logs[0] != 3 {
+		t.Errorf("expected 3 requests up to 50ms, got %v", logs)
+	}
+	if other := h[otherBucketsLabel]["getobject"]; other[0] != 2 {
+		t.Errorf("expected records and backups to be aggregated, got %v", other)
+	}
+
+	// Busier untracked buckets replace tracked buckets once ranked.
+	for i := 0; i < 5; i++ {
+		s.record("getobject", "backups", 10*time.Millisecond)
+	}
+	s.rank(time.Now().Add(bucketLatencyEvictInterval))
+	if _, ok := s.buckets["backups"]; !ok {
+		t.Fatalf("expected backups to be tracked after ranking")
+	}
+	if _, ok := s.buckets["records"]; ok {
+		t.Fatalf("expected records to be evicted after ranking")
+	}
+	if other := s.other.copyAPIs()["getobject"]; other[0] != 7 {
+		t.Errorf("expected the requests of evicted buckets to be aggregated, got %v", other)
+	}
+	s.record("getobject", "backups", 10*time.Millisecond)
+	if backups := s.buckets["backups"].copyAPIs()["getobject"]; backups[0] != 1 {
+		t.Errorf("expected newly tracked bucket to start empty, got %v", backups)
+	}
+
+	s.Update(xmetrics.Config{BucketLatencyTop: 2, BucketLatencyLimit: 3})
+	if h = s.histograms(); h != nil {
+		t.Fatalf("expected the histograms to be reset once disabled, got %v", h)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/config/identity/rotation"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Interval at which credential ages are checked against the
+// configured maximum age.
+const credentialRotationInterval = time.Hour
+
+var credentialRotationLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// userRotationInfo extends the admin user listing with the credential
+// rotation state of the user's access key.
+type userRotationInfo struct {
+	madmin.UserInfo
+	CredentialUpdatedAt *time.Time      `json:"credentialUpdatedAt,omitempty"`
+	RotationStatus      rotation.Status `json:"rotationStatus,omitempty"`
+}
+
+func getCredentialRotationConfig() rotation.Config {
+	globalCredentialRotationMu.Lock()
+	defer globalCredentialRotationMu.Unlock()
+	return globalCredentialRotationConfig
+}
+
+// GetRotatableCredentials - returns the credentials of all regular
+// users and service accounts, temporary credentials are not rotated.
+func (store *IAMStoreSys) GetRotatableCredentials() []auth.Credentials {
+	cache := store.rlock()
+	defer store.runlock()
+
+	creds := make([]auth.Credentials, 0, len(cache.iamUsersMap))
+	for _, cred := range cache.iamUsersMap {
+		if cred.IsTemp() {
+			continue
+		}
+		creds = append(creds, cred)
+	}
+	return creds
+}
+
+// SetCredentialUpdatedAt - records t as the time the secret key of the
+// given user or service account was last set. A time already recorded
+// is only replaced with overwrite. Credentials created before rotation
+// was tracked have none.
+func (store *IAMStoreSys) SetCredentialUpdatedAt(ctx context.Context, accessKey string, t time.Time, overwrite bool) error {
+	cache := store.lock()
+	defer store.unlock()
+
+	cred, ok := cache.iamUsersMap[accessKey]
+	if !ok {
+		return errNoSuchUser
+	}
+	if cred.UpdatedAt != nil && !overwrite {
+		return nil
+	}
+
+	userType := regUser
+	if cred.IsServiceAccount() {
+		userType = svcUser
+	}
+	cred.UpdatedAt = &t
+	if err := store.saveUserIdentity(ctx, accessKey, userType, newUserIdentity(cred)); err != nil {
+		return err
+	}
+	cache.iamUsersMap[accessKey] = cred
+	return nil
+}
+
+// credentialRotationNotices remembers the rotation status last reported
+// for each access key, so that events are only sent on a change.
+type credentialRotationNotices struct {
+	mu       sync.Mutex
+	statuses map[string]rotation.Status
+}
+
+// update records status for accessKey and returns true if it differs
+// from the previously recorded status.
+func (n *credentialRotationNotices) update(accessKey string, status rotation.Status) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.statuses == nil {
+		n.statuses = make(map[string]rotation.Status)
+	}
+	if n.statuses[accessKey] == status {
+		return false
+	}
+	n.statuses[accessKey] = status
+	return true
+}
+
+// retain forgets the status of all access keys not in accessKeys.
+func (n *credentialRotationNotices) retain(accessKeys map[string]struct{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for accessKey := range n.statuses {
+		if _, ok := accessKeys[accessKey]; !ok {
+			delete(n.statuses, accessKey)
+		}
+	}
+}
+
+// ListUsersWithRotation - lists all users along with the rotation
+// status of their access keys.
+func (sys *IAMSys) ListUsersWithRotation() (map[string]userRotationInfo, error) {
+	users, err := sys.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := getCredentialRotationConfig()
+	now := UTCNow()
+	result := make(map[string]userRotationInfo, len(users))
+	for name, info := range users {
+		u := userRotationInfo{UserInfo: info}
+		cred, _ := sys.store.GetUser(name)
+		u.CredentialUpdatedAt = cred.UpdatedAt
+		if cfg.Enabled() {
+			u.RotationStatus = cfg.Check(cred.SecretKeyUpdatedAt(), now)
+		}
+		result[name] = u
+	}
+	return result, nil
+}
+
+// enforceCredentialRotation flags access keys approaching or exceeding
+// the configured maximum age and disables expired keys if requested.
+// Access keys without a recorded update time are stamped with the
+// current time, their age is counted from the first check onwards.
+// Each status change of a key is reported once.
+func (sys *IAMSys) enforceCredentialRotation(ctx context.Context) {
+	cfg := getCredentialRotationConfig()
+	if !cfg.Enabled() || sys.usersSysType != MinIOUsersSysType {
+		return
+	}
+
+	now := UTCNow()
+	creds := sys.store.GetRotatableCredentials()
+	accessKeys := make(map[string]struct{}, len(creds))
+	for _, cred := range creds {
+		accessKeys[cred.AccessKey] = struct{}{}
+		status := cfg.Check(cred.SecretKeyUpdatedAt(), now)
+		if status == rotation.StatusUnknown {
+			if err := sys.store.SetCredentialUpdatedAt(ctx, cred.AccessKey, now, false); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("unable to record the update time of access key %s: %w", cred.AccessKey, err))
+				continue
+			}
+			if cred.IsServiceAccount() {
+				sys.notifyForServiceAccount(ctx, cred.AccessKey)
+			} else {
+				sys.notifyForUser(ctx, cred.AccessKey, false)
+			}
+			status = rotation.StatusOK
+		}
+		if !sys.rotationNotices.update(cred.AccessKey, status) {
+			continue
+		}
+
+		switch status {
+		case rotation.StatusGrace:
+			expiresIn := cred.SecretKeyUpdatedAt().Add(cfg.MaxAge).Sub(now).Round(time.Hour)
+			logger.Info("access key %s must be rotated within %s", cred.AccessKey, expiresIn)
+			sendCredentialRotationEvent(ctx, cred, "grace")
+		case rotation.StatusExpired:
+			if !cfg.Disable || !cred.IsValid() {
+				logger.Info("access key %s exceeds the maximum credential age of %s", cred.AccessKey, cfg.MaxAge)
+				sendCredentialRotationEvent(ctx, cred, "expired")
+				continue
+			}
+			var err error
+			if cred.IsServiceAccount() {
+				err = sys.UpdateServiceAccount(ctx, cred.AccessKey, updateServiceAccountOpts{status: auth.AccountOff})
+			} else {
+				err = sys.SetUserStatus(ctx, cred.AccessKey, madmin.AccountDisabled)
+			}
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("unable to disable expired access key %s: %w", cred.AccessKey, err))
+				// Retry on the next check.
+				sys.rotationNotices.update(cred.AccessKey, rotation.StatusUnknown)
+				continue
+			}
+			logger.Info("access key %s disabled, it exceeds the maximum credential age of %s", cred.AccessKey, cfg.MaxAge)
+		}
+	}
+	sys.rotationNotices.retain(accessKeys)
+}
+
+// watchCredentialRotation enforces the credential rotation settings
+// until ctx is canceled. Only the node holding the leader lock enforces
+// them, so that keys are disabled and events are sent once per
+// cluster. The other nodes take over if the leader goes away.
+func (sys *IAMSys) watchCredentialRotation(ctx context.Context, objAPI ObjectLayer) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		sys.runCredentialRotation(ctx, objAPI)
+		duration := time.Duration(r.Float64() * float64(credentialRotationInterval))
+		if duration < time.Second {
+			duration = time.Second
+		}
+		select {
+		case <-time.After(duration):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runCredentialRotation periodically enforces the credential rotation
+// settings while holding the leader lock, it returns once the lock is
+// lost or ctx is canceled.
+func (sys *IAMSys) runCredentialRotation(ctx context.Context, objAPI ObjectLayer) {
+	locker := objAPI.NewNSLock(minioMetaBucket, "iam/runCredentialRotation.lock")
+	lkctx, err := locker.GetLock(ctx, credentialRotationLeaderLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer lkctx.Cancel()
+	// No unlock for "leader" lock.
+
+	ticker := time.NewTicker(credentialRotationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sys.enforceCredentialRotation(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendCredentialRotationEvent reports the rotation status of an access
+// key as an IAM change event, disabling a key is reported separately by
+// the status change.
+func sendCredentialRotationEvent(ctx context.Context, cred auth.Credentials, status string) {
+	entityType := iamChangeUser
+	if cred.IsServiceAccount() {
+		entityType = iamChangeServiceAccount
+	}
+	sendIAMChangeEvent(ctx, event.IAMMetadata{
+		Type:      entityType,
+		Operation: iamChangeOperationRotation,
+		Entity:    cred.AccessKey,
+		Changes: []event.FieldChange{{
+			Field: "rotationStatus",
+			New:   status,
+		}},
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/config/identity/rotation"
+)
+
+func TestUserRotationInfoCompat(t *testing.T) {
+	updatedAt := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	info := map[string]userRotationInfo{
+		"alice": {
+			UserInfo: madmin.UserInfo{
+				PolicyName: "readwrite",
+				Status:     madmin.AccountEnabled,
+			},
+			CredentialUpdatedAt: &updatedAt,
+			RotationStatus:      rotation.StatusGrace,
+		},
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Older clients must still be able to decode the listing.
+	var users map[string]madmin.UserInfo
+	if err = json.Unmarshal(data, &users); err != nil {
+		t.Fatal(err)
+	}
+	if u := users["alice"]; u.PolicyName != "readwrite" || u.Status != madmin.AccountEnabled {
+		t.Fatalf("unexpected user info %v", u)
+	}
+}
+
+func TestCredentialRotationNotices(t *testing.T) {
+	var n credentialRotationNotices
+	if !n.update("alice", rotation.StatusGrace) {
+		t.Fatal("expected the first grace status to be reported")
+	}
+	if n.update("alice", rotation.StatusGrace) {
+		t.Fatal("expected an unchanged status not to be reported again")
+	}
+	if !n.update("alice", rotation.StatusExpired) {
+		t.Fatal("expected the expired status to be reported")
+	}
+
+	// Forgotten keys are reported again.
+	n.retain(map[string]struct{}{})
+	if !n.update("alice", rotation.StatusExpired) {
+		t.Fatal("expected the status of a forgotten key to be reported")
+	}
+}
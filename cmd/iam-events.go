@@ -0,0 +1,242 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/event"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// Types of IAM entities reported by IAM change events.
+const (
+	iamChangeUser               = "user"
+	iamChangeServiceAccount     = "service-account"
+	iamChangePolicy             = "policy"
+	iamChangeGroup              = "group"
+	iamChangeUserPolicyMapping  = "user-policy-mapping"
+	iamChangeGroupPolicyMapping = "group-policy-mapping"
+
+	iamChangeOperationCreate   = "create"
+	iamChangeOperationUpdate   = "update"
+	iamChangeOperationDelete   = "delete"
+	iamChangeOperationRotation = "rotation"
+)
+
+// getIAMEventsConfig returns the notification targets receiving IAM
+// change events.
+func getIAMEventsConfig() []event.TargetID {
+	globalIAMEventsMu.RLock()
+	defer globalIAMEventsMu.RUnlock()
+	return globalIAMEventsConfig.Targets
+}
+
+// iamEntityState returns the current state of the IAM entity as a
+// flat map of attributes, nil if the entity does not exist. Secrets
+// are never part of the state.
+func (sys *IAMSys) iamEntityState(ctx context.Context, entityType, name string) map[string]interface{} {
+	var state interface{}
+	switch entityType {
+	case iamChangeUser:
+		cred, ok := sys.store.GetUser(name)
+		if !ok || cred.IsTemp() || cred.IsServiceAccount() {
+			return nil
+		}
+		info, err := sys.store.GetUserInfo(name)
+		if err != nil {
+			return nil
+		}
+		state = struct {
+			Status              string   `json:"status"`
+			PolicyName          string   `json:"policyName,omitempty"`
+			MemberOf            []string `json:"memberOf,omitempty"`
+			CredentialUpdatedAt string   `json:"credentialUpdatedAt,omitempty"`
+		}{string(info.Status), info.PolicyName, info.MemberOf, credentialUpdatedAt(cred)}
+	case iamChangeServiceAccount:
+		cred, ok := sys.store.GetUser(name)
+		if !ok || !cred.IsServiceAccount() {
+			return nil
+		}
+		_, sessionPolicy, _ := sys.getServiceAccount(ctx, name)
+		state = struct {
+			ParentUser          string      `json:"parentUser"`
+			Status              string      `json:"status"`
+			SessionPolicy       interface{} `json:"sessionPolicy,omitempty"`
+			CredentialUpdatedAt string      `json:"credentialUpdatedAt,omitempty"`
+		}{cred.ParentUser, cred.Status, sessionPolicy, credentialUpdatedAt(cred)}
+	case iamChangePolicy:
+		doc, err := sys.store.GetPolicyDoc(name)
+		if err != nil {
+			return nil
+		}
+		state = struct {
+			Policy interface{} `json:"policy"`
+		}{doc.Policy}
+	case iamChangeGroup:
+		gd, err := sys.store.GetGroupDescription(name)
+		if err != nil {
+			return nil
+		}
+		subgroups, _, _ := sys.store.GetGroupNesting(name)
+		sort.Strings(gd.Members)
+		sort.Strings(subgroups)
+		state = struct {
+			Status    string   `json:"status,omitempty"`
+			Members   []string `json:"members,omitempty"`
+			Subgroups []string `json:"subgroups,omitempty"`
+		}{gd.Status, gd.Members, subgroups}
+	case iamChangeUserPolicyMapping, iamChangeGroupPolicyMapping:
+		mp, ok := sys.store.GetMappedPolicy(name, entityType == iamChangeGroupPolicyMapping)
+		if !ok || mp.Policies == "" {
+			return nil
+		}
+		state = struct {
+			Policies []string `json:"policies"`
+		}{mp.toSlice()}
+	default:
+		return nil
+	}
+
+	// Normalize through JSON so that states compare and serialize
+	// the same way.
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// credentialUpdatedAt returns when the secret key of cred was last
+// set, the secret key itself is never reported.
+func credentialUpdatedAt(cred auth.Credentials) string {
+	if cred.UpdatedAt == nil {
+		return ""
+	}
+	return cred.UpdatedAt.Format(time.RFC3339)
+}
+
+// diffIAMState returns the attributes that differ between the two
+// states, sorted by attribute name.
+func diffIAMState(before, after map[string]interface{}) []event.FieldChange {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for k := range before {
+		fields[k] = struct{}{}
+	}
+	for k := range after {
+		fields[k] = struct{}{}
+	}
+
+	var changes []event.FieldChange
+	for field := range fields {
+		if reflect.DeepEqual(before[field], after[field]) {
+			continue
+		}
+		changes = append(changes, event.FieldChange{
+			Field: field,
+			Old:   before[field],
+			New:   after[field],
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// notifyIAMChange reports the change of the IAM entity, given its state
+// before the change, to the audit log and the configured notification
+// targets. Nothing is reported if the state did not change.
+func (sys *IAMSys) notifyIAMChange(ctx context.Context, entityType, name string, before map[string]interface{}) {
+	after := sys.iamEntityState(ctx, entityType, name)
+	changes := diffIAMState(before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	operation := iamChangeOperationUpdate
+	switch {
+	case before == nil:
+		operation = iamChangeOperationCreate
+	case after == nil:
+		operation = iamChangeOperationDelete
+	}
+	sendIAMChangeEvent(ctx, event.IAMMetadata{
+		Type:      entityType,
+		Operation: operation,
+		Entity:    name,
+		Changes:   changes,
+	})
+}
+
+// sendIAMChangeEvent sends the IAM change along with the identity of
+// the admin performing it to the audit log and to the configured
+// notification targets.
+func sendIAMChangeEvent(ctx context.Context, change event.IAMMetadata) {
+	reqInfo := logger.GetReqInfo(ctx)
+	now := UTCNow()
+
+	entry := audit.NewEntry(globalDeploymentID)
+	entry.Time = now
+	entry.Trigger = "iam-change"
+	entry.API.Name = "IAMChange"
+	entry.RemoteHost = reqInfo.RemoteHost
+	entry.RequestID = reqInfo.RequestID
+	entry.UserAgent = reqInfo.UserAgent
+	entry.Tags = map[string]interface{}{
+		"accessKey": reqInfo.AccessKey,
+		"iamChange": change,
+	}
+	logger.AuditLog(logger.SetAuditEntry(ctx, &entry), nil, nil, nil)
+
+	targets := getIAMEventsConfig()
+	if len(targets) == 0 || globalNotificationSys == nil {
+		return
+	}
+
+	globalNotificationSys.SendIAMChange(event.Event{
+		EventVersion: event.LatestSchemaVersion,
+		EventSource:  "minio:iam",
+		AwsRegion:    globalSite.Region,
+		EventTime:    now.Format(event.AMZTimeFormat),
+		EventName:    event.IAMChange,
+		UserIdentity: event.Identity{PrincipalID: reqInfo.AccessKey},
+		RequestParameters: map[string]string{
+			"sourceIPAddress": reqInfo.RemoteHost,
+		},
+		ResponseElements: map[string]string{
+			xhttp.AmzRequestID: reqInfo.RequestID,
+		},
+		Source: event.Source{
+			Host:      reqInfo.RemoteHost,
+			UserAgent: reqInfo.UserAgent,
+		},
+		IAM: &change,
+	}, targets)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/event"
+)
+
+func TestDiffIAMState(t *testing.T) {
+	testCases := []struct {
+		before, after map[string]interface{}
+		expected      []event.FieldChange
+	}{
+		{
+			before:   map[string]interface{}{"status": "enabled"},
+			after:    map[string]interface{}{"status": "enabled"},
+			expected: nil,
+		},
+		{
+			before: map[string]interface{}{"status": "enabled", "policyName": "readonly"},
+			after:  map[string]interface{}{"status": "disabled", "policyName": "readonly"},
+			expected: []event.FieldChange{
+				{Field: "status", Old: "enabled", New: "disabled"},
+			},
+		},
+		{
+			before: nil,
+			after:  map[string]interface{}{"status": "enabled", "members": []interface{}{"alice"}},
+			expected: []event.FieldChange{
+				{Field: "members", New: []interface{}{"alice"}},
+				{Field: "status", New: "enabled"},
+			},
+		},
+		{
+			before: map[string]interface{}{"policies": []interface{}{"readwrite"}},
+			after:  nil,
+			expected: []event.FieldChange{
+				{Field: "policies", Old: []interface{}{"readwrite"}},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		changes := diffIAMState(testCase.before, testCase.after)
+		if !reflect.DeepEqual(changes, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, changes)
+		}
+	}
+}
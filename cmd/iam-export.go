@@ -0,0 +1,445 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/auth"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+const iamExportVersion = 1
+
+// Conflict resolution modes for IAM imports.
+const (
+	// Abort the import if any entity of the bundle already exists.
+	iamImportConflictFail = "fail"
+	// Keep existing entities, import only new ones.
+	iamImportConflictSkip = "skip"
+	// Replace existing entities with the ones of the bundle.
+	iamImportConflictOverwrite = "overwrite"
+)
+
+var (
+	errIAMExportInvalidVersion = AdminError{
+		Code:       "XMinioAdminIAMExportInvalidVersion",
+		Message:    "The IAM export bundle version is not supported",
+		StatusCode: http.StatusBadRequest,
+	}
+	errIAMImportInvalidConflict = AdminError{
+		Code:       "XMinioAdminIAMImportInvalidConflict",
+		Message:    "Conflict resolution must be one of 'fail', 'skip' or 'overwrite'",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// iamExportUser is a regular user of an IAM export bundle.
+type iamExportUser struct {
+	SecretKey string     `json:"secretKey"`
+	Status    string     `json:"status"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// iamExportServiceAccount is a service account of an IAM export
+// bundle. Service account tokens are signed with the root credentials
+// of the exporting cluster, so service accounts are re-created from
+// their keys, claims and session policy on import.
+type iamExportServiceAccount struct {
+	SecretKey     string                 `json:"secretKey"`
+	ParentUser    string                 `json:"parentUser"`
+	Groups        []string               `json:"groups,omitempty"`
+	Status        string                 `json:"status"`
+	SessionPolicy *iampolicy.Policy      `json:"sessionPolicy,omitempty"`
+	Claims        map[string]interface{} `json:"claims,omitempty"`
+	UpdatedAt     *time.Time             `json:"updatedAt,omitempty"`
+}
+
+// IAMExportBundle is the full IAM state of a cluster, excluding the
+// built-in policies, the root user and temporary credentials.
+type IAMExportBundle struct {
+	Version         int                                `json:"version"`
+	CreatedAt       time.Time                          `json:"createdAt"`
+	Policies        map[string]iampolicy.Policy        `json:"policies,omitempty"`
+	Users           map[string]iamExportUser           `json:"users,omitempty"`
+	Groups          map[string]GroupInfo               `json:"groups,omitempty"`
+	ServiceAccounts map[string]iamExportServiceAccount `json:"serviceAccounts,omitempty"`
+	UserPolicies    map[string]string                  `json:"userPolicies,omitempty"`
+	GroupPolicies   map[string]string                  `json:"groupPolicies,omitempty"`
+}
+
+// IAMImportResult lists the entities created, replaced or skipped by
+// an IAM import, entries are prefixed by the entity type e.g.
+// "user:alice".
+type IAMImportResult struct {
+	Imported    []string `json:"imported,omitempty"`
+	Overwritten []string `json:"overwritten,omitempty"`
+	Skipped     []string `json:"skipped,omitempty"`
+}
+
+func isBuiltinPolicy(name string) bool {
+	for _, p := range iampolicy.DefaultPolicies {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportIAM - returns a snapshot of the IAM state.
+func (store *IAMStoreSys) ExportIAM() (b IAMExportBundle, serviceAccounts []auth.Credentials) {
+	cache := store.rlock()
+	defer store.runlock()
+
+	b = IAMExportBundle{
+		Version:         iamExportVersion,
+		CreatedAt:       UTCNow(),
+		Policies:        make(map[string]iampolicy.Policy),
+		Users:           make(map[string]iamExportUser),
+		Groups:          make(map[string]GroupInfo),
+		ServiceAccounts: make(map[string]iamExportServiceAccount),
+		UserPolicies:    make(map[string]string),
+		GroupPolicies:   make(map[string]string),
+	}
+	for name, doc := range cache.iamPolicyDocsMap {
+		if !isBuiltinPolicy(name) {
+			b.Policies[name] = doc.Policy
+		}
+	}
+	for name, cred := range cache.iamUsersMap {
+		switch {
+		case cred.IsTemp():
+			continue
+		case cred.IsServiceAccount():
+			serviceAccounts = append(serviceAccounts, cred)
+		default:
+			b.Users[name] = iamExportUser{
+				SecretKey: cred.SecretKey,
+				Status:    cred.Status,
+				UpdatedAt: cred.UpdatedAt,
+			}
+		}
+	}
+	for name, gi := range cache.iamGroupsMap {
+		b.Groups[name] = gi
+	}
+	for name, mp := range cache.iamUserPolicyMap {
+		// Skip mappings of temporary credentials.
+		if cred, ok := cache.iamUsersMap[name]; ok && cred.IsTemp() {
+			continue
+		}
+		b.UserPolicies[name] = mp.Policies
+	}
+	for name, mp := range cache.iamGroupPolicyMap {
+		b.GroupPolicies[name] = mp.Policies
+	}
+	return b, serviceAccounts
+}
+
+// ExportIAM - exports the full IAM state of the cluster.
+func (sys *IAMSys) ExportIAM(ctx context.Context) (IAMExportBundle, error) {
+	if !sys.Initialized() {
+		return IAMExportBundle{}, errServerNotInitialized
+	}
+
+	<-sys.configLoaded
+
+	b, serviceAccounts := sys.store.ExportIAM()
+	for _, sa := range serviceAccounts {
+		_, sessionPolicy, err := sys.getServiceAccount(ctx, sa.AccessKey)
+		if err != nil {
+			return b, err
+		}
+		claims, err := auth.ExtractClaims(sa.SessionToken, globalActiveCred.SecretKey)
+		if err != nil {
+			return b, err
+		}
+		// Claims set by NewServiceAccount are re-created on import.
+		m := claims.Map()
+		for _, k := range []string{expClaim, parentClaim, iampolicy.SessionPolicyName, iamPolicyClaimNameSA(), "accessKey"} {
+			delete(m, k)
+		}
+		b.ServiceAccounts[sa.AccessKey] = iamExportServiceAccount{
+			SecretKey:     sa.SecretKey,
+			ParentUser:    sa.ParentUser,
+			Groups:        sa.Groups,
+			Status:        sa.Status,
+			SessionPolicy: sessionPolicy,
+			Claims:        m,
+			UpdatedAt:     sa.UpdatedAt,
+		}
+	}
+	return b, nil
+}
+
+// iamImporter applies an IAM export bundle according to the conflict
+// resolution mode and records the outcome per entity.
+type iamImporter struct {
+	conflict string
+	result   IAMImportResult
+}
+
+// apply imports the entity unless it exists and conflicts are skipped.
+func (im *iamImporter) apply(entity string, exists bool, fn func() error) error {
+	if exists && im.conflict == iamImportConflictSkip {
+		im.result.Skipped = append(im.result.Skipped, entity)
+		return nil
+	}
+	if err := fn(); err != nil {
+		return fmt.Errorf("unable to import %s: %w", entity, err)
+	}
+	if exists {
+		im.result.Overwritten = append(im.result.Overwritten, entity)
+	} else {
+		im.result.Imported = append(im.result.Imported, entity)
+	}
+	return nil
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]iampolicy.Policy:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]iamExportUser:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]GroupInfo:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]iamExportServiceAccount:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]string:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// iamImportConflicts returns all entities of the bundle that already exist.
+func (sys *IAMSys) iamImportConflicts(b IAMExportBundle) []string {
+	var conflicts []string
+	for _, name := range sortedKeys(b.Policies) {
+		if _, err := sys.store.GetPolicy(name); err == nil {
+			conflicts = append(conflicts, "policy:"+name)
+		}
+	}
+	for _, name := range sortedKeys(b.Users) {
+		if _, ok := sys.store.GetUser(name); ok {
+			conflicts = append(conflicts, "user:"+name)
+		}
+	}
+	for _, name := range sortedKeys(b.Groups) {
+		if _, err := sys.store.GetGroupDescription(name); err == nil {
+			conflicts = append(conflicts, "group:"+name)
+		}
+	}
+	for _, name := range sortedKeys(b.ServiceAccounts) {
+		if _, ok := sys.store.GetUser(name); ok {
+			conflicts = append(conflicts, "service-account:"+name)
+		}
+	}
+	return conflicts
+}
+
+// ImportIAM - imports an IAM export bundle. Policies are imported
+// first, followed by users, groups, policy mappings and service
+// accounts, so that all references resolve.
+func (sys *IAMSys) ImportIAM(ctx context.Context, b IAMExportBundle, conflict string) (IAMImportResult, error) {
+	if !sys.Initialized() {
+		return IAMImportResult{}, errServerNotInitialized
+	}
+	if b.Version != iamExportVersion {
+		return IAMImportResult{}, errIAMExportInvalidVersion
+	}
+	switch conflict {
+	case iamImportConflictFail, iamImportConflictSkip, iamImportConflictOverwrite:
+	default:
+		return IAMImportResult{}, errIAMImportInvalidConflict
+	}
+
+	<-sys.configLoaded
+
+	if conflict == iamImportConflictFail {
+		if conflicts := sys.iamImportConflicts(b); len(conflicts) > 0 {
+			return IAMImportResult{Skipped: conflicts}, AdminError{
+				Code:       "XMinioAdminIAMImportConflict",
+				Message:    fmt.Sprintf("IAM entities already exist: %v", conflicts),
+				StatusCode: http.StatusConflict,
+			}
+		}
+	}
+
+	im := &iamImporter{conflict: conflict}
+	for _, name := range sortedKeys(b.Policies) {
+		_, err := sys.store.GetPolicy(name)
+		if err = im.apply("policy:"+name, err == nil, func() error {
+			return sys.SetPolicy(ctx, name, b.Policies[name])
+		}); err != nil {
+			return im.result, err
+		}
+	}
+
+	if sys.usersSysType == MinIOUsersSysType {
+		for _, name := range sortedKeys(b.Users) {
+			u := b.Users[name]
+			_, exists := sys.store.GetUser(name)
+			if err := im.apply("user:"+name, exists, func() error {
+				status := madmin.AccountDisabled
+				if u.Status == auth.AccountOn {
+					status = madmin.AccountEnabled
+				}
+				if err := sys.CreateUser(ctx, name, madmin.AddOrUpdateUserReq{
+					SecretKey: u.SecretKey,
+					Status:    status,
+				}); err != nil {
+					return err
+				}
+				// Keep the credential age for rotation enforcement.
+				if u.UpdatedAt == nil {
+					return nil
+				}
+				if err := sys.store.SetCredentialUpdatedAt(ctx, name, *u.UpdatedAt, true); err != nil {
+					return err
+				}
+				sys.notifyForUser(ctx, name, false)
+				return nil
+			}); err != nil {
+				return im.result, err
+			}
+		}
+
+		var nested []string
+		for _, name := range sortedKeys(b.Groups) {
+			gi := b.Groups[name]
+			gd, err := sys.store.GetGroupDescription(name)
+			exists := err == nil
+			if err = im.apply("group:"+name, exists, func() error {
+				if exists {
+					// An overwritten group keeps only the exported
+					// members and subgroups.
+					if err := sys.removeGroupEntriesNotIn(ctx, name, gd.Members, gi); err != nil {
+						return err
+					}
+				}
+				if err := sys.AddUsersToGroup(ctx, name, gi.Members); err != nil {
+					return err
+				}
+				if len(gi.Subgroups) > 0 {
+					nested = append(nested, name)
+				}
+				return sys.SetGroupStatus(ctx, name, gi.Status != statusDisabled)
+			}); err != nil {
+				return im.result, err
+			}
+		}
+
+		// Subgroups are nested once all groups exist.
+		for _, name := range nested {
+			if err := sys.AddSubgroups(ctx, name, b.Groups[name].Subgroups); err != nil {
+				return im.result, fmt.Errorf("unable to import group:%s: %w", name, err)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(b.UserPolicies) {
+		mp, exists := sys.store.GetMappedPolicy(name, false)
+		if err := im.apply("user-policy:"+name, exists && mp.Policies != "", func() error {
+			return sys.PolicyDBSet(ctx, name, b.UserPolicies[name], false)
+		}); err != nil {
+			return im.result, err
+		}
+	}
+	for _, name := range sortedKeys(b.GroupPolicies) {
+		mp, exists := sys.store.GetMappedPolicy(name, true)
+		if err := im.apply("group-policy:"+name, exists && mp.Policies != "", func() error {
+			return sys.PolicyDBSet(ctx, name, b.GroupPolicies[name], true)
+		}); err != nil {
+			return im.result, err
+		}
+	}
+
+	for _, name := range sortedKeys(b.ServiceAccounts) {
+		sa := b.ServiceAccounts[name]
+		_, exists := sys.store.GetUser(name)
+		if err := im.apply("service-account:"+name, exists, func() error {
+			if exists {
+				if err := sys.DeleteServiceAccount(ctx, name, true); err != nil {
+					return err
+				}
+			}
+			if _, err := sys.NewServiceAccount(ctx, sa.ParentUser, sa.Groups, newServiceAccountOpts{
+				sessionPolicy: sa.SessionPolicy,
+				accessKey:     name,
+				secretKey:     sa.SecretKey,
+				claims:        sa.Claims,
+			}); err != nil {
+				return err
+			}
+			if sa.UpdatedAt != nil {
+				if err := sys.store.SetCredentialUpdatedAt(ctx, name, *sa.UpdatedAt, true); err != nil {
+					return err
+				}
+				sys.notifyForServiceAccount(ctx, name)
+			}
+			if sa.Status == auth.AccountOff {
+				return sys.UpdateServiceAccount(ctx, name, updateServiceAccountOpts{status: auth.AccountOff})
+			}
+			return nil
+		}); err != nil {
+			return im.result, err
+		}
+	}
+
+	return im.result, nil
+}
+
+// removeGroupEntriesNotIn removes the members and subgroups of an
+// existing group which are not part of the imported group gi.
+func (sys *IAMSys) removeGroupEntriesNotIn(ctx context.Context, group string, members []string, gi GroupInfo) error {
+	stale := set.CreateStringSet(members...).Difference(set.CreateStringSet(gi.Members...))
+	if !stale.IsEmpty() {
+		if err := sys.RemoveUsersFromGroup(ctx, group, stale.ToSlice()); err != nil {
+			return err
+		}
+	}
+
+	subgroups, _, err := sys.store.GetGroupNesting(group)
+	if err != nil {
+		return err
+	}
+	stale = set.CreateStringSet(subgroups...).Difference(set.CreateStringSet(gi.Subgroups...))
+	if !stale.IsEmpty() {
+		return sys.RemoveSubgroups(ctx, group, stale.ToSlice())
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+func TestIAMExportImport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	newAllSubsystems()
+
+	initConfigSubsystem(ctx, objLayer)
+
+	globalIAMSys.Init(ctx, objLayer, globalEtcdClient, globalNotificationSys, 2*time.Second)
+
+	p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::photos/*"]}]}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy(ctx, "photos-read", *p); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.CreateUser(ctx, "alice", madmin.AddOrUpdateUserReq{
+		SecretKey: "alice-secret",
+		Status:    madmin.AccountEnabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.AddUsersToGroup(ctx, "photographers", []string{"alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet(ctx, "photographers", "photos-read", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalIAMSys.NewServiceAccount(ctx, "alice", nil, newServiceAccountOpts{
+		accessKey: "alice-sa",
+		secretKey: "alice-sa-secret",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := globalIAMSys.ExportIAM(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bundle.Policies["photos-read"]; !ok {
+		t.Fatal("expected custom policy in export")
+	}
+	if _, ok := bundle.Policies["readwrite"]; ok {
+		t.Fatal("built-in policies must not be exported")
+	}
+	if bundle.Users["alice"].SecretKey != "alice-secret" {
+		t.Fatalf("unexpected exported user %v", bundle.Users["alice"])
+	}
+	if sa := bundle.ServiceAccounts["alice-sa"]; sa.ParentUser != "alice" || sa.SecretKey != "alice-sa-secret" {
+		t.Fatalf("unexpected exported service account %v", sa)
+	}
+	if bundle.GroupPolicies["photographers"] != "photos-read" {
+		t.Fatalf("unexpected exported group policies %v", bundle.GroupPolicies)
+	}
+
+	// Bundles must survive the encryption round-trip.
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := madmin.EncryptData("export-passphrase", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = madmin.DecryptData("export-passphrase", bytes.NewReader(encrypted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle = IAMExportBundle{}
+	if err = json.Unmarshal(data, &bundle); err != nil {
+		t.Fatal(err)
+	}
+
+	var aerr AdminError
+	if _, err = globalIAMSys.ImportIAM(ctx, bundle, iamImportConflictFail); !errors.As(err, &aerr) || aerr.StatusCode != 409 {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+	if _, err = globalIAMSys.ImportIAM(ctx, bundle, "merge"); err != errIAMImportInvalidConflict {
+		t.Fatalf("expected %v, got %v", errIAMImportInvalidConflict, err)
+	}
+
+	if err = globalIAMSys.DeleteServiceAccount(ctx, "alice-sa", false); err != nil {
+		t.Fatal(err)
+	}
+	result, err := globalIAMSys.ImportIAM(ctx, bundle, iamImportConflictSkip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Imported) != 1 || result.Imported[0] != "service-account:alice-sa" {
+		t.Fatalf("unexpected import result %v", result)
+	}
+	sa, _, err := globalIAMSys.GetServiceAccount(ctx, "alice-sa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sa.ParentUser != "alice" {
+		t.Fatalf("unexpected parent user %s of imported service account", sa.ParentUser)
+	}
+
+	// Imported credentials keep the time their secret key was set.
+	updatedAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	u := bundle.Users["alice"]
+	u.UpdatedAt = &updatedAt
+	bundle.Users["alice"] = u
+	sab := bundle.ServiceAccounts["alice-sa"]
+	sab.UpdatedAt = &updatedAt
+	bundle.ServiceAccounts["alice-sa"] = sab
+
+	result, err = globalIAMSys.ImportIAM(ctx, bundle, iamImportConflictOverwrite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Imported) != 0 || len(result.Overwritten) == 0 {
+		t.Fatalf("unexpected import result %v", result)
+	}
+	for _, name := range []string{"alice", "alice-sa"} {
+		cred, ok := globalIAMSys.store.GetUser(name)
+		if !ok || cred.UpdatedAt == nil || !cred.UpdatedAt.Equal(updatedAt) {
+			t.Fatalf("expected %s to be updated at %v, got %v", name, updatedAt, cred.UpdatedAt)
+		}
+	}
+
+	// Overwriting a group with fewer members and subgroups than it has
+	// now removes the ones missing from the bundle.
+	if err = globalIAMSys.CreateUser(ctx, "bob", madmin.AddOrUpdateUserReq{
+		SecretKey: "bob-secret",
+		Status:    madmin.AccountEnabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.AddUsersToGroup(ctx, "photographers", []string{"bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.AddUsersToGroup(ctx, "editors", []string{"bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.AddSubgroups(ctx, "photographers", []string{"editors"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalIAMSys.ImportIAM(ctx, bundle, iamImportConflictOverwrite); err != nil {
+		t.Fatal(err)
+	}
+	gd, err := globalIAMSys.GetGroupDescription("photographers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gd.Members) != 1 || gd.Members[0] != "alice" {
+		t.Fatalf("expected only the exported members, got %v", gd.Members)
+	}
+	subgroups, _, err := globalIAMSys.GetGroupNesting("photographers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subgroups) != 0 {
+		t.Fatalf("expected no subgroups, got %v", subgroups)
+	}
+}
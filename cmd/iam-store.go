@@ -138,6 +138,9 @@ type GroupInfo struct {
 	Version int      `json:"version"`
 	Status  string   `json:"status"`
 	Members []string `json:"members"`
+	// Groups nested in this group. Members of a subgroup inherit
+	// the policies attached to this group.
+	Subgroups []string `json:"subgroups,omitempty"`
 }
 
 func newGroupInfo(members []string) GroupInfo {
@@ -249,6 +252,8 @@ type iamCache struct {
 	iamGroupsMap map[string]GroupInfo
 	// map of user names to groups they are a member of
 	iamUserGroupMemberships map[string]set.StringSet
+	// map of group names to the groups they are nested in
+	iamGroupParents map[string]set.StringSet
 	// map of usernames/temporary access keys to policy names
 	iamUserPolicyMap map[string]MappedPolicy
 	// map of group names to policy names
@@ -261,6 +266,7 @@ func newIamCache() *iamCache {
 		iamUsersMap:             map[string]auth.Credentials{},
 		iamGroupsMap:            map[string]GroupInfo{},
 		iamUserGroupMemberships: map[string]set.StringSet{},
+		iamGroupParents:         map[string]set.StringSet{},
 		iamUserPolicyMap:        map[string]MappedPolicy{},
 		iamGroupPolicyMap:       map[string]MappedPolicy{},
 	}
@@ -289,6 +295,15 @@ func (c *iamCache) updateGroupMembershipsMap(group string, gi *GroupInfo) {
 		}
 		c.iamUserGroupMemberships[member] = v
 	}
+	for _, subgroup := range gi.Subgroups {
+		v := c.iamGroupParents[subgroup]
+		if v == nil {
+			v = set.CreateStringSet(group)
+		} else {
+			v.Add(group)
+		}
+		c.iamGroupParents[subgroup] = v
+	}
 }
 
 // removeGroupFromMembershipsMap - removes the group from every member
@@ -301,14 +316,68 @@ func (c *iamCache) removeGroupFromMembershipsMap(group string) {
 		groups.Remove(group)
 		c.iamUserGroupMemberships[member] = groups
 	}
+	for subgroup, parents := range c.iamGroupParents {
+		if !parents.Contains(group) {
+			continue
+		}
+		parents.Remove(group)
+		c.iamGroupParents[subgroup] = parents
+	}
+}
+
+// expandGroups - returns the given groups along with all groups they
+// are transitively nested in. Missing and disabled groups are skipped,
+// and a disabled group does not pass on the policies of its parents.
+// IMPORTANT: Assumes that c.RLock is held by caller.
+func (c *iamCache) expandGroups(groups ...string) []string {
+	seen := set.NewStringSet()
+	var expanded []string
+	for len(groups) > 0 {
+		group := groups[0]
+		groups = groups[1:]
+		if seen.Contains(group) {
+			continue
+		}
+		seen.Add(group)
+
+		gi, ok := c.iamGroupsMap[group]
+		if !ok || gi.Status == statusDisabled {
+			continue
+		}
+		expanded = append(expanded, group)
+		groups = append(groups, c.iamGroupParents[group].ToSlice()...)
+	}
+	return expanded
+}
+
+// isNestedIn - returns true if group is transitively nested in
+// parent, regardless of the status of the groups in between.
+// IMPORTANT: Assumes that c.RLock is held by caller.
+func (c *iamCache) isNestedIn(group, parent string) bool {
+	seen := set.NewStringSet()
+	pending := []string{group}
+	for len(pending) > 0 {
+		g := pending[0]
+		pending = pending[1:]
+		if g == parent {
+			return true
+		}
+		if seen.Contains(g) {
+			continue
+		}
+		seen.Add(g)
+		pending = append(pending, c.iamGroupParents[g].ToSlice()...)
+	}
+	return false
 }
 
 // policyDBGet - lower-level helper; does not take locks.
 //
-// If a group is passed, it returns policies associated with the group.
+// If a group is passed, it returns policies associated with the group and
+// the groups it is nested in.
 //
 // If a user is passed, it returns policies of the user along with any groups
-// that the server knows the user is a member of.
+// that the server knows the user is a member of, directly or through nesting.
 //
 // In LDAP users mode, the server does not store any group membership
 // information in IAM (i.e sys.iam*Map) - this info is stored only in the STS
@@ -327,6 +396,12 @@ func (c *iamCache) policyDBGet(mode UsersSysType, name string, isGroup bool) ([]
 			if g.Status == statusDisabled {
 				return nil, nil
 			}
+
+			var policies []string
+			for _, group := range c.expandGroups(name) {
+				policies = append(policies, c.iamGroupPolicyMap[group].toSlice()...)
+			}
+			return policies, nil
 		}
 
 		return c.iamGroupPolicyMap[name].toSlice(), nil
@@ -363,13 +438,8 @@ func (c *iamCache) policyDBGet(mode UsersSysType, name string, isGroup bool) ([]
 	// returned policy could be empty
 	policies := mp.toSlice()
 
-	for _, group := range c.iamUserGroupMemberships[name].ToSlice() {
-		// Skip missing or disabled groups
-		gi, ok := c.iamGroupsMap[group]
-		if !ok || gi.Status == statusDisabled {
-			continue
-		}
-
+	// Missing or disabled groups are skipped by the expansion.
+	for _, group := range c.expandGroups(c.iamUserGroupMemberships[name].ToSlice()...) {
 		policies = append(policies, c.iamGroupPolicyMap[group].toSlice()...)
 	}
 
@@ -479,6 +549,7 @@ func (store *IAMStoreSys) LoadIAMCache(ctx context.Context) error {
 	cache.iamGroupsMap = newCache.iamGroupsMap
 	cache.iamPolicyDocsMap = newCache.iamPolicyDocsMap
 	cache.iamUserGroupMemberships = newCache.iamUserGroupMemberships
+	cache.iamGroupParents = newCache.iamGroupParents
 	cache.iamUserPolicyMap = newCache.iamUserPolicyMap
 	cache.iamUsersMap = newCache.iamUsersMap
 
@@ -537,6 +608,7 @@ func (store *IAMStoreSys) GroupNotificationHandler(ctx context.Context, group st
 		cache.removeGroupFromMembershipsMap(group)
 		delete(cache.iamGroupsMap, group)
 		delete(cache.iamGroupPolicyMap, group)
+		delete(cache.iamGroupParents, group)
 		return nil
 	}
 
@@ -690,7 +762,7 @@ func (store *IAMStoreSys) RemoveUsersFromGroup(ctx context.Context, group string
 	}
 
 	// Check if attempting to delete a non-empty group.
-	if len(members) == 0 && len(gi.Members) != 0 {
+	if len(members) == 0 && (len(gi.Members) != 0 || len(gi.Subgroups) != 0) {
 		return errGroupNotEmpty
 	}
 
@@ -706,15 +778,109 @@ func (store *IAMStoreSys) RemoveUsersFromGroup(ctx context.Context, group string
 			return err
 		}
 
+		// Unlink the group from the groups it is nested in, so that
+		// a group created later with the same name does not inherit
+		// their policies.
+		for _, parent := range cache.iamGroupParents[group].ToSlice() {
+			pgi, ok := cache.iamGroupsMap[parent]
+			if !ok {
+				continue
+			}
+			pgi.Subgroups = set.CreateStringSet(pgi.Subgroups...).Difference(set.CreateStringSet(group)).ToSlice()
+			if err := store.saveGroupInfo(ctx, parent, pgi); err != nil {
+				return err
+			}
+			cache.iamGroupsMap[parent] = pgi
+		}
+
 		// Delete from server memory
 		delete(cache.iamGroupsMap, group)
 		delete(cache.iamGroupPolicyMap, group)
+		delete(cache.iamGroupParents, group)
 		return nil
 	}
 
 	return removeMembersFromGroup(ctx, store, cache, group, members, false)
 }
 
+// AddSubgroups - nests the given groups in group, creating group if
+// needed. All subgroups must exist and nesting must not create a cycle.
+func (store *IAMStoreSys) AddSubgroups(ctx context.Context, group string, subgroups []string) error {
+	if group == "" {
+		return errInvalidArgument
+	}
+
+	cache := store.lock()
+	defer store.unlock()
+
+	for _, subgroup := range subgroups {
+		if _, ok := cache.iamGroupsMap[subgroup]; !ok {
+			return errNoSuchGroup
+		}
+		// Nesting subgroup in group creates a cycle if group is
+		// already (transitively) nested in subgroup.
+		if cache.isNestedIn(group, subgroup) {
+			return errGroupNestingCycle
+		}
+	}
+
+	gi, ok := cache.iamGroupsMap[group]
+	if !ok {
+		gi = newGroupInfo(nil)
+	}
+	gi.Subgroups = set.CreateStringSet(append(gi.Subgroups, subgroups...)...).ToSlice()
+
+	if err := store.saveGroupInfo(ctx, group, gi); err != nil {
+		return err
+	}
+
+	cache.iamGroupsMap[group] = gi
+	cache.updateGroupMembershipsMap(group, &gi)
+	return nil
+}
+
+// RemoveSubgroups - removes the given groups from the subgroups of
+// group.
+func (store *IAMStoreSys) RemoveSubgroups(ctx context.Context, group string, subgroups []string) error {
+	if group == "" {
+		return errInvalidArgument
+	}
+
+	cache := store.lock()
+	defer store.unlock()
+
+	gi, ok := cache.iamGroupsMap[group]
+	if !ok {
+		return errNoSuchGroup
+	}
+	gi.Subgroups = set.CreateStringSet(gi.Subgroups...).Difference(set.CreateStringSet(subgroups...)).ToSlice()
+
+	if err := store.saveGroupInfo(ctx, group, gi); err != nil {
+		return err
+	}
+
+	cache.iamGroupsMap[group] = gi
+	for _, subgroup := range subgroups {
+		if parents := cache.iamGroupParents[subgroup]; parents != nil {
+			parents.Remove(group)
+		}
+	}
+	return nil
+}
+
+// GetGroupNesting - returns the direct subgroups of group and the
+// groups it is directly nested in.
+func (store *IAMStoreSys) GetGroupNesting(group string) (subgroups, parents []string, err error) {
+	cache := store.rlock()
+	defer store.runlock()
+
+	gi, ok := cache.iamGroupsMap[group]
+	if !ok {
+		return nil, nil, errNoSuchGroup
+	}
+	return gi.Subgroups, cache.iamGroupParents[group].ToSlice(), nil
+}
+
 // SetGroupStatus - updates group status
 func (store *IAMStoreSys) SetGroupStatus(ctx context.Context, group string, enabled bool) error {
 	if group == "" {
@@ -1491,6 +1657,7 @@ func (store *IAMStoreSys) SetUserStatus(ctx context.Context, accessKey string, s
 			}
 			return auth.AccountOff
 		}(),
+		UpdatedAt: cred.UpdatedAt,
 	})
 
 	if err := store.saveUserIdentity(ctx, accessKey, regUser, uinfo); err != nil {
@@ -1524,6 +1691,8 @@ func (store *IAMStoreSys) AddServiceAccount(ctx context.Context, cred auth.Crede
 		return errIAMServiceAccount
 	}
 
+	updatedAt := UTCNow()
+	cred.UpdatedAt = &updatedAt
 	u := newUserIdentity(cred)
 	err := store.saveUserIdentity(ctx, u.Credentials.AccessKey, svcUser, u)
 	if err != nil {
@@ -1550,6 +1719,8 @@ func (store *IAMStoreSys) UpdateServiceAccount(ctx context.Context, accessKey st
 			return auth.ErrInvalidSecretKeyLength
 		}
 		cr.SecretKey = opts.secretKey
+		updatedAt := UTCNow()
+		cr.UpdatedAt = &updatedAt
 	}
 
 	switch opts.status {
@@ -1629,6 +1800,12 @@ func (store *IAMStoreSys) AddUser(ctx context.Context, accessKey string, ureq ma
 		return errIAMActionNotAllowed
 	}
 
+	now := UTCNow()
+	updatedAt := &now
+	if ok && cr.SecretKey == ureq.SecretKey {
+		updatedAt = cr.UpdatedAt
+	}
+
 	u := newUserIdentity(auth.Credentials{
 		AccessKey: accessKey,
 		SecretKey: ureq.SecretKey,
@@ -1638,6 +1815,7 @@ func (store *IAMStoreSys) AddUser(ctx context.Context, accessKey string, ureq ma
 			}
 			return auth.AccountOff
 		}(),
+		UpdatedAt: updatedAt,
 	})
 
 	if err := store.saveUserIdentity(ctx, accessKey, regUser, u); err != nil {
@@ -1660,6 +1838,8 @@ func (store *IAMStoreSys) UpdateUserSecretKey(ctx context.Context, accessKey, se
 	}
 
 	cred.SecretKey = secretKey
+	updatedAt := UTCNow()
+	cred.UpdatedAt = &updatedAt
 	u := newUserIdentity(cred)
 	if err := store.saveUserIdentity(ctx, accessKey, regUser, u); err != nil {
 		return err
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/minio/minio/internal/auth"
+)
+
+func TestIAMCacheNestedGroupPolicies(t *testing.T) {
+	c := newIamCache()
+	c.iamUsersMap["alice"] = auth.Credentials{
+		AccessKey: "alice",
+		SecretKey: "alice-secret",
+		Status:    auth.AccountOn,
+	}
+	c.iamGroupsMap = map[string]GroupInfo{
+		"org":         {Version: 1, Status: statusEnabled, Subgroups: []string{"engineering"}},
+		"engineering": {Version: 1, Status: statusEnabled, Subgroups: []string{"storage"}},
+		"storage":     {Version: 1, Status: statusEnabled, Members: []string{"alice"}},
+		"archived":    {Version: 1, Status: statusDisabled, Subgroups: []string{"storage"}},
+	}
+	c.iamGroupPolicyMap = map[string]MappedPolicy{
+		"org":         newMappedPolicy("readonly"),
+		"engineering": newMappedPolicy("diagnostics"),
+		"storage":     newMappedPolicy("writeonly"),
+		"archived":    newMappedPolicy("consoleAdmin"),
+	}
+	c.buildUserGroupMemberships()
+
+	policies, err := c.policyDBGet(MinIOUsersSysType, "alice", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(policies)
+	if expected := []string{"diagnostics", "readonly", "writeonly"}; !reflect.DeepEqual(policies, expected) {
+		t.Fatalf("expected user policies %v, got %v", expected, policies)
+	}
+
+	policies, err = c.policyDBGet(MinIOUsersSysType, "engineering", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(policies)
+	if expected := []string{"diagnostics", "readonly"}; !reflect.DeepEqual(policies, expected) {
+		t.Fatalf("expected group policies %v, got %v", expected, policies)
+	}
+
+	// A disabled group in the middle stops inheritance.
+	gi := c.iamGroupsMap["engineering"]
+	gi.Status = statusDisabled
+	c.iamGroupsMap["engineering"] = gi
+	policies, err = c.policyDBGet(MinIOUsersSysType, "alice", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"writeonly"}; !reflect.DeepEqual(policies, expected) {
+		t.Fatalf("expected user policies %v, got %v", expected, policies)
+	}
+
+	if !c.isNestedIn("storage", "org") || c.isNestedIn("org", "storage") {
+		t.Fatal("unexpected nesting of groups")
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/minio/minio/internal/logger"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	etcd "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // UsersSysType - defines the type of users and groups system that is
@@ -77,6 +78,10 @@ type IAMSys struct {
 
 	// configLoaded will be closed and remain so after first load.
 	configLoaded chan struct{}
+
+	// rotationNotices tracks the credential rotation status
+	// already reported for each access key.
+	rotationNotices credentialRotationNotices
 }
 
 // IAMUserType represents a user type inside MinIO server
@@ -319,6 +324,9 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer, etcdClient *etc
 	// Start watching changes to storage.
 	go sys.watch(ctx)
 
+	// Start enforcing the maximum age of access keys.
+	go sys.watchCredentialRotation(ctx, objAPI)
+
 	// Load RoleARN
 	if roleARN, rolePolicy, enabled := globalOpenIDConfig.GetRoleInfo(); enabled {
 		numPolicies := len(strings.Split(rolePolicy, ","))
@@ -456,11 +464,20 @@ func (sys *IAMSys) DeletePolicy(ctx context.Context, policyName string, notifyPe
 		return errServerNotInitialized
 	}
 
+	var before map[string]interface{}
+	if notifyPeers {
+		before = sys.iamEntityState(ctx, iamChangePolicy, policyName)
+	}
+
 	err := sys.store.DeletePolicy(ctx, policyName)
 	if err != nil {
 		return err
 	}
 
+	if notifyPeers {
+		sys.notifyIAMChange(ctx, iamChangePolicy, policyName, before)
+	}
+
 	if !notifyPeers || sys.HasWatcher() {
 		return nil
 	}
@@ -517,10 +534,12 @@ func (sys *IAMSys) SetPolicy(ctx context.Context, policyName string, p iampolicy
 		return errServerNotInitialized
 	}
 
+	before := sys.iamEntityState(ctx, iamChangePolicy, policyName)
 	err := sys.store.SetPolicy(ctx, policyName, p)
 	if err != nil {
 		return err
 	}
+	sys.notifyIAMChange(ctx, iamChangePolicy, policyName, before)
 
 	if !sys.HasWatcher() {
 		// Notify all other MinIO peers to reload policy
@@ -540,10 +559,19 @@ func (sys *IAMSys) DeleteUser(ctx context.Context, accessKey string, notifyPeers
 		return errServerNotInitialized
 	}
 
+	var before map[string]interface{}
+	if notifyPeers {
+		before = sys.iamEntityState(ctx, iamChangeUser, accessKey)
+	}
+
 	if err := sys.store.DeleteUser(ctx, accessKey, regUser); err != nil {
 		return err
 	}
 
+	if notifyPeers {
+		sys.notifyIAMChange(ctx, iamChangeUser, accessKey, before)
+	}
+
 	// Notify all other MinIO peers to delete user.
 	if notifyPeers && !sys.HasWatcher() {
 		for _, nerr := range sys.notificationSys.DeleteUser(accessKey) {
@@ -723,11 +751,13 @@ func (sys *IAMSys) SetUserStatus(ctx context.Context, accessKey string, status m
 		return errIAMActionNotAllowed
 	}
 
+	before := sys.iamEntityState(ctx, iamChangeUser, accessKey)
 	err := sys.store.SetUserStatus(ctx, accessKey, status)
 	if err != nil {
 		return err
 	}
 
+	sys.notifyIAMChange(ctx, iamChangeUser, accessKey, before)
 	sys.notifyForUser(ctx, accessKey, false)
 	return nil
 }
@@ -821,6 +851,7 @@ func (sys *IAMSys) NewServiceAccount(ctx context.Context, parentUser string, gro
 		return auth.Credentials{}, err
 	}
 
+	sys.notifyIAMChange(ctx, iamChangeServiceAccount, cred.AccessKey, nil)
 	sys.notifyForServiceAccount(ctx, cred.AccessKey)
 	return cred, nil
 }
@@ -837,11 +868,13 @@ func (sys *IAMSys) UpdateServiceAccount(ctx context.Context, accessKey string, o
 		return errServerNotInitialized
 	}
 
+	before := sys.iamEntityState(ctx, iamChangeServiceAccount, accessKey)
 	err := sys.store.UpdateServiceAccount(ctx, accessKey, opts)
 	if err != nil {
 		return err
 	}
 
+	sys.notifyIAMChange(ctx, iamChangeServiceAccount, accessKey, before)
 	sys.notifyForServiceAccount(ctx, accessKey)
 	return nil
 }
@@ -935,10 +968,19 @@ func (sys *IAMSys) DeleteServiceAccount(ctx context.Context, accessKey string, n
 		return nil
 	}
 
+	var before map[string]interface{}
+	if notifyPeers {
+		before = sys.iamEntityState(ctx, iamChangeServiceAccount, accessKey)
+	}
+
 	if err := sys.store.DeleteUser(ctx, accessKey, svcUser); err != nil {
 		return err
 	}
 
+	if notifyPeers {
+		sys.notifyIAMChange(ctx, iamChangeServiceAccount, accessKey, before)
+	}
+
 	if notifyPeers && !sys.HasWatcher() {
 		for _, nerr := range sys.notificationSys.DeleteServiceAccount(accessKey) {
 			if nerr.Err != nil {
@@ -970,11 +1012,13 @@ func (sys *IAMSys) CreateUser(ctx context.Context, accessKey string, ureq madmin
 		return auth.ErrInvalidSecretKeyLength
 	}
 
+	before := sys.iamEntityState(ctx, iamChangeUser, accessKey)
 	err := sys.store.AddUser(ctx, accessKey, ureq)
 	if err != nil {
 		return err
 	}
 
+	sys.notifyIAMChange(ctx, iamChangeUser, accessKey, before)
 	sys.notifyForUser(ctx, accessKey, false)
 	return nil
 }
@@ -997,7 +1041,13 @@ func (sys *IAMSys) SetUserSecretKey(ctx context.Context, accessKey string, secre
 		return auth.ErrInvalidSecretKeyLength
 	}
 
-	return sys.store.UpdateUserSecretKey(ctx, accessKey, secretKey)
+	before := sys.iamEntityState(ctx, iamChangeUser, accessKey)
+	if err := sys.store.UpdateUserSecretKey(ctx, accessKey, secretKey); err != nil {
+		return err
+	}
+
+	sys.notifyIAMChange(ctx, iamChangeUser, accessKey, before)
+	return nil
 }
 
 // purgeExpiredCredentialsForExternalSSO - validates if local credentials are still valid
@@ -1127,6 +1177,12 @@ func (sys *IAMSys) GetUser(ctx context.Context, accessKey string) (cred auth.Cre
 		return cred, false
 	}
 
+	ctx, span := startSpan(ctx, "iam.GetUser")
+	defer func() {
+		span.SetAttributes(attribute.Bool("iam.found", ok))
+		span.End()
+	}()
+
 	fallback := false
 	select {
 	case <-sys.configLoaded:
@@ -1173,11 +1229,13 @@ func (sys *IAMSys) AddUsersToGroup(ctx context.Context, group string, members []
 		return errIAMActionNotAllowed
 	}
 
+	before := sys.iamEntityState(ctx, iamChangeGroup, group)
 	err := sys.store.AddUsersToGroup(ctx, group, members)
 	if err != nil {
 		return err
 	}
 
+	sys.notifyIAMChange(ctx, iamChangeGroup, group, before)
 	sys.notifyForGroup(ctx, group)
 	return nil
 }
@@ -1193,11 +1251,64 @@ func (sys *IAMSys) RemoveUsersFromGroup(ctx context.Context, group string, membe
 		return errIAMActionNotAllowed
 	}
 
+	// Deleting a group also updates the groups it is nested in.
+	var parents []string
+	if len(members) == 0 {
+		_, parents, _ = sys.store.GetGroupNesting(group)
+	}
+
+	before := sys.iamEntityState(ctx, iamChangeGroup, group)
 	err := sys.store.RemoveUsersFromGroup(ctx, group, members)
 	if err != nil {
 		return err
 	}
 
+	sys.notifyIAMChange(ctx, iamChangeGroup, group, before)
+	sys.notifyForGroup(ctx, group)
+	for _, parent := range parents {
+		sys.notifyForGroup(ctx, parent)
+	}
+	return nil
+}
+
+// AddSubgroups - nests groups in group, creating group if needed.
+func (sys *IAMSys) AddSubgroups(ctx context.Context, group string, subgroups []string) error {
+	if !sys.Initialized() {
+		return errServerNotInitialized
+	}
+
+	if sys.usersSysType != MinIOUsersSysType {
+		return errIAMActionNotAllowed
+	}
+
+	before := sys.iamEntityState(ctx, iamChangeGroup, group)
+	err := sys.store.AddSubgroups(ctx, group, subgroups)
+	if err != nil {
+		return err
+	}
+
+	sys.notifyIAMChange(ctx, iamChangeGroup, group, before)
+	sys.notifyForGroup(ctx, group)
+	return nil
+}
+
+// RemoveSubgroups - removes nested groups from group.
+func (sys *IAMSys) RemoveSubgroups(ctx context.Context, group string, subgroups []string) error {
+	if !sys.Initialized() {
+		return errServerNotInitialized
+	}
+
+	if sys.usersSysType != MinIOUsersSysType {
+		return errIAMActionNotAllowed
+	}
+
+	before := sys.iamEntityState(ctx, iamChangeGroup, group)
+	err := sys.store.RemoveSubgroups(ctx, group, subgroups)
+	if err != nil {
+		return err
+	}
+
+	sys.notifyIAMChange(ctx, iamChangeGroup, group, before)
 	sys.notifyForGroup(ctx, group)
 	return nil
 }
@@ -1212,11 +1323,13 @@ func (sys *IAMSys) SetGroupStatus(ctx context.Context, group string, enabled boo
 		return errIAMActionNotAllowed
 	}
 
+	before := sys.iamEntityState(ctx, iamChangeGroup, group)
 	err := sys.store.SetGroupStatus(ctx, group, enabled)
 	if err != nil {
 		return err
 	}
 
+	sys.notifyIAMChange(ctx, iamChangeGroup, group, before)
 	sys.notifyForGroup(ctx, group)
 	return nil
 }
@@ -1230,6 +1343,20 @@ func (sys *IAMSys) GetGroupDescription(group string) (gd madmin.GroupDesc, err e
 	return sys.store.GetGroupDescription(group)
 }
 
+// GetGroupNesting - returns the direct subgroups of group and the
+// groups it is directly nested in.
+func (sys *IAMSys) GetGroupNesting(group string) (subgroups, parents []string, err error) {
+	if !sys.Initialized() {
+		return nil, nil, errServerNotInitialized
+	}
+
+	if sys.usersSysType != MinIOUsersSysType {
+		return nil, nil, nil
+	}
+
+	return sys.store.GetGroupNesting(group)
+}
+
 // ListGroups - lists groups.
 func (sys *IAMSys) ListGroups(ctx context.Context) (r []string, err error) {
 	if !sys.Initialized() {
@@ -1253,10 +1380,16 @@ func (sys *IAMSys) PolicyDBSet(ctx context.Context, name, policy string, isGroup
 		userType = stsUser
 	}
 
+	entityType := iamChangeUserPolicyMapping
+	if isGroup {
+		entityType = iamChangeGroupPolicyMapping
+	}
+	before := sys.iamEntityState(ctx, entityType, name)
 	err := sys.store.PolicyDBSet(ctx, name, policy, userType, isGroup)
 	if err != nil {
 		return err
 	}
+	sys.notifyIAMChange(ctx, entityType, name, before)
 
 	// Notify all other MinIO peers to reload policy
 	if !sys.HasWatcher() {
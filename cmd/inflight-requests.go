@@ -0,0 +1,252 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/handlers"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// inflightRequest - an API request which is currently executing.
+type inflightRequest struct {
+	// bytes received and sent so far, accessed atomically.
+	bytesReceived int64
+	bytesSent     int64
+
+	id         string
+	api        string
+	bucket     string
+	object     string
+	accessKey  string
+	remoteHost string
+	startTime  time.Time
+	cancel     context.CancelFunc
+}
+
+// inflightRequestInfo - an API request currently executing on a node.
+type inflightRequestInfo struct {
+	ID            string        `json:"id"`
+	API           string        `json:"api"`
+	Bucket        string        `json:"bucket,omitempty"`
+	Object        string        `json:"object,omitempty"`
+	AccessKey     string        `json:"accessKey,omitempty"`
+	RemoteHost    string        `json:"remoteHost"`
+	StartTime     time.Time     `json:"startTime"`
+	Elapsed       time.Duration `json:"elapsed"`
+	BytesReceived int64         `json:"bytesReceived"`
+	BytesSent     int64         `json:"bytesSent"`
+}
+
+// inflightRequestsStatus - the API requests currently executing on a node.
+type inflightRequestsStatus struct {
+	Node     string                `json:"node"`
+	Requests []inflightRequestInfo `json:"requests"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// inflightRequests - keeps track of the API requests executing on this
+// node by their request ID.
+type inflightRequests struct {
+	sync.Mutex
+	requests map[string]*inflightRequest
+}
+
+var globalInflightRequests = &inflightRequests{requests: make(map[string]*inflightRequest)}
+
+// add - registers the request, returns false if the ID is unknown or
+// already taken.
+func (t *inflightRequests) add(req *inflightRequest) bool {
+	if req.id == "" {
+		return false
+	}
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.requests[req.id]; ok {
+		return false
+	}
+	t.requests[req.id] = req
+	return true
+}
+
+func (t *inflightRequests) remove(req *inflightRequest) {
+	t.Lock()
+	if t.requests[req.id] == req {
+		delete(t.requests, req.id)
+	}
+	t.Unlock()
+}
+
+// cancel - cancels the request with the ID, returns false if there is
+// no such request.
+func (t *inflightRequests) cancel(id string) bool {
+	t.Lock()
+	req, ok := t.requests[id]
+	t.Unlock()
+	if ok {
+		req.cancel()
+	}
+	return ok
+}
+
+// Status - returns the executing requests, longest running first.
+func (t *inflightRequests) Status() inflightRequestsStatus {
+	now := time.Now()
+	t.Lock()
+	requests := make([]inflightRequestInfo, 0, len(t.requests))
+	for _, req := range t.requests {
+		requests = append(requests, inflightRequestInfo{
+			ID:            req.id,
+			API:           req.api,
+			Bucket:        req.bucket,
+			Object:        req.object,
+			AccessKey:     req.accessKey,
+			RemoteHost:    req.remoteHost,
+			StartTime:     req.startTime,
+			Elapsed:       now.Sub(req.startTime),
+			BytesReceived: atomic.LoadInt64(&req.bytesReceived),
+			BytesSent:     atomic.LoadInt64(&req.bytesSent),
+		})
+	}
+	t.Unlock()
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].StartTime.Before(requests[j].StartTime)
+	})
+	return inflightRequestsStatus{Node: globalLocalNodeName, Requests: requests}
+}
+
+// track - registers the request while it executes. The returned
+// request and response writer count the transferred bytes and fail
+// once the request is canceled.
+func (t *inflightRequests) track(api string, w http.ResponseWriter, r *http.Request) (http.ResponseWriter, *http.Request, func()) {
+	ctx, cancel := context.WithCancel(r.Context())
+	vars := mux.Vars(r)
+	req := &inflightRequest{
+		id:         w.Header().Get(xhttp.AmzRequestID),
+		api:        api,
+		bucket:     vars["bucket"],
+		object:     vars["object"],
+		accessKey:  inflightAccessKey(r),
+		remoteHost: handlers.GetSourceIP(r),
+		startTime:  time.Now(),
+		cancel:     cancel,
+	}
+	if !t.add(req) {
+		cancel()
+		return w, r, func() {}
+	}
+
+	r = r.WithContext(ctx)
+	r.Body = &inflightRequestBody{ReadCloser: r.Body, ctx: ctx, n: &req.bytesReceived}
+	w = &inflightResponseWriter{ResponseWriter: w, ctx: ctx, n: &req.bytesSent}
+	return w, r, func() {
+		t.remove(req)
+		cancel()
+	}
+}
+
+// inflightAccessKey - returns the access key the request claims to be
+// signed with, without verifying the signature.
+func inflightAccessKey(r *http.Request) string {
+	if auth := r.Header.Get(xhttp.Authorization); auth != "" {
+		switch {
+		case strings.HasPrefix(auth, signV4Algorithm):
+			i := strings.Index(auth, "Credential=")
+			if i < 0 {
+				return ""
+			}
+			cred := auth[i+len("Credential="):]
+			if i := strings.IndexByte(cred, '/'); i >= 0 {
+				return cred[:i]
+			}
+		case strings.HasPrefix(auth, signV2Algorithm+" "):
+			cred := strings.TrimPrefix(auth, signV2Algorithm+" ")
+			if i := strings.IndexByte(cred, ':'); i >= 0 {
+				return cred[:i]
+			}
+		}
+		return ""
+	}
+	query := r.URL.Query()
+	if cred := query.Get(xhttp.AmzCredential); cred != "" {
+		if i := strings.IndexByte(cred, '/'); i >= 0 {
+			return cred[:i]
+		}
+		return ""
+	}
+	return query.Get(xhttp.AmzAccessKeyID)
+}
+
+// inflightRequestBody counts the bytes read from the request body.
+type inflightRequestBody struct {
+	io.ReadCloser
+	ctx context.Context
+	n   *int64
+}
+
+func (b *inflightRequestBody) Read(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := b.ReadCloser.Read(p)
+	atomic.AddInt64(b.n, int64(n))
+	return n, err
+}
+
+// inflightResponseWriter counts the bytes written to the response.
+type inflightResponseWriter struct {
+	http.ResponseWriter
+	ctx context.Context
+	n   *int64
+}
+
+func (w *inflightResponseWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := w.ResponseWriter.Write(p)
+	atomic.AddInt64(w.n, int64(n))
+	return n, err
+}
+
+// Flush - calls the underlying Flush.
+func (w *inflightResponseWriter) Flush() {
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+// Hijack - calls the underlying Hijack.
+func (w *inflightResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return hj.Hijack()
+}
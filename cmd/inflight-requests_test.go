@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestInflightRequests(t *testing.T) {
+	tracker := &inflightRequests{requests: make(map[string]*inflightRequest)}
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set(xhttp.AmzRequestID, "16A2E4B5C3D1F000")
+	r := httptest.NewRequest(http.MethodPut, "/bucket/object", strings.NewReader("hello world"))
+	r.Header.Set(xhttp.Authorization, "AWS4-HMAC-SHA256 Credential=minio/20220101/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abcd")
+
+	w, r, done := tracker.track("PutObject", rec, r)
+	buf := make([]byte, 5)
+	if _, err := io.ReadFull(r.Body, buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("ok")); err != nil {
+		t.Fatal(err)
+	}
+
+	status := tracker.Status()
+	if len(status.Requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(status.Requests))
+	}
+	req := status.Requests[0]
+	if req.ID != "16A2E4B5C3D1F000" || req.API != "PutObject" || req.AccessKey != "minio" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	if req.BytesReceived != 5 || req.BytesSent != 2 {
+		t.Fatalf("expected 5 bytes received and 2 sent, got %d and %d", req.BytesReceived, req.BytesSent)
+	}
+
+	if tracker.cancel("unknown") {
+		t.Fatal("expected canceling an unknown request to fail")
+	}
+	if !tracker.cancel(req.ID) {
+		t.Fatal("expected the request to be canceled")
+	}
+	if !errors.Is(r.Context().Err(), context.Canceled) {
+		t.Fatalf("expected the request context to be canceled, got %v", r.Context().Err())
+	}
+	if _, err := r.Body.Read(buf); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected reading a canceled request to fail, got %v", err)
+	}
+	if _, err := w.Write([]byte("ok")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected writing a canceled request to fail, got %v", err)
+	}
+
+	done()
+	if status := tracker.Status(); len(status.Requests) != 0 {
+		t.Fatalf("expected no requests, got %v", status.Requests)
+	}
+}
+
+func TestInflightAccessKey(t *testing.T) {
+	testCases := []struct {
+		header    string
+		query     string
+		accessKey string
+	}{
+		{"AWS4-HMAC-SHA256 Credential=minio/20220101/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abcd", "", "minio"},
+		{"AWS minio:c2lnbmF0dXJl", "", "minio"},
+		{"", "X-Amz-Credential=minio%2F20220101%2Fus-east-1%2Fs3%2Faws4_request", "minio"},
+		{"", "AWSAccessKeyId=minio&Signature=abcd", "minio"},
+		{"Bearer token", "", ""},
+		{"", "", ""},
+	}
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/bucket/object?"+testCase.query, nil)
+		if testCase.header != "" {
+			r.Header.Set(xhttp.Authorization, testCase.header)
+		}
+		if accessKey := inflightAccessKey(r); accessKey != testCase.accessKey {
+			t.Errorf("test %d: expected %q, got %q", i+1, testCase.accessKey, accessKey)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/certs"
+)
+
+const (
+	// internodeServerName is the TLS server name internode clients
+	// send and verify. It is part of every internode certificate, so
+	// servers can tell internode connections from client connections.
+	internodeServerName = "internode.minio.local"
+
+	// Lifetime of internode certificates. Certificates are renewed
+	// once half of their lifetime has passed.
+	internodeCertValidity = 24 * time.Hour
+)
+
+var errInternodeCertificate = errors.New("internode client certificate is missing or invalid")
+
+// internodeCA issues short-lived certificates for node-to-node mTLS.
+// The CA key is derived from the root credentials, so all nodes of a
+// deployment share the CA without distributing any key material.
+// Each node signs its own certificate with the CA key.
+type internodeCA struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+	pool *x509.CertPool
+
+	dnsNames []string
+	ips      []net.IP
+
+	mu   sync.RWMutex
+	leaf *tls.Certificate
+}
+
+// newInternodeCA returns the internode CA of the deployment with the given
+// root credentials. It issues the first certificate of this node for the
+// given host names and IP addresses.
+func newInternodeCA(cred auth.Credentials, hosts []string) (*internodeCA, error) {
+	if !cred.IsValid() {
+		return nil, errors.New("internode CA requires valid root credentials")
+	}
+	mac := hmac.New(sha256.New, []byte(cred.SecretKey))
+	mac.Write([]byte("MinIO internode CA:" + cred.AccessKey))
+
+	curve := elliptic.P256()
+	d := new(big.Int).SetBytes(mac.Sum(nil))
+	d.Mod(d, new(big.Int).Sub(curve.Params().N, big.NewInt(1)))
+	d.Add(d, big.NewInt(1))
+	key := &ecdsa.PrivateKey{D: d}
+	key.PublicKey.Curve = curve
+	key.PublicKey.X, key.PublicKey.Y = curve.ScalarBaseMult(d.Bytes())
+
+	// The subject key id is derived from the public key, so the CA
+	// certificates created by the individual nodes are interchangeable.
+	skid := sha256.Sum256(elliptic.Marshal(curve, key.PublicKey.X, key.PublicKey.Y))
+	now := UTCNow()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "MinIO Internode CA"},
+		SubjectKeyId:          skid[:20],
+		NotBefore:             now.Add(-DefaultSkewTime),
+		NotAfter:              now.Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &internodeCA{
+		key:      key,
+		cert:     cert,
+		pool:     x509.NewCertPool(),
+		dnsNames: []string{internodeServerName},
+	}
+	ca.pool.AddCert(cert)
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			ca.ips = append(ca.ips, ip)
+		} else if host != "" {
+			ca.dnsNames = append(ca.dnsNames, host)
+		}
+	}
+	if err = ca.renew(now); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+// renew issues a new certificate for this node if the current one
+// has passed half of its lifetime.
+func (ca *internodeCA) renew(now time.Time) error {
+	ca.mu.RLock()
+	leaf := ca.leaf
+	ca.mu.RUnlock()
+	if leaf != nil && now.Before(leaf.Leaf.NotBefore.Add(DefaultSkewTime+internodeCertValidity/2)) {
+		return nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: internodeServerName},
+		DNSNames:     ca.dnsNames,
+		IPAddresses:  ca.ips,
+		NotBefore:    now.Add(-DefaultSkewTime),
+		NotAfter:     now.Add(internodeCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return err
+	}
+
+	ca.mu.Lock()
+	ca.leaf = &tls.Certificate{
+		Certificate: [][]byte{der, ca.cert.Raw},
+		PrivateKey:  key,
+		Leaf:        cert,
+	}
+	ca.mu.Unlock()
+	return nil
+}
+
+// certificate returns the current certificate of this node.
+func (ca *internodeCA) certificate() *tls.Certificate {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+	return ca.leaf
+}
+
+// GetCertificate returns a certificate function serving the internode
+// certificate to internode clients and to all clients if there is no
+// other certificate.
+func (ca *internodeCA) GetCertificate(getCert certs.GetCertificateFunc) certs.GetCertificateFunc {
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		if getCert == nil || hello.ServerName == internodeServerName {
+			return ca.certificate(), nil
+		}
+		return getCert(hello)
+	}
+}
+
+// verifyClient returns an error unless the peer of the connection
+// presented a valid internode certificate.
+func (ca *internodeCA) verifyClient(state *tls.ConnectionState) error {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return errInternodeCertificate
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       internodeServerName,
+		Roots:         ca.pool,
+		Intermediates: intermediates,
+		CurrentTime:   UTCNow(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return errInternodeCertificate
+	}
+	return nil
+}
+
+// getInternodeClientCertificate presents the internode certificate
+// of this node to its peers.
+func getInternodeClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if globalInternodeCA == nil {
+		return &tls.Certificate{}, nil
+	}
+	return globalInternodeCA.certificate(), nil
+}
+
+// verifyInternodeRequest verifies the client certificate of internode
+// requests if internode mTLS is enabled.
+func verifyInternodeRequest(state *tls.ConnectionState) error {
+	if globalInternodeCA == nil {
+		return nil
+	}
+	return globalInternodeCA.verifyClient(state)
+}
+
+// internodeCAHosts returns the host names and IP addresses of the local
+// endpoints, which are part of the internode certificate of this node.
+func internodeCAHosts(endpointServerPools EndpointServerPools) []string {
+	hosts := set.CreateStringSet("localhost")
+	if globalMinioHost != "" {
+		hosts.Add(globalMinioHost)
+	}
+	for _, ip := range mustGetLocalIP4().ToSlice() {
+		hosts.Add(ip)
+	}
+	for _, ep := range endpointServerPools {
+		for _, endpoint := range ep.Endpoints {
+			if endpoint.IsLocal && endpoint.Host != "" {
+				hosts.Add(endpoint.Hostname())
+			}
+		}
+	}
+	return hosts.ToSlice()
+}
+
+// initInternodeCA starts renewing the certificate of this node.
+func initInternodeCA(ctx context.Context, ca *internodeCA) {
+	go func() {
+		t := time.NewTicker(time.Minute)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				logger.LogIf(ctx, ca.renew(UTCNow()))
+			}
+		}
+	}()
+}
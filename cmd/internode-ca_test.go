@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/internal/auth"
+)
+
+func TestInternodeCA(t *testing.T) {
+	cred := auth.Credentials{AccessKey: "minioadmin", SecretKey: "minioadmin"}
+	server, err := newInternodeCA(cred, []string{"node1", "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer, err := newInternodeCA(cred, []string{"node2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := newInternodeCA(auth.Credentials{AccessKey: "minioadmin", SecretKey: "othersecret"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := server.verifyClient(r.TLS); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	ts.TLS = &tls.Config{
+		GetCertificate: server.GetCertificate(nil),
+		ClientAuth:     tls.RequestClientCert,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	get := func(roots, client *internodeCA) (int, error) {
+		tr := &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:    roots.pool,
+			ServerName: internodeServerName,
+			GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return client.certificate(), nil
+			},
+		}}
+		defer tr.CloseIdleConnections()
+		resp, err := (&http.Client{Transport: tr}).Get(ts.URL)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	// Nodes of the same deployment trust each other.
+	if status, err := get(peer, peer); err != nil || status != http.StatusOK {
+		t.Fatalf("expected the peer to be accepted: %d %v", status, err)
+	}
+	// Certificates of other deployments are rejected.
+	if status, err := get(peer, other); err != nil || status != http.StatusForbidden {
+		t.Fatalf("expected the client certificate to be rejected: %d %v", status, err)
+	}
+	if _, err := get(other, other); err == nil {
+		t.Fatal("expected the server certificate to be rejected")
+	}
+}
+
+func TestInternodeCARenew(t *testing.T) {
+	ca, err := newInternodeCA(auth.Credentials{AccessKey: "minioadmin", SecretKey: "minioadmin"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := ca.certificate()
+
+	if err = ca.renew(UTCNow().Add(internodeCertValidity / 4)); err != nil {
+		t.Fatal(err)
+	}
+	if ca.certificate() != leaf {
+		t.Fatal("certificate renewed before half of its lifetime")
+	}
+	if err = ca.renew(UTCNow().Add(internodeCertValidity / 2)); err != nil {
+		t.Fatal(err)
+	}
+	if renewed := ca.certificate(); renewed == leaf || !renewed.Leaf.NotAfter.After(leaf.Leaf.NotAfter) {
+		t.Fatal("certificate has not been renewed")
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/internal/kms"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// kmsAuditTrigger is the audit entry trigger of key usage records.
+const kmsAuditTrigger = "kms"
+
+// kmsAuditRecord is a single key usage record. Each record contains
+// the hash of its predecessor, such that removing or modifying a record
+// breaks the chain. A chain starts with sequence number 1 and an empty
+// previous hash whenever the server starts.
+type kmsAuditRecord struct {
+	Sequence  uint64 `json:"sequence"`
+	Time      string `json:"time"`
+	Node      string `json:"node"`
+	Operation string `json:"operation"`
+	KeyID     string `json:"keyId"`
+	Bucket    string `json:"bucket,omitempty"`
+	Object    string `json:"object,omitempty"`
+	Principal string `json:"principal,omitempty"`
+	Error     string `json:"error,omitempty"`
+	PrevHash  string `json:"prevHash"`
+}
+
+// hash returns the hex-encoded SHA-256 hash of the JSON encoded record.
+func (r kmsAuditRecord) hash() string {
+	data, _ := json.Marshal(r)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// kmsAuditLog hash-chains key usage records and sends them
+// to the audit targets.
+type kmsAuditLog struct {
+	mu       sync.Mutex
+	sequence uint64
+	lastHash string
+}
+
+var globalKMSAuditLog = &kmsAuditLog{}
+
+// next appends a record for the key usage to the chain and
+// returns it together with its hash.
+func (l *kmsAuditLog) next(usage kms.KeyUsage) (kmsAuditRecord, string) {
+	bucket, object := kmsContextObject(usage.Context)
+	record := kmsAuditRecord{
+		Time:      UTCNow().Format(iso8601TimeFormat),
+		Node:      globalLocalNodeName,
+		Operation: usage.Operation,
+		KeyID:     usage.KeyID,
+		Bucket:    bucket,
+		Object:    object,
+		Principal: usage.Principal,
+	}
+	if usage.Err != nil {
+		record.Error = usage.Err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sequence++
+	record.Sequence = l.sequence
+	record.PrevHash = l.lastHash
+	l.lastHash = record.hash()
+	return record, l.lastHash
+}
+
+// log records the key usage and sends it to all audit targets.
+func (l *kmsAuditLog) log(usage kms.KeyUsage) {
+	record, hash := l.next(usage)
+
+	entry := audit.NewEntry(globalDeploymentID)
+	entry.Trigger = kmsAuditTrigger
+	entry.API.Name = "KMS." + record.Operation
+	entry.API.Bucket = record.Bucket
+	entry.API.Object = record.Object
+	entry.Tags = map[string]interface{}{
+		"keyUsage": record,
+		"hash":     hash,
+	}
+	for _, t := range logger.AuditTargets() {
+		if err := t.Send(entry, string(logger.All)); err != nil {
+			logger.LogAlwaysIf(GlobalContext, fmt.Errorf("key usage record %d was not sent to audit target (%v): %w", record.Sequence, t, err), logger.All)
+		}
+	}
+}
+
+// kmsContextObject returns the bucket and object a KMS context
+// refers to, if any. Object keys are bound to a context mapping
+// the bucket to the bucket/object path.
+func kmsContextObject(ctx kms.Context) (bucket, object string) {
+	for k, v := range ctx {
+		if strings.HasPrefix(v, k+SlashSeparator) {
+			return k, strings.TrimPrefix(v, k+SlashSeparator)
+		}
+	}
+	return "", ""
+}
+
+// kmsForRequest returns GlobalKMS attributing all key usage
+// to the principal of the request and tracing it as part of
+// the request.
+func kmsForRequest(ctx context.Context) kms.KMS {
+	reqInfo := logger.GetReqInfo(ctx)
+	if reqInfo == nil {
+		return kms.WithTracing(ctx, GlobalKMS)
+	}
+	return kms.WithTracing(ctx, kms.WithPrincipal(GlobalKMS, reqInfo.AccessKey))
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/kms"
+)
+
+func TestKMSAuditLogChain(t *testing.T) {
+	secretKMS, err := kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		auditLog kmsAuditLog
+		records  []kmsAuditRecord
+		hashes   []string
+	)
+	KMS := kms.NewAudit(secretKMS, func(usage kms.KeyUsage) {
+		record, hash := auditLog.next(usage)
+		records = append(records, record)
+		hashes = append(hashes, hash)
+	})
+
+	context := kms.Context{"bucket": "bucket/dir/object"}
+	key, err := kms.WithPrincipal(KMS, "alice").GenerateKey("", context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, context); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, kms.Context{}); err == nil {
+		t.Fatal("expected decryption with a different context to fail")
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	first := records[0]
+	if first.Operation != kms.OpGenerateKey || first.KeyID != "my-minio-key" || first.Principal != "alice" {
+		t.Errorf("unexpected record %+v", first)
+	}
+	if first.Bucket != "bucket" || first.Object != "dir/object" {
+		t.Errorf("unexpected bucket/object %q/%q", first.Bucket, first.Object)
+	}
+	if records[1].Operation != kms.OpDecryptKey || records[1].Principal != "" || records[1].Error != "" {
+		t.Errorf("unexpected record %+v", records[1])
+	}
+	if records[2].Error == "" {
+		t.Errorf("expected failed decryption to be recorded with an error")
+	}
+
+	for i, record := range records {
+		if record.Sequence != uint64(i+1) {
+			t.Errorf("record %d: unexpected sequence number %d", i, record.Sequence)
+		}
+		if record.hash() != hashes[i] {
+			t.Errorf("record %d: hash mismatch", i)
+		}
+		if i > 0 && record.PrevHash != hashes[i-1] {
+			t.Errorf("record %d: not chained to its predecessor", i)
+		}
+	}
+
+	// Tampering with a record must break the chain.
+	tampered := records[0]
+	tampered.Principal = "bob"
+	if tampered.hash() == records[1].PrevHash {
+		t.Error("tampered record still matches the chain")
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/kms"
+	"github.com/minio/minio/internal/logger"
+)
+
+const kmsImportedKeysFile = "imported-keys.json"
+
+var kmsImportedKeysPath = path.Join(minioConfigPrefix, "kms", kmsImportedKeysFile)
+
+var (
+	errKMSKeyAlreadyImported = AdminError{
+		Code:       "XMinioKMSKeyAlreadyImported",
+		Message:    "A key with this key ID has already been imported",
+		StatusCode: http.StatusConflict,
+	}
+	errKMSImportInvalidKey = AdminError{
+		Code:       "XMinioKMSInvalidImportKey",
+		Message:    "Imported key material must be a 256 bit key",
+		StatusCode: http.StatusBadRequest,
+	}
+	errKMSImportNoBuckets = AdminError{
+		Code:       "XMinioKMSInvalidImportKey",
+		Message:    "An imported key must be restricted to at least one bucket",
+		StatusCode: http.StatusBadRequest,
+	}
+	errKMSImportNotSupported = AdminError{
+		Code:       "XMinioKMSImportNotSupported",
+		Message:    "The KMS does not support importing keys",
+		StatusCode: http.StatusNotImplemented,
+	}
+)
+
+// KMSImportedKey describes key material imported into the KMS.
+// Imported keys may only be used for SSE-KMS on the listed
+// buckets.
+type KMSImportedKey struct {
+	KeyID      string    `json:"keyId"`
+	Buckets    []string  `json:"buckets"`
+	ImportedAt time.Time `json:"importedAt"`
+}
+
+// KMSImportedKeysSys maintains the cluster wide list of
+// imported KMS keys.
+type KMSImportedKeysSys struct {
+	sync.RWMutex
+	keys map[string]KMSImportedKey
+}
+
+// NewKMSImportedKeysSys - creates a new, empty list of imported keys.
+func NewKMSImportedKeysSys() *KMSImportedKeysSys {
+	return &KMSImportedKeysSys{keys: map[string]KMSImportedKey{}}
+}
+
+// IsAllowed - returns false if the key has been imported for
+// other buckets than the given one. Keys created by the KMS
+// itself are allowed for all buckets.
+func (sys *KMSImportedKeysSys) IsAllowed(keyID, bucket string) bool {
+	if sys == nil || keyID == "" {
+		return true
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	key, ok := sys.keys[keyID]
+	if !ok {
+		return true
+	}
+	for _, b := range key.Buckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// List - returns all imported keys ordered by key ID.
+func (sys *KMSImportedKeysSys) List() []KMSImportedKey {
+	sys.RLock()
+	defer sys.RUnlock()
+	keys := make([]KMSImportedKey, 0, len(sys.keys))
+	for _, key := range sys.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].KeyID < keys[j].KeyID })
+	return keys
+}
+
+// Load - loads the list of imported keys from the backend.
+func (sys *KMSImportedKeysSys) Load(ctx context.Context, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	data, err := readConfig(ctx, objAPI, kmsImportedKeysPath)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+
+	var imported []KMSImportedKey
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &imported); err != nil {
+			return err
+		}
+	}
+
+	keys := make(map[string]KMSImportedKey, len(imported))
+	for _, key := range imported {
+		keys[key.KeyID] = key
+	}
+	sys.Lock()
+	sys.keys = keys
+	sys.Unlock()
+	return nil
+}
+
+// Import - imports the key material into the KMS and restricts
+// its use to the buckets of key. The restriction is persisted and
+// peers are told to reload it before the key is imported, such
+// that the key never becomes usable for other buckets.
+func (sys *KMSImportedKeysSys) Import(ctx context.Context, objAPI ObjectLayer, key KMSImportedKey, material []byte) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	if GlobalKMS == nil {
+		return errKMSNotConfigured
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.keys[key.KeyID]; ok {
+		return errKMSKeyAlreadyImported
+	}
+	key.ImportedAt = UTCNow()
+
+	keys := make(map[string]KMSImportedKey, len(sys.keys)+1)
+	for id, k := range sys.keys {
+		keys[id] = k
+	}
+	keys[key.KeyID] = key
+	if err := sys.save(ctx, objAPI, keys); err != nil {
+		return err
+	}
+	reloadKMSImportedKeys(ctx)
+
+	if err := kms.ImportKey(GlobalKMS, key.KeyID, material); err != nil {
+		if errors.Is(err, kms.ErrKeyImportNotSupported) {
+			err = errKMSImportNotSupported
+		}
+		// Drop the restriction again, the key does not exist.
+		if err := sys.save(ctx, objAPI, sys.keys); err != nil {
+			logger.LogIf(ctx, err)
+		} else {
+			reloadKMSImportedKeys(ctx)
+		}
+		return err
+	}
+	sys.keys = keys
+	return nil
+}
+
+func reloadKMSImportedKeys(ctx context.Context) {
+	if globalNotificationSys == nil {
+		return
+	}
+	for _, nerr := range globalNotificationSys.ReloadKMSImportedKeys(ctx) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+func (sys *KMSImportedKeysSys) save(ctx context.Context, objAPI ObjectLayer, keys map[string]KMSImportedKey) error {
+	imported := make([]KMSImportedKey, 0, len(keys))
+	for _, key := range keys {
+		imported = append(imported, key)
+	}
+	data, err := json.Marshal(imported)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, kmsImportedKeysPath, data)
+}
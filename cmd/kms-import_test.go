@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/internal/kms"
+)
+
+func TestKMSImportedKeysIsAllowed(t *testing.T) {
+	sys := &KMSImportedKeysSys{
+		keys: map[string]KMSImportedKey{
+			"imported-key": {KeyID: "imported-key", Buckets: []string{"alpha", "beta"}},
+		},
+	}
+
+	testCases := []struct {
+		keyID    string
+		bucket   string
+		expected bool
+	}{
+		{"imported-key", "alpha", true},
+		{"imported-key", "beta", true},
+		{"imported-key", "gamma", false},
+		{"other-key", "gamma", true},
+		{"", "gamma", true},
+	}
+	for i, testCase := range testCases {
+		if got := sys.IsAllowed(testCase.keyID, testCase.bucket); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+
+	var nilSys *KMSImportedKeysSys
+	if !nilSys.IsAllowed("imported-key", "gamma") {
+		t.Error("expected all keys to be allowed without imported keys subsystem")
+	}
+}
+
+func TestKMSImportedKeysImportNotSupported(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	oldKMS := GlobalKMS
+	defer func() { GlobalKMS = oldKMS }()
+	GlobalKMS, err = kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw=")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sys := NewKMSImportedKeysSys()
+	key := KMSImportedKey{KeyID: "imported-key", Buckets: []string{"alpha"}}
+	if err = sys.Import(ctx, objLayer, key, make([]byte, 32)); !errors.Is(err, errKMSImportNotSupported) {
+		t.Fatalf("expected %v, got %v", errKMSImportNotSupported, err)
+	}
+
+	// The restriction must have been rolled back.
+	if err = sys.Load(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if keys := sys.List(); len(keys) != 0 {
+		t.Fatalf("expected no imported keys, got %v", keys)
+	}
+}
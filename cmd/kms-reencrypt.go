@@ -0,0 +1,292 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	// Number of object versions processed between two checkpoints.
+	kmsReencryptBatchSize = 1000
+
+	// How often nodes look for interrupted re-encryption jobs.
+	kmsReencryptResumeInterval = 5 * time.Minute
+)
+
+// Status of a re-encryption job.
+const (
+	kmsReencryptRunning   = "running"
+	kmsReencryptCompleted = "completed"
+	kmsReencryptFailed    = "failed"
+	kmsReencryptCanceled  = "canceled"
+)
+
+var (
+	errKMSReencryptRunning = AdminError{
+		Code:       "XMinioAdminKMSReencryptRunning",
+		Message:    "A re-encryption job is already running for this bucket",
+		StatusCode: http.StatusConflict,
+	}
+	errKMSReencryptNotFound = AdminError{
+		Code:       "XMinioAdminKMSReencryptNotFound",
+		Message:    "No re-encryption job exists for this bucket",
+		StatusCode: http.StatusNotFound,
+	}
+
+	errKMSReencryptObjectChanged = errors.New("object changed during re-encryption")
+)
+
+// kmsReencryptJob re-wraps the object keys of all SSE-KMS encrypted
+// object versions of a bucket with the current SSE-KMS key of the
+// bucket. The object data is not re-encrypted, only the sealed object
+// keys are replaced, which is sufficient to retire old key versions.
+// The job checkpoints its listing position such that it can be
+// resumed by any node after an interruption.
+type kmsReencryptJob struct {
+	Bucket      string    `json:"bucket"`
+	KeyID       string    `json:"keyId"`
+	Status      string    `json:"status"`
+	StartedAt   time.Time `json:"startedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	CompletedAt time.Time `json:"completedAt,omitempty"`
+	Error       string    `json:"error,omitempty"`
+
+	Marker        string `json:"marker,omitempty"`
+	VersionMarker string `json:"versionMarker,omitempty"`
+
+	Scanned   int64 `json:"scanned"`
+	Rewrapped int64 `json:"rewrapped"`
+	Skipped   int64 `json:"skipped"`
+	Failed    int64 `json:"failed"`
+}
+
+func kmsReencryptJobPath(bucket string) string {
+	return path.Join(minioConfigPrefix, "kms", "reencrypt", bucket+".json")
+}
+
+func loadKMSReencryptJob(ctx context.Context, objAPI ObjectLayer, bucket string) (*kmsReencryptJob, error) {
+	data, err := readConfig(ctx, objAPI, kmsReencryptJobPath(bucket))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, errKMSReencryptNotFound
+		}
+		return nil, err
+	}
+	var job kmsReencryptJob
+	if err = json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func saveKMSReencryptJob(ctx context.Context, objAPI ObjectLayer, job *kmsReencryptJob) error {
+	job.UpdatedAt = UTCNow()
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, kmsReencryptJobPath(job.Bucket), data)
+}
+
+// lockKMSReencryptJob is held by the node running the job of the bucket.
+func lockKMSReencryptJob(ctx context.Context, objAPI ObjectLayer, bucket string) (RWLocker, LockContext, error) {
+	locker := objAPI.NewNSLock(minioMetaBucket, path.Join("kms/reencrypt", bucket+".lock"))
+	lkctx, err := locker.GetLock(ctx, kmsKeyRotationLockTimeout)
+	return locker, lkctx, err
+}
+
+// startKMSReencryptJob creates a re-encryption job for the bucket and
+// runs it in the background.
+func startKMSReencryptJob(ctx context.Context, objAPI ObjectLayer, bucket string) (*kmsReencryptJob, error) {
+	_, keyID, err := bucketKMSKeyID(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := loadKMSReencryptJob(ctx, objAPI, bucket)
+	if err != nil && !errors.Is(err, errKMSReencryptNotFound) {
+		return nil, err
+	}
+	if job != nil && job.Status == kmsReencryptRunning {
+		return nil, errKMSReencryptRunning
+	}
+
+	now := UTCNow()
+	job = &kmsReencryptJob{
+		Bucket:    bucket,
+		KeyID:     keyID,
+		Status:    kmsReencryptRunning,
+		StartedAt: now,
+	}
+	if err = saveKMSReencryptJob(ctx, objAPI, job); err != nil {
+		return nil, err
+	}
+	go runKMSReencryptJob(GlobalContext, objAPI, bucket)
+	return job, nil
+}
+
+// cancelKMSReencryptJob marks the running job of the bucket as canceled,
+// the node running it stops at its next checkpoint.
+func cancelKMSReencryptJob(ctx context.Context, objAPI ObjectLayer, bucket string) (*kmsReencryptJob, error) {
+	job, err := loadKMSReencryptJob(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != kmsReencryptRunning {
+		return job, nil
+	}
+	job.Status = kmsReencryptCanceled
+	if err = saveKMSReencryptJob(ctx, objAPI, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// runKMSReencryptJob processes the job of the bucket from its last
+// checkpoint. It returns immediately if another node is running it.
+func runKMSReencryptJob(ctx context.Context, objAPI ObjectLayer, bucket string) {
+	locker, lkctx, err := lockKMSReencryptJob(ctx, objAPI, bucket)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	job, err := loadKMSReencryptJob(ctx, objAPI, bucket)
+	if err != nil || job.Status != kmsReencryptRunning {
+		return
+	}
+
+	for {
+		result, err := objAPI.ListObjectVersions(ctx, bucket, "", job.Marker, job.VersionMarker, "", kmsReencryptBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Interrupted, some node resumes the job later.
+				return
+			}
+			job.Status, job.Error = kmsReencryptFailed, err.Error()
+			logger.LogIf(ctx, saveKMSReencryptJob(ctx, objAPI, job))
+			return
+		}
+
+		for _, oi := range result.Objects {
+			job.Scanned++
+			rewrapped, err := rewrapObjectKMSKey(ctx, objAPI, oi, job.KeyID)
+			switch {
+			case err != nil:
+				job.Failed++
+				logger.LogIf(ctx, fmt.Errorf("unable to re-encrypt %s/%s (%s): %w", bucket, oi.Name, oi.VersionID, err))
+			case rewrapped:
+				job.Rewrapped++
+			default:
+				job.Skipped++
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		if result.IsTruncated {
+			job.Marker, job.VersionMarker = result.NextMarker, result.NextVersionIDMarker
+		} else {
+			job.Status, job.CompletedAt = kmsReencryptCompleted, UTCNow()
+		}
+
+		// Do not overwrite a cancellation issued in the meantime.
+		if cur, err := loadKMSReencryptJob(ctx, objAPI, bucket); err == nil && cur.Status == kmsReencryptCanceled {
+			return
+		}
+		if err = saveKMSReencryptJob(ctx, objAPI, job); err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		if job.Status != kmsReencryptRunning {
+			return
+		}
+	}
+}
+
+// rewrapObjectKMSKey seals the object key of an SSE-KMS encrypted object
+// version with a data key of keyID. It returns false if the object
+// version is not SSE-KMS encrypted or already uses keyID.
+func rewrapObjectKMSKey(ctx context.Context, objAPI ObjectLayer, oi ObjectInfo, keyID string) (bool, error) {
+	if oi.DeleteMarker || !crypto.S3KMS.IsEncrypted(oi.UserDefined) || oi.UserDefined[crypto.MetaKeyID] == keyID {
+		return false, nil
+	}
+	_, err := objAPI.PutObjectMetadata(ctx, oi.Bucket, oi.Name, ObjectOptions{
+		VersionID: oi.VersionID,
+		MTime:     oi.ModTime,
+		EvalMetadataFn: func(cur ObjectInfo) error {
+			// The object was overwritten in the meantime.
+			if !cur.ModTime.Equal(oi.ModTime) {
+				return errKMSReencryptObjectChanged
+			}
+			return rotateKey(ctx, nil, keyID, nil, cur.Bucket, cur.Name, cur.UserDefined, nil)
+		},
+	})
+	if errors.Is(err, errKMSReencryptObjectChanged) || isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// initKMSReencryption periodically resumes re-encryption jobs which were
+// interrupted, e.g. by a restart of the node running them.
+func initKMSReencryption(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		ticker := time.NewTicker(kmsReencryptResumeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if GlobalKMS != nil {
+					resumeKMSReencryptJobs(ctx, objAPI)
+				}
+			}
+		}
+	}()
+}
+
+func resumeKMSReencryptJobs(ctx context.Context, objAPI ObjectLayer) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, bi := range buckets {
+		job, err := loadKMSReencryptJob(ctx, objAPI, bi.Name)
+		if err != nil || job.Status != kmsReencryptRunning {
+			continue
+		}
+		// Jobs running on other nodes hold their lock, so this
+		// returns immediately for them.
+		go runKMSReencryptJob(ctx, objAPI, bi.Name)
+	}
+}
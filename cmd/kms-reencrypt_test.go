@@ -0,0 +1,108 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/minio/internal/crypto"
+	"github.com/minio/minio/internal/kms"
+)
+
+// multiKeyKMS accepts any key ID, but protects all data keys with
+// the same secret key.
+type multiKeyKMS struct{ kms.KMS }
+
+func (k multiKeyKMS) GenerateKey(keyID string, ctx kms.Context) (kms.DEK, error) {
+	key, err := k.KMS.GenerateKey("", ctx)
+	key.KeyID = keyID
+	return key, err
+}
+
+func (k multiKeyKMS) DecryptKey(_ string, ciphertext []byte, ctx kms.Context) ([]byte, error) {
+	return k.KMS.DecryptKey("my-minio-key", ciphertext, ctx)
+}
+
+func TestRunKMSReencryptJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	secretKMS, err := kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(old kms.KMS) { GlobalKMS = old }(GlobalKMS)
+	GlobalKMS = multiKeyKMS{secretKMS}
+
+	const bucket = "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("abcd")
+	objectKeys := map[string]crypto.ObjectKey{}
+	for object, keyID := range map[string]string{"old": "old-key", "new": "new-key"} {
+		metadata := map[string]string{}
+		objectKeys[object], err = newEncryptMetadata(ctx, crypto.S3KMS, keyID, nil, bucket, object, metadata, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{UserDefined: metadata}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = obj.PutObject(ctx, bucket, "plain", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	job := &kmsReencryptJob{Bucket: bucket, KeyID: "new-key", Status: kmsReencryptRunning, StartedAt: UTCNow()}
+	if err = saveKMSReencryptJob(ctx, obj, job); err != nil {
+		t.Fatal(err)
+	}
+	runKMSReencryptJob(ctx, obj, bucket)
+
+	if job, err = loadKMSReencryptJob(ctx, obj, bucket); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != kmsReencryptCompleted || job.Scanned != 3 || job.Rewrapped != 1 || job.Skipped != 2 || job.Failed != 0 {
+		t.Fatalf("unexpected job state %+v", job)
+	}
+
+	oi, err := obj.GetObjectInfo(ctx, bucket, "old", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyID := oi.UserDefined[crypto.MetaKeyID]; keyID != "new-key" {
+		t.Fatalf("expected key ID new-key, got %s", keyID)
+	}
+	objectKey, err := decryptObjectInfo(ctx, nil, bucket, "old", oi.UserDefined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oldKey := objectKeys["old"]; !bytes.Equal(objectKey, oldKey[:]) {
+		t.Fatal("re-wrapping must not change the object key")
+	}
+}
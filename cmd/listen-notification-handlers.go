@@ -18,21 +18,27 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/minio/minio/internal/event"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	policy "github.com/minio/pkg/bucket/policy"
 )
 
-func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := newContext(r, w, "ListenNotification")
-
-	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+// Query parameter of the listen request selecting the event schema
+// version, events are sent in the latest version by default.
+const listenSchemaVersion = "schema-version"
 
+// listenNotificationReq - validates the listen request and returns the
+// bucket and the rules selected by the prefix, suffix and events
+// parameters, an empty bucket selects all buckets.
+func (api objectAPIHandlers) listenNotificationReq(ctx context.Context, w http.ResponseWriter, r *http.Request) (bucketName string, rulesMap event.RulesMap, schemaVersion string, ok bool) {
 	// Validate if bucket exists.
 	objAPI := api.ObjectAPI()
 	if objAPI == nil {
@@ -51,7 +57,7 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 	}
 
 	vars := mux.Vars(r)
-	bucketName := vars["bucket"]
+	bucketName = vars["bucket"]
 
 	if bucketName == "" {
 		if s3Error := checkRequestAuthType(ctx, r, policy.ListenNotificationAction, bucketName, ""); s3Error != ErrNone {
@@ -99,9 +105,22 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 
 	pattern := event.NewPattern(prefix, suffix)
 
+	schemaVersion = values.Get(listenSchemaVersion)
+	if err := event.ValidateSchemaVersion(schemaVersion); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if schemaVersion == "" {
+		schemaVersion = event.LatestSchemaVersion
+	}
+
 	var eventNames []event.Name
 	for _, s := range values[peerRESTListenEvents] {
 		eventName, err := event.ParseName(s)
+		if err == nil && eventName == event.IAMChange {
+			// IAM changes are never sent to listeners.
+			err = &event.ErrInvalidEventName{Name: s}
+		}
 		if err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
@@ -117,17 +136,15 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 		}
 	}
 
-	rulesMap := event.NewRulesMap(eventNames, pattern, event.TargetID{ID: mustGetUUID()})
-
-	setEventStreamHeaders(w)
-
-	// Listen Publisher and peer-listen-client uses nonblocking send and hence does not wait for slow receivers.
-	// Use buffered channel to take care of burst sends or slow w.Write()
-	listenCh := make(chan interface{}, 4000)
+	return bucketName, event.NewRulesMap(eventNames, pattern, event.TargetID{ID: mustGetUUID()}), schemaVersion, true
+}
 
+// listenNotification - subscribes listenCh to the events of this node
+// and of all peers matching the listen request until doneCh is closed.
+func listenNotification(r *http.Request, bucketName string, rulesMap event.RulesMap, listenCh chan interface{}, doneCh <-chan struct{}) {
 	peers, _ := newPeerRestClients(globalEndpoints)
 
-	globalHTTPListen.Subscribe(listenCh, ctx.Done(), func(evI interface{}) bool {
+	globalHTTPListen.Subscribe(listenCh, doneCh, func(evI interface{}) bool {
 		ev, ok := evI.(event.Event)
 		if !ok {
 			return false
@@ -140,6 +157,7 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 		return rulesMap.MatchSimple(ev.EventName, ev.S3.Object.Key)
 	})
 
+	values := r.Form
 	if bucketName != "" {
 		values.Set(peerRESTListenBucket, bucketName)
 	}
@@ -147,9 +165,29 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 		if peer == nil {
 			continue
 		}
-		peer.Listen(listenCh, ctx.Done(), values)
+		peer.Listen(listenCh, doneCh, values)
+	}
+}
+
+func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListenNotification")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	bucketName, rulesMap, schemaVersion, ok := api.listenNotificationReq(ctx, w, r)
+	if !ok {
+		return
 	}
 
+	setEventStreamHeaders(w)
+	w.Header().Set(xhttp.MinIOEventSchemaVersion, schemaVersion)
+
+	// Listen Publisher and peer-listen-client uses nonblocking send and hence does not wait for slow receivers.
+	// Use buffered channel to take care of burst sends or slow w.Write()
+	listenCh := make(chan interface{}, 4000)
+
+	listenNotification(r, bucketName, rulesMap, listenCh, ctx.Done())
+
 	keepAliveTicker := time.NewTicker(500 * time.Millisecond)
 	defer keepAliveTicker.Stop()
 
@@ -159,6 +197,7 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 		case evI := <-listenCh:
 			ev, ok := evI.(event.Event)
 			if ok {
+				ev = ev.ToSchemaVersion(schemaVersion)
 				if err := enc.Encode(struct{ Records []event.Event }{[]event.Event{ev}}); err != nil {
 					return
 				}
@@ -178,3 +217,91 @@ func (api objectAPIHandlers) ListenNotificationHandler(w http.ResponseWriter, r
 		}
 	}
 }
+
+const (
+	// Interval of pings to websocket listeners, listeners which do not
+	// answer within listenWebsocketPongWait are disconnected.
+	listenWebsocketPingInterval = 30 * time.Second
+	listenWebsocketPongWait     = 2 * listenWebsocketPingInterval
+	listenWebsocketWriteWait    = 10 * time.Second
+)
+
+var listenWebsocketUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 32 << 10,
+	// Requests are authenticated by their signature, so listeners of
+	// any origin, e.g. browsers with presigned URLs, are allowed.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// ListenNotificationWebsocketHandler - streams the events matching the
+// listen request as websocket messages, one message per event.
+func (api objectAPIHandlers) ListenNotificationWebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListenNotificationWebsocket")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	bucketName, rulesMap, schemaVersion, ok := api.listenNotificationReq(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	conn, err := listenWebsocketUpgrader.Upgrade(w, r, http.Header{xhttp.MinIOEventSchemaVersion: []string{schemaVersion}})
+	if err != nil {
+		// Upgrade replies with an error response itself.
+		return
+	}
+	defer conn.Close()
+
+	// The context of the request is not canceled when the client of
+	// a hijacked connection goes away, the reader takes care of it.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Listeners only send control messages, reading processes them.
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(listenWebsocketPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(listenWebsocketPongWait))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Listen Publisher and peer-listen-client uses nonblocking send and hence does not wait for slow receivers.
+	// Use buffered channel to take care of burst sends or slow writes.
+	listenCh := make(chan interface{}, 4000)
+
+	listenNotification(r, bucketName, rulesMap, listenCh, ctx.Done())
+
+	pingTicker := time.NewTicker(listenWebsocketPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case evI := <-listenCh:
+			ev, ok := evI.(event.Event)
+			if !ok {
+				continue
+			}
+			ev = ev.ToSchemaVersion(schemaVersion)
+			conn.SetWriteDeadline(time.Now().Add(listenWebsocketWriteWait))
+			if err := conn.WriteJSON(struct{ Records []event.Event }{[]event.Event{ev}}); err != nil {
+				return
+			}
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(listenWebsocketWriteWait)); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(listenWebsocketWriteWait))
+			return
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	xmetrics "github.com/minio/minio/internal/config/metrics"
+	"github.com/minio/pkg/wildcard"
+)
+
+// userLabel is the label of the metrics by access key.
+const userLabel = "user"
+
+// metricsTrafficLimit is the maximum number of buckets and of users
+// whose traffic is tracked to rank them, further ones are never among
+// the top values.
+const metricsTrafficLimit = 100000
+
+// metricsGovernance drops the metric families which are not exported
+// and aggregates the series of the buckets and users which are not
+// exported with their own label, such that the cardinality of the
+// metrics of large deployments is bounded.
+type metricsGovernance struct {
+	mu  sync.RWMutex
+	cfg xmetrics.Config
+	// requests by label and value since the configuration changed.
+	traffic map[string]map[string]uint64
+}
+
+var globalMetricsGovernance = &metricsGovernance{}
+
+// Update applies a new configuration and resets the traffic.
+func (g *metricsGovernance) Update(cfg xmetrics.Config) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cfg = cfg
+	g.traffic = make(map[string]map[string]uint64)
+}
+
+// limits returns the label limits which are enabled by label.
+func (g *metricsGovernance) limits() map[string]xmetrics.LabelLimit {
+	limits := make(map[string]xmetrics.LabelLimit, 2)
+	if g.cfg.BucketLabels.Enabled() {
+		limits["bucket"] = g.cfg.BucketLabels
+	}
+	if g.cfg.UserLabels.Enabled() {
+		limits[userLabel] = g.cfg.UserLabels
+	}
+	return limits
+}
+
+// recordTraffic - records a request to the bucket by the access key of
+// the request, if the top buckets or users are exported.
+func (g *metricsGovernance) recordTraffic(bucket string, r *http.Request) {
+	g.mu.RLock()
+	bucketTop, userTop := g.cfg.BucketLabels.Top > 0, g.cfg.UserLabels.Top > 0
+	g.mu.RUnlock()
+	if !bucketTop && !userTop {
+		return
+	}
+
+	var user string
+	if userTop {
+		user = inflightAccessKey(r)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if bucketTop && bucket != "" {
+		g.record("bucket", bucket)
+	}
+	if userTop && user != "" {
+		g.record(userLabel, user)
+	}
+}
+
+func (g *metricsGovernance) record(label, value string) {
+	if g.traffic == nil {
+		g.traffic = make(map[string]map[string]uint64)
+	}
+	values, ok := g.traffic[label]
+	if !ok {
+		values = make(map[string]uint64)
+		g.traffic[label] = values
+	}
+	if _, ok := values[value]; !ok && len(values) >= metricsTrafficLimit {
+		return
+	}
+	values[value]++
+}
+
+// top returns the n values of the label with the most requests.
+func (g *metricsGovernance) top(label string, n int) map[string]struct{} {
+	values := g.traffic[label]
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if ri, rj := values[names[i]], values[names[j]]; ri != rj {
+			return ri > rj
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+	top := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		top[name] = struct{}{}
+	}
+	return top
+}
+
+// apply - drops the metrics of the families which are not exported and
+// aggregates the series of the buckets and users which are not
+// exported with their own label as otherBucketsLabel.
+func (g *metricsGovernance) apply(metrics []Metric) []Metric {
+	g.mu.RLock()
+	cfg := g.cfg
+	limits := g.limits()
+	tops := make(map[string]map[string]struct{}, len(limits))
+	for label, limit := range limits {
+		if limit.Top > 0 {
+			tops[label] = g.top(label, limit.Top)
+		}
+	}
+	g.mu.RUnlock()
+
+	exported := func(label, value string) bool {
+		if _, ok := tops[label][value]; ok {
+			return true
+		}
+		for _, pattern := range limits[label].Allow {
+			if wildcard.Match(pattern, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	result := metrics[:0]
+	aggregated := make(map[string]int)
+	for _, m := range metrics {
+		if !cfg.FamilyEnabled(m.Description.fullName()) {
+			continue
+		}
+		var other bool
+		for label := range limits {
+			value, ok := m.VariableLabels[label]
+			if !ok {
+				continue
+			}
+			if value != otherBucketsLabel && !exported(label, value) {
+				m.VariableLabels[label] = otherBucketsLabel
+				value = otherBucketsLabel
+			}
+			other = other || value == otherBucketsLabel
+		}
+		if !other {
+			result = append(result, m)
+			continue
+		}
+		key := m.seriesKey()
+		if i, ok := aggregated[key]; ok {
+			result[i].Value += m.Value
+			for k, v := range m.Histogram {
+				result[i].Histogram[k] += v
+			}
+			continue
+		}
+		aggregated[key] = len(result)
+		result = append(result, m)
+	}
+	return result
+}
+
+func (d MetricDescription) fullName() string {
+	return prometheus.BuildFQName(string(d.Namespace), string(d.Subsystem), string(d.Name))
+}
+
+// seriesKey returns the name and the labels of the metric.
+func (m *Metric) seriesKey() string {
+	labels := make([]string, 0, len(m.VariableLabels))
+	for k, v := range m.VariableLabels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	return m.Description.fullName() + "{" + strings.Join(labels, ",") + "}" + m.HistogramBucketLabel
+}
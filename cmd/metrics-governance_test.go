@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xmetrics "github.com/minio/minio/internal/config/metrics"
+)
+
+func TestMetricsGovernance(t *testing.T) {
+	usage := MetricDescription{Namespace: bucketMetricNamespace, Subsystem: usageSubsystem, Name: totalBytes, Type: gaugeMetric}
+	drive := MetricDescription{Namespace: nodeMetricNamespace, Subsystem: "drive_health", Name: ioErrors, Type: gaugeMetric}
+	metrics := func() []Metric {
+		return []Metric{
+			{Description: usage, Value: 10, VariableLabels: map[string]string{"bucket": "photos"}},
+			{Description: usage, Value: 20, VariableLabels: map[string]string{"bucket": "prod-logs"}},
+			{Description: usage, Value: 30, VariableLabels: map[string]string{"bucket": "records"}},
+			{Description: usage, Value: 40, VariableLabels: map[string]string{"bucket": "archive"}},
+			{Description: drive, Value: 1, VariableLabels: map[string]string{"disk": "/mnt/drive1"}},
+		}
+	}
+
+	g := &metricsGovernance{}
+	if result := g.apply(metrics()); len(result) != 5 {
+		t.Fatalf("expected all metrics by default, got %v", result)
+	}
+
+	g.Update(xmetrics.Config{
+		FamiliesDisabled: []string{"minio_node_drive_health_"},
+		BucketLabels:     xmetrics.LabelLimit{Allow: []string{"prod-*"}, Top: 1},
+	})
+	r := httptest.NewRequest(http.MethodGet, "/records/object", nil)
+	for i := 0; i < 3; i++ {
+		g.recordTraffic("records", r)
+	}
+	g.recordTraffic("photos", r)
+
+	values := make(map[string]float64)
+	for _, m := range g.apply(metrics()) {
+		if m.Description != usage {
+			t.Fatalf("expected the drive health metrics to be dropped, got %v", m)
+		}
+		values[m.VariableLabels["bucket"]] = m.Value
+	}
+	expected := map[string]float64{"prod-logs": 20, "records": 30, otherBucketsLabel: 50}
+	if len(values) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+	for bucket, value := range expected {
+		if values[bucket] != value {
+			t.Fatalf("expected %v, got %v", expected, values)
+		}
+	}
+}
+
+func TestMetricsGovernanceUsers(t *testing.T) {
+	requests := MetricDescription{Namespace: s3MetricNamespace, Subsystem: requestsSubsystem, Name: total, Type: counterMetric}
+	g := &metricsGovernance{}
+	g.Update(xmetrics.Config{UserLabels: xmetrics.LabelLimit{Top: 1}})
+
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object?AWSAccessKeyId=minio&Signature=abcd", nil)
+	g.recordTraffic("bucket", r)
+	if _, ok := g.traffic["bucket"]; ok {
+		t.Fatal("expected buckets not to be tracked")
+	}
+
+	result := g.apply([]Metric{
+		{Description: requests, Value: 5, VariableLabels: map[string]string{userLabel: "minio"}},
+		{Description: requests, Value: 2, VariableLabels: map[string]string{userLabel: "alice"}},
+		{Description: requests, Value: 3, VariableLabels: map[string]string{userLabel: "bob"}},
+	})
+	if len(result) != 2 {
+		t.Fatalf("expected 2 metrics, got %v", result)
+	}
+	if result[0].VariableLabels[userLabel] != "minio" || result[0].Value != 5 {
+		t.Fatalf("unexpected metric %v", result[0])
+	}
+	if result[1].VariableLabels[userLabel] != otherBucketsLabel || result[1].Value != 5 {
+		t.Fatalf("unexpected metric %v", result[1])
+	}
+}
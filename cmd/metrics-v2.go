@@ -28,6 +28,8 @@ import (
 	"time"
 
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/dsync"
+	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -47,6 +49,7 @@ func init() {
 		getMinioHealingMetrics(),
 		getNodeHealthMetrics(),
 		getClusterStorageMetrics(),
+		getSpeedtestMetrics(),
 	}
 
 	peerMetricsGroups = []*MetricsGroup{
@@ -58,8 +61,15 @@ func init() {
 		getMinioVersionMetrics(),
 		getNetworkMetrics(),
 		getS3TTFBMetric(),
+		getBucketLatencyMetrics(),
 		getILMNodeMetrics(),
 		getScannerNodeMetrics(),
+		getTierNodeMetrics(),
+		getKMSNodeMetrics(),
+		getNotifyNodeMetrics(),
+		getDriveHealthNodeMetrics(),
+		getResourceLimitsNodeMetrics(),
+		getLockNodeMetrics(),
 	}
 
 	allMetricsGroups := func() (allMetrics []*MetricsGroup) {
@@ -75,6 +85,7 @@ func init() {
 		getNetworkMetrics(),
 		getMinioVersionMetrics(),
 		getS3TTFBMetric(),
+		getBucketLatencyMetrics(),
 	})
 
 	clusterCollector = newMinioClusterCollector(allMetricsGroups)
@@ -117,6 +128,13 @@ const (
 	usageSubsystem            MetricSubsystem = "usage"
 	ilmSubsystem              MetricSubsystem = "ilm"
 	scannerSubsystem          MetricSubsystem = "scanner"
+	tierSubsystem             MetricSubsystem = "tier"
+	kmsSubsystem              MetricSubsystem = "kms"
+	notifySubsystem           MetricSubsystem = "notify"
+	driveHealthSubsystem      MetricSubsystem = "drive_health"
+	limitsSubsystem           MetricSubsystem = "limits"
+	speedtestSubsystem        MetricSubsystem = "speedtest"
+	locksSubsystem            MetricSubsystem = "locks"
 )
 
 // MetricName are the individual names for the metric.
@@ -126,6 +144,7 @@ const (
 	authTotal      MetricName = "auth_total"
 	canceledTotal  MetricName = "canceled_total"
 	errorsTotal    MetricName = "errors_total"
+	requestsTotal  MetricName = "requests_total"
 	headerTotal    MetricName = "header_total"
 	healTotal      MetricName = "heal_total"
 	hitsTotal      MetricName = "hits_total"
@@ -137,6 +156,7 @@ const (
 	objectTotal    MetricName = "object_total"
 	offlineTotal   MetricName = "offline_total"
 	onlineTotal    MetricName = "online_total"
+	online         MetricName = "online"
 	openTotal      MetricName = "open_total"
 	readTotal      MetricName = "read_total"
 	timestampTotal MetricName = "timestamp_total"
@@ -146,6 +166,10 @@ const (
 
 	failedCount     MetricName = "failed_count"
 	failedBytes     MetricName = "failed_bytes"
+	pendingCount    MetricName = "pending_count"
+	pendingBytes    MetricName = "pending_bytes"
+	completedTotal  MetricName = "completed_total"
+	failedTotal     MetricName = "failed_total"
 	freeBytes       MetricName = "free_bytes"
 	readBytes       MetricName = "read_bytes"
 	rcharBytes      MetricName = "rchar_bytes"
@@ -163,8 +187,10 @@ const (
 	usageInfo   MetricName = "usage_info"
 	versionInfo MetricName = "version_info"
 
-	sizeDistribution = "size_distribution"
-	ttfbDistribution = "ttfb_seconds_distribution"
+	sizeDistribution    = "size_distribution"
+	ttfbDistribution    = "ttfb_seconds_distribution"
+	latencyDistribution = "latency_seconds_distribution"
+	lagDistribution     = "lag_distribution"
 
 	lastActivityTime = "last_activity_nano_seconds"
 	startTime        = "starttime_seconds"
@@ -175,6 +201,47 @@ const (
 	expiryPendingTasks     MetricName = "expiry_pending_tasks"
 	transitionPendingTasks MetricName = "transition_pending_tasks"
 	transitionActiveTasks  MetricName = "transition_active_tasks"
+
+	opLatencyMilliSec MetricName = "op_latency_ms"
+	opRequestsTotal   MetricName = "op_requests_total"
+	opErrorsTotal     MetricName = "op_errors_total"
+	opRejectedTotal   MetricName = "op_rejected_total"
+	breakerOpen       MetricName = "breaker_open"
+
+	eventsQueuedTotal           MetricName = "events_queued_total"
+	eventsDeliveredTotal        MetricName = "events_delivered_total"
+	eventsFailedTotal           MetricName = "events_failed_total"
+	deliveryLatencyDistribution MetricName = "delivery_latency_distribution"
+	backlogEvents               MetricName = "backlog_events"
+	oldestUndeliveredAge        MetricName = "oldest_undelivered_age_seconds"
+
+	reallocatedSectors   MetricName = "reallocated_sectors"
+	pendingSectors       MetricName = "pending_sectors"
+	uncorrectableSectors MetricName = "uncorrectable_sectors"
+	mediaErrors          MetricName = "media_errors"
+	ioErrors             MetricName = "io_errors"
+	healthEventsTotal    MetricName = "events_total"
+
+	limitValue         MetricName = "limit"
+	limitInUse         MetricName = "in_use"
+	limitWaiting       MetricName = "waiting"
+	limitAdmittedTotal MetricName = "admitted_total"
+	limitRejectedTotal MetricName = "rejected_total"
+
+	speedtestLastRun      MetricName = "last_run_timestamp_seconds"
+	objectThroughputBytes MetricName = "object_throughput_bytes"
+	objectThroughputRatio MetricName = "object_throughput_ratio"
+	driveThroughputBytes  MetricName = "drive_throughput_bytes"
+	driveThroughputRatio  MetricName = "drive_throughput_ratio"
+	netThroughputBytes    MetricName = "net_throughput_bytes"
+	netThroughputRatio    MetricName = "net_throughput_ratio"
+
+	locksOutstanding         MetricName = "outstanding"
+	grantLatencyDistribution MetricName = "grant_latency_distribution"
+	quorumErrorsTotal        MetricName = "quorum_errors_total"
+	timeoutsTotal            MetricName = "timeouts_total"
+	refreshFailuresTotal     MetricName = "refresh_failures_total"
+	forceUnlocksTotal        MetricName = "force_unlocks_total"
 )
 
 const (
@@ -465,6 +532,56 @@ func getBucketRepFailedOperationsMD() MetricDescription {
 	}
 }
 
+func getBucketRepPendingBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingBytes,
+		Help:      "Total number of bytes pending replication to the target bucket.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepPendingOperationsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingCount,
+		Help:      "Total number of operations pending replication to the target bucket.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepCompletedOperationsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      completedTotal,
+		Help:      "Total number of replication operations completed since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getBucketRepFailedOperationsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      failedTotal,
+		Help:      "Total number of replication operations failed since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getBucketRepLagMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      lagDistribution,
+		Help:      "Distribution of the time from object modification until replication completed.",
+		Type:      histogramMetric,
+	}
+}
+
 func getBucketObjectDistributionMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -1095,6 +1212,577 @@ func getExpiryPendingTasksMD() MetricDescription {
 	}
 }
 
+func getBucketTierTotalBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      totalBytes,
+		Help:      "Total bucket size stored on the remote tier, as of the last scan.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      requestsTotal,
+		Help:      "Total number of requests to the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierErrorsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      errorsTotal,
+		Help:      "Total number of failed requests to the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierSentBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      sentBytes,
+		Help:      "Total number of bytes transitioned to the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierReceivedBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      receivedBytes,
+		Help:      "Total number of bytes retrieved from the remote tier since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		for _, u := range globalTierUsage.Usage() {
+			for api, n := range map[string]uint64{"put": u.PutRequests, "get": u.GetRequests, "remove": u.RemoveRequests} {
+				metrics = append(metrics, Metric{
+					Description:    getTierRequestsTotalMD(),
+					Value:          float64(n),
+					VariableLabels: map[string]string{"tier": u.Tier, "bucket": u.Bucket, "api": api},
+				})
+			}
+			metrics = append(metrics, Metric{
+				Description:    getTierErrorsTotalMD(),
+				Value:          float64(u.Errors),
+				VariableLabels: map[string]string{"tier": u.Tier, "bucket": u.Bucket},
+			})
+			for rule, n := range u.RuleTransitionedBytes {
+				metrics = append(metrics, Metric{
+					Description:    getTierSentBytesMD(),
+					Value:          float64(n),
+					VariableLabels: map[string]string{"tier": u.Tier, "bucket": u.Bucket, "rule": rule},
+				})
+			}
+			metrics = append(metrics, Metric{
+				Description:    getTierReceivedBytesMD(),
+				Value:          float64(u.RetrievedBytes),
+				VariableLabels: map[string]string{"tier": u.Tier, "bucket": u.Bucket},
+			})
+		}
+		return
+	})
+	return mg
+}
+
+func getKMSEndpointOnlineMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      online,
+		Help:      "Reports whether the KMS endpoint is online (1) or offline (0).",
+		Type:      gaugeMetric,
+	}
+}
+
+func getKMSEndpointLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      latencyMilliSec,
+		Help:      "Average latency of successful requests to the KMS endpoint in milliseconds.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getKMSEndpointRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      requestsTotal,
+		Help:      "Total number of requests sent to the KMS endpoint since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getKMSEndpointErrorsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      errorsTotal,
+		Help:      "Total number of requests that failed because the KMS endpoint was unreachable.",
+		Type:      counterMetric,
+	}
+}
+
+func getKMSOperationLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      opLatencyMilliSec,
+		Help:      "Average latency of successful KMS operations in milliseconds.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getKMSOperationRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      opRequestsTotal,
+		Help:      "Total number of KMS operations since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getKMSOperationErrorsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      opErrorsTotal,
+		Help:      "Total number of KMS operations that failed.",
+		Type:      counterMetric,
+	}
+}
+
+func getKMSOperationRejectedTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      opRejectedTotal,
+		Help:      "Total number of KMS operations rejected by the circuit breaker.",
+		Type:      counterMetric,
+	}
+}
+
+func getKMSBreakerOpenMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: kmsSubsystem,
+		Name:      breakerOpen,
+		Help:      "Reports whether the KMS circuit breaker is open (1) or closed (0).",
+		Type:      gaugeMetric,
+	}
+}
+
+func getKMSNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		if GlobalKMS == nil {
+			return
+		}
+		for _, stat := range kms.EndpointStats(GlobalKMS) {
+			labels := map[string]string{"endpoint": stat.Endpoint}
+			var up float64
+			if stat.Online {
+				up = 1
+			}
+			metrics = append(metrics, Metric{
+				Description:    getKMSEndpointOnlineMD(),
+				Value:          up,
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getKMSEndpointLatencyMD(),
+				Value:          float64(stat.Latency) / float64(time.Millisecond),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getKMSEndpointRequestsTotalMD(),
+				Value:          float64(stat.Requests),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getKMSEndpointErrorsTotalMD(),
+				Value:          float64(stat.Failures),
+				VariableLabels: labels,
+			})
+		}
+
+		breaker, ok := kms.Breaker(GlobalKMS)
+		if !ok {
+			return
+		}
+		var open float64
+		if breaker.Open {
+			open = 1
+		}
+		metrics = append(metrics, Metric{
+			Description: getKMSBreakerOpenMD(),
+			Value:       open,
+		})
+		for _, stat := range breaker.Operations {
+			labels := map[string]string{"operation": stat.Operation}
+			metrics = append(metrics, Metric{
+				Description:    getKMSOperationLatencyMD(),
+				Value:          float64(stat.Latency) / float64(time.Millisecond),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getKMSOperationRequestsTotalMD(),
+				Value:          float64(stat.Requests),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getKMSOperationErrorsTotalMD(),
+				Value:          float64(stat.Errors),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getKMSOperationRejectedTotalMD(),
+				Value:          float64(stat.Rejected),
+				VariableLabels: labels,
+			})
+		}
+		return
+	})
+	return mg
+}
+
+func getNotifyEventsQueuedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsQueuedTotal,
+		Help:      "Total number of events queued for the notification target since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyEventsDeliveredMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsDeliveredTotal,
+		Help:      "Total number of events delivered to the notification target since server start.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyEventsFailedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      eventsFailedTotal,
+		Help:      "Total number of failed attempts to deliver events to the notification target by error class.",
+		Type:      counterMetric,
+	}
+}
+
+func getNotifyDeliveryLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      deliveryLatencyDistribution,
+		Help:      "Distribution of the time from queuing an event until the notification target accepted it.",
+		Type:      histogramMetric,
+	}
+}
+
+func getNotifyBacklogMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      backlogEvents,
+		Help:      "Number of undelivered events in the queue store of the notification target.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNotifyOldestUndeliveredAgeMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: notifySubsystem,
+		Name:      oldestUndeliveredAge,
+		Help:      "Age of the oldest undelivered event in the queue store of the notification target.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getNotifyNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		cacheInterval: 10 * time.Second,
+	}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		if globalNotificationSys == nil {
+			return
+		}
+		now := time.Now()
+		for _, stat := range globalNotificationSys.targetList.Stats(globalSite.Region) {
+			labels := map[string]string{"targetArn": stat.ARN}
+			metrics = append(metrics, Metric{
+				Description:    getNotifyEventsQueuedMD(),
+				Value:          float64(stat.QueuedEvents),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getNotifyEventsDeliveredMD(),
+				Value:          float64(stat.DeliveredEvents),
+				VariableLabels: labels,
+			})
+			for class, n := range stat.FailedEvents {
+				metrics = append(metrics, Metric{
+					Description:    getNotifyEventsFailedMD(),
+					Value:          float64(n),
+					VariableLabels: map[string]string{"targetArn": stat.ARN, "class": class},
+				})
+			}
+			metrics = append(metrics, Metric{
+				Description:          getNotifyDeliveryLatencyMD(),
+				VariableLabels:       labels,
+				HistogramBucketLabel: "range",
+				Histogram:            stat.DeliveryLatency,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getNotifyBacklogMD(),
+				Value:          float64(stat.Backlog),
+				VariableLabels: labels,
+			})
+			var age float64
+			if !stat.OldestUndelivered.IsZero() {
+				age = now.Sub(stat.OldestUndelivered).Seconds()
+			}
+			metrics = append(metrics, Metric{
+				Description:    getNotifyOldestUndeliveredAgeMD(),
+				Value:          age,
+				VariableLabels: labels,
+			})
+		}
+		return
+	})
+	return mg
+}
+
+func getDriveHealthMD(name MetricName, help string, metricType MetricType) MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: driveHealthSubsystem,
+		Name:      name,
+		Help:      help,
+		Type:      metricType,
+	}
+}
+
+func getDriveHealthNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		cacheInterval: time.Minute,
+	}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		for _, drive := range globalDriveHealth.Status().Drives {
+			if drive.Time.IsZero() {
+				continue
+			}
+			labels := map[string]string{"disk": drive.Drive}
+			h := drive.Health
+			for _, m := range []struct {
+				md    MetricDescription
+				value uint64
+			}{
+				{getDriveHealthMD(reallocatedSectors, "Number of reallocated sectors of an ATA drive.", gaugeMetric), h.ReallocatedSectors},
+				{getDriveHealthMD(pendingSectors, "Number of sectors of an ATA drive waiting to be reallocated.", gaugeMetric), h.PendingSectors},
+				{getDriveHealthMD(uncorrectableSectors, "Number of uncorrectable sectors of an ATA drive.", gaugeMetric), h.UncorrectableSectors},
+				{getDriveHealthMD(mediaErrors, "Number of media and data integrity errors of a NVMe drive.", gaugeMetric), h.MediaErrors},
+				{getDriveHealthMD(ioErrors, "Number of commands to the drive which failed, as counted by the kernel.", gaugeMetric), h.IOErrors},
+				{getDriveHealthMD(healthEventsTotal, "Total number of health events raised for the drive since server start.", counterMetric), drive.Events},
+			} {
+				metrics = append(metrics, Metric{
+					Description:    m.md,
+					Value:          float64(m.value),
+					VariableLabels: labels,
+				})
+			}
+		}
+		return
+	})
+	return mg
+}
+
+func getResourceLimitsMD(name MetricName, help string, metricType MetricType) MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: limitsSubsystem,
+		Name:      name,
+		Help:      help,
+		Type:      metricType,
+	}
+}
+
+func getResourceLimitsNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		for _, status := range globalResourceLimits.Status() {
+			labels := map[string]string{"resource": status.Resource}
+			for _, m := range []struct {
+				md    MetricDescription
+				value float64
+			}{
+				{getResourceLimitsMD(limitValue, "Configured limit of the resource, 0 if it is not limited.", gaugeMetric), float64(status.Limit)},
+				{getResourceLimitsMD(limitInUse, "Amount of the resource held by admitted operations.", gaugeMetric), float64(status.InUse)},
+				{getResourceLimitsMD(limitWaiting, "Number of operations waiting to be admitted.", gaugeMetric), float64(status.Waiting)},
+				{getResourceLimitsMD(limitAdmittedTotal, "Total number of operations admitted since server start.", counterMetric), float64(status.Admitted)},
+				{getResourceLimitsMD(limitRejectedTotal, "Total number of operations rejected since server start, because their deadline passed while waiting.", counterMetric), float64(status.Rejected)},
+			} {
+				metrics = append(metrics, Metric{
+					Description:    m.md,
+					Value:          m.value,
+					VariableLabels: labels,
+				})
+			}
+		}
+		return
+	})
+	return mg
+}
+
+func getSpeedtestMD(name MetricName, help string) MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: speedtestSubsystem,
+		Name:      name,
+		Help:      help,
+		Type:      gaugeMetric,
+	}
+}
+
+func getSpeedtestMetrics() *MetricsGroup {
+	mg := &MetricsGroup{
+		cacheInterval: time.Minute,
+	}
+	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
+		objLayer := newObjectLayerFn()
+		// Service not initialized yet
+		if objLayer == nil || !globalIsErasure {
+			return
+		}
+
+		history, err := loadSpeedtestHistory(ctx, objLayer)
+		if err != nil || len(history) == 0 {
+			return
+		}
+		metrics = append(metrics, Metric{
+			Description: getSpeedtestMD(speedtestLastRun, "Time the last scheduled speedtests started as seconds since the epoch."),
+			Value:       float64(history[len(history)-1].Start.Unix()),
+		})
+
+		object, drives, net := speedtestTrends(history)
+		for _, series := range []struct {
+			trends            []*speedtestTrend
+			throughput, ratio MetricDescription
+		}{
+			{
+				object,
+				getSpeedtestMD(objectThroughputBytes, "Throughput of the last scheduled object speedtest in bytes per second."),
+				getSpeedtestMD(objectThroughputRatio, "Throughput of the last scheduled object speedtest relative to the median of the previous ones."),
+			},
+			{
+				drives,
+				getSpeedtestMD(driveThroughputBytes, "Write throughput of the drive in the last scheduled speedtest in bytes per second."),
+				getSpeedtestMD(driveThroughputRatio, "Write throughput of the drive in the last scheduled speedtest relative to the median of the previous ones."),
+			},
+			{
+				net,
+				getSpeedtestMD(netThroughputBytes, "Throughput between two nodes in the last scheduled speedtest in bytes per second."),
+				getSpeedtestMD(netThroughputRatio, "Throughput between two nodes in the last scheduled speedtest relative to the median of the previous ones."),
+			},
+		} {
+			for _, trend := range series.trends {
+				metrics = append(metrics, Metric{
+					Description:    series.throughput,
+					Value:          trend.latest(),
+					VariableLabels: trend.labels,
+				})
+				if ratio, ok := trend.ratio(); ok {
+					metrics = append(metrics, Metric{
+						Description:    series.ratio,
+						Value:          ratio,
+						VariableLabels: trend.labels,
+					})
+				}
+			}
+		}
+		return
+	})
+	return mg
+}
+
+func getLocksMD(name MetricName, help string, metricType MetricType) MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: locksSubsystem,
+		Name:      name,
+		Help:      help,
+		Type:      metricType,
+	}
+}
+
+func getLockNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		if !globalIsDistErasure {
+			return
+		}
+		stats := dsync.GetStats()
+		for lockType, stat := range stats.Types {
+			labels := map[string]string{"type": lockType}
+			metrics = append(metrics, Metric{
+				Description:    getLocksMD(locksOutstanding, "Number of distributed locks currently held by the node.", gaugeMetric),
+				Value:          float64(stat.Outstanding),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:          getLocksMD(grantLatencyDistribution, "Distribution of the time until distributed locks were granted, including waiting for other holders.", histogramMetric),
+				VariableLabels:       labels,
+				HistogramBucketLabel: "range",
+				Histogram:            stat.GrantLatency,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getLocksMD(quorumErrorsTotal, "Total number of lock attempts which failed because too many lockers were unreachable.", counterMetric),
+				Value:          float64(stat.QuorumErrors),
+				VariableLabels: labels,
+			})
+			metrics = append(metrics, Metric{
+				Description:    getLocksMD(timeoutsTotal, "Total number of lock requests which were not granted before their timeout.", counterMetric),
+				Value:          float64(stat.Timeouts),
+				VariableLabels: labels,
+			})
+		}
+		metrics = append(metrics, Metric{
+			Description: getLocksMD(refreshFailuresTotal, "Total number of lock refresh calls to lockers which failed.", counterMetric),
+			Value:       float64(stats.RefreshFailures),
+		})
+		for reason, n := range stats.ForceUnlocks {
+			metrics = append(metrics, Metric{
+				Description:    getLocksMD(forceUnlocksTotal, "Total number of locks released forcefully, because they lost quorum or by an administrator.", counterMetric),
+				Value:          float64(n),
+				VariableLabels: map[string]string{"reason": reason},
+			})
+		}
+		return
+	})
+	return mg
+}
+
 func getILMNodeMetrics() *MetricsGroup {
 	mg := &MetricsGroup{}
 	mg.RegisterRead(func(_ context.Context) []Metric {
@@ -1529,10 +2217,53 @@ func getBucketUsageMetrics() *MetricsGroup {
 						Histogram:            stat.Latency.getUploadLatency(),
 						VariableLabels:       map[string]string{"bucket": bucket, "operation": "upload", "targetArn": arn},
 					})
+					metrics = append(metrics, Metric{
+						Description:          getBucketRepLatencyMD(),
+						HistogramBucketLabel: "range",
+						Histogram:            stat.Latency.getProxyLatency(),
+						VariableLabels:       map[string]string{"bucket": bucket, "operation": "proxy", "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepPendingBytesMD(),
+						Value:          float64(stat.PendingSize),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepPendingOperationsMD(),
+						Value:          float64(stat.PendingCount),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepCompletedOperationsTotalMD(),
+						Value:          float64(stat.CompletedOperations),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepFailedOperationsTotalMD(),
+						Value:          float64(stat.FailedOperations),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:          getBucketRepLagMD(),
+						HistogramBucketLabel: "range",
+						Histogram:            stat.Lag.getHistogram(),
+						VariableLabels:       map[string]string{"bucket": bucket, "targetArn": arn},
+					})
 
 				}
 			}
 
+			for tier, st := range usage.TierStats {
+				if tier == minioHotTier {
+					continue
+				}
+				metrics = append(metrics, Metric{
+					Description:    getBucketTierTotalBytesMD(),
+					Value:          float64(st.TotalSize),
+					VariableLabels: map[string]string{"bucket": bucket, "tier": tier},
+				})
+			}
+
 			metrics = append(metrics, Metric{
 				Description:          getBucketObjectDistributionMD(),
 				Histogram:            usage.ObjectSizesHistogram,
@@ -1759,7 +2490,7 @@ func populateAndPublish(metricsGroups []*MetricsGroup, publish func(m Metric) bo
 		if mg == nil {
 			continue
 		}
-		for _, metric := range mg.Get() {
+		for _, metric := range globalMetricsGovernance.apply(mg.Get()) {
 			if !publish(metric) {
 				return
 			}
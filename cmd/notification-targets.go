@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/notify"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Time to wait for the events being saved to a removed or modified
+// notification target, before the target is closed.
+const targetDrainTimeout = 30 * time.Second
+
+// Notification sub-systems of the configuration the targets of
+// globalConfigTargetList were initialized from.
+var configTargets = struct {
+	sync.Mutex
+	cfg config.Config
+}{}
+
+func notifyConfig(s config.Config) config.Config {
+	cfg := make(config.Config, len(config.NotifySubSystems))
+	for subSys := range config.NotifySubSystems {
+		cfg[subSys] = make(map[string]config.KVS, len(s[subSys]))
+		for tgt, kvs := range s[subSys] {
+			cfg[subSys][tgt] = kvs.Clone()
+		}
+	}
+	return cfg
+}
+
+// setConfigTargets - sets the configuration the targets of
+// globalConfigTargetList were initialized from.
+func setConfigTargets(s config.Config) {
+	configTargets.Lock()
+	configTargets.cfg = notifyConfig(s)
+	configTargets.Unlock()
+}
+
+// reloadConfigTargets - applies the notification target configuration
+// of s without a restart. Added and modified targets are initialized,
+// removed and modified targets stop receiving events immediately and are
+// closed once the events being saved to them were saved.
+func reloadConfigTargets(ctx context.Context, s config.Config) error {
+	configTargets.Lock()
+	defer configTargets.Unlock()
+
+	if configTargets.cfg == nil || globalNotificationSys == nil {
+		// Targets are not initialized yet.
+		return nil
+	}
+
+	changed := notify.ChangedNotificationTargets(configTargets.cfg, s)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	targetList, err := notify.GetNotificationTargetsByID(GlobalContext, s, NewGatewayHTTPTransport(), changed)
+	if err != nil {
+		if err != notify.ErrTargetsOffline {
+			return err
+		}
+		// Offline targets are added like on startup, their events
+		// are delivered once they are back online.
+		logger.LogIf(ctx, err)
+	}
+
+	configTargetList := event.NewTargetList()
+	for _, target := range globalConfigTargetList.Targets() {
+		if _, ok := changed[target.ID()]; !ok {
+			logger.LogIf(ctx, configTargetList.Add(target))
+		}
+	}
+	if err = configTargetList.Add(targetList.Targets()...); err != nil {
+		return err
+	}
+
+	if err = globalNotificationSys.targetList.Replace(changed, targetList.Targets(), targetDrainTimeout); err != nil {
+		return err
+	}
+	globalConfigTargetList = configTargetList
+	configTargets.cfg = notifyConfig(s)
+	return nil
+}
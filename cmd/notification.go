@@ -29,6 +29,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bits-and-blooms/bloom/v3"
@@ -691,6 +692,9 @@ func (sys *NotificationSys) Init(ctx context.Context, buckets []BucketInfo, objA
 				reqInfo := &logger.ReqInfo{}
 				reqInfo.AppendTags("targetID", res.ID.Name)
 				logger.LogOnceIf(logger.SetReqInfo(GlobalContext, reqInfo), res.Err, res.ID)
+				if res.Event != nil {
+					logger.LogOnceIf(GlobalContext, globalEventDeadLetters.Put(res.ID, *res.Event, res.Err), "event-deadletter")
+				}
 			}
 		}
 	}()
@@ -791,14 +795,33 @@ func (sys *NotificationSys) RemoveAllRemoteTargets() {
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) {
 	sys.RLock()
-	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
+	targetIDSet := sys.bucketRulesMap[args.BucketName].MatchObject(args.EventName, args.Object.Name, args.objectAttributes())
 	sys.RUnlock()
 
 	if len(targetIDSet) == 0 {
 		return
 	}
 
-	sys.targetList.Send(args.ToEvent(true), targetIDSet, sys.targetResCh)
+	ev := args.ToEvent(true)
+	logger.LogOnceIf(GlobalContext, globalEventJournal.Append(ev), "event-journal")
+	sys.targetList.Send(ev, targetIDSet, sys.targetResCh)
+}
+
+// SendIAMChange - sends the IAM change event to the given notification
+// targets, targets not configured on this server are ignored.
+func (sys *NotificationSys) SendIAMChange(ev event.Event, targetIDs []event.TargetID) {
+	targetIDSet := event.NewTargetIDSet()
+	for _, targetID := range targetIDs {
+		if sys.targetList.Exists(targetID) {
+			targetIDSet[targetID] = struct{}{}
+		}
+	}
+
+	if len(targetIDSet) == 0 {
+		return
+	}
+
+	sys.targetList.Send(ev, targetIDSet, sys.targetResCh)
 }
 
 // GetNetPerfInfo - Net information
@@ -1319,6 +1342,10 @@ func GetPeerOnlineCount() (nodesOnline, nodesOffline int) {
 	return
 }
 
+// replicationTargetArnElement is the response element naming the
+// replication target of replication threshold events.
+const replicationTargetArnElement = "x-minio-replication-target-arn"
+
 type eventArgs struct {
 	EventName    event.Name
 	BucketName   string
@@ -1329,10 +1356,29 @@ type eventArgs struct {
 	UserAgent    string
 }
 
+// lastEventSequence - sequence of the last event generated by this node.
+var lastEventSequence uint64
+
+// nextEventSequence - returns the event time in nanoseconds, or the
+// next sequence if the clock did not advance since the last event, so
+// that the sequencers of events generated by this node strictly increase.
+func nextEventSequence(t time.Time) uint64 {
+	for {
+		last := atomic.LoadUint64(&lastEventSequence)
+		next := uint64(t.UnixNano())
+		if next <= last {
+			next = last + 1
+		}
+		if atomic.CompareAndSwapUint64(&lastEventSequence, last, next) {
+			return next
+		}
+	}
+}
+
 // ToEvent - converts to notification event.
 func (args eventArgs) ToEvent(escape bool) event.Event {
 	eventTime := UTCNow()
-	uniqueID := fmt.Sprintf("%X", eventTime.UnixNano())
+	uniqueID := fmt.Sprintf("%016X", nextEventSequence(eventTime))
 
 	respElements := map[string]string{
 		"x-amz-request-id": args.RespElements["requestId"],
@@ -1350,12 +1396,18 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 	if args.RespElements["content-length"] != "" {
 		respElements["content-length"] = args.RespElements["content-length"]
 	}
+	if args.RespElements[replicationTargetArnElement] != "" {
+		respElements[replicationTargetArnElement] = args.RespElements[replicationTargetArnElement]
+	}
+	if args.RespElements[tierVerifyErrorElement] != "" {
+		respElements[tierVerifyErrorElement] = args.RespElements[tierVerifyErrorElement]
+	}
 	keyName := args.Object.Name
 	if escape {
 		keyName = url.QueryEscape(args.Object.Name)
 	}
 	newEvent := event.Event{
-		EventVersion:      "2.0",
+		EventVersion:      event.LatestSchemaVersion,
 		EventSource:       "minio:s3",
 		AwsRegion:         args.ReqParams["region"],
 		EventTime:         eventTime.Format(event.AMZTimeFormat),
@@ -1383,7 +1435,12 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 		},
 	}
 
-	if args.EventName != event.ObjectRemovedDelete && args.EventName != event.ObjectRemovedDeleteMarkerCreated {
+	switch args.EventName {
+	case event.ObjectRemovedDelete, event.ObjectRemovedDeleteMarkerCreated,
+		event.ObjectLifecycleExpirationDelete, event.ObjectLifecycleExpirationDeleteMarkerCreated,
+		event.TestEvent:
+		// Removed objects and test events have no content attributes.
+	default:
 		newEvent.S3.Object.ETag = args.Object.ETag
 		newEvent.S3.Object.Size = args.Object.Size
 		newEvent.S3.Object.ContentType = args.Object.ContentType
@@ -1396,9 +1453,44 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 		}
 	}
 
+	if args.EventName == event.ObjectRestorePostCompleted && !args.Object.RestoreExpires.IsZero() {
+		newEvent.GlacierEventData = &event.GlacierEventData{
+			RestoreEventData: event.RestoreEventData{
+				LifecycleRestorationExpiryTime: args.Object.RestoreExpires.Format(event.AMZTimeFormat),
+				LifecycleRestoreStorageClass:   args.Object.TransitionedObject.Tier,
+			},
+		}
+	}
+
 	return newEvent
 }
 
+// objectAttributes - returns the attributes of the object evaluated by
+// the object attribute conditions of notification filters.
+func (args eventArgs) objectAttributes() event.ObjectAttributes {
+	attrs := event.ObjectAttributes{
+		Size:         args.Object.Size,
+		StorageClass: args.Object.StorageClass,
+	}
+	if args.Object.UserTags != "" {
+		if values, err := url.ParseQuery(args.Object.UserTags); err == nil {
+			attrs.Tags = make(map[string]string, len(values))
+			for k, v := range values {
+				attrs.Tags[k] = v[0]
+			}
+		}
+	}
+	for k, v := range args.Object.UserDefined {
+		if k = strings.ToLower(k); strings.HasPrefix(k, "x-amz-meta-") {
+			if attrs.UserMetadata == nil {
+				attrs.UserMetadata = make(map[string]string)
+			}
+			attrs.UserMetadata[k] = v
+		}
+	}
+	return attrs
+}
+
 func sendEvent(args eventArgs) {
 	args.Object.Size, _ = args.Object.GetActualSize()
 
@@ -1630,3 +1722,413 @@ func (sys *NotificationSys) ReloadSiteReplicationConfig(ctx context.Context) []e
 	wg.Wait()
 	return errs
 }
+
+// ReloadSTSRevocations - tells all peer minio nodes to reload the STS
+// revocation list.
+func (sys *NotificationSys) ReloadSTSRevocations(ctx context.Context) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ReloadSTSRevocations(ctx)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// ReloadKMSImportedKeys - tells all peer minio nodes to reload the
+// list of imported KMS keys.
+func (sys *NotificationSys) ReloadKMSImportedKeys(ctx context.Context) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ReloadKMSImportedKeys(ctx)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// GetTierVerifyStatus - returns the tier verification status of all
+// peers, excluding the local node.
+func (sys *NotificationSys) GetTierVerifyStatus(ctx context.Context) []tierVerifyStatus {
+	statuses := make([]tierVerifyStatus, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			statuses[index], err = sys.peerClients[index].GetTierVerifyStatus(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+	return statuses
+}
+
+// GetTierUsage - returns the remote tier usage recorded by all peers,
+// excluding the local node.
+func (sys *NotificationSys) GetTierUsage(ctx context.Context) [][]tierUsage {
+	usage := make([][]tierUsage, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			usage[index], err = sys.peerClients[index].GetTierUsage(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+		}
+	}
+	return usage
+}
+
+// ReplayEvents - replays the events journaled by all peers, excluding
+// the local node, to the notification target.
+func (sys *NotificationSys) ReplayEvents(ctx context.Context, arn event.ARN, filter eventReplayFilter) []eventReplayResult {
+	results := make([]eventReplayResult, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			results[index].Node = sys.peerClients[index].host.String()
+			var err error
+			results[index].Replayed, err = sys.peerClients[index].ReplayEvents(ctx, arn, filter)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// eventDeadLetters - runs fn against all peers, excluding the local node.
+func (sys *NotificationSys) eventDeadLetters(fn func(client *peerRESTClient, result *eventDeadLetterResult) error) []eventDeadLetterResult {
+	results := make([]eventDeadLetterResult, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			results[index].Node = sys.peerClients[index].host.String()
+			return fn(sys.peerClients[index], &results[index])
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// ListEventDeadLetters - lists the events all peers could not deliver.
+func (sys *NotificationSys) ListEventDeadLetters(ctx context.Context, arn string, maxEntries int) []eventDeadLetterResult {
+	return sys.eventDeadLetters(func(client *peerRESTClient, result *eventDeadLetterResult) (err error) {
+		result.DeadLetters, err = client.ListEventDeadLetters(ctx, arn, maxEntries)
+		return err
+	})
+}
+
+// RedriveEventDeadLetters - sends the events all peers could not deliver
+// to their targets again.
+func (sys *NotificationSys) RedriveEventDeadLetters(ctx context.Context, arn string) []eventDeadLetterResult {
+	return sys.eventDeadLetters(func(client *peerRESTClient, result *eventDeadLetterResult) (err error) {
+		result.Redriven, err = client.RedriveEventDeadLetters(ctx, arn)
+		return err
+	})
+}
+
+// PurgeEventDeadLetters - removes the events all peers could not deliver.
+func (sys *NotificationSys) PurgeEventDeadLetters(ctx context.Context, arn string) []eventDeadLetterResult {
+	return sys.eventDeadLetters(func(client *peerRESTClient, result *eventDeadLetterResult) (err error) {
+		result.Purged, err = client.PurgeEventDeadLetters(ctx, arn)
+		return err
+	})
+}
+
+// eventTargetStatsResult - delivery statistics of the notification
+// targets of a single node.
+type eventTargetStatsResult struct {
+	Node    string             `json:"node"`
+	Targets []event.TargetStat `json:"targets,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+// GetEventTargetStats - returns the delivery statistics of the
+// notification targets of all peers, excluding the local node.
+func (sys *NotificationSys) GetEventTargetStats(ctx context.Context) []eventTargetStatsResult {
+	results := make([]eventTargetStatsResult, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			results[index].Node = sys.peerClients[index].host.String()
+			results[index].Targets, err = sys.peerClients[index].GetEventTargetStats(ctx)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// eventTargetTestTimeout - time to wait for a notification target to
+// accept a test event.
+const eventTargetTestTimeout = 10 * time.Second
+
+// eventTargetTestResult - outcome of delivering a test event through the
+// notification targets of a single node.
+type eventTargetTestResult struct {
+	Node    string                   `json:"node"`
+	Targets []event.TargetTestResult `json:"targets,omitempty"`
+	Error   string                   `json:"error,omitempty"`
+}
+
+// newTestEvent - returns the event sent to verify notification targets.
+func newTestEvent(bucket string) event.Event {
+	return eventArgs{
+		EventName:  event.TestEvent,
+		BucketName: bucket,
+		ReqParams:  map[string]string{"region": globalSite.Region},
+	}.ToEvent(false)
+}
+
+// SendTestEvent - sends a test event of the bucket to the targets of its
+// notification configuration, so that they can verify it as with S3.
+func (sys *NotificationSys) SendTestEvent(bucket string, config *event.Config) {
+	targetIDSet := event.NewTargetIDSet()
+	for _, queue := range config.QueueList {
+		targetIDSet[queue.ARN.TargetID] = struct{}{}
+	}
+
+	if len(targetIDSet) == 0 {
+		return
+	}
+
+	sys.targetList.Send(newTestEvent(bucket), targetIDSet, sys.targetResCh)
+}
+
+// testEventTargets - delivers a test event to the notification target,
+// or to all targets if targetID is empty, and returns the outcome. If
+// bucket is set, only the targets of its notification rules are tested.
+func (sys *NotificationSys) testEventTargets(targetID event.TargetID, bucket string) ([]event.TargetTestResult, error) {
+	targetIDSet := event.NewTargetIDSet()
+	if targetID != (event.TargetID{}) {
+		if !sys.targetList.Exists(targetID) {
+			return nil, &event.ErrARNNotFound{ARN: targetID.ToARN(globalSite.Region)}
+		}
+		targetIDSet[targetID] = struct{}{}
+	}
+
+	if bucket != "" {
+		bucketTargetIDSet := event.NewTargetIDSet()
+		sys.RLock()
+		for _, rules := range sys.bucketRulesMap[bucket] {
+			for _, ruleTargetIDSet := range rules {
+				bucketTargetIDSet = bucketTargetIDSet.Union(ruleTargetIDSet)
+			}
+		}
+		sys.RUnlock()
+
+		if _, ok := bucketTargetIDSet[targetID]; ok {
+			bucketTargetIDSet = targetIDSet
+		} else if len(targetIDSet) != 0 {
+			bucketTargetIDSet = event.NewTargetIDSet()
+		}
+		// An empty set would select all targets.
+		if len(bucketTargetIDSet) == 0 {
+			return []event.TargetTestResult{}, nil
+		}
+		targetIDSet = bucketTargetIDSet
+	}
+
+	return sys.targetList.Test(newTestEvent(bucket), targetIDSet, globalSite.Region, eventTargetTestTimeout), nil
+}
+
+// TestEventTargets - delivers a test event through the notification
+// targets of all peers, excluding the local node.
+func (sys *NotificationSys) TestEventTargets(ctx context.Context, arn, bucket string) []eventTargetTestResult {
+	results := make([]eventTargetTestResult, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			results[index].Node = sys.peerClients[index].host.String()
+			results[index].Targets, err = sys.peerClients[index].TestEventTargets(ctx, arn, bucket)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// GetDriveHealth - returns the drive health of all peers, excluding
+// the local node.
+func (sys *NotificationSys) GetDriveHealth(ctx context.Context) []driveHealthStatus {
+	results := make([]driveHealthStatus, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			results[index], err = sys.peerClients[index].GetDriveHealth(ctx)
+			if results[index].Node == "" {
+				results[index].Node = sys.peerClients[index].host.String()
+			}
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// GetInflightRequests - returns the API requests executing on all
+// peers, excluding the local node.
+func (sys *NotificationSys) GetInflightRequests(ctx context.Context) []inflightRequestsStatus {
+	results := make([]inflightRequestsStatus, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			results[index], err = sys.peerClients[index].GetInflightRequests(ctx)
+			if results[index].Node == "" {
+				results[index].Node = sys.peerClients[index].host.String()
+			}
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// CancelInflightRequest - cancels the API request with the ID on the
+// peers, or only on the peer named node if it is set. Returns true if
+// any peer canceled the request, otherwise the first peer error.
+func (sys *NotificationSys) CancelInflightRequest(ctx context.Context, node, id string) (bool, error) {
+	canceled := make([]bool, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		client := sys.peerClients[index]
+		if client == nil || (node != "" && client.host.String() != node) {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			canceled[index], err = client.CancelInflightRequest(ctx, id)
+			return err
+		}, index)
+	}
+
+	var firstErr error
+	for index, err := range g.Wait() {
+		if canceled[index] {
+			return true, nil
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return false, firstErr
+}
+
+// ReloadCerts - reloads the TLS certificates of all peers, excluding
+// the local node.
+func (sys *NotificationSys) ReloadCerts(ctx context.Context) []certsReloadStatus {
+	results := make([]certsReloadStatus, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			results[index], err = sys.peerClients[index].ReloadCerts(ctx)
+			if results[index].Node == "" {
+				results[index].Node = sys.peerClients[index].host.String()
+			}
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			results[index].Error = err.Error()
+		}
+	}
+	return results
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+)
+
+func TestRestoreEventGlacierData(t *testing.T) {
+	expiry := time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+	oi := ObjectInfo{
+		Name:               "obj",
+		RestoreExpires:     expiry,
+		TransitionedObject: TransitionedObject{Tier: "WARM"},
+	}
+
+	ev := eventArgs{EventName: event.ObjectRestorePostInitiated, BucketName: "bucket", Object: oi}.ToEvent(false)
+	if ev.GlacierEventData != nil {
+		t.Fatal("expected no restore data for initiated restores")
+	}
+
+	ev = eventArgs{EventName: event.ObjectRestorePostCompleted, BucketName: "bucket", Object: oi}.ToEvent(false)
+	if ev.GlacierEventData == nil {
+		t.Fatal("expected restore data for completed restores")
+	}
+	expected := event.RestoreEventData{
+		LifecycleRestorationExpiryTime: "2022-01-02T00:00:00.000Z",
+		LifecycleRestoreStorageClass:   "WARM",
+	}
+	if ev.GlacierEventData.RestoreEventData != expected {
+		t.Fatalf("expected %+v, got %+v", expected, ev.GlacierEventData.RestoreEventData)
+	}
+}
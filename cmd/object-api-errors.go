@@ -370,6 +370,13 @@ func (e BucketSSEConfigNotFound) Error() string {
 	return "No bucket encryption configuration found for bucket: " + e.Bucket
 }
 
+// BucketPublicAccessBlockNotFound - no bucket public access block found
+type BucketPublicAccessBlockNotFound GenericError
+
+func (e BucketPublicAccessBlockNotFound) Error() string {
+	return "No public access block configuration found for bucket: " + e.Bucket
+}
+
 // BucketTaggingNotFound - no bucket tags found
 type BucketTaggingNotFound GenericError
 
@@ -85,6 +85,7 @@ type ExpirationOptions struct {
 type TransitionOptions struct {
 	Status         string
 	Tier           string
+	RuleID         string
 	ETag           string
 	RestoreRequest *RestoreObjectRequest
 	RestoreExpiry  time.Time
@@ -4025,3 +4025,182 @@ func testAPIListObjectPartsHandler(obj ObjectLayer, instanceType, bucketName str
 	// `ExecObjectLayerAPINilTest` sets the Object Layer to `nil` and calls the handler.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// Wrapper for calling the SSE-C server side copy tests for both Erasure
+// multiple disks and single node setup.
+func TestAPICopyObjectPartHandlerSSEC(t *testing.T) {
+	defer DetectTestLeak(t)()
+	// Handlers are matched in order, so the multipart and copy handlers
+	// must be registered before the generic object handlers.
+	ExecObjectLayerAPITest(t, testAPICopyObjectPartHandlerSSEC, []string{"NewMultipart", "CopyObjectPart", "PutObjectPart", "ListObjectParts", "CompleteMultipart", "CopyObject", "GetObject", "PutObject"})
+}
+
+func testAPICopyObjectPartHandlerSSEC(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	// Set SSL to on to do encryption tests
+	globalIsTLS = true
+	defer func() { globalIsTLS = false }()
+
+	ssecHeaders := func(key []byte, copySource bool) map[string]string {
+		keyMD5 := md5.Sum(key)
+		if copySource {
+			return map[string]string{
+				xhttp.AmzServerSideEncryptionCopyCustomerAlgorithm: xhttp.AmzEncryptionAES,
+				xhttp.AmzServerSideEncryptionCopyCustomerKey:       base64.StdEncoding.EncodeToString(key),
+				xhttp.AmzServerSideEncryptionCopyCustomerKeyMD5:    base64.StdEncoding.EncodeToString(keyMD5[:]),
+			}
+		}
+		return map[string]string{
+			xhttp.AmzServerSideEncryptionCustomerAlgorithm: xhttp.AmzEncryptionAES,
+			xhttp.AmzServerSideEncryptionCustomerKey:       base64.StdEncoding.EncodeToString(key),
+			xhttp.AmzServerSideEncryptionCustomerKeyMD5:    base64.StdEncoding.EncodeToString(keyMD5[:]),
+		}
+	}
+	merge := func(headers ...map[string]string) map[string]string {
+		m := make(map[string]string)
+		for _, h := range headers {
+			for k, v := range h {
+				m[k] = v
+			}
+		}
+		return m
+	}
+	copySource := func(object string) map[string]string {
+		return map[string]string{xhttp.AmzCopySource: url.QueryEscape(pathJoin(bucketName, object))}
+	}
+	call := func(method, urlStr string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(body)), bytes.NewReader(body),
+			credentials.AccessKey, credentials.SecretKey, headers)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+	mustCall := func(name, method, urlStr string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+		rec := call(method, urlStr, body, headers)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("MinIO %s: %s: expected 200, got %d: %s", instanceType, name, rec.Code, rec.Body.String())
+		}
+		return rec
+	}
+	newUpload := func(object string, headers map[string]string) string {
+		rec := mustCall("NewMultipartUpload", http.MethodPost, getNewMultipartURL("", bucketName, object), nil, headers)
+		var resp InitiateMultipartUploadResponse
+		if err := xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.UploadID
+	}
+	complete := func(object, uploadID string, etags []string, headers map[string]string) {
+		var parts CompleteMultipartUpload
+		for i, etag := range etags {
+			parts.Parts = append(parts.Parts, CompletePart{PartNumber: i + 1, ETag: canonicalizeETag(etag)})
+		}
+		body, err := xml.Marshal(parts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustCall("CompleteMultipartUpload", http.MethodPost, getCompleteMultipartUploadURL("", bucketName, object, uploadID), body, headers)
+	}
+	verify := func(object string, headers map[string]string, expected []byte) {
+		rec := mustCall("GetObject", http.MethodGet, getGetObjectURL("", bucketName, object), nil, headers)
+		if !bytes.Equal(rec.Body.Bytes(), expected) {
+			t.Errorf("MinIO %s: %s: content mismatch", instanceType, object)
+		}
+	}
+
+	var (
+		keyA = bytes.Repeat([]byte{'a'}, 32)
+		keyB = bytes.Repeat([]byte{'b'}, 32)
+		data = bytes.Repeat([]byte("0123456789abcdef"), (6*humanize.MiByte)/16)
+		part = data[:5*humanize.MiByte]
+	)
+
+	// Single part and multipart SSE-C sources.
+	mustCall("PutObject", http.MethodPut, getPutObjectURL("", bucketName, "src"), data[:1000], ssecHeaders(keyA, false))
+	uploadID := newUpload("src-mp", ssecHeaders(keyA, false))
+	var etags []string
+	for i, p := range [][]byte{part, data[len(part):]} {
+		rec := mustCall("PutObjectPart", http.MethodPut, getPutObjectPartURL("", bucketName, "src-mp", uploadID, strconv.Itoa(i+1)), p, ssecHeaders(keyA, false))
+		etags = append(etags, rec.Header()[xhttp.ETag][0])
+	}
+	complete("src-mp", uploadID, etags, ssecHeaders(keyA, false))
+
+	// CopyObject from an SSE-C source to another SSE-C key.
+	for src, content := range map[string][]byte{"src": data[:1000], "src-mp": data} {
+		mustCall("CopyObject", http.MethodPut, getPutObjectURL("", bucketName, "dst-"+src), nil,
+			merge(ssecHeaders(keyB, false), ssecHeaders(keyA, true), copySource(src)))
+		verify("dst-"+src, ssecHeaders(keyB, false), content)
+	}
+
+	// Multipart uploads whose parts are copied from SSE-C sources.
+	testCases := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"SSE-C", ssecHeaders(keyB, false)},
+		{"SSE-C same key", ssecHeaders(keyA, false)},
+		{"unencrypted", nil},
+	}
+	for _, testCase := range testCases {
+		uploadID := newUpload("dst-mp", testCase.headers)
+		var etags []string
+		for i, src := range []map[string]string{
+			merge(copySource("src-mp"), map[string]string{xhttp.AmzCopySourceRange: fmt.Sprintf("bytes=0-%d", len(part)-1)}),
+			copySource("src"),
+		} {
+			rec := mustCall(testCase.name+": CopyObjectPart", http.MethodPut, getCopyObjectPartURL("", bucketName, "dst-mp", uploadID, strconv.Itoa(i+1)), nil,
+				merge(testCase.headers, ssecHeaders(keyA, true), src))
+			var resp CopyObjectPartResponse
+			if err := xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			etags = append(etags, resp.ETag)
+		}
+
+		rec := mustCall(testCase.name+": ListObjectParts", http.MethodGet, getListMultipartURLWithParams("", bucketName, "dst-mp", uploadID, "", "", ""), nil, testCase.headers)
+		var parts ListPartsResponse
+		if err := xml.Unmarshal(rec.Body.Bytes(), &parts); err != nil {
+			t.Fatal(err)
+		}
+		if len(parts.Parts) != 2 || parts.Parts[0].Size != int64(len(part)) || parts.Parts[1].Size != 1000 {
+			t.Errorf("MinIO %s: %s: unexpected parts %v", instanceType, testCase.name, parts.Parts)
+		}
+		for i := range parts.Parts {
+			if canonicalizeETag(parts.Parts[i].ETag) != canonicalizeETag(etags[i]) {
+				t.Errorf("MinIO %s: %s: part %d ETag %s does not match the copy ETag %s", instanceType, testCase.name, i+1, parts.Parts[i].ETag, etags[i])
+			}
+		}
+
+		complete("dst-mp", uploadID, etags, testCase.headers)
+		verify("dst-mp", testCase.headers, append(append([]byte{}, part...), data[:1000]...))
+	}
+
+	// Copying parts with missing or wrong keys fails.
+	mustCall("PutObject", http.MethodPut, getPutObjectURL("", bucketName, "plain"), data[:1000], nil)
+	uploadID = newUpload("dst-mp", ssecHeaders(keyB, false))
+	failures := []struct {
+		name     string
+		headers  map[string]string
+		expected int
+	}{
+		{"missing copy key", merge(ssecHeaders(keyB, false), copySource("src")), http.StatusBadRequest},
+		{"wrong copy key", merge(ssecHeaders(keyB, false), ssecHeaders(keyB, true), copySource("src")), http.StatusForbidden},
+		{"copy key for unencrypted source", merge(ssecHeaders(keyB, false), ssecHeaders(keyA, true), copySource("plain")), http.StatusBadRequest},
+		{"missing key", merge(ssecHeaders(keyA, true), copySource("src")), http.StatusBadRequest},
+		{"wrong key", merge(ssecHeaders(keyA, false), ssecHeaders(keyA, true), copySource("src")), http.StatusForbidden},
+	}
+	for _, failure := range failures {
+		rec := call(http.MethodPut, getCopyObjectPartURL("", bucketName, "dst-mp", uploadID, "1"), nil, failure.headers)
+		if rec.Code != failure.expected {
+			t.Errorf("MinIO %s: %s: expected %d, got %d", instanceType, failure.name, failure.expected, rec.Code)
+		}
+	}
+	rec := call(http.MethodGet, getListMultipartURLWithParams("", bucketName, "dst-mp", uploadID, "", "", ""), nil, ssecHeaders(keyA, false))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("MinIO %s: ListObjectParts with wrong key: expected %d, got %d", instanceType, http.StatusForbidden, rec.Code)
+	}
+}
@@ -787,14 +787,15 @@ func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) erro
 	return nil
 }
 
-func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
+func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan struct{}, opts traceOpts) {
 	values := make(url.Values)
-	values.Set(peerRESTTraceErr, strconv.FormatBool(traceOpts.OnlyErrors))
-	values.Set(peerRESTTraceS3, strconv.FormatBool(traceOpts.S3))
-	values.Set(peerRESTTraceStorage, strconv.FormatBool(traceOpts.Storage))
-	values.Set(peerRESTTraceOS, strconv.FormatBool(traceOpts.OS))
-	values.Set(peerRESTTraceInternal, strconv.FormatBool(traceOpts.Internal))
-	values.Set(peerRESTTraceThreshold, traceOpts.Threshold.String())
+	values.Set(peerRESTTraceErr, strconv.FormatBool(opts.OnlyErrors))
+	values.Set(peerRESTTraceS3, strconv.FormatBool(opts.S3))
+	values.Set(peerRESTTraceStorage, strconv.FormatBool(opts.Storage))
+	values.Set(peerRESTTraceOS, strconv.FormatBool(opts.OS))
+	values.Set(peerRESTTraceInternal, strconv.FormatBool(opts.Internal))
+	values.Set(peerRESTTraceThreshold, opts.Threshold.String())
+	opts.encodeTraceFilters(values)
 
 	// To cancel the REST request in case doneCh gets closed.
 	ctx, cancel := context.WithCancel(GlobalContext)
@@ -888,10 +889,10 @@ func (client *peerRESTClient) Listen(listenCh chan interface{}, doneCh <-chan st
 }
 
 // Trace - send http trace request to peer nodes
-func (client *peerRESTClient) Trace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
+func (client *peerRESTClient) Trace(traceCh chan interface{}, doneCh <-chan struct{}, opts traceOpts) {
 	go func() {
 		for {
-			client.doTrace(traceCh, doneCh, traceOpts)
+			client.doTrace(traceCh, doneCh, opts)
 			select {
 			case <-doneCh:
 				return
@@ -1017,6 +1018,174 @@ func (client *peerRESTClient) MonitorBandwidth(ctx context.Context, buckets []st
 	return &bandwidthReport, err
 }
 
+// GetTierVerifyStatus - returns the tier verification status of the peer.
+func (client *peerRESTClient) GetTierVerifyStatus(ctx context.Context) (tierVerifyStatus, error) {
+	var status tierVerifyStatus
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetTierVerifyStatus, nil, nil, -1)
+	if err != nil {
+		return status, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
+
+// GetTierUsage - returns the remote tier usage recorded by the peer.
+func (client *peerRESTClient) GetTierUsage(ctx context.Context) ([]tierUsage, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetTierUsage, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	var usage []tierUsage
+	err = gob.NewDecoder(respBody).Decode(&usage)
+	return usage, err
+}
+
+// ReplayEvents - replays the events journaled by the peer to the
+// notification target and returns the number of replayed events.
+func (client *peerRESTClient) ReplayEvents(ctx context.Context, arn event.ARN, filter eventReplayFilter) (int, error) {
+	values := make(url.Values)
+	values.Set(peerRESTReplayARN, arn.String())
+	values.Set(peerRESTBucket, filter.Bucket)
+	values.Set(peerRESTReplayPrefix, filter.Prefix)
+	if !filter.Start.IsZero() {
+		values.Set(peerRESTReplayStart, filter.Start.Format(time.RFC3339Nano))
+	}
+	if !filter.End.IsZero() {
+		values.Set(peerRESTReplayEnd, filter.End.Format(time.RFC3339Nano))
+	}
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReplayEvents, values, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer http.DrainBody(respBody)
+	var n int
+	err = gob.NewDecoder(respBody).Decode(&n)
+	return n, err
+}
+
+// ListEventDeadLetters - returns up to maxEntries events the peer could
+// not deliver to the notification target, or to any target if arn is empty.
+func (client *peerRESTClient) ListEventDeadLetters(ctx context.Context, arn string, maxEntries int) ([]eventDeadLetter, error) {
+	values := make(url.Values)
+	values.Set(peerRESTDeadLetterARN, arn)
+	values.Set(peerRESTDeadLetterMaxEntries, strconv.Itoa(maxEntries))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodListEventDeadLetters, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	var dls []eventDeadLetter
+	err = gob.NewDecoder(respBody).Decode(&dls)
+	return dls, err
+}
+
+// RedriveEventDeadLetters - sends the events the peer could not deliver
+// to their targets again and returns the number of re-driven events.
+func (client *peerRESTClient) RedriveEventDeadLetters(ctx context.Context, arn string) (int, error) {
+	return client.eventDeadLettersCall(ctx, peerRESTMethodRedriveEventDeadLetters, arn)
+}
+
+// PurgeEventDeadLetters - removes the events the peer could not deliver
+// and returns the number of removed events.
+func (client *peerRESTClient) PurgeEventDeadLetters(ctx context.Context, arn string) (int, error) {
+	return client.eventDeadLettersCall(ctx, peerRESTMethodPurgeEventDeadLetters, arn)
+}
+
+func (client *peerRESTClient) eventDeadLettersCall(ctx context.Context, method, arn string) (int, error) {
+	values := make(url.Values)
+	values.Set(peerRESTDeadLetterARN, arn)
+	respBody, err := client.callWithContext(ctx, method, values, nil, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer http.DrainBody(respBody)
+	var n int
+	err = gob.NewDecoder(respBody).Decode(&n)
+	return n, err
+}
+
+// GetEventTargetStats - returns the delivery statistics of the
+// notification targets of the peer.
+func (client *peerRESTClient) GetEventTargetStats(ctx context.Context) ([]event.TargetStat, error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetEventTargetStats, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	var stats []event.TargetStat
+	err = gob.NewDecoder(respBody).Decode(&stats)
+	return stats, err
+}
+
+// TestEventTargets - delivers a test event through the notification
+// targets of the peer and returns the outcome.
+func (client *peerRESTClient) TestEventTargets(ctx context.Context, arn, bucket string) ([]event.TargetTestResult, error) {
+	values := make(url.Values)
+	values.Set(peerRESTTestEventARN, arn)
+	values.Set(peerRESTTestEventBucket, bucket)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodTestEventTargets, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	var results []event.TargetTestResult
+	err = gob.NewDecoder(respBody).Decode(&results)
+	return results, err
+}
+
+// GetDriveHealth - returns the drive health of the peer.
+func (client *peerRESTClient) GetDriveHealth(ctx context.Context) (driveHealthStatus, error) {
+	var status driveHealthStatus
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetDriveHealth, nil, nil, -1)
+	if err != nil {
+		return status, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
+
+// GetInflightRequests - returns the API requests executing on the peer.
+func (client *peerRESTClient) GetInflightRequests(ctx context.Context) (inflightRequestsStatus, error) {
+	var status inflightRequestsStatus
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetInflightRequests, nil, nil, -1)
+	if err != nil {
+		return status, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
+
+// CancelInflightRequest - cancels the API request with the ID on the
+// peer, returns false if the peer has no such request.
+func (client *peerRESTClient) CancelInflightRequest(ctx context.Context, id string) (bool, error) {
+	values := make(url.Values)
+	values.Set(peerRESTRequestID, id)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodCancelInflightRequest, values, nil, -1)
+	if err != nil {
+		return false, err
+	}
+	defer http.DrainBody(respBody)
+	var canceled bool
+	err = gob.NewDecoder(respBody).Decode(&canceled)
+	return canceled, err
+}
+
+// ReloadCerts - reloads the TLS certificates of the peer.
+func (client *peerRESTClient) ReloadCerts(ctx context.Context) (certsReloadStatus, error) {
+	var status certsReloadStatus
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadCerts, nil, nil, -1)
+	if err != nil {
+		return status, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
+
 func (client *peerRESTClient) GetPeerMetrics(ctx context.Context) (<-chan Metric, error) {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodGetPeerMetrics, nil, nil, -1)
 	if err != nil {
@@ -1075,3 +1244,21 @@ func (client *peerRESTClient) ReloadSiteReplicationConfig(ctx context.Context) e
 	defer http.DrainBody(respBody)
 	return nil
 }
+
+func (client *peerRESTClient) ReloadSTSRevocations(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadSTSRevocations, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+func (client *peerRESTClient) ReloadKMSImportedKeys(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadKMSImportedKeys, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
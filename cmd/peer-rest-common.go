@@ -18,7 +18,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v18" // Add LoadPoolMeta
+	peerRESTVersion       = "v28" // Add reload certificates method
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -68,12 +68,27 @@ const (
 	peerRESTMethodSpeedtest                   = "/speedtest"
 	peerRESTMethodReloadSiteReplicationConfig = "/reloadsitereplicationconfig"
 	peerRESTMethodReloadPoolMeta              = "/reloadpoolmeta"
+	peerRESTMethodReloadSTSRevocations        = "/reloadstsrevocations"
+	peerRESTMethodReloadKMSImportedKeys       = "/reloadkmsimportedkeys"
+	peerRESTMethodGetTierVerifyStatus         = "/gettierverifystatus"
+	peerRESTMethodGetTierUsage                = "/gettierusage"
+	peerRESTMethodReplayEvents                = "/replayevents"
+	peerRESTMethodListEventDeadLetters        = "/listeventdeadletters"
+	peerRESTMethodRedriveEventDeadLetters     = "/redriveeventdeadletters"
+	peerRESTMethodPurgeEventDeadLetters       = "/purgeeventdeadletters"
+	peerRESTMethodGetEventTargetStats         = "/geteventtargetstats"
+	peerRESTMethodGetDriveHealth              = "/getdrivehealth"
+	peerRESTMethodGetInflightRequests         = "/getinflightrequests"
+	peerRESTMethodCancelInflightRequest       = "/cancelinflightrequest"
+	peerRESTMethodReloadCerts                 = "/reloadcerts"
+	peerRESTMethodTestEventTargets            = "/testeventtargets"
 )
 
 const (
 	peerRESTBucket         = "bucket"
 	peerRESTBuckets        = "buckets"
 	peerRESTUser           = "user"
+	peerRESTRequestID      = "request-id"
 	peerRESTGroup          = "group"
 	peerRESTUserTemp       = "user-temp"
 	peerRESTPolicy         = "policy"
@@ -96,4 +111,15 @@ const (
 	peerRESTListenPrefix = "prefix"
 	peerRESTListenSuffix = "suffix"
 	peerRESTListenEvents = "events"
+
+	peerRESTReplayARN    = "arn"
+	peerRESTReplayPrefix = "prefix"
+	peerRESTReplayStart  = "start"
+	peerRESTReplayEnd    = "end"
+
+	peerRESTDeadLetterARN        = "arn"
+	peerRESTDeadLetterMaxEntries = "max-entries"
+
+	peerRESTTestEventARN    = "arn"
+	peerRESTTestEventBucket = "bucket"
 )
@@ -566,6 +566,44 @@ func (s *peerRESTServer) ReloadSiteReplicationConfigHandler(w http.ResponseWrite
 	logger.LogIf(r.Context(), globalSiteReplicationSys.Init(ctx, objAPI))
 }
 
+// ReloadSTSRevocationsHandler - reloads the STS revocation list from the disks
+func (s *peerRESTServer) ReloadSTSRevocationsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	if err := globalSTSRevocationSys.Load(r.Context(), objAPI); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// ReloadKMSImportedKeysHandler - reloads the list of imported KMS keys from the disks
+func (s *peerRESTServer) ReloadKMSImportedKeysHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	if err := globalKMSImportedKeysSys.Load(r.Context(), objAPI); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
 // GetBucketStatsHandler - fetches current in-memory bucket stats, currently only
 // returns BucketReplicationStatus
 func (s *peerRESTServer) GetBucketStatsHandler(w http.ResponseWriter, r *http.Request) {
@@ -946,7 +984,7 @@ func (s *peerRESTServer) ListenHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func extractTraceOptsFromPeerRequest(r *http.Request) (opts madmin.ServiceTraceOpts, err error) {
+func extractTraceOptsFromPeerRequest(r *http.Request) (opts traceOpts, err error) {
 	opts.S3 = r.Form.Get(peerRESTTraceS3) == "true"
 	opts.OS = r.Form.Get(peerRESTTraceOS) == "true"
 	opts.Storage = r.Form.Get(peerRESTTraceStorage) == "true"
@@ -960,6 +998,7 @@ func extractTraceOptsFromPeerRequest(r *http.Request) (opts madmin.ServiceTraceO
 		}
 		opts.Threshold = d
 	}
+	err = opts.parseTraceFilters(r.Form)
 	return
 }
 
@@ -1123,6 +1162,202 @@ func (s *peerRESTServer) GetBandwidth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetTierVerifyStatusHandler - returns the tier verification status of this node.
+func (s *peerRESTServer) GetTierVerifyStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalTierVerifier.Status()))
+}
+
+// GetTierUsageHandler - returns the remote tier usage recorded by this node.
+func (s *peerRESTServer) GetTierUsageHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalTierUsage.Usage()))
+}
+
+// ReplayEventsHandler - replays the events journaled by this node.
+func (s *peerRESTServer) ReplayEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	arn, filter, err := eventReplayFromForm(r.Form.Get(peerRESTReplayARN), r.Form.Get(peerRESTBucket),
+		r.Form.Get(peerRESTReplayPrefix), r.Form.Get(peerRESTReplayStart), r.Form.Get(peerRESTReplayEnd))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	n, err := replayJournaledEvents(r.Context(), arn, filter)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(n))
+}
+
+// GetEventTargetStatsHandler - returns the delivery statistics of the
+// notification targets of this node.
+func (s *peerRESTServer) GetEventTargetStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalNotificationSys.targetList.Stats(globalSite.Region)))
+}
+
+// TestEventTargetsHandler - delivers a test event through the
+// notification targets of this node.
+func (s *peerRESTServer) TestEventTargetsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	targetID, err := eventDeadLetterTargetFromForm(r.Form.Get(peerRESTTestEventARN))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	results, err := globalNotificationSys.testEventTargets(targetID, r.Form.Get(peerRESTTestEventBucket))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(results))
+}
+
+// GetDriveHealthHandler - returns the drive health of this node.
+func (s *peerRESTServer) GetDriveHealthHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalDriveHealth.Status()))
+}
+
+// GetInflightRequestsHandler - returns the API requests executing on this node.
+func (s *peerRESTServer) GetInflightRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalInflightRequests.Status()))
+}
+
+// CancelInflightRequestHandler - cancels an API request executing on this node.
+func (s *peerRESTServer) CancelInflightRequestHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	id := mux.Vars(r)[peerRESTRequestID]
+	if id == "" {
+		s.writeErrorResponse(w, errors.New("request id is missing"))
+		return
+	}
+
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(globalInflightRequests.cancel(id)))
+}
+
+// ReloadCertsHandler - reloads the TLS certificates of this node.
+func (s *peerRESTServer) ReloadCertsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	if globalCertsReloader == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	status := globalCertsReloader.Status(globalCertsReloader.Reload())
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(status))
+}
+
+// ListEventDeadLettersHandler - lists the events this node could not deliver.
+func (s *peerRESTServer) ListEventDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	targetID, err := eventDeadLetterTargetFromForm(r.Form.Get(peerRESTDeadLetterARN))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	maxEntries, err := strconv.Atoi(r.Form.Get(peerRESTDeadLetterMaxEntries))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	dls, err := globalEventDeadLetters.List(targetID, maxEntries)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(dls))
+}
+
+// RedriveEventDeadLettersHandler - sends the events this node could not
+// deliver to their targets again.
+func (s *peerRESTServer) RedriveEventDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	targetID, err := eventDeadLetterTargetFromForm(r.Form.Get(peerRESTDeadLetterARN))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	n, err := globalEventDeadLetters.Redrive(r.Context(), targetID)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(n))
+}
+
+// PurgeEventDeadLettersHandler - removes the events this node could not deliver.
+func (s *peerRESTServer) PurgeEventDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	targetID, err := eventDeadLetterTargetFromForm(r.Form.Get(peerRESTDeadLetterARN))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	n, err := globalEventDeadLetters.Purge(targetID)
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	logger.LogIf(r.Context(), gob.NewEncoder(w).Encode(n))
+}
+
 // GetPeerMetrics gets the metrics to be federated across peers.
 func (s *peerRESTServer) GetPeerMetrics(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -1375,4 +1610,18 @@ func registerPeerRESTHandlers(router *mux.Router) {
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodSpeedtest).HandlerFunc(httpTraceHdrs(server.SpeedtestHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadSiteReplicationConfig).HandlerFunc(httpTraceHdrs(server.ReloadSiteReplicationConfigHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadPoolMeta).HandlerFunc(httpTraceHdrs(server.ReloadPoolMetaHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadSTSRevocations).HandlerFunc(httpTraceHdrs(server.ReloadSTSRevocationsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadKMSImportedKeys).HandlerFunc(httpTraceHdrs(server.ReloadKMSImportedKeysHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetTierVerifyStatus).HandlerFunc(httpTraceHdrs(server.GetTierVerifyStatusHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetTierUsage).HandlerFunc(httpTraceHdrs(server.GetTierUsageHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReplayEvents).HandlerFunc(httpTraceHdrs(server.ReplayEventsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodListEventDeadLetters).HandlerFunc(httpTraceHdrs(server.ListEventDeadLettersHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodRedriveEventDeadLetters).HandlerFunc(httpTraceHdrs(server.RedriveEventDeadLettersHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodPurgeEventDeadLetters).HandlerFunc(httpTraceHdrs(server.PurgeEventDeadLettersHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetEventTargetStats).HandlerFunc(httpTraceHdrs(server.GetEventTargetStatsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetDriveHealth).HandlerFunc(httpTraceHdrs(server.GetDriveHealthHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetInflightRequests).HandlerFunc(httpTraceHdrs(server.GetInflightRequestsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodCancelInflightRequest).HandlerFunc(httpTraceHdrs(server.CancelInflightRequestHandler)).Queries(restQueries(peerRESTRequestID)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadCerts).HandlerFunc(httpTraceHdrs(server.ReloadCertsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodTestEventTargets).HandlerFunc(httpTraceHdrs(server.TestEventTargetsHandler))
 }
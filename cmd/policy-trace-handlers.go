@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// error returned when the traced action is not a valid action
+var errPolicyTraceInvalidAction = AdminError{
+	Code:       "XMinioAdminInvalidPolicyTraceAction",
+	Message:    "Action to trace is not a valid S3 or admin action",
+	StatusCode: http.StatusBadRequest,
+}
+
+// error returned when policy decisions are delegated to OPA
+var errPolicyTraceOPA = AdminError{
+	Code:       "XMinioAdminPolicyTraceNotSupported",
+	Message:    "Policy decisions are taken by the configured OPA endpoint and cannot be traced",
+	StatusCode: http.StatusNotImplemented,
+}
+
+// PolicyTraceHandler - POST /minio/admin/v3/policy-trace
+//
+// Returns the evaluation trace of the policies applying to the request
+// described by the JSON encoded body: the policies considered, the
+// statements matching the request and the failing conditions.
+func (a adminAPIHandlers) PolicyTraceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PolicyTrace")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetPolicyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	var req policyTraceReq
+	if err = json.Unmarshal(data, &req); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	action := iampolicy.Action(req.Action)
+	if !action.IsValid() && !iampolicy.AdminAction(req.Action).IsValid() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errPolicyTraceInvalidAction), r.URL)
+		return
+	}
+
+	trace, err := tracePolicyDecision(req)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err = json.Marshal(trace)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Sources of the policies considered by a policy decision trace.
+const (
+	policyTraceSourceIdentity = "identity"
+	policyTraceSourceSession  = "session"
+	policyTraceSourceBucket   = "bucket"
+)
+
+// policyTraceReq describes the request a policy decision trace is
+// computed for. An empty access key traces an anonymous request.
+type policyTraceReq struct {
+	AccessKey       string              `json:"accessKey"`
+	Action          string              `json:"action"`
+	Bucket          string              `json:"bucket,omitempty"`
+	Object          string              `json:"object,omitempty"`
+	ConditionValues map[string][]string `json:"conditionValues,omitempty"`
+}
+
+// policyTraceStatement is the evaluation of a single policy statement.
+type policyTraceStatement struct {
+	Index            int      `json:"index"`
+	SID              string   `json:"sid,omitempty"`
+	Effect           string   `json:"effect"`
+	PrincipalMatched *bool    `json:"principalMatched,omitempty"`
+	ActionMatched    bool     `json:"actionMatched"`
+	ResourceMatched  bool     `json:"resourceMatched"`
+	ConditionMatched bool     `json:"conditionMatched"`
+	FailedConditions []string `json:"failedConditions,omitempty"`
+	// Matched is set if the statement applies to the request.
+	Matched bool `json:"matched"`
+}
+
+// policyTracePolicy is the evaluation of a policy considered for a
+// request.
+type policyTracePolicy struct {
+	Name       string                 `json:"name"`
+	Source     string                 `json:"source"`
+	Missing    bool                   `json:"missing,omitempty"`
+	Allowed    bool                   `json:"allowed"`
+	Statements []policyTraceStatement `json:"statements,omitempty"`
+}
+
+// policyTrace is the full evaluation trace of a policy decision.
+type policyTrace struct {
+	AccessKey  string              `json:"accessKey,omitempty"`
+	ParentUser string              `json:"parentUser,omitempty"`
+	Groups     []string            `json:"groups,omitempty"`
+	Action     string              `json:"action"`
+	Resource   string              `json:"resource"`
+	Policies   []policyTracePolicy `json:"policies,omitempty"`
+	Allowed    bool                `json:"allowed"`
+	Reason     string              `json:"reason"`
+}
+
+// failedConditions returns the condition functions in functions that
+// are not satisfied by values.
+func failedConditions(functions condition.Functions, values map[string][]string) []string {
+	var failed []string
+	for _, f := range functions {
+		if !condition.NewFunctions(f).Evaluate(values) {
+			failed = append(failed, f.String())
+		}
+	}
+	return failed
+}
+
+// traceIAMPolicy evaluates all statements of the IAM policy p for
+// args, following the evaluation order of iampolicy.Policy.IsAllowed.
+func traceIAMPolicy(name, source string, p iampolicy.Policy, args iampolicy.Args) policyTracePolicy {
+	resource := args.BucketName
+	if args.ObjectName != "" {
+		if !strings.HasPrefix(args.ObjectName, "/") {
+			resource += "/"
+		}
+		resource += args.ObjectName
+	} else {
+		resource += "/"
+	}
+
+	tp := policyTracePolicy{Name: name, Source: source}
+	for i, statement := range p.Statements {
+		ts := policyTraceStatement{
+			Index:         i,
+			SID:           string(statement.SID),
+			Effect:        string(statement.Effect),
+			ActionMatched: statement.Actions.Match(args.Action),
+		}
+		// Resources of admin statements are not matched for the
+		// decision, instead they scope the admin action.
+		ts.ResourceMatched = statement.Resources.Match(resource, args.ConditionValues) || isAdminStatement(statement)
+		ts.FailedConditions = failedConditions(statement.Conditions, args.ConditionValues)
+		ts.ConditionMatched = len(ts.FailedConditions) == 0
+		ts.Matched = ts.ActionMatched && ts.ResourceMatched && ts.ConditionMatched
+		tp.Statements = append(tp.Statements, ts)
+	}
+	tp.Allowed = p.IsAllowed(args)
+	return tp
+}
+
+func isAdminStatement(statement iampolicy.Statement) bool {
+	for action := range statement.Actions {
+		if iampolicy.AdminAction(action).IsValid() {
+			return true
+		}
+	}
+	return false
+}
+
+// traceBucketPolicy evaluates all statements of the bucket policy p
+// for args, following the evaluation order of policy.Policy.IsAllowed.
+func traceBucketPolicy(p policy.Policy, args policy.Args) policyTracePolicy {
+	resource := args.BucketName
+	if args.ObjectName != "" {
+		if !strings.HasPrefix(args.ObjectName, "/") {
+			resource += "/"
+		}
+		resource += args.ObjectName
+	}
+
+	tp := policyTracePolicy{Name: args.BucketName, Source: policyTraceSourceBucket}
+	for i, statement := range p.Statements {
+		principalMatched := statement.Principal.Match(args.AccountName)
+		ts := policyTraceStatement{
+			Index:            i,
+			SID:              string(statement.SID),
+			Effect:           string(statement.Effect),
+			PrincipalMatched: &principalMatched,
+			ActionMatched:    statement.Actions.Contains(args.Action),
+			ResourceMatched:  statement.Resources.Match(resource, args.ConditionValues),
+		}
+		ts.FailedConditions = failedConditions(statement.Conditions, args.ConditionValues)
+		ts.ConditionMatched = len(ts.FailedConditions) == 0
+		ts.Matched = principalMatched && ts.ActionMatched && ts.ResourceMatched && ts.ConditionMatched
+		tp.Statements = append(tp.Statements, ts)
+	}
+	tp.Allowed = p.IsAllowed(args)
+	return tp
+}
+
+// reason summarizes why the request was allowed or denied.
+func (t policyTrace) reason() string {
+	for _, p := range t.Policies {
+		for _, s := range p.Statements {
+			if s.Matched && s.Effect == string(policy.Deny) {
+				return fmt.Sprintf("explicitly denied by statement %d of %s policy %s", s.Index, p.Source, p.Name)
+			}
+		}
+	}
+	if t.Allowed {
+		return "allowed"
+	}
+	for _, p := range t.Policies {
+		if p.Missing {
+			return fmt.Sprintf("%s policy %s does not exist", p.Source, p.Name)
+		}
+	}
+	for _, p := range t.Policies {
+		if p.Source == policyTraceSourceSession && !p.Allowed {
+			return "not allowed by the session policy"
+		}
+	}
+	if len(t.Policies) == 0 {
+		return "no policy applies to the account"
+	}
+	return "no statement allows the request"
+}
+
+// tracePolicyDecision - returns the evaluation trace of the policies
+// applying to the request described by req. The decision itself is
+// always taken by the regular authorization code.
+func tracePolicyDecision(req policyTraceReq) (policyTrace, error) {
+	if req.ConditionValues == nil {
+		req.ConditionValues = make(map[string][]string)
+	}
+
+	trace := policyTrace{
+		AccessKey: req.AccessKey,
+		Action:    req.Action,
+		Resource:  policy.ResourceARNPrefix + strings.TrimSuffix(req.Bucket+"/"+req.Object, "/"),
+	}
+
+	if req.AccessKey == "" {
+		args := policy.Args{
+			Action:          policy.Action(req.Action),
+			BucketName:      req.Bucket,
+			ObjectName:      req.Object,
+			ConditionValues: req.ConditionValues,
+		}
+		if p, err := globalPolicySys.Get(req.Bucket); err == nil {
+			trace.Policies = append(trace.Policies, traceBucketPolicy(*p, args))
+		}
+		trace.Allowed = globalPolicySys.IsAllowed(args)
+		trace.Reason = trace.reason()
+		if !trace.Allowed && getPublicAccessBlock(req.Bucket).RestrictPublicBuckets {
+			trace.Reason = "public access to the bucket is restricted"
+		}
+		return trace, nil
+	}
+
+	if req.AccessKey == globalActiveCred.AccessKey {
+		trace.Allowed = true
+		trace.Reason = "policies do not apply to the owner"
+		return trace, nil
+	}
+
+	if globalPolicyOPA != nil {
+		return trace, errPolicyTraceOPA
+	}
+
+	cred, ok := globalIAMSys.GetUser(GlobalContext, req.AccessKey)
+	if !ok {
+		return trace, errNoSuchUser
+	}
+	claims, err := getClaimsFromToken(cred.SessionToken)
+	if err != nil {
+		return trace, err
+	}
+
+	trace.ParentUser = cred.ParentUser
+	trace.Groups = cred.Groups
+	args := iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Groups:          cred.Groups,
+		Action:          iampolicy.Action(req.Action),
+		BucketName:      req.Bucket,
+		ObjectName:      req.Object,
+		ConditionValues: req.ConditionValues,
+		Claims:          claims,
+	}
+
+	names, err := globalIAMSys.adminScopePolicies(args)
+	if err != nil {
+		return trace, err
+	}
+
+	// Identity policies are evaluated on behalf of the parent user.
+	// The condition values are copied, args are still evaluated as
+	// the requested credentials below.
+	parentArgs := args
+	if cred.ParentUser != "" {
+		parentArgs.AccountName = cred.ParentUser
+		parentArgs.ConditionValues = make(map[string][]string, len(args.ConditionValues)+2)
+		for k, v := range args.ConditionValues {
+			parentArgs.ConditionValues[k] = v
+		}
+		parentArgs.ConditionValues["username"] = []string{cred.ParentUser}
+		parentArgs.ConditionValues["userid"] = []string{cred.ParentUser}
+	}
+	for _, name := range names {
+		p, err := globalIAMSys.store.GetPolicy(name)
+		if err != nil {
+			trace.Policies = append(trace.Policies, policyTracePolicy{
+				Name:    name,
+				Source:  policyTraceSourceIdentity,
+				Missing: true,
+			})
+			continue
+		}
+		trace.Policies = append(trace.Policies, traceIAMPolicy(name, policyTraceSourceIdentity, p, parentArgs))
+	}
+
+	if sp, ok := claims[iampolicy.SessionPolicyName].(string); ok {
+		if p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(sp))); err == nil && p.Version != "" {
+			trace.Policies = append(trace.Policies, traceIAMPolicy(iampolicy.SessionPolicyName, policyTraceSourceSession, *p, parentArgs))
+		}
+	}
+
+	trace.Allowed = globalIAMSys.IsAllowed(args)
+	trace.Reason = trace.reason()
+	return trace, nil
+}
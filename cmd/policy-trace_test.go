@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+func TestTraceIAMPolicy(t *testing.T) {
+	policyJSON := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "ReadPhotos",
+      "Effect": "Allow",
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::photos/*"],
+      "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}
+    },
+    {
+      "Effect": "Deny",
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::photos/private/*"]
+    }
+  ]
+}`
+	p, err := iampolicy.ParseConfig(bytes.NewReader([]byte(policyJSON)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object   string
+		sourceIP string
+		allowed  bool
+		matched  []bool
+		failed   int
+		reason   string
+	}{
+		{"2021/cat.jpg", "10.1.2.3", true, []bool{true, false}, 0, "allowed"},
+		{"2021/cat.jpg", "192.168.1.1", false, []bool{false, false}, 1, "no statement allows the request"},
+		{"private/cat.jpg", "10.1.2.3", false, []bool{true, true}, 0, "explicitly denied by statement 1 of identity policy readphotos"},
+	}
+
+	for i, testCase := range testCases {
+		tp := traceIAMPolicy("readphotos", policyTraceSourceIdentity, *p, iampolicy.Args{
+			Action:          iampolicy.GetObjectAction,
+			BucketName:      "photos",
+			ObjectName:      testCase.object,
+			ConditionValues: map[string][]string{"SourceIp": {testCase.sourceIP}},
+		})
+		if tp.Allowed != testCase.allowed {
+			t.Errorf("Test %d: expected allowed %v, got %v", i+1, testCase.allowed, tp.Allowed)
+		}
+		for j, matched := range testCase.matched {
+			if tp.Statements[j].Matched != matched {
+				t.Errorf("Test %d: expected statement %d matched %v, got %v", i+1, j, matched, tp.Statements[j].Matched)
+			}
+		}
+		if len(tp.Statements[0].FailedConditions) != testCase.failed {
+			t.Errorf("Test %d: expected %d failed conditions, got %v", i+1, testCase.failed, tp.Statements[0].FailedConditions)
+		}
+		trace := policyTrace{Policies: []policyTracePolicy{tp}, Allowed: tp.Allowed}
+		if reason := trace.reason(); reason != testCase.reason {
+			t.Errorf("Test %d: expected reason %q, got %q", i+1, testCase.reason, reason)
+		}
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/fips"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 )
@@ -123,9 +124,9 @@ func isServerResolvable(endpoint Endpoint, timeout time.Duration) error {
 
 	var tlsConfig *tls.Config
 	if globalIsTLS {
-		tlsConfig = &tls.Config{
+		tlsConfig = fips.RestrictTLS(&tls.Config{
 			RootCAs: globalRootCAs,
-		}
+		})
 	}
 
 	httpClient := &http.Client{
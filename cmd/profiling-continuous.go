@@ -0,0 +1,240 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/config/profiling"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	// profilingCheckInterval is the interval the thresholds are checked at.
+	profilingCheckInterval = 10 * time.Second
+	// profilingThresholdCooldown is the minimum time between two
+	// captures triggered by thresholds.
+	profilingThresholdCooldown = 5 * time.Minute
+
+	profilingTimeFormat = "20060102T150405Z"
+)
+
+// profilingTrigger is the reason profiles were captured, it is part
+// of the name of the stored profiles.
+type profilingTrigger string
+
+const (
+	profilingScheduled          profilingTrigger = "scheduled"
+	profilingCPUThreshold       profilingTrigger = "cpu-threshold"
+	profilingHeapThreshold      profilingTrigger = "heap-threshold"
+	profilingGoroutineThreshold profilingTrigger = "goroutine-threshold"
+)
+
+// continuousProfiler captures profiles of the node on a schedule and
+// when thresholds are exceeded and stores them in the configured bucket
+// as `<node>/<time>-<trigger>/<type>.pprof`.
+type continuousProfiler struct {
+	mu  sync.Mutex
+	cfg profiling.Config
+
+	lastScheduled time.Time
+	lastThreshold time.Time
+}
+
+var globalContinuousProfiler = &continuousProfiler{}
+
+func initContinuousProfiling(ctx context.Context, objAPI ObjectLayer) {
+	go globalContinuousProfiler.run(ctx, objAPI)
+}
+
+// Update applies a new configuration, the next scheduled capture
+// happens one interval after the previous one.
+func (p *continuousProfiler) Update(cfg profiling.Config) {
+	p.mu.Lock()
+	p.cfg = cfg
+	p.mu.Unlock()
+}
+
+func (p *continuousProfiler) config() profiling.Config {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cfg
+}
+
+func (p *continuousProfiler) run(ctx context.Context, objAPI ObjectLayer) {
+	proc, err := process.NewProcessWithContext(ctx, int32(os.Getpid()))
+	logger.LogIf(ctx, err)
+
+	ticker := time.NewTicker(profilingCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cfg := p.config()
+		var cpuPercent float64
+		if proc != nil {
+			// Usage since the previous check, of all CPUs.
+			if percent, err := proc.PercentWithContext(ctx, 0); err == nil {
+				cpuPercent = percent / float64(runtime.NumCPU())
+			}
+		}
+		if !cfg.Enabled() {
+			continue
+		}
+
+		now := UTCNow()
+		trigger, ok := profilingScheduled, now.Sub(p.lastScheduled) >= cfg.Interval
+		if !ok && now.Sub(p.lastThreshold) >= profilingThresholdCooldown {
+			trigger, ok = checkProfilingThresholds(cfg, cpuPercent)
+			if ok {
+				p.lastThreshold = now
+			}
+		}
+		if !ok {
+			continue
+		}
+		if trigger == profilingScheduled {
+			p.lastScheduled = now
+		}
+
+		if err := captureProfiles(ctx, objAPI, cfg, trigger, now); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to store %s profiles in bucket %s: %w", trigger, cfg.Bucket, err))
+		}
+		if trigger == profilingScheduled {
+			logger.LogIf(ctx, expireProfiles(ctx, objAPI, cfg, now))
+		}
+	}
+}
+
+// checkProfilingThresholds returns the first threshold of cfg
+// exceeded by the node, if any.
+func checkProfilingThresholds(cfg profiling.Config, cpuPercent float64) (profilingTrigger, bool) {
+	if cfg.CPUThreshold > 0 && cpuPercent > cfg.CPUThreshold {
+		return profilingCPUThreshold, true
+	}
+	if cfg.GoroutineThreshold > 0 && runtime.NumGoroutine() > cfg.GoroutineThreshold {
+		return profilingGoroutineThreshold, true
+	}
+	if cfg.HeapThreshold > 0 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		if stats.HeapAlloc > cfg.HeapThreshold {
+			return profilingHeapThreshold, true
+		}
+	}
+	return "", false
+}
+
+// captureProfile records a profile of the given type in pprof format.
+func captureProfile(ctx context.Context, profileType string, cpuDuration time.Duration) ([]byte, error) {
+	var buf bytes.Buffer
+	switch profileType {
+	case profiling.TypeCPU:
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(cpuDuration):
+		}
+		pprof.StopCPUProfile()
+	case profiling.TypeHeap:
+		if err := pprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	case profiling.TypeGoroutines:
+		if err := pprof.Lookup("goroutine").WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown profile type %s", profileType)
+	}
+	return buf.Bytes(), nil
+}
+
+// profilesPrefix returns the prefix of the profiles of this node.
+func profilesPrefix() string {
+	return globalLocalNodeName + SlashSeparator
+}
+
+// captureProfiles captures all profiles of cfg and stores them.
+// A profile failing to capture does not prevent the others from
+// being stored.
+func captureProfiles(ctx context.Context, objAPI ObjectLayer, cfg profiling.Config, trigger profilingTrigger, now time.Time) error {
+	prefix := path.Join(profilesPrefix(), now.Format(profilingTimeFormat)+"-"+string(trigger))
+	var firstErr error
+	for _, profileType := range cfg.Types {
+		data, err := captureProfile(ctx, profileType, cfg.CPUDuration)
+		if err == nil {
+			err = putProfile(ctx, objAPI, cfg.Bucket, path.Join(prefix, profileType+".pprof"), data)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s profile: %w", profileType, err)
+		}
+	}
+	return firstErr
+}
+
+func putProfile(ctx context.Context, objAPI ObjectLayer, bucket, object string, data []byte) error {
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	_, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hashReader), ObjectOptions{
+		UserDefined: map[string]string{"content-type": "application/octet-stream"},
+	})
+	return err
+}
+
+// expireProfiles removes the profiles of this node older than the
+// retention of cfg.
+func expireProfiles(ctx context.Context, objAPI ObjectLayer, cfg profiling.Config, now time.Time) error {
+	var marker string
+	for {
+		result, err := objAPI.ListObjects(ctx, cfg.Bucket, profilesPrefix(), marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, obj := range result.Objects {
+			if now.Sub(obj.ModTime) <= cfg.Retention {
+				continue
+			}
+			if _, err = objAPI.DeleteObject(ctx, cfg.Bucket, obj.Name, ObjectOptions{}); err != nil && !isErrObjectNotFound(err) {
+				return err
+			}
+		}
+		if !result.IsTruncated {
+			return nil
+		}
+		marker = result.NextMarker
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config/profiling"
+)
+
+func TestContinuousProfiling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objAPI, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	newAllSubsystems()
+	setObjectLayer(objAPI)
+	defer setObjectLayer(nil)
+
+	defer func(name string) { globalLocalNodeName = name }(globalLocalNodeName)
+	globalLocalNodeName = "node1:9000"
+
+	if err = objAPI.MakeBucketWithLocation(ctx, "profiles", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := profiling.Config{
+		Bucket:      "profiles",
+		Types:       []string{profiling.TypeCPU, profiling.TypeHeap, profiling.TypeGoroutines},
+		CPUDuration: 50 * time.Millisecond,
+		Retention:   time.Hour,
+	}
+	now := UTCNow()
+	if err = captureProfiles(ctx, objAPI, cfg, profilingScheduled, now); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := objAPI.ListObjects(ctx, "profiles", profilesPrefix(), "", "", maxObjectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != len(cfg.Types) {
+		t.Fatalf("expected %d profiles, got %d", len(cfg.Types), len(result.Objects))
+	}
+	prefix := profilesPrefix() + now.Format(profilingTimeFormat) + "-scheduled/"
+	for _, obj := range result.Objects {
+		if !strings.HasPrefix(obj.Name, prefix) || !strings.HasSuffix(obj.Name, ".pprof") {
+			t.Errorf("unexpected profile name %s", obj.Name)
+		}
+		if obj.Size == 0 {
+			t.Errorf("profile %s is empty", obj.Name)
+		}
+	}
+
+	// Profiles within the retention are kept.
+	if err = expireProfiles(ctx, objAPI, cfg, now.Add(30*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ = objAPI.ListObjects(ctx, "profiles", profilesPrefix(), "", "", maxObjectList); len(result.Objects) != len(cfg.Types) {
+		t.Fatalf("expected the profiles to be kept, got %d", len(result.Objects))
+	}
+	if err = expireProfiles(ctx, objAPI, cfg, now.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ = objAPI.ListObjects(ctx, "profiles", profilesPrefix(), "", "", maxObjectList); len(result.Objects) != 0 {
+		t.Fatalf("expected the profiles to expire, got %d", len(result.Objects))
+	}
+}
+
+func TestCheckProfilingThresholds(t *testing.T) {
+	testCases := []struct {
+		cfg        profiling.Config
+		cpuPercent float64
+		trigger    profilingTrigger
+		ok         bool
+	}{
+		{profiling.Config{}, 100, "", false},
+		{profiling.Config{CPUThreshold: 80}, 50, "", false},
+		{profiling.Config{CPUThreshold: 80}, 90, profilingCPUThreshold, true},
+		{profiling.Config{GoroutineThreshold: 1}, 0, profilingGoroutineThreshold, true},
+		{profiling.Config{GoroutineThreshold: 1 << 30}, 0, "", false},
+		{profiling.Config{HeapThreshold: 1}, 0, profilingHeapThreshold, true},
+	}
+	for i, testCase := range testCases {
+		trigger, ok := checkProfilingThresholds(testCase.cfg, testCase.cpuPercent)
+		if trigger != testCase.trigger || ok != testCase.ok {
+			t.Errorf("Test %d: expected %q %v, got %q %v", i+1, testCase.trigger, testCase.ok, trigger, ok)
+		}
+	}
+}
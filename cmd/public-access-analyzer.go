@@ -0,0 +1,179 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Actions which make the contents of a bucket readable, listable or
+// writable when granted to anonymous principals.
+var (
+	publicReadActions = []policy.Action{
+		policy.GetObjectAction,
+		policy.GetObjectVersionAction,
+	}
+	publicListActions = []policy.Action{
+		policy.ListBucketAction,
+		policy.ListBucketVersionsAction,
+		policy.ListBucketMultipartUploadsAction,
+	}
+	publicWriteActions = []policy.Action{
+		policy.PutObjectAction,
+		policy.DeleteObjectAction,
+		policy.DeleteObjectVersionAction,
+		policy.PutBucketPolicyAction,
+		policy.DeleteBucketPolicyAction,
+	}
+)
+
+// BucketPolicyPublicAccessFinding describes the anonymous access
+// granted by the policy of a single bucket along with the statements
+// granting it.
+type BucketPolicyPublicAccessFinding struct {
+	Bucket   string `json:"bucket"`
+	Readable bool   `json:"readable"`
+	Listable bool   `json:"listable"`
+	Writable bool   `json:"writable"`
+	// Conditional is set when all granting statements carry
+	// conditions, e.g. a source IP restriction, which may limit
+	// the exposure.
+	Conditional bool `json:"conditional"`
+	// Restricted is set when the public access block of the
+	// bucket restricts public buckets, the statements do not
+	// grant any access then.
+	Restricted bool               `json:"restricted"`
+	Statements []policy.Statement `json:"statements"`
+}
+
+// BucketPolicyPublicAccessReport is the result of analyzing all bucket
+// policies in the deployment. Bucket policies are the only source of
+// anonymous access, MinIO honors no ACLs other than private and has
+// no access points.
+type BucketPolicyPublicAccessReport struct {
+	GeneratedAt time.Time                         `json:"generatedAt"`
+	Buckets     int                               `json:"bucketsScanned"`
+	Findings    []BucketPolicyPublicAccessFinding `json:"findings"`
+}
+
+// isPublicStatement returns true if the statement grants access to
+// anonymous principals.
+func isPublicStatement(statement policy.Statement) bool {
+	return statement.Effect == policy.Allow && statement.Principal.AWS.Contains("*")
+}
+
+func matchesAnyAction(actions policy.ActionSet, candidates []policy.Action) bool {
+	for _, action := range candidates {
+		if actions.Contains(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzePublicAccess returns the public access finding for the bucket
+// policy, ok is false when the policy does not grant any anonymous
+// access.
+func analyzePublicAccess(bucket string, p *policy.Policy) (finding BucketPolicyPublicAccessFinding, ok bool) {
+	if p == nil {
+		return finding, false
+	}
+
+	finding.Bucket = bucket
+	finding.Conditional = true
+	for _, statement := range p.Statements {
+		if !isPublicStatement(statement) {
+			continue
+		}
+		readable := matchesAnyAction(statement.Actions, publicReadActions)
+		listable := matchesAnyAction(statement.Actions, publicListActions)
+		writable := matchesAnyAction(statement.Actions, publicWriteActions)
+		if !readable && !listable && !writable {
+			continue
+		}
+		finding.Readable = finding.Readable || readable
+		finding.Listable = finding.Listable || listable
+		finding.Writable = finding.Writable || writable
+		finding.Conditional = finding.Conditional && len(statement.Conditions) > 0
+		finding.Statements = append(finding.Statements, statement)
+	}
+	return finding, len(finding.Statements) > 0
+}
+
+// generateBucketPolicyPublicAccessReport scans the policies of all
+// buckets.
+func generateBucketPolicyPublicAccessReport(ctx context.Context, objAPI ObjectLayer) (BucketPolicyPublicAccessReport, error) {
+	report := BucketPolicyPublicAccessReport{GeneratedAt: UTCNow()}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.Buckets = len(buckets)
+
+	for _, bucket := range buckets {
+		p, err := globalBucketMetadataSys.GetPolicyConfig(bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketPolicyNotFound); ok {
+				continue
+			}
+			return report, err
+		}
+		if finding, ok := analyzePublicAccess(bucket.Name, p); ok {
+			finding.Restricted = getPublicAccessBlock(bucket.Name).RestrictPublicBuckets
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+	return report, nil
+}
+
+// BucketPolicyPublicAccessHandler - GET /minio/admin/v3/bucket-policy-public-access
+//
+// Reports all buckets whose policies grant anonymous read, list or
+// write access, along with the granting statements.
+func (a adminAPIHandlers) BucketPolicyPublicAccessHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BucketPolicyPublicAccess")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	report, err := generateBucketPolicyPublicAccessReport(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/bucket/policy/condition"
+)
+
+func TestAnalyzePublicAccess(t *testing.T) {
+	ipCondition, err := condition.NewIPAddressFunc(condition.AWSSourceIP.ToKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		policy      *policy.Policy
+		found       bool
+		readable    bool
+		listable    bool
+		writable    bool
+		conditional bool
+	}{
+		{nil, false, false, false, false, false},
+		{
+			&policy.Policy{
+				Version: policy.DefaultVersion,
+				Statements: []policy.Statement{
+					policy.NewStatement("", policy.Allow, policy.NewPrincipal("arn:aws:iam::user/alice"),
+						policy.NewActionSet(policy.GetObjectAction),
+						policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+						condition.NewFunctions()),
+				},
+			},
+			false, false, false, false, false,
+		},
+		{
+			&policy.Policy{
+				Version: policy.DefaultVersion,
+				Statements: []policy.Statement{
+					policy.NewStatement("", policy.Allow, policy.NewPrincipal("*"),
+						policy.NewActionSet(policy.GetObjectAction),
+						policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+						condition.NewFunctions()),
+					policy.NewStatement("", policy.Allow, policy.NewPrincipal("*"),
+						policy.NewActionSet(policy.ListBucketAction),
+						policy.NewResourceSet(policy.NewResource("mybucket", "")),
+						condition.NewFunctions()),
+				},
+			},
+			true, true, true, false, false,
+		},
+		{
+			&policy.Policy{
+				Version: policy.DefaultVersion,
+				Statements: []policy.Statement{
+					policy.NewStatement("", policy.Allow, policy.NewPrincipal("*"),
+						policy.NewActionSet(policy.PutObjectAction),
+						policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+						condition.NewFunctions(ipCondition)),
+				},
+			},
+			true, false, false, true, true,
+		},
+		{
+			&policy.Policy{
+				Version: policy.DefaultVersion,
+				Statements: []policy.Statement{
+					policy.NewStatement("", policy.Deny, policy.NewPrincipal("*"),
+						policy.NewActionSet(policy.PutObjectAction),
+						policy.NewResourceSet(policy.NewResource("mybucket", "*")),
+						condition.NewFunctions()),
+				},
+			},
+			false, false, false, false, false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		finding, found := analyzePublicAccess("mybucket", testCase.policy)
+		if found != testCase.found {
+			t.Fatalf("Test %d: expected found %v, got %v", i+1, testCase.found, found)
+		}
+		if !found {
+			continue
+		}
+		if finding.Readable != testCase.readable || finding.Listable != testCase.listable ||
+			finding.Writable != testCase.writable || finding.Conditional != testCase.conditional {
+			t.Errorf("Test %d: unexpected finding %+v", i+1, finding)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/config/limits"
+)
+
+// Resources of a node which are limited by admission control, S3
+// requests are rejected once their deadline passes while background
+// subsystems wait until they are admitted.
+const (
+	resourceMemory   = "memory"
+	resourceRequests = "requests"
+)
+
+var globalResourceLimits = newResourceLimits()
+
+// resourceSemaphore admits holders until the sum of their weights
+// reaches the limit, which can be changed at runtime. A limit of 0
+// admits all holders.
+type resourceSemaphore struct {
+	mu       sync.Mutex
+	limit    uint64
+	inUse    uint64
+	waiting  int
+	admitted uint64
+	rejected uint64
+	// changed is closed and replaced whenever holders may be admitted.
+	changed chan struct{}
+}
+
+func newResourceSemaphore() *resourceSemaphore {
+	return &resourceSemaphore{changed: make(chan struct{})}
+}
+
+func (s *resourceSemaphore) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *resourceSemaphore) setLimit(limit uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit == s.limit {
+		return
+	}
+	s.limit = limit
+	s.notify()
+}
+
+// acquire waits until n can be admitted, the context is canceled or
+// the deadline passes, a nil deadline never passes. A holder heavier
+// than the limit is admitted once there are no other holders.
+func (s *resourceSemaphore) acquire(ctx context.Context, n uint64, deadline <-chan time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		if s.limit == 0 || s.inUse == 0 || s.inUse+n <= s.limit {
+			s.inUse += n
+			s.admitted++
+			return true
+		}
+		changed := s.changed
+		s.waiting++
+		s.mu.Unlock()
+		var ok bool
+		select {
+		case <-changed:
+			ok = true
+		case <-ctx.Done():
+		case <-deadline:
+		}
+		s.mu.Lock()
+		s.waiting--
+		if !ok {
+			s.rejected++
+			return false
+		}
+	}
+}
+
+func (s *resourceSemaphore) release(n uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inUse -= n
+	s.notify()
+}
+
+// resourceLimitStatus is the admission status of a limited resource.
+type resourceLimitStatus struct {
+	Resource string `json:"resource"`
+	Limit    uint64 `json:"limit"`
+	InUse    uint64 `json:"inUse"`
+	Waiting  int    `json:"waiting"`
+	Admitted uint64 `json:"admitted"`
+	Rejected uint64 `json:"rejected"`
+}
+
+func (s *resourceSemaphore) status(resource string) resourceLimitStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return resourceLimitStatus{
+		Resource: resource,
+		Limit:    s.limit,
+		InUse:    s.inUse,
+		Waiting:  s.waiting,
+		Admitted: s.admitted,
+		Rejected: s.rejected,
+	}
+}
+
+// resourceLimits enforces the limits of the limits config subsystem.
+type resourceLimits struct {
+	memory     *resourceSemaphore
+	requests   *resourceSemaphore
+	subsystems map[string]*resourceSemaphore
+}
+
+func newResourceLimits() *resourceLimits {
+	l := &resourceLimits{
+		memory:     newResourceSemaphore(),
+		requests:   newResourceSemaphore(),
+		subsystems: make(map[string]*resourceSemaphore, len(limits.Subsystems)),
+	}
+	for _, subsys := range limits.Subsystems {
+		l.subsystems[subsys] = newResourceSemaphore()
+	}
+	return l
+}
+
+// Update applies new limits, holders admitted under the previous limits
+// are not affected.
+func (l *resourceLimits) Update(cfg limits.Config) {
+	l.memory.setLimit(cfg.MemoryMax)
+	l.requests.setLimit(uint64(cfg.RequestsMax))
+	for subsys, s := range l.subsystems {
+		s.setLimit(uint64(cfg.Goroutines[subsys]))
+	}
+}
+
+// admitRequest admits an S3 request against the request and memory
+// limits, the returned function must be called once it completes.
+func (l *resourceLimits) admitRequest(ctx context.Context, deadline <-chan time.Time) (release func(), ok bool) {
+	if !l.requests.acquire(ctx, 1, deadline) {
+		return nil, false
+	}
+	mem := globalAPIConfig.getRequestMemory()
+	if !l.memory.acquire(ctx, mem, deadline) {
+		l.requests.release(1)
+		return nil, false
+	}
+	return func() {
+		l.memory.release(mem)
+		l.requests.release(1)
+	}, true
+}
+
+// acquire waits until an operation of the subsystem is admitted, false
+// if the context is canceled first.
+func (l *resourceLimits) acquire(ctx context.Context, subsys string) (release func(), ok bool) {
+	s := l.subsystems[subsys]
+	if !s.acquire(ctx, 1, nil) {
+		return nil, false
+	}
+	return func() { s.release(1) }, true
+}
+
+// Status returns the admission status of all limited resources.
+func (l *resourceLimits) Status() []resourceLimitStatus {
+	status := []resourceLimitStatus{
+		l.memory.status(resourceMemory),
+		l.requests.status(resourceRequests),
+	}
+	for _, subsys := range limits.Subsystems {
+		status = append(status, l.subsystems[subsys].status(subsys))
+	}
+	return status
+}
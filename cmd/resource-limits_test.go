@@ -0,0 +1,135 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config/limits"
+)
+
+func TestResourceSemaphore(t *testing.T) {
+	ctx := context.Background()
+	s := newResourceSemaphore()
+
+	// No limit admits everything.
+	for i := 0; i < 10; i++ {
+		if !s.acquire(ctx, 100, nil) {
+			t.Fatal("expected to be admitted without a limit")
+		}
+	}
+	s.release(1000)
+
+	s.setLimit(2)
+	if !s.acquire(ctx, 1, nil) || !s.acquire(ctx, 1, nil) {
+		t.Fatal("expected to be admitted within the limit")
+	}
+	deadline := time.NewTimer(10 * time.Millisecond)
+	if s.acquire(ctx, 1, deadline.C) {
+		t.Fatal("expected to be rejected above the limit")
+	}
+
+	// A waiting holder is admitted once another releases.
+	admitted := make(chan bool)
+	go func() { admitted <- s.acquire(ctx, 1, nil) }()
+	time.Sleep(10 * time.Millisecond)
+	s.release(1)
+	if !<-admitted {
+		t.Fatal("expected to be admitted after release")
+	}
+
+	// Raising the limit admits waiting holders.
+	go func() { admitted <- s.acquire(ctx, 1, nil) }()
+	time.Sleep(10 * time.Millisecond)
+	s.setLimit(3)
+	if !<-admitted {
+		t.Fatal("expected to be admitted after raising the limit")
+	}
+
+	// Canceled waiters are rejected.
+	cctx, cancel := context.WithCancel(ctx)
+	go func() { admitted <- s.acquire(cctx, 1, nil) }()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if <-admitted {
+		t.Fatal("expected to be rejected after cancel")
+	}
+
+	status := s.status("test")
+	if status.InUse != 3 || status.Limit != 3 || status.Rejected != 2 || status.Waiting != 0 {
+		t.Errorf("unexpected status %+v", status)
+	}
+	s.release(3)
+
+	// A holder heavier than the limit is admitted while idle.
+	if !s.acquire(ctx, 10, nil) {
+		t.Fatal("expected a heavy holder to be admitted while idle")
+	}
+	s.release(10)
+}
+
+func TestResourceLimitsUpdate(t *testing.T) {
+	l := newResourceLimits()
+	l.Update(limits.Config{
+		MemoryMax:   1 << 30,
+		RequestsMax: 100,
+		Goroutines:  map[string]int{limits.Heal: 4},
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		if _, ok := l.acquire(ctx, limits.Heal); !ok {
+			t.Fatal("expected heal to be admitted")
+		}
+	}
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, ok := l.acquire(cctx, limits.Heal); ok {
+		t.Fatal("expected heal to wait above the limit")
+	}
+	release, ok := l.acquire(ctx, limits.Scanner)
+	if !ok {
+		t.Fatal("expected unlimited scanner to be admitted")
+	}
+	release()
+
+	release, ok = l.admitRequest(ctx, nil)
+	if !ok {
+		t.Fatal("expected request to be admitted")
+	}
+	release()
+
+	for _, status := range l.Status() {
+		switch status.Resource {
+		case resourceMemory:
+			if status.Limit != 1<<30 || status.InUse != 0 {
+				t.Errorf("unexpected memory status %+v", status)
+			}
+		case resourceRequests:
+			if status.Limit != 100 || status.Admitted != 1 || status.InUse != 0 {
+				t.Errorf("unexpected requests status %+v", status)
+			}
+		case limits.Heal:
+			if status.Limit != 4 || status.InUse != 4 || status.Rejected != 1 {
+				t.Errorf("unexpected heal status %+v", status)
+			}
+		}
+	}
+}
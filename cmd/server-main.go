@@ -20,12 +20,14 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"math/rand"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -155,15 +157,18 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	globalPublicCerts, globalTLSCerts, globalIsTLS, err = getTLSConfig()
 	logger.FatalIf(err, "Unable to load the TLS configuration")
 
+	// Internode mTLS requires TLS even without any certificates.
+	internodeCA := env.Get(config.EnvInternodeCA, config.EnableOff) == config.EnableOn
+	if internodeCA {
+		globalIsTLS = true
+	}
+
 	// Check and load Root CAs.
-	globalRootCAs, err = certs.GetRootCAs(globalCertsCADir.Get())
+	// The global public crts are part of the global root CAs, as on
+	// reload.
+	globalRootCAs, err = loadRootCAs(globalPublicCerts)
 	logger.FatalIf(err, "Failed to read root CAs (%v)", err)
 
-	// Add the global public crts as part of global root CAs
-	for _, publicCrt := range globalPublicCerts {
-		globalRootCAs.AddCert(publicCrt)
-	}
-
 	// Register root CAs for remote ENVs
 	env.RegisterGlobalCAs(globalRootCAs)
 
@@ -183,20 +188,21 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 		}
 	}
 
-	// allow transport to be HTTP/1.1 for proxying.
-	globalProxyTransport = newCustomHTTPProxyTransport(&tls.Config{
-		RootCAs:            globalRootCAs,
-		CipherSuites:       fips.CipherSuitesTLS(),
-		CurvePreferences:   fips.EllipticCurvesTLS(),
-		ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
-	}, rest.DefaultTimeout)()
+	// Transports to peers are replaced when the root CAs are reloaded.
+	globalCertsReloader = newCertsReloader(globalTLSCerts, globalPublicCerts, globalRootCAs)
+	proxyTransport := newReloadableTransport(globalRootCAs, func(rootCAs *x509.CertPool) http.RoundTripper {
+		// allow transport to be HTTP/1.1 for proxying.
+		return newCustomHTTPProxyTransport(newInternodeTLSConfig(rootCAs), rest.DefaultTimeout)()
+	})
+	internodeTransport := newReloadableTransport(globalRootCAs, func(rootCAs *x509.CertPool) http.RoundTripper {
+		return newInternodeHTTPTransport(newInternodeTLSConfig(rootCAs), rest.DefaultTimeout)()
+	})
+	globalCertsReloader.addTransport(proxyTransport)
+	globalCertsReloader.addTransport(internodeTransport)
+
+	globalProxyTransport = proxyTransport
 	globalProxyEndpoints = GetProxyEndpoints(globalEndpoints)
-	globalInternodeTransport = newInternodeHTTPTransport(&tls.Config{
-		RootCAs:            globalRootCAs,
-		CipherSuites:       fips.CipherSuitesTLS(),
-		CurvePreferences:   fips.EllipticCurvesTLS(),
-		ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
-	}, rest.DefaultTimeout)()
+	globalInternodeTransport = internodeTransport
 
 	// On macOS, if a process already listens on LOCALIPADDR:PORT, net.Listen() falls back
 	// to IPv6 address ie minio will start listening on IPv6 address whereas another
@@ -211,6 +217,25 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	}
 }
 
+// newInternodeTLSConfig returns the TLS configuration of connections to
+// peers, which are verified with rootCAs.
+func newInternodeTLSConfig(rootCAs *x509.CertPool) *tls.Config {
+	tlsConfig := &tls.Config{
+		RootCAs:            rootCAs,
+		CipherSuites:       fips.CipherSuitesTLS(),
+		CurvePreferences:   fips.EllipticCurvesTLS(),
+		MaxVersion:         fips.MaxVersionTLS(),
+		ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
+	}
+	if env.Get(config.EnvInternodeCA, config.EnableOff) == config.EnableOn {
+		// Peers authenticate with the certificates of the internode
+		// CA, which is initialized once the root credentials are known.
+		tlsConfig.ServerName = internodeServerName
+		tlsConfig.GetClientCertificate = getInternodeClientCertificate
+	}
+	return tlsConfig
+}
+
 func serverHandleEnvVars() {
 	// Handle common environment variables.
 	handleCommonEnvVars()
@@ -274,6 +299,12 @@ func newAllSubsystems() {
 
 	// Create new ILM tier configuration subsystem
 	globalTierConfigMgr = NewTierConfigMgr()
+
+	// Create new STS revocation list subsystem
+	globalSTSRevocationSys = NewSTSRevocationSys()
+
+	// Create new subsystem for imported KMS keys
+	globalKMSImportedKeysSys = NewKMSImportedKeysSys()
 }
 
 func configRetriableErrors(err error) bool {
@@ -479,6 +510,18 @@ func serverMain(ctx *cli.Context) {
 		globalActiveCred = auth.DefaultCredentials
 	}
 
+	if env.Get(config.EnvInternodeCA, config.EnableOff) == config.EnableOn {
+		ca, err := newInternodeCA(globalActiveCred, internodeCAHosts(globalEndpoints))
+		logger.FatalIf(err, "Unable to initialize the internode CA")
+		if globalTLSCerts == nil && globalBrowserEnabled {
+			logger.Fatal(errors.New("the console requires a server certificate, set MINIO_BROWSER=off to run without one"),
+				"Unable to start the server")
+		}
+		globalInternodeCA = ca
+		globalRootCAs.AddCert(ca.cert)
+		initInternodeCA(GlobalContext, ca)
+	}
+
 	// Set system resources to maximum.
 	setMaxResources()
 
@@ -490,7 +533,11 @@ func serverMain(ctx *cli.Context) {
 
 	var getCert certs.GetCertificateFunc
 	if globalTLSCerts != nil {
-		getCert = globalTLSCerts.GetCertificate
+		getCert = globalCertsReloader.GetCertificate
+		go globalCertsReloader.watch(GlobalContext, globalCertsReloadInterval)
+	}
+	if globalInternodeCA != nil {
+		getCert = globalInternodeCA.GetCertificate(getCert)
 	}
 
 	listeners := ctx.Int("listeners")
@@ -580,6 +627,16 @@ func serverMain(ctx *cli.Context) {
 	// Initialize users credentials and policies in background right after config has initialized.
 	go globalIAMSys.Init(GlobalContext, newObject, globalEtcdClient, globalNotificationSys, globalRefreshIAMInterval)
 
+	// Load the list of revoked temporary credentials.
+	if err := globalSTSRevocationSys.Load(GlobalContext, newObject); err != nil {
+		logger.LogIf(GlobalContext, err)
+	}
+
+	// Load the list of imported KMS keys.
+	if err := globalKMSImportedKeysSys.Load(GlobalContext, newObject); err != nil {
+		logger.LogIf(GlobalContext, err)
+	}
+
 	// Initialize transition tier configuration manager
 	if globalIsErasure {
 		if err := globalTierConfigMgr.Init(GlobalContext, newObject); err != nil {
@@ -589,9 +646,20 @@ func serverMain(ctx *cli.Context) {
 
 	initDataScanner(GlobalContext, newObject)
 
+	initContinuousProfiling(GlobalContext, newObject)
+
+	initDriveHealthMonitor(GlobalContext)
+
 	if globalIsErasure { // to be done after config init
 		initBackgroundReplication(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
+		initLastAccessTracker(GlobalContext, newObject)
+		initTierVerifier(GlobalContext)
+		initILMPriorityScanner(GlobalContext, newObject)
+		initTierMigrator(GlobalContext, newObject)
+		initBucketKMSRotation(GlobalContext, newObject)
+		initKMSReencryption(GlobalContext, newObject)
+		initScheduledSpeedtest(GlobalContext, newObject)
 		globalTierJournal, err = initTierDeletionJournal(GlobalContext)
 		if err != nil {
 			logger.FatalIf(err, "Unable to initialize remote tier pending deletes journal")
@@ -34,7 +34,9 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/websocket"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/event"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/pkg/bucket/policy"
 )
@@ -540,6 +542,54 @@ func (s *TestSuiteCommon) TestListenNotificationHandler(c *check) {
 	}
 }
 
+// Test streams bucket events over a websocket.
+func (s *TestSuiteCommon) TestListenNotificationWebsocket(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	req, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the request.
+	response, err := s.client.Do(req)
+	c.Assert(err, nil)
+	// assert the http response status code.
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	req, err = newTestSignedRequest(http.MethodGet,
+		getListenNotificationURL(s.endPoint, bucketName, []string{"images/"}, []string{}, []string{"s3:ObjectCreated:*"}),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	if transport, ok := s.client.Transport.(*http.Transport); ok {
+		dialer.TLSClientConfig = transport.TLSClientConfig
+	}
+	wsURL := "ws" + strings.TrimPrefix(req.URL.String(), "http")
+	conn, response, err := dialer.Dial(wsURL, req.Header)
+	c.Assert(err, nil)
+	defer conn.Close()
+	c.Assert(response.StatusCode, http.StatusSwitchingProtocols)
+
+	// Objects not matching the prefix are filtered on the server.
+	for _, objectName := range []string{"other/object", "images/object"} {
+		req, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(len("hello")), bytes.NewReader([]byte("hello")), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		response, err = s.client.Do(req)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	var msg struct{ Records []event.Event }
+	c.Assert(conn.SetReadDeadline(time.Now().Add(10*time.Second)), nil)
+	c.Assert(conn.ReadJSON(&msg), nil)
+	c.Assert(len(msg.Records), 1)
+	c.Assert(msg.Records[0].EventName, event.ObjectCreatedPut)
+	c.Assert(msg.Records[0].S3.Object.Key, "images%2Fobject")
+}
+
 // Test deletes multiple objects and verifies server response.
 func (s *TestSuiteCommon) TestDeleteMultipleObjects(c *check) {
 	// generate a random bucket name.
@@ -70,6 +70,9 @@ func handleSignals() {
 			logger.LogIf(context.Background(), srv.Shutdown())
 		}
 
+		// Export the spans of the last requests.
+		logger.LogIf(context.Background(), globalTracing.Shutdown(context.Background()))
+
 		return (err == nil && oerr == nil)
 	}
 
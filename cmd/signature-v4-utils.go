@@ -168,6 +168,9 @@ func checkKeyValid(r *http.Request, accessKey string) (auth.Credentials, bool, A
 	if s3Err != ErrNone {
 		return cred, false, s3Err
 	}
+	if globalSTSRevocationSys.IsRevoked(cred, claims) {
+		return cred, false, ErrInvalidToken
+	}
 	cred.Claims = claims
 
 	owner := cred.AccessKey == globalActiveCred.AccessKey
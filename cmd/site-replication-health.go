@@ -0,0 +1,276 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/minio/madmin-go"
+	bktpolicy "github.com/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// SRHealthInfo is the site replication health of all peers compared to
+// the local site.
+type SRHealthInfo struct {
+	Enabled      bool                    `json:"enabled"`
+	Name         string                  `json:"name"`
+	DeploymentID string                  `json:"deploymentID"`
+	Peers        map[string]SRPeerHealth `json:"peers,omitempty"`
+}
+
+// SRPeerHealth is the site replication health of a peer site compared
+// to the local site.
+type SRPeerHealth struct {
+	madmin.PeerInfo
+	Online bool   `json:"online"`
+	Error  string `json:"error,omitempty"`
+	// Last time metadata was replicated successfully to the peer by the
+	// node serving the request.
+	LastMetadataSync time.Time `json:"lastMetadataSync,omitempty"`
+	// Buckets with differing metadata, object counts or sizes.
+	Buckets map[string]SRBucketHealth `json:"buckets,omitempty"`
+	// IAM policies missing on either site or with differing content.
+	Policies map[string]SRPolicyHealth `json:"policies,omitempty"`
+}
+
+// SRBucketHealth describes how a bucket of a peer differs from the
+// same bucket of the local site.
+type SRBucketHealth struct {
+	MissingLocal        bool `json:"missingLocal,omitempty"`
+	MissingPeer         bool `json:"missingPeer,omitempty"`
+	PolicyMismatch      bool `json:"policyMismatch,omitempty"`
+	TagMismatch         bool `json:"tagMismatch,omitempty"`
+	OLockConfigMismatch bool `json:"olockConfigMismatch,omitempty"`
+	SSEConfigMismatch   bool `json:"sseConfigMismatch,omitempty"`
+	// Objects and bytes of the peer minus those of the local site, as
+	// of the last data usage scan of each site.
+	ObjectsDelta int64 `json:"objectsDelta"`
+	BytesDelta   int64 `json:"bytesDelta"`
+	// Last time an object version of the bucket was replicated
+	// successfully to the peer.
+	LastReplication time.Time `json:"lastReplication,omitempty"`
+}
+
+func (h SRBucketHealth) diverged() bool {
+	return h.MissingLocal || h.MissingPeer || h.PolicyMismatch || h.TagMismatch ||
+		h.OLockConfigMismatch || h.SSEConfigMismatch || h.ObjectsDelta != 0 || h.BytesDelta != 0
+}
+
+// SRPolicyHealth describes how an IAM policy of a peer differs from the
+// same policy of the local site.
+type SRPolicyHealth struct {
+	MissingLocal bool `json:"missingLocal,omitempty"`
+	MissingPeer  bool `json:"missingPeer,omitempty"`
+	Mismatch     bool `json:"mismatch,omitempty"`
+}
+
+// markPeerSynced records a successful replication of metadata to the peer.
+func (c *SiteReplicationSys) markPeerSynced(deploymentID string) {
+	c.peerSyncMu.Lock()
+	defer c.peerSyncMu.Unlock()
+	if c.lastPeerSync == nil {
+		c.lastPeerSync = make(map[string]time.Time)
+	}
+	c.lastPeerSync[deploymentID] = UTCNow()
+}
+
+func (c *SiteReplicationSys) peerSyncTime(deploymentID string) time.Time {
+	c.peerSyncMu.Lock()
+	defer c.peerSyncMu.Unlock()
+	return c.lastPeerSync[deploymentID]
+}
+
+// SiteReplicationHealth - compares the metadata and data usage of each
+// peer to the local site. Unreachable peers are reported as offline.
+func (c *SiteReplicationSys) SiteReplicationHealth(ctx context.Context, objAPI ObjectLayer) (info SRHealthInfo, err error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return info, nil
+	}
+
+	local, err := c.SiteReplicationMetaInfo(ctx, objAPI)
+	if err != nil {
+		return info, errSRBackendIssue(err)
+	}
+	localUsage, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		return info, errSRBackendIssue(err)
+	}
+
+	info.Enabled = true
+	info.Name = c.state.Name
+	info.DeploymentID = globalDeploymentID
+	info.Peers = make(map[string]SRPeerHealth, len(c.state.Peers))
+	for d, peer := range c.state.Peers {
+		if d == globalDeploymentID {
+			continue
+		}
+		h := SRPeerHealth{
+			PeerInfo:         peer,
+			LastMetadataSync: c.peerSyncTime(d),
+		}
+		admClient, err := c.getAdminClient(ctx, d)
+		if err != nil {
+			h.Error = err.Error()
+			info.Peers[d] = h
+			continue
+		}
+		sri, err := admClient.SRMetaInfo(ctx)
+		if err != nil {
+			h.Error = err.Error()
+			info.Peers[d] = h
+			continue
+		}
+		peerUsage, err := admClient.DataUsageInfo(ctx)
+		if err != nil {
+			h.Error = err.Error()
+			info.Peers[d] = h
+			continue
+		}
+		h.Online = true
+		h.Buckets = srBucketsHealth(ctx, peer, local, sri, localUsage, peerUsage)
+		h.Policies = srPoliciesHealth(local, sri)
+		info.Peers[d] = h
+	}
+	return info, nil
+}
+
+func srBucketsHealth(ctx context.Context, peer madmin.PeerInfo, local, remote madmin.SRInfo, localUsage DataUsageInfo, peerUsage madmin.DataUsageInfo) map[string]SRBucketHealth {
+	buckets := make(map[string]SRBucketHealth)
+	for b, lb := range local.Buckets {
+		var h SRBucketHealth
+		rb, ok := remote.Buckets[b]
+		if !ok {
+			h.MissingPeer = true
+		} else {
+			h.PolicyMismatch = !srBucketPolicyEqual(b, lb.Policy, rb.Policy)
+			h.TagMismatch = !srStringPtrEqual(lb.Tags, rb.Tags)
+			h.OLockConfigMismatch = !srStringPtrEqual(lb.ObjectLockConfig, rb.ObjectLockConfig)
+			rSSEConfig := rb.SSEConfig
+			if rSSEConfig != nil {
+				sseConfig := localSSEConfigStr(*rSSEConfig)
+				rSSEConfig = &sseConfig
+			}
+			h.SSEConfigMismatch = !srStringPtrEqual(lb.SSEConfig, rSSEConfig)
+		}
+		lu := localUsage.BucketsUsage[b]
+		ru := peerUsage.BucketsUsage[b]
+		h.ObjectsDelta = int64(ru.ObjectsCount) - int64(lu.ObjectsCount)
+		h.BytesDelta = int64(ru.Size) - int64(lu.Size)
+		h.LastReplication = srLastReplication(ctx, peer, b, lu)
+		if h.diverged() {
+			buckets[b] = h
+		}
+	}
+	for b := range remote.Buckets {
+		if _, ok := local.Buckets[b]; !ok {
+			ru := peerUsage.BucketsUsage[b]
+			buckets[b] = SRBucketHealth{
+				MissingLocal: true,
+				ObjectsDelta: int64(ru.ObjectsCount),
+				BytesDelta:   int64(ru.Size),
+			}
+		}
+	}
+	return buckets
+}
+
+// srLastReplication returns the last time an object version of the
+// bucket was replicated to the targets of the bucket on the peer.
+func srLastReplication(ctx context.Context, peer madmin.PeerInfo, bucket string, u BucketUsageInfo) (last time.Time) {
+	ep, err := url.Parse(peer.Endpoint)
+	if err != nil {
+		return last
+	}
+	tgts, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		return last
+	}
+	var stats *BucketReplicationStats
+	for _, t := range tgts.Targets {
+		if t.Endpoint != ep.Host {
+			continue
+		}
+		if stats == nil {
+			s := getLatestReplicationStats(bucket, u)
+			stats = &s
+		}
+		if st, ok := stats.Stats[t.Arn]; ok && st.LastReplicationTime.After(last) {
+			last = st.LastReplicationTime
+		}
+	}
+	return last
+}
+
+func srPoliciesHealth(local, remote madmin.SRInfo) map[string]SRPolicyHealth {
+	policies := make(map[string]SRPolicyHealth)
+	for name, lp := range local.Policies {
+		rp, ok := remote.Policies[name]
+		switch {
+		case !ok:
+			policies[name] = SRPolicyHealth{MissingPeer: true}
+		case !srIAMPolicyEqual(lp, rp):
+			policies[name] = SRPolicyHealth{Mismatch: true}
+		}
+	}
+	for name := range remote.Policies {
+		if _, ok := local.Policies[name]; !ok {
+			policies[name] = SRPolicyHealth{MissingLocal: true}
+		}
+	}
+	return policies
+}
+
+func srStringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func srBucketPolicyEqual(bucket string, a, b []byte) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	pa, err := bktpolicy.ParseConfig(bytes.NewReader(a), bucket)
+	if err != nil {
+		return false
+	}
+	pb, err := bktpolicy.ParseConfig(bytes.NewReader(b), bucket)
+	if err != nil {
+		return false
+	}
+	return pa.Equals(*pb)
+}
+
+func srIAMPolicyEqual(a, b []byte) bool {
+	pa, err := iampolicy.ParseConfig(bytes.NewReader(a))
+	if err != nil {
+		return false
+	}
+	pb, err := iampolicy.ParseConfig(bytes.NewReader(b))
+	if err != nil {
+		return false
+	}
+	return pa.Equals(*pb)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestSRPoliciesHealth(t *testing.T) {
+	readonly := []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::*"]}]}`)
+	readonlySpaced := []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*"]}]}`)
+	writeonly := []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObject"],"Resource":["arn:aws:s3:::*"]}]}`)
+
+	local := madmin.SRInfo{Policies: map[string]json.RawMessage{
+		"same":    readonly,
+		"changed": readonly,
+		"local":   readonly,
+	}}
+	remote := madmin.SRInfo{Policies: map[string]json.RawMessage{
+		"same":    readonlySpaced,
+		"changed": writeonly,
+		"remote":  writeonly,
+	}}
+	expected := map[string]SRPolicyHealth{
+		"changed": {Mismatch: true},
+		"local":   {MissingPeer: true},
+		"remote":  {MissingLocal: true},
+	}
+
+	got := srPoliciesHealth(local, remote)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for name, h := range expected {
+		if got[name] != h {
+			t.Errorf("policy %s: expected %+v, got %+v", name, h, got[name])
+		}
+	}
+}
+
+func TestSRBucketHealthDiverged(t *testing.T) {
+	tags := "<Tagging/>"
+	otherTags := "<Tagging><TagSet/></Tagging>"
+	if !srStringPtrEqual(nil, nil) || srStringPtrEqual(&tags, nil) || srStringPtrEqual(&tags, &otherTags) {
+		t.Fatal("unexpected comparison result")
+	}
+	if (SRBucketHealth{}).diverged() {
+		t.Fatal("an empty bucket health must not be diverged")
+	}
+	if !(SRBucketHealth{ObjectsDelta: -1}).diverged() {
+		t.Fatal("a bucket with fewer objects on the peer must be diverged")
+	}
+}
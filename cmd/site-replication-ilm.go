@@ -0,0 +1,267 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Lifecycle configuration and remote tiers are not part of the
+// bucket metadata known to madmin, so they are sent to peers with
+// custom requests against the site replication peer API.
+const (
+	srBucketMetaTypeLifecycle = "lc-config"
+
+	srPeerBucketMetaPath = "/" + madmin.AdminAPIVersion + "/site-replication/peer/bucket-meta"
+	srPeerTierPath       = "/" + madmin.AdminAPIVersion + "/site-replication/peer/tier"
+)
+
+// srBucketMeta extends madmin.SRBucketMeta with the bucket metadata
+// types replicated only between MinIO peers.
+type srBucketMeta struct {
+	madmin.SRBucketMeta
+
+	// Base64 encoded lifecycle configuration XML, nil on removal.
+	LifecycleConfig *string `json:"lcConfig,omitempty"`
+}
+
+// srPeerPut sends content to relPath on the peer, converting admin
+// API error responses to errors.
+func srPeerPut(ctx context.Context, admClient *madmin.AdminClient, relPath string, content []byte) error {
+	resp, err := admClient.ExecuteMethod(ctx, http.MethodPut, madmin.RequestData{
+		RelPath: relPath,
+		Content: content,
+	})
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		var errResp madmin.ErrorResponse
+		if err = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&errResp); err != nil || errResp.Code == "" {
+			return fmt.Errorf("unexpected response from peer: %s", resp.Status)
+		}
+		return errResp
+	}
+	return nil
+}
+
+// BucketLifecycleHook - called when the bucket lifecycle configuration
+// is set or removed, to replicate the change to peer clusters.
+func (c *SiteReplicationSys) BucketLifecycleHook(ctx context.Context, bucket string, lcConfig []byte) error {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return nil
+	}
+
+	item := srBucketMeta{
+		SRBucketMeta: madmin.SRBucketMeta{
+			Type:   srBucketMetaTypeLifecycle,
+			Bucket: bucket,
+		},
+	}
+	if lcConfig != nil {
+		cfgStr := base64.StdEncoding.EncodeToString(lcConfig)
+		item.LifecycleConfig = &cfgStr
+	}
+	b, err := json.Marshal(item)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+
+	cErr := c.concDo(nil, func(d string, p madmin.PeerInfo) error {
+		admClient, err := c.getAdminClient(ctx, d)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+
+		err = srPeerPut(ctx, admClient, srPeerBucketMetaPath, b)
+		logger.LogIf(ctx, c.annotatePeerErr(p.Name, "SRPeerReplicateBucketMeta", err))
+		return err
+	})
+	return cErr.summaryErr
+}
+
+// PeerBucketLifecycleHandler - copies/deletes the lifecycle
+// configuration to the local cluster. Remote tiers used by transition
+// rules must have been replicated before.
+func (c *SiteReplicationSys) PeerBucketLifecycleHandler(ctx context.Context, bucket string, lcConfig *string) error {
+	if lcConfig == nil {
+		if err := globalBucketMetadataSys.Update(bucket, bucketLifecycleConfig, nil); err != nil {
+			return wrapSRErr(err)
+		}
+		return nil
+	}
+
+	configData, err := base64.StdEncoding.DecodeString(*lcConfig)
+	if err != nil {
+		return wrapSRErr(err)
+	}
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader(configData))
+	if err != nil {
+		return errSRInvalidRequest(err)
+	}
+	if err = validateTransitionTier(lc); err != nil {
+		return errSRBucketMetaError(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketLifecycleConfig, configData); err != nil {
+		return wrapSRErr(err)
+	}
+	return nil
+}
+
+// TierChangeHook - called when a remote tier is added or its
+// credentials are edited, to replicate the tier to peer clusters. The
+// tier configuration includes the credentials of the remote tier, so it
+// is encrypted with the secret key of the site replicator service
+// account.
+func (c *SiteReplicationSys) TierChangeHook(ctx context.Context, tierName string) error {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return nil
+	}
+
+	tier, ok := globalTierConfigMgr.getTier(tierName)
+	if !ok {
+		return errTierNotFound
+	}
+	return c.replicateTiers(ctx, []madmin.TierConfig{tier})
+}
+
+// replicateTiers sends the tiers to all peers. NOTE: ensure to take at
+// least a read lock on SiteReplicationSys before calling this.
+func (c *SiteReplicationSys) replicateTiers(ctx context.Context, tiers []madmin.TierConfig) error {
+	creds, err := c.getPeerCreds()
+	if err != nil {
+		return wrapSRErr(err)
+	}
+
+	for _, tier := range tiers {
+		b, err := json.Marshal(tier)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		encBytes, err := madmin.EncryptData(creds.SecretKey, b)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+
+		cErr := c.concDo(nil, func(d string, p madmin.PeerInfo) error {
+			admClient, err := c.getAdminClient(ctx, d)
+			if err != nil {
+				return wrapSRErr(err)
+			}
+
+			err = srPeerPut(ctx, admClient, srPeerTierPath, encBytes)
+			logger.LogIf(ctx, c.annotatePeerErr(p.Name, "SRPeerReplicateTier", err))
+			return err
+		})
+		if cErr.summaryErr != nil {
+			return cErr.summaryErr
+		}
+	}
+	return nil
+}
+
+// PeerTierChangeHandler - adds or replaces the remote tier on the
+// local cluster.
+func (c *SiteReplicationSys) PeerTierChangeHandler(ctx context.Context, objAPI ObjectLayer, tier madmin.TierConfig) error {
+	// Refresh from the disk in case we had missed notifications about edits from peers.
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		return wrapSRErr(err)
+	}
+	if err := globalTierConfigMgr.Set(ctx, tier); err != nil {
+		return wrapSRErr(err)
+	}
+	if err := globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		return wrapSRErr(err)
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+	return nil
+}
+
+// syncILMToPeers copies all remote tiers and the lifecycle
+// configuration of the buckets to all peers. Tiers are copied first
+// as lifecycle rules may transition to them.
+func (c *SiteReplicationSys) syncILMToPeers(ctx context.Context, buckets []BucketInfo) error {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return nil
+	}
+
+	if err := c.replicateTiers(ctx, globalTierConfigMgr.getTiers()); err != nil {
+		return errSRBackendIssue(err)
+	}
+
+	for _, bucketInfo := range buckets {
+		bucket := bucketInfo.Name
+		lc, err := globalBucketMetadataSys.GetLifecycleConfig(bucket)
+		if _, ok := err.(BucketLifecycleNotFound); ok {
+			continue
+		} else if err != nil {
+			return errSRBackendIssue(err)
+		}
+
+		lcData, err := xml.Marshal(lc)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		lcStr := base64.StdEncoding.EncodeToString(lcData)
+		b, err := json.Marshal(srBucketMeta{
+			SRBucketMeta: madmin.SRBucketMeta{
+				Type:   srBucketMetaTypeLifecycle,
+				Bucket: bucket,
+			},
+			LifecycleConfig: &lcStr,
+		})
+		if err != nil {
+			return wrapSRErr(err)
+		}
+
+		cErr := c.concDo(nil, func(d string, p madmin.PeerInfo) error {
+			admClient, err := c.getAdminClient(ctx, d)
+			if err != nil {
+				return wrapSRErr(err)
+			}
+
+			err = srPeerPut(ctx, admClient, srPeerBucketMetaPath, b)
+			logger.LogIf(ctx, c.annotatePeerErr(p.Name, "SRPeerReplicateBucketMeta", err))
+			return err
+		})
+		if cErr.summaryErr != nil {
+			return errSRBucketMetaError(cErr.summaryErr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestSRBucketMetaEncoding(t *testing.T) {
+	lcStr := "PExpZmVjeWNsZUNvbmZpZ3VyYXRpb24vPg=="
+	b, err := json.Marshal(srBucketMeta{
+		SRBucketMeta: madmin.SRBucketMeta{
+			Type:   srBucketMetaTypeLifecycle,
+			Bucket: "bucket",
+		},
+		LifecycleConfig: &lcStr,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Peers decode the madmin fields as before.
+	var item madmin.SRBucketMeta
+	if err = json.Unmarshal(b, &item); err != nil {
+		t.Fatal(err)
+	}
+	if item.Type != srBucketMetaTypeLifecycle || item.Bucket != "bucket" {
+		t.Fatalf("unexpected bucket meta %#v", item)
+	}
+
+	var got srBucketMeta
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.LifecycleConfig == nil || *got.LifecycleConfig != lcStr {
+		t.Fatalf("lifecycle config not preserved: %s", b)
+	}
+
+	// Removal is encoded by omitting the configuration.
+	b, err = json.Marshal(srBucketMeta{SRBucketMeta: madmin.SRBucketMeta{Type: srBucketMetaTypeLifecycle, Bucket: "bucket"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = srBucketMeta{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.LifecycleConfig != nil {
+		t.Fatalf("expected no lifecycle config: %s", b)
+	}
+}
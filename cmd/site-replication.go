@@ -40,7 +40,9 @@ import (
 	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/auth"
+	sse "github.com/minio/minio/internal/bucket/encryption"
 	sreplication "github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/fips"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
 	"github.com/minio/pkg/bucket/policy"
@@ -160,6 +162,11 @@ type SiteReplicationSys struct {
 
 	// In-memory and persisted multi-site replication state.
 	state srState
+
+	// Time of the last successful replication of metadata from this
+	// node to each peer, keyed by deployment ID.
+	peerSyncMu   sync.Mutex
+	lastPeerSync map[string]time.Time
 }
 
 type srState srStateV1
@@ -782,149 +789,13 @@ func (c *SiteReplicationSys) PeerBucketConfigureReplHandler(ctx context.Context,
 	// The following function, creates a bucket remote and sets up a bucket
 	// replication rule for the given peer.
 	configurePeerFn := func(d string, peer madmin.PeerInfo) error {
-		ep, _ := url.Parse(peer.Endpoint)
-		targets := globalBucketTargetSys.ListTargets(ctx, bucket, string(madmin.ReplicationService))
-		targetARN := ""
-		for _, target := range targets {
-			if target.SourceBucket == bucket &&
-				target.TargetBucket == bucket &&
-				target.Endpoint == ep.Host &&
-				target.Secure == (ep.Scheme == "https") &&
-				target.Type == madmin.ReplicationService {
-				targetARN = target.Arn
-				break
-			}
-		}
-		if targetARN == "" {
-			bucketTarget := madmin.BucketTarget{
-				SourceBucket: bucket,
-				Endpoint:     ep.Host,
-				Credentials: &madmin.Credentials{
-					AccessKey: creds.AccessKey,
-					SecretKey: creds.SecretKey,
-				},
-				TargetBucket:    bucket,
-				Secure:          ep.Scheme == "https",
-				API:             "s3v4",
-				Type:            madmin.ReplicationService,
-				Region:          "",
-				ReplicationSync: false,
-			}
-			bucketTarget.Arn = globalBucketTargetSys.getRemoteARN(bucket, &bucketTarget)
-			err := globalBucketTargetSys.SetTarget(ctx, bucket, &bucketTarget, false)
-			if err != nil {
-				logger.LogIf(ctx, c.annotatePeerErr(peer.Name, "Bucket target creation error", err))
-				return err
-			}
-			targets, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
-			if err != nil {
-				return err
-			}
-			tgtBytes, err := json.Marshal(&targets)
-			if err != nil {
-				return err
-			}
-			if err = globalBucketMetadataSys.Update(bucket, bucketTargetsFile, tgtBytes); err != nil {
-				return err
-			}
-			targetARN = bucketTarget.Arn
-		}
-
-		// Create bucket replication rule to this peer.
-
-		// To add the bucket replication rule, we fetch the current
-		// server configuration, and convert it to minio-go's
-		// replication configuration type (by converting to xml and
-		// parsing it back), use minio-go's add rule function, and
-		// finally convert it back to the server type (again via xml).
-		// This is needed as there is no add-rule function in the server
-		// yet.
-
-		// Though we do not check if the rule already exists, this is
-		// not a problem as we are always using the same replication
-		// rule ID - if the rule already exists, it is just replaced.
-		replicationConfigS, err := globalBucketMetadataSys.GetReplicationConfig(ctx, bucket)
-		if err != nil {
-			_, ok := err.(BucketReplicationConfigNotFound)
-			if !ok {
-				return err
-			}
-		}
-		var replicationConfig replication.Config
-		if replicationConfigS != nil {
-			replCfgSBytes, err := xml.Marshal(replicationConfigS)
-			if err != nil {
-				return err
-			}
-			err = xml.Unmarshal(replCfgSBytes, &replicationConfig)
-			if err != nil {
-				return err
-			}
-		}
-		var (
-			ruleID  = fmt.Sprintf("site-repl-%s", d)
-			hasRule bool
-			opts    = replication.Options{
-				// Set the ID so we can identify the rule as being
-				// created for site-replication and include the
-				// destination cluster's deployment ID.
-				ID: ruleID,
-
-				// Use a helper to generate unique priority numbers.
-				Priority: fmt.Sprintf("%d", getPriorityHelper(replicationConfig)),
-
-				Op:         replication.AddOption,
-				RuleStatus: "enable",
-				DestBucket: targetARN,
-
-				// Replicate everything!
-				ReplicateDeletes:        "enable",
-				ReplicateDeleteMarkers:  "enable",
-				ReplicaSync:             "enable",
-				ExistingObjectReplicate: "enable",
-			}
-		)
-		for _, r := range replicationConfig.Rules {
-			if r.ID == ruleID {
-				hasRule = true
-			}
-		}
-		switch {
-		case hasRule:
-			err = replicationConfig.EditRule(opts)
-		default:
-			err = replicationConfig.AddRule(opts)
-		}
-
-		if err != nil {
-			logger.LogIf(ctx, c.annotatePeerErr(peer.Name, "Error adding bucket replication rule", err))
-			return err
-		}
-		// Now convert the configuration back to server's type so we can
-		// do some validation.
-		newReplCfgBytes, err := xml.Marshal(replicationConfig)
-		if err != nil {
-			return err
-		}
-		newReplicationConfig, err := sreplication.ParseConfig(bytes.NewReader(newReplCfgBytes))
-		if err != nil {
-			return err
-		}
-		sameTarget, apiErr := validateReplicationDestination(ctx, bucket, newReplicationConfig)
-		if apiErr != noError {
-			return fmt.Errorf("bucket replication config validation error: %#v", apiErr)
-		}
-		err = newReplicationConfig.Validate(bucket, sameTarget)
-		if err != nil {
-			return err
-		}
-		// Config looks good, so we save it.
-		replCfgData, err := xml.Marshal(newReplicationConfig)
-		if err != nil {
-			return err
-		}
-		err = globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, replCfgData)
-		logger.LogIf(ctx, c.annotatePeerErr(peer.Name, "Error updating replication configuration", err))
+		err := configureReplicationPeer(ctx, bucket, fmt.Sprintf("site-repl-%s", d), replicationPeer{
+			Name:      peer.Name,
+			Endpoint:  peer.Endpoint,
+			AccessKey: creds.AccessKey,
+			SecretKey: creds.SecretKey,
+		})
+		logger.LogIf(ctx, c.annotatePeerErr(peer.Name, "Error configuring bucket replication", err))
 		return err
 	}
 
@@ -1275,6 +1146,46 @@ func (c *SiteReplicationSys) PeerBucketObjectLockConfigHandler(ctx context.Conte
 	return nil
 }
 
+// localSSEConfig translates the KMS key IDs of a bucket encryption
+// configuration received from a site replication peer into the KMS key
+// IDs of this site, as configured by the site KMS key map.
+func localSSEConfig(configData []byte) ([]byte, error) {
+	sseConfig, err := sse.ParseBucketSSEConfig(bytes.NewReader(configData))
+	if err != nil {
+		return nil, err
+	}
+	translated := false
+	for i, rule := range sseConfig.Rules {
+		keyID := rule.DefaultEncryptionAction.MasterKeyID
+		if keyID == "" {
+			continue
+		}
+		if localKeyID := globalSite.LocalKMSKeyID(keyID); localKeyID != keyID {
+			sseConfig.Rules[i].DefaultEncryptionAction.MasterKeyID = localKeyID
+			translated = true
+		}
+	}
+	if !translated {
+		return configData, nil
+	}
+	return xml.Marshal(sseConfig)
+}
+
+// localSSEConfigStr is like localSSEConfig for base64 encoded bucket
+// encryption configurations of site replication peers, used when
+// comparing them with the configuration of this site. Configurations
+// that cannot be translated are returned as is.
+func localSSEConfigStr(sseConfig string) string {
+	configData, err := base64.StdEncoding.DecodeString(sseConfig)
+	if err != nil {
+		return sseConfig
+	}
+	if configData, err = localSSEConfig(configData); err != nil {
+		return sseConfig
+	}
+	return base64.StdEncoding.EncodeToString(configData)
+}
+
 // PeerBucketSSEConfigHandler - copies/deletes SSE config to local cluster.
 func (c *SiteReplicationSys) PeerBucketSSEConfigHandler(ctx context.Context, bucket string, sseConfig *string) error {
 	if sseConfig != nil {
@@ -1282,6 +1193,11 @@ func (c *SiteReplicationSys) PeerBucketSSEConfigHandler(ctx context.Context, buc
 		if err != nil {
 			return wrapSRErr(err)
 		}
+		// KMS keys are site local, translate key IDs so that the
+		// replicated configuration refers to keys of this site.
+		if configData, err = localSSEConfig(configData); err != nil {
+			return wrapSRErr(err)
+		}
 		err = globalBucketMetadataSys.Update(bucket, bucketSSEConfig, configData)
 		if err != nil {
 			return wrapSRErr(err)
@@ -1454,6 +1370,10 @@ func (c *SiteReplicationSys) syncLocalToPeers(ctx context.Context) error {
 			}
 		}
 	}
+	// Replicate remote tiers and bucket lifecycle configuration.
+	if err := c.syncILMToPeers(ctx, buckets); err != nil {
+		return err
+	}
 
 	{
 		// Replicate IAM policies on local to all peers.
@@ -1636,6 +1556,9 @@ func (c *SiteReplicationSys) concDo(selfActionFn func() error, peerActionFn func
 				}
 			} else {
 				errs[i] = peerActionFn(depIDs[i], c.state.Peers[depIDs[i]])
+				if errs[i] == nil {
+					c.markPeerSynced(depIDs[i])
+				}
 			}
 			wg.Done()
 		}(i)
@@ -1668,6 +1591,22 @@ func (c *SiteReplicationSys) annotatePeerErr(dstPeer string, annotation string,
 	return fmt.Errorf("%s->%s: %s: %v", c.state.Name, dstPeer, annotation, err)
 }
 
+// peerNameByEndpoint returns the name of the peer site with the given
+// endpoint host, empty if there is none.
+func (c *SiteReplicationSys) peerNameByEndpoint(host string) string {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return ""
+	}
+	for _, peer := range c.state.Peers {
+		if ep, err := url.Parse(peer.Endpoint); err == nil && ep.Host == host {
+			return peer.Name
+		}
+	}
+	return ""
+}
+
 // isEnabled returns true if site replication is enabled
 func (c *SiteReplicationSys) isEnabled() bool {
 	c.RLock()
@@ -1682,10 +1621,10 @@ func (c *SiteReplicationSys) isEnabled() bool {
 func newRemoteClusterHTTPTransport() *http.Transport {
 	tr := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
+		TLSClientConfig: fips.RestrictTLS(&tls.Config{
 			RootCAs:            globalRootCAs,
 			ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
-		},
+		}),
 	}
 	return tr
 }
@@ -1989,8 +1928,12 @@ func (c *SiteReplicationSys) SiteReplicationStatus(ctx context.Context, objAPI O
 				}
 			}
 			if s.SSEConfig != nil {
-				if !sseCfgSet.Contains(*s.SSEConfig) {
-					sseCfgSet.Add(*s.SSEConfig)
+				sseCfg := *s.SSEConfig
+				if s.DeploymentID != globalDeploymentID {
+					sseCfg = localSSEConfigStr(sseCfg)
+				}
+				if !sseCfgSet.Contains(sseCfg) {
+					sseCfgSet.Add(sseCfg)
 				}
 				sseCfgCount++
 			}
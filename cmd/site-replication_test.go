@@ -18,10 +18,15 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/xml"
 	"testing"
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/set"
+	sse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/config"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // TestGetMissingSiteNames
@@ -66,3 +71,39 @@ func TestGetMissingSiteNames(t *testing.T) {
 		}
 	}
 }
+
+func TestLocalSSEConfig(t *testing.T) {
+	defer func(site config.Site) { globalSite = site }(globalSite)
+	globalSite = config.Site{KMSKeyMap: map[string]string{"site-a-key": "site-b-key"}}
+
+	sseConfig := func(algo, keyID string) []byte {
+		cfg := &sse.BucketSSEConfig{
+			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
+			Rules: []sse.Rule{{DefaultEncryptionAction: sse.EncryptionAction{Algorithm: sse.Algorithm(algo), MasterKeyID: keyID}}},
+		}
+		data, err := xml.Marshal(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	testCases := []struct {
+		configData []byte
+		expected   []byte
+	}{
+		{sseConfig(xhttp.AmzEncryptionKMS, "site-a-key"), sseConfig(xhttp.AmzEncryptionKMS, "site-b-key")},
+		{sseConfig(xhttp.AmzEncryptionKMS, "other-key"), sseConfig(xhttp.AmzEncryptionKMS, "other-key")},
+		{sseConfig(xhttp.AmzEncryptionAES, ""), sseConfig(xhttp.AmzEncryptionAES, "")},
+	}
+
+	for i, tc := range testCases {
+		configData, err := localSSEConfig(tc.configData)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !bytes.Equal(configData, tc.expected) {
+			t.Errorf("Test %d: Expected `%s`, got `%s`", i+1, tc.expected, configData)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/config/speedtest"
+	"github.com/minio/minio/internal/logger"
+)
+
+const (
+	speedtestHistoryFile = "history.json"
+
+	// speedtestCheckInterval is how often nodes check whether the
+	// speedtests of the current maintenance window are due.
+	speedtestCheckInterval = time.Minute
+)
+
+var (
+	speedtestHistoryPath = path.Join(minioConfigPrefix, "speedtest", speedtestHistoryFile)
+
+	speedtestLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+)
+
+// objectSpeedtestResult - the throughput of the PUT and GET requests of
+// the object speedtest in bytes and objects per second.
+type objectSpeedtestResult struct {
+	Size          int    `json:"size"`
+	Concurrent    int    `json:"concurrent"`
+	PutThroughput uint64 `json:"putThroughput"`
+	PutObjects    uint64 `json:"putObjects"`
+	GetThroughput uint64 `json:"getThroughput"`
+	GetObjects    uint64 `json:"getObjects"`
+	Error         string `json:"error,omitempty"`
+}
+
+// driveSpeedtestResult - the average write throughput in bytes per
+// second and latency in seconds of a drive.
+type driveSpeedtestResult struct {
+	Node       string  `json:"node"`
+	Path       string  `json:"path"`
+	Throughput uint64  `json:"throughput"`
+	Latency    float64 `json:"latency"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// netSpeedtestResult - the average throughput in bytes per second and
+// latency in seconds between two nodes.
+type netSpeedtestResult struct {
+	Node       string  `json:"node"`
+	Peer       string  `json:"peer"`
+	Throughput uint64  `json:"throughput"`
+	Latency    float64 `json:"latency"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// scheduledSpeedtestResult - the results of the speedtests run in a
+// maintenance window.
+type scheduledSpeedtestResult struct {
+	Window   time.Time              `json:"window"`
+	Start    time.Time              `json:"start"`
+	Duration time.Duration          `json:"duration"`
+	Object   *objectSpeedtestResult `json:"object,omitempty"`
+	Drives   []driveSpeedtestResult `json:"drives,omitempty"`
+	Net      []netSpeedtestResult   `json:"net,omitempty"`
+}
+
+// scheduledSpeedtest runs the configured speedtests once per
+// maintenance window on one node of the cluster.
+type scheduledSpeedtest struct {
+	mu  sync.Mutex
+	cfg speedtest.Config
+	// the window the speedtests are known to have run in.
+	lastWindow time.Time
+}
+
+var globalScheduledSpeedtest = &scheduledSpeedtest{}
+
+// Update applies a new configuration.
+func (s *scheduledSpeedtest) Update(cfg speedtest.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+}
+
+func (s *scheduledSpeedtest) config() speedtest.Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
+}
+
+func loadSpeedtestHistory(ctx context.Context, objAPI ObjectLayer) ([]scheduledSpeedtestResult, error) {
+	data, err := readConfig(ctx, objAPI, speedtestHistoryPath)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var history []scheduledSpeedtestResult
+	if err = json.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func saveSpeedtestHistory(ctx context.Context, objAPI ObjectLayer, history []scheduledSpeedtestResult) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, speedtestHistoryPath, data)
+}
+
+// initScheduledSpeedtest starts running the speedtests in the
+// configured maintenance windows in the background.
+func initScheduledSpeedtest(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		ticker := time.NewTicker(speedtestCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				globalScheduledSpeedtest.run(ctx, objAPI)
+			}
+		}
+	}()
+}
+
+// run - runs the speedtests if the current maintenance window has no
+// results yet, only one node of the cluster runs them at a time.
+func (s *scheduledSpeedtest) run(ctx context.Context, objAPI ObjectLayer) {
+	cfg := s.config()
+	window, ok := cfg.WindowStart(UTCNow())
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	done := s.lastWindow.Equal(window)
+	s.mu.Unlock()
+	if done {
+		return
+	}
+
+	locker := objAPI.NewNSLock(minioMetaBucket, "speedtest/scheduled.lock")
+	lkctx, err := locker.GetLock(ctx, speedtestLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	history, err := loadSpeedtestHistory(ctx, objAPI)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	if n := len(history); n == 0 || history[n-1].Window.Before(window) {
+		result := runSpeedtests(ctx, objAPI, cfg)
+		result.Window = window
+		history = append(history, result)
+		if len(history) > cfg.History {
+			history = history[len(history)-cfg.History:]
+		}
+		if err = saveSpeedtestHistory(ctx, objAPI, history); err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.lastWindow = window
+	s.mu.Unlock()
+}
+
+// runSpeedtests - runs the configured speedtests one after the other.
+func runSpeedtests(ctx context.Context, objAPI ObjectLayer, cfg speedtest.Config) scheduledSpeedtestResult {
+	result := scheduledSpeedtestResult{Start: UTCNow()}
+	if cfg.Runs(speedtest.TestDrive) {
+		result.Drives = runDriveSpeedtest(ctx)
+	}
+	if cfg.Runs(speedtest.TestNet) && globalIsDistErasure {
+		result.Net = runNetSpeedtest(ctx)
+	}
+	if cfg.Runs(speedtest.TestObject) {
+		result.Object = runObjectSpeedtest(ctx, objAPI, cfg)
+	}
+	result.Duration = UTCNow().Sub(result.Start)
+	return result
+}
+
+func runDriveSpeedtest(ctx context.Context) []driveSpeedtestResult {
+	var results []driveSpeedtestResult
+	add := func(info madmin.DrivePerfInfos) {
+		if info.Error != "" && len(info.SerialPerf) == 0 {
+			results = append(results, driveSpeedtestResult{Node: info.Addr, Error: info.Error})
+			return
+		}
+		for _, perf := range info.SerialPerf {
+			results = append(results, driveSpeedtestResult{
+				Node:       info.Addr,
+				Path:       perf.Path,
+				Throughput: perf.Throughput.Avg,
+				Latency:    perf.Latency.Avg,
+				Error:      perf.Error,
+			})
+		}
+	}
+	add(getDrivePerfInfos(ctx, globalLocalNodeName))
+	for info := range globalNotificationSys.GetDrivePerfInfos(ctx) {
+		add(info)
+	}
+	return results
+}
+
+func runNetSpeedtest(ctx context.Context) []netSpeedtestResult {
+	var results []netSpeedtestResult
+	infos := append([]madmin.NetPerfInfo{globalNotificationSys.GetNetPerfInfo(ctx)}, globalNotificationSys.DispatchNetPerfInfo(ctx)...)
+	for _, info := range infos {
+		if info.Error != "" && len(info.RemotePeers) == 0 {
+			results = append(results, netSpeedtestResult{Node: info.Addr, Error: info.Error})
+			continue
+		}
+		for _, peer := range info.RemotePeers {
+			results = append(results, netSpeedtestResult{
+				Node:       info.Addr,
+				Peer:       peer.Addr,
+				Throughput: peer.Throughput.Avg,
+				Latency:    peer.Latency.Avg,
+				Error:      peer.Error,
+			})
+		}
+	}
+	return results
+}
+
+func runObjectSpeedtest(ctx context.Context, objAPI ObjectLayer, cfg speedtest.Config) *objectSpeedtestResult {
+	concurrent := cfg.Concurrency
+	if runtime.GOMAXPROCS(0) < concurrent {
+		concurrent = runtime.GOMAXPROCS(0)
+	}
+
+	defer objAPI.DeleteBucket(context.Background(), pathJoin(minioMetaSpeedTestBucket, minioMetaSpeedTestBucketPrefix), DeleteBucketOptions{
+		Force:      true,
+		NoRecreate: true,
+	})
+
+	// Freeze all incoming S3 API calls while the speedtest runs, as
+	// the speedtest admin API does.
+	globalNotificationSys.ServiceFreeze(ctx, true)
+	defer globalNotificationSys.ServiceFreeze(ctx, false)
+
+	var last madmin.SpeedTestResult
+	for r := range speedTest(ctx, speedTestOpts{
+		throughputSize:   cfg.ObjectSize,
+		concurrencyStart: concurrent,
+		duration:         cfg.Duration,
+	}) {
+		last = r
+	}
+
+	result := &objectSpeedtestResult{
+		Size:          last.Size,
+		Concurrent:    last.Concurrent,
+		PutThroughput: last.PUTStats.ThroughputPerSec,
+		PutObjects:    last.PUTStats.ObjectsPerSec,
+		GetThroughput: last.GETStats.ThroughputPerSec,
+		GetObjects:    last.GETStats.ObjectsPerSec,
+	}
+	for _, server := range last.PUTStats.Servers {
+		if server.Err != "" {
+			result.Error = server.Err
+			break
+		}
+	}
+	if err := ctx.Err(); err != nil && result.Error == "" {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// speedtestTrend - the values of a series of speedtest results.
+type speedtestTrend struct {
+	labels map[string]string
+	values []float64
+	// index of the last result with a value.
+	last int
+}
+
+func (t *speedtestTrend) latest() float64 {
+	return t.values[len(t.values)-1]
+}
+
+// ratio returns the latest value relative to the median of the values
+// before it, false if there are no values before it.
+func (t *speedtestTrend) ratio() (float64, bool) {
+	if len(t.values) < 2 {
+		return 0, false
+	}
+	previous := append([]float64(nil), t.values[:len(t.values)-1]...)
+	sort.Float64s(previous)
+	median := previous[len(previous)/2]
+	if len(previous)%2 == 0 {
+		median = (previous[len(previous)/2-1] + median) / 2
+	}
+	if median <= 0 {
+		return 0, false
+	}
+	return t.latest() / median, true
+}
+
+type speedtestSeries struct {
+	trends map[string]*speedtestTrend
+	order  []string
+}
+
+func newSpeedtestSeries() *speedtestSeries {
+	return &speedtestSeries{trends: make(map[string]*speedtestTrend)}
+}
+
+func (s *speedtestSeries) add(index int, labels map[string]string, value float64) {
+	key := fmt.Sprint(labels)
+	t, ok := s.trends[key]
+	if !ok {
+		t = &speedtestTrend{labels: labels}
+		s.trends[key] = t
+		s.order = append(s.order, key)
+	}
+	t.values = append(t.values, value)
+	t.last = index
+}
+
+// current returns the trends which have a value in the result with
+// the index, in the order they were first seen.
+func (s *speedtestSeries) current(index int) []*speedtestTrend {
+	var trends []*speedtestTrend
+	for _, key := range s.order {
+		if t := s.trends[key]; t.last == index {
+			trends = append(trends, t)
+		}
+	}
+	return trends
+}
+
+// speedtestTrends - returns the object, drive and network throughput
+// trends of the history, series without a value in the latest result
+// are omitted. Failed tests are ignored.
+func speedtestTrends(history []scheduledSpeedtestResult) (object, drives, net []*speedtestTrend) {
+	objectSeries, driveSeries, netSeries := newSpeedtestSeries(), newSpeedtestSeries(), newSpeedtestSeries()
+	for i, result := range history {
+		if o := result.Object; o != nil && o.Error == "" {
+			objectSeries.add(i, map[string]string{"api": "put"}, float64(o.PutThroughput))
+			objectSeries.add(i, map[string]string{"api": "get"}, float64(o.GetThroughput))
+		}
+		for _, d := range result.Drives {
+			if d.Error == "" {
+				driveSeries.add(i, map[string]string{"node": d.Node, "drive": d.Path}, float64(d.Throughput))
+			}
+		}
+		for _, n := range result.Net {
+			if n.Error == "" {
+				netSeries.add(i, map[string]string{"node": n.Node, "peer": n.Peer}, float64(n.Throughput))
+			}
+		}
+	}
+	latest := len(history) - 1
+	return objectSeries.current(latest), driveSeries.current(latest), netSeries.current(latest)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestSpeedtestTrends(t *testing.T) {
+	history := []scheduledSpeedtestResult{
+		{
+			Object: &objectSpeedtestResult{PutThroughput: 100, GetThroughput: 200},
+			Drives: []driveSpeedtestResult{{Node: "node1:9000", Path: "/mnt/drive1", Throughput: 50}, {Node: "node1:9000", Path: "/mnt/drive2", Throughput: 50}},
+		},
+		{
+			Object: &objectSpeedtestResult{PutThroughput: 120, GetThroughput: 220},
+			Drives: []driveSpeedtestResult{{Node: "node1:9000", Path: "/mnt/drive1", Throughput: 70}},
+		},
+		{
+			Object: &objectSpeedtestResult{Error: "timeout"},
+			Net:    []netSpeedtestResult{{Node: "node1:9000", Peer: "node2:9000", Throughput: 10}},
+		},
+		{
+			Object: &objectSpeedtestResult{PutThroughput: 55, GetThroughput: 210},
+			Drives: []driveSpeedtestResult{{Node: "node1:9000", Path: "/mnt/drive1", Throughput: 30}},
+		},
+	}
+
+	object, drives, net := speedtestTrends(history)
+	if len(object) != 2 || len(drives) != 1 || len(net) != 0 {
+		t.Fatalf("expected 2 object, 1 drive and no net trends, got %d, %d and %d", len(object), len(drives), len(net))
+	}
+
+	put := object[0]
+	if put.labels["api"] != "put" || put.latest() != 55 {
+		t.Fatalf("unexpected put trend %+v", put)
+	}
+	// The failed test is ignored, the median of 100 and 120 is 110.
+	if ratio, ok := put.ratio(); !ok || ratio != 0.5 {
+		t.Fatalf("expected ratio 0.5, got %v %v", ratio, ok)
+	}
+
+	drive := drives[0]
+	if drive.labels["drive"] != "/mnt/drive1" || drive.latest() != 30 {
+		t.Fatalf("unexpected drive trend %+v", drive)
+	}
+	if ratio, ok := drive.ratio(); !ok || ratio != 0.5 {
+		t.Fatalf("expected ratio 0.5, got %v %v", ratio, ok)
+	}
+
+	if _, ok := (&speedtestTrend{values: []float64{10}}).ratio(); ok {
+		t.Fatal("expected no ratio without previous values")
+	}
+}
@@ -69,6 +69,10 @@ const DefaultSkewTime = 15 * time.Minute
 
 // Authenticates storage client's requests and validates for skewed time.
 func storageServerRequestValidate(r *http.Request) error {
+	if err := verifyInternodeRequest(r.TLS); err != nil {
+		return err
+	}
+
 	token, err := jwtreq.AuthorizationHeaderExtractor.ExtractToken(r)
 	if err != nil {
 		if err == jwtreq.ErrNoTokenInRequest {
@@ -176,6 +176,44 @@ type ClientGrantsResult struct {
 	SubjectFromToken string `xml:",omitempty"`
 }
 
+// StartDeviceAuthorizationResponse contains the result of a successful
+// StartDeviceAuthorization request.
+type StartDeviceAuthorizationResponse struct {
+	XMLName          xml.Name                  `xml:"https://sts.amazonaws.com/doc/2011-06-15/ StartDeviceAuthorizationResponse" json:"-"`
+	Result           DeviceAuthorizationResult `xml:"StartDeviceAuthorizationResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
+// DeviceAuthorizationResult - contains the codes of a pending device
+// authorization. The user must visit the verification URI on another
+// device and enter the user code, while the client polls
+// AssumeRoleWithDeviceCode with the device code.
+type DeviceAuthorizationResult struct {
+	DeviceCode              string `xml:"DeviceCode"`
+	UserCode                string `xml:"UserCode"`
+	VerificationURI         string `xml:"VerificationUri"`
+	VerificationURIComplete string `xml:"VerificationUriComplete,omitempty"`
+
+	// Lifetime of the device code in seconds.
+	ExpiresIn int `xml:"ExpiresIn"`
+
+	// Minimum number of seconds the client must wait between
+	// polling requests.
+	Interval int `xml:"Interval,omitempty"`
+}
+
+// AssumeRoleWithDeviceCodeResponse contains the result of successful
+// AssumeRoleWithDeviceCode request.
+type AssumeRoleWithDeviceCodeResponse struct {
+	XMLName          xml.Name          `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithDeviceCodeResponse" json:"-"`
+	Result           WebIdentityResult `xml:"AssumeRoleWithDeviceCodeResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
 // AssumeRoleWithLDAPResponse contains the result of successful
 // AssumeRoleWithLDAPIdentity request
 type AssumeRoleWithLDAPResponse struct {
@@ -192,6 +230,25 @@ type LDAPIdentityResult struct {
 	Credentials auth.Credentials `xml:",omitempty"`
 }
 
+// AssumeRoleWithKerberosResponse contains the result of successful
+// AssumeRoleWithKerberos request.
+type AssumeRoleWithKerberosResponse struct {
+	XMLName          xml.Name               `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithKerberosResponse" json:"-"`
+	Result           KerberosIdentityResult `xml:"AssumeRoleWithKerberosResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
+// KerberosIdentityResult - contains credentials for a successful
+// AssumeRoleWithKerberos request.
+type KerberosIdentityResult struct {
+	Credentials auth.Credentials `xml:",omitempty"`
+
+	// The Kerberos principal the credentials were issued to.
+	Principal string `xml:",omitempty"`
+}
+
 // AssumeRoleWithCertificateResponse contains the result of
 // a successful AssumeRoleWithCertificate request.
 type AssumeRoleWithCertificateResponse struct {
@@ -95,6 +95,9 @@ const (
 	ErrSTSMalformedPolicyDocument
 	ErrSTSInsecureConnection
 	ErrSTSInvalidClientCertificate
+	ErrSTSAuthorizationPending
+	ErrSTSSlowDown
+	ErrSTSInvalidKerberosToken
 	ErrSTSNotInitialized
 	ErrSTSInternalError
 )
@@ -157,6 +160,21 @@ var stsErrCodes = stsErrorCodeMap{
 		Description:    "The provided client certificate is invalid. Retry with a different certificate.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrSTSAuthorizationPending: {
+		Code:           "AuthorizationPending",
+		Description:    "The device authorization has not been completed by the user yet, retry after the polling interval.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSSlowDown: {
+		Code:           "SlowDown",
+		Description:    "The device code is polled too frequently, increase the polling interval.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSTSInvalidKerberosToken: {
+		Code:           "InvalidIdentityToken",
+		Description:    "The Kerberos service ticket that was passed could not be validated by MinIO.",
+		HTTPStatusCode: http.StatusUnauthorized,
+	},
 	ErrSTSNotInitialized: {
 		Code:           "STSNotInitialized",
 		Description:    "STS API not initialized, please try again.",
@@ -33,6 +33,7 @@ import (
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/config/identity/openid"
+	xtls "github.com/minio/minio/internal/config/identity/tls"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	iampolicy "github.com/minio/pkg/iam/policy"
@@ -52,6 +53,7 @@ const (
 	stsDurationSeconds        = "DurationSeconds"
 	stsLDAPUsername           = "LDAPUsername"
 	stsLDAPPassword           = "LDAPPassword"
+	stsDeviceCode             = "DeviceCode"
 
 	// STS API action constants
 	clientGrants      = "AssumeRoleWithClientGrants"
@@ -59,6 +61,12 @@ const (
 	ldapIdentity      = "AssumeRoleWithLDAPIdentity"
 	clientCertificate = "AssumeRoleWithCertificate"
 	assumeRole        = "AssumeRole"
+	deviceCode        = "AssumeRoleWithDeviceCode"
+	kerberosIdentity  = "AssumeRoleWithKerberos"
+
+	// MinIO extension to start the OAuth 2.0 device authorization
+	// grant used by AssumeRoleWithDeviceCode.
+	startDeviceAuthorization = "StartDeviceAuthorization"
 
 	stsRequestBodyLimit = 10 * (1 << 20) // 10 MiB
 
@@ -68,6 +76,8 @@ const (
 	audClaim = "aud"
 	azpClaim = "azp"
 	issClaim = "iss"
+	iatClaim = "iat"
+	amrClaim = "amr"
 
 	// JWT claim to check the parent user
 	parentClaim = "parent"
@@ -139,6 +149,22 @@ func registerSTSRouter(router *mux.Router) {
 	stsRouter.Methods(http.MethodPost).HandlerFunc(httpTraceAll(sts.AssumeRoleWithCertificate)).
 		Queries(stsAction, clientCertificate).
 		Queries(stsVersion, stsAPIVersion)
+
+	// AssumeRoleWithKerberos
+	stsRouter.Methods(http.MethodPost).HandlerFunc(httpTraceAll(sts.AssumeRoleWithKerberos)).
+		Queries(stsAction, kerberosIdentity).
+		Queries(stsVersion, stsAPIVersion)
+
+	// StartDeviceAuthorization
+	stsRouter.Methods(http.MethodPost).HandlerFunc(httpTraceAll(sts.StartDeviceAuthorization)).
+		Queries(stsAction, startDeviceAuthorization).
+		Queries(stsVersion, stsAPIVersion)
+
+	// AssumeRoleWithDeviceCode
+	stsRouter.Methods(http.MethodPost).HandlerFunc(httpTraceAll(sts.AssumeRoleWithDeviceCode)).
+		Queries(stsAction, deviceCode).
+		Queries(stsVersion, stsAPIVersion).
+		Queries(stsDeviceCode, "{DeviceCode:.*}")
 }
 
 func checkAssumeRoleAuth(ctx context.Context, r *http.Request) (user auth.Credentials, isErrCodeSTS bool, stsErr STSErrorCode) {
@@ -246,6 +272,7 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 
 	m := map[string]interface{}{
 		expClaim:    UTCNow().Add(duration).Unix(),
+		iatClaim:    UTCNow().Unix(),
 		parentClaim: user.AccessKey,
 	}
 
@@ -323,7 +350,13 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 	case ldapIdentity:
 		sts.AssumeRoleWithLDAPIdentity(w, r)
 		return
-	case clientGrants, webIdentity:
+	case startDeviceAuthorization:
+		sts.StartDeviceAuthorization(w, r)
+		return
+	case kerberosIdentity:
+		sts.AssumeRoleWithKerberos(w, r)
+		return
+	case clientGrants, webIdentity, deviceCode:
 	default:
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, fmt.Errorf("Unsupported action %s", action))
 		return
@@ -350,6 +383,20 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 
 	accessToken := r.Form.Get(stsWebIdentityAccessToken)
 
+	if action == deviceCode {
+		code := r.Form.Get(stsDeviceCode)
+		if code == "" {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, fmt.Errorf("%s is required", stsDeviceCode))
+			return
+		}
+		deviceToken, err := globalOpenIDConfig.ExchangeDeviceCode(code)
+		if err != nil {
+			writeSTSErrorResponse(ctx, w, true, deviceCodeErrToSTSErrCode(err), err)
+			return
+		}
+		token, accessToken = deviceToken.IDToken, deviceToken.AccessToken
+	}
+
 	m, err := v.Validate(token, accessToken, r.Form.Get(stsDurationSeconds))
 	if err != nil {
 		switch err {
@@ -357,7 +404,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 			switch action {
 			case clientGrants:
 				writeSTSErrorResponse(ctx, w, true, ErrSTSClientGrantsExpiredToken, err)
-			case webIdentity:
+			case webIdentity, deviceCode:
 				writeSTSErrorResponse(ctx, w, true, ErrSTSWebIdentityExpiredToken, err)
 			}
 			return
@@ -467,6 +514,8 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
 	}
 
+	m[iatClaim] = UTCNow().Unix()
+
 	secret := globalActiveCred.SecretKey
 	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
 	if err != nil {
@@ -548,6 +597,15 @@ func (sts *stsAPIHandlers) AssumeRoleWithSSO(w http.ResponseWriter, r *http.Requ
 		}
 		webIdentityResponse.ResponseMetadata.RequestID = w.Header().Get(xhttp.AmzRequestID)
 		encodedSuccessResponse = encodeResponse(webIdentityResponse)
+	case deviceCode:
+		deviceCodeResponse := &AssumeRoleWithDeviceCodeResponse{
+			Result: WebIdentityResult{
+				Credentials:                 cred,
+				SubjectFromWebIdentityToken: subFromToken,
+			},
+		}
+		deviceCodeResponse.ResponseMetadata.RequestID = w.Header().Get(xhttp.AmzRequestID)
+		encodedSuccessResponse = encodeResponse(deviceCodeResponse)
 	}
 
 	writeSuccessResponseXML(w, encodedSuccessResponse)
@@ -563,6 +621,86 @@ func (sts *stsAPIHandlers) AssumeRoleWithWebIdentity(w http.ResponseWriter, r *h
 	sts.AssumeRoleWithSSO(w, r)
 }
 
+// StartDeviceAuthorization - MinIO STS extension starting an OAuth 2.0
+// device authorization grant with the configured OpenID provider. The
+// returned verification URI and user code are presented to the user,
+// who completes the login on another device, while the client polls
+// AssumeRoleWithDeviceCode with the returned device code.
+//
+// Eg:-
+//    $ curl https://minio:9000/?Action=StartDeviceAuthorization&Version=2011-06-15
+func (sts *stsAPIHandlers) StartDeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, startDeviceAuthorization)
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if err := parseForm(r); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	if r.Form.Get(stsVersion) != stsAPIVersion {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, fmt.Errorf("Invalid STS API version %s, expecting %s", r.Form.Get("Version"), stsAPIVersion))
+		return
+	}
+
+	if !globalOpenIDConfig.Enabled {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSNotInitialized, errors.New("OpenID is not configured"))
+		return
+	}
+
+	d, err := globalOpenIDConfig.StartDeviceAuthorization()
+	if err != nil {
+		if errors.Is(err, openid.ErrDeviceFlowNotSupported) {
+			writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+			return
+		}
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
+		return
+	}
+
+	response := &StartDeviceAuthorizationResponse{
+		Result: DeviceAuthorizationResult{
+			DeviceCode:              d.DeviceCode,
+			UserCode:                d.UserCode,
+			VerificationURI:         d.VerificationURI,
+			VerificationURIComplete: d.VerificationURIComplete,
+			ExpiresIn:               d.ExpiresIn,
+			Interval:                d.Interval,
+		},
+	}
+	response.ResponseMetadata.RequestID = w.Header().Get(xhttp.AmzRequestID)
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+// AssumeRoleWithDeviceCode - MinIO STS extension completing a device
+// authorization grant started with StartDeviceAuthorization. The ID
+// token obtained for the device code is handled like a web identity
+// token.
+//
+// Eg:-
+//    $ curl https://minio:9000/?Action=AssumeRoleWithDeviceCode&DeviceCode=<code>&Version=2011-06-15
+func (sts *stsAPIHandlers) AssumeRoleWithDeviceCode(w http.ResponseWriter, r *http.Request) {
+	sts.AssumeRoleWithSSO(w, r)
+}
+
+// deviceCodeErrToSTSErrCode maps device code exchange errors to STS
+// error codes, letting clients tell apart pending authorizations.
+func deviceCodeErrToSTSErrCode(err error) STSErrorCode {
+	switch {
+	case errors.Is(err, openid.ErrDeviceAuthorizationPending):
+		return ErrSTSAuthorizationPending
+	case errors.Is(err, openid.ErrDeviceSlowDown):
+		return ErrSTSSlowDown
+	case errors.Is(err, openid.ErrDeviceAccessDenied):
+		return ErrSTSAccessDenied
+	case errors.Is(err, openid.ErrDeviceCodeExpired):
+		return ErrSTSWebIdentityExpiredToken
+	case errors.Is(err, openid.ErrDeviceFlowNotSupported):
+		return ErrSTSInvalidParameterValue
+	}
+	return ErrSTSInternalError
+}
+
 // AssumeRoleWithClientGrants - implementation of AWS STS extension API supporting
 // OAuth2.0 client credential grants.
 //
@@ -653,6 +791,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 
 	m := map[string]interface{}{
 		expClaim:  UTCNow().Add(expiryDur).Unix(),
+		iatClaim:  UTCNow().Unix(),
 		ldapUser:  ldapUserDN,
 		ldapUserN: ldapUsername,
 	}
@@ -798,12 +937,18 @@ func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *h
 
 	// We map the X.509 subject common name to the policy. So, a client
 	// with the common name "foo" will be associated with the policy "foo".
-	// Other mapping functions - e.g. public-key hash based mapping - are
-	// possible but not implemented.
+	// The configured policy mappings associate further policies based on
+	// the subject OU or the SANs of the certificate, e.g. SPIFFE IDs.
 	//
 	// Group mapping is not possible with standard X.509 certificates.
-	if certificate.Subject.CommonName == "" {
-		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, errors.New("certificate subject CN cannot be empty"))
+	subject := xtls.Subject(certificate)
+	if subject == "" {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, errors.New("certificate subject CN and URI SAN cannot both be empty"))
+		return
+	}
+	policies := globalSTSTLSConfig.Policies(certificate)
+	if len(policies) == 0 {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSAccessDenied, fmt.Errorf("no policy is mapped to the certificate %s", subject))
 		return
 	}
 
@@ -821,13 +966,14 @@ func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *h
 		expiry = validUntil
 	}
 
-	// Associate any service accounts to the certificate CN
-	parentUser := "tls:" + certificate.Subject.CommonName
+	// Associate any service accounts to the certificate subject
+	parentUser := "tls:" + subject
 
 	tmpCredentials, err := auth.GetNewCredentialsWithMetadata(map[string]interface{}{
 		expClaim:    UTCNow().Add(expiry).Unix(),
+		iatClaim:    UTCNow().Unix(),
 		parentClaim: parentUser,
-		subClaim:    certificate.Subject.CommonName,
+		subClaim:    subject,
 		audClaim:    certificate.Subject.Organization,
 		issClaim:    certificate.Issuer.CommonName,
 	}, globalActiveCred.SecretKey)
@@ -837,7 +983,7 @@ func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *h
 	}
 
 	tmpCredentials.ParentUser = parentUser
-	policyName := certificate.Subject.CommonName
+	policyName := strings.Join(policies, ",")
 	err = globalIAMSys.SetTempUser(ctx, tmpCredentials.AccessKey, tmpCredentials, policyName)
 	if err != nil {
 		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
@@ -864,3 +1010,100 @@ func (sts *stsAPIHandlers) AssumeRoleWithCertificate(w http.ResponseWriter, r *h
 	response.Metadata.RequestID = w.Header().Get(xhttp.AmzRequestID)
 	writeSuccessResponseXML(w, encodeResponse(response))
 }
+
+// AssumeRoleWithKerberos implements user authentication with Kerberos
+// service tickets negotiated via SPNEGO. The principal's realm is mapped
+// to policies through the identity_kerberos configuration, a policy
+// named after the principal is applied as well if it exists.
+//
+// API endpoint: https://minio:9000?Action=AssumeRoleWithKerberos&Version=2011-06-15
+//
+// Eg:-
+//    $ curl --negotiate -u : -X POST "https://minio:9000?Action=AssumeRoleWithKerberos&Version=2011-06-15"
+func (sts *stsAPIHandlers) AssumeRoleWithKerberos(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AssumeRoleWithKerberos")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !globalKerberosConfig.Enabled {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSNotInitialized, errors.New("STS API 'AssumeRoleWithKerberos' is disabled"))
+		return
+	}
+
+	if err := parseForm(r); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	if r.Form.Get(stsVersion) != stsAPIVersion {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSMissingParameter, fmt.Errorf("Invalid STS API version %s, expecting %s", r.Form.Get("Version"), stsAPIVersion))
+		return
+	}
+
+	id, err := globalKerberosConfig.Authenticate(r.Header.Get(xhttp.Authorization))
+	if err != nil {
+		// Challenge the client to start the SPNEGO negotiation.
+		w.Header().Set(xhttp.WwwAuthenticate, "Negotiate")
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidKerberosToken, err)
+		return
+	}
+
+	policies := append([]string{id.Principal()}, globalKerberosConfig.Policies(id)...)
+	policyName := globalIAMSys.CurrentPolicies(strings.Join(policies, ","))
+	if policyName == "" && globalPolicyOPA == nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSAccessDenied,
+			fmt.Errorf("No policy is mapped to the Kerberos principal %s, credentials will not be generated", id.Principal()))
+		return
+	}
+
+	expiry, err := globalKerberosConfig.GetExpiryDuration(r.Form.Get(stsDurationSeconds))
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInvalidParameterValue, err)
+		return
+	}
+
+	// Associate any service accounts to the Kerberos principal.
+	parentUser := "krb:" + id.Principal()
+
+	cred, err := auth.GetNewCredentialsWithMetadata(map[string]interface{}{
+		expClaim:    UTCNow().Add(expiry).Unix(),
+		iatClaim:    UTCNow().Unix(),
+		parentClaim: parentUser,
+		subClaim:    id.Principal(),
+		issClaim:    id.Realm,
+	}, globalActiveCred.SecretKey)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
+		return
+	}
+
+	cred.ParentUser = parentUser
+	if err = globalIAMSys.SetTempUser(ctx, cred.AccessKey, cred, policyName); err != nil {
+		writeSTSErrorResponse(ctx, w, true, ErrSTSInternalError, err)
+		return
+	}
+
+	// Call hook for site replication.
+	if err := globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemSTSAcc,
+		STSCredential: &madmin.SRSTSCredential{
+			AccessKey:           cred.AccessKey,
+			SecretKey:           cred.SecretKey,
+			SessionToken:        cred.SessionToken,
+			ParentUser:          cred.ParentUser,
+			ParentPolicyMapping: policyName,
+		},
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	response := &AssumeRoleWithKerberosResponse{
+		Result: KerberosIdentityResult{
+			Credentials: cred,
+			Principal:   id.Principal(),
+		},
+	}
+	response.ResponseMetadata.RequestID = w.Header().Get(xhttp.AmzRequestID)
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+)
+
+const (
+	stsRevocationFile = "sts-revocations.json"
+
+	// Maximum lifetime of temporary credentials.
+	maxSTSDuration = 365 * 24 * time.Hour
+)
+
+var (
+	stsRevocationPath = path.Join(iamConfigPrefix, stsRevocationFile)
+
+	stsRevocationLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+)
+
+// STSRevocation is a single entry of the STS revocation list. Exactly
+// one of AccessKey, ParentUser or Issuer is set. Entries for a parent
+// user or an issuing provider revoke all temporary credentials issued
+// up to RevokedAt, which is always set by the server.
+type STSRevocation struct {
+	AccessKey  string    `json:"accessKey,omitempty"`
+	ParentUser string    `json:"parentUser,omitempty"`
+	Issuer     string    `json:"issuer,omitempty"`
+	RevokedAt  time.Time `json:"revokedAt"`
+	// Revocations are pruned once all credentials they could
+	// apply to have expired.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (rv STSRevocation) validate() error {
+	n := 0
+	for _, s := range []string{rv.AccessKey, rv.ParentUser, rv.Issuer} {
+		if s != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return errSTSRevocationInvalid
+	}
+	return nil
+}
+
+// matches returns true if the temporary credential with the given
+// claims is revoked by this entry.
+func (rv STSRevocation) matches(cred auth.Credentials, claims map[string]interface{}) bool {
+	if rv.AccessKey != "" {
+		return rv.AccessKey == cred.AccessKey
+	}
+
+	if rv.ParentUser != "" && rv.ParentUser != cred.ParentUser {
+		return false
+	}
+	if rv.Issuer != "" {
+		iss, _ := claims[issClaim].(string)
+		if iss != rv.Issuer {
+			return false
+		}
+	}
+
+	// Tokens issued before the issued-at claim was introduced are
+	// treated as issued before the revocation.
+	iat, err := auth.ExpToInt64(claims[iatClaim])
+	if err != nil {
+		return true
+	}
+	return !time.Unix(iat, 0).After(rv.RevokedAt)
+}
+
+// STSRevocationSys maintains the cluster wide list of revoked
+// temporary credentials.
+type STSRevocationSys struct {
+	sync.RWMutex
+	revocations []STSRevocation
+}
+
+// NewSTSRevocationSys - creates a new, empty STS revocation list.
+func NewSTSRevocationSys() *STSRevocationSys {
+	return &STSRevocationSys{}
+}
+
+// IsRevoked - returns true if the temporary credential has been revoked.
+func (sys *STSRevocationSys) IsRevoked(cred auth.Credentials, claims map[string]interface{}) bool {
+	if sys == nil || !cred.IsTemp() {
+		return false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	for _, rv := range sys.revocations {
+		if rv.matches(cred, claims) {
+			return true
+		}
+	}
+	return false
+}
+
+// List - returns a copy of the current revocation list.
+func (sys *STSRevocationSys) List() []STSRevocation {
+	sys.RLock()
+	defer sys.RUnlock()
+	return append([]STSRevocation(nil), sys.revocations...)
+}
+
+// Load - loads the revocation list from the backend, dropping all
+// entries whose credentials must have expired by now.
+func (sys *STSRevocationSys) Load(ctx context.Context, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	revocations, err := readSTSRevocations(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	sys.revocations = pruneSTSRevocations(revocations, UTCNow())
+	sys.Unlock()
+	return nil
+}
+
+// Revoke - adds the revocation to the list as of now and persists it.
+// Callers must notify peers to reload the list.
+func (sys *STSRevocationSys) Revoke(ctx context.Context, objAPI ObjectLayer, rv STSRevocation) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	if err := rv.validate(); err != nil {
+		return err
+	}
+
+	// The revocation takes effect when it is added, a time
+	// supplied by the caller is ignored.
+	now := UTCNow()
+	rv.RevokedAt = now
+	// No temporary credential lives longer than the maximum STS
+	// duration, so the entry can be dropped after that.
+	rv.ExpiresAt = rv.RevokedAt.Add(maxSTSDuration)
+
+	// Other nodes may add revocations concurrently, the list is
+	// re-read under the cluster lock so that none of them is lost.
+	locker := objAPI.NewNSLock(minioMetaBucket, stsRevocationPath+".lock")
+	lkctx, err := locker.GetLock(ctx, stsRevocationLockTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	revocations, err := readSTSRevocations(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	revocations = append(pruneSTSRevocations(revocations, now), rv)
+	data, err := json.Marshal(revocations)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, stsRevocationPath, data); err != nil {
+		return err
+	}
+	sys.revocations = revocations
+	return nil
+}
+
+func readSTSRevocations(ctx context.Context, objAPI ObjectLayer) ([]STSRevocation, error) {
+	data, err := readConfig(ctx, objAPI, stsRevocationPath)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, err
+	}
+
+	var revocations []STSRevocation
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &revocations); err != nil {
+			return nil, err
+		}
+	}
+	return revocations, nil
+}
+
+func pruneSTSRevocations(revocations []STSRevocation, now time.Time) []STSRevocation {
+	pruned := revocations[:0:0]
+	for _, rv := range revocations {
+		if rv.ExpiresAt.After(now) {
+			pruned = append(pruned, rv)
+		}
+	}
+	return pruned
+}
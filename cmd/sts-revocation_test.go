@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+)
+
+func TestSTSRevocationIsRevoked(t *testing.T) {
+	revokedAt := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	sys := &STSRevocationSys{
+		revocations: []STSRevocation{
+			{AccessKey: "REVOKEDKEY", RevokedAt: revokedAt},
+			{ParentUser: "alice", RevokedAt: revokedAt},
+			{Issuer: "https://idp.example.com", RevokedAt: revokedAt},
+		},
+	}
+
+	tempCred := func(accessKey, parent string) auth.Credentials {
+		return auth.Credentials{
+			AccessKey:    accessKey,
+			SessionToken: "token",
+			ParentUser:   parent,
+			Expiration:   revokedAt.Add(time.Hour),
+		}
+	}
+	before := revokedAt.Add(-time.Minute).Unix()
+	after := revokedAt.Add(time.Minute).Unix()
+
+	testCases := []struct {
+		cred     auth.Credentials
+		claims   map[string]interface{}
+		expected bool
+	}{
+		{tempCred("REVOKEDKEY", "bob"), map[string]interface{}{iatClaim: after}, true},
+		{tempCred("OTHERKEY", "bob"), map[string]interface{}{iatClaim: before}, false},
+		{tempCred("OTHERKEY", "alice"), map[string]interface{}{iatClaim: before}, true},
+		{tempCred("OTHERKEY", "alice"), map[string]interface{}{iatClaim: after}, false},
+		// Tokens without issued-at are considered issued before revocation.
+		{tempCred("OTHERKEY", "alice"), map[string]interface{}{}, true},
+		{tempCred("OTHERKEY", "bob"), map[string]interface{}{issClaim: "https://idp.example.com", iatClaim: before}, true},
+		{tempCred("OTHERKEY", "bob"), map[string]interface{}{issClaim: "https://idp.example.com", iatClaim: after}, false},
+		// Only temporary credentials are subject to revocation.
+		{auth.Credentials{AccessKey: "REVOKEDKEY"}, map[string]interface{}{}, false},
+	}
+
+	for i, testCase := range testCases {
+		if got := sys.IsRevoked(testCase.cred, testCase.claims); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestPruneSTSRevocations(t *testing.T) {
+	now := UTCNow()
+	revocations := []STSRevocation{
+		{AccessKey: "EXPIRED", ExpiresAt: now.Add(-time.Second)},
+		{AccessKey: "LIVE", ExpiresAt: now.Add(time.Hour)},
+	}
+	pruned := pruneSTSRevocations(revocations, now)
+	if len(pruned) != 1 || pruned[0].AccessKey != "LIVE" {
+		t.Fatalf("unexpected pruned revocations %v", pruned)
+	}
+	if len(revocations) != 2 {
+		t.Fatal("pruning must not modify the input slice")
+	}
+}
+
+func TestSTSRevocationValidate(t *testing.T) {
+	if err := (STSRevocation{}).validate(); err == nil {
+		t.Fatal("expected an error for an empty revocation")
+	}
+	if err := (STSRevocation{AccessKey: "a", ParentUser: "b"}).validate(); err == nil {
+		t.Fatal("expected an error for a revocation with multiple targets")
+	}
+	if err := (STSRevocation{Issuer: "iss"}).validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSTSRevocationRevokeIgnoresRevokedAt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	sys := NewSTSRevocationSys()
+	before := UTCNow()
+	for _, revokedAt := range []time.Time{before.Add(-2 * maxSTSDuration), before.Add(time.Hour)} {
+		if err = sys.Revoke(ctx, obj, STSRevocation{ParentUser: "alice", RevokedAt: revokedAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	revocations := sys.List()
+	if len(revocations) != 2 {
+		t.Fatalf("expected 2 revocations, got %v", revocations)
+	}
+	for _, rv := range revocations {
+		if rv.RevokedAt.Before(before) || rv.RevokedAt.After(UTCNow()) {
+			t.Fatalf("expected the revocation time to be set by the server, got %v", rv.RevokedAt)
+		}
+		if !rv.ExpiresAt.Equal(rv.RevokedAt.Add(maxSTSDuration)) {
+			t.Fatalf("unexpected expiry %v", rv.ExpiresAt)
+		}
+	}
+}
+
+func TestSTSRevocationRevokeKeepsConcurrentEntries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	// Two nodes, neither has loaded the entry added by the other.
+	node1, node2 := NewSTSRevocationSys(), NewSTSRevocationSys()
+	if err = node1.Revoke(ctx, obj, STSRevocation{ParentUser: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = node2.Revoke(ctx, obj, STSRevocation{ParentUser: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	sys := NewSTSRevocationSys()
+	if err = sys.Load(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if revocations := sys.List(); len(revocations) != 2 {
+		t.Fatalf("expected 2 revocations, got %v", revocations)
+	}
+}
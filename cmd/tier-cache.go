@@ -0,0 +1,249 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/minio/minio/internal/config/tiercache"
+)
+
+// tierCacheDir is the directory used on every cache drive. Cached data
+// is not persisted across restarts, the directory is emptied when the
+// cache is created.
+const tierCacheDir = ".minio-tier-cache"
+
+var globalTierReadCache *tierReadCache
+
+type tierCacheEntry struct {
+	key  string
+	path string
+	size int64
+}
+
+// tierReadCache keeps the content of recently read transitioned objects
+// on local drives, evicting the least recently used objects once the
+// quota is exceeded. Only complete objects are cached, they are filled
+// by reads of the whole object and serve ranged reads as well.
+type tierReadCache struct {
+	drives []string
+	quota  int64
+
+	mu      sync.Mutex
+	used    int64
+	lru     *list.List // most recently used at the front
+	entries map[string]*list.Element
+	filling map[string]struct{}
+}
+
+func newTierReadCache(cfg tiercache.Config) (*tierReadCache, error) {
+	c := &tierReadCache{
+		quota:   int64(cfg.Quota),
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		filling: make(map[string]struct{}),
+	}
+	for _, drive := range cfg.Drives {
+		dir := filepath.Join(drive, tierCacheDir)
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, err
+		}
+		c.drives = append(c.drives, dir)
+	}
+	return c, nil
+}
+
+// tierCacheKey returns the cache key of the transitioned content of oi.
+// Transitioned objects are never modified on the tier, so the key of
+// their remote name and version remains valid until they are removed.
+func tierCacheKey(oi ObjectInfo) string {
+	sum := sha256.Sum256([]byte(oi.TransitionedObject.Tier + SlashSeparator + oi.TransitionedObject.Name + SlashSeparator + oi.TransitionedObject.VersionID))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *tierReadCache) path(key string) string {
+	drive := c.drives[crc32.ChecksumIEEE([]byte(key))%uint32(len(c.drives))]
+	return filepath.Join(drive, key[:2], key)
+}
+
+// Get returns a reader of length bytes at offset of the cached object,
+// false if it is not cached.
+func (c *tierReadCache) Get(key string, offset, length int64) (io.ReadCloser, bool) {
+	c.mu.Lock()
+	el, ok := c.entries[key]
+	if ok {
+		c.lru.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*tierCacheEntry)
+	if offset+length > entry.size {
+		return nil, false
+	}
+	f, err := os.Open(entry.path)
+	if err != nil {
+		c.remove(key)
+		return nil, false
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, offset, length), f}, true
+}
+
+// Fill returns a reader which caches the object read from r, the
+// whole object of the given size is expected to be read.
+func (c *tierReadCache) Fill(key string, size int64, r io.ReadCloser) io.ReadCloser {
+	if size > c.quota {
+		return r
+	}
+
+	c.mu.Lock()
+	_, cached := c.entries[key]
+	_, filling := c.filling[key]
+	if !cached && !filling {
+		c.filling[key] = struct{}{}
+	}
+	c.mu.Unlock()
+	if cached || filling {
+		return r
+	}
+
+	path := c.path(key)
+	f, err := c.create(path)
+	if err != nil {
+		c.mu.Lock()
+		delete(c.filling, key)
+		c.mu.Unlock()
+		return r
+	}
+	return &tierCacheFiller{ReadCloser: r, c: c, key: key, path: path, size: size, f: f}
+}
+
+func (c *tierReadCache) create(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	return os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+}
+
+// add makes the object available and evicts the least recently used
+// objects beyond the quota.
+func (c *tierReadCache) add(key, path string, size int64) {
+	var evicted []string
+	c.mu.Lock()
+	delete(c.filling, key)
+	c.entries[key] = c.lru.PushFront(&tierCacheEntry{key: key, path: path, size: size})
+	c.used += size
+	for c.used > c.quota {
+		entry := c.lru.Remove(c.lru.Back()).(*tierCacheEntry)
+		delete(c.entries, entry.key)
+		c.used -= entry.size
+		evicted = append(evicted, entry.path)
+	}
+	c.mu.Unlock()
+
+	// Open readers of evicted objects keep reading the unlinked files.
+	for _, path := range evicted {
+		os.Remove(path)
+	}
+}
+
+func (c *tierReadCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		entry := c.lru.Remove(el).(*tierCacheEntry)
+		delete(c.entries, key)
+		c.used -= entry.size
+		os.Remove(entry.path)
+	}
+}
+
+// tierCacheFiller writes the data read from the tier to a temporary
+// file, which is added to the cache once the whole object was read.
+type tierCacheFiller struct {
+	io.ReadCloser
+	c    *tierReadCache
+	key  string
+	path string
+	size int64
+
+	f *os.File
+	n int64
+}
+
+func (t *tierCacheFiller) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if t.f != nil && n > 0 {
+		if _, werr := t.f.Write(p[:n]); werr != nil {
+			t.abort()
+		}
+		t.n += int64(n)
+	}
+	if t.f != nil && err == io.EOF {
+		t.commit()
+	}
+	return n, err
+}
+
+func (t *tierCacheFiller) commit() {
+	f := t.f
+	t.f = nil
+	if t.n == t.size && f.Close() == nil && os.Rename(f.Name(), t.path) == nil {
+		t.c.add(t.key, t.path, t.size)
+		return
+	}
+	t.discard(f)
+}
+
+func (t *tierCacheFiller) abort() {
+	f := t.f
+	t.f = nil
+	t.discard(f)
+}
+
+// discard removes the temporary file and allows the object to be
+// filled again.
+func (t *tierCacheFiller) discard(f *os.File) {
+	f.Close()
+	os.Remove(f.Name())
+	t.c.mu.Lock()
+	delete(t.c.filling, t.key)
+	t.c.mu.Unlock()
+}
+
+func (t *tierCacheFiller) Close() error {
+	if t.f != nil {
+		t.abort()
+	}
+	return t.ReadCloser.Close()
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/minio/minio/internal/config/tiercache"
+)
+
+func TestTierReadCache(t *testing.T) {
+	c, err := newTierReadCache(tiercache.Config{Drives: []string{t.TempDir(), t.TempDir()}, Quota: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := func(name string) string {
+		return tierCacheKey(ObjectInfo{TransitionedObject: TransitionedObject{Tier: "WARM", Name: name}})
+	}
+	fill := func(name, data string) {
+		key := key(name)
+		r := c.Fill(key, int64(len(data)), ioutil.NopCloser(bytes.NewReader([]byte(data))))
+		if _, err := io.Copy(ioutil.Discard, r); err != nil {
+			t.Fatal(err)
+		}
+		r.Close()
+	}
+	get := func(name string, offset, length int64) (string, bool) {
+		r, ok := c.Get(key(name), offset, length)
+		if !ok {
+			return "", false
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data), true
+	}
+
+	fill("a", "aaaa")
+	if data, ok := get("a", 1, 2); !ok || data != "aa" {
+		t.Fatalf("expected cached range, got %q (%v)", data, ok)
+	}
+
+	// Partially read objects are not cached.
+	r := c.Fill(key("b"), 4, ioutil.NopCloser(bytes.NewReader([]byte("bbbb"))))
+	r.Read(make([]byte, 2))
+	r.Close()
+	if _, ok := get("b", 0, 4); ok {
+		t.Fatal("expected partially read object not to be cached")
+	}
+
+	// Objects shorter than announced are not cached.
+	r = c.Fill(key("c"), 4, ioutil.NopCloser(bytes.NewReader([]byte("cc"))))
+	io.Copy(ioutil.Discard, r)
+	r.Close()
+	if _, ok := get("c", 0, 2); ok {
+		t.Fatal("expected truncated object not to be cached")
+	}
+
+	fill("b", "bbbb")
+	get("a", 0, 4)
+	// "b" is the least recently used object and evicted.
+	fill("d", "dddd")
+	if _, ok := get("b", 0, 4); ok {
+		t.Fatal("expected least recently used object to be evicted")
+	}
+	for _, name := range []string{"a", "d"} {
+		if _, ok := get(name, 0, 4); !ok {
+			t.Fatalf("expected %s to be cached", name)
+		}
+	}
+	if c.used != 8 {
+		t.Fatalf("expected 8 bytes used, got %d", c.used)
+	}
+
+	// Objects larger than the quota are never cached.
+	fill("e", "eeeeeeeeeeee")
+	if _, ok := get("e", 0, 12); ok {
+		t.Fatal("expected object larger than the quota not to be cached")
+	}
+}
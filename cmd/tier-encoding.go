@@ -0,0 +1,315 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/minio/minio/internal/fips"
+	"github.com/minio/minio/internal/kms"
+	"github.com/minio/sio"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore tierEncodedBackend tierEncodedReader
+
+// Object content may be compressed and encrypted before it is uploaded
+// to a cloud tier. Encoded content starts with a header recording how
+// it was encoded, and the object metadata records that the content was
+// encoded, so that content transitioned before the tier's settings
+// changed remains readable.
+//
+//	magic [8]byte | flags byte | [keyID len uint16 | keyID | sealed key len uint16 | sealed key]
+//
+// The data key of encrypted content is generated by the tier's KMS key
+// and bound to the remote object name.
+
+const (
+	tierEncodingCompressed = 1 << iota
+	tierEncodingEncrypted
+)
+
+const (
+	// Multipart uploads of encoded content, whose size is unknown,
+	// use parts of at least this size.
+	tierEncodingMinPartSize = 16 << 20
+
+	minTierPartSize = 5 << 20
+	maxTierPartSize = 5 << 30
+)
+
+var (
+	tierEncodingMagic = [8]byte{'M', 'I', 'N', 'I', 'O', 'T', 'E', 1}
+
+	errTierEncodingHeader = errors.New("invalid remote tier object encoding header")
+)
+
+// tierEncoding configures how object content is encoded before it is
+// uploaded to a cloud tier.
+type tierEncoding struct {
+	// Compress the content with S2.
+	Compress bool `json:"compress,omitempty"`
+	// KMSKeyID is the KMS key generating the data keys which encrypt
+	// the content.
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+	// PartSize of uploads to the tier, the backend default if 0.
+	PartSize int64 `json:"partSize,omitempty"`
+}
+
+// IsEmpty returns true if the content is uploaded as is.
+func (enc tierEncoding) IsEmpty() bool {
+	return enc == tierEncoding{}
+}
+
+func (enc tierEncoding) validate() error {
+	if enc.PartSize != 0 && (enc.PartSize < minTierPartSize || enc.PartSize > maxTierPartSize) {
+		return errTierInvalidPartSize
+	}
+	if enc.KMSKeyID != "" {
+		if GlobalKMS == nil {
+			return errKMSNotConfigured
+		}
+		if _, err := GlobalKMS.GenerateKey(enc.KMSKeyID, kms.Context{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetEncoding sets the encoding of object content uploaded to the cloud
+// tier tierName. Content already on the tier keeps its encoding and is
+// decoded as recorded in the metadata of its object.
+func (config *TierConfigMgr) SetEncoding(tierName string, enc tierEncoding) error {
+	config.Lock()
+	defer config.Unlock()
+
+	if _, exists := config.isTierNameInUse(tierName); !exists {
+		return errTierNotFound
+	}
+	if _, ok := config.Tiers[tierName]; !ok {
+		return errTierEncodingUnsupported
+	}
+	if err := enc.validate(); err != nil {
+		return err
+	}
+	if enc.IsEmpty() {
+		delete(config.Encodings, tierName)
+	} else {
+		config.Encodings[tierName] = enc
+	}
+	return nil
+}
+
+// Encoding returns the encoding of object content uploaded to the tier
+// tierName.
+func (config *TierConfigMgr) Encoding(tierName string) (tierEncoding, error) {
+	config.RLock()
+	defer config.RUnlock()
+
+	if _, exists := config.isTierNameInUse(tierName); !exists {
+		return tierEncoding{}, errTierNotFound
+	}
+	return config.Encodings[tierName], nil
+}
+
+// partSizeWarmBackend is implemented by remote tiers whose upload part
+// size can be tuned.
+type partSizeWarmBackend interface {
+	PutWithPartSize(ctx context.Context, object string, r io.Reader, length, partSize int64) (remoteVersionID, error)
+}
+
+// tierEncodedBackend encodes object content as configured for the tier
+// before it is uploaded and decodes content read with
+// WarmBackendGetOpts.encoded set. Cloud tiers are always wrapped, as
+// their content may have been encoded under an earlier setting.
+type tierEncodedBackend struct {
+	WarmBackend
+	enc tierEncoding
+}
+
+func newTierEncodedBackend(d WarmBackend, enc tierEncoding) WarmBackend {
+	return &tierEncodedBackend{WarmBackend: d, enc: enc}
+}
+
+// encodes returns true if content uploaded by Put is encoded.
+func (t *tierEncodedBackend) encodes() bool {
+	return t.enc.Compress || t.enc.KMSKeyID != ""
+}
+
+// isTierEncoded returns true if content uploaded to d is encoded.
+func isTierEncoded(d WarmBackend) bool {
+	t, ok := d.(*tierEncodedBackend)
+	return ok && t.encodes()
+}
+
+// isTierObjectEncoded returns true if the content of the transitioned
+// object oi was encoded when it was uploaded to the remote tier.
+func isTierObjectEncoded(oi ObjectInfo) bool {
+	return oi.UserDefined[ReservedMetadataPrefixLower+TransitionedEncoded] == "true"
+}
+
+func (t *tierEncodedBackend) put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	if p, ok := t.WarmBackend.(partSizeWarmBackend); ok {
+		partSize := t.enc.PartSize
+		if partSize == 0 && length < 0 {
+			partSize = tierEncodingMinPartSize
+		}
+		return p.PutWithPartSize(ctx, object, r, length, partSize)
+	}
+	return t.WarmBackend.Put(ctx, object, r, length)
+}
+
+func (t *tierEncodedBackend) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	if !t.encodes() {
+		return t.put(ctx, object, r, length)
+	}
+
+	var flags byte
+	if t.enc.Compress {
+		flags |= tierEncodingCompressed
+	}
+	if t.enc.KMSKeyID != "" {
+		flags |= tierEncodingEncrypted
+	}
+	var hdr bytes.Buffer
+	hdr.Write(tierEncodingMagic[:])
+	hdr.WriteByte(flags)
+
+	if t.enc.Compress {
+		cr := newS2CompressReader(r, length)
+		defer cr.Close()
+		r = cr
+	}
+	if t.enc.KMSKeyID != "" {
+		if GlobalKMS == nil {
+			return "", errKMSNotConfigured
+		}
+		dek, err := GlobalKMS.GenerateKey(t.enc.KMSKeyID, tierEncodingContext(object))
+		if err != nil {
+			return "", err
+		}
+		writeTierEncodingField(&hdr, []byte(dek.KeyID))
+		writeTierEncodingField(&hdr, dek.Ciphertext)
+		if r, err = sio.EncryptReader(r, sio.Config{Key: dek.Plaintext, MinVersion: sio.Version20, CipherSuites: fips.CipherSuitesDARE()}); err != nil {
+			return "", err
+		}
+	}
+
+	// The size of encoded content is only known once it is uploaded.
+	return t.put(ctx, object, io.MultiReader(&hdr, r), -1)
+}
+
+func (t *tierEncodedBackend) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	if !opts.encoded {
+		return t.WarmBackend.Get(ctx, object, rv, opts)
+	}
+	// Offsets into encoded content are unknown, so ranges are read
+	// from the beginning of the content.
+	rc, err := t.WarmBackend.Get(ctx, object, rv, WarmBackendGetOpts{})
+	if err != nil {
+		return nil, err
+	}
+	r, err := decodeTierContent(object, bufio.NewReader(rc))
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	if opts.startOffset > 0 {
+		if _, err = io.CopyN(io.Discard, r, opts.startOffset); err != nil {
+			rc.Close()
+			return nil, err
+		}
+	}
+	if opts.length > 0 {
+		r = io.LimitReader(r, opts.length)
+	}
+	return &tierEncodedReader{Reader: r, Closer: rc}, nil
+}
+
+type tierEncodedReader struct {
+	io.Reader
+	io.Closer
+}
+
+// decodeTierContent returns the decoded content read from r, which
+// must start with an encoding header.
+func decodeTierContent(object string, r *bufio.Reader) (io.Reader, error) {
+	var magic [len(tierEncodingMagic)]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil || magic != tierEncodingMagic {
+		return nil, errTierEncodingHeader
+	}
+
+	flags, err := r.ReadByte()
+	if err != nil {
+		return nil, errTierEncodingHeader
+	}
+	var dr io.Reader = r
+	if flags&tierEncodingEncrypted != 0 {
+		keyID, err := readTierEncodingField(r)
+		if err != nil {
+			return nil, err
+		}
+		sealedKey, err := readTierEncodingField(r)
+		if err != nil {
+			return nil, err
+		}
+		if GlobalKMS == nil {
+			return nil, errKMSNotConfigured
+		}
+		key, err := GlobalKMS.DecryptKey(string(keyID), sealedKey, tierEncodingContext(object))
+		if err != nil {
+			return nil, err
+		}
+		if dr, err = sio.DecryptReader(dr, sio.Config{Key: key, MinVersion: sio.Version20, CipherSuites: fips.CipherSuitesDARE()}); err != nil {
+			return nil, err
+		}
+	}
+	if flags&tierEncodingCompressed != 0 {
+		dr = s2.NewReader(dr)
+	}
+	return dr, nil
+}
+
+func tierEncodingContext(object string) kms.Context {
+	return kms.Context{"MinIO remote tier object": object}
+}
+
+func writeTierEncodingField(w *bytes.Buffer, b []byte) {
+	var n [2]byte
+	binary.LittleEndian.PutUint16(n[:], uint16(len(b)))
+	w.Write(n[:])
+	w.Write(b)
+}
+
+func readTierEncodingField(r io.Reader) ([]byte, error) {
+	var n [2]byte
+	if _, err := io.ReadFull(r, n[:]); err != nil {
+		return nil, errTierEncodingHeader
+	}
+	b := make([]byte, binary.LittleEndian.Uint16(n[:]))
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, errTierEncodingHeader
+	}
+	return b, nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/minio/minio/internal/kms"
+)
+
+func TestTierEncodedBackend(t *testing.T) {
+	ctx := context.Background()
+	oldKMS := GlobalKMS
+	defer func() { GlobalKMS = oldKMS }()
+	var err error
+	if GlobalKMS, err = kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw="); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("transitioned object content "), 1000)
+	testCases := []tierEncoding{
+		{Compress: true},
+		{KMSKeyID: "my-minio-key"},
+		{Compress: true, KMSKeyID: "my-minio-key"},
+	}
+	for i, enc := range testCases {
+		backend := testVerifyBackend{objects: map[string][]byte{}}
+		d := newTierEncodedBackend(backend, enc)
+		if _, err = d.Put(ctx, "object", bytes.NewReader(data), int64(len(data))); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		stored := backend.objects["object"]
+		if bytes.Contains(stored, data[:100]) {
+			t.Fatalf("Test %d: expected encoded content", i+1)
+		}
+		if enc.Compress && len(stored) >= len(data) {
+			t.Fatalf("Test %d: expected compressed content, got %d bytes", i+1, len(stored))
+		}
+
+		if !isTierEncoded(d) {
+			t.Fatalf("Test %d: expected content to be encoded", i+1)
+		}
+		r, err := d.Get(ctx, "object", "", WarmBackendGetOpts{startOffset: 28, length: 100, encoded: true})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !bytes.Equal(got, data[28:128]) {
+			t.Fatalf("Test %d: unexpected range content of %d bytes", i+1, len(got))
+		}
+
+		// Content is bound to the remote object name.
+		if enc.KMSKeyID != "" {
+			backend.objects["other"] = stored
+			if _, err = d.Get(ctx, "other", "", WarmBackendGetOpts{encoded: true}); err == nil {
+				t.Fatalf("Test %d: expected decryption of moved content to fail", i+1)
+			}
+		}
+	}
+
+	// Content uploaded before the tier was encoded is read as is, even
+	// if it looks like encoded content.
+	backend := testVerifyBackend{objects: map[string][]byte{}}
+	if _, err = newTierEncodedBackend(backend, tierEncoding{Compress: true}).Put(ctx, "object", bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	encoded := backend.objects["object"]
+	plain := testVerifyBackend{objects: map[string][]byte{"plain": data, "lookalike": encoded}}
+	d := newTierEncodedBackend(plain, tierEncoding{Compress: true})
+	for _, object := range []string{"plain", "lookalike"} {
+		r, err := d.Get(ctx, object, "", WarmBackendGetOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := ioutil.ReadAll(r)
+		r.Close()
+		if !bytes.Equal(got, plain.objects[object]) {
+			t.Fatalf("unexpected content of %s", object)
+		}
+	}
+
+	// Content uploaded while the tier was encoded is decoded after the
+	// encoding is cleared.
+	d = newTierEncodedBackend(testVerifyBackend{objects: map[string][]byte{"object": encoded, "plain": data}}, tierEncoding{})
+	if isTierEncoded(d) {
+		t.Fatal("expected content not to be encoded")
+	}
+	r, err := d.Get(ctx, "object", "", WarmBackendGetOpts{encoded: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := ioutil.ReadAll(r)
+	r.Close()
+	if !bytes.Equal(got, data) {
+		t.Fatal("unexpected content of object encoded under an earlier setting")
+	}
+
+	// Encoded content must start with an encoding header.
+	if _, err = d.Get(ctx, "plain", "", WarmBackendGetOpts{encoded: true}); err != errTierEncodingHeader {
+		t.Fatalf("expected %v, got %v", errTierEncodingHeader, err)
+	}
+}
+
+func TestTierEncodingValidate(t *testing.T) {
+	oldKMS := GlobalKMS
+	defer func() { GlobalKMS = oldKMS }()
+	GlobalKMS = nil
+
+	testCases := []struct {
+		enc tierEncoding
+		err error
+	}{
+		{tierEncoding{Compress: true}, nil},
+		{tierEncoding{PartSize: 64 << 20}, nil},
+		{tierEncoding{PartSize: 1 << 20}, errTierInvalidPartSize},
+		{tierEncoding{PartSize: 6 << 30}, errTierInvalidPartSize},
+		{tierEncoding{KMSKeyID: "my-minio-key"}, errKMSNotConfigured},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.enc.validate(); err != testCase.err {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 
@@ -61,12 +62,63 @@ var (
 		Message:    "Invalid remote tier credentials",
 		StatusCode: http.StatusBadRequest,
 	}
+	// error returned when editing credentials of a tier without any.
+	errTierNotEditable = AdminError{
+		Code:       "XMinioAdminTierNotEditable",
+		Message:    "Remote tier has no credentials to edit",
+		StatusCode: http.StatusBadRequest,
+	}
 	// error returned when reserved internal names are used.
 	errTierReservedName = AdminError{
 		Code:       "XMinioAdminTierReserved",
 		Message:    "Cannot use reserved tier name",
 		StatusCode: http.StatusBadRequest,
 	}
+	// error returned when migrating objects to the tier they are on.
+	errTierMigrateSameTier = AdminError{
+		Code:       "XMinioAdminTierMigrateSameTier",
+		Message:    "Source and destination tiers of a migration must differ",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when a tier migration is already running.
+	errTierMigrateInProgress = AdminError{
+		Code:       "XMinioAdminTierMigrateInProgress",
+		Message:    "A tier migration is already in progress",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when no tier migration was started.
+	errTierMigrateNotFound = AdminError{
+		Code:       "XMinioAdminTierMigrateNotFound",
+		Message:    "No tier migration was found",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when temporary credentials are set for a tier
+	// not supporting them.
+	errTierSTSUnsupported = AdminError{
+		Code:       "XMinioAdminTierSTSUnsupported",
+		Message:    "Temporary credentials are only supported by S3 tiers",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when a new tier endpoint does not serve the
+	// objects on the tier.
+	errTierEndpointMismatch = AdminError{
+		Code:       "XMinioAdminTierEndpointMismatch",
+		Message:    "Remote tier endpoint does not serve the objects of the tier",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when setting content encodings of tiers on
+	// filesystems and tapes.
+	errTierEncodingUnsupported = AdminError{
+		Code:       "XMinioAdminTierEncodingUnsupported",
+		Message:    "Content encoding is only supported by cloud tiers",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the upload part size of a tier is out of range.
+	errTierInvalidPartSize = AdminError{
+		Code:       "XMinioAdminTierInvalidPartSize",
+		Message:    "Remote tier part size must be between 5MiB and 5GiB",
+		StatusCode: http.StatusBadRequest,
+	}
 )
 
 func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Request) {
@@ -124,9 +176,175 @@ func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Reques
 	}
 	globalNotificationSys.LoadTransitionTierConfig(ctx)
 
+	// Call site replication hook.
+	if err = globalSiteReplicationSys.TierChangeHook(ctx, cfg.Name); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// AddTierFSHandler - PUT /minio/admin/v3/tier-fs
+//
+// Adds a remote tier on a filesystem mounted at the same path on all
+// nodes.
+func (api adminAPIHandlers) AddTierFSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddTierFS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	var cfg tierFS
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	// Disallow remote tiers with internal storage class names
+	switch cfg.Name {
+	case storageclass.STANDARD, storageclass.RRS:
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errTierReservedName), r.URL)
+		return
+	}
+	// Refresh from the disk in case we had missed notifications about edits from peers.
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.AddFS(ctx, cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
+	writeSuccessNoContent(w)
+}
+
+// ListTierFSHandler - GET /minio/admin/v3/tier-fs
+//
+// Lists the filesystem tiers with their space usage.
+func (api adminAPIHandlers) ListTierFSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListTierFS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(globalTierConfigMgr.ListFSTiers())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// AddTierTapeHandler - PUT /minio/admin/v3/tier-tape
+//
+// Adds a remote tier on LTFS formatted tapes mounted at the same paths
+// on all nodes.
+func (api adminAPIHandlers) AddTierTapeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddTierTape")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	var cfg tierTape
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	// Disallow remote tiers with internal storage class names
+	switch cfg.Name {
+	case storageclass.STANDARD, storageclass.RRS:
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errTierReservedName), r.URL)
+		return
+	}
+	// Refresh from the disk in case we had missed notifications about edits from peers.
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.AddTape(ctx, cfg); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
 	writeSuccessNoContent(w)
 }
 
+// ListTierTapeHandler - GET /minio/admin/v3/tier-tape
+//
+// Lists the tape tiers with the space usage of their pools.
+func (api adminAPIHandlers) ListTierTapeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListTierTape")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(globalTierConfigMgr.ListTapeTiers())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 func (api adminAPIHandlers) ListTierHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ListTier")
 
@@ -202,9 +420,165 @@ func (api adminAPIHandlers) EditTierHandler(w http.ResponseWriter, r *http.Reque
 	}
 	globalNotificationSys.LoadTransitionTierConfig(ctx)
 
+	// Call site replication hook.
+	if err = globalSiteReplicationSys.TierChangeHook(ctx, scName); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// TierRotateHandler - POST /minio/admin/v3/tier-rotate/{tier}
+// ----------
+// Updates the endpoint and credentials of a remote tier after
+// validating them, without interrupting transitions to and reads from
+// the tier.
+func (api adminAPIHandlers) TierRotateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TierRotate")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, cred := validateAdminReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+	vars := mux.Vars(r)
+	scName := vars["tier"]
+
+	password := cred.SecretKey
+	reqBytes, err := madmin.DecryptData(password, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	var req tierRotateReq
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(reqBytes, &req); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Refresh from the disk in case we had missed notifications about edits from peers.
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Rotate(ctx, scName, req); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
+	// Call site replication hook.
+	if err = globalSiteReplicationSys.TierChangeHook(ctx, scName); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
 	writeSuccessNoContent(w)
 }
 
+// SetTierEncodingHandler - PUT /minio/admin/v3/tier-encoding/{tier}
+// ----------
+// Sets the compression, encryption and upload part size of object
+// content transitioned to a cloud tier.
+func (api adminAPIHandlers) SetTierEncodingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetTierEncoding")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+	scName := mux.Vars(r)["tier"]
+
+	var enc tierEncoding
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&enc); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	// Refresh from the disk in case we had missed notifications about edits from peers.
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.SetEncoding(scName, enc); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalTierConfigMgr.Save(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalNotificationSys.LoadTransitionTierConfig(ctx)
+
+	// Call site replication hook.
+	if err := globalSiteReplicationSys.TierChangeHook(ctx, scName); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// GetTierEncodingHandler - GET /minio/admin/v3/tier-encoding/{tier}
+// ----------
+// Returns the content encoding of a remote tier.
+func (api adminAPIHandlers) GetTierEncodingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetTierEncoding")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	enc, err := globalTierConfigMgr.Encoding(mux.Vars(r)["tier"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(enc)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 func (api adminAPIHandlers) TierStatsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "TierStats")
 
@@ -234,3 +608,143 @@ func (api adminAPIHandlers) TierStatsHandler(w http.ResponseWriter, r *http.Requ
 	}
 	writeSuccessResponseJSON(w, data)
 }
+
+// TierVerifyStatusHandler - GET /minio/admin/v3/tier-verify-status
+// ----------
+// Returns the cluster wide verification status of transitioned objects
+// sampled by the scanner, including the most recent missing or corrupted
+// objects.
+func (api adminAPIHandlers) TierVerifyStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TierVerifyStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	status := globalTierVerifier.Status()
+	for _, peerStatus := range globalNotificationSys.GetTierVerifyStatus(ctx) {
+		status.merge(peerStatus)
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// TierUsageHandler - GET /minio/admin/v3/tier-usage
+// ----------
+// Returns the data stored on each remote tier per bucket along with the
+// requests and traffic to the tiers since server start.
+func (api adminAPIHandlers) TierUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TierUsage")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	dui, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	nodes := append(globalNotificationSys.GetTierUsage(ctx), globalTierUsage.Usage())
+	data, err := json.Marshal(mergeTierUsage(nodes, dui))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// TierMigrateHandler - POST /minio/admin/v3/tier-migrate?from=<tier>&to=<tier>[&bucket=<bucket>]
+// ----------
+// Starts migrating the objects transitioned to the tier from to the
+// tier to, or resumes the interrupted migration between them.
+func (api adminAPIHandlers) TierMigrateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TierMigrate")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	st, err := globalTierMigrator.Start(ctx, objAPI, vars["from"], vars["to"], r.Form.Get("bucket"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// TierMigrateStatusHandler - GET /minio/admin/v3/tier-migrate-status
+// ----------
+// Returns the progress of the last tier migration.
+func (api adminAPIHandlers) TierMigrateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TierMigrateStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	st, err := globalTierMigrator.Status(ctx, objAPI)
+	if errors.Is(err, errConfigNotFound) {
+		err = errTierMigrateNotFound
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -165,6 +165,7 @@ func deleteObjectFromRemoteTier(ctx context.Context, objName, rvID, tierName str
 		return err
 	}
 	err = w.Remove(ctx, objName, remoteVersionID(rvID))
+	globalTierUsage.recordRemove(tierName, objName)
 	if err != nil {
 		return err
 	}
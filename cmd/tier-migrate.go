@@ -0,0 +1,436 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Objects transitioned to one remote tier may be migrated to another,
+// e.g when switching cloud providers. The tiered content of each object
+// version is copied to the new tier before its transition pointers are
+// updated in place, the content on the old tier is then removed through
+// the tier journal. Migrations are run by a single node and persist
+// their progress, so that they can be resumed after a restart or by
+// starting the same migration again.
+const (
+	tierMigrateFile = "tier-migrate.json"
+
+	// Checkpoints are persisted after this many object versions have
+	// been scanned or this much time has passed, whichever comes first.
+	tierMigrateCheckpointObjects  = 1000
+	tierMigrateCheckpointInterval = 30 * time.Second
+)
+
+var (
+	tierMigratePath = path.Join(minioConfigPrefix, tierMigrateFile)
+
+	tierMigrateLockTimeout = newDynamicTimeout(5*time.Second, time.Second)
+
+	errTierMigrateConflict = errors.New("transitioned object changed during migration")
+)
+
+var globalTierMigrator = &tierMigrator{}
+
+// tierMigrateStatusType is the status of a tier migration.
+type tierMigrateStatusType string
+
+const (
+	tierMigrateOngoing   tierMigrateStatusType = "Ongoing"
+	tierMigrateCompleted tierMigrateStatusType = "Completed"
+	tierMigrateFailed    tierMigrateStatusType = "Failed"
+)
+
+// tierMigrateCheckpoint is the position of a migration. Buckets and
+// object versions are listed in lexical order, so the migration resumes
+// listing Bucket from Marker and VersionIDMarker.
+type tierMigrateCheckpoint struct {
+	Bucket          string `json:"bucket,omitempty"`
+	Marker          string `json:"marker,omitempty"`
+	VersionIDMarker string `json:"versionIdMarker,omitempty"`
+}
+
+// tierMigrateStatus - progress of the migration of transitioned objects
+// from one remote tier to another.
+type tierMigrateStatus struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Only objects of this bucket are migrated, if set.
+	Bucket     string                `json:"bucket,omitempty"`
+	Status     tierMigrateStatusType `json:"status"`
+	Error      string                `json:"error,omitempty"`
+	StartTime  time.Time             `json:"startTime"`
+	LastUpdate time.Time             `json:"lastUpdate"`
+
+	ScannedVersions  uint64 `json:"scannedVersions"`
+	MigratedVersions uint64 `json:"migratedVersions"`
+	MigratedBytes    uint64 `json:"migratedBytes"`
+	// Versions which could not be migrated remain on the old tier and
+	// are retried when the migration is started again.
+	FailedVersions uint64 `json:"failedVersions"`
+	LastFailure    string `json:"lastFailure,omitempty"`
+
+	Checkpoint tierMigrateCheckpoint `json:"checkpoint"`
+}
+
+func (st tierMigrateStatus) sameJob(from, to, bucket string) bool {
+	return st.From == from && st.To == to && st.Bucket == bucket
+}
+
+func loadTierMigrateStatus(ctx context.Context, objAPI ObjectLayer) (st tierMigrateStatus, err error) {
+	data, err := readConfig(ctx, objAPI, tierMigratePath)
+	if err != nil {
+		return st, err
+	}
+	err = json.Unmarshal(data, &st)
+	return st, err
+}
+
+func saveTierMigrateStatus(ctx context.Context, objAPI ObjectLayer, st tierMigrateStatus) error {
+	st.LastUpdate = UTCNow()
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, tierMigratePath, data)
+}
+
+// tierMigrator runs the tier migration of this node, if any.
+type tierMigrator struct {
+	mu      sync.Mutex
+	running bool
+	st      tierMigrateStatus
+	pending int
+	saved   time.Time
+}
+
+// Status - returns the status of the migration run by this node, or
+// the last persisted status if this node does not run one.
+func (m *tierMigrator) Status(ctx context.Context, objAPI ObjectLayer) (tierMigrateStatus, error) {
+	m.mu.Lock()
+	if m.running {
+		st := m.st
+		m.mu.Unlock()
+		return st, nil
+	}
+	m.mu.Unlock()
+	return loadTierMigrateStatus(ctx, objAPI)
+}
+
+// Start - starts migrating the objects transitioned to the tier from,
+// optionally only those of bucket, to the tier to. A previous migration
+// between the same tiers which did not complete is resumed from its
+// checkpoint.
+func (m *tierMigrator) Start(ctx context.Context, objAPI ObjectLayer, from, to, bucket string) (tierMigrateStatus, error) {
+	if from == to {
+		return tierMigrateStatus{}, errTierMigrateSameTier
+	}
+	for _, tier := range []string{from, to} {
+		if !globalTierConfigMgr.IsTierValid(tier) {
+			return tierMigrateStatus{}, errTierNotFound
+		}
+	}
+	if bucket != "" {
+		if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+			return tierMigrateStatus{}, err
+		}
+	}
+
+	// The lock is held by the migration until it ends, its context
+	// must outlive the request.
+	locker := objAPI.NewNSLock(minioMetaBucket, "tier/migrate.lock")
+	lkctx, err := locker.GetLock(GlobalContext, tierMigrateLockTimeout)
+	if err != nil {
+		return tierMigrateStatus{}, errTierMigrateInProgress
+	}
+
+	st, err := loadTierMigrateStatus(ctx, objAPI)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		locker.Unlock(lkctx.Cancel)
+		return tierMigrateStatus{}, err
+	}
+	if err != nil || st.Status == tierMigrateCompleted || !st.sameJob(from, to, bucket) {
+		st = tierMigrateStatus{
+			From:      from,
+			To:        to,
+			Bucket:    bucket,
+			StartTime: UTCNow(),
+		}
+	}
+	st.Status = tierMigrateOngoing
+	st.Error = ""
+	if err = saveTierMigrateStatus(ctx, objAPI, st); err != nil {
+		locker.Unlock(lkctx.Cancel)
+		return tierMigrateStatus{}, err
+	}
+
+	m.begin(st)
+	go func() {
+		defer locker.Unlock(lkctx.Cancel)
+		m.run(lkctx.Context(), objAPI)
+	}()
+	return st, nil
+}
+
+// initTierMigrator resumes a migration interrupted by a restart, on the
+// first node to acquire the migration lock.
+func initTierMigrator(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		locker := objAPI.NewNSLock(minioMetaBucket, "tier/migrate.lock")
+		lkctx, err := locker.GetLock(ctx, tierMigrateLockTimeout)
+		if err != nil {
+			return
+		}
+		defer locker.Unlock(lkctx.Cancel)
+
+		st, err := loadTierMigrateStatus(lkctx.Context(), objAPI)
+		if err != nil {
+			if !errors.Is(err, errConfigNotFound) {
+				logger.LogIf(ctx, err)
+			}
+			return
+		}
+		if st.Status != tierMigrateOngoing {
+			return
+		}
+		globalTierMigrator.begin(st)
+		globalTierMigrator.run(lkctx.Context(), objAPI)
+	}()
+}
+
+func (m *tierMigrator) begin(st tierMigrateStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.running = true
+	m.st = st
+	m.pending = 0
+	m.saved = UTCNow()
+}
+
+func (m *tierMigrator) status() tierMigrateStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.st
+}
+
+func (m *tierMigrator) run(ctx context.Context, objAPI ObjectLayer) {
+	err := m.migrate(ctx, objAPI)
+	if ctx.Err() != nil {
+		// Interrupted, persist the progress so that it can be resumed.
+		logger.LogIf(GlobalContext, m.save(GlobalContext, objAPI, true))
+		m.mu.Lock()
+		m.running = false
+		m.mu.Unlock()
+		return
+	}
+
+	m.mu.Lock()
+	m.st.Status = tierMigrateCompleted
+	if err != nil {
+		m.st.Status = tierMigrateFailed
+		m.st.Error = err.Error()
+	}
+	m.mu.Unlock()
+	logger.LogIf(ctx, m.save(ctx, objAPI, true))
+
+	m.mu.Lock()
+	m.running = false
+	m.mu.Unlock()
+}
+
+// save persists the status if enough progress has been made since the
+// last save, or unconditionally if force is set.
+func (m *tierMigrator) save(ctx context.Context, objAPI ObjectLayer, force bool) error {
+	m.mu.Lock()
+	if !force && m.pending < tierMigrateCheckpointObjects && time.Since(m.saved) < tierMigrateCheckpointInterval {
+		m.mu.Unlock()
+		return nil
+	}
+	st := m.st
+	m.pending = 0
+	m.saved = UTCNow()
+	m.mu.Unlock()
+
+	return saveTierMigrateStatus(ctx, objAPI, st)
+}
+
+// migrate lists all buckets to be migrated from the checkpoint on.
+func (m *tierMigrator) migrate(ctx context.Context, objAPI ObjectLayer) error {
+	st := m.status()
+	buckets := []string{st.Bucket}
+	if st.Bucket == "" {
+		bis, err := objAPI.ListBuckets(ctx)
+		if err != nil {
+			return err
+		}
+		buckets = buckets[:0]
+		for _, bi := range bis {
+			buckets = append(buckets, bi.Name)
+		}
+		sort.Strings(buckets)
+	}
+
+	for _, bucket := range buckets {
+		cp := st.Checkpoint
+		switch {
+		case bucket < cp.Bucket:
+			continue
+		case bucket > cp.Bucket:
+			cp = tierMigrateCheckpoint{Bucket: bucket}
+		}
+		if err := m.migrateBucket(ctx, objAPI, st.From, st.To, cp); err != nil && !isErrBucketNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *tierMigrator) migrateBucket(ctx context.Context, objAPI ObjectLayer, from, to string, cp tierMigrateCheckpoint) error {
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, cp.Bucket, "", cp.Marker, cp.VersionIDMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range loi.Objects {
+			if oi.TransitionedObject.Status != lifecycle.TransitionComplete || oi.TransitionedObject.Tier != from {
+				m.scanned(oi, nil, false)
+				continue
+			}
+			err := migrateTieredObject(ctx, objAPI, oi, to)
+			if _, ok := err.(BackendDown); ok {
+				return err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			m.scanned(oi, err, true)
+		}
+		if !loi.IsTruncated {
+			return nil
+		}
+		cp.Marker, cp.VersionIDMarker = loi.NextMarker, loi.NextVersionIDMarker
+		m.checkpoint(cp)
+		logger.LogIf(ctx, m.save(ctx, objAPI, false))
+	}
+}
+
+// scanned records the outcome of the migration of oi, if it was
+// eligible.
+func (m *tierMigrator) scanned(oi ObjectInfo, err error, eligible bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.st.ScannedVersions++
+	m.pending++
+	if !eligible {
+		return
+	}
+	if err != nil {
+		m.st.FailedVersions++
+		m.st.LastFailure = fmt.Sprintf("%s/%s(%s): %v", oi.Bucket, oi.Name, oi.VersionID, err)
+		return
+	}
+	m.st.MigratedVersions++
+	m.st.MigratedBytes += uint64(oi.Size)
+}
+
+func (m *tierMigrator) checkpoint(cp tierMigrateCheckpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.st.Checkpoint = cp
+}
+
+// migrateTieredObject copies the tiered content of oi to the tier to and
+// points oi to the copy, if oi was not modified meanwhile. The content
+// on the previous tier is removed through the tier journal.
+func migrateTieredObject(ctx context.Context, objAPI ObjectLayer, oi ObjectInfo, to string) error {
+	from := oi.TransitionedObject
+	src, err := globalTierConfigMgr.getDriver(from.Tier)
+	if err != nil {
+		return err
+	}
+	dst, err := globalTierConfigMgr.getDriver(to)
+	if err != nil {
+		return err
+	}
+	destObj, err := genTransitionObjName(oi.Bucket)
+	if err != nil {
+		return err
+	}
+
+	r, err := src.Get(ctx, from.Name, remoteVersionID(from.VersionID), WarmBackendGetOpts{encoded: isTierObjectEncoded(oi)})
+	if err != nil {
+		globalTierUsage.recordGet(from.Tier, oi.Bucket)
+		return err
+	}
+	r = globalTierUsage.countGet(from.Tier, oi.Bucket, r)
+	h := sha256.New()
+	rv, err := dst.Put(ctx, destObj, io.TeeReader(r, h), oi.Size)
+	r.Close()
+	globalTierUsage.recordPut(to, oi.Bucket, "", oi.Size, err)
+	if err != nil {
+		return err
+	}
+
+	removeCopy := func() {
+		logger.LogIf(ctx, globalTierJournal.AddEntry(jentry{ObjName: destObj, VersionID: string(rv), TierName: to}))
+	}
+	checksum := hex.EncodeToString(h.Sum(nil))
+	if expected := oi.UserDefined[ReservedMetadataPrefixLower+TransitionedChecksum]; expected != "" && expected != checksum {
+		removeCopy()
+		return errTierChecksumMismatch
+	}
+
+	_, err = objAPI.PutObjectMetadata(ctx, oi.Bucket, oi.Name, ObjectOptions{
+		VersionID: oi.VersionID,
+		MTime:     oi.ModTime,
+		EvalMetadataFn: func(cur ObjectInfo) error {
+			if cur.TransitionedObject.Status != lifecycle.TransitionComplete ||
+				cur.TransitionedObject.Tier != from.Tier ||
+				cur.TransitionedObject.Name != from.Name ||
+				cur.TransitionedObject.VersionID != from.VersionID {
+				return errTierMigrateConflict
+			}
+			cur.UserDefined[ReservedMetadataPrefixLower+TransitionTier] = to
+			cur.UserDefined[ReservedMetadataPrefixLower+TransitionedObjectName] = destObj
+			cur.UserDefined[ReservedMetadataPrefixLower+TransitionedVersionID] = string(rv)
+			cur.UserDefined[ReservedMetadataPrefixLower+TransitionedChecksum] = checksum
+			cur.UserDefined[ReservedMetadataPrefixLower+TransitionedEncoded] = strconv.FormatBool(isTierEncoded(dst))
+			return nil
+		},
+	})
+	if err != nil {
+		removeCopy()
+		return err
+	}
+
+	return globalTierJournal.AddEntry(jentry{ObjName: from.Name, VersionID: from.VersionID, TierName: from.Tier})
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+)
+
+func TestTierMigrate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objAPI, disks, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer objAPI.Shutdown(context.Background())
+	defer removeRoots(disks)
+
+	warm := testVerifyBackend{objects: map[string][]byte{"warm/object": []byte("hello world")}}
+	cold := testVerifyBackend{objects: map[string][]byte{}}
+	oldMgr, oldJournal := globalTierConfigMgr, globalTierJournal
+	defer func() { globalTierConfigMgr, globalTierJournal = oldMgr, oldJournal }()
+	globalTierConfigMgr = NewTierConfigMgr()
+	globalTierConfigMgr.drivercache["WARM"] = warm
+	globalTierConfigMgr.drivercache["COLD"] = cold
+	globalTierJournal = &tierJournal{tierMemJournal: newTierMemJoural(10)}
+
+	if err = objAPI.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hello world")
+	oi, err := objAPI.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Mark the object as transitioned to the WARM tier.
+	_, err = objAPI.PutObjectMetadata(ctx, "bucket", "object", ObjectOptions{
+		MTime: oi.ModTime,
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			oi.UserDefined[ReservedMetadataPrefixLower+TransitionStatus] = lifecycle.TransitionComplete
+			oi.UserDefined[ReservedMetadataPrefixLower+TransitionTier] = "WARM"
+			oi.UserDefined[ReservedMetadataPrefixLower+TransitionedObjectName] = "warm/object"
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &tierMigrator{}
+	m.begin(tierMigrateStatus{From: "WARM", To: "COLD", Bucket: "bucket", Status: tierMigrateOngoing})
+	if err = m.migrate(ctx, objAPI); err != nil {
+		t.Fatal(err)
+	}
+	if st := m.status(); st.ScannedVersions != 1 || st.MigratedVersions != 1 || st.MigratedBytes != uint64(len(data)) {
+		t.Fatalf("unexpected status %+v", st)
+	}
+
+	oi, err = objAPI.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.TransitionedObject.Tier != "COLD" || !bytes.Equal(cold.objects[oi.TransitionedObject.Name], data) {
+		t.Fatalf("object not migrated: %+v", oi.TransitionedObject)
+	}
+	if je := <-globalTierJournal.entries; je.TierName != "WARM" || je.ObjName != "warm/object" {
+		t.Fatalf("expected the WARM copy to be queued for removal, got %+v", je)
+	}
+
+	// Objects which changed since they were listed are left untouched
+	// and their new copy is removed.
+	stale := oi
+	stale.TransitionedObject.Name = "stale"
+	cold.objects["stale"] = data
+	if err = migrateTieredObject(ctx, objAPI, stale, "WARM"); err != errTierMigrateConflict {
+		t.Fatalf("expected %v, got %v", errTierMigrateConflict, err)
+	}
+	if je := <-globalTierJournal.entries; je.TierName != "WARM" {
+		t.Fatalf("expected the new copy to be queued for removal, got %+v", je)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/url"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+// The endpoint and credentials of a remote tier may be rotated while
+// objects are being transitioned to and read from it. The new
+// configuration is validated before it replaces the current one, which
+// keeps serving requests until then. S3 tiers may use temporary
+// credentials obtained by AssumeRole instead of long-lived static keys.
+
+const tierRotateProbeObject = "probeobject-rotate"
+
+// tierSTS configures an S3 tier to authenticate with temporary
+// credentials obtained by AssumeRole, which are refreshed before they
+// expire.
+type tierSTS struct {
+	// Endpoint of the STS service, the tier endpoint if empty.
+	Endpoint        string `json:"endpoint,omitempty"`
+	AccessKey       string `json:"accessKey"`
+	SecretKey       string `json:"secretKey"`
+	RoleARN         string `json:"roleARN,omitempty"`
+	Region          string `json:"region,omitempty"`
+	DurationSeconds int    `json:"durationSeconds,omitempty"`
+}
+
+func (sts tierSTS) credentials(tierURL *url.URL) (*credentials.Credentials, error) {
+	endpoint := sts.Endpoint
+	if endpoint == "" {
+		endpoint = tierURL.Scheme + "://" + tierURL.Host
+	}
+	return credentials.NewSTSAssumeRole(endpoint, credentials.STSAssumeRoleOptions{
+		AccessKey:       sts.AccessKey,
+		SecretKey:       sts.SecretKey,
+		Location:        sts.Region,
+		DurationSeconds: sts.DurationSeconds,
+		RoleARN:         sts.RoleARN,
+		RoleSessionName: "minio-tier",
+	})
+}
+
+//msgp:ignore tierRotateReq
+
+// tierRotateReq is the request to update the endpoint and credentials
+// of a remote tier. Fields left empty are unchanged.
+type tierRotateReq struct {
+	Endpoint string           `json:"endpoint,omitempty"`
+	Creds    madmin.TierCreds `json:"creds"`
+	STS      *tierSTS         `json:"sts,omitempty"`
+}
+
+func (req tierRotateReq) hasCreds() bool {
+	c := req.Creds
+	return c.AccessKey != "" || c.SecretKey != "" || c.AWSRole || c.CredsJSON != nil
+}
+
+// Rotate updates the endpoint and credentials of the remote tier
+// tierName. The new configuration must pass the same checks as a new
+// tier, and a changed endpoint must serve the objects already on the
+// tier, before it replaces the current one.
+func (config *TierConfigMgr) Rotate(ctx context.Context, tierName string, req tierRotateReq) error {
+	config.Lock()
+	defer config.Unlock()
+
+	tierType, exists := config.isTierNameInUse(tierName)
+	if !exists {
+		return errTierNotFound
+	}
+	if _, ok := config.FSTiers[tierName]; ok {
+		return errTierNotEditable
+	}
+	if _, ok := config.TapeTiers[tierName]; ok {
+		return errTierNotEditable
+	}
+	if req.Endpoint == "" && !req.hasCreds() && req.STS == nil {
+		return errTierInsufficientCreds
+	}
+	if req.STS != nil {
+		if tierType != madmin.S3 {
+			return errTierSTSUnsupported
+		}
+		if req.hasCreds() || req.STS.AccessKey == "" || req.STS.SecretKey == "" {
+			return errTierInsufficientCreds
+		}
+	}
+
+	// Copy the tier config so that the current one is left untouched
+	// if validation fails.
+	cfg := config.Tiers[tierName]
+	creds := req.Creds
+	switch tierType {
+	case madmin.S3:
+		s3 := *cfg.S3
+		if req.Endpoint != "" {
+			s3.Endpoint = req.Endpoint
+		}
+		switch {
+		case !req.hasCreds():
+		case creds.AWSRole:
+			s3.AWSRole = true
+			s3.AccessKey, s3.SecretKey = "", ""
+		case creds.AccessKey == "" || creds.SecretKey == "":
+			return errTierInsufficientCreds
+		default:
+			s3.AWSRole = false
+			s3.AccessKey, s3.SecretKey = creds.AccessKey, creds.SecretKey
+		}
+		cfg.S3 = &s3
+	case madmin.Azure:
+		az := *cfg.Azure
+		if req.Endpoint != "" {
+			az.Endpoint = req.Endpoint
+		}
+		if req.hasCreds() {
+			if creds.SecretKey == "" {
+				return errTierInsufficientCreds
+			}
+			az.AccountKey = creds.SecretKey
+		}
+		cfg.Azure = &az
+	case madmin.GCS:
+		gcs := *cfg.GCS
+		if req.Endpoint != "" {
+			gcs.Endpoint = req.Endpoint
+		}
+		if req.hasCreds() {
+			if creds.CredsJSON == nil {
+				return errTierInsufficientCreds
+			}
+			gcs.Creds = base64.URLEncoding.EncodeToString(creds.CredsJSON)
+		}
+		cfg.GCS = &gcs
+	}
+
+	// Temporary credentials are kept unless replaced by static ones.
+	sts, hasSTS := config.STSTiers[tierName]
+	switch {
+	case req.STS != nil:
+		sts, hasSTS = *req.STS, true
+	case req.hasCreds():
+		hasSTS = false
+	}
+
+	var stsp *tierSTS
+	if hasSTS {
+		stsp = &sts
+	}
+	d, err := newWarmBackend(ctx, cfg, stsp)
+	if err != nil {
+		return err
+	}
+	if req.Endpoint != "" {
+		if err = checkTierRotateEndpoint(ctx, config.drivercache[tierName], d); err != nil {
+			return err
+		}
+	}
+
+	config.Tiers[tierName] = cfg
+	if hasSTS {
+		config.STSTiers[tierName] = sts
+	} else {
+		delete(config.STSTiers, tierName)
+	}
+	// Operations in progress on the current driver are not interrupted.
+	config.drivercache[tierName] = d
+	return nil
+}
+
+// checkTierRotateEndpoint checks that an object written with the
+// current driver of a tier is read by its new driver, i.e. that the new
+// endpoint serves the same storage. The check is skipped if the
+// current driver can't write, e.g when its credentials were revoked.
+func checkTierRotateEndpoint(ctx context.Context, cur, next WarmBackend) error {
+	if cur == nil {
+		return nil
+	}
+	var empty bytes.Reader
+	rv, err := cur.Put(ctx, tierRotateProbeObject, &empty, 0)
+	if err != nil {
+		return nil
+	}
+	defer cur.Remove(ctx, tierRotateProbeObject, rv)
+
+	r, err := next.Get(ctx, tierRotateProbeObject, rv, WarmBackendGetOpts{})
+	if err != nil {
+		return errTierEndpointMismatch
+	}
+	r.Close()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestTierRotateValidation(t *testing.T) {
+	config := NewTierConfigMgr()
+	config.Tiers["S3TIER"] = madmin.TierConfig{
+		Type: madmin.S3,
+		Name: "S3TIER",
+		S3:   &madmin.TierS3{Endpoint: "https://s3.amazonaws.com", AccessKey: "access", SecretKey: "secret"},
+	}
+	config.Tiers["AZTIER"] = madmin.TierConfig{
+		Type:  madmin.Azure,
+		Name:  "AZTIER",
+		Azure: &madmin.TierAzure{Endpoint: "https://blob.core.windows.net", AccountKey: "key"},
+	}
+	config.FSTiers["NAS"] = tierFS{Name: "NAS", Path: t.TempDir()}
+
+	testCases := []struct {
+		tier string
+		req  tierRotateReq
+		err  error
+	}{
+		{"MISSING", tierRotateReq{Endpoint: "https://example.com"}, errTierNotFound},
+		{"NAS", tierRotateReq{Endpoint: "https://example.com"}, errTierNotEditable},
+		{"S3TIER", tierRotateReq{}, errTierInsufficientCreds},
+		{"S3TIER", tierRotateReq{Creds: madmin.TierCreds{AccessKey: "access"}}, errTierInsufficientCreds},
+		{"S3TIER", tierRotateReq{STS: &tierSTS{AccessKey: "access"}}, errTierInsufficientCreds},
+		{"AZTIER", tierRotateReq{STS: &tierSTS{AccessKey: "access", SecretKey: "secret"}}, errTierSTSUnsupported},
+	}
+	for i, testCase := range testCases {
+		if err := config.Rotate(context.Background(), testCase.tier, testCase.req); err != testCase.err {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+	}
+	// Failed rotations leave the tier unchanged.
+	if s3 := config.Tiers["S3TIER"].S3; s3.AccessKey != "access" || s3.SecretKey != "secret" {
+		t.Fatalf("unexpected tier credentials %s %s", s3.AccessKey, s3.SecretKey)
+	}
+}
+
+func TestCheckTierRotateEndpoint(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	newFS := func(prefix string) WarmBackend {
+		d, err := newWarmBackendFS(tierFS{Name: "NAS", Path: dir, Prefix: prefix})
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-d.scanned
+		return d
+	}
+
+	cur := newFS("tier")
+	if err := checkTierRotateEndpoint(ctx, cur, newFS("tier")); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkTierRotateEndpoint(ctx, cur, newFS("other")); err != errTierEndpointMismatch {
+		t.Fatalf("expected %v, got %v", errTierEndpointMismatch, err)
+	}
+	if err := checkTierRotateEndpoint(ctx, nil, newFS("other")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Requests and traffic to remote tiers are accounted per tier and
+// bucket since server start, so tier costs can be attributed to the
+// buckets and lifecycle rules causing them.
+
+var globalTierUsage = newTierUsageSys()
+
+type tierUsageKey struct {
+	tier, bucket string
+}
+
+type tierUsageCounters struct {
+	putRequests       uint64
+	getRequests       uint64
+	removeRequests    uint64
+	errors            uint64
+	transitionedBytes uint64
+	retrievedBytes    uint64
+
+	// bytes transitioned by each lifecycle rule
+	mu    sync.Mutex
+	rules map[string]uint64
+}
+
+// tierUsage is the usage of a remote tier by a bucket.
+type tierUsage struct {
+	Tier   string `json:"tier"`
+	Bucket string `json:"bucket"`
+	// Data of the bucket stored on the tier, as of the last scan.
+	StoredBytes    uint64 `json:"storedBytes"`
+	StoredVersions int    `json:"storedVersions"`
+
+	PutRequests       uint64 `json:"putRequests"`
+	GetRequests       uint64 `json:"getRequests"`
+	RemoveRequests    uint64 `json:"removeRequests"`
+	Errors            uint64 `json:"errors"`
+	TransitionedBytes uint64 `json:"transitionedBytes"`
+	RetrievedBytes    uint64 `json:"retrievedBytes"`
+	// Bytes transitioned by each lifecycle rule ID.
+	RuleTransitionedBytes map[string]uint64 `json:"ruleTransitionedBytes,omitempty"`
+}
+
+func (u *tierUsage) merge(other tierUsage) {
+	u.PutRequests += other.PutRequests
+	u.GetRequests += other.GetRequests
+	u.RemoveRequests += other.RemoveRequests
+	u.Errors += other.Errors
+	u.TransitionedBytes += other.TransitionedBytes
+	u.RetrievedBytes += other.RetrievedBytes
+	for rule, n := range other.RuleTransitionedBytes {
+		if u.RuleTransitionedBytes == nil {
+			u.RuleTransitionedBytes = make(map[string]uint64)
+		}
+		u.RuleTransitionedBytes[rule] += n
+	}
+}
+
+type tierUsageSys struct {
+	mu       sync.RWMutex
+	counters map[tierUsageKey]*tierUsageCounters
+}
+
+func newTierUsageSys() *tierUsageSys {
+	return &tierUsageSys{
+		counters: make(map[tierUsageKey]*tierUsageCounters),
+	}
+}
+
+func (s *tierUsageSys) get(tier, bucket string) *tierUsageCounters {
+	key := tierUsageKey{tier: tier, bucket: bucket}
+	s.mu.RLock()
+	c, ok := s.counters[key]
+	s.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok = s.counters[key]; !ok {
+		c = &tierUsageCounters{rules: make(map[string]uint64)}
+		s.counters[key] = c
+	}
+	return c
+}
+
+// recordPut accounts the transition of size bytes of bucket to tier by
+// the lifecycle rule ruleID.
+func (s *tierUsageSys) recordPut(tier, bucket, ruleID string, size int64, err error) {
+	c := s.get(tier, bucket)
+	atomic.AddUint64(&c.putRequests, 1)
+	if err != nil {
+		atomic.AddUint64(&c.errors, 1)
+		return
+	}
+	atomic.AddUint64(&c.transitionedBytes, uint64(size))
+	c.mu.Lock()
+	c.rules[ruleID] += uint64(size)
+	c.mu.Unlock()
+}
+
+// recordGet accounts a failed read of bucket data from tier.
+func (s *tierUsageSys) recordGet(tier, bucket string) {
+	c := s.get(tier, bucket)
+	atomic.AddUint64(&c.getRequests, 1)
+	atomic.AddUint64(&c.errors, 1)
+}
+
+// countGet accounts a read of bucket data from tier, the bytes read
+// from r are accounted as retrieved.
+func (s *tierUsageSys) countGet(tier, bucket string, r io.ReadCloser) io.ReadCloser {
+	c := s.get(tier, bucket)
+	atomic.AddUint64(&c.getRequests, 1)
+	return &tierUsageReader{ReadCloser: r, counters: c}
+}
+
+// recordRemove accounts the removal of the transitioned object objName
+// from tier.
+func (s *tierUsageSys) recordRemove(tier, objName string) {
+	atomic.AddUint64(&s.get(tier, tierObjectBucket(objName)).removeRequests, 1)
+}
+
+// Usage returns the usage of all tiers by all buckets recorded on
+// this node ordered by tier and bucket.
+func (s *tierUsageSys) Usage() []tierUsage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	usage := make([]tierUsage, 0, len(s.counters))
+	for key, c := range s.counters {
+		u := tierUsage{
+			Tier:              key.tier,
+			Bucket:            key.bucket,
+			PutRequests:       atomic.LoadUint64(&c.putRequests),
+			GetRequests:       atomic.LoadUint64(&c.getRequests),
+			RemoveRequests:    atomic.LoadUint64(&c.removeRequests),
+			Errors:            atomic.LoadUint64(&c.errors),
+			TransitionedBytes: atomic.LoadUint64(&c.transitionedBytes),
+			RetrievedBytes:    atomic.LoadUint64(&c.retrievedBytes),
+		}
+		c.mu.Lock()
+		if len(c.rules) > 0 {
+			u.RuleTransitionedBytes = make(map[string]uint64, len(c.rules))
+			for rule, n := range c.rules {
+				u.RuleTransitionedBytes[rule] = n
+			}
+		}
+		c.mu.Unlock()
+		usage = append(usage, u)
+	}
+	sortTierUsage(usage)
+	return usage
+}
+
+func sortTierUsage(usage []tierUsage) {
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Tier != usage[j].Tier {
+			return usage[i].Tier < usage[j].Tier
+		}
+		return usage[i].Bucket < usage[j].Bucket
+	})
+}
+
+// mergeTierUsage merges the usage reported by all nodes and adds the
+// data stored on each tier per bucket according to the data usage.
+func mergeTierUsage(nodes [][]tierUsage, dui DataUsageInfo) []tierUsage {
+	merged := make(map[tierUsageKey]*tierUsage)
+	lookup := func(tier, bucket string) *tierUsage {
+		key := tierUsageKey{tier: tier, bucket: bucket}
+		u, ok := merged[key]
+		if !ok {
+			u = &tierUsage{Tier: tier, Bucket: bucket}
+			merged[key] = u
+		}
+		return u
+	}
+	for _, usage := range nodes {
+		for _, u := range usage {
+			lookup(u.Tier, u.Bucket).merge(u)
+		}
+	}
+	for bucket, bui := range dui.BucketsUsage {
+		for tier, st := range bui.TierStats {
+			if tier == minioHotTier {
+				continue
+			}
+			u := lookup(tier, bucket)
+			u.StoredBytes = st.TotalSize
+			u.StoredVersions = st.NumVersions
+		}
+	}
+
+	usage := make([]tierUsage, 0, len(merged))
+	for _, u := range merged {
+		usage = append(usage, *u)
+	}
+	sortTierUsage(usage)
+	return usage
+}
+
+// tierObjectBucket returns the bucket of a transitioned object name
+// generated by genTransitionObjName.
+func tierObjectBucket(objName string) string {
+	parts := strings.SplitN(objName, "/", 3)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+type tierUsageReader struct {
+	io.ReadCloser
+	counters *tierUsageCounters
+}
+
+func (r *tierUsageReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	atomic.AddUint64(&r.counters.retrievedBytes, uint64(n))
+	return n, err
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestTierUsage(t *testing.T) {
+	s := newTierUsageSys()
+	s.recordPut("WARM", "bucket", "rule-1", 100, nil)
+	s.recordPut("WARM", "bucket", "rule-2", 50, nil)
+	s.recordPut("WARM", "bucket", "rule-1", 100, errors.New("tier down"))
+	s.recordGet("WARM", "bucket")
+	r := s.countGet("WARM", "bucket", ioutil.NopCloser(bytes.NewReader(make([]byte, 42))))
+	if _, err := io.Copy(ioutil.Discard, r); err != nil {
+		t.Fatal(err)
+	}
+	s.recordRemove("COLD", "deployment-id/other/ab/cd/abcd")
+
+	usage := s.Usage()
+	if len(usage) != 2 {
+		t.Fatalf("expected usage of 2 buckets, got %v", usage)
+	}
+	if usage[0].Tier != "COLD" || usage[0].Bucket != "other" || usage[0].RemoveRequests != 1 {
+		t.Fatalf("unexpected usage %+v", usage[0])
+	}
+	warm := usage[1]
+	if warm.PutRequests != 3 || warm.GetRequests != 2 || warm.Errors != 2 {
+		t.Fatalf("unexpected request counts %+v", warm)
+	}
+	if warm.TransitionedBytes != 150 || warm.RetrievedBytes != 42 {
+		t.Fatalf("unexpected byte counts %+v", warm)
+	}
+	if warm.RuleTransitionedBytes["rule-1"] != 100 || warm.RuleTransitionedBytes["rule-2"] != 50 {
+		t.Fatalf("unexpected rule byte counts %v", warm.RuleTransitionedBytes)
+	}
+
+	dui := DataUsageInfo{
+		BucketsUsage: map[string]BucketUsageInfo{
+			"bucket": {TierStats: map[string]madmin.TierStats{
+				"WARM":       {TotalSize: 1000, NumVersions: 3},
+				minioHotTier: {TotalSize: 10},
+			}},
+			"idle": {TierStats: map[string]madmin.TierStats{
+				"WARM": {TotalSize: 7, NumVersions: 1},
+			}},
+		},
+	}
+	merged := mergeTierUsage([][]tierUsage{usage, {warm}}, dui)
+	if len(merged) != 3 {
+		t.Fatalf("expected usage of 3 buckets, got %v", merged)
+	}
+	if m := merged[1]; m.Bucket != "bucket" || m.PutRequests != 6 || m.StoredBytes != 1000 || m.StoredVersions != 3 || m.RuleTransitionedBytes["rule-1"] != 200 {
+		t.Fatalf("unexpected merged usage %+v", m)
+	}
+	if m := merged[2]; m.Bucket != "idle" || m.StoredBytes != 7 || m.PutRequests != 0 {
+		t.Fatalf("unexpected merged usage %+v", m)
+	}
+}
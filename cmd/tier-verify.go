@@ -0,0 +1,249 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+)
+
+// Transitioned objects sampled by the scanner are verified against the
+// size and checksum recorded when they were transitioned. Objects which
+// are missing or corrupted on their remote tier are reported in the
+// verification status and with s3:ObjectTransition:Corrupted events.
+const (
+	tierVerifyQueueSize = 1000
+	tierVerifyFailures  = 100
+
+	// tierVerifyErrorElement is the response element describing
+	// the failed verification of s3:ObjectTransition:Corrupted events.
+	tierVerifyErrorElement = "x-minio-tier-verify-error"
+)
+
+var (
+	errTierObjectMissing    = errors.New("object is missing on the remote tier")
+	errTierSizeMismatch     = errors.New("object size on the remote tier does not match")
+	errTierChecksumMismatch = errors.New("object checksum on the remote tier does not match")
+	errTierVerifySkipped    = errors.New("remote tier is offline")
+)
+
+var globalTierVerifier = newTierVerifier()
+
+// tierVerifyFailure is a transitioned object version that failed
+// verification.
+type tierVerifyFailure struct {
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+	Tier      string    `json:"tier"`
+	Error     string    `json:"error"`
+	Time      time.Time `json:"time"`
+}
+
+// tierVerifyStatus is the verification status of a node.
+type tierVerifyStatus struct {
+	SampleRate int    `json:"sampleRate"`
+	Verified   uint64 `json:"verified"`
+	Missing    uint64 `json:"missing"`
+	Corrupted  uint64 `json:"corrupted"`
+	// Verifications which failed because the tier was not reachable.
+	Unreachable uint64 `json:"unreachable"`
+	// Sampled objects not verified since the queue was full.
+	Dropped uint64 `json:"dropped"`
+	// The most recent failures, oldest first.
+	Failures []tierVerifyFailure `json:"failures"`
+}
+
+type tierVerifier struct {
+	sampleRate int32
+	queue      chan ObjectInfo
+
+	mu     sync.Mutex
+	status tierVerifyStatus
+}
+
+func newTierVerifier() *tierVerifier {
+	return &tierVerifier{
+		queue: make(chan ObjectInfo, tierVerifyQueueSize),
+	}
+}
+
+func initTierVerifier(ctx context.Context) {
+	go globalTierVerifier.run(ctx)
+}
+
+func (v *tierVerifier) setSampleRate(rate int) {
+	atomic.StoreInt32(&v.sampleRate, int32(rate))
+}
+
+// Sample queues the transitioned object oi for verification according
+// to the configured sample rate.
+func (v *tierVerifier) Sample(oi ObjectInfo) {
+	rate := atomic.LoadInt32(&v.sampleRate)
+	if rate <= 0 || (rate > 1 && rand.Int31n(rate) != 0) {
+		return
+	}
+	select {
+	case v.queue <- oi:
+	default:
+		v.mu.Lock()
+		v.status.Dropped++
+		v.mu.Unlock()
+	}
+}
+
+func (v *tierVerifier) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case oi := <-v.queue:
+			v.record(ctx, oi, verifyTieredObject(ctx, oi))
+		}
+	}
+}
+
+// Status returns a copy of the verification status.
+func (v *tierVerifier) Status() tierVerifyStatus {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	status := v.status
+	status.SampleRate = int(atomic.LoadInt32(&v.sampleRate))
+	status.Failures = append([]tierVerifyFailure{}, v.status.Failures...)
+	return status
+}
+
+// merge adds the counters and failures of other to s. Failures are
+// kept ordered by time and truncated to the most recent ones.
+func (s *tierVerifyStatus) merge(other tierVerifyStatus) {
+	s.Verified += other.Verified
+	s.Missing += other.Missing
+	s.Corrupted += other.Corrupted
+	s.Unreachable += other.Unreachable
+	s.Dropped += other.Dropped
+	s.Failures = append(s.Failures, other.Failures...)
+	sort.SliceStable(s.Failures, func(i, j int) bool {
+		return s.Failures[i].Time.Before(s.Failures[j].Time)
+	})
+	if len(s.Failures) > tierVerifyFailures {
+		s.Failures = s.Failures[len(s.Failures)-tierVerifyFailures:]
+	}
+}
+
+func (v *tierVerifier) record(ctx context.Context, oi ObjectInfo, err error) {
+	if errors.Is(err, errTierVerifySkipped) {
+		return
+	}
+
+	var failed bool
+	v.mu.Lock()
+	switch {
+	case err == nil:
+		v.status.Verified++
+	case errors.Is(err, errTierObjectMissing):
+		v.status.Missing++
+		failed = true
+	case errors.Is(err, errTierSizeMismatch), errors.Is(err, errTierChecksumMismatch):
+		v.status.Corrupted++
+		failed = true
+	default:
+		v.status.Unreachable++
+	}
+	if failed {
+		if len(v.status.Failures) == tierVerifyFailures {
+			v.status.Failures = v.status.Failures[1:]
+		}
+		v.status.Failures = append(v.status.Failures, tierVerifyFailure{
+			Bucket:    oi.Bucket,
+			Object:    oi.Name,
+			VersionID: oi.VersionID,
+			Tier:      oi.TransitionedObject.Tier,
+			Error:     err.Error(),
+			Time:      UTCNow(),
+		})
+	}
+	v.mu.Unlock()
+	if !failed {
+		return
+	}
+
+	logger.LogIf(ctx, fmt.Errorf("Verification of %s/%s(%s) on %s tier failed: %w", oi.Bucket, oi.Name, oi.VersionID, oi.TransitionedObject.Tier, err))
+	sendEvent(eventArgs{
+		EventName:    event.ObjectTransitionCorrupted,
+		BucketName:   oi.Bucket,
+		Object:       oi,
+		RespElements: map[string]string{tierVerifyErrorElement: err.Error()},
+		Host:         "Internal: [ILM-Verify]",
+	})
+}
+
+// verifyTieredObject reads the transitioned content of oi and compares
+// it with the size and the checksum recorded at transition. Objects
+// transitioned before checksums were recorded are only verified by size.
+// Objects on offline tiers are not verified.
+func verifyTieredObject(ctx context.Context, oi ObjectInfo) error {
+	tgtClient, err := globalTierConfigMgr.getDriver(oi.TransitionedObject.Tier)
+	if err != nil {
+		return err
+	}
+	if ob, ok := tgtClient.(offlineWarmBackend); ok && ob.Offline() {
+		return errTierVerifySkipped
+	}
+
+	r, err := tgtClient.Get(ctx, oi.TransitionedObject.Name, remoteVersionID(oi.TransitionedObject.VersionID), WarmBackendGetOpts{encoded: isTierObjectEncoded(oi)})
+	if err != nil {
+		globalTierUsage.recordGet(oi.TransitionedObject.Tier, oi.Bucket)
+		if _, ok := err.(BackendDown); ok {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errTierObjectMissing, err)
+	}
+	r = globalTierUsage.countGet(oi.TransitionedObject.Tier, oi.Bucket, r)
+	defer r.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		// Filesystem tiers verify the checksum while reading.
+		var mismatch hash.SHA256Mismatch
+		if errors.As(err, &mismatch) {
+			return errTierChecksumMismatch
+		}
+		return err
+	}
+	if n != oi.Size {
+		return fmt.Errorf("%w: expected %d bytes, got %d", errTierSizeMismatch, oi.Size, n)
+	}
+	if checksum := oi.UserDefined[ReservedMetadataPrefixLower+TransitionedChecksum]; checksum != "" && checksum != hex.EncodeToString(h.Sum(nil)) {
+		return errTierChecksumMismatch
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+type testVerifyBackend struct {
+	objects map[string][]byte
+}
+
+func (b testVerifyBackend) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	data, err := ioutil.ReadAll(r)
+	b.objects[object] = data
+	return "", err
+}
+
+func (b testVerifyBackend) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	data, ok := b.objects[object]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (b testVerifyBackend) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	delete(b.objects, object)
+	return nil
+}
+
+func (b testVerifyBackend) InUse(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func TestVerifyTieredObject(t *testing.T) {
+	backend := testVerifyBackend{objects: map[string][]byte{
+		"good":    []byte("hello world"),
+		"corrupt": []byte("hello w0rld"),
+		"short":   []byte("hello"),
+	}}
+	oldMgr := globalTierConfigMgr
+	defer func() { globalTierConfigMgr = oldMgr }()
+	globalTierConfigMgr = NewTierConfigMgr()
+	globalTierConfigMgr.drivercache["WARM"] = backend
+
+	sum := sha256.Sum256([]byte("hello world"))
+	checksum := hex.EncodeToString(sum[:])
+	tiered := func(name, checksum string) ObjectInfo {
+		oi := ObjectInfo{
+			Bucket:      "bucket",
+			Name:        "object",
+			Size:        int64(len("hello world")),
+			UserDefined: map[string]string{},
+		}
+		oi.TransitionedObject.Tier = "WARM"
+		oi.TransitionedObject.Name = name
+		if checksum != "" {
+			oi.UserDefined[ReservedMetadataPrefixLower+TransitionedChecksum] = checksum
+		}
+		return oi
+	}
+
+	testCases := []struct {
+		oi       ObjectInfo
+		expected error
+	}{
+		{tiered("good", checksum), nil},
+		{tiered("corrupt", checksum), errTierChecksumMismatch},
+		// Objects transitioned without a checksum are verified by size only.
+		{tiered("corrupt", ""), nil},
+		{tiered("short", checksum), errTierSizeMismatch},
+		{tiered("missing", checksum), errTierObjectMissing},
+	}
+	for i, testCase := range testCases {
+		err := verifyTieredObject(context.Background(), testCase.oi)
+		if !errors.Is(err, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, err)
+		}
+	}
+}
+
+func TestTierVerifierStatus(t *testing.T) {
+	v := newTierVerifier()
+	ctx := context.Background()
+	oi := ObjectInfo{Bucket: "bucket", Name: "object"}
+
+	v.record(ctx, oi, nil)
+	v.record(ctx, oi, errTierVerifySkipped)
+	v.record(ctx, oi, BackendDown{})
+	for i := 0; i < tierVerifyFailures+1; i++ {
+		v.record(ctx, oi, errTierChecksumMismatch)
+	}
+	v.record(ctx, oi, errTierObjectMissing)
+
+	status := v.Status()
+	if status.Verified != 1 || status.Unreachable != 1 || status.Corrupted != tierVerifyFailures+1 || status.Missing != 1 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if len(status.Failures) != tierVerifyFailures {
+		t.Fatalf("expected %d failures, got %d", tierVerifyFailures, len(status.Failures))
+	}
+	if last := status.Failures[len(status.Failures)-1]; last.Error != errTierObjectMissing.Error() {
+		t.Fatalf("expected the most recent failure last, got %v", last)
+	}
+
+	status.merge(tierVerifyStatus{Verified: 2, Failures: []tierVerifyFailure{{Error: "peer"}}})
+	if status.Verified != 3 || len(status.Failures) != tierVerifyFailures {
+		t.Fatalf("unexpected merged status %+v", status)
+	}
+}
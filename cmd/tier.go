@@ -24,7 +24,10 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"path"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -59,6 +62,16 @@ type TierConfigMgr struct {
 	drivercache  map[string]WarmBackend `msg:"-"`
 
 	Tiers map[string]madmin.TierConfig `json:"tiers"`
+	// Tiers on mounted filesystems, which are not supported by
+	// madmin.TierConfig.
+	FSTiers map[string]tierFS `json:"fsTiers,omitempty"`
+	// Tiers on LTFS formatted tapes.
+	TapeTiers map[string]tierTape `json:"tapeTiers,omitempty"`
+	// Temporary credentials of S3 tiers, which are used instead of
+	// their static credentials.
+	STSTiers map[string]tierSTS `json:"stsTiers,omitempty"`
+	// Encodings of object content uploaded to cloud tiers.
+	Encodings map[string]tierEncoding `json:"encodings,omitempty"`
 }
 
 // IsTierValid returns true if there exists a remote tier by name tierName,
@@ -78,6 +91,12 @@ func (config *TierConfigMgr) isTierNameInUse(tierName string) (madmin.TierType,
 	if t, ok := config.Tiers[tierName]; ok {
 		return t.Type, true
 	}
+	if _, ok := config.FSTiers[tierName]; ok {
+		return madmin.Unsupported, true
+	}
+	if _, ok := config.TapeTiers[tierName]; ok {
+		return madmin.Unsupported, true
+	}
 	return madmin.Unsupported, false
 }
 
@@ -97,7 +116,7 @@ func (config *TierConfigMgr) Add(ctx context.Context, tier madmin.TierConfig) er
 		return errTierAlreadyExists
 	}
 
-	d, err := newWarmBackend(ctx, tier)
+	d, err := newWarmBackend(ctx, tier, nil)
 	if err != nil {
 		return err
 	}
@@ -116,9 +135,197 @@ func (config *TierConfigMgr) Add(ctx context.Context, tier madmin.TierConfig) er
 	return nil
 }
 
+// AddFS adds the filesystem tier to config if it passes all validations.
+func (config *TierConfigMgr) AddFS(ctx context.Context, tier tierFS) error {
+	config.Lock()
+	defer config.Unlock()
+
+	tierName := tier.Name
+	if tierName != strings.ToUpper(tierName) {
+		return errTierNameNotUppercase
+	}
+	if _, exists := config.isTierNameInUse(tierName); exists {
+		return errTierAlreadyExists
+	}
+
+	d, err := newWarmBackendFS(tier)
+	if err != nil {
+		return err
+	}
+	inUse, err := d.InUse(ctx)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errTierBackendInUse
+	}
+	if err = checkWarmBackend(ctx, d); err != nil {
+		return err
+	}
+
+	config.FSTiers[tierName] = tier
+	config.drivercache[tierName] = d
+	return nil
+}
+
+// AddTape adds the tape tier to config if it passes all validations.
+func (config *TierConfigMgr) AddTape(ctx context.Context, tier tierTape) error {
+	config.Lock()
+	defer config.Unlock()
+
+	tierName := tier.Name
+	if tierName != strings.ToUpper(tierName) {
+		return errTierNameNotUppercase
+	}
+	if _, exists := config.isTierNameInUse(tierName); exists {
+		return errTierAlreadyExists
+	}
+
+	d, err := newWarmBackendTape(tier)
+	if err != nil {
+		return err
+	}
+	inUse, err := d.InUse(ctx)
+	if err == nil && inUse {
+		err = errTierBackendInUse
+	}
+	if err == nil {
+		err = checkWarmBackend(ctx, d)
+	}
+	if err != nil {
+		d.Close()
+		return err
+	}
+
+	config.TapeTiers[tierName] = tier
+	config.drivercache[tierName] = d
+	return nil
+}
+
+// ListTapeTiers lists the tape tiers with the space usage of their
+// pools as seen from this node.
+func (config *TierConfigMgr) ListTapeTiers() []tierTapeInfo {
+	config.RLock()
+	tiers := make([]tierTape, 0, len(config.TapeTiers))
+	for _, tier := range config.TapeTiers {
+		tiers = append(tiers, tier)
+	}
+	config.RUnlock()
+
+	infos := make([]tierTapeInfo, 0, len(tiers))
+	for _, tier := range tiers {
+		d, err := config.getDriver(tier.Name)
+		if err != nil {
+			infos = append(infos, tierTapeInfo{tierTape: tier})
+			continue
+		}
+		if t, ok := d.(*warmBackendTape); ok {
+			infos = append(infos, t.info(tier))
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// ListFSTiers lists the filesystem tiers with their space usage as
+// seen from this node.
+func (config *TierConfigMgr) ListFSTiers() []tierFSInfo {
+	config.RLock()
+	tiers := make([]tierFS, 0, len(config.FSTiers))
+	for _, tier := range config.FSTiers {
+		tiers = append(tiers, tier)
+	}
+	config.RUnlock()
+
+	infos := make([]tierFSInfo, 0, len(tiers))
+	for _, tier := range tiers {
+		info := tierFSInfo{tierFS: tier}
+		d, err := config.getDriver(tier.Name)
+		if err == nil {
+			if fs, ok := d.(*warmBackendFS); ok {
+				info.Used, info.Free, err = fs.Usage()
+			}
+		}
+		if err != nil {
+			info.Error = err.Error()
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// Set adds tier to config, replacing an existing tier by the same name.
+// Unlike Add, it does not check if the warm backend is in use, since it
+// applies tiers replicated from peer sites which share the backend.
+func (config *TierConfigMgr) Set(ctx context.Context, tier madmin.TierConfig) error {
+	config.Lock()
+	defer config.Unlock()
+
+	tierName := tier.Name
+	if tierName != strings.ToUpper(tierName) {
+		return errTierNameNotUppercase
+	}
+
+	d, err := newWarmBackend(ctx, tier, nil)
+	if err != nil {
+		return err
+	}
+	config.Tiers[tierName] = tier
+	delete(config.STSTiers, tierName)
+	config.drivercache[tierName] = d
+	return nil
+}
+
+// getTier returns the configuration of the remote tier including its
+// credentials. Unlike ListTiers, the result must not be returned to
+// clients.
+func (config *TierConfigMgr) getTier(tierName string) (madmin.TierConfig, bool) {
+	config.RLock()
+	defer config.RUnlock()
+	tier, ok := config.Tiers[tierName]
+	return tier, ok
+}
+
+// getTiers returns the configuration of all remote tiers including
+// their credentials.
+func (config *TierConfigMgr) getTiers() []madmin.TierConfig {
+	config.RLock()
+	defer config.RUnlock()
+	tiers := make([]madmin.TierConfig, 0, len(config.Tiers))
+	for _, tier := range config.Tiers {
+		tiers = append(tiers, tier)
+	}
+	return tiers
+}
+
+// tierNames returns the names of all tiers, remote, filesystem and tape.
+func (config *TierConfigMgr) tierNames() []string {
+	config.RLock()
+	defer config.RUnlock()
+	names := make([]string, 0, len(config.Tiers)+len(config.FSTiers)+len(config.TapeTiers))
+	for name := range config.Tiers {
+		names = append(names, name)
+	}
+	for name := range config.FSTiers {
+		names = append(names, name)
+	}
+	for name := range config.TapeTiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Empty returns if tier targets are empty
 func (config *TierConfigMgr) Empty() bool {
-	return len(config.ListTiers()) == 0
+	config.RLock()
+	defer config.RUnlock()
+	return len(config.Tiers) == 0 && len(config.FSTiers) == 0 && len(config.TapeTiers) == 0
 }
 
 // ListTiers lists remote tiers configured in this deployment.
@@ -147,6 +354,13 @@ func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds ma
 		return errTierNotFound
 	}
 
+	if _, ok := config.FSTiers[tierName]; ok {
+		return errTierNotEditable
+	}
+	if _, ok := config.TapeTiers[tierName]; ok {
+		return errTierNotEditable
+	}
+
 	cfg := config.Tiers[tierName]
 	switch tierType {
 	case madmin.S3:
@@ -173,11 +387,12 @@ func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds ma
 		cfg.GCS.Creds = base64.URLEncoding.EncodeToString(creds.CredsJSON)
 	}
 
-	d, err := newWarmBackend(ctx, cfg)
+	d, err := newWarmBackend(ctx, cfg, nil)
 	if err != nil {
 		return err
 	}
 	config.Tiers[tierName] = cfg
+	delete(config.STSTiers, tierName)
 	config.drivercache[tierName] = d
 	return nil
 }
@@ -205,20 +420,41 @@ func (config *TierConfigMgr) getDriver(tierName string) (d WarmBackend, err erro
 	// Lookup in-memory drivercache
 	d, ok = config.drivercache[tierName]
 	if ok {
+		if _, ok = config.Tiers[tierName]; ok {
+			d = newTierEncodedBackend(d, config.Encodings[tierName])
+		}
 		return d, nil
 	}
 
 	// Initialize driver from tier config matching tierName
+	if t, ok := config.FSTiers[tierName]; ok {
+		if d, err = newWarmBackendFS(t); err != nil {
+			return nil, err
+		}
+		config.drivercache[tierName] = d
+		return d, nil
+	}
+	if t, ok := config.TapeTiers[tierName]; ok {
+		if d, err = newWarmBackendTape(t); err != nil {
+			return nil, err
+		}
+		config.drivercache[tierName] = d
+		return d, nil
+	}
 	t, ok := config.Tiers[tierName]
 	if !ok {
 		return nil, errTierNotFound
 	}
-	d, err = newWarmBackend(context.TODO(), t)
+	var sts *tierSTS
+	if c, ok := config.STSTiers[tierName]; ok {
+		sts = &c
+	}
+	d, err = newWarmBackend(context.TODO(), t, sts)
 	if err != nil {
 		return nil, err
 	}
 	config.drivercache[tierName] = d
-	return d, nil
+	return newTierEncodedBackend(d, config.Encodings[tierName]), nil
 }
 
 // configReader returns a PutObjReader and ObjectOptions needed to save config
@@ -247,7 +483,7 @@ func (config *TierConfigMgr) configReader() (*PutObjReader, *ObjectOptions, erro
 
 	// Encrypt json encoded tier configurations
 	metadata := make(map[string]string)
-	encBr, oek, err := newEncryptReader(hr, crypto.S3, "", nil, minioMetaBucket, tierConfigPath, metadata, kms.Context{})
+	encBr, oek, err := newEncryptReader(GlobalContext, hr, crypto.S3, "", nil, minioMetaBucket, tierConfigPath, metadata, kms.Context{})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -287,18 +523,50 @@ func (config *TierConfigMgr) Reload(ctx context.Context, objAPI ObjectLayer) err
 
 	config.Lock()
 	defer config.Unlock()
-	// Reset drivercache built using current config
-	for k := range config.drivercache {
+	// Reset drivercache built using current config. Tape tiers keep
+	// their drivers while unchanged, so that queued operations on the
+	// tapes are not interrupted.
+	for k, d := range config.drivercache {
+		if t, ok := config.TapeTiers[k]; ok && reflect.DeepEqual(t, newConfig.TapeTiers[k]) {
+			continue
+		}
+		if c, ok := d.(io.Closer); ok {
+			c.Close()
+		}
 		delete(config.drivercache, k)
 	}
 	// Remove existing tier configs
 	for k := range config.Tiers {
 		delete(config.Tiers, k)
 	}
+	for k := range config.FSTiers {
+		delete(config.FSTiers, k)
+	}
+	for k := range config.TapeTiers {
+		delete(config.TapeTiers, k)
+	}
+	for k := range config.STSTiers {
+		delete(config.STSTiers, k)
+	}
+	for k := range config.Encodings {
+		delete(config.Encodings, k)
+	}
 	// Copy over the new tier configs
 	for tier, cfg := range newConfig.Tiers {
 		config.Tiers[tier] = cfg
 	}
+	for tier, cfg := range newConfig.FSTiers {
+		config.FSTiers[tier] = cfg
+	}
+	for tier, cfg := range newConfig.TapeTiers {
+		config.TapeTiers[tier] = cfg
+	}
+	for tier, cfg := range newConfig.STSTiers {
+		config.STSTiers[tier] = cfg
+	}
+	for tier, enc := range newConfig.Encodings {
+		config.Encodings[tier] = enc
+	}
 
 	return nil
 }
@@ -323,6 +591,10 @@ func NewTierConfigMgr() *TierConfigMgr {
 	return &TierConfigMgr{
 		drivercache: make(map[string]WarmBackend),
 		Tiers:       make(map[string]madmin.TierConfig),
+		FSTiers:     make(map[string]tierFS),
+		TapeTiers:   make(map[string]tierTape),
+		STSTiers:    make(map[string]tierSTS),
+		Encodings:   make(map[string]tierEncoding),
 	}
 }
 
@@ -55,6 +55,126 @@ func (z *TierConfigMgr) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.Tiers[za0001] = za0002
 			}
+		case "FSTiers":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "FSTiers")
+				return
+			}
+			if z.FSTiers == nil {
+				z.FSTiers = make(map[string]tierFS, zb0003)
+			} else if len(z.FSTiers) > 0 {
+				for key := range z.FSTiers {
+					delete(z.FSTiers, key)
+				}
+			}
+			for zb0003 > 0 {
+				zb0003--
+				var za0003 string
+				var za0004 tierFS
+				za0003, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "FSTiers")
+					return
+				}
+				err = za0004.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "FSTiers", za0003)
+					return
+				}
+				z.FSTiers[za0003] = za0004
+			}
+		case "TapeTiers":
+			var zb0004 uint32
+			zb0004, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "TapeTiers")
+				return
+			}
+			if z.TapeTiers == nil {
+				z.TapeTiers = make(map[string]tierTape, zb0004)
+			} else if len(z.TapeTiers) > 0 {
+				for key := range z.TapeTiers {
+					delete(z.TapeTiers, key)
+				}
+			}
+			for zb0004 > 0 {
+				zb0004--
+				var za0005 string
+				var za0006 tierTape
+				za0005, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "TapeTiers")
+					return
+				}
+				err = za0006.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "TapeTiers", za0005)
+					return
+				}
+				z.TapeTiers[za0005] = za0006
+			}
+		case "STSTiers":
+			var zb0005 uint32
+			zb0005, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "STSTiers")
+				return
+			}
+			if z.STSTiers == nil {
+				z.STSTiers = make(map[string]tierSTS, zb0005)
+			} else if len(z.STSTiers) > 0 {
+				for key := range z.STSTiers {
+					delete(z.STSTiers, key)
+				}
+			}
+			for zb0005 > 0 {
+				zb0005--
+				var za0007 string
+				var za0008 tierSTS
+				za0007, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "STSTiers")
+					return
+				}
+				err = za0008.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "STSTiers", za0007)
+					return
+				}
+				z.STSTiers[za0007] = za0008
+			}
+		case "Encodings":
+			var zb0006 uint32
+			zb0006, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Encodings")
+				return
+			}
+			if z.Encodings == nil {
+				z.Encodings = make(map[string]tierEncoding, zb0006)
+			} else if len(z.Encodings) > 0 {
+				for key := range z.Encodings {
+					delete(z.Encodings, key)
+				}
+			}
+			for zb0006 > 0 {
+				zb0006--
+				var za0009 string
+				var za0010 tierEncoding
+				za0009, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Encodings")
+					return
+				}
+				err = za0010.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Encodings", za0009)
+					return
+				}
+				z.Encodings[za0009] = za0010
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -68,9 +188,9 @@ func (z *TierConfigMgr) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *TierConfigMgr) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+	// map header, size 5
 	// write "Tiers"
-	err = en.Append(0x81, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
+	err = en.Append(0x85, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
 	if err != nil {
 		return
 	}
@@ -91,15 +211,103 @@ func (z *TierConfigMgr) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "FSTiers"
+	err = en.Append(0xa7, 0x46, 0x53, 0x54, 0x69, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.FSTiers)))
+	if err != nil {
+		err = msgp.WrapError(err, "FSTiers")
+		return
+	}
+	for za0003, za0004 := range z.FSTiers {
+		err = en.WriteString(za0003)
+		if err != nil {
+			err = msgp.WrapError(err, "FSTiers")
+			return
+		}
+		err = za0004.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "FSTiers", za0003)
+			return
+		}
+	}
+	// write "TapeTiers"
+	err = en.Append(0xa9, 0x54, 0x61, 0x70, 0x65, 0x54, 0x69, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.TapeTiers)))
+	if err != nil {
+		err = msgp.WrapError(err, "TapeTiers")
+		return
+	}
+	for za0005, za0006 := range z.TapeTiers {
+		err = en.WriteString(za0005)
+		if err != nil {
+			err = msgp.WrapError(err, "TapeTiers")
+			return
+		}
+		err = za0006.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "TapeTiers", za0005)
+			return
+		}
+	}
+	// write "STSTiers"
+	err = en.Append(0xa8, 0x53, 0x54, 0x53, 0x54, 0x69, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.STSTiers)))
+	if err != nil {
+		err = msgp.WrapError(err, "STSTiers")
+		return
+	}
+	for za0007, za0008 := range z.STSTiers {
+		err = en.WriteString(za0007)
+		if err != nil {
+			err = msgp.WrapError(err, "STSTiers")
+			return
+		}
+		err = za0008.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "STSTiers", za0007)
+			return
+		}
+	}
+	// write "Encodings"
+	err = en.Append(0xa9, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Encodings)))
+	if err != nil {
+		err = msgp.WrapError(err, "Encodings")
+		return
+	}
+	for za0009, za0010 := range z.Encodings {
+		err = en.WriteString(za0009)
+		if err != nil {
+			err = msgp.WrapError(err, "Encodings")
+			return
+		}
+		err = za0010.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Encodings", za0009)
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *TierConfigMgr) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 5
 	// string "Tiers"
-	o = append(o, 0x81, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
+	o = append(o, 0x85, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Tiers)))
 	for za0001, za0002 := range z.Tiers {
 		o = msgp.AppendString(o, za0001)
@@ -109,6 +317,50 @@ func (z *TierConfigMgr) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "FSTiers"
+	o = append(o, 0xa7, 0x46, 0x53, 0x54, 0x69, 0x65, 0x72, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.FSTiers)))
+	for za0003, za0004 := range z.FSTiers {
+		o = msgp.AppendString(o, za0003)
+		o, err = za0004.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "FSTiers", za0003)
+			return
+		}
+	}
+	// string "TapeTiers"
+	o = append(o, 0xa9, 0x54, 0x61, 0x70, 0x65, 0x54, 0x69, 0x65, 0x72, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.TapeTiers)))
+	for za0005, za0006 := range z.TapeTiers {
+		o = msgp.AppendString(o, za0005)
+		o, err = za0006.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "TapeTiers", za0005)
+			return
+		}
+	}
+	// string "STSTiers"
+	o = append(o, 0xa8, 0x53, 0x54, 0x53, 0x54, 0x69, 0x65, 0x72, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.STSTiers)))
+	for za0007, za0008 := range z.STSTiers {
+		o = msgp.AppendString(o, za0007)
+		o, err = za0008.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "STSTiers", za0007)
+			return
+		}
+	}
+	// string "Encodings"
+	o = append(o, 0xa9, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Encodings)))
+	for za0009, za0010 := range z.Encodings {
+		o = msgp.AppendString(o, za0009)
+		o, err = za0010.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Encodings", za0009)
+			return
+		}
+	}
 	return
 }
 
@@ -160,6 +412,126 @@ func (z *TierConfigMgr) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Tiers[za0001] = za0002
 			}
+		case "FSTiers":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FSTiers")
+				return
+			}
+			if z.FSTiers == nil {
+				z.FSTiers = make(map[string]tierFS, zb0003)
+			} else if len(z.FSTiers) > 0 {
+				for key := range z.FSTiers {
+					delete(z.FSTiers, key)
+				}
+			}
+			for zb0003 > 0 {
+				var za0003 string
+				var za0004 tierFS
+				zb0003--
+				za0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "FSTiers")
+					return
+				}
+				bts, err = za0004.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "FSTiers", za0003)
+					return
+				}
+				z.FSTiers[za0003] = za0004
+			}
+		case "TapeTiers":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TapeTiers")
+				return
+			}
+			if z.TapeTiers == nil {
+				z.TapeTiers = make(map[string]tierTape, zb0004)
+			} else if len(z.TapeTiers) > 0 {
+				for key := range z.TapeTiers {
+					delete(z.TapeTiers, key)
+				}
+			}
+			for zb0004 > 0 {
+				var za0005 string
+				var za0006 tierTape
+				zb0004--
+				za0005, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "TapeTiers")
+					return
+				}
+				bts, err = za0006.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "TapeTiers", za0005)
+					return
+				}
+				z.TapeTiers[za0005] = za0006
+			}
+		case "STSTiers":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "STSTiers")
+				return
+			}
+			if z.STSTiers == nil {
+				z.STSTiers = make(map[string]tierSTS, zb0005)
+			} else if len(z.STSTiers) > 0 {
+				for key := range z.STSTiers {
+					delete(z.STSTiers, key)
+				}
+			}
+			for zb0005 > 0 {
+				var za0007 string
+				var za0008 tierSTS
+				zb0005--
+				za0007, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "STSTiers")
+					return
+				}
+				bts, err = za0008.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "STSTiers", za0007)
+					return
+				}
+				z.STSTiers[za0007] = za0008
+			}
+		case "Encodings":
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Encodings")
+				return
+			}
+			if z.Encodings == nil {
+				z.Encodings = make(map[string]tierEncoding, zb0006)
+			} else if len(z.Encodings) > 0 {
+				for key := range z.Encodings {
+					delete(z.Encodings, key)
+				}
+			}
+			for zb0006 > 0 {
+				var za0009 string
+				var za0010 tierEncoding
+				zb0006--
+				za0009, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Encodings")
+					return
+				}
+				bts, err = za0010.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Encodings", za0009)
+					return
+				}
+				z.Encodings[za0009] = za0010
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -181,5 +553,33 @@ func (z *TierConfigMgr) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0001) + za0002.Msgsize()
 		}
 	}
+	s += 8 + msgp.MapHeaderSize
+	if z.FSTiers != nil {
+		for za0003, za0004 := range z.FSTiers {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003) + za0004.Msgsize()
+		}
+	}
+	s += 10 + msgp.MapHeaderSize
+	if z.TapeTiers != nil {
+		for za0005, za0006 := range z.TapeTiers {
+			_ = za0006
+			s += msgp.StringPrefixSize + len(za0005) + za0006.Msgsize()
+		}
+	}
+	s += 9 + msgp.MapHeaderSize
+	if z.STSTiers != nil {
+		for za0007, za0008 := range z.STSTiers {
+			_ = za0008
+			s += msgp.StringPrefixSize + len(za0007) + za0008.Msgsize()
+		}
+	}
+	s += 10 + msgp.MapHeaderSize
+	if z.Encodings != nil {
+		for za0009, za0010 := range z.Encodings {
+			_ = za0010
+			s += msgp.StringPrefixSize + len(za0009) + za0010.Msgsize()
+		}
+	}
 	return
 }
@@ -0,0 +1,311 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/certs"
+)
+
+// certsReloadStatus - the outcome of reloading the TLS certificates
+// of a node.
+type certsReloadStatus struct {
+	Node string `json:"node"`
+	// Subject and expiry of the public certificate in use.
+	Subject  string    `json:"subject,omitempty"`
+	NotAfter time.Time `json:"notAfter,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// reloadableTransport is a transport to peers whose TLS configuration
+// is replaced when the root CAs are reloaded. Connections established
+// before a reload keep being used until they are idle.
+type reloadableTransport struct {
+	newTransport func(rootCAs *x509.CertPool) http.RoundTripper
+	transport    atomic.Value
+}
+
+func newReloadableTransport(rootCAs *x509.CertPool, newTransport func(rootCAs *x509.CertPool) http.RoundTripper) *reloadableTransport {
+	t := &reloadableTransport{newTransport: newTransport}
+	t.transport.Store(newTransport(rootCAs))
+	return t
+}
+
+// RoundTrip - implements http.RoundTripper.
+func (t *reloadableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.Load().(http.RoundTripper).RoundTrip(req)
+}
+
+func (t *reloadableTransport) reload(rootCAs *x509.CertPool) {
+	old := t.transport.Swap(t.newTransport(rootCAs))
+	if tr, ok := old.(interface{ CloseIdleConnections() }); ok {
+		tr.CloseIdleConnections()
+	}
+}
+
+// certsReloader serves the TLS certificates of the server listener and
+// verifies peers with the root CAs. Both are loaded again as a whole,
+// and only replaced if all of them are valid, when the certs directory
+// changes, on SIGHUP or on request of the admin API.
+type certsReloader struct {
+	// Serializes reloads.
+	mu          sync.Mutex
+	cancel      context.CancelFunc
+	fingerprint string
+	transports  []*reloadableTransport
+
+	// Certificates originally loaded on startup, which are used by
+	// the console and reload their files on their own.
+	startupCerts *certs.Manager
+
+	manager     atomic.Value // *certs.Manager
+	publicCerts atomic.Value // []*x509.Certificate
+	rootCAs     atomic.Value // *x509.CertPool
+}
+
+func newCertsReloader(manager *certs.Manager, publicCerts []*x509.Certificate, rootCAs *x509.CertPool) *certsReloader {
+	r := &certsReloader{
+		startupCerts: manager,
+		fingerprint:  certsDirFingerprint(globalCertsDir.Get()),
+	}
+	r.manager.Store(manager)
+	r.publicCerts.Store(publicCerts)
+	r.rootCAs.Store(rootCAs)
+	return r
+}
+
+// GetCertificate - returns the current TLS certificate for the client hello.
+func (r *certsReloader) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.manager.Load().(*certs.Manager).GetCertificate(hello)
+}
+
+// PublicCerts - returns the public certificates currently in use.
+func (r *certsReloader) PublicCerts() []*x509.Certificate {
+	return r.publicCerts.Load().([]*x509.Certificate)
+}
+
+// RootCAs - returns the root CAs peers are currently verified with.
+func (r *certsReloader) RootCAs() *x509.CertPool {
+	return r.rootCAs.Load().(*x509.CertPool)
+}
+
+// addTransport registers a transport to peers, which is updated with
+// the root CAs on every reload.
+func (r *certsReloader) addTransport(t *reloadableTransport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transports = append(r.transports, t)
+}
+
+// Reload - loads the certificates and root CAs from the certs
+// directory and replaces the current ones if they are all valid.
+func (r *certsReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reload()
+}
+
+func (r *certsReloader) reload() error {
+	if r.startupCerts == nil {
+		return errTLSNotConfigured
+	}
+	// Files which fail to load are not loaded again until they change.
+	r.fingerprint = certsDirFingerprint(globalCertsDir.Get())
+
+	ctx, cancel := context.WithCancel(GlobalContext)
+	publicCerts, manager, err := loadTLSCerts(ctx, func(err error) error { return err })
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	rootCAs, err := loadCAs()
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	// Peers verify this node with the CAs, the certificate must be
+	// issued by one of them for them to accept it. Self-signed
+	// certificates are trusted as they are, as on startup.
+	if !isSelfSignedCert(publicCerts[0]) {
+		opts := x509.VerifyOptions{
+			Roots:         rootCAs,
+			Intermediates: x509.NewCertPool(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}
+		for _, cert := range publicCerts[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		if _, err = publicCerts[0].Verify(opts); err != nil {
+			cancel()
+			return fmt.Errorf("Unable to verify the public certificate with the root CAs: %w", err)
+		}
+	}
+	for _, publicCrt := range publicCerts {
+		rootCAs.AddCert(publicCrt)
+	}
+
+	r.manager.Store(manager)
+	r.publicCerts.Store(publicCerts)
+	r.rootCAs.Store(rootCAs)
+	for _, t := range r.transports {
+		t.reload(rootCAs)
+	}
+	// Certificates loaded on startup are only watched by themselves.
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.cancel = cancel
+	r.startupCerts.ReloadCerts()
+	return nil
+}
+
+// Status - returns the public certificate in use, and err if it is
+// not nil.
+func (r *certsReloader) Status(err error) certsReloadStatus {
+	status := certsReloadStatus{Node: globalLocalNodeName}
+	if publicCerts := r.PublicCerts(); len(publicCerts) > 0 {
+		status.Subject = publicCerts[0].Subject.String()
+		status.NotAfter = publicCerts[0].NotAfter
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// watch reloads the certificates on SIGHUP and whenever the files of
+// the certs directory change, which is checked every interval unless
+// it is 0.
+func (r *certsReloader) watch(ctx context.Context, interval time.Duration) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	var tickCh <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+		case <-tickCh:
+			r.mu.Lock()
+			changed := certsDirFingerprint(globalCertsDir.Get()) != r.fingerprint
+			r.mu.Unlock()
+			if !changed {
+				continue
+			}
+		}
+		if err := r.Reload(); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to reload the TLS certificates, the current ones are kept: %w", err), logger.Minio)
+		} else {
+			logger.Info("Reloaded the TLS certificates")
+		}
+	}
+}
+
+// loadRootCAs loads the root CAs peers are verified with, the CAs of
+// the certs directory, the internode CA if enabled and the public
+// certificates of this node.
+func loadRootCAs(publicCerts []*x509.Certificate) (*x509.CertPool, error) {
+	rootCAs, err := loadCAs()
+	if err != nil {
+		return nil, err
+	}
+	for _, publicCrt := range publicCerts {
+		rootCAs.AddCert(publicCrt)
+	}
+	return rootCAs, nil
+}
+
+// loadCAs loads the CAs of the certs directory and the internode CA if
+// enabled.
+func loadCAs() (*x509.CertPool, error) {
+	rootCAs, err := certs.GetRootCAs(globalCertsCADir.Get())
+	if err != nil {
+		return nil, err
+	}
+	if globalInternodeCA != nil {
+		rootCAs.AddCert(globalInternodeCA.cert)
+	}
+	return rootCAs, nil
+}
+
+// isSelfSignedCert returns whether cert is signed by its own key.
+func isSelfSignedCert(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// certsDirFingerprint returns the names, sizes and modification times
+// of the files in dir and in its sub-directories, following symbolic
+// links, so that files replaced by updating a link are detected.
+func certsDirFingerprint(dir string) string {
+	var entries []string
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, file := range files {
+			if strings.HasPrefix(file.Name(), "..") {
+				continue
+			}
+			name := filepath.Join(dir, file.Name())
+			fi, err := os.Stat(name)
+			if err != nil {
+				continue
+			}
+			if fi.IsDir() {
+				if depth > 0 {
+					walk(name, depth-1)
+				}
+				continue
+			}
+			entries = append(entries, fmt.Sprintf("%s:%d:%d", name, fi.Size(), fi.ModTime().UnixNano()))
+		}
+	}
+	walk(dir, 1)
+	sort.Strings(entries)
+	return strings.Join(entries, "\n")
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCertsReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(certsDir, caDir *ConfigDir) {
+		globalCertsDir, globalCertsCADir = certsDir, caDir
+	}(globalCertsDir, globalCertsCADir)
+	globalCertsDir = &ConfigDir{path: dir}
+	globalCertsCADir = &ConfigDir{path: filepath.Join(dir, certsCADir)}
+
+	writeCerts := func(cert, key []byte) {
+		if err := ioutil.WriteFile(getPublicCertFile(), cert, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(getPrivateKeyFile(), key, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cert, key, err := generateTLSCertKey("localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeCerts(cert, key)
+
+	publicCerts, manager, _, err := getTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCAs, err := loadRootCAs(publicCerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newCertsReloader(manager, publicCerts, rootCAs)
+
+	var reloaded []*x509.CertPool
+	transport := newReloadableTransport(rootCAs, func(rootCAs *x509.CertPool) http.RoundTripper {
+		reloaded = append(reloaded, rootCAs)
+		return &http.Transport{}
+	})
+	r.addTransport(transport)
+
+	serial := func() string {
+		c, err := r.GetCertificate(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		x509Cert, err := x509.ParseCertificate(c.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return x509Cert.SerialNumber.String()
+	}
+	before := serial()
+
+	// Make sure the modification time of the files changes.
+	time.Sleep(10 * time.Millisecond)
+	cert, key, err = generateTLSCertKey("localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeCerts(cert, key)
+	if certsDirFingerprint(dir) == r.fingerprint {
+		t.Fatal("expected the certs directory to be changed")
+	}
+	if err = r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	after := serial()
+	if after == before {
+		t.Error("expected the new certificate to be served")
+	}
+	if r.PublicCerts()[0].SerialNumber.String() != after {
+		t.Error("expected the new public certificate")
+	}
+	// The transport is created once on startup and once on reload.
+	if len(reloaded) != 2 || reloaded[1] != r.RootCAs() {
+		t.Error("expected the transport to use the reloaded root CAs")
+	}
+
+	// Invalid certificates are rejected and the current ones kept.
+	writeCerts([]byte("invalid"), key)
+	if err = r.Reload(); err == nil {
+		t.Fatal("expected reloading an invalid certificate to fail")
+	}
+	if serial() != after {
+		t.Error("expected the current certificate to be kept")
+	}
+	if len(reloaded) != 2 {
+		t.Error("expected the transport not to be reloaded")
+	}
+
+	if err = newCertsReloader(nil, nil, rootCAs).Reload(); err != errTLSNotConfigured {
+		t.Errorf("expected %v, got %v", errTLSNotConfigured, err)
+	}
+}
+
+// generateCASignedCertKey returns a CA certificate and a certificate
+// for localhost issued by the CA with its private key, PEM encoded.
+func generateCASignedCertKey(t *testing.T) (caCert, cert, key []byte) {
+	newCert := func(serial int64, template, parent *x509.Certificate, pub, priv interface{}) []byte {
+		template.SerialNumber = big.NewInt(serial)
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(time.Hour)
+		der, err := x509.CreateCertificate(crand.Reader, template, parent, pub, priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caCert = newCert(1, ca, ca, &caKey.PublicKey, caKey)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert = newCert(2, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, &priv.PublicKey, caKey)
+	der, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	return caCert, cert, key
+}
+
+func TestCertsReloaderUnknownCA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(certsDir, caDir *ConfigDir) {
+		globalCertsDir, globalCertsCADir = certsDir, caDir
+	}(globalCertsDir, globalCertsCADir)
+	globalCertsDir = &ConfigDir{path: dir}
+	globalCertsCADir = &ConfigDir{path: filepath.Join(dir, certsCADir)}
+
+	cert, key, err := generateTLSCertKey("localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(getPublicCertFile(), cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(getPrivateKeyFile(), key, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	publicCerts, manager, _, err := getTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCAs, err := loadRootCAs(publicCerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newCertsReloader(manager, publicCerts, rootCAs)
+
+	caCert, cert, key := generateCASignedCertKey(t)
+	if err = ioutil.WriteFile(getPublicCertFile(), cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(getPrivateKeyFile(), key, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Peers do not know the CA issuing the certificate.
+	if err = r.Reload(); err == nil {
+		t.Fatal("expected a certificate of an unknown CA to be rejected")
+	}
+
+	if err = os.MkdirAll(globalCertsCADir.Get(), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(globalCertsCADir.Get(), "ca.crt"), caCert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/pkg/wildcard"
+)
+
+// Query parameters of the server side trace filters, the same names
+// are used by the admin API and the peer REST API.
+const (
+	traceFilterAPI    = "api"
+	traceFilterBucket = "bucket"
+	traceFilterStatus = "status"
+	traceFilterSample = "sample"
+)
+
+// traceOpts - trace options requested by the client, the filters in
+// addition to madmin.ServiceTraceOpts are applied on every node before
+// an entry is sent, so that only matching entries reach the client.
+type traceOpts struct {
+	madmin.ServiceTraceOpts
+
+	// APIs is the list of API names to trace, either the full name
+	// such as 's3.PutObject' or only the name without the prefix.
+	APIs []string
+	// Buckets is the list of buckets to trace, wildcards are allowed.
+	Buckets []string
+	// Statuses is the list of HTTP response codes or response classes
+	// such as '5xx' to trace.
+	Statuses []string
+	// SampleRate is the fraction of matching entries in (0, 1] to send,
+	// zero sends all of them.
+	SampleRate float64
+}
+
+var errInvalidTraceFilter = errors.New("invalid trace filter")
+
+func splitTraceFilter(s string) (values []string) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseTraceFilters - parses the trace filters from the query values.
+func (o *traceOpts) parseTraceFilters(q url.Values) error {
+	o.APIs = splitTraceFilter(q.Get(traceFilterAPI))
+	o.Buckets = splitTraceFilter(q.Get(traceFilterBucket))
+	o.Statuses = splitTraceFilter(q.Get(traceFilterStatus))
+	for _, status := range o.Statuses {
+		if !validTraceStatus(status) {
+			return errInvalidTraceFilter
+		}
+	}
+	if s := q.Get(traceFilterSample); s != "" {
+		rate, err := strconv.ParseFloat(s, 64)
+		if err != nil || rate <= 0 || rate > 1 {
+			return errInvalidTraceFilter
+		}
+		o.SampleRate = rate
+	}
+	return nil
+}
+
+// encodeTraceFilters - adds the trace filters to the query values.
+func (o traceOpts) encodeTraceFilters(values url.Values) {
+	set := func(key string, vs []string) {
+		if len(vs) > 0 {
+			values.Set(key, strings.Join(vs, ","))
+		}
+	}
+	set(traceFilterAPI, o.APIs)
+	set(traceFilterBucket, o.Buckets)
+	set(traceFilterStatus, o.Statuses)
+	if o.SampleRate > 0 {
+		values.Set(traceFilterSample, strconv.FormatFloat(o.SampleRate, 'f', -1, 64))
+	}
+}
+
+// validTraceStatus - returns true for a response code such as '503'
+// or a response class such as '5xx'.
+func validTraceStatus(status string) bool {
+	if len(status) != 3 || status[0] < '1' || status[0] > '5' {
+		return false
+	}
+	if strings.EqualFold(status[1:], "xx") {
+		return true
+	}
+	_, err := strconv.Atoi(status)
+	return err == nil
+}
+
+func (o traceOpts) matchAPI(funcName string) bool {
+	if len(o.APIs) == 0 {
+		return true
+	}
+	name := funcName
+	if i := strings.Index(funcName, "."); i >= 0 {
+		name = funcName[i+1:]
+	}
+	for _, api := range o.APIs {
+		if strings.EqualFold(api, funcName) || strings.EqualFold(api, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o traceOpts) matchStatus(code int) bool {
+	if len(o.Statuses) == 0 {
+		return true
+	}
+	s := strconv.Itoa(code)
+	for _, status := range o.Statuses {
+		if status == s || (strings.EqualFold(status[1:], "xx") && status[0] == s[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// traceBucket - returns the bucket of the trace entry, if any.
+func traceBucket(trcInfo madmin.TraceInfo) string {
+	switch trcInfo.TraceType {
+	case madmin.TraceHTTP:
+		resource, err := getResource(trcInfo.ReqInfo.Path, trcInfo.ReqInfo.Headers.Get("Host"), globalDomainNames)
+		if err != nil {
+			return ""
+		}
+		bucket, _ := path2BucketObject(resource)
+		return bucket
+	case madmin.TraceStorage:
+		// The first path of storage calls is the volume.
+		volume := strings.SplitN(trcInfo.StorageStats.Path, " ", 2)[0]
+		bucket, _ := path2BucketObject(volume)
+		return bucket
+	}
+	return ""
+}
+
+func (o traceOpts) matchBucket(trcInfo madmin.TraceInfo) bool {
+	if len(o.Buckets) == 0 {
+		return true
+	}
+	bucket := traceBucket(trcInfo)
+	if bucket == "" {
+		return false
+	}
+	for _, pattern := range o.Buckets {
+		if wildcard.Match(pattern, bucket) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFilters - returns true if the trace entry passes the error,
+// API, bucket and status filters and is selected by sampling.
+func (o traceOpts) matchFilters(trcInfo madmin.TraceInfo) bool {
+	if o.OnlyErrors && trcInfo.RespInfo.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	if len(o.Statuses) > 0 && (trcInfo.TraceType != madmin.TraceHTTP || !o.matchStatus(trcInfo.RespInfo.StatusCode)) {
+		return false
+	}
+	if !o.matchAPI(trcInfo.FuncName) || !o.matchBucket(trcInfo) {
+		return false
+	}
+	return o.SampleRate <= 0 || o.SampleRate >= 1 || rand.Float64() < o.SampleRate
+}
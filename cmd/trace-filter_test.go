@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestTraceFilters(t *testing.T) {
+	httpTrace := func(funcName, path string, status int, latency time.Duration) madmin.TraceInfo {
+		return madmin.TraceInfo{
+			TraceType: madmin.TraceHTTP,
+			FuncName:  funcName,
+			ReqInfo:   madmin.TraceRequestInfo{Path: path, Headers: http.Header{}},
+			RespInfo:  madmin.TraceResponseInfo{StatusCode: status},
+			CallStats: madmin.TraceCallStats{Latency: latency},
+		}
+	}
+	storageTrace := madmin.TraceInfo{
+		TraceType:    madmin.TraceStorage,
+		FuncName:     "storage.ReadVersion",
+		StorageStats: madmin.TraceStorageStats{Path: "photos 2021/a.jpg"},
+	}
+
+	testCases := []struct {
+		query url.Values
+		entry madmin.TraceInfo
+		trace bool
+	}{
+		{url.Values{"s3": {"true"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, 0), true},
+		{url.Values{"s3": {"true"}, "api": {"GetObject,s3.ListObjectsV2"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, 0), false},
+		{url.Values{"s3": {"true"}, "api": {"GetObject,s3.ListObjectsV2"}}, httpTrace("s3.GetObject", "/photos/a.jpg", 200, 0), true},
+		{url.Values{"s3": {"true"}, "api": {"getobject,s3.ListObjectsV2"}}, httpTrace("s3.ListObjectsV2", "/photos", 200, 0), true},
+		{url.Values{"s3": {"true"}, "bucket": {"photo*"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, 0), true},
+		{url.Values{"s3": {"true"}, "bucket": {"videos"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, 0), false},
+		{url.Values{"s3": {"true"}, "bucket": {"photos"}}, httpTrace("s3.ListBuckets", "/", 200, 0), false},
+		{url.Values{"storage": {"true"}, "bucket": {"photos"}}, storageTrace, true},
+		{url.Values{"storage": {"true"}, "bucket": {"videos"}}, storageTrace, false},
+		{url.Values{"s3": {"true"}, "status": {"5xx"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 503, 0), true},
+		{url.Values{"s3": {"true"}, "status": {"5xx,404"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 404, 0), true},
+		{url.Values{"s3": {"true"}, "status": {"5xx"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 403, 0), false},
+		{url.Values{"storage": {"true"}, "status": {"5xx"}}, storageTrace, false},
+		{url.Values{"s3": {"true"}, "err": {"true"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, 0), false},
+		{url.Values{"s3": {"true"}, "threshold": {"100ms"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, 50*time.Millisecond), false},
+		{url.Values{"s3": {"true"}, "threshold": {"100ms"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, time.Second), true},
+		{url.Values{"s3": {"true"}, "sample": {"1"}}, httpTrace("s3.PutObject", "/photos/a.jpg", 200, 0), true},
+	}
+
+	for i, testCase := range testCases {
+		r := &http.Request{Form: testCase.query}
+		opts, err := extractTraceOptions(r)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if trace := mustTrace(testCase.entry, opts); trace != testCase.trace {
+			t.Errorf("Test %d: expected trace %v, got %v", i+1, testCase.trace, trace)
+		}
+
+		// Peers must apply the same filters.
+		values := make(url.Values)
+		opts.encodeTraceFilters(values)
+		peerOpts := traceOpts{ServiceTraceOpts: opts.ServiceTraceOpts}
+		if err = peerOpts.parseTraceFilters(values); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if trace := mustTrace(testCase.entry, peerOpts); trace != testCase.trace {
+			t.Errorf("Test %d: expected peer trace %v, got %v", i+1, testCase.trace, trace)
+		}
+	}
+
+	for _, query := range []url.Values{
+		{"sample": {"0"}},
+		{"sample": {"1.5"}},
+		{"sample": {"half"}},
+		{"status": {"6xx"}},
+		{"status": {"50"}},
+	} {
+		if _, err := extractTraceOptions(&http.Request{Form: query}); err == nil {
+			t.Errorf("expected error for %v", query)
+		}
+	}
+}
+
+func TestTraceSampling(t *testing.T) {
+	opts := traceOpts{ServiceTraceOpts: madmin.ServiceTraceOpts{S3: true}, SampleRate: 0.1}
+	entry := madmin.TraceInfo{TraceType: madmin.TraceHTTP, FuncName: "s3.GetObject", ReqInfo: madmin.TraceRequestInfo{Path: "/photos/a.jpg"}}
+
+	var traced int
+	for i := 0; i < 10000; i++ {
+		if mustTrace(entry, opts) {
+			traced++
+		}
+	}
+	if traced < 500 || traced > 1500 {
+		t.Errorf("expected about 1000 sampled entries, got %d", traced)
+	}
+}
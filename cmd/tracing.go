@@ -0,0 +1,205 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/minio/internal/config/tracing"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the server.
+var tracer = otel.Tracer("github.com/minio/minio/cmd")
+
+// tracingSys exports the spans of the server to an OTLP endpoint.
+// The tracer provider is installed once, the exporter is replaced
+// whenever the configuration changes.
+type tracingSys struct {
+	once      sync.Once
+	mu        sync.Mutex
+	provider  *sdktrace.TracerProvider
+	processor sdktrace.SpanProcessor
+	sampler   atomic.Value // tracingSampler
+}
+
+// tracingSampler wraps the sampler of the current configuration,
+// atomic.Value requires a consistent concrete type.
+type tracingSampler struct {
+	sdktrace.Sampler
+}
+
+var globalTracing = &tracingSys{}
+
+func (t *tracingSys) init() {
+	t.sampler.Store(tracingSampler{sdktrace.NeverSample()})
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceNameKey.String("minio"),
+		semconv.ServiceVersionKey.String(Version),
+		semconv.ServiceInstanceIDKey.String(globalLocalNodeName),
+	)
+	t.provider = sdktrace.NewTracerProvider(sdktrace.WithSampler(t), sdktrace.WithResource(res))
+	otel.SetTracerProvider(t.provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// ShouldSample - implements sdktrace.Sampler, no spans are recorded
+// while tracing is disabled.
+func (t *tracingSys) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return t.sampler.Load().(tracingSampler).ShouldSample(p)
+}
+
+// Description - implements sdktrace.Sampler.
+func (t *tracingSys) Description() string {
+	return t.sampler.Load().(tracingSampler).Description()
+}
+
+// Update replaces the exporter of the spans with the one of cfg.
+// Spans queued for the previous exporter are flushed in the background.
+func (t *tracingSys) Update(ctx context.Context, cfg tracing.Config) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.once.Do(t.init)
+
+	var processor sdktrace.SpanProcessor
+	if cfg.Enabled() {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.Endpoint.Host),
+			otlptracehttp.WithHeaders(cfg.Headers),
+		}
+		if p := cfg.Endpoint.Path; p != "" && p != SlashSeparator {
+			opts = append(opts, otlptracehttp.WithURLPath(p))
+		}
+		if cfg.Endpoint.Scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(&tls.Config{RootCAs: globalRootCAs}))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		processor = sdktrace.NewBatchSpanProcessor(exporter)
+	}
+
+	if t.processor != nil {
+		t.provider.UnregisterSpanProcessor(t.processor)
+		go func(p sdktrace.SpanProcessor) {
+			logger.LogIf(GlobalContext, p.Shutdown(context.Background()))
+		}(t.processor)
+	}
+	t.processor = processor
+	if processor == nil {
+		t.sampler.Store(tracingSampler{sdktrace.NeverSample()})
+		return nil
+	}
+	t.provider.RegisterSpanProcessor(processor)
+	t.sampler.Store(tracingSampler{sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))})
+	return nil
+}
+
+// Shutdown exports all queued spans.
+func (t *tracingSys) Shutdown(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.provider == nil {
+		return nil
+	}
+	return t.provider.Shutdown(ctx)
+}
+
+// startSpan starts a child span of the request traced by ctx, untraced
+// background operations get a no-op span.
+func startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return tracer.Start(ctx, name, opts...)
+}
+
+// internodeSpanName returns the name of the span of an internode
+// request, e.g. "storage.readall" for a storage REST call.
+func internodeSpanName(urlPath string) string {
+	service := strings.TrimPrefix(urlPath, minioReservedBucketPath+SlashSeparator)
+	if i := strings.Index(service, SlashSeparator); i >= 0 {
+		service = service[:i]
+	}
+	return service + "." + path.Base(urlPath)
+}
+
+// setTracingHandler starts a server span for every request, continuing
+// the trace of the caller given by the `traceparent` header. Internode
+// requests are only traced as part of the trace of a client request.
+func setTracingHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if guessIsHealthCheckReq(r) || guessIsMetricsReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		name := "s3"
+		if guessIsRPCReq(r) && !isAdminReq(r) {
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				h.ServeHTTP(w, r)
+				return
+			}
+			name = internodeSpanName(r.URL.Path)
+		} else if strings.HasPrefix(r.URL.Path, minioReservedBucketPath+SlashSeparator) {
+			name = r.URL.Path
+		}
+		ctx, span := tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(globalLocalNodeName, "", r)...),
+		)
+		defer span.End()
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// traceAPIResult names the span of the request after the S3 API and
+// records the response status.
+func traceAPIResult(ctx context.Context, api string, w *logger.ResponseWriter) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetName("s3." + api)
+	span.SetAttributes(
+		semconv.HTTPStatusCodeKey.Int(w.StatusCode),
+		attribute.String("s3.request_id", w.Header().Get(xhttp.AmzRequestID)),
+	)
+	if w.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(w.StatusCode))
+	}
+}
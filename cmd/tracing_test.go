@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/internal/config/tracing"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInternodeSpanName(t *testing.T) {
+	testCases := []struct {
+		path, expected string
+	}{
+		{"/minio/storage/data/disk1/v42/readall", "storage.readall"},
+		{"/minio/peer/v25/serverinfo", "peer.serverinfo"},
+		{"/minio/lock/v4/lock", "lock.lock"},
+	}
+	for i, testCase := range testCases {
+		if got := internodeSpanName(testCase.path); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestTracingHandler(t *testing.T) {
+	if err := globalTracing.Update(GlobalContext, tracing.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	globalTracing.provider.RegisterSpanProcessor(recorder)
+	globalTracing.sampler.Store(tracingSampler{sdktrace.AlwaysSample()})
+	defer func() {
+		globalTracing.provider.UnregisterSpanProcessor(recorder)
+		globalTracing.sampler.Store(tracingSampler{sdktrace.NeverSample()})
+	}()
+
+	var spanCtx trace.SpanContext
+	handler := setTracingHandler(collectAPIStats("getobject", func(w http.ResponseWriter, r *http.Request) {
+		spanCtx = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if spanCtx.TraceID().String() != traceID {
+		t.Fatalf("expected the trace %s of the caller to continue, got %s", traceID, spanCtx.TraceID())
+	}
+
+	// Internode requests without a caller are not traced.
+	req = httptest.NewRequest(http.MethodPost, "/minio/storage/data/v42/readall", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if spanCtx.IsValid() {
+		t.Fatal("expected an untraced internode request not to start a trace")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "s3.getobject" {
+		t.Errorf("expected span s3.getobject, got %s", spans[0].Name())
+	}
+	if spans[0].Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("expected the span of the caller as parent, got %s", spans[0].Parent().SpanID())
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("expected an error status for a 500 response, got %s", spans[0].Status().Code)
+	}
+}
@@ -78,6 +78,10 @@ var errNoSuchGroup = errors.New("Specified group does not exist")
 // deleted.
 var errGroupNotEmpty = errors.New("Specified group is not empty - cannot remove it")
 
+// error returned in IAM subsystem when nesting a group would make it a
+// member of itself.
+var errGroupNestingCycle = errors.New("Specified group nesting would create a cycle")
+
 // error returned in IAM subsystem when policy doesn't exist.
 var errNoSuchPolicy = errors.New("Specified canned policy does not exist")
 
@@ -102,3 +106,9 @@ var errIAMNotInitialized = errors.New("IAM sub-system is being initialized, plea
 
 // error returned when upload id not found
 var errUploadIDNotFound = errors.New("Specified Upload ID is not found")
+
+// error returned when no API request with the ID is in progress
+var errNoSuchInflightRequest = errors.New("Specified request is not in progress")
+
+// error returned when certificates are reloaded on a server without TLS
+var errTLSNotConfigured = errors.New("Server is not configured with TLS, certificates are only loaded on startup")
@@ -35,6 +35,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/minio/minio/internal/fips"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/env"
@@ -416,10 +417,10 @@ func getUpdateTransport(timeout time.Duration) http.RoundTripper {
 		IdleConnTimeout:       timeout,
 		TLSHandshakeTimeout:   timeout,
 		ExpectContinueTimeout: timeout,
-		TLSClientConfig: &tls.Config{
+		TLSClientConfig: fips.RestrictTLS(&tls.Config{
 			RootCAs:            globalRootCAs,
 			ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
-		},
+		}),
 		DisableCompression: true,
 	}
 	return updateTransport
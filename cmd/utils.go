@@ -616,10 +616,10 @@ func NewGatewayHTTPTransport() *http.Transport {
 }
 
 func newGatewayHTTPTransport(timeout time.Duration) *http.Transport {
-	tr := newCustomHTTPTransport(&tls.Config{
+	tr := newCustomHTTPTransport(fips.RestrictTLS(&tls.Config{
 		RootCAs:            globalRootCAs,
 		ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
-	}, defaultDialTimeout)()
+	}), defaultDialTimeout)()
 
 	// Customize response header timeout for gateway transport.
 	tr.ResponseHeaderTimeout = timeout
@@ -643,10 +643,10 @@ func NewRemoteTargetHTTPTransport() *http.Transport {
 		IdleConnTimeout:       15 * time.Second,
 		TLSHandshakeTimeout:   5 * time.Second,
 		ExpectContinueTimeout: 5 * time.Second,
-		TLSClientConfig: &tls.Config{
+		TLSClientConfig: fips.RestrictTLS(&tls.Config{
 			RootCAs:            globalRootCAs,
 			ClientSessionCache: tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
-		},
+		}),
 		// Go net/http automatically unzip if content-type is
 		// gzip disable this feature, as we are always interested
 		// in raw stream.
@@ -1145,15 +1145,16 @@ func newTLSConfig(getCert certs.GetCertificateFunc) *tls.Config {
 	}
 
 	tlsClientIdentity := env.Get(xtls.EnvIdentityTLSEnabled, "") == config.EnableOn
-	if tlsClientIdentity {
+	if tlsClientIdentity || globalInternodeCA != nil {
 		tlsConfig.ClientAuth = tls.RequestClientCert
 	}
 
 	secureCiphers := env.Get(api.EnvAPISecureCiphers, config.EnableOn) == config.EnableOn
-	if secureCiphers || fips.Enabled {
+	if secureCiphers || fips.Required() {
 		// Hardened ciphers
 		tlsConfig.CipherSuites = fips.CipherSuitesTLS()
 		tlsConfig.CurvePreferences = fips.EllipticCurvesTLS()
+		tlsConfig.MaxVersion = fips.MaxVersionTLS()
 	} else {
 		// Default ciphers while excluding those with security issues
 		for _, cipher := range tls.CipherSuites() {
@@ -59,6 +59,10 @@ func (az *warmBackendAzure) tier() azblob.AccessTierType {
 // this. Currently it's a no-op.
 
 func (az *warmBackendAzure) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	return az.PutWithPartSize(ctx, object, r, length, 0)
+}
+
+func (az *warmBackendAzure) PutWithPartSize(ctx context.Context, object string, r io.Reader, length, partSize int64) (remoteVersionID, error) {
 	blobURL := az.serviceURL.NewContainerURL(az.Bucket).NewBlockBlobURL(az.getDest(object))
 	// set tier if specified -
 	if az.StorageClass != "" {
@@ -66,7 +70,9 @@ func (az *warmBackendAzure) Put(ctx context.Context, object string, r io.Reader,
 			return "", azureToObjectError(err, az.Bucket, object)
 		}
 	}
-	res, err := azblob.UploadStreamToBlockBlob(ctx, r, blobURL, azblob.UploadStreamToBlockBlobOptions{})
+	res, err := azblob.UploadStreamToBlockBlob(ctx, r, blobURL, azblob.UploadStreamToBlockBlobOptions{
+		BufferSize: int(partSize),
+	})
 	if err != nil {
 		return "", azureToObjectError(err, az.Bucket, object)
 	}
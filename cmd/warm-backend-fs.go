@@ -0,0 +1,370 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio/internal/disk"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/lock"
+	"github.com/minio/minio/internal/logger"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore warmBackendFS tierFSReader tierFSInfo
+
+const (
+	// Directory below the tier root holding partially written objects.
+	tierFSTmpDir = ".minio.tmp"
+	// File below the tier root holding the bytes used by the tier. It
+	// is shared by all nodes and only accessed while locked.
+	tierFSUsageFile = ".minio.usage"
+)
+
+var (
+	errTierFSPathInvalid   = errors.New("filesystem tier path must be an absolute path to an existing directory")
+	errTierFSQuotaExceeded = errors.New("filesystem tier quota exceeded")
+)
+
+// tierFS configures a remote tier on a filesystem that is mounted at
+// the same path on all nodes, for example an NFS export of a NAS.
+type tierFS struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Prefix string `json:"prefix,omitempty"`
+	// Bytes the tier may use, 0 to use all free space of the
+	// filesystem.
+	Quota uint64 `json:"quota,omitempty"`
+}
+
+// Validate - validates the filesystem tier configuration.
+func (t tierFS) Validate() error {
+	if !filepath.IsAbs(t.Path) {
+		return errTierFSPathInvalid
+	}
+	fi, err := os.Stat(t.Path)
+	if err != nil || !fi.IsDir() {
+		return errTierFSPathInvalid
+	}
+	if prefix := filepath.Clean(filepath.FromSlash(t.Prefix)); filepath.IsAbs(prefix) || prefix == ".." || strings.HasPrefix(prefix, ".."+string(os.PathSeparator)) {
+		return errTierFSPathInvalid
+	}
+	return nil
+}
+
+// warmBackendFS stores transitioned objects as files below the tier
+// root, keeping the directory layout of the transitioned object names.
+// The remote version ID of an object is the hex encoded SHA-256 of its
+// content, which is verified when the object is read in full.
+type warmBackendFS struct {
+	root  string
+	quota uint64
+	// Closed once the usage is known, the usage file is created by
+	// scanning the tier root if it does not exist yet.
+	scanned chan struct{}
+}
+
+func newWarmBackendFS(conf tierFS) (*warmBackendFS, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	d := &warmBackendFS{
+		root:    filepath.Join(conf.Path, filepath.FromSlash(conf.Prefix)),
+		quota:   conf.Quota,
+		scanned: make(chan struct{}),
+	}
+	if err := os.MkdirAll(filepath.Join(d.root, tierFSTmpDir), 0o755); err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(d.scanned)
+		logger.LogIf(GlobalContext, d.updateUsage(func(used int64, ok bool) (int64, error) {
+			if ok {
+				return used, nil
+			}
+			return d.scanUsage()
+		}))
+	}()
+	return d, nil
+}
+
+// scanUsage returns the size of all objects below the tier root.
+func (fs *warmBackendFS) scanUsage() (used int64, err error) {
+	tmpDir := filepath.Join(fs.root, tierFSTmpDir)
+	usageFile := filepath.Join(fs.root, tierFSUsageFile)
+	err = filepath.Walk(fs.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path == tmpDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path != usageFile {
+			used += info.Size()
+		}
+		return nil
+	})
+	return used, err
+}
+
+// updateUsage replaces the used bytes recorded in the usage file by
+// the result of fn while holding the file locked, such that all nodes
+// see the same usage. ok is false if no usage was recorded yet.
+func (fs *warmBackendFS) updateUsage(fn func(used int64, ok bool) (int64, error)) error {
+	f, err := lock.LockedOpenFile(filepath.Join(fs.root, tierFSUsageFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	used, ok, err := readTierFSUsage(f)
+	if err != nil {
+		return err
+	}
+	if used, err = fn(used, ok); err != nil {
+		return err
+	}
+	if used < 0 {
+		used = 0
+	}
+	if err = f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.WriteAt([]byte(strconv.FormatInt(used, 10)), 0)
+	return err
+}
+
+// addUsage adds delta to the used bytes.
+func (fs *warmBackendFS) addUsage(delta int64) error {
+	return fs.updateUsage(func(used int64, _ bool) (int64, error) {
+		return used + delta, nil
+	})
+}
+
+func readTierFSUsage(r io.Reader) (used int64, ok bool, err error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil || len(b) == 0 {
+		return 0, false, err
+	}
+	used, err = strconv.ParseInt(string(b), 10, 64)
+	return used, err == nil, err
+}
+
+// Usage returns the bytes stored in the tier and the bytes available to
+// it, limited by the free space of the filesystem and the quota.
+func (fs *warmBackendFS) Usage() (used, free uint64, err error) {
+	f, err := lock.RLockedOpenFile(filepath.Join(fs.root, tierFSUsageFile))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, 0, err
+	}
+	if err == nil {
+		n, _, err := readTierFSUsage(f)
+		f.Close()
+		if err != nil {
+			return 0, 0, err
+		}
+		used = uint64(n)
+	}
+	info, err := disk.GetInfo(fs.root)
+	if err != nil {
+		return used, 0, err
+	}
+	free = info.Free
+	if fs.quota > 0 {
+		if used >= fs.quota {
+			return used, 0, nil
+		}
+		if fs.quota-used < free {
+			free = fs.quota - used
+		}
+	}
+	return used, free, nil
+}
+
+func (fs *warmBackendFS) getDest(object string) (string, error) {
+	dest := filepath.Join(fs.root, filepath.FromSlash(object))
+	if !strings.HasPrefix(dest, fs.root+string(os.PathSeparator)) {
+		return "", errFileAccessDenied
+	}
+	return dest, nil
+}
+
+func (fs *warmBackendFS) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	dest, err := fs.getDest(object)
+	if err != nil {
+		return "", err
+	}
+	info, err := disk.GetInfo(fs.root)
+	if err != nil {
+		return "", err
+	}
+	if uint64(length) > info.Free {
+		return "", errDiskFull
+	}
+	// Reserve the space of the object so that concurrent puts, on
+	// any node, cannot exceed the quota together.
+	if err = fs.updateUsage(func(used int64, _ bool) (int64, error) {
+		if fs.quota > 0 && uint64(used+length) > fs.quota {
+			return used, errTierFSQuotaExceeded
+		}
+		return used + length, nil
+	}); err != nil {
+		return "", err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			logger.LogIf(ctx, fs.addUsage(-length))
+		}
+	}()
+
+	tmp := filepath.Join(fs.root, tierFSTmpDir, uuid.New().String())
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp)
+
+	h := sha256.New()
+	n, err := io.Copy(f, io.TeeReader(r, h))
+	if err == nil && n != length {
+		err = IncompleteBody{}
+	}
+	if err == nil {
+		err = disk.Fdatasync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Replacing an existing object releases its space.
+	var prev int64
+	if fi, err := os.Stat(dest); err == nil {
+		prev = fi.Size()
+	}
+	if err = renameAll(tmp, dest); err != nil {
+		return "", err
+	}
+	committed = true
+	if prev > 0 {
+		logger.LogIf(ctx, fs.addUsage(-prev))
+	}
+	return remoteVersionID(hex.EncodeToString(h.Sum(nil))), nil
+}
+
+// tierFSReader closes the file of a verifying reader.
+type tierFSReader struct {
+	io.Reader
+	io.Closer
+}
+
+func (fs *warmBackendFS) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	dest, err := fs.getDest(object)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(dest)
+	if err != nil {
+		return nil, osErrToFileErr(err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if opts.startOffset > 0 || (opts.length > 0 && opts.length < fi.Size()) {
+		length := opts.length
+		if length <= 0 {
+			length = fi.Size() - opts.startOffset
+		}
+		return tierFSReader{io.NewSectionReader(f, opts.startOffset, length), f}, nil
+	}
+
+	// Full reads are verified against the checksum recorded on put.
+	hr, err := hash.NewReader(f, fi.Size(), "", string(rv), fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return tierFSReader{hr, f}, nil
+}
+
+func (fs *warmBackendFS) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	dest, err := fs.getDest(object)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(dest)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err = os.Remove(dest); err != nil {
+		return err
+	}
+	logger.LogIf(ctx, fs.addUsage(-fi.Size()))
+
+	// Remove parent directories left empty, up to the tier root.
+	for dir := filepath.Dir(dest); dir != fs.root && strings.HasPrefix(dir, fs.root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (fs *warmBackendFS) InUse(ctx context.Context) (bool, error) {
+	entries, err := readDirN(fs.root, 3)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if name := strings.TrimSuffix(entry, SlashSeparator); name != tierFSTmpDir && name != tierFSUsageFile {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// tierFSInfo is a filesystem tier with its space usage.
+type tierFSInfo struct {
+	tierFS
+	Used  uint64 `json:"used"`
+	Free  uint64 `json:"free"`
+	Error string `json:"error,omitempty"`
+}
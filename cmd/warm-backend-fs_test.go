@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWarmBackendFS(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	d, err := newWarmBackendFS(tierFS{Name: "NAS", Path: dir, Prefix: "tier"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-d.scanned
+	if err = checkWarmBackend(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || inUse {
+		t.Fatalf("expected an unused tier, got %v, %v", inUse, err)
+	}
+
+	data := []byte("transitioned object content")
+	rv, err := d.Put(ctx, "deployment/bucket/ab/cd/object", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || !inUse {
+		t.Fatalf("expected a used tier, got %v, %v", inUse, err)
+	}
+	if used, _, err := d.Usage(); err != nil || used != uint64(len(data)) {
+		t.Fatalf("expected %d bytes used, got %d, %v", len(data), used, err)
+	}
+
+	r, err := d.Get(ctx, "deployment/bucket/ab/cd/object", rv, WarmBackendGetOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("unexpected content %q, %v", got, err)
+	}
+
+	r, err = d.Get(ctx, "deployment/bucket/ab/cd/object", rv, WarmBackendGetOpts{startOffset: 5, length: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || string(got) != "itione" {
+		t.Fatalf("unexpected range %q, %v", got, err)
+	}
+
+	// Corrupted content must be detected on full reads.
+	path := filepath.Join(dir, "tier", "deployment", "bucket", "ab", "cd", "object")
+	if err = ioutil.WriteFile(path, []byte("transitioned object CONTENT"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err = d.Get(ctx, "deployment/bucket/ab/cd/object", rv, WarmBackendGetOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ioutil.ReadAll(r); err == nil {
+		t.Fatal("expected a checksum mismatch")
+	}
+	r.Close()
+
+	if err = d.Remove(ctx, "deployment/bucket/ab/cd/object", rv); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "tier", "deployment")); !os.IsNotExist(err) {
+		t.Fatalf("expected empty directories to be removed, got %v", err)
+	}
+	if used, _, _ := d.Usage(); used != 0 {
+		t.Fatalf("expected no bytes used, got %d", used)
+	}
+
+	if _, err = d.Get(ctx, "../outside", "", WarmBackendGetOpts{}); err != errFileAccessDenied {
+		t.Fatalf("expected %v, got %v", errFileAccessDenied, err)
+	}
+}
+
+func TestWarmBackendFSQuota(t *testing.T) {
+	d, err := newWarmBackendFS(tierFS{Name: "NAS", Path: t.TempDir(), Quota: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("more than ten bytes")
+	if _, err = d.Put(context.Background(), "object", bytes.NewReader(data), int64(len(data))); err != errTierFSQuotaExceeded {
+		t.Fatalf("expected %v, got %v", errTierFSQuotaExceeded, err)
+	}
+}
+
+func TestWarmBackendFSSharedUsage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	// Backends of two nodes mounting the same filesystem.
+	d1, err := newWarmBackendFS(tierFS{Name: "NAS", Path: dir, Quota: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-d1.scanned
+	d2, err := newWarmBackendFS(tierFS{Name: "NAS", Path: dir, Quota: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-d2.scanned
+
+	data := []byte("sixsix")
+	rv, err := d1.Put(ctx, "object1", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used, free, err := d2.Usage(); err != nil || used != 6 || free != 4 {
+		t.Fatalf("expected 6 bytes used and 4 free, got %d, %d, %v", used, free, err)
+	}
+	if _, err = d2.Put(ctx, "object2", bytes.NewReader(data), int64(len(data))); err != errTierFSQuotaExceeded {
+		t.Fatalf("expected %v, got %v", errTierFSQuotaExceeded, err)
+	}
+	if used, _, _ := d1.Usage(); used != 6 {
+		t.Fatalf("expected the failed put to release its space, got %d bytes used", used)
+	}
+
+	if err = d2.Remove(ctx, "object1", rv); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = d2.Put(ctx, "object2", bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new node reuses the recorded usage instead of scanning.
+	d3, err := newWarmBackendFS(tierFS{Name: "NAS", Path: dir, Quota: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-d3.scanned
+	if used, _, _ := d3.Usage(); used != 6 {
+		t.Fatalf("expected 6 bytes used, got %d", used)
+	}
+}
+
+func TestTierFSValidate(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		tier    tierFS
+		success bool
+	}{
+		{tierFS{Path: dir}, true},
+		{tierFS{Path: dir, Prefix: "cold/data"}, true},
+		{tierFS{Path: "relative"}, false},
+		{tierFS{Path: filepath.Join(dir, "missing")}, false},
+		{tierFS{Path: dir, Prefix: "../escape"}, false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.tier.Validate()
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
@@ -50,12 +50,19 @@ func (gcs *warmBackendGCS) getDest(object string) string {
 // Currently it's a no-op.
 
 func (gcs *warmBackendGCS) Put(ctx context.Context, key string, data io.Reader, length int64) (remoteVersionID, error) {
+	return gcs.PutWithPartSize(ctx, key, data, length, 0)
+}
+
+func (gcs *warmBackendGCS) PutWithPartSize(ctx context.Context, key string, data io.Reader, length, partSize int64) (remoteVersionID, error) {
 	object := gcs.client.Bucket(gcs.Bucket).Object(gcs.getDest(key))
 	// TODO: set storage class
 	w := object.NewWriter(ctx)
 	if gcs.StorageClass != "" {
 		w.ObjectAttrs.StorageClass = gcs.StorageClass
 	}
+	if partSize > 0 {
+		w.ChunkSize = int(partSize)
+	}
 	if _, err := xioutil.Copy(w, data); err != nil {
 		return "", gcsToObjectError(err, gcs.Bucket, key)
 	}
@@ -57,7 +57,14 @@ func (s3 *warmBackendS3) getDest(object string) string {
 }
 
 func (s3 *warmBackendS3) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
-	res, err := s3.client.PutObject(ctx, s3.Bucket, s3.getDest(object), r, length, minio.PutObjectOptions{StorageClass: s3.StorageClass})
+	return s3.PutWithPartSize(ctx, object, r, length, 0)
+}
+
+func (s3 *warmBackendS3) PutWithPartSize(ctx context.Context, object string, r io.Reader, length, partSize int64) (remoteVersionID, error) {
+	res, err := s3.client.PutObject(ctx, s3.Bucket, s3.getDest(object), r, length, minio.PutObjectOptions{
+		StorageClass: s3.StorageClass,
+		PartSize:     uint64(partSize),
+	})
 	return remoteVersionID(res.VersionID), s3.ToObjectError(err, object)
 }
 
@@ -98,15 +105,20 @@ func (s3 *warmBackendS3) InUse(ctx context.Context) (bool, error) {
 	return len(result.CommonPrefixes) > 0 || len(result.Contents) > 0, nil
 }
 
-func newWarmBackendS3(conf madmin.TierS3) (*warmBackendS3, error) {
+func newWarmBackendS3(conf madmin.TierS3, sts *tierSTS) (*warmBackendS3, error) {
 	u, err := url.Parse(conf.Endpoint)
 	if err != nil {
 		return nil, err
 	}
 	var creds *credentials.Credentials
-	if conf.AWSRole {
+	switch {
+	case sts != nil:
+		if creds, err = sts.credentials(u); err != nil {
+			return nil, err
+		}
+	case conf.AWSRole:
 		creds = credentials.NewChainCredentials(defaultAWSCredProvider)
-	} else {
+	default:
 		creds = credentials.NewStaticV4(conf.AccessKey, conf.SecretKey, "")
 	}
 	getRemoteTargetInstanceTransportOnce.Do(func() {
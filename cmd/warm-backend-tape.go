@@ -0,0 +1,417 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore warmBackendTape tapePool tapeOp tapeReader tapeVersionID tierTapeInfo tierTapePoolInfo
+
+const defaultTapeBatchSize = 64
+
+var (
+	errTierTapeNoPools     = errors.New("tape tier needs at least one pool")
+	errTierTapeVersionID   = errors.New("invalid tape tier remote version id")
+	errTierTapePoolsFull   = errors.New("all pools of the tape tier are full")
+	errTierTapeBackendDone = errors.New("tape tier backend is closed")
+)
+
+// tierTape configures a remote tier on LTFS formatted tapes. Every pool
+// is the LTFS mount point of a tape or tape pool, mounted at the same
+// path on all nodes. Tape tiers are offline, objects transitioned to
+// them must be restored before they can be read.
+type tierTape struct {
+	Name   string   `json:"name"`
+	Pools  []string `json:"pools"`
+	Prefix string   `json:"prefix,omitempty"`
+	// Maximum number of writes served before queued restores of the
+	// pool, defaults to 64.
+	BatchSize int `json:"batchSize,omitempty"`
+	// Seconds it takes to mount and position a tape. Restores of an
+	// idle pool are collected for this long, so that a single mount
+	// serves all of them.
+	MountLatency int `json:"mountLatency,omitempty"`
+}
+
+// Validate - validates the tape tier configuration.
+func (t tierTape) Validate() error {
+	if len(t.Pools) == 0 {
+		return errTierTapeNoPools
+	}
+	for _, pool := range t.Pools {
+		if err := (tierFS{Path: pool, Prefix: t.Prefix}).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t tierTape) batchSize() int {
+	if t.BatchSize > 0 {
+		return t.BatchSize
+	}
+	return defaultTapeBatchSize
+}
+
+// tapeVersionID is the catalog entry of an object on tape, kept as the
+// remote version ID in the metadata of the transitioned object on the
+// hot tier.
+type tapeVersionID struct {
+	Pool int
+	// Unix time in nanoseconds the object was written, which orders
+	// objects by their position on the tape.
+	Written int64
+	Sum     string
+}
+
+func (v tapeVersionID) String() string {
+	return fmt.Sprintf("%d:%d:%s", v.Pool, v.Written, v.Sum)
+}
+
+func parseTapeVersionID(rv remoteVersionID) (v tapeVersionID, err error) {
+	s := strings.SplitN(string(rv), ":", 3)
+	if len(s) != 3 {
+		return v, errTierTapeVersionID
+	}
+	if v.Pool, err = strconv.Atoi(s[0]); err != nil {
+		return v, errTierTapeVersionID
+	}
+	if v.Written, err = strconv.ParseInt(s[1], 10, 64); err != nil {
+		return v, errTierTapeVersionID
+	}
+	v.Sum = s[2]
+	return v, nil
+}
+
+// tapeOp is a queued operation on a tape pool.
+type tapeOp struct {
+	// Position on the tape, reads are served in this order.
+	pos  int64
+	fn   func()
+	done chan struct{}
+}
+
+// tapePool serializes all access to a tape, since a drive can only
+// serve one stream without repositioning the tape. Writes are served
+// in batches, restores are collected while the tape is mounted.
+type tapePool struct {
+	fs           *warmBackendFS
+	batchSize    int
+	mountLatency time.Duration
+
+	writes chan *tapeOp
+	reads  chan *tapeOp
+	// Unix time in nanoseconds of the last served operation.
+	lastActive int64
+}
+
+func (p *tapePool) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case op := <-p.writes:
+			p.serveWrites(ctx, op)
+		case op := <-p.reads:
+			p.serveReads(ctx, op)
+		}
+		atomic.StoreInt64(&p.lastActive, time.Now().UnixNano())
+	}
+}
+
+func (p *tapePool) serve(ctx context.Context, op *tapeOp) {
+	op.fn()
+	select {
+	case <-op.done:
+	case <-ctx.Done():
+	}
+}
+
+func (p *tapePool) serveWrites(ctx context.Context, op *tapeOp) {
+	p.serve(ctx, op)
+	for i := 1; i < p.batchSize; i++ {
+		select {
+		case op = <-p.writes:
+			p.serve(ctx, op)
+		default:
+			return
+		}
+	}
+}
+
+func (p *tapePool) serveReads(ctx context.Context, op *tapeOp) {
+	ops := []*tapeOp{op}
+	collect := func() bool {
+		select {
+		case op := <-p.reads:
+			ops = append(ops, op)
+			return true
+		default:
+			return false
+		}
+	}
+
+	// An idle tape needs to be mounted first, collect the restores
+	// arriving in the meantime.
+	if idle := time.Duration(time.Now().UnixNano() - atomic.LoadInt64(&p.lastActive)); idle > p.mountLatency && p.mountLatency > 0 {
+		timer := time.NewTimer(p.mountLatency)
+	wait:
+		for {
+			select {
+			case op := <-p.reads:
+				ops = append(ops, op)
+			case <-timer.C:
+				break wait
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}
+	for collect() {
+	}
+
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].pos < ops[j].pos
+	})
+	for _, op := range ops {
+		p.serve(ctx, op)
+	}
+}
+
+// submit queues fn on q and waits until it was served. fn must close
+// done when the tape is no longer in use by the operation.
+func (p *tapePool) submit(ctx, bctx context.Context, q chan *tapeOp, pos int64, fn func(done chan struct{})) error {
+	done := make(chan struct{})
+	started := make(chan struct{})
+	op := &tapeOp{pos: pos, done: done, fn: func() {
+		close(started)
+		fn(done)
+	}}
+	select {
+	case q <- op:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-bctx.Done():
+		return errTierTapeBackendDone
+	}
+	select {
+	case <-started:
+		return nil
+	case <-bctx.Done():
+		return errTierTapeBackendDone
+	}
+}
+
+// warmBackendTape distributes transitioned objects over the pools of a
+// tape tier.
+type warmBackendTape struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	pools  []*tapePool
+}
+
+func newWarmBackendTape(conf tierTape) (*warmBackendTape, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(GlobalContext)
+	d := &warmBackendTape{ctx: ctx, cancel: cancel}
+	for _, path := range conf.Pools {
+		fs, err := newWarmBackendFS(tierFS{Path: path, Prefix: conf.Prefix})
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		p := &tapePool{
+			fs:           fs,
+			batchSize:    conf.batchSize(),
+			mountLatency: time.Duration(conf.MountLatency) * time.Second,
+			writes:       make(chan *tapeOp),
+			reads:        make(chan *tapeOp),
+		}
+		go p.run(ctx)
+		d.pools = append(d.pools, p)
+	}
+	return d, nil
+}
+
+// Offline - tape tiers need objects to be restored before reading.
+func (t *warmBackendTape) Offline() bool {
+	return true
+}
+
+// Close stops serving the pools of the backend.
+func (t *warmBackendTape) Close() error {
+	t.cancel()
+	return nil
+}
+
+// pickPool returns the pool with the most free space.
+func (t *warmBackendTape) pickPool(length int64) (int, error) {
+	best, bestFree := -1, uint64(0)
+	for i, p := range t.pools {
+		_, free, err := p.fs.Usage()
+		if err != nil {
+			continue
+		}
+		if free >= uint64(length) && (best < 0 || free > bestFree) {
+			best, bestFree = i, free
+		}
+	}
+	if best < 0 {
+		return 0, errTierTapePoolsFull
+	}
+	return best, nil
+}
+
+func (t *warmBackendTape) Put(ctx context.Context, object string, r io.Reader, length int64) (rv remoteVersionID, err error) {
+	idx, err := t.pickPool(length)
+	if err != nil {
+		return "", err
+	}
+	p := t.pools[idx]
+	var written time.Time
+	var sum remoteVersionID
+	var perr error
+	finished := make(chan struct{})
+	if err = p.submit(ctx, t.ctx, p.writes, 0, func(done chan struct{}) {
+		defer close(finished)
+		defer close(done)
+		written = time.Now()
+		sum, perr = p.fs.Put(ctx, object, r, length)
+	}); err != nil {
+		return "", err
+	}
+	<-finished
+	if perr != nil {
+		return "", perr
+	}
+	return remoteVersionID(tapeVersionID{Pool: idx, Written: written.UnixNano(), Sum: string(sum)}.String()), nil
+}
+
+// tapeReader releases the tape when closed.
+type tapeReader struct {
+	io.ReadCloser
+	once sync.Once
+	done chan struct{}
+}
+
+func (r *tapeReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(func() { close(r.done) })
+	return err
+}
+
+func (t *warmBackendTape) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	v, err := parseTapeVersionID(rv)
+	if err != nil {
+		return nil, err
+	}
+	if v.Pool < 0 || v.Pool >= len(t.pools) {
+		return nil, errTierTapeVersionID
+	}
+	p := t.pools[v.Pool]
+	var rc io.ReadCloser
+	var gerr error
+	finished := make(chan struct{})
+	if err = p.submit(ctx, t.ctx, p.reads, v.Written, func(done chan struct{}) {
+		defer close(finished)
+		r, err := p.fs.Get(ctx, object, remoteVersionID(v.Sum), opts)
+		if err != nil {
+			gerr = err
+			close(done)
+			return
+		}
+		rc = &tapeReader{ReadCloser: r, done: done}
+	}); err != nil {
+		return nil, err
+	}
+	<-finished
+	return rc, gerr
+}
+
+func (t *warmBackendTape) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	v, err := parseTapeVersionID(rv)
+	if err != nil {
+		return err
+	}
+	if v.Pool < 0 || v.Pool >= len(t.pools) {
+		return errTierTapeVersionID
+	}
+	p := t.pools[v.Pool]
+	var rerr error
+	finished := make(chan struct{})
+	if err = p.submit(ctx, t.ctx, p.writes, 0, func(done chan struct{}) {
+		defer close(finished)
+		defer close(done)
+		rerr = p.fs.Remove(ctx, object, remoteVersionID(v.Sum))
+	}); err != nil {
+		return err
+	}
+	<-finished
+	return rerr
+}
+
+func (t *warmBackendTape) InUse(ctx context.Context) (bool, error) {
+	for _, p := range t.pools {
+		inUse, err := p.fs.InUse(ctx)
+		if err != nil || inUse {
+			return inUse, err
+		}
+	}
+	return false, nil
+}
+
+// tierTapePoolInfo is the space usage of a tape pool.
+type tierTapePoolInfo struct {
+	Path  string `json:"path"`
+	Used  uint64 `json:"used"`
+	Free  uint64 `json:"free"`
+	Error string `json:"error,omitempty"`
+}
+
+// tierTapeInfo is a tape tier with the space usage of its pools.
+type tierTapeInfo struct {
+	tierTape
+	PoolsInfo []tierTapePoolInfo `json:"poolsInfo"`
+}
+
+func (t *warmBackendTape) info(conf tierTape) tierTapeInfo {
+	info := tierTapeInfo{tierTape: conf}
+	for i, p := range t.pools {
+		pi := tierTapePoolInfo{Path: conf.Pools[i]}
+		var err error
+		if pi.Used, pi.Free, err = p.fs.Usage(); err != nil {
+			pi.Error = err.Error()
+		}
+		info.PoolsInfo = append(info.PoolsInfo, pi)
+	}
+	return info
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseTapeVersionID(t *testing.T) {
+	v := tapeVersionID{Pool: 2, Written: 1639000000000000000, Sum: "abcdef"}
+	got, err := parseTapeVersionID(remoteVersionID(v.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != v {
+		t.Fatalf("expected %v, got %v", v, got)
+	}
+	for _, rv := range []string{"", "abcdef", "x:1:abc", "1:x:abc"} {
+		if _, err = parseTapeVersionID(remoteVersionID(rv)); err == nil {
+			t.Errorf("expected an error for %q", rv)
+		}
+	}
+}
+
+func TestWarmBackendTape(t *testing.T) {
+	ctx := context.Background()
+	d, err := newWarmBackendTape(tierTape{Name: "TAPE", Pools: []string{t.TempDir(), t.TempDir()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if !d.Offline() {
+		t.Fatal("tape tiers must be offline")
+	}
+	if err = checkWarmBackend(ctx, d); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("archived object")
+	rv, err := d.Put(ctx, "deployment/bucket/object", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := d.Get(ctx, "deployment/bucket/object", rv, WarmBackendGetOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("unexpected content %q, %v", got, err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || !inUse {
+		t.Fatalf("expected a used tier, got %v, %v", inUse, err)
+	}
+	if err = d.Remove(ctx, "deployment/bucket/object", rv); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = d.Get(ctx, "deployment/bucket/object", "9:0:abc", WarmBackendGetOpts{}); err != errTierTapeVersionID {
+		t.Fatalf("expected %v, got %v", errTierTapeVersionID, err)
+	}
+}
+
+func TestTapePoolReadOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := &tapePool{
+		batchSize:    defaultTapeBatchSize,
+		mountLatency: 200 * time.Millisecond,
+		writes:       make(chan *tapeOp),
+		reads:        make(chan *tapeOp),
+	}
+	go p.run(ctx)
+
+	var mu sync.Mutex
+	var order []int64
+	var wg sync.WaitGroup
+	for _, pos := range []int64{3, 1, 2} {
+		wg.Add(1)
+		go func(pos int64) {
+			defer wg.Done()
+			err := p.submit(ctx, ctx, p.reads, pos, func(done chan struct{}) {
+				mu.Lock()
+				order = append(order, pos)
+				mu.Unlock()
+				close(done)
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(pos)
+	}
+	wg.Wait()
+
+	// All restores arrive while the tape is mounted and are served in
+	// their order on the tape.
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Fatalf("unexpected read order %v", order)
+	}
+}
@@ -32,6 +32,9 @@ import (
 type WarmBackendGetOpts struct {
 	startOffset int64
 	length      int64
+	// encoded is set if the remote content was compressed or
+	// encrypted when it was transitioned.
+	encoded bool
 }
 
 // WarmBackend provides interface to be implemented by remote tier backends
@@ -42,6 +45,12 @@ type WarmBackend interface {
 	InUse(ctx context.Context) (bool, error)
 }
 
+// offlineWarmBackend is implemented by remote tiers whose objects must
+// be restored before they can be read.
+type offlineWarmBackend interface {
+	Offline() bool
+}
+
 const probeObject = "probeobject"
 
 // checkWarmBackend checks if tier config credentials have sufficient privileges
@@ -60,7 +69,7 @@ func checkWarmBackend(ctx context.Context, w WarmBackend) error {
 		}
 	}
 
-	_, err = w.Get(ctx, probeObject, rv, WarmBackendGetOpts{})
+	r, err := w.Get(ctx, probeObject, rv, WarmBackendGetOpts{})
 	if err != nil {
 		switch err.(type) {
 		case BackendDown:
@@ -78,6 +87,7 @@ func checkWarmBackend(ctx context.Context, w WarmBackend) error {
 			}
 		}
 	}
+	r.Close()
 
 	if err = w.Remove(ctx, probeObject, rv); err != nil {
 		switch err.(type) {
@@ -132,11 +142,12 @@ func errIsTierPermError(err error) bool {
 type remoteVersionID string
 
 // newWarmBackend instantiates the tier type specific WarmBackend, runs
-// checkWarmBackend on it.
-func newWarmBackend(ctx context.Context, tier madmin.TierConfig) (d WarmBackend, err error) {
+// checkWarmBackend on it. S3 tiers authenticate with temporary
+// credentials if sts is not nil.
+func newWarmBackend(ctx context.Context, tier madmin.TierConfig, sts *tierSTS) (d WarmBackend, err error) {
 	switch tier.Type {
 	case madmin.S3:
-		d, err = newWarmBackendS3(*tier.S3)
+		d, err = newWarmBackendS3(*tier.S3, sts)
 	case madmin.Azure:
 		d, err = newWarmBackendAzure(*tier.Azure)
 	case madmin.GCS:
@@ -492,6 +492,14 @@ func (j *xlMetaV2Object) SetTransition(fi FileInfo) {
 	j.MetaSys[ReservedMetadataPrefixLower+TransitionedObjectName] = []byte(fi.TransitionedObjName)
 	j.MetaSys[ReservedMetadataPrefixLower+TransitionedVersionID] = []byte(fi.TransitionVersionID)
 	j.MetaSys[ReservedMetadataPrefixLower+TransitionTier] = []byte(fi.TransitionTier)
+	if checksum, ok := fi.Metadata[ReservedMetadataPrefixLower+TransitionedChecksum]; ok {
+		j.MetaSys[ReservedMetadataPrefixLower+TransitionedChecksum] = []byte(checksum)
+	}
+	if encoded, ok := fi.Metadata[ReservedMetadataPrefixLower+TransitionedEncoded]; ok {
+		j.MetaSys[ReservedMetadataPrefixLower+TransitionedEncoded] = []byte(encoded)
+	} else {
+		delete(j.MetaSys, ReservedMetadataPrefixLower+TransitionedEncoded)
+	}
 }
 
 func (j *xlMetaV2Object) RemoveRestoreHdrs() {
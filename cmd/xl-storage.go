@@ -519,6 +519,7 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			tier := minioHotTier
 			if oi.TransitionedObject.Status == lifecycle.TransitionComplete {
 				tier = oi.TransitionedObject.Tier
+				globalTierVerifier.Sample(oi)
 			}
 			sizeS.tiers[tier] = sizeS.tiers[tier].add(oi.tierStats())
 		}
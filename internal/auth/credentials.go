@@ -102,6 +102,9 @@ type Credentials struct {
 	ParentUser   string                 `xml:"-" json:"parentUser,omitempty"`
 	Groups       []string               `xml:"-" json:"groups,omitempty"`
 	Claims       map[string]interface{} `xml:"-" json:"claims,omitempty"`
+	// UpdatedAt is the time the secret key was last set, it is
+	// nil for credentials created by older releases.
+	UpdatedAt *time.Time `xml:"-" json:"updatedAt,omitempty"`
 }
 
 func (cred Credentials) String() string {
@@ -129,6 +132,15 @@ func (cred Credentials) IsExpired() bool {
 	return cred.Expiration.Before(time.Now().UTC())
 }
 
+// SecretKeyUpdatedAt - returns the time the secret key was last set,
+// or the zero time if it is unknown.
+func (cred Credentials) SecretKeyUpdatedAt() time.Time {
+	if cred.UpdatedAt == nil {
+		return time.Time{}
+	}
+	return *cred.UpdatedAt
+}
+
 // IsTemp - returns whether credential is temporary or not.
 func (cred Credentials) IsTemp() bool {
 	return cred.SessionToken != "" && !cred.Expiration.IsZero() && !cred.Expiration.Equal(timeSentinel)
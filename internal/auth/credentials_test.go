@@ -19,6 +19,7 @@ package auth
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -180,3 +181,29 @@ func TestCredentialsEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestCredentialsUpdatedAtJSON(t *testing.T) {
+	data, err := json.Marshal(Credentials{AccessKey: "minio", SecretKey: "minio123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "updatedAt") {
+		t.Fatalf("unexpected updatedAt in %s", data)
+	}
+
+	updatedAt := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	data, err = json.Marshal(Credentials{AccessKey: "minio", SecretKey: "minio123", UpdatedAt: &updatedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cred Credentials
+	if err = json.Unmarshal(data, &cred); err != nil {
+		t.Fatal(err)
+	}
+	if !cred.SecretKeyUpdatedAt().Equal(updatedAt) {
+		t.Fatalf("expected %v, got %v", updatedAt, cred.SecretKeyUpdatedAt())
+	}
+	if !(Credentials{}).SecretKeyUpdatedAt().IsZero() {
+		t.Fatal("expected the zero time without updatedAt")
+	}
+}
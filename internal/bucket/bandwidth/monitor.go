@@ -19,6 +19,7 @@ package bandwidth
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,8 +34,9 @@ type throttle struct {
 
 // Monitor holds the state of the global bucket monitor
 type Monitor struct {
-	tlock                 sync.RWMutex // mutex for bucketThrottle
+	tlock                 sync.RWMutex // mutex for bucketThrottle and targetThrottle
 	bucketThrottle        map[string]*throttle
+	targetThrottle        map[string]*throttle          // Throttles of scheduled targets by bucket and ARN
 	mlock                 sync.RWMutex                  // mutex for activeBuckets map
 	activeBuckets         map[string]*bucketMeasurement // Buckets with objects in flight
 	bucketMovingAvgTicker *time.Ticker                  // Ticker for calculating moving averages
@@ -47,6 +49,7 @@ func NewMonitor(ctx context.Context, numNodes uint64) *Monitor {
 	m := &Monitor{
 		activeBuckets:         make(map[string]*bucketMeasurement),
 		bucketThrottle:        make(map[string]*throttle),
+		targetThrottle:        make(map[string]*throttle),
 		bucketMovingAvgTicker: time.NewTicker(2 * time.Second),
 		ctx:                   ctx,
 		NodeCount:             numNodes,
@@ -151,34 +154,103 @@ func (m *Monitor) track(bucket string) {
 func (m *Monitor) DeleteBucket(bucket string) {
 	m.tlock.Lock()
 	delete(m.bucketThrottle, bucket)
+	for key := range m.targetThrottle {
+		if strings.HasPrefix(key, bucket+"/") {
+			delete(m.targetThrottle, key)
+		}
+	}
 	m.tlock.Unlock()
 	m.mlock.Lock()
 	delete(m.activeBuckets, bucket)
 	m.mlock.Unlock()
 }
 
-// throttle returns currently configured throttle for this bucket
-func (m *Monitor) throttle(bucket string) *throttle {
+func targetKey(bucket, arn string) string {
+	return bucket + "/" + arn
+}
+
+// throttle returns currently configured throttle for this target of
+// the bucket, falling back to the throttle of the bucket.
+func (m *Monitor) throttle(bucket, arn string) *throttle {
 	m.tlock.RLock()
 	defer m.tlock.RUnlock()
+	if t, ok := m.targetThrottle[targetKey(bucket, arn)]; ok {
+		return t
+	}
 	return m.bucketThrottle[bucket]
 }
 
-// SetBandwidthLimit sets the bandwidth limit for a bucket
-func (m *Monitor) SetBandwidthLimit(bucket string, limit int64) {
-	m.tlock.Lock()
-	defer m.tlock.Unlock()
+// setThrottle updates the throttle for limit, allocating it if t is nil.
+// Limits smaller than the number of nodes allow each node 1 byte/sec.
+func (m *Monitor) setThrottle(t *throttle, limit int64) *throttle {
 	bw := limit / int64(m.NodeCount)
-	t, ok := m.bucketThrottle[bucket]
-	if !ok {
-		t = &throttle{
-			NodeBandwidthPerSec: bw,
-		}
+	if bw < 1 {
+		bw = 1
+	}
+	if t == nil {
+		t = &throttle{}
 	}
 	t.NodeBandwidthPerSec = bw
 	newlimit := rate.Every(time.Second / time.Duration(t.NodeBandwidthPerSec))
 	t.Limiter = rate.NewLimiter(newlimit, int(t.NodeBandwidthPerSec))
-	m.bucketThrottle[bucket] = t
+	return t
+}
+
+// SetBandwidthLimit sets the bandwidth limit for a bucket
+func (m *Monitor) SetBandwidthLimit(bucket string, limit int64) {
+	m.tlock.Lock()
+	defer m.tlock.Unlock()
+	m.bucketThrottle[bucket] = m.setThrottle(m.bucketThrottle[bucket], limit)
+}
+
+// SetTargetBandwidthLimit sets the bandwidth limit for a target of the
+// bucket, overriding the bandwidth limit of the bucket. A limit of 0
+// removes the override.
+func (m *Monitor) SetTargetBandwidthLimit(bucket, arn string, limit int64) {
+	m.tlock.Lock()
+	defer m.tlock.Unlock()
+	key := targetKey(bucket, arn)
+	if limit <= 0 {
+		delete(m.targetThrottle, key)
+		return
+	}
+	t, ok := m.targetThrottle[key]
+	if ok && t.NodeBandwidthPerSec == limit/int64(m.NodeCount) {
+		// Keep the limiter state if the limit did not change.
+		return
+	}
+	m.targetThrottle[key] = m.setThrottle(t, limit)
+}
+
+// TargetBandwidthLimit returns the bandwidth limit set for the target
+// of the bucket with SetTargetBandwidthLimit, 0 if there is none.
+func (m *Monitor) TargetBandwidthLimit(bucket, arn string) int64 {
+	m.tlock.RLock()
+	defer m.tlock.RUnlock()
+	if t, ok := m.targetThrottle[targetKey(bucket, arn)]; ok {
+		return t.NodeBandwidthPerSec * int64(m.NodeCount)
+	}
+	return 0
+}
+
+// Limit returns the bandwidth limit in bytes per second that applies to
+// replication to the target of the bucket, 0 if it is unlimited.
+func (m *Monitor) Limit(bucket, arn string) int64 {
+	if t := m.throttle(bucket, arn); t != nil {
+		return t.NodeBandwidthPerSec * int64(m.NodeCount)
+	}
+	return 0
+}
+
+// CurrentBandwidth returns the moving average of the bytes per second
+// currently replicated for the bucket by this node.
+func (m *Monitor) CurrentBandwidth(bucket string) float64 {
+	m.mlock.RLock()
+	defer m.mlock.RUnlock()
+	if bm, ok := m.activeBuckets[bucket]; ok {
+		return bm.getExpMovingAvgBytesPerSecond()
+	}
+	return 0
 }
 
 // IsThrottled returns true if a bucket has bandwidth throttling enabled.
@@ -188,3 +260,9 @@ func (m *Monitor) IsThrottled(bucket string) bool {
 	_, ok := m.bucketThrottle[bucket]
 	return ok
 }
+
+// IsTargetThrottled returns true if replication to the target of the
+// bucket is subject to bandwidth throttling.
+func (m *Monitor) IsTargetThrottled(bucket, arn string) bool {
+	return m.throttle(bucket, arn) != nil
+}
@@ -111,3 +111,15 @@ func TestMonitor_GetReport(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitorSetBandwidthLimitBelowNodeCount(t *testing.T) {
+	m := &Monitor{
+		bucketThrottle: make(map[string]*throttle),
+		targetThrottle: make(map[string]*throttle),
+		NodeCount:      4,
+	}
+	m.SetBandwidthLimit("bucket", 3)
+	if bw := m.throttle("bucket", "").NodeBandwidthPerSec; bw != 1 {
+		t.Fatalf("expected node bandwidth of 1 byte/sec, got %d", bw)
+	}
+}
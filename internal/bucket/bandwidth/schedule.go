@@ -0,0 +1,163 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bandwidth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Window is a weekly recurring time window with its own bandwidth
+// limit. A window whose end is not after its start wraps past
+// midnight and belongs to the day it starts on.
+type Window struct {
+	// Days of the week the window applies to, e.g. "Sat", all days if
+	// empty.
+	Days []string `json:"days,omitempty"`
+	// Start and end of the window as "HH:MM".
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Limit in bytes per second.
+	Limit int64 `json:"limit"`
+
+	days       [7]bool
+	start, end int // minutes of the day
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) || strings.EqualFold(s, d.String()[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day of week %q", s)
+}
+
+func (w *Window) parse() (err error) {
+	if w.Limit <= 0 {
+		return errors.New("window bandwidth limit must be positive")
+	}
+	if w.start, err = parseClock(w.Start); err != nil {
+		return err
+	}
+	if w.end, err = parseClock(w.End); err != nil {
+		return err
+	}
+	w.days = [7]bool{}
+	for _, s := range w.Days {
+		d, err := parseWeekday(s)
+		if err != nil {
+			return err
+		}
+		w.days[d] = true
+	}
+	if len(w.Days) == 0 {
+		w.days = [7]bool{true, true, true, true, true, true, true}
+	}
+	return nil
+}
+
+// active returns true if the window includes t.
+func (w Window) active(t time.Time) bool {
+	d, m := t.Weekday(), t.Hour()*60+t.Minute()
+	if w.start < w.end {
+		return w.days[d] && m >= w.start && m < w.end
+	}
+	prev := (d + 6) % 7
+	return (w.days[d] && m >= w.start) || (w.days[prev] && m < w.end)
+}
+
+// Schedule holds the bandwidth limits of a replication target by time
+// window. While no window is active the bandwidth limit of the target
+// applies.
+type Schedule struct {
+	// Name of the time zone of the windows, e.g. "Europe/Berlin". UTC
+	// if empty.
+	TimeZone string   `json:"timeZone,omitempty"`
+	Windows  []Window `json:"windows"`
+
+	loc *time.Location
+}
+
+// Validate - validates the schedule, it must be called before Limit.
+func (s *Schedule) Validate() error {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", s.TimeZone, err)
+	}
+	s.loc = loc
+	for i := range s.Windows {
+		if err := s.Windows[i].parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Limit returns the bandwidth limit of the first window active at t,
+// or false if no window is active.
+func (s Schedule) Limit(t time.Time) (int64, bool) {
+	if s.loc != nil {
+		t = t.In(s.loc)
+	}
+	for _, w := range s.Windows {
+		if w.active(t) {
+			return w.Limit, true
+		}
+	}
+	return 0, false
+}
+
+// ScheduleConfig holds the bandwidth schedules of the replication
+// targets of a bucket, keyed by target ARN.
+type ScheduleConfig struct {
+	Targets map[string]*Schedule `json:"targets"`
+}
+
+// ParseScheduleConfig - parses and validates the bandwidth schedules
+// of a bucket.
+func ParseScheduleConfig(r io.Reader) (*ScheduleConfig, error) {
+	var config ScheduleConfig
+	if err := json.NewDecoder(r).Decode(&config); err != nil {
+		return nil, err
+	}
+	if config.Targets == nil {
+		config.Targets = make(map[string]*Schedule)
+	}
+	for arn, s := range config.Targets {
+		if s == nil {
+			return nil, fmt.Errorf("missing bandwidth schedule for target %s", arn)
+		}
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &config, nil
+}
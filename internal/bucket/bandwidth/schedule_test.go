@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bandwidth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScheduleLimit(t *testing.T) {
+	config, err := ParseScheduleConfig(strings.NewReader(`{
+  "targets": {
+    "arn1": {
+      "windows": [
+        {"days": ["Sat", "Sunday"], "start": "00:00", "end": "00:00", "limit": 200},
+        {"days": ["Mon", "Tue", "Wed", "Thu", "Fri"], "start": "09:00", "end": "18:00", "limit": 50},
+        {"start": "22:00", "end": "06:00", "limit": 200}
+      ]
+    }
+  }
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := config.Targets["arn1"]
+
+	testCases := []struct {
+		t      time.Time
+		limit  int64
+		active bool
+	}{
+		// Saturday and Sunday, all day.
+		{time.Date(2021, 12, 4, 12, 0, 0, 0, time.UTC), 200, true},
+		{time.Date(2021, 12, 5, 23, 59, 0, 0, time.UTC), 200, true},
+		// Monday business hours.
+		{time.Date(2021, 12, 6, 9, 0, 0, 0, time.UTC), 50, true},
+		{time.Date(2021, 12, 6, 17, 59, 0, 0, time.UTC), 50, true},
+		{time.Date(2021, 12, 6, 18, 0, 0, 0, time.UTC), 0, false},
+		// Nights wrap past midnight.
+		{time.Date(2021, 12, 6, 23, 0, 0, 0, time.UTC), 200, true},
+		{time.Date(2021, 12, 7, 5, 59, 0, 0, time.UTC), 200, true},
+		{time.Date(2021, 12, 7, 6, 0, 0, 0, time.UTC), 0, false},
+	}
+	for i, testCase := range testCases {
+		limit, active := s.Limit(testCase.t)
+		if limit != testCase.limit || active != testCase.active {
+			t.Errorf("Test %d: expected %d %v, got %d %v", i+1, testCase.limit, testCase.active, limit, active)
+		}
+	}
+}
+
+func TestScheduleTimeZone(t *testing.T) {
+	s := Schedule{
+		TimeZone: "America/New_York",
+		Windows:  []Window{{Start: "09:00", End: "17:00", Limit: 50}},
+	}
+	if err := s.Validate(); err != nil {
+		t.Skip(err) // time zone database not available
+	}
+	if _, ok := s.Limit(time.Date(2021, 12, 6, 10, 0, 0, 0, time.UTC)); ok {
+		t.Fatal("10:00 UTC is outside of 09:00-17:00 in New York")
+	}
+	if _, ok := s.Limit(time.Date(2021, 12, 6, 15, 0, 0, 0, time.UTC)); !ok {
+		t.Fatal("15:00 UTC is within 09:00-17:00 in New York")
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	for i, w := range []Window{
+		{Start: "9:00", End: "17:00", Limit: 0},
+		{Start: "25:00", End: "17:00", Limit: 1},
+		{Start: "09:00", End: "17:00", Limit: 1, Days: []string{"Funday"}},
+	} {
+		s := Schedule{Windows: []Window{w}}
+		if err := s.Validate(); err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestMonitorTargetThrottle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewMonitor(ctx, 2)
+	m.SetBandwidthLimit("bucket", 200)
+	m.SetTargetBandwidthLimit("bucket", "arn1", 100)
+
+	if got := m.throttle("bucket", "arn1").NodeBandwidthPerSec; got != 50 {
+		t.Fatalf("expected target throttle of 50 per node, got %d", got)
+	}
+	if got := m.throttle("bucket", "arn2").NodeBandwidthPerSec; got != 100 {
+		t.Fatalf("expected bucket throttle of 100 per node, got %d", got)
+	}
+	if got := m.TargetBandwidthLimit("bucket", "arn1"); got != 100 {
+		t.Fatalf("expected target limit 100, got %d", got)
+	}
+
+	m.SetTargetBandwidthLimit("bucket", "arn1", 0)
+	if got := m.throttle("bucket", "arn1").NodeBandwidthPerSec; got != 100 {
+		t.Fatalf("expected fallback to bucket throttle, got %d", got)
+	}
+
+	m.SetTargetBandwidthLimit("bucket", "arn1", 100)
+	m.DeleteBucket("bucket")
+	if m.IsTargetThrottled("bucket", "arn1") {
+		t.Fatal("target throttle must be removed with the bucket")
+	}
+}
@@ -32,6 +32,8 @@ const (
 	AES256 Algorithm = "AES256"
 	// AWSKms is used with SSE-KMS
 	AWSKms Algorithm = "aws:kms"
+	// AWSKmsDSSE is used with dual-layer SSE-KMS
+	AWSKmsDSSE Algorithm = "aws:kms:dsse"
 )
 
 // Algorithm - represents valid SSE algorithms supported; currently only AES256 is supported
@@ -49,6 +51,8 @@ func (alg *Algorithm) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error
 		*alg = AES256
 	case string(AWSKms):
 		*alg = AWSKms
+	case string(AWSKmsDSSE):
+		*alg = AWSKmsDSSE
 	default:
 		return errors.New("Unknown SSE algorithm")
 	}
@@ -61,10 +65,41 @@ func (alg *Algorithm) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.EncodeElement(string(*alg), start)
 }
 
+const (
+	// AES256GCM selects AES-256-GCM as object content cipher
+	AES256GCM ContentCipher = "AES-256-GCM"
+	// ChaCha20Poly1305 selects ChaCha20-Poly1305 as object content cipher
+	ChaCha20Poly1305 ContentCipher = "ChaCha20-Poly1305"
+)
+
+// ContentCipher - represents the cipher used to encrypt the object
+// content. This is a MinIO extension to the S3 encryption configuration.
+type ContentCipher string
+
+// UnmarshalXML - Unmarshals XML tag to valid content cipher
+func (c *ContentCipher) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	switch s {
+	case string(AES256GCM):
+		*c = AES256GCM
+	case string(ChaCha20Poly1305):
+		*c = ChaCha20Poly1305
+	default:
+		return errors.New("Unknown content cipher")
+	}
+
+	return nil
+}
+
 // EncryptionAction - for ApplyServerSideEncryptionByDefault XML tag
 type EncryptionAction struct {
-	Algorithm   Algorithm `xml:"SSEAlgorithm,omitempty"`
-	MasterKeyID string    `xml:"KMSMasterKeyID,omitempty"`
+	Algorithm     Algorithm     `xml:"SSEAlgorithm,omitempty"`
+	MasterKeyID   string        `xml:"KMSMasterKeyID,omitempty"`
+	ContentCipher ContentCipher `xml:"ContentCipher,omitempty"`
 }
 
 // Rule - for ServerSideEncryptionConfiguration XML tag
@@ -101,9 +136,9 @@ func ParseBucketSSEConfig(r io.Reader) (*BucketSSEConfig, error) {
 			if rule.DefaultEncryptionAction.MasterKeyID != "" {
 				return nil, errors.New("MasterKeyID is allowed with aws:kms only")
 			}
-		case AWSKms:
+		case AWSKms, AWSKmsDSSE:
 			if rule.DefaultEncryptionAction.MasterKeyID == "" {
-				return nil, errors.New("MasterKeyID is missing with aws:kms")
+				return nil, errors.New("MasterKeyID is missing with " + string(rule.DefaultEncryptionAction.Algorithm))
 			}
 		}
 	}
@@ -148,9 +183,22 @@ func (b *BucketSSEConfig) Apply(headers http.Header, opts ApplyOptions) {
 	case xhttp.AmzEncryptionKMS:
 		headers.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMS)
 		headers.Set(xhttp.AmzServerSideEncryptionKmsID, b.KeyID())
+	case xhttp.AmzEncryptionKMSDSSE:
+		headers.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMSDSSE)
+		headers.Set(xhttp.AmzServerSideEncryptionKmsID, b.KeyID())
 	}
 }
 
+// IsKMS returns true if the SSE configuration specifies SSE-KMS or
+// dual-layer SSE-KMS.
+func (b *BucketSSEConfig) IsKMS() bool {
+	switch b.Algo() {
+	case AWSKms, AWSKmsDSSE:
+		return true
+	}
+	return false
+}
+
 // Algo returns the SSE algorithm specified by the SSE configuration.
 func (b *BucketSSEConfig) Algo() Algorithm {
 	for _, rule := range b.Rules {
@@ -159,6 +207,16 @@ func (b *BucketSSEConfig) Algo() Algorithm {
 	return ""
 }
 
+// ContentCipher returns the object content cipher specified by the SSE
+// configuration. If the SSE configuration does not specify a cipher it
+// returns an empty content cipher.
+func (b *BucketSSEConfig) ContentCipher() ContentCipher {
+	for _, rule := range b.Rules {
+		return rule.DefaultEncryptionAction.ContentCipher
+	}
+	return ""
+}
+
 // KeyID returns the KMS key ID specified by the SSE configuration.
 // If the SSE configuration does not specify SSE-KMS it returns an
 // empty key ID.
@@ -68,6 +68,36 @@ func TestParseBucketSSEConfig(t *testing.T) {
 		},
 	}
 
+	actualDSSEConfig := &BucketSSEConfig{
+		XMLNS: xmlNS,
+		XMLName: xml.Name{
+			Local: "ServerSideEncryptionConfiguration",
+		},
+		Rules: []Rule{
+			{
+				DefaultEncryptionAction: EncryptionAction{
+					Algorithm:   AWSKmsDSSE,
+					MasterKeyID: "arn:aws:kms:us-east-1:1234/5678example",
+				},
+			},
+		},
+	}
+
+	actualChaChaConfig := &BucketSSEConfig{
+		XMLNS: xmlNS,
+		XMLName: xml.Name{
+			Local: "ServerSideEncryptionConfiguration",
+		},
+		Rules: []Rule{
+			{
+				DefaultEncryptionAction: EncryptionAction{
+					Algorithm:     AES256,
+					ContentCipher: ChaCha20Poly1305,
+				},
+			},
+		},
+	}
+
 	testCases := []struct {
 		inputXML       string
 		expectedErr    error
@@ -119,6 +149,32 @@ func TestParseBucketSSEConfig(t *testing.T) {
 			shouldPass:     true,
 			expectedConfig: actualAES256NoNSConfig,
 		},
+		// 8. Valid XML SSE-KMS with dual-layer encryption
+		{
+			inputXML:       `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms:dsse</SSEAlgorithm><KMSMasterKeyID>arn:aws:kms:us-east-1:1234/5678example</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr:    nil,
+			shouldPass:     true,
+			expectedConfig: actualDSSEConfig,
+		},
+		// 9. Invalid XML - master key ID not provided when algorithm is set to aws:kms:dsse algorithm
+		{
+			inputXML:    `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms:dsse</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr: errors.New("MasterKeyID is missing with aws:kms:dsse"),
+			shouldPass:  false,
+		},
+		// 10. Valid XML SSE-S3 with ChaCha20-Poly1305 content cipher
+		{
+			inputXML:       `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm><ContentCipher>ChaCha20-Poly1305</ContentCipher></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr:    nil,
+			shouldPass:     true,
+			expectedConfig: actualChaChaConfig,
+		},
+		// 11. Invalid content cipher
+		{
+			inputXML:    `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm><ContentCipher>DES</ContentCipher></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr: errors.New("Unknown content cipher"),
+			shouldPass:  false,
+		},
 	}
 
 	for i, tc := range testCases {
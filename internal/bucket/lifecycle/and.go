@@ -23,16 +23,22 @@ import (
 
 var errDuplicateTagKey = Errorf("Duplicate Tag Keys are not allowed")
 
-// And - a tag to combine a prefix and multiple tags for lifecycle configuration rule.
+// And - a tag to combine a prefix, multiple tags and object sizes for lifecycle configuration rule.
 type And struct {
-	XMLName xml.Name `xml:"And"`
-	Prefix  Prefix   `xml:"Prefix,omitempty"`
-	Tags    []Tag    `xml:"Tag,omitempty"`
+	XMLName               xml.Name `xml:"And"`
+	Prefix                Prefix   `xml:"Prefix,omitempty"`
+	Tags                  []Tag    `xml:"Tag,omitempty"`
+	ObjectSizeLessThan    int64    `xml:"ObjectSizeLessThan,omitempty"`
+	ObjectSizeGreaterThan int64    `xml:"ObjectSizeGreaterThan,omitempty"`
 }
 
-// isEmpty returns true if Tags field is null
+// isEmpty returns true if Tags, Prefix and object sizes are null
 func (a And) isEmpty() bool {
-	return len(a.Tags) == 0 && !a.Prefix.set
+	return len(a.Tags) == 0 && !a.Prefix.set && !a.sizeSet()
+}
+
+func (a And) sizeSet() bool {
+	return a.ObjectSizeGreaterThan != 0 || a.ObjectSizeLessThan != 0
 }
 
 // Validate - validates the And field
@@ -40,12 +46,18 @@ func (a And) Validate() error {
 	emptyPrefix := !a.Prefix.set
 	emptyTags := len(a.Tags) == 0
 
-	if emptyPrefix && emptyTags {
-		return nil
-	}
+	if a.sizeSet() {
+		if err := validateObjectSize(a.ObjectSizeGreaterThan, a.ObjectSizeLessThan); err != nil {
+			return err
+		}
+	} else {
+		if emptyPrefix && emptyTags {
+			return nil
+		}
 
-	if emptyPrefix && !emptyTags || !emptyPrefix && emptyTags {
-		return errXMLNotWellFormed
+		if emptyPrefix && !emptyTags || !emptyPrefix && emptyTags {
+			return errXMLNotWellFormed
+		}
 	}
 
 	if a.ContainsDuplicateTag() {
@@ -22,7 +22,10 @@ import (
 	"io"
 )
 
-var errInvalidFilter = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+var (
+	errInvalidFilter     = Errorf("Filter must have exactly one of Prefix, Tag, ObjectSizeGreaterThan, ObjectSizeLessThan or And specified")
+	errInvalidObjectSize = Errorf("ObjectSizeGreaterThan and ObjectSizeLessThan must be positive and ObjectSizeLessThan must be greater than ObjectSizeGreaterThan")
+)
 
 // Filter - a filter for a lifecycle configuration Rule.
 type Filter struct {
@@ -36,12 +39,16 @@ type Filter struct {
 
 	Tag    Tag
 	tagSet bool
+
+	ObjectSizeLessThan    int64
+	ObjectSizeGreaterThan int64
 	// Caching tags, only once
 	cachedTags []string
 }
 
 // MarshalXML - produces the xml representation of the Filter struct
-// only one of Prefix, And and Tag should be present in the output.
+// only one of Prefix, And, Tag and the object size elements should be
+// present in the output.
 func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start); err != nil {
 		return err
@@ -56,8 +63,16 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
 			return err
 		}
+	case f.ObjectSizeGreaterThan > 0:
+		if err := e.EncodeElement(f.ObjectSizeGreaterThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeGreaterThan"}}); err != nil {
+			return err
+		}
+	case f.ObjectSizeLessThan > 0:
+		if err := e.EncodeElement(f.ObjectSizeLessThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeLessThan"}}); err != nil {
+			return err
+		}
 	default:
-		// Always print Prefix field when both And & Tag are empty
+		// Always print Prefix field when And, Tag and object sizes are empty
 		if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
 			return err
 		}
@@ -102,6 +117,14 @@ func (f *Filter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error
 				}
 				f.Tag = tag
 				f.tagSet = true
+			case "ObjectSizeGreaterThan":
+				if err = d.DecodeElement(&f.ObjectSizeGreaterThan, &se); err != nil {
+					return err
+				}
+			case "ObjectSizeLessThan":
+				if err = d.DecodeElement(&f.ObjectSizeLessThan, &se); err != nil {
+					return err
+				}
 			default:
 				return errUnknownXMLTag
 			}
@@ -120,34 +143,56 @@ func (f Filter) Validate() error {
 	if f.IsEmpty() {
 		return errXMLNotWellFormed
 	}
-	// A Filter must have exactly one of Prefix, Tag, or And specified.
-	if !f.And.isEmpty() {
-		if f.Prefix.set {
-			return errInvalidFilter
-		}
-		if !f.Tag.IsEmpty() {
-			return errInvalidFilter
+	// A Filter must have exactly one of Prefix, Tag, ObjectSizeGreaterThan,
+	// ObjectSizeLessThan or And specified.
+	var n int
+	for _, set := range []bool{f.Prefix.set, !f.Tag.IsEmpty(), !f.And.isEmpty(), f.ObjectSizeGreaterThan != 0, f.ObjectSizeLessThan != 0} {
+		if set {
+			n++
 		}
+	}
+	if n > 1 {
+		return errInvalidFilter
+	}
+	if !f.And.isEmpty() {
 		if err := f.And.Validate(); err != nil {
 			return err
 		}
 	}
-	if f.Prefix.set {
-		if !f.Tag.IsEmpty() {
-			return errInvalidFilter
-		}
-	}
 	if !f.Tag.IsEmpty() {
-		if f.Prefix.set {
-			return errInvalidFilter
-		}
 		if err := f.Tag.Validate(); err != nil {
 			return err
 		}
 	}
+	return validateObjectSize(f.ObjectSizeGreaterThan, f.ObjectSizeLessThan)
+}
+
+func validateObjectSize(greaterThan, lessThan int64) error {
+	if greaterThan < 0 || lessThan < 0 {
+		return errInvalidObjectSize
+	}
+	if greaterThan > 0 && lessThan > 0 && lessThan <= greaterThan {
+		return errInvalidObjectSize
+	}
 	return nil
 }
 
+// BySize returns true if an object of size sz satisfies the object
+// size requirements of the Filter, it returns true if there are none.
+func (f Filter) BySize(sz int64) bool {
+	greaterThan, lessThan := f.ObjectSizeGreaterThan, f.ObjectSizeLessThan
+	if !f.And.isEmpty() {
+		greaterThan, lessThan = f.And.ObjectSizeGreaterThan, f.And.ObjectSizeLessThan
+	}
+	if greaterThan > 0 && sz <= greaterThan {
+		return false
+	}
+	if lessThan > 0 && sz >= lessThan {
+		return false
+	}
+	return true
+}
+
 // TestTags tests if the object tags satisfy the Filter tags requirement,
 // it returns true if there is no tags in the underlying Filter.
 func (f Filter) TestTags(tags []string) bool {
@@ -109,6 +109,44 @@ func TestUnsupportedFilters(t *testing.T) {
 						</Filter>`,
 			expectedErr: errInvalidFilter,
 		},
+		{ // Filter with ObjectSizeGreaterThan
+			inputXML: ` <Filter>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with Prefix and ObjectSizeLessThan but without And
+			inputXML: ` <Filter>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeLessThan>1024</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidFilter,
+		},
+		{ // Filter with And, Prefix and object size range
+			inputXML: ` <Filter>
+							<And>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>4096</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with And and an empty object size range
+			inputXML: ` <Filter>
+							<And>
+							<ObjectSizeGreaterThan>4096</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>1024</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: errInvalidObjectSize,
+		},
+		{ // Filter with negative ObjectSizeLessThan
+			inputXML: ` <Filter>
+							<ObjectSizeLessThan>-1</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidObjectSize,
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
@@ -124,3 +162,25 @@ func TestUnsupportedFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterBySize(t *testing.T) {
+	testCases := []struct {
+		filter   Filter
+		size     int64
+		expected bool
+	}{
+		{Filter{}, 0, true},
+		{Filter{ObjectSizeGreaterThan: 1024}, 1024, false},
+		{Filter{ObjectSizeGreaterThan: 1024}, 1025, true},
+		{Filter{ObjectSizeLessThan: 1024}, 1023, true},
+		{Filter{ObjectSizeLessThan: 1024}, 1024, false},
+		{Filter{And: And{ObjectSizeGreaterThan: 10, ObjectSizeLessThan: 20}}, 15, true},
+		{Filter{And: And{ObjectSizeGreaterThan: 10, ObjectSizeLessThan: 20}}, 20, false},
+		{Filter{And: And{ObjectSizeGreaterThan: 10, ObjectSizeLessThan: 20}}, 5, false},
+	}
+	for i, tc := range testCases {
+		if got := tc.filter.BySize(tc.size); got != tc.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.expected, got)
+		}
+	}
+}
@@ -84,6 +84,17 @@ func (lc Lifecycle) HasTransition() bool {
 	return false
 }
 
+// HasLastAccessTransition returns 'true' if an enabled rule transitions
+// objects based on their last access time.
+func (lc Lifecycle) HasLastAccessTransition() bool {
+	for _, rule := range lc.Rules {
+		if rule.Status == Enabled && rule.Transition.DaysSinceLastAccess > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // UnmarshalXML - decodes XML data.
 func (lc *Lifecycle) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
 	switch start.Name.Local {
@@ -231,6 +242,10 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 		if !strings.HasPrefix(obj.Name, rule.GetPrefix()) {
 			continue
 		}
+		// Object size filters don't apply to delete markers.
+		if !obj.DeleteMarker && !rule.Filter.BySize(obj.Size) {
+			continue
+		}
 		// Indicates whether MinIO will remove a delete marker with no
 		// noncurrent versions. If set to true, the delete marker will
 		// be expired; if set to false the policy takes no action. This
@@ -274,6 +289,7 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 type ObjectOpts struct {
 	Name             string
 	UserTags         string
+	Size             int64
 	ModTime          time.Time
 	VersionID        string
 	IsLatest         bool
@@ -283,6 +299,7 @@ type ObjectOpts struct {
 	TransitionStatus string
 	RestoreOngoing   bool
 	RestoreExpires   time.Time
+	LastAccessTime   time.Time
 }
 
 // ExpiredObjectDeleteMarker returns true if an object version referred to by o
@@ -295,9 +312,23 @@ func (o ObjectOpts) ExpiredObjectDeleteMarker() bool {
 // ComputeAction returns the action to perform by evaluating all lifecycle rules
 // against the object name and its modification time.
 func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
-	action := NoneAction
+	return lc.ComputeActionAt(obj, time.Now().UTC())
+}
+
+// ComputeActionAt returns the action that would be performed on the object
+// when the lifecycle rules are evaluated at the given time.
+func (lc Lifecycle) ComputeActionAt(obj ObjectOpts, now time.Time) Action {
+	action, _ := lc.ComputeRuleActionAt(obj, now)
+	return action
+}
+
+// ComputeRuleActionAt is like ComputeActionAt and also returns the ID of
+// the rule resulting in the action. The rule ID is empty for the expiry
+// of restored copies, which does not depend on rules.
+func (lc Lifecycle) ComputeRuleActionAt(obj ObjectOpts, now time.Time) (action Action, ruleID string) {
+	action = NoneAction
 	if obj.ModTime.IsZero() {
-		return action
+		return action, ""
 	}
 	for _, rule := range lc.FilterActionableRules(obj) {
 		if obj.ExpiredObjectDeleteMarker() {
@@ -306,15 +337,15 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 				// Only latest marker is removed. If set to true, the delete marker will be expired;
 				// if set to false the policy takes no action. This cannot be specified with Days or
 				// Date in a Lifecycle Expiration Policy.
-				return DeleteVersionAction
+				return DeleteVersionAction, rule.ID
 			}
 
 			if !rule.Expiration.IsDaysNull() {
 				// Specifying the Days tag will automatically perform ExpiredObjectDeleteMarker cleanup
 				// once delete markers are old enough to satisfy the age criteria.
 				// https://docs.aws.amazon.com/AmazonS3/latest/userguide/lifecycle-configuration-examples.html
-				if time.Now().UTC().After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
-					return DeleteVersionAction
+				if now.After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
+					return DeleteVersionAction, rule.ID
 				}
 			}
 		}
@@ -330,8 +361,8 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 			if obj.VersionID != "" && !obj.IsLatest && !obj.SuccessorModTime.IsZero() {
 				// Non current versions should be deleted if their age exceeds non current days configuration
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
-				if time.Now().UTC().After(ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionExpiration.NoncurrentDays))) {
-					return DeleteVersionAction
+				if now.After(ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionExpiration.NoncurrentDays))) {
+					return DeleteVersionAction, rule.ID
 				}
 			}
 		}
@@ -340,8 +371,8 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 			if obj.VersionID != "" && !obj.IsLatest && !obj.SuccessorModTime.IsZero() && !obj.DeleteMarker && obj.TransitionStatus != TransitionComplete {
 				// Non current versions should be transitioned if their age exceeds non current days configuration
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
-				if due, ok := rule.NoncurrentVersionTransition.NextDue(obj); ok && time.Now().UTC().After(due) {
-					return TransitionVersionAction
+				if due, ok := rule.NoncurrentVersionTransition.NextDue(obj); ok && now.After(due) {
+					return TransitionVersionAction, rule.ID
 				}
 			}
 		}
@@ -350,41 +381,34 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 		if obj.VersionID == "" || obj.IsLatest && !obj.DeleteMarker {
 			switch {
 			case !rule.Expiration.IsDateNull():
-				if time.Now().UTC().After(rule.Expiration.Date.Time) {
-					return DeleteAction
+				if now.After(rule.Expiration.Date.Time) {
+					return DeleteAction, rule.ID
 				}
 			case !rule.Expiration.IsDaysNull():
-				if time.Now().UTC().After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
-					return DeleteAction
+				if now.After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
+					return DeleteAction, rule.ID
 				}
 			}
 
 			if obj.TransitionStatus != TransitionComplete {
 				if due, ok := rule.Transition.NextDue(obj); ok {
-					if time.Now().UTC().After(due) {
-						action = TransitionAction
-					}
-				}
-
-				if !obj.RestoreExpires.IsZero() && time.Now().UTC().After(obj.RestoreExpires) {
-					if obj.VersionID != "" {
-						action = DeleteRestoredVersionAction
-					} else {
-						action = DeleteRestoredAction
+					if now.After(due) {
+						action, ruleID = TransitionAction, rule.ID
 					}
 				}
 			}
-			if !obj.RestoreExpires.IsZero() && time.Now().UTC().After(obj.RestoreExpires) {
-				if obj.VersionID != "" {
-					action = DeleteRestoredVersionAction
-				} else {
-					action = DeleteRestoredAction
-				}
-			}
+		}
+	}
 
+	// Restored copies of transitioned objects are temporary, they
+	// expire regardless of the rules applying to the object.
+	if !obj.RestoreExpires.IsZero() && now.After(obj.RestoreExpires) {
+		if obj.VersionID != "" {
+			return DeleteRestoredVersionAction, ""
 		}
+		return DeleteRestoredAction, ""
 	}
-	return action
+	return action, ruleID
 }
 
 // ExpectedExpiryTime calculates the expiry, transition or restore date/time based on a object modtime.
@@ -490,15 +514,22 @@ func (lc Lifecycle) SetPredictionHeaders(w http.ResponseWriter, obj ObjectOpts)
 
 // TransitionTier returns remote tier that applies to obj per ILM rules.
 func (lc Lifecycle) TransitionTier(obj ObjectOpts) string {
+	_, tier := lc.TransitionRule(obj)
+	return tier
+}
+
+// TransitionRule returns the ID of the ILM rule transitioning obj and
+// the remote tier it applies.
+func (lc Lifecycle) TransitionRule(obj ObjectOpts) (ruleID, tier string) {
 	for _, rule := range lc.FilterActionableRules(obj) {
 		if obj.IsLatest && rule.Transition.StorageClass != "" {
-			return rule.Transition.StorageClass
+			return rule.ID, rule.Transition.StorageClass
 		}
 		if !obj.IsLatest && rule.NoncurrentVersionTransition.StorageClass != "" {
-			return rule.NoncurrentVersionTransition.StorageClass
+			return rule.ID, rule.NoncurrentVersionTransition.StorageClass
 		}
 	}
-	return ""
+	return "", ""
 }
 
 // NoncurrentVersionsExpirationLimit returns the maximum limit on number of
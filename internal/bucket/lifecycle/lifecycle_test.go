@@ -227,6 +227,7 @@ func TestComputeActions(t *testing.T) {
 		objectName             string
 		objectTags             string
 		objectModTime          time.Time
+		objectSize             int64
 		isExpiredDelMarker     bool
 		expectedAction         Action
 		isNoncurrent           bool
@@ -431,6 +432,38 @@ func TestComputeActions(t *testing.T) {
 			isNoncurrent:           true,
 			expectedAction:         DeleteVersionAction,
 		},
+		// Object larger than ObjectSizeGreaterThan should be expired
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "fooobject",
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			objectSize:     2048,
+			expectedAction: DeleteAction,
+		},
+		// Object smaller than ObjectSizeGreaterThan should not be expired
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "fooobject",
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			objectSize:     512,
+			expectedAction: NoneAction,
+		},
+		// Object within the And object size range and with matching prefix should be expired
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Prefix>foodir/</Prefix><ObjectSizeLessThan>1024</ObjectSizeLessThan></And></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			objectSize:     512,
+			expectedAction: DeleteAction,
+		},
+		// Expired delete markers are removed regardless of object size filters
+		{
+			inputConfig:        `<LifecycleConfiguration><Rule><Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:         "fooobject",
+			objectModTime:      time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			isExpiredDelMarker: true,
+			expectedAction:     DeleteVersionAction,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -443,6 +476,7 @@ func TestComputeActions(t *testing.T) {
 			if resultAction := lc.ComputeAction(ObjectOpts{
 				Name:             tc.objectName,
 				UserTags:         tc.objectTags,
+				Size:             tc.objectSize,
 				ModTime:          tc.objectModTime,
 				DeleteMarker:     tc.isExpiredDelMarker,
 				NumVersions:      1,
@@ -457,6 +491,45 @@ func TestComputeActions(t *testing.T) {
 	}
 }
 
+func TestComputeActionRestoredExpiry(t *testing.T) {
+	lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	restored := func(name, versionID string, isLatest bool, expires time.Time) ObjectOpts {
+		return ObjectOpts{
+			Name:             name,
+			ModTime:          now.Add(-time.Hour),
+			VersionID:        versionID,
+			IsLatest:         isLatest,
+			NumVersions:      2,
+			SuccessorModTime: now.Add(-time.Minute),
+			TransitionStatus: TransitionComplete,
+			RestoreExpires:   expires,
+		}
+	}
+
+	testCases := []struct {
+		lc       Lifecycle
+		obj      ObjectOpts
+		expected Action
+	}{
+		{*lc, restored("foodir/obj", "", true, now.Add(-time.Second)), DeleteRestoredAction},
+		{*lc, restored("foodir/obj", "", true, now.Add(time.Hour)), NoneAction},
+		// Restored copies expire whether or not rules apply to them.
+		{*lc, restored("bardir/obj", "", true, now.Add(-time.Second)), DeleteRestoredAction},
+		{Lifecycle{}, restored("obj", "v1", true, now.Add(-time.Second)), DeleteRestoredVersionAction},
+		{Lifecycle{}, restored("obj", "v1", false, now.Add(-time.Second)), DeleteRestoredVersionAction},
+		{Lifecycle{}, restored("obj", "v1", false, time.Time{}), NoneAction},
+	}
+	for i, testCase := range testCases {
+		if action := testCase.lc.ComputeActionAt(testCase.obj, now); action != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, action)
+		}
+	}
+}
+
 func TestHasActiveRules(t *testing.T) {
 	testCases := []struct {
 		inputConfig    string
@@ -641,6 +714,9 @@ func TestTransitionTier(t *testing.T) {
 	if got := lc.TransitionTier(obj2); got != "TIER-2" {
 		t.Fatalf("Expected TIER-2 but got %s", got)
 	}
+	if ruleID, tier := lc.TransitionRule(obj2); ruleID != "rule-2" || tier != "TIER-2" {
+		t.Fatalf("Expected rule-2 and TIER-2 but got %s and %s", ruleID, tier)
+	}
 }
 
 func TestNoncurrentVersionsLimit(t *testing.T) {
@@ -27,6 +27,8 @@ var (
 	errTransitionInvalidDate     = Errorf("Date must be provided in ISO 8601 format")
 	errTransitionInvalid         = Errorf("Exactly one of Days (0 or greater) or Date (positive ISO 8601 format) should be present in Transition.")
 	errTransitionDateNotMidnight = Errorf("'Date' must be at midnight GMT")
+
+	errTransitionInvalidLastAccess = Errorf("DaysSinceLastAccess cannot be combined with Days or Date in Transition")
 )
 
 // TransitionDate is a embedded type containing time.Time to unmarshal
@@ -103,6 +105,10 @@ type Transition struct {
 	Date         TransitionDate `xml:"Date,omitempty"`
 	StorageClass string         `xml:"StorageClass,omitempty"`
 
+	// DaysSinceLastAccess is a MinIO extension which transitions
+	// objects that have not been read for the given number of days.
+	DaysSinceLastAccess TransitionDays `xml:"DaysSinceLastAccess,omitempty"`
+
 	set bool
 }
 
@@ -143,6 +149,10 @@ func (t Transition) Validate() error {
 		return errTransitionInvalid
 	}
 
+	if t.DaysSinceLastAccess > 0 && (!t.IsDateNull() || t.Days > 0) {
+		return errTransitionInvalidLastAccess
+	}
+
 	if t.StorageClass == "" {
 		return errXMLNotWellFormed
 	}
@@ -170,6 +180,16 @@ func (t Transition) NextDue(obj ObjectOpts) (time.Time, bool) {
 		return t.Date.Time, true
 	}
 
+	// Objects which were never read since last access tracking was
+	// enabled are considered last accessed at their creation.
+	if t.DaysSinceLastAccess > 0 {
+		lastAccess := obj.LastAccessTime
+		if lastAccess.IsZero() || lastAccess.Before(obj.ModTime) {
+			lastAccess = obj.ModTime
+		}
+		return ExpectedExpiryTime(lastAccess, int(t.DaysSinceLastAccess)), true
+	}
+
 	// Days == 0 indicates immediate tiering, i.e object is eligible for tiering since its creation.
 	if t.Days == 0 {
 		return obj.ModTime, true
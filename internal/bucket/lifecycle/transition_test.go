@@ -20,6 +20,7 @@ package lifecycle
 import (
 	"encoding/xml"
 	"testing"
+	"time"
 )
 
 func TestTransitionUnmarshalXML(t *testing.T) {
@@ -48,6 +49,21 @@ func TestTransitionUnmarshalXML(t *testing.T) {
 		  </Transition>`,
 			err: errXMLNotWellFormed,
 		},
+		{
+			input: `<Transition>
+			<DaysSinceLastAccess>30</DaysSinceLastAccess>
+			<StorageClass>S3TIER-1</StorageClass>
+		  </Transition>`,
+			err: nil,
+		},
+		{
+			input: `<Transition>
+			<Days>1</Days>
+			<DaysSinceLastAccess>30</DaysSinceLastAccess>
+			<StorageClass>S3TIER-1</StorageClass>
+		  </Transition>`,
+			err: errTransitionInvalidLastAccess,
+		},
 	}
 
 	for i, tc := range trTests {
@@ -91,3 +107,41 @@ func TestTransitionUnmarshalXML(t *testing.T) {
 		}
 	}
 }
+
+func TestTransitionNextDueLastAccess(t *testing.T) {
+	tr := Transition{DaysSinceLastAccess: 30, StorageClass: "S3TIER-1", set: true}
+	modTime := time.Date(2021, 1, 1, 13, 0, 0, 0, time.UTC)
+	accessTime := time.Date(2021, 3, 1, 13, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		obj      ObjectOpts
+		expected time.Time
+	}{
+		// never accessed, falls back to creation time
+		{ObjectOpts{IsLatest: true, ModTime: modTime}, ExpectedExpiryTime(modTime, 30)},
+		{ObjectOpts{IsLatest: true, ModTime: modTime, LastAccessTime: accessTime}, ExpectedExpiryTime(accessTime, 30)},
+		// access times older than the object are ignored
+		{ObjectOpts{IsLatest: true, ModTime: accessTime, LastAccessTime: modTime}, ExpectedExpiryTime(accessTime, 30)},
+	}
+	for i, tc := range testCases {
+		due, ok := tr.NextDue(tc.obj)
+		if !ok || !due.Equal(tc.expected) {
+			t.Fatalf("%d: expected %v, got %v (%v)", i+1, tc.expected, due, ok)
+		}
+	}
+	if _, ok := tr.NextDue(ObjectOpts{ModTime: modTime}); ok {
+		t.Fatal("expected non-current versions to be ignored")
+	}
+
+	lc := Lifecycle{Rules: []Rule{{ID: "cold", Status: Enabled, Transition: tr}}}
+	if !lc.HasLastAccessTransition() {
+		t.Fatal("expected lifecycle to use last access times")
+	}
+	recent := ObjectOpts{Name: "obj", IsLatest: true, ModTime: modTime, LastAccessTime: time.Now().UTC()}
+	if action := lc.ComputeAction(recent); action != NoneAction {
+		t.Fatalf("expected recently read object to stay, got %v", action)
+	}
+	if action := lc.ComputeAction(ObjectOpts{Name: "obj", IsLatest: true, ModTime: modTime}); action != TransitionAction {
+		t.Fatalf("expected cold object to transition, got %v", action)
+	}
+}
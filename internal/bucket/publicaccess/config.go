@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package publicaccess
+
+import (
+	"fmt"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Deployment wide public access block keys and environment variables
+const (
+	BlockPublicAcls       = "block_public_acls"
+	IgnorePublicAcls      = "ignore_public_acls"
+	BlockPublicPolicy     = "block_public_policy"
+	RestrictPublicBuckets = "restrict_public_buckets"
+
+	EnvBlockPublicAcls       = "MINIO_PUBLIC_ACCESS_BLOCK_BLOCK_PUBLIC_ACLS"
+	EnvIgnorePublicAcls      = "MINIO_PUBLIC_ACCESS_BLOCK_IGNORE_PUBLIC_ACLS"
+	EnvBlockPublicPolicy     = "MINIO_PUBLIC_ACCESS_BLOCK_BLOCK_PUBLIC_POLICY"
+	EnvRestrictPublicBuckets = "MINIO_PUBLIC_ACCESS_BLOCK_RESTRICT_PUBLIC_BUCKETS"
+)
+
+var (
+	// DefaultKVS - default KV config for the deployment wide public
+	// access block
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   BlockPublicAcls,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   IgnorePublicAcls,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   BlockPublicPolicy,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   RestrictPublicBuckets,
+			Value: config.EnableOff,
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         BlockPublicAcls,
+			Description: `set to 'on' to reject requests setting public canned ACLs on any bucket`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         IgnorePublicAcls,
+			Description: `set to 'on' to ignore public ACLs on any bucket`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         BlockPublicPolicy,
+			Description: `set to 'on' to reject bucket policies granting public access on any bucket`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         RestrictPublicBuckets,
+			Description: `set to 'on' to deny anonymous access granted by bucket policies on any bucket`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.PublicAccessBlockSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	for _, setting := range []struct {
+		key, env string
+		value    *bool
+	}{
+		{BlockPublicAcls, EnvBlockPublicAcls, &cfg.BlockPublicAcls},
+		{IgnorePublicAcls, EnvIgnorePublicAcls, &cfg.IgnorePublicAcls},
+		{BlockPublicPolicy, EnvBlockPublicPolicy, &cfg.BlockPublicPolicy},
+		{RestrictPublicBuckets, EnvRestrictPublicBuckets, &cfg.RestrictPublicBuckets},
+	} {
+		*setting.value, err = config.ParseBool(env.Get(setting.env, kvs.GetWithDefault(setting.key, DefaultKVS)))
+		if err != nil {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.PublicAccessBlockSubSys, setting.key, err)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package publicaccess
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// ErrMalformedXML - returned when the configuration carries no
+// PublicAccessBlockConfiguration element.
+var ErrMalformedXML = errors.New("malformed public access block configuration")
+
+// Config - PublicAccessBlockConfiguration of a bucket or of the whole
+// deployment.
+//
+// ACLs other than 'private' are never honored by the server, so
+// IgnorePublicAcls is always in effect and BlockPublicAcls only
+// rejects requests carrying a public canned ACL.
+type Config struct {
+	XMLNS                 string   `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name `xml:"PublicAccessBlockConfiguration"`
+	BlockPublicAcls       bool     `xml:"BlockPublicAcls"`
+	IgnorePublicAcls      bool     `xml:"IgnorePublicAcls"`
+	BlockPublicPolicy     bool     `xml:"BlockPublicPolicy"`
+	RestrictPublicBuckets bool     `xml:"RestrictPublicBuckets"`
+}
+
+// Merge - returns the settings in effect for a bucket, a setting is
+// enabled if it is enabled at either level.
+func (c Config) Merge(other Config) Config {
+	c.BlockPublicAcls = c.BlockPublicAcls || other.BlockPublicAcls
+	c.IgnorePublicAcls = c.IgnorePublicAcls || other.IgnorePublicAcls
+	c.BlockPublicPolicy = c.BlockPublicPolicy || other.BlockPublicPolicy
+	c.RestrictPublicBuckets = c.RestrictPublicBuckets || other.RestrictPublicBuckets
+	return c
+}
+
+// IsPublicACL - returns true if the canned ACL grants access to
+// principals other than the owner.
+func IsPublicACL(acl string) bool {
+	switch acl {
+	case "public-read", "public-read-write", "authenticated-read":
+		return true
+	}
+	return false
+}
+
+// ParseConfig - parses data in given reader to PublicAccessBlockConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if c.XMLName.Local != "PublicAccessBlockConfiguration" {
+		return nil, ErrMalformedXML
+	}
+	return &c, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package publicaccess
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		expected  Config
+		expectErr bool
+	}{
+		{
+			input:    `<PublicAccessBlockConfiguration><BlockPublicAcls>true</BlockPublicAcls><RestrictPublicBuckets>true</RestrictPublicBuckets></PublicAccessBlockConfiguration>`,
+			expected: Config{BlockPublicAcls: true, RestrictPublicBuckets: true},
+		},
+		{
+			input:    `<PublicAccessBlockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><BlockPublicPolicy>true</BlockPublicPolicy></PublicAccessBlockConfiguration>`,
+			expected: Config{BlockPublicPolicy: true},
+		},
+		{
+			input:     `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`,
+			expectErr: true,
+		},
+		{
+			input:     `<PublicAccessBlockConfiguration><BlockPublicAcls>maybe</BlockPublicAcls></PublicAccessBlockConfiguration>`,
+			expectErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		c, err := ParseConfig(strings.NewReader(testCase.input))
+		if testCase.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+			continue
+		}
+		c.XMLNS, c.XMLName = "", testCase.expected.XMLName
+		if *c != testCase.expected {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, *c)
+		}
+	}
+}
+
+func TestConfigMerge(t *testing.T) {
+	account := Config{BlockPublicPolicy: true}
+	bucket := Config{RestrictPublicBuckets: true}
+	merged := bucket.Merge(account)
+	if !merged.BlockPublicPolicy || !merged.RestrictPublicBuckets || merged.BlockPublicAcls {
+		t.Fatalf("unexpected merged config %+v", merged)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/minio/pkg/wildcard"
 )
@@ -37,9 +38,52 @@ type Destination struct {
 	Bucket       string   `xml:"Bucket" json:"Bucket"`
 	StorageClass string   `xml:"StorageClass" json:"StorageClass"`
 	ARN          string
+	// Metrics enables replication threshold events for the destination.
+	Metrics *Metrics `xml:"Metrics,omitempty" json:"Metrics,omitempty"`
 	// EncryptionConfiguration TODO: not needed for MinIO
 }
 
+// DefaultEventThreshold is the replication event threshold of enabled
+// metrics without EventThreshold.
+const DefaultEventThreshold = 15 * time.Minute
+
+// EventThreshold - age of a version in minutes after which it has
+// missed the replication threshold.
+type EventThreshold struct {
+	Minutes int `xml:"Minutes" json:"Minutes"`
+}
+
+// Metrics - replication metrics settings of a destination. Versions
+// not replicated within EventThreshold emit an OperationMissedThreshold
+// event, followed by an OperationReplicatedAfterThreshold event once they
+// are replicated.
+type Metrics struct {
+	Status         Status          `xml:"Status" json:"Status"`
+	EventThreshold *EventThreshold `xml:"EventThreshold,omitempty" json:"EventThreshold,omitempty"`
+}
+
+// Validate validates the metrics settings.
+func (m Metrics) Validate() error {
+	if m.Status != Enabled && m.Status != Disabled {
+		return errInvalidMetricsStatus
+	}
+	if m.EventThreshold != nil && m.EventThreshold.Minutes <= 0 {
+		return errInvalidEventThreshold
+	}
+	return nil
+}
+
+// Threshold returns the event threshold, zero if metrics are disabled.
+func (m *Metrics) Threshold() time.Duration {
+	if m == nil || m.Status != Enabled {
+		return 0
+	}
+	if m.EventThreshold == nil {
+		return DefaultEventThreshold
+	}
+	return time.Duration(m.EventThreshold.Minutes) * time.Minute
+}
+
 func (d Destination) isValidStorageClass() bool {
 	if d.StorageClass == "" {
 		return true
@@ -81,6 +125,11 @@ func (d Destination) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 			return err
 		}
 	}
+	if d.Metrics != nil {
+		if err := e.EncodeElement(d.Metrics, xml.StartElement{Name: xml.Name{Local: "Metrics"}}); err != nil {
+			return err
+		}
+	}
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
@@ -105,6 +154,12 @@ func (d *Destination) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) (er
 		}
 	}
 	parsedDest.StorageClass = dest.StorageClass
+	if dest.Metrics != nil {
+		if err := dest.Metrics.Validate(); err != nil {
+			return err
+		}
+	}
+	parsedDest.Metrics = dest.Metrics
 	*d = parsedDest
 	return nil
 }
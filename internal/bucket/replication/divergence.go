@@ -0,0 +1,70 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (r Rule) name() string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return fmt.Sprintf("with priority %d", r.Priority)
+}
+
+func (r Rule) deleteReplication() (deleteMarkers, versions, replicaDeletes bool) {
+	return r.DeleteMarkerReplication.Status == Enabled,
+		r.DeleteReplication.Status == Enabled,
+		r.SourceSelectionCriteria.ReplicaDeletes.Status != Disabled
+}
+
+// DivergenceWarnings returns human readable warnings for delete replication
+// settings that are valid but let the source and the destination diverge.
+func (c Config) DivergenceWarnings() []string {
+	var warnings []string
+	for i, r := range c.Rules {
+		if r.Status == Disabled {
+			continue
+		}
+		deleteMarkers, versions, replicaDeletes := r.deleteReplication()
+		switch {
+		case deleteMarkers && !versions:
+			warnings = append(warnings, fmt.Sprintf("rule %s replicates delete markers but not their removal, objects restored on the source stay hidden on the destination", r.name()))
+		case versions && !deleteMarkers:
+			warnings = append(warnings, fmt.Sprintf("rule %s replicates version deletes but not delete markers, objects deleted on the source stay visible on the destination", r.name()))
+		}
+		if !replicaDeletes && (deleteMarkers || versions) {
+			warnings = append(warnings, fmt.Sprintf("rule %s does not replicate deletes of replicas, in active-active setups replicas deleted here remain on the other site", r.name()))
+		}
+		for _, o := range c.Rules[i+1:] {
+			if o.Status == Disabled || o.Destination.String() != r.Destination.String() {
+				continue
+			}
+			if !strings.HasPrefix(r.Prefix(), o.Prefix()) && !strings.HasPrefix(o.Prefix(), r.Prefix()) {
+				continue
+			}
+			om, ov, or := o.deleteReplication()
+			if om != deleteMarkers || ov != versions || or != replicaDeletes {
+				warnings = append(warnings, fmt.Sprintf("overlapping rules %s and %s for the same destination disagree on delete replication, the higher priority rule applies", r.name(), o.name()))
+			}
+		}
+	}
+	return warnings
+}
@@ -19,6 +19,7 @@ package replication
 
 import (
 	"encoding/xml"
+	"net/url"
 )
 
 var errInvalidFilter = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
@@ -29,13 +30,23 @@ type Filter struct {
 	Prefix  string
 	And     And
 	Tag     Tag
+	// MinIO extensions, applied in addition to the above. Objects
+	// matching any of ExcludeTags or ExcludeMetadata are never
+	// replicated, Metadata entries must all match.
+	ExcludeTags     []Match `xml:"ExcludeTag,omitempty" json:"ExcludeTag,omitempty"`
+	Metadata        []Match `xml:"Metadata,omitempty" json:"Metadata,omitempty"`
+	ExcludeMetadata []Match `xml:"ExcludeMetadata,omitempty" json:"ExcludeMetadata,omitempty"`
 	// Caching tags, only once
 	cachedTags map[string]struct{}
 }
 
 // IsEmpty returns true if filter is not set
 func (f Filter) IsEmpty() bool {
-	return f.And.isEmpty() && f.Tag.IsEmpty() && f.Prefix == ""
+	return f.And.isEmpty() && f.Tag.IsEmpty() && f.Prefix == "" && !f.hasMatches()
+}
+
+func (f Filter) hasMatches() bool {
+	return len(f.ExcludeTags) > 0 || len(f.Metadata) > 0 || len(f.ExcludeMetadata) > 0
 }
 
 // MarshalXML - produces the xml representation of the Filter struct
@@ -61,6 +72,21 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		}
 	}
 
+	for _, m := range []struct {
+		name    string
+		matches []Match
+	}{
+		{"ExcludeTag", f.ExcludeTags},
+		{"Metadata", f.Metadata},
+		{"ExcludeMetadata", f.ExcludeMetadata},
+	} {
+		for _, match := range m.matches {
+			if err := e.EncodeElement(match, xml.StartElement{Name: xml.Name{Local: m.name}}); err != nil {
+				return err
+			}
+		}
+	}
+
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
@@ -88,9 +114,46 @@ func (f Filter) Validate() error {
 			return err
 		}
 	}
+	for _, matches := range [][]Match{f.ExcludeTags, f.Metadata, f.ExcludeMetadata} {
+		if err := validateMatches(matches); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// TestMatches tests the object tags and user metadata against the
+// ExcludeTag, Metadata and ExcludeMetadata extensions, it returns true
+// if there are none in the underlying Filter.
+func (f Filter) TestMatches(userTags string, meta map[string]string) bool {
+	if !f.hasMatches() {
+		return true
+	}
+	if len(f.ExcludeTags) > 0 {
+		tags, err := url.ParseQuery(userTags)
+		if err != nil {
+			// Unparsable tags cannot be proven to not be excluded.
+			return false
+		}
+		for _, m := range f.ExcludeTags {
+			if m.matchTags(tags) {
+				return false
+			}
+		}
+	}
+	for _, m := range f.ExcludeMetadata {
+		if m.matchMetadata(meta) {
+			return false
+		}
+	}
+	for _, m := range f.Metadata {
+		if !m.matchMetadata(meta) {
+			return false
+		}
+	}
+	return true
+}
+
 // TestTags tests if the object tags satisfy the Filter tags requirement,
 // it returns true if there is no tags in the underlying Filter.
 func (f *Filter) TestTags(ttags []string) bool {
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replication
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+const userMetadataPrefix = "x-amz-meta-"
+
+var (
+	errInvalidMatchKey   = Errorf("The Key of ExcludeTag, Metadata or ExcludeMetadata filters must not be empty")
+	errInvalidMatchValue = Errorf("The Value of ExcludeTag, Metadata or ExcludeMetadata filters is invalid")
+)
+
+// Match - a MinIO extension to match an object tag or user metadata
+// entry in a replication rule filter. Value is a wildcard pattern, so
+// `*` matches any value of Key.
+type Match struct {
+	Key   string `xml:"Key" json:"Key"`
+	Value string `xml:"Value" json:"Value"`
+}
+
+// Validate checks this match.
+func (m Match) Validate() error {
+	if m.Key == "" || utf8.RuneCountInString(m.Key) > 128 {
+		return errInvalidMatchKey
+	}
+	if utf8.RuneCountInString(m.Value) > 256 {
+		return errInvalidMatchValue
+	}
+	return nil
+}
+
+// matchTags returns true if any of the object tags matches.
+func (m Match) matchTags(tags url.Values) bool {
+	values, ok := tags[m.Key]
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if wildcard.MatchSimple(m.Value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMetadata returns true if the user metadata entry matches. Keys
+// are compared case-insensitively, with or without the x-amz-meta-
+// prefix.
+func (m Match) matchMetadata(meta map[string]string) bool {
+	key := strings.TrimPrefix(strings.ToLower(m.Key), userMetadataPrefix)
+	for k, v := range meta {
+		k = strings.ToLower(k)
+		if !strings.HasPrefix(k, userMetadataPrefix) || k[len(userMetadataPrefix):] != key {
+			continue
+		}
+		if wildcard.MatchSimple(m.Value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateMatches(matches []Match) error {
+	for _, m := range matches {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
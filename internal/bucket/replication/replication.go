@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
@@ -60,6 +61,9 @@ func ParseConfig(reader io.Reader) (*Config, error) {
 				ReplicaModifications: ReplicaModifications{
 					Status: Enabled,
 				},
+				ReplicaDeletes: ReplicaDeletes{
+					Status: Enabled,
+				},
 			}
 		}
 	}
@@ -136,6 +140,7 @@ func (t Type) Valid() bool {
 type ObjectOpts struct {
 	Name           string
 	UserTags       string
+	UserMetadata   map[string]string
 	VersionID      string
 	DeleteMarker   bool
 	SSEC           bool
@@ -171,6 +176,9 @@ func (c Config) FilterActionableRules(obj ObjectOpts) []Rule {
 		if !strings.HasPrefix(obj.Name, rule.Prefix()) {
 			continue
 		}
+		if !rule.Filter.TestMatches(obj.UserTags, obj.UserMetadata) {
+			continue
+		}
 		if rule.Filter.TestTags(strings.Split(obj.UserTags, "&")) {
 			rules = append(rules, rule)
 		}
@@ -203,6 +211,9 @@ func (c Config) Replicate(obj ObjectOpts) bool {
 			return false
 		}
 		if obj.OpType == DeleteReplicationType {
+			if !rule.ReplicaDeleteReplicate(obj) {
+				return false
+			}
 			switch {
 			case obj.VersionID != "":
 				// check MinIO extension for versioned deletes
@@ -216,6 +227,30 @@ func (c Config) Replicate(obj ObjectOpts) bool {
 	return false
 }
 
+// SyncReplication returns whether the object must be replicated synchronously
+// to obj.TargetArn, and the timeout after which replication continues
+// asynchronously, zero if it has none.
+func (c Config) SyncReplication(obj ObjectOpts) (bool, time.Duration) {
+	rules := c.FilterActionableRules(obj)
+	if len(rules) == 0 {
+		return false, 0
+	}
+	sync := rules[0].SyncReplication
+	return sync.Status == Enabled, sync.timeout()
+}
+
+// EventThreshold returns the age after which obj has missed the
+// replication threshold of obj.TargetArn, zero if no matching rule has
+// metrics enabled for the target.
+func (c Config) EventThreshold(obj ObjectOpts) time.Duration {
+	for _, rule := range c.FilterActionableRules(obj) {
+		if threshold := rule.Destination.Metrics.Threshold(); threshold > 0 {
+			return threshold
+		}
+	}
+	return 0
+}
+
 // HasActiveRules - returns whether replication policy has active rules
 // Optionally a prefix can be supplied.
 // If recursive is specified the function will also return true if any level below the
@@ -244,11 +279,12 @@ func (c Config) HasActiveRules(prefix string, recursive bool) bool {
 	return false
 }
 
-// FilterTargetArns returns a slice of distinct target arns in the config
+// FilterTargetArns returns a slice of distinct target arns in the config,
+// ordered by the decreasing priority of their highest priority rule.
 func (c Config) FilterTargetArns(obj ObjectOpts) []string {
 	var arns []string
 
-	tgtsMap := make(map[string]struct{})
+	tgtsMap := make(map[string]int)
 	rules := c.FilterActionableRules(obj)
 	for _, rule := range rules {
 		if rule.Status == Disabled {
@@ -258,12 +294,18 @@ func (c Config) FilterTargetArns(obj ObjectOpts) []string {
 			arns = append(arns, c.RoleArn) // use legacy RoleArn if present
 			return arns
 		}
-		if _, ok := tgtsMap[rule.Destination.ARN]; !ok {
-			tgtsMap[rule.Destination.ARN] = struct{}{}
+		if p, ok := tgtsMap[rule.Destination.ARN]; !ok || rule.Priority > p {
+			tgtsMap[rule.Destination.ARN] = rule.Priority
 		}
 	}
 	for k := range tgtsMap {
 		arns = append(arns, k)
 	}
+	sort.Slice(arns, func(i, j int) bool {
+		if tgtsMap[arns[i]] != tgtsMap[arns[j]] {
+			return tgtsMap[arns[i]] > tgtsMap[arns[j]]
+		}
+		return arns[i] < arns[j]
+	})
 	return arns
 }
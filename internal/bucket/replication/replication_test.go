@@ -19,8 +19,10 @@ package replication
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestParseAndValidateReplicationConfig(t *testing.T) {
@@ -417,3 +419,177 @@ func TestFilterActionableRules(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterTargetArnsOrder(t *testing.T) {
+	rule := func(arn string, priority int) string {
+		return fmt.Sprintf(`<Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Prefix>prefix</Prefix><Priority>%d</Priority><Destination><Bucket>%s</Bucket></Destination></Rule>`, priority, arn)
+	}
+	inputConfig := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+		rule("arn:minio:replication:xxx::c", 1) +
+		rule("arn:minio:replication:xxx::a", 2) +
+		rule("arn:minio:replication:xxx::b", 5) +
+		rule("arn:minio:replication:xxx::a", 3) +
+		rule("arn:minio:replication:xxx::d", 1) +
+		`</ReplicationConfiguration>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(inputConfig)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"arn:minio:replication:xxx::b",
+		"arn:minio:replication:xxx::a",
+		"arn:minio:replication:xxx::c",
+		"arn:minio:replication:xxx::d",
+	}
+	for i := 0; i < 10; i++ {
+		if got := cfg.FilterTargetArns(ObjectOpts{Name: "prefix/object"}); fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestFilterActionableRulesMatches(t *testing.T) {
+	inputConfig := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Prefix>docs/</Prefix><ExcludeTag><Key>classification</Key><Value>sensitive*</Value></ExcludeTag></Filter><Priority>1</Priority><Destination><Bucket>arn:minio:replication:xxx::crossborder</Bucket></Destination></Rule><Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Filter><Metadata><Key>X-Amz-Meta-Region</Key><Value>eu-*</Value></Metadata><ExcludeMetadata><Key>legal-hold</Key><Value>*</Value></ExcludeMetadata></Filter><Priority>2</Priority><Destination><Bucket>arn:minio:replication:xxx::eu</Bucket></Destination></Rule></ReplicationConfiguration>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(inputConfig)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.Validate("bucket", false); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		opts         ObjectOpts
+		expectedArns []string
+	}{
+		{ObjectOpts{Name: "docs/a"}, []string{"arn:minio:replication:xxx::crossborder"}},
+		{ObjectOpts{Name: "docs/a", UserTags: "classification=sensitive-pii"}, nil},
+		{ObjectOpts{Name: "docs/a", UserTags: "classification=public"}, []string{"arn:minio:replication:xxx::crossborder"}},
+		{ObjectOpts{Name: "a", UserMetadata: map[string]string{"X-Amz-Meta-Region": "eu-west"}}, []string{"arn:minio:replication:xxx::eu"}},
+		{ObjectOpts{Name: "a", UserMetadata: map[string]string{"x-amz-meta-region": "us-east"}}, nil},
+		{ObjectOpts{Name: "a", UserMetadata: map[string]string{"X-Amz-Meta-Region": "eu-west", "X-Amz-Meta-Legal-Hold": "yes"}}, nil},
+		// Non user metadata must not be matched.
+		{ObjectOpts{Name: "a", UserMetadata: map[string]string{"Region": "eu-west"}}, nil},
+	}
+	for i, tc := range testCases {
+		var arns []string
+		for _, rule := range cfg.FilterActionableRules(tc.opts) {
+			arns = append(arns, rule.Destination.ARN)
+		}
+		if fmt.Sprint(arns) != fmt.Sprint(tc.expectedArns) {
+			t.Errorf("case %d: expected %v, got %v", i+1, tc.expectedArns, arns)
+		}
+	}
+
+	// The extensions must survive a round trip.
+	data, err := xml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("<ExcludeTag><Key>classification</Key><Value>sensitive*</Value></ExcludeTag>")) {
+		t.Fatalf("missing ExcludeTag in %s", data)
+	}
+
+	invalid := Filter{Metadata: []Match{{Value: "v"}}}
+	if err = invalid.Validate(); err != errInvalidMatchKey {
+		t.Fatalf("expected %v, got %v", errInvalidMatchKey, err)
+	}
+}
+
+func TestReplicaDeletesAndDivergenceWarnings(t *testing.T) {
+	inputConfig := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>logs</ID><Status>Enabled</Status><Priority>2</Priority><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><SourceSelectionCriteria><ReplicaDeletes><Status>Disabled</Status></ReplicaDeletes></SourceSelectionCriteria><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:minio:replication:xxx::dest</Bucket></Destination></Rule><Rule><ID>all</ID><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Enabled</Status></DeleteReplication><Filter><Prefix></Prefix></Filter><Destination><Bucket>arn:minio:replication:xxx::dest</Bucket></Destination></Rule></ReplicationConfiguration>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(inputConfig)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.Validate("bucket", false); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Rules[0].SourceSelectionCriteria.ReplicaModifications.Status != Enabled {
+		t.Fatal("expected replica modifications to default to Enabled")
+	}
+
+	testCases := []struct {
+		opts     ObjectOpts
+		expected bool
+	}{
+		{ObjectOpts{Name: "logs/a", DeleteMarker: true, OpType: DeleteReplicationType}, true},
+		{ObjectOpts{Name: "logs/a", DeleteMarker: true, OpType: DeleteReplicationType, Replica: true}, false},
+		{ObjectOpts{Name: "data/a", DeleteMarker: true, OpType: DeleteReplicationType, Replica: true}, true},
+		// Replica deletes only affect deletes.
+		{ObjectOpts{Name: "logs/a", Replica: true}, true},
+	}
+	for i, tc := range testCases {
+		tc.opts.TargetArn = "arn:minio:replication:xxx::dest"
+		if got := cfg.Replicate(tc.opts); got != tc.expected {
+			t.Errorf("case %d: expected %v, got %v", i+1, tc.expected, got)
+		}
+	}
+
+	warnings := cfg.DivergenceWarnings()
+	if len(warnings) != 3 {
+		t.Fatalf("expected 3 warnings, got %v", warnings)
+	}
+
+	data, err := xml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("<ReplicaDeletes><Status>Disabled</Status></ReplicaDeletes>")) {
+		t.Fatalf("missing ReplicaDeletes in %s", data)
+	}
+}
+
+func TestEventThreshold(t *testing.T) {
+	rule := func(arn, metrics string) string {
+		return fmt.Sprintf(`<Rule><Status>Enabled</Status><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Disabled</Status></DeleteReplication><Prefix>prefix</Prefix><Priority>1</Priority><Destination><Bucket>%s</Bucket>%s</Destination></Rule>`, arn, metrics)
+	}
+	inputConfig := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+		rule("arn:minio:replication:xxx::a", `<Metrics><Status>Enabled</Status><EventThreshold><Minutes>30</Minutes></EventThreshold></Metrics>`) +
+		rule("arn:minio:replication:xxx::b", `<Metrics><Status>Enabled</Status></Metrics>`) +
+		rule("arn:minio:replication:xxx::c", `<Metrics><Status>Disabled</Status></Metrics>`) +
+		rule("arn:minio:replication:xxx::d", "") +
+		`</ReplicationConfiguration>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(inputConfig)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		arn      string
+		expected time.Duration
+	}{
+		{"arn:minio:replication:xxx::a", 30 * time.Minute},
+		{"arn:minio:replication:xxx::b", DefaultEventThreshold},
+		{"arn:minio:replication:xxx::c", 0},
+		{"arn:minio:replication:xxx::d", 0},
+	}
+	for i, tc := range testCases {
+		if got := cfg.EventThreshold(ObjectOpts{Name: "prefix/object", TargetArn: tc.arn}); got != tc.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expected, got)
+		}
+	}
+
+	// Metrics must survive a round trip.
+	data, err := xml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = ParseConfig(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.EventThreshold(ObjectOpts{Name: "prefix/object", TargetArn: "arn:minio:replication:xxx::a"}); got != 30*time.Minute {
+		t.Errorf("expected 30m after round trip, got %s", got)
+	}
+
+	for _, metrics := range []string{
+		`<Metrics><Status>On</Status></Metrics>`,
+		`<Metrics><Status>Enabled</Status><EventThreshold><Minutes>0</Minutes></EventThreshold></Metrics>`,
+	} {
+		invalidConfig := `<ReplicationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+			rule("arn:minio:replication:xxx::a", metrics) + `</ReplicationConfiguration>`
+		if _, err := ParseConfig(bytes.NewReader([]byte(invalidConfig))); err == nil {
+			t.Errorf("expected an error for %s", metrics)
+		}
+	}
+}
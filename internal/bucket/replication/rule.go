@@ -20,6 +20,7 @@ package replication
 import (
 	"bytes"
 	"encoding/xml"
+	"time"
 )
 
 // Status represents Enabled/Disabled status
@@ -127,6 +128,34 @@ func (e *ExistingObjectReplication) UnmarshalXML(dec *xml.Decoder, start xml.Sta
 	return nil
 }
 
+// SyncReplication - whether writes wait for objects to be replicated - this is
+// a MinIO only extension. Once Timeout elapses, e.g. "30s", writes return and
+// replication continues asynchronously. Without Timeout writes wait until
+// replication completes.
+type SyncReplication struct {
+	Status  Status `xml:"Status" json:"Status"`
+	Timeout string `xml:"Timeout,omitempty" json:"Timeout,omitempty"`
+}
+
+// Validate validates the synchronous replication settings.
+func (s SyncReplication) Validate() error {
+	if s.Status != "" && s.Status != Enabled && s.Status != Disabled {
+		return errInvalidSyncReplicationStatus
+	}
+	if s.Timeout != "" {
+		if d, err := time.ParseDuration(s.Timeout); err != nil || d <= 0 {
+			return errInvalidSyncReplicationTimeout
+		}
+	}
+	return nil
+}
+
+// timeout returns the synchronous replication timeout, zero if unset.
+func (s SyncReplication) timeout() time.Duration {
+	d, _ := time.ParseDuration(s.Timeout)
+	return d
+}
+
 // Rule - a rule for replication configuration.
 type Rule struct {
 	XMLName                 xml.Name                `xml:"Rule" json:"Rule"`
@@ -140,6 +169,8 @@ type Rule struct {
 	SourceSelectionCriteria   SourceSelectionCriteria   `xml:"SourceSelectionCriteria" json:"SourceSelectionCriteria"`
 	Filter                    Filter                    `xml:"Filter" json:"Filter"`
 	ExistingObjectReplication ExistingObjectReplication `xml:"ExistingObjectReplication,omitempty" json:"ExistingObjectReplication,omitempty"`
+	// MinIO extension to replicate writes synchronously
+	SyncReplication SyncReplication `xml:"SyncReplication,omitempty" json:"SyncReplication,omitempty"`
 }
 
 var (
@@ -153,6 +184,10 @@ var (
 	errDeleteReplicationMissing               = Errorf("Delete replication must be specified")
 	errInvalidDeleteReplicationStatus         = Errorf("Delete replication is either enable|disable")
 	errInvalidExistingObjectReplicationStatus = Errorf("Existing object replication status is invalid")
+	errInvalidSyncReplicationStatus           = Errorf("Sync replication status is invalid")
+	errInvalidSyncReplicationTimeout          = Errorf("Sync replication timeout must be a positive duration")
+	errInvalidMetricsStatus                   = Errorf("Metrics status must be set to either Enabled or Disabled")
+	errInvalidEventThreshold                  = Errorf("Metrics event threshold must be a positive number of minutes")
 )
 
 // validateID - checks if ID is valid or not.
@@ -239,6 +274,9 @@ func (r Rule) Validate(bucket string, sameTarget bool) error {
 	if r.Destination.Bucket == bucket && sameTarget {
 		return errDestinationSourceIdentical
 	}
+	if err := r.SyncReplication.Validate(); err != nil {
+		return err
+	}
 	return r.ExistingObjectReplication.Validate()
 }
 
@@ -250,3 +288,12 @@ func (r Rule) MetadataReplicate(obj ObjectOpts) bool {
 	}
 	return obj.Replica && r.SourceSelectionCriteria.ReplicaModifications.Status == Enabled
 }
+
+// ReplicaDeleteReplicate returns true if the delete is not on a replica or in
+// the case of replicas, replica delete replication is enabled.
+func (r Rule) ReplicaDeleteReplicate(obj ObjectOpts) bool {
+	if !obj.Replica {
+		return true
+	}
+	return r.SourceSelectionCriteria.ReplicaDeletes.Status != Disabled
+}
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestMetadataReplicate(t *testing.T) {
@@ -70,3 +71,26 @@ func TestMetadataReplicate(t *testing.T) {
 
 	}
 }
+
+func TestSyncReplication(t *testing.T) {
+	testCases := []struct {
+		sync            SyncReplication
+		expectedErr     error
+		expectedTimeout time.Duration
+	}{
+		{SyncReplication{}, nil, 0},
+		{SyncReplication{Status: Enabled}, nil, 0},
+		{SyncReplication{Status: Enabled, Timeout: "30s"}, nil, 30 * time.Second},
+		{SyncReplication{Status: Enabled, Timeout: "-1s"}, errInvalidSyncReplicationTimeout, 0},
+		{SyncReplication{Status: Enabled, Timeout: "soon"}, errInvalidSyncReplicationTimeout, 0},
+		{SyncReplication{Status: "On"}, errInvalidSyncReplicationStatus, 0},
+	}
+	for i, tc := range testCases {
+		if err := tc.sync.Validate(); err != tc.expectedErr {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.expectedErr, err)
+		}
+		if tc.expectedErr == nil && tc.sync.timeout() != tc.expectedTimeout {
+			t.Fatalf("Test %d: expected timeout %s, got %s", i+1, tc.expectedTimeout, tc.sync.timeout())
+		}
+	}
+}
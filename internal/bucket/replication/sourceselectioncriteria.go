@@ -26,13 +26,27 @@ type ReplicaModifications struct {
 	Status Status `xml:"Status" json:"Status"`
 }
 
+// ReplicaDeletes specifies if deletes of replicas are replicated - this is
+// a MinIO only extension. In active-active setups disabling it keeps
+// deletes performed on one site from propagating back to the site the
+// objects were replicated from.
+type ReplicaDeletes struct {
+	Status Status `xml:"Status" json:"Status"`
+}
+
 // SourceSelectionCriteria - specifies additional source selection criteria in ReplicationConfiguration.
 type SourceSelectionCriteria struct {
 	ReplicaModifications ReplicaModifications `xml:"ReplicaModifications" json:"ReplicaModifications"`
+	ReplicaDeletes       ReplicaDeletes       `xml:"ReplicaDeletes,omitempty" json:"ReplicaDeletes,omitempty"`
 }
 
 // IsValid - checks whether SourceSelectionCriteria is valid or not.
 func (s SourceSelectionCriteria) IsValid() bool {
+	switch s.ReplicaDeletes.Status {
+	case "", Enabled, Disabled:
+	default:
+		return false
+	}
 	return s.ReplicaModifications.Status == Enabled || s.ReplicaModifications.Status == Disabled
 }
 
@@ -58,6 +72,9 @@ func (s *SourceSelectionCriteria) UnmarshalXML(dec *xml.Decoder, start xml.Start
 	if len(ssc.ReplicaModifications.Status) == 0 {
 		ssc.ReplicaModifications.Status = Enabled
 	}
+	if len(ssc.ReplicaDeletes.Status) == 0 {
+		ssc.ReplicaDeletes.Status = Enabled
+	}
 	*s = SourceSelectionCriteria(ssc)
 	return nil
 }
@@ -71,6 +88,11 @@ func (s SourceSelectionCriteria) MarshalXML(e *xml.Encoder, start xml.StartEleme
 		if err := e.EncodeElement(s.ReplicaModifications, xml.StartElement{Name: xml.Name{Local: "ReplicaModifications"}}); err != nil {
 			return err
 		}
+		if s.ReplicaDeletes.Status != "" {
+			if err := e.EncodeElement(s.ReplicaDeletes, xml.StartElement{Name: xml.Name{Local: "ReplicaDeletes"}}); err != nil {
+				return err
+			}
+		}
 	}
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
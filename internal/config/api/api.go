@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/pkg/env"
 )
@@ -39,6 +40,10 @@ const (
 	apiListQuorum                  = "list_quorum"
 	apiReplicationWorkers          = "replication_workers"
 	apiReplicationFailedWorkers    = "replication_failed_workers"
+	apiReplicationWorkerBandwidth  = "replication_worker_bandwidth"
+	apiReplicationAdaptive         = "replication_adaptive"
+	apiReplicationAdaptiveDiskUtil = "replication_adaptive_disk_util"
+	apiReplicationAdaptiveNetBW    = "replication_adaptive_net_bandwidth"
 	apiTransitionWorkers           = "transition_workers"
 	apiStaleUploadsCleanupInterval = "stale_uploads_cleanup_interval"
 	apiStaleUploadsExpiry          = "stale_uploads_expiry"
@@ -54,6 +59,10 @@ const (
 	EnvAPISecureCiphers            = "MINIO_API_SECURE_CIPHERS" // default "on"
 	EnvAPIReplicationWorkers       = "MINIO_API_REPLICATION_WORKERS"
 	EnvAPIReplicationFailedWorkers = "MINIO_API_REPLICATION_FAILED_WORKERS"
+	EnvAPIReplicationWorkerBW      = "MINIO_API_REPLICATION_WORKER_BANDWIDTH"
+	EnvAPIReplicationAdaptive      = "MINIO_API_REPLICATION_ADAPTIVE"
+	EnvAPIReplicationAdaptiveDisk  = "MINIO_API_REPLICATION_ADAPTIVE_DISK_UTIL"
+	EnvAPIReplicationAdaptiveNetBW = "MINIO_API_REPLICATION_ADAPTIVE_NET_BANDWIDTH"
 	EnvAPITransitionWorkers        = "MINIO_API_TRANSITION_WORKERS"
 
 	EnvAPIStaleUploadsCleanupInterval = "MINIO_API_STALE_UPLOADS_CLEANUP_INTERVAL"
@@ -104,6 +113,22 @@ var (
 			Key:   apiReplicationFailedWorkers,
 			Value: "8",
 		},
+		config.KV{
+			Key:   apiReplicationWorkerBandwidth,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiReplicationAdaptive,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiReplicationAdaptiveDiskUtil,
+			Value: "90",
+		},
+		config.KV{
+			Key:   apiReplicationAdaptiveNetBW,
+			Value: "0",
+		},
 		config.KV{
 			Key:   apiTransitionWorkers,
 			Value: "100",
@@ -137,6 +162,10 @@ type Config struct {
 	ListQuorum                  string        `json:"list_quorum"`
 	ReplicationWorkers          int           `json:"replication_workers"`
 	ReplicationFailedWorkers    int           `json:"replication_failed_workers"`
+	ReplicationWorkerBandwidth  uint64        `json:"replication_worker_bandwidth"`
+	ReplicationAdaptive         bool          `json:"replication_adaptive"`
+	ReplicationAdaptiveDiskUtil float64       `json:"replication_adaptive_disk_util"`
+	ReplicationAdaptiveNetBW    uint64        `json:"replication_adaptive_net_bandwidth"`
 	TransitionWorkers           int           `json:"transition_workers"`
 	StaleUploadsCleanupInterval time.Duration `json:"stale_uploads_cleanup_interval"`
 	StaleUploadsExpiry          time.Duration `json:"stale_uploads_expiry"`
@@ -233,6 +262,29 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		return cfg, config.ErrInvalidReplicationWorkersValue(nil).Msg("Minimum number of replication failed workers should be 1")
 	}
 
+	replicationWorkerBandwidth, err := humanize.ParseBytes(env.Get(EnvAPIReplicationWorkerBW, kvs.Get(apiReplicationWorkerBandwidth)))
+	if err != nil {
+		return cfg, err
+	}
+
+	replicationAdaptive, err := config.ParseBool(env.Get(EnvAPIReplicationAdaptive, kvs.Get(apiReplicationAdaptive)))
+	if err != nil {
+		return cfg, err
+	}
+
+	replicationAdaptiveDiskUtil, err := strconv.ParseFloat(env.Get(EnvAPIReplicationAdaptiveDisk, kvs.Get(apiReplicationAdaptiveDiskUtil)), 64)
+	if err != nil {
+		return cfg, err
+	}
+	if replicationAdaptiveDiskUtil <= 0 || replicationAdaptiveDiskUtil > 100 {
+		return cfg, errors.New("invalid replication adaptive disk utilization, expected a percentage between 1 and 100")
+	}
+
+	replicationAdaptiveNetBW, err := humanize.ParseBytes(env.Get(EnvAPIReplicationAdaptiveNetBW, kvs.Get(apiReplicationAdaptiveNetBW)))
+	if err != nil {
+		return cfg, err
+	}
+
 	transitionWorkers, err := strconv.Atoi(env.Get(EnvAPITransitionWorkers, kvs.Get(apiTransitionWorkers)))
 	if err != nil {
 		return cfg, err
@@ -272,6 +324,10 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		ListQuorum:                  listQuorum,
 		ReplicationWorkers:          replicationWorkers,
 		ReplicationFailedWorkers:    replicationFailedWorkers,
+		ReplicationWorkerBandwidth:  replicationWorkerBandwidth,
+		ReplicationAdaptive:         replicationAdaptive,
+		ReplicationAdaptiveDiskUtil: replicationAdaptiveDiskUtil,
+		ReplicationAdaptiveNetBW:    replicationAdaptiveNetBW,
 		TransitionWorkers:           transitionWorkers,
 		StaleUploadsCleanupInterval: staleUploadsCleanupInterval,
 		StaleUploadsExpiry:          staleUploadsExpiry,
@@ -70,6 +70,30 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         apiReplicationWorkerBandwidth,
+			Description: `set the maximum bandwidth per second of each replication worker e.g. "50MiB", defaults to "0" for unlimited`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiReplicationAdaptive,
+			Description: `set to "on" to reduce the number of active replication workers while the node is busy, defaults to "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiReplicationAdaptiveDiskUtil,
+			Description: `set the disk utilization percentage of the busiest drive above which adaptive replication backs off, defaults to 90`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiReplicationAdaptiveNetBW,
+			Description: `set the network bandwidth per second of the node above which adaptive replication backs off e.g. "1GiB", defaults to "0" for no limit`,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         apiTransitionWorkers,
 			Description: `set the number of transition workers, defaults to 100`,
@@ -55,52 +55,72 @@ const (
 	EnableOn  = madmin.EnableOn
 	EnableOff = madmin.EnableOff
 
-	RegionKey  = "region"
-	NameKey    = "name"
-	RegionName = "name"
-	AccessKey  = "access_key"
-	SecretKey  = "secret_key"
-	License    = "license" // Deprecated Dec 2021
-	APIKey     = "api_key"
+	RegionKey    = "region"
+	NameKey      = "name"
+	KMSKeyMapKey = "kms_key_map"
+	RegionName   = "name"
+	AccessKey    = "access_key"
+	SecretKey    = "secret_key"
+	License      = "license" // Deprecated Dec 2021
+	APIKey       = "api_key"
 )
 
 // Top level config constants.
 const (
-	CredentialsSubSys    = "credentials"
-	PolicyOPASubSys      = "policy_opa"
-	IdentityOpenIDSubSys = "identity_openid"
-	IdentityLDAPSubSys   = "identity_ldap"
-	IdentityTLSSubSys    = "identity_tls"
-	CacheSubSys          = "cache"
-	SiteSubSys           = "site"
-	RegionSubSys         = "region"
-	EtcdSubSys           = "etcd"
-	StorageClassSubSys   = "storage_class"
-	APISubSys            = "api"
-	CompressionSubSys    = "compression"
-	LoggerWebhookSubSys  = "logger_webhook"
-	AuditWebhookSubSys   = "audit_webhook"
-	AuditKafkaSubSys     = "audit_kafka"
-	HealSubSys           = "heal"
-	ScannerSubSys        = "scanner"
-	CrawlerSubSys        = "crawler"
-	SubnetSubSys         = "subnet"
+	CredentialsSubSys        = "credentials"
+	PolicyOPASubSys          = "policy_opa"
+	IdentityOpenIDSubSys     = "identity_openid"
+	IdentityLDAPSubSys       = "identity_ldap"
+	IdentityTLSSubSys        = "identity_tls"
+	CacheSubSys              = "cache"
+	SiteSubSys               = "site"
+	RegionSubSys             = "region"
+	EtcdSubSys               = "etcd"
+	StorageClassSubSys       = "storage_class"
+	APISubSys                = "api"
+	CompressionSubSys        = "compression"
+	LoggerWebhookSubSys      = "logger_webhook"
+	AuditWebhookSubSys       = "audit_webhook"
+	AuditKafkaSubSys         = "audit_kafka"
+	AuditSplunkSubSys        = "audit_splunk"
+	LoggerSyslogSubSys       = "logger_syslog"
+	AuditSyslogSubSys        = "audit_syslog"
+	HealSubSys               = "heal"
+	ScannerSubSys            = "scanner"
+	CrawlerSubSys            = "crawler"
+	SubnetSubSys             = "subnet"
+	CredentialRotationSubSys = "credential_rotation"
+	IdentityKerberosSubSys   = "identity_kerberos"
+	PublicAccessBlockSubSys  = "public_access_block"
+	IAMEventsSubSys          = "iam_events"
+	ReplConflictSubSys       = "replication_conflict"
+	TierCacheSubSys          = "tier_cache"
+	TierVerifySubSys         = "tier_verify"
+	ILMApprovalSubSys        = "ilm_approval"
+	TracingSubSys            = "tracing"
+	ProfilingSubSys          = "profiling"
+	MetricsSubSys            = "metrics"
+	LimitsSubSys             = "limits"
+	SpeedtestSubSys          = "speedtest"
 
 	// Add new constants here if you add new fields to config.
 )
 
 // Notification config constants.
 const (
-	NotifyKafkaSubSys    = "notify_kafka"
-	NotifyMQTTSubSys     = "notify_mqtt"
-	NotifyMySQLSubSys    = "notify_mysql"
-	NotifyNATSSubSys     = "notify_nats"
-	NotifyNSQSubSys      = "notify_nsq"
-	NotifyESSubSys       = "notify_elasticsearch"
-	NotifyAMQPSubSys     = "notify_amqp"
-	NotifyPostgresSubSys = "notify_postgres"
-	NotifyRedisSubSys    = "notify_redis"
-	NotifyWebhookSubSys  = "notify_webhook"
+	NotifyKafkaSubSys     = "notify_kafka"
+	NotifyMQTTSubSys      = "notify_mqtt"
+	NotifyMySQLSubSys     = "notify_mysql"
+	NotifyNATSSubSys      = "notify_nats"
+	NotifyNSQSubSys       = "notify_nsq"
+	NotifyPubSubSubSys    = "notify_pubsub"
+	NotifyESSubSys        = "notify_elasticsearch"
+	NotifyEventHubsSubSys = "notify_eventhubs"
+	NotifySQSSubSys       = "notify_sqs"
+	NotifyAMQPSubSys      = "notify_amqp"
+	NotifyPostgresSubSys  = "notify_postgres"
+	NotifyRedisSubSys     = "notify_redis"
+	NotifyWebhookSubSys   = "notify_webhook"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -118,6 +138,9 @@ var SubSystems = set.CreateStringSet(
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
 	AuditKafkaSubSys,
+	AuditSplunkSubSys,
+	LoggerSyslogSubSys,
+	AuditSyslogSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
@@ -126,15 +149,48 @@ var SubSystems = set.CreateStringSet(
 	HealSubSys,
 	NotifyAMQPSubSys,
 	NotifyESSubSys,
+	NotifyEventHubsSubSys,
 	NotifyKafkaSubSys,
 	NotifyMQTTSubSys,
 	NotifyMySQLSubSys,
 	NotifyNATSSubSys,
 	NotifyNSQSubSys,
+	NotifyPubSubSubSys,
 	NotifyPostgresSubSys,
 	NotifyRedisSubSys,
+	NotifySQSSubSys,
 	NotifyWebhookSubSys,
 	SubnetSubSys,
+	CredentialRotationSubSys,
+	IdentityKerberosSubSys,
+	PublicAccessBlockSubSys,
+	IAMEventsSubSys,
+	ReplConflictSubSys,
+	TierCacheSubSys,
+	TierVerifySubSys,
+	ILMApprovalSubSys,
+	TracingSubSys,
+	ProfilingSubSys,
+	MetricsSubSys,
+	LimitsSubSys,
+	SpeedtestSubSys,
+)
+
+// NotifySubSystems - all notification sub-systems.
+var NotifySubSystems = set.CreateStringSet(
+	NotifyAMQPSubSys,
+	NotifyESSubSys,
+	NotifyEventHubsSubSys,
+	NotifyKafkaSubSys,
+	NotifyMQTTSubSys,
+	NotifyMySQLSubSys,
+	NotifyNATSSubSys,
+	NotifyNSQSubSys,
+	NotifyPubSubSubSys,
+	NotifyPostgresSubSys,
+	NotifyRedisSubSys,
+	NotifySQSSubSys,
+	NotifyWebhookSubSys,
 )
 
 // SubSystemsDynamic - all sub-systems that have dynamic config.
@@ -144,7 +200,18 @@ var SubSystemsDynamic = set.CreateStringSet(
 	ScannerSubSys,
 	HealSubSys,
 	SubnetSubSys,
-)
+	CredentialRotationSubSys,
+	PublicAccessBlockSubSys,
+	IAMEventsSubSys,
+	ReplConflictSubSys,
+	TierVerifySubSys,
+	ILMApprovalSubSys,
+	TracingSubSys,
+	ProfilingSubSys,
+	MetricsSubSys,
+	LimitsSubSys,
+	SpeedtestSubSys,
+).Union(NotifySubSystems)
 
 // SubSystemsSingleTargets - subsystems which only support single target.
 var SubSystemsSingleTargets = set.CreateStringSet([]string{
@@ -162,6 +229,19 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	IdentityTLSSubSys,
 	HealSubSys,
 	ScannerSubSys,
+	CredentialRotationSubSys,
+	IdentityKerberosSubSys,
+	PublicAccessBlockSubSys,
+	IAMEventsSubSys,
+	ReplConflictSubSys,
+	TierCacheSubSys,
+	TierVerifySubSys,
+	ILMApprovalSubSys,
+	TracingSubSys,
+	ProfilingSubSys,
+	MetricsSubSys,
+	LimitsSubSys,
+	SpeedtestSubSys,
 }...)
 
 // Constant separators
@@ -477,6 +557,10 @@ var (
 			Key:   RegionKey,
 			Value: "",
 		},
+		KV{
+			Key:   KMSKeyMapKey,
+			Value: "",
+		},
 	}
 
 	DefaultRegionKVS = KVS{
@@ -501,10 +585,45 @@ func LookupCreds(kv KVS) (auth.Credentials, error) {
 	return auth.CreateCredentials(accessKey, secretKey)
 }
 
-// Site - holds site info - name and region.
+// Site - holds site info - name, region and KMS key translations.
 type Site struct {
 	Name   string
 	Region string
+	// KMSKeyMap translates KMS key IDs of bucket encryption
+	// configurations received from site replication peers into KMS
+	// key IDs of this site.
+	KMSKeyMap map[string]string
+}
+
+// LocalKMSKeyID returns the KMS key ID of this site the key ID used by
+// a site replication peer translates to. Key IDs without translation
+// are returned as is.
+func (s Site) LocalKMSKeyID(keyID string) string {
+	if localKeyID, ok := s.KMSKeyMap[keyID]; ok {
+		return localKeyID
+	}
+	return keyID
+}
+
+// parseKMSKeyMap parses a comma separated list of `peer-key:local-key`
+// KMS key ID translations.
+func parseKMSKeyMap(v string) (map[string]string, error) {
+	keyMap := make(map[string]string)
+	for _, kv := range strings.Split(v, ValueSeparator) {
+		if kv = strings.TrimSpace(kv); kv == "" {
+			continue
+		}
+		tokens := strings.SplitN(kv, ":", 2)
+		if len(tokens) != 2 || strings.TrimSpace(tokens[0]) == "" || strings.TrimSpace(tokens[1]) == "" {
+			return nil, Errorf("KMS key translation '%s' is invalid, expected 'peer-key:local-key'", kv)
+		}
+		peerKeyID, localKeyID := strings.TrimSpace(tokens[0]), strings.TrimSpace(tokens[1])
+		if _, ok := keyMap[peerKeyID]; ok {
+			return nil, Errorf("KMS key '%s' is translated more than once", peerKeyID)
+		}
+		keyMap[peerKeyID] = localKeyID
+	}
+	return keyMap, nil
 }
 
 var validRegionRegex = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9-_-]+$")
@@ -560,6 +679,8 @@ func LookupSite(siteKV KVS, regionKV KVS) (s Site, err error) {
 		}
 		s.Name = name
 	}
+
+	s.KMSKeyMap, err = parseKMSKeyMap(env.Get(EnvSiteKMSKeyMap, siteKV.Get(KMSKeyMapKey)))
 	return
 }
 
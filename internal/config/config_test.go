@@ -18,6 +18,7 @@
 package config
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/minio/madmin-go"
@@ -131,3 +132,38 @@ func TestValidRegion(t *testing.T) {
 		})
 	}
 }
+
+func TestParseKMSKeyMap(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected map[string]string
+		success  bool
+	}{
+		{value: "", expected: map[string]string{}, success: true},
+		{value: "site-a-key:site-b-key", expected: map[string]string{"site-a-key": "site-b-key"}, success: true},
+		{value: "a:b, c:d", expected: map[string]string{"a": "b", "c": "d"}, success: true},
+		{value: "a", success: false},
+		{value: "a:", success: false},
+		{value: "a:b,a:c", success: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			keyMap, err := parseKMSKeyMap(test.value)
+			if test.success != (err == nil) {
+				t.Fatalf("Expected success %t, got %v", test.success, err)
+			}
+			if test.success && !reflect.DeepEqual(keyMap, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, keyMap)
+			}
+		})
+	}
+
+	s := Site{KMSKeyMap: map[string]string{"a": "b"}}
+	if got := s.LocalKMSKeyID("a"); got != "b" {
+		t.Errorf("Expected b, got %s", got)
+	}
+	if got := s.LocalKMSKeyID("c"); got != "c" {
+		t.Errorf("Expected c, got %s", got)
+	}
+}
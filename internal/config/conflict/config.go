@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conflict
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Replication conflict resolution environment variables
+const (
+	Resolution   = "resolution"
+	SitePriority = "site_priority"
+
+	EnvResolution   = "MINIO_REPLICATION_CONFLICT_RESOLUTION"
+	EnvSitePriority = "MINIO_REPLICATION_CONFLICT_SITE_PRIORITY"
+)
+
+// Supported conflict resolutions
+const (
+	// LastWriterWins keeps the version with the latest source
+	// modification time as the latest version.
+	LastWriterWins = "last_writer_wins"
+	// SitePriorityWins keeps the version written on the site with the
+	// highest priority as the latest version.
+	SitePriorityWins = "site_priority"
+	// KeepBoth keeps both versions and tags the replicated one.
+	KeepBoth = "keep_both"
+)
+
+// Config represents how conflicting writes of active-active replication
+// are resolved. A conflict occurs when a replica arrives for an object
+// whose latest version was written locally and not replicated yet.
+type Config struct {
+	Resolution string `json:"resolution"`
+	// Site names ordered by decreasing priority.
+	SitePriority []string `json:"sitePriority"`
+}
+
+// LocalWins returns true if the version written on the local site wins
+// a conflict against a version written on the remote site by priority,
+// sites missing from the priority list have the lowest priority.
+func (c Config) LocalWins(local, remote string) bool {
+	priority := func(site string) int {
+		for i, s := range c.SitePriority {
+			if site != "" && s == site {
+				return i
+			}
+		}
+		return len(c.SitePriority)
+	}
+	return priority(local) < priority(remote)
+}
+
+var (
+	// DefaultKVS - default KV config for replication conflict resolution
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   Resolution,
+			Value: LastWriterWins,
+		},
+		config.KV{
+			Key:   SitePriority,
+			Value: "",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Resolution,
+			Description: `resolution of conflicting active-active writes, one of "last_writer_wins", "site_priority" or "keep_both"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SitePriority,
+			Description: `comma separated list of site names ordered by decreasing priority e.g. "site-a,site-b"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.ReplConflictSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Resolution = env.Get(EnvResolution, kvs.Get(Resolution))
+	switch cfg.Resolution {
+	case "":
+		cfg.Resolution = LastWriterWins
+	case LastWriterWins, SitePriorityWins, KeepBoth:
+	default:
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.ReplConflictSubSys, Resolution, cfg.Resolution)
+	}
+	for _, s := range strings.Split(env.Get(EnvSitePriority, kvs.Get(SitePriority)), config.ValueSeparator) {
+		if s = strings.TrimSpace(s); s != "" {
+			cfg.SitePriority = append(cfg.SitePriority, s)
+		}
+	}
+	if cfg.Resolution == SitePriorityWins && len(cfg.SitePriority) == 0 {
+		return cfg, fmt.Errorf("'%s:%s' must be set for site priority conflict resolution", config.ReplConflictSubSys, SitePriority)
+	}
+	return cfg, nil
+}
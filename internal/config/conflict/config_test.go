@@ -0,0 +1,72 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conflict
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		resolution   string
+		sitePriority string
+		success      bool
+	}{
+		{"", "", true},
+		{LastWriterWins, "", true},
+		{KeepBoth, "", true},
+		{SitePriorityWins, "site-a, site-b", true},
+		{SitePriorityWins, "", false},
+		{"first_writer_wins", "", false},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: Resolution, Value: testCase.resolution},
+			config.KV{Key: SitePriority, Value: testCase.sitePriority},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && cfg.Resolution == "" {
+			t.Fatalf("Test %d: expected a default resolution", i+1)
+		}
+	}
+}
+
+func TestLocalWins(t *testing.T) {
+	cfg := Config{Resolution: SitePriorityWins, SitePriority: []string{"site-a", "site-b"}}
+	testCases := []struct {
+		local, remote string
+		expected      bool
+	}{
+		{"site-a", "site-b", true},
+		{"site-b", "site-a", false},
+		{"site-b", "site-c", true},
+		{"site-c", "site-a", false},
+		// Unknown sites never win against each other.
+		{"site-c", "", false},
+	}
+	for i, testCase := range testCases {
+		if got := cfg.LocalWins(testCase.local, testCase.remote); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -50,8 +50,9 @@ const (
 	EnvArgs       = "MINIO_ARGS"
 	EnvDNSWebhook = "MINIO_DNS_WEBHOOK_ENDPOINT"
 
-	EnvSiteName   = "MINIO_SITE_NAME"
-	EnvSiteRegion = "MINIO_SITE_REGION"
+	EnvSiteName      = "MINIO_SITE_NAME"
+	EnvSiteRegion    = "MINIO_SITE_REGION"
+	EnvSiteKMSKeyMap = "MINIO_SITE_KMS_KEY_MAP"
 
 	EnvMinIOSubnetLicense      = "MINIO_SUBNET_LICENSE" // Deprecated Dec 2021
 	EnvMinIOSubnetAPIKey       = "MINIO_SUBNET_API_KEY"
@@ -61,6 +62,23 @@ const (
 
 	EnvUpdate = "MINIO_UPDATE"
 
+	EnvNotifyInternalEvents = "MINIO_NOTIFY_INTERNAL_EVENTS"
+
+	EnvEventJournalDir       = "MINIO_EVENT_JOURNAL_DIR"
+	EnvEventJournalRetention = "MINIO_EVENT_JOURNAL_RETENTION"
+
+	EnvEventDeadLetterDir   = "MINIO_EVENT_DEADLETTER_DIR"
+	EnvEventDeadLetterLimit = "MINIO_EVENT_DEADLETTER_LIMIT"
+
+	EnvDriveHealthInterval = "MINIO_DRIVE_HEALTH_INTERVAL"
+
+	EnvInternodeCA = "MINIO_INTERNODE_CA"
+	EnvFIPS        = "MINIO_FIPS"
+
+	EnvCertsReloadInterval = "MINIO_CERTS_RELOAD_INTERVAL"
+
+	EnvReplicationSTSTokenDir = "MINIO_REPLICATION_STS_TOKEN_DIR"
+
 	EnvKMSSecretKey     = "MINIO_KMS_SECRET_KEY"
 	EnvKMSSecretKeyFile = "MINIO_KMS_SECRET_KEY_FILE"
 	EnvKESEndpoint      = "MINIO_KMS_KES_ENDPOINT"
@@ -68,6 +86,34 @@ const (
 	EnvKESClientKey     = "MINIO_KMS_KES_KEY_FILE"
 	EnvKESClientCert    = "MINIO_KMS_KES_CERT_FILE"
 	EnvKESServerCA      = "MINIO_KMS_KES_CAPATH"
+	EnvKMIPEndpoint     = "MINIO_KMS_KMIP_ENDPOINT"
+	EnvKMIPKeyName      = "MINIO_KMS_KMIP_KEY_NAME"
+	EnvKMIPClientKey    = "MINIO_KMS_KMIP_KEY_FILE"
+	EnvKMIPClientCert   = "MINIO_KMS_KMIP_CERT_FILE"
+	EnvKMIPServerCA     = "MINIO_KMS_KMIP_CAPATH"
+	EnvKMIPVersion      = "MINIO_KMS_KMIP_VERSION"
+
+	EnvVaultEndpoint      = "MINIO_KMS_VAULT_ENDPOINT"
+	EnvVaultNamespace     = "MINIO_KMS_VAULT_NAMESPACE"
+	EnvVaultTransitMount  = "MINIO_KMS_VAULT_TRANSIT_MOUNT"
+	EnvVaultKeyName       = "MINIO_KMS_VAULT_KEY_NAME"
+	EnvVaultAppRoleID     = "MINIO_KMS_VAULT_APPROLE_ID"
+	EnvVaultAppRoleSecret = "MINIO_KMS_VAULT_APPROLE_SECRET"
+	EnvVaultAppRoleMount  = "MINIO_KMS_VAULT_APPROLE_MOUNT"
+	EnvVaultK8SRole       = "MINIO_KMS_VAULT_K8S_ROLE"
+	EnvVaultK8SJWTFile    = "MINIO_KMS_VAULT_K8S_JWT_FILE"
+	EnvVaultK8SMount      = "MINIO_KMS_VAULT_K8S_MOUNT"
+	EnvVaultServerCA      = "MINIO_KMS_VAULT_CAPATH"
+
+	EnvKMSDoubleEncryption = "MINIO_KMS_DOUBLE_ENCRYPTION"
+	EnvKMSAudit            = "MINIO_KMS_AUDIT"
+
+	EnvKMSBreaker             = "MINIO_KMS_BREAKER"
+	EnvKMSBreakerThreshold    = "MINIO_KMS_BREAKER_THRESHOLD"
+	EnvKMSBreakerCooldown     = "MINIO_KMS_BREAKER_COOLDOWN"
+	EnvKMSBreakerQueueTimeout = "MINIO_KMS_BREAKER_QUEUE_TIMEOUT"
+	EnvKMSBreakerCacheTTL     = "MINIO_KMS_BREAKER_CACHE_TTL"
+	EnvKMSBreakerCacheSize    = "MINIO_KMS_BREAKER_CACHE_SIZE"
 
 	EnvEndpoints  = "MINIO_ENDPOINTS"   // legacy
 	EnvWorm       = "MINIO_WORM"        // legacy
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import "sort"
+
+// redactedValue replaces the values of sensitive keys.
+const redactedValue = "*redacted*"
+
+// Change - a change of a single key of a sub-system target
+// between two configurations.
+type Change struct {
+	SubSys   string `json:"subSys"`
+	Target   string `json:"target,omitempty"`
+	Key      string `json:"key"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+}
+
+func isSensitive(subSys, key string) bool {
+	for _, helpKV := range HelpSubSysMap[subSys] {
+		if helpKV.Key == key {
+			return helpKV.Sensitive
+		}
+	}
+	return false
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// Diff - returns the changes from c to the configuration nc, sorted by
+// sub-system, target and key. Values of sensitive keys are redacted and
+// the server credentials are left out altogether.
+func (c Config) Diff(nc Config) []Change {
+	var changes []Change
+	subSystems := make(map[string]struct{})
+	for subSys := range c {
+		subSystems[subSys] = struct{}{}
+	}
+	for subSys := range nc {
+		subSystems[subSys] = struct{}{}
+	}
+	delete(subSystems, CredentialsSubSys)
+
+	for subSys := range subSystems {
+		targets := make(map[string]struct{})
+		for tgt := range c[subSys] {
+			targets[tgt] = struct{}{}
+		}
+		for tgt := range nc[subSys] {
+			targets[tgt] = struct{}{}
+		}
+		for tgt := range targets {
+			oldKVS, newKVS := c[subSys][tgt], nc[subSys][tgt]
+			keys := make(map[string]struct{})
+			for _, kv := range oldKVS {
+				keys[kv.Key] = struct{}{}
+			}
+			for _, kv := range newKVS {
+				keys[kv.Key] = struct{}{}
+			}
+			for key := range keys {
+				oldValue, _ := oldKVS.Lookup(key)
+				newValue, _ := newKVS.Lookup(key)
+				if oldValue == newValue {
+					continue
+				}
+				if isSensitive(subSys, key) {
+					oldValue, newValue = redact(oldValue), redact(newValue)
+				}
+				target := tgt
+				if target == Default {
+					target = ""
+				}
+				changes = append(changes, Change{
+					SubSys:   subSys,
+					Target:   target,
+					Key:      key,
+					OldValue: oldValue,
+					NewValue: newValue,
+				})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].SubSys != changes[j].SubSys {
+			return changes[i].SubSys < changes[j].SubSys
+		}
+		if changes[i].Target != changes[j].Target {
+			return changes[i].Target < changes[j].Target
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
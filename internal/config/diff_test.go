@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigDiff(t *testing.T) {
+	RegisterHelpSubSys(map[string]HelpKVS{
+		"diff_test": {
+			HelpKV{Key: "endpoint"},
+			HelpKV{Key: "auth_token", Sensitive: true},
+		},
+	})
+
+	oldCfg := Config{
+		CredentialsSubSys: {Default: KVS{KV{Key: "access_key", Value: "minio"}}},
+		"diff_test": {
+			Default: KVS{
+				KV{Key: Enable, Value: EnableOn},
+				KV{Key: "endpoint", Value: "http://old"},
+				KV{Key: "auth_token", Value: "secret"},
+			},
+			"removed": KVS{KV{Key: Enable, Value: EnableOn}},
+		},
+	}
+	newCfg := oldCfg.Clone()
+	newCfg[CredentialsSubSys][Default] = KVS{KV{Key: "access_key", Value: "admin"}}
+	newCfg["diff_test"][Default] = KVS{
+		KV{Key: Enable, Value: EnableOn},
+		KV{Key: "endpoint", Value: "http://new"},
+		KV{Key: "auth_token", Value: "new-secret"},
+	}
+	delete(newCfg["diff_test"], "removed")
+	newCfg["diff_test"]["added"] = KVS{KV{Key: "endpoint", Value: "http://added"}}
+
+	expected := []Change{
+		{SubSys: "diff_test", Key: "auth_token", OldValue: redactedValue, NewValue: redactedValue},
+		{SubSys: "diff_test", Key: "endpoint", OldValue: "http://old", NewValue: "http://new"},
+		{SubSys: "diff_test", Target: "added", Key: "endpoint", NewValue: "http://added"},
+		{SubSys: "diff_test", Target: "removed", Key: Enable, OldValue: EnableOn},
+	}
+	if changes := oldCfg.Diff(newCfg); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, changes)
+	}
+
+	if changes := oldCfg.Diff(oldCfg.Clone()); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
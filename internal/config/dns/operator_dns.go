@@ -33,6 +33,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/fips"
 	xhttp "github.com/minio/minio/internal/http"
 )
 
@@ -229,9 +230,9 @@ func NewOperatorDNS(endpoint string, setters ...OperatorOption) (Store, error) {
 			ResponseHeaderTimeout: 3 * time.Second,
 			TLSHandshakeTimeout:   3 * time.Second,
 			ExpectContinueTimeout: 3 * time.Second,
-			TLSClientConfig: &tls.Config{
+			TLSClientConfig: fips.RestrictTLS(&tls.Config{
 				RootCAs: args.rootCAs,
-			},
+			}),
 			// Go net/http automatically unzip if content-type is
 			// gzip disable this feature, as we are always interested
 			// in raw stream.
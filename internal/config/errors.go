@@ -25,6 +25,12 @@ var (
 		"Environment can only accept `on` and `off` values. To disable Console access, set this value to `off`",
 	)
 
+	ErrInvalidNotifyInternalEventsValue = newErrFn(
+		"Invalid internal events value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To send bucket events for lifecycle expirations and heals, set this value to `on`",
+	)
+
 	ErrInvalidFSOSyncValue = newErrFn(
 		"Invalid O_SYNC value",
 		"Please check the passed value",
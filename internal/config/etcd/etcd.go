@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/fips"
 	"github.com/minio/pkg/env"
 	xnet "github.com/minio/pkg/net"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -158,9 +159,9 @@ func LookupConfig(kvs config.KVS, rootCAs *x509.CertPool) (Config, error) {
 	// Default path prefix for all keys on etcd, other than CoreDNSPath.
 	cfg.PathPrefix = env.Get(EnvEtcdPathPrefix, kvs.Get(PathPrefix))
 	if etcdSecure {
-		cfg.TLS = &tls.Config{
+		cfg.TLS = fips.RestrictTLS(&tls.Config{
 			RootCAs: rootCAs,
-		}
+		})
 		// This is only to support client side certificate authentication
 		// https://coreos.com/etcd/docs/latest/op-guide/security.html
 		etcdClientCertFile := env.Get(EnvEtcdClientCert, kvs.Get(ClientCert))
@@ -64,6 +64,12 @@ var (
 			Description: `name of the location of the server e.g. "us-west-1"`,
 			Optional:    true,
 		},
+		HelpKV{
+			Key:         KMSKeyMapKey,
+			Type:        "csv",
+			Description: `comma separated KMS key IDs of site replication peers translated to local KMS key IDs e.g. "peer-key:local-key"`,
+			Optional:    true,
+		},
 		HelpKV{
 			Key:         Comment,
 			Type:        "sentence",
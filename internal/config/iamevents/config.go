@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package iamevents
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/pkg/env"
+)
+
+// IAM change event environment variables
+const (
+	Targets = "targets"
+
+	EnvTargets = "MINIO_IAM_EVENTS_TARGETS"
+)
+
+// Config represents the notification targets receiving IAM change
+// events. IAM change events are always sent to the audit log.
+type Config struct {
+	Targets []event.TargetID `json:"targets"`
+}
+
+var (
+	// DefaultKVS - default KV config for IAM change events
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   Targets,
+			Value: "",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Targets,
+			Description: `comma separated list of notification target ARNs receiving IAM change events e.g. "arn:minio:sqs::primary:webhook"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.IAMEventsSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	targets := env.Get(EnvTargets, kvs.Get(Targets))
+	for _, s := range strings.Split(targets, config.ValueSeparator) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		arn, err := event.ParseARN(s)
+		if err != nil {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.IAMEventsSubSys, Targets, err)
+		}
+		cfg.Targets = append(cfg.Targets, arn.TargetID)
+	}
+	return cfg, nil
+}
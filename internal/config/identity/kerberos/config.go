@@ -0,0 +1,257 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kerberos
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jcmturner/gofork/encoding/asn1"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/service"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Kerberos config constants.
+const (
+	Keytab           = "keytab"
+	ServicePrincipal = "service_principal"
+	RealmPolicies    = "realm_policies"
+
+	EnvKeytab           = "MINIO_IDENTITY_KERBEROS_KEYTAB"
+	EnvServicePrincipal = "MINIO_IDENTITY_KERBEROS_SERVICE_PRINCIPAL"
+	EnvRealmPolicies    = "MINIO_IDENTITY_KERBEROS_REALM_POLICIES"
+)
+
+const (
+	negotiate = "Negotiate"
+
+	// Context key under which gokrb5 stores the authenticated
+	// credentials after accepting a SPNEGO context.
+	ctxCredentials = "github.com/jcmturner/gokrb5/v8/ctxCredentials"
+)
+
+// ErrNoNegotiateToken is returned when the request carries no SPNEGO
+// `Authorization: Negotiate` header.
+var ErrNoNegotiateToken = errors.New("no SPNEGO negotiate token provided")
+
+// Config - Kerberos identity provider configuration.
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// ServicePrincipal is the principal, e.g. HTTP/minio.example.com,
+	// whose key in the keytab decrypts service tickets. Defaults to
+	// the principal of the ticket.
+	ServicePrincipal string `json:"servicePrincipal,omitempty"`
+
+	// RealmPolicies maps Kerberos realms to the policies of users
+	// authenticated in that realm.
+	RealmPolicies map[string][]string `json:"realmPolicies,omitempty"`
+
+	keytab *keytab.Keytab
+}
+
+const (
+	defaultExpiry time.Duration = 1 * time.Hour
+	minExpiry     time.Duration = 15 * time.Minute
+	maxExpiry     time.Duration = 365 * 24 * time.Hour
+)
+
+// GetExpiryDuration - return parsed expiry duration.
+func (c Config) GetExpiryDuration(dsecs string) (time.Duration, error) {
+	if dsecs == "" {
+		return defaultExpiry, nil
+	}
+
+	d, err := strconv.Atoi(dsecs)
+	if err != nil {
+		return 0, auth.ErrInvalidDuration
+	}
+
+	dur := time.Duration(d) * time.Second
+
+	if dur < minExpiry || dur > maxExpiry {
+		return 0, auth.ErrInvalidDuration
+	}
+	return dur, nil
+}
+
+// Identity is a principal authenticated by a Kerberos service ticket.
+type Identity struct {
+	UserName string
+	Realm    string
+}
+
+// Principal returns the principal name in the form user@REALM.
+func (id Identity) Principal() string {
+	return id.UserName + "@" + id.Realm
+}
+
+// Policies returns the policies mapped to the realm of the identity.
+func (c Config) Policies(id Identity) []string {
+	return c.RealmPolicies[id.Realm]
+}
+
+// Authenticate - verifies the SPNEGO token of an `Authorization:
+// Negotiate` header value against the configured keytab and returns
+// the authenticated principal.
+func (c Config) Authenticate(authHeader string) (id Identity, err error) {
+	v := strings.SplitN(authHeader, " ", 2)
+	if len(v) != 2 || v[0] != negotiate {
+		return id, ErrNoNegotiateToken
+	}
+
+	b, err := base64.StdEncoding.DecodeString(v[1])
+	if err != nil {
+		return id, fmt.Errorf("invalid SPNEGO negotiate token: %w", err)
+	}
+
+	var st spnego.SPNEGOToken
+	if err = st.Unmarshal(b); err != nil {
+		// Some clients send raw KRB5 tokens instead of wrapping
+		// them into a SPNEGO token.
+		var k5t spnego.KRB5Token
+		if k5t.Unmarshal(b) != nil {
+			return id, fmt.Errorf("invalid SPNEGO negotiate token: %w", err)
+		}
+		st.Init = true
+		st.NegTokenInit = spnego.NegTokenInit{
+			MechTypes:      []asn1.ObjectIdentifier{k5t.OID},
+			MechTokenBytes: b,
+		}
+	}
+
+	var settings []func(*service.Settings)
+	if c.ServicePrincipal != "" {
+		settings = append(settings, service.KeytabPrincipal(c.ServicePrincipal))
+	}
+	ok, ctx, status := spnego.SPNEGOService(c.keytab, settings...).AcceptSecContext(&st)
+	if !ok {
+		return id, fmt.Errorf("kerberos authentication failed: %s", status.Message)
+	}
+
+	creds, ok := ctx.Value(ctxCredentials).(*credentials.Credentials)
+	if !ok || creds.UserName() == "" {
+		return id, errors.New("kerberos authentication failed: no principal in service ticket")
+	}
+	return Identity{
+		UserName: creds.UserName(),
+		Realm:    creds.Domain(),
+	}, nil
+}
+
+// DefaultKVS - default config for Kerberos config
+var DefaultKVS = config.KVS{
+	config.KV{
+		Key:   Keytab,
+		Value: "",
+	},
+	config.KV{
+		Key:   ServicePrincipal,
+		Value: "",
+	},
+	config.KV{
+		Key:   RealmPolicies,
+		Value: "",
+	},
+}
+
+// Help - help for the Kerberos identity configuration.
+var Help = config.HelpKVS{
+	config.HelpKV{
+		Key:         Keytab,
+		Description: `path to the keytab of the MinIO service principal e.g. "/etc/minio/minio.keytab"`,
+		Type:        "path",
+	},
+	config.HelpKV{
+		Key:         ServicePrincipal,
+		Description: `service principal used to look up the key in the keytab e.g. "HTTP/minio.example.com"`,
+		Optional:    true,
+		Type:        "string",
+	},
+	config.HelpKV{
+		Key:         RealmPolicies,
+		Description: `";" separated list of realm to policies mappings e.g. "EXAMPLE.COM=readwrite;CORP.COM=readonly,diagnostics"`,
+		Optional:    true,
+		Type:        "string",
+	},
+	config.HelpKV{
+		Key:         config.Comment,
+		Description: config.DefaultComment,
+		Optional:    true,
+		Type:        "sentence",
+	},
+}
+
+func parseRealmPolicies(s string) (map[string][]string, error) {
+	m := make(map[string][]string)
+	for _, mapping := range strings.Split(s, ";") {
+		mapping = strings.TrimSpace(mapping)
+		if mapping == "" {
+			continue
+		}
+		v := strings.SplitN(mapping, "=", 2)
+		if len(v) != 2 || strings.TrimSpace(v[0]) == "" {
+			return nil, config.Errorf("invalid realm policy mapping '%s', expected REALM=policy[,policy]", mapping)
+		}
+		var policies []string
+		for _, policy := range strings.Split(v[1], ",") {
+			if policy = strings.TrimSpace(policy); policy != "" {
+				policies = append(policies, policy)
+			}
+		}
+		if len(policies) == 0 {
+			return nil, config.Errorf("no policies mapped to realm '%s'", v[0])
+		}
+		m[strings.TrimSpace(v[0])] = policies
+	}
+	return m, nil
+}
+
+// LookupConfig - lookup Kerberos config and override with valid
+// environment settings if any.
+func LookupConfig(kvs config.KVS) (c Config, err error) {
+	if err = config.CheckValidKeys(config.IdentityKerberosSubSys, kvs, DefaultKVS); err != nil {
+		return c, err
+	}
+
+	keytabFile := env.Get(EnvKeytab, kvs.Get(Keytab))
+	if keytabFile == "" {
+		return c, nil
+	}
+
+	c.keytab, err = keytab.Load(keytabFile)
+	if err != nil {
+		return c, config.Errorf("unable to load kerberos keytab '%s': %v", keytabFile, err)
+	}
+	c.ServicePrincipal = env.Get(EnvServicePrincipal, kvs.Get(ServicePrincipal))
+	c.RealmPolicies, err = parseRealmPolicies(env.Get(EnvRealmPolicies, kvs.Get(RealmPolicies)))
+	if err != nil {
+		return c, err
+	}
+	c.Enabled = true
+	return c, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kerberos
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/minio/minio/internal/config"
+)
+
+func TestParseRealmPolicies(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected map[string][]string
+		success  bool
+	}{
+		{"", map[string][]string{}, true},
+		{"EXAMPLE.COM=readwrite", map[string][]string{"EXAMPLE.COM": {"readwrite"}}, true},
+		{"EXAMPLE.COM=readwrite; CORP.COM=readonly,diagnostics", map[string][]string{
+			"EXAMPLE.COM": {"readwrite"},
+			"CORP.COM":    {"readonly", "diagnostics"},
+		}, true},
+		{"EXAMPLE.COM", nil, false},
+		{"=readwrite", nil, false},
+		{"EXAMPLE.COM=", nil, false},
+	}
+
+	for i, testCase := range testCases {
+		m, err := parseRealmPolicies(testCase.value)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if testCase.success && !reflect.DeepEqual(m, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, m)
+		}
+	}
+}
+
+func TestLookupConfig(t *testing.T) {
+	cfg, err := LookupConfig(config.KVS{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Enabled {
+		t.Fatal("expected kerberos to be disabled without a keytab")
+	}
+
+	kt := keytab.New()
+	if err = kt.AddEntry("HTTP/minio.example.com", "EXAMPLE.COM", "secret", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96); err != nil {
+		t.Fatal(err)
+	}
+	data, err := kt.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keytabFile := filepath.Join(t.TempDir(), "minio.keytab")
+	if err = os.WriteFile(keytabFile, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = LookupConfig(config.KVS{
+		config.KV{Key: Keytab, Value: keytabFile},
+		config.KV{Key: RealmPolicies, Value: "EXAMPLE.COM=readwrite"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enabled {
+		t.Fatal("expected kerberos to be enabled")
+	}
+	if p := cfg.Policies(Identity{UserName: "alice", Realm: "EXAMPLE.COM"}); !reflect.DeepEqual(p, []string{"readwrite"}) {
+		t.Fatalf("unexpected policies %v", p)
+	}
+
+	if _, err = cfg.Authenticate("Basic YWxpY2U6c2VjcmV0"); err != ErrNoNegotiateToken {
+		t.Fatalf("expected %v, got %v", ErrNoNegotiateToken, err)
+	}
+	if _, err = cfg.Authenticate("Negotiate aW52YWxpZA=="); err == nil {
+		t.Fatal("expected an error for an invalid SPNEGO token")
+	}
+}
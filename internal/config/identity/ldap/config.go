@@ -31,6 +31,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/fips"
 	"github.com/minio/pkg/env"
 )
 
@@ -324,10 +325,10 @@ func (l *Config) Connect() (ldapConn *ldap.Conn, err error) {
 		return ldap.Dial("tcp", l.ServerAddr)
 	}
 
-	tlsConfig := &tls.Config{
+	tlsConfig := fips.RestrictTLS(&tls.Config{
 		InsecureSkipVerify: l.tlsSkipVerify,
 		RootCAs:            l.rootCAs,
-	}
+	})
 
 	if l.serverStartTLS {
 		conn, err := ldap.Dial("tcp", l.ServerAddr)
@@ -373,6 +374,25 @@ func (l Config) testConnection() error {
 	return nil
 }
 
+// TestConnection - verifies that the LDAP server is reachable
+// and accepts the lookup bind credentials.
+func (l Config) TestConnection() error {
+	return l.testConnection()
+}
+
+// TLSConnectionState - connects to the LDAP server and returns the
+// state of the TLS connection, ok is false for plain text connections.
+func (l Config) TLSConnectionState() (state tls.ConnectionState, ok bool, err error) {
+	conn, err := l.Connect()
+	if err != nil {
+		return state, false, err
+	}
+	defer conn.Close()
+
+	state, ok = conn.TLSConnectionState()
+	return state, ok, nil
+}
+
 // IsLDAPUserDN determines if the given string could be a user DN from LDAP.
 func (l Config) IsLDAPUserDN(user string) bool {
 	return strings.HasSuffix(user, ","+l.UserDNSearchBaseDN)
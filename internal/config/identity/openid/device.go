@@ -0,0 +1,157 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OAuth 2.0 device authorization grant - https://datatracker.ietf.org/doc/html/rfc8628
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Device authorization grant errors returned by the token endpoint.
+var (
+	ErrDeviceFlowNotSupported     = errors.New("identity provider does not support the device authorization grant")
+	ErrDeviceAuthorizationPending = errors.New("device authorization is pending, the user has not yet completed the verification")
+	ErrDeviceSlowDown             = errors.New("device code polled too frequently, increase the polling interval")
+	ErrDeviceAccessDenied         = errors.New("device authorization request was denied")
+	ErrDeviceCodeExpired          = errors.New("device code has expired, start a new device authorization")
+)
+
+// DeviceAuthorization is the response of the identity provider to a
+// device authorization request.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// DeviceToken is the token endpoint response of a completed device
+// authorization.
+type DeviceToken struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+}
+
+type deviceTokenError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func (r *Config) postForm(endpoint string, form url.Values, v interface{}) error {
+	transport := http.DefaultTransport
+	if r.transport != nil {
+		transport = r.transport
+	}
+	client := &http.Client{
+		Transport: transport,
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.closeRespFn(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		var terr deviceTokenError
+		if json.NewDecoder(resp.Body).Decode(&terr) == nil && terr.Error != "" {
+			switch terr.Error {
+			case "authorization_pending":
+				return ErrDeviceAuthorizationPending
+			case "slow_down":
+				return ErrDeviceSlowDown
+			case "access_denied":
+				return ErrDeviceAccessDenied
+			case "expired_token":
+				return ErrDeviceCodeExpired
+			}
+			return fmt.Errorf("%s: %s", terr.Error, terr.ErrorDescription)
+		}
+		return errors.New(resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (r *Config) setClientAuth(form url.Values) {
+	form.Set("client_id", r.ClientID)
+	if r.ClientSecret != "" {
+		form.Set("client_secret", r.ClientSecret)
+	}
+}
+
+// StartDeviceAuthorization - requests a device and user code from the
+// identity provider. The user completes the authorization by visiting
+// the returned verification URI on another device.
+func (r *Config) StartDeviceAuthorization() (d DeviceAuthorization, err error) {
+	if r.DiscoveryDoc.DeviceAuthorizationEndpoint == "" {
+		return d, ErrDeviceFlowNotSupported
+	}
+
+	scopes := r.DiscoveryDoc.ScopesSupported
+	if len(scopes) == 0 {
+		scopes = []string{"openid"}
+	}
+
+	form := url.Values{}
+	r.setClientAuth(form)
+	form.Set("scope", strings.Join(scopes, " "))
+	if err = r.postForm(r.DiscoveryDoc.DeviceAuthorizationEndpoint, form, &d); err != nil {
+		return d, err
+	}
+	if d.DeviceCode == "" {
+		return d, errors.New("identity provider returned an empty device code")
+	}
+	return d, nil
+}
+
+// ExchangeDeviceCode - polls the token endpoint for the tokens issued
+// once the user has completed the device authorization. Returns
+// ErrDeviceAuthorizationPending while the user has not done so yet.
+func (r *Config) ExchangeDeviceCode(deviceCode string) (t DeviceToken, err error) {
+	if r.DiscoveryDoc.TokenEndpoint == "" {
+		return t, ErrDeviceFlowNotSupported
+	}
+
+	form := url.Values{}
+	r.setClientAuth(form)
+	form.Set("grant_type", deviceCodeGrantType)
+	form.Set("device_code", deviceCode)
+	if err = r.postForm(r.DiscoveryDoc.TokenEndpoint, form, &t); err != nil {
+		return t, err
+	}
+	if t.IDToken == "" {
+		return t, errors.New("identity provider did not return an ID token, ensure the 'openid' scope is granted")
+	}
+	return t, nil
+}
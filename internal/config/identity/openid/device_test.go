@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	approved := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("client_id") != "minio" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://idp.example.com/device",
+			ExpiresIn:       600,
+			Interval:        5,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("grant_type") != deviceCodeGrantType || r.PostFormValue("device_code") != "device-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(deviceTokenError{Error: "invalid_grant"})
+			return
+		}
+		if !approved {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(deviceTokenError{Error: "authorization_pending"})
+			return
+		}
+		json.NewEncoder(w).Encode(DeviceToken{IDToken: "id-token", AccessToken: "access-token"})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{
+		ClientID: "minio",
+		DiscoveryDoc: DiscoveryDoc{
+			DeviceAuthorizationEndpoint: ts.URL + "/device",
+			TokenEndpoint:               ts.URL + "/token",
+		},
+		closeRespFn: func(rc io.ReadCloser) { rc.Close() },
+	}
+
+	d, err := cfg.StartDeviceAuthorization()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.DeviceCode != "device-code" || d.UserCode != "ABCD-EFGH" || d.Interval != 5 {
+		t.Fatalf("unexpected device authorization %#v", d)
+	}
+
+	if _, err = cfg.ExchangeDeviceCode(d.DeviceCode); err != ErrDeviceAuthorizationPending {
+		t.Fatalf("expected %v, got %v", ErrDeviceAuthorizationPending, err)
+	}
+
+	approved = true
+	token, err := cfg.ExchangeDeviceCode(d.DeviceCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.IDToken != "id-token" {
+		t.Fatalf("unexpected ID token %s", token.IDToken)
+	}
+
+	if _, err = cfg.ExchangeDeviceCode("unknown"); err == nil {
+		t.Fatal("expected an error for an unknown device code")
+	}
+
+	cfg.DiscoveryDoc.DeviceAuthorizationEndpoint = ""
+	if _, err = cfg.StartDeviceAuthorization(); err != ErrDeviceFlowNotSupported {
+		t.Fatalf("expected %v, got %v", ErrDeviceFlowNotSupported, err)
+	}
+}
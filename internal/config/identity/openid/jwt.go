@@ -18,6 +18,7 @@
 package openid
 
 import (
+	"context"
 	"crypto"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -233,6 +234,35 @@ func (r *Config) PopulatePublicKey() error {
 	return nil
 }
 
+// TestConnection - verifies that the JWKS endpoint of the
+// OpenID provider is reachable.
+func (r *Config) TestConnection(ctx context.Context) error {
+	if r.JWKS.URL == nil || r.JWKS.URL.String() == "" {
+		return errors.New("no JWKS endpoint configured")
+	}
+	transport := http.DefaultTransport
+	if r.transport != nil {
+		transport = r.transport
+	}
+	client := &http.Client{
+		Transport: transport,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.JWKS.URL.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.closeRespFn(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
 // UnmarshalJSON - decodes JSON data.
 func (r *Config) UnmarshalJSON(data []byte) error {
 	// subtype to avoid recursive call to UnmarshalJSON()
@@ -450,6 +480,7 @@ type DiscoveryDoc struct {
 	TokenEndpoint                    string   `json:"token_endpoint,omitempty"`
 	UserInfoEndpoint                 string   `json:"userinfo_endpoint,omitempty"`
 	RevocationEndpoint               string   `json:"revocation_endpoint,omitempty"`
+	DeviceAuthorizationEndpoint      string   `json:"device_authorization_endpoint,omitempty"`
 	JwksURI                          string   `json:"jwks_uri,omitempty"`
 	ResponseTypesSupported           []string `json:"response_types_supported,omitempty"`
 	SubjectTypesSupported            []string `json:"subject_types_supported,omitempty"`
@@ -27,6 +27,7 @@ type DiscoveryDoc struct {
 	TokenEndpoint                    string   `json:"token_endpoint,omitempty"`
 	UserInfoEndpoint                 string   `json:"userinfo_endpoint,omitempty"`
 	RevocationEndpoint               string   `json:"revocation_endpoint,omitempty"`
+	DeviceAuthorizationEndpoint      string   `json:"device_authorization_endpoint,omitempty"`
 	JwksURI                          string   `json:"jwks_uri,omitempty"`
 	ResponseTypesSupported           []string `json:"response_types_supported,omitempty"`
 	SubjectTypesSupported            []string `json:"subject_types_supported,omitempty"`
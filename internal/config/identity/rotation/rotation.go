@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rotation
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Credential rotation environment variables
+const (
+	MaxAgeDays = "max_age_days"
+	GraceDays  = "grace_days"
+	Disable    = "disable"
+
+	EnvMaxAgeDays = "MINIO_CREDENTIAL_ROTATION_MAX_AGE_DAYS"
+	EnvGraceDays  = "MINIO_CREDENTIAL_ROTATION_GRACE_DAYS"
+	EnvDisable    = "MINIO_CREDENTIAL_ROTATION_DISABLE"
+)
+
+// Status of a credential with respect to the rotation policy.
+type Status string
+
+// Credential rotation states.
+const (
+	// StatusOK - credential is younger than the grace threshold.
+	StatusOK Status = "ok"
+	// StatusGrace - credential expires within the grace period.
+	StatusGrace Status = "grace"
+	// StatusExpired - credential is older than the maximum age.
+	StatusExpired Status = "expired"
+	// StatusUnknown - credential has no recorded creation time.
+	StatusUnknown Status = "unknown"
+)
+
+// Config represents the credential rotation settings.
+type Config struct {
+	// MaxAge is the maximum age of long-term access keys, zero
+	// disables rotation enforcement.
+	MaxAge time.Duration `json:"maxAge"`
+	// Grace is the period before MaxAge in which credentials are
+	// flagged for rotation.
+	Grace time.Duration `json:"grace"`
+	// Disable expired credentials instead of only flagging them.
+	Disable bool `json:"disable"`
+}
+
+// Enabled returns true if a maximum credential age is configured.
+func (cfg Config) Enabled() bool {
+	return cfg.MaxAge > 0
+}
+
+// Check returns the rotation status of a credential last updated at
+// updatedAt.
+func (cfg Config) Check(updatedAt, now time.Time) Status {
+	if !cfg.Enabled() {
+		return StatusOK
+	}
+	if updatedAt.IsZero() {
+		return StatusUnknown
+	}
+	age := now.Sub(updatedAt)
+	switch {
+	case age >= cfg.MaxAge:
+		return StatusExpired
+	case age >= cfg.MaxAge-cfg.Grace:
+		return StatusGrace
+	}
+	return StatusOK
+}
+
+var (
+	// DefaultKVS - default KV config for credential rotation settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   MaxAgeDays,
+			Value: "0",
+		},
+		config.KV{
+			Key:   GraceDays,
+			Value: "7",
+		},
+		config.KV{
+			Key:   Disable,
+			Value: config.EnableOff,
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         MaxAgeDays,
+			Description: `maximum age in days of user and service account access keys, '0' disables enforcement`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         GraceDays,
+			Description: `days before the maximum age in which access keys are flagged for rotation, defaults to '7'`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Disable,
+			Description: `set to 'on' to disable access keys older than the maximum age`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+	}
+)
+
+const day = 24 * time.Hour
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.CredentialRotationSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	maxAge, err := strconv.Atoi(env.Get(EnvMaxAgeDays, kvs.GetWithDefault(MaxAgeDays, DefaultKVS)))
+	if err != nil || maxAge < 0 {
+		return cfg, fmt.Errorf("'credential_rotation:max_age_days' value invalid: %v", kvs.Get(MaxAgeDays))
+	}
+	grace, err := strconv.Atoi(env.Get(EnvGraceDays, kvs.GetWithDefault(GraceDays, DefaultKVS)))
+	if err != nil || grace < 0 {
+		return cfg, fmt.Errorf("'credential_rotation:grace_days' value invalid: %v", kvs.Get(GraceDays))
+	}
+	if maxAge > 0 && grace >= maxAge {
+		return cfg, fmt.Errorf("'credential_rotation:grace_days' must be less than 'max_age_days'")
+	}
+	cfg.Disable, err = config.ParseBool(env.Get(EnvDisable, kvs.GetWithDefault(Disable, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'credential_rotation:disable' value invalid: %w", err)
+	}
+	cfg.MaxAge = time.Duration(maxAge) * day
+	cfg.Grace = time.Duration(grace) * day
+	return cfg, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestCheck(t *testing.T) {
+	now := time.Now()
+	cfg := Config{MaxAge: 90 * day, Grace: 7 * day}
+
+	testCases := []struct {
+		updatedAt time.Time
+		expected  Status
+	}{
+		{time.Time{}, StatusUnknown},
+		{now.Add(-time.Hour), StatusOK},
+		{now.Add(-85 * day), StatusGrace},
+		{now.Add(-90 * day), StatusExpired},
+		{now.Add(-365 * day), StatusExpired},
+	}
+	for i, testCase := range testCases {
+		if got := cfg.Check(testCase.updatedAt, now); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+
+	if got := (Config{}).Check(now.Add(-365*day), now); got != StatusOK {
+		t.Errorf("expected disabled rotation to report %s, got %s", StatusOK, got)
+	}
+}
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		kvs     config.KVS
+		success bool
+	}{
+		{DefaultKVS, true},
+		{config.KVS{{Key: MaxAgeDays, Value: "90"}, {Key: GraceDays, Value: "10"}, {Key: Disable, Value: "on"}}, true},
+		{config.KVS{{Key: MaxAgeDays, Value: "5"}, {Key: GraceDays, Value: "10"}}, false},
+		{config.KVS{{Key: MaxAgeDays, Value: "-1"}}, false},
+		{config.KVS{{Key: "unknown", Value: "1"}}, false},
+	}
+	for i, testCase := range testCases {
+		_, err := LookupConfig(testCase.kvs)
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+}
@@ -18,12 +18,15 @@
 package tls
 
 import (
+	"crypto/x509"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/pkg/env"
+	"github.com/minio/pkg/wildcard"
 )
 
 const (
@@ -41,8 +44,29 @@ const (
 	// clients to obtain temp. credentials with arbitrary policy
 	// permissions - including admin permissions.
 	EnvIdentityTLSSkipVerify = "MINIO_IDENTITY_TLS_SKIP_VERIFY"
+
+	// EnvIdentityTLSPolicyMapping is an environment variable that maps
+	// client certificate attributes to policies.
+	EnvIdentityTLSPolicyMapping = "MINIO_IDENTITY_TLS_POLICY_MAPPING"
+)
+
+// Client certificate attributes usable in policy mappings.
+const (
+	AttributeCommonName = "cn"
+	AttributeOrgUnit    = "ou"
+	AttributeURI        = "uri"
+	AttributeDNS        = "dns"
+	AttributeEmail      = "email"
 )
 
+// PolicyMapping maps client certificates with an attribute value
+// matching Pattern to policies.
+type PolicyMapping struct {
+	Attribute string   `json:"attribute"`
+	Pattern   string   `json:"pattern"`
+	Policies  []string `json:"policies"`
+}
+
 // Config contains the STS TLS configuration for generating temp.
 // credentials and mapping client certificates to S3 policies.
 type Config struct {
@@ -52,6 +76,115 @@ type Config struct {
 	// certificate verification. It should only be set for
 	// debugging or testing purposes.
 	InsecureSkipVerify bool `json:"skip_verify"`
+
+	// PolicyMappings map certificate attributes, e.g. SPIFFE IDs
+	// in URI SANs, to policies in addition to the subject CN.
+	PolicyMappings []PolicyMapping `json:"policy_mapping,omitempty"`
+}
+
+func certificateAttributes(cert *x509.Certificate, attribute string) []string {
+	switch attribute {
+	case AttributeCommonName:
+		if cert.Subject.CommonName != "" {
+			return []string{cert.Subject.CommonName}
+		}
+	case AttributeOrgUnit:
+		return cert.Subject.OrganizationalUnit
+	case AttributeURI:
+		uris := make([]string, 0, len(cert.URIs))
+		for _, u := range cert.URIs {
+			uris = append(uris, u.String())
+		}
+		return uris
+	case AttributeDNS:
+		return cert.DNSNames
+	case AttributeEmail:
+		return cert.EmailAddresses
+	}
+	return nil
+}
+
+// Subject returns the identity of the client certificate, which is
+// the subject CN or, if the CN is empty, the first URI SAN as used by
+// SPIFFE identities.
+func Subject(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	return ""
+}
+
+// Policies returns the policies of the client certificate. A policy
+// named after the subject CN always applies, further policies are
+// added by the configured policy mappings.
+func (l Config) Policies(cert *x509.Certificate) []string {
+	var policies []string
+	seen := map[string]bool{}
+	add := func(policy string) {
+		if !seen[policy] {
+			seen[policy] = true
+			policies = append(policies, policy)
+		}
+	}
+	if cert.Subject.CommonName != "" {
+		add(cert.Subject.CommonName)
+	}
+	for _, m := range l.PolicyMappings {
+		for _, v := range certificateAttributes(cert, m.Attribute) {
+			if !wildcard.Match(m.Pattern, v) {
+				continue
+			}
+			for _, policy := range m.Policies {
+				add(policy)
+			}
+			break
+		}
+	}
+	return policies
+}
+
+func parsePolicyMappings(s string) ([]PolicyMapping, error) {
+	var mappings []PolicyMapping
+	for _, mapping := range strings.Split(s, ";") {
+		mapping = strings.TrimSpace(mapping)
+		if mapping == "" {
+			continue
+		}
+		// The pattern may contain '=' e.g. in URIs with queries, the
+		// policies never do.
+		i := strings.LastIndex(mapping, "=")
+		if i < 0 {
+			return nil, config.Errorf("invalid policy mapping '%s', expected attribute:pattern=policy[,policy]", mapping)
+		}
+		match, policyList := mapping[:i], mapping[i+1:]
+		v := strings.SplitN(match, ":", 2)
+		if len(v) != 2 || v[1] == "" {
+			return nil, config.Errorf("invalid policy mapping '%s', expected attribute:pattern=policy[,policy]", mapping)
+		}
+		switch v[0] {
+		case AttributeCommonName, AttributeOrgUnit, AttributeURI, AttributeDNS, AttributeEmail:
+		default:
+			return nil, config.Errorf("unsupported certificate attribute '%s' in policy mapping '%s'", v[0], mapping)
+		}
+		var policies []string
+		for _, policy := range strings.Split(policyList, ",") {
+			if policy = strings.TrimSpace(policy); policy != "" {
+				policies = append(policies, policy)
+			}
+		}
+		if len(policies) == 0 {
+			return nil, config.Errorf("no policies in policy mapping '%s'", mapping)
+		}
+		mappings = append(mappings, PolicyMapping{
+			Attribute: v[0],
+			Pattern:   v[1],
+			Policies:  policies,
+		})
+	}
+	return mappings, nil
 }
 
 const (
@@ -99,11 +232,16 @@ func Lookup(kvs config.KVS) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+	cfg.PolicyMappings, err = parsePolicyMappings(env.Get(EnvIdentityTLSPolicyMapping, kvs.Get(policyMapping)))
+	if err != nil {
+		return Config{}, err
+	}
 	return cfg, nil
 }
 
 const (
-	skipVerify = "skip_verify"
+	skipVerify    = "skip_verify"
+	policyMapping = "policy_mapping"
 )
 
 // DefaultKVS is the the default K/V config system for
@@ -113,6 +251,10 @@ var DefaultKVS = config.KVS{
 		Key:   skipVerify,
 		Value: "off",
 	},
+	config.KV{
+		Key:   policyMapping,
+		Value: "",
+	},
 }
 
 // Help is the help and description for the STS API K/V configuration.
@@ -123,4 +265,10 @@ var Help = config.HelpKVS{
 		Optional:    true,
 		Type:        "on|off",
 	},
+	config.HelpKV{
+		Key:         policyMapping,
+		Description: `";" separated certificate attribute to policies mappings e.g. "uri:spiffe://example.org/ns/prod/*=readwrite;ou:ops=diagnostics", supported attributes are cn, ou, uri, dns and email`,
+		Optional:    true,
+		Type:        "string",
+	},
 }
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tls
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestPolicies(t *testing.T) {
+	mappings, err := parsePolicyMappings("uri:spiffe://example.org/ns/prod/*=readwrite; ou:ops=diagnostics,readonly ;dns:*.internal=readonly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{PolicyMappings: mappings}
+
+	spiffeID, _ := url.Parse("spiffe://example.org/ns/prod/sa/web")
+	testCases := []struct {
+		cert     *x509.Certificate
+		subject  string
+		policies []string
+	}{
+		{
+			cert:     &x509.Certificate{Subject: pkix.Name{CommonName: "consoleAdmin"}},
+			subject:  "consoleAdmin",
+			policies: []string{"consoleAdmin"},
+		},
+		{
+			cert:     &x509.Certificate{URIs: []*url.URL{spiffeID}},
+			subject:  "spiffe://example.org/ns/prod/sa/web",
+			policies: []string{"readwrite"},
+		},
+		{
+			cert: &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "web", OrganizationalUnit: []string{"dev", "ops"}},
+				DNSNames: []string{"web.internal"},
+			},
+			subject:  "web",
+			policies: []string{"web", "diagnostics", "readonly"},
+		},
+		{
+			cert:     &x509.Certificate{DNSNames: []string{"web.example.com"}},
+			subject:  "",
+			policies: nil,
+		},
+	}
+
+	for i, testCase := range testCases {
+		if subject := Subject(testCase.cert); subject != testCase.subject {
+			t.Errorf("Test %d: expected subject %s, got %s", i+1, testCase.subject, subject)
+		}
+		if policies := cfg.Policies(testCase.cert); !reflect.DeepEqual(policies, testCase.policies) {
+			t.Errorf("Test %d: expected policies %v, got %v", i+1, testCase.policies, policies)
+		}
+	}
+}
+
+func TestParsePolicyMappings(t *testing.T) {
+	for i, value := range []string{"readwrite", "uri=readwrite", "uri:=readwrite", "serial:1234=readwrite", "ou:ops="} {
+		if _, err := parsePolicyMappings(value); err == nil {
+			t.Errorf("Test %d: expected an error for '%s'", i+1, value)
+		}
+	}
+	mappings, err := parsePolicyMappings("uri:spiffe://example.org/?a=b=readwrite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 1 || mappings[0].Pattern != "spiffe://example.org/?a=b" {
+		t.Fatalf("unexpected mappings %v", mappings)
+	}
+}
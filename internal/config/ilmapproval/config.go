@@ -0,0 +1,179 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilmapproval
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Lifecycle expiration approval environment variables
+const (
+	URL       = "url"
+	AuthToken = "auth_token"
+	Timeout   = "timeout"
+	FailOpen  = "fail_open"
+	Rules     = "rules"
+
+	EnvURL       = "MINIO_ILM_APPROVAL_URL"
+	EnvAuthToken = "MINIO_ILM_APPROVAL_AUTH_TOKEN"
+	EnvTimeout   = "MINIO_ILM_APPROVAL_TIMEOUT"
+	EnvFailOpen  = "MINIO_ILM_APPROVAL_FAIL_OPEN"
+	EnvRules     = "MINIO_ILM_APPROVAL_RULES"
+)
+
+// Config represents the webhook consulted before lifecycle rules expire
+// objects.
+type Config struct {
+	URL       *xnet.URL     `json:"url"`
+	AuthToken string        `json:"authToken"`
+	Timeout   time.Duration `json:"timeout"`
+	// FailOpen allows expirations when the webhook cannot be reached
+	// or fails, otherwise they are postponed to a later scan.
+	FailOpen bool `json:"failOpen"`
+	// Rules are the buckets, as `bucket`, or lifecycle rules of a
+	// bucket, as `bucket/rule-id`, whose expirations require approval.
+	// All expirations require approval if empty.
+	Rules []string `json:"rules"`
+}
+
+// Enabled returns true if expirations require approval.
+func (c Config) Enabled() bool {
+	return c.URL != nil
+}
+
+// Matches returns true if expirations by the rule ruleID of bucket
+// require approval.
+func (c Config) Matches(bucket, ruleID string) bool {
+	if !c.Enabled() {
+		return false
+	}
+	if len(c.Rules) == 0 {
+		return true
+	}
+	for _, r := range c.Rules {
+		if r == bucket || r == bucket+"/"+ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultKVS - default KV config for lifecycle expiration approval
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   URL,
+			Value: "",
+		},
+		config.KV{
+			Key:   AuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   Timeout,
+			Value: "5s",
+		},
+		config.KV{
+			Key:   FailOpen,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Rules,
+			Value: "",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         URL,
+			Description: `webhook endpoint approving lifecycle expirations e.g. "http://localhost:8080/approve"`,
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         AuthToken,
+			Description: "authorization header sent to the webhook",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         Timeout,
+			Description: `time to wait for the webhook to respond e.g. "5s"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         FailOpen,
+			Description: `set to "on" to expire objects when the webhook fails, "off" postpones the expirations`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         Rules,
+			Description: `comma separated list of buckets and lifecycle rules, as "bucket/rule-id", whose expirations require approval, all if empty`,
+			Optional:    true,
+			Type:        "csv",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.ILMApprovalSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	u := env.Get(EnvURL, kvs.Get(URL))
+	if u == "" {
+		return cfg, nil
+	}
+	if cfg.URL, err = xnet.ParseHTTPURL(u); err != nil {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.ILMApprovalSubSys, URL, err)
+	}
+	cfg.AuthToken = env.Get(EnvAuthToken, kvs.Get(AuthToken))
+
+	timeout := env.Get(EnvTimeout, kvs.Get(Timeout))
+	if timeout == "" {
+		timeout = "5s"
+	}
+	cfg.Timeout, err = time.ParseDuration(timeout)
+	if err != nil || cfg.Timeout <= 0 {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.ILMApprovalSubSys, Timeout, timeout)
+	}
+
+	failOpen := env.Get(EnvFailOpen, kvs.Get(FailOpen))
+	if failOpen != "" {
+		if cfg.FailOpen, err = config.ParseBool(failOpen); err != nil {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.ILMApprovalSubSys, FailOpen, err)
+		}
+	}
+
+	for _, r := range strings.Split(env.Get(EnvRules, kvs.Get(Rules)), config.ValueSeparator) {
+		if r = strings.TrimSpace(r); r != "" {
+			cfg.Rules = append(cfg.Rules, r)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilmapproval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		url, timeout, failOpen string
+		success, enabled       bool
+	}{
+		{"", "", "", true, false},
+		{"http://localhost:8080/approve", "", "", true, true},
+		{"http://localhost:8080/approve", "10s", "on", true, true},
+		{"localhost:8080", "", "", false, false},
+		{"http://localhost:8080/approve", "-1s", "", false, false},
+		{"http://localhost:8080/approve", "", "maybe", false, false},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: URL, Value: testCase.url},
+			config.KV{Key: Timeout, Value: testCase.timeout},
+			config.KV{Key: FailOpen, Value: testCase.failOpen},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && cfg.Enabled() != testCase.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, testCase.enabled, cfg.Enabled())
+		}
+		if cfg.Enabled() && testCase.timeout == "" && cfg.Timeout != 5*time.Second {
+			t.Fatalf("Test %d: expected the default timeout, got %s", i+1, cfg.Timeout)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	kvs := config.KVS{
+		config.KV{Key: URL, Value: "http://localhost:8080/approve"},
+		config.KV{Key: Rules, Value: "records, logs/expire-old"},
+	}
+	cfg, err := LookupConfig(kvs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		bucket, ruleID string
+		expected       bool
+	}{
+		{"records", "any", true},
+		{"logs", "expire-old", true},
+		{"logs", "expire-tmp", false},
+		{"photos", "expire-old", false},
+	}
+	for i, testCase := range testCases {
+		if got := cfg.Matches(testCase.bucket, testCase.ruleID); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+
+	// All expirations require approval without rules.
+	cfg.Rules = nil
+	if !cfg.Matches("photos", "expire-old") {
+		t.Error("expected all rules to match")
+	}
+	if (Config{}).Matches("records", "any") {
+		t.Error("expected no rules to match without a webhook")
+	}
+}
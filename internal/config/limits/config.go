@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package limits
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Resource limits environment variables
+const (
+	MemoryMax     = "memory_max"
+	RequestsMax   = "requests_max"
+	GoroutinesMax = "goroutines_max"
+
+	EnvMemoryMax     = "MINIO_LIMITS_MEMORY_MAX"
+	EnvRequestsMax   = "MINIO_LIMITS_REQUESTS_MAX"
+	EnvGoroutinesMax = "MINIO_LIMITS_GOROUTINES_MAX"
+)
+
+// Subsystems whose concurrency can be limited with goroutines_max.
+const (
+	Heal        = "heal"
+	Scanner     = "scanner"
+	Replication = "replication"
+	Transition  = "transition"
+)
+
+// Subsystems - all subsystems whose concurrency can be limited.
+var Subsystems = []string{Heal, Scanner, Replication, Transition}
+
+// Config represents the resource limits of a node, zero values are
+// not limited.
+type Config struct {
+	// MemoryMax is the memory in bytes S3 requests may reserve for
+	// their buffers at the same time.
+	MemoryMax uint64 `json:"memoryMax"`
+	// RequestsMax is the maximum number of concurrent S3 requests.
+	RequestsMax int `json:"requestsMax"`
+	// Goroutines is the maximum number of concurrent operations by
+	// subsystem.
+	Goroutines map[string]int `json:"goroutines,omitempty"`
+}
+
+var (
+	// DefaultKVS - default KV config for resource limits
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   MemoryMax,
+			Value: "0",
+		},
+		config.KV{
+			Key:   RequestsMax,
+			Value: "0",
+		},
+		config.KV{
+			Key:   GoroutinesMax,
+			Value: "",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         MemoryMax,
+			Description: `memory S3 requests of this node may reserve for buffers at the same time e.g. "4GiB", "0" is not limited`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         RequestsMax,
+			Description: `maximum number of concurrent S3 requests on this node, "0" is not limited`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         GoroutinesMax,
+			Description: `comma separated maximum number of concurrent operations by subsystem e.g. "heal=4,scanner=2", one of ` + strings.Join(Subsystems, ", "),
+			Optional:    true,
+			Type:        "csv",
+		},
+	}
+)
+
+func parseGoroutines(s string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, part := range strings.Split(s, config.ValueSeparator) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("'%s' must be of the form subsystem=number", part)
+		}
+		name := strings.TrimSpace(kv[0])
+		valid := false
+		for _, subsys := range Subsystems {
+			valid = valid || subsys == name
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown subsystem '%s'", name)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("'%s' must be a positive number", part)
+		}
+		limits[name] = n
+	}
+	return limits, nil
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.LimitsSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	if v := env.Get(EnvMemoryMax, kvs.Get(MemoryMax)); v != "" && v != "0" {
+		if cfg.MemoryMax, err = humanize.ParseBytes(v); err != nil {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.LimitsSubSys, MemoryMax, err)
+		}
+	}
+	if v := env.Get(EnvRequestsMax, kvs.Get(RequestsMax)); v != "" {
+		cfg.RequestsMax, err = strconv.Atoi(v)
+		if err != nil || cfg.RequestsMax < 0 {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.LimitsSubSys, RequestsMax, v)
+		}
+	}
+	if cfg.Goroutines, err = parseGoroutines(env.Get(EnvGoroutinesMax, kvs.Get(GoroutinesMax))); err != nil {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.LimitsSubSys, GoroutinesMax, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package limits
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		memory, requests, goroutines string
+		success                      bool
+		expected                     Config
+	}{
+		{"", "", "", true, Config{Goroutines: map[string]int{}}},
+		{"0", "0", "", true, Config{Goroutines: map[string]int{}}},
+		{"4GiB", "500", "heal=4, scanner=2", true, Config{
+			MemoryMax:   4 << 30,
+			RequestsMax: 500,
+			Goroutines:  map[string]int{Heal: 4, Scanner: 2},
+		}},
+		{"lots", "", "", false, Config{}},
+		{"", "-1", "", false, Config{}},
+		{"", "", "heal", false, Config{}},
+		{"", "", "heal=0", false, Config{}},
+		{"", "", "listing=4", false, Config{}},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: MemoryMax, Value: testCase.memory},
+			config.KV{Key: RequestsMax, Value: testCase.requests},
+			config.KV{Key: GoroutinesMax, Value: testCase.goroutines},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && !reflect.DeepEqual(cfg, testCase.expected) {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, testCase.expected, cfg)
+		}
+	}
+
+}
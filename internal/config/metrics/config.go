@@ -0,0 +1,266 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Metrics environment variables
+const (
+	BucketLatency      = "bucket_latency"
+	BucketLatencyTop   = "bucket_latency_top"
+	BucketLatencyLimit = "bucket_latency_limit"
+	Families           = "families"
+	FamiliesDisabled   = "families_disabled"
+	BucketLabels       = "bucket_labels"
+	BucketLabelsTop    = "bucket_labels_top"
+	UserLabels         = "user_labels"
+	UserLabelsTop      = "user_labels_top"
+
+	EnvBucketLatency      = "MINIO_METRICS_BUCKET_LATENCY"
+	EnvBucketLatencyTop   = "MINIO_METRICS_BUCKET_LATENCY_TOP"
+	EnvBucketLatencyLimit = "MINIO_METRICS_BUCKET_LATENCY_LIMIT"
+	EnvFamilies           = "MINIO_METRICS_FAMILIES"
+	EnvFamiliesDisabled   = "MINIO_METRICS_FAMILIES_DISABLED"
+	EnvBucketLabels       = "MINIO_METRICS_BUCKET_LABELS"
+	EnvBucketLabelsTop    = "MINIO_METRICS_BUCKET_LABELS_TOP"
+	EnvUserLabels         = "MINIO_METRICS_USER_LABELS"
+	EnvUserLabelsTop      = "MINIO_METRICS_USER_LABELS_TOP"
+)
+
+// LabelLimit restricts the values of a label exported with their own
+// series, the series of all other values are aggregated.
+type LabelLimit struct {
+	// Allow are the patterns of the values always exported.
+	Allow []string `json:"allow"`
+	// Top is the number of values with the most traffic exported.
+	Top int `json:"top"`
+}
+
+// Enabled returns true if the values of the label are restricted.
+func (l LabelLimit) Enabled() bool {
+	return len(l.Allow) > 0 || l.Top > 0
+}
+
+// Config represents the optional metrics of the server.
+type Config struct {
+	// BucketLatency enables request latency histograms by API and bucket.
+	BucketLatency bool `json:"bucketLatency"`
+	// BucketLatencyTop is the number of buckets, by requests, exported
+	// with their own label, all other buckets are aggregated.
+	BucketLatencyTop int `json:"bucketLatencyTop"`
+	// BucketLatencyLimit is the maximum number of buckets tracked by a
+	// node, requests to further buckets are only aggregated.
+	BucketLatencyLimit int `json:"bucketLatencyLimit"`
+	// Families are the prefixes of the names of the exported metrics,
+	// all metrics are exported if empty.
+	Families []string `json:"families"`
+	// FamiliesDisabled are the prefixes of the names of the metrics
+	// which are never exported.
+	FamiliesDisabled []string `json:"familiesDisabled"`
+	// BucketLabels restricts the buckets exported with their own label.
+	BucketLabels LabelLimit `json:"bucketLabels"`
+	// UserLabels restricts the users exported with their own label.
+	UserLabels LabelLimit `json:"userLabels"`
+}
+
+// FamilyEnabled returns true if the metric with the name is exported.
+func (c Config) FamilyEnabled(name string) bool {
+	for _, prefix := range c.FamiliesDisabled {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	if len(c.Families) == 0 {
+		return true
+	}
+	for _, prefix := range c.Families {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultKVS - default KV config for metrics
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   BucketLatency,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   BucketLatencyTop,
+			Value: "10",
+		},
+		config.KV{
+			Key:   BucketLatencyLimit,
+			Value: "1000",
+		},
+		config.KV{
+			Key:   Families,
+			Value: "",
+		},
+		config.KV{
+			Key:   FamiliesDisabled,
+			Value: "",
+		},
+		config.KV{
+			Key:   BucketLabels,
+			Value: "",
+		},
+		config.KV{
+			Key:   BucketLabelsTop,
+			Value: "0",
+		},
+		config.KV{
+			Key:   UserLabels,
+			Value: "",
+		},
+		config.KV{
+			Key:   UserLabelsTop,
+			Value: "0",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         BucketLatency,
+			Description: `set to "on" to export request latency histograms by API and bucket`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         BucketLatencyTop,
+			Description: `number of buckets with the most requests exported with their own label, others are aggregated e.g. "10"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         BucketLatencyLimit,
+			Description: `maximum number of buckets tracked per node, requests to further buckets are aggregated e.g. "1000"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Families,
+			Description: `comma separated prefixes of the metrics to export, all if empty e.g. "minio_s3_,minio_node_"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         FamiliesDisabled,
+			Description: `comma separated prefixes of the metrics not to export e.g. "minio_bucket_replication_"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         BucketLabels,
+			Description: `comma separated buckets always exported with their own label, wildcards allowed e.g. "prod-*"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         BucketLabelsTop,
+			Description: `number of buckets with the most requests exported with their own label, "0" exports all if no buckets are listed`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         UserLabels,
+			Description: `comma separated access keys always exported with their own label, wildcards allowed`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         UserLabelsTop,
+			Description: `number of access keys with the most requests exported with their own label, "0" exports all if no access keys are listed`,
+			Optional:    true,
+			Type:        "number",
+		},
+	}
+)
+
+func lookupCount(kvs config.KVS, key, envKey, def string) (int, error) {
+	v := env.Get(envKey, kvs.Get(key))
+	if v == "" {
+		v = def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("'%s:%s' value invalid: %s", config.MetricsSubSys, key, v)
+	}
+	return n, nil
+}
+
+func lookupList(kvs config.KVS, key, envKey string) []string {
+	var list []string
+	for _, v := range strings.Split(env.Get(envKey, kvs.Get(key)), config.ValueSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func lookupLabelLimit(kvs config.KVS, key, envKey, topKey, envTopKey string) (l LabelLimit, err error) {
+	l.Allow = lookupList(kvs, key, envKey)
+	if v := env.Get(envTopKey, kvs.Get(topKey)); v != "" {
+		if l.Top, err = strconv.Atoi(v); err != nil || l.Top < 0 {
+			return l, fmt.Errorf("'%s:%s' value invalid: %s", config.MetricsSubSys, topKey, v)
+		}
+	}
+	return l, nil
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.MetricsSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	if v := env.Get(EnvBucketLatency, kvs.Get(BucketLatency)); v != "" {
+		if cfg.BucketLatency, err = config.ParseBool(v); err != nil {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.MetricsSubSys, BucketLatency, err)
+		}
+	}
+	if cfg.BucketLatencyTop, err = lookupCount(kvs, BucketLatencyTop, EnvBucketLatencyTop, "10"); err != nil {
+		return cfg, err
+	}
+	if cfg.BucketLatencyLimit, err = lookupCount(kvs, BucketLatencyLimit, EnvBucketLatencyLimit, "1000"); err != nil {
+		return cfg, err
+	}
+	if cfg.BucketLatencyTop > cfg.BucketLatencyLimit {
+		return cfg, fmt.Errorf("'%s:%s' must not exceed '%s'", config.MetricsSubSys, BucketLatencyTop, BucketLatencyLimit)
+	}
+	cfg.Families = lookupList(kvs, Families, EnvFamilies)
+	cfg.FamiliesDisabled = lookupList(kvs, FamiliesDisabled, EnvFamiliesDisabled)
+	if cfg.BucketLabels, err = lookupLabelLimit(kvs, BucketLabels, EnvBucketLabels, BucketLabelsTop, EnvBucketLabelsTop); err != nil {
+		return cfg, err
+	}
+	if cfg.UserLabels, err = lookupLabelLimit(kvs, UserLabels, EnvUserLabels, UserLabelsTop, EnvUserLabelsTop); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
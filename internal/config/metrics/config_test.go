@@ -0,0 +1,128 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		enabled, top, limit string
+		success             bool
+		expected            Config
+	}{
+		{"", "", "", true, Config{BucketLatencyTop: 10, BucketLatencyLimit: 1000}},
+		{"on", "", "", true, Config{BucketLatency: true, BucketLatencyTop: 10, BucketLatencyLimit: 1000}},
+		{"on", "50", "5000", true, Config{BucketLatency: true, BucketLatencyTop: 50, BucketLatencyLimit: 5000}},
+		{"maybe", "", "", false, Config{}},
+		{"on", "0", "", false, Config{}},
+		{"on", "", "-1", false, Config{}},
+		{"on", "20", "10", false, Config{}},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: BucketLatency, Value: testCase.enabled},
+			config.KV{Key: BucketLatencyTop, Value: testCase.top},
+			config.KV{Key: BucketLatencyLimit, Value: testCase.limit},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && !reflect.DeepEqual(cfg, testCase.expected) {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, testCase.expected, cfg)
+		}
+	}
+}
+
+func TestLookupConfigCardinality(t *testing.T) {
+	testCases := []struct {
+		kvs      config.KVS
+		success  bool
+		expected Config
+	}{
+		{
+			kvs:      config.KVS{},
+			success:  true,
+			expected: Config{BucketLatencyTop: 10, BucketLatencyLimit: 1000},
+		},
+		{
+			kvs: config.KVS{
+				config.KV{Key: Families, Value: "minio_s3_, minio_node_"},
+				config.KV{Key: FamiliesDisabled, Value: "minio_node_drive_health_"},
+				config.KV{Key: BucketLabels, Value: "prod-*,logs"},
+				config.KV{Key: BucketLabelsTop, Value: "20"},
+				config.KV{Key: UserLabelsTop, Value: "5"},
+			},
+			success: true,
+			expected: Config{
+				BucketLatencyTop:   10,
+				BucketLatencyLimit: 1000,
+				Families:           []string{"minio_s3_", "minio_node_"},
+				FamiliesDisabled:   []string{"minio_node_drive_health_"},
+				BucketLabels:       LabelLimit{Allow: []string{"prod-*", "logs"}, Top: 20},
+				UserLabels:         LabelLimit{Top: 5},
+			},
+		},
+		{
+			kvs:     config.KVS{config.KV{Key: BucketLabelsTop, Value: "-1"}},
+			success: false,
+		},
+		{
+			kvs:     config.KVS{config.KV{Key: UserLabelsTop, Value: "many"}},
+			success: false,
+		},
+	}
+	for i, testCase := range testCases {
+		cfg, err := LookupConfig(testCase.kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && !reflect.DeepEqual(cfg, testCase.expected) {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, testCase.expected, cfg)
+		}
+	}
+}
+
+func TestFamilyEnabled(t *testing.T) {
+	cfg := Config{
+		Families:         []string{"minio_node_", "minio_s3_"},
+		FamiliesDisabled: []string{"minio_node_drive_health_"},
+	}
+	testCases := []struct {
+		name    string
+		enabled bool
+	}{
+		{"minio_s3_requests_total", true},
+		{"minio_node_process_uptime_seconds", true},
+		{"minio_node_drive_health_io_errors", false},
+		{"minio_bucket_usage_total_bytes", false},
+	}
+	for _, testCase := range testCases {
+		if enabled := cfg.FamilyEnabled(testCase.name); enabled != testCase.enabled {
+			t.Errorf("%s: expected %v, got %v", testCase.name, testCase.enabled, enabled)
+		}
+	}
+	if !(Config{}).FamilyEnabled("minio_bucket_usage_total_bytes") {
+		t.Error("expected all metrics to be exported by default")
+	}
+}
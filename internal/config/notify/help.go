@@ -23,9 +23,19 @@ import (
 )
 
 const (
-	formatComment     = `'namespace' reflects current bucket/object list and 'access' reflects a journal of object operations, defaults to 'namespace'`
-	queueDirComment   = `staging dir for undelivered messages e.g. '/home/events'`
-	queueLimitComment = `maximum limit for undelivered messages, defaults to '100000'`
+	formatComment      = `'namespace' reflects current bucket/object list and 'access' reflects a journal of object operations, defaults to 'namespace'`
+	queueDirComment    = `staging dir for undelivered messages e.g. '/home/events'`
+	queueLimitComment  = `maximum limit for undelivered messages, defaults to '100000'`
+	eventFormatComment = `'s3' or 'cloudevents' event payload format, defaults to 's3'`
+
+	orderedDeliveryComment = `set to 'on' to deliver events of the same object in operation order, defaults to 'off'`
+	queueMaxAgeComment     = `drop queued events older than this duration e.g. "24h", by default events are kept until delivered`
+	queueOverflowComment   = `action on a full queue_dir, one of 'reject', 'block' or 'drop_oldest', defaults to 'reject'`
+	schemaVersionComment   = `event schema version to send events in e.g. "2.0", defaults to the latest version`
+
+	batchMaxCountComment   = `maximum number of events delivered in one request, batching is enabled for values larger than '1'`
+	batchMaxBytesComment   = `maximum size in bytes of a batch of events, defaults to '0' i.e. unlimited`
+	batchMaxLatencyComment = `maximum time an event waits for its batch to fill up, defaults to '1s'`
 )
 
 // Help template inputs for all notification targets
@@ -56,6 +66,60 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.WebhookOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.WebhookQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.WebhookQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.WebhookSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.WebhookEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
+		config.HelpKV{
+			Key:         target.WebhookBatchMaxCount,
+			Description: batchMaxCountComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.WebhookBatchMaxBytes,
+			Description: batchMaxBytesComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.WebhookBatchMaxLatency,
+			Description: batchMaxLatencyComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.WebhookCEMode,
+			Description: "CloudEvents HTTP content mode, 'structured' or 'binary', defaults to 'structured'",
+			Optional:    true,
+			Type:        "structured|binary",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -76,6 +140,13 @@ var (
 			Type:        "string",
 			Sensitive:   true,
 		},
+		config.HelpKV{
+			Key:         target.WebhookHMACSecret,
+			Description: "comma separated secrets to sign requests with HMAC-SHA256, add the new secret first when rotating",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
 	}
 
 	HelpAMQP = config.HelpKVS{
@@ -158,6 +229,36 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.AmqpOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.AmqpQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.AmqpQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.AmqpSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.AmqpEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -248,6 +349,54 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.KafkaOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.KafkaQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.KafkaQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.KafkaSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.KafkaEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
+		config.HelpKV{
+			Key:         target.KafkaBatchMaxCount,
+			Description: batchMaxCountComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.KafkaBatchMaxBytes,
+			Description: batchMaxBytesComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.KafkaBatchMaxLatency,
+			Description: batchMaxLatencyComment,
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         target.KafkaVersion,
 			Description: "specify the version of the Kafka cluster",
@@ -271,7 +420,7 @@ var (
 		},
 		config.HelpKV{
 			Key:         target.MqttTopic,
-			Description: "name of the MQTT topic to publish",
+			Description: "name of the MQTT topic to publish, `{bucket}` and `{event}` are replaced by the bucket and event name",
 			Type:        "string",
 		},
 		config.HelpKV{
@@ -318,6 +467,54 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.MqttOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.MqttQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.MqttQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.MqttSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.MqttEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
+		config.HelpKV{
+			Key:         target.MqttProtocolVersion,
+			Description: "MQTT protocol version, defaults to '3.1.1'",
+			Optional:    true,
+			Type:        "3.1.1|5",
+		},
+		config.HelpKV{
+			Key:         target.MqttSessionExpiry,
+			Description: "time the broker keeps the session after a disconnect in s,m,h,d, requires protocol version 5",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.MqttMessageExpiry,
+			Description: "time the broker keeps undelivered events in s,m,h,d, requires protocol version 5",
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -355,6 +552,30 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.PostgresOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PostgresQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.PostgresQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.PostgresSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -399,6 +620,30 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.MySQLOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.MySQLQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.MySQLQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.MySQLSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -488,6 +733,30 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         target.NATSJetStream,
+			Description: "set to 'on', to publish to NATS JetStream with acknowledgements",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.NATSJetStreamStream,
+			Description: "JetStream stream for the subject, created if it does not exist",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.NATSJetStreamAsync,
+			Description: "set to 'on', to publish to JetStream without waiting for each acknowledgement",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.NATSJetStreamMaxPending,
+			Description: "number of asynchronous JetStream messages awaiting acknowledgement",
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         target.NATSCertAuthority,
 			Description: "path to certificate chain of the target NATS server",
@@ -521,6 +790,36 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.NATSOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.NATSQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.NATSQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.NATSSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.NATSEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -565,6 +864,305 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.NSQOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.NSQQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.NSQQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.NSQSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.NSQEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpPubSub = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.PubSubProjectID,
+			Description: "Google Cloud project ID of the topic",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PubSubTopic,
+			Description: "Pub/Sub topic ID",
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PubSubCredentialsFile,
+			Description: "path to a service account JSON key, defaults to the application default credentials e.g. workload identity",
+			Optional:    true,
+			Type:        "path",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.PubSubEndpoint,
+			Description: "Pub/Sub API endpoint e.g. 'https://us-east1-pubsub.googleapis.com/'",
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         target.PubSubOrdering,
+			Description: "set to 'on' to publish the events of an object with the object name as ordering key",
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PubSubMaxRetries,
+			Description: "maximum number of retries of a failed publish request, defaults to '3'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.PubSubRetryInterval,
+			Description: "initial backoff between retries, doubled on every retry e.g. '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.PubSubQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.PubSubQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.PubSubOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.PubSubQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.PubSubQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.PubSubSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.PubSubEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpEventHubs = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.EventHubsNamespace,
+			Description: "fully qualified Event Hubs namespace e.g. 'mynamespace.servicebus.windows.net', defaults to the connection string endpoint",
+			Optional:    true,
+			Type:        "address",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsEventHub,
+			Description: "event hub name, defaults to the connection string entity path",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsProtocol,
+			Description: "protocol to publish events with, 'kafka' or 'https', defaults to 'kafka'",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsConnectionString,
+			Description: "shared access signature connection string of the namespace or event hub",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.EventHubsTenantID,
+			Description: "Azure AD tenant ID of the service principal",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsClientID,
+			Description: "Azure AD client ID of the service principal",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsClientSecret,
+			Description: "Azure AD client secret of the service principal",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.EventHubsQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.EventHubsEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpSQS = config.HelpKVS{
+		config.HelpKV{
+			Key:         target.SQSQueueURL,
+			Description: "SQS queue URL e.g. 'https://sqs.us-east-1.amazonaws.com/123456789012/myqueue.fifo', queues ending in '.fifo' are FIFO queues",
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         target.SQSRegion,
+			Description: "AWS region of the queue, defaults to the region in the queue URL",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSAccessKey,
+			Description: "AWS access key, defaults to the AWS environment variables or the IAM role of the instance",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSSecretKey,
+			Description: "AWS secret key",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.SQSSessionToken,
+			Description: "AWS session token for temporary credentials",
+			Optional:    true,
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueDir,
+			Description: queueDirComment,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueLimit,
+			Description: queueLimitComment,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         target.SQSOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.SQSQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.SQSSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         target.SQSEventFormat,
+			Description: eventFormatComment,
+			Optional:    true,
+			Type:        "s3|cloudevents",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -602,6 +1200,30 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.ElasticOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.ElasticQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.ElasticQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.ElasticSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         target.ElasticUsername,
 			Description: "username for Elasticsearch basic-auth",
@@ -661,6 +1283,30 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         target.RedisOrderedDelivery,
+			Description: orderedDeliveryComment,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         target.RedisQueueMaxAge,
+			Description: queueMaxAgeComment,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         target.RedisQueueOverflow,
+			Description: queueOverflowComment,
+			Optional:    true,
+			Type:        "reject|block|drop_oldest",
+		},
+		config.HelpKV{
+			Key:         target.RedisSchemaVersion,
+			Description: schemaVersionComment,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
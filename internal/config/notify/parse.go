@@ -95,6 +95,52 @@ func RegisterNotificationTargets(ctx context.Context, cfg config.Config, transpo
 // If `returnOnTargetError` is set to true, The function returns when a target initialization fails
 // Else, the function will return a complete TargetList irrespective of errors
 func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *http.Transport, test bool, returnOnTargetError bool) (_ *event.TargetList, err error) {
+	includeAll := func(subSys, id string) bool { return true }
+	return fetchTargets(ctx, cfg, transport, test, returnOnTargetError, includeAll)
+}
+
+// GetNotificationTargetsByID initializes the enabled notification
+// targets of cfg with the given target IDs only.
+func GetNotificationTargetsByID(ctx context.Context, cfg config.Config, transport *http.Transport, targetIDs event.TargetIDSet) (*event.TargetList, error) {
+	include := func(subSys, id string) bool {
+		_, ok := targetIDs[event.TargetID{ID: id, Name: targetNames[subSys]}]
+		return ok
+	}
+	return fetchTargets(ctx, cfg, transport, false, false, include)
+}
+
+// ChangedNotificationTargets returns the IDs of the notification targets
+// which were added, modified or removed in cfg compared to prev.
+func ChangedNotificationTargets(prev, cfg config.Config) event.TargetIDSet {
+	changed := event.NewTargetIDSet()
+	for subSys, name := range targetNames {
+		for id, kvs := range cfg[subSys] {
+			if prevKVS, ok := prev[subSys][id]; !ok || !equalKVS(prevKVS, kvs) {
+				changed[event.TargetID{ID: id, Name: name}] = struct{}{}
+			}
+		}
+		for id := range prev[subSys] {
+			if _, ok := cfg[subSys][id]; !ok {
+				changed[event.TargetID{ID: id, Name: name}] = struct{}{}
+			}
+		}
+	}
+	return changed
+}
+
+func equalKVS(a, b config.KVS) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, kv := range a {
+		if v, ok := b.Lookup(kv.Key); !ok || v != kv.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func fetchTargets(ctx context.Context, cfg config.Config, transport *http.Transport, test bool, returnOnTargetError bool, include func(subSys, id string) bool) (_ *event.TargetList, err error) {
 	targetList := event.NewTargetList()
 	var targetsOffline bool
 
@@ -123,6 +169,16 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		return nil, err
 	}
 
+	eventHubsTargets, err := GetNotifyEventHubs(cfg[config.NotifyEventHubsSubSys], transport.TLSClientConfig.RootCAs)
+	if err != nil {
+		return nil, err
+	}
+
+	sqsTargets, err := GetNotifySQS(cfg[config.NotifySQSSubSys], transport)
+	if err != nil {
+		return nil, err
+	}
+
 	kafkaTargets, err := GetNotifyKafka(cfg[config.NotifyKafkaSubSys])
 	if err != nil {
 		return nil, err
@@ -148,6 +204,11 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		return nil, err
 	}
 
+	pubsubTargets, err := GetNotifyPubSub(cfg[config.NotifyPubSubSubSys])
+	if err != nil {
+		return nil, err
+	}
+
 	postgresTargets, err := GetNotifyPostgres(cfg[config.NotifyPostgresSubSys])
 	if err != nil {
 		return nil, err
@@ -164,7 +225,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range amqpTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyAMQPSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewAMQPTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
@@ -185,7 +246,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range esTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyESSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewElasticsearchTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
@@ -204,8 +265,48 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		}
 	}
 
+	for id, args := range eventHubsTargets {
+		if !args.Enable || !include(config.NotifyEventHubsSubSys, id) {
+			continue
+		}
+		newTarget, err := target.NewEventHubsTarget(id, args, ctx.Done(), logger.LogOnceIf, transport, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
+	for id, args := range sqsTargets {
+		if !args.Enable || !include(config.NotifySQSSubSys, id) {
+			continue
+		}
+		newTarget, err := target.NewSQSTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
 	for id, args := range kafkaTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyKafkaSubSys, id) {
 			continue
 		}
 		args.TLS.RootCAs = transport.TLSClientConfig.RootCAs
@@ -226,7 +327,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range mqttTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyMQTTSubSys, id) {
 			continue
 		}
 		args.RootCAs = transport.TLSClientConfig.RootCAs
@@ -247,7 +348,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range mysqlTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyMySQLSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewMySQLTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
@@ -267,7 +368,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range natsTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyNATSSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewNATSTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
@@ -287,7 +388,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range nsqTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyNSQSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewNSQTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
@@ -306,8 +407,28 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 		}
 	}
 
+	for id, args := range pubsubTargets {
+		if !args.Enable || !include(config.NotifyPubSubSubSys, id) {
+			continue
+		}
+		newTarget, err := target.NewPubSubTarget(ctx, id, args, logger.LogOnceIf, test)
+		if err != nil {
+			targetsOffline = true
+			if returnOnTargetError {
+				return nil, err
+			}
+			_ = newTarget.Close()
+		}
+		if err = targetList.Add(newTarget); err != nil {
+			logger.LogIf(context.Background(), err)
+			if returnOnTargetError {
+				return nil, err
+			}
+		}
+	}
+
 	for id, args := range postgresTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyPostgresSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewPostgreSQLTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
@@ -327,7 +448,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range redisTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyRedisSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewRedisTarget(id, args, ctx.Done(), logger.LogOnceIf, test)
@@ -347,7 +468,7 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	}
 
 	for id, args := range webhookTargets {
-		if !args.Enable {
+		if !args.Enable || !include(config.NotifyWebhookSubSys, id) {
 			continue
 		}
 		newTarget, err := target.NewWebhookTarget(ctx, id, args, logger.LogOnceIf, transport, test)
@@ -373,19 +494,39 @@ func FetchRegisteredTargets(ctx context.Context, cfg config.Config, transport *h
 	return targetList, nil
 }
 
+// targetNames - the target ID names of the notification sub-systems.
+var targetNames = map[string]string{
+	config.NotifyAMQPSubSys:      "amqp",
+	config.NotifyKafkaSubSys:     "kafka",
+	config.NotifyMQTTSubSys:      "mqtt",
+	config.NotifyMySQLSubSys:     "mysql",
+	config.NotifyNATSSubSys:      "nats",
+	config.NotifyNSQSubSys:       "nsq",
+	config.NotifyPubSubSubSys:    "pubsub",
+	config.NotifyPostgresSubSys:  "postgresql",
+	config.NotifyRedisSubSys:     "redis",
+	config.NotifyWebhookSubSys:   "webhook",
+	config.NotifyESSubSys:        "elasticsearch",
+	config.NotifyEventHubsSubSys: "eventhubs",
+	config.NotifySQSSubSys:       "sqs",
+}
+
 // DefaultNotificationKVS - default notification list of kvs.
 var (
 	DefaultNotificationKVS = map[string]config.KVS{
-		config.NotifyAMQPSubSys:     DefaultAMQPKVS,
-		config.NotifyKafkaSubSys:    DefaultKafkaKVS,
-		config.NotifyMQTTSubSys:     DefaultMQTTKVS,
-		config.NotifyMySQLSubSys:    DefaultMySQLKVS,
-		config.NotifyNATSSubSys:     DefaultNATSKVS,
-		config.NotifyNSQSubSys:      DefaultNSQKVS,
-		config.NotifyPostgresSubSys: DefaultPostgresKVS,
-		config.NotifyRedisSubSys:    DefaultRedisKVS,
-		config.NotifyWebhookSubSys:  DefaultWebhookKVS,
-		config.NotifyESSubSys:       DefaultESKVS,
+		config.NotifyAMQPSubSys:      DefaultAMQPKVS,
+		config.NotifyKafkaSubSys:     DefaultKafkaKVS,
+		config.NotifyMQTTSubSys:      DefaultMQTTKVS,
+		config.NotifyMySQLSubSys:     DefaultMySQLKVS,
+		config.NotifyNATSSubSys:      DefaultNATSKVS,
+		config.NotifyNSQSubSys:       DefaultNSQKVS,
+		config.NotifyPubSubSubSys:    DefaultPubSubKVS,
+		config.NotifyPostgresSubSys:  DefaultPostgresKVS,
+		config.NotifyRedisSubSys:     DefaultRedisKVS,
+		config.NotifyWebhookSubSys:   DefaultWebhookKVS,
+		config.NotifyESSubSys:        DefaultESKVS,
+		config.NotifyEventHubsSubSys: DefaultEventHubsKVS,
+		config.NotifySQSSubSys:       DefaultSQSKVS,
 	}
 )
 
@@ -465,6 +606,38 @@ var (
 			Key:   target.KafkaQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.KafkaOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.KafkaQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.KafkaSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.KafkaEventFormat,
+			Value: event.S3Format,
+		},
+		config.KV{
+			Key:   target.KafkaBatchMaxCount,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.KafkaBatchMaxBytes,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.KafkaBatchMaxLatency,
+			Value: "1s",
+		},
 		config.KV{
 			Key:   target.KafkaQueueDir,
 			Value: "",
@@ -521,6 +694,36 @@ func GetNotifyKafka(kafkaKVS map[string]config.KVS) (map[string]target.KafkaArgs
 			return nil, err
 		}
 
+		orderedDeliveryEnv := target.EnvKafkaOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.KafkaOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvKafkaQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.KafkaQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvKafkaQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.KafkaQueueOverflow))
+
+		schemaVersionEnv := target.EnvKafkaSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.KafkaSchemaVersion))
+
 		clientAuthEnv := target.EnvKafkaTLSClientAuth
 		if k != config.Default {
 			clientAuthEnv = clientAuthEnv + config.Default + k
@@ -545,13 +748,45 @@ func GetNotifyKafka(kafkaKVS map[string]config.KVS) (map[string]target.KafkaArgs
 			versionEnv = versionEnv + config.Default + k
 		}
 
+		eventFormatEnv := target.EnvKafkaEventFormat
+		if k != config.Default {
+			eventFormatEnv = eventFormatEnv + config.Default + k
+		}
+
+		batchMaxCountEnv := target.EnvKafkaBatchMaxCount
+		if k != config.Default {
+			batchMaxCountEnv = batchMaxCountEnv + config.Default + k
+		}
+		batchMaxBytesEnv := target.EnvKafkaBatchMaxBytes
+		if k != config.Default {
+			batchMaxBytesEnv = batchMaxBytesEnv + config.Default + k
+		}
+		batchMaxLatencyEnv := target.EnvKafkaBatchMaxLatency
+		if k != config.Default {
+			batchMaxLatencyEnv = batchMaxLatencyEnv + config.Default + k
+		}
+		batchArgs, err := parseBatchArgs(
+			env.Get(batchMaxCountEnv, kv.Get(target.KafkaBatchMaxCount)),
+			env.Get(batchMaxBytesEnv, kv.Get(target.KafkaBatchMaxBytes)),
+			env.Get(batchMaxLatencyEnv, kv.Get(target.KafkaBatchMaxLatency)),
+		)
+		if err != nil {
+			return nil, err
+		}
+
 		kafkaArgs := target.KafkaArgs{
-			Enable:     enabled,
-			Brokers:    brokers,
-			Topic:      env.Get(topicEnv, kv.Get(target.KafkaTopic)),
-			QueueDir:   env.Get(queueDirEnv, kv.Get(target.KafkaQueueDir)),
-			QueueLimit: queueLimit,
-			Version:    env.Get(versionEnv, kv.Get(target.KafkaVersion)),
+			Enable:          enabled,
+			Brokers:         brokers,
+			Topic:           env.Get(topicEnv, kv.Get(target.KafkaTopic)),
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.KafkaQueueDir)),
+			QueueLimit:      queueLimit,
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
+			EventFormat:     env.Get(eventFormatEnv, kv.Get(target.KafkaEventFormat)),
+			Version:         env.Get(versionEnv, kv.Get(target.KafkaVersion)),
+			Batch:           batchArgs,
 		}
 
 		tlsEnableEnv := target.EnvKafkaTLS
@@ -654,6 +889,38 @@ var (
 			Key:   target.MqttQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.MqttOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.MqttQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.MqttQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.MqttSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.MqttEventFormat,
+			Value: event.S3Format,
+		},
+		config.KV{
+			Key:   target.MqttProtocolVersion,
+			Value: target.MQTTProtocolV311,
+		},
+		config.KV{
+			Key:   target.MqttSessionExpiry,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   target.MqttMessageExpiry,
+			Value: "0s",
+		},
 	}
 )
 
@@ -713,6 +980,36 @@ func GetNotifyMQTT(mqttKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			return nil, err
 		}
 
+		orderedDeliveryEnv := target.EnvMQTTOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.MqttOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvMQTTQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.MqttQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvMQTTQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.MqttQueueOverflow))
+
+		schemaVersionEnv := target.EnvMQTTSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.MqttSchemaVersion))
+
 		qosEnv := target.EnvMQTTQoS
 		if k != config.Default {
 			qosEnv = qosEnv + config.Default + k
@@ -744,6 +1041,36 @@ func GetNotifyMQTT(mqttKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			queueDirEnv = queueDirEnv + config.Default + k
 		}
 
+		eventFormatEnv := target.EnvMQTTEventFormat
+		if k != config.Default {
+			eventFormatEnv = eventFormatEnv + config.Default + k
+		}
+
+		protocolVersionEnv := target.EnvMQTTProtocolVersion
+		if k != config.Default {
+			protocolVersionEnv = protocolVersionEnv + config.Default + k
+		}
+
+		sessionExpiryEnv := target.EnvMQTTSessionExpiry
+		if k != config.Default {
+			sessionExpiryEnv = sessionExpiryEnv + config.Default + k
+		}
+		sessionExpiry, err := time.ParseDuration(env.Get(sessionExpiryEnv,
+			kv.Get(target.MqttSessionExpiry)))
+		if err != nil {
+			return nil, err
+		}
+
+		messageExpiryEnv := target.EnvMQTTMessageExpiry
+		if k != config.Default {
+			messageExpiryEnv = messageExpiryEnv + config.Default + k
+		}
+		messageExpiry, err := time.ParseDuration(env.Get(messageExpiryEnv,
+			kv.Get(target.MqttMessageExpiry)))
+		if err != nil {
+			return nil, err
+		}
+
 		mqttArgs := target.MQTTArgs{
 			Enable:               enabled,
 			Broker:               *brokerURL,
@@ -756,6 +1083,14 @@ func GetNotifyMQTT(mqttKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			RootCAs:              rootCAs,
 			QueueDir:             env.Get(queueDirEnv, kv.Get(target.MqttQueueDir)),
 			QueueLimit:           queueLimit,
+			OrderedDelivery:      orderedDelivery,
+			QueueMaxAge:          queueMaxAge,
+			QueueOverflow:        queueOverflow,
+			SchemaVersion:        schemaVersion,
+			EventFormat:          env.Get(eventFormatEnv, kv.Get(target.MqttEventFormat)),
+			ProtocolVersion:      env.Get(protocolVersionEnv, kv.Get(target.MqttProtocolVersion)),
+			SessionExpiry:        sessionExpiry,
+			MessageExpiry:        messageExpiry,
 		}
 
 		if err = mqttArgs.Validate(); err != nil {
@@ -793,6 +1128,22 @@ var (
 			Key:   target.MySQLQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.MySQLOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.MySQLQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.MySQLQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.MySQLSchemaVersion,
+			Value: "",
+		},
 		config.KV{
 			Key:   target.MySQLMaxOpenConnections,
 			Value: "2",
@@ -826,6 +1177,36 @@ func GetNotifyMySQL(mysqlKVS map[string]config.KVS) (map[string]target.MySQLArgs
 			return nil, err
 		}
 
+		orderedDeliveryEnv := target.EnvMySQLOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.MySQLOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvMySQLQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.MySQLQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvMySQLQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.MySQLQueueOverflow))
+
+		schemaVersionEnv := target.EnvMySQLSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.MySQLSchemaVersion))
+
 		formatEnv := target.EnvMySQLFormat
 		if k != config.Default {
 			formatEnv = formatEnv + config.Default + k
@@ -863,6 +1244,10 @@ func GetNotifyMySQL(mysqlKVS map[string]config.KVS) (map[string]target.MySQLArgs
 			Table:              env.Get(tableEnv, kv.Get(target.MySQLTable)),
 			QueueDir:           env.Get(queueDirEnv, kv.Get(target.MySQLQueueDir)),
 			QueueLimit:         queueLimit,
+			OrderedDelivery:    orderedDelivery,
+			QueueMaxAge:        queueMaxAge,
+			QueueOverflow:      queueOverflow,
+			SchemaVersion:      schemaVersion,
 			MaxOpenConnections: maxOpenConnections,
 		}
 		if err = mysqlArgs.Validate(); err != nil {
@@ -940,6 +1325,22 @@ var (
 			Key:   target.NATSStreamingClusterID,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.NATSJetStream,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.NATSJetStreamStream,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.NATSJetStreamAsync,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.NATSJetStreamMaxPending,
+			Value: "0",
+		},
 		config.KV{
 			Key:   target.NATSQueueDir,
 			Value: "",
@@ -948,6 +1349,26 @@ var (
 			Key:   target.NATSQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.NATSOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.NATSQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.NATSQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.NATSSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.NATSEventFormat,
+			Value: event.S3Format,
+		},
 	}
 )
 
@@ -998,17 +1419,47 @@ func GetNotifyNATS(natsKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			return nil, err
 		}
 
-		tlsEnv := target.EnvNATSTLS
+		orderedDeliveryEnv := target.EnvNATSOrderedDelivery
 		if k != config.Default {
-			tlsEnv = tlsEnv + config.Default + k
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.NATSOrderedDelivery)))
+		if err != nil {
+			return nil, err
 		}
 
-		tlsSkipVerifyEnv := target.EnvNATSTLSSkipVerify
+		queueMaxAgeEnv := target.EnvNATSQueueMaxAge
 		if k != config.Default {
-			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.NATSQueueMaxAge)))
+		if err != nil {
+			return nil, err
 		}
 
-		subjectEnv := target.EnvNATSSubject
+		queueOverflowEnv := target.EnvNATSQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.NATSQueueOverflow))
+
+		schemaVersionEnv := target.EnvNATSSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.NATSSchemaVersion))
+
+		tlsEnv := target.EnvNATSTLS
+		if k != config.Default {
+			tlsEnv = tlsEnv + config.Default + k
+		}
+
+		tlsSkipVerifyEnv := target.EnvNATSTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+
+		subjectEnv := target.EnvNATSSubject
 		if k != config.Default {
 			subjectEnv = subjectEnv + config.Default + k
 		}
@@ -1048,22 +1499,32 @@ func GetNotifyNATS(natsKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			clientKeyEnv = clientKeyEnv + config.Default + k
 		}
 
+		eventFormatEnv := target.EnvNATSEventFormat
+		if k != config.Default {
+			eventFormatEnv = eventFormatEnv + config.Default + k
+		}
+
 		natsArgs := target.NATSArgs{
-			Enable:        true,
-			Address:       *address,
-			Subject:       env.Get(subjectEnv, kv.Get(target.NATSSubject)),
-			Username:      env.Get(usernameEnv, kv.Get(target.NATSUsername)),
-			Password:      env.Get(passwordEnv, kv.Get(target.NATSPassword)),
-			CertAuthority: env.Get(certAuthorityEnv, kv.Get(target.NATSCertAuthority)),
-			ClientCert:    env.Get(clientCertEnv, kv.Get(target.NATSClientCert)),
-			ClientKey:     env.Get(clientKeyEnv, kv.Get(target.NATSClientKey)),
-			Token:         env.Get(tokenEnv, kv.Get(target.NATSToken)),
-			TLS:           env.Get(tlsEnv, kv.Get(target.NATSTLS)) == config.EnableOn,
-			TLSSkipVerify: env.Get(tlsSkipVerifyEnv, kv.Get(target.NATSTLSSkipVerify)) == config.EnableOn,
-			PingInterval:  pingInterval,
-			QueueDir:      env.Get(queueDirEnv, kv.Get(target.NATSQueueDir)),
-			QueueLimit:    queueLimit,
-			RootCAs:       rootCAs,
+			Enable:          true,
+			Address:         *address,
+			Subject:         env.Get(subjectEnv, kv.Get(target.NATSSubject)),
+			Username:        env.Get(usernameEnv, kv.Get(target.NATSUsername)),
+			Password:        env.Get(passwordEnv, kv.Get(target.NATSPassword)),
+			CertAuthority:   env.Get(certAuthorityEnv, kv.Get(target.NATSCertAuthority)),
+			ClientCert:      env.Get(clientCertEnv, kv.Get(target.NATSClientCert)),
+			ClientKey:       env.Get(clientKeyEnv, kv.Get(target.NATSClientKey)),
+			Token:           env.Get(tokenEnv, kv.Get(target.NATSToken)),
+			TLS:             env.Get(tlsEnv, kv.Get(target.NATSTLS)) == config.EnableOn,
+			TLSSkipVerify:   env.Get(tlsSkipVerifyEnv, kv.Get(target.NATSTLSSkipVerify)) == config.EnableOn,
+			PingInterval:    pingInterval,
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.NATSQueueDir)),
+			QueueLimit:      queueLimit,
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
+			EventFormat:     env.Get(eventFormatEnv, kv.Get(target.NATSEventFormat)),
+			RootCAs:         rootCAs,
 		}
 
 		streamingEnableEnv := target.EnvNATSStreaming
@@ -1096,6 +1557,35 @@ func GetNotifyNATS(natsKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			natsArgs.Streaming.MaxPubAcksInflight = maxPubAcksInflight
 		}
 
+		jetStreamEnableEnv := target.EnvNATSJetStream
+		if k != config.Default {
+			jetStreamEnableEnv = jetStreamEnableEnv + config.Default + k
+		}
+
+		jetStreamEnabled := env.Get(jetStreamEnableEnv, kv.Get(target.NATSJetStream)) == config.EnableOn
+		if jetStreamEnabled {
+			streamEnv := target.EnvNATSJetStreamStream
+			if k != config.Default {
+				streamEnv = streamEnv + config.Default + k
+			}
+			asyncEnv := target.EnvNATSJetStreamAsync
+			if k != config.Default {
+				asyncEnv = asyncEnv + config.Default + k
+			}
+			maxPendingEnv := target.EnvNATSJetStreamMaxPending
+			if k != config.Default {
+				maxPendingEnv = maxPendingEnv + config.Default + k
+			}
+			maxPending, err := strconv.Atoi(env.Get(maxPendingEnv, kv.Get(target.NATSJetStreamMaxPending)))
+			if err != nil {
+				return nil, err
+			}
+			natsArgs.JetStream.Enable = jetStreamEnabled
+			natsArgs.JetStream.Stream = env.Get(streamEnv, kv.Get(target.NATSJetStreamStream))
+			natsArgs.JetStream.Async = env.Get(asyncEnv, kv.Get(target.NATSJetStreamAsync)) == config.EnableOn
+			natsArgs.JetStream.MaxPending = maxPending
+		}
+
 		if err = natsArgs.Validate(); err != nil {
 			return nil, err
 		}
@@ -1136,14 +1626,469 @@ var (
 			Key:   target.NSQQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.NSQOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.NSQQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.NSQQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.NSQSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.NSQEventFormat,
+			Value: event.S3Format,
+		},
+	}
+)
+
+// GetNotifyNSQ - returns a map of registered notification 'nsq' targets
+func GetNotifyNSQ(nsqKVS map[string]config.KVS) (map[string]target.NSQArgs, error) {
+	nsqTargets := make(map[string]target.NSQArgs)
+	for k, kv := range config.Merge(nsqKVS, target.EnvNSQEnable, DefaultNSQKVS) {
+		enableEnv := target.EnvNSQEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		addressEnv := target.EnvNSQAddress
+		if k != config.Default {
+			addressEnv = addressEnv + config.Default + k
+		}
+		nsqdAddress, err := xnet.ParseHost(env.Get(addressEnv, kv.Get(target.NSQAddress)))
+		if err != nil {
+			return nil, err
+		}
+		tlsEnableEnv := target.EnvNSQTLS
+		if k != config.Default {
+			tlsEnableEnv = tlsEnableEnv + config.Default + k
+		}
+		tlsSkipVerifyEnv := target.EnvNSQTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+
+		queueLimitEnv := target.EnvNSQQueueLimit
+		if k != config.Default {
+			queueLimitEnv = queueLimitEnv + config.Default + k
+		}
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.NSQQueueLimit)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		orderedDeliveryEnv := target.EnvNSQOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.NSQOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvNSQQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.NSQQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvNSQQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.NSQQueueOverflow))
+
+		schemaVersionEnv := target.EnvNSQSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.NSQSchemaVersion))
+
+		topicEnv := target.EnvNSQTopic
+		if k != config.Default {
+			topicEnv = topicEnv + config.Default + k
+		}
+		queueDirEnv := target.EnvNSQQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+
+		eventFormatEnv := target.EnvNSQEventFormat
+		if k != config.Default {
+			eventFormatEnv = eventFormatEnv + config.Default + k
+		}
+
+		nsqArgs := target.NSQArgs{
+			Enable:          enabled,
+			NSQDAddress:     *nsqdAddress,
+			Topic:           env.Get(topicEnv, kv.Get(target.NSQTopic)),
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.NSQQueueDir)),
+			QueueLimit:      queueLimit,
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
+			EventFormat:     env.Get(eventFormatEnv, kv.Get(target.NSQEventFormat)),
+		}
+		nsqArgs.TLS.Enable = env.Get(tlsEnableEnv, kv.Get(target.NSQTLS)) == config.EnableOn
+		nsqArgs.TLS.SkipVerify = env.Get(tlsSkipVerifyEnv, kv.Get(target.NSQTLSSkipVerify)) == config.EnableOn
+
+		if err = nsqArgs.Validate(); err != nil {
+			return nil, err
+		}
+
+		nsqTargets[k] = nsqArgs
+	}
+	return nsqTargets, nil
+}
+
+// DefaultEventHubsKVS - Azure Event Hubs KV for config
+var (
+	DefaultEventHubsKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.EventHubsNamespace,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsEventHub,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsProtocol,
+			Value: target.EventHubsKafka,
+		},
+		config.KV{
+			Key:   target.EventHubsConnectionString,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsTenantID,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsClientID,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsClientSecret,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsQueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsQueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.EventHubsOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.EventHubsQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.EventHubsSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.EventHubsEventFormat,
+			Value: event.S3Format,
+		},
+	}
+)
+
+// GetNotifyEventHubs - returns a map of registered notification 'eventhubs' targets
+func GetNotifyEventHubs(eventHubsKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[string]target.EventHubsArgs, error) {
+	eventHubsTargets := make(map[string]target.EventHubsArgs)
+	for k, kv := range config.Merge(eventHubsKVS, target.EnvEventHubsEnable, DefaultEventHubsKVS) {
+		enableEnv := target.EnvEventHubsEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		getEnv := func(envName, key string) string {
+			if k != config.Default {
+				envName = envName + config.Default + k
+			}
+			return env.Get(envName, kv.Get(key))
+		}
+
+		queueLimit, err := strconv.ParseUint(getEnv(target.EnvEventHubsQueueLimit, target.EventHubsQueueLimit), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		orderedDelivery, err := config.ParseBool(getEnv(target.EnvEventHubsOrderedDelivery, target.EventHubsOrderedDelivery))
+		if err != nil {
+			return nil, err
+		}
+		queueMaxAge, err := parseQueueMaxAge(getEnv(target.EnvEventHubsQueueMaxAge, target.EventHubsQueueMaxAge))
+		if err != nil {
+			return nil, err
+		}
+		queueOverflow := getEnv(target.EnvEventHubsQueueOverflow, target.EventHubsQueueOverflow)
+		schemaVersion := getEnv(target.EnvEventHubsSchemaVersion, target.EventHubsSchemaVersion)
+
+		eventHubsArgs := target.EventHubsArgs{
+			Enable:           enabled,
+			Namespace:        getEnv(target.EnvEventHubsNamespace, target.EventHubsNamespace),
+			EventHub:         getEnv(target.EnvEventHubsEventHub, target.EventHubsEventHub),
+			Protocol:         getEnv(target.EnvEventHubsProtocol, target.EventHubsProtocol),
+			ConnectionString: getEnv(target.EnvEventHubsConnectionString, target.EventHubsConnectionString),
+			TenantID:         getEnv(target.EnvEventHubsTenantID, target.EventHubsTenantID),
+			ClientID:         getEnv(target.EnvEventHubsClientID, target.EventHubsClientID),
+			ClientSecret:     getEnv(target.EnvEventHubsClientSecret, target.EventHubsClientSecret),
+			QueueDir:         getEnv(target.EnvEventHubsQueueDir, target.EventHubsQueueDir),
+			QueueLimit:       queueLimit,
+			OrderedDelivery:  orderedDelivery,
+			QueueMaxAge:      queueMaxAge,
+			QueueOverflow:    queueOverflow,
+			SchemaVersion:    schemaVersion,
+			EventFormat:      getEnv(target.EnvEventHubsEventFormat, target.EventHubsEventFormat),
+			RootCAs:          rootCAs,
+		}
+		if err = eventHubsArgs.Validate(); err != nil {
+			return nil, err
+		}
+
+		eventHubsTargets[k] = eventHubsArgs
+	}
+	return eventHubsTargets, nil
+}
+
+// DefaultSQSKVS - Amazon SQS KV for config
+var (
+	DefaultSQSKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.SQSQueueURL,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSRegion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSAccessKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSSecretKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSSessionToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSQueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSQueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.SQSOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.SQSQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.SQSSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.SQSEventFormat,
+			Value: event.S3Format,
+		},
+	}
+)
+
+// GetNotifySQS - returns a map of registered notification 'sqs' targets
+func GetNotifySQS(sqsKVS map[string]config.KVS, transport *http.Transport) (map[string]target.SQSArgs, error) {
+	sqsTargets := make(map[string]target.SQSArgs)
+	for k, kv := range config.Merge(sqsKVS, target.EnvSQSEnable, DefaultSQSKVS) {
+		enableEnv := target.EnvSQSEnable
+		if k != config.Default {
+			enableEnv = enableEnv + config.Default + k
+		}
+
+		enabled, err := config.ParseBool(env.Get(enableEnv, kv.Get(config.Enable)))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		getEnv := func(envName, key string) string {
+			if k != config.Default {
+				envName = envName + config.Default + k
+			}
+			return env.Get(envName, kv.Get(key))
+		}
+
+		queueURL, err := xnet.ParseHTTPURL(getEnv(target.EnvSQSQueueURL, target.SQSQueueURL))
+		if err != nil {
+			return nil, err
+		}
+		queueLimit, err := strconv.ParseUint(getEnv(target.EnvSQSQueueLimit, target.SQSQueueLimit), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		orderedDelivery, err := config.ParseBool(getEnv(target.EnvSQSOrderedDelivery, target.SQSOrderedDelivery))
+		if err != nil {
+			return nil, err
+		}
+		queueMaxAge, err := parseQueueMaxAge(getEnv(target.EnvSQSQueueMaxAge, target.SQSQueueMaxAge))
+		if err != nil {
+			return nil, err
+		}
+		queueOverflow := getEnv(target.EnvSQSQueueOverflow, target.SQSQueueOverflow)
+		schemaVersion := getEnv(target.EnvSQSSchemaVersion, target.SQSSchemaVersion)
+
+		sqsArgs := target.SQSArgs{
+			Enable:          enabled,
+			QueueURL:        *queueURL,
+			Region:          getEnv(target.EnvSQSRegion, target.SQSRegion),
+			AccessKey:       getEnv(target.EnvSQSAccessKey, target.SQSAccessKey),
+			SecretKey:       getEnv(target.EnvSQSSecretKey, target.SQSSecretKey),
+			SessionToken:    getEnv(target.EnvSQSSessionToken, target.SQSSessionToken),
+			QueueDir:        getEnv(target.EnvSQSQueueDir, target.SQSQueueDir),
+			QueueLimit:      queueLimit,
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
+			EventFormat:     getEnv(target.EnvSQSEventFormat, target.SQSEventFormat),
+			Transport:       transport,
+		}
+		if err = sqsArgs.Validate(); err != nil {
+			return nil, err
+		}
+
+		sqsTargets[k] = sqsArgs
+	}
+	return sqsTargets, nil
+}
+
+// DefaultPubSubKVS - Google Cloud Pub/Sub KV for config
+var (
+	DefaultPubSubKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PubSubProjectID,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubTopic,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubCredentialsFile,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubEndpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubOrdering,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PubSubMaxRetries,
+			Value: "3",
+		},
+		config.KV{
+			Key:   target.PubSubRetryInterval,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   target.PubSubQueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubQueueLimit,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.PubSubOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PubSubQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.PubSubSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PubSubEventFormat,
+			Value: event.S3Format,
+		},
 	}
 )
 
-// GetNotifyNSQ - returns a map of registered notification 'nsq' targets
-func GetNotifyNSQ(nsqKVS map[string]config.KVS) (map[string]target.NSQArgs, error) {
-	nsqTargets := make(map[string]target.NSQArgs)
-	for k, kv := range config.Merge(nsqKVS, target.EnvNSQEnable, DefaultNSQKVS) {
-		enableEnv := target.EnvNSQEnable
+// GetNotifyPubSub - returns a map of registered notification 'pubsub' targets
+func GetNotifyPubSub(pubsubKVS map[string]config.KVS) (map[string]target.PubSubArgs, error) {
+	pubsubTargets := make(map[string]target.PubSubArgs)
+	for k, kv := range config.Merge(pubsubKVS, target.EnvPubSubEnable, DefaultPubSubKVS) {
+		enableEnv := target.EnvPubSubEnable
 		if k != config.Default {
 			enableEnv = enableEnv + config.Default + k
 		}
@@ -1156,58 +2101,122 @@ func GetNotifyNSQ(nsqKVS map[string]config.KVS) (map[string]target.NSQArgs, erro
 			continue
 		}
 
-		addressEnv := target.EnvNSQAddress
+		projectIDEnv := target.EnvPubSubProjectID
 		if k != config.Default {
-			addressEnv = addressEnv + config.Default + k
+			projectIDEnv = projectIDEnv + config.Default + k
 		}
-		nsqdAddress, err := xnet.ParseHost(env.Get(addressEnv, kv.Get(target.NSQAddress)))
+		topicEnv := target.EnvPubSubTopic
+		if k != config.Default {
+			topicEnv = topicEnv + config.Default + k
+		}
+		credentialsFileEnv := target.EnvPubSubCredentialsFile
+		if k != config.Default {
+			credentialsFileEnv = credentialsFileEnv + config.Default + k
+		}
+		endpointEnv := target.EnvPubSubEndpoint
+		if k != config.Default {
+			endpointEnv = endpointEnv + config.Default + k
+		}
+
+		orderingEnv := target.EnvPubSubOrdering
+		if k != config.Default {
+			orderingEnv = orderingEnv + config.Default + k
+		}
+		ordering, err := config.ParseBool(env.Get(orderingEnv, kv.Get(target.PubSubOrdering)))
 		if err != nil {
 			return nil, err
 		}
-		tlsEnableEnv := target.EnvNSQTLS
+
+		maxRetriesEnv := target.EnvPubSubMaxRetries
 		if k != config.Default {
-			tlsEnableEnv = tlsEnableEnv + config.Default + k
+			maxRetriesEnv = maxRetriesEnv + config.Default + k
 		}
-		tlsSkipVerifyEnv := target.EnvNSQTLSSkipVerify
+		maxRetries, err := strconv.Atoi(env.Get(maxRetriesEnv, kv.Get(target.PubSubMaxRetries)))
+		if err != nil {
+			return nil, err
+		}
+
+		retryIntervalEnv := target.EnvPubSubRetryInterval
 		if k != config.Default {
-			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+			retryIntervalEnv = retryIntervalEnv + config.Default + k
+		}
+		retryInterval, err := time.ParseDuration(env.Get(retryIntervalEnv, kv.Get(target.PubSubRetryInterval)))
+		if err != nil {
+			return nil, err
 		}
 
-		queueLimitEnv := target.EnvNSQQueueLimit
+		queueDirEnv := target.EnvPubSubQueueDir
+		if k != config.Default {
+			queueDirEnv = queueDirEnv + config.Default + k
+		}
+		queueLimitEnv := target.EnvPubSubQueueLimit
 		if k != config.Default {
 			queueLimitEnv = queueLimitEnv + config.Default + k
 		}
-		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.NSQQueueLimit)), 10, 64)
+		queueLimit, err := strconv.ParseUint(env.Get(queueLimitEnv, kv.Get(target.PubSubQueueLimit)), 10, 64)
 		if err != nil {
 			return nil, err
 		}
 
-		topicEnv := target.EnvNSQTopic
+		orderedDeliveryEnv := target.EnvPubSubOrderedDelivery
 		if k != config.Default {
-			topicEnv = topicEnv + config.Default + k
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
 		}
-		queueDirEnv := target.EnvNSQQueueDir
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.PubSubOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvPubSubQueueMaxAge
 		if k != config.Default {
-			queueDirEnv = queueDirEnv + config.Default + k
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.PubSubQueueMaxAge)))
+		if err != nil {
+			return nil, err
 		}
 
-		nsqArgs := target.NSQArgs{
-			Enable:      enabled,
-			NSQDAddress: *nsqdAddress,
-			Topic:       env.Get(topicEnv, kv.Get(target.NSQTopic)),
-			QueueDir:    env.Get(queueDirEnv, kv.Get(target.NSQQueueDir)),
-			QueueLimit:  queueLimit,
+		queueOverflowEnv := target.EnvPubSubQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
 		}
-		nsqArgs.TLS.Enable = env.Get(tlsEnableEnv, kv.Get(target.NSQTLS)) == config.EnableOn
-		nsqArgs.TLS.SkipVerify = env.Get(tlsSkipVerifyEnv, kv.Get(target.NSQTLSSkipVerify)) == config.EnableOn
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.PubSubQueueOverflow))
 
-		if err = nsqArgs.Validate(); err != nil {
+		schemaVersionEnv := target.EnvPubSubSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.PubSubSchemaVersion))
+
+		eventFormatEnv := target.EnvPubSubEventFormat
+		if k != config.Default {
+			eventFormatEnv = eventFormatEnv + config.Default + k
+		}
+
+		pubsubArgs := target.PubSubArgs{
+			Enable:          enabled,
+			ProjectID:       env.Get(projectIDEnv, kv.Get(target.PubSubProjectID)),
+			Topic:           env.Get(topicEnv, kv.Get(target.PubSubTopic)),
+			CredentialsFile: env.Get(credentialsFileEnv, kv.Get(target.PubSubCredentialsFile)),
+			Endpoint:        env.Get(endpointEnv, kv.Get(target.PubSubEndpoint)),
+			Ordering:        ordering,
+			MaxRetries:      maxRetries,
+			RetryInterval:   retryInterval,
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.PubSubQueueDir)),
+			QueueLimit:      queueLimit,
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
+			EventFormat:     env.Get(eventFormatEnv, kv.Get(target.PubSubEventFormat)),
+		}
+		if err = pubsubArgs.Validate(); err != nil {
 			return nil, err
 		}
 
-		nsqTargets[k] = nsqArgs
+		pubsubTargets[k] = pubsubArgs
 	}
-	return nsqTargets, nil
+	return pubsubTargets, nil
 }
 
 // DefaultPostgresKVS - default Postgres KV for server config.
@@ -1237,6 +2246,22 @@ var (
 			Key:   target.PostgresQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.PostgresOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.PostgresQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.PostgresQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.PostgresSchemaVersion,
+			Value: "",
+		},
 		config.KV{
 			Key:   target.PostgresMaxOpenConnections,
 			Value: "2",
@@ -1271,6 +2296,36 @@ func GetNotifyPostgres(postgresKVS map[string]config.KVS) (map[string]target.Pos
 			return nil, err
 		}
 
+		orderedDeliveryEnv := target.EnvPostgresOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.PostgresOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvPostgresQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.PostgresQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvPostgresQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.PostgresQueueOverflow))
+
+		schemaVersionEnv := target.EnvPostgresSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.PostgresSchemaVersion))
+
 		formatEnv := target.EnvPostgresFormat
 		if k != config.Default {
 			formatEnv = formatEnv + config.Default + k
@@ -1308,6 +2363,10 @@ func GetNotifyPostgres(postgresKVS map[string]config.KVS) (map[string]target.Pos
 			Table:              env.Get(tableEnv, kv.Get(target.PostgresTable)),
 			QueueDir:           env.Get(queueDirEnv, kv.Get(target.PostgresQueueDir)),
 			QueueLimit:         uint64(queueLimit),
+			OrderedDelivery:    orderedDelivery,
+			QueueMaxAge:        queueMaxAge,
+			QueueOverflow:      queueOverflow,
+			SchemaVersion:      schemaVersion,
 			MaxOpenConnections: maxOpenConnections,
 		}
 		if err = psqlArgs.Validate(); err != nil {
@@ -1350,6 +2409,22 @@ var (
 			Key:   target.RedisQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.RedisOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.RedisQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.RedisQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.RedisSchemaVersion,
+			Value: "",
+		},
 	}
 )
 
@@ -1386,6 +2461,36 @@ func GetNotifyRedis(redisKVS map[string]config.KVS) (map[string]target.RedisArgs
 		if err != nil {
 			return nil, err
 		}
+
+		orderedDeliveryEnv := target.EnvRedisOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.RedisOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvRedisQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.RedisQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvRedisQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.RedisQueueOverflow))
+
+		schemaVersionEnv := target.EnvRedisSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.RedisSchemaVersion))
 		formatEnv := target.EnvRedisFormat
 		if k != config.Default {
 			formatEnv = formatEnv + config.Default + k
@@ -1403,13 +2508,17 @@ func GetNotifyRedis(redisKVS map[string]config.KVS) (map[string]target.RedisArgs
 			queueDirEnv = queueDirEnv + config.Default + k
 		}
 		redisArgs := target.RedisArgs{
-			Enable:     enabled,
-			Format:     env.Get(formatEnv, kv.Get(target.RedisFormat)),
-			Addr:       *addr,
-			Password:   env.Get(passwordEnv, kv.Get(target.RedisPassword)),
-			Key:        env.Get(keyEnv, kv.Get(target.RedisKey)),
-			QueueDir:   env.Get(queueDirEnv, kv.Get(target.RedisQueueDir)),
-			QueueLimit: uint64(queueLimit),
+			Enable:          enabled,
+			Format:          env.Get(formatEnv, kv.Get(target.RedisFormat)),
+			Addr:            *addr,
+			Password:        env.Get(passwordEnv, kv.Get(target.RedisPassword)),
+			Key:             env.Get(keyEnv, kv.Get(target.RedisKey)),
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.RedisQueueDir)),
+			QueueLimit:      uint64(queueLimit),
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
 		}
 		if err = redisArgs.Validate(); err != nil {
 			return nil, err
@@ -1438,6 +2547,42 @@ var (
 			Key:   target.WebhookQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.WebhookOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.WebhookQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.WebhookQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.WebhookSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.WebhookEventFormat,
+			Value: event.S3Format,
+		},
+		config.KV{
+			Key:   target.WebhookBatchMaxCount,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.WebhookBatchMaxBytes,
+			Value: "0",
+		},
+		config.KV{
+			Key:   target.WebhookBatchMaxLatency,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   target.WebhookCEMode,
+			Value: target.CEModeStructured,
+		},
 		config.KV{
 			Key:   target.WebhookQueueDir,
 			Value: "",
@@ -1450,6 +2595,10 @@ var (
 			Key:   target.WebhookClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.WebhookHMACSecret,
+			Value: "",
+		},
 	}
 )
 
@@ -1485,6 +2634,36 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 		if err != nil {
 			return nil, err
 		}
+
+		orderedDeliveryEnv := target.EnvWebhookOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.WebhookOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvWebhookQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.WebhookQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvWebhookQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.WebhookQueueOverflow))
+
+		schemaVersionEnv := target.EnvWebhookSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.WebhookSchemaVersion))
 		queueDirEnv := target.EnvWebhookQueueDir
 		if k != config.Default {
 			queueDirEnv = queueDirEnv + config.Default + k
@@ -1502,16 +2681,64 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 		if k != config.Default {
 			clientKeyEnv = clientKeyEnv + config.Default + k
 		}
+		hmacSecretEnv := target.EnvWebhookHMACSecret
+		if k != config.Default {
+			hmacSecretEnv = hmacSecretEnv + config.Default + k
+		}
+		var hmacSecrets []string
+		for _, secret := range strings.Split(env.Get(hmacSecretEnv, kv.Get(target.WebhookHMACSecret)), config.ValueSeparator) {
+			if secret = strings.TrimSpace(secret); secret != "" {
+				hmacSecrets = append(hmacSecrets, secret)
+			}
+		}
+
+		eventFormatEnv := target.EnvWebhookEventFormat
+		if k != config.Default {
+			eventFormatEnv = eventFormatEnv + config.Default + k
+		}
+		ceModeEnv := target.EnvWebhookCEMode
+		if k != config.Default {
+			ceModeEnv = ceModeEnv + config.Default + k
+		}
+
+		batchMaxCountEnv := target.EnvWebhookBatchMaxCount
+		if k != config.Default {
+			batchMaxCountEnv = batchMaxCountEnv + config.Default + k
+		}
+		batchMaxBytesEnv := target.EnvWebhookBatchMaxBytes
+		if k != config.Default {
+			batchMaxBytesEnv = batchMaxBytesEnv + config.Default + k
+		}
+		batchMaxLatencyEnv := target.EnvWebhookBatchMaxLatency
+		if k != config.Default {
+			batchMaxLatencyEnv = batchMaxLatencyEnv + config.Default + k
+		}
+		batchArgs, err := parseBatchArgs(
+			env.Get(batchMaxCountEnv, kv.Get(target.WebhookBatchMaxCount)),
+			env.Get(batchMaxBytesEnv, kv.Get(target.WebhookBatchMaxBytes)),
+			env.Get(batchMaxLatencyEnv, kv.Get(target.WebhookBatchMaxLatency)),
+		)
+		if err != nil {
+			return nil, err
+		}
 
 		webhookArgs := target.WebhookArgs{
-			Enable:     enabled,
-			Endpoint:   *url,
-			Transport:  transport,
-			AuthToken:  env.Get(authEnv, kv.Get(target.WebhookAuthToken)),
-			QueueDir:   env.Get(queueDirEnv, kv.Get(target.WebhookQueueDir)),
-			QueueLimit: uint64(queueLimit),
-			ClientCert: env.Get(clientCertEnv, kv.Get(target.WebhookClientCert)),
-			ClientKey:  env.Get(clientKeyEnv, kv.Get(target.WebhookClientKey)),
+			Enable:          enabled,
+			Endpoint:        *url,
+			Transport:       transport,
+			AuthToken:       env.Get(authEnv, kv.Get(target.WebhookAuthToken)),
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.WebhookQueueDir)),
+			QueueLimit:      uint64(queueLimit),
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
+			EventFormat:     env.Get(eventFormatEnv, kv.Get(target.WebhookEventFormat)),
+			CEMode:          env.Get(ceModeEnv, kv.Get(target.WebhookCEMode)),
+			ClientCert:      env.Get(clientCertEnv, kv.Get(target.WebhookClientCert)),
+			ClientKey:       env.Get(clientKeyEnv, kv.Get(target.WebhookClientKey)),
+			Batch:           batchArgs,
+			HMACSecrets:     hmacSecrets,
 		}
 		if err = webhookArgs.Validate(); err != nil {
 			return nil, err
@@ -1548,6 +2775,22 @@ var (
 			Key:   target.ElasticQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.ElasticOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.ElasticQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.ElasticQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.ElasticSchemaVersion,
+			Value: "",
+		},
 		config.KV{
 			Key:   target.ElasticUsername,
 			Value: "",
@@ -1595,6 +2838,36 @@ func GetNotifyES(esKVS map[string]config.KVS, transport *http.Transport) (map[st
 			return nil, err
 		}
 
+		orderedDeliveryEnv := target.EnvElasticOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.ElasticOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvElasticQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.ElasticQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvElasticQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.ElasticQueueOverflow))
+
+		schemaVersionEnv := target.EnvElasticSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.ElasticSchemaVersion))
+
 		formatEnv := target.EnvElasticFormat
 		if k != config.Default {
 			formatEnv = formatEnv + config.Default + k
@@ -1621,15 +2894,19 @@ func GetNotifyES(esKVS map[string]config.KVS, transport *http.Transport) (map[st
 		}
 
 		esArgs := target.ElasticsearchArgs{
-			Enable:     enabled,
-			Format:     env.Get(formatEnv, kv.Get(target.ElasticFormat)),
-			URL:        *url,
-			Index:      env.Get(indexEnv, kv.Get(target.ElasticIndex)),
-			QueueDir:   env.Get(queueDirEnv, kv.Get(target.ElasticQueueDir)),
-			QueueLimit: uint64(queueLimit),
-			Transport:  transport,
-			Username:   env.Get(usernameEnv, kv.Get(target.ElasticUsername)),
-			Password:   env.Get(passwordEnv, kv.Get(target.ElasticPassword)),
+			Enable:          enabled,
+			Format:          env.Get(formatEnv, kv.Get(target.ElasticFormat)),
+			URL:             *url,
+			Index:           env.Get(indexEnv, kv.Get(target.ElasticIndex)),
+			QueueDir:        env.Get(queueDirEnv, kv.Get(target.ElasticQueueDir)),
+			QueueLimit:      uint64(queueLimit),
+			OrderedDelivery: orderedDelivery,
+			QueueMaxAge:     queueMaxAge,
+			QueueOverflow:   queueOverflow,
+			SchemaVersion:   schemaVersion,
+			Transport:       transport,
+			Username:        env.Get(usernameEnv, kv.Get(target.ElasticUsername)),
+			Password:        env.Get(passwordEnv, kv.Get(target.ElasticPassword)),
 		}
 		if err = esArgs.Validate(); err != nil {
 			return nil, err
@@ -1694,6 +2971,26 @@ var (
 			Key:   target.AmqpQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.AmqpOrderedDelivery,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.AmqpQueueMaxAge,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.AmqpQueueOverflow,
+			Value: target.QueueOverflowReject,
+		},
+		config.KV{
+			Key:   target.AmqpSchemaVersion,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.AmqpEventFormat,
+			Value: event.S3Format,
+		},
 		config.KV{
 			Key:   target.AmqpQueueDir,
 			Value: "",
@@ -1784,6 +3081,41 @@ func GetNotifyAMQP(amqpKVS map[string]config.KVS) (map[string]target.AMQPArgs, e
 		if err != nil {
 			return nil, err
 		}
+
+		orderedDeliveryEnv := target.EnvAMQPOrderedDelivery
+		if k != config.Default {
+			orderedDeliveryEnv = orderedDeliveryEnv + config.Default + k
+		}
+		orderedDelivery, err := config.ParseBool(env.Get(orderedDeliveryEnv, kv.Get(target.AmqpOrderedDelivery)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueMaxAgeEnv := target.EnvAMQPQueueMaxAge
+		if k != config.Default {
+			queueMaxAgeEnv = queueMaxAgeEnv + config.Default + k
+		}
+		queueMaxAge, err := parseQueueMaxAge(env.Get(queueMaxAgeEnv, kv.Get(target.AmqpQueueMaxAge)))
+		if err != nil {
+			return nil, err
+		}
+
+		queueOverflowEnv := target.EnvAMQPQueueOverflow
+		if k != config.Default {
+			queueOverflowEnv = queueOverflowEnv + config.Default + k
+		}
+		queueOverflow := env.Get(queueOverflowEnv, kv.Get(target.AmqpQueueOverflow))
+
+		schemaVersionEnv := target.EnvAMQPSchemaVersion
+		if k != config.Default {
+			schemaVersionEnv = schemaVersionEnv + config.Default + k
+		}
+		schemaVersion := env.Get(schemaVersionEnv, kv.Get(target.AmqpSchemaVersion))
+		eventFormatEnv := target.EnvAMQPEventFormat
+		if k != config.Default {
+			eventFormatEnv = eventFormatEnv + config.Default + k
+		}
+
 		amqpArgs := target.AMQPArgs{
 			Enable:            enabled,
 			URL:               *url,
@@ -1800,6 +3132,11 @@ func GetNotifyAMQP(amqpKVS map[string]config.KVS) (map[string]target.AMQPArgs, e
 			PublisherConfirms: env.Get(publisherConfirmsEnv, kv.Get(target.AmqpPublisherConfirms)) == config.EnableOn,
 			QueueDir:          env.Get(queueDirEnv, kv.Get(target.AmqpQueueDir)),
 			QueueLimit:        queueLimit,
+			OrderedDelivery:   orderedDelivery,
+			QueueMaxAge:       queueMaxAge,
+			QueueOverflow:     queueOverflow,
+			SchemaVersion:     schemaVersion,
+			EventFormat:       env.Get(eventFormatEnv, kv.Get(target.AmqpEventFormat)),
 		}
 		if err = amqpArgs.Validate(); err != nil {
 			return nil, err
@@ -1808,3 +3145,33 @@ func GetNotifyAMQP(amqpKVS map[string]config.KVS) (map[string]target.AMQPArgs, e
 	}
 	return amqpTargets, nil
 }
+
+// parseQueueMaxAge - parses the maximum age of queued events, an
+// empty value disables the limit.
+func parseQueueMaxAge(maxAge string) (time.Duration, error) {
+	if maxAge == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(maxAge)
+}
+
+// parseBatchArgs - parses the batch_max_* settings of a target, empty
+// values are treated as their defaults.
+func parseBatchArgs(maxCount, maxBytes, maxLatency string) (args target.BatchArgs, err error) {
+	if maxCount != "" {
+		if args.MaxCount, err = strconv.Atoi(maxCount); err != nil {
+			return args, err
+		}
+	}
+	if maxBytes != "" {
+		if args.MaxBytes, err = strconv.Atoi(maxBytes); err != nil {
+			return args, err
+		}
+	}
+	if maxLatency != "" {
+		if args.MaxLatency, err = time.ParseDuration(maxLatency); err != nil {
+			return args, err
+		}
+	}
+	return args, nil
+}
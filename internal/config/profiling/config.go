@@ -0,0 +1,249 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package profiling
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Continuous profiling environment variables
+const (
+	Bucket             = "bucket"
+	Interval           = "interval"
+	Types              = "types"
+	CPUDuration        = "cpu_duration"
+	Retention          = "retention"
+	CPUThreshold       = "cpu_threshold"
+	HeapThreshold      = "heap_threshold"
+	GoroutineThreshold = "goroutine_threshold"
+
+	EnvBucket             = "MINIO_PROFILING_BUCKET"
+	EnvInterval           = "MINIO_PROFILING_INTERVAL"
+	EnvTypes              = "MINIO_PROFILING_TYPES"
+	EnvCPUDuration        = "MINIO_PROFILING_CPU_DURATION"
+	EnvRetention          = "MINIO_PROFILING_RETENTION"
+	EnvCPUThreshold       = "MINIO_PROFILING_CPU_THRESHOLD"
+	EnvHeapThreshold      = "MINIO_PROFILING_HEAP_THRESHOLD"
+	EnvGoroutineThreshold = "MINIO_PROFILING_GOROUTINE_THRESHOLD"
+)
+
+// Supported profile types.
+const (
+	TypeCPU        = "cpu"
+	TypeHeap       = "heap"
+	TypeGoroutines = "goroutines"
+)
+
+// Config represents the profiles captured continuously by every
+// node and stored in a bucket.
+type Config struct {
+	// Bucket storing the profiles, profiling is disabled if unset.
+	Bucket string `json:"bucket"`
+	// Interval between scheduled captures.
+	Interval time.Duration `json:"interval"`
+	Types    []string      `json:"types"`
+	// CPUDuration is the time the CPU is profiled for every capture.
+	CPUDuration time.Duration `json:"cpuDuration"`
+	// Retention is the time profiles are kept in the bucket.
+	Retention time.Duration `json:"retention"`
+
+	// Profiles are captured in addition to the schedule when one of the
+	// thresholds, if set, is exceeded. CPUThreshold is the percentage
+	// of all CPUs used by the server.
+	CPUThreshold       float64 `json:"cpuThreshold"`
+	HeapThreshold      uint64  `json:"heapThreshold"`
+	GoroutineThreshold int     `json:"goroutineThreshold"`
+}
+
+// Enabled returns true if profiles are captured.
+func (c Config) Enabled() bool {
+	return c.Bucket != ""
+}
+
+var (
+	// DefaultKVS - default KV config for continuous profiling
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   Bucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   Interval,
+			Value: "15m",
+		},
+		config.KV{
+			Key:   Types,
+			Value: "cpu,heap,goroutines",
+		},
+		config.KV{
+			Key:   CPUDuration,
+			Value: "10s",
+		},
+		config.KV{
+			Key:   Retention,
+			Value: "168h",
+		},
+		config.KV{
+			Key:   CPUThreshold,
+			Value: "0",
+		},
+		config.KV{
+			Key:   HeapThreshold,
+			Value: "",
+		},
+		config.KV{
+			Key:   GoroutineThreshold,
+			Value: "0",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Bucket,
+			Description: `bucket storing the profiles of all nodes e.g. "profiles"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Interval,
+			Description: `time between scheduled captures e.g. "15m"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         Types,
+			Description: `comma separated list of profiles to capture, any of "cpu,heap,goroutines"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         CPUDuration,
+			Description: `time the CPU is profiled for each capture e.g. "10s"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         Retention,
+			Description: `time profiles are kept in the bucket e.g. "168h"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         CPUThreshold,
+			Description: `capture profiles when the server uses more than this percentage of all CPUs, "0" disables the threshold`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         HeapThreshold,
+			Description: `capture profiles when the heap grows beyond this size e.g. "8GiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         GoroutineThreshold,
+			Description: `capture profiles when the number of goroutines exceeds this count, "0" disables the threshold`,
+			Optional:    true,
+			Type:        "number",
+		},
+	}
+)
+
+func lookupDuration(kvs config.KVS, key, envKey, def string) (time.Duration, error) {
+	v := env.Get(envKey, kvs.Get(key))
+	if v == "" {
+		v = def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("'%s:%s' value invalid: %s", config.ProfilingSubSys, key, v)
+	}
+	return d, nil
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.ProfilingSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Bucket = env.Get(EnvBucket, kvs.Get(Bucket))
+	if cfg.Bucket == "" {
+		return cfg, nil
+	}
+	if err = s3utils.CheckValidBucketNameStrict(cfg.Bucket); err != nil {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.ProfilingSubSys, Bucket, err)
+	}
+
+	if cfg.Interval, err = lookupDuration(kvs, Interval, EnvInterval, "15m"); err != nil {
+		return cfg, err
+	}
+	if cfg.CPUDuration, err = lookupDuration(kvs, CPUDuration, EnvCPUDuration, "10s"); err != nil {
+		return cfg, err
+	}
+	if cfg.CPUDuration >= cfg.Interval {
+		return cfg, fmt.Errorf("'%s:%s' must be shorter than '%s'", config.ProfilingSubSys, CPUDuration, Interval)
+	}
+	if cfg.Retention, err = lookupDuration(kvs, Retention, EnvRetention, "168h"); err != nil {
+		return cfg, err
+	}
+
+	types := env.Get(EnvTypes, kvs.Get(Types))
+	if types == "" {
+		types = "cpu,heap,goroutines"
+	}
+	for _, t := range strings.Split(types, config.ValueSeparator) {
+		switch t = strings.TrimSpace(t); t {
+		case "":
+		case TypeCPU, TypeHeap, TypeGoroutines:
+			cfg.Types = append(cfg.Types, t)
+		default:
+			return cfg, fmt.Errorf("'%s:%s' value invalid: unknown profile type %s", config.ProfilingSubSys, Types, t)
+		}
+	}
+	if len(cfg.Types) == 0 {
+		return cfg, fmt.Errorf("'%s:%s' must list at least one profile type", config.ProfilingSubSys, Types)
+	}
+
+	if v := env.Get(EnvCPUThreshold, kvs.Get(CPUThreshold)); v != "" {
+		cfg.CPUThreshold, err = strconv.ParseFloat(v, 64)
+		if err != nil || cfg.CPUThreshold < 0 || cfg.CPUThreshold > 100 {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.ProfilingSubSys, CPUThreshold, v)
+		}
+	}
+	if v := env.Get(EnvHeapThreshold, kvs.Get(HeapThreshold)); v != "" {
+		if cfg.HeapThreshold, err = humanize.ParseBytes(v); err != nil {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.ProfilingSubSys, HeapThreshold, v)
+		}
+	}
+	if v := env.Get(EnvGoroutineThreshold, kvs.Get(GoroutineThreshold)); v != "" {
+		cfg.GoroutineThreshold, err = strconv.Atoi(v)
+		if err != nil || cfg.GoroutineThreshold < 0 {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.ProfilingSubSys, GoroutineThreshold, v)
+		}
+	}
+	return cfg, nil
+}
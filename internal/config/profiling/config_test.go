@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package profiling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		bucket, interval, types, cpuDuration, cpuThreshold, heapThreshold string
+		success, enabled                                                  bool
+	}{
+		{"", "", "", "", "", "", true, false},
+		{"profiles", "", "", "", "", "", true, true},
+		{"profiles", "5m", "cpu, heap", "30s", "80", "8GiB", true, true},
+		{"Profiles_", "", "", "", "", "", false, false},
+		{"profiles", "-1m", "", "", "", "", false, false},
+		{"profiles", "", "threads", "", "", "", false, false},
+		{"profiles", "", " , ", "", "", "", false, false},
+		{"profiles", "10s", "", "10s", "", "", false, false},
+		{"profiles", "", "", "", "120", "", false, false},
+		{"profiles", "", "", "", "", "lots", false, false},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: Bucket, Value: testCase.bucket},
+			config.KV{Key: Interval, Value: testCase.interval},
+			config.KV{Key: Types, Value: testCase.types},
+			config.KV{Key: CPUDuration, Value: testCase.cpuDuration},
+			config.KV{Key: CPUThreshold, Value: testCase.cpuThreshold},
+			config.KV{Key: HeapThreshold, Value: testCase.heapThreshold},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && cfg.Enabled() != testCase.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, testCase.enabled, cfg.Enabled())
+		}
+	}
+}
+
+func TestLookupConfigDefaults(t *testing.T) {
+	cfg, err := LookupConfig(config.KVS{config.KV{Key: Bucket, Value: "profiles"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Interval != 15*time.Minute || cfg.CPUDuration != 10*time.Second || cfg.Retention != 7*24*time.Hour {
+		t.Fatalf("unexpected default durations: %+v", cfg)
+	}
+	if len(cfg.Types) != 3 {
+		t.Fatalf("expected all profile types by default, got %v", cfg.Types)
+	}
+	if cfg.CPUThreshold != 0 || cfg.HeapThreshold != 0 || cfg.GoroutineThreshold != 0 {
+		t.Fatalf("expected thresholds to be disabled by default: %+v", cfg)
+	}
+}
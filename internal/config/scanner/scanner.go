@@ -18,7 +18,9 @@
 package scanner
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio/internal/config"
@@ -31,11 +33,16 @@ const (
 	MaxWait = "max_wait"
 	Cycle   = "cycle"
 
-	EnvDelay         = "MINIO_SCANNER_DELAY"
-	EnvCycle         = "MINIO_SCANNER_CYCLE"
-	EnvDelayLegacy   = "MINIO_CRAWLER_DELAY"
-	EnvMaxWait       = "MINIO_SCANNER_MAX_WAIT"
-	EnvMaxWaitLegacy = "MINIO_CRAWLER_MAX_WAIT"
+	ILMPriority      = "ilm_priority"
+	ILMPriorityCycle = "ilm_priority_cycle"
+
+	EnvDelay            = "MINIO_SCANNER_DELAY"
+	EnvCycle            = "MINIO_SCANNER_CYCLE"
+	EnvILMPriority      = "MINIO_SCANNER_ILM_PRIORITY"
+	EnvILMPriorityCycle = "MINIO_SCANNER_ILM_PRIORITY_CYCLE"
+	EnvDelayLegacy      = "MINIO_CRAWLER_DELAY"
+	EnvMaxWait          = "MINIO_SCANNER_MAX_WAIT"
+	EnvMaxWaitLegacy    = "MINIO_CRAWLER_MAX_WAIT"
 )
 
 // Config represents the heal settings.
@@ -46,6 +53,10 @@ type Config struct {
 	MaxWait time.Duration
 	// Cycle is the time.Duration between each scanner cycles
 	Cycle time.Duration
+	// ILMPriority are the buckets and prefixes, as `bucket/prefix`,
+	// whose lifecycle rules are applied every ILMPriorityCycle.
+	ILMPriority      []string
+	ILMPriorityCycle time.Duration
 }
 
 var (
@@ -63,6 +74,14 @@ var (
 			Key:   Cycle,
 			Value: "1m",
 		},
+		config.KV{
+			Key:   ILMPriority,
+			Value: "",
+		},
+		config.KV{
+			Key:   ILMPriorityCycle,
+			Value: "1h",
+		},
 	}
 
 	// Help provides help for config values
@@ -85,6 +104,18 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         ILMPriority,
+			Description: `comma separated list of buckets or bucket prefixes e.g. "bucket1,bucket2/logs/" to apply lifecycle rules to every 'ilm_priority_cycle'`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         ILMPriorityCycle,
+			Description: `time duration between lifecycle runs on 'ilm_priority' prefixes, defaults to '1h'`,
+			Optional:    true,
+			Type:        "duration",
+		},
 	}
 )
 
@@ -114,5 +145,20 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, err
 	}
+
+	for _, p := range strings.Split(env.Get(EnvILMPriority, kvs.GetWithDefault(ILMPriority, DefaultKVS)), config.ValueSeparator) {
+		p = strings.TrimPrefix(strings.TrimSpace(p), "/")
+		if p == "" {
+			continue
+		}
+		cfg.ILMPriority = append(cfg.ILMPriority, p)
+	}
+	cfg.ILMPriorityCycle, err = time.ParseDuration(env.Get(EnvILMPriorityCycle, kvs.GetWithDefault(ILMPriorityCycle, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.ILMPriorityCycle < time.Minute {
+		return cfg, fmt.Errorf("%s must be at least 1m, got %s", ILMPriorityCycle, cfg.ILMPriorityCycle)
+	}
 	return cfg, nil
 }
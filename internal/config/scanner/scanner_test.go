@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scanner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfigILMPriority(t *testing.T) {
+	testCases := []struct {
+		prefixes         string
+		cycle            string
+		expectedPrefixes []string
+		expectedCycle    time.Duration
+		success          bool
+	}{
+		{"", "", nil, time.Hour, true},
+		{"bucket1, /bucket2/logs/,", "30m", []string{"bucket1", "bucket2/logs/"}, 30 * time.Minute, true},
+		{"bucket1", "10s", nil, 0, false},
+		{"bucket1", "hourly", nil, 0, false},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{config.KV{Key: ILMPriority, Value: testCase.prefixes}}
+		if testCase.cycle != "" {
+			kvs = append(kvs, config.KV{Key: ILMPriorityCycle, Value: testCase.cycle})
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(cfg.ILMPriority, testCase.expectedPrefixes) {
+			t.Fatalf("Test %d: expected prefixes %v, got %v", i+1, testCase.expectedPrefixes, cfg.ILMPriority)
+		}
+		if cfg.ILMPriorityCycle != testCase.expectedCycle {
+			t.Fatalf("Test %d: expected cycle %s, got %s", i+1, testCase.expectedCycle, cfg.ILMPriorityCycle)
+		}
+	}
+}
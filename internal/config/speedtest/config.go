@@ -0,0 +1,325 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package speedtest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Scheduled speedtest environment variables
+const (
+	Window      = "window"
+	Days        = "days"
+	Tests       = "tests"
+	ObjectSize  = "object_size"
+	Duration    = "duration"
+	Concurrency = "concurrency"
+	History     = "history"
+
+	EnvWindow      = "MINIO_SPEEDTEST_WINDOW"
+	EnvDays        = "MINIO_SPEEDTEST_DAYS"
+	EnvTests       = "MINIO_SPEEDTEST_TESTS"
+	EnvObjectSize  = "MINIO_SPEEDTEST_OBJECT_SIZE"
+	EnvDuration    = "MINIO_SPEEDTEST_DURATION"
+	EnvConcurrency = "MINIO_SPEEDTEST_CONCURRENCY"
+	EnvHistory     = "MINIO_SPEEDTEST_HISTORY"
+)
+
+// Tests which can be scheduled.
+const (
+	TestDrive  = "drive"
+	TestNet    = "net"
+	TestObject = "object"
+)
+
+// AllTests - all tests which can be scheduled.
+var AllTests = []string{TestDrive, TestNet, TestObject}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Config represents the speedtests run automatically once per
+// maintenance window.
+type Config struct {
+	// Start and End of the maintenance window as the time of the day
+	// in UTC, the window ends on the next day if End is before Start.
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	// Days are the days the window starts on, every day if empty.
+	Days []time.Weekday `json:"days,omitempty"`
+	// Tests to run, a subset of AllTests.
+	Tests []string `json:"tests"`
+	// ObjectSize, Duration and Concurrency configure the object test.
+	ObjectSize  int           `json:"objectSize"`
+	Duration    time.Duration `json:"duration"`
+	Concurrency int           `json:"concurrency"`
+	// History is the number of results kept.
+	History int `json:"history"`
+}
+
+// Enabled returns true if a maintenance window is configured.
+func (c Config) Enabled() bool {
+	return c.Start != c.End
+}
+
+// Runs returns true if the test is scheduled.
+func (c Config) Runs(test string) bool {
+	for _, t := range c.Tests {
+		if t == test {
+			return true
+		}
+	}
+	return false
+}
+
+// WindowStart returns the start of the maintenance window now is in,
+// false if now is outside of a window.
+func (c Config) WindowStart(now time.Time) (time.Time, bool) {
+	if !c.Enabled() {
+		return time.Time{}, false
+	}
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	// The window may have started today or, if it ends on the next
+	// day, yesterday.
+	for _, start := range []time.Time{day.Add(c.Start), day.AddDate(0, 0, -1).Add(c.Start)} {
+		length := c.End - c.Start
+		if length < 0 {
+			length += 24 * time.Hour
+		}
+		if now.Before(start) || !now.Before(start.Add(length)) {
+			continue
+		}
+		if c.onDay(start.Weekday()) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (c Config) onDay(day time.Weekday) bool {
+	if len(c.Days) == 0 {
+		return true
+	}
+	for _, d := range c.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultKVS - default KV config for scheduled speedtests
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   Window,
+			Value: "",
+		},
+		config.KV{
+			Key:   Days,
+			Value: "",
+		},
+		config.KV{
+			Key:   Tests,
+			Value: strings.Join(AllTests, config.ValueSeparator),
+		},
+		config.KV{
+			Key:   ObjectSize,
+			Value: "64MiB",
+		},
+		config.KV{
+			Key:   Duration,
+			Value: "10s",
+		},
+		config.KV{
+			Key:   Concurrency,
+			Value: "32",
+		},
+		config.KV{
+			Key:   History,
+			Value: "30",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Window,
+			Description: `maintenance window in UTC to run the speedtests in e.g. "02:00-04:00", disabled if empty`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Days,
+			Description: `comma separated days the window starts on e.g. "sat,sun", every day if empty`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Tests,
+			Description: `comma separated tests to run, any of ` + strings.Join(AllTests, ", "),
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         ObjectSize,
+			Description: `size of the objects of the object speedtest e.g. "64MiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         Duration,
+			Description: `duration of the object speedtest e.g. "10s"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         Concurrency,
+			Description: `concurrent requests per node of the object speedtest e.g. "32"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         History,
+			Description: `number of speedtest results kept e.g. "30"`,
+			Optional:    true,
+			Type:        "number",
+		},
+	}
+)
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseWindow(s string) (start, end time.Duration, err error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("'%s' must be of the form HH:MM-HH:MM", s)
+	}
+	if start, err = parseTimeOfDay(parts[0]); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseTimeOfDay(parts[1]); err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("'%s' must not be empty", s)
+	}
+	return start, end, nil
+}
+
+func lookupList(kvs config.KVS, key, envKey string) []string {
+	var list []string
+	for _, v := range strings.Split(env.Get(envKey, kvs.Get(key)), config.ValueSeparator) {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func lookupPositive(kvs config.KVS, key, envKey, def string) (int, error) {
+	v := env.Get(envKey, kvs.Get(key))
+	if v == "" {
+		v = def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("'%s:%s' value invalid: %s", config.SpeedtestSubSys, key, v)
+	}
+	return n, nil
+}
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.SpeedtestSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	if v := env.Get(EnvWindow, kvs.Get(Window)); v != "" {
+		if cfg.Start, cfg.End, err = parseWindow(v); err != nil {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.SpeedtestSubSys, Window, err)
+		}
+	}
+	for _, day := range lookupList(kvs, Days, EnvDays) {
+		d, ok := weekdays[day]
+		if !ok {
+			return cfg, fmt.Errorf("'%s:%s' unknown day '%s'", config.SpeedtestSubSys, Days, day)
+		}
+		cfg.Days = append(cfg.Days, d)
+	}
+
+	cfg.Tests = lookupList(kvs, Tests, EnvTests)
+	if len(cfg.Tests) == 0 && env.Get(EnvTests, kvs.Get(Tests)) == "" {
+		cfg.Tests = AllTests
+	}
+	for _, test := range cfg.Tests {
+		valid := false
+		for _, t := range AllTests {
+			valid = valid || t == test
+		}
+		if !valid {
+			return cfg, fmt.Errorf("'%s:%s' unknown test '%s'", config.SpeedtestSubSys, Tests, test)
+		}
+	}
+
+	size := env.Get(EnvObjectSize, kvs.Get(ObjectSize))
+	if size == "" {
+		size = "64MiB"
+	}
+	objectSize, err := humanize.ParseBytes(size)
+	if err != nil || objectSize == 0 || objectSize > 1<<30 {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.SpeedtestSubSys, ObjectSize, size)
+	}
+	cfg.ObjectSize = int(objectSize)
+
+	duration := env.Get(EnvDuration, kvs.Get(Duration))
+	if duration == "" {
+		duration = "10s"
+	}
+	if cfg.Duration, err = time.ParseDuration(duration); err != nil || cfg.Duration < time.Second {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.SpeedtestSubSys, Duration, duration)
+	}
+
+	if cfg.Concurrency, err = lookupPositive(kvs, Concurrency, EnvConcurrency, "32"); err != nil {
+		return cfg, err
+	}
+	if cfg.History, err = lookupPositive(kvs, History, EnvHistory, "30"); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
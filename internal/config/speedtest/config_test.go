@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package speedtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		kvs     config.KVS
+		success bool
+	}{
+		{config.KVS{}, true},
+		{config.KVS{config.KV{Key: Window, Value: "02:00-04:00"}, config.KV{Key: Days, Value: "sat,sun"}}, true},
+		{config.KVS{config.KV{Key: Window, Value: "22:30-01:00"}, config.KV{Key: Tests, Value: "drive,net"}}, true},
+		{config.KVS{config.KV{Key: Window, Value: "02:00"}}, false},
+		{config.KVS{config.KV{Key: Window, Value: "02:00-02:00"}}, false},
+		{config.KVS{config.KV{Key: Window, Value: "25:00-02:00"}}, false},
+		{config.KVS{config.KV{Key: Days, Value: "someday"}}, false},
+		{config.KVS{config.KV{Key: Tests, Value: "cpu"}}, false},
+		{config.KVS{config.KV{Key: ObjectSize, Value: "0"}}, false},
+		{config.KVS{config.KV{Key: Duration, Value: "10ms"}}, false},
+		{config.KVS{config.KV{Key: History, Value: "0"}}, false},
+	}
+	for i, testCase := range testCases {
+		_, err := LookupConfig(testCase.kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+
+	cfg, err := LookupConfig(config.KVS{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Enabled() {
+		t.Fatal("expected scheduled speedtests to be disabled by default")
+	}
+	for _, test := range AllTests {
+		if !cfg.Runs(test) {
+			t.Fatalf("expected the %s test to run by default", test)
+		}
+	}
+	if cfg.ObjectSize != 64<<20 || cfg.Duration != 10*time.Second || cfg.Concurrency != 32 || cfg.History != 30 {
+		t.Fatalf("unexpected defaults %+v", cfg)
+	}
+}
+
+func TestWindowStart(t *testing.T) {
+	// 2022-01-01 is a saturday.
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		window string
+		days   string
+		now    time.Time
+		start  time.Time
+		ok     bool
+	}{
+		{"02:00-04:00", "", day.Add(3 * time.Hour), day.Add(2 * time.Hour), true},
+		{"02:00-04:00", "", day.Add(4 * time.Hour), time.Time{}, false},
+		{"02:00-04:00", "", day.Add(time.Hour), time.Time{}, false},
+		{"02:00-04:00", "sat", day.Add(3 * time.Hour), day.Add(2 * time.Hour), true},
+		{"02:00-04:00", "sun", day.Add(3 * time.Hour), time.Time{}, false},
+		{"22:00-02:00", "", day.Add(23 * time.Hour), day.Add(22 * time.Hour), true},
+		{"22:00-02:00", "", day.Add(time.Hour), day.Add(-2 * time.Hour), true},
+		{"22:00-02:00", "fri", day.Add(time.Hour), day.Add(-2 * time.Hour), true},
+		{"22:00-02:00", "sat", day.Add(time.Hour), time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		cfg, err := LookupConfig(config.KVS{
+			config.KV{Key: Window, Value: testCase.window},
+			config.KV{Key: Days, Value: testCase.days},
+		})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		start, ok := cfg.WindowStart(testCase.now)
+		if ok != testCase.ok || !start.Equal(testCase.start) {
+			t.Errorf("Test %d: expected %v %v, got %v %v", i+1, testCase.start, testCase.ok, start, ok)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tiercache
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Tier read cache environment variables
+const (
+	Drives = "drives"
+	Quota  = "quota"
+
+	EnvDrives = "MINIO_TIER_CACHE_DRIVES"
+	EnvQuota  = "MINIO_TIER_CACHE_QUOTA"
+)
+
+// Config represents the local cache of object content read back from
+// remote tiers.
+type Config struct {
+	Drives []string `json:"drives"`
+	// Maximum number of bytes cached across all drives.
+	Quota uint64 `json:"quota"`
+}
+
+// Enabled returns true if the tier read cache is configured.
+func (c Config) Enabled() bool {
+	return len(c.Drives) > 0
+}
+
+var (
+	// DefaultKVS - default KV config for the tier read cache
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   Drives,
+			Value: "",
+		},
+		config.KV{
+			Key:   Quota,
+			Value: "",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Drives,
+			Description: `comma separated list of absolute paths caching data read from remote tiers e.g. "/mnt/cache1,/mnt/cache2"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         Quota,
+			Description: `maximum size of the cache across all drives e.g. "100GiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.TierCacheSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	for _, s := range strings.Split(env.Get(EnvDrives, kvs.Get(Drives)), config.ValueSeparator) {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		if !filepath.IsAbs(s) {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: %s is not an absolute path", config.TierCacheSubSys, Drives, s)
+		}
+		cfg.Drives = append(cfg.Drives, s)
+	}
+	if !cfg.Enabled() {
+		return cfg, nil
+	}
+	quota := env.Get(EnvQuota, kvs.Get(Quota))
+	if quota == "" {
+		return cfg, fmt.Errorf("'%s:%s' must be set when drives are configured", config.TierCacheSubSys, Quota)
+	}
+	if cfg.Quota, err = humanize.ParseBytes(quota); err != nil || cfg.Quota == 0 {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.TierCacheSubSys, Quota, quota)
+	}
+	return cfg, nil
+}
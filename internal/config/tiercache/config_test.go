@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tiercache
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		drives  string
+		quota   string
+		enabled bool
+		success bool
+	}{
+		{"", "", false, true},
+		{"/mnt/cache1, /mnt/cache2", "10GiB", true, true},
+		{"/mnt/cache1", "", true, false},
+		{"/mnt/cache1", "0", true, false},
+		{"/mnt/cache1", "lots", true, false},
+		{"cache1", "10GiB", false, false},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: Drives, Value: testCase.drives},
+			config.KV{Key: Quota, Value: testCase.quota},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && cfg.Enabled() != testCase.enabled {
+			t.Fatalf("Test %d: expected enabled %v", i+1, testCase.enabled)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tierverify
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Tiered data verification environment variables
+const (
+	SampleRate = "sample_rate"
+
+	EnvSampleRate = "MINIO_TIER_VERIFY_SAMPLE_RATE"
+)
+
+// Config represents the background verification of transitioned
+// objects against the checksum and size recorded at transition.
+type Config struct {
+	// One in SampleRate transitioned objects is verified when it is
+	// scanned, 1 verifies every object and 0 disables verification.
+	SampleRate int `json:"sampleRate"`
+}
+
+// Enabled returns true if tiered data is verified.
+func (c Config) Enabled() bool {
+	return c.SampleRate > 0
+}
+
+var (
+	// DefaultKVS - default KV config for tiered data verification
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   SampleRate,
+			Value: "0",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         SampleRate,
+			Description: `verify one in n transitioned objects per scanner cycle, "1" verifies all objects and "0" disables verification`,
+			Optional:    true,
+			Type:        "number",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.TierVerifySubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	rate := env.Get(EnvSampleRate, kvs.Get(SampleRate))
+	if rate == "" {
+		return cfg, nil
+	}
+	cfg.SampleRate, err = strconv.Atoi(rate)
+	if err != nil || cfg.SampleRate < 0 {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.TierVerifySubSys, SampleRate, rate)
+	}
+	return cfg, nil
+}
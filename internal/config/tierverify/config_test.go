@@ -0,0 +1,48 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tierverify
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		sampleRate string
+		expected   int
+		success    bool
+	}{
+		{"", 0, true},
+		{"0", 0, true},
+		{"1", 1, true},
+		{"100", 100, true},
+		{"-1", 0, false},
+		{"all", 0, false},
+	}
+	for i, testCase := range testCases {
+		cfg, err := LookupConfig(config.KVS{config.KV{Key: SampleRate, Value: testCase.sampleRate}})
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && cfg.SampleRate != testCase.expected {
+			t.Fatalf("Test %d: expected sample rate %d, got %d", i+1, testCase.expected, cfg.SampleRate)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Distributed tracing environment variables
+const (
+	Endpoint    = "endpoint"
+	SampleRatio = "sample_ratio"
+	Headers     = "headers"
+
+	EnvEndpoint    = "MINIO_TRACING_ENDPOINT"
+	EnvSampleRatio = "MINIO_TRACING_SAMPLE_RATIO"
+	EnvHeaders     = "MINIO_TRACING_HEADERS"
+)
+
+// Config - distributed tracing configuration.
+type Config struct {
+	// Endpoint is the OTLP/HTTP endpoint receiving the spans,
+	// tracing is disabled if unset.
+	Endpoint *xnet.URL `json:"endpoint"`
+	// SampleRatio is the fraction of the requests without a sampled
+	// parent span which are traced.
+	SampleRatio float64 `json:"sampleRatio"`
+	// Headers are sent with every export request, e.g. to authenticate.
+	Headers map[string]string `json:"headers"`
+}
+
+// Enabled returns true if spans are exported.
+func (c Config) Enabled() bool {
+	return c.Endpoint != nil
+}
+
+var (
+	// DefaultKVS - default KV config for distributed tracing
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   SampleRatio,
+			Value: "1",
+		},
+		config.KV{
+			Key:   Headers,
+			Value: "",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `OTLP/HTTP endpoint receiving the spans e.g. "http://localhost:4318"`,
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         SampleRatio,
+			Description: `fraction of requests traced unless the caller decided, between 0 and 1 e.g. "0.1"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         Headers,
+			Description: `comma separated list of headers sent to the endpoint e.g. "authorization=Bearer token"`,
+			Optional:    true,
+			Type:        "csv",
+			Sensitive:   true,
+		},
+	}
+)
+
+// LookupConfig - lookup distributed tracing config.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.TracingSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	u := env.Get(EnvEndpoint, kvs.Get(Endpoint))
+	if u == "" {
+		return cfg, nil
+	}
+	if cfg.Endpoint, err = xnet.ParseHTTPURL(u); err != nil {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %w", config.TracingSubSys, Endpoint, err)
+	}
+
+	ratio := env.Get(EnvSampleRatio, kvs.Get(SampleRatio))
+	if ratio == "" {
+		ratio = "1"
+	}
+	cfg.SampleRatio, err = strconv.ParseFloat(ratio, 64)
+	if err != nil || cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return cfg, fmt.Errorf("'%s:%s' value invalid: %s", config.TracingSubSys, SampleRatio, ratio)
+	}
+
+	for _, h := range strings.Split(env.Get(EnvHeaders, kvs.Get(Headers)), config.ValueSeparator) {
+		if h = strings.TrimSpace(h); h == "" {
+			continue
+		}
+		kv := strings.SplitN(h, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return cfg, fmt.Errorf("'%s:%s' value invalid: expected key=value, got '%s'", config.TracingSubSys, Headers, h)
+		}
+		if cfg.Headers == nil {
+			cfg.Headers = make(map[string]string)
+		}
+		cfg.Headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return cfg, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/minio/minio/internal/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		endpoint, ratio, headers string
+		success, enabled         bool
+		expectedRatio            float64
+	}{
+		{"", "", "", true, false, 0},
+		{"http://localhost:4318", "", "", true, true, 1},
+		{"https://otel.example.com:4318", "0.25", "authorization=Bearer token, x-team=storage", true, true, 0.25},
+		{"localhost:4318", "", "", false, false, 0},
+		{"http://localhost:4318", "1.5", "", false, false, 0},
+		{"http://localhost:4318", "-0.1", "", false, false, 0},
+		{"http://localhost:4318", "all", "", false, false, 0},
+		{"http://localhost:4318", "", "authorization", false, false, 0},
+	}
+	for i, testCase := range testCases {
+		kvs := config.KVS{
+			config.KV{Key: Endpoint, Value: testCase.endpoint},
+			config.KV{Key: SampleRatio, Value: testCase.ratio},
+			config.KV{Key: Headers, Value: testCase.headers},
+		}
+		cfg, err := LookupConfig(kvs)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err != nil {
+			continue
+		}
+		if cfg.Enabled() != testCase.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, testCase.enabled, cfg.Enabled())
+		}
+		if cfg.SampleRatio != testCase.expectedRatio {
+			t.Fatalf("Test %d: expected sample ratio %v, got %v", i+1, testCase.expectedRatio, cfg.SampleRatio)
+		}
+	}
+}
+
+func TestLookupConfigHeaders(t *testing.T) {
+	kvs := config.KVS{
+		config.KV{Key: Endpoint, Value: "http://localhost:4318"},
+		config.KV{Key: Headers, Value: "authorization=Bearer a=b, x-team=storage"},
+	}
+	cfg, err := LookupConfig(kvs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Headers) != 2 {
+		t.Fatalf("expected 2 headers, got %v", cfg.Headers)
+	}
+	if v := cfg.Headers["authorization"]; v != "Bearer a=b" {
+		t.Errorf("expected 'Bearer a=b', got '%s'", v)
+	}
+	if v := cfg.Headers["x-team"]; v != "storage" {
+		t.Errorf("expected 'storage', got '%s'", v)
+	}
+}
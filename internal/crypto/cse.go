@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import "strings"
+
+// Object metadata used by the AWS S3 encryption clients and the AWS
+// Encryption SDK to store the envelope of client-side encrypted
+// objects. MinIO treats them as regular user metadata but must never
+// drop them, since the object cannot be decrypted without them.
+const (
+	MetaCSEKeyV1   = "X-Amz-Meta-X-Amz-Key"
+	MetaCSEKeyV2   = "X-Amz-Meta-X-Amz-Key-V2"
+	MetaCSEIV      = "X-Amz-Meta-X-Amz-Iv"
+	MetaCSEMatDesc = "X-Amz-Meta-X-Amz-Matdesc"
+	MetaCSEWrapAlg = "X-Amz-Meta-X-Amz-Wrap-Alg"
+	MetaCSECEKAlg  = "X-Amz-Meta-X-Amz-Cek-Alg"
+	MetaCSETagLen  = "X-Amz-Meta-X-Amz-Tag-Len"
+)
+
+// cseMetadataKeys are all envelope metadata entries of client-side
+// encrypted objects.
+var cseMetadataKeys = []string{
+	MetaCSEKeyV1,
+	MetaCSEKeyV2,
+	MetaCSEIV,
+	MetaCSEMatDesc,
+	MetaCSEWrapAlg,
+	MetaCSECEKAlg,
+	MetaCSETagLen,
+}
+
+// CSEInfo describes the envelope of a client-side encrypted object.
+type CSEInfo struct {
+	// Version is 1 for objects written by the V1 S3 encryption
+	// clients and 2 for objects written by the V2 clients.
+	Version       int    `json:"version"`
+	CEKAlgorithm  string `json:"cekAlgorithm,omitempty"`
+	WrapAlgorithm string `json:"wrapAlgorithm,omitempty"`
+}
+
+// lookupMeta returns the value of the metadata entry key, ignoring
+// the case of the entry names.
+func lookupMeta(metadata map[string]string, key string) (string, bool) {
+	if v, ok := metadata[key]; ok {
+		return v, true
+	}
+	for k, v := range metadata {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// ParseClientSideEncryption returns the envelope information if the
+// object metadata indicates that the object was encrypted by an S3
+// encryption client before uploading it.
+func ParseClientSideEncryption(metadata map[string]string) (CSEInfo, bool) {
+	var info CSEInfo
+	if _, ok := lookupMeta(metadata, MetaCSEKeyV2); ok {
+		info.Version = 2
+	} else if _, ok = lookupMeta(metadata, MetaCSEKeyV1); ok {
+		info.Version = 1
+	} else {
+		return info, false
+	}
+	if _, ok := lookupMeta(metadata, MetaCSEIV); !ok {
+		return CSEInfo{}, false
+	}
+	info.CEKAlgorithm, _ = lookupMeta(metadata, MetaCSECEKAlg)
+	info.WrapAlgorithm, _ = lookupMeta(metadata, MetaCSEWrapAlg)
+	return info, true
+}
+
+// IsClientSideEncrypted returns true if the object metadata indicates
+// that the object was encrypted by an S3 encryption client.
+func IsClientSideEncrypted(metadata map[string]string) bool {
+	_, ok := ParseClientSideEncryption(metadata)
+	return ok
+}
+
+// PreserveClientSideEncryption copies the envelope metadata of a
+// client-side encrypted source object to the target metadata, unless
+// the target metadata contains an envelope on its own. It is used when
+// the metadata of an object gets replaced, e.g. by a CopyObject with
+// the REPLACE metadata directive, which would otherwise render the
+// object undecryptable.
+func PreserveClientSideEncryption(target, source map[string]string) {
+	if !IsClientSideEncrypted(source) || IsClientSideEncrypted(target) {
+		return
+	}
+	for _, key := range cseMetadataKeys {
+		if v, ok := lookupMeta(source, key); ok {
+			target[key] = v
+		}
+	}
+}
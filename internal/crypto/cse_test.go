@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import "testing"
+
+func TestParseClientSideEncryption(t *testing.T) {
+	testCases := []struct {
+		metadata map[string]string
+		version  int
+	}{
+		{map[string]string{MetaCSEKeyV2: "a2V5", MetaCSEIV: "aXY=", MetaCSECEKAlg: "AES/GCM/NoPadding", MetaCSEWrapAlg: "kms+context"}, 2},
+		{map[string]string{MetaCSEKeyV1: "a2V5", MetaCSEIV: "aXY="}, 1},
+		// Metadata keys replicated by other S3 clients may be lower case.
+		{map[string]string{"x-amz-meta-x-amz-key-v2": "a2V5", "x-amz-meta-x-amz-iv": "aXY="}, 2},
+		// Without an IV the envelope is incomplete.
+		{map[string]string{MetaCSEKeyV2: "a2V5"}, 0},
+		{map[string]string{"X-Amz-Meta-Key": "value"}, 0},
+	}
+	for i, testCase := range testCases {
+		info, ok := ParseClientSideEncryption(testCase.metadata)
+		if ok != (testCase.version != 0) || info.Version != testCase.version {
+			t.Errorf("Test %d: got version %d (%v) - want %d", i, info.Version, ok, testCase.version)
+		}
+	}
+}
+
+func TestPreserveClientSideEncryption(t *testing.T) {
+	source := map[string]string{MetaCSEKeyV2: "a2V5", MetaCSEIV: "aXY=", MetaCSEMatDesc: "{}", "X-Amz-Meta-Old": "old"}
+
+	target := map[string]string{"X-Amz-Meta-New": "new"}
+	PreserveClientSideEncryption(target, source)
+	if target[MetaCSEKeyV2] != "a2V5" || target[MetaCSEIV] != "aXY=" || target[MetaCSEMatDesc] != "{}" {
+		t.Fatalf("the envelope has not been preserved: %v", target)
+	}
+	if _, ok := target["X-Amz-Meta-Old"]; ok {
+		t.Fatal("metadata other than the envelope must not be preserved")
+	}
+
+	// An envelope sent with the new metadata replaces the old envelope.
+	target = map[string]string{MetaCSEKeyV1: "bmV3", MetaCSEIV: "bmV3aXY="}
+	PreserveClientSideEncryption(target, source)
+	if _, ok := target[MetaCSEKeyV2]; ok || target[MetaCSEIV] != "bmV3aXY=" {
+		t.Fatalf("the new envelope has been modified: %v", target)
+	}
+}
@@ -48,6 +48,19 @@ const (
 	// the KMS.
 	MetaDataEncryptionKey = "X-Minio-Internal-Server-Side-Encryption-S3-Kms-Sealed-Key"
 
+	// MetaDualLayer indicates that the object is encrypted using
+	// dual-layer SSE-KMS (DSSE-KMS).
+	MetaDualLayer = "X-Minio-Internal-Server-Side-Encryption-Dual-Layer"
+	// MetaDualLayerDataEncryptionKey is the sealed second-layer data
+	// encryption key (DEK) of a DSSE-KMS object received from the KMS.
+	MetaDualLayerDataEncryptionKey = "X-Minio-Internal-Server-Side-Encryption-Dual-Layer-Sealed-Key"
+	// MetaDualLayerIV is the random IV used to seal the second-layer
+	// object key of a DSSE-KMS object.
+	MetaDualLayerIV = "X-Minio-Internal-Server-Side-Encryption-Dual-Layer-Iv"
+	// MetaDualLayerSealedObjectKey is the sealed second-layer object
+	// key of a DSSE-KMS object.
+	MetaDualLayerSealedObjectKey = "X-Minio-Internal-Server-Side-Encryption-Dual-Layer-Object-Key"
+
 	// MetaContext is the KMS context provided by a client when encrypting an
 	// object with SSE-KMS. A client may not send a context in which case the
 	// MetaContext will not be present.
@@ -103,6 +116,10 @@ func RemoveInternalEntries(metadata map[string]string) {
 	delete(metadata, MetaSealedKeyKMS)
 	delete(metadata, MetaKeyID)
 	delete(metadata, MetaDataEncryptionKey)
+	delete(metadata, MetaDualLayer)
+	delete(metadata, MetaDualLayerDataEncryptionKey)
+	delete(metadata, MetaDualLayerIV)
+	delete(metadata, MetaDualLayerSealedObjectKey)
 }
 
 // IsSourceEncrypted returns true if the source is encrypted
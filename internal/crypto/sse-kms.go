@@ -19,8 +19,13 @@ package crypto
 
 import (
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/binary"
 	"errors"
+	"io"
 	"net/http"
 	"path"
 	"strings"
@@ -33,6 +38,10 @@ import (
 
 type ssekms struct{}
 
+// dualLayerDomain is the domain of sealed second-layer object keys
+// of DSSE-KMS objects.
+const dualLayerDomain = "DSSE-KMS"
+
 var (
 	// S3KMS represents AWS SSE-KMS. It provides functionality to
 	// handle SSE-KMS requests.
@@ -64,7 +73,7 @@ func (ssekms) IsRequested(h http.Header) bool {
 // and the KMS context on success.
 func (ssekms) ParseHTTP(h http.Header) (string, kms.Context, error) {
 	algorithm := h.Get(xhttp.AmzServerSideEncryption)
-	if algorithm != xhttp.AmzEncryptionKMS {
+	if algorithm != xhttp.AmzEncryptionKMS && algorithm != xhttp.AmzEncryptionKMSDSSE {
 		return "", nil, ErrInvalidEncryptionMethod
 	}
 
@@ -83,6 +92,139 @@ func (ssekms) ParseHTTP(h http.Header) (string, kms.Context, error) {
 	return h.Get(xhttp.AmzServerSideEncryptionKmsID), ctx, nil
 }
 
+// IsDualLayerRequested returns true if the HTTP headers request
+// dual-layer SSE-KMS (DSSE-KMS).
+func (ssekms) IsDualLayerRequested(h http.Header) bool {
+	return h.Get(xhttp.AmzServerSideEncryption) == xhttp.AmzEncryptionKMSDSSE
+}
+
+// IsDualLayer returns true if the object metadata indicates that
+// the object is encrypted using DSSE-KMS.
+func (ssekms) IsDualLayer(metadata map[string]string) bool {
+	_, ok := metadata[MetaDualLayer]
+	return ok
+}
+
+// Algorithm returns the SSE algorithm of an SSE-KMS object as sent
+// in the X-Amz-Server-Side-Encryption header.
+func (s3 ssekms) Algorithm(metadata map[string]string) string {
+	if s3.IsDualLayer(metadata) {
+		return xhttp.AmzEncryptionKMSDSSE
+	}
+	return xhttp.AmzEncryptionKMS
+}
+
+// CreateDualLayerKey generates the object key of the second
+// encryption layer of a DSSE-KMS object. The key is sealed with a
+// second data key generated by the KMS, independent of the data key
+// sealing the object key, and stored in the metadata.
+func (s3 ssekms) CreateDualLayerKey(KMS kms.KMS, keyID string, ctx kms.Context, bucket, object string, metadata map[string]string) (ObjectKey, error) {
+	dek, err := KMS.GenerateKey(keyID, ctx)
+	if err != nil {
+		return ObjectKey{}, err
+	}
+	key := GenerateKey(dek.Plaintext, rand.Reader)
+	s3.sealDualLayerKey(key, dek, bucket, object, metadata)
+	return key, nil
+}
+
+// RotateDualLayerKey seals the object key of the second encryption
+// layer of a DSSE-KMS object with a new data key generated by the
+// KMS key newKeyID. The content remains encrypted with the same key.
+func (s3 ssekms) RotateDualLayerKey(KMS kms.KMS, newKeyID string, ctx kms.Context, bucket, object string, metadata map[string]string) error {
+	key, err := s3.UnsealDualLayerKey(KMS, metadata, bucket, object)
+	if err != nil {
+		return err
+	}
+	dek, err := KMS.GenerateKey(newKeyID, ctx)
+	if err != nil {
+		return err
+	}
+	s3.sealDualLayerKey(key, dek, bucket, object, metadata)
+	return nil
+}
+
+func (ssekms) sealDualLayerKey(key ObjectKey, dek kms.DEK, bucket, object string, metadata map[string]string) {
+	sealedKey := key.Seal(dek.Plaintext, GenerateIV(rand.Reader), dualLayerDomain, bucket, object)
+	metadata[MetaDualLayer] = "true"
+	metadata[MetaDualLayerDataEncryptionKey] = base64.StdEncoding.EncodeToString(dek.Ciphertext)
+	metadata[MetaDualLayerIV] = base64.StdEncoding.EncodeToString(sealedKey.IV[:])
+	metadata[MetaDualLayerSealedObjectKey] = base64.StdEncoding.EncodeToString(sealedKey.Key[:])
+}
+
+// UnsealDualLayerKey extracts and decrypts the sealed object key of
+// the second encryption layer of a DSSE-KMS object using KMS.
+func (s3 ssekms) UnsealDualLayerKey(KMS kms.KMS, metadata map[string]string, bucket, object string) (key ObjectKey, err error) {
+	keyID, _, _, ctx, err := s3.ParseMetadata(metadata)
+	if err != nil {
+		return key, err
+	}
+	if ctx == nil {
+		ctx = kms.Context{bucket: path.Join(bucket, object)}
+	} else if _, ok := ctx[bucket]; !ok {
+		ctx[bucket] = path.Join(bucket, object)
+	}
+
+	b64DataKey, ok := metadata[MetaDualLayerDataEncryptionKey]
+	if !ok {
+		return key, Errorf("The object metadata is missing the internal sealed second-layer KMS data key for DSSE-KMS")
+	}
+	b64IV, ok := metadata[MetaDualLayerIV]
+	if !ok {
+		return key, Errorf("The object metadata is missing the internal second-layer IV for DSSE-KMS")
+	}
+	b64SealedKey, ok := metadata[MetaDualLayerSealedObjectKey]
+	if !ok {
+		return key, Errorf("The object metadata is missing the internal sealed second-layer object key for DSSE-KMS")
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(b64DataKey)
+	if err != nil {
+		return key, Errorf("The internal sealed second-layer KMS data key for DSSE-KMS is invalid")
+	}
+	iv, err := base64.StdEncoding.DecodeString(b64IV)
+	if err != nil || len(iv) != 32 {
+		return key, Errorf("The internal second-layer IV for DSSE-KMS is invalid")
+	}
+	encryptedKey, err := base64.StdEncoding.DecodeString(b64SealedKey)
+	if err != nil || len(encryptedKey) != 64 {
+		return key, Errorf("The internal sealed second-layer object key for DSSE-KMS is invalid")
+	}
+
+	sealedKey := SealedKey{Algorithm: SealAlgorithm}
+	copy(sealedKey.IV[:], iv)
+	copy(sealedKey.Key[:], encryptedKey)
+
+	unsealKey, err := KMS.DecryptKey(keyID, dataKey, ctx)
+	if err != nil {
+		return key, err
+	}
+	err = key.Unseal(unsealKey, sealedKey, dualLayerDomain, bucket, object)
+	return key, err
+}
+
+// DualLayerReader returns a reader applying the second encryption
+// layer of a DSSE-KMS object to the encrypted content r of the object,
+// or of its part partID, using the second-layer object key. The layer
+// is an AES-256-CTR key stream of a key derived from the object key and
+// the part ID, so the size of the content does not change and ranges of
+// it can be read; offset is the position of r within the content. As
+// with the CTR mode itself, the same reader adds and removes the layer.
+func DualLayerReader(key ObjectKey, partID uint32, r io.Reader, offset int64) (io.Reader, error) {
+	partKey := key.DerivePartKey(partID)
+	block, err := aes.NewCipher(partKey[:])
+	if err != nil {
+		return nil, err
+	}
+	var iv [aes.BlockSize]byte
+	binary.BigEndian.PutUint64(iv[8:], uint64(offset/aes.BlockSize))
+	stream := cipher.NewCTR(block, iv[:])
+	if skip := offset % aes.BlockSize; skip > 0 {
+		var discard [aes.BlockSize]byte
+		stream.XORKeyStream(discard[:skip], discard[:skip])
+	}
+	return cipher.StreamReader{S: stream, R: r}, nil
+}
+
 // IsEncrypted returns true if the object metadata indicates
 // that the object was uploaded using SSE-KMS.
 func (ssekms) IsEncrypted(metadata map[string]string) bool {
@@ -46,8 +46,9 @@ func (sses3) String() string { return "SSE-S3" }
 
 func (sses3) IsRequested(h http.Header) bool {
 	_, ok := h[xhttp.AmzServerSideEncryption]
-	// Return only true if the SSE header is specified and does not contain the SSE-KMS value
-	return ok && !strings.EqualFold(h.Get(xhttp.AmzServerSideEncryption), xhttp.AmzEncryptionKMS)
+	// Return only true if the SSE header is specified and does not contain an SSE-KMS value
+	return ok && !strings.EqualFold(h.Get(xhttp.AmzServerSideEncryption), xhttp.AmzEncryptionKMS) &&
+		!strings.EqualFold(h.Get(xhttp.AmzServerSideEncryption), xhttp.AmzEncryptionKMSDSSE)
 }
 
 // ParseHTTP parses the SSE-S3 related HTTP headers and checks
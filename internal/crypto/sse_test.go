@@ -18,8 +18,15 @@
 package crypto
 
 import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
 	"net/http"
+	"path"
 	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/kms"
 )
 
 func TestS3String(t *testing.T) {
@@ -185,3 +192,107 @@ func TestSSECopyUnsealObjectKey(t *testing.T) {
 		}
 	}
 }
+
+func TestS3KMSDualLayerUnsealObjectKey(t *testing.T) {
+	KMS, err := kms.Parse("my-minio-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := http.Header{}
+	h.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMSDSSE)
+	if !S3KMS.IsRequested(h) || S3.IsRequested(h) || !S3KMS.IsDualLayerRequested(h) {
+		t.Fatal("DSSE-KMS request is not detected as SSE-KMS request")
+	}
+	if _, _, err = S3KMS.ParseHTTP(h); err != nil {
+		t.Fatal(err)
+	}
+
+	const bucket, object = "bucket", "object"
+	ctx := kms.Context{bucket: path.Join(bucket, object)}
+	key, err := KMS.GenerateKey("", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := map[string]string{}
+	dualLayerKey, err := S3KMS.CreateDualLayerKey(KMS, key.KeyID, ctx, bucket, object, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectKey := GenerateKey(key.Plaintext, rand.Reader)
+	sealedKey := objectKey.Seal(key.Plaintext, GenerateIV(rand.Reader), S3KMS.String(), bucket, object)
+	S3KMS.CreateMetadata(metadata, key.KeyID, key.Ciphertext, sealedKey, nil)
+
+	if algorithm := S3KMS.Algorithm(metadata); algorithm != xhttp.AmzEncryptionKMSDSSE {
+		t.Fatalf("got algorithm %s - want %s", algorithm, xhttp.AmzEncryptionKMSDSSE)
+	}
+	unsealedKey, err := S3KMS.UnsealObjectKey(KMS, metadata, bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unsealedKey != objectKey {
+		t.Fatal("unsealed object key does not match the generated object key")
+	}
+	if dualLayerKey == objectKey {
+		t.Fatal("the second-layer object key must differ from the object key")
+	}
+	unsealedKey, err = S3KMS.UnsealDualLayerKey(KMS, metadata, bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unsealedKey != dualLayerKey {
+		t.Fatal("unsealed second-layer object key does not match the generated key")
+	}
+
+	// Rotating the data key keeps the second-layer object key.
+	sealedDataKey := metadata[MetaDualLayerDataEncryptionKey]
+	if err = S3KMS.RotateDualLayerKey(KMS, key.KeyID, ctx, bucket, object, metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata[MetaDualLayerDataEncryptionKey] == sealedDataKey {
+		t.Fatal("second-layer data key was not rotated")
+	}
+	if unsealedKey, err = S3KMS.UnsealDualLayerKey(KMS, metadata, bucket, object); err != nil || unsealedKey != dualLayerKey {
+		t.Fatalf("second-layer object key changed on rotation: %v", err)
+	}
+
+	// The second-layer object key is bound to the object.
+	if _, err = S3KMS.UnsealDualLayerKey(KMS, metadata, bucket, "other"); err == nil {
+		t.Fatal("second-layer object key unsealed for another object")
+	}
+}
+
+func TestDualLayerReader(t *testing.T) {
+	key := GenerateKey(make([]byte, 32), rand.Reader)
+	content := make([]byte, 1000)
+	if _, err := rand.Read(content); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := DualLayerReader(key, 1, bytes.NewReader(content), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(encrypted) != len(content) || bytes.Equal(encrypted, content) {
+		t.Fatal("second layer must encrypt the content without changing its size")
+	}
+
+	// Ranges are decrypted at their offset, parts use distinct keys.
+	for _, offset := range []int64{0, 1, 15, 16, 17, 500} {
+		r, err = DualLayerReader(key, 1, bytes.NewReader(encrypted[offset:]), offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, _ := ioutil.ReadAll(r)
+		if !bytes.Equal(decrypted, content[offset:]) {
+			t.Fatalf("range at offset %d not decrypted", offset)
+		}
+	}
+	r, _ = DualLayerReader(key, 2, bytes.NewReader(encrypted), 0)
+	if decrypted, _ := ioutil.ReadAll(r); bytes.Equal(decrypted, content) {
+		t.Fatal("content decrypted with the key of another part")
+	}
+}
@@ -27,8 +27,13 @@ import (
 	"time"
 
 	"github.com/minio/pkg/console"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/minio/minio/internal/dsync")
+
 // Indicator if logging is enabled.
 var dsyncLog bool
 
@@ -75,6 +80,7 @@ type DRWMutex struct {
 type Granted struct {
 	index   int
 	lockUID string // Locked if set with UID string, unlocked if empty
+	offline bool   // The locker could not be reached
 }
 
 func (g *Granted) isLocked() bool {
@@ -157,6 +163,21 @@ const (
 // time has elapsed than the timeout value.
 func (dm *DRWMutex) lockBlocking(ctx context.Context, lockLossCallback func(), id, source string, isReadLock bool, opts Options) (locked bool) {
 	restClnts, _ := dm.clnt.GetLockers()
+	start := time.Now()
+
+	// Locks are traced as part of the request taking them, if any.
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		name := "dsync.Lock"
+		if isReadLock {
+			name = "dsync.RLock"
+		}
+		var span trace.Span
+		ctx, span = tracer.Start(ctx, name, trace.WithAttributes(attribute.StringSlice("dsync.resources", dm.Names)))
+		defer func() {
+			span.SetAttributes(attribute.Bool("dsync.locked", locked))
+			span.End()
+		}()
+	}
 
 	// Create lock array to capture the successful lockers
 	locks := make([]string, len(restClnts))
@@ -187,6 +208,9 @@ func (dm *DRWMutex) lockBlocking(ctx context.Context, lockLossCallback func(), i
 	for {
 		select {
 		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				recordTimeout(isReadLock)
+			}
 			return false
 		default:
 			// Try to acquire the lock.
@@ -202,6 +226,7 @@ func (dm *DRWMutex) lockBlocking(ctx context.Context, lockLossCallback func(), i
 
 				dm.m.Unlock()
 				log("lockBlocking %s/%s for %#v: granted\n", id, source, dm.Names)
+				recordGranted(isReadLock, time.Since(start))
 
 				// Refresh lock continuously and cancel if there is no quorum in the lock anymore
 				dm.startContinousLockRefresh(lockLossCallback, id, source, quorum)
@@ -237,6 +262,7 @@ func (dm *DRWMutex) startContinousLockRefresh(lockLossCallback func(), id, sourc
 				noQuorum, err := refreshLock(ctx, dm.clnt, id, source, quorum)
 				if err == nil && noQuorum {
 					// Clean the lock locally and in remote nodes
+					RecordForceUnlock(ForceUnlockLost)
 					forceUnlock(ctx, dm.clnt, id)
 					// Execute the caller lock loss callback
 					if lockLossCallback != nil {
@@ -296,15 +322,20 @@ func refreshLock(ctx context.Context, ds *Dsync, id, source string, quorum int)
 			defer wg.Done()
 
 			if c == nil {
+				recordRefreshFailure()
 				ch <- refreshResult{offline: true}
 				return
 			}
 
-			ctx, cancel := context.WithTimeout(ctx, drwMutexRefreshCallTimeout)
+			refreshCtx, cancel := context.WithTimeout(ctx, drwMutexRefreshCallTimeout)
 			defer cancel()
 
-			refreshed, err := c.Refresh(ctx, args)
+			refreshed, err := c.Refresh(refreshCtx, args)
 			if err != nil {
+				// Refreshing is canceled when the lock is released.
+				if ctx.Err() == nil {
+					recordRefreshFailure()
+				}
 				ch <- refreshResult{offline: true}
 				log("dsync: Unable to call Refresh failed with %s for %#v at %s\n", err, args, c)
 			} else {
@@ -390,6 +421,7 @@ func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, is
 			g := Granted{index: index}
 			if c == nil {
 				log("dsync: nil locker\n")
+				g.offline = true
 				ch <- g
 				return
 			}
@@ -408,6 +440,7 @@ func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, is
 			if locked {
 				g.lockUID = args.UID
 			}
+			g.offline = err != nil
 			ch <- g
 		}(index, isReadLock, c)
 	}
@@ -418,7 +451,7 @@ func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, is
 	// b) received too many 'non-'locks for quorum to be still possible
 	// c) timed out
 	//
-	i, locksFailed := 0, 0
+	i, locksFailed, lockersOffline := 0, 0, 0
 	done := false
 
 	for ; i < len(restClnts); i++ { // Loop until we acquired all locks
@@ -428,6 +461,9 @@ func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, is
 				// Mark that this node has acquired the lock
 				(*locks)[grant.index] = grant.lockUID
 			} else {
+				if grant.offline {
+					lockersOffline++
+				}
 				locksFailed++
 				if locksFailed > tolerance {
 					// We know that we are not going to get the lock anymore,
@@ -437,6 +473,7 @@ func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, is
 			}
 		case <-ctx.Done():
 			// Capture timedout locks as failed or took too long
+			lockersOffline++
 			locksFailed++
 			if locksFailed > tolerance {
 				// We know that we are not going to get the lock anymore,
@@ -453,6 +490,10 @@ func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, is
 	quorumLocked := checkQuorumLocked(locks, quorum) && locksFailed <= tolerance
 	if !quorumLocked {
 		log("dsync: Unable to acquire lock in quorum %#v\n", args)
+		if lockersOffline > tolerance {
+			// Quorum could not be reached, regardless of other holders.
+			recordQuorumError(isReadLock)
+		}
 		// Release all acquired locks without quorum.
 		if !releaseAll(ds, tolerance, owner, locks, isReadLock, restClnts, names...) {
 			log("Unable to release acquired locks, these locks will expire automatically %#v\n", args)
@@ -563,6 +604,7 @@ func (dm *DRWMutex) Unlock() {
 		if !lockFound {
 			panic("Trying to Unlock() while no Lock() is active")
 		}
+		recordReleased(false)
 
 		// Copy write locks to stack array
 		copy(locks, dm.writeLocks)
@@ -604,6 +646,7 @@ func (dm *DRWMutex) RUnlock() {
 		if !lockFound {
 			panic("Trying to RUnlock() while no RLock() is active")
 		}
+		recordReleased(true)
 
 		// Copy write locks to stack array
 		copy(locks, dm.readLocks)
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dsync
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// Lock types of the statistics.
+const (
+	WriteLockType = "write"
+	ReadLockType  = "read"
+)
+
+// Reasons of force unlocks.
+const (
+	// ForceUnlockLost - the lock lost its quorum while refreshing.
+	ForceUnlockLost = "lost"
+	// ForceUnlockAdmin - the lock was released by an administrator.
+	ForceUnlockAdmin = "admin"
+)
+
+// grantLatencyBucketLen must be the length of grantLatencyIntervals
+const grantLatencyBucketLen = 6
+
+// grantLatencyIntervals are the upper bounds of the lock grant latency
+// histogram intervals. Latency is the time from requesting a lock until
+// it was granted, including the time spent waiting for other holders.
+var grantLatencyIntervals = [grantLatencyBucketLen]struct {
+	name string
+	end  time.Duration
+}{
+	{"LESS_THAN_1_MS", time.Millisecond},
+	{"BETWEEN_1_MS_AND_10_MS", 10 * time.Millisecond},
+	{"BETWEEN_10_MS_AND_100_MS", 100 * time.Millisecond},
+	{"BETWEEN_100_MS_AND_1_SEC", time.Second},
+	{"BETWEEN_1_SEC_AND_10_SEC", 10 * time.Second},
+	{"GREATER_THAN_10_SEC", math.MaxInt64},
+}
+
+// typeStats are the statistics of a lock type, all fields are
+// accessed atomically.
+type typeStats struct {
+	outstanding  int64
+	quorumErrors uint64
+	timeouts     uint64
+	grantLatency [grantLatencyBucketLen]uint64
+}
+
+var lockStats struct {
+	write, read     typeStats
+	refreshFailures uint64
+	forceLost       uint64
+	forceAdmin      uint64
+}
+
+func getTypeStats(isReadLock bool) *typeStats {
+	if isReadLock {
+		return &lockStats.read
+	}
+	return &lockStats.write
+}
+
+func recordGranted(isReadLock bool, latency time.Duration) {
+	ts := getTypeStats(isReadLock)
+	atomic.AddInt64(&ts.outstanding, 1)
+	for i, interval := range grantLatencyIntervals {
+		if latency < interval.end {
+			atomic.AddUint64(&ts.grantLatency[i], 1)
+			return
+		}
+	}
+}
+
+func recordReleased(isReadLock bool) {
+	atomic.AddInt64(&getTypeStats(isReadLock).outstanding, -1)
+}
+
+func recordQuorumError(isReadLock bool) {
+	atomic.AddUint64(&getTypeStats(isReadLock).quorumErrors, 1)
+}
+
+func recordTimeout(isReadLock bool) {
+	atomic.AddUint64(&getTypeStats(isReadLock).timeouts, 1)
+}
+
+func recordRefreshFailure() {
+	atomic.AddUint64(&lockStats.refreshFailures, 1)
+}
+
+// RecordForceUnlock - records a lock released forcefully for the reason.
+func RecordForceUnlock(reason string) {
+	switch reason {
+	case ForceUnlockLost:
+		atomic.AddUint64(&lockStats.forceLost, 1)
+	case ForceUnlockAdmin:
+		atomic.AddUint64(&lockStats.forceAdmin, 1)
+	}
+}
+
+// TypeStats - statistics of the locks of one type taken by this node.
+type TypeStats struct {
+	// Outstanding is the number of locks currently held.
+	Outstanding int64 `json:"outstanding"`
+	// QuorumErrors is the number of lock attempts which failed
+	// because too many lockers were unreachable.
+	QuorumErrors uint64 `json:"quorumErrors"`
+	// Timeouts is the number of lock requests which were not
+	// granted before their timeout.
+	Timeouts     uint64            `json:"timeouts"`
+	GrantLatency map[string]uint64 `json:"grantLatency"`
+}
+
+// Stats - statistics of the distributed locks taken by this node
+// since server start.
+type Stats struct {
+	Types map[string]TypeStats `json:"types"`
+	// RefreshFailures is the number of refresh calls to lockers
+	// which failed.
+	RefreshFailures uint64            `json:"refreshFailures"`
+	ForceUnlocks    map[string]uint64 `json:"forceUnlocks"`
+}
+
+// GetStats - returns the lock statistics of this node.
+func GetStats() Stats {
+	load := func(ts *typeStats) TypeStats {
+		stat := TypeStats{
+			Outstanding:  atomic.LoadInt64(&ts.outstanding),
+			QuorumErrors: atomic.LoadUint64(&ts.quorumErrors),
+			Timeouts:     atomic.LoadUint64(&ts.timeouts),
+			GrantLatency: make(map[string]uint64, grantLatencyBucketLen),
+		}
+		for i, interval := range grantLatencyIntervals {
+			stat.GrantLatency[interval.name] = atomic.LoadUint64(&ts.grantLatency[i])
+		}
+		return stat
+	}
+	return Stats{
+		Types: map[string]TypeStats{
+			WriteLockType: load(&lockStats.write),
+			ReadLockType:  load(&lockStats.read),
+		},
+		RefreshFailures: atomic.LoadUint64(&lockStats.refreshFailures),
+		ForceUnlocks: map[string]uint64{
+			ForceUnlockLost:  atomic.LoadUint64(&lockStats.forceLost),
+			ForceUnlockAdmin: atomic.LoadUint64(&lockStats.forceAdmin),
+		},
+	}
+}
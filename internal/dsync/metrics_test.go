@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dsync
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLockStats(t *testing.T) {
+	before := GetStats().Types[WriteLockType]
+
+	drwm := NewDRWMutex(ds, "statslock")
+	ctx, cancel := context.WithCancel(context.Background())
+	if !drwm.GetLock(ctx, cancel, id, source, Options{Timeout: time.Second}) {
+		t.Fatal("Failed to acquire write lock")
+	}
+	held := GetStats().Types[WriteLockType]
+	if held.Outstanding != before.Outstanding+1 {
+		t.Errorf("expected %d outstanding write locks, got %d", before.Outstanding+1, held.Outstanding)
+	}
+
+	// A second write lock on the same resource times out.
+	other := NewDRWMutex(ds, "statslock")
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	if other.GetLock(ctx2, cancel2, id, source, Options{Timeout: 100 * time.Millisecond}) {
+		t.Fatal("Unexpected second write lock")
+	}
+	drwm.Unlock()
+
+	after := GetStats().Types[WriteLockType]
+	if after.Outstanding != before.Outstanding {
+		t.Errorf("expected %d outstanding write locks, got %d", before.Outstanding, after.Outstanding)
+	}
+	if after.Timeouts != before.Timeouts+1 {
+		t.Errorf("expected %d timeouts, got %d", before.Timeouts+1, after.Timeouts)
+	}
+	var grantedBefore, grantedAfter uint64
+	for interval, n := range after.GrantLatency {
+		grantedAfter += n
+		grantedBefore += before.GrantLatency[interval]
+	}
+	if grantedAfter != grantedBefore+1 {
+		t.Errorf("expected %d granted write locks, got %d", grantedBefore+1, grantedAfter)
+	}
+}
+
+func TestRecordForceUnlock(t *testing.T) {
+	before := GetStats().ForceUnlocks
+	RecordForceUnlock(ForceUnlockAdmin)
+	RecordForceUnlock("unknown")
+	after := GetStats().ForceUnlocks
+	if after[ForceUnlockAdmin] != before[ForceUnlockAdmin]+1 {
+		t.Errorf("expected %d admin force unlocks, got %d", before[ForceUnlockAdmin]+1, after[ForceUnlockAdmin])
+	}
+	if after[ForceUnlockLost] != before[ForceUnlockLost] {
+		t.Errorf("expected %d lost force unlocks, got %d", before[ForceUnlockLost], after[ForceUnlockLost])
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	// S3Format - S3 event log format, the default of message based event targets.
+	S3Format = "s3"
+
+	// CloudEventsFormat - CloudEvents 1.0 JSON format of message based event targets.
+	CloudEventsFormat = "cloudevents"
+
+	// CloudEventsSpecVersion - implemented version of the CloudEvents specification.
+	CloudEventsSpecVersion = "1.0"
+
+	cloudEventsTypePrefix = "io.min."
+)
+
+// CloudEvent is an event in the CloudEvents 1.0 JSON format as
+// defined in https://github.com/cloudevents/spec/blob/v1.0/json-format.md.
+// The data of a CloudEvent is the S3 event record.
+type CloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time,omitempty"`
+	DataContentType string `json:"datacontenttype"`
+	// SchemaVersion is the schema version of the data.
+	SchemaVersion string `json:"schemaversion"`
+	Data          Event  `json:"data"`
+}
+
+// NewCloudEvent returns the event as CloudEvent. The ID is unique for
+// every event of an object version, so consumers can drop duplicates.
+func NewCloudEvent(e Event) CloudEvent {
+	objectName, err := url.QueryUnescape(e.S3.Object.Key)
+	if err != nil {
+		objectName = e.S3.Object.Key
+	}
+
+	source := e.S3.Bucket.ARN
+	if source == "" {
+		source = e.EventSource // e.g. 'minio:iam'
+	}
+	return CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              DedupID(e),
+		Source:          source,
+		Type:            cloudEventsTypePrefix + strings.ReplaceAll(strings.TrimSuffix(e.EventName.String(), ":*"), ":", "."),
+		Subject:         objectName,
+		Time:            e.EventTime,
+		DataContentType: "application/json",
+		SchemaVersion:   e.EventVersion,
+		Data:            e,
+	}
+}
+
+// DedupID returns an ID of the event which is the same for
+// all deliveries of the event.
+func DedupID(e Event) string {
+	objectName, err := url.QueryUnescape(e.S3.Object.Key)
+	if err != nil {
+		objectName = e.S3.Object.Key
+	}
+	return e.S3.Bucket.Name + "/" + objectName + "/" + e.S3.Object.VersionID + "/" + e.S3.Object.Sequencer
+}
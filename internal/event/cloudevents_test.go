@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewCloudEvent(t *testing.T) {
+	e := Event{
+		EventVersion: "2.0",
+		EventSource:  "minio:s3",
+		EventTime:    "2021-12-01T00:00:00.000Z",
+		EventName:    ObjectCreatedPut,
+	}
+	e.S3.Bucket.Name = "photos"
+	e.S3.Bucket.ARN = "arn:aws:s3:::photos"
+	e.S3.Object.Key = "2021%2Fholiday.jpg"
+	e.S3.Object.VersionID = "v1"
+	e.S3.Object.Sequencer = "16BC5A0C1F8E3A3B"
+
+	ce := NewCloudEvent(e)
+	if ce.SpecVersion != CloudEventsSpecVersion {
+		t.Errorf("expected specversion %s, got %s", CloudEventsSpecVersion, ce.SpecVersion)
+	}
+	if ce.Source != "arn:aws:s3:::photos" {
+		t.Errorf("unexpected source %s", ce.Source)
+	}
+	if ce.Subject != "2021/holiday.jpg" {
+		t.Errorf("unexpected subject %s", ce.Subject)
+	}
+	if ce.Type != "io.min.s3.ObjectCreated.Put" {
+		t.Errorf("unexpected type %s", ce.Type)
+	}
+	if ce.ID != "photos/2021/holiday.jpg/v1/16BC5A0C1F8E3A3B" {
+		t.Errorf("unexpected id %s", ce.ID)
+	}
+	if ce.ID != DedupID(e) {
+		t.Error("id must match the dedup id")
+	}
+
+	data, err := json.Marshal(ce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, attr := range []string{"specversion", "id", "source", "type", "subject", "time", "datacontenttype", "data"} {
+		if _, ok := m[attr]; !ok {
+			t.Errorf("missing attribute %s", attr)
+		}
+	}
+}
@@ -137,14 +137,21 @@ func (ruleList FilterRuleList) Pattern() string {
 	return NewPattern(prefix, suffix)
 }
 
-// S3Key - represents elements inside <S3Key>...</S3Key>
+// S3Key - represents elements inside <Filter>...</Filter>
 type S3Key struct {
 	RuleList FilterRuleList `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
+
+	// MinIO extension, conditions on the object attributes.
+	Tags                  []FilterTag      `xml:"Tag,omitempty" json:"Tag,omitempty"`
+	Metadata              []FilterMetadata `xml:"Metadata,omitempty" json:"Metadata,omitempty"`
+	ObjectSizeGreaterThan int64            `xml:"ObjectSizeGreaterThan,omitempty" json:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64            `xml:"ObjectSizeLessThan,omitempty" json:"ObjectSizeLessThan,omitempty"`
+	StorageClass          string           `xml:"StorageClass,omitempty" json:"StorageClass,omitempty"`
 }
 
 // MarshalXML implements a custom marshaller to support `omitempty` feature.
 func (s3Key S3Key) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	if s3Key.RuleList.isEmpty() {
+	if s3Key.RuleList.isEmpty() && !s3Key.hasConditions() {
 		return nil
 	}
 	type s3KeyWrapper S3Key
@@ -180,6 +187,12 @@ func (q *Queue) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 
 	eventStringSet := set.NewStringSet()
 	for _, eventName := range parsedQueue.Events {
+		// IAM changes are not bound to a bucket, they are only
+		// sent to the targets configured for IAM events.
+		if eventName == TestEvent || eventName == IAMChange {
+			return &ErrInvalidEventName{eventName.String()}
+		}
+
 		if eventStringSet.Contains(eventName.String()) {
 			return &ErrDuplicateEventName{eventName}
 		}
@@ -187,6 +200,10 @@ func (q *Queue) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		eventStringSet.Add(eventName.String())
 	}
 
+	if err := parsedQueue.Filter.validateConditions(); err != nil {
+		return err
+	}
+
 	*q = Queue(parsedQueue)
 
 	return nil
@@ -220,7 +237,10 @@ func (q *Queue) SetRegion(region string) {
 // ToRulesMap - converts Queue to RulesMap
 func (q Queue) ToRulesMap() RulesMap {
 	pattern := q.Filter.RuleList.Pattern()
-	return NewRulesMap(q.Events, pattern, q.ARN.TargetID)
+	if pattern == "" {
+		pattern = "*"
+	}
+	return NewRulesMap(q.Events, q.Filter.ruleKey(pattern), q.ARN.TargetID)
 }
 
 // Unused.  Available for completion.
@@ -180,6 +180,22 @@ func TestQueueUnmarshalXML(t *testing.T) {
    <Event>s3:ObjectCreated:Put</Event>
 </QueueConfiguration>`)
 
+	dataCase4 := []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+   <Filter></Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:TestEvent</Event>
+</QueueConfiguration>`)
+
+	dataCase5 := []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+   <Filter></Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:IAMChange:*</Event>
+</QueueConfiguration>`)
+
 	testCases := []struct {
 		data      []byte
 		expectErr bool
@@ -187,6 +203,8 @@ func TestQueueUnmarshalXML(t *testing.T) {
 		{dataCase1, false},
 		{dataCase2, false},
 		{dataCase3, true},
+		{dataCase4, true},
+		{dataCase5, true},
 	}
 
 	for i, testCase := range testCases {
@@ -61,6 +61,15 @@ func (err ErrInvalidFilterName) Error() string {
 	return fmt.Sprintf("invalid filter name '%v'", err.FilterName)
 }
 
+// ErrInvalidFilterCondition - invalid object attribute condition of a filter.
+type ErrInvalidFilterCondition struct {
+	Reason string
+}
+
+func (err ErrInvalidFilterCondition) Error() string {
+	return fmt.Sprintf("invalid filter condition: %v", err.Reason)
+}
+
 // ErrFilterNamePrefix - more than one prefix usage error.
 type ErrFilterNamePrefix struct{}
 
@@ -151,3 +160,12 @@ type ErrInvalidEventName struct {
 func (err ErrInvalidEventName) Error() string {
 	return fmt.Sprintf("invalid event name '%v'", err.Name)
 }
+
+// ErrUnsupportedSchemaVersion - unsupported event schema version error.
+type ErrUnsupportedSchemaVersion struct {
+	Version string
+}
+
+func (err ErrUnsupportedSchemaVersion) Error() string {
+	return fmt.Sprintf("unsupported event schema version '%v', supported versions are %v", err.Version, SchemaVersions)
+}
@@ -79,6 +79,37 @@ type Event struct {
 	ResponseElements  map[string]string `json:"responseElements"`
 	S3                Metadata          `json:"s3"`
 	Source            Source            `json:"source"`
+	// IAM is only set on IAMChange events.
+	IAM *IAMMetadata `json:"iam,omitempty"`
+	// GlacierEventData is only set on ObjectRestore:Completed events.
+	GlacierEventData *GlacierEventData `json:"glacierEventData,omitempty"`
+}
+
+// GlacierEventData represents the restored copy of a transitioned object.
+type GlacierEventData struct {
+	RestoreEventData RestoreEventData `json:"restoreEventData"`
+}
+
+// RestoreEventData represents the expiry and the tier of a restored copy.
+type RestoreEventData struct {
+	LifecycleRestorationExpiryTime string `json:"lifecycleRestorationExpiryTime"`
+	LifecycleRestoreStorageClass   string `json:"lifecycleRestoreStorageClass"`
+}
+
+// IAMMetadata describes a change of a user, group, policy, service
+// account or policy mapping, along with the changed attributes.
+type IAMMetadata struct {
+	Type      string        `json:"type"`
+	Operation string        `json:"operation"`
+	Entity    string        `json:"entity"`
+	Changes   []FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is a single changed attribute of an IAM entity.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
 }
 
 // Log represents event information for some event targets.
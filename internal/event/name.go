@@ -26,6 +26,9 @@ import (
 // Refer http://docs.aws.amazon.com/AmazonS3/latest/dev/NotificationHowTo.html#notification-how-to-event-types-and-destinations
 // for most basic values we have since extend this and its not really much applicable other than a reference point.
 // "s3:Replication:OperationCompletedReplication" is a MinIO extension.
+// "s3:LifecycleExpiration:*" and "s3:ObjectHeal:*" events are only sent
+// if internal operation events are enabled.
+// "s3:TestEvent" is sent to verify targets and cannot be subscribed to.
 type Name int
 
 // Values of event Name
@@ -61,6 +64,14 @@ const (
 	ObjectTransitionAll
 	ObjectTransitionFailed
 	ObjectTransitionComplete
+	ObjectTransitionCorrupted
+	IAMChange
+	ObjectLifecycleExpirationAll
+	ObjectLifecycleExpirationDelete
+	ObjectLifecycleExpirationDeleteMarkerCreated
+	ObjectHealAll
+	ObjectHealRepaired
+	TestEvent
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -104,7 +115,15 @@ func (name Name) Expand() []Name {
 		return []Name{
 			ObjectTransitionFailed,
 			ObjectTransitionComplete,
+			ObjectTransitionCorrupted,
 		}
+	case ObjectLifecycleExpirationAll:
+		return []Name{
+			ObjectLifecycleExpirationDelete,
+			ObjectLifecycleExpirationDeleteMarkerCreated,
+		}
+	case ObjectHealAll:
+		return []Name{ObjectHealRepaired}
 	default:
 		return []Name{name}
 	}
@@ -173,6 +192,22 @@ func (name Name) String() string {
 		return "s3:ObjectTransition:Failed"
 	case ObjectTransitionComplete:
 		return "s3:ObjectTransition:Complete"
+	case ObjectTransitionCorrupted:
+		return "s3:ObjectTransition:Corrupted"
+	case IAMChange:
+		return "s3:IAMChange:*"
+	case ObjectLifecycleExpirationAll:
+		return "s3:LifecycleExpiration:*"
+	case ObjectLifecycleExpirationDelete:
+		return "s3:LifecycleExpiration:Delete"
+	case ObjectLifecycleExpirationDeleteMarkerCreated:
+		return "s3:LifecycleExpiration:DeleteMarkerCreated"
+	case ObjectHealAll:
+		return "s3:ObjectHeal:*"
+	case ObjectHealRepaired:
+		return "s3:ObjectHeal:Repaired"
+	case TestEvent:
+		return "s3:TestEvent"
 	}
 
 	return ""
@@ -283,8 +318,24 @@ func ParseName(s string) (Name, error) {
 		return ObjectTransitionFailed, nil
 	case "s3:ObjectTransition:Complete":
 		return ObjectTransitionComplete, nil
+	case "s3:ObjectTransition:Corrupted":
+		return ObjectTransitionCorrupted, nil
 	case "s3:ObjectTransition:*":
 		return ObjectTransitionAll, nil
+	case "s3:IAMChange:*":
+		return IAMChange, nil
+	case "s3:LifecycleExpiration:*":
+		return ObjectLifecycleExpirationAll, nil
+	case "s3:LifecycleExpiration:Delete":
+		return ObjectLifecycleExpirationDelete, nil
+	case "s3:LifecycleExpiration:DeleteMarkerCreated":
+		return ObjectLifecycleExpirationDeleteMarkerCreated, nil
+	case "s3:ObjectHeal:*":
+		return ObjectHealAll, nil
+	case "s3:ObjectHeal:Repaired":
+		return ObjectHealRepaired, nil
+	case "s3:TestEvent":
+		return TestEvent, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
@@ -37,6 +37,8 @@ func TestNameExpand(t *testing.T) {
 			ObjectCreatedPutRetention, ObjectCreatedPutLegalHold, ObjectCreatedPutTagging, ObjectCreatedDeleteTagging,
 		}},
 		{ObjectRemovedAll, []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}},
+		{ObjectLifecycleExpirationAll, []Name{ObjectLifecycleExpirationDelete, ObjectLifecycleExpirationDeleteMarkerCreated}},
+		{ObjectHealAll, []Name{ObjectHealRepaired}},
 		{ObjectAccessedHead, []Name{ObjectAccessedHead}},
 	}
 
@@ -68,10 +70,13 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedPut, "s3:ObjectCreated:Put"},
 		{ObjectRemovedAll, "s3:ObjectRemoved:*"},
 		{ObjectRemovedDelete, "s3:ObjectRemoved:Delete"},
+		{ObjectLifecycleExpirationDelete, "s3:LifecycleExpiration:Delete"},
+		{ObjectHealRepaired, "s3:ObjectHeal:Repaired"},
 		{ObjectCreatedPutRetention, "s3:ObjectCreated:PutRetention"},
 		{ObjectCreatedPutLegalHold, "s3:ObjectCreated:PutLegalHold"},
 		{ObjectAccessedGetRetention, "s3:ObjectAccessed:GetRetention"},
 		{ObjectAccessedGetLegalHold, "s3:ObjectAccessed:GetLegalHold"},
+		{TestEvent, "s3:TestEvent"},
 
 		{blankName, ""},
 	}
@@ -213,6 +218,9 @@ func TestParseName(t *testing.T) {
 	}{
 		{"s3:ObjectAccessed:*", ObjectAccessedAll, false},
 		{"s3:ObjectRemoved:Delete", ObjectRemovedDelete, false},
+		{"s3:LifecycleExpiration:*", ObjectLifecycleExpirationAll, false},
+		{"s3:ObjectHeal:Repaired", ObjectHealRepaired, false},
+		{"s3:TestEvent", TestEvent, false},
 		{"", blankName, true},
 	}
 
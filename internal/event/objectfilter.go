@@ -0,0 +1,202 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Filters on object attributes are a MinIO extension of the <Filter>
+// element of queue configurations. They are evaluated on the object of
+// an event at event time; all conditions of a filter must be met.
+
+const (
+	metadataPrefix = "x-amz-meta-"
+
+	// Separates the name pattern of a rule from its conditions.
+	ruleConditionsSep = "\x00"
+)
+
+// FilterTag - represents elements inside <Tag>...</Tag> of a filter.
+type FilterTag struct {
+	Key   string `xml:"Key" json:"Key"`
+	Value string `xml:"Value" json:"Value"`
+}
+
+// FilterMetadata - represents elements inside <Metadata>...</Metadata>
+// of a filter, Name is the user metadata header e.g. 'x-amz-meta-origin'.
+type FilterMetadata struct {
+	Name  string `xml:"Name" json:"Name"`
+	Value string `xml:"Value" json:"Value"`
+}
+
+// ObjectAttributes - attributes of the object of an event evaluated by
+// the filter conditions.
+type ObjectAttributes struct {
+	Size         int64
+	StorageClass string
+	Tags         map[string]string
+	// User metadata with lower case 'x-amz-meta-' prefixed keys.
+	UserMetadata map[string]string
+}
+
+// conditions - object attribute conditions of a filter.
+type conditions struct {
+	tags         map[string]string
+	metadata     map[string]string
+	sizeGT       int64
+	sizeLT       int64
+	storageClass string
+}
+
+func (c conditions) match(attrs ObjectAttributes) bool {
+	for k, v := range c.tags {
+		if tv, ok := attrs.Tags[k]; !ok || tv != v {
+			return false
+		}
+	}
+	for k, v := range c.metadata {
+		if mv, ok := attrs.UserMetadata[k]; !ok || mv != v {
+			return false
+		}
+	}
+	if c.sizeGT > 0 && attrs.Size <= c.sizeGT {
+		return false
+	}
+	if c.sizeLT > 0 && attrs.Size >= c.sizeLT {
+		return false
+	}
+	if c.storageClass != "" {
+		storageClass := attrs.StorageClass
+		if storageClass == "" {
+			storageClass = "STANDARD"
+		}
+		if storageClass != c.storageClass {
+			return false
+		}
+	}
+	return true
+}
+
+// validateConditions - checks the object attribute conditions of the filter.
+func (s3Key S3Key) validateConditions() error {
+	tagKeys := make(map[string]struct{}, len(s3Key.Tags))
+	for _, tag := range s3Key.Tags {
+		if tag.Key == "" {
+			return &ErrInvalidFilterCondition{"tag key must not be empty"}
+		}
+		if _, ok := tagKeys[tag.Key]; ok {
+			return &ErrInvalidFilterCondition{"duplicate tag key '" + tag.Key + "'"}
+		}
+		tagKeys[tag.Key] = struct{}{}
+	}
+	names := make(map[string]struct{}, len(s3Key.Metadata))
+	for _, md := range s3Key.Metadata {
+		name := strings.ToLower(md.Name)
+		if !strings.HasPrefix(name, metadataPrefix) || name == metadataPrefix {
+			return &ErrInvalidFilterCondition{"metadata name '" + md.Name + "' must start with '" + metadataPrefix + "'"}
+		}
+		if _, ok := names[name]; ok {
+			return &ErrInvalidFilterCondition{"duplicate metadata name '" + md.Name + "'"}
+		}
+		names[name] = struct{}{}
+	}
+	if s3Key.ObjectSizeGreaterThan < 0 || s3Key.ObjectSizeLessThan < 0 {
+		return &ErrInvalidFilterCondition{"object size must not be negative"}
+	}
+	if s3Key.ObjectSizeLessThan > 0 && s3Key.ObjectSizeLessThan <= s3Key.ObjectSizeGreaterThan {
+		return &ErrInvalidFilterCondition{"ObjectSizeLessThan must be larger than ObjectSizeGreaterThan"}
+	}
+	return nil
+}
+
+// hasConditions - returns true if the filter has object attribute conditions.
+func (s3Key S3Key) hasConditions() bool {
+	return len(s3Key.Tags) > 0 || len(s3Key.Metadata) > 0 ||
+		s3Key.ObjectSizeGreaterThan > 0 || s3Key.ObjectSizeLessThan > 0 ||
+		s3Key.StorageClass != ""
+}
+
+// ruleKey - returns the key of the filter in Rules, the name pattern
+// followed by the canonical encoding of its conditions, if any.
+func (s3Key S3Key) ruleKey(pattern string) string {
+	if !s3Key.hasConditions() {
+		return pattern
+	}
+	values := make(url.Values)
+	for _, tag := range s3Key.Tags {
+		values.Set("tag:"+tag.Key, tag.Value)
+	}
+	for _, md := range s3Key.Metadata {
+		values.Set("meta:"+strings.ToLower(md.Name), md.Value)
+	}
+	if s3Key.ObjectSizeGreaterThan > 0 {
+		values.Set("size-gt", strconv.FormatInt(s3Key.ObjectSizeGreaterThan, 10))
+	}
+	if s3Key.ObjectSizeLessThan > 0 {
+		values.Set("size-lt", strconv.FormatInt(s3Key.ObjectSizeLessThan, 10))
+	}
+	if s3Key.StorageClass != "" {
+		values.Set("storage-class", s3Key.StorageClass)
+	}
+	return pattern + ruleConditionsSep + values.Encode()
+}
+
+// Parsed conditions of rule keys, rule keys are immutable.
+var parsedConditions sync.Map
+
+// splitRuleKey - returns the name pattern and conditions of a rule key.
+func splitRuleKey(key string) (pattern string, cond *conditions) {
+	i := strings.Index(key, ruleConditionsSep)
+	if i < 0 {
+		return key, nil
+	}
+	pattern = key[:i]
+	if v, ok := parsedConditions.Load(key); ok {
+		return pattern, v.(*conditions)
+	}
+
+	cond = &conditions{}
+	values, _ := url.ParseQuery(key[i+len(ruleConditionsSep):])
+	for k, vs := range values {
+		v := vs[0]
+		switch {
+		case strings.HasPrefix(k, "tag:"):
+			if cond.tags == nil {
+				cond.tags = make(map[string]string)
+			}
+			cond.tags[strings.TrimPrefix(k, "tag:")] = v
+		case strings.HasPrefix(k, "meta:"):
+			if cond.metadata == nil {
+				cond.metadata = make(map[string]string)
+			}
+			cond.metadata[strings.TrimPrefix(k, "meta:")] = v
+		case k == "size-gt":
+			cond.sizeGT, _ = strconv.ParseInt(v, 10, 64)
+		case k == "size-lt":
+			cond.sizeLT, _ = strconv.ParseInt(v, 10, 64)
+		case k == "storage-class":
+			cond.storageClass = v
+		}
+	}
+	parsedConditions.Store(key, cond)
+	return pattern, cond
+}
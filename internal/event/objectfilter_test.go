@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestQueueObjectConditions(t *testing.T) {
+	data := []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+   <Filter>
+      <S3Key>
+         <FilterRule>
+            <Name>prefix</Name>
+            <Value>images/</Value>
+         </FilterRule>
+      </S3Key>
+      <Tag>
+         <Key>project</Key>
+         <Value>apollo</Value>
+      </Tag>
+      <Metadata>
+         <Name>X-Amz-Meta-Origin</Name>
+         <Value>camera</Value>
+      </Metadata>
+      <ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+      <StorageClass>STANDARD</StorageClass>
+   </Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:ObjectCreated:Put</Event>
+</QueueConfiguration>`)
+
+	var q Queue
+	if err := xml.Unmarshal(data, &q); err != nil {
+		t.Fatal(err)
+	}
+	rulesMap := q.ToRulesMap()
+	targetID := TargetID{"1", "webhook"}
+	match := func(attrs ObjectAttributes) bool {
+		_, ok := rulesMap.MatchObject(ObjectCreatedPut, "images/a.jpg", attrs)[targetID]
+		return ok
+	}
+
+	attrs := ObjectAttributes{
+		Size:         2048,
+		Tags:         map[string]string{"project": "apollo", "team": "a"},
+		UserMetadata: map[string]string{"x-amz-meta-origin": "camera"},
+	}
+	if !match(attrs) {
+		t.Error("expected object with all attributes to match")
+	}
+	if _, ok := rulesMap.MatchObject(ObjectCreatedPut, "docs/a.jpg", attrs)[targetID]; ok {
+		t.Error("expected object outside of prefix not to match")
+	}
+
+	testCases := []ObjectAttributes{
+		{Size: 512, Tags: attrs.Tags, UserMetadata: attrs.UserMetadata},
+		{Size: 2048, Tags: map[string]string{"project": "gemini"}, UserMetadata: attrs.UserMetadata},
+		{Size: 2048, Tags: attrs.Tags},
+		{Size: 2048, Tags: attrs.Tags, UserMetadata: attrs.UserMetadata, StorageClass: "REDUCED_REDUNDANCY"},
+	}
+	for i, testCase := range testCases {
+		if match(testCase) {
+			t.Errorf("test %v: expected no match", i+1)
+		}
+	}
+
+	// Conditions are not evaluated without object attributes.
+	if !rulesMap.MatchSimple(ObjectCreatedPut, "images/a.jpg") {
+		t.Error("expected the name pattern to match")
+	}
+
+	output, err := xml.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var q2 Queue
+	if err = xml.Unmarshal(output, &q2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(q, q2) {
+		t.Fatalf("expected %v, got %v", q, q2)
+	}
+}
+
+func TestQueueObjectConditionsInvalid(t *testing.T) {
+	testCases := []string{
+		`<Tag><Key></Key><Value>x</Value></Tag>`,
+		`<Metadata><Name>origin</Name><Value>camera</Value></Metadata>`,
+		`<ObjectSizeGreaterThan>100</ObjectSizeGreaterThan><ObjectSizeLessThan>10</ObjectSizeLessThan>`,
+		`<Tag><Key>a</Key><Value>x</Value></Tag><Tag><Key>a</Key><Value>y</Value></Tag>`,
+	}
+	for i, testCase := range testCases {
+		data := []byte(`<QueueConfiguration><Id>1</Id><Filter>` + testCase + `</Filter>` +
+			`<Queue>arn:minio:sqs:us-east-1:1:webhook</Queue><Event>s3:ObjectCreated:Put</Event></QueueConfiguration>`)
+		var q Queue
+		if err := xml.Unmarshal(data, &q); err == nil {
+			t.Errorf("test %v: expected an error", i+1)
+		} else if _, ok := err.(*ErrInvalidFilterCondition); !ok {
+			t.Errorf("test %v: unexpected error %v", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"sort"
+	"sync"
+)
+
+// OrderedTarget - is implemented by targets which can be configured to
+// receive the events of an object in the order they were generated.
+type OrderedTarget interface {
+	Target
+	Ordered() bool
+}
+
+// orderedQueue serializes saving events of the same object to a
+// target, events of different objects are saved concurrently.
+type orderedQueue struct {
+	sync.Mutex
+	// Events waiting to be saved per object, sorted by sequencer.
+	// An object is present while its events are being saved.
+	pending map[string][]Event
+	// Done for every saved event.
+	inflight *sync.WaitGroup
+	// Released for every saved event.
+	slots chan struct{}
+}
+
+func newOrderedQueue(inflight *sync.WaitGroup, slots chan struct{}) *orderedQueue {
+	return &orderedQueue{pending: make(map[string][]Event), inflight: inflight, slots: slots}
+}
+
+// send - queues the event to be saved to the target after all earlier
+// events of the same object. Events which are queued out of order, for
+// example because they were generated concurrently, are re-ordered by
+// their sequencer as long as they are still pending.
+func (q *orderedQueue) send(target Target, event Event, resCh chan<- TargetIDResult) {
+	key := event.S3.Bucket.Name + "/" + event.S3.Object.Key
+
+	q.Lock()
+	events, running := q.pending[key]
+	i := sort.Search(len(events), func(i int) bool {
+		return events[i].S3.Object.Sequencer > event.S3.Object.Sequencer
+	})
+	events = append(events, Event{})
+	copy(events[i+1:], events[i:])
+	events[i] = event
+	q.pending[key] = events
+	q.Unlock()
+
+	if !running {
+		go q.drain(target, key, resCh)
+	}
+}
+
+// drain - saves the pending events of the object one after another.
+func (q *orderedQueue) drain(target Target, key string, resCh chan<- TargetIDResult) {
+	for {
+		q.Lock()
+		events := q.pending[key]
+		if len(events) == 0 {
+			delete(q.pending, key)
+			q.Unlock()
+			return
+		}
+		event := events[0]
+		q.pending[key] = events[1:]
+		q.Unlock()
+
+		tgtRes := TargetIDResult{ID: target.ID()}
+		if err := saveEvent(target, event); err != nil {
+			tgtRes.Err = err
+			tgtRes.Event = &event
+		}
+		resCh <- tgtRes
+		q.inflight.Done()
+		<-q.slots
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// orderedExampleTarget - records the events saved per object, the first
+// save blocks until released.
+type orderedExampleTarget struct {
+	ExampleTarget
+	mu      sync.Mutex
+	saved   []string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (target *orderedExampleTarget) Ordered() bool {
+	return true
+}
+
+func (target *orderedExampleTarget) Save(eventData Event) error {
+	target.mu.Lock()
+	first := len(target.saved) == 0
+	target.saved = append(target.saved, eventData.S3.Object.Sequencer)
+	target.mu.Unlock()
+	if first {
+		close(target.started)
+		<-target.release
+	}
+	return nil
+}
+
+func TestTargetListSendOrdered(t *testing.T) {
+	target := &orderedExampleTarget{
+		ExampleTarget: ExampleTarget{id: TargetID{"1", "ordered"}},
+		started:       make(chan struct{}),
+		release:       make(chan struct{}),
+	}
+	targetList := NewTargetList()
+	if err := targetList.Add(target); err != nil {
+		t.Fatal(err)
+	}
+
+	newEvent := func(sequencer string) Event {
+		var ev Event
+		ev.S3.Bucket.Name = "bucket"
+		ev.S3.Object.Key = "object"
+		ev.S3.Object.Sequencer = sequencer
+		return ev
+	}
+
+	resCh := make(chan TargetIDResult, 4)
+	targetIDSet := NewTargetIDSet(target.ID())
+	targetList.Send(newEvent("0000000000000001"), targetIDSet, resCh)
+	<-target.started
+
+	// Pending events are saved in sequencer order.
+	targetList.Send(newEvent("0000000000000004"), targetIDSet, resCh)
+	targetList.Send(newEvent("0000000000000002"), targetIDSet, resCh)
+	targetList.Send(newEvent("0000000000000003"), targetIDSet, resCh)
+	close(target.release)
+
+	for i := 0; i < 4; i++ {
+		if res := <-resCh; res.Err != nil {
+			t.Fatal(res.Err)
+		}
+	}
+
+	expected := []string{"0000000000000001", "0000000000000002", "0000000000000003", "0000000000000004"}
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	if !reflect.DeepEqual(target.saved, expected) {
+		t.Fatalf("expected %v, got %v", expected, target.saved)
+	}
+}
@@ -46,7 +46,8 @@ func NewPattern(prefix, suffix string) (pattern string) {
 	return pattern
 }
 
-// Rules - event rules
+// Rules - event rules, keyed by name pattern and object attribute
+// conditions if any.
 type Rules map[string]TargetIDSet
 
 // Add - adds pattern and target ID.
@@ -55,21 +56,23 @@ func (rules Rules) Add(pattern string, targetID TargetID) {
 }
 
 // MatchSimple - returns true one of the matching object name in rules.
+// Object attribute conditions are not evaluated.
 func (rules Rules) MatchSimple(objectName string) bool {
-	for pattern := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for key := range rules {
+		if pattern, _ := splitRuleKey(key); wildcard.MatchSimple(pattern, objectName) {
 			return true
 		}
 	}
 	return false
 }
 
-// Match - returns TargetIDSet matching object name in rules.
+// Match - returns TargetIDSet matching object name in rules. Object
+// attribute conditions are not evaluated.
 func (rules Rules) Match(objectName string) TargetIDSet {
 	targetIDs := NewTargetIDSet()
 
-	for pattern, targetIDSet := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for key, targetIDSet := range rules {
+		if pattern, _ := splitRuleKey(key); wildcard.MatchSimple(pattern, objectName) {
 			targetIDs = targetIDs.Union(targetIDSet)
 		}
 	}
@@ -77,6 +80,25 @@ func (rules Rules) Match(objectName string) TargetIDSet {
 	return targetIDs
 }
 
+// MatchObject - returns TargetIDSet matching object name and object
+// attributes in rules.
+func (rules Rules) MatchObject(objectName string, attrs ObjectAttributes) TargetIDSet {
+	targetIDs := NewTargetIDSet()
+
+	for key, targetIDSet := range rules {
+		pattern, cond := splitRuleKey(key)
+		if !wildcard.MatchSimple(pattern, objectName) {
+			continue
+		}
+		if cond != nil && !cond.match(attrs) {
+			continue
+		}
+		targetIDs = targetIDs.Union(targetIDSet)
+	}
+
+	return targetIDs
+}
+
 // Clone - returns copy of this rules.
 func (rules Rules) Clone() Rules {
 	rulesCopy := make(Rules)
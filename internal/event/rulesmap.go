@@ -71,6 +71,12 @@ func (rulesMap RulesMap) Match(eventName Name, objectName string) TargetIDSet {
 	return rulesMap[eventName].Match(objectName)
 }
 
+// MatchObject - returns TargetIDSet matching event name, object name and
+// object attributes in rules map.
+func (rulesMap RulesMap) MatchObject(eventName Name, objectName string, attrs ObjectAttributes) TargetIDSet {
+	return rulesMap[eventName].MatchObject(objectName, attrs)
+}
+
 // NewRulesMap - creates new rules map with given values.
 func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap {
 	// If pattern is empty, add '*' wildcard to match all.
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+// Event schema versions, the schema version of an event is its
+// eventVersion. Every version only adds to the previous one, so that
+// events can be converted to all earlier versions.
+const (
+	// SchemaVersion20 - fields of the S3 event record.
+	SchemaVersion20 = "2.0"
+
+	// SchemaVersion21 - adds glacierEventData to ObjectRestore:Completed
+	// events and iam to IAM change events.
+	SchemaVersion21 = "2.1"
+
+	// LatestSchemaVersion - schema version of generated events.
+	LatestSchemaVersion = SchemaVersion21
+)
+
+// SchemaVersions - all supported event schema versions, oldest first.
+var SchemaVersions = []string{SchemaVersion20, SchemaVersion21}
+
+// SchemaTarget - is implemented by targets which can be pinned to an
+// event schema version.
+type SchemaTarget interface {
+	Target
+	// SchemaVersion returns the pinned schema version, empty for
+	// the latest version.
+	SchemaVersion() string
+}
+
+// ValidateSchemaVersion - returns an error if the schema version is not
+// supported, an empty version is the latest version.
+func ValidateSchemaVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	for _, v := range SchemaVersions {
+		if v == version {
+			return nil
+		}
+	}
+	return &ErrUnsupportedSchemaVersion{version}
+}
+
+// ToSchemaVersion - returns the event converted to the schema version,
+// fields added by later versions are removed. Events are returned as is
+// for an empty or unsupported version.
+func (e Event) ToSchemaVersion(version string) Event {
+	if version == "" || version == e.EventVersion || ValidateSchemaVersion(version) != nil {
+		return e
+	}
+	if version == SchemaVersion20 {
+		e.GlacierEventData = nil
+		e.IAM = nil
+	}
+	e.EventVersion = version
+	return e
+}
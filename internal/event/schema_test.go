@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import "testing"
+
+func TestEventToSchemaVersion(t *testing.T) {
+	e := Event{
+		EventVersion:     LatestSchemaVersion,
+		EventName:        ObjectRestorePostCompleted,
+		GlacierEventData: &GlacierEventData{},
+		IAM:              &IAMMetadata{Type: "user"},
+	}
+
+	testCases := []struct {
+		version         string
+		expectedVersion string
+		expectNewFields bool
+	}{
+		{"", LatestSchemaVersion, true},
+		{SchemaVersion21, SchemaVersion21, true},
+		{SchemaVersion20, SchemaVersion20, false},
+		{"3.0", LatestSchemaVersion, true},
+	}
+
+	for i, testCase := range testCases {
+		got := e.ToSchemaVersion(testCase.version)
+		if got.EventVersion != testCase.expectedVersion {
+			t.Errorf("test %d: expected version %s, got %s", i+1, testCase.expectedVersion, got.EventVersion)
+		}
+		if hasNewFields := got.GlacierEventData != nil && got.IAM != nil; hasNewFields != testCase.expectNewFields {
+			t.Errorf("test %d: expected fields of later versions %v, got %v", i+1, testCase.expectNewFields, hasNewFields)
+		}
+	}
+	if e.IAM == nil || e.EventVersion != LatestSchemaVersion {
+		t.Fatal("converting must not modify the event")
+	}
+}
+
+func TestValidateSchemaVersion(t *testing.T) {
+	for _, version := range append([]string{""}, SchemaVersions...) {
+		if err := ValidateSchemaVersion(version); err != nil {
+			t.Errorf("expected %q to be valid, got %v", version, err)
+		}
+	}
+	if err := ValidateSchemaVersion("1.0"); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+}
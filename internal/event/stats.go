@@ -0,0 +1,206 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Delivery error classes.
+const (
+	DeliveryErrTimeout    = "timeout"
+	DeliveryErrConnection = "connection"
+	DeliveryErrStoreFull  = "store_full"
+	DeliveryErrExpired    = "expired"
+	DeliveryErrOther      = "other"
+)
+
+// BacklogTarget - optional interface of targets which persist events
+// in a store before delivering them.
+type BacklogTarget interface {
+	// Backlog returns the number of undelivered events in the store
+	// and the time the oldest of them was queued.
+	Backlog() (int, time.Time, error)
+}
+
+// deliveryLatencyBucketLen must be the length of deliveryLatencyIntervals
+const deliveryLatencyBucketLen = 7
+
+// deliveryLatencyIntervals are the upper bounds of the delivery latency
+// histogram intervals. Latency is the time from queuing an event until
+// the target accepted it.
+var deliveryLatencyIntervals = [deliveryLatencyBucketLen]struct {
+	name string
+	end  time.Duration
+}{
+	{"LESS_THAN_10_MS", 10 * time.Millisecond},
+	{"BETWEEN_10_MS_AND_100_MS", 100 * time.Millisecond},
+	{"BETWEEN_100_MS_AND_1_SEC", time.Second},
+	{"BETWEEN_1_SEC_AND_10_SEC", 10 * time.Second},
+	{"BETWEEN_10_SEC_AND_1_MIN", time.Minute},
+	{"BETWEEN_1_MIN_AND_1_HOUR", time.Hour},
+	{"GREATER_THAN_1_HOUR", math.MaxInt64},
+}
+
+// TargetStat - delivery statistics of a notification target since
+// server start.
+type TargetStat struct {
+	ID              TargetID          `json:"id"`
+	ARN             string            `json:"arn"`
+	QueuedEvents    uint64            `json:"queuedEvents"`
+	DeliveredEvents uint64            `json:"deliveredEvents"`
+	FailedEvents    map[string]uint64 `json:"failedEvents"`
+	DeliveryLatency map[string]uint64 `json:"deliveryLatency"`
+	// Backlog is the number of undelivered events in the store of
+	// the target, OldestUndelivered is zero if there are none.
+	Backlog           int       `json:"backlog"`
+	OldestUndelivered time.Time `json:"oldestUndelivered"`
+	BacklogError      string    `json:"backlogError,omitempty"`
+}
+
+type targetStats struct {
+	sync.Mutex
+	queued    uint64
+	delivered uint64
+	failed    map[string]uint64
+	latency   [deliveryLatencyBucketLen]uint64
+}
+
+// Statistics are kept by target ID, so that they survive reloading
+// the targets of the configuration.
+var deliveryStats = struct {
+	sync.Mutex
+	targets map[TargetID]*targetStats
+}{targets: make(map[TargetID]*targetStats)}
+
+func getTargetStats(id TargetID) *targetStats {
+	deliveryStats.Lock()
+	defer deliveryStats.Unlock()
+	stats, ok := deliveryStats.targets[id]
+	if !ok {
+		stats = &targetStats{failed: make(map[string]uint64)}
+		deliveryStats.targets[id] = stats
+	}
+	return stats
+}
+
+// RecordDelivery - records the outcome of an attempt to deliver an
+// event to the target. Latency is ignored for failed attempts.
+func RecordDelivery(id TargetID, latency time.Duration, err error) {
+	stats := getTargetStats(id)
+	stats.Lock()
+	defer stats.Unlock()
+	if err != nil {
+		stats.failed[DeliveryErrorClass(err)]++
+		return
+	}
+	stats.delivered++
+	for i, interval := range deliveryLatencyIntervals {
+		if latency < interval.end {
+			stats.latency[i]++
+			return
+		}
+	}
+}
+
+func recordQueued(id TargetID) {
+	stats := getTargetStats(id)
+	stats.Lock()
+	stats.queued++
+	stats.Unlock()
+}
+
+// DeliveryErrorClass - returns the class of an error returned by a
+// target. Errors may implement DeliveryErrorClass() to set their class.
+func DeliveryErrorClass(err error) string {
+	var classified interface{ DeliveryErrorClass() string }
+	if errors.As(err, &classified) {
+		return classified.DeliveryErrorClass()
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeliveryErrTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return DeliveryErrTimeout
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET):
+		return DeliveryErrConnection
+	case netErr != nil:
+		return DeliveryErrConnection
+	}
+	return DeliveryErrOther
+}
+
+// saveEvent - saves the event in the schema version of the target and
+// records the outcome.
+// Targets with a store only queue the event, their deliveries are
+// recorded once the event is sent from the store.
+func saveEvent(target Target, event Event) error {
+	if t, ok := target.(SchemaTarget); ok {
+		event = event.ToSchemaVersion(t.SchemaVersion())
+	}
+	recordQueued(target.ID())
+	start := time.Now()
+	err := target.Save(event)
+	if err != nil || !target.HasQueueStore() {
+		RecordDelivery(target.ID(), time.Since(start), err)
+	}
+	return err
+}
+
+// Stats - returns the delivery statistics of all targets in the list.
+func (list *TargetList) Stats(region string) []TargetStat {
+	targets := list.Targets()
+	stats := make([]TargetStat, 0, len(targets))
+	for _, target := range targets {
+		ts := getTargetStats(target.ID())
+		ts.Lock()
+		stat := TargetStat{
+			ID:              target.ID(),
+			ARN:             target.ID().ToARN(region).String(),
+			QueuedEvents:    ts.queued,
+			DeliveredEvents: ts.delivered,
+			FailedEvents:    make(map[string]uint64, len(ts.failed)),
+			DeliveryLatency: make(map[string]uint64, len(ts.latency)),
+		}
+		for class, n := range ts.failed {
+			stat.FailedEvents[class] = n
+		}
+		for i, n := range ts.latency {
+			stat.DeliveryLatency[deliveryLatencyIntervals[i].name] = n
+		}
+		ts.Unlock()
+
+		if t, ok := target.(BacklogTarget); ok {
+			backlog, oldest, err := t.Backlog()
+			if err != nil {
+				stat.BacklogError = err.Error()
+			}
+			stat.Backlog, stat.OldestUndelivered = backlog, oldest
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
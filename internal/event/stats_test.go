@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type classifiedError struct{}
+
+func (classifiedError) Error() string              { return "classified" }
+func (classifiedError) DeliveryErrorClass() string { return DeliveryErrStoreFull }
+
+func TestDeliveryErrorClass(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{context.DeadlineExceeded, DeliveryErrTimeout},
+		{fmt.Errorf("publish: %w", context.DeadlineExceeded), DeliveryErrTimeout},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, DeliveryErrConnection},
+		{syscall.ECONNRESET, DeliveryErrConnection},
+		{fmt.Errorf("put: %w", classifiedError{}), DeliveryErrStoreFull},
+		{errors.New("bad request"), DeliveryErrOther},
+	}
+
+	for i, testCase := range testCases {
+		if class := DeliveryErrorClass(testCase.err); class != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, class)
+		}
+	}
+}
+
+func TestTargetListStats(t *testing.T) {
+	targetList := NewTargetList()
+	ok := &ExampleTarget{TargetID{"stats-ok", "testcase"}, false, false}
+	failing := &ExampleTarget{TargetID{"stats-failing", "testcase"}, true, false}
+	if err := targetList.Add(ok, failing); err != nil {
+		t.Fatal(err)
+	}
+
+	resCh := make(chan TargetIDResult)
+	targetList.Send(Event{}, NewTargetIDSet(ok.ID(), failing.ID()), resCh)
+	<-resCh
+	<-resCh
+
+	RecordDelivery(ok.ID(), 2*time.Hour, nil)
+
+	stats := map[TargetID]TargetStat{}
+	for _, stat := range targetList.Stats("us-east-1") {
+		stats[stat.ID] = stat
+	}
+
+	stat := stats[ok.ID()]
+	if stat.ARN != "arn:minio:sqs:us-east-1:stats-ok:testcase" {
+		t.Errorf("unexpected ARN %s", stat.ARN)
+	}
+	if stat.QueuedEvents != 1 || stat.DeliveredEvents != 2 || len(stat.FailedEvents) != 0 {
+		t.Errorf("unexpected stats %+v", stat)
+	}
+	if n := stat.DeliveryLatency["GREATER_THAN_1_HOUR"]; n != 1 {
+		t.Errorf("expected 1 delivery with a latency of more than an hour, got %d", n)
+	}
+
+	stat = stats[failing.ID()]
+	if stat.QueuedEvents != 1 || stat.DeliveredEvents != 0 || stat.FailedEvents[DeliveryErrOther] != 1 {
+		t.Errorf("unexpected stats %+v", stat)
+	}
+}
@@ -19,14 +19,13 @@ package target
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
-	"net/url"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/minio/minio/internal/event"
 	xnet "github.com/minio/pkg/net"
@@ -35,29 +34,39 @@ import (
 
 // AMQPArgs - AMQP target arguments.
 type AMQPArgs struct {
-	Enable            bool     `json:"enable"`
-	URL               xnet.URL `json:"url"`
-	Exchange          string   `json:"exchange"`
-	RoutingKey        string   `json:"routingKey"`
-	ExchangeType      string   `json:"exchangeType"`
-	DeliveryMode      uint8    `json:"deliveryMode"`
-	Mandatory         bool     `json:"mandatory"`
-	Immediate         bool     `json:"immediate"`
-	Durable           bool     `json:"durable"`
-	Internal          bool     `json:"internal"`
-	NoWait            bool     `json:"noWait"`
-	AutoDeleted       bool     `json:"autoDeleted"`
-	PublisherConfirms bool     `json:"publisherConfirms"`
-	QueueDir          string   `json:"queueDir"`
-	QueueLimit        uint64   `json:"queueLimit"`
+	Enable            bool          `json:"enable"`
+	URL               xnet.URL      `json:"url"`
+	Exchange          string        `json:"exchange"`
+	RoutingKey        string        `json:"routingKey"`
+	ExchangeType      string        `json:"exchangeType"`
+	DeliveryMode      uint8         `json:"deliveryMode"`
+	Mandatory         bool          `json:"mandatory"`
+	Immediate         bool          `json:"immediate"`
+	Durable           bool          `json:"durable"`
+	Internal          bool          `json:"internal"`
+	NoWait            bool          `json:"noWait"`
+	AutoDeleted       bool          `json:"autoDeleted"`
+	PublisherConfirms bool          `json:"publisherConfirms"`
+	QueueDir          string        `json:"queueDir"`
+	QueueLimit        uint64        `json:"queueLimit"`
+	OrderedDelivery   bool          `json:"orderedDelivery"`
+	QueueMaxAge       time.Duration `json:"queueMaxAge"`
+	QueueOverflow     string        `json:"queueOverflow"`
+	SchemaVersion     string        `json:"schemaVersion"`
+	EventFormat       string        `json:"eventFormat"`
 }
 
 //lint:file-ignore ST1003 We cannot change these exported names.
 
 // AMQP input constants.
 const (
-	AmqpQueueDir   = "queue_dir"
-	AmqpQueueLimit = "queue_limit"
+	AmqpQueueDir        = "queue_dir"
+	AmqpQueueLimit      = "queue_limit"
+	AmqpOrderedDelivery = "ordered_delivery"
+	AmqpQueueMaxAge     = "queue_max_age"
+	AmqpQueueOverflow   = "queue_overflow"
+	AmqpSchemaVersion   = "schema_version"
+	AmqpEventFormat     = "event_format"
 
 	AmqpURL               = "url"
 	AmqpExchange          = "exchange"
@@ -89,6 +98,11 @@ const (
 	EnvAMQPPublisherConfirms = "MINIO_NOTIFY_AMQP_PUBLISHING_CONFIRMS"
 	EnvAMQPQueueDir          = "MINIO_NOTIFY_AMQP_QUEUE_DIR"
 	EnvAMQPQueueLimit        = "MINIO_NOTIFY_AMQP_QUEUE_LIMIT"
+	EnvAMQPOrderedDelivery   = "MINIO_NOTIFY_AMQP_ORDERED_DELIVERY"
+	EnvAMQPQueueMaxAge       = "MINIO_NOTIFY_AMQP_QUEUE_MAX_AGE"
+	EnvAMQPQueueOverflow     = "MINIO_NOTIFY_AMQP_QUEUE_OVERFLOW"
+	EnvAMQPSchemaVersion     = "MINIO_NOTIFY_AMQP_SCHEMA_VERSION"
+	EnvAMQPEventFormat       = "MINIO_NOTIFY_AMQP_EVENT_FORMAT"
 )
 
 // Validate AMQP arguments
@@ -99,6 +113,15 @@ func (a *AMQPArgs) Validate() error {
 	if _, err := amqp.ParseURI(a.URL.String()); err != nil {
 		return err
 	}
+	if err := validateEventFormat(a.EventFormat); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(a.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(a.SchemaVersion); err != nil {
+		return err
+	}
 	if a.QueueDir != "" {
 		if !filepath.IsAbs(a.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -116,6 +139,7 @@ type AMQPTarget struct {
 	connMutex  sync.Mutex
 	store      Store
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns TargetID.
@@ -140,6 +164,22 @@ func (target *AMQPTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *AMQPTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *AMQPTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *AMQPTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 func (target *AMQPTarget) channel() (*amqp.Channel, chan amqp.Confirmation, error) {
 	var err error
 	var conn *amqp.Connection
@@ -211,13 +251,7 @@ func (target *AMQPTarget) channel() (*amqp.Channel, chan amqp.Confirmation, erro
 
 // send - sends an event to the AMQP.
 func (target *AMQPTarget) send(eventData event.Event, ch *amqp.Channel, confirms chan amqp.Confirmation) error {
-	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
-	if err != nil {
-		return err
-	}
-	key := eventData.S3.Bucket.Name + "/" + objectName
-
-	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	_, data, err := encodeEvent(target.args.EventFormat, eventData)
 	if err != nil {
 		return err
 	}
@@ -270,6 +304,20 @@ func (target *AMQPTarget) Save(eventData event.Event) error {
 	return target.send(eventData, ch, confirms)
 }
 
+// SendTest - sends the test event to the AMQP exchange, bypassing the queue store.
+func (target *AMQPTarget) SendTest(eventData event.Event) error {
+	ch, confirms, err := target.channel()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cErr := ch.Close()
+		target.loggerOnce(context.Background(), cErr, target.ID())
+	}()
+
+	return target.send(eventData, ch, confirms)
+}
+
 // Send - sends event to AMQP.
 func (target *AMQPTarget) Send(eventKey string) error {
 	ch, confirms, err := target.channel()
@@ -301,6 +349,7 @@ func (target *AMQPTarget) Send(eventKey string) error {
 
 // Close - does nothing and available for interface compatibility.
 func (target *AMQPTarget) Close() error {
+	target.quit.close()
 	if target.conn != nil {
 		return target.conn.Close()
 	}
@@ -316,13 +365,14 @@ func NewAMQPTarget(id string, args AMQPArgs, doneCh <-chan struct{}, loggerOnce
 
 	target := &AMQPTarget{
 		id:         event.TargetID{ID: id, Name: "amqp"},
+		quit:       newQuitCh(),
 		args:       args,
 		loggerOnce: loggerOnce,
 	}
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-amqp-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if oErr := store.Open(); oErr != nil {
 			target.loggerOnce(context.Background(), oErr, target.ID())
 			return target, oErr
@@ -341,10 +391,11 @@ func NewAMQPTarget(id string, args AMQPArgs, doneCh <-chan struct{}, loggerOnce
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -0,0 +1,171 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+)
+
+// BatchArgs - limits of the batches in which a target delivers events.
+type BatchArgs struct {
+	// Maximum number of events per batch, batching is
+	// disabled unless it is larger than one.
+	MaxCount int `json:"maxCount"`
+	// Maximum size of the JSON encoded events of a batch,
+	// zero means unlimited.
+	MaxBytes int `json:"maxBytes"`
+	// Maximum time an event waits for its batch to fill up.
+	MaxLatency time.Duration `json:"maxLatency"`
+}
+
+// Enabled - returns true if events are delivered in batches.
+func (b BatchArgs) Enabled() bool {
+	return b.MaxCount > 1
+}
+
+// Validate BatchArgs fields
+func (b BatchArgs) Validate() error {
+	if b.MaxCount < 0 || b.MaxBytes < 0 {
+		return errors.New("batch limits must not be negative")
+	}
+	if b.Enabled() && b.MaxLatency <= 0 {
+		return errors.New("batch_max_latency must be set when batching events")
+	}
+	return nil
+}
+
+// batch collects events and delivers them together once the
+// batch is full or its oldest event waited for the maximum
+// latency. Events replayed from a queue store stay in the store
+// until their batch has been delivered.
+type batch struct {
+	sync.Mutex
+	args    BatchArgs
+	store   Store
+	sendFn  func([]event.Event) error
+	events  []event.Event
+	keys    map[string]struct{}
+	size    int
+	timer   *time.Timer
+	logOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	id      event.TargetID
+}
+
+func newBatch(args BatchArgs, store Store, sendFn func([]event.Event) error, loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}), id event.TargetID) *batch {
+	return &batch{
+		args:    args,
+		store:   store,
+		sendFn:  sendFn,
+		keys:    make(map[string]struct{}),
+		logOnce: loggerOnce,
+		id:      id,
+	}
+}
+
+// add adds the event to the batch and delivers the batch if it is
+// full. eventKey is the key of the event in the queue store, if any.
+func (b *batch) add(eventKey string, eventData event.Event) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if _, ok := b.keys[eventKey]; ok && eventKey != "" {
+		// The store has been listed again, so all
+		// pending events are part of this batch.
+		return b.flush()
+	}
+
+	size := 0
+	if b.args.MaxBytes > 0 {
+		data, err := json.Marshal(eventData)
+		if err != nil {
+			return err
+		}
+		size = len(data)
+		if len(b.events) > 0 && b.size+size > b.args.MaxBytes {
+			if err = b.flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	b.events = append(b.events, eventData)
+	if eventKey != "" {
+		b.keys[eventKey] = struct{}{}
+	}
+	b.size += size
+	if len(b.events) == 1 {
+		b.timer = time.AfterFunc(b.args.MaxLatency, b.flushOnTimeout)
+	}
+
+	if len(b.events) >= b.args.MaxCount || b.args.MaxBytes > 0 && b.size >= b.args.MaxBytes {
+		return b.flush()
+	}
+	return nil
+}
+
+func (b *batch) flushOnTimeout() {
+	b.Lock()
+	defer b.Unlock()
+	if err := b.flush(); err != nil && err != errNotConnected {
+		b.logOnce(context.Background(), fmt.Errorf("sending batch of events failed with '%w'", err), b.id)
+	}
+}
+
+// close delivers all pending events.
+func (b *batch) close() error {
+	b.Lock()
+	defer b.Unlock()
+	return b.flush()
+}
+
+// flush delivers the batch. Batches of events from a queue store
+// are kept on failure, since they are retried by the replay.
+func (b *batch) flush() error {
+	if len(b.events) == 0 {
+		return nil
+	}
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	err := b.sendFn(b.events)
+	if err != nil && b.store != nil {
+		return err
+	}
+	if err == nil && b.store != nil {
+		for key := range b.keys {
+			if dErr := b.store.Del(key); dErr != nil && !os.IsNotExist(dErr) {
+				err = dErr
+			}
+		}
+	}
+
+	b.events = nil
+	b.keys = make(map[string]struct{})
+	b.size = 0
+	return err
+}
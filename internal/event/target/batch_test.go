@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+)
+
+type batchRecorder struct {
+	sync.Mutex
+	batches [][]event.Event
+	err     error
+}
+
+func (r *batchRecorder) send(events []event.Event) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.batches = append(r.batches, append([]event.Event(nil), events...))
+	return nil
+}
+
+func (r *batchRecorder) count() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.batches)
+}
+
+func noopLoggerOnce(ctx context.Context, err error, id interface{}, errKind ...interface{}) {}
+
+func TestBatchMaxCount(t *testing.T) {
+	r := &batchRecorder{}
+	b := newBatch(BatchArgs{MaxCount: 3, MaxLatency: time.Hour}, nil, r.send, noopLoggerOnce, event.TargetID{})
+	for i := 0; i < 7; i++ {
+		if err := b.add("", testEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.count() != 2 {
+		t.Fatalf("expected 2 batches, got %d", r.count())
+	}
+	if err := b.close(); err != nil {
+		t.Fatal(err)
+	}
+	if r.count() != 3 || len(r.batches[2]) != 1 {
+		t.Fatalf("expected the pending event to be delivered on close, got %v", r.batches)
+	}
+}
+
+func TestBatchMaxLatency(t *testing.T) {
+	r := &batchRecorder{}
+	b := newBatch(BatchArgs{MaxCount: 100, MaxLatency: 10 * time.Millisecond}, nil, r.send, noopLoggerOnce, event.TargetID{})
+	if err := b.add("", testEvent); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for r.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if r.count() != 1 {
+		t.Fatal("expected the batch to be delivered after max latency")
+	}
+}
+
+func TestBatchMaxBytes(t *testing.T) {
+	r := &batchRecorder{}
+	// Each test event is larger than 100 bytes.
+	b := newBatch(BatchArgs{MaxCount: 100, MaxBytes: 100, MaxLatency: time.Hour}, nil, r.send, noopLoggerOnce, event.TargetID{})
+	for i := 0; i < 3; i++ {
+		if err := b.add("", testEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.count() != 3 {
+		t.Fatalf("expected 3 batches, got %d", r.count())
+	}
+}
+
+func TestBatchQueueStore(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	store, err := setUpStore(queueDir, 100)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = store.Put(testEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &batchRecorder{err: errNotConnected}
+	b := newBatch(BatchArgs{MaxCount: 10, MaxLatency: time.Hour}, store, r.send, noopLoggerOnce, event.TargetID{})
+	for _, name := range names {
+		if err = b.add(strings.TrimSuffix(name, eventExt), testEvent); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Seeing a batched key again delivers the batch.
+	key := strings.TrimSuffix(names[0], eventExt)
+	if err = b.add(key, testEvent); !errors.Is(err, errNotConnected) {
+		t.Fatalf("expected %v, got %v", errNotConnected, err)
+	}
+	if names, _ = store.List(); len(names) != 2 {
+		t.Fatal("failed batches must stay in the store")
+	}
+
+	r.Lock()
+	r.err = nil
+	r.Unlock()
+	if err = b.add(key, testEvent); err != nil {
+		t.Fatal(err)
+	}
+	if r.count() != 1 || len(r.batches[0]) != 2 {
+		t.Fatalf("expected one batch of 2 events, got %v", r.batches)
+	}
+	if names, _ = store.List(); len(names) != 0 {
+		t.Fatalf("expected delivered events to be removed from the store, got %v", names)
+	}
+}
+
+func TestBatchArgsValidate(t *testing.T) {
+	if err := (BatchArgs{MaxCount: 10}).Validate(); err == nil {
+		t.Fatal("expected an error without max latency")
+	}
+	if err := (BatchArgs{MaxCount: -1}).Validate(); err == nil {
+		t.Fatal("expected an error for negative limits")
+	}
+	if err := (BatchArgs{MaxCount: 10, MaxLatency: time.Second}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
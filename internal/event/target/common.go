@@ -17,7 +17,15 @@
 
 package target
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/minio/minio/internal/event"
+)
 
 func getNewUUID() (string, error) {
 	u, err := uuid.NewRandom()
@@ -27,3 +35,54 @@ func getNewUUID() (string, error) {
 
 	return u.String(), nil
 }
+
+// validateEventFormat returns an error if the event format
+// is not supported by message based targets.
+func validateEventFormat(format string) error {
+	switch format {
+	case "", event.S3Format, event.CloudEventsFormat:
+		return nil
+	}
+	return errors.New("unknown event_format, must be 's3' or 'cloudevents'")
+}
+
+// validateQueueOverflow returns an error if the overflow policy
+// of the queue store is not supported.
+func validateQueueOverflow(overflow string) error {
+	switch overflow {
+	case "", QueueOverflowReject, QueueOverflowBlock, QueueOverflowDropOldest:
+		return nil
+	}
+	return errors.New("unknown queue_overflow, must be 'reject', 'block' or 'drop_oldest'")
+}
+
+// encodeEvent returns the key of the object the event refers to and
+// the event encoded in the given format.
+func encodeEvent(format string, eventData event.Event) (key string, data []byte, err error) {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return "", nil, err
+	}
+	key = eventData.S3.Bucket.Name + "/" + objectName
+
+	if format == event.CloudEventsFormat {
+		data, err = json.Marshal(event.NewCloudEvent(eventData))
+	} else {
+		data, err = json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	}
+	return key, data, err
+}
+
+// encodeBatch - encodes a batch of events in the given format. Batches
+// in the S3 format carry all events in Records, batches of CloudEvents
+// are JSON arrays.
+func encodeBatch(format string, events []event.Event) ([]byte, error) {
+	if format == event.CloudEventsFormat {
+		ces := make([]event.CloudEvent, 0, len(events))
+		for _, e := range events {
+			ces = append(ces, event.NewCloudEvent(e))
+		}
+		return json.Marshal(ces)
+	}
+	return json.Marshal(event.Log{Records: events})
+}
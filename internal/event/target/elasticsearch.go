@@ -42,22 +42,30 @@ import (
 
 // Elastic constants
 const (
-	ElasticFormat     = "format"
-	ElasticURL        = "url"
-	ElasticIndex      = "index"
-	ElasticQueueDir   = "queue_dir"
-	ElasticQueueLimit = "queue_limit"
-	ElasticUsername   = "username"
-	ElasticPassword   = "password"
-
-	EnvElasticEnable     = "MINIO_NOTIFY_ELASTICSEARCH_ENABLE"
-	EnvElasticFormat     = "MINIO_NOTIFY_ELASTICSEARCH_FORMAT"
-	EnvElasticURL        = "MINIO_NOTIFY_ELASTICSEARCH_URL"
-	EnvElasticIndex      = "MINIO_NOTIFY_ELASTICSEARCH_INDEX"
-	EnvElasticQueueDir   = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_DIR"
-	EnvElasticQueueLimit = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_LIMIT"
-	EnvElasticUsername   = "MINIO_NOTIFY_ELASTICSEARCH_USERNAME"
-	EnvElasticPassword   = "MINIO_NOTIFY_ELASTICSEARCH_PASSWORD"
+	ElasticFormat          = "format"
+	ElasticURL             = "url"
+	ElasticIndex           = "index"
+	ElasticQueueDir        = "queue_dir"
+	ElasticQueueLimit      = "queue_limit"
+	ElasticOrderedDelivery = "ordered_delivery"
+	ElasticQueueMaxAge     = "queue_max_age"
+	ElasticQueueOverflow   = "queue_overflow"
+	ElasticSchemaVersion   = "schema_version"
+	ElasticUsername        = "username"
+	ElasticPassword        = "password"
+
+	EnvElasticEnable          = "MINIO_NOTIFY_ELASTICSEARCH_ENABLE"
+	EnvElasticFormat          = "MINIO_NOTIFY_ELASTICSEARCH_FORMAT"
+	EnvElasticURL             = "MINIO_NOTIFY_ELASTICSEARCH_URL"
+	EnvElasticIndex           = "MINIO_NOTIFY_ELASTICSEARCH_INDEX"
+	EnvElasticQueueDir        = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_DIR"
+	EnvElasticQueueLimit      = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_LIMIT"
+	EnvElasticOrderedDelivery = "MINIO_NOTIFY_ELASTICSEARCH_ORDERED_DELIVERY"
+	EnvElasticQueueMaxAge     = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_MAX_AGE"
+	EnvElasticQueueOverflow   = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_OVERFLOW"
+	EnvElasticSchemaVersion   = "MINIO_NOTIFY_ELASTICSEARCH_SCHEMA_VERSION"
+	EnvElasticUsername        = "MINIO_NOTIFY_ELASTICSEARCH_USERNAME"
+	EnvElasticPassword        = "MINIO_NOTIFY_ELASTICSEARCH_PASSWORD"
 )
 
 // ESSupportStatus is a typed string representing the support status for
@@ -114,15 +122,19 @@ type esClient interface {
 
 // ElasticsearchArgs - Elasticsearch target arguments.
 type ElasticsearchArgs struct {
-	Enable     bool            `json:"enable"`
-	Format     string          `json:"format"`
-	URL        xnet.URL        `json:"url"`
-	Index      string          `json:"index"`
-	QueueDir   string          `json:"queueDir"`
-	QueueLimit uint64          `json:"queueLimit"`
-	Transport  *http.Transport `json:"-"`
-	Username   string          `json:"username"`
-	Password   string          `json:"password"`
+	Enable          bool            `json:"enable"`
+	Format          string          `json:"format"`
+	URL             xnet.URL        `json:"url"`
+	Index           string          `json:"index"`
+	QueueDir        string          `json:"queueDir"`
+	QueueLimit      uint64          `json:"queueLimit"`
+	OrderedDelivery bool            `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration   `json:"queueMaxAge"`
+	QueueOverflow   string          `json:"queueOverflow"`
+	SchemaVersion   string          `json:"schemaVersion"`
+	Transport       *http.Transport `json:"-"`
+	Username        string          `json:"username"`
+	Password        string          `json:"password"`
 }
 
 // Validate ElasticsearchArgs fields
@@ -147,6 +159,13 @@ func (a ElasticsearchArgs) Validate() error {
 		return errors.New("username and password should be set in pairs")
 	}
 
+	if err := validateQueueOverflow(a.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(a.SchemaVersion); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -157,6 +176,7 @@ type ElasticsearchTarget struct {
 	client     esClient
 	store      Store
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -169,6 +189,22 @@ func (target *ElasticsearchTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *ElasticsearchTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *ElasticsearchTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *ElasticsearchTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *ElasticsearchTarget) IsActive() (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -203,6 +239,22 @@ func (target *ElasticsearchTarget) Save(eventData event.Event) error {
 	return err
 }
 
+// SendTest - sends the test event to the Elasticsearch index, bypassing the queue store.
+func (target *ElasticsearchTarget) SendTest(eventData event.Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := target.checkAndInitClient(ctx); err != nil {
+		return err
+	}
+
+	err := target.send(eventData)
+	if xnet.IsNetworkOrHostDown(err, false) {
+		return errNotConnected
+	}
+	return err
+}
+
 // send - sends the event to the target.
 func (target *ElasticsearchTarget) send(eventData event.Event) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -277,6 +329,7 @@ func (target *ElasticsearchTarget) Send(eventKey string) error {
 
 // Close - does nothing and available for interface compatibility.
 func (target *ElasticsearchTarget) Close() error {
+	target.quit.close()
 	if target.client != nil {
 		// Stops the background processes that the client is running.
 		target.client.stop()
@@ -323,13 +376,14 @@ func (target *ElasticsearchTarget) checkAndInitClient(ctx context.Context) error
 func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*ElasticsearchTarget, error) {
 	target := &ElasticsearchTarget{
 		id:         event.TargetID{ID: id, Name: "elasticsearch"},
+		quit:       newQuitCh(),
 		args:       args,
 		loggerOnce: loggerOnce,
 	}
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-elasticsearch-"+id)
-		target.store = NewQueueStore(queueDir, args.QueueLimit)
+		target.store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if err := target.store.Open(); err != nil {
 			target.loggerOnce(context.Background(), err, target.ID())
 			return target, err
@@ -349,9 +403,10 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan str
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -0,0 +1,543 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/fips"
+	xnet "github.com/minio/pkg/net"
+
+	sarama "github.com/Shopify/sarama"
+)
+
+// Azure Event Hubs constants
+const (
+	EventHubsNamespace        = "namespace"
+	EventHubsEventHub         = "event_hub"
+	EventHubsProtocol         = "protocol"
+	EventHubsConnectionString = "connection_string"
+	EventHubsTenantID         = "tenant_id"
+	EventHubsClientID         = "client_id"
+	EventHubsClientSecret     = "client_secret"
+	EventHubsQueueDir         = "queue_dir"
+	EventHubsQueueLimit       = "queue_limit"
+	EventHubsOrderedDelivery  = "ordered_delivery"
+	EventHubsQueueMaxAge      = "queue_max_age"
+	EventHubsQueueOverflow    = "queue_overflow"
+	EventHubsSchemaVersion    = "schema_version"
+	EventHubsEventFormat      = "event_format"
+
+	EnvEventHubsEnable           = "MINIO_NOTIFY_EVENTHUBS_ENABLE"
+	EnvEventHubsNamespace        = "MINIO_NOTIFY_EVENTHUBS_NAMESPACE"
+	EnvEventHubsEventHub         = "MINIO_NOTIFY_EVENTHUBS_EVENT_HUB"
+	EnvEventHubsProtocol         = "MINIO_NOTIFY_EVENTHUBS_PROTOCOL"
+	EnvEventHubsConnectionString = "MINIO_NOTIFY_EVENTHUBS_CONNECTION_STRING"
+	EnvEventHubsTenantID         = "MINIO_NOTIFY_EVENTHUBS_TENANT_ID"
+	EnvEventHubsClientID         = "MINIO_NOTIFY_EVENTHUBS_CLIENT_ID"
+	EnvEventHubsClientSecret     = "MINIO_NOTIFY_EVENTHUBS_CLIENT_SECRET"
+	EnvEventHubsQueueDir         = "MINIO_NOTIFY_EVENTHUBS_QUEUE_DIR"
+	EnvEventHubsQueueLimit       = "MINIO_NOTIFY_EVENTHUBS_QUEUE_LIMIT"
+	EnvEventHubsOrderedDelivery  = "MINIO_NOTIFY_EVENTHUBS_ORDERED_DELIVERY"
+	EnvEventHubsQueueMaxAge      = "MINIO_NOTIFY_EVENTHUBS_QUEUE_MAX_AGE"
+	EnvEventHubsQueueOverflow    = "MINIO_NOTIFY_EVENTHUBS_QUEUE_OVERFLOW"
+	EnvEventHubsSchemaVersion    = "MINIO_NOTIFY_EVENTHUBS_SCHEMA_VERSION"
+	EnvEventHubsEventFormat      = "MINIO_NOTIFY_EVENTHUBS_EVENT_FORMAT"
+)
+
+// Event Hubs protocols
+const (
+	// EventHubsKafka publishes through the Kafka endpoint
+	// of the namespace. It requires the standard tier or above.
+	EventHubsKafka = "kafka"
+
+	// EventHubsHTTPS publishes through the REST API.
+	EventHubsHTTPS = "https"
+)
+
+const (
+	eventHubsKafkaPort = "9093"
+	eventHubsScope     = "https://eventhubs.azure.net/.default"
+	eventHubsSASTTL    = time.Hour
+)
+
+// EventHubsArgs - Azure Event Hubs target arguments.
+type EventHubsArgs struct {
+	Enable bool `json:"enable"`
+	// Namespace is the fully qualified namespace e.g.
+	// 'mynamespace.servicebus.windows.net'.
+	Namespace string `json:"namespace"`
+	EventHub  string `json:"eventHub"`
+	Protocol  string `json:"protocol"`
+
+	// ConnectionString authenticates with a shared access
+	// signature and is mutually exclusive with the Azure AD
+	// client credentials.
+	ConnectionString string `json:"connectionString"`
+	TenantID         string `json:"tenantID"`
+	ClientID         string `json:"clientID"`
+	ClientSecret     string `json:"clientSecret"`
+
+	QueueDir        string        `json:"queueDir"`
+	QueueLimit      uint64        `json:"queueLimit"`
+	OrderedDelivery bool          `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration `json:"queueMaxAge"`
+	QueueOverflow   string        `json:"queueOverflow"`
+	SchemaVersion   string        `json:"schemaVersion"`
+	EventFormat     string        `json:"eventFormat"`
+
+	RootCAs *x509.CertPool `json:"-"`
+}
+
+// eventHubsSAS is a parsed Event Hubs connection string.
+type eventHubsSAS struct {
+	Namespace string
+	EventHub  string
+	KeyName   string
+	Key       string
+}
+
+// parseEventHubsConnectionString parses connection strings like
+// 'Endpoint=sb://<namespace>/;SharedAccessKeyName=<name>;SharedAccessKey=<key>[;EntityPath=<event hub>]'.
+func parseEventHubsConnectionString(s string) (eventHubsSAS, error) {
+	var sas eventHubsSAS
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		i := strings.Index(part, "=")
+		if i < 0 {
+			return sas, errors.New("invalid connection_string")
+		}
+		key, value := part[:i], part[i+1:]
+		switch strings.ToLower(key) {
+		case "endpoint":
+			u, err := url.Parse(value)
+			if err != nil {
+				return sas, fmt.Errorf("invalid connection_string endpoint: %w", err)
+			}
+			sas.Namespace = u.Host
+		case "sharedaccesskeyname":
+			sas.KeyName = value
+		case "sharedaccesskey":
+			sas.Key = value
+		case "entitypath":
+			sas.EventHub = value
+		}
+	}
+	if sas.Namespace == "" || sas.KeyName == "" || sas.Key == "" {
+		return sas, errors.New("connection_string must contain an Endpoint, SharedAccessKeyName and SharedAccessKey")
+	}
+	return sas, nil
+}
+
+// token returns a shared access signature for the resource.
+func (sas eventHubsSAS) token(resource string, expiry time.Time) string {
+	resource = url.QueryEscape(strings.ToLower(resource))
+	se := strconv.FormatInt(expiry.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(sas.Key))
+	mac.Write([]byte(resource + "\n" + se))
+	sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return "SharedAccessSignature sr=" + resource + "&sig=" + url.QueryEscape(sig) + "&se=" + se + "&skn=" + url.QueryEscape(sas.KeyName)
+}
+
+// Validate EventHubsArgs fields
+func (e EventHubsArgs) Validate() error {
+	if !e.Enable {
+		return nil
+	}
+
+	aad := e.TenantID != "" || e.ClientID != "" || e.ClientSecret != ""
+	switch {
+	case e.ConnectionString != "" && aad:
+		return errors.New("connection_string and Azure AD credentials are mutually exclusive")
+	case e.ConnectionString != "":
+		sas, err := parseEventHubsConnectionString(e.ConnectionString)
+		if err != nil {
+			return err
+		}
+		e.Namespace, e.EventHub = firstNonEmpty(e.Namespace, sas.Namespace), firstNonEmpty(e.EventHub, sas.EventHub)
+	case aad:
+		if e.TenantID == "" || e.ClientID == "" || e.ClientSecret == "" {
+			return errors.New("tenant_id, client_id and client_secret must be specified together")
+		}
+	default:
+		return errors.New("either connection_string or Azure AD credentials must be specified")
+	}
+
+	if e.Namespace == "" {
+		return errors.New("empty namespace")
+	}
+	if _, err := xnet.ParseHost(e.Namespace); err != nil {
+		return err
+	}
+	if e.EventHub == "" {
+		return errors.New("empty event_hub")
+	}
+	switch e.Protocol {
+	case "", EventHubsKafka, EventHubsHTTPS:
+	default:
+		return fmt.Errorf("unknown protocol '%s'", e.Protocol)
+	}
+	if err := validateEventFormat(e.EventFormat); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(e.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(e.SchemaVersion); err != nil {
+		return err
+	}
+	if e.QueueDir != "" {
+		if !filepath.IsAbs(e.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// eventHubsTokenProvider provides Azure AD tokens for the
+// OAUTHBEARER authentication of the Kafka endpoint.
+type eventHubsTokenProvider struct {
+	tokens oauth2.TokenSource
+}
+
+func (p eventHubsTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &sarama.AccessToken{Token: token.AccessToken}, nil
+}
+
+// EventHubsTarget - Azure Event Hubs target.
+type EventHubsTarget struct {
+	id   event.TargetID
+	args EventHubsArgs
+
+	endpoint string             // endpoint of the REST API
+	sas      *eventHubsSAS      // nil if Azure AD is used
+	tokens   oauth2.TokenSource // nil if a connection string is used
+
+	config     *sarama.Config
+	producer   sarama.SyncProducer
+	httpClient *http.Client
+
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
+}
+
+// ID - returns target ID.
+func (target *EventHubsTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *EventHubsTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *EventHubsTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *EventHubsTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *EventHubsTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
+// IsActive - Return true if target is up and active
+func (target *EventHubsTarget) IsActive() (bool, error) {
+	port := "443"
+	if target.args.Protocol == EventHubsKafka {
+		port = eventHubsKafkaPort
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(target.args.Namespace, port), 5*time.Second)
+	if err != nil {
+		return false, errNotConnected
+	}
+	conn.Close()
+	return true, nil
+}
+
+// Save - saves the events to the store which will be replayed when the Event Hubs namespace is reachable.
+func (target *EventHubsTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
+// SendTest - sends the test event to the event hub, bypassing the queue store.
+func (target *EventHubsTarget) SendTest(eventData event.Event) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
+// send - sends an event to the event hub. The object name is used as
+// partition key, so all events of an object end up in the same partition.
+func (target *EventHubsTarget) send(eventData event.Event) error {
+	key, data, err := encodeEvent(target.args.EventFormat, eventData)
+	if err != nil {
+		return err
+	}
+
+	if target.args.Protocol == EventHubsKafka {
+		if target.producer == nil {
+			if err = target.connectKafka(); err != nil {
+				return err
+			}
+		}
+		_, _, err = target.producer.SendMessage(&sarama.ProducerMessage{
+			Topic: target.args.EventHub,
+			Key:   sarama.StringEncoder(key),
+			Value: sarama.ByteEncoder(data),
+		})
+		if err == sarama.ErrLeaderNotAvailable || err != nil && err.Error() == "circuit breaker is open" {
+			return errNotConnected
+		}
+		return err
+	}
+	return target.sendHTTPS(key, data)
+}
+
+func (target *EventHubsTarget) sendHTTPS(key string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resource := target.endpoint + "/" + target.args.EventHub
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, resource+"/messages?timeout=60", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if target.sas != nil {
+		req.Header.Set("Authorization", target.sas.token(resource, time.Now().Add(eventHubsSASTTL)))
+	} else {
+		token, err := target.tokens.Token()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	}
+	brokerProperties, err := json.Marshal(map[string]string{"PartitionKey": key})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("BrokerProperties", string(brokerProperties))
+	req.Header.Set("Content-Type", "application/atom+xml;type=entry;charset=utf-8")
+
+	resp, err := target.httpClient.Do(req)
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("sending event failed with %v", resp.Status)
+	}
+	return nil
+}
+
+// Send - reads an event from store and sends it to Event Hubs.
+func (target *EventHubsTarget) Send(eventKey string) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err = target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes underneath connections to Event Hubs.
+func (target *EventHubsTarget) Close() error {
+	target.quit.close()
+	if target.producer != nil {
+		return target.producer.Close()
+	}
+	if target.httpClient != nil {
+		target.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (target *EventHubsTarget) connectKafka() error {
+	producer, err := sarama.NewSyncProducer([]string{net.JoinHostPort(target.args.Namespace, eventHubsKafkaPort)}, target.config)
+	if err != nil {
+		if err == sarama.ErrOutOfBrokers {
+			return errNotConnected
+		}
+		return err
+	}
+	target.producer = producer
+	return nil
+}
+
+// NewEventHubsTarget - creates new Azure Event Hubs target.
+func NewEventHubsTarget(id string, args EventHubsArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), transport *http.Transport, test bool) (*EventHubsTarget, error) {
+	target := &EventHubsTarget{
+		id:         event.TargetID{ID: id, Name: "eventhubs"},
+		quit:       newQuitCh(),
+		args:       args,
+		loggerOnce: loggerOnce,
+	}
+
+	if args.ConnectionString != "" {
+		sas, err := parseEventHubsConnectionString(args.ConnectionString)
+		if err != nil {
+			return target, err
+		}
+		target.sas = &sas
+		target.args.Namespace = firstNonEmpty(args.Namespace, sas.Namespace)
+		target.args.EventHub = firstNonEmpty(args.EventHub, sas.EventHub)
+	} else {
+		credentials := clientcredentials.Config{
+			ClientID:     args.ClientID,
+			ClientSecret: args.ClientSecret,
+			TokenURL:     "https://login.microsoftonline.com/" + url.PathEscape(args.TenantID) + "/oauth2/v2.0/token",
+			Scopes:       []string{eventHubsScope},
+		}
+		target.tokens = credentials.TokenSource(context.Background())
+	}
+	if target.args.Protocol == "" {
+		target.args.Protocol = EventHubsKafka
+	}
+	target.endpoint = "https://" + target.args.Namespace
+
+	if target.args.Protocol == EventHubsKafka {
+		config := sarama.NewConfig()
+		config.Version = sarama.V1_0_0_0
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = fips.RestrictTLS(&tls.Config{RootCAs: args.RootCAs})
+		config.Net.SASL.Enable = true
+		if target.sas != nil {
+			// The Kafka endpoint accepts the connection
+			// string as SASL PLAIN password.
+			config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+			config.Net.SASL.User = "$ConnectionString"
+			config.Net.SASL.Password = args.ConnectionString
+		} else {
+			config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+			config.Net.SASL.TokenProvider = eventHubsTokenProvider{tokens: target.tokens}
+		}
+		config.Producer.RequiredAcks = sarama.WaitForAll
+		config.Producer.Retry.Max = 10
+		config.Producer.Return.Successes = true
+		target.config = config
+	} else {
+		target.httpClient = &http.Client{Transport: transport}
+	}
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-eventhubs-"+id)
+		store := newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
+		if oErr := store.Open(); oErr != nil {
+			target.loggerOnce(context.Background(), oErr, target.ID())
+			return target, oErr
+		}
+		target.store = store
+	}
+
+	if _, err := target.IsActive(); err != nil {
+		if target.store == nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+	} else if target.args.Protocol == EventHubsKafka {
+		if err = target.connectKafka(); err != nil && (target.store == nil || err != errNotConnected) {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+)
+
+const testEventHubsConnectionString = "Endpoint=sb://myns.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=c2VjcmV0;EntityPath=events"
+
+func TestParseEventHubsConnectionString(t *testing.T) {
+	sas, err := parseEventHubsConnectionString(testEventHubsConnectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := eventHubsSAS{
+		Namespace: "myns.servicebus.windows.net",
+		EventHub:  "events",
+		KeyName:   "RootManageSharedAccessKey",
+		Key:       "c2VjcmV0",
+	}
+	if sas != want {
+		t.Fatalf("got %+v, want %+v", sas, want)
+	}
+
+	for _, s := range []string{"", "Endpoint=sb://myns.servicebus.windows.net/", "invalid"} {
+		if _, err = parseEventHubsConnectionString(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
+
+func TestEventHubsSASToken(t *testing.T) {
+	sas := eventHubsSAS{KeyName: "key", Key: "secret"}
+	token := sas.token("https://myns.servicebus.windows.net/events", time.Unix(1638352800, 0))
+	want := "SharedAccessSignature sr=https%3A%2F%2Fmyns.servicebus.windows.net%2Fevents&sig=" +
+		url.QueryEscape("lsHHv42iZGOmOk2Dpl5VUz2jNe5KdnKNHkDCI7xzsAI=") + "&se=1638352800&skn=key"
+	if token != want {
+		t.Fatalf("got %q, want %q", token, want)
+	}
+}
+
+func TestEventHubsArgsValidate(t *testing.T) {
+	testCases := []struct {
+		args    EventHubsArgs
+		wantErr bool
+	}{
+		{EventHubsArgs{}, false},
+		{EventHubsArgs{Enable: true, ConnectionString: testEventHubsConnectionString}, false},
+		{EventHubsArgs{Enable: true, Namespace: "myns.servicebus.windows.net", EventHub: "events", TenantID: "t", ClientID: "c", ClientSecret: "s"}, false},
+		{EventHubsArgs{Enable: true, Namespace: "myns.servicebus.windows.net", EventHub: "events"}, true},
+		{EventHubsArgs{Enable: true, Namespace: "myns.servicebus.windows.net", EventHub: "events", TenantID: "t"}, true},
+		{EventHubsArgs{Enable: true, ConnectionString: testEventHubsConnectionString, ClientID: "c"}, true},
+		{EventHubsArgs{Enable: true, ConnectionString: testEventHubsConnectionString, Protocol: "amqp"}, true},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.args.Validate(); (err != nil) != testCase.wantErr {
+			t.Errorf("Test %d: got error %v, want error: %v", i+1, err, testCase.wantErr)
+		}
+	}
+}
+
+func TestEventHubsSendHTTPS(t *testing.T) {
+	var (
+		authorization    string
+		brokerProperties map[string]string
+		log              event.Log
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events/messages" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		authorization = r.Header.Get("Authorization")
+		json.Unmarshal([]byte(r.Header.Get("BrokerProperties")), &brokerProperties)
+		data, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(data, &log)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	sas, err := parseEventHubsConnectionString(testEventHubsConnectionString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := &EventHubsTarget{
+		args:       EventHubsArgs{EventHub: "events", Protocol: EventHubsHTTPS},
+		endpoint:   server.URL,
+		sas:        &sas,
+		httpClient: server.Client(),
+	}
+
+	var eventData event.Event
+	eventData.EventName = event.ObjectCreatedPut
+	eventData.S3.Bucket.Name = "bucket"
+	eventData.S3.Object.Key = "photos%2Fimage.png"
+	if err = target.send(eventData); err != nil {
+		t.Fatalf("Failed to send event: %v", err)
+	}
+	if !strings.HasPrefix(authorization, "SharedAccessSignature ") {
+		t.Errorf("Unexpected authorization %q", authorization)
+	}
+	if brokerProperties["PartitionKey"] != "bucket/photos/image.png" {
+		t.Errorf("Unexpected partition key %q", brokerProperties["PartitionKey"])
+	}
+	if log.Key != "bucket/photos/image.png" {
+		t.Errorf("Unexpected event key %q", log.Key)
+	}
+}
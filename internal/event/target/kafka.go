@@ -21,12 +21,11 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"errors"
 	"net"
-	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/minio/minio/internal/event"
 	xnet "github.com/minio/pkg/net"
@@ -37,47 +36,69 @@ import (
 
 // Kafka input constants
 const (
-	KafkaBrokers       = "brokers"
-	KafkaTopic         = "topic"
-	KafkaQueueDir      = "queue_dir"
-	KafkaQueueLimit    = "queue_limit"
-	KafkaTLS           = "tls"
-	KafkaTLSSkipVerify = "tls_skip_verify"
-	KafkaTLSClientAuth = "tls_client_auth"
-	KafkaSASL          = "sasl"
-	KafkaSASLUsername  = "sasl_username"
-	KafkaSASLPassword  = "sasl_password"
-	KafkaSASLMechanism = "sasl_mechanism"
-	KafkaClientTLSCert = "client_tls_cert"
-	KafkaClientTLSKey  = "client_tls_key"
-	KafkaVersion       = "version"
-
-	EnvKafkaEnable        = "MINIO_NOTIFY_KAFKA_ENABLE"
-	EnvKafkaBrokers       = "MINIO_NOTIFY_KAFKA_BROKERS"
-	EnvKafkaTopic         = "MINIO_NOTIFY_KAFKA_TOPIC"
-	EnvKafkaQueueDir      = "MINIO_NOTIFY_KAFKA_QUEUE_DIR"
-	EnvKafkaQueueLimit    = "MINIO_NOTIFY_KAFKA_QUEUE_LIMIT"
-	EnvKafkaTLS           = "MINIO_NOTIFY_KAFKA_TLS"
-	EnvKafkaTLSSkipVerify = "MINIO_NOTIFY_KAFKA_TLS_SKIP_VERIFY"
-	EnvKafkaTLSClientAuth = "MINIO_NOTIFY_KAFKA_TLS_CLIENT_AUTH"
-	EnvKafkaSASLEnable    = "MINIO_NOTIFY_KAFKA_SASL"
-	EnvKafkaSASLUsername  = "MINIO_NOTIFY_KAFKA_SASL_USERNAME"
-	EnvKafkaSASLPassword  = "MINIO_NOTIFY_KAFKA_SASL_PASSWORD"
-	EnvKafkaSASLMechanism = "MINIO_NOTIFY_KAFKA_SASL_MECHANISM"
-	EnvKafkaClientTLSCert = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_CERT"
-	EnvKafkaClientTLSKey  = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_KEY"
-	EnvKafkaVersion       = "MINIO_NOTIFY_KAFKA_VERSION"
+	KafkaBrokers         = "brokers"
+	KafkaTopic           = "topic"
+	KafkaQueueDir        = "queue_dir"
+	KafkaQueueLimit      = "queue_limit"
+	KafkaOrderedDelivery = "ordered_delivery"
+	KafkaQueueMaxAge     = "queue_max_age"
+	KafkaQueueOverflow   = "queue_overflow"
+	KafkaSchemaVersion   = "schema_version"
+	KafkaEventFormat     = "event_format"
+	KafkaTLS             = "tls"
+	KafkaTLSSkipVerify   = "tls_skip_verify"
+	KafkaTLSClientAuth   = "tls_client_auth"
+	KafkaSASL            = "sasl"
+	KafkaSASLUsername    = "sasl_username"
+	KafkaSASLPassword    = "sasl_password"
+	KafkaSASLMechanism   = "sasl_mechanism"
+	KafkaClientTLSCert   = "client_tls_cert"
+	KafkaClientTLSKey    = "client_tls_key"
+	KafkaVersion         = "version"
+	KafkaBatchMaxCount   = "batch_max_count"
+	KafkaBatchMaxBytes   = "batch_max_bytes"
+	KafkaBatchMaxLatency = "batch_max_latency"
+
+	EnvKafkaEnable          = "MINIO_NOTIFY_KAFKA_ENABLE"
+	EnvKafkaBrokers         = "MINIO_NOTIFY_KAFKA_BROKERS"
+	EnvKafkaTopic           = "MINIO_NOTIFY_KAFKA_TOPIC"
+	EnvKafkaQueueDir        = "MINIO_NOTIFY_KAFKA_QUEUE_DIR"
+	EnvKafkaQueueLimit      = "MINIO_NOTIFY_KAFKA_QUEUE_LIMIT"
+	EnvKafkaOrderedDelivery = "MINIO_NOTIFY_KAFKA_ORDERED_DELIVERY"
+	EnvKafkaQueueMaxAge     = "MINIO_NOTIFY_KAFKA_QUEUE_MAX_AGE"
+	EnvKafkaQueueOverflow   = "MINIO_NOTIFY_KAFKA_QUEUE_OVERFLOW"
+	EnvKafkaSchemaVersion   = "MINIO_NOTIFY_KAFKA_SCHEMA_VERSION"
+	EnvKafkaEventFormat     = "MINIO_NOTIFY_KAFKA_EVENT_FORMAT"
+	EnvKafkaTLS             = "MINIO_NOTIFY_KAFKA_TLS"
+	EnvKafkaTLSSkipVerify   = "MINIO_NOTIFY_KAFKA_TLS_SKIP_VERIFY"
+	EnvKafkaTLSClientAuth   = "MINIO_NOTIFY_KAFKA_TLS_CLIENT_AUTH"
+	EnvKafkaSASLEnable      = "MINIO_NOTIFY_KAFKA_SASL"
+	EnvKafkaSASLUsername    = "MINIO_NOTIFY_KAFKA_SASL_USERNAME"
+	EnvKafkaSASLPassword    = "MINIO_NOTIFY_KAFKA_SASL_PASSWORD"
+	EnvKafkaSASLMechanism   = "MINIO_NOTIFY_KAFKA_SASL_MECHANISM"
+	EnvKafkaClientTLSCert   = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_CERT"
+	EnvKafkaClientTLSKey    = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_KEY"
+	EnvKafkaVersion         = "MINIO_NOTIFY_KAFKA_VERSION"
+	EnvKafkaBatchMaxCount   = "MINIO_NOTIFY_KAFKA_BATCH_MAX_COUNT"
+	EnvKafkaBatchMaxBytes   = "MINIO_NOTIFY_KAFKA_BATCH_MAX_BYTES"
+	EnvKafkaBatchMaxLatency = "MINIO_NOTIFY_KAFKA_BATCH_MAX_LATENCY"
 )
 
 // KafkaArgs - Kafka target arguments.
 type KafkaArgs struct {
-	Enable     bool        `json:"enable"`
-	Brokers    []xnet.Host `json:"brokers"`
-	Topic      string      `json:"topic"`
-	QueueDir   string      `json:"queueDir"`
-	QueueLimit uint64      `json:"queueLimit"`
-	Version    string      `json:"version"`
-	TLS        struct {
+	Enable          bool          `json:"enable"`
+	Brokers         []xnet.Host   `json:"brokers"`
+	Topic           string        `json:"topic"`
+	QueueDir        string        `json:"queueDir"`
+	QueueLimit      uint64        `json:"queueLimit"`
+	OrderedDelivery bool          `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration `json:"queueMaxAge"`
+	QueueOverflow   string        `json:"queueOverflow"`
+	SchemaVersion   string        `json:"schemaVersion"`
+	EventFormat     string        `json:"eventFormat"`
+	Version         string        `json:"version"`
+	Batch           BatchArgs     `json:"batch"`
+	TLS             struct {
 		Enable        bool               `json:"enable"`
 		RootCAs       *x509.CertPool     `json:"-"`
 		SkipVerify    bool               `json:"skipVerify"`
@@ -106,6 +127,18 @@ func (k KafkaArgs) Validate() error {
 			return err
 		}
 	}
+	if err := validateEventFormat(k.EventFormat); err != nil {
+		return err
+	}
+	if err := k.Batch.Validate(); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(k.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(k.SchemaVersion); err != nil {
+		return err
+	}
 	if k.QueueDir != "" {
 		if !filepath.IsAbs(k.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -126,7 +159,9 @@ type KafkaTarget struct {
 	producer   sarama.SyncProducer
 	config     *sarama.Config
 	store      Store
+	batch      *batch
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -139,6 +174,22 @@ func (target *KafkaTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *KafkaTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *KafkaTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *KafkaTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *KafkaTarget) IsActive() (bool, error) {
 	if !target.args.pingBrokers() {
@@ -152,6 +203,18 @@ func (target *KafkaTarget) Save(eventData event.Event) error {
 	if target.store != nil {
 		return target.store.Put(eventData)
 	}
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	if target.batch != nil {
+		return target.batch.add("", eventData)
+	}
+	return target.send(eventData)
+}
+
+// SendTest - sends the test event to the Kafka topic, bypassing the queue store and batching.
+func (target *KafkaTarget) SendTest(eventData event.Event) error {
 	_, err := target.IsActive()
 	if err != nil {
 		return err
@@ -164,13 +227,7 @@ func (target *KafkaTarget) send(eventData event.Event) error {
 	if target.producer == nil {
 		return errNotConnected
 	}
-	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
-	if err != nil {
-		return err
-	}
-	key := eventData.S3.Bucket.Name + "/" + objectName
-
-	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	key, data, err := encodeEvent(target.args.EventFormat, eventData)
 	if err != nil {
 		return err
 	}
@@ -186,6 +243,35 @@ func (target *KafkaTarget) send(eventData event.Event) error {
 	return err
 }
 
+// sendBatch - sends a batch of events to the kafka in one request.
+func (target *KafkaTarget) sendBatch(events []event.Event) error {
+	if target.producer == nil {
+		return errNotConnected
+	}
+	msgs := make([]*sarama.ProducerMessage, 0, len(events))
+	for _, eventData := range events {
+		key, data, err := encodeEvent(target.args.EventFormat, eventData)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic: target.args.Topic,
+			Key:   sarama.StringEncoder(key),
+			Value: sarama.ByteEncoder(data),
+		})
+	}
+
+	err := target.producer.SendMessages(msgs)
+	var perrs sarama.ProducerErrors
+	if errors.As(err, &perrs) && len(perrs) > 0 {
+		err = perrs[0].Err
+	}
+	if err == sarama.ErrLeaderNotAvailable || err != nil && err.Error() == "circuit breaker is open" {
+		return errNotConnected
+	}
+	return err
+}
+
 // Send - reads an event from store and sends it to Kafka.
 func (target *KafkaTarget) Send(eventKey string) error {
 	var err error
@@ -218,6 +304,10 @@ func (target *KafkaTarget) Send(eventKey string) error {
 		return eErr
 	}
 
+	if target.batch != nil {
+		return target.batch.add(eventKey, eventData)
+	}
+
 	err = target.send(eventData)
 	if err != nil {
 		// Sarama opens the ciruit breaker after 3 consecutive connection failures.
@@ -233,6 +323,12 @@ func (target *KafkaTarget) Send(eventKey string) error {
 
 // Close - closes underneath kafka connection.
 func (target *KafkaTarget) Close() error {
+	target.quit.close()
+	if target.batch != nil {
+		if err := target.batch.close(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+		}
+	}
 	if target.producer != nil {
 		return target.producer.Close()
 	}
@@ -256,6 +352,7 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 
 	target := &KafkaTarget{
 		id:         event.TargetID{ID: id, Name: "kafka"},
+		quit:       newQuitCh(),
 		args:       args,
 		loggerOnce: loggerOnce,
 	}
@@ -301,7 +398,7 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-kafka-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if oErr := store.Open(); oErr != nil {
 			target.loggerOnce(context.Background(), oErr, target.ID())
 			return target, oErr
@@ -318,11 +415,16 @@ func NewKafkaTarget(id string, args KafkaArgs, doneCh <-chan struct{}, loggerOnc
 	}
 	target.producer = producer
 
+	if args.Batch.Enabled() {
+		target.batch = newBatch(args.Batch, target.store, target.sendBatch, target.loggerOnce, target.ID())
+	}
+
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -21,16 +21,21 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/fips"
 	xnet "github.com/minio/pkg/net"
 )
 
@@ -50,6 +55,14 @@ const (
 	MqttKeepAliveInterval = "keep_alive_interval"
 	MqttQueueDir          = "queue_dir"
 	MqttQueueLimit        = "queue_limit"
+	MqttOrderedDelivery   = "ordered_delivery"
+	MqttQueueMaxAge       = "queue_max_age"
+	MqttQueueOverflow     = "queue_overflow"
+	MqttSchemaVersion     = "schema_version"
+	MqttEventFormat       = "event_format"
+	MqttProtocolVersion   = "protocol_version"
+	MqttSessionExpiry     = "session_expiry"
+	MqttMessageExpiry     = "message_expiry"
 
 	EnvMQTTEnable            = "MINIO_NOTIFY_MQTT_ENABLE"
 	EnvMQTTBroker            = "MINIO_NOTIFY_MQTT_BROKER"
@@ -61,6 +74,26 @@ const (
 	EnvMQTTKeepAliveInterval = "MINIO_NOTIFY_MQTT_KEEP_ALIVE_INTERVAL"
 	EnvMQTTQueueDir          = "MINIO_NOTIFY_MQTT_QUEUE_DIR"
 	EnvMQTTQueueLimit        = "MINIO_NOTIFY_MQTT_QUEUE_LIMIT"
+	EnvMQTTOrderedDelivery   = "MINIO_NOTIFY_MQTT_ORDERED_DELIVERY"
+	EnvMQTTQueueMaxAge       = "MINIO_NOTIFY_MQTT_QUEUE_MAX_AGE"
+	EnvMQTTQueueOverflow     = "MINIO_NOTIFY_MQTT_QUEUE_OVERFLOW"
+	EnvMQTTSchemaVersion     = "MINIO_NOTIFY_MQTT_SCHEMA_VERSION"
+	EnvMQTTEventFormat       = "MINIO_NOTIFY_MQTT_EVENT_FORMAT"
+	EnvMQTTProtocolVersion   = "MINIO_NOTIFY_MQTT_PROTOCOL_VERSION"
+	EnvMQTTSessionExpiry     = "MINIO_NOTIFY_MQTT_SESSION_EXPIRY"
+	EnvMQTTMessageExpiry     = "MINIO_NOTIFY_MQTT_MESSAGE_EXPIRY"
+)
+
+// MQTT protocol versions
+const (
+	MQTTProtocolV311 = "3.1.1"
+	MQTTProtocolV5   = "5"
+)
+
+// Placeholders of MQTT topic templates
+const (
+	mqttTopicBucket = "{bucket}"
+	mqttTopicEvent  = "{event}"
 )
 
 // MQTTArgs - MQTT target arguments.
@@ -76,6 +109,14 @@ type MQTTArgs struct {
 	RootCAs              *x509.CertPool `json:"-"`
 	QueueDir             string         `json:"queueDir"`
 	QueueLimit           uint64         `json:"queueLimit"`
+	OrderedDelivery      bool           `json:"orderedDelivery"`
+	QueueMaxAge          time.Duration  `json:"queueMaxAge"`
+	QueueOverflow        string         `json:"queueOverflow"`
+	SchemaVersion        string         `json:"schemaVersion"`
+	EventFormat          string         `json:"eventFormat"`
+	ProtocolVersion      string         `json:"protocolVersion"`
+	SessionExpiry        time.Duration  `json:"sessionExpiry"`
+	MessageExpiry        time.Duration  `json:"messageExpiry"`
 }
 
 // Validate MQTTArgs fields
@@ -92,6 +133,37 @@ func (m MQTTArgs) Validate() error {
 	default:
 		return errors.New("unknown protocol in broker address")
 	}
+	if strings.ContainsAny(m.Topic, "+#") {
+		return errors.New("topic must not contain wildcards")
+	}
+	if strings.HasPrefix(m.Topic, "$share/") {
+		return errors.New("topic must not be a shared subscription, subscribe to $share/<group>/<topic> instead")
+	}
+	switch m.ProtocolVersion {
+	case "", MQTTProtocolV311:
+		if m.SessionExpiry != 0 || m.MessageExpiry != 0 {
+			return errors.New("session and message expiry require protocol version 5")
+		}
+	case MQTTProtocolV5:
+		// Expiry intervals are 32 bit seconds.
+		if m.SessionExpiry < 0 || m.SessionExpiry/time.Second > math.MaxUint32 {
+			return errors.New("invalid session expiry")
+		}
+		if m.MessageExpiry < 0 || m.MessageExpiry/time.Second > math.MaxUint32 {
+			return errors.New("invalid message expiry")
+		}
+	default:
+		return fmt.Errorf("unsupported protocol version %q, must be %s or %s", m.ProtocolVersion, MQTTProtocolV311, MQTTProtocolV5)
+	}
+	if err := validateEventFormat(m.EventFormat); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(m.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(m.SchemaVersion); err != nil {
+		return err
+	}
 	if m.QueueDir != "" {
 		if !filepath.IsAbs(m.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -108,9 +180,9 @@ func (m MQTTArgs) Validate() error {
 type MQTTTarget struct {
 	id         event.TargetID
 	args       MQTTArgs
-	client     mqtt.Client
+	client     mqttClient
 	store      Store
-	quitCh     chan struct{}
+	quit       *quitCh
 	loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})
 }
 
@@ -124,9 +196,25 @@ func (target *MQTTTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *MQTTTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *MQTTTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *MQTTTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *MQTTTarget) IsActive() (bool, error) {
-	if !target.client.IsConnectionOpen() {
+	if !target.client.isConnected() {
 		return false, errNotConnected
 	}
 	return true, nil
@@ -134,22 +222,16 @@ func (target *MQTTTarget) IsActive() (bool, error) {
 
 // send - sends an event to the mqtt.
 func (target *MQTTTarget) send(eventData event.Event) error {
-	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	_, data, err := encodeEvent(target.args.EventFormat, eventData)
 	if err != nil {
 		return err
 	}
-	key := eventData.S3.Bucket.Name + "/" + objectName
 
-	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
-	if err != nil {
-		return err
-	}
-
-	token := target.client.Publish(target.args.Topic, target.args.QoS, false, string(data))
-	if !token.WaitTimeout(reconnectInterval * time.Second) {
-		return errNotConnected
-	}
-	return token.Error()
+	topic := strings.NewReplacer(
+		mqttTopicBucket, eventData.S3.Bucket.Name,
+		mqttTopicEvent, eventData.EventName.String(),
+	).Replace(target.args.Topic)
+	return target.client.publish(topic, target.args.QoS, data, eventData)
 }
 
 // Send - reads an event from store and sends it to MQTT.
@@ -194,10 +276,19 @@ func (target *MQTTTarget) Save(eventData event.Event) error {
 	return target.send(eventData)
 }
 
+// SendTest - sends the test event to the MQTT topic, bypassing the queue store.
+func (target *MQTTTarget) SendTest(eventData event.Event) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
 // Close - does nothing and available for interface compatibility.
 func (target *MQTTTarget) Close() error {
-	target.client.Disconnect(100)
-	close(target.quitCh)
+	target.client.disconnect()
+	target.quit.close()
 	return nil
 }
 
@@ -213,57 +304,73 @@ func NewMQTTTarget(id string, args MQTTArgs, doneCh <-chan struct{}, loggerOnce
 		args.KeepAlive = 10 * time.Second
 	}
 
-	options := mqtt.NewClientOptions().
-		SetClientID(id).
-		SetCleanSession(true).
-		SetUsername(args.User).
-		SetPassword(args.Password).
-		SetMaxReconnectInterval(args.MaxReconnectInterval).
-		SetKeepAlive(args.KeepAlive).
-		SetTLSConfig(&tls.Config{RootCAs: args.RootCAs}).
-		AddBroker(args.Broker.String())
-
-	client := mqtt.NewClient(options)
-
 	target := &MQTTTarget{
 		id:         event.TargetID{ID: id, Name: "mqtt"},
 		args:       args,
-		client:     client,
-		quitCh:     make(chan struct{}),
+		quit:       newQuitCh(),
 		loggerOnce: loggerOnce,
 	}
 
-	token := client.Connect()
-	retryRegister := func() {
-		for {
-		retry:
-			select {
-			case <-doneCh:
-				return
-			case <-target.quitCh:
-				return
-			default:
-				ok := token.WaitTimeout(reconnectInterval * time.Second)
-				if ok && token.Error() != nil {
-					target.loggerOnce(context.Background(),
-						fmt.Errorf("Previous connect failed with %w attempting a reconnect",
-							token.Error()),
-						target.ID())
-					time.Sleep(reconnectInterval * time.Second)
-					token = client.Connect()
-					goto retry
-				}
-				if ok {
-					// Successfully connected.
+	// retryRegister connects in the background until the connection is
+	// up, awaitConnection returns the outcome of the first attempt.
+	var retryRegister func()
+	var awaitConnection func() error
+	if args.ProtocolVersion == MQTTProtocolV5 {
+		client := newMQTTClientV5(target.ID(), args, loggerOnce)
+		target.client = client
+		// The connection manager reconnects by itself.
+		retryRegister = func() {}
+		awaitConnection = client.awaitConnection
+	} else {
+		options := mqtt.NewClientOptions().
+			SetClientID(id).
+			SetCleanSession(true).
+			SetUsername(args.User).
+			SetPassword(args.Password).
+			SetMaxReconnectInterval(args.MaxReconnectInterval).
+			SetKeepAlive(args.KeepAlive).
+			SetTLSConfig(fips.RestrictTLS(&tls.Config{RootCAs: args.RootCAs})).
+			AddBroker(args.Broker.String())
+
+		client := mqtt.NewClient(options)
+		target.client = mqttClientV3{client}
+
+		token := client.Connect()
+		retryRegister = func() {
+			for {
+			retry:
+				select {
+				case <-doneCh:
+					return
+				case <-target.quit.ch:
 					return
+				default:
+					ok := token.WaitTimeout(reconnectInterval * time.Second)
+					if ok && token.Error() != nil {
+						target.loggerOnce(context.Background(),
+							fmt.Errorf("Previous connect failed with %w attempting a reconnect",
+								token.Error()),
+							target.ID())
+						time.Sleep(reconnectInterval * time.Second)
+						token = client.Connect()
+						goto retry
+					}
+					if ok {
+						// Successfully connected.
+						return
+					}
 				}
 			}
 		}
+		awaitConnection = func() error {
+			token.Wait()
+			return token.Error()
+		}
 	}
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-mqtt-"+id)
-		target.store = NewQueueStore(queueDir, args.QueueLimit)
+		target.store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if err := target.store.Open(); err != nil {
 			target.loggerOnce(context.Background(), err, target.ID())
 			return target, err
@@ -272,12 +379,152 @@ func NewMQTTTarget(id string, args MQTTArgs, doneCh <-chan struct{}, loggerOnce
 		if !test {
 			go retryRegister()
 			// Replays the events from the store.
-			eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+			stopCh := target.quit.or(doneCh)
+			eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 			// Start replaying events from the store.
-			go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+			go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 		}
-	} else if token.Wait() && token.Error() != nil {
-		return target, token.Error()
+	} else if err := awaitConnection(); err != nil {
+		return target, err
 	}
 	return target, nil
 }
+
+// Interface for MQTT client objects of the supported protocol versions
+type mqttClient interface {
+	isConnected() bool
+	publish(topic string, qos byte, data []byte, eventData event.Event) error
+	disconnect()
+}
+
+// MQTT v3.1.1 client
+
+type mqttClientV3 struct {
+	mqtt.Client
+}
+
+func (c mqttClientV3) isConnected() bool {
+	return c.IsConnectionOpen()
+}
+
+func (c mqttClientV3) publish(topic string, qos byte, data []byte, _ event.Event) error {
+	token := c.Publish(topic, qos, false, string(data))
+	if !token.WaitTimeout(reconnectInterval * time.Second) {
+		return errNotConnected
+	}
+	return token.Error()
+}
+
+func (c mqttClientV3) disconnect() {
+	c.Disconnect(100)
+}
+
+// MQTT v5 client
+
+type mqttClientV5 struct {
+	cm            *autopaho.ConnectionManager
+	connected     int32
+	messageExpiry time.Duration
+	// firstConn receives the outcome of the first connection attempt.
+	firstConn chan error
+}
+
+func newMQTTClientV5(id event.TargetID, args MQTTArgs, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) *mqttClientV5 {
+	c := &mqttClientV5{
+		messageExpiry: args.MessageExpiry,
+		firstConn:     make(chan error, 1),
+	}
+	firstConn := func(err error) {
+		select {
+		case c.firstConn <- err:
+		default:
+		}
+	}
+
+	brokerURL := url.URL(args.Broker)
+	cfg := autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{&brokerURL},
+		TlsCfg:            fips.RestrictTLS(&tls.Config{RootCAs: args.RootCAs}),
+		KeepAlive:         uint16(args.KeepAlive / time.Second),
+		ConnectRetryDelay: reconnectInterval * time.Second,
+		OnConnectionUp: func(*autopaho.ConnectionManager, *paho.Connack) {
+			atomic.StoreInt32(&c.connected, 1)
+			firstConn(nil)
+		},
+		OnConnectError: func(err error) {
+			atomic.StoreInt32(&c.connected, 0)
+			loggerOnce(context.Background(), fmt.Errorf("Connect failed with %w attempting a reconnect", err), id)
+			firstConn(err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: id.ID,
+			OnClientError: func(err error) {
+				atomic.StoreInt32(&c.connected, 0)
+			},
+			OnServerDisconnect: func(*paho.Disconnect) {
+				atomic.StoreInt32(&c.connected, 0)
+			},
+		},
+	}
+	cfg.SetUsernamePassword(args.User, []byte(args.Password))
+	if args.SessionExpiry > 0 {
+		// Keep the session on the broker across reconnects.
+		sessionExpiry := uint32(args.SessionExpiry / time.Second)
+		cfg.SetConnectPacketConfigurator(func(cp *paho.Connect) *paho.Connect {
+			cp.CleanStart = false
+			cp.Properties = &paho.ConnectProperties{SessionExpiryInterval: &sessionExpiry}
+			return cp
+		})
+	}
+
+	// Only fails if the context is canceled.
+	c.cm, _ = autopaho.NewConnection(context.Background(), cfg)
+	return c
+}
+
+func (c *mqttClientV5) awaitConnection() error {
+	err := <-c.firstConn
+	if err != nil {
+		c.disconnect()
+	}
+	return err
+}
+
+func (c *mqttClientV5) isConnected() bool {
+	return atomic.LoadInt32(&c.connected) == 1
+}
+
+// publish - publishes the event with the bucket, the object key and the
+// event name as user properties, so that consumers can route it without
+// decoding the payload.
+func (c *mqttClientV5) publish(topic string, qos byte, data []byte, eventData event.Event) error {
+	props := &paho.PublishProperties{}
+	props.User.Add("bucket", eventData.S3.Bucket.Name)
+	if key, err := url.QueryUnescape(eventData.S3.Object.Key); err == nil {
+		props.User.Add("key", key)
+	}
+	props.User.Add("eventName", eventData.EventName.String())
+	if c.messageExpiry > 0 {
+		messageExpiry := uint32(c.messageExpiry / time.Second)
+		props.MessageExpiry = &messageExpiry
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconnectInterval*time.Second)
+	defer cancel()
+	_, err := c.cm.Publish(ctx, &paho.Publish{
+		Topic:      topic,
+		QoS:        qos,
+		Payload:    data,
+		Properties: props,
+	})
+	if errors.Is(err, autopaho.ConnectionDownError) || errors.Is(err, context.DeadlineExceeded) {
+		return errNotConnected
+	}
+	return err
+}
+
+func (c *mqttClientV5) disconnect() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.cm.Disconnect(ctx)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestMQTTArgs_Validate(t *testing.T) {
+	broker, err := xnet.ParseURL("tcp://localhost:1883")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    MQTTArgs
+		wantErr bool
+	}{
+		{
+			name: "v3_OK",
+			args: MQTTArgs{Enable: true, Broker: *broker, Topic: "minio/{bucket}/{event}"},
+		},
+		{
+			name:    "v3_expiry",
+			args:    MQTTArgs{Enable: true, Broker: *broker, Topic: "minio", MessageExpiry: time.Hour},
+			wantErr: true,
+		},
+		{
+			name: "v5_OK",
+			args: MQTTArgs{
+				Enable: true, Broker: *broker, Topic: "minio/{bucket}",
+				ProtocolVersion: MQTTProtocolV5, SessionExpiry: time.Hour, MessageExpiry: time.Minute,
+			},
+		},
+		{
+			name: "v5_expiry_overflow",
+			args: MQTTArgs{
+				Enable: true, Broker: *broker, Topic: "minio",
+				ProtocolVersion: MQTTProtocolV5, MessageExpiry: 1 << 33 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown_version",
+			args:    MQTTArgs{Enable: true, Broker: *broker, Topic: "minio", ProtocolVersion: "4"},
+			wantErr: true,
+		},
+		{
+			name:    "wildcard_topic",
+			args:    MQTTArgs{Enable: true, Broker: *broker, Topic: "minio/+/events"},
+			wantErr: true,
+		},
+		{
+			name:    "shared_subscription_topic",
+			args:    MQTTArgs{Enable: true, Broker: *broker, Topic: "$share/group/minio"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.args.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMQTTTargetV5(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// A broker accepting a single connection, which sends the packets
+	// it received.
+	packetCh := make(chan *packets.ControlPacket, 2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			cp, err := packets.ReadPacket(conn)
+			if err != nil {
+				return
+			}
+			packetCh <- cp
+			if cp.Type == packets.CONNECT {
+				if _, err = packets.NewControlPacket(packets.CONNACK).WriteTo(conn); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	broker, err := xnet.ParseURL("tcp://" + l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := MQTTArgs{
+		Enable:          true,
+		Broker:          *broker,
+		Topic:           "minio/{bucket}/{event}",
+		ProtocolVersion: MQTTProtocolV5,
+		SessionExpiry:   time.Hour,
+		MessageExpiry:   time.Minute,
+	}
+	target, err := NewMQTTTarget("1", args, nil, func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	connect := (<-packetCh).Content.(*packets.Connect)
+	if connect.CleanStart || connect.Properties.SessionExpiryInterval == nil || *connect.Properties.SessionExpiryInterval != 3600 {
+		t.Fatalf("unexpected connect packet %+v", connect)
+	}
+
+	ev := event.Event{EventName: event.ObjectCreatedPut}
+	ev.S3.Bucket.Name = "mybucket"
+	ev.S3.Object.Key = "photos%2Fa+b.jpg"
+	if err = target.Save(ev); err != nil {
+		t.Fatal(err)
+	}
+
+	publish := (<-packetCh).Content.(*packets.Publish)
+	if publish.Topic != "minio/mybucket/s3:ObjectCreated:Put" {
+		t.Errorf("unexpected topic %s", publish.Topic)
+	}
+	if publish.Properties.MessageExpiry == nil || *publish.Properties.MessageExpiry != 60 {
+		t.Errorf("unexpected message expiry %v", publish.Properties.MessageExpiry)
+	}
+	user := make(map[string]string)
+	for _, p := range publish.Properties.User {
+		user[p.Key] = p.Value
+	}
+	if user["bucket"] != "mybucket" || user["key"] != "photos/a b.jpg" || user["eventName"] != "s3:ObjectCreated:Put" {
+		t.Errorf("unexpected user properties %v", user)
+	}
+}
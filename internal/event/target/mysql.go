@@ -59,6 +59,10 @@ const (
 	MySQLPassword           = "password"
 	MySQLDatabase           = "database"
 	MySQLQueueLimit         = "queue_limit"
+	MySQLOrderedDelivery    = "ordered_delivery"
+	MySQLQueueMaxAge        = "queue_max_age"
+	MySQLQueueOverflow      = "queue_overflow"
+	MySQLSchemaVersion      = "schema_version"
 	MySQLQueueDir           = "queue_dir"
 	MySQLMaxOpenConnections = "max_open_connections"
 
@@ -72,24 +76,32 @@ const (
 	EnvMySQLPassword           = "MINIO_NOTIFY_MYSQL_PASSWORD"
 	EnvMySQLDatabase           = "MINIO_NOTIFY_MYSQL_DATABASE"
 	EnvMySQLQueueLimit         = "MINIO_NOTIFY_MYSQL_QUEUE_LIMIT"
+	EnvMySQLOrderedDelivery    = "MINIO_NOTIFY_MYSQL_ORDERED_DELIVERY"
+	EnvMySQLQueueMaxAge        = "MINIO_NOTIFY_MYSQL_QUEUE_MAX_AGE"
+	EnvMySQLQueueOverflow      = "MINIO_NOTIFY_MYSQL_QUEUE_OVERFLOW"
+	EnvMySQLSchemaVersion      = "MINIO_NOTIFY_MYSQL_SCHEMA_VERSION"
 	EnvMySQLQueueDir           = "MINIO_NOTIFY_MYSQL_QUEUE_DIR"
 	EnvMySQLMaxOpenConnections = "MINIO_NOTIFY_MYSQL_MAX_OPEN_CONNECTIONS"
 )
 
 // MySQLArgs - MySQL target arguments.
 type MySQLArgs struct {
-	Enable             bool     `json:"enable"`
-	Format             string   `json:"format"`
-	DSN                string   `json:"dsnString"`
-	Table              string   `json:"table"`
-	Host               xnet.URL `json:"host"`
-	Port               string   `json:"port"`
-	User               string   `json:"user"`
-	Password           string   `json:"password"`
-	Database           string   `json:"database"`
-	QueueDir           string   `json:"queueDir"`
-	QueueLimit         uint64   `json:"queueLimit"`
-	MaxOpenConnections int      `json:"maxOpenConnections"`
+	Enable             bool          `json:"enable"`
+	Format             string        `json:"format"`
+	DSN                string        `json:"dsnString"`
+	Table              string        `json:"table"`
+	Host               xnet.URL      `json:"host"`
+	Port               string        `json:"port"`
+	User               string        `json:"user"`
+	Password           string        `json:"password"`
+	Database           string        `json:"database"`
+	QueueDir           string        `json:"queueDir"`
+	QueueLimit         uint64        `json:"queueLimit"`
+	OrderedDelivery    bool          `json:"orderedDelivery"`
+	QueueMaxAge        time.Duration `json:"queueMaxAge"`
+	QueueOverflow      string        `json:"queueOverflow"`
+	SchemaVersion      string        `json:"schemaVersion"`
+	MaxOpenConnections int           `json:"maxOpenConnections"`
 }
 
 // Validate MySQLArgs fields
@@ -126,6 +138,12 @@ func (m MySQLArgs) Validate() error {
 		}
 	}
 
+	if err := validateQueueOverflow(m.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(m.SchemaVersion); err != nil {
+		return err
+	}
 	if m.QueueDir != "" {
 		if !filepath.IsAbs(m.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -150,6 +168,7 @@ type MySQLTarget struct {
 	store      Store
 	firstPing  bool
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -162,6 +181,22 @@ func (target *MySQLTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *MySQLTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *MySQLTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *MySQLTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *MySQLTarget) IsActive() (bool, error) {
 	if target.db == nil {
@@ -196,6 +231,15 @@ func (target *MySQLTarget) Save(eventData event.Event) error {
 	return target.send(eventData)
 }
 
+// SendTest - sends the test event to the MySQL table, bypassing the queue store.
+func (target *MySQLTarget) SendTest(eventData event.Event) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
 // send - sends an event to the mysql.
 func (target *MySQLTarget) send(eventData event.Event) error {
 	if target.args.Format == event.NamespaceFormat {
@@ -277,6 +321,7 @@ func (target *MySQLTarget) Send(eventKey string) error {
 
 // Close - closes underneath connections to MySQL database.
 func (target *MySQLTarget) Close() error {
+	target.quit.close()
 	if target.updateStmt != nil {
 		// FIXME: log returned error. ignore time being.
 		_ = target.updateStmt.Close()
@@ -347,6 +392,7 @@ func NewMySQLTarget(id string, args MySQLArgs, doneCh <-chan struct{}, loggerOnc
 
 	target := &MySQLTarget{
 		id:         event.TargetID{ID: id, Name: "mysql"},
+		quit:       newQuitCh(),
 		args:       args,
 		firstPing:  false,
 		loggerOnce: loggerOnce,
@@ -368,7 +414,7 @@ func NewMySQLTarget(id string, args MySQLArgs, doneCh <-chan struct{}, loggerOnc
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-mysql-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if oErr := store.Open(); oErr != nil {
 			target.loggerOnce(context.Background(), oErr, target.ID())
 			return target, oErr
@@ -392,9 +438,10 @@ func NewMySQLTarget(id string, args MySQLArgs, doneCh <-chan struct{}, loggerOnc
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -21,13 +21,14 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"errors"
-	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/fips"
 	xnet "github.com/minio/pkg/net"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/stan.go"
@@ -35,19 +36,24 @@ import (
 
 // NATS related constants
 const (
-	NATSAddress       = "address"
-	NATSSubject       = "subject"
-	NATSUsername      = "username"
-	NATSPassword      = "password"
-	NATSToken         = "token"
-	NATSTLS           = "tls"
-	NATSTLSSkipVerify = "tls_skip_verify"
-	NATSPingInterval  = "ping_interval"
-	NATSQueueDir      = "queue_dir"
-	NATSQueueLimit    = "queue_limit"
-	NATSCertAuthority = "cert_authority"
-	NATSClientCert    = "client_cert"
-	NATSClientKey     = "client_key"
+	NATSAddress         = "address"
+	NATSSubject         = "subject"
+	NATSUsername        = "username"
+	NATSPassword        = "password"
+	NATSToken           = "token"
+	NATSTLS             = "tls"
+	NATSTLSSkipVerify   = "tls_skip_verify"
+	NATSPingInterval    = "ping_interval"
+	NATSQueueDir        = "queue_dir"
+	NATSQueueLimit      = "queue_limit"
+	NATSOrderedDelivery = "ordered_delivery"
+	NATSQueueMaxAge     = "queue_max_age"
+	NATSQueueOverflow   = "queue_overflow"
+	NATSSchemaVersion   = "schema_version"
+	NATSEventFormat     = "event_format"
+	NATSCertAuthority   = "cert_authority"
+	NATSClientCert      = "client_cert"
+	NATSClientKey       = "client_key"
 
 	// Streaming constants
 	NATSStreaming                   = "streaming"
@@ -55,51 +61,80 @@ const (
 	NATSStreamingAsync              = "streaming_async"
 	NATSStreamingMaxPubAcksInFlight = "streaming_max_pub_acks_in_flight"
 
-	EnvNATSEnable        = "MINIO_NOTIFY_NATS_ENABLE"
-	EnvNATSAddress       = "MINIO_NOTIFY_NATS_ADDRESS"
-	EnvNATSSubject       = "MINIO_NOTIFY_NATS_SUBJECT"
-	EnvNATSUsername      = "MINIO_NOTIFY_NATS_USERNAME"
-	EnvNATSPassword      = "MINIO_NOTIFY_NATS_PASSWORD"
-	EnvNATSToken         = "MINIO_NOTIFY_NATS_TOKEN"
-	EnvNATSTLS           = "MINIO_NOTIFY_NATS_TLS"
-	EnvNATSTLSSkipVerify = "MINIO_NOTIFY_NATS_TLS_SKIP_VERIFY"
-	EnvNATSPingInterval  = "MINIO_NOTIFY_NATS_PING_INTERVAL"
-	EnvNATSQueueDir      = "MINIO_NOTIFY_NATS_QUEUE_DIR"
-	EnvNATSQueueLimit    = "MINIO_NOTIFY_NATS_QUEUE_LIMIT"
-	EnvNATSCertAuthority = "MINIO_NOTIFY_NATS_CERT_AUTHORITY"
-	EnvNATSClientCert    = "MINIO_NOTIFY_NATS_CLIENT_CERT"
-	EnvNATSClientKey     = "MINIO_NOTIFY_NATS_CLIENT_KEY"
+	// JetStream constants
+	NATSJetStream           = "jetstream"
+	NATSJetStreamStream     = "jetstream_stream"
+	NATSJetStreamAsync      = "jetstream_async"
+	NATSJetStreamMaxPending = "jetstream_max_pending"
+
+	EnvNATSEnable          = "MINIO_NOTIFY_NATS_ENABLE"
+	EnvNATSAddress         = "MINIO_NOTIFY_NATS_ADDRESS"
+	EnvNATSSubject         = "MINIO_NOTIFY_NATS_SUBJECT"
+	EnvNATSUsername        = "MINIO_NOTIFY_NATS_USERNAME"
+	EnvNATSPassword        = "MINIO_NOTIFY_NATS_PASSWORD"
+	EnvNATSToken           = "MINIO_NOTIFY_NATS_TOKEN"
+	EnvNATSTLS             = "MINIO_NOTIFY_NATS_TLS"
+	EnvNATSTLSSkipVerify   = "MINIO_NOTIFY_NATS_TLS_SKIP_VERIFY"
+	EnvNATSPingInterval    = "MINIO_NOTIFY_NATS_PING_INTERVAL"
+	EnvNATSQueueDir        = "MINIO_NOTIFY_NATS_QUEUE_DIR"
+	EnvNATSQueueLimit      = "MINIO_NOTIFY_NATS_QUEUE_LIMIT"
+	EnvNATSOrderedDelivery = "MINIO_NOTIFY_NATS_ORDERED_DELIVERY"
+	EnvNATSQueueMaxAge     = "MINIO_NOTIFY_NATS_QUEUE_MAX_AGE"
+	EnvNATSQueueOverflow   = "MINIO_NOTIFY_NATS_QUEUE_OVERFLOW"
+	EnvNATSSchemaVersion   = "MINIO_NOTIFY_NATS_SCHEMA_VERSION"
+	EnvNATSEventFormat     = "MINIO_NOTIFY_NATS_EVENT_FORMAT"
+	EnvNATSCertAuthority   = "MINIO_NOTIFY_NATS_CERT_AUTHORITY"
+	EnvNATSClientCert      = "MINIO_NOTIFY_NATS_CLIENT_CERT"
+	EnvNATSClientKey       = "MINIO_NOTIFY_NATS_CLIENT_KEY"
 
 	// Streaming constants
 	EnvNATSStreaming                   = "MINIO_NOTIFY_NATS_STREAMING"
 	EnvNATSStreamingClusterID          = "MINIO_NOTIFY_NATS_STREAMING_CLUSTER_ID"
 	EnvNATSStreamingAsync              = "MINIO_NOTIFY_NATS_STREAMING_ASYNC"
 	EnvNATSStreamingMaxPubAcksInFlight = "MINIO_NOTIFY_NATS_STREAMING_MAX_PUB_ACKS_IN_FLIGHT"
+
+	// JetStream constants
+	EnvNATSJetStream           = "MINIO_NOTIFY_NATS_JETSTREAM"
+	EnvNATSJetStreamStream     = "MINIO_NOTIFY_NATS_JETSTREAM_STREAM"
+	EnvNATSJetStreamAsync      = "MINIO_NOTIFY_NATS_JETSTREAM_ASYNC"
+	EnvNATSJetStreamMaxPending = "MINIO_NOTIFY_NATS_JETSTREAM_MAX_PENDING"
 )
 
 // NATSArgs - NATS target arguments.
 type NATSArgs struct {
-	Enable        bool      `json:"enable"`
-	Address       xnet.Host `json:"address"`
-	Subject       string    `json:"subject"`
-	Username      string    `json:"username"`
-	Password      string    `json:"password"`
-	Token         string    `json:"token"`
-	TLS           bool      `json:"tls"`
-	TLSSkipVerify bool      `json:"tlsSkipVerify"`
-	Secure        bool      `json:"secure"`
-	CertAuthority string    `json:"certAuthority"`
-	ClientCert    string    `json:"clientCert"`
-	ClientKey     string    `json:"clientKey"`
-	PingInterval  int64     `json:"pingInterval"`
-	QueueDir      string    `json:"queueDir"`
-	QueueLimit    uint64    `json:"queueLimit"`
-	Streaming     struct {
+	Enable          bool          `json:"enable"`
+	Address         xnet.Host     `json:"address"`
+	Subject         string        `json:"subject"`
+	Username        string        `json:"username"`
+	Password        string        `json:"password"`
+	Token           string        `json:"token"`
+	TLS             bool          `json:"tls"`
+	TLSSkipVerify   bool          `json:"tlsSkipVerify"`
+	Secure          bool          `json:"secure"`
+	CertAuthority   string        `json:"certAuthority"`
+	ClientCert      string        `json:"clientCert"`
+	ClientKey       string        `json:"clientKey"`
+	PingInterval    int64         `json:"pingInterval"`
+	QueueDir        string        `json:"queueDir"`
+	QueueLimit      uint64        `json:"queueLimit"`
+	OrderedDelivery bool          `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration `json:"queueMaxAge"`
+	QueueOverflow   string        `json:"queueOverflow"`
+	SchemaVersion   string        `json:"schemaVersion"`
+	EventFormat     string        `json:"eventFormat"`
+	Streaming       struct {
 		Enable             bool   `json:"enable"`
 		ClusterID          string `json:"clusterID"`
 		Async              bool   `json:"async"`
 		MaxPubAcksInflight int    `json:"maxPubAcksInflight"`
 	} `json:"streaming"`
+	JetStream struct {
+		Enable bool `json:"enable"`
+		// Stream is created with the subject if it does not exist.
+		Stream     string `json:"stream"`
+		Async      bool   `json:"async"`
+		MaxPending int    `json:"maxPending"`
+	} `json:"jetStream"`
 
 	RootCAs *x509.CertPool `json:"-"`
 }
@@ -130,8 +165,24 @@ func (n NATSArgs) Validate() error {
 		if n.Streaming.ClusterID == "" {
 			return errors.New("empty cluster id")
 		}
+		if n.JetStream.Enable {
+			return errors.New("streaming and jetstream cannot be enabled at the same time")
+		}
+	}
+
+	if n.JetStream.Stream != "" && strings.ContainsAny(n.JetStream.Stream, ". *>") {
+		return errors.New("invalid jetstream stream name")
 	}
 
+	if err := validateEventFormat(n.EventFormat); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(n.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(n.SchemaVersion); err != nil {
+		return err
+	}
 	if n.QueueDir != "" {
 		if !filepath.IsAbs(n.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -153,7 +204,7 @@ func (n NATSArgs) connectNats() (*nats.Conn, error) {
 	if n.Secure || n.TLS && n.TLSSkipVerify {
 		connOpts = append(connOpts, nats.Secure(nil))
 	} else if n.TLS {
-		connOpts = append(connOpts, nats.Secure(&tls.Config{RootCAs: n.RootCAs}))
+		connOpts = append(connOpts, nats.Secure(fips.RestrictTLS(&tls.Config{RootCAs: n.RootCAs})))
 	}
 	if n.CertAuthority != "" {
 		connOpts = append(connOpts, nats.RootCAs(n.CertAuthority))
@@ -164,6 +215,34 @@ func (n NATSArgs) connectNats() (*nats.Conn, error) {
 	return nats.Connect(n.Address.String(), connOpts...)
 }
 
+// To obtain a JetStream context from args. The stream is
+// created if it does not exist.
+func (n NATSArgs) connectJetStream(conn *nats.Conn) (nats.JetStreamContext, error) {
+	var opts []nats.JSOpt
+	if n.JetStream.MaxPending > 0 {
+		opts = append(opts, nats.PublishAsyncMaxPending(n.JetStream.MaxPending))
+	}
+	js, err := conn.JetStream(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if n.JetStream.Stream == "" {
+		return js, nil
+	}
+
+	_, err = js.StreamInfo(n.JetStream.Stream)
+	if err != nil && strings.Contains(err.Error(), "stream not found") {
+		_, err = js.AddStream(&nats.StreamConfig{
+			Name:     n.JetStream.Stream,
+			Subjects: []string{n.Subject},
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return js, nil
+}
+
 // To obtain a streaming connection from args.
 func (n NATSArgs) connectStan() (stan.Conn, error) {
 	scheme := "nats"
@@ -200,8 +279,10 @@ type NATSTarget struct {
 	args       NATSArgs
 	natsConn   *nats.Conn
 	stanConn   stan.Conn
+	jstream    nats.JetStreamContext
 	store      Store
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -214,6 +295,22 @@ func (target *NATSTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *NATSTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *NATSTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *NATSTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *NATSTarget) IsActive() (bool, error) {
 	var connErr error
@@ -229,6 +326,9 @@ func (target *NATSTarget) IsActive() (bool, error) {
 		} else if !target.natsConn.IsConnected() {
 			return false, errNotConnected
 		}
+		if connErr == nil && target.args.JetStream.Enable && target.jstream == nil {
+			target.jstream, connErr = target.args.connectJetStream(target.natsConn)
+		}
 	}
 
 	if connErr != nil {
@@ -250,32 +350,74 @@ func (target *NATSTarget) Save(eventData event.Event) error {
 	if err != nil {
 		return err
 	}
-	return target.send(eventData)
+	return target.send(eventData, "")
 }
 
-// send - sends an event to the Nats.
-func (target *NATSTarget) send(eventData event.Event) error {
-	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+// SendTest - sends the test event to the NATS subject, bypassing the queue store.
+func (target *NATSTarget) SendTest(eventData event.Event) error {
+	_, err := target.IsActive()
 	if err != nil {
 		return err
 	}
-	key := eventData.S3.Bucket.Name + "/" + objectName
+	return target.send(eventData, "")
+}
 
-	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+// send - sends an event to the Nats. If the event has been read from
+// the store, it is removed from the store once it is delivered.
+func (target *NATSTarget) send(eventData event.Event, eventKey string) error {
+	_, data, err := encodeEvent(target.args.EventFormat, eventData)
 	if err != nil {
 		return err
 	}
 
-	if target.stanConn != nil {
+	switch {
+	case target.stanConn != nil:
 		if target.args.Streaming.Async {
 			_, err = target.stanConn.PublishAsync(target.args.Subject, data, nil)
 		} else {
 			err = target.stanConn.Publish(target.args.Subject, data)
 		}
-	} else {
+	case target.jstream != nil:
+		// JetStream drops messages with an ID it has already seen within
+		// the duplicate window of the stream, so replayed events are
+		// stored only once.
+		msgID := nats.MsgId(event.DedupID(eventData))
+		msg := &nats.Msg{Subject: target.args.Subject, Data: data}
+		if target.args.JetStream.Async {
+			var future nats.PubAckFuture
+			if future, err = target.jstream.PublishMsgAsync(msg, msgID); err != nil {
+				return err
+			}
+			go target.awaitAck(future, eventKey)
+			return nil
+		}
+		_, err = target.jstream.PublishMsg(msg, msgID)
+	default:
 		err = target.natsConn.Publish(target.args.Subject, data)
 	}
-	return err
+	if err != nil || eventKey == "" {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// awaitAck waits for the JetStream acknowledgement of an asynchronously
+// published event. Unacknowledged events remain in the store, if any,
+// and are replayed.
+func (target *NATSTarget) awaitAck(future nats.PubAckFuture, eventKey string) {
+	select {
+	case <-future.Ok():
+		if eventKey != "" {
+			// The event may have been replayed and acknowledged meanwhile.
+			if err := target.store.Del(eventKey); err != nil && !os.IsNotExist(err) {
+				target.loggerOnce(context.Background(), err, target.ID())
+			}
+		}
+	case err := <-future.Err():
+		target.loggerOnce(context.Background(), err, target.ID())
+	}
 }
 
 // Send - sends event to Nats.
@@ -295,15 +437,12 @@ func (target *NATSTarget) Send(eventKey string) error {
 		return eErr
 	}
 
-	if err := target.send(eventData); err != nil {
-		return err
-	}
-
-	return target.store.Del(eventKey)
+	return target.send(eventData, eventKey)
 }
 
 // Close - closes underneath connections to NATS server.
 func (target *NATSTarget) Close() (err error) {
+	target.quit.close()
 	if target.stanConn != nil {
 		// closing the streaming connection does not close the provided NATS connection.
 		if target.stanConn.NatsConn() != nil {
@@ -330,13 +469,14 @@ func NewNATSTarget(id string, args NATSArgs, doneCh <-chan struct{}, loggerOnce
 
 	target := &NATSTarget{
 		id:         event.TargetID{ID: id, Name: "nats"},
+		quit:       newQuitCh(),
 		args:       args,
 		loggerOnce: loggerOnce,
 	}
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-nats-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if oErr := store.Open(); oErr != nil {
 			target.loggerOnce(context.Background(), oErr, target.ID())
 			return target, oErr
@@ -350,6 +490,9 @@ func NewNATSTarget(id string, args NATSArgs, doneCh <-chan struct{}, loggerOnce
 	} else {
 		natsConn, err = args.connectNats()
 		target.natsConn = natsConn
+		if err == nil && args.JetStream.Enable {
+			target.jstream, err = args.connectJetStream(natsConn)
+		}
 	}
 
 	if err != nil {
@@ -361,9 +504,10 @@ func NewNATSTarget(id string, args NATSArgs, doneCh <-chan struct{}, loggerOnce
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
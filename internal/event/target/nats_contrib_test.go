@@ -17,8 +17,10 @@
 package target
 
 import (
+	"context"
 	"testing"
 
+	"github.com/minio/minio/internal/event"
 	xnet "github.com/minio/pkg/net"
 	natsserver "github.com/nats-io/nats-server/v2/test"
 )
@@ -96,3 +98,55 @@ func TestNatsConnToken(t *testing.T) {
 	}
 	defer con.Close()
 }
+
+func TestNatsJetStream(t *testing.T) {
+	opts := natsserver.DefaultTestOptions
+	opts.Port = 14226
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	s := natsserver.RunServer(&opts)
+	defer s.Shutdown()
+
+	args := NATSArgs{
+		Enable: true,
+		Address: xnet.Host{
+			Name:      "localhost",
+			Port:      (xnet.Port(opts.Port)),
+			IsPortSet: true,
+		},
+		Subject: "minio.events",
+	}
+	args.JetStream.Enable = true
+	args.JetStream.Stream = "MINIO"
+	if err := args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := NewNATSTarget("1", args, nil, func(ctx context.Context, err error, id interface{}, kind ...interface{}) {
+		t.Error(err)
+	}, true)
+	if err != nil {
+		t.Fatalf("Could not create JetStream target: %v", err)
+	}
+	defer target.Close()
+
+	var eventData event.Event
+	eventData.EventName = event.ObjectCreatedPut
+	eventData.S3.Bucket.Name = "bucket"
+	eventData.S3.Object.Key = "object"
+	eventData.S3.Object.Sequencer = "16BC8F1F0D5E8B2A"
+	// Publishing the same event twice must store it only once.
+	for i := 0; i < 2; i++ {
+		if err = target.Save(eventData); err != nil {
+			t.Fatalf("Could not publish event: %v", err)
+		}
+	}
+
+	info, err := target.jstream.StreamInfo("MINIO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State.Msgs != 1 {
+		t.Fatalf("Expected 1 message in stream, got %d", info.State.Msgs)
+	}
+}
@@ -20,34 +20,44 @@ package target
 import (
 	"context"
 	"crypto/tls"
-	"encoding/json"
 	"errors"
-	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/nsqio/go-nsq"
 
 	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/fips"
 	xnet "github.com/minio/pkg/net"
 )
 
 // NSQ constants
 const (
-	NSQAddress       = "nsqd_address"
-	NSQTopic         = "topic"
-	NSQTLS           = "tls"
-	NSQTLSSkipVerify = "tls_skip_verify"
-	NSQQueueDir      = "queue_dir"
-	NSQQueueLimit    = "queue_limit"
-
-	EnvNSQEnable        = "MINIO_NOTIFY_NSQ_ENABLE"
-	EnvNSQAddress       = "MINIO_NOTIFY_NSQ_NSQD_ADDRESS"
-	EnvNSQTopic         = "MINIO_NOTIFY_NSQ_TOPIC"
-	EnvNSQTLS           = "MINIO_NOTIFY_NSQ_TLS"
-	EnvNSQTLSSkipVerify = "MINIO_NOTIFY_NSQ_TLS_SKIP_VERIFY"
-	EnvNSQQueueDir      = "MINIO_NOTIFY_NSQ_QUEUE_DIR"
-	EnvNSQQueueLimit    = "MINIO_NOTIFY_NSQ_QUEUE_LIMIT"
+	NSQAddress         = "nsqd_address"
+	NSQTopic           = "topic"
+	NSQTLS             = "tls"
+	NSQTLSSkipVerify   = "tls_skip_verify"
+	NSQQueueDir        = "queue_dir"
+	NSQQueueLimit      = "queue_limit"
+	NSQOrderedDelivery = "ordered_delivery"
+	NSQQueueMaxAge     = "queue_max_age"
+	NSQQueueOverflow   = "queue_overflow"
+	NSQSchemaVersion   = "schema_version"
+	NSQEventFormat     = "event_format"
+
+	EnvNSQEnable          = "MINIO_NOTIFY_NSQ_ENABLE"
+	EnvNSQAddress         = "MINIO_NOTIFY_NSQ_NSQD_ADDRESS"
+	EnvNSQTopic           = "MINIO_NOTIFY_NSQ_TOPIC"
+	EnvNSQTLS             = "MINIO_NOTIFY_NSQ_TLS"
+	EnvNSQTLSSkipVerify   = "MINIO_NOTIFY_NSQ_TLS_SKIP_VERIFY"
+	EnvNSQQueueDir        = "MINIO_NOTIFY_NSQ_QUEUE_DIR"
+	EnvNSQQueueLimit      = "MINIO_NOTIFY_NSQ_QUEUE_LIMIT"
+	EnvNSQOrderedDelivery = "MINIO_NOTIFY_NSQ_ORDERED_DELIVERY"
+	EnvNSQQueueMaxAge     = "MINIO_NOTIFY_NSQ_QUEUE_MAX_AGE"
+	EnvNSQQueueOverflow   = "MINIO_NOTIFY_NSQ_QUEUE_OVERFLOW"
+	EnvNSQSchemaVersion   = "MINIO_NOTIFY_NSQ_SCHEMA_VERSION"
+	EnvNSQEventFormat     = "MINIO_NOTIFY_NSQ_EVENT_FORMAT"
 )
 
 // NSQArgs - NSQ target arguments.
@@ -59,8 +69,13 @@ type NSQArgs struct {
 		Enable     bool `json:"enable"`
 		SkipVerify bool `json:"skipVerify"`
 	} `json:"tls"`
-	QueueDir   string `json:"queueDir"`
-	QueueLimit uint64 `json:"queueLimit"`
+	QueueDir        string        `json:"queueDir"`
+	QueueLimit      uint64        `json:"queueLimit"`
+	OrderedDelivery bool          `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration `json:"queueMaxAge"`
+	QueueOverflow   string        `json:"queueOverflow"`
+	SchemaVersion   string        `json:"schemaVersion"`
+	EventFormat     string        `json:"eventFormat"`
 }
 
 // Validate NSQArgs fields
@@ -76,6 +91,15 @@ func (n NSQArgs) Validate() error {
 	if n.Topic == "" {
 		return errors.New("empty topic")
 	}
+	if err := validateEventFormat(n.EventFormat); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(n.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(n.SchemaVersion); err != nil {
+		return err
+	}
 	if n.QueueDir != "" {
 		if !filepath.IsAbs(n.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -93,6 +117,7 @@ type NSQTarget struct {
 	store      Store
 	config     *nsq.Config
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -105,6 +130,22 @@ func (target *NSQTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *NSQTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *NSQTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *NSQTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *NSQTarget) IsActive() (bool, error) {
 	if target.producer == nil {
@@ -137,15 +178,18 @@ func (target *NSQTarget) Save(eventData event.Event) error {
 	return target.send(eventData)
 }
 
-// send - sends an event to the NSQ.
-func (target *NSQTarget) send(eventData event.Event) error {
-	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+// SendTest - sends the test event to the NSQ topic, bypassing the queue store.
+func (target *NSQTarget) SendTest(eventData event.Event) error {
+	_, err := target.IsActive()
 	if err != nil {
 		return err
 	}
-	key := eventData.S3.Bucket.Name + "/" + objectName
+	return target.send(eventData)
+}
 
-	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+// send - sends an event to the NSQ.
+func (target *NSQTarget) send(eventData event.Event) error {
+	_, data, err := encodeEvent(target.args.EventFormat, eventData)
 	if err != nil {
 		return err
 	}
@@ -180,6 +224,7 @@ func (target *NSQTarget) Send(eventKey string) error {
 
 // Close - closes underneath connections to NSQD server.
 func (target *NSQTarget) Close() (err error) {
+	target.quit.close()
 	if target.producer != nil {
 		// this blocks until complete:
 		target.producer.Stop()
@@ -192,15 +237,16 @@ func NewNSQTarget(id string, args NSQArgs, doneCh <-chan struct{}, loggerOnce fu
 	config := nsq.NewConfig()
 	if args.TLS.Enable {
 		config.TlsV1 = true
-		config.TlsConfig = &tls.Config{
+		config.TlsConfig = fips.RestrictTLS(&tls.Config{
 			InsecureSkipVerify: args.TLS.SkipVerify,
-		}
+		})
 	}
 
 	var store Store
 
 	target := &NSQTarget{
 		id:         event.TargetID{ID: id, Name: "nsq"},
+		quit:       newQuitCh(),
 		args:       args,
 		config:     config,
 		loggerOnce: loggerOnce,
@@ -208,7 +254,7 @@ func NewNSQTarget(id string, args NSQArgs, doneCh <-chan struct{}, loggerOnce fu
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-nsq-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if oErr := store.Open(); oErr != nil {
 			target.loggerOnce(context.Background(), oErr, target.ID())
 			return target, oErr
@@ -233,9 +279,10 @@ func NewNSQTarget(id string, args NSQArgs, doneCh <-chan struct{}, loggerOnce fu
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -58,6 +58,10 @@ const (
 	PostgresDatabase           = "database"
 	PostgresQueueDir           = "queue_dir"
 	PostgresQueueLimit         = "queue_limit"
+	PostgresOrderedDelivery    = "ordered_delivery"
+	PostgresQueueMaxAge        = "queue_max_age"
+	PostgresQueueOverflow      = "queue_overflow"
+	PostgresSchemaVersion      = "schema_version"
 	PostgresMaxOpenConnections = "max_open_connections"
 
 	EnvPostgresEnable             = "MINIO_NOTIFY_POSTGRES_ENABLE"
@@ -71,23 +75,31 @@ const (
 	EnvPostgresDatabase           = "MINIO_NOTIFY_POSTGRES_DATABASE"
 	EnvPostgresQueueDir           = "MINIO_NOTIFY_POSTGRES_QUEUE_DIR"
 	EnvPostgresQueueLimit         = "MINIO_NOTIFY_POSTGRES_QUEUE_LIMIT"
+	EnvPostgresOrderedDelivery    = "MINIO_NOTIFY_POSTGRES_ORDERED_DELIVERY"
+	EnvPostgresQueueMaxAge        = "MINIO_NOTIFY_POSTGRES_QUEUE_MAX_AGE"
+	EnvPostgresQueueOverflow      = "MINIO_NOTIFY_POSTGRES_QUEUE_OVERFLOW"
+	EnvPostgresSchemaVersion      = "MINIO_NOTIFY_POSTGRES_SCHEMA_VERSION"
 	EnvPostgresMaxOpenConnections = "MINIO_NOTIFY_POSTGRES_MAX_OPEN_CONNECTIONS"
 )
 
 // PostgreSQLArgs - PostgreSQL target arguments.
 type PostgreSQLArgs struct {
-	Enable             bool      `json:"enable"`
-	Format             string    `json:"format"`
-	ConnectionString   string    `json:"connectionString"`
-	Table              string    `json:"table"`
-	Host               xnet.Host `json:"host"`     // default: localhost
-	Port               string    `json:"port"`     // default: 5432
-	Username           string    `json:"username"` // default: user running minio
-	Password           string    `json:"password"` // default: no password
-	Database           string    `json:"database"` // default: same as user
-	QueueDir           string    `json:"queueDir"`
-	QueueLimit         uint64    `json:"queueLimit"`
-	MaxOpenConnections int       `json:"maxOpenConnections"`
+	Enable             bool          `json:"enable"`
+	Format             string        `json:"format"`
+	ConnectionString   string        `json:"connectionString"`
+	Table              string        `json:"table"`
+	Host               xnet.Host     `json:"host"`     // default: localhost
+	Port               string        `json:"port"`     // default: 5432
+	Username           string        `json:"username"` // default: user running minio
+	Password           string        `json:"password"` // default: no password
+	Database           string        `json:"database"` // default: same as user
+	QueueDir           string        `json:"queueDir"`
+	QueueLimit         uint64        `json:"queueLimit"`
+	OrderedDelivery    bool          `json:"orderedDelivery"`
+	QueueMaxAge        time.Duration `json:"queueMaxAge"`
+	QueueOverflow      string        `json:"queueOverflow"`
+	SchemaVersion      string        `json:"schemaVersion"`
+	MaxOpenConnections int           `json:"maxOpenConnections"`
 }
 
 // Validate PostgreSQLArgs fields
@@ -121,6 +133,12 @@ func (p PostgreSQLArgs) Validate() error {
 		}
 	}
 
+	if err := validateQueueOverflow(p.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(p.SchemaVersion); err != nil {
+		return err
+	}
 	if p.QueueDir != "" {
 		if !filepath.IsAbs(p.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -146,6 +164,7 @@ type PostgreSQLTarget struct {
 	firstPing  bool
 	connString string
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -158,6 +177,22 @@ func (target *PostgreSQLTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *PostgreSQLTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *PostgreSQLTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *PostgreSQLTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *PostgreSQLTarget) IsActive() (bool, error) {
 	if target.db == nil {
@@ -192,6 +227,15 @@ func (target *PostgreSQLTarget) Save(eventData event.Event) error {
 	return target.send(eventData)
 }
 
+// SendTest - sends the test event to the PostgreSQL table, bypassing the queue store.
+func (target *PostgreSQLTarget) SendTest(eventData event.Event) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
 // IsConnErr - To detect a connection error.
 func IsConnErr(err error) bool {
 	return IsConnRefusedErr(err) || err.Error() == "sql: database is closed" || err.Error() == "sql: statement is closed" || err.Error() == "invalid connection"
@@ -276,6 +320,7 @@ func (target *PostgreSQLTarget) Send(eventKey string) error {
 
 // Close - closes underneath connections to PostgreSQL database.
 func (target *PostgreSQLTarget) Close() error {
+	target.quit.close()
 	if target.updateStmt != nil {
 		// FIXME: log returned error. ignore time being.
 		_ = target.updateStmt.Close()
@@ -353,6 +398,7 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, doneCh <-chan struct{},
 
 	target := &PostgreSQLTarget{
 		id:         event.TargetID{ID: id, Name: "postgresql"},
+		quit:       newQuitCh(),
 		args:       args,
 		firstPing:  false,
 		connString: connStr,
@@ -374,7 +420,7 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, doneCh <-chan struct{},
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-postgresql-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if oErr := store.Open(); oErr != nil {
 			target.loggerOnce(context.Background(), oErr, target.ID())
 			return target, oErr
@@ -398,9 +444,10 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, doneCh <-chan struct{},
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
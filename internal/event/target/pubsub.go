@@ -0,0 +1,356 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/api/pubsub/v1"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Google Cloud Pub/Sub constants
+const (
+	PubSubProjectID       = "project_id"
+	PubSubTopic           = "topic"
+	PubSubCredentialsFile = "credentials_file"
+	PubSubEndpoint        = "endpoint"
+	PubSubOrdering        = "ordering"
+	PubSubMaxRetries      = "max_retries"
+	PubSubRetryInterval   = "retry_interval"
+	PubSubQueueDir        = "queue_dir"
+	PubSubQueueLimit      = "queue_limit"
+	PubSubOrderedDelivery = "ordered_delivery"
+	PubSubQueueMaxAge     = "queue_max_age"
+	PubSubQueueOverflow   = "queue_overflow"
+	PubSubSchemaVersion   = "schema_version"
+	PubSubEventFormat     = "event_format"
+
+	EnvPubSubEnable          = "MINIO_NOTIFY_PUBSUB_ENABLE"
+	EnvPubSubProjectID       = "MINIO_NOTIFY_PUBSUB_PROJECT_ID"
+	EnvPubSubTopic           = "MINIO_NOTIFY_PUBSUB_TOPIC"
+	EnvPubSubCredentialsFile = "MINIO_NOTIFY_PUBSUB_CREDENTIALS_FILE"
+	EnvPubSubEndpoint        = "MINIO_NOTIFY_PUBSUB_ENDPOINT"
+	EnvPubSubOrdering        = "MINIO_NOTIFY_PUBSUB_ORDERING"
+	EnvPubSubMaxRetries      = "MINIO_NOTIFY_PUBSUB_MAX_RETRIES"
+	EnvPubSubRetryInterval   = "MINIO_NOTIFY_PUBSUB_RETRY_INTERVAL"
+	EnvPubSubQueueDir        = "MINIO_NOTIFY_PUBSUB_QUEUE_DIR"
+	EnvPubSubQueueLimit      = "MINIO_NOTIFY_PUBSUB_QUEUE_LIMIT"
+	EnvPubSubOrderedDelivery = "MINIO_NOTIFY_PUBSUB_ORDERED_DELIVERY"
+	EnvPubSubQueueMaxAge     = "MINIO_NOTIFY_PUBSUB_QUEUE_MAX_AGE"
+	EnvPubSubQueueOverflow   = "MINIO_NOTIFY_PUBSUB_QUEUE_OVERFLOW"
+	EnvPubSubSchemaVersion   = "MINIO_NOTIFY_PUBSUB_SCHEMA_VERSION"
+	EnvPubSubEventFormat     = "MINIO_NOTIFY_PUBSUB_EVENT_FORMAT"
+)
+
+// maxPubSubRetryInterval is the upper bound of the exponential
+// backoff between publish attempts.
+const maxPubSubRetryInterval = 30 * time.Second
+
+// PubSubArgs - Google Cloud Pub/Sub target arguments.
+type PubSubArgs struct {
+	Enable    bool   `json:"enable"`
+	ProjectID string `json:"projectID"`
+	Topic     string `json:"topic"`
+	// CredentialsFile is the path of a service account JSON key. If
+	// empty, the application default credentials are used, e.g. the
+	// workload identity of the GKE pod.
+	CredentialsFile string `json:"credentialsFile"`
+	// Endpoint overrides the Pub/Sub API endpoint, e.g. to use a
+	// regional endpoint or the Pub/Sub emulator.
+	Endpoint string `json:"endpoint"`
+	// Ordering publishes all events of an object with the same
+	// ordering key.
+	Ordering        bool          `json:"ordering"`
+	MaxRetries      int           `json:"maxRetries"`
+	RetryInterval   time.Duration `json:"retryInterval"`
+	QueueDir        string        `json:"queueDir"`
+	QueueLimit      uint64        `json:"queueLimit"`
+	OrderedDelivery bool          `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration `json:"queueMaxAge"`
+	QueueOverflow   string        `json:"queueOverflow"`
+	SchemaVersion   string        `json:"schemaVersion"`
+	EventFormat     string        `json:"eventFormat"`
+}
+
+// Validate PubSubArgs fields
+func (p PubSubArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+	if p.ProjectID == "" {
+		return errors.New("empty project_id")
+	}
+	if p.Topic == "" {
+		return errors.New("empty topic")
+	}
+	if p.Endpoint != "" {
+		if _, err := xnet.ParseHTTPURL(p.Endpoint); err != nil {
+			return err
+		}
+	}
+	if p.MaxRetries < 0 {
+		return errors.New("max_retries must not be negative")
+	}
+	if p.RetryInterval < 0 {
+		return errors.New("retry_interval must not be negative")
+	}
+	if err := validateEventFormat(p.EventFormat); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(p.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(p.SchemaVersion); err != nil {
+		return err
+	}
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+// PubSubTarget - Google Cloud Pub/Sub target.
+type PubSubTarget struct {
+	id         event.TargetID
+	args       PubSubArgs
+	topic      string
+	service    *pubsub.Service
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
+}
+
+// ID - returns target ID.
+func (target *PubSubTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *PubSubTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *PubSubTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *PubSubTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *PubSubTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
+// IsActive - Return true if target is up and active
+func (target *PubSubTarget) IsActive() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := target.service.Projects.Topics.Get(target.topic).Context(ctx).Do(); err != nil {
+		if isPubSubUnavailable(err) {
+			return false, errNotConnected
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Save - saves the events to the store if queuestore is configured, which will be replayed when the Pub/Sub connection is active.
+func (target *PubSubTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	err := target.send(eventData)
+	if err != nil && isPubSubUnavailable(err) {
+		return errNotConnected
+	}
+	return err
+}
+
+// SendTest - sends the test event to the Pub/Sub topic, bypassing the queue store.
+func (target *PubSubTarget) SendTest(eventData event.Event) error {
+	err := target.send(eventData)
+	if err != nil && isPubSubUnavailable(err) {
+		return errNotConnected
+	}
+	return err
+}
+
+// send - publishes an event to the Pub/Sub topic, retrying
+// transient failures with exponential backoff.
+func (target *PubSubTarget) send(eventData event.Event) error {
+	key, data, err := encodeEvent(target.args.EventFormat, eventData)
+	if err != nil {
+		return err
+	}
+
+	message := &pubsub.PubsubMessage{
+		Data: base64.StdEncoding.EncodeToString(data),
+		Attributes: map[string]string{
+			"eventName": eventData.EventName.String(),
+			"key":       key,
+		},
+	}
+	if target.args.Ordering {
+		message.OrderingKey = key
+	}
+	request := &pubsub.PublishRequest{Messages: []*pubsub.PubsubMessage{message}}
+
+	interval := target.args.RetryInterval
+	for retry := 0; ; retry++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err = target.service.Projects.Topics.Publish(target.topic, request).Context(ctx).Do()
+		cancel()
+		if err == nil || retry >= target.args.MaxRetries || !isPubSubRetryable(err) {
+			return err
+		}
+
+		time.Sleep(interval)
+		if interval *= 2; interval > maxPubSubRetryInterval {
+			interval = maxPubSubRetryInterval
+		}
+	}
+}
+
+// Send - reads an event from store and sends it to Pub/Sub.
+func (target *PubSubTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and would've been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		if isPubSubUnavailable(err) {
+			return errNotConnected
+		}
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - does nothing and available for interface compatibility.
+func (target *PubSubTarget) Close() error {
+	target.quit.close()
+	return nil
+}
+
+// isPubSubUnavailable returns true if the Pub/Sub API could
+// not be reached.
+func isPubSubUnavailable(err error) bool {
+	if xnet.IsNetworkOrHostDown(err, false) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusServiceUnavailable
+}
+
+// isPubSubRetryable returns true if publishing may succeed
+// when retried.
+func isPubSubRetryable(err error) bool {
+	if isPubSubUnavailable(err) {
+		return true
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// NewPubSubTarget - creates new Google Cloud Pub/Sub target.
+func NewPubSubTarget(ctx context.Context, id string, args PubSubArgs, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*PubSubTarget, error) {
+	target := &PubSubTarget{
+		id:         event.TargetID{ID: id, Name: "pubsub"},
+		quit:       newQuitCh(),
+		args:       args,
+		topic:      "projects/" + args.ProjectID + "/topics/" + args.Topic,
+		loggerOnce: loggerOnce,
+	}
+
+	opts := []option.ClientOption{option.WithUserAgent("MinIO")}
+	if args.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(args.Endpoint))
+	}
+	if args.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(args.CredentialsFile))
+	}
+
+	service, err := pubsub.NewService(ctx, opts...)
+	if err != nil {
+		target.loggerOnce(ctx, err, target.ID())
+		return target, err
+	}
+	target.service = service
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pubsub-"+id)
+		store := newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
+		if err := store.Open(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		target.store = store
+	}
+
+	_, err = target.IsActive()
+	if err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(ctx, err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		stopCh := target.quit.or(ctx.Done())
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/pubsub/v1"
+
+	"github.com/minio/minio/internal/event"
+)
+
+func TestPubSubArgsValidate(t *testing.T) {
+	testCases := []struct {
+		args    PubSubArgs
+		wantErr bool
+	}{
+		{PubSubArgs{}, false},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "topic"}, false},
+		{PubSubArgs{Enable: true, Topic: "topic"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "topic", MaxRetries: -1}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "topic", QueueDir: "relative/dir"}, true},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.args.Validate(); (err != nil) != testCase.wantErr {
+			t.Errorf("Test %d: got error %v, want error: %v", i+1, err, testCase.wantErr)
+		}
+	}
+}
+
+func TestPubSubSend(t *testing.T) {
+	var requests int32
+	var published pubsub.PublishRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/project/topics/topic:publish" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Fail the first attempt to verify that it is retried.
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&published); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"messageIds":["1"]}`))
+	}))
+	defer server.Close()
+
+	service, err := pubsub.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := &PubSubTarget{
+		args:    PubSubArgs{Ordering: true, MaxRetries: 1},
+		topic:   "projects/project/topics/topic",
+		service: service,
+	}
+
+	var eventData event.Event
+	eventData.EventName = event.ObjectCreatedPut
+	eventData.S3.Bucket.Name = "bucket"
+	eventData.S3.Object.Key = "photos%2Fimage.png"
+	if err = target.send(eventData); err != nil {
+		t.Fatalf("Failed to publish event: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("Expected 2 publish requests, got %d", requests)
+	}
+	if len(published.Messages) != 1 {
+		t.Fatalf("Expected a single message, got %d", len(published.Messages))
+	}
+	message := published.Messages[0]
+	if message.OrderingKey != "bucket/photos/image.png" {
+		t.Errorf("Unexpected ordering key %q", message.OrderingKey)
+	}
+	data, err := base64.StdEncoding.DecodeString(message.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log event.Log
+	if err = json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Key != "bucket/photos/image.png" || log.EventName != event.ObjectCreatedPut {
+		t.Errorf("Unexpected event log %+v", log)
+	}
+}
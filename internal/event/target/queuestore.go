@@ -18,13 +18,17 @@
 package target
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/minio/minio/internal/event"
 )
@@ -34,24 +38,72 @@ const (
 	eventExt     = ".event"
 )
 
+// Maximum time Put waits for space in a full store with the block
+// overflow policy.
+var queueBlockTimeout = time.Minute
+
+// Overflow policies of a full queue store.
+const (
+	// QueueOverflowReject - new events are rejected.
+	QueueOverflowReject = "reject"
+	// QueueOverflowBlock - new events wait for space, up to a minute.
+	QueueOverflowBlock = "block"
+	// QueueOverflowDropOldest - the oldest events are dropped.
+	QueueOverflowDropOldest = "drop_oldest"
+)
+
+// errEventExpired - the event was queued longer than the maximum age.
+var errEventExpired error = &deliveryError{"queued event exceeded the maximum age", event.DeliveryErrExpired}
+
 // QueueStore - Filestore for persisting events.
 type QueueStore struct {
 	sync.RWMutex
 	currentEntries uint64
 	entryLimit     uint64
 	directory      string
+	// Time of the last key, keys are strictly increasing.
+	lastKeyTime int64
+
+	// Events older than maxAge are dropped, if set.
+	maxAge   time.Duration
+	overflow string
+	// Signaled whenever an event is deleted.
+	freed *sync.Cond
+	// Called for every event dropped by the store.
+	onDrop func(key string, err error)
 }
 
 // NewQueueStore - Creates an instance for QueueStore.
 func NewQueueStore(directory string, limit uint64) Store {
+	return newQueueStore(directory, limit, 0, QueueOverflowReject)
+}
+
+func newQueueStore(directory string, limit uint64, maxAge time.Duration, overflow string) *QueueStore {
 	if limit == 0 {
 		limit = defaultLimit
 	}
 
-	return &QueueStore{
+	store := &QueueStore{
 		directory:  directory,
 		entryLimit: limit,
+		maxAge:     maxAge,
+		overflow:   overflow,
 	}
+	store.freed = sync.NewCond(&store.RWMutex)
+	return store
+}
+
+// newTargetQueueStore - creates the queue store of a target, events
+// dropped by the store are logged and count as failed deliveries.
+func newTargetQueueStore(id event.TargetID, directory string, limit uint64, maxAge time.Duration, overflow string,
+	loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}),
+) Store {
+	store := newQueueStore(directory, limit, maxAge, overflow)
+	store.onDrop = func(key string, err error) {
+		event.RecordDelivery(id, 0, err)
+		loggerOnce(context.Background(), fmt.Errorf("dropped queued event %s: %w", key, err), id)
+	}
+	return store
 }
 
 // Open - Creates the directory if not present.
@@ -69,7 +121,7 @@ func (store *QueueStore) Open() error {
 	}
 
 	currentEntries := uint64(len(names))
-	if currentEntries >= store.entryLimit {
+	if currentEntries >= store.entryLimit && store.rejectsOverflow() {
 		return errLimitExceeded
 	}
 
@@ -97,20 +149,82 @@ func (store *QueueStore) write(key string, e event.Event) error {
 	return nil
 }
 
+func (store *QueueStore) rejectsOverflow() bool {
+	return store.overflow != QueueOverflowBlock && store.overflow != QueueOverflowDropOldest
+}
+
 // Put - puts a event to the store.
 func (store *QueueStore) Put(e event.Event) error {
 	store.Lock()
 	defer store.Unlock()
 	if store.currentEntries >= store.entryLimit {
-		return errLimitExceeded
+		if err := store.overflowed(); err != nil {
+			return err
+		}
 	}
-	key, err := getNewUUID()
+	key, err := store.newKey()
 	if err != nil {
 		return err
 	}
 	return store.write(key, e)
 }
 
+// overflowed - applies the overflow policy to the full store, returns
+// an error if there is no space for a new event.
+func (store *QueueStore) overflowed() error {
+	switch store.overflow {
+	case QueueOverflowBlock:
+		deadline := time.Now().Add(queueBlockTimeout)
+		timer := time.AfterFunc(queueBlockTimeout, store.freed.Broadcast)
+		defer timer.Stop()
+		for store.currentEntries >= store.entryLimit {
+			if !time.Now().Before(deadline) {
+				return errLimitExceeded
+			}
+			store.freed.Wait()
+		}
+		return nil
+	case QueueOverflowDropOldest:
+		names, err := store.list()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if store.currentEntries < store.entryLimit {
+				break
+			}
+			key := strings.TrimSuffix(name, eventExt)
+			if err = store.del(key); err != nil {
+				return err
+			}
+			store.dropped(key, errLimitExceeded)
+		}
+		return nil
+	}
+	return errLimitExceeded
+}
+
+func (store *QueueStore) dropped(key string, err error) {
+	if store.onDrop != nil {
+		store.onDrop(key, err)
+	}
+}
+
+// newKey - returns a key starting with the current time, so that
+// entries with the same modification time are listed in order.
+func (store *QueueStore) newKey() (string, error) {
+	uuid, err := getNewUUID()
+	if err != nil {
+		return "", err
+	}
+	t := time.Now().UnixNano()
+	if t <= store.lastKeyTime {
+		t = store.lastKeyTime + 1
+	}
+	store.lastKeyTime = t
+	return fmt.Sprintf("%020d-%s", t, uuid), nil
+}
+
 // Get - gets a event from the store.
 func (store *QueueStore) Get(key string) (event event.Event, err error) {
 	store.RLock()
@@ -166,14 +280,37 @@ func (store *QueueStore) del(key string) error {
 	if store.currentEntries == math.MaxUint64 {
 		store.currentEntries = 0
 	}
+	store.freed.Broadcast()
 	return nil
 }
 
-// List - lists all files from the directory.
+// List - lists all files from the directory, events older than the
+// maximum age are dropped.
 func (store *QueueStore) List() ([]string, error) {
-	store.RLock()
-	defer store.RUnlock()
-	return store.list()
+	if store.maxAge <= 0 {
+		store.RLock()
+		defer store.RUnlock()
+		return store.list()
+	}
+
+	store.Lock()
+	defer store.Unlock()
+	names, err := store.list()
+	if err != nil {
+		return names, err
+	}
+	live := names[:0]
+	for _, name := range names {
+		key := strings.TrimSuffix(name, eventExt)
+		if queued, ok := eventKeyTime(key); ok && time.Since(queued) > store.maxAge {
+			if err = store.del(key); err == nil {
+				store.dropped(key, errEventExpired)
+				continue
+			}
+		}
+		live = append(live, name)
+	}
+	return live, nil
 }
 
 // list lock less.
@@ -186,6 +323,9 @@ func (store *QueueStore) list() ([]string, error) {
 
 	// Sort the dentries.
 	sort.Slice(files, func(i, j int) bool {
+		if files[i].ModTime().Equal(files[j].ModTime()) {
+			return files[i].Name() < files[j].Name()
+		}
 		return files[i].ModTime().Before(files[j].ModTime())
 	})
 
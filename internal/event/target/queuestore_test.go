@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/minio/internal/event"
 )
@@ -211,3 +212,147 @@ func TestQueueStoreListN(t *testing.T) {
 		t.Fatalf("Expected List() to fail with os.ErrNotExist, %s", err)
 	}
 }
+
+// TestStoreBacklog - tests for storeBacklog
+func TestStoreBacklog(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	n, oldest, err := storeBacklog(nil)
+	if err != nil || n != 0 || !oldest.IsZero() {
+		t.Fatalf("expected an empty backlog without a store, got %d, %v, %v", n, oldest, err)
+	}
+
+	store, err := setUpStore(queueDir, 10)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	before := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := store.Put(testEvent); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+
+	n, oldest, err = storeBacklog(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("expected a backlog of 3 events, got %d", n)
+	}
+	if oldest.Before(before) || oldest.After(time.Now()) {
+		t.Fatalf("unexpected time of the oldest event %v", oldest)
+	}
+}
+
+func openQueueStore(t *testing.T, limit uint64, maxAge time.Duration, overflow string) *QueueStore {
+	store := newQueueStore(queueDir, limit, maxAge, overflow)
+	if err := store.Open(); err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	return store
+}
+
+// TestQueueStoreDropOldest - tests the drop_oldest overflow policy.
+func TestQueueStoreDropOldest(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	store := openQueueStore(t, 3, 0, QueueOverflowDropOldest)
+	var dropped []string
+	store.onDrop = func(key string, err error) {
+		if err != errLimitExceeded {
+			t.Errorf("unexpected drop error %v", err)
+		}
+		dropped = append(dropped, key)
+	}
+	for i := 0; i < 3; i++ {
+		if err := store.Put(testEvent); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Put(testEvent); err != nil {
+		t.Fatal("Expected the oldest event to be dropped, got ", err)
+	}
+	if len(dropped) != 1 || dropped[0]+eventExt != names[0] {
+		t.Fatalf("expected %s to be dropped, got %v", names[0], dropped)
+	}
+	if names, err = store.List(); err != nil || len(names) != 3 {
+		t.Fatalf("List() Expected: 3, got %d, %v", len(names), err)
+	}
+}
+
+// TestQueueStoreBlock - tests the block overflow policy.
+func TestQueueStoreBlock(t *testing.T) {
+	defer func(timeout time.Duration) {
+		queueBlockTimeout = timeout
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}(queueBlockTimeout)
+	queueBlockTimeout = 100 * time.Millisecond
+
+	store := openQueueStore(t, 1, 0, QueueOverflowBlock)
+	if err := store.Put(testEvent); err != nil {
+		t.Fatal("Failed to put to queue store ", err)
+	}
+	// Nothing is delivered, Put gives up after the timeout.
+	if err := store.Put(testEvent); err != errLimitExceeded {
+		t.Fatalf("Expected to fail with %s, got %v", errLimitExceeded, err)
+	}
+
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		store.Del(strings.TrimSuffix(names[0], eventExt))
+	}()
+	if err = store.Put(testEvent); err != nil {
+		t.Fatal("Expected Put to succeed once an event is deleted, got ", err)
+	}
+}
+
+// TestQueueStoreMaxAge - tests that List drops events older than the maximum age.
+func TestQueueStoreMaxAge(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	store := openQueueStore(t, 10, 50*time.Millisecond, QueueOverflowReject)
+	var expired int
+	store.onDrop = func(key string, err error) {
+		if event.DeliveryErrorClass(err) != event.DeliveryErrExpired {
+			t.Errorf("unexpected drop error %v", err)
+		}
+		expired++
+	}
+	for i := 0; i < 2; i++ {
+		if err := store.Put(testEvent); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := store.Put(testEvent); err != nil {
+		t.Fatal("Failed to put to queue store ", err)
+	}
+
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || expired != 2 {
+		t.Fatalf("expected 2 expired and 1 queued event, got %d and %d", expired, len(names))
+	}
+}
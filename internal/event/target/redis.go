@@ -35,31 +35,43 @@ import (
 
 // Redis constants
 const (
-	RedisFormat     = "format"
-	RedisAddress    = "address"
-	RedisPassword   = "password"
-	RedisKey        = "key"
-	RedisQueueDir   = "queue_dir"
-	RedisQueueLimit = "queue_limit"
-
-	EnvRedisEnable     = "MINIO_NOTIFY_REDIS_ENABLE"
-	EnvRedisFormat     = "MINIO_NOTIFY_REDIS_FORMAT"
-	EnvRedisAddress    = "MINIO_NOTIFY_REDIS_ADDRESS"
-	EnvRedisPassword   = "MINIO_NOTIFY_REDIS_PASSWORD"
-	EnvRedisKey        = "MINIO_NOTIFY_REDIS_KEY"
-	EnvRedisQueueDir   = "MINIO_NOTIFY_REDIS_QUEUE_DIR"
-	EnvRedisQueueLimit = "MINIO_NOTIFY_REDIS_QUEUE_LIMIT"
+	RedisFormat          = "format"
+	RedisAddress         = "address"
+	RedisPassword        = "password"
+	RedisKey             = "key"
+	RedisQueueDir        = "queue_dir"
+	RedisQueueLimit      = "queue_limit"
+	RedisOrderedDelivery = "ordered_delivery"
+	RedisQueueMaxAge     = "queue_max_age"
+	RedisQueueOverflow   = "queue_overflow"
+	RedisSchemaVersion   = "schema_version"
+
+	EnvRedisEnable          = "MINIO_NOTIFY_REDIS_ENABLE"
+	EnvRedisFormat          = "MINIO_NOTIFY_REDIS_FORMAT"
+	EnvRedisAddress         = "MINIO_NOTIFY_REDIS_ADDRESS"
+	EnvRedisPassword        = "MINIO_NOTIFY_REDIS_PASSWORD"
+	EnvRedisKey             = "MINIO_NOTIFY_REDIS_KEY"
+	EnvRedisQueueDir        = "MINIO_NOTIFY_REDIS_QUEUE_DIR"
+	EnvRedisQueueLimit      = "MINIO_NOTIFY_REDIS_QUEUE_LIMIT"
+	EnvRedisOrderedDelivery = "MINIO_NOTIFY_REDIS_ORDERED_DELIVERY"
+	EnvRedisQueueMaxAge     = "MINIO_NOTIFY_REDIS_QUEUE_MAX_AGE"
+	EnvRedisQueueOverflow   = "MINIO_NOTIFY_REDIS_QUEUE_OVERFLOW"
+	EnvRedisSchemaVersion   = "MINIO_NOTIFY_REDIS_SCHEMA_VERSION"
 )
 
 // RedisArgs - Redis target arguments.
 type RedisArgs struct {
-	Enable     bool      `json:"enable"`
-	Format     string    `json:"format"`
-	Addr       xnet.Host `json:"address"`
-	Password   string    `json:"password"`
-	Key        string    `json:"key"`
-	QueueDir   string    `json:"queueDir"`
-	QueueLimit uint64    `json:"queueLimit"`
+	Enable          bool          `json:"enable"`
+	Format          string        `json:"format"`
+	Addr            xnet.Host     `json:"address"`
+	Password        string        `json:"password"`
+	Key             string        `json:"key"`
+	QueueDir        string        `json:"queueDir"`
+	QueueLimit      uint64        `json:"queueLimit"`
+	OrderedDelivery bool          `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration `json:"queueMaxAge"`
+	QueueOverflow   string        `json:"queueOverflow"`
+	SchemaVersion   string        `json:"schemaVersion"`
 }
 
 // RedisAccessEvent holds event log data and timestamp
@@ -85,6 +97,12 @@ func (r RedisArgs) Validate() error {
 		return fmt.Errorf("empty key")
 	}
 
+	if err := validateQueueOverflow(r.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(r.SchemaVersion); err != nil {
+		return err
+	}
 	if r.QueueDir != "" {
 		if !filepath.IsAbs(r.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -122,6 +140,7 @@ type RedisTarget struct {
 	store      Store
 	firstPing  bool
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -134,6 +153,22 @@ func (target *RedisTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *RedisTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *RedisTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *RedisTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *RedisTarget) IsActive() (bool, error) {
 	conn := target.pool.Get()
@@ -163,6 +198,15 @@ func (target *RedisTarget) Save(eventData event.Event) error {
 	return target.send(eventData)
 }
 
+// SendTest - sends the test event to the Redis key, bypassing the queue store.
+func (target *RedisTarget) SendTest(eventData event.Event) error {
+	_, err := target.IsActive()
+	if err != nil {
+		return err
+	}
+	return target.send(eventData)
+}
+
 // send - sends an event to the redis.
 func (target *RedisTarget) send(eventData event.Event) error {
 	conn := target.pool.Get()
@@ -254,6 +298,7 @@ func (target *RedisTarget) Send(eventKey string) error {
 
 // Close - releases the resources used by the pool.
 func (target *RedisTarget) Close() error {
+	target.quit.close()
 	return target.pool.Close()
 }
 
@@ -297,6 +342,7 @@ func NewRedisTarget(id string, args RedisArgs, doneCh <-chan struct{}, loggerOnc
 
 	target := &RedisTarget{
 		id:         event.TargetID{ID: id, Name: "redis"},
+		quit:       newQuitCh(),
 		args:       args,
 		pool:       pool,
 		loggerOnce: loggerOnce,
@@ -304,7 +350,7 @@ func NewRedisTarget(id string, args RedisArgs, doneCh <-chan struct{}, loggerOnc
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-redis-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if oErr := store.Open(); oErr != nil {
 			target.loggerOnce(context.Background(), oErr, target.ID())
 			return target, oErr
@@ -334,9 +380,10 @@ func NewRedisTarget(id string, args RedisArgs, doneCh <-chan struct{}, loggerOnc
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, doneCh, target.loggerOnce, target.ID())
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, doneCh, target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -0,0 +1,439 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Amazon SQS constants
+const (
+	SQSQueueURL        = "queue_url"
+	SQSRegion          = "region"
+	SQSAccessKey       = "access_key"
+	SQSSecretKey       = "secret_key"
+	SQSSessionToken    = "session_token"
+	SQSQueueDir        = "queue_dir"
+	SQSQueueLimit      = "queue_limit"
+	SQSOrderedDelivery = "ordered_delivery"
+	SQSQueueMaxAge     = "queue_max_age"
+	SQSQueueOverflow   = "queue_overflow"
+	SQSSchemaVersion   = "schema_version"
+	SQSEventFormat     = "event_format"
+
+	EnvSQSEnable          = "MINIO_NOTIFY_SQS_ENABLE"
+	EnvSQSQueueURL        = "MINIO_NOTIFY_SQS_QUEUE_URL"
+	EnvSQSRegion          = "MINIO_NOTIFY_SQS_REGION"
+	EnvSQSAccessKey       = "MINIO_NOTIFY_SQS_ACCESS_KEY"
+	EnvSQSSecretKey       = "MINIO_NOTIFY_SQS_SECRET_KEY"
+	EnvSQSSessionToken    = "MINIO_NOTIFY_SQS_SESSION_TOKEN"
+	EnvSQSQueueDir        = "MINIO_NOTIFY_SQS_QUEUE_DIR"
+	EnvSQSQueueLimit      = "MINIO_NOTIFY_SQS_QUEUE_LIMIT"
+	EnvSQSOrderedDelivery = "MINIO_NOTIFY_SQS_ORDERED_DELIVERY"
+	EnvSQSQueueMaxAge     = "MINIO_NOTIFY_SQS_QUEUE_MAX_AGE"
+	EnvSQSQueueOverflow   = "MINIO_NOTIFY_SQS_QUEUE_OVERFLOW"
+	EnvSQSSchemaVersion   = "MINIO_NOTIFY_SQS_SCHEMA_VERSION"
+	EnvSQSEventFormat     = "MINIO_NOTIFY_SQS_EVENT_FORMAT"
+)
+
+const (
+	sqsAPIVersion = "2012-11-05"
+
+	// Maximum length of a FIFO message group ID.
+	sqsMaxMessageGroupIDLen = 128
+)
+
+// SQSArgs - Amazon SQS target arguments.
+type SQSArgs struct {
+	Enable bool `json:"enable"`
+	// QueueURL of the queue, queues with a name ending in '.fifo' are
+	// FIFO queues.
+	QueueURL xnet.URL `json:"queueURL"`
+	// Region is derived from the queue URL if empty.
+	Region string `json:"region"`
+	// If no access key is set, credentials are read from the AWS
+	// environment variables or the IAM role of the instance.
+	AccessKey       string          `json:"accessKey"`
+	SecretKey       string          `json:"secretKey"`
+	SessionToken    string          `json:"sessionToken"`
+	QueueDir        string          `json:"queueDir"`
+	QueueLimit      uint64          `json:"queueLimit"`
+	OrderedDelivery bool            `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration   `json:"queueMaxAge"`
+	QueueOverflow   string          `json:"queueOverflow"`
+	SchemaVersion   string          `json:"schemaVersion"`
+	EventFormat     string          `json:"eventFormat"`
+	Transport       *http.Transport `json:"-"`
+}
+
+// Validate SQSArgs fields
+func (s SQSArgs) Validate() error {
+	if !s.Enable {
+		return nil
+	}
+	if s.QueueURL.IsEmpty() {
+		return errors.New("empty queue_url")
+	}
+	if s.Region == "" && sqsRegionFromURL(s.QueueURL) == "" {
+		return errors.New("empty region, unable to derive it from queue_url")
+	}
+	if (s.AccessKey == "") != (s.SecretKey == "") {
+		return errors.New("access_key and secret_key must be set together")
+	}
+	if err := validateEventFormat(s.EventFormat); err != nil {
+		return err
+	}
+	if err := validateQueueOverflow(s.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(s.SchemaVersion); err != nil {
+		return err
+	}
+	if s.QueueDir != "" {
+		if !filepath.IsAbs(s.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	return nil
+}
+
+// sqsRegionFromURL returns the region of queue URLs of the form
+// https://sqs.<region>.amazonaws.com/<account>/<queue>.
+func sqsRegionFromURL(u xnet.URL) string {
+	labels := strings.Split((*url.URL)(&u).Hostname(), ".")
+	if len(labels) >= 4 && labels[0] == "sqs" && labels[2] == "amazonaws" {
+		return labels[1]
+	}
+	return ""
+}
+
+// SQSTarget - Amazon SQS target.
+type SQSTarget struct {
+	id         event.TargetID
+	args       SQSArgs
+	region     string
+	fifo       bool
+	creds      *credentials.Credentials
+	httpClient *http.Client
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
+}
+
+// ID - returns target ID.
+func (target *SQSTarget) ID() event.TargetID {
+	return target.id
+}
+
+// HasQueueStore - Checks if the queueStore has been configured for the target
+func (target *SQSTarget) HasQueueStore() bool {
+	return target.store != nil
+}
+
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *SQSTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *SQSTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *SQSTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
+// IsActive - Return true if target is up and active
+func (target *SQSTarget) IsActive() (bool, error) {
+	form := url.Values{}
+	form.Set("Action", "GetQueueAttributes")
+	form.Set("AttributeName.1", "QueueArn")
+	if err := target.call(form); err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return false, errNotConnected
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Save - saves the events to the store if queuestore is configured, which will be replayed when the SQS connection is active.
+func (target *SQSTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	err := target.send(eventData)
+	if err != nil && xnet.IsNetworkOrHostDown(err, false) {
+		return errNotConnected
+	}
+	return err
+}
+
+// SendTest - sends the test event to the SQS queue, bypassing the queue store.
+func (target *SQSTarget) SendTest(eventData event.Event) error {
+	err := target.send(eventData)
+	if err != nil && xnet.IsNetworkOrHostDown(err, false) {
+		return errNotConnected
+	}
+	return err
+}
+
+// send - sends an event to the queue. Messages sent to FIFO queues are
+// grouped by object, so that consumers receive the events of an object
+// in order, and are deduplicated by their content.
+func (target *SQSTarget) send(eventData event.Event) error {
+	key, data, err := encodeEvent(target.args.EventFormat, eventData)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("Action", "SendMessage")
+	form.Set("MessageBody", string(data))
+	if target.fifo {
+		form.Set("MessageGroupId", sqsMessageGroupID(key))
+		sum := sha256.Sum256(data)
+		form.Set("MessageDeduplicationId", hex.EncodeToString(sum[:]))
+	}
+	return target.call(form)
+}
+
+// sqsMessageGroupID returns the object key if it is a valid message
+// group ID, otherwise its hex encoded SHA-256 checksum.
+func sqsMessageGroupID(key string) string {
+	valid := len(key) <= sqsMaxMessageGroupIDLen
+	for i := 0; valid && i < len(key); i++ {
+		// Alphanumeric characters and punctuation are allowed.
+		valid = key[i] > ' ' && key[i] <= '~'
+	}
+	if valid {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// sqsError is the error response of the SQS query API.
+type sqsError struct {
+	StatusCode int    `xml:"-"`
+	Type       string `xml:"Error>Type"`
+	Code       string `xml:"Error>Code"`
+	Message    string `xml:"Error>Message"`
+}
+
+func (e *sqsError) Error() string {
+	return fmt.Sprintf("sqs: %s: %s (%d)", e.Code, e.Message, e.StatusCode)
+}
+
+// call - sends a signed request of the SQS query API to the queue.
+func (target *SQSTarget) call(form url.Values) error {
+	form.Set("Version", sqsAPIVersion)
+	body := []byte(form.Encode())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.args.QueueURL.String(), strings.NewReader(string(body)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	creds, err := target.creds.Get()
+	if err != nil {
+		return err
+	}
+	signSQSRequest(req, body, creds, target.region, "sqs", time.Now().UTC())
+
+	resp, err := target.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+	sqsErr := &sqsError{StatusCode: resp.StatusCode}
+	if err = xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(sqsErr); err != nil {
+		sqsErr.Code = resp.Status
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return errNotConnected
+	}
+	return sqsErr
+}
+
+// signSQSRequest signs the request with AWS signature version 4.
+func signSQSRequest(req *http.Request, body []byte, creds credentials.Value, region, service string, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{t.Format("20060102"), region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	hmacSHA256 := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		return h.Sum(nil)
+	}
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), t.Format("20060102"))
+	for _, s := range []string{region, service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// Send - reads an event from store and sends it to SQS.
+func (target *SQSTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and would've been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		if xnet.IsNetworkOrHostDown(err, false) {
+			return errNotConnected
+		}
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - does nothing and available for interface compatibility.
+func (target *SQSTarget) Close() error {
+	target.quit.close()
+	return nil
+}
+
+// NewSQSTarget - creates new Amazon SQS target.
+func NewSQSTarget(id string, args SQSArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), test bool) (*SQSTarget, error) {
+	target := &SQSTarget{
+		id:         event.TargetID{ID: id, Name: "sqs"},
+		quit:       newQuitCh(),
+		args:       args,
+		region:     args.Region,
+		fifo:       strings.HasSuffix(args.QueueURL.Path, ".fifo"),
+		httpClient: &http.Client{Transport: args.Transport},
+		loggerOnce: loggerOnce,
+	}
+	if target.region == "" {
+		target.region = sqsRegionFromURL(args.QueueURL)
+	}
+	if args.AccessKey != "" {
+		target.creds = credentials.NewStaticV4(args.AccessKey, args.SecretKey, args.SessionToken)
+	} else {
+		target.creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.IAM{Client: &http.Client{Transport: args.Transport}},
+		})
+	}
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-sqs-"+id)
+		store := newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
+		if err := store.Open(); err != nil {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+		target.store = store
+	}
+
+	_, err := target.IsActive()
+	if err != nil {
+		if target.store == nil || err != errNotConnected {
+			target.loggerOnce(context.Background(), err, target.ID())
+			return target, err
+		}
+	}
+
+	if target.store != nil && !test {
+		// Replays the events from the store.
+		stopCh := target.quit.or(doneCh)
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+// TestSignSQSRequest verifies the signature against the 'get-vanilla'
+// example of the AWS signature version 4 test suite.
+func TestSignSQSRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := credentials.Value{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signSQSRequest(req, nil, creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestSQSMessageGroupID(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"photos/2021/a.jpg", "photos/2021/a.jpg"},
+		{"photos/a b.jpg", "234a3fabd552d6dd42b04f289ec2d397460c810c4694a6cf3107c63737869a85"},
+		{"photos/" + strings.Repeat("a", 128), ""},
+	}
+	for i, testCase := range testCases {
+		got := sqsMessageGroupID(testCase.key)
+		if len(got) > sqsMaxMessageGroupIDLen {
+			t.Errorf("Test %d: message group ID too long: %s", i+1, got)
+		}
+		if testCase.expected != "" && got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestSQSTargetFIFO(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Form.Get("Action") == "SendMessage" {
+			form = map[string]string{}
+			for k := range r.Form {
+				form[k] = r.Form.Get(k)
+			}
+		}
+	}))
+	defer server.Close()
+
+	queueURL, err := xnet.ParseHTTPURL(server.URL + "/123456789012/events.fifo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := SQSArgs{
+		Enable:    true,
+		QueueURL:  *queueURL,
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+		Transport: http.DefaultTransport.(*http.Transport),
+	}
+	if err = args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	target, err := NewSQSTarget("1", args, nil, func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ev event.Event
+	ev.EventName = event.ObjectCreatedPut
+	ev.S3.Bucket.Name = "photos"
+	ev.S3.Object.Key = "2021/a.jpg"
+	if err = target.Save(ev); err != nil {
+		t.Fatal(err)
+	}
+	if form["MessageGroupId"] != "photos/2021/a.jpg" {
+		t.Errorf("unexpected message group ID %q", form["MessageGroupId"])
+	}
+	if len(form["MessageDeduplicationId"]) != 64 {
+		t.Errorf("unexpected message deduplication ID %q", form["MessageDeduplicationId"])
+	}
+	if !strings.Contains(form["MessageBody"], `"key":"2021/a.jpg"`) {
+		t.Errorf("unexpected message body %s", form["MessageBody"])
+	}
+}
+
+func TestSQSRegionFromURL(t *testing.T) {
+	u, err := xnet.ParseHTTPURL("https://sqs.eu-west-1.amazonaws.com/123456789012/events.fifo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region := sqsRegionFromURL(*u); region != "eu-west-1" {
+		t.Fatalf("expected eu-west-1, got %s", region)
+	}
+}
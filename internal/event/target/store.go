@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -30,11 +32,26 @@ import (
 
 const retryInterval = 3 * time.Second
 
+// deliveryError - error with the class it is reported as in the
+// delivery statistics of the target.
+type deliveryError struct {
+	msg   string
+	class string
+}
+
+func (e *deliveryError) Error() string {
+	return e.msg
+}
+
+func (e *deliveryError) DeliveryErrorClass() string {
+	return e.class
+}
+
 // errNotConnected - indicates that the target connection is not active.
-var errNotConnected = errors.New("not connected to target server/service")
+var errNotConnected error = &deliveryError{"not connected to target server/service", event.DeliveryErrConnection}
 
 // errLimitExceeded error is sent when the maximum limit is reached.
-var errLimitExceeded = errors.New("the maximum store limit reached")
+var errLimitExceeded error = &deliveryError{"the maximum store limit reached", event.DeliveryErrStoreFull}
 
 // Store - To persist the events.
 type Store interface {
@@ -45,6 +62,37 @@ type Store interface {
 	Open() error
 }
 
+// quitCh - is closed when its target is closed, to stop replaying the
+// store of the target. A target may be closed more than once.
+type quitCh struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+func newQuitCh() *quitCh {
+	return &quitCh{ch: make(chan struct{})}
+}
+
+func (q *quitCh) close() {
+	if q == nil {
+		return
+	}
+	q.once.Do(func() { close(q.ch) })
+}
+
+// or - returns a channel which is closed once either doneCh or q is closed.
+func (q *quitCh) or(doneCh <-chan struct{}) <-chan struct{} {
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		select {
+		case <-doneCh:
+		case <-q.ch:
+		}
+	}()
+	return ch
+}
+
 // replayEvents - Reads the events from the store and replays.
 func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), id event.TargetID) <-chan string {
 	eventKeyCh := make(chan string)
@@ -83,6 +131,39 @@ func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx conte
 	return eventKeyCh
 }
 
+// eventKeyTime - returns the time the event with the given store key
+// was queued, keys of older releases do not contain it.
+func eventKeyTime(key string) (time.Time, bool) {
+	i := strings.IndexByte(key, '-')
+	if i < 0 {
+		return time.Time{}, false
+	}
+	nsec, err := strconv.ParseInt(key[:i], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nsec), true
+}
+
+// storeBacklog - returns the number of events in the store and the
+// time the oldest of them was queued.
+func storeBacklog(store Store) (n int, oldest time.Time, err error) {
+	if store == nil {
+		return 0, oldest, nil
+	}
+	names, err := store.List()
+	if err != nil {
+		return 0, oldest, err
+	}
+	for _, name := range names {
+		t, ok := eventKeyTime(strings.TrimSuffix(name, eventExt))
+		if ok && (oldest.IsZero() || t.Before(oldest)) {
+			oldest = t
+		}
+	}
+	return len(names), oldest, nil
+}
+
 // IsConnRefusedErr - To check fot "connection refused" error.
 func IsConnRefusedErr(err error) bool {
 	return errors.Is(err, syscall.ECONNREFUSED)
@@ -105,6 +186,11 @@ func sendEvents(target event.Target, eventKeyCh <-chan string, doneCh <-chan str
 	send := func(eventKey string) bool {
 		for {
 			err := target.Send(eventKey)
+			var latency time.Duration
+			if queued, ok := eventKeyTime(eventKey); ok {
+				latency = time.Since(queued)
+			}
+			event.RecordDelivery(target.ID(), latency, err)
 			if err == nil {
 				break
 			}
@@ -20,16 +20,19 @@ package target
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -41,32 +44,77 @@ import (
 
 // Webhook constants
 const (
-	WebhookEndpoint   = "endpoint"
-	WebhookAuthToken  = "auth_token"
-	WebhookQueueDir   = "queue_dir"
-	WebhookQueueLimit = "queue_limit"
-	WebhookClientCert = "client_cert"
-	WebhookClientKey  = "client_key"
-
-	EnvWebhookEnable     = "MINIO_NOTIFY_WEBHOOK_ENABLE"
-	EnvWebhookEndpoint   = "MINIO_NOTIFY_WEBHOOK_ENDPOINT"
-	EnvWebhookAuthToken  = "MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN"
-	EnvWebhookQueueDir   = "MINIO_NOTIFY_WEBHOOK_QUEUE_DIR"
-	EnvWebhookQueueLimit = "MINIO_NOTIFY_WEBHOOK_QUEUE_LIMIT"
-	EnvWebhookClientCert = "MINIO_NOTIFY_WEBHOOK_CLIENT_CERT"
-	EnvWebhookClientKey  = "MINIO_NOTIFY_WEBHOOK_CLIENT_KEY"
+	WebhookEndpoint        = "endpoint"
+	WebhookAuthToken       = "auth_token"
+	WebhookQueueDir        = "queue_dir"
+	WebhookQueueLimit      = "queue_limit"
+	WebhookOrderedDelivery = "ordered_delivery"
+	WebhookQueueMaxAge     = "queue_max_age"
+	WebhookQueueOverflow   = "queue_overflow"
+	WebhookSchemaVersion   = "schema_version"
+	WebhookEventFormat     = "event_format"
+	WebhookCEMode          = "cloudevents_mode"
+	WebhookClientCert      = "client_cert"
+	WebhookClientKey       = "client_key"
+	WebhookHMACSecret      = "hmac_secret"
+	WebhookBatchMaxCount   = "batch_max_count"
+	WebhookBatchMaxBytes   = "batch_max_bytes"
+	WebhookBatchMaxLatency = "batch_max_latency"
+
+	EnvWebhookEnable          = "MINIO_NOTIFY_WEBHOOK_ENABLE"
+	EnvWebhookEndpoint        = "MINIO_NOTIFY_WEBHOOK_ENDPOINT"
+	EnvWebhookAuthToken       = "MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN"
+	EnvWebhookQueueDir        = "MINIO_NOTIFY_WEBHOOK_QUEUE_DIR"
+	EnvWebhookQueueLimit      = "MINIO_NOTIFY_WEBHOOK_QUEUE_LIMIT"
+	EnvWebhookOrderedDelivery = "MINIO_NOTIFY_WEBHOOK_ORDERED_DELIVERY"
+	EnvWebhookQueueMaxAge     = "MINIO_NOTIFY_WEBHOOK_QUEUE_MAX_AGE"
+	EnvWebhookQueueOverflow   = "MINIO_NOTIFY_WEBHOOK_QUEUE_OVERFLOW"
+	EnvWebhookSchemaVersion   = "MINIO_NOTIFY_WEBHOOK_SCHEMA_VERSION"
+	EnvWebhookEventFormat     = "MINIO_NOTIFY_WEBHOOK_EVENT_FORMAT"
+	EnvWebhookCEMode          = "MINIO_NOTIFY_WEBHOOK_CLOUDEVENTS_MODE"
+	EnvWebhookClientCert      = "MINIO_NOTIFY_WEBHOOK_CLIENT_CERT"
+	EnvWebhookClientKey       = "MINIO_NOTIFY_WEBHOOK_CLIENT_KEY"
+	EnvWebhookHMACSecret      = "MINIO_NOTIFY_WEBHOOK_HMAC_SECRET"
+	EnvWebhookBatchMaxCount   = "MINIO_NOTIFY_WEBHOOK_BATCH_MAX_COUNT"
+	EnvWebhookBatchMaxBytes   = "MINIO_NOTIFY_WEBHOOK_BATCH_MAX_BYTES"
+	EnvWebhookBatchMaxLatency = "MINIO_NOTIFY_WEBHOOK_BATCH_MAX_LATENCY"
+)
+
+// WebhookSignatureHeader carries the HMAC-SHA256 signatures of a request
+// in the format 't=<unix timestamp>,v1=<hex signature>[,v1=...]'. Each
+// configured secret signs '<unix timestamp>.<body>', so receivers keep
+// accepting requests while secrets are rotated.
+const WebhookSignatureHeader = "X-Minio-Signature"
+
+// CloudEvents HTTP content modes
+const (
+	// CEModeStructured sends the CloudEvent, including the
+	// event data, as request body.
+	CEModeStructured = "structured"
+
+	// CEModeBinary sends the event data as request body
+	// and the CloudEvent attributes as headers.
+	CEModeBinary = "binary"
 )
 
 // WebhookArgs - Webhook target arguments.
 type WebhookArgs struct {
-	Enable     bool            `json:"enable"`
-	Endpoint   xnet.URL        `json:"endpoint"`
-	AuthToken  string          `json:"authToken"`
-	Transport  *http.Transport `json:"-"`
-	QueueDir   string          `json:"queueDir"`
-	QueueLimit uint64          `json:"queueLimit"`
-	ClientCert string          `json:"clientCert"`
-	ClientKey  string          `json:"clientKey"`
+	Enable          bool            `json:"enable"`
+	Endpoint        xnet.URL        `json:"endpoint"`
+	AuthToken       string          `json:"authToken"`
+	Transport       *http.Transport `json:"-"`
+	QueueDir        string          `json:"queueDir"`
+	QueueLimit      uint64          `json:"queueLimit"`
+	OrderedDelivery bool            `json:"orderedDelivery"`
+	QueueMaxAge     time.Duration   `json:"queueMaxAge"`
+	QueueOverflow   string          `json:"queueOverflow"`
+	SchemaVersion   string          `json:"schemaVersion"`
+	EventFormat     string          `json:"eventFormat"`
+	CEMode          string          `json:"cloudEventsMode"`
+	ClientCert      string          `json:"clientCert"`
+	ClientKey       string          `json:"clientKey"`
+	HMACSecrets     []string        `json:"hmacSecrets"`
+	Batch           BatchArgs       `json:"batch"`
 }
 
 // Validate WebhookArgs fields
@@ -77,6 +125,26 @@ func (w WebhookArgs) Validate() error {
 	if w.Endpoint.IsEmpty() {
 		return errors.New("endpoint empty")
 	}
+	if err := validateEventFormat(w.EventFormat); err != nil {
+		return err
+	}
+	switch w.CEMode {
+	case "", CEModeStructured, CEModeBinary:
+	default:
+		return errors.New("unknown cloudevents_mode, must be 'structured' or 'binary'")
+	}
+	if err := w.Batch.Validate(); err != nil {
+		return err
+	}
+	if w.Batch.Enabled() && w.EventFormat == event.CloudEventsFormat && w.CEMode == CEModeBinary {
+		return errors.New("batching is not supported in the cloudevents binary mode")
+	}
+	if err := validateQueueOverflow(w.QueueOverflow); err != nil {
+		return err
+	}
+	if err := event.ValidateSchemaVersion(w.SchemaVersion); err != nil {
+		return err
+	}
 	if w.QueueDir != "" {
 		if !filepath.IsAbs(w.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -85,6 +153,11 @@ func (w WebhookArgs) Validate() error {
 	if w.ClientCert != "" && w.ClientKey == "" || w.ClientCert == "" && w.ClientKey != "" {
 		return errors.New("cert and key must be specified as a pair")
 	}
+	for _, secret := range w.HMACSecrets {
+		if len(secret) < 16 {
+			return errors.New("hmac_secret must be at least 16 characters long")
+		}
+	}
 	return nil
 }
 
@@ -94,7 +167,9 @@ type WebhookTarget struct {
 	args       WebhookArgs
 	httpClient *http.Client
 	store      Store
+	batch      *batch
 	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+	quit       *quitCh
 }
 
 // ID - returns target ID.
@@ -107,6 +182,22 @@ func (target *WebhookTarget) HasQueueStore() bool {
 	return target.store != nil
 }
 
+// Backlog - returns the number of undelivered events in the queue store
+// and the time the oldest of them was queued.
+func (target *WebhookTarget) Backlog() (int, time.Time, error) {
+	return storeBacklog(target.store)
+}
+
+// Ordered - returns true if events of an object must be delivered in order.
+func (target *WebhookTarget) Ordered() bool {
+	return target.args.OrderedDelivery
+}
+
+// SchemaVersion - returns the event schema version the target is pinned to.
+func (target *WebhookTarget) SchemaVersion() string {
+	return target.args.SchemaVersion
+}
+
 // IsActive - Return true if target is up and active
 func (target *WebhookTarget) IsActive() (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -138,7 +229,12 @@ func (target *WebhookTarget) Save(eventData event.Event) error {
 	if target.store != nil {
 		return target.store.Put(eventData)
 	}
-	err := target.send(eventData)
+	var err error
+	if target.batch != nil {
+		err = target.batch.add("", eventData)
+	} else {
+		err = target.send(eventData)
+	}
 	if err != nil {
 		if xnet.IsNetworkOrHostDown(err, false) {
 			return errNotConnected
@@ -147,23 +243,75 @@ func (target *WebhookTarget) Save(eventData event.Event) error {
 	return err
 }
 
+// SendTest - sends the test event to the webhook endpoint, bypassing the queue store and batching.
+func (target *WebhookTarget) SendTest(eventData event.Event) error {
+	err := target.send(eventData)
+	if err != nil && xnet.IsNetworkOrHostDown(err, false) {
+		return errNotConnected
+	}
+	return err
+}
+
 // send - sends an event to the webhook.
 func (target *WebhookTarget) send(eventData event.Event) error {
-	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
-	if err != nil {
+	var (
+		data     []byte
+		ceHeader http.Header
+		err      error
+	)
+	if target.args.EventFormat == event.CloudEventsFormat && target.args.CEMode == CEModeBinary {
+		ce := event.NewCloudEvent(eventData)
+		if data, err = json.Marshal(ce.Data); err != nil {
+			return err
+		}
+		ceHeader = http.Header{}
+		ceHeader.Set("ce-specversion", ce.SpecVersion)
+		ceHeader.Set("ce-id", ce.ID)
+		ceHeader.Set("ce-source", ce.Source)
+		ceHeader.Set("ce-type", ce.Type)
+		ceHeader.Set("ce-schemaversion", ce.SchemaVersion)
+		if ce.Subject != "" {
+			ceHeader.Set("ce-subject", ce.Subject)
+		}
+		if ce.Time != "" {
+			ceHeader.Set("ce-time", ce.Time)
+		}
+	} else if _, data, err = encodeEvent(target.args.EventFormat, eventData); err != nil {
 		return err
 	}
-	key := eventData.S3.Bucket.Name + "/" + objectName
 
-	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	contentType := "application/json"
+	if ceHeader == nil && target.args.EventFormat == event.CloudEventsFormat {
+		contentType = "application/cloudevents+json"
+	}
+	return target.post(data, contentType, ceHeader)
+}
+
+// sendBatch - sends a batch of events to the webhook.
+func (target *WebhookTarget) sendBatch(events []event.Event) error {
+	data, err := encodeBatch(target.args.EventFormat, events)
 	if err != nil {
 		return err
 	}
+	contentType := "application/json"
+	if target.args.EventFormat == event.CloudEventsFormat {
+		contentType = "application/cloudevents-batch+json"
+	}
+	if err = target.post(data, contentType, nil); err != nil && xnet.IsNetworkOrHostDown(err, false) {
+		return errNotConnected
+	}
+	return err
+}
 
+// post - posts the data to the webhook endpoint.
+func (target *WebhookTarget) post(data []byte, contentType string, header http.Header) error {
 	req, err := http.NewRequest("POST", target.args.Endpoint.String(), bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 
 	// Verify if the authToken already contains
 	// <Key> <Token> like format, if this is
@@ -177,24 +325,42 @@ func (target *WebhookTarget) send(eventData event.Event) error {
 		req.Header.Set("Authorization", "Bearer "+target.args.AuthToken)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
+	if len(target.args.HMACSecrets) > 0 {
+		req.Header.Set(WebhookSignatureHeader, signWebhookPayload(target.args.HMACSecrets, time.Now(), data))
+	}
 
 	resp, err := target.httpClient.Do(req)
 	if err != nil {
-		target.Close()
+		target.httpClient.CloseIdleConnections()
 		return err
 	}
 	defer resp.Body.Close()
 	io.Copy(ioutil.Discard, resp.Body)
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		target.Close()
+		target.httpClient.CloseIdleConnections()
 		return fmt.Errorf("sending event failed with %v", resp.Status)
 	}
 
 	return nil
 }
 
+// signWebhookPayload - returns the signature header value of the payload
+// signed with every secret at the given time.
+func signWebhookPayload(secrets []string, t time.Time, data []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	sig := "t=" + timestamp
+	for _, secret := range secrets {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp))
+		mac.Write([]byte{'.'})
+		mac.Write(data)
+		sig += ",v1=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	return sig
+}
+
 // Send - reads an event from store and sends it to webhook.
 func (target *WebhookTarget) Send(eventKey string) error {
 	eventData, eErr := target.store.Get(eventKey)
@@ -207,6 +373,10 @@ func (target *WebhookTarget) Send(eventKey string) error {
 		return eErr
 	}
 
+	if target.batch != nil {
+		return target.batch.add(eventKey, eventData)
+	}
+
 	if err := target.send(eventData); err != nil {
 		if xnet.IsNetworkOrHostDown(err, false) {
 			return errNotConnected
@@ -218,11 +388,16 @@ func (target *WebhookTarget) Send(eventKey string) error {
 	return target.store.Del(eventKey)
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - delivers pending batched events and closes idle connections.
 func (target *WebhookTarget) Close() error {
+	target.quit.close()
+	var err error
+	if target.batch != nil {
+		err = target.batch.close()
+	}
 	// Close idle connection with "keep-alive" states
 	target.httpClient.CloseIdleConnections()
-	return nil
+	return err
 }
 
 // NewWebhookTarget - creates new Webhook target.
@@ -230,6 +405,7 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 	var store Store
 	target := &WebhookTarget{
 		id:         event.TargetID{ID: id, Name: "webhook"},
+		quit:       newQuitCh(),
 		args:       args,
 		loggerOnce: loggerOnce,
 	}
@@ -240,13 +416,19 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 			return target, err
 		}
 		manager.ReloadOnSignal(syscall.SIGHUP) // allow reloads upon SIGHUP
+		// The transport is shared by all webhook targets, the client
+		// certificate must only be presented by this one.
+		transport = transport.Clone()
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
 		transport.TLSClientConfig.GetClientCertificate = manager.GetClientCertificate
 	}
 	target.httpClient = &http.Client{Transport: transport}
 
 	if args.QueueDir != "" {
 		queueDir := filepath.Join(args.QueueDir, storePrefix+"-webhook-"+id)
-		store = NewQueueStore(queueDir, args.QueueLimit)
+		store = newTargetQueueStore(target.ID(), queueDir, args.QueueLimit, args.QueueMaxAge, args.QueueOverflow, loggerOnce)
 		if err := store.Open(); err != nil {
 			target.loggerOnce(context.Background(), err, target.ID())
 			return target, err
@@ -254,6 +436,10 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 		target.store = store
 	}
 
+	if args.Batch.Enabled() {
+		target.batch = newBatch(args.Batch, target.store, target.sendBatch, target.loggerOnce, target.ID())
+	}
+
 	_, err := target.IsActive()
 	if err != nil {
 		if target.store == nil || err != errNotConnected {
@@ -264,9 +450,10 @@ func NewWebhookTarget(ctx context.Context, id string, args WebhookArgs, loggerOn
 
 	if target.store != nil && !test {
 		// Replays the events from the store.
-		eventKeyCh := replayEvents(target.store, ctx.Done(), target.loggerOnce, target.ID())
+		stopCh := target.quit.or(ctx.Done())
+		eventKeyCh := replayEvents(target.store, stopCh, target.loggerOnce, target.ID())
 		// Start replaying events from the store.
-		go sendEvents(target, eventKeyCh, ctx.Done(), target.loggerOnce)
+		go sendEvents(target, eventKeyCh, stopCh, target.loggerOnce)
 	}
 
 	return target, nil
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/event"
+	xnet "github.com/minio/pkg/net"
+)
+
+func verifyWebhookSignature(header, secret string, body []byte) bool {
+	var timestamp string
+	var sigs []string
+	for _, part := range strings.Split(header, ",") {
+		switch {
+		case strings.HasPrefix(part, "t="):
+			timestamp = strings.TrimPrefix(part, "t=")
+		case strings.HasPrefix(part, "v1="):
+			sigs = append(sigs, strings.TrimPrefix(part, "v1="))
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	for _, sig := range sigs {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWebhookHMACSignature(t *testing.T) {
+	const (
+		oldSecret = "0123456789abcdef-old"
+		newSecret = "0123456789abcdef-new"
+	)
+	received := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		sig := r.Header.Get(WebhookSignatureHeader)
+		received <- verifyWebhookSignature(sig, oldSecret, body) && verifyWebhookSignature(sig, newSecret, body)
+	}))
+	defer server.Close()
+
+	endpoint, err := xnet.ParseHTTPURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := WebhookArgs{
+		Enable:      true,
+		Endpoint:    *endpoint,
+		HMACSecrets: []string{newSecret, oldSecret},
+	}
+	if err = args.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	target, err := NewWebhookTarget(context.Background(), "1", args, func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}, &http.Transport{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = target.Save(event.Event{EventName: event.ObjectCreatedPut}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ok := <-received:
+		if !ok {
+			t.Fatal("request signature does not verify with both secrets")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no request received")
+	}
+}
+
+func TestWebhookHMACSecretValidate(t *testing.T) {
+	endpoint, err := xnet.ParseHTTPURL("http://localhost:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := WebhookArgs{Enable: true, Endpoint: *endpoint, HMACSecrets: []string{"short"}}
+	if err = args.Validate(); err == nil {
+		t.Fatal("expected an error for a short secret")
+	}
+}
@@ -20,8 +20,14 @@ package event
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
+// maxInflightEvents is the maximum number of events being saved to a
+// target at a time. Send blocks once it is reached, e.g. while the queue
+// store of the target is full and blocks new events.
+const maxInflightEvents = 1000
+
 // Target - event target interface
 type Target interface {
 	ID() TargetID
@@ -36,6 +42,28 @@ type Target interface {
 type TargetList struct {
 	sync.RWMutex
 	targets map[TargetID]Target
+	// Queues of targets which require ordered delivery.
+	queues map[TargetID]*orderedQueue
+	// Events being saved to each target.
+	inflight map[TargetID]*sync.WaitGroup
+	// Slots of the events being saved to each target, limiting
+	// them to maxInflightEvents.
+	slots map[TargetID]chan struct{}
+}
+
+func (list *TargetList) add(target Target) error {
+	if _, ok := list.targets[target.ID()]; ok {
+		return fmt.Errorf("target %v already exists", target.ID())
+	}
+	inflight := &sync.WaitGroup{}
+	slots := make(chan struct{}, maxInflightEvents)
+	list.targets[target.ID()] = target
+	list.inflight[target.ID()] = inflight
+	list.slots[target.ID()] = slots
+	if t, ok := target.(OrderedTarget); ok && t.Ordered() {
+		list.queues[target.ID()] = newOrderedQueue(inflight, slots)
+	}
+	return nil
 }
 
 // Add - adds unique target to target list.
@@ -44,10 +72,9 @@ func (list *TargetList) Add(targets ...Target) error {
 	defer list.Unlock()
 
 	for _, target := range targets {
-		if _, ok := list.targets[target.ID()]; ok {
-			return fmt.Errorf("target %v already exists", target.ID())
+		if err := list.add(target); err != nil {
+			return err
 		}
-		list.targets[target.ID()] = target
 	}
 
 	return nil
@@ -69,6 +96,8 @@ type TargetIDResult struct {
 	ID TargetID
 	// Stores any error while removing a target or while sending an event.
 	Err error
+	// Event which could not be sent, only set by Send.
+	Event *Event
 }
 
 // Remove - closes and removes targets by given target IDs.
@@ -81,10 +110,57 @@ func (list *TargetList) Remove(targetIDSet TargetIDSet) {
 		if ok {
 			target.Close()
 			delete(list.targets, id)
+			delete(list.queues, id)
+			delete(list.inflight, id)
+			delete(list.slots, id)
 		}
 	}
 }
 
+// Replace - replaces the targets by given target IDs with targets, new
+// events are sent to the new targets immediately. The replaced targets
+// are closed once the events being saved to them were saved, or after
+// drainTimeout.
+func (list *TargetList) Replace(targetIDSet TargetIDSet, targets []Target, drainTimeout time.Duration) error {
+	list.Lock()
+	defer list.Unlock()
+
+	for id := range targetIDSet {
+		target, ok := list.targets[id]
+		if !ok {
+			continue
+		}
+		go drainTarget(target, list.inflight[id], drainTimeout)
+		delete(list.targets, id)
+		delete(list.queues, id)
+		delete(list.inflight, id)
+		delete(list.slots, id)
+	}
+
+	for _, target := range targets {
+		if err := list.add(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func drainTarget(target Target, inflight *sync.WaitGroup, timeout time.Duration) {
+	drained := make(chan struct{})
+	go func() {
+		inflight.Wait()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+	case <-timer.C:
+	}
+	target.Close()
+}
+
 // Targets - list all targets
 func (list *TargetList) Targets() []Target {
 	if list == nil {
@@ -122,27 +198,64 @@ func (list *TargetList) TargetMap() map[TargetID]Target {
 	return list.targets
 }
 
-// Send - sends events to targets identified by target IDs.
+// Send - sends events to targets identified by target IDs. Events for
+// targets requiring ordered delivery are queued before Send returns,
+// so that events of an object are saved in the order of the calls.
+// Send blocks while maxInflightEvents are being saved to a target.
 func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<- TargetIDResult) {
+	// Events are counted as in-flight before Send returns, so that
+	// targets replaced afterwards are closed once they were saved.
+	type inflightTarget struct {
+		id       TargetID
+		target   Target
+		inflight *sync.WaitGroup
+		slots    chan struct{}
+	}
+	unordered := make([]inflightTarget, 0, len(targetIDset))
+	for id := range targetIDset {
+		list.RLock()
+		target, ok := list.targets[id]
+		queue := list.queues[id]
+		inflight := list.inflight[id]
+		slots := list.slots[id]
+		if ok {
+			// Done once the event was saved.
+			inflight.Add(1)
+		}
+		list.RUnlock()
+		if ok {
+			// Released once the event was saved.
+			slots <- struct{}{}
+		}
+		if ok && queue != nil {
+			queue.send(target, event, resCh)
+		} else {
+			unordered = append(unordered, inflightTarget{id, target, inflight, slots})
+		}
+	}
+	if len(unordered) == 0 {
+		return
+	}
+
 	go func() {
 		var wg sync.WaitGroup
-		for id := range targetIDset {
-			list.RLock()
-			target, ok := list.targets[id]
-			list.RUnlock()
-			if ok {
-				wg.Add(1)
-				go func(id TargetID, target Target) {
-					defer wg.Done()
-					tgtRes := TargetIDResult{ID: id}
-					if err := target.Save(event); err != nil {
-						tgtRes.Err = err
-					}
-					resCh <- tgtRes
-				}(id, target)
-			} else {
-				resCh <- TargetIDResult{ID: id}
+		for _, t := range unordered {
+			if t.target == nil {
+				resCh <- TargetIDResult{ID: t.id}
+				continue
 			}
+			wg.Add(1)
+			go func(t inflightTarget) {
+				defer wg.Done()
+				defer t.inflight.Done()
+				defer func() { <-t.slots }()
+				tgtRes := TargetIDResult{ID: t.id}
+				if err := saveEvent(t.target, event); err != nil {
+					tgtRes.Err = err
+					tgtRes.Event = &event
+				}
+				resCh <- tgtRes
+			}(t)
 		}
 		wg.Wait()
 	}()
@@ -150,5 +263,10 @@ func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<-
 
 // NewTargetList - creates TargetList.
 func NewTargetList() *TargetList {
-	return &TargetList{targets: make(map[TargetID]Target)}
+	return &TargetList{
+		targets:  make(map[TargetID]Target),
+		queues:   make(map[TargetID]*orderedQueue),
+		inflight: make(map[TargetID]*sync.WaitGroup),
+		slots:    make(map[TargetID]chan struct{}),
+	}
 }
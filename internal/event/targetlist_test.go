@@ -259,3 +259,118 @@ func TestNewTargetList(t *testing.T) {
 		t.Fatalf("test: result: expected: <non-nil>, got: <nil>")
 	}
 }
+
+// blockingTarget - saves an event once release is closed.
+type blockingTarget struct {
+	ExampleTarget
+	release chan struct{}
+	closed  chan struct{}
+}
+
+func (target *blockingTarget) Save(eventData Event) error {
+	<-target.release
+	return nil
+}
+
+func (target *blockingTarget) Close() error {
+	close(target.closed)
+	return nil
+}
+
+func TestTargetListReplace(t *testing.T) {
+	id := TargetID{"1", "webhook"}
+	old := &blockingTarget{ExampleTarget{id: id}, make(chan struct{}), make(chan struct{})}
+	targetList := NewTargetList()
+	if err := targetList.Add(old); err != nil {
+		t.Fatal(err)
+	}
+
+	resCh := make(chan TargetIDResult, 2)
+	targetList.Send(Event{}, TargetIDSet{id: {}}, resCh)
+
+	replacement := &ExampleTarget{id: id}
+	if err := targetList.Replace(TargetIDSet{id: {}}, []Target{replacement}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if targetList.TargetMap()[id] != replacement {
+		t.Fatal("expected the target to be replaced")
+	}
+
+	select {
+	case <-old.closed:
+		t.Fatal("target closed while an event is being saved")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(old.release)
+	<-resCh
+	select {
+	case <-old.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the replaced target to be closed once drained")
+	}
+
+	// Replaced targets are closed after the timeout at the latest.
+	stuck := &blockingTarget{ExampleTarget{id: id}, make(chan struct{}), make(chan struct{})}
+	if err := targetList.Replace(TargetIDSet{id: {}}, []Target{stuck}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	targetList.Send(Event{}, TargetIDSet{id: {}}, resCh)
+	if err := targetList.Replace(TargetIDSet{id: {}}, nil, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-stuck.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the replaced target to be closed after the timeout")
+	}
+	if targetList.Exists(id) {
+		t.Fatal("expected the target to be removed")
+	}
+	close(stuck.release)
+}
+
+// blockedExampleTarget - blocks saving events until released.
+type blockedExampleTarget struct {
+	ExampleTarget
+	release chan struct{}
+}
+
+func (target *blockedExampleTarget) Save(eventData Event) error {
+	<-target.release
+	return nil
+}
+
+func TestTargetListSendBlocksWhenFull(t *testing.T) {
+	target := &blockedExampleTarget{
+		ExampleTarget: ExampleTarget{id: TargetID{"1", "blocked"}},
+		release:       make(chan struct{}),
+	}
+	targetList := NewTargetList()
+	if err := targetList.Add(target); err != nil {
+		t.Fatal(err)
+	}
+
+	resCh := make(chan TargetIDResult, maxInflightEvents+1)
+	for i := 0; i < maxInflightEvents; i++ {
+		targetList.Send(Event{}, NewTargetIDSet(target.ID()), resCh)
+	}
+
+	sent := make(chan struct{})
+	go func() {
+		targetList.Send(Event{}, NewTargetIDSet(target.ID()), resCh)
+		close(sent)
+	}()
+	select {
+	case <-sent:
+		t.Fatal("expected Send to block while the target is full")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(target.release)
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Send to return once events were saved")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// TestableTarget - is implemented by targets which can deliver a test
+// event directly, bypassing their queue store and batching.
+type TestableTarget interface {
+	Target
+	// SendTest delivers the event and returns once the target
+	// accepted or rejected it.
+	SendTest(Event) error
+}
+
+var errTestNotSupported = errors.New("target does not support test events")
+
+// errTestTimeout - the target did not accept the test event in time.
+var errTestTimeout = testTimeoutError{}
+
+type testTimeoutError struct{}
+
+func (testTimeoutError) Error() string {
+	return "timed out waiting for the target to accept the test event"
+}
+
+func (testTimeoutError) DeliveryErrorClass() string {
+	return DeliveryErrTimeout
+}
+
+// TargetTestResult - outcome of delivering a test event to a target.
+type TargetTestResult struct {
+	ID         TargetID      `json:"id"`
+	ARN        string        `json:"arn"`
+	Delivered  bool          `json:"delivered"`
+	Latency    time.Duration `json:"latency"`
+	Error      string        `json:"error,omitempty"`
+	ErrorClass string        `json:"errorClass,omitempty"`
+}
+
+// Test - delivers the test event to the targets of targetIDSet, or to
+// all targets if it is empty, and waits up to timeout for each of them.
+// Test events are not counted in the delivery statistics.
+func (list *TargetList) Test(event Event, targetIDSet TargetIDSet, region string, timeout time.Duration) []TargetTestResult {
+	var targets []Target
+	for _, target := range list.Targets() {
+		if _, ok := targetIDSet[target.ID()]; ok || len(targetIDSet) == 0 {
+			targets = append(targets, target)
+		}
+	}
+
+	results := make([]TargetTestResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer wg.Done()
+			results[i] = testTarget(target, event, region, timeout)
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+func testTarget(target Target, event Event, region string, timeout time.Duration) TargetTestResult {
+	result := TargetTestResult{
+		ID:  target.ID(),
+		ARN: target.ID().ToARN(region).String(),
+	}
+
+	t, ok := target.(TestableTarget)
+	if !ok {
+		result.Error = errTestNotSupported.Error()
+		result.ErrorClass = DeliveryErrOther
+		return result
+	}
+	if st, ok := target.(SchemaTarget); ok {
+		event = event.ToSchemaVersion(st.SchemaVersion())
+	}
+
+	// Buffered, so that a target which does not return in time does
+	// not leak the goroutine once it does.
+	errCh := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		errCh <- t.SendTest(event)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-timer.C:
+		err = errTestTimeout
+	}
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		result.ErrorClass = DeliveryErrorClass(err)
+		return result
+	}
+	result.Delivered = true
+	return result
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testableTarget - delivers test events after delay, failing if err is set.
+type testableTarget struct {
+	ExampleTarget
+	delay time.Duration
+	err   error
+}
+
+func (target *testableTarget) SendTest(eventData Event) error {
+	time.Sleep(target.delay)
+	return target.err
+}
+
+func TestTargetListTest(t *testing.T) {
+	targetList := NewTargetList()
+	if err := targetList.Add(
+		&testableTarget{ExampleTarget: ExampleTarget{id: TargetID{"1", "webhook"}}},
+		&testableTarget{ExampleTarget: ExampleTarget{id: TargetID{"2", "webhook"}}, err: errors.New("unauthorized")},
+		&testableTarget{ExampleTarget: ExampleTarget{id: TargetID{"3", "webhook"}}, delay: time.Second},
+		ExampleTarget{id: TargetID{"4", "webhook"}},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[TargetID]struct {
+		delivered  bool
+		errorClass string
+	}{
+		{"1", "webhook"}: {true, ""},
+		{"2", "webhook"}: {false, DeliveryErrOther},
+		{"3", "webhook"}: {false, DeliveryErrTimeout},
+		{"4", "webhook"}: {false, DeliveryErrOther},
+	}
+
+	ev := Event{EventName: TestEvent}
+	results := targetList.Test(ev, NewTargetIDSet(), "us-east-1", 100*time.Millisecond)
+	if len(results) != len(testCases) {
+		t.Fatalf("expected %d results, got %d", len(testCases), len(results))
+	}
+	for _, result := range results {
+		testCase := testCases[result.ID]
+		if result.Delivered != testCase.delivered || result.ErrorClass != testCase.errorClass {
+			t.Errorf("%v: expected delivered %v with class %q, got %v with class %q (%s)",
+				result.ID, testCase.delivered, testCase.errorClass, result.Delivered, result.ErrorClass, result.Error)
+		}
+		if result.ARN != result.ID.ToARN("us-east-1").String() {
+			t.Errorf("%v: unexpected ARN %s", result.ID, result.ARN)
+		}
+	}
+
+	results = targetList.Test(ev, NewTargetIDSet(TargetID{"1", "webhook"}), "", time.Second)
+	if len(results) != 1 || !results[0].Delivered {
+		t.Fatalf("expected target 1 to be delivered, got %+v", results)
+	}
+}
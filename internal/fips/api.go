@@ -32,7 +32,17 @@
 // [1]: https://en.wikipedia.org/wiki/FIPS_140
 package fips
 
-import "crypto/tls"
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/minio/sio"
+)
 
 // Enabled indicates whether cryptographic primitives,
 // like AES or SHA-256, are implemented using a FIPS 140
@@ -42,20 +52,116 @@ import "crypto/tls"
 // primitives must be used.
 const Enabled = enabled
 
+var required uint32
+
+// Require restricts all cryptographic primitives to FIPS
+// approved ones, independent of whether the binary has been
+// built with a FIPS 140 certified module. It must be called
+// before any TLS configuration or encryption key is created.
+func Require() {
+	atomic.StoreUint32(&required, 1)
+}
+
+// Required returns true if only FIPS approved primitives
+// must be used - either because the binary has been built
+// with FIPS 140 support or because Require has been called.
+func Required() bool {
+	return Enabled || atomic.LoadUint32(&required) == 1
+}
+
 // CipherSuitesDARE returns the supported cipher suites
 // for the DARE object encryption.
 func CipherSuitesDARE() []byte {
+	if Required() {
+		return []byte{sio.AES_256_GCM}
+	}
 	return cipherSuitesDARE()
 }
 
+// CipherSuiteNameDARE returns the name of the
+// DARE cipher suite.
+func CipherSuiteNameDARE(cipher byte) string {
+	switch cipher {
+	case sio.AES_256_GCM:
+		return "AES-256-GCM"
+	case sio.CHACHA20_POLY1305:
+		return "ChaCha20-Poly1305"
+	default:
+		return fmt.Sprintf("0x%02X", cipher)
+	}
+}
+
 // CipherSuitesTLS returns the supported cipher suites
 // used by the TLS stack.
 func CipherSuitesTLS() []uint16 {
+	if Required() {
+		return []uint16{
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		}
+	}
 	return cipherSuitesTLS()
 }
 
 // EllipticCurvesTLS returns the supported elliptic
 // curves used by the TLS stack.
 func EllipticCurvesTLS() []tls.CurveID {
+	if Required() {
+		return []tls.CurveID{tls.CurveP256, tls.CurveP384}
+	}
 	return ellipticCurvesTLS()
 }
+
+// MaxVersionTLS returns the maximum TLS version used by
+// the TLS stack, 0 if there is no restriction.
+//
+// The TLS 1.3 cipher suites cannot be configured and
+// include ChaCha20-Poly1305, so FIPS mode is limited
+// to TLS 1.2.
+func MaxVersionTLS() uint16 {
+	if Required() {
+		return tls.VersionTLS12
+	}
+	return 0
+}
+
+// RestrictTLS restricts the cipher suites, elliptic curves
+// and TLS versions of the TLS configuration to FIPS approved
+// ones, if required. It returns the modified configuration.
+func RestrictTLS(config *tls.Config) *tls.Config {
+	if Required() {
+		config.CipherSuites = CipherSuitesTLS()
+		config.CurvePreferences = EllipticCurvesTLS()
+		config.MaxVersion = MaxVersionTLS()
+	}
+	return config
+}
+
+// VerifyCertificate returns an error if the certificate
+// uses a key type, key size or signature algorithm that
+// is not FIPS approved.
+func VerifyCertificate(cert *x509.Certificate) error {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return fmt.Errorf("fips: RSA key of %q is smaller than 2048 bits", cert.Subject.CommonName)
+		}
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() && key.Curve != elliptic.P384() && key.Curve != elliptic.P521() {
+			return fmt.Errorf("fips: elliptic curve %s of %q is not approved", key.Curve.Params().Name, cert.Subject.CommonName)
+		}
+	default:
+		return fmt.Errorf("fips: key type %T of %q is not approved", key, cert.Subject.CommonName)
+	}
+
+	switch cert.SignatureAlgorithm {
+	case x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+		x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS,
+		x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512:
+		return nil
+	default:
+		return fmt.Errorf("fips: signature algorithm %s of %q is not approved", cert.SignatureAlgorithm, cert.Subject.CommonName)
+	}
+}
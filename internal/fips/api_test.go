@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fips
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/minio/sio"
+)
+
+func TestRequire(t *testing.T) {
+	Require()
+	if !Required() {
+		t.Fatal("FIPS mode is not required")
+	}
+	if suites := CipherSuitesDARE(); len(suites) != 1 || suites[0] != sio.AES_256_GCM {
+		t.Fatalf("unexpected DARE cipher suites %v", suites)
+	}
+	for _, id := range CipherSuitesTLS() {
+		if id == tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305 || id == tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305 {
+			t.Fatalf("unexpected TLS cipher suite %s", tls.CipherSuiteName(id))
+		}
+	}
+	config := RestrictTLS(&tls.Config{})
+	if config.MaxVersion != tls.VersionTLS12 || len(config.CurvePreferences) == 0 {
+		t.Fatalf("TLS configuration has not been restricted: %v", config)
+	}
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	newCert := func(pub crypto.PublicKey, priv crypto.Signer) *x509.Certificate {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "minio"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyCertificate(newCert(&ecdsaKey.PublicKey, ecdsaKey)); err != nil {
+		t.Fatal(err)
+	}
+
+	ed25519Pub, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyCertificate(newCert(ed25519Pub, ed25519Key)); err == nil {
+		t.Fatal("expected Ed25519 certificate to be rejected")
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyCertificate(newCert(&rsaKey.PublicKey, rsaKey)); err == nil {
+		t.Fatal("expected 1024 bit RSA certificate to be rejected")
+	}
+}
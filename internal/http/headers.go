@@ -38,6 +38,7 @@ const (
 	CacheControl       = "Cache-Control"
 	ContentDisposition = "Content-Disposition"
 	Authorization      = "Authorization"
+	WwwAuthenticate    = "WWW-Authenticate"
 	Action             = "Action"
 	Range              = "Range"
 )
@@ -127,6 +128,8 @@ const (
 
 	AmzEncryptionAES = "AES256"
 	AmzEncryptionKMS = "aws:kms"
+	// AmzEncryptionKMSDSSE is the SSE algorithm of dual-layer SSE-KMS.
+	AmzEncryptionKMSDSSE = "aws:kms:dsse"
 
 	// Signature v2 related constants
 	AmzSignatureV2 = "Signature"
@@ -156,6 +159,9 @@ const (
 	// Reports number of drives currently healing
 	MinIOHealingDrives = "x-minio-healing-drives"
 
+	// Reports whether the KMS is online or offline
+	MinIOKMSStatus = "x-minio-kms-status"
+
 	// Header indicates if the delete marker should be preserved by client
 	MinIOSourceDeleteMarker = "x-minio-source-deletemarker"
 
@@ -170,8 +176,16 @@ const (
 	MinIOSourceProxyRequest = "X-Minio-Source-Proxy-Request"
 	// Header indicates that this request is a replication request to create a REPLICA
 	MinIOSourceReplicationRequest = "X-Minio-Source-Replication-Request"
+	// Header indicates the name of the site sending a replication request
+	MinIOSourceReplicationSite = "X-Minio-Source-Replication-Site"
 	// Header indicates replication reset status.
 	MinIOReplicationResetStatus = "X-Minio-Replication-Reset-Status"
+	// Header indicates the replication status of an object for each site
+	MinIOReplicationSiteStatus = "X-Minio-Replication-Site-Status"
+	// Header indicates the outcome of synchronous replication of a write
+	MinIOReplicationSyncStatus = "X-Minio-Replication-Sync-Status"
+	// Header carries warnings about replication settings that let sites diverge
+	MinIOReplicationWarning = "X-Minio-Replication-Warning"
 
 	// Header indiicates last tag update time on source
 	MinIOSourceTaggingTimestamp = "X-Minio-Source-Replication-Tagging-Timestamp"
@@ -181,6 +195,8 @@ const (
 	MinIOSourceObjectLegalHoldTimestamp = "X-Minio-Source-Replication-LegalHold-Timestamp"
 	// predicted date/time of transition
 	MinIOTransition = "X-Minio-Transition"
+	// Event schema version of the events sent to a listener
+	MinIOEventSchemaVersion = "X-Minio-Event-Schema-Version"
 )
 
 // Common http query params S3 API
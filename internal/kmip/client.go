@@ -0,0 +1,401 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kmip
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// KMIP operations used by the client.
+const (
+	OperationCreate   int32 = 0x01
+	OperationLocate   int32 = 0x08
+	OperationActivate int32 = 0x12
+	OperationQuery    int32 = 0x18
+	OperationEncrypt  int32 = 0x1F
+	OperationDecrypt  int32 = 0x20
+)
+
+// KMIP enumeration values used by the client.
+const (
+	ObjectTypeSymmetricKey          int32 = 0x02
+	CryptographicAlgorithmAES       int32 = 0x03
+	BlockCipherModeGCM              int32 = 0x09
+	UsageMaskEncrypt                int32 = 0x04
+	UsageMaskDecrypt                int32 = 0x08
+	NameTypeUninterpretedTextString int32 = 0x01
+	QueryFunctionOperations         int32 = 0x01
+
+	ResultStatusSuccess      int32 = 0x00
+	ResultReasonItemNotFound int32 = 0x01
+)
+
+// ProtocolVersion is a KMIP protocol version.
+type ProtocolVersion struct {
+	Major, Minor int32
+}
+
+// Supported protocol versions. Attributes are encoded differently by
+// KMIP 1.x and 2.x.
+var (
+	Version14 = ProtocolVersion{Major: 1, Minor: 4}
+	Version20 = ProtocolVersion{Major: 2, Minor: 0}
+)
+
+func (v ProtocolVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// ParseVersion parses a protocol version as "1.4" or "2.0".
+func ParseVersion(s string) (ProtocolVersion, error) {
+	switch s {
+	case "", "1.4":
+		return Version14, nil
+	case "2.0":
+		return Version20, nil
+	}
+	return ProtocolVersion{}, fmt.Errorf("kmip: unsupported protocol version %q", s)
+}
+
+// ErrNotFound is returned when no object exists by a name or identifier.
+var ErrNotFound = errors.New("kmip: object not found")
+
+// Error is returned when the server fails an operation.
+type Error struct {
+	Operation int32
+	Reason    int32
+	Message   string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("kmip: operation %#x failed with reason %#x: %s", e.Operation, e.Reason, e.Message)
+}
+
+// Is returns true for ErrNotFound if no object was found.
+func (e Error) Is(target error) bool {
+	return target == ErrNotFound && e.Reason == ResultReasonItemNotFound
+}
+
+// Config configures a client.
+type Config struct {
+	// Endpoints of the KMIP servers as host:port, which are tried in
+	// order when a server cannot be reached.
+	Endpoints []string
+	// TLS configures the mutually authenticated TLS connections
+	// to the servers.
+	TLS *tls.Config
+	// Version is the protocol version, KMIP 1.4 if unset.
+	Version ProtocolVersion
+	// Timeout of each request, 10s if unset.
+	Timeout time.Duration
+
+	// Dial dials a server, if unset TLS connections are dialed.
+	Dial func(ctx context.Context, endpoint string) (net.Conn, error)
+}
+
+// Client sends requests to KMIP servers over a single connection,
+// reconnecting when it fails.
+type Client struct {
+	cfg Config
+
+	mu   sync.Mutex
+	conn net.Conn
+	next int // index of the endpoint to connect to
+}
+
+// NewClient returns a new client.
+func NewClient(cfg Config) (*Client, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("kmip: no server endpoints")
+	}
+	cfg.Endpoints = append([]string(nil), cfg.Endpoints...)
+	if cfg.Version == (ProtocolVersion{}) {
+		cfg.Version = Version14
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.Dial == nil {
+		dialer := &tls.Dialer{Config: cfg.TLS}
+		cfg.Dial = func(ctx context.Context, endpoint string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", endpoint)
+		}
+	}
+	return &Client{cfg: cfg}, nil
+}
+
+// Endpoints returns the endpoints of the servers.
+func (c *Client) Endpoints() []string {
+	return append([]string(nil), c.cfg.Endpoints...)
+}
+
+// Version returns the protocol version of the client.
+func (c *Client) Version() ProtocolVersion {
+	return c.cfg.Version
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// connect returns the current connection or connects to the first
+// server reachable.
+func (c *Client) connect(ctx context.Context) (net.Conn, error) {
+	if c.conn != nil {
+		return c.conn, nil
+	}
+	var err error
+	for i := 0; i < len(c.cfg.Endpoints); i++ {
+		endpoint := c.cfg.Endpoints[(c.next+i)%len(c.cfg.Endpoints)]
+		var conn net.Conn
+		if conn, err = c.cfg.Dial(ctx, endpoint); err == nil {
+			c.next = (c.next + i) % len(c.cfg.Endpoints)
+			c.conn = conn
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// Do sends a request of the operation with the payload and returns the
+// response payload.
+func (c *Client) Do(ctx context.Context, operation int32, payload ...Item) (Item, error) {
+	req, err := Structure(TagRequestMessage,
+		Structure(TagRequestHeader,
+			Structure(TagProtocolVersion,
+				Integer(TagProtocolVersionMajor, c.cfg.Version.Major),
+				Integer(TagProtocolVersionMinor, c.cfg.Version.Minor),
+			),
+			Integer(TagBatchCount, 1),
+		),
+		Structure(TagBatchItem,
+			Enumeration(TagOperation, operation),
+			Structure(TagRequestPayload, payload...),
+		),
+	).MarshalTTLV()
+	if err != nil {
+		return Item{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// An idle connection may have been closed by the server, so a
+	// request failing on an existing connection is sent once more on
+	// a new one.
+	var resp Item
+	for reused := c.conn != nil; ; reused = false {
+		var conn net.Conn
+		if conn, err = c.connect(ctx); err != nil {
+			return Item{}, err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		if _, err = conn.Write(req); err == nil {
+			resp, err = ReadMessage(conn)
+		}
+		if err == nil {
+			break
+		}
+		conn.Close()
+		c.conn = nil
+		c.next = (c.next + 1) % len(c.cfg.Endpoints)
+		if !reused {
+			return Item{}, err
+		}
+	}
+	return parseResponse(operation, resp)
+}
+
+func parseResponse(operation int32, resp Item) (Item, error) {
+	if resp.Tag != TagResponseMessage {
+		return Item{}, errMalformed
+	}
+	batch, ok := resp.Find(TagBatchItem)
+	if !ok {
+		return Item{}, errMalformed
+	}
+	status, ok := batch.Find(TagResultStatus)
+	if !ok {
+		return Item{}, errMalformed
+	}
+	if status.Int() != ResultStatusSuccess {
+		reason, _ := batch.Find(TagResultReason)
+		msg, _ := batch.Find(TagResultMessage)
+		return Item{}, Error{Operation: operation, Reason: reason.Int(), Message: msg.Text()}
+	}
+	payload, _ := batch.Find(TagResponsePayload)
+	return payload, nil
+}
+
+// attribute is an object attribute, item is tagged by the attribute.
+type attribute struct {
+	name string
+	item Item
+}
+
+// attributes encodes attrs as a template attribute for KMIP 1.x, or as
+// attributes for KMIP 2.x.
+func (c *Client) attributes(attrs ...attribute) Item {
+	items := make([]Item, 0, len(attrs))
+	for _, attr := range attrs {
+		if c.cfg.Version.Major >= 2 {
+			items = append(items, attr.item)
+			continue
+		}
+		value := attr.item
+		value.Tag = TagAttributeValue
+		items = append(items, Structure(TagAttribute, TextString(TagAttributeName, attr.name), value))
+	}
+	if c.cfg.Version.Major >= 2 {
+		return Structure(TagAttributes, items...)
+	}
+	return Structure(TagTemplateAttribute, items...)
+}
+
+func nameAttribute(name string) attribute {
+	return attribute{name: "Name", item: Structure(TagName,
+		TextString(TagNameValue, name),
+		Enumeration(TagNameType, NameTypeUninterpretedTextString),
+	)}
+}
+
+// Create creates a 256 bit AES key by the name and returns its unique
+// identifier.
+func (c *Client) Create(ctx context.Context, name string) (string, error) {
+	payload, err := c.Do(ctx, OperationCreate,
+		Enumeration(TagObjectType, ObjectTypeSymmetricKey),
+		c.attributes(
+			attribute{name: "Cryptographic Algorithm", item: Enumeration(TagCryptographicAlgorithm, CryptographicAlgorithmAES)},
+			attribute{name: "Cryptographic Length", item: Integer(TagCryptographicLength, 256)},
+			attribute{name: "Cryptographic Usage Mask", item: Integer(TagCryptographicUsageMask, UsageMaskEncrypt|UsageMaskDecrypt)},
+			nameAttribute(name),
+		),
+	)
+	if err != nil {
+		return "", err
+	}
+	id, ok := payload.Find(TagUniqueIdentifier)
+	if !ok {
+		return "", errMalformed
+	}
+	return id.Text(), nil
+}
+
+// Activate activates the key by the unique identifier. Keys are
+// created in the pre-active state, in which servers enforcing the
+// key state refuse to encrypt with them.
+func (c *Client) Activate(ctx context.Context, id string) error {
+	_, err := c.Do(ctx, OperationActivate, TextString(TagUniqueIdentifier, id))
+	return err
+}
+
+// Locate returns the unique identifier of the key by the name.
+func (c *Client) Locate(ctx context.Context, name string) (string, error) {
+	var payload Item
+	var err error
+	if c.cfg.Version.Major >= 2 {
+		payload, err = c.Do(ctx, OperationLocate, c.attributes(nameAttribute(name)))
+	} else {
+		// KMIP 1.x locates objects by attributes in the payload.
+		payload, err = c.Do(ctx, OperationLocate, c.attributes(nameAttribute(name)).Items()...)
+	}
+	if err != nil {
+		return "", err
+	}
+	id, ok := payload.Find(TagUniqueIdentifier)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return id.Text(), nil
+}
+
+func gcmParameters() Item {
+	return Structure(TagCryptographicParameters,
+		Enumeration(TagBlockCipherMode, BlockCipherModeGCM),
+		Enumeration(TagCryptographicAlgorithm, CryptographicAlgorithmAES),
+	)
+}
+
+// Encrypt encrypts plaintext with the AES key by the unique identifier
+// in GCM mode, authenticating the additional data.
+func (c *Client) Encrypt(ctx context.Context, id string, plaintext, iv, additionalData []byte) (ciphertext, tag []byte, err error) {
+	payload, err := c.Do(ctx, OperationEncrypt,
+		TextString(TagUniqueIdentifier, id),
+		gcmParameters(),
+		ByteString(TagData, plaintext),
+		ByteString(TagIVCounterNonce, iv),
+		ByteString(TagAuthenticatedEncryptionAdditionalData, additionalData),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, ok := payload.Find(TagData)
+	if !ok {
+		return nil, nil, errMalformed
+	}
+	t, ok := payload.Find(TagAuthenticatedEncryptionTag)
+	if !ok {
+		return nil, nil, errMalformed
+	}
+	return data.Bytes(), t.Bytes(), nil
+}
+
+// Decrypt decrypts ciphertext encrypted by Encrypt.
+func (c *Client) Decrypt(ctx context.Context, id string, ciphertext, iv, additionalData, tag []byte) ([]byte, error) {
+	payload, err := c.Do(ctx, OperationDecrypt,
+		TextString(TagUniqueIdentifier, id),
+		gcmParameters(),
+		ByteString(TagData, ciphertext),
+		ByteString(TagIVCounterNonce, iv),
+		ByteString(TagAuthenticatedEncryptionAdditionalData, additionalData),
+		ByteString(TagAuthenticatedEncryptionTag, tag),
+	)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := payload.Find(TagData)
+	if !ok {
+		return nil, errMalformed
+	}
+	return data.Bytes(), nil
+}
+
+// Query checks that the server is reachable and serves requests.
+func (c *Client) Query(ctx context.Context) error {
+	_, err := c.Do(ctx, OperationQuery, Enumeration(TagQueryFunction, QueryFunctionOperations))
+	return err
+}
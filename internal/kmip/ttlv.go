@@ -0,0 +1,328 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package kmip implements a minimal client of the Key Management
+// Interoperability Protocol (KMIP), supporting the operations needed
+// to wrap data encryption keys with symmetric keys held by a KMIP
+// server.
+package kmip
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Tag identifies a KMIP item.
+type Tag uint32
+
+// KMIP tags used by the client.
+const (
+	TagAttribute                             Tag = 0x420008
+	TagAttributeName                         Tag = 0x42000A
+	TagAttributeValue                        Tag = 0x42000B
+	TagBatchCount                            Tag = 0x42000D
+	TagBatchItem                             Tag = 0x42000F
+	TagBlockCipherMode                       Tag = 0x420011
+	TagCryptographicAlgorithm                Tag = 0x420028
+	TagCryptographicLength                   Tag = 0x42002A
+	TagCryptographicParameters               Tag = 0x42002B
+	TagCryptographicUsageMask                Tag = 0x42002C
+	TagIVCounterNonce                        Tag = 0x42003D
+	TagName                                  Tag = 0x420053
+	TagNameType                              Tag = 0x420054
+	TagNameValue                             Tag = 0x420055
+	TagObjectType                            Tag = 0x420057
+	TagOperation                             Tag = 0x42005C
+	TagProtocolVersion                       Tag = 0x420069
+	TagProtocolVersionMajor                  Tag = 0x42006A
+	TagProtocolVersionMinor                  Tag = 0x42006B
+	TagQueryFunction                         Tag = 0x420074
+	TagRequestHeader                         Tag = 0x420077
+	TagRequestMessage                        Tag = 0x420078
+	TagRequestPayload                        Tag = 0x420079
+	TagResponseHeader                        Tag = 0x42007A
+	TagResponseMessage                       Tag = 0x42007B
+	TagResponsePayload                       Tag = 0x42007C
+	TagResultMessage                         Tag = 0x42007D
+	TagResultReason                          Tag = 0x42007E
+	TagResultStatus                          Tag = 0x42007F
+	TagTemplateAttribute                     Tag = 0x420091
+	TagTimeStamp                             Tag = 0x420092
+	TagUniqueIdentifier                      Tag = 0x420094
+	TagData                                  Tag = 0x4200C2
+	TagAuthenticatedEncryptionAdditionalData Tag = 0x4200FE
+	TagAuthenticatedEncryptionTag            Tag = 0x4200FF
+	TagAttributes                            Tag = 0x420125
+)
+
+// Type is the type of a KMIP item.
+type Type byte
+
+// KMIP item types.
+const (
+	TypeStructure   Type = 0x01
+	TypeInteger     Type = 0x02
+	TypeLongInteger Type = 0x03
+	TypeEnumeration Type = 0x05
+	TypeBoolean     Type = 0x06
+	TypeTextString  Type = 0x07
+	TypeByteString  Type = 0x08
+	TypeDateTime    Type = 0x09
+	TypeInterval    Type = 0x0A
+)
+
+// Items are limited in size to protect against malformed messages.
+const maxItemLength = 1 << 20
+
+var errMalformed = errors.New("kmip: malformed message")
+
+// Item is a KMIP item encoded as tag, type, length and value (TTLV).
+// The value of a structure is a []Item, of (long) integers and
+// enumerations an int32 or int64, of text strings a string, of byte
+// strings a []byte, of booleans a bool and of date times a time.Time.
+type Item struct {
+	Tag   Tag
+	Type  Type
+	Value interface{}
+}
+
+// Structure returns a structure holding items.
+func Structure(tag Tag, items ...Item) Item {
+	return Item{Tag: tag, Type: TypeStructure, Value: items}
+}
+
+// Integer returns an integer item.
+func Integer(tag Tag, v int32) Item { return Item{Tag: tag, Type: TypeInteger, Value: v} }
+
+// Enumeration returns an enumeration item.
+func Enumeration(tag Tag, v int32) Item { return Item{Tag: tag, Type: TypeEnumeration, Value: v} }
+
+// TextString returns a text string item.
+func TextString(tag Tag, v string) Item { return Item{Tag: tag, Type: TypeTextString, Value: v} }
+
+// ByteString returns a byte string item.
+func ByteString(tag Tag, v []byte) Item { return Item{Tag: tag, Type: TypeByteString, Value: v} }
+
+// DateTime returns a date time item.
+func DateTime(tag Tag, v time.Time) Item { return Item{Tag: tag, Type: TypeDateTime, Value: v} }
+
+// Items returns the items of a structure.
+func (it Item) Items() []Item {
+	items, _ := it.Value.([]Item)
+	return items
+}
+
+// Find returns the first item of a structure with the tag.
+func (it Item) Find(tag Tag) (Item, bool) {
+	for _, item := range it.Items() {
+		if item.Tag == tag {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// Int returns the value of an integer or enumeration item.
+func (it Item) Int() int32 {
+	v, _ := it.Value.(int32)
+	return v
+}
+
+// Text returns the value of a text string item.
+func (it Item) Text() string {
+	v, _ := it.Value.(string)
+	return v
+}
+
+// Bytes returns the value of a byte string item.
+func (it Item) Bytes() []byte {
+	v, _ := it.Value.([]byte)
+	return v
+}
+
+// MarshalTTLV encodes the item.
+func (it Item) MarshalTTLV() ([]byte, error) {
+	return it.appendTTLV(nil)
+}
+
+func (it Item) appendTTLV(b []byte) ([]byte, error) {
+	var value []byte
+	switch it.Type {
+	case TypeStructure:
+		items, ok := it.Value.([]Item)
+		if !ok && it.Value != nil {
+			return nil, fmt.Errorf("kmip: invalid structure value of %#x", uint32(it.Tag))
+		}
+		for _, item := range items {
+			var err error
+			if value, err = item.appendTTLV(value); err != nil {
+				return nil, err
+			}
+		}
+	case TypeInteger, TypeEnumeration, TypeInterval:
+		v, ok := it.Value.(int32)
+		if !ok {
+			return nil, fmt.Errorf("kmip: invalid integer value of %#x", uint32(it.Tag))
+		}
+		value = make([]byte, 4)
+		binary.BigEndian.PutUint32(value, uint32(v))
+	case TypeLongInteger:
+		v, ok := it.Value.(int64)
+		if !ok {
+			return nil, fmt.Errorf("kmip: invalid long integer value of %#x", uint32(it.Tag))
+		}
+		value = appendUint64(nil, uint64(v))
+	case TypeBoolean:
+		v, ok := it.Value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("kmip: invalid boolean value of %#x", uint32(it.Tag))
+		}
+		var n uint64
+		if v {
+			n = 1
+		}
+		value = appendUint64(nil, n)
+	case TypeDateTime:
+		v, ok := it.Value.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("kmip: invalid date time value of %#x", uint32(it.Tag))
+		}
+		value = appendUint64(nil, uint64(v.Unix()))
+	case TypeTextString:
+		v, ok := it.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("kmip: invalid text string value of %#x", uint32(it.Tag))
+		}
+		value = []byte(v)
+	case TypeByteString:
+		v, ok := it.Value.([]byte)
+		if !ok && it.Value != nil {
+			return nil, fmt.Errorf("kmip: invalid byte string value of %#x", uint32(it.Tag))
+		}
+		value = v
+	default:
+		return nil, fmt.Errorf("kmip: unsupported type %#x of %#x", byte(it.Type), uint32(it.Tag))
+	}
+
+	var hdr [8]byte
+	hdr[0], hdr[1], hdr[2] = byte(it.Tag>>16), byte(it.Tag>>8), byte(it.Tag)
+	hdr[3] = byte(it.Type)
+	binary.BigEndian.PutUint32(hdr[4:], uint32(len(value)))
+	b = append(b, hdr[:]...)
+	b = append(b, value...)
+	// Values are padded to a multiple of 8 bytes.
+	if pad := len(value) % 8; pad != 0 {
+		b = append(b, make([]byte, 8-pad)...)
+	}
+	return b, nil
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], v)
+	return append(b, n[:]...)
+}
+
+// UnmarshalTTLV decodes the item encoded in b.
+func UnmarshalTTLV(b []byte) (Item, error) {
+	it, rest, err := decodeItem(b)
+	if err != nil {
+		return Item{}, err
+	}
+	if len(rest) != 0 {
+		return Item{}, errMalformed
+	}
+	return it, nil
+}
+
+func decodeItem(b []byte) (it Item, rest []byte, err error) {
+	if len(b) < 8 {
+		return it, nil, errMalformed
+	}
+	it.Tag = Tag(b[0])<<16 | Tag(b[1])<<8 | Tag(b[2])
+	it.Type = Type(b[3])
+	n := int(binary.BigEndian.Uint32(b[4:8]))
+	padded := n
+	if pad := n % 8; pad != 0 {
+		padded += 8 - pad
+	}
+	if n > maxItemLength || len(b)-8 < padded {
+		return it, nil, errMalformed
+	}
+	value, rest := b[8:8+n], b[8+padded:]
+
+	switch it.Type {
+	case TypeStructure:
+		items := []Item{}
+		for len(value) > 0 {
+			var item Item
+			if item, value, err = decodeItem(value); err != nil {
+				return it, nil, err
+			}
+			items = append(items, item)
+		}
+		it.Value = items
+	case TypeInteger, TypeEnumeration, TypeInterval:
+		if n != 4 {
+			return it, nil, errMalformed
+		}
+		it.Value = int32(binary.BigEndian.Uint32(value))
+	case TypeLongInteger, TypeBoolean, TypeDateTime:
+		if n != 8 {
+			return it, nil, errMalformed
+		}
+		v := binary.BigEndian.Uint64(value)
+		switch it.Type {
+		case TypeLongInteger:
+			it.Value = int64(v)
+		case TypeBoolean:
+			it.Value = v != 0
+		default:
+			it.Value = time.Unix(int64(v), 0).UTC()
+		}
+	case TypeTextString:
+		it.Value = string(value)
+	case TypeByteString:
+		it.Value = append([]byte(nil), value...)
+	default:
+		// Values of other types, e.g big integers, are not used by
+		// the client and kept as is.
+		it.Value = append([]byte(nil), value...)
+	}
+	return it, rest, nil
+}
+
+// ReadMessage reads a single TTLV encoded message from r.
+func ReadMessage(r io.Reader) (Item, error) {
+	var hdr [8]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return Item{}, err
+	}
+	n := binary.BigEndian.Uint32(hdr[4:])
+	if Type(hdr[3]) != TypeStructure || n > maxItemLength || n%8 != 0 {
+		return Item{}, errMalformed
+	}
+	b := make([]byte, 8+n)
+	copy(b, hdr[:])
+	if _, err := io.ReadFull(r, b[8:]); err != nil {
+		return Item{}, err
+	}
+	return UnmarshalTTLV(b)
+}
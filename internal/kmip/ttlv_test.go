@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kmip
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test vectors of the KMIP 1.4 specification, section 9.1.2.
+func TestMarshalTTLV(t *testing.T) {
+	testCases := []struct {
+		item     Item
+		expected string
+	}{
+		{Integer(0x420020, 8), "42002002000000040000000800000000"},
+		{Item{Tag: 0x420020, Type: TypeLongInteger, Value: int64(123456789000000000)}, "420020030000000801B69B4BA5749200"},
+		{Enumeration(0x420020, 255), "4200200500000004000000FF00000000"},
+		{Item{Tag: 0x420020, Type: TypeBoolean, Value: true}, "42002006000000080000000000000001"},
+		{TextString(0x420020, "Hello World"), "420020070000000B48656C6C6F20576F726C640000000000"},
+		{ByteString(0x420020, []byte{1, 2, 3}), "42002008000000030102030000000000"},
+		{DateTime(0x420020, time.Unix(0x47DA67F8, 0).UTC()), "42002009000000080000000047DA67F8"},
+		{Structure(0x420020, Enumeration(0x420004, 254), Integer(0x420005, 255)), "42002001000000204200040500000004000000FE000000004200050200000004000000FF00000000"},
+	}
+	for i, testCase := range testCases {
+		b, err := testCase.item.MarshalTTLV()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if got := hex.EncodeToString(b); got != hex.EncodeToString(mustDecodeHex(t, testCase.expected)) {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+		item, err := UnmarshalTTLV(b)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(item, testCase.item) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.item, item)
+		}
+	}
+}
+
+func TestUnmarshalTTLVMalformed(t *testing.T) {
+	testCases := []string{
+		"420020020000",
+		// Integers must be 4 bytes long.
+		"42002002000000080000000000000008",
+		// Lengths exceeding the message.
+		"42002007000000100000000000000000",
+		// Trailing bytes.
+		"420020020000000400000008000000000000",
+	}
+	for i, testCase := range testCases {
+		if _, err := UnmarshalTTLV(mustDecodeHex(t, testCase)); err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestReadMessage(t *testing.T) {
+	msg := Structure(TagResponseMessage, Structure(TagBatchItem, Enumeration(TagResultStatus, ResultStatusSuccess)))
+	b, err := msg.MarshalTTLV()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadMessage(bytes.NewReader(append(b, b...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, msg) {
+		t.Fatalf("expected %v, got %v", msg, got)
+	}
+	if _, err = ReadMessage(bytes.NewReader(b[:len(b)-8])); err == nil {
+		t.Fatal("expected an error for a truncated message")
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+// KeyUsage describes a single use of a KMS key to
+// generate or decrypt a data encryption key.
+type KeyUsage struct {
+	Operation string // Either "GenerateKey" or "DecryptKey"
+	KeyID     string
+	Context   Context
+	Principal string // Empty if the key is used on behalf of the server
+	Err       error
+}
+
+// Key usage operations.
+const (
+	OpGenerateKey = "GenerateKey"
+	OpDecryptKey  = "DecryptKey"
+)
+
+// NewAudit returns a KMS that reports every generated
+// and decrypted data encryption key to audit.
+func NewAudit(kms KMS, audit func(KeyUsage)) KMS {
+	return &auditKMS{
+		kms:   kms,
+		audit: audit,
+	}
+}
+
+// WithPrincipal returns a KMS that attributes all key
+// usage reported by an audit KMS to the given principal.
+// It returns kms unmodified if it is not an audit KMS.
+func WithPrincipal(kms KMS, principal string) KMS {
+	if a, ok := kms.(*auditKMS); ok && principal != "" {
+		return &auditKMS{
+			kms:       a.kms,
+			audit:     a.audit,
+			principal: principal,
+		}
+	}
+	return kms
+}
+
+type auditKMS struct {
+	kms       KMS
+	audit     func(KeyUsage)
+	principal string
+}
+
+var _ KMS = (*auditKMS)(nil) // compiler check
+
+func (a *auditKMS) Stat() (Status, error) { return a.kms.Stat() }
+
+func (a *auditKMS) CreateKey(keyID string) error { return a.kms.CreateKey(keyID) }
+
+func (a *auditKMS) GenerateKey(keyID string, context Context) (DEK, error) {
+	key, err := a.kms.GenerateKey(keyID, context)
+	if keyID == "" {
+		// Report the default key actually used.
+		keyID = key.KeyID
+	}
+	a.audit(KeyUsage{
+		Operation: OpGenerateKey,
+		KeyID:     keyID,
+		Context:   context,
+		Principal: a.principal,
+		Err:       err,
+	})
+	return key, err
+}
+
+func (a *auditKMS) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	key, err := a.kms.DecryptKey(keyID, ciphertext, context)
+	a.audit(KeyUsage{
+		Operation: OpDecryptKey,
+		KeyID:     keyID,
+		Context:   context,
+		Principal: a.principal,
+		Err:       err,
+	})
+	return key, err
+}
+
+func (a *auditKMS) importKey(keyID string, key []byte) error { return ImportKey(a.kms, keyID, key) }
+
+func (a *auditKMS) endpointStats() []EndpointStat { return EndpointStats(a.kms) }
+
+func (a *auditKMS) breakerStatus() BreakerStatus {
+	status, _ := Breaker(a.kms)
+	return status
+}
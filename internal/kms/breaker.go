@@ -0,0 +1,382 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/kes"
+)
+
+// ErrUnavailable is returned by a KMS with an open circuit
+// breaker instead of sending the request to the KMS.
+var ErrUnavailable = errors.New("kms: KMS is unavailable")
+
+// BreakerMode defines how a KMS behaves while its circuit
+// breaker is open.
+type BreakerMode string
+
+// Circuit breaker modes.
+const (
+	// BreakerOff never opens the circuit breaker. The KMS
+	// operations are still measured.
+	BreakerOff BreakerMode = "off"
+
+	// BreakerReject rejects all requests with ErrUnavailable.
+	BreakerReject BreakerMode = "reject"
+
+	// BreakerQueue holds requests back until the KMS recovers
+	// or the queue timeout expires.
+	BreakerQueue BreakerMode = "queue"
+
+	// BreakerCache decrypts data keys decrypted or generated
+	// within the cache TTL from memory. New data keys cannot
+	// be generated, so these requests are rejected.
+	BreakerCache BreakerMode = "cache"
+)
+
+// BreakerConfig is a structure containing the circuit breaker
+// configuration of a KMS.
+type BreakerConfig struct {
+	Mode BreakerMode
+
+	// Threshold is the number of consecutive requests that
+	// failed because the KMS was unavailable after which the
+	// circuit breaker opens.
+	Threshold int
+
+	// Cooldown is the time after which an open circuit
+	// breaker lets a single request through to find out
+	// whether the KMS has recovered.
+	Cooldown time.Duration
+
+	// QueueTimeout is the time requests are held back
+	// in BreakerQueue mode.
+	QueueTimeout time.Duration
+
+	// CacheTTL is the time data keys are cached in
+	// BreakerCache mode and CacheSize the maximum
+	// number of cached keys.
+	CacheTTL  time.Duration
+	CacheSize int
+}
+
+// OperationStat describes the latency and failures of
+// a single KMS operation, like GenerateKey.
+type OperationStat struct {
+	Operation string
+	Latency   time.Duration // Moving average of successful requests
+	Requests  uint64        // Requests since server start
+	Errors    uint64        // Requests that failed
+	Rejected  uint64        // Requests rejected by the circuit breaker
+}
+
+// BreakerStatus describes the state of the circuit breaker
+// of a KMS and its operations.
+type BreakerStatus struct {
+	Mode       BreakerMode
+	Open       bool
+	OpenedAt   time.Time // Zero unless the breaker is open
+	Operations []OperationStat
+}
+
+// Breaker returns the circuit breaker status of the given KMS.
+// It returns false if the KMS has no circuit breaker.
+func Breaker(kms KMS) (BreakerStatus, bool) {
+	if b, ok := kms.(interface{ breakerStatus() BreakerStatus }); ok {
+		return b.breakerStatus(), true
+	}
+	return BreakerStatus{}, false
+}
+
+// NewBreaker returns a KMS that measures all operations of the KMS
+// and stops sending requests to it once it is unavailable, as
+// defined by the configuration.
+func NewBreaker(kms KMS, config BreakerConfig) KMS {
+	b := &breakerKMS{
+		kms:       kms,
+		config:    config,
+		recovered: make(chan struct{}),
+	}
+	for _, op := range []string{opStat, opCreateKey, OpGenerateKey, OpDecryptKey} {
+		b.operations = append(b.operations, &OperationStat{Operation: op})
+	}
+	if config.Mode == BreakerCache {
+		b.cache = make(map[[sha256.Size]byte]cachedKey)
+	}
+	return b
+}
+
+const (
+	opStat      = "Stat"
+	opCreateKey = "CreateKey"
+)
+
+type cachedKey struct {
+	plaintext []byte
+	expires   time.Time
+}
+
+type breakerKMS struct {
+	kms    KMS
+	config BreakerConfig
+
+	mu         sync.Mutex
+	open       bool
+	openedAt   time.Time
+	probing    bool
+	failures   int           // consecutive requests failed due to unavailability
+	recovered  chan struct{} // closed once an open breaker closes
+	operations []*OperationStat
+	cache      map[[sha256.Size]byte]cachedKey
+}
+
+var _ KMS = (*breakerKMS)(nil) // compiler check
+
+func (b *breakerKMS) Stat() (status Status, err error) {
+	err = b.do(opStat, func() error {
+		status, err = b.kms.Stat()
+		return err
+	})
+	return status, err
+}
+
+func (b *breakerKMS) CreateKey(keyID string) error {
+	return b.do(opCreateKey, func() error { return b.kms.CreateKey(keyID) })
+}
+
+func (b *breakerKMS) GenerateKey(keyID string, context Context) (key DEK, err error) {
+	err = b.do(OpGenerateKey, func() error {
+		key, err = b.kms.GenerateKey(keyID, context)
+		return err
+	})
+	if err == nil {
+		b.cacheKey(key.KeyID, key.Ciphertext, context, key.Plaintext)
+	}
+	return key, err
+}
+
+func (b *breakerKMS) DecryptKey(keyID string, ciphertext []byte, context Context) (key []byte, err error) {
+	err = b.do(OpDecryptKey, func() error {
+		key, err = b.kms.DecryptKey(keyID, ciphertext, context)
+		return err
+	})
+	switch {
+	case err == nil:
+		b.cacheKey(keyID, ciphertext, context, key)
+	case errors.Is(err, ErrUnavailable) || isUnavailable(err):
+		if cached, ok := b.cachedKey(keyID, ciphertext, context); ok {
+			return cached, nil
+		}
+	}
+	return key, err
+}
+
+func (b *breakerKMS) importKey(keyID string, key []byte) error {
+	return b.do(opCreateKey, func() error { return ImportKey(b.kms, keyID, key) })
+}
+
+func (b *breakerKMS) endpointStats() []EndpointStat { return EndpointStats(b.kms) }
+
+func (b *breakerKMS) breakerStatus() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := BreakerStatus{
+		Mode: b.config.Mode,
+		Open: b.open,
+	}
+	if b.open {
+		status.OpenedAt = b.openedAt
+	}
+	for _, op := range b.operations {
+		status.Operations = append(status.Operations, *op)
+	}
+	return status
+}
+
+// do executes f unless the circuit breaker is open and records
+// the outcome of the operation.
+func (b *breakerKMS) do(operation string, f func() error) error {
+	var deadline time.Time
+	if b.config.Mode == BreakerQueue {
+		deadline = time.Now().Add(b.config.QueueTimeout)
+	}
+	for {
+		allowed, recovered := b.allow()
+		if allowed {
+			break
+		}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			b.record(operation, 0, ErrUnavailable, true)
+			return ErrUnavailable
+		}
+		if wait > time.Second {
+			wait = time.Second
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-recovered:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+
+	start := time.Now()
+	err := f()
+	b.record(operation, time.Since(start), err, false)
+	return err
+}
+
+// allow returns true if a request may be sent to the KMS. Otherwise,
+// it returns a channel that is closed once the KMS has recovered.
+func (b *breakerKMS) allow() (bool, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true, nil
+	}
+	if !b.probing && time.Since(b.openedAt) >= b.config.Cooldown {
+		b.probing = true
+		return true, nil
+	}
+	return false, b.recovered
+}
+
+func (b *breakerKMS) record(operation string, latency time.Duration, err error, rejected bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, op := range b.operations {
+		if op.Operation != operation {
+			continue
+		}
+		op.Requests++
+		switch {
+		case rejected:
+			op.Rejected++
+		case err != nil:
+			op.Errors++
+		case op.Latency == 0:
+			op.Latency = latency
+		default:
+			op.Latency = (7*op.Latency + latency) / 8
+		}
+	}
+	if rejected || b.config.Mode == BreakerOff {
+		return
+	}
+
+	b.probing = false
+	if isUnavailable(err) {
+		b.failures++
+		if b.open {
+			b.openedAt = time.Now()
+		} else if b.failures >= b.config.Threshold {
+			b.open, b.openedAt = true, time.Now()
+		}
+		return
+	}
+	b.failures = 0
+	if b.open {
+		b.open = false
+		close(b.recovered)
+		b.recovered = make(chan struct{})
+	}
+}
+
+func cacheID(keyID string, ciphertext []byte, context Context) [sha256.Size]byte {
+	associatedData, _ := context.MarshalText()
+	h := sha256.New()
+	h.Write([]byte(keyID))
+	h.Write([]byte{0})
+	h.Write(ciphertext)
+	h.Write([]byte{0})
+	h.Write(associatedData)
+
+	var id [sha256.Size]byte
+	h.Sum(id[:0])
+	return id
+}
+
+func (b *breakerKMS) cacheKey(keyID string, ciphertext []byte, context Context, plaintext []byte) {
+	if b.cache == nil {
+		return
+	}
+	id := cacheID(keyID, ciphertext, context)
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.cache[id]; !ok && len(b.cache) >= b.config.CacheSize {
+		for id, key := range b.cache {
+			if now.After(key.expires) {
+				delete(b.cache, id)
+			}
+		}
+		if len(b.cache) >= b.config.CacheSize {
+			return
+		}
+	}
+	b.cache[id] = cachedKey{
+		plaintext: append([]byte(nil), plaintext...),
+		expires:   now.Add(b.config.CacheTTL),
+	}
+}
+
+func (b *breakerKMS) cachedKey(keyID string, ciphertext []byte, context Context) ([]byte, bool) {
+	if b.cache == nil {
+		return nil, false
+	}
+	id := cacheID(keyID, ciphertext, context)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key, ok := b.cache[id]
+	if !ok || time.Now().After(key.expires) {
+		return nil, false
+	}
+	return append([]byte(nil), key.plaintext...), true
+}
+
+// isUnavailable returns true if err indicates that the KMS
+// could not be reached or failed to process the request.
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var (
+		netErr   net.Error
+		kesErr   kes.Error
+		vaultErr vaultError
+	)
+	switch {
+	case errors.As(err, &kesErr):
+		return kesErr.Status() >= http.StatusInternalServerError
+	case errors.As(err, &vaultErr):
+		return vaultErr.StatusCode >= http.StatusInternalServerError
+	case errors.As(err, &netErr):
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
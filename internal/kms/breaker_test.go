@@ -0,0 +1,205 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errConnRefused error = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+// flakyKMS wraps a KMS and fails all requests with a network
+// error while down is set.
+type flakyKMS struct {
+	KMS
+	down int32
+}
+
+func (f *flakyKMS) setDown(down bool) {
+	if down {
+		atomic.StoreInt32(&f.down, 1)
+	} else {
+		atomic.StoreInt32(&f.down, 0)
+	}
+}
+
+func (f *flakyKMS) GenerateKey(keyID string, context Context) (DEK, error) {
+	if atomic.LoadInt32(&f.down) == 1 {
+		return DEK{}, errConnRefused
+	}
+	return f.KMS.GenerateKey(keyID, context)
+}
+
+func (f *flakyKMS) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	if atomic.LoadInt32(&f.down) == 1 {
+		return nil, errConnRefused
+	}
+	return f.KMS.DecryptKey(keyID, ciphertext, context)
+}
+
+func newFlakyKMS(t *testing.T) *flakyKMS {
+	KMS, err := Parse("my-key:eEm+JI9/q4JhH8QwKvf3LKo4DEBl6QbfvAl1CAbMIv8=")
+	if err != nil {
+		t.Fatalf("Failed to initialize KMS: %v", err)
+	}
+	return &flakyKMS{KMS: KMS}
+}
+
+func TestBreakerReject(t *testing.T) {
+	backend := newFlakyKMS(t)
+	KMS := NewBreaker(backend, BreakerConfig{
+		Mode:      BreakerReject,
+		Threshold: 2,
+		Cooldown:  time.Hour,
+	})
+
+	backend.setDown(true)
+	for i := 0; i < 2; i++ {
+		if _, err := KMS.GenerateKey("my-key", Context{}); !errors.Is(err, errConnRefused) {
+			t.Fatalf("Request %d: got error %v - want %v", i, err, errConnRefused)
+		}
+	}
+	backend.setDown(false)
+	if _, err := KMS.GenerateKey("my-key", Context{}); err != ErrUnavailable {
+		t.Fatalf("Open breaker did not reject request: got error %v", err)
+	}
+
+	status, ok := Breaker(KMS)
+	if !ok || !status.Open {
+		t.Fatalf("Breaker is not open: %+v", status)
+	}
+	for _, op := range status.Operations {
+		if op.Operation != OpGenerateKey {
+			continue
+		}
+		if op.Requests != 3 || op.Errors != 2 || op.Rejected != 1 {
+			t.Fatalf("Invalid operation stats: %+v", op)
+		}
+	}
+}
+
+func TestBreakerRecover(t *testing.T) {
+	backend := newFlakyKMS(t)
+	KMS := NewBreaker(backend, BreakerConfig{
+		Mode:      BreakerReject,
+		Threshold: 1,
+		Cooldown:  10 * time.Millisecond,
+	})
+
+	backend.setDown(true)
+	KMS.GenerateKey("my-key", Context{})
+	backend.setDown(false)
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := KMS.GenerateKey("my-key", Context{}); err != nil {
+		t.Fatalf("Breaker did not let probe request through: %v", err)
+	}
+	if status, _ := Breaker(KMS); status.Open {
+		t.Fatal("Breaker did not close after a successful request")
+	}
+}
+
+func TestBreakerQueue(t *testing.T) {
+	backend := newFlakyKMS(t)
+	KMS := NewBreaker(backend, BreakerConfig{
+		Mode:         BreakerQueue,
+		Threshold:    1,
+		Cooldown:     10 * time.Millisecond,
+		QueueTimeout: time.Minute,
+	})
+
+	backend.setDown(true)
+	KMS.GenerateKey("my-key", Context{})
+	backend.setDown(false)
+
+	// The request is held back until the cooldown has passed.
+	if _, err := KMS.GenerateKey("my-key", Context{}); err != nil {
+		t.Fatalf("Queued request failed: %v", err)
+	}
+
+	KMS = NewBreaker(backend, BreakerConfig{
+		Mode:         BreakerQueue,
+		Threshold:    1,
+		Cooldown:     time.Hour,
+		QueueTimeout: 10 * time.Millisecond,
+	})
+	backend.setDown(true)
+	KMS.GenerateKey("my-key", Context{})
+	if _, err := KMS.GenerateKey("my-key", Context{}); err != ErrUnavailable {
+		t.Fatalf("Queued request did not time out: got error %v", err)
+	}
+}
+
+func TestBreakerCache(t *testing.T) {
+	backend := newFlakyKMS(t)
+	KMS := NewBreaker(backend, BreakerConfig{
+		Mode:      BreakerCache,
+		Threshold: 1,
+		Cooldown:  time.Hour,
+		CacheTTL:  time.Hour,
+		CacheSize: 1,
+	})
+
+	context := Context{"bucket": "object"}
+	key, err := KMS.GenerateKey("my-key", context)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	other, err := backend.GenerateKey("my-key", context)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	backend.setDown(true)
+	plaintext, err := KMS.DecryptKey(key.KeyID, key.Ciphertext, context)
+	if err != nil {
+		t.Fatalf("Failed to decrypt cached key: %v", err)
+	}
+	if !bytes.Equal(plaintext, key.Plaintext) {
+		t.Fatalf("Cached key does not match generated one: got %x - want %x", plaintext, key.Plaintext)
+	}
+	if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{}); err == nil {
+		t.Fatal("Decrypted cached key with a different context")
+	}
+	if _, err = KMS.DecryptKey(other.KeyID, other.Ciphertext, context); err != ErrUnavailable {
+		t.Fatalf("Decrypted key that is not cached: got error %v", err)
+	}
+	if _, err = KMS.GenerateKey("my-key", context); err != ErrUnavailable {
+		t.Fatalf("Generated key while KMS is unavailable: got error %v", err)
+	}
+}
+
+func TestBreakerOff(t *testing.T) {
+	backend := newFlakyKMS(t)
+	KMS := NewBreaker(backend, BreakerConfig{Mode: BreakerOff, Threshold: 1})
+
+	backend.setDown(true)
+	for i := 0; i < 3; i++ {
+		if _, err := KMS.GenerateKey("my-key", Context{}); !errors.Is(err, errConnRefused) {
+			t.Fatalf("Request %d: got error %v - want %v", i, err, errConnRefused)
+		}
+	}
+	if status, _ := Breaker(KMS); status.Open {
+		t.Fatal("Breaker opened although it is turned off")
+	}
+}
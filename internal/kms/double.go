@@ -0,0 +1,186 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/secure-io/sio-go/sioutil"
+)
+
+// algorithmDouble marks ciphertexts of data encryption keys
+// encrypted by two KMS.
+const algorithmDouble = "DOUBLE-AES-256-GCM"
+
+// NewDouble returns a KMS that encrypts every data encryption key
+// with two independent KMS. Each DEK is generated and encrypted by
+// the primary KMS. The resulting ciphertext is then encrypted again
+// with AES-256-GCM using a key generated by the secondary KMS, such
+// that the secondary KMS and then the primary KMS are required to
+// decrypt the DEK.
+//
+// Key IDs refer to keys at the primary KMS. The secondary KMS
+// always uses its default key.
+//
+// Ciphertexts produced by the primary KMS alone - e.g. for objects
+// encrypted before double encryption got enabled - can still be
+// decrypted.
+func NewDouble(primary, secondary KMS) KMS {
+	return &doubleKMS{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+type doubleKMS struct {
+	primary   KMS
+	secondary KMS
+}
+
+var _ KMS = (*doubleKMS)(nil) // compiler check
+
+// doubleEncryptedKey is the ciphertext of a DEK generated by
+// a doubleKMS: the ciphertext produced by the primary KMS,
+// encrypted with the key sealed by the secondary KMS.
+type doubleEncryptedKey struct {
+	Algorithm      string `json:"aead"`
+	SecondaryKeyID string `json:"secondaryKeyId"`
+	Secondary      []byte `json:"secondary"`
+	Nonce          []byte `json:"nonce"`
+	Bytes          []byte `json:"bytes"`
+}
+
+func (d *doubleKMS) endpointStats() []EndpointStat {
+	return append(EndpointStats(d.primary), EndpointStats(d.secondary)...)
+}
+
+// Stat returns the combined status of both KMS.
+func (d *doubleKMS) Stat() (Status, error) {
+	primary, err := d.primary.Stat()
+	if err != nil {
+		return Status{}, err
+	}
+	secondary, err := d.secondary.Stat()
+	if err != nil {
+		return Status{}, err
+	}
+	endpoints := make([]string, 0, len(primary.Endpoints)+len(secondary.Endpoints))
+	endpoints = append(endpoints, primary.Endpoints...)
+	endpoints = append(endpoints, secondary.Endpoints...)
+	return Status{
+		Name:       primary.Name + "+" + secondary.Name,
+		Endpoints:  endpoints,
+		DefaultKey: primary.DefaultKey,
+	}, nil
+}
+
+// CreateKey creates the key at the primary KMS.
+func (d *doubleKMS) CreateKey(keyID string) error {
+	return d.primary.CreateKey(keyID)
+}
+
+// importKey imports the key into the primary KMS.
+func (d *doubleKMS) importKey(keyID string, key []byte) error {
+	return ImportKey(d.primary, keyID, key)
+}
+
+// GenerateKey generates a new data encryption key with the key at
+// the primary KMS referenced by the key ID and encrypts its
+// ciphertext with a key generated by the default key of the
+// secondary KMS.
+func (d *doubleKMS) GenerateKey(keyID string, context Context) (DEK, error) {
+	primary, err := d.primary.GenerateKey(keyID, context)
+	if err != nil {
+		return DEK{}, err
+	}
+	secondary, err := d.secondary.GenerateKey("", context)
+	if err != nil {
+		return DEK{}, fmt.Errorf("kms: secondary KMS: %w", err)
+	}
+	aead, err := newDoubleAEAD(secondary.Plaintext)
+	if err != nil {
+		return DEK{}, err
+	}
+	nonce, err := sioutil.Random(aead.NonceSize())
+	if err != nil {
+		return DEK{}, err
+	}
+	associatedData, _ := context.MarshalText()
+
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	ciphertext, err := json.Marshal(doubleEncryptedKey{
+		Algorithm:      algorithmDouble,
+		SecondaryKeyID: secondary.KeyID,
+		Secondary:      secondary.Ciphertext,
+		Nonce:          nonce,
+		Bytes:          aead.Seal(nil, nonce, primary.Ciphertext, associatedData),
+	})
+	if err != nil {
+		return DEK{}, err
+	}
+	return DEK{
+		KeyID:      primary.KeyID,
+		Plaintext:  primary.Plaintext,
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+// DecryptKey decrypts the outer layer of the ciphertext with the
+// secondary KMS and the inner layer with the primary KMS.
+// Ciphertexts not generated by a doubleKMS are decrypted by the
+// primary KMS.
+func (d *doubleKMS) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	var encryptedKey doubleEncryptedKey
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(ciphertext, &encryptedKey); err != nil || encryptedKey.Algorithm != algorithmDouble {
+		return d.primary.DecryptKey(keyID, ciphertext, context)
+	}
+
+	secondary, err := d.secondary.DecryptKey(encryptedKey.SecondaryKeyID, encryptedKey.Secondary, context)
+	if err != nil {
+		return nil, fmt.Errorf("kms: secondary KMS: %w", err)
+	}
+	aead, err := newDoubleAEAD(secondary)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(encryptedKey.Nonce); n != aead.NonceSize() {
+		return nil, fmt.Errorf("kms: invalid nonce size %d", n)
+	}
+	associatedData, _ := context.MarshalText()
+	primary, err := aead.Open(nil, encryptedKey.Nonce, encryptedKey.Bytes, associatedData)
+	if err != nil {
+		return nil, errors.New("kms: double encrypted key is not authentic")
+	}
+	return d.primary.DecryptKey(keyID, primary, context)
+}
+
+func newDoubleAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, errors.New("kms: secondary data encryption key is not 256 bits")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestDoubleRoundtrip(t *testing.T) {
+	primary, err := Parse("primary-key:eEm+JI9/q4JhH8QwKvf3LKo4DEBl6QbfvAl1CAbMIv8=")
+	if err != nil {
+		t.Fatalf("Failed to initialize primary KMS: %v", err)
+	}
+	secondary, err := Parse("secondary-key:5lF+0pJM0OWwlQrvK2S/I7W9mO4a6rJJI7wzj7v09cw=")
+	if err != nil {
+		t.Fatalf("Failed to initialize secondary KMS: %v", err)
+	}
+	KMS := NewDouble(primary, secondary)
+
+	context := Context{"bucket": "object"}
+	key, err := KMS.GenerateKey("primary-key", context)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	plaintext, err := KMS.DecryptKey(key.KeyID, key.Ciphertext, context)
+	if err != nil {
+		t.Fatalf("Failed to decrypt key: %v", err)
+	}
+	if !bytes.Equal(key.Plaintext, plaintext) {
+		t.Fatalf("Decrypted key does not match generated one: got %x - want %x", plaintext, key.Plaintext)
+	}
+
+	// A single KMS must not be able to decrypt the key.
+	if _, err = primary.DecryptKey(key.KeyID, key.Ciphertext, context); err == nil {
+		t.Fatal("Primary KMS decrypted a double encrypted key")
+	}
+
+	// The data key is generated by the primary KMS and its ciphertext
+	// is only readable through the secondary KMS.
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	var encryptedKey doubleEncryptedKey
+	if err = json.Unmarshal(key.Ciphertext, &encryptedKey); err != nil {
+		t.Fatal(err)
+	}
+	inner, err := secondary.DecryptKey(encryptedKey.SecondaryKeyID, encryptedKey.Secondary, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := newDoubleAEAD(inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	associatedData, _ := context.MarshalText()
+	primaryCiphertext, err := aead.Open(nil, encryptedKey.Nonce, encryptedKey.Bytes, associatedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plaintext, err = primary.DecryptKey(key.KeyID, primaryCiphertext, context); err != nil || !bytes.Equal(plaintext, key.Plaintext) {
+		t.Fatalf("Primary KMS did not decrypt the inner layer: %v", err)
+	}
+	if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{"bucket": "other"}); err == nil {
+		t.Fatal("Decrypted a double encrypted key with another context")
+	}
+	other, err := Parse("secondary-key:eEm+JI9/q4JhH8QwKvf3LKo4DEBl6QbfvAl1CAbMIv8=")
+	if err != nil {
+		t.Fatalf("Failed to initialize KMS: %v", err)
+	}
+	if _, err = NewDouble(primary, other).DecryptKey(key.KeyID, key.Ciphertext, context); err == nil {
+		t.Fatal("Decrypted a double encrypted key with the wrong secondary KMS")
+	}
+
+	// Keys generated by the primary KMS alone remain readable.
+	single, err := primary.GenerateKey("primary-key", context)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	plaintext, err = KMS.DecryptKey(single.KeyID, single.Ciphertext, context)
+	if err != nil {
+		t.Fatalf("Failed to decrypt key: %v", err)
+	}
+	if !bytes.Equal(single.Plaintext, plaintext) {
+		t.Fatalf("Decrypted key does not match generated one: got %x - want %x", plaintext, single.Plaintext)
+	}
+}
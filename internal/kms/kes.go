@@ -22,9 +22,13 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"net/http"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/minio/kes"
+	"github.com/minio/minio/internal/fips"
 )
 
 // Config contains various KMS-related configuration
@@ -51,42 +55,191 @@ type Config struct {
 
 // NewWithConfig returns a new KMS using the given
 // configuration.
+//
+// Requests are routed to the healthy endpoint with the
+// lowest latency. If an endpoint cannot be reached, the
+// request fails over to the next one. Offline endpoints
+// are health-checked in the background and receive
+// requests again once they respond.
 func NewWithConfig(config Config) (KMS, error) {
 	if len(config.Endpoints) == 0 {
 		return nil, errors.New("kms: no server endpoints")
 	}
-	endpoints := make([]string, len(config.Endpoints)) // Copy => avoid being affect by any changes to the original slice
-	copy(endpoints, config.Endpoints)
 
-	client := kes.NewClientWithConfig("", &tls.Config{
+	client := kes.NewClientWithConfig("", fips.RestrictTLS(&tls.Config{
 		MinVersion:   tls.VersionTLS12,
 		Certificates: []tls.Certificate{config.Certificate},
 		RootCAs:      config.RootCAs,
-	})
-	client.Endpoints = endpoints
-	return &kesClient{
-		client:       client,
+	}))
+	c := &kesClient{
 		defaultKeyID: config.DefaultKeyID,
-	}, nil
+		endpoints:    make([]*kesEndpoint, 0, len(config.Endpoints)),
+	}
+	for _, endpoint := range config.Endpoints {
+		c.endpoints = append(c.endpoints, &kesEndpoint{
+			client: &kes.Client{
+				Endpoints:  []string{endpoint},
+				HTTPClient: client.HTTPClient, // All endpoints share the same transport.
+			},
+			online: true,
+		})
+	}
+	go c.healthCheck(kesHealthCheckInterval)
+	return c, nil
 }
 
+const (
+	kesHealthCheckInterval = 10 * time.Second
+	kesHealthCheckTimeout  = 5 * time.Second
+)
+
 type kesClient struct {
 	defaultKeyID string
-	client       *kes.Client
+	endpoints    []*kesEndpoint
 }
 
 var _ KMS = (*kesClient)(nil) // compiler check
 
+// kesEndpoint tracks the health and latency of
+// a single KES server endpoint.
+type kesEndpoint struct {
+	client *kes.Client
+
+	mu       sync.Mutex
+	online   bool
+	latency  time.Duration // moving average
+	requests uint64
+	failures uint64
+}
+
+func (e *kesEndpoint) stat() EndpointStat {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return EndpointStat{
+		Endpoint: e.client.Endpoints[0],
+		Online:   e.online,
+		Latency:  e.latency,
+		Requests: e.requests,
+		Failures: e.failures,
+	}
+}
+
+// update records the outcome of a request or health check
+// against the endpoint.
+func (e *kesEndpoint) update(online bool, latency time.Duration, request bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.online = online
+	if request {
+		e.requests++
+		if !online {
+			e.failures++
+		}
+	}
+	if online {
+		if e.latency == 0 {
+			e.latency = latency
+		} else {
+			e.latency = (7*e.latency + latency) / 8
+		}
+	}
+}
+
+// isEndpointFailure returns true if err indicates that the
+// endpoint itself, not the request, failed.
+func isEndpointFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var kesErr kes.Error
+	if errors.As(err, &kesErr) {
+		return kesErr.Status() >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// route returns the endpoints in the order they should
+// be tried: online endpoints ordered by latency followed
+// by offline endpoints.
+func (c *kesClient) route() []*kesEndpoint {
+	type candidate struct {
+		endpoint *kesEndpoint
+		stat     EndpointStat
+	}
+	candidates := make([]candidate, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		candidates = append(candidates, candidate{endpoint: e, stat: e.stat()})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].stat.Online != candidates[j].stat.Online {
+			return candidates[i].stat.Online
+		}
+		return candidates[i].stat.Latency < candidates[j].stat.Latency
+	})
+	endpoints := make([]*kesEndpoint, 0, len(candidates))
+	for _, c := range candidates {
+		endpoints = append(endpoints, c.endpoint)
+	}
+	return endpoints
+}
+
+// do executes f with the endpoints returned by route until
+// one of them is reachable or the context is done.
+func (c *kesClient) do(ctx context.Context, f func(*kes.Client) error) (err error) {
+	for _, e := range c.route() {
+		start := time.Now()
+		err = f(e.client)
+		if !isEndpointFailure(err) {
+			e.update(true, time.Since(start), true)
+			return err
+		}
+		e.update(false, 0, true)
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// healthCheck periodically checks whether the endpoints
+// respond and measures their latency.
+func (c *kesClient) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, e := range c.endpoints {
+			ctx, cancel := context.WithTimeout(context.Background(), kesHealthCheckTimeout)
+			start := time.Now()
+			_, err := e.client.Version(ctx)
+			cancel()
+			e.update(!isEndpointFailure(err), time.Since(start), false)
+		}
+	}
+}
+
+func (c *kesClient) endpointStats() []EndpointStat {
+	stats := make([]EndpointStat, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		stats = append(stats, e.stat())
+	}
+	return stats
+}
+
 // Stat returns the current KES status containing a
 // list of KES endpoints and the default key ID.
 func (c *kesClient) Stat() (Status, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if _, err := c.client.Version(ctx); err != nil {
+	if err := c.do(ctx, func(client *kes.Client) error {
+		_, err := client.Version(ctx)
+		return err
+	}); err != nil {
 		return Status{}, err
 	}
-	endpoints := make([]string, len(c.client.Endpoints))
-	copy(endpoints, c.client.Endpoints)
+	endpoints := make([]string, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		endpoints = append(endpoints, e.client.Endpoints[0])
+	}
 	return Status{
 		Name:       "KES",
 		Endpoints:  endpoints,
@@ -100,7 +253,17 @@ func (c *kesClient) Stat() (Status, error) {
 // If the a key with the same keyID already exists then
 // CreateKey returns kes.ErrKeyExists.
 func (c *kesClient) CreateKey(keyID string) error {
-	return c.client.CreateKey(context.Background(), keyID)
+	ctx := context.Background()
+	return c.do(ctx, func(client *kes.Client) error {
+		return client.CreateKey(ctx, keyID)
+	})
+}
+
+func (c *kesClient) importKey(keyID string, key []byte) error {
+	ctx := context.Background()
+	return c.do(ctx, func(client *kes.Client) error {
+		return client.ImportKey(ctx, keyID, key)
+	})
 }
 
 // GenerateKey generates a new data encryption key using
@@ -119,7 +282,13 @@ func (c *kesClient) GenerateKey(keyID string, ctx Context) (DEK, error) {
 	if err != nil {
 		return DEK{}, err
 	}
-	dek, err := c.client.GenerateKey(context.Background(), keyID, ctxBytes)
+
+	var dek kes.DEK
+	reqCtx := context.Background()
+	err = c.do(reqCtx, func(client *kes.Client) (err error) {
+		dek, err = client.GenerateKey(reqCtx, keyID, ctxBytes)
+		return err
+	})
 	if err != nil {
 		return DEK{}, err
 	}
@@ -138,5 +307,12 @@ func (c *kesClient) DecryptKey(keyID string, ciphertext []byte, ctx Context) ([]
 	if err != nil {
 		return nil, err
 	}
-	return c.client.Decrypt(context.Background(), keyID, ciphertext, ctxBytes)
+
+	var plaintext []byte
+	reqCtx := context.Background()
+	err = c.do(reqCtx, func(client *kes.Client) (err error) {
+		plaintext, err = client.Decrypt(reqCtx, keyID, ciphertext, ctxBytes)
+		return err
+	})
+	return plaintext, err
 }
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newKESTestServer(status int) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string][]byte{
+			"plaintext":  make([]byte, 32),
+			"ciphertext": []byte("ciphertext"),
+		})
+	}))
+}
+
+func TestKESFailover(t *testing.T) {
+	offline := newKESTestServer(http.StatusOK)
+	offline.Close()
+	failing := newKESTestServer(http.StatusServiceUnavailable)
+	defer failing.Close()
+	online := newKESTestServer(http.StatusOK)
+	defer online.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(failing.Certificate())
+	rootCAs.AddCert(online.Certificate())
+	KMS, err := NewWithConfig(Config{
+		Endpoints:    []string{offline.URL, failing.URL, online.URL},
+		DefaultKeyID: "my-key",
+		RootCAs:      rootCAs,
+	})
+	if err != nil {
+		t.Fatalf("Failed to initialize KMS: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err = KMS.GenerateKey("", Context{}); err != nil {
+			t.Fatalf("Failed to generate key: %v", err)
+		}
+	}
+
+	stats := EndpointStats(KMS)
+	if len(stats) != 3 {
+		t.Fatalf("Expected 3 endpoint stats, got %d", len(stats))
+	}
+	for i, want := range []struct {
+		online   bool
+		requests uint64
+		failures uint64
+	}{
+		{false, 1, 1},
+		{false, 1, 1},
+		{true, 2, 0},
+	} {
+		if stats[i].Online != want.online || stats[i].Requests != want.requests || stats[i].Failures != want.failures {
+			t.Errorf("Endpoint %d: got %+v - want %+v", i, stats[i], want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/minio/kes"
+	"github.com/minio/minio/internal/fips"
+	"github.com/minio/minio/internal/kmip"
+	"github.com/secure-io/sio-go/sioutil"
+)
+
+// KMIPConfig contains the configuration of KMIP servers, e.g HSM
+// appliances, used as KMS.
+type KMIPConfig struct {
+	// Endpoints of the KMIP servers as host:port.
+	Endpoints []string
+
+	// DefaultKeyID is the name of the key used when
+	// no explicit key ID is specified.
+	DefaultKeyID string
+
+	// Version is the KMIP protocol version, "1.4" or "2.0".
+	Version string
+
+	// Certificate is the client TLS certificate
+	// to authenticate to the KMIP servers.
+	Certificate tls.Certificate
+
+	// RootCAs is a set of root CA certificates
+	// to verify the KMIP server TLS certificates.
+	RootCAs *x509.CertPool
+}
+
+// NewKMIP returns a new KMS using the KMIP servers of the given
+// configuration. Data encryption keys are generated by MinIO and
+// encrypted by the servers with AES-GCM keys looked up by name.
+func NewKMIP(config KMIPConfig) (KMS, error) {
+	version, err := kmip.ParseVersion(config.Version)
+	if err != nil {
+		return nil, err
+	}
+	client, err := kmip.NewClient(kmip.Config{
+		Endpoints: config.Endpoints,
+		TLS: fips.RestrictTLS(&tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{config.Certificate},
+			RootCAs:      config.RootCAs,
+		}),
+		Version: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newKMIPClient(client, config.DefaultKeyID), nil
+}
+
+func newKMIPClient(client *kmip.Client, defaultKeyID string) *kmipClient {
+	return &kmipClient{
+		client:       client,
+		defaultKeyID: defaultKeyID,
+		ids:          make(map[string]string),
+	}
+}
+
+type kmipClient struct {
+	client       *kmip.Client
+	defaultKeyID string
+
+	// Unique identifiers of the keys by name.
+	mu  sync.RWMutex
+	ids map[string]string
+}
+
+var _ KMS = (*kmipClient)(nil) // compiler check
+
+const algorithmKMIPAESGCM = "KMIP-AES-256-GCM"
+
+// kmipEncryptedKey is a data encryption key encrypted by a KMIP server.
+type kmipEncryptedKey struct {
+	Algorithm string `json:"aead"`
+	Nonce     []byte `json:"nonce"`
+	Bytes     []byte `json:"bytes"`
+	Tag       []byte `json:"tag"`
+}
+
+func kmipContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+// Stat returns the current KMIP status containing the
+// KMIP endpoints and the default key ID.
+func (c *kmipClient) Stat() (Status, error) {
+	ctx, cancel := kmipContext()
+	defer cancel()
+	if err := c.client.Query(ctx); err != nil {
+		return Status{}, err
+	}
+	return Status{
+		Name:       "KMIP " + c.client.Version().String(),
+		Endpoints:  c.client.Endpoints(),
+		DefaultKey: c.defaultKeyID,
+	}, nil
+}
+
+// CreateKey creates a new AES key by the key ID at the KMIP server.
+//
+// If a key with the same keyID already exists then
+// CreateKey returns kes.ErrKeyExists.
+func (c *kmipClient) CreateKey(keyID string) error {
+	ctx, cancel := kmipContext()
+	defer cancel()
+	if _, err := c.lookup(ctx, keyID); err == nil {
+		return kes.ErrKeyExists
+	} else if !errors.Is(err, kmip.ErrNotFound) {
+		return err
+	}
+	id, err := c.client.Create(ctx, keyID)
+	if err != nil {
+		return err
+	}
+	if err = c.client.Activate(ctx, id); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.ids[keyID] = id
+	c.mu.Unlock()
+	return nil
+}
+
+// lookup returns the unique identifier of the key by the key ID.
+func (c *kmipClient) lookup(ctx context.Context, keyID string) (string, error) {
+	c.mu.RLock()
+	id, ok := c.ids[keyID]
+	c.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+	id, err := c.client.Locate(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.ids[keyID] = id
+	c.mu.Unlock()
+	return id, nil
+}
+
+// GenerateKey generates a new data encryption key and encrypts
+// it with the key at the KMIP server referenced by the key ID.
+//
+// The default key ID will be used if keyID is empty.
+//
+// The context is associated and tied to the generated DEK.
+// The same context must be provided when the generated
+// key should be decrypted.
+func (c *kmipClient) GenerateKey(keyID string, context Context) (DEK, error) {
+	if keyID == "" {
+		keyID = c.defaultKeyID
+	}
+	ctx, cancel := kmipContext()
+	defer cancel()
+	id, err := c.lookup(ctx, keyID)
+	if err != nil {
+		return DEK{}, fmt.Errorf("kms: key %q does not exist: %w", keyID, err)
+	}
+
+	plaintext, err := sioutil.Random(32)
+	if err != nil {
+		return DEK{}, err
+	}
+	nonce, err := sioutil.Random(12)
+	if err != nil {
+		return DEK{}, err
+	}
+	associatedData, _ := context.MarshalText()
+	ciphertext, tag, err := c.client.Encrypt(ctx, id, plaintext, nonce, associatedData)
+	if err != nil {
+		return DEK{}, err
+	}
+
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	ciphertext, err = json.Marshal(kmipEncryptedKey{
+		Algorithm: algorithmKMIPAESGCM,
+		Nonce:     nonce,
+		Bytes:     ciphertext,
+		Tag:       tag,
+	})
+	if err != nil {
+		return DEK{}, err
+	}
+	return DEK{
+		KeyID:      keyID,
+		Plaintext:  plaintext,
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+// DecryptKey decrypts the ciphertext with the key at the KMIP
+// server referenced by the key ID. The context must match the
+// context value used to generate the ciphertext.
+func (c *kmipClient) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	var encryptedKey kmipEncryptedKey
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	if err := json.Unmarshal(ciphertext, &encryptedKey); err != nil {
+		return nil, err
+	}
+	if encryptedKey.Algorithm != algorithmKMIPAESGCM {
+		return nil, fmt.Errorf("kms: invalid algorithm: %q", encryptedKey.Algorithm)
+	}
+
+	ctx, cancel := kmipContext()
+	defer cancel()
+	id, err := c.lookup(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("kms: key %q does not exist: %w", keyID, err)
+	}
+	associatedData, _ := context.MarshalText()
+	return c.client.Decrypt(ctx, id, encryptedKey.Bytes, encryptedKey.Nonce, associatedData, encryptedKey.Tag)
+}
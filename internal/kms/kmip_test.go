@@ -0,0 +1,222 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/minio/kes"
+	"github.com/minio/minio/internal/kmip"
+)
+
+// testKMIPServer serves KMIP requests with AES keys held in memory.
+// Like KMIP servers enforcing the key state, it only encrypts and
+// decrypts with activated keys.
+type testKMIPServer struct {
+	mu     sync.Mutex
+	names  map[string]string
+	keys   map[string]cipher.AEAD
+	active map[string]bool
+}
+
+func (s *testKMIPServer) dial(ctx context.Context, endpoint string) (net.Conn, error) {
+	client, server := net.Pipe()
+	go s.serve(server)
+	return client, nil
+}
+
+func (s *testKMIPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		req, err := kmip.ReadMessage(conn)
+		if err != nil {
+			return
+		}
+		batch, _ := req.Find(kmip.TagBatchItem)
+		op, _ := batch.Find(kmip.TagOperation)
+		payload, _ := batch.Find(kmip.TagRequestPayload)
+		items, err := s.handle(op.Int(), payload)
+
+		result := []kmip.Item{kmip.Enumeration(kmip.TagOperation, op.Int())}
+		if err != nil {
+			result = append(result,
+				kmip.Enumeration(kmip.TagResultStatus, 1),
+				kmip.Enumeration(kmip.TagResultReason, kmip.ResultReasonItemNotFound),
+				kmip.TextString(kmip.TagResultMessage, err.Error()),
+			)
+		} else {
+			result = append(result,
+				kmip.Enumeration(kmip.TagResultStatus, kmip.ResultStatusSuccess),
+				kmip.Structure(kmip.TagResponsePayload, items...),
+			)
+		}
+		b, _ := kmip.Structure(kmip.TagResponseMessage,
+			kmip.Structure(kmip.TagResponseHeader, kmip.Integer(kmip.TagBatchCount, 1)),
+			kmip.Structure(kmip.TagBatchItem, result...),
+		).MarshalTTLV()
+		if _, err = conn.Write(b); err != nil {
+			return
+		}
+	}
+}
+
+func (s *testKMIPServer) handle(op int32, payload kmip.Item) ([]kmip.Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bytesOf := func(tag kmip.Tag) []byte {
+		item, _ := payload.Find(tag)
+		return item.Bytes()
+	}
+	key := func() (string, cipher.AEAD, error) {
+		id, _ := payload.Find(kmip.TagUniqueIdentifier)
+		aead, ok := s.keys[id.Text()]
+		if !ok {
+			return "", nil, errors.New("key not found")
+		}
+		if !s.active[id.Text()] {
+			return "", nil, errors.New("key is not active")
+		}
+		return id.Text(), aead, nil
+	}
+
+	switch op {
+	case kmip.OperationCreate:
+		tmpl, _ := payload.Find(kmip.TagTemplateAttribute)
+		for _, attr := range tmpl.Items() {
+			if name, _ := attr.Find(kmip.TagAttributeName); name.Text() != "Name" {
+				continue
+			}
+			value, _ := attr.Find(kmip.TagAttributeValue)
+			name, _ := value.Find(kmip.TagNameValue)
+			id := strconv.Itoa(len(s.keys) + 1)
+			block, _ := aes.NewCipher(bytes.Repeat([]byte{byte(len(s.keys))}, 32))
+			s.keys[id], _ = cipher.NewGCM(block)
+			s.names[name.Text()] = id
+			return []kmip.Item{kmip.TextString(kmip.TagUniqueIdentifier, id)}, nil
+		}
+		return nil, errors.New("missing name")
+	case kmip.OperationActivate:
+		id, _ := payload.Find(kmip.TagUniqueIdentifier)
+		if _, ok := s.keys[id.Text()]; !ok {
+			return nil, errors.New("key not found")
+		}
+		s.active[id.Text()] = true
+		return []kmip.Item{kmip.TextString(kmip.TagUniqueIdentifier, id.Text())}, nil
+	case kmip.OperationLocate:
+		attr, _ := payload.Find(kmip.TagAttribute)
+		value, _ := attr.Find(kmip.TagAttributeValue)
+		name, _ := value.Find(kmip.TagNameValue)
+		id, ok := s.names[name.Text()]
+		if !ok {
+			return nil, nil
+		}
+		return []kmip.Item{kmip.Integer(0x4200D5, 1), kmip.TextString(kmip.TagUniqueIdentifier, id)}, nil
+	case kmip.OperationEncrypt:
+		id, aead, err := key()
+		if err != nil {
+			return nil, err
+		}
+		sealed := aead.Seal(nil, bytesOf(kmip.TagIVCounterNonce), bytesOf(kmip.TagData), bytesOf(kmip.TagAuthenticatedEncryptionAdditionalData))
+		n := len(sealed) - aead.Overhead()
+		return []kmip.Item{
+			kmip.TextString(kmip.TagUniqueIdentifier, id),
+			kmip.ByteString(kmip.TagData, sealed[:n]),
+			kmip.ByteString(kmip.TagAuthenticatedEncryptionTag, sealed[n:]),
+		}, nil
+	case kmip.OperationDecrypt:
+		id, aead, err := key()
+		if err != nil {
+			return nil, err
+		}
+		sealed := append(bytesOf(kmip.TagData), bytesOf(kmip.TagAuthenticatedEncryptionTag)...)
+		plaintext, err := aead.Open(nil, bytesOf(kmip.TagIVCounterNonce), sealed, bytesOf(kmip.TagAuthenticatedEncryptionAdditionalData))
+		if err != nil {
+			return nil, err
+		}
+		return []kmip.Item{
+			kmip.TextString(kmip.TagUniqueIdentifier, id),
+			kmip.ByteString(kmip.TagData, plaintext),
+		}, nil
+	case kmip.OperationQuery:
+		return nil, nil
+	}
+	return nil, errors.New("unsupported operation")
+}
+
+func TestKMIPClient(t *testing.T) {
+	server := &testKMIPServer{names: map[string]string{}, keys: map[string]cipher.AEAD{}, active: map[string]bool{}}
+	client, err := kmip.NewClient(kmip.Config{Endpoints: []string{"kmip:5696"}, Dial: server.dial})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	KMS := newKMIPClient(client, "my-key")
+
+	if _, err = KMS.GenerateKey("", Context{}); err == nil {
+		t.Fatal("expected an error for a missing key")
+	}
+	if err = KMS.CreateKey("my-key"); err != nil {
+		t.Fatal(err)
+	}
+	server.mu.Lock()
+	active := server.active[server.names["my-key"]]
+	server.mu.Unlock()
+	if !active {
+		t.Fatal("expected the created key to be activated")
+	}
+	if err = KMS.CreateKey("my-key"); !errors.Is(err, kes.ErrKeyExists) {
+		t.Fatalf("expected %v, got %v", kes.ErrKeyExists, err)
+	}
+	if _, err = KMS.Stat(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := Context{"bucket": "object"}
+	dek, err := KMS.GenerateKey("", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dek.KeyID != "my-key" || len(dek.Plaintext) != 32 {
+		t.Fatalf("unexpected DEK %s %d", dek.KeyID, len(dek.Plaintext))
+	}
+	plaintext, err := KMS.DecryptKey(dek.KeyID, dek.Ciphertext, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plaintext, dek.Plaintext) {
+		t.Fatal("decrypted key does not match the generated key")
+	}
+	if _, err = KMS.DecryptKey(dek.KeyID, dek.Ciphertext, Context{"bucket": "other"}); err == nil {
+		t.Fatal("expected decryption with a different context to fail")
+	}
+
+	// Keys are located again by a new client.
+	KMS = newKMIPClient(client, "my-key")
+	if _, err = KMS.DecryptKey(dek.KeyID, dek.Ciphertext, ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -20,6 +20,8 @@ package kms
 import (
 	"encoding"
 	"encoding/json"
+	"errors"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 )
@@ -64,6 +66,40 @@ type Status struct {
 	DefaultKey string
 }
 
+// ErrKeyImportNotSupported is returned by ImportKey if the
+// KMS does not support importing key material.
+var ErrKeyImportNotSupported = errors.New("kms: importing keys is not supported")
+
+// ImportKey imports the given key material as a new key with
+// the key ID at the KMS. Imported keys never leave the KMS
+// again - there is no way to export them.
+func ImportKey(kms KMS, keyID string, key []byte) error {
+	if i, ok := kms.(interface{ importKey(string, []byte) error }); ok {
+		return i.importKey(keyID, key)
+	}
+	return ErrKeyImportNotSupported
+}
+
+// EndpointStat describes the health and latency of
+// a single KMS server endpoint.
+type EndpointStat struct {
+	Endpoint string
+	Online   bool
+	Latency  time.Duration // Moving average of successful requests
+	Requests uint64        // Requests sent since server start
+	Failures uint64        // Requests that failed because the endpoint was unreachable
+}
+
+// EndpointStats returns health and latency statistics of
+// the endpoints of the given KMS. It returns nil if the KMS
+// does not track its endpoints.
+func EndpointStats(kms KMS) []EndpointStat {
+	if s, ok := kms.(interface{ endpointStats() []EndpointStat }); ok {
+		return s.endpointStats()
+	}
+	return nil
+}
+
 // DEK is a data encryption key. It consists of a
 // plaintext-ciphertext pair and the ID of the key
 // used to generate the ciphertext.
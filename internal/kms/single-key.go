@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/minio/minio/internal/fips"
 	"github.com/secure-io/sio-go/sioutil"
 	"golang.org/x/crypto/chacha20"
 	"golang.org/x/crypto/chacha20poly1305"
@@ -104,7 +105,7 @@ func (kms secretKey) GenerateKey(keyID string, context Context) (DEK, error) {
 	}
 
 	var algorithm string
-	if sioutil.NativeAES() {
+	if sioutil.NativeAES() || fips.Required() {
 		algorithm = algorithmAESGCM
 	} else {
 		algorithm = algorithmChaCha20Poly1305
@@ -200,6 +201,9 @@ func (kms secretKey) DecryptKey(keyID string, ciphertext []byte, context Context
 			return nil, err
 		}
 	case algorithmChaCha20Poly1305:
+		if fips.Required() {
+			return nil, fmt.Errorf("kms: algorithm %q is not supported in FIPS mode", encryptedKey.Algorithm)
+		}
 		sealingKey, err := chacha20.HChaCha20(kms.key, encryptedKey.IV)
 		if err != nil {
 			return nil, err
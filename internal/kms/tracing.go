@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/minio/minio/internal/kms")
+
+// WithTracing returns a KMS that traces all key operations as
+// part of the request traced by ctx. It returns kms unmodified
+// if ctx is not traced.
+func WithTracing(ctx context.Context, kms KMS) KMS {
+	if kms == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return kms
+	}
+	return &tracingKMS{kms: kms, ctx: ctx}
+}
+
+type tracingKMS struct {
+	kms KMS
+	ctx context.Context
+}
+
+var _ KMS = (*tracingKMS)(nil) // compiler check
+
+func (t *tracingKMS) Stat() (Status, error) { return t.kms.Stat() }
+
+func (t *tracingKMS) CreateKey(keyID string) error { return t.kms.CreateKey(keyID) }
+
+func (t *tracingKMS) GenerateKey(keyID string, context Context) (DEK, error) {
+	_, span := tracer.Start(t.ctx, "kms."+OpGenerateKey, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	key, err := t.kms.GenerateKey(keyID, context)
+	if keyID == "" {
+		keyID = key.KeyID
+	}
+	endSpan(span, keyID, err)
+	return key, err
+}
+
+func (t *tracingKMS) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	_, span := tracer.Start(t.ctx, "kms."+OpDecryptKey, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	key, err := t.kms.DecryptKey(keyID, ciphertext, context)
+	endSpan(span, keyID, err)
+	return key, err
+}
+
+func (t *tracingKMS) importKey(keyID string, key []byte) error { return ImportKey(t.kms, keyID, key) }
+
+func (t *tracingKMS) endpointStats() []EndpointStat { return EndpointStats(t.kms) }
+
+func (t *tracingKMS) breakerStatus() BreakerStatus {
+	status, _ := Breaker(t.kms)
+	return status
+}
+
+func endSpan(span trace.Span, keyID string, err error) {
+	span.SetAttributes(attribute.String("kms.key_id", keyID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	KMS, err := Parse("my-key:eEm+JI9/q4JhH8QwKvf3LKo4DEBl6QbfvAl1CAbMIv8=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := WithTracing(context.Background(), KMS).(*tracingKMS); ok {
+		t.Fatal("KMS of an untraced context must not be wrapped")
+	}
+	if k := WithTracing(context.Background(), nil); k != nil {
+		t.Fatal("nil KMS must not be wrapped")
+	}
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "request")
+	traced := WithTracing(ctx, KMS)
+	key, err := traced.GenerateKey("", Context{"bucket": "bucket/object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = traced.DecryptKey(key.KeyID, key.Ciphertext, Context{"bucket": "other/object"}); err == nil {
+		t.Fatal("decrypting with a different context must fail")
+	}
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	for i, name := range []string{"kms.GenerateKey", "kms.DecryptKey"} {
+		s := spans[i]
+		if s.Name() != name {
+			t.Errorf("expected span %s, got %s", name, s.Name())
+		}
+		if s.Parent().SpanID() != span.SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of the request", s.Name())
+		}
+	}
+	if spans[0].Status().Code == codes.Error {
+		t.Error("expected GenerateKey to succeed")
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Error("expected DecryptKey to record the error")
+	}
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == "kms.key_id" && attr.Value.AsString() != "my-key" {
+			t.Errorf("expected the default key to be reported, got %s", attr.Value.AsString())
+		}
+	}
+}
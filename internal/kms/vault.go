@@ -0,0 +1,354 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/minio/kes"
+	"github.com/minio/minio/internal/fips"
+	"github.com/secure-io/sio-go/sioutil"
+)
+
+// VaultConfig contains the configuration of a HashiCorp Vault
+// server whose Transit secrets engine is used as KMS.
+type VaultConfig struct {
+	// Endpoint is the Vault server URL, e.g. https://vault:8200.
+	Endpoint string
+
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string
+
+	// Mount is the mount path of the Transit secrets
+	// engine. Defaults to "transit".
+	Mount string
+
+	// DefaultKeyID is the name of the Transit key used
+	// when no explicit key ID is specified.
+	DefaultKeyID string
+
+	// AppRole authentication, used if AppRoleID is set.
+	AppRoleID     string
+	AppRoleSecret string
+	AppRoleMount  string // Defaults to "approle".
+
+	// Kubernetes authentication, used if K8SRole is set. The
+	// service account token is read from K8SJWTFile on every
+	// login since Kubernetes rotates it.
+	K8SRole    string
+	K8SJWTFile string
+	K8SMount   string // Defaults to "kubernetes".
+
+	// RootCAs is a set of root CA certificates
+	// to verify the Vault server TLS certificate.
+	RootCAs *x509.CertPool
+}
+
+// NewVault returns a new KMS using the Transit secrets engine of the
+// Vault server of the given configuration. Data encryption keys are
+// generated by MinIO and encrypted by Vault with the named Transit key.
+// Vault key versioning is transparent: new data keys are encrypted with
+// the latest key version, existing ones remain decryptable as long as
+// their key version is not trimmed.
+func NewVault(config VaultConfig) (KMS, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("kms: no Vault endpoint")
+	}
+	if config.AppRoleID == "" && config.K8SRole == "" {
+		return nil, errors.New("kms: no Vault AppRole or Kubernetes authentication configured")
+	}
+	if config.Mount == "" {
+		config.Mount = "transit"
+	}
+	if config.AppRoleMount == "" {
+		config.AppRoleMount = "approle"
+	}
+	if config.K8SMount == "" {
+		config.K8SMount = "kubernetes"
+	}
+	c := &vaultClient{
+		config: config,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: fips.RestrictTLS(&tls.Config{
+					MinVersion: tls.VersionTLS12,
+					RootCAs:    config.RootCAs,
+				}),
+			},
+		},
+	}
+	ctx, cancel := vaultContext()
+	defer cancel()
+	if _, err := c.token(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type vaultClient struct {
+	config VaultConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	authToken string
+	expiresAt time.Time
+}
+
+var _ KMS = (*vaultClient)(nil) // compiler check
+
+// vaultError is an error response of the Vault server.
+type vaultError struct {
+	StatusCode int
+	Errors     []string
+}
+
+func (e vaultError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("kms: Vault: %s", http.StatusText(e.StatusCode))
+	}
+	return "kms: Vault: " + strings.Join(e.Errors, ", ")
+}
+
+func vaultContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+// do sends the request with the JSON encoded body to Vault and decodes
+// the JSON response into result, if not nil.
+func (c *vaultClient) do(ctx context.Context, method, apiPath, token string, body, result interface{}) error {
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	endpoint, err := url.Parse(c.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	endpoint.Path = path.Join(endpoint.Path, "v1", apiPath)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.config.Namespace)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		verr := vaultError{StatusCode: resp.StatusCode}
+		json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&verr)
+		return verr
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(result)
+}
+
+// token returns a valid Vault token, logging in again if the
+// current token expires soon.
+func (c *vaultClient) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.authToken != "" && time.Now().Before(c.expiresAt) {
+		return c.authToken, nil
+	}
+
+	var (
+		loginPath string
+		login     map[string]string
+	)
+	if c.config.AppRoleID != "" {
+		loginPath = path.Join("auth", c.config.AppRoleMount, "login")
+		login = map[string]string{"role_id": c.config.AppRoleID, "secret_id": c.config.AppRoleSecret}
+	} else {
+		jwt, err := ioutil.ReadFile(c.config.K8SJWTFile)
+		if err != nil {
+			return "", fmt.Errorf("kms: unable to read the Kubernetes service account token: %w", err)
+		}
+		loginPath = path.Join("auth", c.config.K8SMount, "login")
+		login = map[string]string{"role": c.config.K8SRole, "jwt": strings.TrimSpace(string(jwt))}
+	}
+	var resp struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := c.do(ctx, http.MethodPost, loginPath, "", login, &resp); err != nil {
+		return "", err
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", errors.New("kms: Vault login returned no token")
+	}
+
+	// Renew by logging in again once half of the lease has passed.
+	lease := time.Duration(resp.Auth.LeaseDuration) * time.Second
+	if lease <= 0 {
+		lease = time.Hour
+	}
+	c.authToken, c.expiresAt = resp.Auth.ClientToken, time.Now().Add(lease/2)
+	return c.authToken, nil
+}
+
+// call sends an authenticated request. If Vault rejects the token,
+// e.g. since it has been revoked, it logs in again and retries once.
+func (c *vaultClient) call(method, apiPath string, body, result interface{}) error {
+	ctx, cancel := vaultContext()
+	defer cancel()
+
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.do(ctx, method, apiPath, token, body, result)
+	var verr vaultError
+	if errors.As(err, &verr) && verr.StatusCode == http.StatusForbidden {
+		c.mu.Lock()
+		if c.authToken == token {
+			c.authToken = ""
+		}
+		c.mu.Unlock()
+		if token, err = c.token(ctx); err != nil {
+			return err
+		}
+		err = c.do(ctx, method, apiPath, token, body, result)
+	}
+	return err
+}
+
+// Stat returns the current Vault status containing the
+// Vault endpoint and the default key ID. It checks that
+// the default key is accessible.
+func (c *vaultClient) Stat() (Status, error) {
+	if err := c.call(http.MethodGet, path.Join(c.config.Mount, "keys", c.config.DefaultKeyID), nil, nil); err != nil {
+		return Status{}, err
+	}
+	return Status{
+		Name:       "Vault Transit",
+		Endpoints:  []string{c.config.Endpoint},
+		DefaultKey: c.config.DefaultKeyID,
+	}, nil
+}
+
+// CreateKey creates a new Transit key by the key ID.
+//
+// If a key with the same keyID already exists then
+// CreateKey returns kes.ErrKeyExists.
+func (c *vaultClient) CreateKey(keyID string) error {
+	keyPath := path.Join(c.config.Mount, "keys", keyID)
+	err := c.call(http.MethodGet, keyPath, nil, nil)
+	if err == nil {
+		return kes.ErrKeyExists
+	}
+	var verr vaultError
+	if !errors.As(err, &verr) || verr.StatusCode != http.StatusNotFound {
+		return err
+	}
+	return c.call(http.MethodPost, keyPath, map[string]string{"type": "aes256-gcm96"}, nil)
+}
+
+// GenerateKey generates a new data encryption key and encrypts
+// it with the Transit key referenced by the key ID.
+//
+// The default key ID will be used if keyID is empty.
+//
+// The context is associated and tied to the generated DEK.
+// The same context must be provided when the generated
+// key should be decrypted.
+func (c *vaultClient) GenerateKey(keyID string, context Context) (DEK, error) {
+	if keyID == "" {
+		keyID = c.config.DefaultKeyID
+	}
+	plaintext, err := sioutil.Random(32)
+	if err != nil {
+		return DEK{}, err
+	}
+	associatedData, _ := context.MarshalText()
+
+	var resp struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	err = c.call(http.MethodPost, path.Join(c.config.Mount, "encrypt", keyID), map[string]string{
+		"plaintext":       base64.StdEncoding.EncodeToString(plaintext),
+		"associated_data": base64.StdEncoding.EncodeToString(associatedData),
+	}, &resp)
+	if err != nil {
+		return DEK{}, err
+	}
+	return DEK{
+		KeyID:      keyID,
+		Plaintext:  plaintext,
+		Ciphertext: []byte(resp.Data.Ciphertext),
+	}, nil
+}
+
+// DecryptKey decrypts the ciphertext with the Transit key referenced
+// by the key ID. The context must match the context value used to
+// generate the ciphertext.
+func (c *vaultClient) DecryptKey(keyID string, ciphertext []byte, context Context) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, []byte("vault:")) {
+		return nil, errors.New("kms: invalid Vault ciphertext")
+	}
+	associatedData, _ := context.MarshalText()
+
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	err := c.call(http.MethodPost, path.Join(c.config.Mount, "decrypt", keyID), map[string]string{
+		"ciphertext":      string(ciphertext),
+		"associated_data": base64.StdEncoding.EncodeToString(associatedData),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+}
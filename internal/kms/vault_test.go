@@ -0,0 +1,184 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/minio/kes"
+)
+
+// fakeVault emulates the AppRole and Kubernetes logins and the Transit
+// encrypt and decrypt APIs. The "ciphertext" is the plaintext and the associated
+// data in the clear.
+type fakeVault struct {
+	logins int32
+	token  string
+	jwt    string
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]string
+	json.NewDecoder(r.Body).Decode(&body)
+	if r.Header.Get("X-Vault-Namespace") != "ns1" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.URL.Path == "/v1/auth/approle/login" || r.URL.Path == "/v1/auth/kubernetes/login" {
+		if r.URL.Path == "/v1/auth/kubernetes/login" && (body["role"] != "role" || body["jwt"] != v.jwt) {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string][]string{"errors": {"invalid service account token"}})
+			return
+		}
+		if r.URL.Path == "/v1/auth/approle/login" && (body["role_id"] != "role" || body["secret_id"] != "secret") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string][]string{"errors": {"invalid role or secret ID"}})
+			return
+		}
+		n := atomic.AddInt32(&v.logins, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"auth": map[string]interface{}{"client_token": "token-" + string(rune('0'+n)), "lease_duration": 3600},
+		})
+		return
+	}
+	if r.Header.Get("X-Vault-Token") != v.token {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string][]string{"errors": {"permission denied"}})
+		return
+	}
+	switch {
+	case r.URL.Path == "/v1/transit/keys/my-key" && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"name": "my-key"}})
+	case strings.HasPrefix(r.URL.Path, "/v1/transit/keys/") && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusNotFound)
+	case strings.HasPrefix(r.URL.Path, "/v1/transit/keys/"):
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Path == "/v1/transit/encrypt/my-key":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]string{"ciphertext": "vault:v1:" + body["plaintext"] + ":" + body["associated_data"]},
+		})
+	case r.URL.Path == "/v1/transit/decrypt/my-key":
+		parts := strings.Split(strings.TrimPrefix(body["ciphertext"], "vault:v1:"), ":")
+		if len(parts) != 2 || parts[1] != body["associated_data"] {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string][]string{"errors": {"cipher: message authentication failed"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"plaintext": parts[0]}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestVaultTransit(t *testing.T) {
+	vault := &fakeVault{token: "token-1"}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	config := VaultConfig{
+		Endpoint:      server.URL,
+		Namespace:     "ns1",
+		DefaultKeyID:  "my-key",
+		AppRoleID:     "role",
+		AppRoleSecret: "secret",
+	}
+	KMS, err := NewVault(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = KMS.Stat(); err != nil {
+		t.Fatal(err)
+	}
+	if err = KMS.CreateKey("my-key"); !errors.Is(err, kes.ErrKeyExists) {
+		t.Fatalf("expected %v, got %v", kes.ErrKeyExists, err)
+	}
+	if err = KMS.CreateKey("new-key"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := Context{"bucket": "bucket/object"}
+	key, err := KMS.GenerateKey("", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.KeyID != "my-key" || !bytes.HasPrefix(key.Ciphertext, []byte("vault:v1:")) {
+		t.Fatalf("unexpected data key %q %q", key.KeyID, key.Ciphertext)
+	}
+	if plaintext, _ := base64.StdEncoding.DecodeString(strings.Split(string(key.Ciphertext), ":")[2]); !bytes.Equal(plaintext, key.Plaintext) {
+		t.Fatal("the data key has not been sent to Vault")
+	}
+
+	// A revoked token is replaced by logging in again.
+	vault.token = "token-2"
+	plaintext, err := KMS.DecryptKey(key.KeyID, key.Ciphertext, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plaintext, key.Plaintext) {
+		t.Fatal("decrypted data key does not match the generated data key")
+	}
+	if _, err = KMS.DecryptKey(key.KeyID, key.Ciphertext, Context{"bucket": "bucket/other"}); err == nil {
+		t.Fatal("decryption with another context must fail")
+	}
+
+	config.AppRoleSecret = "wrong"
+	if _, err = NewVault(config); err == nil || !strings.Contains(err.Error(), "invalid role or secret ID") {
+		t.Fatalf("expected login error, got %v", err)
+	}
+}
+
+func TestVaultKubernetesTokenRotation(t *testing.T) {
+	vault := &fakeVault{token: "token-1", jwt: "jwt-1"}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	jwtFile := filepath.Join(t.TempDir(), "token")
+	if err := ioutil.WriteFile(jwtFile, []byte("jwt-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	KMS, err := NewVault(VaultConfig{
+		Endpoint:     server.URL,
+		Namespace:    "ns1",
+		DefaultKeyID: "my-key",
+		K8SRole:      "role",
+		K8SJWTFile:   jwtFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Kubernetes rotates the token, the next login must use the new one.
+	vault.jwt = "jwt-2"
+	if err = ioutil.WriteFile(jwtFile, []byte("jwt-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	vault.token = "token-2"
+	if _, err = KMS.Stat(); err != nil {
+		t.Fatal(err)
+	}
+}
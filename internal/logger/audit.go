@@ -18,10 +18,13 @@
 package logger
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"sync/atomic"
@@ -116,6 +119,22 @@ func (lrw *ResponseWriter) WriteHeader(code int) {
 	}
 }
 
+// Hijack - hijacks the underlying connection, the response is logged
+// as a protocol switch.
+func (lrw *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := lrw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, rw, err := hj.Hijack()
+	if err == nil && !lrw.headersLogged {
+		lrw.StatusCode = http.StatusSwitchingProtocols
+		lrw.writeHeaders(&lrw.headers, lrw.StatusCode, lrw.ResponseWriter.Header())
+		lrw.headersLogged = true
+	}
+	return conn, rw, err
+}
+
 // Flush - Calls the underlying Flush.
 func (lrw *ResponseWriter) Flush() {
 	lrw.ResponseWriter.(http.Flusher).Flush()
@@ -20,8 +20,10 @@ package logger
 import (
 	"crypto/tls"
 	"errors"
+	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/minio/pkg/env"
 	xnet "github.com/minio/pkg/net"
@@ -29,6 +31,9 @@ import (
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/logger/target/http"
 	"github.com/minio/minio/internal/logger/target/kafka"
+	"github.com/minio/minio/internal/logger/target/splunk"
+	"github.com/minio/minio/internal/logger/target/store"
+	"github.com/minio/minio/internal/logger/target/syslog"
 )
 
 // Console logger target
@@ -44,6 +49,10 @@ const (
 	ClientKey  = "client_key"
 	QueueSize  = "queue_size"
 
+	QueueDir      = "queue_dir"
+	QueueOverflow = "queue_overflow"
+	FlushInterval = "flush_interval"
+
 	KafkaBrokers       = "brokers"
 	KafkaTopic         = "topic"
 	KafkaTLS           = "tls"
@@ -57,6 +66,19 @@ const (
 	KafkaClientTLSKey  = "client_tls_key"
 	KafkaVersion       = "version"
 
+	SplunkToken         = "token"
+	SplunkIndex         = "index"
+	SplunkSourceType    = "sourcetype"
+	SplunkSource        = "source"
+	SplunkBatchSize     = "batch_size"
+	SplunkFlushInterval = "flush_interval"
+
+	SyslogAddress       = "address"
+	SyslogNetwork       = "network"
+	SyslogFacility      = "facility"
+	SyslogAppName       = "app_name"
+	SyslogTLSSkipVerify = "tls_skip_verify"
+
 	EnvLoggerWebhookEnable     = "MINIO_LOGGER_WEBHOOK_ENABLE"
 	EnvLoggerWebhookEndpoint   = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
 	EnvLoggerWebhookAuthToken  = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
@@ -64,6 +86,10 @@ const (
 	EnvLoggerWebhookClientKey  = "MINIO_LOGGER_WEBHOOK_CLIENT_KEY"
 	EnvLoggerWebhookQueueSize  = "MINIO_LOGGER_WEBHOOK_QUEUE_SIZE"
 
+	EnvLoggerWebhookQueueDir      = "MINIO_LOGGER_WEBHOOK_QUEUE_DIR"
+	EnvLoggerWebhookQueueOverflow = "MINIO_LOGGER_WEBHOOK_QUEUE_OVERFLOW"
+	EnvLoggerWebhookFlushInterval = "MINIO_LOGGER_WEBHOOK_FLUSH_INTERVAL"
+
 	EnvAuditWebhookEnable     = "MINIO_AUDIT_WEBHOOK_ENABLE"
 	EnvAuditWebhookEndpoint   = "MINIO_AUDIT_WEBHOOK_ENDPOINT"
 	EnvAuditWebhookAuthToken  = "MINIO_AUDIT_WEBHOOK_AUTH_TOKEN"
@@ -71,6 +97,10 @@ const (
 	EnvAuditWebhookClientKey  = "MINIO_AUDIT_WEBHOOK_CLIENT_KEY"
 	EnvAuditWebhookQueueSize  = "MINIO_AUDIT_WEBHOOK_QUEUE_SIZE"
 
+	EnvAuditWebhookQueueDir      = "MINIO_AUDIT_WEBHOOK_QUEUE_DIR"
+	EnvAuditWebhookQueueOverflow = "MINIO_AUDIT_WEBHOOK_QUEUE_OVERFLOW"
+	EnvAuditWebhookFlushInterval = "MINIO_AUDIT_WEBHOOK_FLUSH_INTERVAL"
+
 	EnvKafkaEnable        = "MINIO_AUDIT_KAFKA_ENABLE"
 	EnvKafkaBrokers       = "MINIO_AUDIT_KAFKA_BROKERS"
 	EnvKafkaTopic         = "MINIO_AUDIT_KAFKA_TOPIC"
@@ -84,6 +114,36 @@ const (
 	EnvKafkaClientTLSCert = "MINIO_AUDIT_KAFKA_CLIENT_TLS_CERT"
 	EnvKafkaClientTLSKey  = "MINIO_AUDIT_KAFKA_CLIENT_TLS_KEY"
 	EnvKafkaVersion       = "MINIO_AUDIT_KAFKA_VERSION"
+	EnvKafkaQueueSize     = "MINIO_AUDIT_KAFKA_QUEUE_SIZE"
+	EnvKafkaQueueDir      = "MINIO_AUDIT_KAFKA_QUEUE_DIR"
+	EnvKafkaQueueOverflow = "MINIO_AUDIT_KAFKA_QUEUE_OVERFLOW"
+	EnvKafkaFlushInterval = "MINIO_AUDIT_KAFKA_FLUSH_INTERVAL"
+
+	EnvSplunkEnable        = "MINIO_AUDIT_SPLUNK_ENABLE"
+	EnvSplunkEndpoint      = "MINIO_AUDIT_SPLUNK_ENDPOINT"
+	EnvSplunkToken         = "MINIO_AUDIT_SPLUNK_TOKEN"
+	EnvSplunkIndex         = "MINIO_AUDIT_SPLUNK_INDEX"
+	EnvSplunkSourceType    = "MINIO_AUDIT_SPLUNK_SOURCETYPE"
+	EnvSplunkSource        = "MINIO_AUDIT_SPLUNK_SOURCE"
+	EnvSplunkBatchSize     = "MINIO_AUDIT_SPLUNK_BATCH_SIZE"
+	EnvSplunkFlushInterval = "MINIO_AUDIT_SPLUNK_FLUSH_INTERVAL"
+	EnvSplunkQueueSize     = "MINIO_AUDIT_SPLUNK_QUEUE_SIZE"
+
+	EnvLoggerSyslogEnable        = "MINIO_LOGGER_SYSLOG_ENABLE"
+	EnvLoggerSyslogAddress       = "MINIO_LOGGER_SYSLOG_ADDRESS"
+	EnvLoggerSyslogNetwork       = "MINIO_LOGGER_SYSLOG_NETWORK"
+	EnvLoggerSyslogFacility      = "MINIO_LOGGER_SYSLOG_FACILITY"
+	EnvLoggerSyslogAppName       = "MINIO_LOGGER_SYSLOG_APP_NAME"
+	EnvLoggerSyslogTLSSkipVerify = "MINIO_LOGGER_SYSLOG_TLS_SKIP_VERIFY"
+	EnvLoggerSyslogQueueSize     = "MINIO_LOGGER_SYSLOG_QUEUE_SIZE"
+
+	EnvAuditSyslogEnable        = "MINIO_AUDIT_SYSLOG_ENABLE"
+	EnvAuditSyslogAddress       = "MINIO_AUDIT_SYSLOG_ADDRESS"
+	EnvAuditSyslogNetwork       = "MINIO_AUDIT_SYSLOG_NETWORK"
+	EnvAuditSyslogFacility      = "MINIO_AUDIT_SYSLOG_FACILITY"
+	EnvAuditSyslogAppName       = "MINIO_AUDIT_SYSLOG_APP_NAME"
+	EnvAuditSyslogTLSSkipVerify = "MINIO_AUDIT_SYSLOG_TLS_SKIP_VERIFY"
+	EnvAuditSyslogQueueSize     = "MINIO_AUDIT_SYSLOG_QUEUE_SIZE"
 )
 
 // Default KVS for loggerHTTP and loggerAuditHTTP
@@ -113,6 +173,18 @@ var (
 			Key:   QueueSize,
 			Value: "100000",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueOverflow,
+			Value: store.OverflowDrop,
+		},
+		config.KV{
+			Key:   FlushInterval,
+			Value: "1s",
+		},
 	}
 
 	DefaultAuditWebhookKVS = config.KVS{
@@ -140,6 +212,18 @@ var (
 			Key:   QueueSize,
 			Value: "100000",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueOverflow,
+			Value: store.OverflowDrop,
+		},
+		config.KV{
+			Key:   FlushInterval,
+			Value: "1s",
+		},
 	}
 
 	DefaultAuditKafkaKVS = config.KVS{
@@ -195,15 +279,135 @@ var (
 			Key:   KafkaVersion,
 			Value: "",
 		},
+		config.KV{
+			Key:   QueueSize,
+			Value: "100000",
+		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueOverflow,
+			Value: store.OverflowDrop,
+		},
+		config.KV{
+			Key:   FlushInterval,
+			Value: "1s",
+		},
+	}
+
+	DefaultAuditSplunkKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   SplunkToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   SplunkIndex,
+			Value: "",
+		},
+		config.KV{
+			Key:   SplunkSourceType,
+			Value: "minio:audit",
+		},
+		config.KV{
+			Key:   SplunkSource,
+			Value: "minio",
+		},
+		config.KV{
+			Key:   SplunkBatchSize,
+			Value: "100",
+		},
+		config.KV{
+			Key:   SplunkFlushInterval,
+			Value: "1s",
+		},
+		config.KV{
+			Key:   QueueSize,
+			Value: "100000",
+		},
+	}
+
+	DefaultLoggerSyslogKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   SyslogAddress,
+			Value: "",
+		},
+		config.KV{
+			Key:   SyslogNetwork,
+			Value: syslog.NetworkUDP,
+		},
+		config.KV{
+			Key:   SyslogFacility,
+			Value: "local0",
+		},
+		config.KV{
+			Key:   SyslogAppName,
+			Value: "minio",
+		},
+		config.KV{
+			Key:   SyslogTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   QueueSize,
+			Value: "100000",
+		},
+	}
+
+	DefaultAuditSyslogKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   SyslogAddress,
+			Value: "",
+		},
+		config.KV{
+			Key:   SyslogNetwork,
+			Value: syslog.NetworkUDP,
+		},
+		config.KV{
+			Key:   SyslogFacility,
+			Value: "local0",
+		},
+		config.KV{
+			Key:   SyslogAppName,
+			Value: "minio",
+		},
+		config.KV{
+			Key:   SyslogTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   QueueSize,
+			Value: "100000",
+		},
 	}
 )
 
 // Config console and http logger targets
 type Config struct {
-	Console      Console                 `json:"console"`
-	HTTP         map[string]http.Config  `json:"http"`
-	AuditWebhook map[string]http.Config  `json:"audit"`
-	AuditKafka   map[string]kafka.Config `json:"audit_kafka"`
+	Console      Console                  `json:"console"`
+	HTTP         map[string]http.Config   `json:"http"`
+	AuditWebhook map[string]http.Config   `json:"audit"`
+	AuditKafka   map[string]kafka.Config  `json:"audit_kafka"`
+	AuditSplunk  map[string]splunk.Config `json:"audit_splunk"`
+	Syslog       map[string]syslog.Config `json:"syslog"`
+	AuditSyslog  map[string]syslog.Config `json:"audit_syslog"`
 }
 
 // NewConfig - initialize new logger config.
@@ -216,6 +420,9 @@ func NewConfig() Config {
 		HTTP:         make(map[string]http.Config),
 		AuditWebhook: make(map[string]http.Config),
 		AuditKafka:   make(map[string]kafka.Config),
+		AuditSplunk:  make(map[string]splunk.Config),
+		Syslog:       make(map[string]syslog.Config),
+		AuditSyslog:  make(map[string]syslog.Config),
 	}
 
 	return cfg
@@ -279,6 +486,31 @@ func lookupLegacyConfig() (Config, error) {
 	return cfg, nil
 }
 
+// lookupQueue parses the queue size, directory, overflow policy and
+// flush interval of a webhook or kafka target.
+func lookupQueue(queueSize, queueDir, overflow, flushInterval string) (size int, dir string, policy string, interval time.Duration, err error) {
+	size, err = strconv.Atoi(queueSize)
+	if err != nil {
+		return 0, "", "", 0, err
+	}
+	if size <= 0 {
+		return 0, "", "", 0, errors.New("invalid queue_size value")
+	}
+	switch overflow {
+	case store.OverflowDrop, store.OverflowBlock:
+	default:
+		return 0, "", "", 0, config.Errorf("'queue_overflow' must be one of drop or block, got %s", overflow)
+	}
+	interval, err = time.ParseDuration(flushInterval)
+	if err != nil {
+		return 0, "", "", 0, err
+	}
+	if interval <= 0 {
+		return 0, "", "", 0, errors.New("invalid flush_interval value")
+	}
+	return size, queueDir, overflow, interval, nil
+}
+
 // GetAuditKafka - returns a map of registered notification 'kafka' targets
 func GetAuditKafka(kafkaKVS map[string]config.KVS) (map[string]kafka.Config, error) {
 	kafkaTargets := make(map[string]kafka.Config)
@@ -388,12 +620,185 @@ func GetAuditKafka(kafkaKVS map[string]config.KVS) (map[string]kafka.Config, err
 		kafkaArgs.SASL.Password = env.Get(saslPasswordEnv, kv.Get(KafkaSASLPassword))
 		kafkaArgs.SASL.Mechanism = env.Get(saslMechanismEnv, kv.Get(KafkaSASLMechanism))
 
+		getEnv := func(envKey, key string) string {
+			if k != config.Default {
+				envKey = envKey + config.Default + k
+			}
+			return env.Get(envKey, kv.Get(key))
+		}
+		kafkaArgs.QueueSize, kafkaArgs.QueueDir, kafkaArgs.Overflow, kafkaArgs.FlushInterval, err = lookupQueue(
+			getEnv(EnvKafkaQueueSize, QueueSize), getEnv(EnvKafkaQueueDir, QueueDir),
+			getEnv(EnvKafkaQueueOverflow, QueueOverflow), getEnv(EnvKafkaFlushInterval, FlushInterval))
+		if err != nil {
+			return nil, err
+		}
+
 		kafkaTargets[k] = kafkaArgs
 	}
 
 	return kafkaTargets, nil
 }
 
+// GetAuditSplunk - returns a map of registered audit 'splunk' targets
+func GetAuditSplunk(splunkKVS map[string]config.KVS) (map[string]splunk.Config, error) {
+	splunkTargets := make(map[string]splunk.Config)
+	for k, kv := range config.Merge(splunkKVS, EnvSplunkEnable, DefaultAuditSplunkKVS) {
+		getEnv := func(envKey, key string) string {
+			if k != config.Default {
+				envKey = envKey + config.Default + k
+			}
+			return env.Get(envKey, kv.Get(key))
+		}
+		enabled, err := config.ParseBool(getEnv(EnvSplunkEnable, config.Enable))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		endpoint := getEnv(EnvSplunkEndpoint, Endpoint)
+		if endpoint == "" {
+			return nil, config.Errorf("splunk 'endpoint' cannot be empty")
+		}
+		token := getEnv(EnvSplunkToken, SplunkToken)
+		if token == "" {
+			return nil, config.Errorf("splunk 'token' cannot be empty")
+		}
+		batchSize, err := strconv.Atoi(getEnv(EnvSplunkBatchSize, SplunkBatchSize))
+		if err != nil {
+			return nil, err
+		}
+		if batchSize <= 0 {
+			return nil, errors.New("invalid batch_size value")
+		}
+		flushInterval, err := time.ParseDuration(getEnv(EnvSplunkFlushInterval, SplunkFlushInterval))
+		if err != nil {
+			return nil, err
+		}
+		if flushInterval <= 0 {
+			return nil, errors.New("invalid flush_interval value")
+		}
+		queueSize, err := strconv.Atoi(getEnv(EnvSplunkQueueSize, QueueSize))
+		if err != nil {
+			return nil, err
+		}
+		if queueSize <= 0 {
+			return nil, errors.New("invalid queue_size value")
+		}
+
+		splunkTargets[k] = splunk.Config{
+			Enabled:       true,
+			Endpoint:      endpoint,
+			Token:         token,
+			Index:         getEnv(EnvSplunkIndex, SplunkIndex),
+			SourceType:    getEnv(EnvSplunkSourceType, SplunkSourceType),
+			Source:        getEnv(EnvSplunkSource, SplunkSource),
+			BatchSize:     batchSize,
+			FlushInterval: flushInterval,
+			QueueSize:     queueSize,
+		}
+	}
+
+	return splunkTargets, nil
+}
+
+// syslogEnvs are the environment variables of the keys of a syslog target.
+type syslogEnvs struct {
+	enable, address, network, facility, appName, tlsSkipVerify, queueSize string
+}
+
+// GetLoggerSyslog - returns a map of registered server logger 'syslog' targets
+func GetLoggerSyslog(syslogKVS map[string]config.KVS) (map[string]syslog.Config, error) {
+	return getSyslog(syslogKVS, DefaultLoggerSyslogKVS, syslogEnvs{
+		enable:        EnvLoggerSyslogEnable,
+		address:       EnvLoggerSyslogAddress,
+		network:       EnvLoggerSyslogNetwork,
+		facility:      EnvLoggerSyslogFacility,
+		appName:       EnvLoggerSyslogAppName,
+		tlsSkipVerify: EnvLoggerSyslogTLSSkipVerify,
+		queueSize:     EnvLoggerSyslogQueueSize,
+	})
+}
+
+// GetAuditSyslog - returns a map of registered audit 'syslog' targets
+func GetAuditSyslog(syslogKVS map[string]config.KVS) (map[string]syslog.Config, error) {
+	return getSyslog(syslogKVS, DefaultAuditSyslogKVS, syslogEnvs{
+		enable:        EnvAuditSyslogEnable,
+		address:       EnvAuditSyslogAddress,
+		network:       EnvAuditSyslogNetwork,
+		facility:      EnvAuditSyslogFacility,
+		appName:       EnvAuditSyslogAppName,
+		tlsSkipVerify: EnvAuditSyslogTLSSkipVerify,
+		queueSize:     EnvAuditSyslogQueueSize,
+	})
+}
+
+func getSyslog(syslogKVS map[string]config.KVS, defaultKVS config.KVS, envs syslogEnvs) (map[string]syslog.Config, error) {
+	syslogTargets := make(map[string]syslog.Config)
+	for k, kv := range config.Merge(syslogKVS, envs.enable, defaultKVS) {
+		getEnv := func(envKey, key string) string {
+			if k != config.Default {
+				envKey = envKey + config.Default + k
+			}
+			return env.Get(envKey, kv.Get(key))
+		}
+		enabled, err := config.ParseBool(getEnv(envs.enable, config.Enable))
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			continue
+		}
+
+		address := getEnv(envs.address, SyslogAddress)
+		if address == "" {
+			return nil, config.Errorf("syslog 'address' cannot be empty")
+		}
+		if _, _, err = net.SplitHostPort(address); err != nil {
+			return nil, config.Errorf("syslog 'address' %s is invalid: %v", address, err)
+		}
+		network := getEnv(envs.network, SyslogNetwork)
+		switch network {
+		case syslog.NetworkUDP, syslog.NetworkTCP, syslog.NetworkTLS:
+		default:
+			return nil, config.Errorf("syslog 'network' must be one of udp, tcp or tls, got %s", network)
+		}
+		facility, err := syslog.ParseFacility(getEnv(envs.facility, SyslogFacility))
+		if err != nil {
+			return nil, err
+		}
+		skipVerify, err := config.ParseBool(getEnv(envs.tlsSkipVerify, SyslogTLSSkipVerify))
+		if err != nil {
+			return nil, err
+		}
+		queueSize, err := strconv.Atoi(getEnv(envs.queueSize, QueueSize))
+		if err != nil {
+			return nil, err
+		}
+		if queueSize <= 0 {
+			return nil, errors.New("invalid queue_size value")
+		}
+
+		syslogArgs := syslog.Config{
+			Enabled:   true,
+			Network:   network,
+			Address:   address,
+			Facility:  facility,
+			AppName:   getEnv(envs.appName, SyslogAppName),
+			QueueSize: queueSize,
+		}
+		if network == syslog.NetworkTLS {
+			syslogArgs.TLSConfig = &tls.Config{
+				InsecureSkipVerify: skipVerify,
+			}
+		}
+		syslogTargets[k] = syslogArgs
+	}
+
+	return syslogTargets, nil
+}
+
 // LookupConfig - lookup logger config, override with ENVs if set.
 func LookupConfig(scfg config.Config) (Config, error) {
 	// Lookup for legacy environment variables first
@@ -457,24 +862,28 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
-		queueSizeEnv := EnvAuditWebhookQueueSize
-		if target != config.Default {
-			queueSizeEnv = EnvAuditWebhookQueueSize + config.Default + target
+		getEnv := func(envKey, defaultValue string) string {
+			if target != config.Default {
+				envKey = envKey + config.Default + target
+			}
+			return env.Get(envKey, defaultValue)
 		}
-		queueSize, err := strconv.Atoi(env.Get(queueSizeEnv, "100000"))
+		queueSize, queueDir, overflow, flushInterval, err := lookupQueue(
+			getEnv(EnvAuditWebhookQueueSize, "100000"), getEnv(EnvLoggerWebhookQueueDir, ""),
+			getEnv(EnvLoggerWebhookQueueOverflow, store.OverflowDrop), getEnv(EnvLoggerWebhookFlushInterval, "1s"))
 		if err != nil {
 			return cfg, err
 		}
-		if queueSize <= 0 {
-			return cfg, errors.New("invalid queue_size value")
-		}
 		cfg.HTTP[target] = http.Config{
-			Enabled:    true,
-			Endpoint:   env.Get(endpointEnv, ""),
-			AuthToken:  env.Get(authTokenEnv, ""),
-			ClientCert: env.Get(clientCertEnv, ""),
-			ClientKey:  env.Get(clientKeyEnv, ""),
-			QueueSize:  queueSize,
+			Enabled:       true,
+			Endpoint:      env.Get(endpointEnv, ""),
+			AuthToken:     env.Get(authTokenEnv, ""),
+			ClientCert:    env.Get(clientCertEnv, ""),
+			ClientKey:     env.Get(clientKeyEnv, ""),
+			QueueSize:     queueSize,
+			QueueDir:      queueDir,
+			Overflow:      overflow,
+			FlushInterval: flushInterval,
 		}
 	}
 
@@ -512,24 +921,28 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
-		queueSizeEnv := EnvAuditWebhookQueueSize
-		if target != config.Default {
-			queueSizeEnv = EnvAuditWebhookQueueSize + config.Default + target
+		getEnv := func(envKey, defaultValue string) string {
+			if target != config.Default {
+				envKey = envKey + config.Default + target
+			}
+			return env.Get(envKey, defaultValue)
 		}
-		queueSize, err := strconv.Atoi(env.Get(queueSizeEnv, "100000"))
+		queueSize, queueDir, overflow, flushInterval, err := lookupQueue(
+			getEnv(EnvAuditWebhookQueueSize, "100000"), getEnv(EnvAuditWebhookQueueDir, ""),
+			getEnv(EnvAuditWebhookQueueOverflow, store.OverflowDrop), getEnv(EnvAuditWebhookFlushInterval, "1s"))
 		if err != nil {
 			return cfg, err
 		}
-		if queueSize <= 0 {
-			return cfg, errors.New("invalid queue_size value")
-		}
 		cfg.AuditWebhook[target] = http.Config{
-			Enabled:    true,
-			Endpoint:   env.Get(endpointEnv, ""),
-			AuthToken:  env.Get(authTokenEnv, ""),
-			ClientCert: env.Get(clientCertEnv, ""),
-			ClientKey:  env.Get(clientKeyEnv, ""),
-			QueueSize:  queueSize,
+			Enabled:       true,
+			Endpoint:      env.Get(endpointEnv, ""),
+			AuthToken:     env.Get(authTokenEnv, ""),
+			ClientCert:    env.Get(clientCertEnv, ""),
+			ClientKey:     env.Get(clientKeyEnv, ""),
+			QueueSize:     queueSize,
+			QueueDir:      queueDir,
+			Overflow:      overflow,
+			FlushInterval: flushInterval,
 		}
 	}
 
@@ -558,20 +971,21 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
-		queueSize, err := strconv.Atoi(kv.Get(QueueSize))
+		queueSize, queueDir, overflow, flushInterval, err := lookupQueue(
+			kv.Get(QueueSize), kv.Get(QueueDir), kv.Get(QueueOverflow), kv.Get(FlushInterval))
 		if err != nil {
 			return cfg, err
 		}
-		if queueSize <= 0 {
-			return cfg, errors.New("invalid queue_size value")
-		}
 		cfg.HTTP[starget] = http.Config{
-			Enabled:    true,
-			Endpoint:   kv.Get(Endpoint),
-			AuthToken:  kv.Get(AuthToken),
-			ClientCert: kv.Get(ClientCert),
-			ClientKey:  kv.Get(ClientKey),
-			QueueSize:  queueSize,
+			Enabled:       true,
+			Endpoint:      kv.Get(Endpoint),
+			AuthToken:     kv.Get(AuthToken),
+			ClientCert:    kv.Get(ClientCert),
+			ClientKey:     kv.Get(ClientKey),
+			QueueSize:     queueSize,
+			QueueDir:      queueDir,
+			Overflow:      overflow,
+			FlushInterval: flushInterval,
 		}
 	}
 
@@ -600,20 +1014,21 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if err != nil {
 			return cfg, err
 		}
-		queueSize, err := strconv.Atoi(kv.Get(QueueSize))
+		queueSize, queueDir, overflow, flushInterval, err := lookupQueue(
+			kv.Get(QueueSize), kv.Get(QueueDir), kv.Get(QueueOverflow), kv.Get(FlushInterval))
 		if err != nil {
 			return cfg, err
 		}
-		if queueSize <= 0 {
-			return cfg, errors.New("invalid queue_size value")
-		}
 		cfg.AuditWebhook[starget] = http.Config{
-			Enabled:    true,
-			Endpoint:   kv.Get(Endpoint),
-			AuthToken:  kv.Get(AuthToken),
-			ClientCert: kv.Get(ClientCert),
-			ClientKey:  kv.Get(ClientKey),
-			QueueSize:  queueSize,
+			Enabled:       true,
+			Endpoint:      kv.Get(Endpoint),
+			AuthToken:     kv.Get(AuthToken),
+			ClientCert:    kv.Get(ClientCert),
+			ClientKey:     kv.Get(ClientKey),
+			QueueSize:     queueSize,
+			QueueDir:      queueDir,
+			Overflow:      overflow,
+			FlushInterval: flushInterval,
 		}
 	}
 
@@ -622,5 +1037,20 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		return cfg, err
 	}
 
+	cfg.AuditSplunk, err = GetAuditSplunk(scfg[config.AuditSplunkSubSys])
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.Syslog, err = GetLoggerSyslog(scfg[config.LoggerSyslogSubSys])
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.AuditSyslog, err = GetAuditSyslog(scfg[config.AuditSyslogSubSys])
+	if err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
@@ -53,10 +53,28 @@ var (
 		},
 		config.HelpKV{
 			Key:         QueueSize,
-			Description: "configure channel queue size for Logger Webhook targets",
+			Description: "configure the maximum number of queued entries for Logger Webhook targets",
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: "staging dir for undelivered log entries e.g. '/home/logs'",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueOverflow,
+			Description: "policy when the queue is full, 'drop' (count and drop new entries) or 'block' (wait up to a minute for space), defaults to 'drop'",
+			Optional:    true,
+			Type:        "drop|block",
+		},
+		config.HelpKV{
+			Key:         FlushInterval,
+			Description: "interval to flush queued entries for delivery and to retry failed deliveries, defaults to '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -95,10 +113,28 @@ var (
 		},
 		config.HelpKV{
 			Key:         QueueSize,
-			Description: "configure channel queue size for Audit Webhook targets",
+			Description: "configure the maximum number of queued entries for Audit Webhook targets",
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: "staging dir for undelivered audit entries e.g. '/home/audit'",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueOverflow,
+			Description: "policy when the queue is full, 'drop' (count and drop new entries) or 'block' (wait up to a minute for space), defaults to 'drop'",
+			Optional:    true,
+			Type:        "drop|block",
+		},
+		config.HelpKV{
+			Key:         FlushInterval,
+			Description: "interval to flush queued entries for delivery and to retry failed deliveries, defaults to '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -183,6 +219,131 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         QueueSize,
+			Description: "configure the maximum number of queued entries for Kafka targets",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: "staging dir for undelivered audit entries e.g. '/home/audit'",
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueOverflow,
+			Description: "policy when the queue is full, 'drop' (count and drop new entries) or 'block' (wait up to a minute for space), defaults to 'drop'",
+			Optional:    true,
+			Type:        "drop|block",
+		},
+		config.HelpKV{
+			Key:         FlushInterval,
+			Description: "interval to flush queued entries for delivery and to retry failed deliveries, defaults to '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpSplunk = config.HelpKVS{
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `Splunk HTTP Event Collector endpoint e.g. "https://splunk:8088"`,
+			Type:        "url",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         SplunkToken,
+			Description: "HTTP Event Collector token",
+			Type:        "string",
+			Sensitive:   true,
+		},
+		config.HelpKV{
+			Key:         SplunkIndex,
+			Description: "Splunk index for audit events, defaults to the default index of the token",
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SplunkSourceType,
+			Description: `Splunk sourcetype for audit events, defaults to "minio:audit"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SplunkSource,
+			Description: `Splunk source for audit events, defaults to "minio"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SplunkBatchSize,
+			Description: "maximum number of audit events sent in one request, defaults to '100'",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         SplunkFlushInterval,
+			Description: "maximum time audit events are held before sending them, defaults to '1s'",
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         QueueSize,
+			Description: "configure channel queue size for Splunk targets, events are dropped when it is full",
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpSyslog = config.HelpKVS{
+		config.HelpKV{
+			Key:         SyslogAddress,
+			Description: `syslog server address e.g. "syslog.example.com:514"`,
+			Type:        "address",
+		},
+		config.HelpKV{
+			Key:         SyslogNetwork,
+			Description: `network to reach the syslog server, one of "udp", "tcp" or "tls", defaults to "udp"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SyslogFacility,
+			Description: `syslog facility of the messages e.g. "daemon", defaults to "local0"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SyslogAppName,
+			Description: `APP-NAME of the messages, defaults to "minio"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SyslogTLSSkipVerify,
+			Description: `trust syslog server TLS without verification, defaults to "off" (verify)`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         QueueSize,
+			Description: "configure channel queue size for syslog targets",
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -21,13 +21,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger/target/store"
 )
 
 // Timeout for the webhook http call
@@ -35,15 +35,18 @@ const webhookCallTimeout = 5 * time.Second
 
 // Config http logger target
 type Config struct {
-	Enabled    bool              `json:"enabled"`
-	Name       string            `json:"name"`
-	UserAgent  string            `json:"userAgent"`
-	Endpoint   string            `json:"endpoint"`
-	AuthToken  string            `json:"authToken"`
-	ClientCert string            `json:"clientCert"`
-	ClientKey  string            `json:"clientKey"`
-	QueueSize  int               `json:"queueSize"`
-	Transport  http.RoundTripper `json:"-"`
+	Enabled       bool              `json:"enabled"`
+	Name          string            `json:"name"`
+	UserAgent     string            `json:"userAgent"`
+	Endpoint      string            `json:"endpoint"`
+	AuthToken     string            `json:"authToken"`
+	ClientCert    string            `json:"clientCert"`
+	ClientKey     string            `json:"clientKey"`
+	QueueSize     int               `json:"queueSize"`
+	QueueDir      string            `json:"queueDir"`
+	Overflow      string            `json:"overflow"`
+	FlushInterval time.Duration     `json:"flushInterval"`
+	Transport     http.RoundTripper `json:"-"`
 
 	// Custom logger
 	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
@@ -51,12 +54,14 @@ type Config struct {
 
 // Target implements logger.Target and sends the json
 // format of a log entry to the configured http endpoint.
-// An internal buffer of logs is maintained but when the
-// buffer is full, new logs are just ignored and an error
-// is returned to the caller.
+// Log entries are buffered in a bounded queue, optionally
+// backed by disk, and failed deliveries are retried unless
+// the endpoint rejected the entry permanently. When
+// the queue is full the overflow policy of the target
+// applies, dropped entries are counted.
 type Target struct {
-	// Channel of log entries
-	logCh chan interface{}
+	// Queue of log entries
+	queue *store.Queue
 
 	config Config
 }
@@ -70,6 +75,13 @@ func (h *Target) String() string {
 	return h.config.Name
 }
 
+// Dropped returns the number of log entries dropped
+// because the queue of the target was full or the
+// endpoint rejected them.
+func (h *Target) Dropped() uint64 {
+	return h.queue.Dropped()
+}
+
 // Init validate and initialize the http target
 func (h *Target) Init() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*webhookCallTimeout)
@@ -101,7 +113,7 @@ func (h *Target) Init() error {
 
 	if !acceptedResponseStatusCode(resp.StatusCode) {
 		switch resp.StatusCode {
-		case http.StatusForbidden:
+		case http.StatusUnauthorized, http.StatusForbidden:
 			return fmt.Errorf("%s returned '%s', please check if your auth token is correctly set",
 				h.config.Endpoint, resp.Status)
 		}
@@ -109,7 +121,12 @@ func (h *Target) Init() error {
 			h.config.Endpoint, resp.Status)
 	}
 
-	go h.startHTTPLogger()
+	if err = h.queue.Open(); err != nil {
+		return err
+	}
+	go h.queue.Run(h.send, func(err error) {
+		h.config.LogOnce(context.Background(), err, h.config.Endpoint)
+	})
 	return nil
 }
 
@@ -120,62 +137,73 @@ func acceptedResponseStatusCode(code int) bool {
 	return acceptedStatusCodeMap[code]
 }
 
-func (h *Target) startHTTPLogger() {
-	// Create a routine which sends json logs received
-	// from an internal channel.
-	go func() {
-		for entry := range h.logCh {
-			logJSON, err := json.Marshal(&entry)
-			if err != nil {
-				continue
-			}
-
-			ctx, cancel := context.WithTimeout(context.Background(), webhookCallTimeout)
-			req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-				h.config.Endpoint, bytes.NewReader(logJSON))
-			if err != nil {
-				h.config.LogOnce(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err), h.config.Endpoint)
-				cancel()
-				continue
-			}
-			req.Header.Set(xhttp.ContentType, "application/json")
-
-			// Set user-agent to indicate MinIO release
-			// version to the configured log endpoint
-			req.Header.Set("User-Agent", h.config.UserAgent)
-
-			if h.config.AuthToken != "" {
-				req.Header.Set("Authorization", h.config.AuthToken)
-			}
-
-			client := http.Client{Transport: h.config.Transport}
-			resp, err := client.Do(req)
-			cancel()
-			if err != nil {
-				h.config.LogOnce(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err), h.config.Endpoint)
-				continue
-			}
-
-			// Drain any response.
-			xhttp.DrainBody(resp.Body)
-
-			if !acceptedResponseStatusCode(resp.StatusCode) {
-				switch resp.StatusCode {
-				case http.StatusForbidden:
-					h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status), h.config.Endpoint)
-				default:
-					h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status), h.config.Endpoint)
-				}
-			}
+// send posts a single log entry to the endpoint.
+func (h *Target) send(logJSON []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookCallTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		h.config.Endpoint, bytes.NewReader(logJSON))
+	if err != nil {
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err)
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+
+	// Set user-agent to indicate MinIO release
+	// version to the configured log endpoint
+	req.Header.Set("User-Agent", h.config.UserAgent)
+
+	if h.config.AuthToken != "" {
+		req.Header.Set("Authorization", h.config.AuthToken)
+	}
+
+	client := http.Client{Transport: h.config.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err)
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	if !acceptedResponseStatusCode(resp.StatusCode) {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			err = fmt.Errorf("%s returned '%s', please check if your auth token is correctly set", h.config.Endpoint, resp.Status)
+		default:
+			err = fmt.Errorf("%s returned '%s', please check your endpoint configuration", h.config.Endpoint, resp.Status)
 		}
-	}()
+		if !retryableResponseStatusCode(resp.StatusCode) {
+			return store.NonRetryable(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// retryableResponseStatusCode returns whether an entry rejected with
+// the status code may be accepted when sent again. Authentication
+// failures are retried since they are fixed by correcting the auth
+// token. Other rejections, such as a bad request or a too large entry,
+// are permanent.
+func retryableResponseStatusCode(code int) bool {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	}
+	return code >= http.StatusInternalServerError
 }
 
 // New initializes a new logger target which
 // sends log over http to the specified endpoint
 func New(config Config) *Target {
 	h := &Target{
-		logCh:  make(chan interface{}, config.QueueSize),
+		queue: store.New(store.Config{
+			Dir:           config.QueueDir,
+			Limit:         config.QueueSize,
+			Overflow:      config.Overflow,
+			FlushInterval: config.FlushInterval,
+		}),
 		config: config,
 	}
 
@@ -184,13 +212,9 @@ func New(config Config) *Target {
 
 // Send log message 'e' to http target.
 func (h *Target) Send(entry interface{}, errKind string) error {
-	select {
-	case h.logCh <- entry:
-	default:
-		// log channel is full, do not wait and return
-		// an error immediately to the caller
-		return errors.New("log buffer full")
+	logJSON, err := json.Marshal(&entry)
+	if err != nil {
+		return err
 	}
-
-	return nil
+	return h.queue.Put(logJSON)
 }
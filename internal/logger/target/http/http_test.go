@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/logger/target/store"
+)
+
+func TestTargetSendRetryable(t *testing.T) {
+	testCases := []struct {
+		status    int
+		err       bool
+		retryable bool
+	}{
+		{http.StatusOK, false, false},
+		{http.StatusNoContent, false, false},
+		{http.StatusBadRequest, true, false},
+		{http.StatusUnauthorized, true, true},
+		{http.StatusForbidden, true, true},
+		{http.StatusRequestEntityTooLarge, true, false},
+		{http.StatusTooManyRequests, true, true},
+		{http.StatusInternalServerError, true, true},
+		{http.StatusServiceUnavailable, true, true},
+	}
+	for i, testCase := range testCases {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(testCase.status)
+		}))
+		h := New(Config{Endpoint: ts.URL})
+		err := h.send([]byte(`{}`))
+		ts.Close()
+
+		if !testCase.err {
+			if err != nil {
+				t.Errorf("Test %d: unexpected error %v", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+			continue
+		}
+		var nerr store.NonRetryableError
+		if retryable := !errors.As(err, &nerr); retryable != testCase.retryable {
+			t.Errorf("Test %d: expected retryable %v, got %v", i+1, testCase.retryable, retryable)
+		}
+	}
+
+	// Transport errors are retried.
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+	h := New(Config{Endpoint: ts.URL})
+	var nerr store.NonRetryableError
+	if err := h.send([]byte(`{}`)); err == nil || errors.As(err, &nerr) {
+		t.Fatalf("expected a retryable error, got %v", err)
+	}
+}
+
+func TestTargetDropsRejectedEntries(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) == `"rejected"` {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		mu.Lock()
+		received = append(received, string(body))
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	h := New(Config{
+		Endpoint:      ts.URL,
+		FlushInterval: 10 * time.Millisecond,
+		LogOnce:       func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {},
+	})
+	if err := h.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []string{"rejected", "accepted"} {
+		if err := h.Send(entry, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The rejected entry must not hold back the entry after it.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(received)
+		mu.Unlock()
+		if n == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the init request and the accepted entry, got %d requests", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if received[1] != `"accepted"` {
+		t.Fatalf("unexpected entry %s", received[1])
+	}
+	if h.Dropped() != 1 {
+		t.Fatalf("expected 1 dropped entry, got %d", h.Dropped())
+	}
+}
@@ -24,18 +24,20 @@ import (
 	"encoding/json"
 	"errors"
 	"net"
+	"time"
 
 	sarama "github.com/Shopify/sarama"
 	saramatls "github.com/Shopify/sarama/tools/tls"
 
 	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/minio/internal/logger/target/store"
 	xnet "github.com/minio/pkg/net"
 )
 
 // Target - Kafka target.
 type Target struct {
-	// Channel of log entries
-	logCh chan interface{}
+	// Queue of audit entries
+	queue *store.Queue
 
 	producer sarama.SyncProducer
 	kconfig  Config
@@ -44,43 +46,57 @@ type Target struct {
 
 // Send log message 'e' to kafka target.
 func (h *Target) Send(entry interface{}, errKind string) error {
-	select {
-	case h.logCh <- entry:
-	default:
-		// log channel is full, do not wait and return
-		// an error immediately to the caller
-		return errors.New("log buffer full")
+	// Only audit entries are sent to kafka.
+	if _, ok := entry.(audit.Entry); !ok {
+		return nil
 	}
+	logJSON, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+	return h.queue.Put(logJSON)
+}
 
-	return nil
+// Dropped returns the number of audit entries dropped
+// because the queue of the target was full or the
+// brokers rejected them.
+func (h *Target) Dropped() uint64 {
+	return h.queue.Dropped()
 }
 
-func (h *Target) startKakfaLogger() {
-	// Create a routine which sends json logs received
-	// from an internal channel.
-	go func() {
-		for entry := range h.logCh {
-			logJSON, err := json.Marshal(&entry)
-			if err != nil {
-				continue
-			}
+// send produces a single audit entry, keyed by its request ID.
+func (h *Target) send(logJSON []byte) error {
+	var ae struct {
+		RequestID string `json:"requestID"`
+	}
+	if err := json.Unmarshal(logJSON, &ae); err != nil {
+		return store.NonRetryable(err)
+	}
+	msg := sarama.ProducerMessage{
+		Topic: h.kconfig.Topic,
+		Key:   sarama.StringEncoder(ae.RequestID),
+		Value: sarama.ByteEncoder(logJSON),
+	}
+	_, _, err := h.producer.SendMessage(&msg)
+	var kerr sarama.KError
+	if errors.As(err, &kerr) && nonRetryableErrors[kerr] {
+		return store.NonRetryable(err)
+	}
+	return err
+}
 
-			ae, ok := entry.(audit.Entry)
-			if ok {
-				msg := sarama.ProducerMessage{
-					Topic: h.kconfig.Topic,
-					Key:   sarama.StringEncoder(ae.RequestID),
-					Value: sarama.ByteEncoder(logJSON),
-				}
-
-				_, _, err = h.producer.SendMessage(&msg)
-				if err != nil {
-					h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
-					continue
-				}
-			}
-		}
-	}()
+// nonRetryableErrors are the errors of a produced message which fail
+// again when the message is produced again.
+var nonRetryableErrors = map[sarama.KError]bool{
+	sarama.ErrInvalidMessage:              true,
+	sarama.ErrInvalidMessageSize:          true,
+	sarama.ErrMessageSizeTooLarge:         true,
+	sarama.ErrInvalidTopic:                true,
+	sarama.ErrInvalidRequiredAcks:         true,
+	sarama.ErrTopicAuthorizationFailed:    true,
+	sarama.ErrClusterAuthorizationFailed:  true,
+	sarama.ErrUnsupportedForMessageFormat: true,
+	sarama.ErrPolicyViolation:             true,
 }
 
 // Config - kafka target arguments.
@@ -104,6 +120,11 @@ type Config struct {
 		Mechanism string `json:"mechanism"`
 	} `json:"sasl"`
 
+	QueueSize     int           `json:"queueSize"`
+	QueueDir      string        `json:"queueDir"`
+	Overflow      string        `json:"overflow"`
+	FlushInterval time.Duration `json:"flushInterval"`
+
 	// Custom logger
 	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
 }
@@ -193,7 +214,12 @@ func (h *Target) Init() error {
 
 	h.producer = producer
 
-	go h.startKakfaLogger()
+	if err = h.queue.Open(); err != nil {
+		return err
+	}
+	go h.queue.Run(h.send, func(err error) {
+		h.kconfig.LogOnce(context.Background(), err, h.kconfig.Topic)
+	})
 	return nil
 }
 
@@ -201,7 +227,12 @@ func (h *Target) Init() error {
 // sends log over http to the specified endpoint
 func New(config Config) *Target {
 	target := &Target{
-		logCh:   make(chan interface{}, 10000),
+		queue: store.New(store.Config{
+			Dir:           config.QueueDir,
+			Limit:         config.QueueSize,
+			Overflow:      config.Overflow,
+			FlushInterval: config.FlushInterval,
+		}),
 		kconfig: config,
 	}
 	return target
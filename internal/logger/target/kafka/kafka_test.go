@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	sarama "github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+
+	"github.com/minio/minio/internal/logger/target/store"
+)
+
+func TestTargetSendRetryable(t *testing.T) {
+	testCases := []struct {
+		err       error
+		retryable bool
+	}{
+		{sarama.ErrMessageSizeTooLarge, false},
+		{sarama.ErrTopicAuthorizationFailed, false},
+		{sarama.ErrInvalidMessage, false},
+		{sarama.ErrNotLeaderForPartition, true},
+		{sarama.ErrRequestTimedOut, true},
+		{sarama.ErrOutOfBrokers, true},
+	}
+	for i, testCase := range testCases {
+		producer := mocks.NewSyncProducer(t, nil)
+		producer.ExpectSendMessageAndFail(testCase.err)
+		h := New(Config{Topic: "audit"})
+		h.producer = producer
+
+		err := h.send([]byte(`{"requestID":"1"}`))
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+		}
+		var nerr store.NonRetryableError
+		if retryable := !errors.As(err, &nerr); retryable != testCase.retryable {
+			t.Errorf("Test %d: expected retryable %v, got %v", i+1, testCase.retryable, retryable)
+		}
+		producer.Close()
+	}
+
+	producer := mocks.NewSyncProducer(t, nil)
+	defer producer.Close()
+	producer.ExpectSendMessageAndSucceed()
+	h := New(Config{Topic: "audit"})
+	h.producer = producer
+	if err := h.send([]byte(`{"requestID":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries which are not valid JSON are never sent.
+	var nerr store.NonRetryableError
+	if err := h.send([]byte(`{`)); !errors.As(err, &nerr) {
+		t.Fatalf("expected a non retryable error, got %v", err)
+	}
+}
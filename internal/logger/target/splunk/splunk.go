@@ -0,0 +1,353 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package splunk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+const (
+	// Timeout for the HEC http call
+	hecCallTimeout = 5 * time.Second
+
+	// Paths of the HEC event and health endpoints
+	hecEventPath  = "/services/collector/event"
+	hecHealthPath = "/services/collector/health"
+
+	// Number of attempts to deliver a batch while HEC
+	// is asking us to back off.
+	maxAttempts = 5
+	maxBackoff  = 30 * time.Second
+)
+
+// Config splunk HTTP Event Collector target
+type Config struct {
+	Enabled       bool              `json:"enabled"`
+	Name          string            `json:"name"`
+	UserAgent     string            `json:"userAgent"`
+	Endpoint      string            `json:"endpoint"`
+	Token         string            `json:"token"`
+	Index         string            `json:"index"`
+	SourceType    string            `json:"sourcetype"`
+	Source        string            `json:"source"`
+	Host          string            `json:"host"`
+	BatchSize     int               `json:"batchSize"`
+	FlushInterval time.Duration     `json:"flushInterval"`
+	QueueSize     int               `json:"queueSize"`
+	Transport     http.RoundTripper `json:"-"`
+
+	// Custom logger
+	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
+}
+
+// Target implements logger.Target and sends log entries as
+// events to a Splunk HTTP Event Collector. Entries are
+// queued and sent in batches of up to BatchSize events or
+// every FlushInterval, whichever comes first. When the queue
+// is full, for example while HEC asks us to back off, new
+// entries are dropped and an error is returned to the caller.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	// Number of entries dropped since the queue was full
+	dropped uint64
+
+	eventURL string
+	config   Config
+}
+
+// hecEvent is the HEC event envelope of a log entry.
+type hecEvent struct {
+	Time       float64     `json:"time,omitempty"`
+	Host       string      `json:"host,omitempty"`
+	Source     string      `json:"source,omitempty"`
+	SourceType string      `json:"sourcetype,omitempty"`
+	Index      string      `json:"index,omitempty"`
+	Event      interface{} `json:"event"`
+}
+
+// hecResponse is the body of HEC replies.
+type hecResponse struct {
+	Text string `json:"text"`
+	Code int    `json:"code"`
+}
+
+// Endpoint returns the backend endpoint
+func (h *Target) Endpoint() string {
+	return h.config.Endpoint
+}
+
+func (h *Target) String() string {
+	return h.config.Name
+}
+
+// Dropped returns the number of log entries dropped
+// because the queue of the target was full.
+func (h *Target) Dropped() uint64 {
+	return atomic.LoadUint64(&h.dropped)
+}
+
+// resolveURL returns the URL of the HEC endpoint at path, the
+// configured endpoint is used as is if it already has a path.
+func (h *Target) resolveURL(path string) (string, error) {
+	u, err := url.Parse(h.config.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid endpoint %s, scheme must be http or https", h.config.Endpoint)
+	}
+	if path == hecHealthPath || u.Path == "" || u.Path == "/" {
+		u.Path = path
+	}
+	return u.String(), nil
+}
+
+func (h *Target) newRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+
+	// Set user-agent to indicate MinIO release
+	// version to the configured log endpoint
+	req.Header.Set("User-Agent", h.config.UserAgent)
+	req.Header.Set("Authorization", "Splunk "+h.config.Token)
+	return req, nil
+}
+
+// Init validate and initialize the splunk target
+func (h *Target) Init() error {
+	if h.config.Token == "" {
+		return errors.New("splunk HEC token cannot be empty")
+	}
+
+	eventURL, err := h.resolveURL(hecEventPath)
+	if err != nil {
+		return err
+	}
+	healthURL, err := h.resolveURL(hecHealthPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*hecCallTimeout)
+	defer cancel()
+
+	req, err := h.newRequest(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return err
+	}
+
+	client := http.Client{Transport: h.config.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%s returned '%s', please check if your HEC token is correctly set",
+			h.config.Endpoint, resp.Status)
+	default:
+		return fmt.Errorf("%s returned '%s', please check your endpoint configuration",
+			h.config.Endpoint, resp.Status)
+	}
+
+	h.eventURL = eventURL
+	go h.startSplunkLogger()
+	return nil
+}
+
+// encode appends the HEC event of entry to the batch.
+func (h *Target) encode(batch *bytes.Buffer, entry interface{}) error {
+	ev := hecEvent{
+		Host:       h.config.Host,
+		Source:     h.config.Source,
+		SourceType: h.config.SourceType,
+		Index:      h.config.Index,
+		Event:      entry,
+	}
+	if ae, ok := entry.(audit.Entry); ok {
+		ev.Time = float64(ae.Time.UnixNano()) / float64(time.Second)
+	}
+	return json.NewEncoder(batch).Encode(&ev)
+}
+
+func (h *Target) startSplunkLogger() {
+	// Create a routine which sends batches of events
+	// received from an internal channel.
+	go func() {
+		var batch bytes.Buffer
+		var n int
+
+		ticker := time.NewTicker(h.config.FlushInterval)
+		defer ticker.Stop()
+
+		flush := func() {
+			if n > 0 {
+				h.send(batch.Bytes(), n)
+			}
+			batch.Reset()
+			n = 0
+		}
+
+		for {
+			select {
+			case entry, ok := <-h.logCh:
+				if !ok {
+					flush()
+					return
+				}
+				if err := h.encode(&batch, entry); err != nil {
+					continue
+				}
+				n++
+				if n >= h.config.BatchSize {
+					flush()
+				}
+			case <-ticker.C:
+				flush()
+			}
+		}
+	}()
+}
+
+// retryAfter returns how long to wait before the next attempt to
+// deliver a batch, HEC may tell us with the Retry-After header.
+func retryAfter(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			if d := time.Duration(secs) * time.Second; d < maxBackoff {
+				return d
+			}
+			return maxBackoff
+		}
+	}
+	d := time.Second << uint(attempt)
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// retryable returns whether HEC asks us to retry the batch later,
+// as it does when its queues are full or it is unavailable.
+func retryable(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable,
+		http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// send delivers a batch of n events to HEC. While HEC asks us to back
+// off, the batch is retried and the queue of the target fills up;
+// once it is full new entries are dropped instead of blocking the
+// callers.
+func (h *Target) send(batch []byte, n int) {
+	client := http.Client{Transport: h.config.Transport}
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), hecCallTimeout)
+		req, err := h.newRequest(ctx, http.MethodPost, h.eventURL, batch)
+		if err != nil {
+			cancel()
+			h.config.LogOnce(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration", h.config.Endpoint, err), h.config.Endpoint)
+			return
+		}
+
+		resp, err := client.Do(req)
+		if err == nil {
+			var hr hecResponse
+			json.NewDecoder(resp.Body).Decode(&hr)
+			xhttp.DrainBody(resp.Body)
+			cancel()
+
+			switch {
+			case resp.StatusCode == http.StatusOK:
+				return
+			case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+				h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s', please check if your HEC token is correctly set", h.config.Endpoint, resp.Status), h.config.Endpoint)
+				return
+			case !retryable(resp.StatusCode):
+				h.config.LogOnce(ctx, fmt.Errorf("%s returned '%s' (%s), dropped %d events", h.config.Endpoint, resp.Status, strings.TrimSpace(hr.Text), n), h.config.Endpoint)
+				return
+			}
+			err = fmt.Errorf("%s returned '%s'", h.config.Endpoint, resp.Status)
+		} else {
+			cancel()
+		}
+
+		if attempt+1 >= maxAttempts {
+			h.config.LogOnce(context.Background(), fmt.Errorf("%w, dropped %d events after %d attempts", err, n, maxAttempts), h.config.Endpoint)
+			return
+		}
+		time.Sleep(retryAfter(resp, attempt))
+	}
+}
+
+// New initializes a new logger target which sends
+// log entries to the specified Splunk HTTP Event Collector
+func New(config Config) *Target {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	h := &Target{
+		logCh:  make(chan interface{}, config.QueueSize),
+		config: config,
+	}
+
+	return h
+}
+
+// Send log message 'e' to splunk target.
+func (h *Target) Send(entry interface{}, errKind string) error {
+	select {
+	case h.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		atomic.AddUint64(&h.dropped, 1)
+		return errors.New("log buffer full")
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package splunk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+type testHEC struct {
+	sync.Mutex
+	events   []hecEvent
+	batches  int
+	throttle int
+}
+
+func (s *testHEC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Splunk test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch r.URL.Path {
+	case hecHealthPath:
+		w.WriteHeader(http.StatusOK)
+		return
+	case hecEventPath:
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if s.throttle > 0 {
+		s.throttle--
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	s.batches++
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var ev hecEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.events = append(s.events, ev)
+	}
+	w.Write([]byte(`{"text":"Success","code":0}`))
+}
+
+func (s *testHEC) received() (events, batches int) {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.events), s.batches
+}
+
+func testLogOnce(t *testing.T) func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+	return func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+		t.Log(err)
+	}
+}
+
+func waitForEvents(t *testing.T, hec *testHEC, n int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if got, _ := hec.received(); got >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	got, _ := hec.received()
+	t.Fatalf("expected %d events, got %d", n, got)
+}
+
+func TestSplunkTarget(t *testing.T) {
+	hec := &testHEC{}
+	srv := httptest.NewServer(hec)
+	defer srv.Close()
+
+	target := New(Config{
+		Enabled:       true,
+		Name:          "splunk",
+		Endpoint:      srv.URL,
+		Token:         "test-token",
+		Index:         "minio",
+		SourceType:    "minio:audit",
+		Host:          "node1",
+		BatchSize:     10,
+		FlushInterval: 50 * time.Millisecond,
+		QueueSize:     100,
+		LogOnce:       testLogOnce(t),
+	})
+	if err := target.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	for i := 0; i < 25; i++ {
+		entry := audit.NewEntry("deployment")
+		entry.Time = now
+		if err := target.Send(entry, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitForEvents(t, hec, 25)
+
+	hec.Lock()
+	defer hec.Unlock()
+	if hec.batches != 3 {
+		t.Errorf("expected 3 batches, got %d", hec.batches)
+	}
+	ev := hec.events[0]
+	if ev.Index != "minio" || ev.SourceType != "minio:audit" || ev.Host != "node1" {
+		t.Errorf("unexpected event metadata %+v", ev)
+	}
+	if int64(ev.Time) != now.Unix() {
+		t.Errorf("expected event time %d, got %f", now.Unix(), ev.Time)
+	}
+}
+
+func TestSplunkTargetInvalidToken(t *testing.T) {
+	srv := httptest.NewServer(&testHEC{})
+	defer srv.Close()
+
+	target := New(Config{
+		Endpoint:  srv.URL,
+		Token:     "wrong-token",
+		QueueSize: 1,
+	})
+	if err := target.Init(); err == nil {
+		t.Fatal("expected an error with an invalid token")
+	}
+}
+
+func TestSplunkTargetBackpressure(t *testing.T) {
+	hec := &testHEC{throttle: 1}
+	srv := httptest.NewServer(hec)
+	defer srv.Close()
+
+	target := New(Config{
+		Endpoint:      srv.URL,
+		Token:         "test-token",
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		QueueSize:     2,
+		LogOnce:       testLogOnce(t),
+	})
+	if err := target.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first event is retried after HEC throttled it, in
+	// the meantime the queue fills up and events are dropped.
+	if err := target.Send("first", ""); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	var failed int
+	for i := 0; i < 5; i++ {
+		if err := target.Send("event", ""); err != nil {
+			failed++
+		}
+	}
+	if failed != 3 || target.Dropped() != 3 {
+		t.Errorf("expected 3 dropped events, got %d (%d)", target.Dropped(), failed)
+	}
+
+	waitForEvents(t, hec, 3)
+	if _, batches := hec.received(); batches != 3 {
+		t.Errorf("expected 3 batches, got %d", batches)
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Overflow policies of a full queue.
+const (
+	// OverflowDrop - new entries are dropped and counted.
+	OverflowDrop = "drop"
+	// OverflowBlock - new entries wait for space, up to a minute,
+	// before they are dropped.
+	OverflowBlock = "block"
+)
+
+const (
+	defaultLimit = 100000 // Default queue limit.
+	batchExt     = ".batch"
+
+	// Maximum number of entries per batch, a batch is flushed
+	// early when it is full.
+	maxBatchEntries = 1000
+
+	// Maximum delay between attempts to deliver an entry.
+	maxRetryInterval = 30 * time.Second
+)
+
+// Maximum time Put waits for space in a full queue with the block
+// overflow policy.
+var blockTimeout = time.Minute
+
+// ErrQueueFull - the entry was dropped because the queue is full.
+var ErrQueueFull = errors.New("log queue is full")
+
+// ErrQueueClosed - the entry was dropped because the queue is closed.
+var ErrQueueClosed = errors.New("log queue is closed")
+
+// NonRetryableError - the entry was rejected by the target and is
+// dropped instead of being sent again.
+type NonRetryableError struct {
+	Err error
+}
+
+func (e NonRetryableError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the rejection error.
+func (e NonRetryableError) Unwrap() error {
+	return e.Err
+}
+
+// NonRetryable marks err as a rejection which fails again when the
+// entry is sent again.
+func NonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return NonRetryableError{Err: err}
+}
+
+// Config of a queue of log entries.
+type Config struct {
+	// Dir persists queued entries across restarts, entries are only
+	// kept in memory when empty.
+	Dir string
+	// Limit is the maximum number of queued entries.
+	Limit int
+	// Overflow is the policy applied when the queue is full.
+	Overflow string
+	// FlushInterval is the interval at which new entries are
+	// flushed to Dir and handed over for delivery.
+	FlushInterval time.Duration
+}
+
+// batch of entries flushed together, only the name is kept in
+// memory for batches persisted on disk.
+type batch struct {
+	name    string
+	entries [][]byte
+	// Number of entries left to deliver.
+	left int
+}
+
+// Queue is a bounded queue of log entries, optionally backed by
+// disk. Entries are added to a pending batch by Put, which never
+// waits for delivery, and are flushed every FlushInterval. Flushed
+// batches are delivered in order by Run, failed deliveries are
+// retried until they succeed, so that a slow or unavailable endpoint
+// fills the queue instead of delaying callers of Put.
+type Queue struct {
+	cfg Config
+
+	mu      sync.Mutex
+	pending [][]byte
+	batches []*batch
+	// Number of queued entries, pending or flushed.
+	queued int
+	closed bool
+	// Time of the last batch name, names are strictly increasing.
+	lastName int64
+	// Signaled whenever entries are delivered or batches flushed.
+	changed *sync.Cond
+
+	flushCh chan struct{}
+	doneCh  chan struct{}
+	dropped uint64
+}
+
+// New returns a queue with the configuration, Open must be called
+// before the queue is used.
+func New(cfg Config) *Queue {
+	if cfg.Limit <= 0 {
+		cfg.Limit = defaultLimit
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	q := &Queue{
+		cfg:     cfg,
+		flushCh: make(chan struct{}, 1),
+		doneCh:  make(chan struct{}),
+	}
+	q.changed = sync.NewCond(&q.mu)
+	return q
+}
+
+// Open loads the batches persisted in the queue directory and
+// starts flushing new entries.
+func (q *Queue) Open() error {
+	if q.cfg.Dir != "" {
+		if err := os.MkdirAll(q.cfg.Dir, os.FileMode(0o770)); err != nil {
+			return err
+		}
+		files, err := ioutil.ReadDir(q.cfg.Dir)
+		if err != nil {
+			return err
+		}
+		var names []string
+		for _, file := range files {
+			if !file.IsDir() && strings.HasSuffix(file.Name(), batchExt) {
+				names = append(names, file.Name())
+			}
+		}
+		sort.Strings(names)
+
+		q.mu.Lock()
+		for _, name := range names {
+			entries, err := q.readBatch(name)
+			if err != nil {
+				q.mu.Unlock()
+				return err
+			}
+			if len(entries) == 0 {
+				os.Remove(filepath.Join(q.cfg.Dir, name))
+				continue
+			}
+			q.batches = append(q.batches, &batch{name: name, left: len(entries)})
+			q.queued += len(entries)
+		}
+		q.mu.Unlock()
+	}
+
+	go q.flusher()
+	return nil
+}
+
+// Close stops the queue, entries which are not flushed yet are
+// flushed first.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	q.mu.Unlock()
+	close(q.doneCh)
+
+	q.mu.Lock()
+	q.flush()
+	q.changed.Broadcast()
+	q.mu.Unlock()
+}
+
+// Dropped returns the number of entries dropped because the
+// queue was full, their batch could not be read back or the
+// target rejected them.
+func (q *Queue) Dropped() uint64 {
+	return atomic.LoadUint64(&q.dropped)
+}
+
+// Len returns the number of queued entries.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queued
+}
+
+// Put adds an entry to the queue, applying the overflow policy
+// when the queue is full.
+func (q *Queue) Put(entry []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if q.queued >= q.cfg.Limit {
+		if q.cfg.Overflow != OverflowBlock || !q.waitForSpace() {
+			atomic.AddUint64(&q.dropped, 1)
+			return ErrQueueFull
+		}
+	}
+
+	q.pending = append(q.pending, entry)
+	q.queued++
+	if len(q.pending) >= maxBatchEntries {
+		select {
+		case q.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// waitForSpace waits until the queue is not full anymore, up to
+// blockTimeout. Must be called with the lock held.
+func (q *Queue) waitForSpace() bool {
+	deadline := time.Now().Add(blockTimeout)
+	timer := time.AfterFunc(blockTimeout, q.changed.Broadcast)
+	defer timer.Stop()
+	for q.queued >= q.cfg.Limit && !q.closed {
+		if !time.Now().Before(deadline) {
+			return false
+		}
+		q.changed.Wait()
+	}
+	return !q.closed
+}
+
+func (q *Queue) flusher() {
+	ticker := time.NewTicker(q.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.doneCh:
+			return
+		case <-ticker.C:
+		case <-q.flushCh:
+		}
+		q.mu.Lock()
+		q.flush()
+		q.mu.Unlock()
+	}
+}
+
+// flush turns the pending entries into a batch ready for delivery.
+// Must be called with the lock held.
+func (q *Queue) flush() {
+	if len(q.pending) == 0 {
+		return
+	}
+	b := &batch{entries: q.pending, left: len(q.pending)}
+	q.pending = nil
+	if q.cfg.Dir != "" {
+		name, err := q.writeBatch(b.entries)
+		if err == nil {
+			// Entries are read back from disk on delivery.
+			b.name, b.entries = name, nil
+		}
+	}
+	q.batches = append(q.batches, b)
+	q.changed.Broadcast()
+}
+
+func (q *Queue) writeBatch(entries [][]byte) (string, error) {
+	now := time.Now().UnixNano()
+	if now <= q.lastName {
+		now = q.lastName + 1
+	}
+	q.lastName = now
+	name := strconv.FormatInt(now, 10) + batchExt
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		buf.Write(entry)
+		buf.WriteByte('\n')
+	}
+	tmp := filepath.Join(q.cfg.Dir, "."+name)
+	if err := ioutil.WriteFile(tmp, buf.Bytes(), os.FileMode(0o770)); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, filepath.Join(q.cfg.Dir, name)); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return name, nil
+}
+
+func (q *Queue) readBatch(name string) ([][]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(q.cfg.Dir, name))
+	if err != nil {
+		return nil, err
+	}
+	var entries [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		if line := scanner.Bytes(); len(line) > 0 {
+			entries = append(entries, append([]byte(nil), line...))
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// next waits for the oldest batch, it returns nil once the queue is
+// closed and all batches have been delivered.
+func (q *Queue) next() *batch {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.batches) == 0 {
+		if q.closed {
+			return nil
+		}
+		q.changed.Wait()
+	}
+	return q.batches[0]
+}
+
+// delivered marks the next entry of the oldest batch as delivered.
+func (q *Queue) delivered(b *batch) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	b.left--
+	q.queued--
+	if b.left == 0 {
+		q.batches = q.batches[1:]
+		if b.name != "" {
+			os.Remove(filepath.Join(q.cfg.Dir, b.name))
+		}
+	}
+	q.changed.Broadcast()
+}
+
+// Run delivers the queued entries in order by calling send, until
+// the queue is closed. Failed deliveries are retried unless send
+// returns a NonRetryableError, onError is called for every failed
+// attempt.
+func (q *Queue) Run(send func(entry []byte) error, onError func(err error)) {
+	for {
+		b := q.next()
+		if b == nil {
+			return
+		}
+		entries := b.entries
+		if b.name != "" {
+			var err error
+			if entries, err = q.readBatch(b.name); err != nil {
+				// The batch cannot be delivered, drop it.
+				onError(err)
+				entries = nil
+			}
+		}
+
+		// Entries missing from a batch modified on disk are dropped.
+		for b.left > len(entries) {
+			atomic.AddUint64(&q.dropped, 1)
+			q.delivered(b)
+		}
+		for _, entry := range entries[len(entries)-b.left:] {
+			if !q.deliver(entry, send, onError) {
+				return
+			}
+			q.delivered(b)
+		}
+	}
+}
+
+// deliver sends the entry until it succeeds or is rejected, it
+// returns false if the queue was closed while retrying.
+func (q *Queue) deliver(entry []byte, send func(entry []byte) error, onError func(err error)) bool {
+	retryInterval := q.cfg.FlushInterval
+	for {
+		err := send(entry)
+		if err == nil {
+			return true
+		}
+		onError(err)
+		var nerr NonRetryableError
+		if errors.As(err, &nerr) {
+			// Sending the entry again fails the same way, drop it
+			// rather than blocking all later entries.
+			atomic.AddUint64(&q.dropped, 1)
+			return true
+		}
+
+		select {
+		case <-q.doneCh:
+			return false
+		case <-time.After(retryInterval):
+		}
+		if retryInterval *= 2; retryInterval > maxRetryInterval {
+			retryInterval = maxRetryInterval
+		}
+	}
+}
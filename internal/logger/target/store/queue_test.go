@@ -0,0 +1,166 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueueOverflowDrop(t *testing.T) {
+	q := New(Config{Limit: 2, Overflow: OverflowDrop, FlushInterval: time.Hour})
+	if err := q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := q.Put([]byte(fmt.Sprintf(`{"n":%d}`, i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Put([]byte(`{"n":2}`)); err != ErrQueueFull {
+		t.Fatalf("expected %v, got %v", ErrQueueFull, err)
+	}
+	if q.Dropped() != 1 {
+		t.Fatalf("expected 1 dropped entry, got %d", q.Dropped())
+	}
+}
+
+func TestQueueOverflowBlock(t *testing.T) {
+	defer func(timeout time.Duration) { blockTimeout = timeout }(blockTimeout)
+	blockTimeout = 50 * time.Millisecond
+
+	q := New(Config{Limit: 1, Overflow: OverflowBlock, FlushInterval: 10 * time.Millisecond})
+	if err := q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+
+	if err := q.Put([]byte(`{"n":0}`)); err != nil {
+		t.Fatal(err)
+	}
+	// Nothing is delivered, Put gives up after the block timeout.
+	start := time.Now()
+	if err := q.Put([]byte(`{"n":1}`)); err != ErrQueueFull {
+		t.Fatalf("expected %v, got %v", ErrQueueFull, err)
+	}
+	if time.Since(start) < blockTimeout {
+		t.Fatal("expected Put to wait for space")
+	}
+
+	var mu sync.Mutex
+	var sent []string
+	go q.Run(func(entry []byte) error {
+		mu.Lock()
+		sent = append(sent, string(entry))
+		mu.Unlock()
+		return nil
+	}, func(err error) {})
+
+	// Space is freed by the delivery of the first entry.
+	if err := q.Put([]byte(`{"n":2}`)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueueRetryAndPersist(t *testing.T) {
+	dir := t.TempDir()
+	q := New(Config{Dir: dir, Limit: 100, Overflow: OverflowDrop, FlushInterval: 10 * time.Millisecond})
+	if err := q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := q.Put([]byte(fmt.Sprintf(`{"n":%d}`, i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Flushes the pending entries to disk.
+	q.Close()
+
+	// Entries are loaded back from disk after a restart.
+	q = New(Config{Dir: dir, Limit: 100, Overflow: OverflowDrop, FlushInterval: 10 * time.Millisecond})
+	if err := q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	if q.Len() != 3 {
+		t.Fatalf("expected 3 queued entries, got %d", q.Len())
+	}
+
+	sentCh := make(chan string, 3)
+	failures := 0
+	go q.Run(func(entry []byte) error {
+		// The endpoint fails twice before accepting entries.
+		if failures < 2 {
+			failures++
+			return errors.New("endpoint unavailable")
+		}
+		sentCh <- string(entry)
+		return nil
+	}, func(err error) {})
+
+	for i := 0; i < 3; i++ {
+		select {
+		case entry := <-sentCh:
+			if expected := fmt.Sprintf(`{"n":%d}`, i); entry != expected {
+				t.Fatalf("expected %s, got %s", expected, entry)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for delivery")
+		}
+	}
+}
+
+func TestQueueNonRetryable(t *testing.T) {
+	q := New(Config{Limit: 100, Overflow: OverflowDrop, FlushInterval: 10 * time.Millisecond})
+	if err := q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	for i := 0; i < 2; i++ {
+		if err := q.Put([]byte(fmt.Sprintf(`{"n":%d}`, i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sentCh := make(chan string, 2)
+	go q.Run(func(entry []byte) error {
+		// The first entry is rejected, it must not block the next one.
+		if string(entry) == `{"n":0}` {
+			return NonRetryable(errors.New("entry rejected"))
+		}
+		sentCh <- string(entry)
+		return nil
+	}, func(err error) {})
+
+	select {
+	case entry := <-sentCh:
+		if entry != `{"n":1}` {
+			t.Fatalf("expected {\"n\":1}, got %s", entry)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+	if q.Dropped() != 1 {
+		t.Fatalf("expected 1 dropped entry, got %d", q.Dropped())
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package syslog
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/minio/internal/logger/message/log"
+)
+
+// Timeout to connect and write to the syslog server
+const syslogTimeout = 5 * time.Second
+
+// Network protocols to reach the syslog server
+const (
+	NetworkUDP = "udp"
+	NetworkTCP = "tcp"
+	NetworkTLS = "tls"
+)
+
+// Severity - syslog message severity.
+type Severity int
+
+// Syslog severities, as defined by RFC5424.
+const (
+	SeverityEmergency Severity = iota
+	SeverityAlert
+	SeverityCritical
+	SeverityError
+	SeverityWarning
+	SeverityNotice
+	SeverityInfo
+	SeverityDebug
+)
+
+// facilities are the syslog facility names in the
+// order of their numerical codes.
+var facilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// ParseFacility - returns the code of the syslog facility name.
+func ParseFacility(name string) (int, error) {
+	for code, facility := range facilities {
+		if strings.EqualFold(name, facility) {
+			return code, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown syslog facility %q", name)
+}
+
+// sdID is the structured data ID of the parameters of MinIO entries,
+// 32473 is the private enterprise number reserved for examples.
+const sdID = "minio@32473"
+
+// Config syslog logger target
+type Config struct {
+	Enabled   bool        `json:"enabled"`
+	Name      string      `json:"name"`
+	Network   string      `json:"network"`
+	Address   string      `json:"address"`
+	Facility  int         `json:"facility"`
+	AppName   string      `json:"appName"`
+	Hostname  string      `json:"hostname"`
+	QueueSize int         `json:"queueSize"`
+	TLSConfig *tls.Config `json:"-"`
+
+	// Custom logger
+	LogOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{}) `json:"-"`
+}
+
+// Target implements logger.Target and sends log entries as
+// RFC5424 messages to a syslog server over UDP, TCP or TLS.
+// Messages sent over TCP and TLS are framed by octet
+// counting, as defined by RFC5425. An internal buffer of
+// logs is maintained but when the buffer is full, new logs
+// are just ignored and an error is returned to the caller.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	conn   net.Conn
+	procID string
+	config Config
+}
+
+// Endpoint returns the backend endpoint
+func (h *Target) Endpoint() string {
+	return h.config.Network + "://" + h.config.Address
+}
+
+func (h *Target) String() string {
+	return h.config.Name
+}
+
+func (h *Target) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	switch h.config.Network {
+	case NetworkUDP, NetworkTCP:
+		return dialer.Dial(h.config.Network, h.config.Address)
+	case NetworkTLS:
+		return tls.DialWithDialer(dialer, "tcp", h.config.Address, h.config.TLSConfig)
+	}
+	return nil, fmt.Errorf("unsupported syslog network %q", h.config.Network)
+}
+
+// Init validate and initialize the syslog target
+func (h *Target) Init() error {
+	if h.config.Address == "" {
+		return errors.New("syslog address cannot be empty")
+	}
+	conn, err := h.dial()
+	if err != nil {
+		return err
+	}
+	h.conn = conn
+
+	go h.startSyslogLogger()
+	return nil
+}
+
+// severity returns the syslog severity of a log entry: server logs
+// by their level and audit logs by the status code of the request.
+func severity(entry interface{}) Severity {
+	switch e := entry.(type) {
+	case log.Entry:
+		switch e.Level {
+		case "FATAL":
+			return SeverityCritical
+		case "ERROR":
+			return SeverityError
+		case "INFO":
+			return SeverityInfo
+		}
+		return SeverityNotice
+	case audit.Entry:
+		switch {
+		case e.API.StatusCode >= 500:
+			return SeverityError
+		case e.API.StatusCode >= 400:
+			return SeverityWarning
+		}
+		return SeverityInfo
+	}
+	return SeverityNotice
+}
+
+// sdParam appends a structured data parameter, empty values are left out.
+func sdParam(b *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+	b.WriteString(" " + name + `="`)
+	for _, r := range value {
+		switch r {
+		case '"', '\\', ']':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+}
+
+// structuredData returns the RFC5424 structured data of a log
+// entry, so that SIEMs can index the request without parsing
+// the message.
+func structuredData(entry interface{}) (msgID, sd string) {
+	var b strings.Builder
+	b.WriteString("[" + sdID)
+	switch e := entry.(type) {
+	case log.Entry:
+		msgID = "log"
+		sdParam(&b, "level", e.Level)
+		sdParam(&b, "kind", e.LogKind)
+		sdParam(&b, "requestID", e.RequestID)
+		sdParam(&b, "remoteHost", e.RemoteHost)
+		if e.API != nil {
+			sdParam(&b, "api", e.API.Name)
+			if e.API.Args != nil {
+				sdParam(&b, "bucket", e.API.Args.Bucket)
+				sdParam(&b, "object", e.API.Args.Object)
+			}
+		}
+	case audit.Entry:
+		msgID = "audit"
+		sdParam(&b, "api", e.API.Name)
+		sdParam(&b, "bucket", e.API.Bucket)
+		sdParam(&b, "object", e.API.Object)
+		if e.API.StatusCode != 0 {
+			sdParam(&b, "statusCode", strconv.Itoa(e.API.StatusCode))
+		}
+		sdParam(&b, "requestID", e.RequestID)
+		sdParam(&b, "remoteHost", e.RemoteHost)
+	default:
+		return "-", "-"
+	}
+	b.WriteString("]")
+	return msgID, b.String()
+}
+
+func nilValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// format returns the RFC5424 message of a log entry, with the
+// JSON of the entry as message.
+func (h *Target) format(entry interface{}) ([]byte, error) {
+	logJSON, err := json.Marshal(&entry)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().UTC()
+	switch e := entry.(type) {
+	case log.Entry:
+		timestamp = e.Time
+	case audit.Entry:
+		timestamp = e.Time
+	}
+
+	msgID, sd := structuredData(entry)
+	pri := h.config.Facility*8 + int(severity(entry))
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "<%d>1 %s %s %s %s %s %s \xEF\xBB\xBF", pri,
+		timestamp.Format("2006-01-02T15:04:05.000000Z07:00"),
+		nilValue(h.config.Hostname), nilValue(h.config.AppName),
+		h.procID, msgID, sd)
+	msg.Write(logJSON)
+	return msg.Bytes(), nil
+}
+
+// write sends a message to the syslog server, messages
+// over TCP and TLS are prefixed by their length.
+func (h *Target) write(msg []byte) error {
+	if h.conn == nil {
+		conn, err := h.dial()
+		if err != nil {
+			return err
+		}
+		h.conn = conn
+	}
+	if h.config.Network != NetworkUDP {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	h.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+	if _, err := h.conn.Write(msg); err != nil {
+		h.conn.Close()
+		h.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (h *Target) startSyslogLogger() {
+	// Create a routine which sends syslog messages
+	// of logs received from an internal channel.
+	go func() {
+		for entry := range h.logCh {
+			msg, err := h.format(entry)
+			if err != nil {
+				continue
+			}
+
+			// Reconnect once, the syslog server may
+			// have closed an idle connection.
+			if err = h.write(msg); err != nil {
+				err = h.write(msg)
+			}
+			if err != nil {
+				h.config.LogOnce(context.Background(), fmt.Errorf("unable to send log to syslog server %s: %w", h.Endpoint(), err), h.Endpoint())
+			}
+		}
+	}()
+}
+
+// New initializes a new logger target which
+// sends log to the specified syslog server
+func New(config Config) *Target {
+	h := &Target{
+		logCh:  make(chan interface{}, config.QueueSize),
+		procID: strconv.Itoa(os.Getpid()),
+		config: config,
+	}
+
+	return h
+}
+
+// Send log message 'e' to syslog target.
+func (h *Target) Send(entry interface{}, errKind string) error {
+	select {
+	case h.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package syslog
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+	"github.com/minio/minio/internal/logger/message/log"
+)
+
+func testLogOnce(t *testing.T) func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+	return func(ctx context.Context, err error, id interface{}, errKind ...interface{}) {
+		t.Log(err)
+	}
+}
+
+func testAuditEntry() audit.Entry {
+	entry := audit.NewEntry("deployment")
+	entry.Time = time.Date(2021, 11, 3, 10, 20, 30, 123456000, time.UTC)
+	entry.API.Name = "PutObject"
+	entry.API.Bucket = "bucket"
+	entry.API.Object = `a "quoted" object]`
+	entry.API.StatusCode = 503
+	entry.RequestID = "16B3FCB9C6E1E7E0"
+	return entry
+}
+
+func TestParseFacility(t *testing.T) {
+	testCases := []struct {
+		name     string
+		code     int
+		expectOk bool
+	}{
+		{"kern", 0, true},
+		{"daemon", 3, true},
+		{"LOCAL0", 16, true},
+		{"local7", 23, true},
+		{"local8", 0, false},
+	}
+	for _, testCase := range testCases {
+		code, err := ParseFacility(testCase.name)
+		if (err == nil) != testCase.expectOk {
+			t.Errorf("%s: unexpected error %v", testCase.name, err)
+		}
+		if err == nil && code != testCase.code {
+			t.Errorf("%s: expected %d, got %d", testCase.name, testCase.code, code)
+		}
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	ok, notFound := testAuditEntry(), testAuditEntry()
+	ok.API.StatusCode, notFound.API.StatusCode = 200, 404
+	testCases := []struct {
+		entry    interface{}
+		severity Severity
+	}{
+		{log.Entry{Level: "FATAL"}, SeverityCritical},
+		{log.Entry{Level: "ERROR"}, SeverityError},
+		{log.Entry{Level: "INFO"}, SeverityInfo},
+		{testAuditEntry(), SeverityError},
+		{notFound, SeverityWarning},
+		{ok, SeverityInfo},
+		{"message", SeverityNotice},
+	}
+	for i, testCase := range testCases {
+		if s := severity(testCase.entry); s != testCase.severity {
+			t.Errorf("Test %d: expected severity %d, got %d", i+1, testCase.severity, s)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	target := New(Config{Facility: 16, AppName: "minio", Hostname: "node1"})
+	msg, err := target.format(testAuditEntry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := `<131>1 2021-11-03T10:20:30.123456Z node1 minio ` + target.procID +
+		` audit [minio@32473 api="PutObject" bucket="bucket" object="a \"quoted\" object\]"` +
+		` statusCode="503" requestID="16B3FCB9C6E1E7E0"] ` + "\xEF\xBB\xBF{"
+	if !strings.HasPrefix(string(msg), prefix) {
+		t.Errorf("expected prefix\n%s\ngot\n%s", prefix, msg)
+	}
+
+	msg, err = target.format("message")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(msg), ` - - `+"\xEF\xBB\xBF"+`"message"`) {
+		t.Errorf("unexpected message %s", msg)
+	}
+}
+
+func TestSyslogTargetUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	target := New(Config{
+		Network:   NetworkUDP,
+		Address:   pc.LocalAddr().String(),
+		Facility:  16,
+		AppName:   "minio",
+		QueueSize: 10,
+		LogOnce:   testLogOnce(t),
+	})
+	if err = target.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err = target.Send(testAuditEntry(), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 64<<10)
+	pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(buf[:n]), "<131>1 ") {
+		t.Errorf("unexpected message %s", buf[:n])
+	}
+}
+
+func TestSyslogTargetTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	target := New(Config{
+		Network:   NetworkTCP,
+		Address:   l.Addr().String(),
+		Facility:  3,
+		QueueSize: 10,
+		LogOnce:   testLogOnce(t),
+	})
+	if err = target.Init(); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, level := range []string{"ERROR", "INFO"} {
+		if err = target.Send(log.Entry{Level: level, Message: "hello"}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Messages are framed by octet counting.
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(conn)
+	for _, pri := range []string{"<27>", "<30>"} {
+		length, err := r.ReadString(' ')
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := make([]byte, n)
+		if _, err = io.ReadFull(r, msg); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(msg), pri+"1 ") || !strings.Contains(string(msg), " log [minio@32473 level=") {
+			t.Errorf("unexpected message %s", msg)
+		}
+	}
+}
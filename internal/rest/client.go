@@ -26,14 +26,22 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	xnet "github.com/minio/pkg/net"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/minio/minio/internal/rest")
+
 // DefaultTimeout - default REST timeout is 10 seconds.
 const DefaultTimeout = 10 * time.Second
 
@@ -124,6 +132,21 @@ func (e restError) Timeout() bool {
 
 // Call - make a REST call with context.
 func (c *Client) Call(ctx context.Context, method string, values url.Values, body io.Reader, length int64) (reply io.ReadCloser, err error) {
+	// Calls are traced as part of the request of the caller, if any.
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		var span trace.Span
+		ctx, span = tracer.Start(ctx, c.spanName(method),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("net.peer.name", c.url.Host)),
+		)
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
 	if !c.IsOnline() {
 		return nil, &NetworkError{Err: &url.Error{Op: method, URL: c.url.String(), Err: restError("remote server offline")}}
 	}
@@ -135,6 +158,7 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 		req.Header.Set("Authorization", "Bearer "+c.newAuthToken(req.URL.RawQuery))
 	}
 	req.Header.Set("X-Minio-Time", time.Now().UTC().Format(time.RFC3339))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 	if body != nil {
 		req.Header.Set("Expect", "100-continue")
 	}
@@ -196,6 +220,16 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	return resp.Body, nil
 }
 
+// spanName returns the name of the span of a call of the method,
+// e.g. "storage.readall".
+func (c *Client) spanName(method string) string {
+	service := strings.TrimPrefix(c.url.Path, "/minio/")
+	if i := strings.Index(service, "/"); i >= 0 {
+		service = service[:i]
+	}
+	return service + "." + strings.TrimPrefix(method, "/")
+}
+
 // Close closes all idle connections of the underlying http client
 func (c *Client) Close() {
 	atomic.StoreInt32(&c.connected, closed)
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package smart
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrNotSupported is returned if the health of the drive cannot be read
+// on this platform or for this type of device.
+var ErrNotSupported = errors.New("drive health is not supported")
+
+// ATA SMART attribute IDs which indicate failing media.
+const (
+	ataAttrReallocatedSectors   = 5
+	ataAttrPowerOnHours         = 9
+	ataAttrReportedUncorrect    = 187
+	ataAttrTemperature          = 194
+	ataAttrPendingSectors       = 197
+	ataAttrOfflineUncorrectable = 198
+)
+
+// Health - counters of a drive which indicate that its media is
+// wearing out, collected from SMART and the kernel.
+type Health struct {
+	Device string `json:"device"`
+
+	// ATA drives
+	ReallocatedSectors   uint64 `json:"reallocatedSectors,omitempty"`
+	PendingSectors       uint64 `json:"pendingSectors,omitempty"`
+	UncorrectableSectors uint64 `json:"uncorrectableSectors,omitempty"`
+
+	// NVMe drives
+	MediaErrors     uint64 `json:"mediaErrors,omitempty"`
+	CriticalWarning uint8  `json:"criticalWarning,omitempty"`
+	SpareAvailable  uint8  `json:"spareAvailable,omitempty"`
+	SpareThreshold  uint8  `json:"spareThreshold,omitempty"`
+	PercentageUsed  uint8  `json:"percentageUsed,omitempty"`
+
+	// IOErrors is the number of commands to the device which
+	// completed with an error, as counted by the kernel.
+	IOErrors uint64 `json:"ioErrors"`
+
+	Temperature  int    `json:"temperature,omitempty"`
+	PowerOnHours uint64 `json:"powerOnHours,omitempty"`
+
+	// SMARTError is set if the SMART attributes could not be read,
+	// the kernel counters are still reported.
+	SMARTError string `json:"smartError,omitempty"`
+}
+
+// SpareLow - returns true if the available spare of a NVMe drive has
+// fallen below the threshold set by the vendor.
+func (h Health) SpareLow() bool {
+	return h.SpareThreshold > 0 && h.SpareAvailable < h.SpareThreshold
+}
+
+// parseATASmartPage - sets the health counters from the raw values of
+// the attributes in the 512 byte SMART READ DATA response.
+func (h *Health) parseATASmartPage(page []byte) error {
+	const attrLen, attrCount = 12, 30
+	if len(page) < 2+attrLen*attrCount {
+		return errors.New("short SMART data page")
+	}
+	for i := 0; i < attrCount; i++ {
+		attr := page[2+i*attrLen : 2+(i+1)*attrLen]
+		// 0: id, 1-2: flags, 3: value, 4: worst, 5-10: raw value
+		raw := make([]byte, 8)
+		copy(raw, attr[5:11])
+		value := binary.LittleEndian.Uint64(raw)
+		switch attr[0] {
+		case ataAttrReallocatedSectors:
+			h.ReallocatedSectors = value
+		case ataAttrPendingSectors:
+			h.PendingSectors = value
+		case ataAttrOfflineUncorrectable, ataAttrReportedUncorrect:
+			h.UncorrectableSectors += value
+		case ataAttrPowerOnHours:
+			// Upper bytes are vendor specific on some drives.
+			h.PowerOnHours = value & 0xffffffff
+		case ataAttrTemperature:
+			h.Temperature = int(attr[5])
+		}
+	}
+	return nil
+}
+
+// parseIOErrorCount - parses the ioerr_cnt attribute of a SCSI device
+// in sysfs, which is a hexadecimal number such as '0x1f'.
+func parseIOErrorCount(s string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimSpace(s), 0, 64)
+}
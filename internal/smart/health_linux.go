@@ -0,0 +1,185 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package smart
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"github.com/dswarbrick/smart/ioctl"
+	"github.com/dswarbrick/smart/utils"
+	"golang.org/x/sys/unix"
+)
+
+// SCSI generic ioctl constants, see <scsi/sg.h>
+const (
+	sgIO           = 0x2285
+	sgDxferFromDev = -3
+	sgInfoOkMask   = 0x1
+	sgTimeout      = 20000 // milliseconds
+
+	scsiATAPassthru16 = 0x85
+	ataSMART          = 0xb0
+	ataSMARTReadData  = 0xd0
+)
+
+// sgIoHdr is sg_io_hdr_t as defined in <scsi/sg.h>
+type sgIoHdr struct {
+	interfaceID    int32
+	dxferDirection int32
+	cmdLen         uint8
+	mxSbLen        uint8
+	iovecCount     uint16
+	dxferLen       uint32
+	dxferp         uintptr
+	cmdp           uintptr
+	sbp            uintptr
+	timeout        uint32
+	flags          uint32
+	packID         int32
+	usrPtr         uintptr
+	status         uint8
+	maskedStatus   uint8
+	msgStatus      uint8
+	sbLenWr        uint8
+	hostStatus     uint16
+	driverStatus   uint16
+	resid          int32
+	duration       uint32
+	info           uint32
+}
+
+// BlockDevice - returns the name of the block device, such as 'sda' or
+// 'nvme0n1', of the drive the path is stored on. Partitions are resolved
+// to the drive they belong to.
+func BlockDevice(path string) (string, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return "", err
+	}
+	//nolint:unconvert
+	dev := uint64(st.Dev)
+	sysPath, err := filepath.EvalSymlinks(fmt.Sprintf("/sys/dev/block/%d:%d", unix.Major(dev), unix.Minor(dev)))
+	if err != nil {
+		return "", err
+	}
+	if _, err = os.Stat(filepath.Join(sysPath, "partition")); err == nil {
+		sysPath = filepath.Dir(sysPath)
+	}
+	return filepath.Base(sysPath), nil
+}
+
+// GetHealth - returns the health counters of the drive the path is
+// stored on. Reading SMART requires permissions to open the device,
+// if that fails only the kernel counters are returned.
+func GetHealth(path string) (Health, error) {
+	name, err := BlockDevice(path)
+	if err != nil {
+		return Health{}, err
+	}
+	h := Health{Device: "/dev/" + name}
+
+	if b, err := os.ReadFile(filepath.Join("/sys/block", name, "device", "ioerr_cnt")); err == nil {
+		h.IOErrors, _ = parseIOErrorCount(string(b))
+	}
+
+	if strings.HasPrefix(name, "nvme") {
+		err = h.readNVMe()
+	} else {
+		err = h.readATA()
+	}
+	if err != nil {
+		h.SMARTError = err.Error()
+	}
+	return h, nil
+}
+
+func (h *Health) readNVMe() error {
+	d := NewNVMeDevice(h.Device)
+	if err := d.Open(); err != nil {
+		return err
+	}
+	defer d.Close()
+
+	buf := make([]byte, 512)
+	if err := d.readLogPage(0x02, &buf); err != nil {
+		return err
+	}
+	var sl nvmeSMARTLog
+	if err := binary.Read(bytes.NewReader(buf), utils.NativeEndian, &sl); err != nil {
+		return err
+	}
+	h.CriticalWarning = sl.CritWarning
+	h.SpareAvailable = sl.AvailSpare
+	h.SpareThreshold = sl.SpareThresh
+	h.PercentageUsed = sl.PercentUsed
+	h.Temperature = int((uint16(sl.Temperature[1])<<8)|uint16(sl.Temperature[0])) - 273 // Kelvin to degrees Celsius
+	h.MediaErrors = le128ToBigInt(sl.MediaErrors).Uint64()
+	h.PowerOnHours = le128ToBigInt(sl.PowerOnHours).Uint64()
+	return nil
+}
+
+// readATA - reads the SMART attributes of an ATA drive through SCSI-ATA
+// translation, which also covers SATA drives behind SAS controllers.
+func (h *Health) readATA() error {
+	fd, err := unix.Open(h.Device, unix.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var cdb [16]byte
+	cdb[0] = scsiATAPassthru16
+	cdb[1] = 0x08 // ATA protocol (4 << 1, PIO data-in)
+	cdb[2] = 0x0e // BYT_BLOK = 1, T_LENGTH = 2, T_DIR = 1
+	cdb[4] = ataSMARTReadData
+	cdb[6] = 0x01 // sector count
+	cdb[10] = 0x4f
+	cdb[12] = 0xc2
+	cdb[14] = ataSMART
+
+	page := make([]byte, 512)
+	sense := make([]byte, 32)
+	hdr := sgIoHdr{
+		interfaceID:    'S',
+		dxferDirection: sgDxferFromDev,
+		cmdLen:         uint8(len(cdb)),
+		mxSbLen:        uint8(len(sense)),
+		dxferLen:       uint32(len(page)),
+		dxferp:         uintptr(unsafe.Pointer(&page[0])),
+		cmdp:           uintptr(unsafe.Pointer(&cdb[0])),
+		sbp:            uintptr(unsafe.Pointer(&sense[0])),
+		timeout:        sgTimeout,
+	}
+	if err = ioctl.Ioctl(uintptr(fd), sgIO, uintptr(unsafe.Pointer(&hdr))); err != nil {
+		return err
+	}
+	if hdr.info&sgInfoOkMask != 0 {
+		return fmt.Errorf("SMART READ DATA failed: SCSI status %#02x, host status %#02x, driver status %#02x",
+			hdr.status, hdr.hostStatus, hdr.driverStatus)
+	}
+	return h.parseATASmartPage(page)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package smart
+
+import "testing"
+
+func TestParseATASmartPage(t *testing.T) {
+	page := make([]byte, 512)
+	setAttr := func(i int, id uint8, raw ...byte) {
+		attr := page[2+i*12:]
+		attr[0] = id
+		copy(attr[5:11], raw)
+	}
+	setAttr(0, ataAttrReallocatedSectors, 0x10, 0x01)
+	setAttr(1, ataAttrPowerOnHours, 0xe8, 0x03, 0, 0, 0x12, 0x34)
+	setAttr(2, ataAttrTemperature, 38, 0, 20, 0, 45, 0)
+	setAttr(3, ataAttrPendingSectors, 3)
+	setAttr(4, ataAttrOfflineUncorrectable, 2)
+	setAttr(5, ataAttrReportedUncorrect, 1)
+
+	var h Health
+	if err := h.parseATASmartPage(page); err != nil {
+		t.Fatal(err)
+	}
+	want := Health{
+		ReallocatedSectors:   272,
+		PendingSectors:       3,
+		UncorrectableSectors: 3,
+		Temperature:          38,
+		PowerOnHours:         1000,
+	}
+	if h != want {
+		t.Errorf("expected %+v, got %+v", want, h)
+	}
+
+	if err := h.parseATASmartPage(page[:100]); err == nil {
+		t.Error("expected error for a short page")
+	}
+}
+
+func TestParseIOErrorCount(t *testing.T) {
+	testCases := []struct {
+		s       string
+		n       uint64
+		wantErr bool
+	}{
+		{"0x0\n", 0, false},
+		{"0x1f\n", 31, false},
+		{"", 0, true},
+		{"none", 0, true},
+	}
+	for _, testCase := range testCases {
+		n, err := parseIOErrorCount(testCase.s)
+		if (err != nil) != testCase.wantErr || n != testCase.n {
+			t.Errorf("%q: expected %d (error %v), got %d (%v)", testCase.s, testCase.n, testCase.wantErr, n, err)
+		}
+	}
+}
+
+func TestSpareLow(t *testing.T) {
+	if (Health{SpareAvailable: 100, SpareThreshold: 10}).SpareLow() {
+		t.Error("expected spare not to be low")
+	}
+	if !(Health{SpareAvailable: 5, SpareThreshold: 10}).SpareLow() {
+		t.Error("expected spare to be low")
+	}
+	if (Health{}).SpareLow() {
+		t.Error("expected spare not to be low without a threshold")
+	}
+}